@@ -52,6 +52,9 @@ type TransactionResult struct {
 	Events       []flow.Event
 	ErrorMessage string
 	BlockID      flow.Identifier
+	// ErrorMessageLength is the length in bytes of the untruncated error message, it is larger
+	// than the length of ErrorMessage if the error message was truncated
+	ErrorMessageLength uint
 }
 
 func TransactionResultToMessage(result *TransactionResult) *access.TransactionResultResponse {
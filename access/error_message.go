@@ -0,0 +1,91 @@
+package access
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxErrorMessageSize is the default budget in bytes for the error message of a
+// transaction result returned to clients.
+const DefaultMaxErrorMessageSize = 4096
+
+// elisionMarker replaces the middle of a truncated error message, it reports the number of
+// elided bytes.
+const elisionMarker = "\n[... %d bytes truncated ...]\n"
+
+// TruncateTransactionError caps the error message of the transaction result at the given
+// budget in bytes, and records the length of the untruncated message in ErrorMessageLength.
+// A budget of zero means DefaultMaxErrorMessageSize.
+// As the error message of a result, which was already truncated, fits the budget, truncating
+// again keeps its recorded length.
+func TruncateTransactionError(result *TransactionResult, maxSize uint) {
+	if uint(len(result.ErrorMessage)) > result.ErrorMessageLength {
+		result.ErrorMessageLength = uint(len(result.ErrorMessage))
+	}
+	result.ErrorMessage = TruncateErrorMessage(result.ErrorMessage, maxSize)
+}
+
+// TruncateErrorMessage strips non-printable characters from the error message and caps it at
+// the given budget in bytes. A budget of zero means DefaultMaxErrorMessageSize.
+//
+// Error messages produced by Cadence can contain stack traces with the full program text. If
+// the message exceeds the budget, its first and last lines are preserved, as they usually hold
+// the error and its location, and the middle is replaced by an elision marker. Messages are
+// only cut at UTF-8 character boundaries.
+func TruncateErrorMessage(message string, maxSize uint) string {
+	if maxSize == 0 {
+		maxSize = DefaultMaxErrorMessageSize
+	}
+	message = strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || (!unicode.IsPrint(r) && r != '\n' && r != '\t') {
+			return -1
+		}
+		return r
+	}, message)
+
+	budget := int(maxSize)
+	if len(message) <= budget {
+		return message
+	}
+
+	// the marker can be at most as long as for eliding the whole message
+	available := budget - len(fmt.Sprintf(elisionMarker, len(message)))
+	if available <= 0 {
+		return prefix(message, budget)
+	}
+
+	// keep the last line, or its end if it is longer than half the budget, and fill the
+	// remaining budget from the start of the message
+	last := message[strings.LastIndexByte(message, '\n')+1:]
+	last = suffix(last, available/2)
+	head := prefix(message, available-len(last))
+	elided := len(message) - len(head) - len(last)
+	return head + fmt.Sprintf(elisionMarker, elided) + last
+}
+
+// prefix returns the longest prefix of the string of at most n bytes, which ends at a UTF-8
+// character boundary.
+func prefix(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// suffix returns the longest suffix of the string of at most n bytes, which starts at a UTF-8
+// character boundary.
+func suffix(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	start := len(s) - n
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
@@ -0,0 +1,81 @@
+package access
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTruncateErrorMessage tests capping error messages at the byte budget.
+func TestTruncateErrorMessage(t *testing.T) {
+	t.Run("no truncation needed", func(t *testing.T) {
+		message := "error: cannot find variable\n  --> script:1:1\n\t|\n"
+		assert.Equal(t, message, TruncateErrorMessage(message, 1024))
+		assert.Equal(t, message, TruncateErrorMessage(message, uint(len(message))))
+	})
+
+	t.Run("default budget", func(t *testing.T) {
+		message := strings.Repeat("a", DefaultMaxErrorMessageSize)
+		assert.Equal(t, message, TruncateErrorMessage(message, 0))
+		truncated := TruncateErrorMessage(message+"a", 0)
+		assert.LessOrEqual(t, len(truncated), DefaultMaxErrorMessageSize)
+	})
+
+	t.Run("non-printable characters are stripped", func(t *testing.T) {
+		message := "error:\x00 invalid\x1b[31m argument\n\tat line 1"
+		assert.Equal(t, "error: invalid[31m argument\n\tat line 1", TruncateErrorMessage(message, 1024))
+	})
+
+	t.Run("first and last lines are preserved", func(t *testing.T) {
+		first := "error: execution failed"
+		last := "  --> 0000000000000001.Contract:12:4"
+		message := first + "\n" + strings.Repeat("stack frame\n", 1000) + last
+
+		truncated := TruncateErrorMessage(message, 256)
+		assert.LessOrEqual(t, len(truncated), 256)
+		assert.True(t, strings.HasPrefix(truncated, first+"\n"))
+		assert.True(t, strings.HasSuffix(truncated, "\n"+last))
+		assert.Contains(t, truncated, "bytes truncated")
+	})
+
+	t.Run("multi-byte characters at the cut points", func(t *testing.T) {
+		// every character is 3 bytes long, so that most cut points fall within a character
+		message := strings.Repeat("錯", 500) + "\n" + strings.Repeat("誤", 500)
+		for budget := uint(60); budget < 80; budget++ {
+			truncated := TruncateErrorMessage(message, budget)
+			assert.LessOrEqual(t, len(truncated), int(budget))
+			assert.True(t, utf8.ValidString(truncated), "budget %d cuts within a character", budget)
+			assert.True(t, strings.HasPrefix(truncated, "錯"))
+			assert.True(t, strings.HasSuffix(truncated, "誤"))
+		}
+
+		// budgets too small for the elision marker only keep the start of the message
+		truncated := TruncateErrorMessage(message, 8)
+		assert.Equal(t, "錯錯", truncated)
+	})
+}
+
+// TestTruncateTransactionError tests that the untruncated length of the error message is recorded,
+// also when truncating the error message of a result repeatedly.
+func TestTruncateTransactionError(t *testing.T) {
+	message := strings.Repeat("error\n", 100)
+	result := &TransactionResult{ErrorMessage: message}
+
+	TruncateTransactionError(result, 100)
+	require.LessOrEqual(t, len(result.ErrorMessage), 100)
+	assert.Equal(t, uint(len(message)), result.ErrorMessageLength)
+
+	truncated := result.ErrorMessage
+	TruncateTransactionError(result, 100)
+	assert.Equal(t, truncated, result.ErrorMessage)
+	assert.Equal(t, uint(len(message)), result.ErrorMessageLength)
+
+	// messages fitting the budget are not truncated
+	result = &TransactionResult{ErrorMessage: "error"}
+	TruncateTransactionError(result, 100)
+	assert.Equal(t, "error", result.ErrorMessage)
+	assert.Equal(t, uint(len("error")), result.ErrorMessageLength)
+}
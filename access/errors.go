@@ -10,6 +10,19 @@ import (
 // ErrUnknownReferenceBlock indicates that a transaction references an unknown block.
 var ErrUnknownReferenceBlock = errors.New("unknown reference block")
 
+// InvalidReferenceBlockError indicates that a transaction references a block, which is unknown.
+type InvalidReferenceBlockError struct {
+	ReferenceBlockID flow.Identifier
+}
+
+func (e InvalidReferenceBlockError) Error() string {
+	return fmt.Sprintf("transaction references unknown block %x", e.ReferenceBlockID)
+}
+
+func (e InvalidReferenceBlockError) Unwrap() error {
+	return ErrUnknownReferenceBlock
+}
+
 // IncompleteTransactionError indicates that a transaction is missing one or more required fields.
 type IncompleteTransactionError struct {
 	MissingFields []string
@@ -90,3 +103,50 @@ type InvalidTxByteSizeError struct {
 func (e InvalidTxByteSizeError) Error() string {
 	return fmt.Sprintf("transaction byte size (%d) exceeds the maximum byte size allowed for a transaction (%d)", e.Actual, e.Maximum)
 }
+
+// InvalidArgumentCountError indicates that a transaction passes more arguments to its script than allowed.
+type InvalidArgumentCountError struct {
+	Maximum uint
+	Actual  uint
+}
+
+func (e InvalidArgumentCountError) Error() string {
+	return fmt.Sprintf("transaction argument count (%d) exceeds the maximum number of arguments allowed for a transaction (%d)", e.Actual, e.Maximum)
+}
+
+// InvalidArgumentByteSizeError indicates that an argument of a transaction exceeds the maximum byte size
+// allowed for a single argument.
+type InvalidArgumentByteSizeError struct {
+	Index   int
+	Maximum uint64
+	Actual  uint64
+}
+
+func (e InvalidArgumentByteSizeError) Error() string {
+	return fmt.Sprintf("transaction argument %d byte size (%d) exceeds the maximum byte size allowed for an argument (%d)", e.Index, e.Actual, e.Maximum)
+}
+
+// InvalidArgumentsByteSizeError indicates that the arguments of a transaction exceed the maximum total
+// byte size allowed for the arguments of a transaction.
+type InvalidArgumentsByteSizeError struct {
+	Maximum uint64
+	Actual  uint64
+}
+
+func (e InvalidArgumentsByteSizeError) Error() string {
+	return fmt.Sprintf("transaction arguments byte size (%d) exceeds the maximum byte size allowed for the arguments of a transaction (%d)", e.Actual, e.Maximum)
+}
+
+// InvalidArgumentEncodingError indicates that an argument of a transaction is not a valid JSON-CDC encoding.
+type InvalidArgumentEncodingError struct {
+	Index     int
+	DecodeErr error
+}
+
+func (e InvalidArgumentEncodingError) Error() string {
+	return fmt.Sprintf("transaction argument %d is not a valid JSON-CDC encoding: %s", e.Index, e.DecodeErr)
+}
+
+func (e InvalidArgumentEncodingError) Unwrap() error {
+	return e.DecodeErr
+}
@@ -7,14 +7,30 @@ import (
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 )
 
+// Keys of the gRPC response header, which describe the error of a failed transaction
+// according to the fvm error code registry.
+const (
+	ErrorCodeHeader      = "flow-error-code"
+	ErrorNameHeader      = "flow-error-name"
+	ErrorCategoryHeader  = "flow-error-category"
+	ErrorRetryableHeader = "flow-error-retryable"
+)
+
+// ErrorMessageLengthHeader is the key of the gRPC and REST response header, which holds the length
+// in bytes of the untruncated error message of a transaction, if the error message was truncated.
+const ErrorMessageLengthHeader = "flow-error-message-length"
+
 type Handler struct {
 	api   API
 	chain flow.Chain
@@ -220,9 +236,34 @@ func (h *Handler) GetTransactionResult(
 		return nil, err
 	}
 
+	if md, ok := TransactionErrorMetadata(result.ErrorMessage); ok {
+		// the error details are informational; setting them only fails if the handler
+		// is not called within a gRPC request, in which case there is no header to set
+		_ = grpc.SetHeader(ctx, md)
+	}
+	if result.ErrorMessageLength > uint(len(result.ErrorMessage)) {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(ErrorMessageLengthHeader, fmt.Sprintf("%d", result.ErrorMessageLength)))
+	}
+
 	return TransactionResultToMessage(result), nil
 }
 
+// TransactionErrorMetadata returns the gRPC response header describing the error of a failed
+// transaction, and false if the error message doesn't contain an error code.
+func TransactionErrorMetadata(errorMessage string) (metadata.MD, bool) {
+	code, ok := fvmerrors.ParseErrorCode(errorMessage)
+	if !ok {
+		return nil, false
+	}
+	registry := fvmerrors.DefaultRegistry
+	return metadata.Pairs(
+		ErrorCodeHeader, fmt.Sprintf("%d", code),
+		ErrorNameHeader, registry.Name(code),
+		ErrorCategoryHeader, string(registry.Category(code)),
+		ErrorRetryableHeader, fmt.Sprintf("%t", registry.IsRetryable(code)),
+	), true
+}
+
 // GetAccount returns an account by address at the latest sealed block.
 func (h *Handler) GetAccount(
 	ctx context.Context,
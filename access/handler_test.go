@@ -0,0 +1,81 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// transactionResultAPI is an API which only serves the given transaction result.
+type transactionResultAPI struct {
+	API
+	result *TransactionResult
+}
+
+func (a *transactionResultAPI) GetTransactionResult(context.Context, flow.Identifier) (*TransactionResult, error) {
+	return a.result, nil
+}
+
+// headerStream records the header set by a handler within a gRPC request.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// getTransactionResultHeader requests the result of a transaction, which failed with the given error,
+// and returns the response header.
+func getTransactionResultHeader(t *testing.T, txErr fvmerrors.Error) metadata.MD {
+	handler := NewHandler(&transactionResultAPI{result: &TransactionResult{
+		Status:       flow.TransactionStatusSealed,
+		StatusCode:   1,
+		ErrorMessage: txErr.Error(),
+	}}, flow.Emulator.Chain())
+
+	stream := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	txID := unittest.IdentifierFixture()
+	resp, err := handler.GetTransactionResult(ctx, &accessproto.GetTransactionRequest{Id: txID[:]})
+	require.NoError(t, err)
+	assert.Equal(t, txErr.Error(), resp.ErrorMessage)
+	return stream.header
+}
+
+func TestGetTransactionResult_ErrorHeader(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	t.Run("sequence number mismatch", func(t *testing.T) {
+		header := getTransactionResultHeader(t, fvmerrors.NewInvalidProposalSeqNumberError(address, 0, 2, 1))
+		assert.Equal(t, []string{"1007"}, header.Get(ErrorCodeHeader))
+		assert.Equal(t, []string{"InvalidProposalSeqNumberError"}, header.Get(ErrorNameHeader))
+		assert.Equal(t, []string{"validation"}, header.Get(ErrorCategoryHeader))
+		assert.Equal(t, []string{"true"}, header.Get(ErrorRetryableHeader))
+	})
+
+	t.Run("payload signature failure", func(t *testing.T) {
+		header := getTransactionResultHeader(t, fvmerrors.NewInvalidPayloadSignatureError(address, 0, fmt.Errorf("invalid signature")))
+		assert.Equal(t, []string{"1008"}, header.Get(ErrorCodeHeader))
+		assert.Equal(t, []string{"InvalidPayloadSignatureError"}, header.Get(ErrorNameHeader))
+		assert.Equal(t, []string{"validation"}, header.Get(ErrorCategoryHeader))
+		assert.Equal(t, []string{"false"}, header.Get(ErrorRetryableHeader))
+	})
+
+	t.Run("successful transaction", func(t *testing.T) {
+		_, ok := TransactionErrorMetadata("")
+		assert.False(t, ok)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime/parser2"
 
 	"github.com/onflow/flow-go/model/flow"
@@ -56,6 +57,18 @@ type TransactionValidationOptions struct {
 	MaxAddressIndex        uint64
 	MaxTransactionByteSize uint64
 	MaxCollectionByteSize  uint64
+	// MaxArgumentCount is the maximum number of arguments a transaction may
+	// pass to its script. A zero value indicates no limit.
+	MaxArgumentCount uint
+	// MaxArgumentByteSize is the maximum byte size of a single argument.
+	// A zero value indicates no limit.
+	MaxArgumentByteSize uint64
+	// MaxArgumentsByteSize is the maximum total byte size of all arguments.
+	// A zero value indicates no limit.
+	MaxArgumentsByteSize uint64
+	// CheckArgumentsEncoding rejects transactions with arguments, which are
+	// not valid JSON-CDC encodings, instead of failing them during execution.
+	CheckArgumentsEncoding bool
 }
 
 type TransactionValidator struct {
@@ -104,6 +117,21 @@ func (v *TransactionValidator) Validate(tx *flow.TransactionBody) (err error) {
 		return err
 	}
 
+	err = v.checkArgumentCount(tx)
+	if err != nil {
+		return err
+	}
+
+	err = v.checkArgumentSize(tx)
+	if err != nil {
+		return err
+	}
+
+	err = v.checkArgumentsEncoding(tx)
+	if err != nil {
+		return err
+	}
+
 	err = v.checkAddresses(tx)
 	if err != nil {
 		return err
@@ -200,7 +228,7 @@ func (v *TransactionValidator) checkExpiry(tx *flow.TransactionBody) error {
 			return nil
 		}
 
-		return ErrUnknownReferenceBlock
+		return InvalidReferenceBlockError{ReferenceBlockID: tx.ReferenceBlockID}
 	}
 
 	// get the latest finalized block we know about
@@ -238,6 +266,63 @@ func (v *TransactionValidator) checkCanBeParsed(tx *flow.TransactionBody) error
 	return nil
 }
 
+func (v *TransactionValidator) checkArgumentCount(tx *flow.TransactionBody) error {
+	if v.options.MaxArgumentCount == 0 {
+		return nil
+	}
+
+	count := uint(len(tx.Arguments))
+	if count > v.options.MaxArgumentCount {
+		return InvalidArgumentCountError{
+			Actual:  count,
+			Maximum: v.options.MaxArgumentCount,
+		}
+	}
+
+	return nil
+}
+
+// checkArgumentSize checks the byte size of every argument, as well as the total byte size of
+// all arguments, against the configured limits.
+func (v *TransactionValidator) checkArgumentSize(tx *flow.TransactionBody) error {
+	total := uint64(0)
+	for i, argument := range tx.Arguments {
+		size := uint64(len(argument))
+		if v.options.MaxArgumentByteSize != 0 && size > v.options.MaxArgumentByteSize {
+			return InvalidArgumentByteSizeError{
+				Index:   i,
+				Actual:  size,
+				Maximum: v.options.MaxArgumentByteSize,
+			}
+		}
+		total += size
+	}
+
+	if v.options.MaxArgumentsByteSize != 0 && total > v.options.MaxArgumentsByteSize {
+		return InvalidArgumentsByteSizeError{
+			Actual:  total,
+			Maximum: v.options.MaxArgumentsByteSize,
+		}
+	}
+
+	return nil
+}
+
+func (v *TransactionValidator) checkArgumentsEncoding(tx *flow.TransactionBody) error {
+	if !v.options.CheckArgumentsEncoding {
+		return nil
+	}
+
+	for i, argument := range tx.Arguments {
+		_, err := jsoncdc.Decode(argument)
+		if err != nil {
+			return InvalidArgumentEncodingError{Index: i, DecodeErr: err}
+		}
+	}
+
+	return nil
+}
+
 func (v *TransactionValidator) checkAddresses(tx *flow.TransactionBody) error {
 
 	for _, address := range append(tx.Authorizers, tx.Payer) {
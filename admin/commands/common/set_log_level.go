@@ -9,27 +9,108 @@ import (
 
 	"github.com/onflow/flow-go/admin"
 	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 var _ commands.AdminCommand = (*SetLogLevelCommand)(nil)
+var _ commands.AdminCommand = (*GetLogLevelsCommand)(nil)
 
-type SetLogLevelCommand struct{}
+// resetLogLevel is the level which restores the global level for a component.
+const resetLogLevel = "default"
+
+// SetLogLevelCommand sets the log level of the node at runtime. The input is either a level,
+// which is set globally, or an object mapping the names of registered components to their
+// level, e.g. {"matching": "debug"}. Setting a component to "default" makes it follow the
+// global level again.
+type SetLogLevelCommand struct {
+	registry *logging.Registry
+}
+
+// setLogLevelRequest is the validated input of the set-log-level command.
+type setLogLevelRequest struct {
+	global     *zerolog.Level
+	components map[string]*zerolog.Level // nil level resets the component
+}
+
+func NewSetLogLevelCommand(registry *logging.Registry) commands.AdminCommand {
+	return &SetLogLevelCommand{
+		registry: registry,
+	}
+}
 
 func (s *SetLogLevelCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
-	level := req.ValidatorData.(zerolog.Level)
-	zerolog.SetGlobalLevel(level)
+	input := req.ValidatorData.(setLogLevelRequest)
+	if input.global != nil {
+		s.registry.SetGlobalLevel(*input.global)
+	}
+	for component, level := range input.components {
+		var err error
+		if level == nil {
+			err = s.registry.ResetLevel(component)
+		} else {
+			err = s.registry.SetLevel(component, *level)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not set log level of component %s: %w", component, err)
+		}
+	}
 	return "ok", nil
 }
 
 func (s *SetLogLevelCommand) Validator(req *admin.CommandRequest) error {
-	level, ok := req.Data.(string)
-	if !ok {
-		return errors.New("the input must be a string")
+	switch data := req.Data.(type) {
+	case string:
+		level, err := zerolog.ParseLevel(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse level: %w", err)
+		}
+		req.ValidatorData = setLogLevelRequest{global: &level}
+		return nil
+	case map[string]interface{}:
+		if len(data) == 0 {
+			return errors.New("the input must map at least one component to its level")
+		}
+		components := make(map[string]*zerolog.Level, len(data))
+		for component, value := range data {
+			if !s.registry.HasComponent(component) {
+				return fmt.Errorf("%w: %s", logging.ErrUnknownComponent, component)
+			}
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("the level of component %s must be a string", component)
+			}
+			if str == resetLogLevel {
+				components[component] = nil
+				continue
+			}
+			level, err := zerolog.ParseLevel(str)
+			if err != nil {
+				return fmt.Errorf("failed to parse level of component %s: %w", component, err)
+			}
+			components[component] = &level
+		}
+		req.ValidatorData = setLogLevelRequest{components: components}
+		return nil
+	default:
+		return errors.New("the input must be a string or an object mapping components to levels")
 	}
-	logLevel, err := zerolog.ParseLevel(level)
-	if err != nil {
-		return fmt.Errorf("failed to parse level: %w", err)
+}
+
+// GetLogLevelsCommand returns the global log level and the levels of all registered components.
+type GetLogLevelsCommand struct {
+	registry *logging.Registry
+}
+
+func NewGetLogLevelsCommand(registry *logging.Registry) commands.AdminCommand {
+	return &GetLogLevelsCommand{
+		registry: registry,
 	}
-	req.ValidatorData = logLevel
+}
+
+func (g *GetLogLevelsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	return toJSONValue(g.registry.Levels())
+}
+
+func (g *GetLogLevelsCommand) Validator(req *admin.CommandRequest) error {
 	return nil
 }
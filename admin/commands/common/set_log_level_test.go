@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+func TestSetLogLevelValidator(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	registry := logging.NewRegistry(zerolog.InfoLevel)
+	registry.Logger("matching", zerolog.Nop())
+	command := NewSetLogLevelCommand(registry)
+
+	for _, data := range []interface{}{
+		"verbose",
+		float64(1),
+		nil,
+		map[string]interface{}{},
+		map[string]interface{}{"unknown": "debug"},
+		map[string]interface{}{"matching": "verbose"},
+		map[string]interface{}{"matching": float64(1)},
+	} {
+		req := &admin.CommandRequest{Data: data}
+		assert.Error(t, command.Validator(req), "input %v should be rejected", data)
+	}
+
+	req := &admin.CommandRequest{Data: "debug"}
+	require.NoError(t, command.Validator(req))
+	level := zerolog.DebugLevel
+	assert.Equal(t, setLogLevelRequest{global: &level}, req.ValidatorData)
+
+	req = &admin.CommandRequest{Data: map[string]interface{}{"matching": "default"}}
+	require.NoError(t, command.Validator(req))
+	assert.Equal(t, setLogLevelRequest{components: map[string]*zerolog.Level{"matching": nil}}, req.ValidatorData)
+}
+
+func TestSetLogLevelHandler(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	registry := logging.NewRegistry(zerolog.InfoLevel)
+	registry.Logger("matching", zerolog.Nop())
+	registry.Logger("epochmgr", zerolog.Nop())
+	setCommand := NewSetLogLevelCommand(registry)
+	getCommand := NewGetLogLevelsCommand(registry)
+
+	run := func(data interface{}) {
+		req := &admin.CommandRequest{Data: data}
+		require.NoError(t, setCommand.Validator(req))
+		_, err := setCommand.Handler(context.Background(), req)
+		require.NoError(t, err)
+	}
+
+	run("warn")
+	run(map[string]interface{}{"matching": "debug", "epochmgr": "error"})
+	result, err := getCommand.Handler(context.Background(), &admin.CommandRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"global": "warn",
+		"components": []interface{}{
+			map[string]interface{}{"name": "epochmgr", "level": "error", "overridden": true},
+			map[string]interface{}{"name": "matching", "level": "debug", "overridden": true},
+		},
+	}, result)
+
+	run(map[string]interface{}{"epochmgr": "default"})
+	assert.Equal(t, "warn", registry.Levels().Components[0].Level)
+	assert.False(t, registry.Levels().Components[0].Overridden)
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/consensus"
+)
+
+var _ commands.AdminCommand = (*SetTraceSamplingRateCommand)(nil)
+
+// SetTraceSamplingRateCommand adjusts the rate at which the consensus engines emit
+// detailed validation traces for receipts and approvals. The input is N for
+// sampling one out of N messages; zero disables sampling.
+type SetTraceSamplingRateCommand struct {
+	sampler *consensus.TraceSampler
+}
+
+func NewSetTraceSamplingRateCommand(sampler *consensus.TraceSampler) commands.AdminCommand {
+	return &SetTraceSamplingRateCommand{
+		sampler: sampler,
+	}
+}
+
+func (s *SetTraceSamplingRateCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	rate := req.ValidatorData.(uint64)
+	s.sampler.SetSamplingRate(rate)
+	return "ok", nil
+}
+
+func (s *SetTraceSamplingRateCommand) Validator(req *admin.CommandRequest) error {
+	rate, ok := req.Data.(float64)
+	if !ok {
+		return fmt.Errorf("invalid value for sampling rate: %v", req.Data)
+	}
+	if rate < 0 || math.Trunc(rate) != rate {
+		return fmt.Errorf("sampling rate must be a non-negative integer")
+	}
+	req.ValidatorData = uint64(rate)
+	return nil
+}
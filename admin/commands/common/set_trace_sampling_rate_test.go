@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/consensus"
+)
+
+func TestSetTraceSamplingRateValidator(t *testing.T) {
+	command := NewSetTraceSamplingRateCommand(consensus.NewTraceSampler(0))
+
+	for _, data := range []interface{}{"1", float64(-1), float64(1.5), nil} {
+		req := &admin.CommandRequest{Data: data}
+		assert.Error(t, command.Validator(req), "input %v should be rejected", data)
+	}
+
+	req := &admin.CommandRequest{Data: float64(10)}
+	require.NoError(t, command.Validator(req))
+	assert.Equal(t, uint64(10), req.ValidatorData)
+}
+
+func TestSetTraceSamplingRateHandler(t *testing.T) {
+	sampler := consensus.NewTraceSampler(0)
+	command := NewSetTraceSamplingRateCommand(sampler)
+
+	req := &admin.CommandRequest{Data: float64(5)}
+	require.NoError(t, command.Validator(req))
+	_, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), sampler.SamplingRate())
+}
@@ -0,0 +1,170 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/updatable_configs"
+)
+
+var _ commands.AdminCommand = (*ListConfigCommand)(nil)
+var _ commands.AdminCommand = (*GetConfigCommand)(nil)
+var _ commands.AdminCommand = (*SetConfigCommand)(nil)
+var _ commands.AdminCommand = (*ResetConfigCommand)(nil)
+
+// ListConfigCommand lists all config fields which can be updated at runtime, along with their
+// current and default values.
+type ListConfigCommand struct {
+	manager *updatable_configs.Manager
+}
+
+func NewListConfigCommand(manager *updatable_configs.Manager) commands.AdminCommand {
+	return &ListConfigCommand{
+		manager: manager,
+	}
+}
+
+func (l *ListConfigCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	return toJSONValue(l.manager.Fields())
+}
+
+func (l *ListConfigCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+// GetConfigCommand returns a single config field. The input is the name of the field.
+type GetConfigCommand struct {
+	manager *updatable_configs.Manager
+}
+
+func NewGetConfigCommand(manager *updatable_configs.Manager) commands.AdminCommand {
+	return &GetConfigCommand{
+		manager: manager,
+	}
+}
+
+func (g *GetConfigCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	info, err := g.manager.Get(req.ValidatorData.(string))
+	if err != nil {
+		return nil, configError(err)
+	}
+	return toJSONValue(info)
+}
+
+func (g *GetConfigCommand) Validator(req *admin.CommandRequest) error {
+	return validateFieldName(g.manager, req)
+}
+
+// SetConfigCommand validates and sets a new value for a config field, and persists it, so that
+// it is kept across restarts. The input is an object mapping the name of the field to its new
+// value, e.g. {"matching.max-unsealed-results": 100}.
+type SetConfigCommand struct {
+	manager *updatable_configs.Manager
+}
+
+// setConfigRequest is the validated input of the set-config command.
+type setConfigRequest struct {
+	name  string
+	value interface{}
+}
+
+func NewSetConfigCommand(manager *updatable_configs.Manager) commands.AdminCommand {
+	return &SetConfigCommand{
+		manager: manager,
+	}
+}
+
+func (s *SetConfigCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	input := req.ValidatorData.(setConfigRequest)
+	info, err := s.manager.Set(input.name, input.value)
+	if err != nil {
+		return nil, configError(err)
+	}
+	return toJSONValue(info)
+}
+
+func (s *SetConfigCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok || len(input) != 1 {
+		return errors.New("the input must be an object with a single field name mapped to its new value")
+	}
+	for name, value := range input {
+		_, err := s.manager.Get(name)
+		if err != nil {
+			return err
+		}
+		req.ValidatorData = setConfigRequest{
+			name:  name,
+			value: value,
+		}
+	}
+	return nil
+}
+
+// ResetConfigCommand restores the default value of a config field and removes its persisted
+// override. The input is the name of the field.
+type ResetConfigCommand struct {
+	manager *updatable_configs.Manager
+}
+
+func NewResetConfigCommand(manager *updatable_configs.Manager) commands.AdminCommand {
+	return &ResetConfigCommand{
+		manager: manager,
+	}
+}
+
+func (r *ResetConfigCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	info, err := r.manager.Reset(req.ValidatorData.(string))
+	if err != nil {
+		return nil, configError(err)
+	}
+	return toJSONValue(info)
+}
+
+func (r *ResetConfigCommand) Validator(req *admin.CommandRequest) error {
+	return validateFieldName(r.manager, req)
+}
+
+// validateFieldName requires the input to be the name of a registered field.
+func validateFieldName(manager *updatable_configs.Manager, req *admin.CommandRequest) error {
+	name, ok := req.Data.(string)
+	if !ok {
+		return errors.New("the input must be a string")
+	}
+	_, err := manager.Get(name)
+	if err != nil {
+		return err
+	}
+	req.ValidatorData = name
+	return nil
+}
+
+// configError reports rejected values as invalid arguments.
+func configError(err error) error {
+	if updatable_configs.IsValidationError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// toJSONValue converts the given value to its JSON representation made of basic types, which
+// can be returned by admin commands.
+func toJSONValue(value interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode result: %w", err)
+	}
+	var result interface{}
+	err = json.Unmarshal(bytes, &result)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode result: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/updatable_configs"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// runConfigCommand validates and handles the given input with the command.
+func runConfigCommand(t *testing.T, command commands.AdminCommand, data interface{}) (interface{}, error) {
+	req := &admin.CommandRequest{Data: data}
+	require.NoError(t, command.Validator(req))
+	return command.Handler(context.Background(), req)
+}
+
+func withConfigManager(t *testing.T, f func(manager *updatable_configs.Manager, limit *uint)) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		manager, err := updatable_configs.NewManager(unittest.Logger(), bstorage.NewConfigOverrides(db))
+		require.NoError(t, err)
+
+		limit := uint(10)
+		err = manager.RegisterUintConfig("test.limit",
+			func() uint { return limit },
+			func(value uint) error {
+				limit = value
+				return nil
+			},
+			nil)
+		require.NoError(t, err)
+		f(manager, &limit)
+	})
+}
+
+func TestConfigCommands(t *testing.T) {
+	withConfigManager(t, func(manager *updatable_configs.Manager, limit *uint) {
+		expected := func(value float64, overridden bool) map[string]interface{} {
+			return map[string]interface{}{
+				"name":       "test.limit",
+				"type":       "uint",
+				"value":      value,
+				"default":    float64(10),
+				"overridden": overridden,
+			}
+		}
+
+		result, err := runConfigCommand(t, NewListConfigCommand(manager), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{expected(10, false)}, result)
+
+		result, err = runConfigCommand(t, NewSetConfigCommand(manager), map[string]interface{}{"test.limit": float64(20)})
+		require.NoError(t, err)
+		assert.Equal(t, expected(20, true), result)
+		assert.Equal(t, uint(20), *limit)
+
+		result, err = runConfigCommand(t, NewGetConfigCommand(manager), "test.limit")
+		require.NoError(t, err)
+		assert.Equal(t, expected(20, true), result)
+
+		result, err = runConfigCommand(t, NewResetConfigCommand(manager), "test.limit")
+		require.NoError(t, err)
+		assert.Equal(t, expected(10, false), result)
+		assert.Equal(t, uint(10), *limit)
+	})
+}
+
+func TestConfigCommands_Invalid(t *testing.T) {
+	withConfigManager(t, func(manager *updatable_configs.Manager, limit *uint) {
+		getCommand := NewGetConfigCommand(manager)
+		setCommand := NewSetConfigCommand(manager)
+		resetCommand := NewResetConfigCommand(manager)
+
+		for _, data := range []interface{}{"test.unknown", float64(1), nil} {
+			assert.Error(t, getCommand.Validator(&admin.CommandRequest{Data: data}), "input %v should be rejected", data)
+			assert.Error(t, resetCommand.Validator(&admin.CommandRequest{Data: data}), "input %v should be rejected", data)
+		}
+
+		for _, data := range []interface{}{
+			"test.limit",
+			map[string]interface{}{},
+			map[string]interface{}{"test.unknown": float64(1)},
+			map[string]interface{}{"test.limit": float64(1), "test.other": float64(2)},
+		} {
+			assert.Error(t, setCommand.Validator(&admin.CommandRequest{Data: data}), "input %v should be rejected", data)
+		}
+
+		// values are validated by the manager, and rejected as invalid arguments
+		_, err := runConfigCommand(t, setCommand, map[string]interface{}{"test.limit": "20"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, uint(10), *limit)
+	})
+}
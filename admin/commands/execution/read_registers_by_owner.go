@@ -0,0 +1,180 @@
+package execution
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var _ commands.AdminCommand = (*ReadRegistersByOwnerCommand)(nil)
+
+type readRegistersByOwnerRequest struct {
+	commit    *flow.StateCommitment // state commitment to read the registers at, if not given by the block
+	blockID   flow.Identifier       // block, at the final state commitment of which the registers are read
+	owner     flow.Address
+	pageToken []byte
+	limit     int
+}
+
+// ReadRegistersByOwnerCommand returns a page of the registers owned by an address, at a state
+// commitment given directly or by the block it is the final state of. The response contains a
+// token to continue with the next page, if there are more registers.
+// As the admin commands are set up before the node modules, the execution state is set once it
+// was created. Until then, the command fails.
+type ReadRegistersByOwnerCommand struct {
+	mu             sync.RWMutex
+	executionState state.ReadOnlyExecutionState
+}
+
+// SetExecutionState sets the execution state, which the registers are read from.
+func (r *ReadRegistersByOwnerCommand) SetExecutionState(executionState state.ReadOnlyExecutionState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executionState = executionState
+}
+
+func (r *ReadRegistersByOwnerCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readRegistersByOwnerRequest)
+
+	r.mu.RLock()
+	executionState := r.executionState
+	r.mu.RUnlock()
+	if executionState == nil {
+		return nil, fmt.Errorf("execution state is not initialized yet")
+	}
+
+	var commit flow.StateCommitment
+	if data.commit != nil {
+		commit = *data.commit
+	} else {
+		var err error
+		commit, err = executionState.StateCommitmentByBlockID(ctx, data.blockID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state commitment of block %v: %w", data.blockID, err)
+		}
+	}
+
+	entries, nextPageToken, err := executionState.GetRegistersByOwner(ctx, commit, data.owner, data.pageToken, data.limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registers of owner %v at state commitment %x: %w", data.owner, commit, err)
+	}
+
+	registers := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		registers = append(registers, map[string]interface{}{
+			"controller": hex.EncodeToString([]byte(entry.Key.Controller)),
+			"key":        hex.EncodeToString([]byte(entry.Key.Key)),
+			"value":      hex.EncodeToString(entry.Value),
+		})
+	}
+
+	// the next page token is omitted after the last page
+	var next interface{}
+	if len(nextPageToken) > 0 {
+		next = hex.EncodeToString(nextPageToken)
+	}
+
+	return map[string]interface{}{
+		"commit":          hex.EncodeToString(commit[:]),
+		"registers":       registers,
+		"next_page_token": next,
+	}, nil
+}
+
+func (r *ReadRegistersByOwnerCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return storageCommands.ErrValidatorReqDataFormat
+	}
+	data := &readRegistersByOwnerRequest{}
+
+	commit, hasCommit := input["commit"]
+	block, hasBlock := input["block"]
+	if hasCommit == hasBlock {
+		return fmt.Errorf("exactly one of the \"commit\" and \"block\" fields is required")
+	}
+	if hasCommit {
+		errInvalidCommitValue := fmt.Errorf("invalid value for \"commit\": expected a state commitment represented as a 64 character long hex string, but got: %v", commit)
+		commitHex, ok := commit.(string)
+		if !ok {
+			return errInvalidCommitValue
+		}
+		commitBytes, err := hex.DecodeString(commitHex)
+		if err != nil {
+			return errInvalidCommitValue
+		}
+		stateCommitment, err := flow.ToStateCommitment(commitBytes)
+		if err != nil {
+			return errInvalidCommitValue
+		}
+		data.commit = &stateCommitment
+	}
+	if hasBlock {
+		errInvalidBlockValue := fmt.Errorf("invalid value for \"block\": expected a block ID represented as a 64 character long hex string, but got: %v", block)
+		blockHex, ok := block.(string)
+		if !ok {
+			return errInvalidBlockValue
+		}
+		blockID, err := flow.HexStringToIdentifier(blockHex)
+		if err != nil {
+			return errInvalidBlockValue
+		}
+		data.blockID = blockID
+	}
+
+	owner, ok := input["owner"]
+	if !ok {
+		return fmt.Errorf("the \"owner\" field is required")
+	}
+	errInvalidOwnerValue := fmt.Errorf("invalid value for \"owner\": expected an address represented as a hex string, but got: %v", owner)
+	ownerHex, ok := owner.(string)
+	if !ok {
+		return errInvalidOwnerValue
+	}
+	ownerBytes, err := hex.DecodeString(strings.TrimPrefix(ownerHex, "0x"))
+	if err != nil || len(ownerBytes) == 0 || len(ownerBytes) > flow.AddressLength {
+		return errInvalidOwnerValue
+	}
+	data.owner = flow.BytesToAddress(ownerBytes)
+
+	if pageToken, ok := input["page_token"]; ok {
+		errInvalidPageTokenValue := fmt.Errorf("invalid value for \"page_token\": expected a hex string, but got: %v", pageToken)
+		pageTokenHex, ok := pageToken.(string)
+		if !ok {
+			return errInvalidPageTokenValue
+		}
+		data.pageToken, err = hex.DecodeString(pageTokenHex)
+		if err != nil {
+			return errInvalidPageTokenValue
+		}
+	}
+
+	data.limit = state.MaxRegistersPageSize
+	if limit, ok := input["limit"]; ok {
+		errInvalidLimitValue := fmt.Errorf("invalid value for \"limit\": expected an integer between 1 and %d, but got: %v", state.MaxRegistersPageSize, limit)
+		n, ok := limit.(float64)
+		if !ok || math.Trunc(n) != n || n < 1 || n > state.MaxRegistersPageSize {
+			return errInvalidLimitValue
+		}
+		data.limit = int(n)
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+// NewReadRegistersByOwnerCommand creates the command, of which the execution state must be set with
+// SetExecutionState once it was created.
+func NewReadRegistersByOwnerCommand() *ReadRegistersByOwnerCommand {
+	return &ReadRegistersByOwnerCommand{}
+}
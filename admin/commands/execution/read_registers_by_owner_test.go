@@ -0,0 +1,117 @@
+package execution
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
+	"github.com/onflow/flow-go/engine/execution/state"
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadRegistersByOwnerValidator(t *testing.T) {
+	t.Parallel()
+
+	command := NewReadRegistersByOwnerCommand()
+	commit := unittest.StateCommitmentFixture()
+	owner := unittest.RandomAddressFixture()
+
+	t.Run("malformed input", func(t *testing.T) {
+		err := command.Validator(&admin.CommandRequest{Data: "commit"})
+		require.True(t, errors.Is(err, storageCommands.ErrValidatorReqDataFormat))
+	})
+
+	invalid := []map[string]interface{}{
+		// neither commit nor block
+		{"owner": owner.Hex()},
+		// both commit and block
+		{"commit": hex.EncodeToString(commit[:]), "block": unittest.IdentifierFixture().String(), "owner": owner.Hex()},
+		{"commit": "invalid", "owner": owner.Hex()},
+		{"block": "invalid", "owner": owner.Hex()},
+		// missing owner
+		{"commit": hex.EncodeToString(commit[:])},
+		{"commit": hex.EncodeToString(commit[:]), "owner": "0xinvalid"},
+		{"commit": hex.EncodeToString(commit[:]), "owner": owner.Hex(), "page_token": "invalid"},
+		{"commit": hex.EncodeToString(commit[:]), "owner": owner.Hex(), "limit": float64(0)},
+		{"commit": hex.EncodeToString(commit[:]), "owner": owner.Hex(), "limit": float64(state.MaxRegistersPageSize + 1)},
+		{"commit": hex.EncodeToString(commit[:]), "owner": owner.Hex(), "limit": 1.5},
+	}
+	for _, data := range invalid {
+		require.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+	}
+
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"commit":     hex.EncodeToString(commit[:]),
+			"owner":      "0x" + owner.Hex(),
+			"page_token": "0102",
+			"limit":      float64(10),
+		},
+	}
+	require.NoError(t, command.Validator(req))
+	require.Equal(t, &readRegistersByOwnerRequest{
+		commit:    &commit,
+		owner:     owner,
+		pageToken: []byte{1, 2},
+		limit:     10,
+	}, req.ValidatorData)
+}
+
+func TestReadRegistersByOwnerHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockID := unittest.IdentifierFixture()
+	commit := unittest.StateCommitmentFixture()
+	owner := unittest.RandomAddressFixture()
+	entry := flow.RegisterEntry{
+		Key:   flow.RegisterID{Owner: string(owner.Bytes()), Controller: "", Key: "key"},
+		Value: []byte{1, 2, 3},
+	}
+
+	command := NewReadRegistersByOwnerCommand()
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"block": blockID.String(),
+			"owner": owner.Hex(),
+		},
+	}
+	require.NoError(t, command.Validator(req))
+
+	t.Run("execution state not set", func(t *testing.T) {
+		_, err := command.Handler(ctx, req)
+		require.Error(t, err)
+	})
+
+	executionState := new(statemock.ReadOnlyExecutionState)
+	executionState.On("StateCommitmentByBlockID", mock.Anything, blockID).Return(commit, nil)
+	executionState.On("GetRegistersByOwner", mock.Anything, commit, owner, []byte(nil), state.MaxRegistersPageSize).
+		Return([]flow.RegisterEntry{entry}, []byte{4, 5}, nil)
+	command.SetExecutionState(executionState)
+
+	result, err := command.Handler(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"commit": hex.EncodeToString(commit[:]),
+		"registers": []interface{}{
+			map[string]interface{}{
+				"controller": "",
+				"key":        hex.EncodeToString([]byte("key")),
+				"value":      "010203",
+			},
+		},
+		"next_page_token": "0405",
+	}, result)
+
+	executionState.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var _ commands.AdminCommand = (*TriggerCheckpointCommand)(nil)
+
+// TriggerCheckpointCommand triggers a checkpoint of the execution state, regardless of the checkpoint
+// distance, and returns the number of the checkpoint. If a checkpoint is being created already, the
+// number of that checkpoint is returned instead.
+// As the admin commands are set up before the node components, the compactor is set once it was
+// created. Until then, the command fails.
+type TriggerCheckpointCommand struct {
+	mu        sync.RWMutex
+	compactor *wal.Compactor
+}
+
+// SetCompactor sets the compactor of the execution state WAL, which creates the checkpoints.
+func (t *TriggerCheckpointCommand) SetCompactor(compactor *wal.Compactor) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.compactor = compactor
+}
+
+func (t *TriggerCheckpointCommand) Handler(_ context.Context, _ *admin.CommandRequest) (interface{}, error) {
+	t.mu.RLock()
+	compactor := t.compactor
+	t.mu.RUnlock()
+	if compactor == nil {
+		return nil, fmt.Errorf("compactor is not initialized yet")
+	}
+
+	checkpoint, err := compactor.TriggerCheckpoint()
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger checkpoint: %w", err)
+	}
+
+	return map[string]interface{}{
+		"checkpoint": checkpoint,
+	}, nil
+}
+
+func (t *TriggerCheckpointCommand) Validator(_ *admin.CommandRequest) error {
+	return nil
+}
+
+// NewTriggerCheckpointCommand creates the command, of which the compactor must be set with
+// SetCompactor once it was created.
+func NewTriggerCheckpointCommand() *TriggerCheckpointCommand {
+	return &TriggerCheckpointCommand{}
+}
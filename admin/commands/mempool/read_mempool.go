@@ -0,0 +1,236 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadMempoolCommand)(nil)
+
+// DefaultLimit is the maximum number of entries returned, if the request does not specify a limit.
+const DefaultLimit = 100
+
+const (
+	// PoolReceipts is the name of the pool of execution receipts, which can be filtered by the
+	// executed block ("block").
+	PoolReceipts = "receipts"
+	// PoolApprovals is the name of the pool of result approvals, which can be filtered by the
+	// approved result ("result").
+	PoolApprovals = "approvals"
+)
+
+var ErrValidatorReqDataFormat error = errors.New("wrong input format: expected JSON")
+
+type readMempoolRequest struct {
+	pool   string
+	filter func(entity flow.Entity) bool
+	limit  int
+}
+
+// receiptSummary is the summary of an execution receipt in a memory pool.
+type receiptSummary struct {
+	ReceiptID  flow.Identifier `json:"receipt_id"`
+	ResultID   flow.Identifier `json:"result_id"`
+	BlockID    flow.Identifier `json:"block_id"`
+	Height     *uint64         `json:"height,omitempty"`
+	ExecutorID flow.Identifier `json:"executor_id"`
+}
+
+// approvalSummary is the summary of a result approval in a memory pool.
+type approvalSummary struct {
+	ApprovalID flow.Identifier `json:"approval_id"`
+	ResultID   flow.Identifier `json:"result_id"`
+	BlockID    flow.Identifier `json:"block_id"`
+	Height     *uint64         `json:"height,omitempty"`
+	ChunkIndex uint64          `json:"chunk_index"`
+	ApproverID flow.Identifier `json:"approver_id"`
+}
+
+// mempoolSummary is the summary of a memory pool and the requested entries.
+type mempoolSummary struct {
+	Pool      string        `json:"pool"`
+	Size      uint          `json:"size"`
+	Capacity  uint          `json:"capacity"`
+	Ejections uint64        `json:"ejections"`
+	Entries   []interface{} `json:"entries"`
+}
+
+// ReadMempoolCommand returns a summary of the entries of a memory pool, consisting of their
+// IDs, the heights of their blocks and the nodes which created them, without their payloads.
+// As memory pools are created after the admin commands, they are registered with the command
+// once they were created.
+type ReadMempoolCommand struct {
+	headers storage.Headers
+	mu      sync.RWMutex
+	pools   map[string]mempool.Inspectable
+}
+
+func (r *ReadMempoolCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readMempoolRequest)
+
+	r.mu.RLock()
+	pool, ok := r.pools[data.pool]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("mempool %q is not available on this node", data.pool)
+	}
+
+	// the pool is only locked while taking the snapshot, the entries are summarized afterwards
+	entities := pool.Snapshot(data.limit, data.filter)
+
+	summary := mempoolSummary{
+		Pool:      data.pool,
+		Size:      pool.Size(),
+		Capacity:  pool.Capacity(),
+		Ejections: pool.EjectionCount(),
+		Entries:   make([]interface{}, 0, len(entities)),
+	}
+	for _, entity := range entities {
+		switch entity := entity.(type) {
+		case *flow.ExecutionReceipt:
+			summary.Entries = append(summary.Entries, receiptSummary{
+				ReceiptID:  entity.ID(),
+				ResultID:   entity.ExecutionResult.ID(),
+				BlockID:    entity.ExecutionResult.BlockID,
+				Height:     r.height(entity.ExecutionResult.BlockID),
+				ExecutorID: entity.ExecutorID,
+			})
+		case *flow.ResultApproval:
+			summary.Entries = append(summary.Entries, approvalSummary{
+				ApprovalID: entity.ID(),
+				ResultID:   entity.Body.ExecutionResultID,
+				BlockID:    entity.Body.BlockID,
+				Height:     r.height(entity.Body.BlockID),
+				ChunkIndex: entity.Body.ChunkIndex,
+				ApproverID: entity.Body.ApproverID,
+			})
+		default:
+			return nil, fmt.Errorf("unexpected entity type %T in mempool %q", entity, data.pool)
+		}
+	}
+
+	return convertToMap(summary)
+}
+
+// height returns the height of the given block, or nil if the block is unknown.
+func (r *ReadMempoolCommand) height(blockID flow.Identifier) *uint64 {
+	if r.headers == nil {
+		return nil
+	}
+	header, err := r.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil
+	}
+	return &header.Height
+}
+
+func (r *ReadMempoolCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	data := &readMempoolRequest{
+		limit: DefaultLimit,
+	}
+
+	pool, ok := input["pool"]
+	if !ok {
+		return fmt.Errorf("the \"pool\" field is required")
+	}
+	switch pool {
+	case PoolReceipts:
+		data.pool = PoolReceipts
+		blockID, ok, err := parseID(input, "block")
+		if err != nil {
+			return err
+		}
+		if ok {
+			data.filter = func(entity flow.Entity) bool {
+				receipt, ok := entity.(*flow.ExecutionReceipt)
+				return ok && receipt.ExecutionResult.BlockID == blockID
+			}
+		}
+	case PoolApprovals:
+		data.pool = PoolApprovals
+		resultID, ok, err := parseID(input, "result")
+		if err != nil {
+			return err
+		}
+		if ok {
+			data.filter = func(entity flow.Entity) bool {
+				approval, ok := entity.(*flow.ResultApproval)
+				return ok && approval.Body.ExecutionResultID == resultID
+			}
+		}
+	default:
+		return fmt.Errorf("invalid value for \"pool\": expected %q or %q, but got: %v", PoolReceipts, PoolApprovals, pool)
+	}
+
+	if limit, ok := input["limit"]; ok {
+		n, ok := limit.(float64)
+		if !ok || n < 1 || math.Trunc(n) != n {
+			return fmt.Errorf("invalid value for \"limit\": expected a positive integer, but got: %v", limit)
+		}
+		data.limit = int(n)
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+// RegisterPool registers the memory pool with the given name, so that it can be inspected.
+func (r *ReadMempoolCommand) RegisterPool(name string, pool mempool.Inspectable) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pools[name] = pool
+}
+
+// NewReadMempoolCommand creates a command inspecting the memory pools registered with it.
+// The headers are used to resolve the heights of the blocks of the entries.
+func NewReadMempoolCommand(headers storage.Headers) *ReadMempoolCommand {
+	return &ReadMempoolCommand{
+		headers: headers,
+		pools:   make(map[string]mempool.Inspectable),
+	}
+}
+
+// parseID parses the optional identifier in the given field of the input. It returns
+// whether the field was given.
+func parseID(input map[string]interface{}, field string) (flow.Identifier, bool, error) {
+	value, ok := input[field]
+	if !ok {
+		return flow.ZeroID, false, nil
+	}
+	errInvalidValue := fmt.Errorf("invalid value for %q: expected an ID represented as a 64 character long hex string, but got: %v", field, value)
+	hex, ok := value.(string)
+	if !ok {
+		return flow.ZeroID, false, errInvalidValue
+	}
+	id, err := flow.HexStringToIdentifier(hex)
+	if err != nil {
+		return flow.ZeroID, false, errInvalidValue
+	}
+	return id, true, nil
+}
+
+func convertToMap(object interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	bytes, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(bytes, &result)
+	return result, err
+}
@@ -0,0 +1,141 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// readMempool runs the command with the given request data, and decodes the summary.
+func readMempool(t *testing.T, command *ReadMempoolCommand, data map[string]interface{}) mempoolSummary {
+	req := &admin.CommandRequest{Data: data}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	bytes, err := json.Marshal(result)
+	require.NoError(t, err)
+	var summary mempoolSummary
+	require.NoError(t, json.Unmarshal(bytes, &summary))
+	return summary
+}
+
+func TestReadMempool_Receipts(t *testing.T) {
+	t.Parallel()
+
+	block := unittest.BlockHeaderFixture()
+	headers := &storagemock.Headers{}
+	headers.On("ByBlockID", block.ID()).Return(&block, nil)
+	headers.On("ByBlockID", mock.Anything).Return(nil, storage.ErrNotFound)
+
+	receipts, err := stdmap.NewReceipts(100)
+	require.NoError(t, err)
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(block.ID()))
+	var matching []*flow.ExecutionReceipt
+	for i := 0; i < 3; i++ {
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithResult(result))
+		matching = append(matching, receipt)
+		receipts.Add(receipt)
+	}
+	for i := 0; i < 5; i++ {
+		receipts.Add(unittest.ExecutionReceiptFixture())
+	}
+
+	command := NewReadMempoolCommand(headers)
+	command.RegisterPool(PoolReceipts, receipts)
+
+	t.Run("by block", func(t *testing.T) {
+		summary := readMempool(t, command, map[string]interface{}{"pool": "receipts", "block": block.ID().String()})
+		assert.Equal(t, PoolReceipts, summary.Pool)
+		assert.Equal(t, uint(8), summary.Size)
+		assert.Equal(t, uint(100), summary.Capacity)
+		assert.Equal(t, uint64(0), summary.Ejections)
+		require.Len(t, summary.Entries, 3)
+
+		expected := make(map[string]*flow.ExecutionReceipt)
+		for _, receipt := range matching {
+			expected[receipt.ID().String()] = receipt
+		}
+		for _, entry := range summary.Entries {
+			entry := entry.(map[string]interface{})
+			receipt, ok := expected[entry["receipt_id"].(string)]
+			require.True(t, ok)
+			assert.Equal(t, result.ID().String(), entry["result_id"])
+			assert.Equal(t, block.ID().String(), entry["block_id"])
+			assert.Equal(t, float64(block.Height), entry["height"])
+			assert.Equal(t, receipt.ExecutorID.String(), entry["executor_id"])
+			// the payload is not rendered
+			assert.NotContains(t, entry, "ExecutionResult")
+		}
+	})
+
+	t.Run("with limit", func(t *testing.T) {
+		summary := readMempool(t, command, map[string]interface{}{"pool": "receipts", "limit": float64(4)})
+		require.Len(t, summary.Entries, 4)
+		for _, entry := range summary.Entries {
+			// the heights of unknown blocks are omitted
+			if entry.(map[string]interface{})["block_id"] != block.ID().String() {
+				assert.NotContains(t, entry, "height")
+			}
+		}
+	})
+
+	t.Run("unavailable pool", func(t *testing.T) {
+		req := &admin.CommandRequest{Data: map[string]interface{}{"pool": "approvals"}}
+		require.NoError(t, command.Validator(req))
+		_, err := command.Handler(context.Background(), req)
+		assert.Error(t, err)
+	})
+}
+
+func TestReadMempool_Approvals(t *testing.T) {
+	t.Parallel()
+
+	approvals := stdmap.NewBackend(stdmap.WithLimit(100))
+	resultID := unittest.IdentifierFixture()
+	approval := unittest.ResultApprovalFixture(unittest.WithExecutionResultID(resultID))
+	approvals.Add(approval)
+	approvals.Add(unittest.ResultApprovalFixture())
+
+	command := NewReadMempoolCommand(nil)
+	command.RegisterPool(PoolApprovals, approvals)
+
+	summary := readMempool(t, command, map[string]interface{}{"pool": "approvals", "result": resultID.String()})
+	assert.Equal(t, uint(2), summary.Size)
+	require.Len(t, summary.Entries, 1)
+	entry := summary.Entries[0].(map[string]interface{})
+	assert.Equal(t, approval.ID().String(), entry["approval_id"])
+	assert.Equal(t, resultID.String(), entry["result_id"])
+	assert.Equal(t, approval.Body.BlockID.String(), entry["block_id"])
+	assert.Equal(t, float64(approval.Body.ChunkIndex), entry["chunk_index"])
+	assert.Equal(t, approval.Body.ApproverID.String(), entry["approver_id"])
+}
+
+func TestReadMempool_Validator(t *testing.T) {
+	t.Parallel()
+
+	command := NewReadMempoolCommand(nil)
+	for _, data := range []interface{}{
+		"receipts",
+		map[string]interface{}{},
+		map[string]interface{}{"pool": "seals"},
+		map[string]interface{}{"pool": "receipts", "block": 1},
+		map[string]interface{}{"pool": "receipts", "block": "deadbeef"},
+		map[string]interface{}{"pool": "approvals", "result": "deadbeef"},
+		map[string]interface{}{"pool": "receipts", "limit": float64(0)},
+		map[string]interface{}{"pool": "receipts", "limit": 1.5},
+	} {
+		assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), "data: %v", data)
+	}
+}
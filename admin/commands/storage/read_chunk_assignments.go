@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadChunkAssignmentsCommand)(nil)
+
+// ReadChunkAssignmentsCommand returns the persisted chunk assignments of an execution result,
+// for each block the result was incorporated in.
+type ReadChunkAssignmentsCommand struct {
+	assignments storage.ChunkAssignments
+}
+
+func (r *ReadChunkAssignmentsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	resultID := req.ValidatorData.(flow.Identifier)
+
+	records, err := r.assignments.ByResultID(resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunk assignments of result %v: %w", resultID, err)
+	}
+
+	return convertToInterfaceList(records)
+}
+
+func (r *ReadChunkAssignmentsCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	result, ok := input["result"]
+	if !ok {
+		return fmt.Errorf("the \"result\" field is required")
+	}
+	errInvalidResultValue := fmt.Errorf("invalid value for \"result\": expected a result ID represented as a 64 character long hex string, but got: %v", result)
+	resultHex, ok := result.(string)
+	if !ok {
+		return errInvalidResultValue
+	}
+	resultID, err := flow.HexStringToIdentifier(resultHex)
+	if err != nil {
+		return errInvalidResultValue
+	}
+	req.ValidatorData = resultID
+
+	return nil
+}
+
+func NewReadChunkAssignmentsCommand(assignments storage.ChunkAssignments) commands.AdminCommand {
+	return &ReadChunkAssignmentsCommand{
+		assignments,
+	}
+}
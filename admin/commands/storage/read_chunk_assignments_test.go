@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadChunkAssignments(t *testing.T) {
+	t.Parallel()
+
+	assignments := new(storagemock.ChunkAssignments)
+	command := NewReadChunkAssignmentsCommand(assignments)
+
+	record := &chunks.AssignmentRecord{
+		ResultID:            unittest.IdentifierFixture(),
+		IncorporatedBlockID: unittest.IdentifierFixture(),
+		IncorporatedHeight:  10,
+		Verifiers:           []flow.IdentifierList{unittest.IdentifierListFixture(2)},
+	}
+
+	t.Run("by result", func(t *testing.T) {
+		assignments.On("ByResultID", record.ResultID).Return([]*chunks.AssignmentRecord{record}, nil).Once()
+
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"result": record.ResultID.String()},
+		}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		var records []*chunks.AssignmentRecord
+		data, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &records))
+		assert.Equal(t, []*chunks.AssignmentRecord{record}, records)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, data := range []interface{}{
+			nil,
+			"result",
+			map[string]interface{}{},
+			map[string]interface{}{"result": 1},
+			map[string]interface{}{"result": "deadbeef"},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}))
+		}
+	})
+
+	assignments.AssertExpectations(t)
+}
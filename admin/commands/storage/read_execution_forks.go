@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadExecutionForksCommand)(nil)
+
+// ReadExecutionForksCommand returns the detected conflicts between execution results for a block.
+type ReadExecutionForksCommand struct {
+	conflicts storage.ExecutionResultConflicts
+}
+
+func (r *ReadExecutionForksCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	blockID := req.ValidatorData.(flow.Identifier)
+
+	conflicts, err := r.conflicts.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution result conflicts: %w", err)
+	}
+
+	return convertToInterfaceList(conflicts)
+}
+
+func (r *ReadExecutionForksCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	block, ok := input["block"]
+	if !ok {
+		return fmt.Errorf("the \"block\" field is required")
+	}
+	errInvalidBlockValue := fmt.Errorf("invalid value for \"block\": expected a block ID represented as a 64 character long hex string, but got: %v", block)
+	blockHex, ok := block.(string)
+	if !ok {
+		return errInvalidBlockValue
+	}
+	blockID, err := flow.HexStringToIdentifier(blockHex)
+	if err != nil {
+		return errInvalidBlockValue
+	}
+	req.ValidatorData = blockID
+
+	return nil
+}
+
+func NewReadExecutionForksCommand(conflicts storage.ExecutionResultConflicts) commands.AdminCommand {
+	return &ReadExecutionForksCommand{
+		conflicts,
+	}
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadExecutionForks(t *testing.T) {
+	t.Parallel()
+
+	conflicts := new(storagemock.ExecutionResultConflicts)
+	command := NewReadExecutionForksCommand(conflicts)
+
+	blockID := unittest.IdentifierFixture()
+	conflict := flow.NewExecutionResultConflict(blockID, unittest.IdentifierFixture(), unittest.IdentifierFixture(), unittest.IdentifierFixture())
+	conflict.AddExecutor(conflict.ResultIDs[0], unittest.IdentifierFixture())
+	conflict.AddExecutor(conflict.ResultIDs[1], unittest.IdentifierFixture())
+	conflicts.On("ByBlockID", blockID).Return([]*flow.ExecutionResultConflict{conflict}, nil).Once()
+
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{"block": blockID.String()},
+	}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	var results []*flow.ExecutionResultConflict
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, conflict, results[0])
+
+	for _, data := range []interface{}{
+		"block",
+		map[string]interface{}{},
+		map[string]interface{}{"block": 1},
+		map[string]interface{}{"block": "deadbeef"},
+	} {
+		assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}))
+	}
+
+	conflicts.AssertExpectations(t)
+}
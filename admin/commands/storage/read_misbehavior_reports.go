@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/misbehavior"
+)
+
+var _ commands.AdminCommand = (*ReadMisbehaviorReportsCommand)(nil)
+
+type readMisbehaviorReportsRequest struct {
+	originID flow.Identifier
+	since    time.Time
+}
+
+// ReadMisbehaviorReportsCommand returns the persisted misbehavior reports, optionally
+// filtered by the origin which misbehaved and the time since which it was reported.
+type ReadMisbehaviorReportsCommand struct {
+	pipeline *misbehavior.Pipeline
+}
+
+func (r *ReadMisbehaviorReportsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readMisbehaviorReportsRequest)
+
+	reports, err := r.pipeline.Query(data.originID, data.since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query misbehavior reports: %w", err)
+	}
+
+	return convertToInterfaceList(reports)
+}
+
+func (r *ReadMisbehaviorReportsCommand) Validator(req *admin.CommandRequest) error {
+	data := &readMisbehaviorReportsRequest{}
+
+	// all reports are returned if no filters are given
+	if req.Data == nil {
+		req.ValidatorData = data
+		return nil
+	}
+
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	if origin, ok := input["origin"]; ok {
+		errInvalidOriginValue := fmt.Errorf("invalid value for \"origin\": expected a node ID represented as a 64 character long hex string, but got: %v", origin)
+		origin, ok := origin.(string)
+		if !ok {
+			return errInvalidOriginValue
+		}
+		originID, err := flow.HexStringToIdentifier(origin)
+		if err != nil {
+			return errInvalidOriginValue
+		}
+		data.originID = originID
+	}
+
+	if since, ok := input["since"]; ok {
+		errInvalidSinceValue := fmt.Errorf("invalid value for \"since\": expected an RFC3339 timestamp, but got: %v", since)
+		since, ok := since.(string)
+		if !ok {
+			return errInvalidSinceValue
+		}
+		timestamp, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return errInvalidSinceValue
+		}
+		data.since = timestamp
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+func NewReadMisbehaviorReportsCommand(pipeline *misbehavior.Pipeline) commands.AdminCommand {
+	return &ReadMisbehaviorReportsCommand{
+		pipeline,
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadMisbehaviorReports(t *testing.T) {
+	t.Parallel()
+
+	reports := new(storagemock.MisbehaviorReports)
+	pipeline := misbehavior.NewPipeline(unittest.Logger(), metrics.NewNoopCollector(), reports, misbehavior.DefaultConfig())
+	command := NewReadMisbehaviorReportsCommand(pipeline)
+
+	originID := unittest.IdentifierFixture()
+	since := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+	report := &flow.MisbehaviorReport{
+		OriginID:    originID,
+		Reason:      flow.MisbehaviorInvalidReceipt,
+		EvidenceIDs: []flow.Identifier{unittest.IdentifierFixture()},
+		Timestamp:   since.Add(time.Minute),
+	}
+
+	query := func(reqData interface{}) []*flow.MisbehaviorReport {
+		req := &admin.CommandRequest{
+			Data: reqData,
+		}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		var results []*flow.MisbehaviorReport
+		data, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &results))
+		return results
+	}
+
+	t.Run("all reports", func(t *testing.T) {
+		reports.On("All", time.Time{}).Return([]*flow.MisbehaviorReport{report}, nil).Once()
+		results := query(nil)
+		require.Len(t, results, 1)
+		assert.Equal(t, report.ID(), results[0].ID())
+	})
+
+	t.Run("by origin since", func(t *testing.T) {
+		reports.On("ByOriginID", originID, since).Return([]*flow.MisbehaviorReport{report}, nil).Once()
+		results := query(map[string]interface{}{
+			"origin": originID.String(),
+			"since":  since.Format(time.RFC3339),
+		})
+		require.Len(t, results, 1)
+		assert.Equal(t, report.ID(), results[0].ID())
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, data := range []interface{}{
+			"origin",
+			map[string]interface{}{"origin": 1},
+			map[string]interface{}{"origin": "deadbeef"},
+			map[string]interface{}{"since": 1},
+			map[string]interface{}{"since": "yesterday"},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}))
+		}
+	})
+
+	reports.AssertExpectations(t)
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadVerificationRecordsCommand)(nil)
+
+// ReadVerificationRecordsCommand returns the persisted records of the chunks of an execution result,
+// which were verified by this node.
+type ReadVerificationRecordsCommand struct {
+	records storage.VerificationRecords
+}
+
+func (r *ReadVerificationRecordsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	resultID := req.ValidatorData.(flow.Identifier)
+
+	records, err := r.records.ByResultID(resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verification records of result %v: %w", resultID, err)
+	}
+
+	return convertToInterfaceList(records)
+}
+
+func (r *ReadVerificationRecordsCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	result, ok := input["result"]
+	if !ok {
+		return fmt.Errorf("the \"result\" field is required")
+	}
+	errInvalidResultValue := fmt.Errorf("invalid value for \"result\": expected a result ID represented as a 64 character long hex string, but got: %v", result)
+	resultHex, ok := result.(string)
+	if !ok {
+		return errInvalidResultValue
+	}
+	resultID, err := flow.HexStringToIdentifier(resultHex)
+	if err != nil {
+		return errInvalidResultValue
+	}
+	req.ValidatorData = resultID
+
+	return nil
+}
+
+func NewReadVerificationRecordsCommand(records storage.VerificationRecords) commands.AdminCommand {
+	return &ReadVerificationRecordsCommand{
+		records,
+	}
+}
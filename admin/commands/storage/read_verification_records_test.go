@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/verification"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadVerificationRecords(t *testing.T) {
+	t.Parallel()
+
+	records := new(storagemock.VerificationRecords)
+	command := NewReadVerificationRecordsCommand(records)
+
+	record := &verification.VerificationRecord{
+		ChunkID:          unittest.IdentifierFixture(),
+		ResultID:         unittest.IdentifierFixture(),
+		ChunkIndex:       1,
+		BlockID:          unittest.IdentifierFixture(),
+		Height:           10,
+		ApprovalID:       unittest.IdentifierFixture(),
+		StartState:       unittest.StateCommitmentFixture(),
+		EndState:         unittest.StateCommitmentFixture(),
+		TransactionCount: 2,
+		Duration:         time.Second,
+		Spock:            unittest.SignatureFixture(),
+	}
+
+	t.Run("by result", func(t *testing.T) {
+		records.On("ByResultID", record.ResultID).Return([]*verification.VerificationRecord{record}, nil).Once()
+
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"result": record.ResultID.String()},
+		}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		var found []*verification.VerificationRecord
+		data, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &found))
+		assert.Equal(t, []*verification.VerificationRecord{record}, found)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		for _, data := range []interface{}{
+			nil,
+			"result",
+			map[string]interface{}{},
+			map[string]interface{}{"result": 1},
+			map[string]interface{}{"result": "deadbeef"},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}))
+		}
+	})
+
+	records.AssertExpectations(t)
+}
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	accessapi "github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
@@ -22,11 +23,13 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/ingestion"
+	"github.com/onflow/flow-go/engine/access/observer"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/common/follower"
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	"github.com/onflow/flow-go/engine/common/requester"
+	splitterNetwork "github.com/onflow/flow-go/engine/common/splitter/network"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/encoding"
@@ -101,6 +104,11 @@ type AccessNodeConfig struct {
 	logTxTimeToFinalizedExecuted bool
 	retryEnabled                 bool
 	rpcMetricsEnabled            bool
+	rpcReflectionEnabled         bool
+	rpcHealthEnabled             bool
+	observerConf                 observer.Config
+	eventsStreamEnabled          bool
+	eventsStreamConf             backend.EventsStreamConfig
 	baseOptions                  []cmd.Option
 }
 
@@ -119,6 +127,12 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			CollectionClientTimeout:   3 * time.Second,
 			ExecutionClientTimeout:    3 * time.Second,
 			MaxHeightRange:            backend.DefaultMaxHeightRange,
+			EarliestHeight:            0,
+			ScriptCacheSize:           0,
+			ScriptCacheTTL:            backend.DefaultScriptCacheTTL,
+			MaxErrorMessageSize:       accessapi.DefaultMaxErrorMessageSize,
+			TransactionExpiryBuffer:   flow.DefaultTransactionExpiryBuffer,
+			TransactionArgumentLimits: backend.ArgumentLimits{MaxCount: flow.DefaultMaxTransactionArgumentCount},
 			PreferredExecutionNodeIDs: nil,
 			FixedExecutionNodeIDs:     nil,
 		},
@@ -129,6 +143,11 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		pingEnabled:                  false,
 		retryEnabled:                 false,
 		rpcMetricsEnabled:            false,
+		rpcReflectionEnabled:         false,
+		rpcHealthEnabled:             true,
+		observerConf:                 observer.DefaultConfig(),
+		eventsStreamEnabled:          false,
+		eventsStreamConf:             backend.DefaultEventsStreamConfig(),
 		nodeInfoFile:                 "",
 		apiRatelimits:                nil,
 		apiBurstlimits:               nil,
@@ -168,9 +187,12 @@ type FlowAccessNodeBuilder struct {
 	// available until after the network has started. Hence, a factory function that needs to be called just before
 	// creating the sync engine
 	SyncEngineParticipantsProviderFactory func() id.IdentifierProvider
+	// the network used by engines which might share the receipts channel with the consensus message observer
+	ReceiptsNetwork network.Network
 
 	// engines
 	IngestEng   *ingestion.Engine
+	ObserverEng *observer.Engine
 	RequestEng  *requester.Engine
 	FollowerEng *followereng.Engine
 	SyncEng     *synceng.Engine
@@ -276,6 +298,7 @@ func (builder *FlowAccessNodeBuilder) buildFollowerEngine() *FlowAccessNodeBuild
 			node.Me,
 			node.Metrics.Engine,
 			node.Metrics.Mempool,
+			node.Metrics.Cache,
 			cleaner,
 			node.Storage.Headers,
 			node.Storage.Payloads,
@@ -431,8 +454,22 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 			anb.rpcConf.TransportCredentials = credentials.NewTLS(tlsConfig)
 			return nil
 		}).
+		Component("consensus message observer", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			// the ingestion engine already receives receipts, so both engines register with a splitter network
+			anb.ReceiptsNetwork = node.Network
+			if anb.observerConf.Enabled {
+				anb.ReceiptsNetwork = splitterNetwork.NewNetwork(node.Network, node.Logger)
+			}
+
+			var err error
+			anb.ObserverEng, err = observer.New(node.Logger, anb.ReceiptsNetwork, node.Me, node.State, anb.observerConf)
+			if err != nil {
+				return nil, fmt.Errorf("could not create consensus message observer: %w", err)
+			}
+			return anb.ObserverEng, nil
+		}).
 		Component("RPC engine", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
-			anb.RpcEng = rpc.New(
+			engine := rpc.New(
 				node.Logger,
 				node.State,
 				anb.rpcConf,
@@ -453,6 +490,20 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 				anb.apiRatelimits,
 				anb.apiBurstlimits,
 			)
+			engineBuilder := rpc.NewRPCEngineBuilder(engine)
+			if anb.rpcReflectionEnabled {
+				engineBuilder.WithReflection()
+			}
+			if anb.rpcHealthEnabled {
+				engineBuilder.WithHealthService()
+			}
+			if anb.observerConf.Enabled {
+				engineBuilder.WithConsensusObserver(anb.ObserverEng)
+			}
+			if anb.eventsStreamEnabled {
+				engineBuilder.WithEventsSubscription(anb.eventsStreamConf)
+			}
+			anb.RpcEng = engineBuilder.Build()
 			return anb.RpcEng, nil
 		}).
 		Component("ingestion engine", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
@@ -472,8 +523,8 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 				return nil, fmt.Errorf("could not create requester engine: %w", err)
 			}
 
-			anb.IngestEng, err = ingestion.New(node.Logger, node.Network, node.State, node.Me, anb.RequestEng, node.Storage.Blocks, node.Storage.Headers, node.Storage.Collections, node.Storage.Transactions, node.Storage.Results, node.Storage.Receipts, anb.TransactionMetrics,
-				anb.CollectionsToMarkFinalized, anb.CollectionsToMarkExecuted, anb.BlocksToMarkExecuted, anb.RpcEng)
+			anb.IngestEng, err = ingestion.New(node.Logger, anb.ReceiptsNetwork, node.State, node.Me, anb.RequestEng, node.Storage.Blocks, node.Storage.Headers, node.Storage.Collections, node.Storage.Transactions, node.Storage.Results, node.Storage.Receipts, anb.TransactionMetrics,
+				anb.CollectionsToMarkFinalized, anb.CollectionsToMarkExecuted, anb.BlocksToMarkExecuted, anb.RpcEng, node.Tracer)
 			if err != nil {
 				return nil, err
 			}
@@ -560,6 +611,15 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.DurationVar(&builder.rpcConf.CollectionClientTimeout, "collection-client-timeout", defaultConfig.rpcConf.CollectionClientTimeout, "grpc client timeout for a collection node")
 		flags.DurationVar(&builder.rpcConf.ExecutionClientTimeout, "execution-client-timeout", defaultConfig.rpcConf.ExecutionClientTimeout, "grpc client timeout for an execution node")
 		flags.UintVar(&builder.rpcConf.MaxHeightRange, "rpc-max-height-range", defaultConfig.rpcConf.MaxHeightRange, "maximum size for height range requests")
+		flags.Uint64Var(&builder.rpcConf.EarliestHeight, "rpc-earliest-height", defaultConfig.rpcConf.EarliestHeight, "earliest block height for which historical data is served (heights below the root block are never served)")
+		flags.UintVar(&builder.rpcConf.ScriptCacheSize, "script-cache-size", defaultConfig.rpcConf.ScriptCacheSize, "maximum number of cached script execution results (0 disables the cache)")
+		flags.DurationVar(&builder.rpcConf.ScriptCacheTTL, "script-cache-ttl", defaultConfig.rpcConf.ScriptCacheTTL, "time for which a script execution result is cached")
+		flags.UintVar(&builder.rpcConf.MaxErrorMessageSize, "max-error-message-size", defaultConfig.rpcConf.MaxErrorMessageSize, "maximum size in bytes of transaction error messages in responses, longer messages are truncated")
+		flags.UintVar(&builder.rpcConf.TransactionExpiryBuffer, "tx-expiry-buffer", defaultConfig.rpcConf.TransactionExpiryBuffer, "minimum number of blocks until expiry for submitted transactions to be forwarded to collection nodes")
+		flags.UintVar(&builder.rpcConf.TransactionArgumentLimits.MaxCount, "tx-max-argument-count", defaultConfig.rpcConf.TransactionArgumentLimits.MaxCount, "maximum number of arguments of submitted transactions (0 means no limit)")
+		flags.Uint64Var(&builder.rpcConf.TransactionArgumentLimits.MaxSize, "tx-max-argument-size", defaultConfig.rpcConf.TransactionArgumentLimits.MaxSize, "maximum byte size of a single argument of submitted transactions (0 means no limit)")
+		flags.Uint64Var(&builder.rpcConf.TransactionArgumentLimits.MaxTotalSize, "tx-max-arguments-size", defaultConfig.rpcConf.TransactionArgumentLimits.MaxTotalSize, "maximum total byte size of the arguments of submitted transactions (0 means no limit)")
+		flags.BoolVar(&builder.rpcConf.TransactionArgumentLimits.Strict, "tx-strict-arguments", defaultConfig.rpcConf.TransactionArgumentLimits.Strict, "reject submitted transactions with arguments, which are not valid JSON-CDC encodings")
 		flags.StringSliceVar(&builder.rpcConf.PreferredExecutionNodeIDs, "preferred-execution-node-ids", defaultConfig.rpcConf.PreferredExecutionNodeIDs, "comma separated list of execution nodes ids to choose from when making an upstream call e.g. b4a4dbdcd443d...,fb386a6a... etc.")
 		flags.StringSliceVar(&builder.rpcConf.FixedExecutionNodeIDs, "fixed-execution-node-ids", defaultConfig.rpcConf.FixedExecutionNodeIDs, "comma separated list of execution nodes ids to choose from when making an upstream call if no matching preferred execution id is found e.g. b4a4dbdcd443d...,fb386a6a... etc.")
 		flags.BoolVar(&builder.logTxTimeToFinalized, "log-tx-time-to-finalized", defaultConfig.logTxTimeToFinalized, "log transaction time to finalized")
@@ -568,6 +628,15 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.BoolVar(&builder.pingEnabled, "ping-enabled", defaultConfig.pingEnabled, "whether to enable the ping process that pings all other peers and report the connectivity to metrics")
 		flags.BoolVar(&builder.retryEnabled, "retry-enabled", defaultConfig.retryEnabled, "whether to enable the retry mechanism at the access node level")
 		flags.BoolVar(&builder.rpcMetricsEnabled, "rpc-metrics-enabled", defaultConfig.rpcMetricsEnabled, "whether to enable the rpc metrics")
+		flags.BoolVar(&builder.rpcReflectionEnabled, "rpc-reflection-enabled", defaultConfig.rpcReflectionEnabled, "whether to register the gRPC reflection service")
+		flags.BoolVar(&builder.rpcHealthEnabled, "rpc-health-enabled", defaultConfig.rpcHealthEnabled, "whether to register the gRPC health service")
+		flags.BoolVar(&builder.observerConf.Enabled, "observe-consensus-messages", defaultConfig.observerConf.Enabled, "whether to subscribe to the receipts and approvals channels and stream the observed messages over gRPC")
+		flags.UintVar(&builder.observerConf.MaxSubscribers, "observer-max-subscribers", defaultConfig.observerConf.MaxSubscribers, "maximum number of concurrent subscribers to the observed consensus messages")
+		flags.UintVar(&builder.observerConf.SubscriberBufferSize, "observer-subscriber-buffer-size", defaultConfig.observerConf.SubscriberBufferSize, "number of observed consensus messages buffered per subscriber before messages are dropped")
+		flags.Float64Var(&builder.observerConf.SubscriberRateLimit, "observer-subscriber-rate-limit", defaultConfig.observerConf.SubscriberRateLimit, "maximum number of observed consensus messages per second sent to a single subscriber (0 for unlimited)")
+		flags.BoolVar(&builder.eventsStreamEnabled, "events-subscription-enabled", defaultConfig.eventsStreamEnabled, "whether to serve the gRPC API streaming the events of sealed blocks")
+		flags.UintVar(&builder.eventsStreamConf.BufferSize, "events-subscription-buffer-size", defaultConfig.eventsStreamConf.BufferSize, "number of block batches buffered per events subscriber before the subscriber is disconnected")
+		flags.DurationVar(&builder.eventsStreamConf.PollInterval, "events-subscription-poll-interval", defaultConfig.eventsStreamConf.PollInterval, "interval at which events subscriptions check for newly sealed blocks")
 		flags.StringVarP(&builder.nodeInfoFile, "node-info-file", "", defaultConfig.nodeInfoFile, "full path to a json file which provides more details about nodes when reporting its reachability metrics")
 		flags.StringToIntVar(&builder.apiRatelimits, "api-rate-limits", defaultConfig.apiRatelimits, "per second rate limits for Access API methods e.g. Ping=300,GetTransaction=500 etc.")
 		flags.StringToIntVar(&builder.apiBurstlimits, "api-burst-limits", defaultConfig.apiBurstlimits, "burst limits for Access API methods e.g. Ping=100,GetTransaction=100 etc.")
@@ -33,6 +33,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/blocktime"
 	"github.com/onflow/flow-go/module/buffer"
 	builder "github.com/onflow/flow-go/module/builder/collection"
 	"github.com/onflow/flow-go/module/epochs"
@@ -61,6 +62,7 @@ func main() {
 		builderExpiryBuffer                    uint
 		builderPayerRateLimit                  float64
 		builderUnlimitedPayers                 []string
+		builderTxPriority                      string
 		hotstuffTimeout                        time.Duration
 		hotstuffMinTimeout                     time.Duration
 		hotstuffTimeoutIncreaseFactor          float64
@@ -69,6 +71,9 @@ func main() {
 		blockRateDelay                         time.Duration
 		startupTimeString                      string
 		startupTime                            time.Time
+		followUnstakedEpochs                   bool
+		followClusterMemberString              string
+		followClusterMember                    flow.Identifier
 
 		followerState protocol.MutableState
 		ingestConf    ingest.Config
@@ -115,12 +120,16 @@ func main() {
 			"how many additional cluster members we propagate transactions to")
 		flags.Uint64Var(&ingestConf.MaxAddressIndex, "ingest-max-address-index", flow.DefaultMaxAddressIndex,
 			"the maximum address index allowed in transactions")
+		flags.UintVar(&ingestConf.MaxArgumentCount, "ingest-max-tx-argument-count", flow.DefaultMaxTransactionArgumentCount,
+			"maximum number of arguments per transaction")
 		flags.UintVar(&builderExpiryBuffer, "builder-expiry-buffer", builder.DefaultExpiryBuffer,
 			"expiry buffer for transactions in proposed collections")
 		flags.Float64Var(&builderPayerRateLimit, "builder-rate-limit", builder.DefaultMaxPayerTransactionRate, // no rate limiting
 			"rate limit for each payer (transactions/collection)")
 		flags.StringSliceVar(&builderUnlimitedPayers, "builder-unlimited-payers", []string{}, // no unlimited payers
 			"set of payer addresses which are omitted from rate limiting")
+		flags.StringVar(&builderTxPriority, "builder-tx-priority", "arrival",
+			"order in which pending transactions are included in proposed collections (arrival, gas-limit)")
 		flags.UintVar(&maxCollectionSize, "builder-max-collection-size", flow.DefaultMaxCollectionSize,
 			"maximum number of transactions in proposed collections")
 		flags.Uint64Var(&maxCollectionByteSize, "builder-max-collection-byte-size", flow.DefaultMaxCollectionByteSize,
@@ -143,6 +152,10 @@ func main() {
 		flags.DurationVar(&blockRateDelay, "block-rate-delay", 250*time.Millisecond,
 			"the delay to broadcast block proposal in order to control block production rate")
 		flags.StringVar(&startupTimeString, "hotstuff-startup-time", cmd.NotSet, "specifies date and time (in ISO 8601 format) after which the consensus participant may enter the first view (e.g (e.g 1996-04-24T15:04:05-07:00))")
+		flags.BoolVar(&followUnstakedEpochs, "follow-unstaked-epochs", false,
+			"whether to follow and serve the cluster state of epochs in which this node is not staked")
+		flags.StringVar(&followClusterMemberString, "follow-cluster-member", cmd.NotSet,
+			"node ID of a collection node, whose cluster is followed in epochs in which this node is not staked (required with --follow-unstaked-epochs)")
 
 		// epoch qc contract flags
 		flags.BoolVar(&insecureAccessAPI, "insecure-access-api", false, "required if insecure GRPC connection should be used")
@@ -155,6 +168,16 @@ func main() {
 			}
 			startupTime = t
 		}
+		if followUnstakedEpochs {
+			if followClusterMemberString == cmd.NotSet {
+				return fmt.Errorf("--follow-cluster-member must be set with --follow-unstaked-epochs")
+			}
+			nodeID, err := flow.HexStringToIdentifier(followClusterMemberString)
+			if err != nil {
+				return fmt.Errorf("invalid follow-cluster-member value: %w", err)
+			}
+			followClusterMember = nodeID
+		}
 		return nil
 	})
 
@@ -181,7 +204,16 @@ func main() {
 			return err
 		}).
 		Module("transactions mempool", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			create := func() mempool.Transactions { return stdmap.NewTransactions(txLimit) }
+			var priority stdmap.TransactionPriorityFunc
+			switch builderTxPriority {
+			case "arrival":
+				priority = stdmap.PriorityArrival
+			case "gas-limit":
+				priority = stdmap.PriorityGasLimit
+			default:
+				return fmt.Errorf("invalid builder-tx-priority value: %s", builderTxPriority)
+			}
+			create := func() mempool.Transactions { return stdmap.NewTransactionsWithPriority(txLimit, priority) }
 			pools = epochpool.NewTransactionPools(create)
 			err := node.Metrics.Mempool.Register(metrics.ResourceTransaction, pools.CombinedSize)
 			return err
@@ -286,6 +318,7 @@ func main() {
 				node.Me,
 				node.Metrics.Engine,
 				node.Metrics.Mempool,
+				node.Metrics.Cache,
 				cleaner,
 				node.Storage.Headers,
 				node.Storage.Payloads,
@@ -485,17 +518,30 @@ func main() {
 				syncFactory,
 			)
 
-			heightEvents := gadgets.NewHeights()
+			final, err := node.State.Final().Head()
+			if err != nil {
+				return nil, fmt.Errorf("could not get finalized header: %w", err)
+			}
+			heightEvents, err := gadgets.NewPersistentHeights(node.Logger, node.DB, final.Height)
+			if err != nil {
+				return nil, fmt.Errorf("could not create height events: %w", err)
+			}
 			node.ProtocolEvents.AddConsumer(heightEvents)
 
+			blockTime := blocktime.NewEstimator()
+			node.ProtocolEvents.AddConsumer(blockTime)
+
 			manager, err := epochmgr.New(
-				node.Logger,
+				node.LoggerRegistry.Logger("epochmgr", node.Logger),
 				node.Me,
 				node.State,
 				pools,
 				rootQCVoter,
+				storagekv.NewClusterQCVotes(node.DB),
 				factory,
 				heightEvents,
+				epochmgr.WithFollowUnstakedEpochs(followClusterMember),
+				epochmgr.WithBlockTimeEstimator(blockTime),
 			)
 			if err != nil {
 				return nil, fmt.Errorf("could not create epoch manager: %w", err)
@@ -14,6 +14,10 @@ import (
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/onflow/flow-go-sdk/crypto"
 
+	"github.com/onflow/flow-go/admin/commands"
+	adminCommands "github.com/onflow/flow-go/admin/commands/common"
+	mempoolCommands "github.com/onflow/flow-go/admin/commands/mempool"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
 	"github.com/onflow/flow-go/consensus"
@@ -28,6 +32,8 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	conengine "github.com/onflow/flow-go/engine/consensus"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
 	"github.com/onflow/flow-go/engine/consensus/approvals/tracker"
 	"github.com/onflow/flow-go/engine/consensus/compliance"
 	dkgeng "github.com/onflow/flow-go/engine/consensus/dkg"
@@ -48,11 +54,13 @@ import (
 	dkgmodule "github.com/onflow/flow-go/module/dkg"
 	"github.com/onflow/flow-go/module/epochs"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/forkdetector"
 	"github.com/onflow/flow-go/module/mempool"
 	consensusMempools "github.com/onflow/flow-go/module/mempool/consensus"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/module/validation"
 	"github.com/onflow/flow-go/state/protocol"
@@ -61,6 +69,7 @@ import (
 	"github.com/onflow/flow-go/state/protocol/events/gadgets"
 	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagecache "github.com/onflow/flow-go/storage/cache"
 	"github.com/onflow/flow-go/utils/io"
 )
 
@@ -76,6 +85,8 @@ func main() {
 		maxInterval                            time.Duration
 		maxSealPerBlock                        uint
 		maxGuaranteePerBlock                   uint
+		maxReceiptPerBlock                     uint
+		maxPayloadSize                         uint64
 		hotstuffTimeout                        time.Duration
 		hotstuffMinTimeout                     time.Duration
 		hotstuffTimeoutIncreaseFactor          float64
@@ -85,7 +96,14 @@ func main() {
 		chunkAlpha                             uint
 		requiredApprovalsForSealVerification   uint
 		requiredApprovalsForSealConstruction   uint
+		sealConstructionStakeFraction          float64
 		emergencySealing                       bool
+		emergencySealingThreshold              uint64
+		maxResultsPerCheck                     uint
+		requireSPoCKs                          bool
+		receiptProcessingDeadline              time.Duration
+		matchingStorageFailureThreshold        uint
+		traceSamplingRate                      uint64
 		dkgControllerConfig                    dkgmodule.ControllerConfig
 		startupTimeString                      string
 		startupTime                            time.Time
@@ -115,6 +133,9 @@ func main() {
 		dkgBrokerTunnel         *dkgmodule.BrokerTunnel
 		blockTimer              protocol.BlockTimer
 		finalizedHeader         *synceng.FinalizedHeaderCache
+		headerCache             *storagecache.Headers
+		traceSampler            *conengine.TraceSampler
+		readMempoolCommand      *mempoolCommands.ReadMempoolCommand
 		dkgState                *bstorage.DKGState
 		safeBeaconKeys          *bstorage.SafeBeaconPrivateKeys
 	)
@@ -132,6 +153,8 @@ func main() {
 		flags.DurationVar(&maxInterval, "max-interval", 90*time.Second, "the maximum amount of time between two blocks")
 		flags.UintVar(&maxSealPerBlock, "max-seal-per-block", 100, "the maximum number of seals to be included in a block")
 		flags.UintVar(&maxGuaranteePerBlock, "max-guarantee-per-block", 100, "the maximum number of collection guarantees to be included in a block")
+		flags.UintVar(&maxReceiptPerBlock, "max-receipt-per-block", 200, "the maximum number of execution receipts to be included in a block")
+		flags.Uint64Var(&maxPayloadSize, "max-payload-size", builder.DefaultMaxPayloadSize, "the maximum total encoded byte size of the seals, guarantees and receipts included in a block")
 		flags.DurationVar(&hotstuffTimeout, "hotstuff-timeout", 60*time.Second, "the initial timeout for the hotstuff pacemaker")
 		flags.DurationVar(&hotstuffMinTimeout, "hotstuff-min-timeout", 2500*time.Millisecond, "the lower timeout bound for the hotstuff pacemaker")
 		flags.Float64Var(&hotstuffTimeoutIncreaseFactor, "hotstuff-timeout-increase-factor", timeout.DefaultConfig.TimeoutIncrease, "multiplicative increase of timeout value in case of time out event")
@@ -141,7 +164,14 @@ func main() {
 		flags.UintVar(&chunkAlpha, "chunk-alpha", chmodule.DefaultChunkAssignmentAlpha, "number of verifiers that should be assigned to each chunk")
 		flags.UintVar(&requiredApprovalsForSealVerification, "required-verification-seal-approvals", validation.DefaultRequiredApprovalsForSealValidation, "minimum number of approvals that are required to verify a seal")
 		flags.UintVar(&requiredApprovalsForSealConstruction, "required-construction-seal-approvals", sealing.DefaultRequiredApprovalsForSealConstruction, "minimum number of approvals that are required to construct a seal")
+		flags.Float64Var(&sealConstructionStakeFraction, "required-construction-seal-stake-fraction", 0, "minimum fraction of the stake of the assigned verifiers that must approve each chunk to construct a seal; zero counts approvals instead (see required-construction-seal-approvals)")
 		flags.BoolVar(&emergencySealing, "emergency-sealing-active", sealing.DefaultEmergencySealingActive, "(de)activation of emergency sealing")
+		flags.Uint64Var(&emergencySealingThreshold, "emergency-sealing-threshold", approvals.DefaultEmergencySealingThreshold, "minimum number of finalized blocks on top of the block incorporating a result, for the result to be emergency sealed (requires emergency-sealing-active)")
+		flags.UintVar(&maxResultsPerCheck, "sealing-max-results-per-check", sealing.DefaultMaxResultsPerCheck, "maximum number of execution results checked for emergency sealing and missing approvals per finalized block; zero means no limit")
+		flags.BoolVar(&requireSPoCKs, "require-spocks", false, "only construct seal candidates, if every approval contributing to the seal provides a SPoCK proof, and include the proofs in the seal (must be identical for all consensus nodes)")
+		flags.DurationVar(&receiptProcessingDeadline, "matching-receipt-processing-deadline", matching.DefaultReceiptProcessingDeadline, "deadline for processing a single execution receipt, after which the overrun is logged")
+		flags.UintVar(&matchingStorageFailureThreshold, "matching-storage-failure-threshold", matching.DefaultConfig().StorageFailureThreshold, "number of consecutive unexpected storage errors in the matching engine, after which the node is stopped (0 to disable)")
+		flags.Uint64Var(&traceSamplingRate, "trace-sampling-rate", 0, "emit a detailed validation trace for one out of N receipts and approvals; zero disables sampling")
 		flags.BoolVar(&insecureAccessAPI, "insecure-access-api", false, "required if insecure GRPC connection should be used")
 		flags.StringSliceVar(&accessNodeIDS, "access-node-ids", []string{}, fmt.Sprintf("array of access node IDs sorted in priority order where the first ID in this array will get the first connection attempt and each subsequent ID after serves as a fallback. Minimum length %d. Use '*' for all IDs in protocol state.", common.DefaultAccessNodeIDSMinimum))
 		flags.DurationVar(&dkgControllerConfig.BaseStartDelay, "dkg-controller-base-start-delay", dkgmodule.DefaultBaseStartDelay, "used to define the range for jitter prior to DKG start (eg. 500µs) - the base value is scaled quadratically with the # of DKG participants")
@@ -165,7 +195,21 @@ func main() {
 		nodeBuilder.Logger.Fatal().Err(err).Send()
 	}
 
+	// the trace sampler is created before the node modules, as the admin commands are set up first
+	traceSampler = conengine.NewTraceSampler(traceSamplingRate)
+
 	nodeBuilder.
+		AdminCommand("set-trace-sampling-rate", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return adminCommands.NewSetTraceSamplingRateCommand(traceSampler)
+		}).
+		AdminCommand("read-execution-forks", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadExecutionForksCommand(bstorage.NewExecutionResultConflicts(config.DB))
+		}).
+		AdminCommand("read-mempool", func(config *cmd.NodeConfig) commands.AdminCommand {
+			// the memory pools are registered with the command once they are created
+			readMempoolCommand = mempoolCommands.NewReadMempoolCommand(config.Storage.Headers)
+			return readMempoolCommand
+		}).
 		Module("consensus node metrics", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			conMetrics = metrics.NewConsensusCollector(node.Tracer, node.MetricsRegisterer)
 			return nil
@@ -193,6 +237,9 @@ func main() {
 			if requiredApprovalsForSealConstruction > chunkAlpha {
 				return fmt.Errorf("invalid consensus parameters: requiredApprovalsForSealConstruction > chunkAlpha")
 			}
+			if sealConstructionStakeFraction < 0 || sealConstructionStakeFraction > 1 {
+				return fmt.Errorf("invalid consensus parameters: sealConstructionStakeFraction must be within [0, 1]")
+			}
 
 			chunkAssigner, err = chmodule.NewChunkAssigner(chunkAlpha, node.State)
 			if err != nil {
@@ -205,9 +252,9 @@ func main() {
 				node.Storage.Index,
 				node.Storage.Results,
 				node.Storage.Seals,
-				signature.NewAggregationVerifier(encoding.ExecutionReceiptTag))
+				signature.NewAggregationVerifier(messages.ExecutionReceiptTag))
 
-			resultApprovalSigVerifier := signature.NewAggregationVerifier(encoding.ResultApprovalTag)
+			resultApprovalSigVerifier := signature.NewAggregationVerifier(messages.ResultApprovalTag)
 
 			sealValidator, err := validation.NewSealValidator(
 				node.State,
@@ -354,7 +401,10 @@ func main() {
 			return nil
 		}).
 		Module("pending receipts mempool", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			pendingReceipts = stdmap.NewPendingReceipts(node.Storage.Headers, pendingReceiptsLimit)
+			pending := stdmap.NewPendingReceipts(node.Storage.Headers, pendingReceiptsLimit)
+			// the admin commands are set up before the modules
+			readMempoolCommand.RegisterPool(mempoolCommands.PoolReceipts, pending)
+			pendingReceipts = pending
 			return nil
 		}).
 		Module("hotstuff main metrics", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
@@ -369,6 +419,16 @@ func main() {
 			finalizationDistributor = pubsub.NewFinalizationDistributor()
 			return nil
 		}).
+		Module("header cache", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
+			// the header cache is shared by the matching engine and the block builder, which
+			// both walk the chain of recent headers
+			headerCache, err = storagecache.NewHeaders(node.Storage.Headers, node.State)
+			if err != nil {
+				return err
+			}
+			finalizationDistributor.AddOnBlockFinalizedConsumer(headerCache.OnFinalizedBlock)
+			return nil
+		}).
 		Module("machine account config", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			machineAccountInfo, err = cmd.LoadNodeMachineAccountInfoFile(node.BootstrapDir, node.NodeID)
 			return err
@@ -403,15 +463,21 @@ func main() {
 		}).
 		Component("sealing engine", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 
-			resultApprovalSigVerifier := signature.NewAggregationVerifier(encoding.ResultApprovalTag)
+			resultApprovalSigVerifier := signature.NewAggregationVerifier(messages.ResultApprovalTag)
 			sealingTracker := tracker.NewSealingTracker(node.Logger, node.Storage.Headers, node.Storage.Receipts, seals)
 
 			config := sealing.DefaultConfig()
 			config.EmergencySealingActive = emergencySealing
+			config.EmergencySealingThreshold = emergencySealingThreshold
 			config.RequiredApprovalsForSealConstruction = requiredApprovalsForSealConstruction
+			if sealConstructionStakeFraction > 0 {
+				config.ApprovalSufficiency = approvals.StakeFraction{Fraction: sealConstructionStakeFraction}
+			}
+			config.MaxResultsPerCheck = maxResultsPerCheck
+			config.RequireSPoCKs = requireSPoCKs
 
 			e, err := sealing.NewEngine(
-				node.Logger,
+				node.LoggerRegistry.Logger("sealing", node.Logger),
 				node.Tracer,
 				conMetrics,
 				node.Metrics.Engine,
@@ -428,6 +494,8 @@ func main() {
 				chunkAssigner,
 				resultApprovalSigVerifier,
 				seals,
+				node.Misbehavior,
+				traceSampler,
 				config,
 			)
 
@@ -454,32 +522,54 @@ func main() {
 				return nil, err
 			}
 
+			matchingConfig := matching.DefaultConfig()
+			matchingConfig.StorageFailureThreshold = matchingStorageFailureThreshold
 			core := matching.NewCore(
 				node.Logger,
 				node.Tracer,
 				conMetrics,
 				node.Metrics.Mempool,
 				node.State,
-				node.Storage.Headers,
+				headerCache,
 				node.Storage.Receipts,
+				bstorage.NewReceiptRequests(node.DB),
 				receipts,
 				pendingReceipts,
 				seals,
 				receiptValidator,
 				receiptRequester,
-				matching.DefaultConfig(),
+				node.Misbehavior,
+				traceSampler,
+				matchingConfig,
 			)
+			err = core.RegisterConfigs(node.ConfigManager)
+			if err != nil {
+				return nil, err
+			}
+			// alert on conflicting execution results, including those of the receipts loaded on warm up
+			forkDetector := forkdetector.NewDetector(node.Logger, conMetrics, bstorage.NewExecutionResultConflicts(node.DB))
+			core.AddReceiptConsumer(forkDetector)
+			// load the receipts received before a restart, so they don't need to be requested again
+			_, err = core.WarmUp()
+			if err != nil {
+				return nil, fmt.Errorf("could not warm up receipts mempool: %w", err)
+			}
 
 			e, err := matching.NewEngine(
-				node.Logger,
+				node.LoggerRegistry.Logger("matching", node.Logger),
 				node.Network,
 				node.Me,
 				node.Metrics.Engine,
+				conMetrics,
 				node.Metrics.Mempool,
 				node.State,
 				node.Storage.Receipts,
 				node.Storage.Index,
 				core,
+				pendingReceipts,
+				signature.NewAggregationVerifier(messages.ExecutionReceiptTag),
+				matching.WithReceiptProcessingDeadline(receiptProcessingDeadline),
+				matching.WithMisbehaviorReporter(node.Misbehavior),
 			)
 			if err != nil {
 				return nil, err
@@ -508,6 +598,7 @@ func main() {
 				node.Logger,
 				node.Tracer,
 				node.Metrics.Mempool,
+				conMetrics,
 				node.State,
 				node.Storage.Headers,
 				guarantees,
@@ -558,9 +649,10 @@ func main() {
 			var build module.Builder
 			build, err = builder.NewBuilder(
 				node.Metrics.Mempool,
+				conMetrics,
 				node.DB,
 				mutableState,
-				node.Storage.Headers,
+				headerCache,
 				node.Storage.Seals,
 				node.Storage.Index,
 				node.Storage.Blocks,
@@ -573,6 +665,8 @@ func main() {
 				builder.WithBlockTimer(blockTimer),
 				builder.WithMaxSealCount(maxSealPerBlock),
 				builder.WithMaxGuaranteeCount(maxGuaranteePerBlock),
+				builder.WithMaxReceiptCount(maxReceiptPerBlock),
+				builder.WithMaxPayloadSize(maxPayloadSize),
 			)
 			if err != nil {
 				return nil, fmt.Errorf("could not initialized block builder: %w", err)
@@ -610,11 +704,17 @@ func main() {
 			if err != nil {
 				return nil, fmt.Errorf("could not create Committee state for main consensus: %w", err)
 			}
+			identityCache, err := committees.NewIdentityCache(committee, committees.DefaultIdentityCacheSize) // cache for the participants of recently verified blocks
+			if err != nil {
+				return nil, fmt.Errorf("could not create identity cache for main consensus: %w", err)
+			}
+			node.ProtocolEvents.AddConsumer(identityCache) // flush the cached participants on epoch transitions
+			committee = identityCache
 			committee = committees.NewMetricsWrapper(committee, mainMetrics) // wrapper for measuring time spent determining consensus committee relations
 
 			epochLookup := epochs.NewEpochLookup(node.State)
 
-			thresholdSignerStore := signature.NewEpochAwareSignerStore(epochLookup, safeBeaconKeys)
+			thresholdSignerStore := signature.NewEpochAwareSignerStore(node.Logger, conMetrics, epochLookup, safeBeaconKeys)
 
 			// initialize the combined signer for hotstuff
 			var signer hotstuff.SignerVerifier
@@ -754,11 +854,13 @@ func main() {
 				node.Me,
 				node.State,
 				dkgState,
+				dkgState,
 				dkgmodule.NewControllerFactory(
 					node.Logger,
 					node.Me,
 					dkgContractClients,
 					dkgBrokerTunnel,
+					dkgState,
 					dkgControllerConfig,
 				),
 				viewsObserver,
@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -15,6 +17,8 @@ import (
 
 	"github.com/onflow/flow-core-contracts/lib/go/templates"
 
+	"github.com/onflow/flow-go/admin/commands"
+	executionCommands "github.com/onflow/flow-go/admin/commands/execution"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
@@ -29,11 +33,13 @@ import (
 	"github.com/onflow/flow-go/engine/execution/checker"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/audit"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/fvm"
@@ -101,17 +107,27 @@ func main() {
 		syncThreshold                 int
 		extensiveLog                  bool
 		pauseExecution                bool
+		chunkBodyVersion              uint
+		executionResultVersion        uint
+		reloadScanLimit               uint64
+		rebroadcastReceipts           bool
+		maxConcurrentExecutions       uint
 		checkStakedAtBlock            func(blockID flow.Identifier) (bool, error)
 		diskWAL                       *wal.DiskWAL
 		scriptLogThreshold            time.Duration
+		effortLogThreshold            uint64
 		chdpQueryTimeout              uint
 		chdpDeliveryTimeout           uint
+		chdpResponseCacheBudget       uint64
 		enableBlockDataUpload         bool
 		gcpBucketName                 string
 		s3BucketName                  string
 		blockDataUploaders            []uploader.Uploader
 		blockDataUploaderMaxRetry     uint64 = 5
 		blockdataUploaderRetryTimeout        = 1 * time.Second
+		auditConfig                          = audit.DefaultConfig()
+		auditOwners                   []string
+		auditor                       *audit.Auditor
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleExecution.String())
@@ -131,6 +147,7 @@ func main() {
 			flags.UintVar(&chdpCacheSize, "chdp-cache", storage.DefaultCacheSize, "cache size for Chunk Data Packs")
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.DurationVar(&scriptLogThreshold, "script-log-threshold", computation.DefaultScriptLogThreshold, "threshold for logging script execution")
+			flags.Uint64Var(&effortLogThreshold, "execution-effort-log-threshold", 0, "threshold of execution effort above which transactions are logged with their effort breakdown, 0 disables execution effort profiling")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.UintVar(&transactionResultsCacheSize, "transaction-results-cache-size", 10000, "number of transaction results to be cached")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "deprecated, sync by blocks instead of execution state deltas")
@@ -139,10 +156,18 @@ func main() {
 			flags.BoolVar(&extensiveLog, "extensive-logging", false, "extensive logging logs tx contents and block headers")
 			flags.UintVar(&chdpQueryTimeout, "chunk-data-pack-query-timeout-sec", 10, "number of seconds to determine a chunk data pack query being slow")
 			flags.UintVar(&chdpDeliveryTimeout, "chunk-data-pack-delivery-timeout-sec", 10, "number of seconds to determine a chunk data pack response delivery being slow")
+			flags.Uint64Var(&chdpResponseCacheBudget, "chunk-data-pack-response-cache-bytes", exeprovider.DefaultChunkDataPackCacheBudget, "total byte size of the chunk data packs cached for responding to verification nodes")
 			flags.BoolVar(&pauseExecution, "pause-execution", false, "pause the execution. when set to true, no block will be executed, but still be able to serve queries")
+			flags.Uint64Var(&reloadScanLimit, "reload-scan-limit", ingestion.DefaultReloadScanLimit, "maximum number of unexecuted finalized blocks scanned for the last executed block when reloading unexecuted blocks on startup")
+			flags.BoolVar(&rebroadcastReceipts, "rebroadcast-receipts", false, "re-broadcast the stored receipts of executed, but unsealed blocks, when skipping them on startup or receiving them again")
+			flags.UintVar(&maxConcurrentExecutions, "max-concurrent-block-executions", ingestion.DefaultMaxConcurrentBlockExecutions, "maximum number of blocks on different forks, which are executed concurrently")
+			flags.UintVar(&chunkBodyVersion, "chunk-body-version", uint(flow.ChunkBodyV0), "version of the chunk bodies of execution results, must be identical for all execution nodes (1 commits to the byte size of chunks)")
+			flags.UintVar(&executionResultVersion, "execution-result-version", uint(flow.ExecutionResultV0), "version of the execution results, must be identical for all execution nodes (1 commits to the execution data ID of blocks)")
 			flags.BoolVar(&enableBlockDataUpload, "enable-blockdata-upload", false, "enable uploading block data to Cloud Bucket")
 			flags.StringVar(&gcpBucketName, "gcp-bucket-name", "", "GCP Bucket name for block data uploader")
 			flags.StringVar(&s3BucketName, "s3-bucket-name", "", "S3 Bucket name for block data uploader")
+			flags.StringSliceVar(&auditOwners, "audit-register-owners", nil, "addresses of the accounts whose register accesses are recorded in the audit trail (audit mode is disabled if empty)")
+			flags.Uint64Var(&auditConfig.Retention, "audit-register-retention", auditConfig.Retention, "number of heights for which register audit records are kept (0 to keep all)")
 		}).
 		ValidateFlags(func() error {
 			if enableBlockDataUpload {
@@ -150,6 +175,19 @@ func main() {
 					return fmt.Errorf("invalid flag. gcp-bucket-name or s3-bucket-name required when blockdata-uploader is enabled")
 				}
 			}
+			if flow.ChunkBodyVersion(chunkBodyVersion) > flow.ChunkBodyV1 {
+				return fmt.Errorf("invalid flag. chunk-body-version must be at most %d", flow.ChunkBodyV1)
+			}
+			if flow.ExecutionResultVersion(executionResultVersion) > flow.ExecutionResultV1 {
+				return fmt.Errorf("invalid flag. execution-result-version must be at most %d", flow.ExecutionResultV1)
+			}
+			for _, owner := range auditOwners {
+				address, err := hex.DecodeString(strings.TrimPrefix(owner, "0x"))
+				if err != nil || len(address) != flow.AddressLength {
+					return fmt.Errorf("invalid flag. audit-register-owners contains an invalid address: %s", owner)
+				}
+				auditConfig.Owners = append(auditConfig.Owners, flow.BytesToAddress(address))
+			}
 			return nil
 		})
 
@@ -157,7 +195,16 @@ func main() {
 		nodeBuilder.Logger.Fatal().Err(err).Send()
 	}
 
+	readRegistersByOwner := executionCommands.NewReadRegistersByOwnerCommand()
+	triggerCheckpoint := executionCommands.NewTriggerCheckpointCommand()
+
 	nodeBuilder.
+		AdminCommand("read-registers-by-owner", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return readRegistersByOwner
+		}).
+		AdminCommand("trigger-checkpoint", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return triggerCheckpoint
+		}).
 		Module("mutable follower state", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -314,10 +361,15 @@ func main() {
 			if err != nil {
 				return nil, fmt.Errorf("cannot create checkpointer: %w", err)
 			}
-			compactor := wal.NewCompactor(checkpointer, 10*time.Second, checkpointDistance, checkpointsToKeep, node.Logger.With().Str("subcomponent", "checkpointer").Logger())
+			compactor := wal.NewCompactor(checkpointer, 10*time.Second, checkpointDistance, checkpointsToKeep, collector, node.Logger.With().Str("subcomponent", "checkpointer").Logger())
+			triggerCheckpoint.SetCompactor(compactor)
 
 			return compactor, nil
 		}).
+		Component("register auditor", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			auditor = audit.NewAuditor(node.Logger, storage.NewRegisterAuditRecords(node.DB), auditConfig)
+			return auditor, nil
+		}).
 		Component("provider engine", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			extraLogPath := path.Join(triedir, "extralogs")
 			err := os.MkdirAll(extraLogPath, 0777)
@@ -345,6 +397,8 @@ func main() {
 				committer,
 				scriptLogThreshold,
 				blockDataUploaders,
+				computer.WithRegisterAuditor(auditor),
+				computer.WithExecutionEffortLogThreshold(effortLogThreshold),
 			)
 			if err != nil {
 				return nil, err
@@ -375,6 +429,7 @@ func main() {
 				node.DB,
 				node.Tracer,
 			)
+			readRegistersByOwner.SetExecutionState(executionState)
 
 			providerEngine, err = exeprovider.New(
 				node.Logger,
@@ -387,10 +442,13 @@ func main() {
 				checkStakedAtBlock,
 				chdpQueryTimeout,
 				chdpDeliveryTimeout,
+				node.Metrics.Cache,
+				chdpResponseCacheBudget,
 			)
 			if err != nil {
 				return nil, err
 			}
+			node.ProtocolEvents.AddConsumer(providerEngine)
 
 			// Get latest executed block and a view at that block
 			ctx := context.Background()
@@ -494,6 +552,12 @@ func main() {
 				syncFast,
 				checkStakedAtBlock,
 				pauseExecution,
+				flow.ChunkBodyVersion(chunkBodyVersion),
+				flow.ExecutionResultVersion(executionResultVersion),
+				myReceipts,
+				reloadScanLimit,
+				rebroadcastReceipts,
+				maxConcurrentExecutions,
 			)
 
 			// TODO: we should solve these mutual dependencies better
@@ -550,6 +614,7 @@ func main() {
 				node.Me,
 				node.Metrics.Engine,
 				node.Metrics.Mempool,
+				node.Metrics.Cache,
 				cleaner,
 				node.Storage.Headers,
 				node.Storage.Payloads,
@@ -17,11 +17,14 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/id"
+	"github.com/onflow/flow-go/module/misbehavior"
+	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/p2p"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/events"
 	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 const NotSet = "not set"
@@ -130,6 +133,8 @@ type BaseConfig struct {
 	db                              *badger.DB
 	PreferredUnicastProtocols       []string
 	NetworkReceivedMessageCacheSize int
+	verifyFingerprints              bool
+	observableApprovals             bool
 }
 
 // NodeConfig contains all the derived parameters such the NodeID, private keys etc. and initialized instances of
@@ -139,6 +144,7 @@ type NodeConfig struct {
 	Cancel context.CancelFunc // cancel function for the context that is passed to the networking layer
 	BaseConfig
 	Logger            zerolog.Logger
+	LoggerRegistry    *logging.Registry
 	NodeID            flow.Identifier
 	Me                module.Local
 	Tracer            module.Tracer
@@ -152,6 +158,8 @@ type NodeConfig struct {
 	Middleware        network.Middleware
 	Network           network.Network
 	MsgValidators     []network.MessageValidator
+	Misbehavior       *misbehavior.Pipeline
+	ConfigManager     *updatable_configs.Manager
 	FvmOptions        []fvm.Option
 	StakingKey        crypto.PrivateKey
 	NetworkKey        crypto.PrivateKey
@@ -200,5 +208,7 @@ func DefaultBaseConfig() *BaseConfig {
 		receiptsCacheSize:               bstorage.DefaultCacheSize,
 		guaranteesCacheSize:             bstorage.DefaultCacheSize,
 		NetworkReceivedMessageCacheSize: p2p.DefaultCacheSize,
+		verifyFingerprints:              true,
+		observableApprovals:             false,
 	}
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -27,6 +28,7 @@ import (
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd/build"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/flow"
@@ -38,10 +40,13 @@ import (
 	"github.com/onflow/flow-go/module/lifecycle"
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
 	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/network"
 	cborcodec "github.com/onflow/flow-go/network/codec/cbor"
 	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/p2p/dns"
 	"github.com/onflow/flow-go/network/p2p/unicast"
 	"github.com/onflow/flow-go/network/topology"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
@@ -64,6 +69,7 @@ type Metrics struct {
 	Cache          module.CacheMetrics
 	Mempool        module.MempoolMetrics
 	CleanCollector module.CleanerMetrics
+	Misbehavior    module.MisbehaviorMetrics
 }
 
 type Storage = storage.All
@@ -121,6 +127,8 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.DurationVar(&fnb.BaseConfig.UnicastMessageTimeout, "unicast-timeout", defaultConfig.UnicastMessageTimeout, "how long a unicast transmission can take to complete")
 	fnb.flags.UintVarP(&fnb.BaseConfig.metricsPort, "metricport", "m", defaultConfig.metricsPort, "port for /metrics endpoint")
 	fnb.flags.BoolVar(&fnb.BaseConfig.profilerEnabled, "profiler-enabled", defaultConfig.profilerEnabled, "whether to enable the auto-profiler")
+	fnb.flags.BoolVar(&fnb.BaseConfig.verifyFingerprints, "verify-entity-fingerprints", defaultConfig.verifyFingerprints, "whether to verify at startup that the entity encodings match the golden fingerprints")
+	fnb.flags.BoolVar(&fnb.BaseConfig.observableApprovals, "observable-approvals", defaultConfig.observableApprovals, "whether access nodes may subscribe to result approvals (must be set on all nodes of the network)")
 	fnb.flags.StringVar(&fnb.BaseConfig.profilerDir, "profiler-dir", defaultConfig.profilerDir, "directory to create auto-profiler profiles")
 	fnb.flags.DurationVar(&fnb.BaseConfig.profilerInterval, "profiler-interval", defaultConfig.profilerInterval,
 		"the interval between auto-profiler runs")
@@ -189,6 +197,23 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 			}
 		}
 
+		resolver := dns.NewResolver(fnb.Metrics.Network, dns.WithTTL(fnb.BaseConfig.DNSCacheTTL))
+		err := fnb.ConfigManager.RegisterDurationConfig("network.dns-cache-ttl",
+			resolver.TTL,
+			func(ttl time.Duration) error {
+				resolver.SetTTL(ttl)
+				return nil
+			},
+			func(ttl time.Duration) error {
+				if ttl <= 0 {
+					return fmt.Errorf("dns cache ttl must be positive")
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, fmt.Errorf("could not register dns cache ttl config: %w", err)
+		}
+
 		libP2PNodeFactory, err := p2p.DefaultLibP2PNodeFactory(
 			fnb.Logger,
 			fnb.Me.NodeID(),
@@ -199,7 +224,7 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 			p2p.DefaultMaxPubSubMsgSize,
 			fnb.Metrics.Network,
 			pingProvider,
-			fnb.BaseConfig.DNSCacheTTL,
+			resolver,
 			fnb.BaseConfig.NodeRole)
 
 		if err != nil {
@@ -251,6 +276,7 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 			subscriptionManager,
 			fnb.Metrics.Network,
 			fnb.IdentityProvider,
+			p2p.WithMisbehaviorReporter(fnb.Misbehavior),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("could not initialize network: %w", err)
@@ -382,8 +408,9 @@ func (fnb *FlowNodeBuilder) initLogger() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("invalid log level")
 	}
-	log = log.Level(zerolog.DebugLevel)
-	zerolog.SetGlobalLevel(lvl)
+	// the registry filters by level, so that levels can be changed at runtime per component
+	fnb.LoggerRegistry = logging.NewRegistry(lvl)
+	log = fnb.LoggerRegistry.Root(log.Level(zerolog.TraceLevel))
 
 	fnb.Logger = log
 }
@@ -409,6 +436,7 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 		Cache:          metrics.NewNoopCollector(),
 		Mempool:        metrics.NewNoopCollector(),
 		CleanCollector: metrics.NewNoopCollector(),
+		Misbehavior:    metrics.NewNoopCollector(),
 	}
 	if fnb.BaseConfig.metricsEnabled {
 		fnb.MetricsRegisterer = prometheus.DefaultRegisterer
@@ -424,6 +452,7 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 			Cache:          metrics.NewNoopCollector(),
 			CleanCollector: metrics.NewCleanerCollector(),
 			Mempool:        mempools,
+			Misbehavior:    metrics.NewMisbehaviorCollector(),
 		}
 
 		// registers mempools as a Component so that its Ready method is invoked upon startup
@@ -433,6 +462,17 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 	}
 }
 
+// verifyEntityFingerprints checks that the canonical encodings of the entities match the golden
+// fingerprints compiled into the node software, as a node computing different entity IDs would
+// fork from the network.
+func (fnb *FlowNodeBuilder) verifyEntityFingerprints() {
+	if !fnb.BaseConfig.verifyFingerprints {
+		return
+	}
+	err := flow.VerifyFingerprintCompatibility()
+	fnb.MustNot(err).Uint("encoding_version", flow.EncodingVersion).Msg("entity encodings are incompatible with the golden fingerprints")
+}
+
 func (fnb *FlowNodeBuilder) initProfiler() {
 	if !fnb.BaseConfig.profilerEnabled {
 		return
@@ -543,24 +583,43 @@ func (fnb *FlowNodeBuilder) initStorage() {
 	setups := bstorage.NewEpochSetups(fnb.Metrics.Cache, fnb.DB)
 	commits := bstorage.NewEpochCommits(fnb.Metrics.Cache, fnb.DB)
 	statuses := bstorage.NewEpochStatuses(fnb.Metrics.Cache, fnb.DB)
+	misbehaviorReports := bstorage.NewMisbehaviorReports(fnb.DB)
+	configOverrides := bstorage.NewConfigOverrides(fnb.DB)
 
 	fnb.Storage = Storage{
-		Headers:      headers,
-		Guarantees:   guarantees,
-		Receipts:     receipts,
-		Results:      results,
-		Seals:        seals,
-		Index:        index,
-		Payloads:     payloads,
-		Blocks:       blocks,
-		Transactions: transactions,
-		Collections:  collections,
-		Setups:       setups,
-		EpochCommits: commits,
-		Statuses:     statuses,
+		Headers:            headers,
+		Guarantees:         guarantees,
+		Receipts:           receipts,
+		Results:            results,
+		Seals:              seals,
+		Index:              index,
+		Payloads:           payloads,
+		Blocks:             blocks,
+		Transactions:       transactions,
+		Collections:        collections,
+		Setups:             setups,
+		EpochCommits:       commits,
+		Statuses:           statuses,
+		MisbehaviorReports: misbehaviorReports,
+		ConfigOverrides:    configOverrides,
 	}
 }
 
+func (fnb *FlowNodeBuilder) initConfigManager() {
+	configManager, err := updatable_configs.NewManager(fnb.Logger, fnb.Storage.ConfigOverrides)
+	fnb.MustNot(err).Msg("could not initialize config manager")
+	fnb.ConfigManager = configManager
+}
+
+func (fnb *FlowNodeBuilder) initMisbehaviorPipeline() {
+	fnb.Misbehavior = misbehavior.NewPipeline(fnb.Logger, fnb.Metrics.Misbehavior, fnb.Storage.MisbehaviorReports, misbehavior.DefaultConfig())
+
+	// registers the pipeline as a Component so that reports beyond the retention are pruned
+	fnb.Component("misbehavior pipeline", func(builder NodeBuilder, node *NodeConfig) (module.ReadyDoneAware, error) {
+		return node.Misbehavior, nil
+	})
+}
+
 func (fnb *FlowNodeBuilder) InitIDProviders() {
 	fnb.Module("id providers", func(builder NodeBuilder, node *NodeConfig) error {
 		idCache, err := p2p.NewProtocolStateIDCache(node.Logger, node.State, node.ProtocolEvents)
@@ -728,6 +787,8 @@ func (fnb *FlowNodeBuilder) initFvmOptions() {
 		fvm.WithChain(fnb.RootChainID.Chain()),
 		fvm.WithBlocks(blockFinder),
 		fvm.WithAccountStorageLimit(true),
+		fvm.WithMaxAccountKeyCount(fvm.DefaultMaxAccountKeyCount),
+		fvm.WithAccountKeyChecks(true),
 	}
 	if fnb.RootChainID == flow.Testnet || fnb.RootChainID == flow.Canary || fnb.RootChainID == flow.Mainnet {
 		vmOpts = append(vmOpts,
@@ -929,6 +990,11 @@ func (fnb *FlowNodeBuilder) Initialize() error {
 		return err
 	}
 
+	// the roles of the channels must be set before the network is initialized
+	if fnb.BaseConfig.observableApprovals {
+		engine.AllowAccessObservingApprovals()
+	}
+
 	// ID providers must be initialized before the network
 	fnb.InitIDProviders()
 
@@ -950,13 +1016,25 @@ func (fnb *FlowNodeBuilder) Initialize() error {
 
 func (fnb *FlowNodeBuilder) RegisterDefaultAdminCommands() {
 	fnb.AdminCommand("set-log-level", func(config *NodeConfig) commands.AdminCommand {
-		return &common.SetLogLevelCommand{}
+		return common.NewSetLogLevelCommand(config.LoggerRegistry)
+	}).AdminCommand("get-log-levels", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewGetLogLevelsCommand(config.LoggerRegistry)
 	}).AdminCommand("read-blocks", func(config *NodeConfig) commands.AdminCommand {
 		return storageCommands.NewReadBlocksCommand(config.State, config.Storage.Blocks)
 	}).AdminCommand("read-results", func(config *NodeConfig) commands.AdminCommand {
 		return storageCommands.NewReadResultsCommand(config.State, config.Storage.Results)
 	}).AdminCommand("read-seals", func(config *NodeConfig) commands.AdminCommand {
 		return storageCommands.NewReadSealsCommand(config.State, config.Storage.Seals, config.Storage.Index)
+	}).AdminCommand("read-misbehavior-reports", func(config *NodeConfig) commands.AdminCommand {
+		return storageCommands.NewReadMisbehaviorReportsCommand(config.Misbehavior)
+	}).AdminCommand("list-configs", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewListConfigCommand(config.ConfigManager)
+	}).AdminCommand("get-config", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewGetConfigCommand(config.ConfigManager)
+	}).AdminCommand("set-config", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewSetConfigCommand(config.ConfigManager)
+	}).AdminCommand("reset-config", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewResetConfigCommand(config.ConfigManager)
 	})
 }
 
@@ -1007,6 +1085,8 @@ func (fnb *FlowNodeBuilder) Ready() <-chan struct{} {
 
 		fnb.initLogger()
 
+		fnb.verifyEntityFingerprints()
+
 		fnb.initProfiler()
 
 		fnb.initDB()
@@ -1016,6 +1096,10 @@ func (fnb *FlowNodeBuilder) Ready() <-chan struct{} {
 
 		fnb.initStorage()
 
+		fnb.initConfigManager()
+
+		fnb.initMisbehaviorPipeline()
+
 		for _, f := range fnb.preInitFns {
 			fnb.handlePreInit(f)
 		}
@@ -1114,13 +1198,12 @@ func loadRootProtocolSnapshot(dir string) (*inmem.Snapshot, error) {
 		return nil, fmt.Errorf("could not read root snapshot (path=%s): %w", path, err)
 	}
 
-	var snapshot inmem.EncodableSnapshot
-	err = json.Unmarshal(data, &snapshot)
+	snapshot, err := inmem.DecodeSnapshot(bytes.NewReader(data))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not decode root snapshot (path=%s): %w", path, err)
 	}
 
-	return inmem.SnapshotFromEncodable(snapshot), nil
+	return snapshot, nil
 }
 
 // Loads the private info for this node from disk (eg. private staking/network keys).
@@ -10,9 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rs/zerolog"
+
 	"github.com/onflow/flow-go/cmd/bootstrap/utils"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -45,3 +49,20 @@ func TestLoadSecretsEncryptionKey(t *testing.T) {
 		})
 	})
 }
+
+// TestInitFvmOptions checks that the account key limit and checks, which change the results of
+// transactions, are configured for the chain rather than by the individual node.
+func TestInitFvmOptions(t *testing.T) {
+	for _, chainID := range []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Canary, flow.Benchnet, flow.Localnet} {
+		t.Run(chainID.String(), func(t *testing.T) {
+			fnb := &FlowNodeBuilder{NodeConfig: &NodeConfig{}}
+			fnb.RootChainID = chainID
+			fnb.initFvmOptions()
+
+			ctx := fvm.NewContext(zerolog.Nop(), fnb.FvmOptions...)
+			assert.Equal(t, chainID, ctx.Chain.ChainID())
+			assert.Equal(t, uint64(fvm.DefaultMaxAccountKeyCount), ctx.MaxAccountKeyCount)
+			assert.True(t, ctx.AccountKeyChecksEnabled)
+		})
+	}
+}
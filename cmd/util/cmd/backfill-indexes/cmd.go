@@ -0,0 +1,92 @@
+package backfill_indexes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/storage/badger/backfill"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var (
+	flagDatadir    string
+	flagFromHeight uint64
+	flagToHeight   uint64
+	flagIndexes    []string
+	flagBatchSize  uint
+)
+
+var Cmd = &cobra.Command{
+	Use:   "backfill-indexes",
+	Short: "Backfills indexes of the protocol state database for finalized blocks",
+	Long: `Backfills indexes of the protocol state database for finalized blocks.
+The node may keep running while the indexes are backfilled. An interrupted
+backfill resumes after the last indexed batch when it is restarted with the
+same height range.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().Uint64Var(&flagFromHeight, "from-height", 0,
+		"first height to backfill")
+	_ = Cmd.MarkFlagRequired("from-height")
+
+	Cmd.Flags().Uint64Var(&flagToHeight, "to-height", 0,
+		"last height to backfill, defaults to the latest finalized height")
+
+	Cmd.Flags().StringSliceVar(&flagIndexes, "indexes", backfill.IndexerNames(),
+		"indexes to backfill: "+strings.Join(backfill.IndexerNames(), ", "))
+
+	Cmd.Flags().UintVar(&flagBatchSize, "batch-size", backfill.DefaultBatchSize,
+		"number of blocks indexed within a single database transaction")
+}
+
+func run(*cobra.Command, []string) {
+	registry := backfill.Indexers()
+	var indexers []backfill.Indexer
+	for _, name := range flagIndexes {
+		indexer, ok := registry[name]
+		if !ok {
+			log.Fatal().Str("index", name).Msg("unknown index")
+		}
+		indexers = append(indexers, indexer)
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	toHeight := flagToHeight
+	if toHeight == 0 {
+		err := db.View(operation.RetrieveFinalizedHeight(&toHeight))
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not retrieve finalized height")
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	runner := backfill.NewRunner(log.Logger, db, flagBatchSize)
+	for _, indexer := range indexers {
+		_, err := runner.Run(ctx, indexer, flagFromHeight, toHeight)
+		if errors.Is(err, context.Canceled) {
+			log.Warn().Str("index", indexer.Name()).Msg("backfill interrupted, restart with the same height range to resume")
+			return
+		}
+		if err != nil {
+			log.Fatal().Err(err).Str("index", indexer.Name()).Msg("could not backfill index")
+		}
+	}
+}
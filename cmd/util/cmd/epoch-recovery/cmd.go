@@ -0,0 +1,138 @@
+package epoch_recovery
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	ioutils "github.com/onflow/flow-go/utils/io"
+)
+
+var (
+	flagSnapshot     string
+	flagFinalView    uint64
+	flagIdentities   string
+	flagNumClusters  uint
+	flagRandomSource string
+	flagOutput       string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "epoch-recovery",
+	Short: "Constructs and validates a recovery epoch setup following the current epoch of a snapshot",
+	Long: "Constructs a candidate recovery epoch setup, and the skeleton of its commit, following the current epoch of a snapshot " +
+		"and validates it against the current epoch. Writes a human-readable report to STDOUT and, if the setup is valid, " +
+		"the JSON encoded setup and commit service events to the output file.",
+	Run: run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagSnapshot, "snapshot", "",
+		"path to a serialized protocol snapshot (e.g. root-protocol-state-snapshot.json)")
+	_ = Cmd.MarkFlagRequired("snapshot")
+
+	Cmd.Flags().Uint64Var(&flagFinalView, "final-view", 0,
+		"final view of the recovery epoch")
+	_ = Cmd.MarkFlagRequired("final-view")
+
+	Cmd.Flags().StringVar(&flagIdentities, "identities", "",
+		"path to a JSON encoded identity list extending the identities of the current epoch")
+
+	Cmd.Flags().UintVar(&flagNumClusters, "collection-clusters", 0,
+		"number of collector clusters, defaults to the number of clusters of the current epoch")
+
+	Cmd.Flags().StringVar(&flagRandomSource, "random-source", "",
+		"hex encoded random source of the recovery epoch, defaults to a fresh random source")
+
+	Cmd.Flags().StringVarP(&flagOutput, "output", "o", "recovery-epoch.json",
+		"file to write the encoded service events to")
+}
+
+func run(*cobra.Command, []string) {
+
+	snapshot, err := readSnapshot(flagSnapshot)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read snapshot")
+	}
+
+	overrides := Overrides{
+		FinalView:   flagFinalView,
+		NumClusters: flagNumClusters,
+	}
+	if flagIdentities != "" {
+		overrides.Identities, err = readIdentities(flagIdentities)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not read identities")
+		}
+	}
+	if flagRandomSource != "" {
+		overrides.RandomSource, err = hex.DecodeString(flagRandomSource)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not decode random source")
+		}
+	}
+
+	recovery, err := Construct(snapshot, overrides)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not construct recovery epoch")
+	}
+
+	validationErr := Validate(recovery.Setup, recovery.Current)
+	err = recovery.WriteReport(os.Stdout, validationErr)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not write report")
+	}
+	if validationErr != nil {
+		log.Fatal().Err(validationErr).Msg("recovery epoch is invalid")
+	}
+
+	data, err := recovery.Encode()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not encode recovery epoch")
+	}
+	err = ioutils.WriteFile(flagOutput, data)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", flagOutput).Msg("could not write recovery epoch")
+	}
+	log.Info().Str("path", flagOutput).Msg("wrote recovery epoch service events")
+}
+
+// readSnapshot reads a JSON-encoded protocol snapshot from the given path.
+func readSnapshot(path string) (protocol.Snapshot, error) {
+	data, err := ioutils.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot (path=%s): %w", path, err)
+	}
+
+	var snapshot inmem.EncodableSnapshot
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode snapshot: %w", err)
+	}
+
+	return inmem.SnapshotFromEncodable(snapshot), nil
+}
+
+// readIdentities reads a JSON-encoded identity list from the given path.
+func readIdentities(path string) (flow.IdentityList, error) {
+	data, err := ioutils.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read identities (path=%s): %w", path, err)
+	}
+
+	var identities flow.IdentityList
+	err = json.Unmarshal(data, &identities)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode identities: %w", err)
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,188 @@
+package epoch_recovery
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// Overrides are the parameters of the recovery epoch which are not carried over
+// from the current epoch of the snapshot.
+type Overrides struct {
+	// FinalView is the final view of the recovery epoch.
+	FinalView uint64
+	// Identities extend the identity list of the current epoch. An identity with the
+	// node ID of a participant of the current epoch replaces that participant.
+	Identities flow.IdentityList
+	// NumClusters is the number of collector clusters. If zero, the number of clusters
+	// of the current epoch is used.
+	NumClusters uint
+	// RandomSource is the source of randomness of the recovery epoch. If nil, a fresh
+	// random source is generated.
+	RandomSource []byte
+}
+
+// Recovery is a candidate recovery epoch, constructed from the current epoch of a snapshot.
+type Recovery struct {
+	// Current is the setup of the current epoch, which the recovery epoch follows.
+	Current *flow.EpochSetup
+	// Setup is the candidate setup of the recovery epoch.
+	Setup *flow.EpochSetup
+	// Commit is the skeleton of the commit of the recovery epoch. It contains one cluster
+	// QC per cluster, listing the cluster members as voters, while the QC signatures and
+	// the DKG keys have to be filled in before submission.
+	Commit *flow.EpochCommit
+}
+
+// Construct constructs a candidate recovery epoch following the current epoch of the given
+// snapshot. The recovery epoch starts right after the final view of the current epoch, keeps
+// the DKG phase lengths of the current epoch and assigns the collectors to the given number
+// of clusters in canonical order.
+//
+// The candidate is not validated, Validate must be used to check it before submission.
+func Construct(snapshot protocol.Snapshot, overrides Overrides) (*Recovery, error) {
+
+	current, err := currentEpochSetup(snapshot.Epochs().Current())
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch: %w", err)
+	}
+
+	randomSource := overrides.RandomSource
+	if randomSource == nil {
+		randomSource = make([]byte, flow.EpochSetupRandomSourceLength)
+		_, err = rand.Read(randomSource)
+		if err != nil {
+			return nil, fmt.Errorf("could not generate random source: %w", err)
+		}
+	}
+
+	numClusters := overrides.NumClusters
+	if numClusters == 0 {
+		numClusters = uint(len(current.Assignments))
+	}
+
+	participants := extendIdentities(current.Participants, overrides.Identities)
+	assignments := assignClusters(numClusters, participants)
+
+	// the recovery epoch starts right after the current epoch, with the same DKG phase lengths
+	firstView := current.FinalView + 1
+	setup := &flow.EpochSetup{
+		Counter:            current.Counter + 1,
+		FirstView:          firstView,
+		DKGPhase1FinalView: firstView + (current.DKGPhase1FinalView - current.FirstView),
+		DKGPhase2FinalView: firstView + (current.DKGPhase2FinalView - current.FirstView),
+		DKGPhase3FinalView: firstView + (current.DKGPhase3FinalView - current.FirstView),
+		FinalView:          overrides.FinalView,
+		Participants:       participants,
+		Assignments:        assignments,
+		RandomSource:       randomSource,
+	}
+
+	clusterQCs := make([]flow.ClusterQCVoteData, 0, len(assignments))
+	for _, assignment := range assignments {
+		clusterQCs = append(clusterQCs, flow.ClusterQCVoteData{
+			VoterIDs: assignment,
+		})
+	}
+	commit := &flow.EpochCommit{
+		Counter:    setup.Counter,
+		ClusterQCs: clusterQCs,
+	}
+
+	return &Recovery{
+		Current: current,
+		Setup:   setup,
+		Commit:  commit,
+	}, nil
+}
+
+// currentEpochSetup reconstructs the setup of the given epoch.
+func currentEpochSetup(epoch protocol.Epoch) (*flow.EpochSetup, error) {
+	var (
+		setup flow.EpochSetup
+		err   error
+	)
+
+	setup.Counter, err = epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get counter: %w", err)
+	}
+	setup.FirstView, err = epoch.FirstView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get first view: %w", err)
+	}
+	setup.DKGPhase1FinalView, err = epoch.DKGPhase1FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg phase 1 final view: %w", err)
+	}
+	setup.DKGPhase2FinalView, err = epoch.DKGPhase2FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg phase 2 final view: %w", err)
+	}
+	setup.DKGPhase3FinalView, err = epoch.DKGPhase3FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg phase 3 final view: %w", err)
+	}
+	setup.FinalView, err = epoch.FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get final view: %w", err)
+	}
+	setup.Participants, err = epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get initial identities: %w", err)
+	}
+	clustering, err := epoch.Clustering()
+	if err != nil {
+		return nil, fmt.Errorf("could not get clustering: %w", err)
+	}
+	setup.Assignments = clustering.Assignments()
+	setup.RandomSource, err = epoch.RandomSource()
+	if err != nil {
+		return nil, fmt.Errorf("could not get random source: %w", err)
+	}
+
+	return &setup, nil
+}
+
+// extendIdentities returns the canonically ordered union of the given identity lists, where
+// the identities of the extension replace identities of the base with the same node ID.
+func extendIdentities(base flow.IdentityList, extension flow.IdentityList) flow.IdentityList {
+	replaced := make(map[flow.Identifier]struct{}, len(extension))
+	for _, identity := range extension {
+		replaced[identity.NodeID] = struct{}{}
+	}
+
+	identities := make(flow.IdentityList, 0, len(base)+len(extension))
+	for _, identity := range base {
+		if _, ok := replaced[identity.NodeID]; !ok {
+			identities = append(identities, identity)
+		}
+	}
+	identities = append(identities, extension...)
+
+	return identities.Sort(order.Canonical)
+}
+
+// assignClusters distributes the staked collectors of the given canonically ordered
+// participants round-robin across the given number of clusters.
+func assignClusters(numClusters uint, participants flow.IdentityList) flow.AssignmentList {
+	collectors := participants.Filter(filter.And(filter.HasRole(flow.RoleCollection), filter.HasStake(true)))
+
+	assignments := make(flow.AssignmentList, numClusters)
+	for i := range assignments {
+		assignments[i] = make([]flow.Identifier, 0)
+	}
+	if numClusters == 0 {
+		return assignments
+	}
+	for i, collector := range collectors {
+		index := uint(i) % numClusters
+		assignments[index] = append(assignments[index], collector.NodeID)
+	}
+
+	return assignments
+}
@@ -0,0 +1,170 @@
+package epoch_recovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// recoveryFixture constructs a valid recovery epoch following the root epoch of a snapshot, which
+// is extended by an additional collector.
+func recoveryFixture(t *testing.T) *Recovery {
+	participants := unittest.IdentityListFixture(8, unittest.WithAllRoles(), unittest.WithKeys)
+	snapshot := unittest.RootSnapshotFixture(participants)
+
+	finalView, err := snapshot.Epochs().Current().FinalView()
+	require.NoError(t, err)
+
+	recovery, err := Construct(snapshot, Overrides{
+		FinalView:   finalView + 10_000,
+		Identities:  unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleCollection), unittest.WithKeys),
+		NumClusters: 2,
+	})
+	require.NoError(t, err)
+	return recovery
+}
+
+// assertViolations asserts that the validation error reports violations of exactly the given constraints.
+func assertViolations(t *testing.T, err error, expected ...Constraint) {
+	require.Error(t, err)
+	violated := make(map[Constraint]struct{})
+	for _, violation := range Violations(err) {
+		violated[violation.Constraint] = struct{}{}
+	}
+	require.Len(t, violated, len(expected), err.Error())
+	for _, constraint := range expected {
+		assert.Contains(t, violated, constraint, err.Error())
+	}
+}
+
+func TestConstruct(t *testing.T) {
+	recovery := recoveryFixture(t)
+	current, setup := recovery.Current, recovery.Setup
+
+	assert.Equal(t, current.Counter+1, setup.Counter)
+	assert.Equal(t, current.FinalView+1, setup.FirstView)
+	assert.Equal(t, current.DKGPhase1FinalView-current.FirstView, setup.DKGPhase1FinalView-setup.FirstView)
+	assert.Len(t, setup.RandomSource, flow.EpochSetupRandomSourceLength)
+	assert.Len(t, setup.Participants, len(current.Participants)+1)
+	assert.True(t, setup.Participants.Sorted(order.Canonical))
+	assert.Len(t, setup.Assignments, 2)
+
+	// the commit skeleton lists the cluster members as voters of the cluster QCs
+	require.Len(t, recovery.Commit.ClusterQCs, 2)
+	for i, qc := range recovery.Commit.ClusterQCs {
+		assert.Equal(t, setup.Assignments[i], qc.VoterIDs)
+	}
+	assert.Equal(t, setup.Counter, recovery.Commit.Counter)
+}
+
+func TestValidate(t *testing.T) {
+
+	t.Run("valid recovery setup", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		assert.NoError(t, Validate(recovery.Setup, recovery.Current))
+	})
+
+	t.Run("counter gap", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.Counter++
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintCounterContinuity)
+	})
+
+	t.Run("overlapping views", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.FirstView = recovery.Current.FinalView
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintViewRange)
+	})
+
+	t.Run("final view before dkg phases", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.FinalView = recovery.Setup.DKGPhase3FinalView
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintViewRange)
+	})
+
+	t.Run("duplicate identity", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		var consensus *flow.Identity
+		for _, participant := range recovery.Setup.Participants {
+			if participant.Role == flow.RoleConsensus {
+				consensus = participant
+			}
+		}
+		recovery.Setup.Participants = append(recovery.Setup.Participants, consensus).Sort(order.Canonical)
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintIdentityValidity)
+	})
+
+	t.Run("invalid identity", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.Participants[0].NetworkPubKey = nil
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintIdentityValidity)
+	})
+
+	t.Run("empty cluster", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.Assignments = append(recovery.Setup.Assignments, []flow.Identifier{})
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintClusterAssignment)
+	})
+
+	t.Run("more clusters than collectors", func(t *testing.T) {
+		participants := unittest.IdentityListFixture(8, unittest.WithAllRoles(), unittest.WithKeys)
+		snapshot := unittest.RootSnapshotFixture(participants)
+		finalView, err := snapshot.Epochs().Current().FinalView()
+		require.NoError(t, err)
+
+		recovery, err := Construct(snapshot, Overrides{
+			FinalView:   finalView + 10_000,
+			NumClusters: uint(len(participants)),
+		})
+		require.NoError(t, err)
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintClusterAssignment)
+	})
+
+	t.Run("unassigned collector", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.Assignments[0] = recovery.Setup.Assignments[0][1:]
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintClusterAssignment)
+	})
+
+	t.Run("multiple violations", func(t *testing.T) {
+		recovery := recoveryFixture(t)
+		recovery.Setup.Counter++
+		recovery.Setup.RandomSource = nil
+		assertViolations(t, Validate(recovery.Setup, recovery.Current), ConstraintCounterContinuity, ConstraintRandomSource)
+	})
+}
+
+func TestReport(t *testing.T) {
+	recovery := recoveryFixture(t)
+
+	t.Run("encoding", func(t *testing.T) {
+		data, err := recovery.Encode()
+		require.NoError(t, err)
+
+		var events []flow.ServiceEvent
+		require.NoError(t, json.Unmarshal(data, &events))
+		require.Len(t, events, 2)
+		assert.Equal(t, flow.ServiceEventSetup, events[0].Type)
+		assert.Equal(t, flow.ServiceEventCommit, events[1].Type)
+
+		setup, ok := events[0].Event.(*flow.EpochSetup)
+		require.True(t, ok)
+		assert.Equal(t, recovery.Setup.Counter, setup.Counter)
+		assert.Equal(t, recovery.Setup.Assignments, setup.Assignments)
+	})
+
+	t.Run("report names violated constraints", func(t *testing.T) {
+		recovery.Setup.Counter++
+		var buf bytes.Buffer
+		require.NoError(t, recovery.WriteReport(&buf, Validate(recovery.Setup, recovery.Current)))
+		assert.Contains(t, buf.String(), "validation: failed with 1 violations")
+		assert.Contains(t, buf.String(), string(ConstraintCounterContinuity))
+	})
+}
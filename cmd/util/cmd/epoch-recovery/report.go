@@ -0,0 +1,72 @@
+package epoch_recovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Encode returns the canonical JSON encoding of the recovery epoch, as the list of its setup and
+// commit service events, which can be decoded with flow.ServiceEvent.
+func (r *Recovery) Encode() ([]byte, error) {
+	events := flow.ServiceEventList{
+		r.Setup.ServiceEvent(),
+		r.Commit.ServiceEvent(),
+	}
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not encode recovery epoch: %w", err)
+	}
+	return data, nil
+}
+
+// WriteReport writes a human-readable report of the recovery epoch and the constraint violations
+// contained in the given validation error to w.
+func (r *Recovery) WriteReport(w io.Writer, validationErr error) error {
+	current, setup := r.Current, r.Setup
+
+	lines := []string{
+		fmt.Sprintf("current epoch:  counter %d, views [%d, %d]", current.Counter, current.FirstView, current.FinalView),
+		fmt.Sprintf("recovery epoch: counter %d, views [%d, %d]", setup.Counter, setup.FirstView, setup.FinalView),
+		fmt.Sprintf("  dkg phase final views: %d, %d, %d", setup.DKGPhase1FinalView, setup.DKGPhase2FinalView, setup.DKGPhase3FinalView),
+		fmt.Sprintf("  random source: %x", setup.RandomSource),
+		fmt.Sprintf("  participants: %d (%d in current epoch)", len(setup.Participants), len(current.Participants)),
+	}
+	for _, role := range flow.Roles() {
+		lines = append(lines, fmt.Sprintf("    %s: %d", role, countRole(setup.Participants, role)))
+	}
+	lines = append(lines, fmt.Sprintf("  clusters: %d (%d in current epoch)", len(setup.Assignments), len(current.Assignments)))
+	for i, assignment := range setup.Assignments {
+		lines = append(lines, fmt.Sprintf("    cluster %d: %d collectors", i, len(assignment)))
+	}
+
+	violations := Violations(validationErr)
+	if len(violations) == 0 {
+		lines = append(lines, "validation: passed")
+	} else {
+		lines = append(lines, fmt.Sprintf("validation: failed with %d violations", len(violations)))
+		for _, violation := range violations {
+			lines = append(lines, fmt.Sprintf("  [%s] %v", violation.Constraint, violation.Err))
+		}
+	}
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return fmt.Errorf("could not write report: %w", err)
+		}
+	}
+	return nil
+}
+
+func countRole(identities flow.IdentityList, role flow.Role) int {
+	count := 0
+	for _, identity := range identities {
+		if identity.Role == role {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,187 @@
+package epoch_recovery
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+)
+
+// Constraint names a constraint which a recovery epoch setup must satisfy.
+type Constraint string
+
+const (
+	// ConstraintCounterContinuity requires the counter of the recovery epoch to follow the current epoch.
+	ConstraintCounterContinuity Constraint = "counter-continuity"
+	// ConstraintViewRange requires the views of the recovery epoch to start right after the current
+	// epoch, without overlapping it, and the DKG phases to be ordered within the recovery epoch.
+	ConstraintViewRange Constraint = "view-range"
+	// ConstraintRandomSource requires the random source to have the expected length.
+	ConstraintRandomSource Constraint = "random-source"
+	// ConstraintIdentityValidity requires every participant to be a valid, unique identity, the
+	// participants to be canonically ordered and every role to be represented.
+	ConstraintIdentityValidity Constraint = "identity-validity"
+	// ConstraintClusterAssignment requires a non-empty cluster assignment in which every staked
+	// collector is assigned to exactly one cluster and no cluster is empty.
+	ConstraintClusterAssignment Constraint = "cluster-assignment"
+)
+
+// ConstraintError is returned if a recovery epoch setup violates a constraint.
+type ConstraintError struct {
+	Constraint Constraint
+	Err        error
+}
+
+func (e ConstraintError) Error() string {
+	return fmt.Sprintf("constraint %s violated: %v", e.Constraint, e.Err)
+}
+
+func (e ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// Violations returns the constraint errors contained in the given validation error.
+func Violations(err error) []ConstraintError {
+	var violations []ConstraintError
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, err := range merr.Errors {
+			violations = append(violations, Violations(err)...)
+		}
+		return violations
+	}
+	var violation ConstraintError
+	if errors.As(err, &violation) {
+		violations = append(violations, violation)
+	}
+	return violations
+}
+
+// Validate validates the recovery epoch setup against the setup of the current epoch, which it
+// must follow. Each violated constraint is reported by a ConstraintError, all violations are
+// aggregated in the returned error, which is nil if the setup is valid.
+func Validate(setup *flow.EpochSetup, current *flow.EpochSetup) error {
+	var merr *multierror.Error
+	violate := func(constraint Constraint, msg string, args ...interface{}) {
+		merr = multierror.Append(merr, ConstraintError{
+			Constraint: constraint,
+			Err:        fmt.Errorf(msg, args...),
+		})
+	}
+
+	if setup.Counter != current.Counter+1 {
+		violate(ConstraintCounterContinuity, "recovery epoch counter must be exactly 1 more than the current epoch counter (%d != %d+1)", setup.Counter, current.Counter)
+	}
+
+	if setup.FirstView != current.FinalView+1 {
+		violate(ConstraintViewRange, "recovery epoch first view must be exactly 1 more than the current epoch final view (%d != %d+1)", setup.FirstView, current.FinalView)
+	}
+	if !(setup.FirstView < setup.DKGPhase1FinalView &&
+		setup.DKGPhase1FinalView < setup.DKGPhase2FinalView &&
+		setup.DKGPhase2FinalView < setup.DKGPhase3FinalView &&
+		setup.DKGPhase3FinalView < setup.FinalView) {
+		violate(ConstraintViewRange, "views must be strictly increasing (first view %d, dkg phase final views %d, %d, %d, final view %d)",
+			setup.FirstView, setup.DKGPhase1FinalView, setup.DKGPhase2FinalView, setup.DKGPhase3FinalView, setup.FinalView)
+	}
+
+	if len(setup.RandomSource) != flow.EpochSetupRandomSourceLength {
+		violate(ConstraintRandomSource, "random source has incorrect length (%d != %d)", len(setup.RandomSource), flow.EpochSetupRandomSourceLength)
+	}
+
+	for _, err := range validateParticipants(setup.Participants) {
+		violate(ConstraintIdentityValidity, "%v", err)
+	}
+
+	for _, err := range validateAssignments(setup.Assignments, setup.Participants) {
+		violate(ConstraintClusterAssignment, "%v", err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// validateParticipants returns the reasons why the given participants are invalid.
+func validateParticipants(participants flow.IdentityList) []error {
+	var errs []error
+
+	nodeIDs := make(map[flow.Identifier]struct{}, len(participants))
+	addresses := make(map[string]struct{}, len(participants))
+	roles := make(map[flow.Role]uint)
+	for _, participant := range participants {
+		err := validateIdentity(participant)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid identity (%x): %w", participant.NodeID, err))
+		}
+		if _, ok := nodeIDs[participant.NodeID]; ok {
+			errs = append(errs, fmt.Errorf("duplicate node identifier (%x)", participant.NodeID))
+		}
+		nodeIDs[participant.NodeID] = struct{}{}
+		if _, ok := addresses[participant.Address]; ok {
+			errs = append(errs, fmt.Errorf("duplicate node address (%s)", participant.Address))
+		}
+		addresses[participant.Address] = struct{}{}
+		if participant.Stake > 0 {
+			roles[participant.Role]++
+		}
+	}
+
+	if !participants.Sorted(order.Canonical) {
+		errs = append(errs, fmt.Errorf("participants are not canonically ordered"))
+	}
+
+	for _, role := range flow.Roles() {
+		if roles[role] < 1 {
+			errs = append(errs, fmt.Errorf("need at least one staked %s node", role))
+		}
+	}
+
+	return errs
+}
+
+// validateIdentity checks that the identity is complete and may participate in the recovery epoch.
+func validateIdentity(identity *flow.Identity) error {
+	if identity.NodeID == flow.ZeroID {
+		return fmt.Errorf("zero node identifier")
+	}
+	if !identity.Role.Valid() {
+		return fmt.Errorf("invalid role (%d)", identity.Role)
+	}
+	if identity.Address == "" {
+		return fmt.Errorf("empty address")
+	}
+	if identity.StakingPubKey == nil {
+		return fmt.Errorf("missing staking key")
+	}
+	if identity.NetworkPubKey == nil {
+		return fmt.Errorf("missing networking key")
+	}
+	if identity.Ejected {
+		return fmt.Errorf("ejected node")
+	}
+	return nil
+}
+
+// validateAssignments returns the reasons why the given cluster assignment of the given participants is invalid.
+func validateAssignments(assignments flow.AssignmentList, participants flow.IdentityList) []error {
+	if len(assignments) == 0 {
+		return []error{fmt.Errorf("need at least one collection cluster")}
+	}
+
+	var errs []error
+	for i, assignment := range assignments {
+		if len(assignment) == 0 {
+			errs = append(errs, fmt.Errorf("cluster %d is empty", i))
+		}
+	}
+
+	collectors := participants.Filter(filter.And(filter.HasRole(flow.RoleCollection), filter.HasStake(true)))
+	_, err := flow.NewClusterList(assignments, collectors)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("assignments do not match the staked collectors: %w", err))
+	}
+
+	return errs
+}
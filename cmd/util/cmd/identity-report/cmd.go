@@ -0,0 +1,147 @@
+package identity_report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	ioutils "github.com/onflow/flow-go/utils/io"
+)
+
+var (
+	flagSnapshot     string
+	flagDatadir      string
+	flagHeight       uint64
+	flagFinal        bool
+	flagSealed       bool
+	flagTopOperators uint
+	flagFormat       string
+	flagOutput       string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "identity-report",
+	Short: "Reports the stake distribution and role composition of the identity table at a block",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagSnapshot, "snapshot", "",
+		"path to a serialized protocol snapshot (e.g. root-protocol-state-snapshot.json), used instead of the protocol database")
+
+	Cmd.Flags().StringVarP(&flagDatadir, "datadir", "d", "",
+		"directory to the badger database of the protocol state")
+
+	Cmd.Flags().Uint64Var(&flagHeight, "height", 0,
+		"block height to report on, when reading from the protocol database")
+
+	Cmd.Flags().BoolVar(&flagFinal, "final", false,
+		"report on the latest finalized block, when reading from the protocol database")
+
+	Cmd.Flags().BoolVar(&flagSealed, "sealed", false,
+		"report on the latest sealed block, when reading from the protocol database")
+
+	Cmd.Flags().UintVar(&flagTopOperators, "top-operators", DefaultTopOperators,
+		"number of operators with the highest stake to include")
+
+	Cmd.Flags().StringVar(&flagFormat, "format", "json",
+		"output format (json, csv)")
+
+	Cmd.Flags().StringVarP(&flagOutput, "output", "o", "",
+		"file to write the report to, defaults to stdout")
+}
+
+func run(*cobra.Command, []string) {
+
+	if flagFormat != "json" && flagFormat != "csv" {
+		log.Fatal().Str("format", flagFormat).Msg("unsupported output format")
+	}
+
+	var report *IdentityReport
+	var err error
+	if flagSnapshot != "" {
+		snapshot, err := readSnapshot(flagSnapshot)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not read snapshot")
+		}
+		report, err = ReportIdentityTable(snapshot, WithTopOperators(flagTopOperators))
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not report identity table")
+		}
+	} else {
+		if flagDatadir == "" {
+			log.Fatal().Msg("either --snapshot or --datadir must be specified")
+		}
+
+		db := common.InitStorage(flagDatadir)
+		defer db.Close()
+
+		storages := common.InitStorages(db)
+		state, err := common.InitProtocolState(db, storages)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not init protocol state")
+		}
+
+		report, err = ReportIdentityTable(selectSnapshot(state), WithTopOperators(flagTopOperators))
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not report identity table")
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if flagOutput != "" {
+		file, err := os.Create(flagOutput)
+		if err != nil {
+			log.Fatal().Err(err).Str("path", flagOutput).Msg("could not create output file")
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if flagFormat == "csv" {
+		err = report.WriteCSV(out)
+	} else {
+		err = report.WriteJSON(out)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not write report")
+	}
+}
+
+// selectSnapshot returns the snapshot of the protocol state selected by the flags.
+func selectSnapshot(state protocol.State) protocol.Snapshot {
+	if flagFinal {
+		log.Info().Msg("reporting on the latest finalized block")
+		return state.Final()
+	}
+	if flagSealed {
+		log.Info().Msg("reporting on the latest sealed block")
+		return state.Sealed()
+	}
+	log.Info().Uint64("height", flagHeight).Msg("reporting on the block at height")
+	return state.AtHeight(flagHeight)
+}
+
+// readSnapshot reads a JSON-encoded protocol snapshot from the given path.
+func readSnapshot(path string) (protocol.Snapshot, error) {
+	data, err := ioutils.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot (path=%s): %w", path, err)
+	}
+
+	var snapshot inmem.EncodableSnapshot
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode snapshot: %w", err)
+	}
+
+	return inmem.SnapshotFromEncodable(snapshot), nil
+}
@@ -0,0 +1,278 @@
+package identity_report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// DefaultTopOperators is the number of operators included in a report by default.
+const DefaultTopOperators = 10
+
+// IdentityReport summarizes the stake distribution and the role composition of the
+// identity table at a given block.
+//
+// Ejected identities are counted, but their stake is excluded from all stake totals
+// and shares, as they no longer carry any weight in the protocol.
+type IdentityReport struct {
+	BlockID      flow.Identifier `json:"block_id"`
+	Height       uint64          `json:"height"`
+	EpochCounter uint64          `json:"epoch_counter"`
+	EpochPhase   string          `json:"epoch_phase"`
+
+	Total        RoleReport       `json:"total"`
+	Roles        []RoleReport     `json:"roles"`
+	TopOperators []OperatorReport `json:"top_operators"`
+}
+
+// RoleReport aggregates the identities of a single role, or of all roles for the total.
+type RoleReport struct {
+	Role        string  `json:"role"`
+	Count       uint    `json:"count"`
+	Ejected     uint    `json:"ejected"`
+	ZeroWeight  uint    `json:"zero_weight"`
+	Stake       uint64  `json:"stake"`
+	Share       float64 `json:"share"` // share of the total stake
+	MinStake    uint64  `json:"min_stake"`
+	MedianStake uint64  `json:"median_stake"`
+	MaxStake    uint64  `json:"max_stake"`
+}
+
+// OperatorReport describes the stake of a single node operator.
+type OperatorReport struct {
+	NodeID          flow.Identifier `json:"node_id"`
+	Role            string          `json:"role"`
+	Stake           uint64          `json:"stake"`
+	Share           float64         `json:"share"`            // share of the total stake
+	CumulativeShare float64         `json:"cumulative_share"` // share of the total stake held by this and all higher ranked operators
+}
+
+type reportConfig struct {
+	topOperators uint
+}
+
+// ReportOption configures the identity report.
+type ReportOption func(*reportConfig)
+
+// WithTopOperators sets the number of operators with the highest stake included in the report.
+func WithTopOperators(k uint) ReportOption {
+	return func(cfg *reportConfig) {
+		cfg.topOperators = k
+	}
+}
+
+// ReportIdentityTable computes the identity report for the given snapshot. It works
+// against any snapshot implementation, including snapshots read from a serialized
+// root protocol snapshot file.
+func ReportIdentityTable(snapshot protocol.Snapshot, opts ...ReportOption) (*IdentityReport, error) {
+	cfg := reportConfig{
+		topOperators: DefaultTopOperators,
+	}
+	for _, apply := range opts {
+		apply(&cfg)
+	}
+
+	head, err := snapshot.Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get head: %w", err)
+	}
+	phase, err := snapshot.Phase()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch phase: %w", err)
+	}
+	counter, err := snapshot.Epochs().Current().Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch counter: %w", err)
+	}
+	identities, err := snapshot.Identities(filter.Any)
+	if err != nil {
+		return nil, fmt.Errorf("could not get identities: %w", err)
+	}
+
+	report := &IdentityReport{
+		BlockID:      head.ID(),
+		Height:       head.Height,
+		EpochCounter: counter,
+		EpochPhase:   phase.String(),
+	}
+
+	total := identities.Filter(filter.Not(filter.Ejected)).TotalStake()
+	report.Total = aggregate("all", identities, total)
+	for _, role := range flow.Roles() {
+		report.Roles = append(report.Roles, aggregate(role.String(), identities.Filter(filter.HasRole(role)), total))
+	}
+
+	// rank the operators by stake, using the node ID as tie-breaker for a deterministic order
+	operators := identities.Filter(filter.Not(filter.Ejected))
+	sort.Slice(operators, func(i, j int) bool {
+		if operators[i].Stake != operators[j].Stake {
+			return operators[i].Stake > operators[j].Stake
+		}
+		return order.Canonical(operators[i], operators[j])
+	})
+	if uint(len(operators)) > cfg.topOperators {
+		operators = operators[:cfg.topOperators]
+	}
+	var cumulative uint64
+	report.TopOperators = make([]OperatorReport, 0, len(operators))
+	for _, operator := range operators {
+		cumulative += operator.Stake
+		report.TopOperators = append(report.TopOperators, OperatorReport{
+			NodeID:          operator.NodeID,
+			Role:            operator.Role.String(),
+			Stake:           operator.Stake,
+			Share:           share(operator.Stake, total),
+			CumulativeShare: share(cumulative, total),
+		})
+	}
+
+	return report, nil
+}
+
+// aggregate computes the role report for the given identities, relative to the given total stake.
+func aggregate(role string, identities flow.IdentityList, total uint64) RoleReport {
+	report := RoleReport{
+		Role:  role,
+		Count: uint(len(identities)),
+	}
+
+	stakes := make([]uint64, 0, len(identities))
+	for _, identity := range identities {
+		if identity.Ejected {
+			report.Ejected++
+			continue
+		}
+		if identity.Stake == 0 {
+			report.ZeroWeight++
+		}
+		report.Stake += identity.Stake
+		stakes = append(stakes, identity.Stake)
+	}
+	report.Share = share(report.Stake, total)
+
+	if len(stakes) > 0 {
+		sort.Slice(stakes, func(i, j int) bool { return stakes[i] < stakes[j] })
+		report.MinStake = stakes[0]
+		report.MedianStake = percentile(stakes, 50)
+		report.MaxStake = stakes[len(stakes)-1]
+	}
+
+	return report
+}
+
+// percentile returns the p-th percentile of the given ascending values using the
+// nearest-rank method, i.e. the smallest value such that at least p percent of
+// all values are less than or equal to it.
+func percentile(sorted []uint64, p uint) uint64 {
+	rank := (p*uint(len(sorted)) + 99) / 100
+	if rank == 0 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// share returns the fraction of the total represented by the given stake.
+func share(stake uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(stake) / float64(total)
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *IdentityReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(r)
+	if err != nil {
+		return fmt.Errorf("could not encode report: %w", err)
+	}
+	return nil
+}
+
+// csvHeader are the columns of the CSV report. Each row carries the block and epoch
+// context, so that reports for different blocks can be concatenated.
+var csvHeader = []string{
+	"height", "block_id", "epoch_counter", "epoch_phase",
+	"section", "role", "node_id", "count", "ejected", "zero_weight",
+	"stake", "share", "cumulative_share", "min_stake", "median_stake", "max_stake",
+}
+
+// WriteCSV writes the report as CSV, with one row for the total, one row per role
+// and one row per top operator.
+func (r *IdentityReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{csvHeader}
+	rows = append(rows, r.roleRow("total", r.Total))
+	for _, role := range r.Roles {
+		rows = append(rows, r.roleRow("role", role))
+	}
+	for _, operator := range r.TopOperators {
+		rows = append(rows, r.operatorRow(operator))
+	}
+
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return fmt.Errorf("could not write report: %w", err)
+	}
+	return nil
+}
+
+func (r *IdentityReport) contextColumns(section string) []string {
+	return []string{
+		strconv.FormatUint(r.Height, 10),
+		r.BlockID.String(),
+		strconv.FormatUint(r.EpochCounter, 10),
+		r.EpochPhase,
+		section,
+	}
+}
+
+func (r *IdentityReport) roleRow(section string, role RoleReport) []string {
+	return append(r.contextColumns(section),
+		role.Role,
+		"",
+		strconv.FormatUint(uint64(role.Count), 10),
+		strconv.FormatUint(uint64(role.Ejected), 10),
+		strconv.FormatUint(uint64(role.ZeroWeight), 10),
+		strconv.FormatUint(role.Stake, 10),
+		formatShare(role.Share),
+		"",
+		strconv.FormatUint(role.MinStake, 10),
+		strconv.FormatUint(role.MedianStake, 10),
+		strconv.FormatUint(role.MaxStake, 10),
+	)
+}
+
+func (r *IdentityReport) operatorRow(operator OperatorReport) []string {
+	zeroWeight := "0"
+	if operator.Stake == 0 {
+		zeroWeight = "1"
+	}
+	return append(r.contextColumns("operator"),
+		operator.Role,
+		operator.NodeID.String(),
+		"1",
+		"0",
+		zeroWeight,
+		strconv.FormatUint(operator.Stake, 10),
+		formatShare(operator.Share),
+		formatShare(operator.CumulativeShare),
+		"",
+		"",
+		"",
+	)
+}
+
+func formatShare(share float64) string {
+	return strconv.FormatFloat(share, 'f', 6, 64)
+}
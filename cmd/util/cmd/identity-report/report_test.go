@@ -0,0 +1,194 @@
+package identity_report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// skewedIdentities returns an identity table in which a single consensus node holds
+// half of the total stake, including an ejected and a zero-weight identity.
+func skewedIdentities() flow.IdentityList {
+	return flow.IdentityList{
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleConsensus), unittest.WithStake(500)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleConsensus), unittest.WithStake(100)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleConsensus), unittest.WithStake(100)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleCollection), unittest.WithStake(100)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleCollection), unittest.WithStake(50)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleCollection), unittest.WithStake(0)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithStake(100)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithStake(1000), func(identity *flow.Identity) {
+			identity.Ejected = true
+		}),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification), unittest.WithStake(50)),
+	}
+}
+
+// snapshotFixture returns a mocked snapshot for the given identity table.
+func snapshotFixture(identities flow.IdentityList, head *flow.Header) *protocol.Snapshot {
+	epoch := new(protocol.Epoch)
+	epoch.On("Counter").Return(uint64(3), nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Current").Return(epoch)
+
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Head").Return(head, nil)
+	snapshot.On("Phase").Return(flow.EpochPhaseSetup, nil)
+	snapshot.On("Epochs").Return(epochs)
+	snapshot.On("Identities", mock.Anything).Return(identities, nil)
+	return snapshot
+}
+
+func roleReport(t *testing.T, report *IdentityReport, role flow.Role) RoleReport {
+	for _, r := range report.Roles {
+		if r.Role == role.String() {
+			return r
+		}
+	}
+	t.Fatalf("missing role %s in report", role)
+	return RoleReport{}
+}
+
+// TestReportIdentityTable tests the aggregations over a skewed identity table.
+func TestReportIdentityTable(t *testing.T) {
+	identities := skewedIdentities()
+	head := unittest.BlockHeaderFixture()
+
+	report, err := ReportIdentityTable(snapshotFixture(identities, &head), WithTopOperators(3))
+	require.NoError(t, err)
+
+	assert.Equal(t, head.ID(), report.BlockID)
+	assert.Equal(t, head.Height, report.Height)
+	assert.Equal(t, uint64(3), report.EpochCounter)
+	assert.Equal(t, flow.EpochPhaseSetup.String(), report.EpochPhase)
+
+	// the stake of the ejected execution node is excluded from the total
+	assert.Equal(t, RoleReport{
+		Role:        "all",
+		Count:       9,
+		Ejected:     1,
+		ZeroWeight:  1,
+		Stake:       1000,
+		Share:       1,
+		MinStake:    0,
+		MedianStake: 100,
+		MaxStake:    500,
+	}, report.Total)
+
+	require.Len(t, report.Roles, len(flow.Roles()))
+	consensus := roleReport(t, report, flow.RoleConsensus)
+	assert.Equal(t, uint(3), consensus.Count)
+	assert.Equal(t, uint64(700), consensus.Stake)
+	assert.InDelta(t, 0.7, consensus.Share, 1e-9)
+	assert.Equal(t, uint64(100), consensus.MinStake)
+	assert.Equal(t, uint64(100), consensus.MedianStake)
+	assert.Equal(t, uint64(500), consensus.MaxStake)
+
+	collection := roleReport(t, report, flow.RoleCollection)
+	assert.Equal(t, uint(3), collection.Count)
+	assert.Equal(t, uint(1), collection.ZeroWeight)
+	assert.Equal(t, uint64(150), collection.Stake)
+	assert.InDelta(t, 0.15, collection.Share, 1e-9)
+	assert.Equal(t, uint64(50), collection.MedianStake)
+
+	execution := roleReport(t, report, flow.RoleExecution)
+	assert.Equal(t, uint(2), execution.Count)
+	assert.Equal(t, uint(1), execution.Ejected)
+	assert.Equal(t, uint64(100), execution.Stake)
+	assert.Equal(t, uint64(100), execution.MaxStake)
+
+	access := roleReport(t, report, flow.RoleAccess)
+	assert.Equal(t, RoleReport{Role: flow.RoleAccess.String()}, access)
+
+	// the ejected node is not ranked, even though it has the highest stake
+	require.Len(t, report.TopOperators, 3)
+	assert.Equal(t, identities[0].NodeID, report.TopOperators[0].NodeID)
+	assert.InDelta(t, 0.5, report.TopOperators[0].Share, 1e-9)
+	assert.InDelta(t, 0.5, report.TopOperators[0].CumulativeShare, 1e-9)
+	for i, operator := range report.TopOperators[1:] {
+		assert.Equal(t, uint64(100), operator.Stake)
+		assert.InDelta(t, 0.1, operator.Share, 1e-9)
+		assert.InDelta(t, 0.5+0.1*float64(i+1), operator.CumulativeShare, 1e-9)
+	}
+	// operators with equal stake are ordered by node ID
+	assert.Less(t, report.TopOperators[1].NodeID.String(), report.TopOperators[2].NodeID.String())
+}
+
+// TestReportIdentityTable_RootSnapshot tests the report against a serialized root snapshot.
+func TestReportIdentityTable_RootSnapshot(t *testing.T) {
+	participants := unittest.CompleteIdentitySet()
+	root := unittest.RootSnapshotFixture(participants)
+
+	// round trip through the serialized format used for root snapshot files
+	data, err := json.Marshal(root.Encodable())
+	require.NoError(t, err)
+	var encodable inmem.EncodableSnapshot
+	require.NoError(t, json.Unmarshal(data, &encodable))
+
+	report, err := ReportIdentityTable(inmem.SnapshotFromEncodable(encodable))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(len(participants)), report.Total.Count)
+	assert.Equal(t, participants.TotalStake(), report.Total.Stake)
+	assert.Equal(t, flow.EpochPhaseStaking.String(), report.EpochPhase)
+	assert.Len(t, report.TopOperators, len(participants))
+}
+
+// TestIdentityReport_Formats tests the JSON and CSV encodings of the report.
+func TestIdentityReport_Formats(t *testing.T) {
+	head := unittest.BlockHeaderFixture()
+	report, err := ReportIdentityTable(snapshotFixture(skewedIdentities(), &head), WithTopOperators(2))
+	require.NoError(t, err)
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.WriteJSON(&buf))
+
+		var decoded IdentityReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, *report, decoded)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.WriteCSV(&buf))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+
+		// header, total, one row per role and one row per top operator
+		require.Len(t, rows, 1+1+len(flow.Roles())+2)
+		assert.Equal(t, csvHeader, rows[0])
+
+		column := make(map[string]int)
+		for i, name := range csvHeader {
+			column[name] = i
+		}
+		for _, row := range rows[1:] {
+			assert.Equal(t, report.BlockID.String(), row[column["block_id"]])
+			assert.Equal(t, "3", row[column["epoch_counter"]])
+		}
+
+		total := rows[1]
+		assert.Equal(t, "total", total[column["section"]])
+		assert.Equal(t, "9", total[column["count"]])
+		assert.Equal(t, "1000", total[column["stake"]])
+		assert.Equal(t, "1.000000", total[column["share"]])
+
+		top := rows[len(rows)-2]
+		assert.Equal(t, "operator", top[column["section"]])
+		assert.Equal(t, report.TopOperators[0].NodeID.String(), top[column["node_id"]])
+		assert.Equal(t, "0.500000", top[column["share"]])
+		assert.Equal(t, "0.600000", rows[len(rows)-1][column["cumulative_share"]])
+	})
+}
@@ -9,11 +9,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	backfill_indexes "github.com/onflow/flow-go/cmd/util/cmd/backfill-indexes"
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
+	epoch_recovery "github.com/onflow/flow-go/cmd/util/cmd/epoch-recovery"
 	epochs "github.com/onflow/flow-go/cmd/util/cmd/epochs/cmd"
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
 	ledger_json_exporter "github.com/onflow/flow-go/cmd/util/cmd/export-json-execution-state"
+	identity_report "github.com/onflow/flow-go/cmd/util/cmd/identity-report"
 	read_badger "github.com/onflow/flow-go/cmd/util/cmd/read-badger/cmd"
 	read_protocol_state "github.com/onflow/flow-go/cmd/util/cmd/read-protocol-state/cmd"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
@@ -58,6 +61,9 @@ func addCommands() {
 	rootCmd.AddCommand(read_protocol_state.RootCmd)
 	rootCmd.AddCommand(ledger_json_exporter.Cmd)
 	rootCmd.AddCommand(epochs.RootCmd)
+	rootCmd.AddCommand(identity_report.Cmd)
+	rootCmd.AddCommand(epoch_recovery.Cmd)
+	rootCmd.AddCommand(backfill_indexes.Cmd)
 }
 
 func initConfig() {
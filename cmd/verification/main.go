@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/onflow/flow-go/admin/commands"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
@@ -52,10 +54,14 @@ func main() {
 		backoffMaxInterval time.Duration // maximum time interval a chunk data pack request waits before dispatching.
 		backoffMultiplier  float64       // base of exponent in exponential backoff multiplier for backing off requests for chunk data packs.
 		requestTargets     uint64        // maximum number of execution nodes a chunk data pack request is dispatched to.
+		dispatchLimit      uint          // maximum number of chunk data pack requests dispatched in a single round.
+		maxRequestDuration time.Duration // maximum time interval a chunk data pack is requested before giving up.
 
 		blockWorkers uint64 // number of blocks processed in parallel.
 		chunkWorkers uint64 // number of chunks processed in parallel.
 
+		approvalBatchWindow time.Duration // window for batching the approvals of the same result.
+
 		chunkStatuses        *stdmap.ChunkStatuses     // used in fetcher engine
 		chunkRequests        *stdmap.ChunkRequests     // used in requester engine
 		processedChunkIndex  *storage.ConsumerProgress // used in chunk consumer
@@ -87,8 +93,11 @@ func main() {
 		flags.DurationVar(&backoffMaxInterval, "backoff-max-interval", vereq.DefaultBackoffMaxInterval, "min time interval a chunk data pack request waits before dispatching")
 		flags.Float64Var(&backoffMultiplier, "backoff-multiplier", vereq.DefaultBackoffMultiplier, "base of exponent in exponential backoff requesting mechanism")
 		flags.Uint64Var(&requestTargets, "request-targets", vereq.DefaultRequestTargets, "maximum number of execution nodes a chunk data pack request is dispatched to")
+		flags.UintVar(&dispatchLimit, "chunk-request-dispatch-limit", vereq.DefaultDispatchLimit, "maximum number of chunk data pack requests dispatched in a single round, requests of small chunks are dispatched first once exceeded (0 for no limit)")
+		flags.DurationVar(&maxRequestDuration, "chunk-request-max-duration", vereq.DefaultMaxRequestDuration, "maximum time interval a chunk data pack is requested from alternating execution nodes before giving up (0 for no limit)")
 		flags.Uint64Var(&blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 		flags.Uint64Var(&chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
+		flags.DurationVar(&approvalBatchWindow, "approval-batch-window", 0, fmt.Sprintf("window within which the approvals for the same result are sent as a single batch, e.g. %v; zero disables batching, which may only be enabled once all consensus nodes decode approval batches", verifier.SuggestedApprovalBatchWindow))
 
 	})
 
@@ -97,6 +106,12 @@ func main() {
 	}
 
 	nodeBuilder.
+		AdminCommand("read-chunk-assignments", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadChunkAssignmentsCommand(storage.NewChunkAssignments(config.DB))
+		}).
+		AdminCommand("read-verification-records", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadVerificationRecordsCommand(storage.NewVerificationRecords(config.DB))
+		}).
 		Module("mutable follower state", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -119,19 +134,11 @@ func main() {
 			return nil
 		}).
 		Module("chunk status memory pool", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			chunkStatuses = stdmap.NewChunkStatuses(chunkLimit)
-			err = node.Metrics.Mempool.Register(metrics.ResourceChunkStatus, chunkStatuses.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
+			chunkStatuses = stdmap.NewChunkStatuses(chunkLimit, stdmap.WithMetrics(node.Metrics.Mempool, metrics.ResourceChunkStatus))
 			return nil
 		}).
 		Module("chunk requests memory pool", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			chunkRequests = stdmap.NewChunkRequests(chunkLimit)
-			err = node.Metrics.Mempool.Register(metrics.ResourceChunkRequest, chunkRequests.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
+			chunkRequests = stdmap.NewChunkRequests(chunkLimit, stdmap.WithMetrics(node.Metrics.Mempool, metrics.ResourceChunkRequest))
 			return nil
 		}).
 		Module("processed chunk index consumer progress", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
@@ -186,7 +193,9 @@ func main() {
 				node.State,
 				node.Me,
 				chunkVerifier,
-				approvalStorage)
+				approvalStorage,
+				verifier.WithApprovalBatchWindow(approvalBatchWindow),
+				verifier.WithVerificationRecords(storage.NewVerificationRecords(node.DB)))
 			return verifierEng, err
 		}).
 		Component("chunk consumer, requester, and fetcher engines", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
@@ -200,7 +209,9 @@ func main() {
 				requestInterval,
 				vereq.RetryAfterQualifier,
 				mempool.ExponentialUpdater(backoffMultiplier, backoffMaxInterval, backoffMinInterval),
-				requestTargets)
+				requestTargets,
+				dispatchLimit,
+				maxRequestDuration)
 
 			fetcherEngine = fetcher.New(
 				node.Logger,
@@ -247,6 +258,7 @@ func main() {
 				chunkAssigner,
 				chunkQueue,
 				chunkConsumer)
+			assignerEngine.WithChunkAssignments(storage.NewChunkAssignments(node.DB))
 
 			return assignerEngine, nil
 		}).
@@ -325,6 +337,7 @@ func main() {
 				node.Me,
 				node.Metrics.Engine,
 				node.Metrics.Mempool,
+				node.Metrics.Cache,
 				cleaner,
 				node.Storage.Headers,
 				node.Storage.Payloads,
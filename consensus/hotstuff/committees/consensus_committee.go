@@ -10,7 +10,6 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
-	"github.com/onflow/flow-go/model/indices"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
@@ -155,9 +154,9 @@ func (c *Consensus) LeaderForView(view uint64) (flow.Identifier, error) {
 			return flow.ZeroID, fmt.Errorf("could not get epoch initial identities: %w", err)
 		}
 		// CAUTION: this is re-using the same leader selection seed from the now-ending epoch
-		seed, err := current.Seed(indices.ProtocolConsensusLeaderSelection...)
+		selectionSeed, err := leader.SeedForConsensus(current)
 		if err != nil {
-			return flow.ZeroID, fmt.Errorf("could not get epoch seed: %w", err)
+			return flow.ZeroID, fmt.Errorf("could not get epoch leader selection seed: %w", err)
 		}
 		currentFinalView, err := current.FinalView()
 		if err != nil {
@@ -170,7 +169,7 @@ func (c *Consensus) LeaderForView(view uint64) (flow.Identifier, error) {
 		firstView := currentFinalView + 1
 		selection, err := leader.ComputeLeaderSelectionFromSeed(
 			firstView,
-			seed,
+			selectionSeed,
 			int(firstView+leader.EstimatedSixMonthOfViews), // the fallback epoch lasts until the next spork
 			identities.Filter(filter.IsVotingConsensusCommitteeMember),
 		)
@@ -0,0 +1,132 @@
+package committees
+
+import (
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+// DefaultIdentityCacheSize is the default number of blocks, for which the HotStuff participants
+// are cached by the CommitteeIdentityCache.
+const DefaultIdentityCacheSize = 100
+
+// CommitteeIdentityCache implements the hotstuff.Committee interface.
+// It wraps a hotstuff.Committee instance and caches the HotStuff participants of the most recently
+// queried blocks. As the participants of a block never change, verifying the votes for a block or
+// the QC certifying it only retrieves the participants from the wrapped committee once, instead of
+// once per signer.
+// As a protocol events consumer, the cache is flushed when the epoch counter changes, so that it
+// only holds the participants of blocks of the current epoch.
+// CommitteeIdentityCache is safe for concurrent use.
+type CommitteeIdentityCache struct {
+	events.Noop
+	committee    hotstuff.Committee
+	participants *lru.Cache // block ID -> all HotStuff participants at the block
+	disabled     bool       // if set, all queries are forwarded to the wrapped committee
+
+	mu      sync.Mutex
+	counter uint64 // counter of the epoch, for which participants are cached
+}
+
+// IdentityCacheOption configures a CommitteeIdentityCache.
+type IdentityCacheOption func(*CommitteeIdentityCache)
+
+// WithCachingDisabled disables caching, so that all queries are forwarded to the wrapped
+// committee. It is intended for tests.
+func WithCachingDisabled() IdentityCacheOption {
+	return func(c *CommitteeIdentityCache) {
+		c.disabled = true
+	}
+}
+
+// NewIdentityCache creates a committee, which caches the HotStuff participants of up to size blocks.
+func NewIdentityCache(committee hotstuff.Committee, size int, opts ...IdentityCacheOption) (*CommitteeIdentityCache, error) {
+	participants, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("could not create identity cache: %w", err)
+	}
+	c := &CommitteeIdentityCache{
+		committee:    committee,
+		participants: participants,
+	}
+	for _, apply := range opts {
+		apply(c)
+	}
+	return c, nil
+}
+
+// EpochTransition flushes the cache if the epoch counter changes, as the participants of blocks of
+// the previous epoch are no longer queried.
+func (c *CommitteeIdentityCache) EpochTransition(newEpochCounter uint64, _ *flow.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if newEpochCounter == c.counter {
+		return
+	}
+	c.counter = newEpochCounter
+	c.participants.Purge()
+}
+
+// participantsAt returns all HotStuff participants at the given block, retrieving them from the
+// wrapped committee if they are not cached.
+func (c *CommitteeIdentityCache) participantsAt(blockID flow.Identifier) (flow.IdentityList, error) {
+	cached, ok := c.participants.Get(blockID)
+	if ok {
+		return cached.(flow.IdentityList), nil
+	}
+	participants, err := c.committee.Identities(blockID, filter.Any)
+	if err != nil {
+		return nil, err
+	}
+	c.participants.Add(blockID, participants)
+	return participants, nil
+}
+
+// Identities returns copies of the cached participants at the given block, which match the selector.
+func (c *CommitteeIdentityCache) Identities(blockID flow.Identifier, selector flow.IdentityFilter) (flow.IdentityList, error) {
+	if c.disabled {
+		return c.committee.Identities(blockID, selector)
+	}
+	participants, err := c.participantsAt(blockID)
+	if err != nil {
+		return nil, err
+	}
+	return participants.Filter(selector).Copy(), nil
+}
+
+// Identity returns a copy of the cached participant at the given block. It returns
+// model.ErrInvalidSigner, if the node is not a HotStuff participant at the block.
+func (c *CommitteeIdentityCache) Identity(blockID flow.Identifier, participantID flow.Identifier) (*flow.Identity, error) {
+	if c.disabled {
+		return c.committee.Identity(blockID, participantID)
+	}
+	participants, err := c.participantsAt(blockID)
+	if err != nil {
+		return nil, err
+	}
+	participant, ok := participants.ByNodeID(participantID)
+	if !ok {
+		return nil, model.ErrInvalidSigner
+	}
+	identity := *participant
+	return &identity, nil
+}
+
+func (c *CommitteeIdentityCache) LeaderForView(view uint64) (flow.Identifier, error) {
+	return c.committee.LeaderForView(view)
+}
+
+func (c *CommitteeIdentityCache) Self() flow.Identifier {
+	return c.committee.Self()
+}
+
+func (c *CommitteeIdentityCache) DKG(blockID flow.Identifier) (hotstuff.DKG, error) {
+	return c.committee.DKG(blockID)
+}
@@ -0,0 +1,196 @@
+package committees
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestIdentityCache_ParticipantsRetrievedOnce tests that the participants of a block are retrieved from
+// the wrapped committee once, however often the identities of the participants are queried.
+func TestIdentityCache_ParticipantsRetrievedOnce(t *testing.T) {
+	participants := unittest.IdentityListFixture(10, unittest.WithRole(flow.RoleConsensus))
+	blockID := unittest.IdentifierFixture()
+
+	committee := &mocks.Committee{}
+	committee.On("Identities", blockID, mock.Anything).Return(participants, nil).Once()
+
+	cache, err := NewIdentityCache(committee, DefaultIdentityCacheSize)
+	require.NoError(t, err)
+
+	// concurrent first queries might all miss the cache, so the participants are cached first
+	_, err = cache.Identity(blockID, participants[0].NodeID)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, participant := range participants {
+				identity, err := cache.Identity(blockID, participant.NodeID)
+				require.NoError(t, err)
+				require.Equal(t, participant, identity)
+			}
+		}()
+	}
+	wg.Wait()
+
+	signers, err := cache.Identities(blockID, filter.HasNodeID(participants[0].NodeID, participants[1].NodeID))
+	require.NoError(t, err)
+	require.Equal(t, participants[:2], signers)
+
+	committee.AssertExpectations(t)
+}
+
+// TestIdentityCache_InvalidSigner tests that nodes, which are not participants at the block, are
+// rejected with model.ErrInvalidSigner.
+func TestIdentityCache_InvalidSigner(t *testing.T) {
+	participants := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleConsensus))
+	blockID := unittest.IdentifierFixture()
+
+	committee := &mocks.Committee{}
+	committee.On("Identities", blockID, mock.Anything).Return(participants, nil).Once()
+
+	cache, err := NewIdentityCache(committee, DefaultIdentityCacheSize)
+	require.NoError(t, err)
+
+	_, err = cache.Identity(blockID, unittest.IdentifierFixture())
+	require.True(t, errors.Is(err, model.ErrInvalidSigner))
+}
+
+// TestIdentityCache_Errors tests that errors of the wrapped committee are returned and not cached.
+func TestIdentityCache_Errors(t *testing.T) {
+	participants := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleConsensus))
+	blockID := unittest.IdentifierFixture()
+	exception := fmt.Errorf("exception")
+
+	committee := &mocks.Committee{}
+	committee.On("Identities", blockID, mock.Anything).Return(nil, exception).Once()
+	committee.On("Identities", blockID, mock.Anything).Return(participants, nil).Once()
+
+	cache, err := NewIdentityCache(committee, DefaultIdentityCacheSize)
+	require.NoError(t, err)
+
+	_, err = cache.Identity(blockID, participants[0].NodeID)
+	require.True(t, errors.Is(err, exception))
+
+	identity, err := cache.Identity(blockID, participants[0].NodeID)
+	require.NoError(t, err)
+	require.Equal(t, participants[0], identity)
+
+	committee.AssertExpectations(t)
+}
+
+// TestIdentityCache_Eviction tests that the participants of the least recently queried blocks are
+// evicted once the cache is full, and retrieved again afterwards.
+func TestIdentityCache_Eviction(t *testing.T) {
+	participants := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleConsensus))
+	first := unittest.IdentifierFixture()
+	second := unittest.IdentifierFixture()
+
+	committee := &mocks.Committee{}
+	committee.On("Identities", first, mock.Anything).Return(participants, nil).Twice()
+	committee.On("Identities", second, mock.Anything).Return(participants, nil).Once()
+
+	cache, err := NewIdentityCache(committee, 1)
+	require.NoError(t, err)
+
+	_, err = cache.Identities(first, filter.Any)
+	require.NoError(t, err)
+	_, err = cache.Identities(second, filter.Any)
+	require.NoError(t, err)
+	_, err = cache.Identities(first, filter.Any)
+	require.NoError(t, err)
+
+	committee.AssertExpectations(t)
+}
+
+// TestIdentityCache_SignersRetrievedOnce tests that many verifications of the votes and QCs for a
+// block don't retrieve any signer more than once from the wrapped committee: all signers are
+// resolved from the participants of the block, which are retrieved with a single query.
+func TestIdentityCache_SignersRetrievedOnce(t *testing.T) {
+	participants := unittest.IdentityListFixture(10, unittest.WithRole(flow.RoleConsensus))
+	blockID := unittest.IdentifierFixture()
+
+	committee := &mocks.Committee{}
+	committee.On("Identities", blockID, mock.Anything).Return(participants, nil).Once()
+
+	cache, err := NewIdentityCache(committee, DefaultIdentityCacheSize)
+	require.NoError(t, err)
+
+	for verification := 0; verification < 100; verification++ {
+		for _, participant := range participants {
+			identity, err := cache.Identity(blockID, participant.NodeID)
+			require.NoError(t, err)
+			require.Equal(t, participant, identity)
+		}
+	}
+
+	committee.AssertExpectations(t)
+	committee.AssertNotCalled(t, "Identity", mock.Anything, mock.Anything)
+}
+
+// TestIdentityCache_EpochTransition tests that the cache is flushed when the epoch counter changes,
+// and kept otherwise.
+func TestIdentityCache_EpochTransition(t *testing.T) {
+	participants := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleConsensus))
+	blockID := unittest.IdentifierFixture()
+	first := unittest.BlockHeaderFixture()
+
+	committee := &mocks.Committee{}
+	committee.On("Identities", blockID, mock.Anything).Return(participants, nil).Twice()
+
+	cache, err := NewIdentityCache(committee, DefaultIdentityCacheSize)
+	require.NoError(t, err)
+
+	_, err = cache.Identity(blockID, participants[0].NodeID)
+	require.NoError(t, err)
+	committee.AssertNumberOfCalls(t, "Identities", 1)
+
+	// a simulated epoch change flushes the cache
+	cache.EpochTransition(1, &first)
+	_, err = cache.Identity(blockID, participants[0].NodeID)
+	require.NoError(t, err)
+	committee.AssertNumberOfCalls(t, "Identities", 2)
+
+	// repeated notifications for the same epoch keep the cache
+	cache.EpochTransition(1, &first)
+	_, err = cache.Identity(blockID, participants[0].NodeID)
+	require.NoError(t, err)
+	committee.AssertNumberOfCalls(t, "Identities", 2)
+}
+
+// TestIdentityCache_CachingDisabled tests that all queries are forwarded to the wrapped committee
+// if caching is disabled.
+func TestIdentityCache_CachingDisabled(t *testing.T) {
+	participants := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleConsensus))
+	blockID := unittest.IdentifierFixture()
+
+	committee := &mocks.Committee{}
+	committee.On("Identity", blockID, participants[0].NodeID).Return(participants[0], nil).Times(3)
+	committee.On("Identities", blockID, mock.Anything).Return(participants, nil).Times(3)
+
+	cache, err := NewIdentityCache(committee, DefaultIdentityCacheSize, WithCachingDisabled())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		identity, err := cache.Identity(blockID, participants[0].NodeID)
+		require.NoError(t, err)
+		require.Equal(t, participants[0], identity)
+		_, err = cache.Identities(blockID, filter.Any)
+		require.NoError(t, err)
+	}
+
+	committee.AssertExpectations(t)
+}
@@ -3,7 +3,6 @@ package leader
 import (
 	"fmt"
 
-	"github.com/onflow/flow-go/model/indices"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
@@ -21,18 +20,12 @@ func SelectionForCluster(cluster protocol.Cluster, epoch protocol.Epoch) (*Leade
 	}
 
 	identities := cluster.Members()
-	seed, err := epoch.Seed(indices.ProtocolCollectorClusterLeaderSelection(cluster.Index())...)
+	selectionSeed, err := SeedForCluster(epoch, cluster.Index())
 	if err != nil {
 		return nil, fmt.Errorf("could not get leader selection seed for cluster (index: %v) at epoch: %v: %w", cluster.Index(), counter, err)
 	}
 	firstView := cluster.RootBlock().Header.View
 	// TODO what is a good value here?
 	finalView := firstView + EstimatedSixMonthOfViews
-	leaders, err := ComputeLeaderSelectionFromSeed(
-		firstView,
-		seed,
-		int(finalView-firstView+1),
-		identities,
-	)
-	return leaders, err
+	return ComputeLeaderSelection(selectionSeed, identities, firstView, finalView)
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/onflow/flow-go/model/flow/filter"
-	"github.com/onflow/flow-go/model/indices"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
@@ -19,9 +18,9 @@ func SelectionForConsensus(epoch protocol.Epoch) (*LeaderSelection, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not get epoch initial identities: %w", err)
 	}
-	seed, err := epoch.Seed(indices.ProtocolConsensusLeaderSelection...)
+	selectionSeed, err := SeedForConsensus(epoch)
 	if err != nil {
-		return nil, fmt.Errorf("could not get epoch seed: %w", err)
+		return nil, fmt.Errorf("could not get leader selection seed: %w", err)
 	}
 	firstView, err := epoch.FirstView()
 	if err != nil {
@@ -31,11 +30,5 @@ func SelectionForConsensus(epoch protocol.Epoch) (*LeaderSelection, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not get epoch final view: %w", err)
 	}
-	leaders, err := ComputeLeaderSelectionFromSeed(
-		firstView,
-		seed,
-		int(finalView-firstView+1), // add 1 because both first/final view are inclusive
-		identities.Filter(filter.IsVotingConsensusCommitteeMember),
-	)
-	return leaders, err
+	return ComputeLeaderSelection(selectionSeed, identities.Filter(filter.IsVotingConsensusCommitteeMember), firstView, finalView)
 }
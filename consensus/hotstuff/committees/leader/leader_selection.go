@@ -82,6 +82,28 @@ func (l LeaderSelection) newInvalidViewError(view uint64) InvalidViewError {
 	}
 }
 
+// ComputeLeaderSelection pre-computes the leaders for all views in [firstView, finalView] from the
+// given seed, with a chance to be selected proportional to the stake of the identities. It is a
+// pure function of its inputs, so that components other than the consensus committee, such as
+// metrics or forecasting tools, can reproduce the leader schedule. Lookups by view take constant
+// time, and the memory is proportional to the number of views. Identities without stake are never
+// selected, and an error is returned if there are no identities, or no identity has stake.
+//
+// The selection is the one run by the consensus and cluster committees: it samples from the
+// xorshift128+ PRG of the crypto/random package, and reduces the samples modulo the total stake.
+// The bias of the reduction is below totalStake/2^64, and negligible for any realistic total stake.
+// Changing the PRG or sampling changes the leader schedule of every epoch, and is only possible
+// with a spork.
+func ComputeLeaderSelection(seed []byte, identities flow.IdentityList, firstView, finalView uint64) (*LeaderSelection, error) {
+	if finalView < firstView {
+		return nil, fmt.Errorf("final view (%d) must not be before first view (%d)", finalView, firstView)
+	}
+	if finalView-firstView >= math.MaxInt32 {
+		return nil, fmt.Errorf("number of views (%d) exceeds maximum (%d)", finalView-firstView+1, math.MaxInt32)
+	}
+	return ComputeLeaderSelectionFromSeed(firstView, seed, int(finalView-firstView+1), identities)
+}
+
 // ComputeLeaderSelectionFromSeed pre-generates a certain number of leader selections, and returns a
 // leader selection instance for querying the leader indexes for certain views.
 // firstView - the start view of the epoch, the generated leader selections start from this view.
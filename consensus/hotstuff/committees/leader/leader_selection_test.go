@@ -361,3 +361,95 @@ func TestZeroStakedNodeWillNotBeSelected(t *testing.T) {
 		})
 	})
 }
+
+// goldenIdentities returns identities with fixed node IDs and the stakes 1, 2, 3 and 4, so that
+// the leader schedule computed from a fixed seed can be pinned.
+func goldenIdentities() flow.IdentityList {
+	identities := make(flow.IdentityList, 0, 4)
+	for i := 0; i < 4; i++ {
+		identities = append(identities, unittest.IdentityFixture(
+			unittest.WithNodeID(flow.Identifier{byte(i + 1)}),
+			unittest.WithStake(uint64(i+1)),
+		))
+	}
+	return identities
+}
+
+// TestComputeLeaderSelection_GoldenVectors pins the leader schedule for a fixed seed and committee.
+// The schedule must only change deliberately, as all nodes must agree on the leader of every view.
+func TestComputeLeaderSelection_GoldenVectors(t *testing.T) {
+	identities := goldenIdentities()
+	firstView := uint64(1000)
+
+	leaders, err := ComputeLeaderSelection(someSeed, identities, firstView, firstView+15)
+	require.NoError(t, err)
+	assert.Equal(t, firstView, leaders.FirstView())
+	assert.Equal(t, firstView+15, leaders.FinalView())
+
+	var selected []byte
+	for view := firstView; view <= firstView+15; view++ {
+		leaderID, err := leaders.LeaderForView(view)
+		require.NoError(t, err)
+		selected = append(selected, leaderID[0])
+	}
+	assert.Equal(t, []byte{3, 4, 4, 3, 1, 4, 3, 4, 4, 4, 3, 4, 4, 4, 3, 3}, selected)
+
+	// the leader of a view is independent of the length of the pre-computed range
+	longer, err := ComputeLeaderSelection(someSeed, identities, firstView, firstView+1000)
+	require.NoError(t, err)
+	for view := firstView; view <= firstView+15; view++ {
+		expected, err := leaders.LeaderForView(view)
+		require.NoError(t, err)
+		actual, err := longer.LeaderForView(view)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+// TestComputeLeaderSelection_Distribution tests that over 100k views, the frequency with which
+// each identity is selected approximates its share of the total stake, and that identities
+// without stake are never selected.
+func TestComputeLeaderSelection_Distribution(t *testing.T) {
+	const N_VIEWS = 100000
+
+	stakeless := unittest.IdentityFixture(unittest.WithStake(0))
+	identities := append(goldenIdentities(), stakeless)
+
+	leaders, err := ComputeLeaderSelection(someSeed, identities, 0, N_VIEWS-1)
+	require.NoError(t, err)
+
+	selected := make(map[flow.Identifier]uint64)
+	for view := uint64(0); view < N_VIEWS; view++ {
+		leaderID, err := leaders.LeaderForView(view)
+		require.NoError(t, err)
+		selected[leaderID]++
+	}
+
+	assert.Zero(t, selected[stakeless.NodeID])
+	for _, identity := range identities {
+		expected := float64(N_VIEWS) * float64(identity.Stake) / 10
+		// the frequency should be within 1% of the views of the expected frequency
+		assert.InDelta(t, expected, float64(selected[identity.NodeID]), N_VIEWS/100)
+	}
+}
+
+// TestComputeLeaderSelection_InputValidation tests that the leader selection cannot be computed
+// for an empty committee or an empty range of views.
+func TestComputeLeaderSelection_InputValidation(t *testing.T) {
+	t.Run("empty committee", func(t *testing.T) {
+		_, err := ComputeLeaderSelection(someSeed, flow.IdentityList{}, 0, 100)
+		assert.Error(t, err)
+	})
+
+	t.Run("final view before first view", func(t *testing.T) {
+		_, err := ComputeLeaderSelection(someSeed, goldenIdentities(), 100, 99)
+		assert.Error(t, err)
+	})
+
+	t.Run("single view", func(t *testing.T) {
+		leaders, err := ComputeLeaderSelection(someSeed, goldenIdentities(), 100, 100)
+		require.NoError(t, err)
+		_, err = leaders.LeaderForView(100)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package leader
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/model/indices"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/seed"
+)
+
+// DomainSeparatedSeedsFirstEpoch is the counter of the first epoch, whose leader selections use
+// the seeds derived with seed.Derive. Leader selections of earlier epochs use the seeds of the
+// legacy indices of the model/indices package.
+//
+// COMPATIBILITY: the seed determines the leader schedule of the epoch, on which all nodes must
+// agree. It may therefore only be changed with a spork, which sets it to the counter of the root
+// epoch of the spork. Until then, no epoch uses the derived seeds.
+const DomainSeparatedSeedsFirstEpoch = math.MaxUint64
+
+// SeedForConsensus returns the seed for the leader selection of the consensus committee in the
+// given epoch.
+func SeedForConsensus(epoch protocol.Epoch) ([]byte, error) {
+	return seedForConsensus(epoch, DomainSeparatedSeedsFirstEpoch)
+}
+
+// SeedForCluster returns the seed for the leader selection of the collector cluster with the
+// given index in the given epoch.
+func SeedForCluster(epoch protocol.Epoch, clusterIndex uint) ([]byte, error) {
+	return seedForCluster(epoch, clusterIndex, DomainSeparatedSeedsFirstEpoch)
+}
+
+func seedForConsensus(epoch protocol.Epoch, firstEpoch uint64) ([]byte, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch counter: %w", err)
+	}
+	if counter < firstEpoch {
+		return epoch.Seed(indices.ProtocolConsensusLeaderSelection...)
+	}
+	source, err := epoch.RandomSource()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch random source: %w", err)
+	}
+	return seed.Derive(source, seed.ConsensusLeaderSelection)
+}
+
+func seedForCluster(epoch protocol.Epoch, clusterIndex uint, firstEpoch uint64) ([]byte, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch counter: %w", err)
+	}
+	if counter < firstEpoch {
+		return epoch.Seed(indices.ProtocolCollectorClusterLeaderSelection(clusterIndex)...)
+	}
+	source, err := epoch.RandomSource()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch random source: %w", err)
+	}
+	return seed.Derive(source, seed.CollectorClusterLeaderSelection, uint32(clusterIndex))
+}
@@ -0,0 +1,83 @@
+package leader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/indices"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/state/protocol/seed"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSeedForConsensus verifies that the legacy seed is used for epochs before the first epoch
+// with domain separated seeds, and the derived seed from then on.
+func TestSeedForConsensus(t *testing.T) {
+	source := unittest.SeedFixture(32)
+	legacy := unittest.SeedFixture(32)
+	derived, err := seed.Derive(source, seed.ConsensusLeaderSelection)
+	require.NoError(t, err)
+
+	var params []interface{}
+	for _, ind := range indices.ProtocolConsensusLeaderSelection {
+		params = append(params, ind)
+	}
+	epoch := new(protocolmock.Epoch)
+	epoch.On("Counter").Return(uint64(10), nil)
+	epoch.On("Seed", params...).Return(legacy, nil)
+	epoch.On("RandomSource").Return(source, nil)
+
+	t.Run("before first epoch", func(t *testing.T) {
+		s, err := seedForConsensus(epoch, 11)
+		require.NoError(t, err)
+		assert.Equal(t, legacy, s)
+	})
+
+	t.Run("from first epoch", func(t *testing.T) {
+		s, err := seedForConsensus(epoch, 10)
+		require.NoError(t, err)
+		assert.Equal(t, derived, s)
+	})
+
+	t.Run("running networks", func(t *testing.T) {
+		s, err := SeedForConsensus(epoch)
+		require.NoError(t, err)
+		assert.Equal(t, legacy, s)
+	})
+}
+
+// TestSeedForCluster verifies that the legacy seed is used for epochs before the first epoch
+// with domain separated seeds, and the derived seed from then on.
+func TestSeedForCluster(t *testing.T) {
+	const clusterIndex = uint(2)
+	source := unittest.SeedFixture(32)
+	legacy := unittest.SeedFixture(32)
+	derived, err := seed.Derive(source, seed.CollectorClusterLeaderSelection, uint32(clusterIndex))
+	require.NoError(t, err)
+
+	inds := indices.ProtocolCollectorClusterLeaderSelection(clusterIndex)
+	epoch := new(protocolmock.Epoch)
+	epoch.On("Counter").Return(uint64(10), nil)
+	epoch.On("Seed", inds[0], inds[1], inds[2]).Return(legacy, nil)
+	epoch.On("RandomSource").Return(source, nil)
+
+	t.Run("before first epoch", func(t *testing.T) {
+		s, err := seedForCluster(epoch, clusterIndex, 11)
+		require.NoError(t, err)
+		assert.Equal(t, legacy, s)
+	})
+
+	t.Run("from first epoch", func(t *testing.T) {
+		s, err := seedForCluster(epoch, clusterIndex, 10)
+		require.NoError(t, err)
+		assert.Equal(t, derived, s)
+	})
+
+	t.Run("running networks", func(t *testing.T) {
+		s, err := SeedForCluster(epoch, clusterIndex)
+		require.NoError(t, err)
+		assert.Equal(t, legacy, s)
+	})
+}
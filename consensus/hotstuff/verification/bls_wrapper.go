@@ -2,7 +2,11 @@
 
 package verification
 
-import "github.com/onflow/flow-go/crypto"
+import (
+	"bytes"
+
+	"github.com/onflow/flow-go/crypto"
+)
 
 func AggregateBLSPublicKeys(keys []crypto.PublicKey) (crypto.PublicKey, error) {
 	return crypto.AggregateBLSPublicKeys(keys)
@@ -15,3 +19,9 @@ func NeutralBLSPublicKey() crypto.PublicKey {
 func RemoveBLSPublicKeys(aggKey crypto.PublicKey, keysToRemove []crypto.PublicKey) (crypto.PublicKey, error) {
 	return crypto.RemoveBLSPublicKeys(aggKey, keysToRemove)
 }
+
+// IsInvalidBeaconShare returns true if the given beacon signature share is the explicitly
+// invalid share, which nodes without a safe random beacon key produce as part of their votes.
+func IsInvalidBeaconShare(share crypto.Signature) bool {
+	return bytes.Equal(share, crypto.BLSInvalidSignature())
+}
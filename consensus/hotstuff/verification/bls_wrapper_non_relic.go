@@ -15,3 +15,7 @@ func NeutralBLSPublicKey() crypto.PublicKey {
 func RemoveBLSPublicKeys(_ crypto.PublicKey, _ []crypto.PublicKey) (crypto.PublicKey, error) {
 	panic("RemoveBLSPublicKeys not supported with non-relic build")
 }
+
+func IsInvalidBeaconShare(_ crypto.Signature) bool {
+	panic("IsInvalidBeaconShare not supported with non-relic build")
+}
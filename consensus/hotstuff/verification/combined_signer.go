@@ -94,7 +94,9 @@ func (c *CombinedSigner) CreateVote(block *model.Block) (*model.Vote, error) {
 }
 
 // CreateQC will create a quorum certificate with a combined aggregated signature and
-// threshold signature for the given votes.
+// threshold signature for the given votes. Votes from nodes without a safe random beacon
+// key are only included in the aggregated staking signature, so the threshold signature
+// can only be reconstructed once sufficient valid beacon shares are available.
 func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, error) {
 
 	// check the consistency of the votes
@@ -112,15 +114,6 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 		return nil, fmt.Errorf("could not get DKG: %w", err)
 	}
 
-	// check if we have sufficient threshold signature shares
-	enoughShares, err := signature.EnoughThresholdShares(int(dkg.Size()), len(votes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to check if shares are enough: %w", err)
-	}
-	if !enoughShares {
-		return nil, signature.ErrInsufficientShares
-	}
-
 	// collect signers, staking signatures, beacon signatures and dkg indices separately
 	signerIDs := make([]flow.Identifier, 0, len(votes))
 	stakingSigs := make([]crypto.Signature, 0, len(votes))
@@ -134,19 +127,33 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 			return nil, fmt.Errorf("could not split signature (voter: %x): %w", vote.SignerID, err)
 		}
 
+		// collect each element in its respective slice
+		signerIDs = append(signerIDs, vote.SignerID)
+		stakingSigs = append(stakingSigs, stakingSig)
+
+		// votes from nodes without a safe beacon key only contribute their staking signature
+		if IsInvalidBeaconShare(beaconShare) {
+			continue
+		}
+
 		// get the dkg index from the dkg state
 		dkgIndex, err := dkg.Index(vote.SignerID)
 		if err != nil {
 			return nil, fmt.Errorf("could not get dkg index (signer: %x): %w", vote.SignerID, err)
 		}
-
-		// collect each element in its respective slice
-		signerIDs = append(signerIDs, vote.SignerID)
-		stakingSigs = append(stakingSigs, stakingSig)
 		beaconShares = append(beaconShares, beaconShare)
 		dkgIndices = append(dkgIndices, dkgIndex)
 	}
 
+	// check if we have sufficient threshold signature shares
+	enoughShares, err := signature.EnoughThresholdShares(int(dkg.Size()), len(beaconShares))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if shares are enough: %w", err)
+	}
+	if !enoughShares {
+		return nil, signature.ErrInsufficientShares
+	}
+
 	// aggregate all staking signatures into one aggregated signature
 	stakingAggSig, err := c.staking.Aggregate(stakingSigs)
 	if err != nil {
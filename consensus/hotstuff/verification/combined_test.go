@@ -8,7 +8,9 @@ import (
 
 	"github.com/onflow/flow-go/consensus/hotstuff/helper"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -60,6 +62,54 @@ func TestCombinedVote(t *testing.T) {
 	vote.SigData[4]--
 }
 
+// TestCombinedVoteWithoutBeaconKey tests that a node without a safe random beacon key
+// produces votes which peers accept based on the staking signature, and which are
+// excluded from the threshold signature when creating a QC.
+func TestCombinedVoteWithoutBeaconKey(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(8, unittest.WithRole(flow.RoleConsensus))
+	minShares := (len(identities)-1)/2 + 1
+	committeeState, stakingKeys, beaconKeys := MakeHotstuffCommitteeState(t, identities, true, epochCounter)
+	signers := MakeSigners(t, committeeState, identities.NodeIDs(), stakingKeys, beaconKeys)
+
+	// the first node falls back to signing without a beacon key
+	signers[0] = MakeBeaconSigner(t, committeeState, identities[0].NodeID, stakingKeys[0], nil)
+
+	block := helper.MakeBlock(t, helper.WithBlockProposer(identities[1].NodeID))
+	var votes []*model.Vote
+	for _, signer := range signers {
+		vote, err := signer.CreateVote(block)
+		require.NoError(t, err)
+		votes = append(votes, vote)
+	}
+
+	// the fallback vote is structurally valid and verifies under the peer-side verifier
+	_, beaconShare, err := signature.NewCombiner(encodable.ConsensusVoteSigLen, encodable.RandomBeaconSigLen).Split(votes[0].SigData)
+	require.NoError(t, err)
+	assert.True(t, IsInvalidBeaconShare(beaconShare))
+	valid, err := signers[1].VerifyVote(identities[0], votes[0].SigData, block)
+	require.NoError(t, err)
+	assert.True(t, valid, "vote without beacon share should be valid")
+
+	// the staking signature of the fallback vote is still verified
+	votes[0].SigData[4]++
+	valid, err = signers[1].VerifyVote(identities[0], votes[0].SigData, block)
+	require.NoError(t, err)
+	assert.False(t, valid, "vote with changed staking signature should be invalid")
+	votes[0].SigData[4]--
+
+	// the fallback vote doesn't count towards the threshold signature shares
+	_, err = signers[1].CreateQC(votes[:minShares])
+	assert.ErrorIs(t, err, signature.ErrInsufficientShares)
+
+	// with sufficient beacon shares from the other votes, the QC is valid
+	qc, err := signers[1].CreateQC(votes[:minShares+1])
+	require.NoError(t, err)
+	valid, err = signers[1].VerifyQC(identities[:minShares+1], qc.SigData, block)
+	require.NoError(t, err)
+	assert.True(t, valid, "QC including a vote without beacon share should be valid")
+}
+
 func TestCombinedProposalIsVote(t *testing.T) {
 
 	// NOTE: I don't think this is true for every signature scheme
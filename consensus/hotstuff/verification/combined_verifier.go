@@ -72,6 +72,12 @@ func (c *CombinedVerifier) VerifyVote(signer *flow.Identity, sigData []byte, blo
 	if !stakingValid {
 		return false, nil
 	}
+	// A node without a safe random beacon key for the epoch (e.g. after a failed DKG)
+	// includes an explicitly invalid beacon share. Such a vote is valid based on its
+	// staking signature alone, but doesn't contribute to the threshold signature.
+	if IsInvalidBeaconShare(beaconShare) {
+		return true, nil
+	}
 	beaconValid, err := c.beacon.Verify(msg, beaconShare, beaconPubKey)
 	if err != nil {
 		return false, fmt.Errorf("internal error while verifying beacon signature: %w", err)
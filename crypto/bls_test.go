@@ -652,6 +652,99 @@ func TestBatchVerify(t *testing.T) {
 	})
 }
 
+// BLS multi-signature
+// batch verification malleability
+//
+// Verify that the batch verification rejects the identity signature, signatures swapped
+// between keys and duplicated signatures, while the remaining valid signatures still verify.
+func TestBatchVerifyMalleability(t *testing.T) {
+	r := time.Now().UnixNano()
+	mrand.Seed(r)
+	t.Logf("math rand seed is %d", r)
+	// random message
+	input := make([]byte, 100)
+	_, err := mrand.Read(input)
+	require.NoError(t, err)
+	kmac := NewBLSKMAC("test tag")
+	sigsNum := 10
+	sigs := make([]Signature, 0, sigsNum)
+	pks := make([]PublicKey, 0, sigsNum)
+	seed := make([]byte, KeyGenSeedMinLenBLSBLS12381)
+
+	for i := 0; i < sigsNum; i++ {
+		sk := randomSK(t, seed)
+		s, err := sk.Sign(input, kmac)
+		require.NoError(t, err)
+		sigs = append(sigs, s)
+		pks = append(pks, sk.PublicKey())
+	}
+
+	// copy the signatures so that each sub-test alters its own list
+	copySigs := func() []Signature {
+		sigsCopy := make([]Signature, 0, sigsNum)
+		for _, s := range sigs {
+			sigsCopy = append(sigsCopy, append(Signature{}, s...))
+		}
+		return sigsCopy
+	}
+	allValid := func() []bool {
+		expected := make([]bool, sigsNum)
+		for i := range expected {
+			expected[i] = true
+		}
+		return expected
+	}
+
+	// the identity (infinity point) signature must not verify against any key
+	t.Run("identity signature", func(t *testing.T) {
+		altered := copySigs()
+		expected := allValid()
+		identity := make([]byte, signatureLengthBLSBLS12381)
+		identity[0] = 0xC0 // compressed infinity point
+		for _, i := range []int{0, sigsNum / 2} {
+			altered[i] = identity
+			expected[i] = false
+		}
+
+		valid, err := BatchVerifyBLSSignaturesOneMessage(pks, altered, input, kmac)
+		require.NoError(t, err)
+		assert.Equal(t, expected, valid)
+
+		// all signatures are the identity
+		for i := range altered {
+			altered[i] = identity
+		}
+		valid, err = BatchVerifyBLSSignaturesOneMessage(pks, altered, input, kmac)
+		require.NoError(t, err)
+		assert.Equal(t, make([]bool, sigsNum), valid)
+	})
+
+	// valid signatures swapped between keys must be rejected, even though
+	// their sum equals the sum of the correct signatures
+	t.Run("swapped signatures", func(t *testing.T) {
+		altered := copySigs()
+		expected := allValid()
+		altered[1], altered[2] = altered[2], altered[1]
+		expected[1], expected[2] = false, false
+
+		valid, err := BatchVerifyBLSSignaturesOneMessage(pks, altered, input, kmac)
+		require.NoError(t, err)
+		assert.Equal(t, expected, valid)
+	})
+
+	// a valid signature duplicated over another key's signature must be rejected
+	t.Run("duplicated signature", func(t *testing.T) {
+		altered := copySigs()
+		expected := allValid()
+		altered[sigsNum-1] = altered[0]
+		expected[sigsNum-1] = false
+
+		valid, err := BatchVerifyBLSSignaturesOneMessage(pks, altered, input, kmac)
+		require.NoError(t, err)
+		assert.Equal(t, expected, valid)
+	})
+}
+
 // alter or fix a signature
 func alterSignature(s Signature) {
 	// this causes the signature to remain in G1 and be invalid
@@ -696,6 +789,20 @@ func BenchmarkBatchVerify(b *testing.B) {
 		b.StopTimer()
 	})
 
+	// Sequential verification bench of the same signatures for comparison
+	// (2*n) pairings.
+	b.Run("sequential", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < sigsNum; j++ {
+				valid, err := pks[j].Verify(sigs[j], input, kmac)
+				require.NoError(b, err)
+				require.True(b, valid)
+			}
+		}
+		b.StopTimer()
+	})
+
 	// Batch verify bench when some signatures are invalid
 	// - if only one signaure is invalid (a valid point in G1):
 	// less than (2*2*log(n)) pairings compared to (2*n) pairings for the simple verification.
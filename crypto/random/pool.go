@@ -0,0 +1,215 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/onflow/flow-go/crypto/hash"
+)
+
+// PoolState is a snapshot of the internal states of the child PRGs of a pool,
+// by purpose and shard index.
+type PoolState map[string][][]byte
+
+// PRGPool is a pool of independent PRGs for concurrent consumers. Each purpose
+// gets its own generator, which is made of several shards: each shard is a
+// child PRG derived from the pool seed, the purpose and the shard index, and
+// concurrent calls are spread over the shards to avoid lock contention.
+//
+// The child PRGs are derived lazily, the first time a purpose is requested.
+// As the derivation is deterministic, a pool with the same seed and number of
+// shards hands out the same child PRGs. However, the shard serving a call
+// depends on the order of the concurrent calls, so that outputs are only
+// reproducible for sequential consumers.
+//
+// PRGPool is safe for concurrent use.
+type PRGPool struct {
+	seed   []byte
+	shards int
+
+	mu       sync.Mutex
+	purposes map[string]*shardedRand // generators by purpose
+}
+
+// NewPRGPool returns a new pool deriving its child PRGs from the input seed,
+// with the given number of shards per purpose. A number of shards in the
+// order of the number of concurrent consumers of a purpose (e.g. GOMAXPROCS)
+// is recommended.
+//
+// It is recommended to sample the seed uniformly at random, the seed must be
+// at least 16 bytes long.
+func NewPRGPool(seed []byte, shards int) (*PRGPool, error) {
+	if len(seed) < 16 {
+		return nil, fmt.Errorf("pool seed length should be at least 16 bytes")
+	}
+	if shards <= 0 {
+		return nil, fmt.Errorf("number of shards should be positive")
+	}
+	return &PRGPool{
+		seed:     append([]byte(nil), seed...),
+		shards:   shards,
+		purposes: make(map[string]*shardedRand),
+	}, nil
+}
+
+// Rand returns the generator of the given purpose, deriving its child PRGs
+// if the purpose is requested for the first time. The returned generator is
+// safe for concurrent use.
+func (p *PRGPool) Rand(purpose string) (Rand, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rand, ok := p.purposes[purpose]
+	if ok {
+		return rand, nil
+	}
+	shards := make([]*threadSafeRand, 0, p.shards)
+	for i := 0; i < p.shards; i++ {
+		child, err := NewRand(p.childSeed(purpose, i))
+		if err != nil {
+			return nil, fmt.Errorf("could not derive child PRG %d for purpose %s: %w", i, purpose, err)
+		}
+		shards = append(shards, &threadSafeRand{prg: child})
+	}
+	rand = &shardedRand{shards: shards}
+	p.purposes[purpose] = rand
+	return rand, nil
+}
+
+// State returns a snapshot of the internal states of all child PRGs derived
+// so far.
+func (p *PRGPool) State() PoolState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state()
+}
+
+// Drain returns a snapshot of the internal states of all child PRGs derived
+// so far and removes them from the pool. The child PRGs are derived again from
+// the seed, the next time their purpose is requested, so that a test can replay
+// the same outputs. Generators returned before draining keep using their
+// (detached) child PRGs.
+func (p *PRGPool) Drain() PoolState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state := p.state()
+	p.purposes = make(map[string]*shardedRand)
+	return state
+}
+
+// Restore replaces the child PRGs of the purposes in the input snapshot with
+// PRGs restored from their internal states, as returned by State or Drain.
+// Generators returned before restoring keep using their previous child PRGs.
+func (p *PRGPool) Restore(state PoolState) error {
+	restored := make(map[string]*shardedRand, len(state))
+	for purpose, states := range state {
+		if len(states) != p.shards {
+			return fmt.Errorf("purpose %s has %d shard states, but the pool has %d shards", purpose, len(states), p.shards)
+		}
+		shards := make([]*threadSafeRand, 0, len(states))
+		for i, s := range states {
+			child, err := NewRand(s)
+			if err != nil {
+				return fmt.Errorf("could not restore child PRG %d for purpose %s: %w", i, purpose, err)
+			}
+			shards = append(shards, &threadSafeRand{prg: child})
+		}
+		restored[purpose] = &shardedRand{shards: shards}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for purpose, rand := range restored {
+		p.purposes[purpose] = rand
+	}
+	return nil
+}
+
+// state returns a snapshot of the internal states of all child PRGs.
+//
+// CAUTION: the caller MUST acquire the lock.
+func (p *PRGPool) state() PoolState {
+	state := make(PoolState, len(p.purposes))
+	for purpose, rand := range p.purposes {
+		state[purpose] = rand.shardStates()
+	}
+	return state
+}
+
+// childSeed derives the seed of the child PRG for the given purpose and shard
+// index, by hashing them together with the pool seed. The SHA2-256 digest
+// seeds two xorshift128+ instances.
+func (p *PRGPool) childSeed(purpose string, shard int) []byte {
+	// the length prefix makes the encoding of (purpose, shard) injective
+	data := make([]byte, 0, len(p.seed)+len(purpose)+8)
+	data = append(data, p.seed...)
+	data = appendUint32(data, uint32(len(purpose)))
+	data = append(data, purpose...)
+	data = appendUint32(data, uint32(shard))
+	return hash.NewSHA2_256().ComputeHash(data)
+}
+
+func appendUint32(data []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(data, buf[:]...)
+}
+
+// shardedRand is a generator made of several thread-safe child PRGs, which
+// serves each call from the next shard in a round-robin fashion.
+type shardedRand struct {
+	next   uint64 // accessed atomically
+	shards []*threadSafeRand
+}
+
+// shard returns the shard serving the next call.
+func (s *shardedRand) shard() *threadSafeRand {
+	i := atomic.AddUint64(&s.next, 1) - 1
+	return s.shards[i%uint64(len(s.shards))]
+}
+
+// shardStates returns the internal states of the shards, starting with the
+// shard serving the next call, so that a generator restored from the states
+// serves sequential calls from the same shards.
+func (s *shardedRand) shardStates() [][]byte {
+	next := atomic.LoadUint64(&s.next)
+	states := make([][]byte, 0, len(s.shards))
+	for i := 0; i < len(s.shards); i++ {
+		shard := s.shards[(next+uint64(i))%uint64(len(s.shards))]
+		states = append(states, shard.State())
+	}
+	return states
+}
+
+func (s *shardedRand) UintN(n uint64) uint64 {
+	return s.shard().UintN(n)
+}
+
+func (s *shardedRand) Permutation(n int) ([]int, error) {
+	return s.shard().Permutation(n)
+}
+
+func (s *shardedRand) SubPermutation(n int, m int) ([]int, error) {
+	return s.shard().SubPermutation(n, m)
+}
+
+func (s *shardedRand) Shuffle(n int, swap func(i, j int)) error {
+	return s.shard().Shuffle(n, swap)
+}
+
+func (s *shardedRand) Samples(n int, m int, swap func(i, j int)) error {
+	return s.shard().Samples(n, m, swap)
+}
+
+// State returns the concatenated internal states of all shards. Since calls
+// are spread over the shards, seeding a single PRG with the returned state does
+// not restore an identical generator, PRGPool.Restore must be used instead.
+func (s *shardedRand) State() []byte {
+	state := make([]byte, 0)
+	for _, shardState := range s.shardStates() {
+		state = append(state, shardState...)
+	}
+	return state
+}
@@ -0,0 +1,192 @@
+package random
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const concurrentConsumers = 32
+
+var poolSeed = []byte("pool seed for PRG pool tests ...")
+
+// runConcurrently runs the function in the given number of goroutines and waits
+// for all of them to return.
+func runConcurrently(goroutines int, f func()) {
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+	wg.Wait()
+}
+
+// exercise makes heavy use of the PRG, checking the outputs are in range.
+func exercise(t *testing.T, rng Rand) {
+	for i := 0; i < 1000; i++ {
+		assert.Less(t, rng.UintN(10), uint64(10))
+	}
+	items := make([]int, 10)
+	for i := 0; i < 100; i++ {
+		_, err := rng.Permutation(10)
+		assert.NoError(t, err)
+		err = rng.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+		assert.NoError(t, err)
+		_ = rng.State()
+	}
+}
+
+// TestThreadSafe tests the thread-safe wrapper under heavy concurrent usage,
+// and that it produces the same outputs as the wrapped PRG.
+func TestThreadSafe(t *testing.T) {
+	t.Run("concurrent usage", func(t *testing.T) {
+		prg, err := NewRand(poolSeed)
+		require.NoError(t, err)
+		rng := NewThreadSafe(prg)
+		runConcurrently(concurrentConsumers, func() { exercise(t, rng) })
+	})
+
+	t.Run("identical outputs", func(t *testing.T) {
+		prg, err := NewRand(poolSeed)
+		require.NoError(t, err)
+		rng := NewThreadSafe(prg)
+		reference, err := NewRand(poolSeed)
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			require.Equal(t, reference.UintN(1024), rng.UintN(1024))
+		}
+		assert.Equal(t, reference.State(), rng.State())
+	})
+}
+
+// TestPRGPool tests the derivation of child PRGs by purpose, and concurrent
+// usage of the pool.
+func TestPRGPool(t *testing.T) {
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := NewPRGPool(poolSeed[:15], 4)
+		assert.Error(t, err)
+		_, err = NewPRGPool(poolSeed, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("concurrent usage", func(t *testing.T) {
+		pool, err := NewPRGPool(poolSeed, 4)
+		require.NoError(t, err)
+		runConcurrently(concurrentConsumers, func() {
+			for _, purpose := range []string{"jitter", "backoff"} {
+				rng, err := pool.Rand(purpose)
+				require.NoError(t, err)
+				exercise(t, rng)
+			}
+			_ = pool.State()
+		})
+		assert.Len(t, pool.State(), 2)
+	})
+
+	t.Run("independent purposes", func(t *testing.T) {
+		pool, err := NewPRGPool(poolSeed, 4)
+		require.NoError(t, err)
+		jitter, err := pool.Rand("jitter")
+		require.NoError(t, err)
+		backoff, err := pool.Rand("backoff")
+		require.NoError(t, err)
+		same, err := pool.Rand("jitter")
+		require.NoError(t, err)
+		assert.Same(t, jitter, same)
+
+		state := pool.State()
+		require.Len(t, state["jitter"], 4)
+		require.Len(t, state["backoff"], 4)
+		for i := 0; i < 4; i++ {
+			assert.NotEqual(t, state["jitter"][i], state["backoff"][i])
+			for j := 0; j < i; j++ {
+				assert.NotEqual(t, state["jitter"][i], state["jitter"][j])
+			}
+		}
+		assert.NotEqual(t, jitter.State(), backoff.State())
+	})
+
+	t.Run("deterministic derivation", func(t *testing.T) {
+		pool, err := NewPRGPool(poolSeed, 4)
+		require.NoError(t, err)
+		other, err := NewPRGPool(poolSeed, 4)
+		require.NoError(t, err)
+		rng, err := pool.Rand("jitter")
+		require.NoError(t, err)
+		otherRng, err := other.Rand("jitter")
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			require.Equal(t, rng.UintN(1024), otherRng.UintN(1024))
+		}
+	})
+}
+
+// TestPRGPool_Replay tests that draining and restoring the pool replays the
+// outputs of sequential consumers.
+func TestPRGPool_Replay(t *testing.T) {
+	pool, err := NewPRGPool(poolSeed, 3)
+	require.NoError(t, err)
+
+	draw := func() []uint64 {
+		rng, err := pool.Rand("jitter")
+		require.NoError(t, err)
+		outputs := make([]uint64, 0, 50)
+		for i := 0; i < 50; i++ {
+			outputs = append(outputs, rng.UintN(1024))
+		}
+		return outputs
+	}
+
+	// after draining, the child PRGs are derived again from the seed
+	first := draw()
+	drained := pool.Drain()
+	assert.Empty(t, pool.State())
+	assert.Equal(t, first, draw())
+
+	// restoring a snapshot replays the outputs from the snapshot on
+	snapshot := pool.State()
+	second := draw()
+	require.NoError(t, pool.Restore(snapshot))
+	assert.Equal(t, second, draw())
+
+	// restoring the drained state continues after the first outputs
+	require.NoError(t, pool.Restore(drained))
+	assert.Equal(t, second, draw())
+
+	// the number of shards of the snapshot must match the pool
+	other, err := NewPRGPool(poolSeed, 2)
+	require.NoError(t, err)
+	assert.Error(t, other.Restore(snapshot))
+}
+
+// BenchmarkConcurrentPRG compares a single thread-safe PRG with the sharded
+// generator of a pool, under 32 concurrent consumers.
+func BenchmarkConcurrentPRG(b *testing.B) {
+	run := func(b *testing.B, rng Rand) {
+		b.ResetTimer()
+		runConcurrently(concurrentConsumers, func() {
+			for i := 0; i < b.N/concurrentConsumers; i++ {
+				_ = rng.UintN(1024)
+			}
+		})
+	}
+
+	b.Run("thread-safe wrapper", func(b *testing.B) {
+		prg, err := NewRand(poolSeed)
+		require.NoError(b, err)
+		run(b, NewThreadSafe(prg))
+	})
+
+	b.Run("sharded pool", func(b *testing.B) {
+		pool, err := NewPRGPool(poolSeed, concurrentConsumers)
+		require.NoError(b, err)
+		rng, err := pool.Rand("benchmark")
+		require.NoError(b, err)
+		run(b, rng)
+	})
+}
@@ -0,0 +1,64 @@
+package random
+
+import (
+	"sync"
+)
+
+// threadSafeRand wraps a PRG and serializes the access to it, so that it can be
+// shared across goroutines.
+type threadSafeRand struct {
+	mu  sync.Mutex
+	prg Rand
+}
+
+// NewThreadSafe returns a PRG that is safe for concurrent use, by serializing
+// all calls to the input PRG with a mutex.
+//
+// The input PRG must not be used directly anymore once it is wrapped.
+// Consumers sharing a PRG under heavy concurrency should rather use a PRGPool,
+// which avoids the lock contention of a single wrapped PRG.
+func NewThreadSafe(prg Rand) Rand {
+	return &threadSafeRand{
+		prg: prg,
+	}
+}
+
+func (t *threadSafeRand) UintN(n uint64) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.prg.UintN(n)
+}
+
+func (t *threadSafeRand) Permutation(n int) ([]int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.prg.Permutation(n)
+}
+
+func (t *threadSafeRand) SubPermutation(n int, m int) ([]int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.prg.SubPermutation(n, m)
+}
+
+// Shuffle permutes the data structure in place. The swap function is called
+// while holding the lock and must not use the same PRG.
+func (t *threadSafeRand) Shuffle(n int, swap func(i, j int)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.prg.Shuffle(n, swap)
+}
+
+// Samples picks random elements of the data structure in place. The swap
+// function is called while holding the lock and must not use the same PRG.
+func (t *threadSafeRand) Samples(n int, m int, swap func(i, j int)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.prg.Samples(n, m, swap)
+}
+
+func (t *threadSafeRand) State() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.prg.State()
+}
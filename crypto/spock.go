@@ -79,3 +79,16 @@ func SPOCKVerify(pk1 PublicKey, proof1 Signature, pk2 PublicKey, proof2 Signatur
 		return false, fmt.Errorf("SPoCK verification failed")
 	}
 }
+
+// VerifySPoCKPair verifies that the SPoCK proofs of two provers, e.g. of the executor and
+// of a verifier of the same chunk, were generated from the same secret data.
+//
+// In contrast to SPOCKVerify, a missing (empty) proof is reported as invalid input
+// rather than as an inconsistent pair, so that callers can tell an absent proof apart
+// from a proof of different data.
+func VerifySPoCKPair(pkA PublicKey, proofA Signature, pkB PublicKey, proofB Signature) (bool, error) {
+	if len(proofA) == 0 || len(proofB) == 0 {
+		return false, invalidInputsErrorf("SPoCK proofs must not be empty")
+	}
+	return SPOCKVerify(pkA, proofA, pkB, proofB)
+}
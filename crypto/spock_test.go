@@ -154,3 +154,54 @@ func TestSPOCKProveVerify(t *testing.T) {
 		assert.False(t, result)
 	})
 }
+
+// tests of happy and unhappy paths of VerifySPoCKPair
+func TestVerifySPoCKPair(t *testing.T) {
+	seed := make([]byte, KeyGenSeedMinLenBLSBLS12381)
+	skA := randomSK(t, seed)
+	skB := randomSK(t, seed)
+	kmac := NewBLSKMAC("spock test")
+
+	secret := make([]byte, 100)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+	otherSecret := make([]byte, 100)
+	_, err = rand.Read(otherSecret)
+	require.NoError(t, err)
+
+	proofA, err := SPOCKProve(skA, secret, kmac)
+	require.NoError(t, err)
+	proofB, err := SPOCKProve(skB, secret, kmac)
+	require.NoError(t, err)
+	otherProofB, err := SPOCKProve(skB, otherSecret, kmac)
+	require.NoError(t, err)
+
+	// proofs of the same secret are consistent
+	t.Run("matching secrets", func(t *testing.T) {
+		result, err := VerifySPoCKPair(skA.PublicKey(), proofA, skB.PublicKey(), proofB)
+		require.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	// proofs of different secrets are inconsistent
+	t.Run("mismatching secrets", func(t *testing.T) {
+		result, err := VerifySPoCKPair(skA.PublicKey(), proofA, skB.PublicKey(), otherProofB)
+		require.NoError(t, err)
+		assert.False(t, result)
+	})
+
+	// proofs verified against swapped public keys are inconsistent
+	t.Run("swapped public keys", func(t *testing.T) {
+		result, err := VerifySPoCKPair(skB.PublicKey(), proofA, skA.PublicKey(), proofB)
+		require.NoError(t, err)
+		assert.False(t, result)
+	})
+
+	// a missing proof is an invalid input
+	t.Run("missing proof", func(t *testing.T) {
+		result, err := VerifySPoCKPair(skA.PublicKey(), proofA, skB.PublicKey(), nil)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInputsError(err))
+		assert.False(t, result)
+	})
+}
@@ -0,0 +1,59 @@
+// +build !relic
+
+package crypto
+
+// SANDBOX ONLY: non-functional stand-ins for relic symbols so that dependent
+// packages type-check and tests that don't exercise BLS can run.
+
+import "github.com/onflow/flow-go/crypto/hash"
+
+func NewBLSKMAC(tag string) hash.Hasher {
+	kmac, _ := hash.NewKMAC_128([]byte(tag+"BLS_SIG_BLS12381G1_XOF:KMAC128_SSWU_RO_POP_"), []byte("H2C"), 128)
+	return kmac
+}
+func BLSInvalidSignature() Signature                      { return make([]byte, 48) }
+func BLSGeneratePOP(sk PrivateKey) (Signature, error)     { panic("sandbox stub") }
+func BLSVerifyPOP(pk PublicKey, s Signature) (bool, error) { panic("sandbox stub") }
+func AggregateBLSSignatures(sigs []Signature) (Signature, error) {
+	panic("sandbox stub")
+}
+func AggregateBLSPrivateKeys(keys []PrivateKey) (PrivateKey, error) { panic("sandbox stub") }
+func AggregateBLSPublicKeys(keys []PublicKey) (PublicKey, error)    { panic("sandbox stub") }
+func NeutralBLSPublicKey() PublicKey                                { panic("sandbox stub") }
+func RemoveBLSPublicKeys(aggKey PublicKey, keysToRemove []PublicKey) (PublicKey, error) {
+	panic("sandbox stub")
+}
+func VerifyBLSSignatureOneMessage(pks []PublicKey, s Signature, message []byte, kmac hash.Hasher) (bool, error) {
+	panic("sandbox stub")
+}
+func VerifyBLSSignatureManyMessages(pks []PublicKey, s Signature, messages [][]byte, kmac []hash.Hasher) (bool, error) {
+	panic("sandbox stub")
+}
+func BatchVerifyBLSSignaturesOneMessage(pks []PublicKey, sigs []Signature, message []byte, kmac hash.Hasher) ([]bool, error) {
+	panic("sandbox stub")
+}
+func NewFeldmanVSS(size int, threshold int, currentIndex int, processor DKGProcessor, leaderIndex int) (DKGState, error) {
+	panic("sandbox stub")
+}
+func NewFeldmanVSSQual(size int, threshold int, currentIndex int, processor DKGProcessor, leaderIndex int) (DKGState, error) {
+	panic("sandbox stub")
+}
+func NewJointFeldman(size int, threshold int, currentIndex int, processor DKGProcessor) (DKGState, error) {
+	panic("sandbox stub")
+}
+func ReconstructThresholdSignature(size int, threshold int, shares []Signature, signers []int) (Signature, error) {
+	panic("sandbox stub")
+}
+func EnoughShares(threshold int, sharesNumber int) (bool, error) { panic("sandbox stub") }
+func ThresholdSignKeyGen(size int, threshold int, seed []byte) ([]PrivateKey, []PublicKey, PublicKey, error) {
+	panic("sandbox stub")
+}
+func SPOCKProve(sk PrivateKey, data []byte, kmac hash.Hasher) (Signature, error) {
+	panic("sandbox stub")
+}
+func SPOCKVerifyAgainstData(pk PublicKey, proof Signature, data []byte, kmac hash.Hasher) (bool, error) {
+	panic("sandbox stub")
+}
+func SPOCKVerify(pk1 PublicKey, proof1 Signature, pk2 PublicKey, proof2 Signature) (bool, error) {
+	panic("sandbox stub")
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network/mocknetwork"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storage "github.com/onflow/flow-go/storage/badger"
@@ -71,7 +72,7 @@ func (suite *Suite) SetupTest() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
 	suite.snapshot.On("Epochs").Return(suite.epochQuery).Maybe()
-	header := unittest.BlockHeaderFixture()
+	header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(0))
 	params := new(protocol.Params)
 	params.On("Root").Return(&header, nil)
 	suite.state.On("Params").Return(params).Maybe()
@@ -117,6 +118,12 @@ func (suite *Suite) RunTest(
 			nil,
 			false,
 			backend.DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			backend.ArgumentLimits{},
 			nil,
 			nil,
 			suite.log,
@@ -293,6 +300,12 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			connFactory, // passing in the connection factory
 			false,
 			backend.DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			backend.ArgumentLimits{},
 			nil,
 			nil,
 			suite.log,
@@ -557,6 +570,12 @@ func (suite *Suite) TestGetSealedTransaction() {
 			connFactory,
 			false,
 			backend.DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			backend.ArgumentLimits{},
 			nil,
 			enNodeIDs.Strings(),
 			suite.log,
@@ -569,13 +588,14 @@ func (suite *Suite) TestGetSealedTransaction() {
 
 		// create the ingest engine
 		ingestEng, err := ingestion.New(suite.log, suite.net, suite.state, suite.me, suite.request, blocks, headers, collections,
-			transactions, results, receipts, metrics, collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, rpcEng)
+			transactions, results, receipts, metrics, collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, rpcEng, trace.NewNoopTracer())
 		require.NoError(suite.T(), err)
 
 		// 1. Assume that follower engine updated the block storage and the protocol state. The block is reported as sealed
 		err = blocks.Store(&block)
 		require.NoError(suite.T(), err)
 		suite.snapshot.On("Head").Return(block.Header, nil).Once()
+		suite.state.On("Boundaries").Return(block.Header, block.Header, nil).Maybe()
 
 		// 2. Ingest engine was notified by the follower engine about a new block.
 		// Follower engine --> Ingest engine
@@ -641,6 +661,12 @@ func (suite *Suite) TestExecuteScript() {
 			connFactory,
 			false,
 			backend.DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			backend.ArgumentLimits{},
 			nil,
 			flow.IdentifierList(identities.NodeIDs()).Strings(),
 			suite.log,
@@ -662,7 +688,7 @@ func (suite *Suite) TestExecuteScript() {
 			Once()
 		// create the ingest engine
 		ingestEng, err := ingestion.New(suite.log, suite.net, suite.state, suite.me, suite.request, blocks, headers, collections,
-			transactions, results, receipts, metrics, collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, nil)
+			transactions, results, receipts, metrics, collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, nil, trace.NewNoopTracer())
 		require.NoError(suite.T(), err)
 
 		// create a block and a seal pointing to that block
@@ -0,0 +1,103 @@
+package access
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/engine/access/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGRPCReflectionAndHealthServices tests that the RPC engine builder registers the
+// reflection and health services, and that the health status follows the engine lifecycle.
+func TestGRPCReflectionAndHealthServices(t *testing.T) {
+	config := rpc.Config{
+		UnsecureGRPCListenAddr: ":0", // :0 to let the OS pick a free port
+		SecureGRPCListenAddr:   ":0",
+		HTTPListenAddr:         ":0",
+	}
+
+	rpcEng := rpc.NewRPCEngineBuilder(
+		rpc.New(zerolog.New(os.Stdout), new(protocol.State), config, new(accessmock.AccessAPIClient), nil,
+			new(storagemock.Blocks), new(storagemock.Headers), new(storagemock.Collections), new(storagemock.Transactions),
+			nil, nil, flow.Testnet, metrics.NewNoopCollector(), 0, 0, false, false, nil, nil),
+	).WithReflection().WithHealthService().Build()
+
+	unittest.AssertClosesBefore(t, rpcEng.Ready(), 2*time.Second)
+
+	// wait for the server to startup
+	require.Eventually(t, func() bool {
+		return rpcEng.UnsecureGRPCAddress() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	conn, err := grpc.Dial(rpcEng.UnsecureGRPCAddress().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("reflection lists the access API", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		require.NoError(t, err)
+
+		err = stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+
+		services := make([]string, 0)
+		for _, service := range resp.GetListServicesResponse().GetService() {
+			services = append(services, service.GetName())
+		}
+		assert.Contains(t, services, accessproto.AccessAPI_ServiceDesc.ServiceName)
+		assert.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+	})
+
+	t.Run("health reports serving once the engine is ready", func(t *testing.T) {
+		client := healthpb.NewHealthClient(conn)
+		for _, service := range []string{"", accessproto.AccessAPI_ServiceDesc.ServiceName} {
+			require.Eventually(t, func() bool {
+				resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+				return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+			}, 5*time.Second, 10*time.Millisecond)
+		}
+	})
+
+	t.Run("health reports not serving once the engine shuts down", func(t *testing.T) {
+		watchCtx, cancelWatch := context.WithCancel(ctx)
+		stream, err := healthpb.NewHealthClient(conn).Watch(watchCtx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		done := rpcEng.Done()
+
+		resp, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+		// the graceful stop waits for the client connection to be closed
+		cancelWatch()
+		require.NoError(t, conn.Close())
+		unittest.AssertClosesBefore(t, done, 2*time.Second)
+	})
+}
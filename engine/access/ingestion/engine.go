@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
@@ -17,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -69,6 +71,7 @@ type Engine struct {
 	collectionsToMarkFinalized *stdmap.Times
 	collectionsToMarkExecuted  *stdmap.Times
 	blocksToMarkExecuted       *stdmap.Times
+	tracer                     module.Tracer
 
 	rpcEngine *rpc.Engine
 }
@@ -91,6 +94,7 @@ func New(
 	collectionsToMarkExecuted *stdmap.Times,
 	blocksToMarkExecuted *stdmap.Times,
 	rpcEngine *rpc.Engine,
+	tracer module.Tracer,
 ) (*Engine, error) {
 
 	// initialize the propagation engine with its dependencies
@@ -111,6 +115,7 @@ func New(
 		collectionsToMarkExecuted:  collectionsToMarkExecuted,
 		blocksToMarkExecuted:       blocksToMarkExecuted,
 		rpcEngine:                  rpcEngine,
+		tracer:                     tracer,
 	}
 
 	// register engine with the execution receipt provider
@@ -209,11 +214,16 @@ func (e *Engine) OnFinalizedBlock(hb *model.Block) {
 
 // processBlock handles an incoming finalized block.
 func (e *Engine) processFinalizedBlock(blockID flow.Identifier) error {
+	span, _, isSampled := e.tracer.StartBlockSpan(context.Background(), blockID, trace.ACCIngestionProcessFinalizedBlock)
+	defer span.Finish()
 
 	block, err := e.blocks.ByID(blockID)
 	if err != nil {
 		return fmt.Errorf("failed to lookup block: %w", err)
 	}
+	if isSampled {
+		span.LogFields(log.Int("guarantees", len(block.Payload.Guarantees)))
+	}
 
 	// Notify rpc handler of new finalized block height
 	e.rpcEngine.SubmitLocal(block)
@@ -271,6 +281,15 @@ func (e *Engine) trackFinalizedMetricForBlock(hb *model.Block) {
 }
 
 func (e *Engine) handleExecutionReceipt(originID flow.Identifier, r *flow.ExecutionReceipt) error {
+	span, _, isSampled := e.tracer.StartBlockSpan(context.Background(), r.ExecutionResult.BlockID, trace.ACCIngestionHandleExecutionReceipt)
+	defer span.Finish()
+	if isSampled {
+		span.LogFields(
+			log.String("result_id", r.ExecutionResult.ID().String()),
+			log.String("executor", r.ExecutorID.String()),
+		)
+	}
+
 	// persist the execution receipt locally, storing will also index the receipt
 	err := e.executionReceipts.Store(r)
 	if err != nil {
@@ -0,0 +1,302 @@
+package observer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/onflow/flow-go/engine"
+	observerproto "github.com/onflow/flow-go/engine/access/observer/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+	jsoncodec "github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// Config defines the configurable options of the consensus message observer.
+type Config struct {
+	// Enabled determines whether the engine subscribes to the receipts and approvals channels.
+	// Approvals are only observed if access nodes are allowed on the approvals channels, see
+	// engine.AllowAccessObservingApprovals.
+	Enabled bool
+	// MaxSubscribers is the maximum number of concurrent stream subscribers.
+	MaxSubscribers uint
+	// SubscriberBufferSize is the number of messages buffered per subscriber. Messages
+	// are dropped for a subscriber whose buffer is full.
+	SubscriberBufferSize uint
+	// SubscriberRateLimit is the maximum number of messages per second sent to a
+	// single subscriber. Zero means no limit.
+	SubscriberRateLimit float64
+}
+
+// DefaultConfig returns the default configuration of the consensus message observer,
+// which is disabled.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:              false,
+		MaxSubscribers:       16,
+		SubscriberBufferSize: 1000,
+		SubscriberRateLimit:  100,
+	}
+}
+
+// subscriber holds the bounded message buffer of a single stream subscriber.
+type subscriber struct {
+	messages       chan *observerproto.ConsensusMessage
+	includePayload bool
+}
+
+// Engine is a read-only observer of the execution receipts and result approvals
+// gossiped through the network. It validates the origin attribution of each message
+// and relays a summary of it to the subscribers of the ConsensusObserverAPI.
+type Engine struct {
+	observerproto.UnimplementedConsensusObserverAPIServer
+
+	unit   *engine.Unit
+	log    zerolog.Logger
+	me     module.Local
+	state  protocol.State
+	codec  network.Codec
+	config Config
+
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// New creates a new consensus message observer. The engine only registers with the
+// receipts and approvals channels if it is enabled in the config, and with the approvals
+// channel only if access nodes are allowed on it.
+func New(log zerolog.Logger, net network.Network, me module.Local, state protocol.State, config Config) (*Engine, error) {
+	e := &Engine{
+		unit:        engine.NewUnit(),
+		log:         log.With().Str("engine", "consensus_observer").Logger(),
+		me:          me,
+		state:       state,
+		codec:       jsoncodec.NewCodec(),
+		config:      config,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+
+	if !config.Enabled {
+		return e, nil
+	}
+
+	_, err := net.Register(engine.ReceiveReceipts, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register for receipts: %w", err)
+	}
+
+	roles, _ := engine.RolesByChannel(engine.ReceiveApprovals)
+	if !roles.Contains(flow.RoleAccess) {
+		e.log.Warn().Msg("access nodes are not allowed on the approvals channel, only receipts are observed")
+		return e, nil
+	}
+	_, err = net.Register(engine.ReceiveApprovals, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register for approvals: %w", err)
+	}
+
+	return e, nil
+}
+
+// Ready returns a ready channel that is closed once the engine has fully started.
+func (e *Engine) Ready() <-chan struct{} {
+	return e.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped.
+// All subscriber streams are terminated.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+// SubmitLocal submits an event originating on the local node.
+func (e *Engine) SubmitLocal(event interface{}) {
+	e.Submit(engine.ReceiveReceipts, e.me.NodeID(), event)
+}
+
+// Submit submits the given event from the node with the given origin ID
+// for processing in a non-blocking manner. It returns instantly and logs
+// a potential processing error internally when done.
+func (e *Engine) Submit(channel network.Channel, originID flow.Identifier, event interface{}) {
+	e.unit.Launch(func() {
+		err := e.process(originID, event)
+		if err != nil {
+			e.log.Warn().Err(err).Hex("origin_id", originID[:]).Msg("could not process observed message")
+		}
+	})
+}
+
+// ProcessLocal processes an event originating on the local node.
+func (e *Engine) ProcessLocal(event interface{}) error {
+	return e.Process(engine.ReceiveReceipts, e.me.NodeID(), event)
+}
+
+// Process processes the given event from the node with the given origin ID in
+// a blocking manner. It returns the potential processing error when done.
+func (e *Engine) Process(channel network.Channel, originID flow.Identifier, event interface{}) error {
+	return e.unit.Do(func() error {
+		return e.process(originID, event)
+	})
+}
+
+// process validates the origin attribution of the given event and relays it to all subscribers.
+// Expected errors during normal operations:
+//  * engine.InvalidInputError if the event is not attributed to its origin
+//  * engine.UnverifiableInputError if the origin can't be checked against the protocol state
+func (e *Engine) process(originID flow.Identifier, event interface{}) error {
+	var (
+		messageType observerproto.MessageType
+		entityID    flow.Identifier
+		blockID     flow.Identifier
+		err         error
+	)
+
+	switch entity := event.(type) {
+	case *flow.ExecutionReceipt:
+		messageType = observerproto.MessageType_MESSAGE_TYPE_EXECUTION_RECEIPT
+		err = e.validateOrigin(originID, entity.ExecutorID, flow.RoleExecution)
+		entityID = entity.ID()
+		blockID = entity.ExecutionResult.BlockID
+	case *flow.ResultApproval:
+		messageType = observerproto.MessageType_MESSAGE_TYPE_RESULT_APPROVAL
+		err = e.validateOrigin(originID, entity.Body.ApproverID, flow.RoleVerification)
+		entityID = entity.ID()
+		blockID = entity.Body.BlockID
+	default:
+		return engine.NewInvalidInputErrorf("invalid event type (%T)", event)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid origin of %s %x: %w", messageType, entityID, err)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if len(e.subscribers) == 0 {
+		return nil
+	}
+
+	observed := timestamppb.Now()
+	var payload []byte
+	for sub := range e.subscribers {
+		if sub.includePayload && payload == nil {
+			payload, err = e.codec.Encode(event)
+			if err != nil {
+				return fmt.Errorf("could not encode %s %x: %w", messageType, entityID, err)
+			}
+		}
+
+		msg := &observerproto.ConsensusMessage{
+			Type:      messageType,
+			OriginId:  originID[:],
+			EntityId:  entityID[:],
+			BlockId:   blockID[:],
+			Timestamp: observed,
+		}
+		if sub.includePayload {
+			msg.Payload = payload
+		}
+
+		// never block the network on a slow subscriber
+		select {
+		case sub.messages <- msg:
+		default:
+			e.log.Debug().
+				Str("type", messageType.String()).
+				Hex("entity_id", entityID[:]).
+				Msg("dropping observed message for subscriber with full buffer")
+		}
+	}
+
+	return nil
+}
+
+// validateOrigin checks that the message was sent by the node it is attributed to,
+// and that this node is a staked node with the expected role.
+func (e *Engine) validateOrigin(originID flow.Identifier, signerID flow.Identifier, role flow.Role) error {
+	if originID != signerID {
+		return engine.NewInvalidInputErrorf("message attributed to %x was sent by %x", signerID, originID)
+	}
+
+	staked, err := protocol.IsNodeStakedWithRoleAt(e.state.Final(), originID, role)
+	if err != nil {
+		return engine.NewUnverifiableInputError("could not check stake of origin %x: %w", originID, err)
+	}
+	if !staked {
+		return engine.NewInvalidInputErrorf("origin %x is not a staked %s node", originID, role)
+	}
+
+	return nil
+}
+
+// SubscribeConsensusMessages streams the observed receipts and approvals to the caller,
+// until the caller cancels the stream or the engine shuts down.
+func (e *Engine) SubscribeConsensusMessages(req *observerproto.SubscribeConsensusMessagesRequest, stream observerproto.ConsensusObserverAPI_SubscribeConsensusMessagesServer) error {
+	if !e.config.Enabled {
+		return status.Error(codes.Unavailable, "observing consensus messages is not enabled on this node")
+	}
+
+	sub, err := e.subscribe(req.GetIncludePayload())
+	if err != nil {
+		return err
+	}
+	defer e.unsubscribe(sub)
+
+	limit := rate.Inf
+	if e.config.SubscriberRateLimit > 0 {
+		limit = rate.Limit(e.config.SubscriberRateLimit)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-e.unit.Quit():
+			return status.Error(codes.Unavailable, "node is shutting down")
+		case msg := <-sub.messages:
+			err := limiter.Wait(ctx)
+			if err != nil {
+				return nil
+			}
+			err = stream.Send(msg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribe adds a new subscriber, unless the maximum number of subscribers is reached.
+func (e *Engine) subscribe(includePayload bool) (*subscriber, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if uint(len(e.subscribers)) >= e.config.MaxSubscribers {
+		return nil, status.Errorf(codes.ResourceExhausted, "maximum number of subscribers (%d) reached", e.config.MaxSubscribers)
+	}
+
+	sub := &subscriber{
+		messages:       make(chan *observerproto.ConsensusMessage, e.config.SubscriberBufferSize),
+		includePayload: includePayload,
+	}
+	e.subscribers[sub] = struct{}{}
+
+	return sub, nil
+}
+
+// unsubscribe removes the given subscriber.
+func (e *Engine) unsubscribe(sub *subscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.subscribers, sub)
+}
@@ -0,0 +1,253 @@
+package observer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine"
+	observerproto "github.com/onflow/flow-go/engine/access/observer/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/state/protocol"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// observerFixture holds a consensus message observer served over a local gRPC server.
+type observerFixture struct {
+	engine     *Engine
+	net        *mocknetwork.Network
+	client     observerproto.ConsensusObserverAPIClient
+	executor   *flow.Identity
+	verifier   *flow.Identity
+	identities flow.IdentityList
+}
+
+func newObserverFixture(t *testing.T, config Config) *observerFixture {
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	identities := flow.IdentityList{executor, verifier}
+
+	snapshot := new(mockprotocol.Snapshot)
+	snapshot.On("Identity", mock.Anything).Return(
+		func(nodeID flow.Identifier) *flow.Identity {
+			identity, _ := identities.ByNodeID(nodeID)
+			return identity
+		},
+		func(nodeID flow.Identifier) error {
+			_, ok := identities.ByNodeID(nodeID)
+			if !ok {
+				return protocol.IdentityNotFoundError{NodeID: nodeID}
+			}
+			return nil
+		},
+	)
+	state := new(mockprotocol.State)
+	state.On("Final").Return(snapshot)
+
+	me := new(mockmodule.Local)
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+
+	// the approvals channel is only observed if access nodes are allowed on it
+	engine.AllowAccessObservingApprovals()
+	network := new(mocknetwork.Network)
+	if config.Enabled {
+		network.On("Register", engine.ReceiveReceipts, mock.Anything).Return(new(mocknetwork.Conduit), nil).Once()
+		network.On("Register", engine.ReceiveApprovals, mock.Anything).Return(new(mocknetwork.Conduit), nil).Once()
+	}
+
+	eng, err := New(unittest.Logger(), network, me, state, config)
+	require.NoError(t, err)
+	unittest.AssertClosesBefore(t, eng.Ready(), time.Second)
+
+	server := grpc.NewServer()
+	observerproto.RegisterConsensusObserverAPIServer(server, eng)
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		unittest.AssertClosesBefore(t, eng.Done(), time.Second)
+		_ = conn.Close()
+		server.Stop()
+	})
+
+	return &observerFixture{
+		engine:     eng,
+		net:        network,
+		client:     observerproto.NewConsensusObserverAPIClient(conn),
+		executor:   executor,
+		verifier:   verifier,
+		identities: identities,
+	}
+}
+
+// subscribe opens a stream and waits until the engine has registered the subscriber.
+func (f *observerFixture) subscribe(t *testing.T, ctx context.Context, includePayload bool) observerproto.ConsensusObserverAPI_SubscribeConsensusMessagesClient {
+	subscribers := f.subscriberCount()
+	stream, err := f.client.SubscribeConsensusMessages(ctx, &observerproto.SubscribeConsensusMessagesRequest{
+		IncludePayload: includePayload,
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return f.subscriberCount() > subscribers
+	}, time.Second, 10*time.Millisecond)
+	return stream
+}
+
+func (f *observerFixture) subscriberCount() int {
+	f.engine.mu.RLock()
+	defer f.engine.mu.RUnlock()
+	return len(f.engine.subscribers)
+}
+
+// TestObserver_Enabled tests that injected receipts and approvals are streamed to
+// subscribers with the correct attribution.
+func TestObserver_Enabled(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	f := newObserverFixture(t, config)
+	f.net.AssertExpectations(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := f.subscribe(t, ctx, false)
+
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(f.executor.NodeID))
+	approval := unittest.ResultApprovalFixture(unittest.WithApproverID(f.verifier.NodeID))
+
+	require.NoError(t, f.engine.Process(engine.ReceiveReceipts, f.executor.NodeID, receipt))
+	require.NoError(t, f.engine.Process(engine.ReceiveApprovals, f.verifier.NodeID, approval))
+
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, observerproto.MessageType_MESSAGE_TYPE_EXECUTION_RECEIPT, msg.GetType())
+	assert.Equal(t, f.executor.NodeID, flow.HashToID(msg.GetOriginId()))
+	assert.Equal(t, receipt.ID(), flow.HashToID(msg.GetEntityId()))
+	assert.Equal(t, receipt.ExecutionResult.BlockID, flow.HashToID(msg.GetBlockId()))
+	assert.NotNil(t, msg.GetTimestamp())
+	assert.Empty(t, msg.GetPayload())
+
+	msg, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, observerproto.MessageType_MESSAGE_TYPE_RESULT_APPROVAL, msg.GetType())
+	assert.Equal(t, f.verifier.NodeID, flow.HashToID(msg.GetOriginId()))
+	assert.Equal(t, approval.ID(), flow.HashToID(msg.GetEntityId()))
+	assert.Equal(t, approval.Body.BlockID, flow.HashToID(msg.GetBlockId()))
+	assert.Empty(t, msg.GetPayload())
+}
+
+// TestObserver_InvalidOrigin tests that messages which are not attributed to their
+// origin, or which originate from a node with the wrong role, are rejected.
+func TestObserver_InvalidOrigin(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	f := newObserverFixture(t, config)
+
+	t.Run("receipt relayed by another node", func(t *testing.T) {
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(f.executor.NodeID))
+		err := f.engine.Process(engine.ReceiveReceipts, f.verifier.NodeID, receipt)
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("approval from a non-verification node", func(t *testing.T) {
+		approval := unittest.ResultApprovalFixture(unittest.WithApproverID(f.executor.NodeID))
+		err := f.engine.Process(engine.ReceiveApprovals, f.executor.NodeID, approval)
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("unknown origin", func(t *testing.T) {
+		executorID := unittest.IdentifierFixture()
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(executorID))
+		err := f.engine.Process(engine.ReceiveReceipts, executorID, receipt)
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+}
+
+// TestObserver_Payload tests that subscribers which opt in receive the encoded entity.
+func TestObserver_Payload(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	f := newObserverFixture(t, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := f.subscribe(t, ctx, true)
+
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(f.executor.NodeID))
+	require.NoError(t, f.engine.Process(engine.ReceiveReceipts, f.executor.NodeID, receipt))
+
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, msg.GetPayload())
+
+	decoded, err := f.engine.codec.Decode(msg.GetPayload())
+	require.NoError(t, err)
+	require.IsType(t, &flow.ExecutionReceipt{}, decoded)
+	assert.Equal(t, receipt.ID(), decoded.(*flow.ExecutionReceipt).ID())
+}
+
+// TestObserver_Disabled tests that a disabled observer doesn't subscribe to any channel
+// and rejects subscribers.
+func TestObserver_Disabled(t *testing.T) {
+	f := newObserverFixture(t, DefaultConfig())
+	f.net.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+
+	stream, err := f.client.SubscribeConsensusMessages(context.Background(), &observerproto.SubscribeConsensusMessagesRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// TestObserver_MaxSubscribers tests that subscribers beyond the limit are rejected.
+func TestObserver_MaxSubscribers(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.MaxSubscribers = 1
+	f := newObserverFixture(t, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f.subscribe(t, ctx, false)
+
+	stream, err := f.client.SubscribeConsensusMessages(ctx, &observerproto.SubscribeConsensusMessagesRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// TestObserver_FullBuffer tests that messages for a subscriber with a full buffer are
+// dropped without blocking the engine.
+func TestObserver_FullBuffer(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.SubscriberBufferSize = 1
+	f := newObserverFixture(t, config)
+
+	sub, err := f.engine.subscribe(false)
+	require.NoError(t, err)
+	defer f.engine.unsubscribe(sub)
+
+	unittest.RequireReturnsBefore(t, func() {
+		for i := 0; i < 3; i++ {
+			receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(f.executor.NodeID))
+			require.NoError(t, f.engine.Process(engine.ReceiveReceipts, f.executor.NodeID, receipt))
+		}
+	}, time.Second, "processing blocked on a full subscriber buffer")
+	assert.Len(t, sub.messages, 1)
+}
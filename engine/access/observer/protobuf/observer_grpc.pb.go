@@ -0,0 +1,130 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package observer
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ConsensusObserverAPIClient is the client API for ConsensusObserverAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConsensusObserverAPIClient interface {
+	// SubscribeConsensusMessages streams the consensus messages observed by the access node.
+	SubscribeConsensusMessages(ctx context.Context, in *SubscribeConsensusMessagesRequest, opts ...grpc.CallOption) (ConsensusObserverAPI_SubscribeConsensusMessagesClient, error)
+}
+
+type consensusObserverAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConsensusObserverAPIClient(cc grpc.ClientConnInterface) ConsensusObserverAPIClient {
+	return &consensusObserverAPIClient{cc}
+}
+
+func (c *consensusObserverAPIClient) SubscribeConsensusMessages(ctx context.Context, in *SubscribeConsensusMessagesRequest, opts ...grpc.CallOption) (ConsensusObserverAPI_SubscribeConsensusMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConsensusObserverAPI_ServiceDesc.Streams[0], "/observer.ConsensusObserverAPI/SubscribeConsensusMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &consensusObserverAPISubscribeConsensusMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConsensusObserverAPI_SubscribeConsensusMessagesClient interface {
+	Recv() (*ConsensusMessage, error)
+	grpc.ClientStream
+}
+
+type consensusObserverAPISubscribeConsensusMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *consensusObserverAPISubscribeConsensusMessagesClient) Recv() (*ConsensusMessage, error) {
+	m := new(ConsensusMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConsensusObserverAPIServer is the server API for ConsensusObserverAPI service.
+// All implementations must embed UnimplementedConsensusObserverAPIServer
+// for forward compatibility
+type ConsensusObserverAPIServer interface {
+	// SubscribeConsensusMessages streams the consensus messages observed by the access node.
+	SubscribeConsensusMessages(*SubscribeConsensusMessagesRequest, ConsensusObserverAPI_SubscribeConsensusMessagesServer) error
+	mustEmbedUnimplementedConsensusObserverAPIServer()
+}
+
+// UnimplementedConsensusObserverAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedConsensusObserverAPIServer struct {
+}
+
+func (UnimplementedConsensusObserverAPIServer) SubscribeConsensusMessages(*SubscribeConsensusMessagesRequest, ConsensusObserverAPI_SubscribeConsensusMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeConsensusMessages not implemented")
+}
+func (UnimplementedConsensusObserverAPIServer) mustEmbedUnimplementedConsensusObserverAPIServer() {}
+
+// UnsafeConsensusObserverAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConsensusObserverAPIServer will
+// result in compilation errors.
+type UnsafeConsensusObserverAPIServer interface {
+	mustEmbedUnimplementedConsensusObserverAPIServer()
+}
+
+func RegisterConsensusObserverAPIServer(s grpc.ServiceRegistrar, srv ConsensusObserverAPIServer) {
+	s.RegisterService(&ConsensusObserverAPI_ServiceDesc, srv)
+}
+
+func _ConsensusObserverAPI_SubscribeConsensusMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeConsensusMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConsensusObserverAPIServer).SubscribeConsensusMessages(m, &consensusObserverAPISubscribeConsensusMessagesServer{stream})
+}
+
+type ConsensusObserverAPI_SubscribeConsensusMessagesServer interface {
+	Send(*ConsensusMessage) error
+	grpc.ServerStream
+}
+
+type consensusObserverAPISubscribeConsensusMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *consensusObserverAPISubscribeConsensusMessagesServer) Send(m *ConsensusMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ConsensusObserverAPI_ServiceDesc is the grpc.ServiceDesc for ConsensusObserverAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConsensusObserverAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "observer.ConsensusObserverAPI",
+	HandlerType: (*ConsensusObserverAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeConsensusMessages",
+			Handler:       _ConsensusObserverAPI_SubscribeConsensusMessages_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protobuf/observer.proto",
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/generated"
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -207,6 +208,67 @@ func transactionResponse(tx *flow.TransactionBody) *generated.Transaction {
 	}
 }
 
+func transactionResultResponse(result *access.TransactionResult) *generated.TransactionResult {
+	var status generated.TransactionStatus
+	switch result.Status {
+	case flow.TransactionStatusPending:
+		status = generated.PENDING
+	case flow.TransactionStatusFinalized:
+		status = generated.FINALIZED
+	case flow.TransactionStatusExecuted:
+		status = generated.EXECUTED
+	case flow.TransactionStatusSealed:
+		status = generated.SEALED
+	case flow.TransactionStatusExpired:
+		status = generated.EXPIRED
+	}
+
+	events := make([]generated.Event, len(result.Events))
+	for i, event := range result.Events {
+		events[i] = generated.Event{
+			Type_:            string(event.Type),
+			TransactionId:    event.TransactionID.String(),
+			TransactionIndex: int32(event.TransactionIndex),
+			EventIndex:       int32(event.EventIndex),
+			Payload:          base64.StdEncoding.EncodeToString(event.Payload),
+		}
+	}
+
+	response := &generated.TransactionResult{
+		BlockId:      result.BlockID.String(),
+		ErrorMessage: result.ErrorMessage,
+		Events:       events,
+	}
+	if status != "" {
+		response.Status = &status
+	}
+	return response
+}
+
+// executionResult extends the generated execution result model with the execution data ID.
+// The generated package is produced from the Access API spec, which is maintained outside this
+// repository and doesn't define the field, so it is declared here to survive regenerations.
+type executionResult struct {
+	generated.ExecutionResult
+	// ExecutionDataId is omitted for results of version ExecutionResultV0, which don't commit to
+	// the execution data of their block.
+	ExecutionDataId string `json:"execution_data_id,omitempty"`
+}
+
+func executionResultResponse(result *flow.ExecutionResult) *executionResult {
+	response := &executionResult{
+		ExecutionResult: generated.ExecutionResult{
+			Id:      result.ID().String(),
+			BlockId: result.BlockID.String(),
+			Events:  []generated.Event{},
+		},
+	}
+	if result.Version() != flow.ExecutionResultV0 {
+		response.ExecutionDataId = result.ExecutionDataID.String()
+	}
+	return response
+}
+
 func blockResponse(flowBlock *flow.Block) *generated.Block {
 	return &generated.Block{
 		Header:  blockHeaderResponse(flowBlock.Header),
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestExecutionResultResponse tests that execution results are converted with their execution
+// data ID, and that the ID is omitted for results without one.
+func TestExecutionResultResponse(t *testing.T) {
+	t.Run("with execution data ID", func(t *testing.T) {
+		executionDataID := unittest.IdentifierFixture()
+		result := unittest.ExecutionResultFixture(unittest.WithExecutionDataID(executionDataID))
+
+		response := executionResultResponse(result)
+		assert.Equal(t, result.ID().String(), response.Id)
+		assert.Equal(t, result.BlockID.String(), response.BlockId)
+		assert.Equal(t, executionDataID.String(), response.ExecutionDataId)
+
+		fields := encodeFields(t, response)
+		assert.Equal(t, executionDataID.String(), fields["execution_data_id"])
+		assert.Equal(t, result.ID().String(), fields["id"])
+	})
+
+	t.Run("without execution data ID", func(t *testing.T) {
+		result := unittest.ExecutionResultFixture()
+
+		response := executionResultResponse(result)
+		assert.Equal(t, result.ID().String(), response.Id)
+		assert.Empty(t, response.ExecutionDataId)
+
+		fields := encodeFields(t, response)
+		assert.NotContains(t, fields, "execution_data_id")
+		assert.Equal(t, result.BlockID.String(), fields["block_id"])
+	})
+}
+
+// encodeFields returns the fields of the JSON encoding of the given value.
+func encodeFields(t *testing.T, value interface{}) map[string]interface{} {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	return fields
+}
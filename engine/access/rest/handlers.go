@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/generated"
+	"github.com/onflow/flow-go/engine/access/rest/middleware"
 )
 
 const BlockIDCntLimit = 50
@@ -23,14 +25,18 @@ var MaxAllowedBlockIDsCnt = BlockIDCntLimit
 
 // Handlers provide collection of handlers used by the API server
 type Handlers struct {
-	backend access.API
-	logger  zerolog.Logger
+	backend             access.API
+	logger              zerolog.Logger
+	maxErrorMessageSize uint // budget in bytes for transaction error messages, 0 means the default
 }
 
-func NewHandlers(backend access.API, logger zerolog.Logger) *Handlers {
+// NewHandlers creates the REST handlers, which cap transaction error messages at the given budget
+// in bytes (0 means access.DefaultMaxErrorMessageSize).
+func NewHandlers(backend access.API, logger zerolog.Logger, maxErrorMessageSize uint) *Handlers {
 	return &Handlers{
-		backend: backend,
-		logger:  logger,
+		backend:             backend,
+		logger:              logger,
+		maxErrorMessageSize: maxErrorMessageSize,
 	}
 }
 
@@ -38,8 +44,6 @@ func (h *Handlers) BlocksIdGet(w http.ResponseWriter, r *http.Request) {
 	// create h logger for the request
 	errorLogger := h.logger.With().Str("request_url", r.URL.String()).Logger()
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-
 	vars := mux.Vars(r)
 	idParam := vars["id"]
 
@@ -72,6 +76,11 @@ func (h *Handlers) BlocksIdGet(w http.ResponseWriter, r *http.Request) {
 				h.errorResponse(w, http.StatusNotFound, fmt.Sprintf("block with ID %s not found", id), errorLogger)
 				return
 			}
+			// if the block is below the history served by the node, return HTTP RequestedRangeNotSatisfiable
+			if status.Code(err) == codes.OutOfRange {
+				h.errorResponse(w, http.StatusRequestedRangeNotSatisfiable, status.Convert(err).Message(), errorLogger)
+				return
+			}
 			errorLogger.Error().Err(err).Str("block_id", id).Msg("failed to look up block")
 			h.errorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to look up block with ID %s", id), errorLogger)
 			return
@@ -79,7 +88,31 @@ func (h *Handlers) BlocksIdGet(w http.ResponseWriter, r *http.Request) {
 		blocks[i] = blockResponse(flowBlock)
 	}
 
-	h.jsonResponse(w, blocks, errorLogger)
+	h.response(w, r, blocks, errorLogger)
+}
+
+// GetExecutionResultByBlockID gets the execution result for the block with the ID of the block_id query parameter.
+func (h *Handlers) GetExecutionResultByBlockID(w http.ResponseWriter, r *http.Request) {
+	errorLogger := h.logger.With().Str("request_url", r.URL.String()).Logger()
+
+	blockID, err := toID(r.URL.Query().Get("block_id"))
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid block ID", errorLogger)
+		return
+	}
+
+	result, err := h.backend.GetExecutionResultForBlockID(r.Context(), blockID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			h.errorResponse(w, http.StatusNotFound, fmt.Sprintf("execution result for block ID %s not found", blockID), errorLogger)
+			return
+		}
+		errorLogger.Error().Err(err).Str("block_id", blockID.String()).Msg("failed to look up execution result")
+		h.errorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to look up execution result for block ID %s", blockID), errorLogger)
+		return
+	}
+
+	h.response(w, r, executionResultResponse(result), errorLogger)
 }
 
 // GetTransactionByID gets a transaction by requested ID.
@@ -100,7 +133,43 @@ func (h *Handlers) GetTransactionByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.jsonResponse(w, transactionResponse(tx), errorLogger)
+	h.response(w, r, transactionResponse(tx), errorLogger)
+}
+
+// GetTransactionResultByID gets the result of a transaction by requested ID.
+func (h *Handlers) GetTransactionResultByID(w http.ResponseWriter, r *http.Request) {
+	errorLogger := h.logger.With().Str("request_url", r.URL.String()).Logger()
+
+	vars := mux.Vars(r)
+	id, err := toID(vars["transaction_id"])
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid transaction ID", errorLogger)
+		return
+	}
+
+	result, err := h.backend.GetTransactionResult(r.Context(), id)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			h.errorResponse(w, http.StatusNotFound, fmt.Sprintf("transaction result for ID %s not found", id), errorLogger)
+			return
+		}
+		if status.Code(err) == codes.OutOfRange {
+			h.errorResponse(w, http.StatusRequestedRangeNotSatisfiable, status.Convert(err).Message(), errorLogger)
+			return
+		}
+		errorLogger.Error().Err(err).Str("transaction_id", id.String()).Msg("failed to look up transaction result")
+		h.errorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to look up transaction result for ID %s", id), errorLogger)
+		return
+	}
+
+	// the backend serving the handlers is not required to cap the error message
+	access.TruncateTransactionError(result, h.maxErrorMessageSize)
+	// the untruncated length is only reported if the error message was truncated
+	if result.ErrorMessageLength > uint(len(result.ErrorMessage)) {
+		w.Header().Set(access.ErrorMessageLengthHeader, fmt.Sprintf("%d", result.ErrorMessageLength))
+	}
+
+	h.response(w, r, transactionResultResponse(result), errorLogger)
 }
 
 // CreateTransaction creates a new transaction from provided payload.
@@ -124,18 +193,23 @@ func (h *Handlers) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.jsonResponse(w, transactionResponse(&tx), h.logger)
+	h.response(w, r, transactionResponse(&tx), h.logger)
 }
 
-func (h *Handlers) jsonResponse(w http.ResponseWriter, responsePayload interface{}, errorLogger zerolog.Logger) {
-	encodedBlocks, err := json.Marshal(responsePayload)
+// response sends the response payload to the client, in the encoding negotiated with the client
+func (h *Handlers) response(w http.ResponseWriter, r *http.Request, responsePayload interface{}, errorLogger zerolog.Logger) {
+	enc := middleware.GetResponseEncoding(r)
+
+	var encoded bytes.Buffer
+	err := enc.Codec.NewEncoder(&encoded).Encode(responsePayload)
 	if err != nil {
 		errorLogger.Error().Err(err).Msg("failed to encode response")
 		h.errorResponse(w, http.StatusInternalServerError, "error generating response", errorLogger)
 		return
 	}
 
-	_, err = w.Write(encodedBlocks)
+	w.Header().Set("Content-Type", enc.ContentType)
+	_, err = w.Write(encoded.Bytes())
 	if err != nil {
 		errorLogger.Error().Err(err).Msg("failed to write response")
 		h.errorResponse(w, http.StatusInternalServerError, "error generating response", errorLogger)
@@ -201,8 +275,9 @@ func (h *Handlers) NotImplemented(w http.ResponseWriter, r *http.Request) {
 }
 
 // errorResponse sends an HTTP error response to the client with the given return code and a model error with the given
-// response message in the response body
+// response message in the response body. Error responses are always encoded in JSON.
 func (h *Handlers) errorResponse(w http.ResponseWriter, returnCode int, responseMessage string, logger zerolog.Logger) {
+	w.Header().Set("Content-Type", middleware.JSONEncoding.ContentType)
 	w.WriteHeader(returnCode)
 	modelError := generated.ModelError{
 		Code:    int32(returnCode),
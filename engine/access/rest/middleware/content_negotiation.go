@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/onflow/flow-go/engine/access/rest/generated"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	jsonencoding "github.com/onflow/flow-go/model/encoding/json"
+)
+
+const prettyQueryParam = "pretty"
+const encodingAttribute = "encoding"
+
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeCBOR = "application/cbor"
+)
+
+// Encoding is the encoding of a response body, which was negotiated with the client.
+type Encoding struct {
+	MediaType   string
+	ContentType string
+	Codec       encoding.Codec
+}
+
+var (
+	JSONEncoding = Encoding{
+		MediaType:   MediaTypeJSON,
+		ContentType: MediaTypeJSON + "; charset=UTF-8",
+		Codec:       &jsonencoding.Codec{},
+	}
+	PrettyJSONEncoding = Encoding{
+		MediaType:   MediaTypeJSON,
+		ContentType: MediaTypeJSON + "; charset=UTF-8",
+		Codec:       &jsonencoding.PrettyCodec{},
+	}
+	CBOREncoding = Encoding{
+		MediaType:   MediaTypeCBOR,
+		ContentType: MediaTypeCBOR,
+		Codec:       &cbor.Codec{},
+	}
+)
+
+// supportedEncodings lists the supported encodings in the order of preference of the server,
+// which is used if the client accepts several of them with the same quality.
+var supportedEncodings = []Encoding{JSONEncoding, CBOREncoding}
+
+// ContentNegotiation middleware selects the encoding of the response from the 'Accept' header of
+// the request, and adds it to the request context. JSON is used if the client accepts any media
+// type, and it is pretty-printed if the request contains the query param 'pretty=true'.
+// If none of the accepted media types is supported, the request is rejected with
+// 406 Not Acceptable, listing the supported media types.
+func ContentNegotiation() mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			enc, ok := negotiateEncoding(req.Header.Get("Accept"))
+			if !ok {
+				notAcceptable(w)
+				return
+			}
+			if enc.MediaType == MediaTypeJSON && req.URL.Query().Get(prettyQueryParam) == "true" {
+				enc = PrettyJSONEncoding
+			}
+			req = addRequestAttribute(req, encodingAttribute, enc)
+			handler.ServeHTTP(w, req)
+		})
+	}
+}
+
+// GetResponseEncoding returns the encoding negotiated for the response, or JSON if the request
+// did not pass the ContentNegotiation middleware.
+func GetResponseEncoding(req *http.Request) Encoding {
+	value, found := getRequestAttribute(req, encodingAttribute)
+	if !found {
+		return JSONEncoding
+	}
+	enc, ok := value.(Encoding)
+	if !ok {
+		return JSONEncoding
+	}
+	return enc
+}
+
+// SupportedMediaTypes returns the media types, which the REST API can encode responses in.
+func SupportedMediaTypes() []string {
+	mediaTypes := make([]string, 0, len(supportedEncodings))
+	for _, enc := range supportedEncodings {
+		mediaTypes = append(mediaTypes, enc.MediaType)
+	}
+	return mediaTypes
+}
+
+// acceptedRange is a media range of an 'Accept' header with its quality.
+type acceptedRange struct {
+	mediaRange string
+	quality    float64
+}
+
+// negotiateEncoding returns the supported encoding, which is accepted by the given 'Accept' header
+// with the highest quality. As usual, the quality of a media type is given by the most specific
+// media range it matches, and a quality of zero marks it as not acceptable. Encodings with the same
+// quality are selected in the order of preference of the server. An empty header accepts any
+// media type.
+func negotiateEncoding(accept string) (Encoding, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return supportedEncodings[0], true
+	}
+
+	var ranges []acceptedRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptedRange{mediaRange: mediaRange, quality: quality})
+	}
+
+	var best Encoding
+	bestQuality := 0.0
+	for _, enc := range supportedEncodings {
+		quality := 0.0
+		specificity := -1
+		for _, r := range ranges {
+			s, ok := matchMediaRange(enc.MediaType, r.mediaRange)
+			if ok && s > specificity {
+				quality = r.quality
+				specificity = s
+			}
+		}
+		if quality > bestQuality {
+			best = enc
+			bestQuality = quality
+		}
+	}
+	return best, bestQuality > 0
+}
+
+// matchMediaRange returns whether the media type is within the media range, e.g. 'application/json'
+// is within 'application/json', 'application/*' and '*/*', and how specific the range is.
+func matchMediaRange(mediaType string, mediaRange string) (int, bool) {
+	switch {
+	case mediaRange == mediaType:
+		return 2, true
+	case mediaRange == "*/*":
+		return 0, true
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+// notAcceptable sends a 406 Not Acceptable error response in JSON, listing the supported media types.
+func notAcceptable(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", JSONEncoding.ContentType)
+	w.WriteHeader(http.StatusNotAcceptable)
+	modelError := generated.ModelError{
+		Code:    http.StatusNotAcceptable,
+		Message: fmt.Sprintf("none of the accepted media types is supported, supported media types are: %s", strings.Join(SupportedMediaTypes(), ", ")),
+	}
+	_ = json.NewEncoder(w).Encode(modelError)
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNegotiateEncoding tests that the supported encoding with the highest quality is selected
+// from the 'Accept' header.
+func TestNegotiateEncoding(t *testing.T) {
+	testcases := []struct {
+		accept    string
+		mediaType string
+		ok        bool
+	}{
+		{accept: "", mediaType: MediaTypeJSON, ok: true},
+		{accept: "*/*", mediaType: MediaTypeJSON, ok: true},
+		{accept: "application/*", mediaType: MediaTypeJSON, ok: true},
+		{accept: "application/json", mediaType: MediaTypeJSON, ok: true},
+		{accept: "application/cbor", mediaType: MediaTypeCBOR, ok: true},
+		{accept: "text/html, application/cbor", mediaType: MediaTypeCBOR, ok: true},
+		{accept: "application/json;q=0.5, application/cbor", mediaType: MediaTypeCBOR, ok: true},
+		{accept: "application/cbor;q=0.9, */*;q=0.1", mediaType: MediaTypeCBOR, ok: true},
+		{accept: "application/json;q=0, */*", mediaType: MediaTypeCBOR, ok: true},
+		{accept: "text/html", ok: false},
+		{accept: "application/xml, text/*", ok: false},
+		{accept: "application/json;q=0", ok: false},
+	}
+	for _, tc := range testcases {
+		enc, ok := negotiateEncoding(tc.accept)
+		require.Equal(t, tc.ok, ok, "unexpected negotiation result for %q", tc.accept)
+		if tc.ok {
+			assert.Equal(t, tc.mediaType, enc.MediaType, "unexpected media type for %q", tc.accept)
+		}
+	}
+}
+
+// TestContentNegotiation tests that the negotiated encoding is added to the request context, and
+// that requests accepting no supported media type are rejected.
+func TestContentNegotiation(t *testing.T) {
+	var negotiated Encoding
+	r := mux.NewRouter()
+	r.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		negotiated = GetResponseEncoding(req)
+	})
+	r.Use(ContentNegotiation())
+
+	send := func(accept string, query string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/"+query, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", accept)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := send("application/cbor", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, CBOREncoding, negotiated)
+
+	rr = send("application/json", "?pretty=true")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, PrettyJSONEncoding, negotiated)
+
+	// pretty-printing only applies to JSON
+	rr = send("application/cbor", "?pretty=true")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, CBOREncoding, negotiated)
+
+	rr = send("text/html", "")
+	assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	assert.Equal(t, JSONEncoding.ContentType, rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "application/json, application/cbor")
+}
@@ -23,6 +23,7 @@ func NewServer(handlers *Handlers, listenAddress string, logger zerolog.Logger)
 	v1SubRouter.Use(middleware.LoggingMiddleware(logger))
 	v1SubRouter.Use(middleware.QueryExpandable())
 	v1SubRouter.Use(middleware.QuerySelect())
+	v1SubRouter.Use(middleware.ContentNegotiation())
 
 	for _, route := range apiRoutes(handlers) {
 		v1SubRouter.
@@ -77,7 +78,7 @@ func apiRoutes(handlers *Handlers) generated.Routes {
 			Name:        "ExecutionResultsGet",
 			Method:      strings.ToUpper("Get"),
 			Pattern:     "/execution_results",
-			HandlerFunc: handlers.NotImplemented,
+			HandlerFunc: handlers.GetExecutionResultByBlockID,
 		},
 
 		generated.Route{
@@ -98,7 +99,7 @@ func apiRoutes(handlers *Handlers) generated.Routes {
 			Name:        "TransactionResultsTransactionIdGet",
 			Method:      strings.ToUpper("Get"),
 			Pattern:     "/transaction_results/{transaction_id}",
-			HandlerFunc: handlers.NotImplemented,
+			HandlerFunc: handlers.GetTransactionResultByID,
 		},
 
 		generated.Route{
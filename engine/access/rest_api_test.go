@@ -2,7 +2,9 @@ package access
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"os"
@@ -10,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fxamacker/cbor/v2"
 	restclient "github.com/onflow/flow/openapi/go-client-generated"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +22,7 @@ import (
 
 	accessmock "github.com/onflow/flow-go/engine/access/mock"
 	"github.com/onflow/flow-go/engine/access/rest"
+	"github.com/onflow/flow-go/engine/access/rest/generated"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
@@ -36,6 +40,7 @@ type RestAPITestSuite struct {
 	state      *protocol.State
 	snapshot   *protocol.Snapshot
 	epochQuery *protocol.EpochQuery
+	rootHeader *flow.Header
 	log        zerolog.Logger
 	net        *network.Network
 	request    *module.Requester
@@ -64,6 +69,11 @@ func (suite *RestAPITestSuite) SetupTest() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
 	suite.snapshot.On("Epochs").Return(suite.epochQuery).Maybe()
+	rootHeader := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(0))
+	suite.rootHeader = &rootHeader
+	params := new(protocol.Params)
+	params.On("Root").Return(suite.rootHeader, nil)
+	suite.state.On("Params").Return(params).Maybe()
 	suite.blocks = new(storagemock.Blocks)
 	suite.headers = new(storagemock.Headers)
 	suite.transactions = new(storagemock.Transactions)
@@ -230,6 +240,92 @@ func (suite *RestAPITestSuite) TestRestAPICall() {
 		assertError(suite.T(), resp, err, http.StatusNotFound, fmt.Sprintf("block with ID %s not found", blockIDs[invalidBlockIndex]))
 	})
 
+	suite.Run("GetBlockByID with a block below the earliest available height", func() {
+
+		block := unittest.BlockFixture()
+		suite.blocks.On("ByID", block.ID()).Return(&block, nil).Once()
+		// the node only serves history starting at the block after the requested one
+		suite.rootHeader.Height = block.Header.Height + 1
+		defer func() { suite.rootHeader.Height = 0 }()
+
+		client := suite.restAPIClient()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		_, resp, err := client.BlocksApi.BlocksIdGet(ctx, []string{block.ID().String()}, nil)
+		require.NotNil(suite.T(), resp)
+		assert.Equal(suite.T(), http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+		require.Error(suite.T(), err)
+		// the generated client does not decode the model error for this status code
+		var modelError restclient.ModelError
+		require.NoError(suite.T(), json.Unmarshal(err.(restclient.GenericSwaggerError).Body(), &modelError))
+		require.EqualValues(suite.T(), http.StatusRequestedRangeNotSatisfiable, modelError.Code)
+		require.Contains(suite.T(), modelError.Message, fmt.Sprintf("earliest available height is %d", block.Header.Height+1))
+	})
+
+}
+
+// TestRestAPIEncodings tests that the REST API encodes responses in the media type negotiated with the client.
+func (suite *RestAPITestSuite) TestRestAPIEncodings() {
+
+	collections := unittest.CollectionListFixture(1)
+	block := unittest.BlockWithGuaranteesFixture(
+		unittest.CollectionGuaranteesWithCollectionIDFixture(collections),
+	)
+	suite.blocks.On("ByID", block.ID()).Return(block, nil)
+
+	url := fmt.Sprintf("http://%s/v1/blocks/%s", suite.rpcEng.RestApiAddress().String(), block.ID())
+	get := func(accept string, query string) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodGet, url+query, nil)
+		require.NoError(suite.T(), err)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(suite.T(), err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(suite.T(), err)
+		return resp, body
+	}
+
+	// the JSON response is the reference for the other encodings
+	resp, body := get("", "")
+	require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(suite.T(), "application/json; charset=UTF-8", resp.Header.Get("Content-Type"))
+	var expected []generated.Block
+	require.NoError(suite.T(), json.Unmarshal(body, &expected))
+	require.Len(suite.T(), expected, 1)
+	assert.Equal(suite.T(), block.ID().String(), expected[0].Header.Id)
+	assert.Len(suite.T(), expected[0].Payload.CollectionGuarantees, 1)
+
+	suite.Run("CBOR", func() {
+		resp, body := get("application/cbor", "")
+		require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "application/cbor", resp.Header.Get("Content-Type"))
+		var actual []generated.Block
+		require.NoError(suite.T(), cbor.Unmarshal(body, &actual))
+		assert.Equal(suite.T(), expected, actual)
+	})
+
+	suite.Run("pretty JSON", func() {
+		resp, body := get("application/json", "?pretty=true")
+		require.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "application/json; charset=UTF-8", resp.Header.Get("Content-Type"))
+		assert.Contains(suite.T(), string(body), "\n  ")
+		var actual []generated.Block
+		require.NoError(suite.T(), json.Unmarshal(body, &actual))
+		assert.Equal(suite.T(), expected, actual)
+	})
+
+	suite.Run("unsupported media type", func() {
+		resp, body := get("application/xml", "")
+		require.Equal(suite.T(), http.StatusNotAcceptable, resp.StatusCode)
+		var modelError generated.ModelError
+		require.NoError(suite.T(), json.Unmarshal(body, &modelError))
+		assert.EqualValues(suite.T(), http.StatusNotAcceptable, modelError.Code)
+		assert.Contains(suite.T(), modelError.Message, "application/json, application/cbor")
+	})
 }
 
 func (suite *RestAPITestSuite) TearDownTest() {
@@ -78,6 +78,12 @@ func New(
 	connFactory ConnectionFactory,
 	retryEnabled bool,
 	maxHeightRange uint,
+	earliestHeight uint64,
+	scriptCacheSize uint,
+	scriptCacheTTL time.Duration,
+	maxErrorMessageSize uint,
+	transactionExpiryBuffer uint,
+	argumentLimits ArgumentLimits,
 	preferredExecutionNodeIDs []string,
 	fixedExecutionNodeIDs []string,
 	log zerolog.Logger,
@@ -87,6 +93,17 @@ func New(
 		retry.Activate()
 	}
 
+	heights := newHeightRange(state, earliestHeight)
+
+	if transactionExpiryBuffer == 0 {
+		transactionExpiryBuffer = flow.DefaultTransactionExpiryBuffer
+	}
+	if transactionExpiryBuffer >= flow.DefaultTransactionExpiry {
+		log.Fatal().
+			Uint("expiry_buffer", transactionExpiryBuffer).
+			Msgf("transaction expiry buffer must be below the transaction expiry of %d blocks", flow.DefaultTransactionExpiry)
+	}
+
 	b := &Backend{
 		state: state,
 		// create the sub-backends
@@ -95,6 +112,8 @@ func New(
 			executionReceipts: executionReceipts,
 			connFactory:       connFactory,
 			state:             state,
+			heights:           heights,
+			cache:             newScriptCache(scriptCacheSize, scriptCacheTTL, transactionMetrics),
 			log:               log,
 		},
 		backendTransactions: backendTransactions{
@@ -105,11 +124,13 @@ func New(
 			blocks:               blocks,
 			transactions:         transactions,
 			executionReceipts:    executionReceipts,
-			transactionValidator: configureTransactionValidator(state, chainID),
+			transactionValidator: configureTransactionValidator(state, chainID, transactionExpiryBuffer, argumentLimits),
 			transactionMetrics:   transactionMetrics,
+			maxErrorMessageSize:  maxErrorMessageSize,
 			retry:                retry,
 			connFactory:          connFactory,
 			previousAccessNodes:  historicalAccessNodes,
+			heights:              heights,
 			log:                  log,
 		},
 		backendEvents: backendEvents{
@@ -119,20 +140,24 @@ func New(
 			connFactory:       connFactory,
 			log:               log,
 			maxHeightRange:    maxHeightRange,
+			heights:           heights,
 		},
 		backendBlockHeaders: backendBlockHeaders{
 			headers: headers,
 			state:   state,
+			heights: heights,
 		},
 		backendBlockDetails: backendBlockDetails{
-			blocks: blocks,
-			state:  state,
+			blocks:  blocks,
+			state:   state,
+			heights: heights,
 		},
 		backendAccounts: backendAccounts{
 			state:             state,
 			headers:           headers,
 			executionReceipts: executionReceipts,
 			connFactory:       connFactory,
+			heights:           heights,
 			log:               log,
 		},
 		backendExecutionResults: backendExecutionResults{
@@ -172,19 +197,36 @@ func identifierList(ids []string) (flow.IdentifierList, error) {
 	return idList, nil
 }
 
-func configureTransactionValidator(state protocol.State, chainID flow.ChainID) *access.TransactionValidator {
+// ArgumentLimits are the limits on the arguments of submitted transactions, which are
+// rejected before they are forwarded to collection nodes, instead of failing during execution.
+// Zero values indicate no limit.
+type ArgumentLimits struct {
+	MaxCount     uint   // max number of arguments
+	MaxSize      uint64 // max byte size of a single argument
+	MaxTotalSize uint64 // max total byte size of all arguments
+	Strict       bool   // reject arguments, which are not valid JSON-CDC encodings
+}
+
+// configureTransactionValidator creates the validator of submitted transactions. Transactions are
+// rejected before they are forwarded to collection nodes, if their reference block is unknown, if
+// fewer than expiryBuffer blocks remain until they expire, or if their arguments exceed the limits.
+func configureTransactionValidator(state protocol.State, chainID flow.ChainID, expiryBuffer uint, argumentLimits ArgumentLimits) *access.TransactionValidator {
 	return access.NewTransactionValidator(
 		access.NewProtocolStateBlocks(state),
 		chainID.Chain(),
 		access.TransactionValidationOptions{
 			Expiry:                       flow.DefaultTransactionExpiry,
-			ExpiryBuffer:                 flow.DefaultTransactionExpiryBuffer,
+			ExpiryBuffer:                 expiryBuffer,
 			AllowEmptyReferenceBlockID:   false,
 			AllowUnknownReferenceBlockID: false,
 			CheckScriptsParse:            false,
 			MaxGasLimit:                  flow.DefaultMaxTransactionGasLimit,
 			MaxTransactionByteSize:       flow.DefaultMaxTransactionByteSize,
 			MaxCollectionByteSize:        flow.DefaultMaxCollectionByteSize,
+			MaxArgumentCount:             argumentLimits.MaxCount,
+			MaxArgumentByteSize:          argumentLimits.MaxSize,
+			MaxArgumentsByteSize:         argumentLimits.MaxTotalSize,
+			CheckArgumentsEncoding:       argumentLimits.Strict,
 		},
 	)
 }
@@ -224,7 +266,8 @@ func (b *Backend) GetNetworkParameters(_ context.Context) access.NetworkParamete
 	}
 }
 
-// GetLatestProtocolStateSnapshot returns the latest finalized snapshot
+// GetLatestProtocolStateSnapshot returns the latest finalized snapshot, in the
+// versioned binary snapshot encoding (see inmem.EncodeSnapshot)
 func (b *Backend) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, error) {
 	data, err := convert.SnapshotToBytes(b.state.Final())
 	if err != nil {
@@ -21,6 +21,7 @@ type backendAccounts struct {
 	headers           storage.Headers
 	executionReceipts storage.ExecutionReceipts
 	connFactory       ConnectionFactory
+	heights           *heightRange
 	log               zerolog.Logger
 }
 
@@ -52,6 +53,11 @@ func (b *backendAccounts) GetAccountAtBlockHeight(
 	address flow.Address,
 	height uint64,
 ) (*flow.Account, error) {
+	err := b.heights.check(height)
+	if err != nil {
+		return nil, err
+	}
+
 	// get header at given height
 	header, err := b.headers.ByHeight(height)
 	if err != nil {
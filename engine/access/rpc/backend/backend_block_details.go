@@ -9,8 +9,9 @@ import (
 )
 
 type backendBlockDetails struct {
-	blocks storage.Blocks
-	state  protocol.State
+	blocks  storage.Blocks
+	state   protocol.State
+	heights *heightRange
 }
 
 func (b *backendBlockDetails) GetLatestBlock(_ context.Context, isSealed bool) (*flow.Block, error) {
@@ -46,10 +47,20 @@ func (b *backendBlockDetails) GetBlockByID(_ context.Context, id flow.Identifier
 		return nil, err
 	}
 
+	err = b.heights.check(block.Header.Height)
+	if err != nil {
+		return nil, err
+	}
+
 	return block, nil
 }
 
 func (b *backendBlockDetails) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	err := b.heights.check(height)
+	if err != nil {
+		return nil, err
+	}
+
 	block, err := b.blocks.ByHeight(height)
 	if err != nil {
 		err = convertStorageError(err)
@@ -11,6 +11,7 @@ import (
 type backendBlockHeaders struct {
 	headers storage.Headers
 	state   protocol.State
+	heights *heightRange
 }
 
 func (b *backendBlockHeaders) GetLatestBlockHeader(_ context.Context, isSealed bool) (*flow.Header, error) {
@@ -40,10 +41,20 @@ func (b *backendBlockHeaders) GetBlockHeaderByID(_ context.Context, id flow.Iden
 		return nil, err
 	}
 
+	err = b.heights.check(header.Height)
+	if err != nil {
+		return nil, err
+	}
+
 	return header, nil
 }
 
 func (b *backendBlockHeaders) GetBlockHeaderByHeight(_ context.Context, height uint64) (*flow.Header, error) {
+	err := b.heights.check(height)
+	if err != nil {
+		return nil, err
+	}
+
 	header, err := b.headers.ByHeight(height)
 	if err != nil {
 		err = convertStorageError(err)
@@ -25,6 +25,7 @@ type backendEvents struct {
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
 	maxHeightRange    uint
+	heights           *heightRange
 }
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
@@ -44,6 +45,12 @@ func (b *backendEvents) GetEventsForHeightRange(
 		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d)", rangeSize, b.maxHeightRange)
 	}
 
+	// start height should not be below the earliest height for which events are served
+	err := b.heights.check(startHeight)
+	if err != nil {
+		return nil, err
+	}
+
 	// get the latest sealed block header
 	head, err := b.state.Sealed().Head()
 	if err != nil {
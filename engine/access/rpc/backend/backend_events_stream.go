@@ -0,0 +1,409 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// EventsStreamConfig defines the configurable options of the events subscriptions.
+type EventsStreamConfig struct {
+	// BufferSize is the number of block batches buffered per subscriber. A subscriber that
+	// falls further behind the latest sealed block is disconnected.
+	BufferSize uint
+	// PollInterval is the interval at which the latest sealed height is checked for new blocks.
+	PollInterval time.Duration
+}
+
+// DefaultEventsStreamConfig returns the default configuration of the events subscriptions.
+func DefaultEventsStreamConfig() EventsStreamConfig {
+	return EventsStreamConfig{
+		BufferSize:   100,
+		PollInterval: time.Second,
+	}
+}
+
+// EventsStreamer streams the events of sealed blocks to subscribers. The subscriptions share a
+// single tracker of the latest sealed height, and the events of recently sealed blocks, so that
+// the events of each type of a block are retrieved from the execution nodes once, however many
+// subscribers stream them.
+type EventsStreamer struct {
+	events *backendEvents
+	config EventsStreamConfig
+	log    zerolog.Logger
+	sealed *sealedHeightTracker
+
+	mu      sync.Mutex
+	fetches *lru.Cache // height -> *blockFetch of recently streamed blocks
+}
+
+// NewEventsStreamer creates a new streamer for the events served by the given backend.
+func NewEventsStreamer(backend *Backend, config EventsStreamConfig) *EventsStreamer {
+	// subscribers which caught up stream the blocks within their buffer size of the last sealed block
+	fetches, err := lru.New(int(config.BufferSize) + 1)
+	if err != nil {
+		panic(fmt.Sprintf("could not create events fetch cache: %v", err))
+	}
+	log := backend.backendEvents.log.With().Str("component", "events_stream").Logger()
+	return &EventsStreamer{
+		events:  &backend.backendEvents,
+		config:  config,
+		log:     log,
+		sealed:  newSealedHeightTracker(backend.backendEvents.state, config.PollInterval, log),
+		fetches: fetches,
+	}
+}
+
+// Subscribe streams the events of the given types for all sealed blocks, starting at the given
+// height, until the context is canceled or an error occurs. For every block, exactly one batch
+// is passed to send, which has no events if the block has no events of the given types.
+//
+// Backfilling the blocks below the latest sealed height is paced by the subscriber. Once the
+// subscription has caught up, a subscriber that falls more than the configured buffer size of
+// blocks behind is disconnected with a RESOURCE_EXHAUSTED error.
+func (s *EventsStreamer) Subscribe(
+	ctx context.Context,
+	startHeight uint64,
+	eventTypes []string,
+	send func(flow.BlockEvents) error,
+) error {
+
+	eventTypes, err := uniqueEventTypes(eventTypes)
+	if err != nil {
+		return err
+	}
+
+	// start height should not be below the earliest height for which events are served
+	err = s.events.heights.check(startHeight)
+	if err != nil {
+		return err
+	}
+
+	unsubscribe, err := s.sealed.subscribe()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get last sealed block: %v", err)
+	}
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan flow.BlockEvents, s.config.BufferSize)
+	produced := make(chan error, 1)
+	go func() {
+		produced <- s.produce(ctx, startHeight, eventTypes, batches)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-produced:
+			return err
+		case batch := <-batches:
+			// a canceled or disconnected subscriber doesn't receive the remaining buffered batches
+			select {
+			case <-ctx.Done():
+				return nil
+			case err := <-produced:
+				return err
+			default:
+			}
+			err := send(batch)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// produce retrieves the events of all sealed blocks starting at the given height, and pushes them
+// into the given buffer. It blocks on a full buffer while backfilling, and returns a
+// RESOURCE_EXHAUSTED error on a full buffer once it has caught up with the latest sealed block.
+func (s *EventsStreamer) produce(
+	ctx context.Context,
+	height uint64,
+	eventTypes []string,
+	batches chan<- flow.BlockEvents,
+) error {
+
+	caughtUp := false
+	for {
+		sealedHeight, updated, err := s.sealed.latest()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get last sealed block: %v", err)
+		}
+
+		for ; height <= sealedHeight; height++ {
+			batch, err := s.blockEvents(ctx, height, eventTypes)
+			if err != nil {
+				return err
+			}
+
+			if !caughtUp {
+				select {
+				case <-ctx.Done():
+					return nil
+				case batches <- batch:
+				}
+				continue
+			}
+
+			select {
+			case batches <- batch:
+			default:
+				s.log.Debug().
+					Uint64("height", height).
+					Uint64("sealed_height", sealedHeight).
+					Msg("disconnecting slow events subscriber")
+				return status.Errorf(codes.ResourceExhausted,
+					"subscriber fell more than %d blocks behind the last sealed block", s.config.BufferSize)
+			}
+		}
+		caughtUp = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-updated:
+		}
+	}
+}
+
+// blockEvents retrieves the events of the given types for the sealed block at the given height,
+// in the order they were emitted.
+func (s *EventsStreamer) blockEvents(ctx context.Context, height uint64, eventTypes []string) (flow.BlockEvents, error) {
+	header, err := s.events.headers.ByHeight(height)
+	if err != nil {
+		return flow.BlockEvents{}, status.Errorf(codes.Internal, "failed to get block at height %d: %v", height, err)
+	}
+
+	batch := flow.BlockEvents{
+		BlockID:        header.ID(),
+		BlockHeight:    header.Height,
+		BlockTimestamp: header.Timestamp,
+	}
+
+	// the execution API serves the events of a single type per request
+	fetch := s.blockFetch(header)
+	for _, eventType := range eventTypes {
+		events, err := fetch.ofType(ctx, s.events, eventType)
+		if err != nil {
+			return flow.BlockEvents{}, err
+		}
+		batch.Events = append(batch.Events, events...)
+	}
+
+	sort.SliceStable(batch.Events, func(i, j int) bool {
+		if batch.Events[i].TransactionIndex != batch.Events[j].TransactionIndex {
+			return batch.Events[i].TransactionIndex < batch.Events[j].TransactionIndex
+		}
+		return batch.Events[i].EventIndex < batch.Events[j].EventIndex
+	})
+
+	return batch, nil
+}
+
+// blockFetch returns the shared retrieval of the events of the given block.
+func (s *EventsStreamer) blockFetch(header *flow.Header) *blockFetch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.fetches.Get(header.Height)
+	if ok {
+		fetch := cached.(*blockFetch)
+		if fetch.header.ID() == header.ID() {
+			return fetch
+		}
+	}
+	fetch := &blockFetch{
+		header: header,
+		byType: make(map[string]*typeFetch),
+	}
+	s.fetches.Add(header.Height, fetch)
+	return fetch
+}
+
+// blockFetch retrieves the events of a sealed block from the execution nodes, once per event
+// type, on behalf of all subscriptions streaming the block.
+type blockFetch struct {
+	header *flow.Header
+
+	mu     sync.Mutex
+	byType map[string]*typeFetch
+}
+
+// typeFetch is the retrieval of the events of a single type of a block. Once done is closed,
+// it holds the events or the error of the retrieval.
+type typeFetch struct {
+	done   chan struct{}
+	events []flow.Event
+	err    error
+}
+
+// ofType returns the events of the given type of the block. The first subscription asking for
+// them retrieves them from the execution nodes, while all others wait for the result. A failed
+// retrieval is not shared, waiting subscriptions retry it on their own.
+func (b *blockFetch) ofType(ctx context.Context, events *backendEvents, eventType string) ([]flow.Event, error) {
+	for {
+		b.mu.Lock()
+		fetch, ok := b.byType[eventType]
+		if !ok {
+			fetch = &typeFetch{done: make(chan struct{})}
+			b.byType[eventType] = fetch
+		}
+		b.mu.Unlock()
+
+		if !ok {
+			results, err := events.getBlockEventsFromExecutionNode(ctx, []*flow.Header{b.header}, eventType)
+			if err != nil {
+				b.mu.Lock()
+				delete(b.byType, eventType)
+				b.mu.Unlock()
+			}
+			for _, result := range results {
+				fetch.events = append(fetch.events, result.Events...)
+			}
+			fetch.err = err
+			close(fetch.done)
+			return fetch.events, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-fetch.done:
+		}
+		if fetch.err == nil {
+			return fetch.events, nil
+		}
+	}
+}
+
+// sealedHeightTracker polls the latest sealed height on behalf of all subscriptions, as long as
+// there is at least one, and notifies them once it increases.
+type sealedHeightTracker struct {
+	state    protocol.State
+	interval time.Duration
+	log      zerolog.Logger
+
+	mu          sync.Mutex
+	subscribers int
+	stop        context.CancelFunc // stops polling
+	height      uint64
+	err         error         // error of the last poll
+	updated     chan struct{} // closed and replaced once the height or the error changes
+}
+
+func newSealedHeightTracker(state protocol.State, interval time.Duration, log zerolog.Logger) *sealedHeightTracker {
+	return &sealedHeightTracker{
+		state:    state,
+		interval: interval,
+		log:      log,
+		updated:  make(chan struct{}),
+	}
+}
+
+// subscribe registers a subscription, and starts polling for the first one. It returns the
+// function to unregister the subscription, which stops polling after the last one.
+func (t *sealedHeightTracker) subscribe() (func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.subscribers == 0 {
+		// the sealed height might be outdated since polling stopped
+		t.pollLocked()
+		if t.err != nil {
+			return nil, t.err
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		t.stop = cancel
+		go t.poll(ctx)
+	}
+	t.subscribers++
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.subscribers--
+		if t.subscribers == 0 {
+			t.stop()
+		}
+	}, nil
+}
+
+// latest returns the latest sealed height, or the error of the last poll, and a channel which is
+// closed once either changes.
+func (t *sealedHeightTracker) latest() (uint64, <-chan struct{}, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.height, t.updated, t.err
+}
+
+func (t *sealedHeightTracker) poll(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		t.mu.Lock()
+		t.pollLocked()
+		t.mu.Unlock()
+	}
+}
+
+// pollLocked retrieves the latest sealed height. It must be called while holding the lock.
+func (t *sealedHeightTracker) pollLocked() {
+	head, err := t.state.Sealed().Head()
+	if err != nil {
+		t.log.Error().Err(err).Msg("failed to get last sealed block")
+	}
+	changed := err != t.err
+	if err == nil && head.Height > t.height {
+		t.height = head.Height
+		changed = true
+	}
+	t.err = err
+	if changed {
+		close(t.updated)
+		t.updated = make(chan struct{})
+	}
+}
+
+// uniqueEventTypes validates the given event types and removes duplicates.
+func uniqueEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no event types given")
+	}
+
+	seen := make(map[string]struct{}, len(eventTypes))
+	unique := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		_, err := convert.EventType(eventType)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[eventType]; ok {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		unique = append(unique, eventType)
+	}
+
+	return unique, nil
+}
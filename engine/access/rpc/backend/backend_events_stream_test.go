@@ -0,0 +1,340 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// sealedChain is the backend of the mocked protocol state, storage and execution nodes of the
+// events stream tests. Its blocks at the heights 1 to n are sealed.
+type sealedChain struct {
+	sync.Mutex
+	headers map[uint64]*flow.Header
+	heights map[flow.Identifier]uint64
+	events  map[flow.Identifier][]flow.Event
+	sealed  uint64
+	fetches int // number of event requests to the execution nodes
+}
+
+// setupSealedChain mocks a chain of the given number of blocks executed by two execution nodes.
+// Block i has i events, alternating between the account created and updated types.
+func (suite *Suite) setupSealedChain(blocks int, sealed uint64) (*sealedChain, *Backend) {
+	chain := &sealedChain{
+		headers: make(map[uint64]*flow.Header),
+		heights: make(map[flow.Identifier]uint64),
+		events:  make(map[flow.Identifier][]flow.Event),
+		sealed:  sealed,
+	}
+
+	executors := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	for height := uint64(1); height <= uint64(blocks); height++ {
+		block := unittest.BlockFixture()
+		block.Header.Height = height
+		chain.headers[height] = block.Header
+		chain.heights[block.ID()] = height
+
+		events := make([]flow.Event, height)
+		for i := range events {
+			events[i] = unittest.EventFixture(flow.EventAccountCreated, uint32(i/2), uint32(i%2), unittest.IdentifierFixture(), 0)
+			if i%2 == 1 {
+				events[i].Type = flow.EventAccountUpdated
+			}
+		}
+		chain.events[block.ID()] = events
+
+		receipts := make(flow.ExecutionReceiptList, len(executors))
+		result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+		for i, executor := range executors {
+			receipts[i] = unittest.ExecutionReceiptFixture(unittest.WithResult(result), unittest.WithExecutorID(executor.NodeID))
+		}
+		suite.receipts.On("ByBlockID", block.ID()).Return(receipts, nil).Maybe()
+	}
+
+	suite.state.On("Sealed").Return(suite.snapshot)
+	suite.state.On("Final").Return(suite.snapshot)
+	suite.snapshot.On("Head").Return(
+		func() *flow.Header {
+			chain.Lock()
+			defer chain.Unlock()
+			return chain.headers[chain.sealed]
+		},
+		func() error { return nil },
+	)
+	suite.snapshot.On("Identities", mock.Anything).Return(executors, nil)
+	suite.headers.On("ByHeight", mock.Anything).Return(
+		func(height uint64) *flow.Header { return chain.headers[height] },
+		func(height uint64) error {
+			if _, ok := chain.headers[height]; !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		},
+	)
+
+	suite.execClient.On("GetEventsForBlockIDs", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *execproto.GetEventsForBlockIDsRequest, _ ...grpc.CallOption) *execproto.GetEventsForBlockIDsResponse {
+			chain.Lock()
+			chain.fetches++
+			chain.Unlock()
+			results := make([]*execproto.GetEventsForBlockIDsResponse_Result, len(req.GetBlockIds()))
+			for i, blockID := range convert.MessagesToIdentifiers(req.GetBlockIds()) {
+				results[i] = &execproto.GetEventsForBlockIDsResponse_Result{
+					BlockId:     blockID[:],
+					BlockHeight: chain.heights[blockID],
+					Events:      convert.EventsToMessages(chain.ofType(blockID, flow.EventType(req.GetType()))),
+				}
+			}
+			return &execproto.GetEventsForBlockIDsResponse{Results: results}
+		},
+		func(context.Context, *execproto.GetEventsForBlockIDsRequest, ...grpc.CallOption) error { return nil },
+	)
+
+	backend := New(
+		suite.state,
+		nil, nil,
+		suite.blocks,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		suite.setupConnectionFactory(),
+		false,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
+		nil,
+		nil,
+		suite.log,
+	)
+
+	return chain, backend
+}
+
+// ofType returns the events of the given type emitted in the given block.
+func (c *sealedChain) ofType(blockID flow.Identifier, eventType flow.EventType) []flow.Event {
+	var events []flow.Event
+	for _, event := range c.events[blockID] {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// seal seals all blocks up to the given height.
+func (c *sealedChain) seal(height uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.sealed = height
+}
+
+// TestSubscribeEvents tests that the events of sealed blocks are backfilled from the start height
+// and then streamed as blocks are sealed, with one batch per block.
+func (suite *Suite) TestSubscribeEvents() {
+	chain, backend := suite.setupSealedChain(6, 4)
+	streamer := NewEventsStreamer(backend, EventsStreamConfig{BufferSize: 10, PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batches := make(chan flow.BlockEvents)
+	done := make(chan error)
+	go func() {
+		done <- streamer.Subscribe(ctx, 2, []string{string(flow.EventAccountUpdated)}, func(batch flow.BlockEvents) error {
+			batches <- batch
+			return nil
+		})
+	}()
+
+	receive := func(height uint64) flow.BlockEvents {
+		select {
+		case batch := <-batches:
+			suite.Require().Equal(height, batch.BlockHeight)
+			suite.Require().Equal(chain.headers[height].ID(), batch.BlockID)
+			return batch
+		case err := <-done:
+			suite.FailNow("subscription ended", "error: %v", err)
+		case <-time.After(time.Second):
+			suite.FailNow("no batch received", "height %d", height)
+		}
+		return flow.BlockEvents{}
+	}
+
+	// backfill the sealed blocks 2 to 4
+	for height := uint64(2); height <= 4; height++ {
+		batch := receive(height)
+		suite.Assert().Equal(chain.ofType(batch.BlockID, flow.EventAccountUpdated), batch.Events)
+		suite.Assert().Len(batch.Events, int(height/2))
+	}
+
+	// stream the newly sealed blocks
+	chain.seal(6)
+	for height := uint64(5); height <= 6; height++ {
+		batch := receive(height)
+		suite.Assert().Len(batch.Events, int(height/2))
+	}
+
+	cancel()
+	suite.Require().NoError(<-done)
+}
+
+// TestSubscribeEvents_MultipleTypes tests that the events of all requested types are merged into a
+// single batch per block, in the order they were emitted, and that blocks without matching events
+// are streamed as empty batches.
+func (suite *Suite) TestSubscribeEvents_MultipleTypes() {
+	chain, backend := suite.setupSealedChain(3, 3)
+	streamer := NewEventsStreamer(backend, EventsStreamConfig{BufferSize: 10, PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []flow.BlockEvents
+	eventTypes := []string{string(flow.EventAccountUpdated), string(flow.EventAccountCreated), string(flow.EventAccountUpdated)}
+	err := streamer.Subscribe(ctx, 1, eventTypes, func(batch flow.BlockEvents) error {
+		received = append(received, batch)
+		if len(received) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().Len(received, 3)
+	for i, batch := range received {
+		suite.Assert().Equal(uint64(i+1), batch.BlockHeight)
+		suite.Assert().Equal(chain.events[batch.BlockID], batch.Events)
+	}
+
+	// block 1 has no account updated events
+	received = nil
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = streamer.Subscribe(ctx, 1, []string{string(flow.EventAccountUpdated)}, func(batch flow.BlockEvents) error {
+		received = append(received, batch)
+		cancel()
+		return nil
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(received, 1)
+	suite.Assert().Empty(received[0].Events)
+}
+
+// TestSubscribeEvents_SharedFetches tests that concurrent subscriptions share the retrieval of the
+// events of each block and type from the execution nodes, as well as the sealed height tracker.
+func (suite *Suite) TestSubscribeEvents_SharedFetches() {
+	chain, backend := suite.setupSealedChain(6, 4)
+	streamer := NewEventsStreamer(backend, EventsStreamConfig{BufferSize: 10, PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// each subscriber streams the blocks 1 to 6 and stops, the subscribers wait for each other
+	// to receive all blocks, so that they are subscribed concurrently
+	const subscribers = 3
+	var received sync.WaitGroup
+	received.Add(subscribers)
+	errs := make(chan error, subscribers)
+	for i := 0; i < subscribers; i++ {
+		go func() {
+			subCtx, subCancel := context.WithCancel(ctx)
+			defer subCancel()
+			errs <- streamer.Subscribe(subCtx, 1, []string{string(flow.EventAccountCreated)}, func(batch flow.BlockEvents) error {
+				suite.Assert().Equal(chain.ofType(batch.BlockID, flow.EventAccountCreated), batch.Events)
+				if batch.BlockHeight == 6 {
+					received.Done()
+					received.Wait()
+					subCancel()
+				}
+				return nil
+			})
+		}()
+	}
+
+	// seal the last blocks once every subscriber caught up
+	time.Sleep(100 * time.Millisecond)
+	chain.seal(6)
+	for i := 0; i < subscribers; i++ {
+		select {
+		case err := <-errs:
+			suite.Require().NoError(err)
+		case <-time.After(time.Second):
+			suite.FailNow("subscription did not end")
+		}
+	}
+
+	chain.Lock()
+	defer chain.Unlock()
+	suite.Assert().Equal(6, chain.fetches)
+
+	// polling stops with the last subscription
+	streamer.sealed.mu.Lock()
+	defer streamer.sealed.mu.Unlock()
+	suite.Assert().Zero(streamer.sealed.subscribers)
+}
+
+// TestSubscribeEvents_SlowSubscriber tests that a subscriber which falls more than the buffer size
+// of blocks behind the last sealed block is disconnected, while backfilling is paced by the subscriber.
+func (suite *Suite) TestSubscribeEvents_SlowSubscriber() {
+	chain, backend := suite.setupSealedChain(10, 4)
+	streamer := NewEventsStreamer(backend, EventsStreamConfig{BufferSize: 2, PollInterval: 10 * time.Millisecond})
+
+	var received []uint64
+	err := streamer.Subscribe(context.Background(), 1, []string{string(flow.EventAccountCreated)}, func(batch flow.BlockEvents) error {
+		received = append(received, batch.BlockHeight)
+		if batch.BlockHeight == 4 {
+			// caught up with the last sealed block, stall while more blocks are sealed
+			chain.seal(10)
+			time.Sleep(200 * time.Millisecond)
+		}
+		return nil
+	})
+
+	suite.Require().Error(err)
+	suite.Assert().Equal(codes.ResourceExhausted, status.Code(err))
+	suite.Assert().Equal([]uint64{1, 2, 3, 4}, received)
+}
+
+// TestSubscribeEvents_InvalidRequest tests that invalid subscriptions are rejected.
+func (suite *Suite) TestSubscribeEvents_InvalidRequest() {
+	_, backend := suite.setupSealedChain(3, 3)
+	streamer := NewEventsStreamer(backend, DefaultEventsStreamConfig())
+	send := func(flow.BlockEvents) error {
+		suite.Fail("unexpected batch")
+		return nil
+	}
+
+	suite.Run("no event types", func() {
+		err := streamer.Subscribe(context.Background(), 1, nil, send)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("empty event type", func() {
+		err := streamer.Subscribe(context.Background(), 1, []string{string(flow.EventAccountCreated), " "}, send)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("start height below earliest height", func() {
+		streamer.events.heights = newHeightRange(suite.state, 2)
+		err := streamer.Subscribe(context.Background(), 1, []string{string(flow.EventAccountCreated)}, send)
+		suite.Assert().True(IsInsufficientHistoryError(err))
+	})
+}
@@ -19,6 +19,8 @@ type backendScripts struct {
 	executionReceipts storage.ExecutionReceipts
 	state             protocol.State
 	connFactory       ConnectionFactory
+	heights           *heightRange
+	cache             *scriptCache // nil if script execution results are not cached
 	log               zerolog.Logger
 }
 
@@ -57,6 +59,11 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 	script []byte,
 	arguments [][]byte,
 ) ([]byte, error) {
+	err := b.heights.check(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
 	// get header at given height
 	header, err := b.headers.ByHeight(blockHeight)
 	if err != nil {
@@ -79,6 +86,14 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 	arguments [][]byte,
 ) ([]byte, error) {
 
+	var key scriptCacheKey
+	if b.cache != nil {
+		key = newScriptCacheKey(blockID, script, arguments)
+		if result, ok := b.cache.get(key); ok {
+			return result, nil
+		}
+	}
+
 	execReq := execproto.ExecuteScriptAtBlockIDRequest{
 		BlockId:   blockID[:],
 		Script:    script,
@@ -102,6 +117,10 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 				Hex("block_id", blockID[:]).
 				Str("script", string(script)).
 				Msg("Successfully executed script")
+			// only successful executions are cached
+			if b.cache != nil {
+				b.cache.put(key, result)
+			}
 			return result, nil
 		}
 		errors = multierror.Append(errors, err)
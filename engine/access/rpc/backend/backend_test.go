@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/storage"
 	storagemock "github.com/onflow/flow-go/storage/mock"
@@ -57,7 +58,7 @@ func (suite *Suite) SetupTest() {
 	suite.log = zerolog.New(zerolog.NewConsoleWriter())
 	suite.state = new(protocol.State)
 	suite.snapshot = new(protocol.Snapshot)
-	header := unittest.BlockHeaderFixture()
+	header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(0))
 	params := new(protocol.Params)
 	params.On("Root").Return(&header, nil)
 	suite.state.On("Params").Return(params).Maybe()
@@ -92,6 +93,12 @@ func (suite *Suite) TestPing() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -116,6 +123,12 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -148,6 +161,12 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot() {
 		nil,
 		false,
 		100,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -179,6 +198,12 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -217,6 +242,12 @@ func (suite *Suite) TestGetTransaction() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -252,6 +283,12 @@ func (suite *Suite) TestGetCollection() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -278,9 +315,9 @@ func (suite *Suite) TestTransactionStatusTransition() {
 	headBlock := unittest.BlockFixture()
 	headBlock.Header.Height = block.Header.Height - 1 // head is behind the current block
 
-	suite.snapshot.
-		On("Head").
-		Return(headBlock.Header, nil)
+	suite.state.
+		On("Boundaries").
+		Return(headBlock.Header, headBlock.Header, nil)
 
 	light := collection.Light()
 
@@ -333,6 +370,12 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		connFactory,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
@@ -413,9 +456,9 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		func() error { return nil },
 	)
 
-	suite.snapshot.
-		On("Head").
-		Return(headBlock.Header, nil)
+	suite.state.
+		On("Boundaries").
+		Return(headBlock.Header, headBlock.Header, nil)
 
 	snapshotAtBlock := new(protocol.Snapshot)
 	snapshotAtBlock.On("Head").Return(block.Header, nil)
@@ -451,6 +494,12 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -527,9 +576,9 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 	headBlock := unittest.BlockFixture()
 	headBlock.Header.Height = refBlock.Header.Height - 1 // head is behind the current refBlock
 
-	suite.snapshot.
-		On("Head").
-		Return(headBlock.Header, nil)
+	suite.state.
+		On("Boundaries").
+		Return(headBlock.Header, headBlock.Header, nil)
 
 	snapshotAtBlock := new(protocol.Snapshot)
 	snapshotAtBlock.On("Head").Return(refBlock.Header, nil)
@@ -608,6 +657,12 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 		connFactory,
 		false,
 		100,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		flow.IdentifierList(enIDs.NodeIDs()).Strings(),
 		suite.log,
@@ -664,6 +719,12 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -705,6 +766,12 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -829,6 +896,12 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			connFactory, // the connection factory should be used to get the execution node client
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
@@ -856,6 +929,12 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			connFactory, // the connection factory should be used to get the execution node client
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			validENIDs.Strings(),
 			suite.log,
@@ -911,6 +990,12 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			connFactory, // the connection factory should be used to get the execution node client
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
@@ -937,6 +1022,12 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			connFactory, // the connection factory should be used to get the execution node client
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			validENIDs.Strings(),
 			suite.log,
@@ -970,7 +1061,7 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 	state.On("Final").Return(snapshot, nil)
 	state.On("Sealed").Return(snapshot, nil)
 
-	rootHeader := unittest.BlockHeaderFixture()
+	rootHeader := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(0))
 	params := new(protocol.Params)
 	params.On("Root").Return(&rootHeader, nil)
 	state.On("Params").Return(params).Maybe()
@@ -1080,6 +1171,12 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			connFactory,
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			nil,
 			suite.log,
@@ -1115,6 +1212,12 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			connFactory,
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			fixedENIdentifiersStr,
 			suite.log,
@@ -1149,6 +1252,12 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			connFactory,
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			fixedENIdentifiersStr,
 			suite.log,
@@ -1182,6 +1291,12 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			connFactory,
 			false,
 			1, // set maximum range to 1
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			fixedENIdentifiersStr,
 			suite.log,
@@ -1215,6 +1330,12 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			connFactory,
 			false,
 			DefaultMaxHeightRange,
+			0,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
 			nil,
 			fixedENIdentifiersStr,
 			suite.log,
@@ -1287,6 +1408,12 @@ func (suite *Suite) TestGetAccount() {
 		connFactory,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -1363,6 +1490,12 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		connFactory,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -1380,6 +1513,275 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 	})
 }
 
+// TestHistoryWindow tests that requests for heights below the earliest height served by the node are rejected
+// with an InsufficientHistoryError, while requests at or above the earliest height are served from storage.
+// TestExecuteScriptCache tests that identical script executions at the same block are served from
+// the script cache within the TTL, while executions differing in a single argument byte, and failed
+// executions, reach the execution node.
+func (suite *Suite) TestExecuteScriptCache() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+	_, ids := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	connFactory := suite.setupConnectionFactory()
+	transactionMetrics := new(mockmodule.TransactionMetrics)
+	transactionMetrics.On("ScriptExecutionCacheHit").Return()
+	transactionMetrics.On("ScriptExecutionCacheMiss").Return()
+
+	backend := New(
+		suite.state,
+		nil, nil, nil,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		transactionMetrics,
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		0,
+		100,
+		time.Minute,
+		0,
+		0,
+		ArgumentLimits{},
+		nil,
+		nil,
+		suite.log,
+	)
+
+	script := []byte("dummy script")
+	arguments := [][]byte{[]byte("argument")}
+	request := func(arguments [][]byte) *execproto.ExecuteScriptAtBlockIDRequest {
+		return &execproto.ExecuteScriptAtBlockIDRequest{
+			BlockId:   blockID[:],
+			Script:    script,
+			Arguments: arguments,
+		}
+	}
+
+	suite.Run("identical execution within the TTL is cached", func() {
+		suite.execClient.
+			On("ExecuteScriptAtBlockID", ctx, request(arguments)).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("result")}, nil).
+			Once()
+
+		for i := 0; i < 2; i++ {
+			result, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+			suite.Require().NoError(err)
+			suite.Assert().Equal([]byte("result"), result)
+		}
+
+		suite.execClient.AssertNumberOfCalls(suite.T(), "ExecuteScriptAtBlockID", 1)
+		transactionMetrics.AssertNumberOfCalls(suite.T(), "ScriptExecutionCacheMiss", 1)
+		transactionMetrics.AssertNumberOfCalls(suite.T(), "ScriptExecutionCacheHit", 1)
+	})
+
+	suite.Run("execution with a different argument byte is not cached", func() {
+		otherArguments := [][]byte{[]byte("argumenT")}
+		suite.execClient.
+			On("ExecuteScriptAtBlockID", ctx, request(otherArguments)).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("other result")}, nil).
+			Once()
+
+		result, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, otherArguments)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("other result"), result)
+
+		suite.execClient.AssertNumberOfCalls(suite.T(), "ExecuteScriptAtBlockID", 2)
+	})
+
+	suite.Run("failed execution is not cached", func() {
+		failingArguments := [][]byte{[]byte("failing")}
+		suite.execClient.
+			On("ExecuteScriptAtBlockID", ctx, request(failingArguments)).
+			Return(nil, status.Error(codes.InvalidArgument, "execution failed")).
+			Times(len(ids))
+		_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, failingArguments)
+		suite.Require().Error(err)
+
+		suite.execClient.
+			On("ExecuteScriptAtBlockID", ctx, request(failingArguments)).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("result")}, nil).
+			Once()
+		result, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, failingArguments)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("result"), result)
+	})
+
+	suite.assertAllExpectations()
+}
+
+func (suite *Suite) TestHistoryWindow() {
+	const rootHeight uint64 = 100
+
+	type endpoint struct {
+		name string
+		// expect sets up the storage expectations of a request which passed the history window
+		expect func(height uint64)
+		call   func(backend *Backend, height uint64) error
+	}
+
+	endpoints := func(blocks *storagemock.Blocks, headers *storagemock.Headers, transactions *storagemock.Transactions,
+		collections *storagemock.Collections, receipts *storagemock.ExecutionReceipts) []endpoint {
+
+		ctx := context.Background()
+		block := unittest.BlockFixture()
+		tx := unittest.TransactionFixture()
+		collection := unittest.CollectionFixture(1)
+		light := collection.Light()
+		// the block and transaction are found in storage, the window is checked against the height of the block
+		transactions.On("ByID", tx.ID()).Return(&tx.TransactionBody, nil).Maybe()
+		collections.On("LightByTransactionID", tx.ID()).Return(&light, nil).Maybe()
+
+		return []endpoint{
+			{
+				name:   "GetBlockByHeight",
+				expect: func(height uint64) { blocks.On("ByHeight", height).Return(nil, storage.ErrNotFound).Once() },
+				call: func(backend *Backend, height uint64) error {
+					_, err := backend.GetBlockByHeight(ctx, height)
+					return err
+				},
+			},
+			{
+				name:   "GetBlockHeaderByHeight",
+				expect: func(height uint64) { headers.On("ByHeight", height).Return(nil, storage.ErrNotFound).Once() },
+				call: func(backend *Backend, height uint64) error {
+					_, err := backend.GetBlockHeaderByHeight(ctx, height)
+					return err
+				},
+			},
+			{
+				name:   "ExecuteScriptAtBlockHeight",
+				expect: func(height uint64) { headers.On("ByHeight", height).Return(nil, storage.ErrNotFound).Once() },
+				call: func(backend *Backend, height uint64) error {
+					_, err := backend.ExecuteScriptAtBlockHeight(ctx, height, []byte("dummy script"), nil)
+					return err
+				},
+			},
+			{
+				name:   "GetAccountAtBlockHeight",
+				expect: func(height uint64) { headers.On("ByHeight", height).Return(nil, storage.ErrNotFound).Once() },
+				call: func(backend *Backend, height uint64) error {
+					_, err := backend.GetAccountAtBlockHeight(ctx, unittest.AddressFixture(), height)
+					return err
+				},
+			},
+			{
+				name:   "GetEventsForHeightRange",
+				expect: func(height uint64) { headers.On("ByHeight", height).Return(nil, storage.ErrNotFound).Once() },
+				call: func(backend *Backend, height uint64) error {
+					_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), height, height+1)
+					return err
+				},
+			},
+			{
+				name: "GetBlockByID",
+				call: func(backend *Backend, height uint64) error {
+					block.Header.Height = height
+					blocks.On("ByID", block.ID()).Return(&block, nil).Once()
+					_, err := backend.GetBlockByID(ctx, block.ID())
+					return err
+				},
+			},
+			{
+				name:   "GetTransactionResult",
+				expect: func(height uint64) { receipts.On("ByBlockID", mock.Anything).Return(nil, storage.ErrNotFound).Once() },
+				call: func(backend *Backend, height uint64) error {
+					block.Header.Height = height
+					blocks.On("ByCollectionID", light.ID()).Return(&block, nil).Once()
+					_, err := backend.GetTransactionResult(ctx, tx.ID())
+					return err
+				},
+			},
+		}
+	}
+
+	test := func(earliestHeight uint64, expectedEarliest uint64) {
+		state := new(protocol.State)
+		snapshot := new(protocol.Snapshot)
+		rootHeader := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(rootHeight))
+		params := new(protocol.Params)
+		params.On("Root").Return(&rootHeader, nil)
+		state.On("Params").Return(params)
+		state.On("Sealed").Return(snapshot).Maybe()
+		sealed := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(expectedEarliest + 10))
+		snapshot.On("Head").Return(&sealed, nil).Maybe()
+		state.On("Boundaries").Return(&sealed, &sealed, nil).Maybe()
+
+		blocks := new(storagemock.Blocks)
+		headers := new(storagemock.Headers)
+		transactions := new(storagemock.Transactions)
+		collections := new(storagemock.Collections)
+		receipts := new(storagemock.ExecutionReceipts)
+
+		backend := New(
+			state,
+			nil, nil,
+			blocks, headers, collections, transactions, receipts, nil,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			nil,
+			false,
+			DefaultMaxHeightRange,
+			earliestHeight,
+			0,
+			0,
+			0,
+			0,
+			ArgumentLimits{},
+			nil,
+			nil,
+			suite.log,
+		)
+
+		for _, e := range endpoints(blocks, headers, transactions, collections, receipts) {
+			suite.Run(e.name, func() {
+				// below the window, the storage is not queried
+				err := e.call(backend, expectedEarliest-1)
+				suite.Require().Error(err)
+				suite.Assert().Equal(codes.OutOfRange, status.Code(err))
+				suite.Require().True(IsInsufficientHistoryError(err))
+				var historyErr InsufficientHistoryError
+				suite.Require().ErrorAs(err, &historyErr)
+				suite.Assert().Equal(expectedEarliest-1, historyErr.Height)
+				suite.Assert().Equal(expectedEarliest, historyErr.EarliestHeight)
+
+				// at the boundary and within the window, the request is served from storage
+				for _, height := range []uint64{expectedEarliest, expectedEarliest + 1} {
+					if e.expect != nil {
+						e.expect(height)
+					}
+					err = e.call(backend, height)
+					suite.Assert().False(IsInsufficientHistoryError(err))
+				}
+			})
+		}
+
+		blocks.AssertExpectations(suite.T())
+		headers.AssertExpectations(suite.T())
+		receipts.AssertExpectations(suite.T())
+	}
+
+	suite.Run("earliest height of the root block", func() {
+		test(0, rootHeight)
+	})
+
+	suite.Run("configured earliest height above the root block", func() {
+		test(rootHeight+50, rootHeight+50)
+	})
+
+	suite.Run("configured earliest height below the root block", func() {
+		test(rootHeight-50, rootHeight)
+	})
+}
+
 func (suite *Suite) TestGetNetworkParameters() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 
@@ -1393,6 +1795,12 @@ func (suite *Suite) TestGetNetworkParameters() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -33,9 +33,11 @@ type backendTransactions struct {
 	state                protocol.State
 	chainID              flow.ChainID
 	transactionMetrics   module.TransactionMetrics
+	maxErrorMessageSize  uint // budget in bytes for transaction error messages, 0 means the default
 	transactionValidator *access.TransactionValidator
 	retry                *Retry
 	connFactory          ConnectionFactory
+	heights              *heightRange
 
 	previousAccessNodes []accessproto.AccessAPIClient
 	log                 zerolog.Logger
@@ -214,6 +216,8 @@ func (b *backendTransactions) GetTransactionResult(
 					StatusCode: uint(status),
 				}, nil
 			}
+			// historical access nodes might not truncate error messages
+			access.TruncateTransactionError(historicalTxResult, b.maxErrorMessageSize)
 			return historicalTxResult, nil
 		}
 		return nil, txErr
@@ -225,6 +229,14 @@ func (b *backendTransactions) GetTransactionResult(
 		return nil, convertStorageError(err)
 	}
 
+	// the block of the transaction is still in storage, but may be below the served history
+	if block != nil {
+		err = b.heights.check(block.Header.Height)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var blockID flow.Identifier
 	var transactionWasExecuted bool
 	var events []flow.Event
@@ -245,13 +257,16 @@ func (b *backendTransactions) GetTransactionResult(
 		return nil, convertStorageError(err)
 	}
 
-	return &access.TransactionResult{
+	result := &access.TransactionResult{
 		Status:       status,
 		StatusCode:   uint(statusCode),
 		Events:       events,
 		ErrorMessage: txError,
 		BlockID:      blockID,
-	}, nil
+	}
+	// error messages can be too large for a response, hence they are truncated
+	access.TruncateTransactionError(result, b.maxErrorMessageSize)
+	return result, nil
 }
 
 // deriveTransactionStatus derives the transaction status based on current protocol state
@@ -269,7 +284,7 @@ func (b *backendTransactions) deriveTransactionStatus(
 		}
 		refHeight := referenceBlock.Height
 		// get the latest finalized block from the state
-		finalized, err := b.state.Final().Head()
+		finalized, _, err := b.state.Boundaries()
 		if err != nil {
 			return flow.TransactionStatusUnknown, err
 		}
@@ -313,7 +328,7 @@ func (b *backendTransactions) deriveTransactionStatus(
 	// From this point on, we know for sure this transaction has at least been executed
 
 	// get the latest sealed block from the state
-	sealed, err := b.state.Sealed().Head()
+	_, sealed, err := b.state.Boundaries()
 	if err != nil {
 		return flow.TransactionStatusUnknown, err
 	}
@@ -0,0 +1,251 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSendTransaction_Expiry tests that transactions are only forwarded to the collection node, if
+// their reference block is known and at least the expiry buffer of blocks remain until they expire.
+func (suite *Suite) TestSendTransaction_Expiry() {
+	const finalHeight = 2 * flow.DefaultTransactionExpiry
+	const expiryBuffer = 50
+
+	final := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(finalHeight))
+	suite.state.On("Final").Return(suite.snapshot)
+	suite.snapshot.On("Head").Return(&final, nil)
+	suite.colClient.On("SendTransaction", mock.Anything, mock.Anything).Return(&accessproto.SendTransactionResponse{}, nil)
+	suite.transactions.On("Store", mock.Anything).Return(nil)
+
+	backend := New(
+		suite.state,
+		suite.colClient,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		expiryBuffer,
+		ArgumentLimits{},
+		nil,
+		nil,
+		suite.log,
+	)
+
+	// transactionAt returns a transaction referencing a block the given number of blocks below the finalized block
+	transactionAt := func(age uint64) *flow.TransactionBody {
+		ref := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(finalHeight - age))
+		snapshot := new(protocol.Snapshot)
+		snapshot.On("Head").Return(&ref, nil)
+		suite.state.On("AtBlockID", ref.ID()).Return(snapshot)
+		tx := unittest.TransactionBodyFixture(unittest.WithReferenceBlock(ref.ID()))
+		return &tx
+	}
+
+	suite.Run("fresh reference block", func() {
+		tx := transactionAt(10)
+		err := backend.SendTransaction(context.Background(), tx)
+		suite.Require().NoError(err)
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 1)
+	})
+
+	suite.Run("reference block at the expiry buffer", func() {
+		tx := transactionAt(flow.DefaultTransactionExpiry - expiryBuffer)
+		err := backend.SendTransaction(context.Background(), tx)
+		suite.Require().NoError(err)
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 2)
+	})
+
+	suite.Run("nearly expired reference block", func() {
+		tx := transactionAt(flow.DefaultTransactionExpiry - expiryBuffer + 1)
+		err := backend.SendTransaction(context.Background(), tx)
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		suite.Assert().Contains(err.Error(), access.ExpiredTransactionError{
+			RefHeight:   finalHeight - flow.DefaultTransactionExpiry + expiryBuffer - 1,
+			FinalHeight: finalHeight,
+		}.Error())
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 2)
+	})
+
+	suite.Run("expired reference block", func() {
+		tx := transactionAt(flow.DefaultTransactionExpiry + 1)
+		err := backend.SendTransaction(context.Background(), tx)
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		suite.Assert().Contains(err.Error(), "transaction is expired")
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 2)
+	})
+
+	suite.Run("unknown reference block", func() {
+		tx := unittest.TransactionBodyFixture()
+		suite.state.On("AtBlockID", tx.ReferenceBlockID).Return(unknownBlockSnapshot())
+		err := backend.SendTransaction(context.Background(), &tx)
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		suite.Assert().Contains(err.Error(), access.InvalidReferenceBlockError{ReferenceBlockID: tx.ReferenceBlockID}.Error())
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 2)
+	})
+}
+
+// TestSendTransaction_ArgumentLimits tests that transactions are only forwarded to the collection node,
+// if their arguments are within the configured limits, and that transactions exceeding a limit are
+// rejected as invalid arguments with an error naming the exceeded limit.
+func (suite *Suite) TestSendTransaction_ArgumentLimits() {
+	backend := suite.argumentLimitsBackend(ArgumentLimits{MaxCount: 3, MaxSize: 10, MaxTotalSize: 25})
+	final, err := suite.snapshot.Head()
+	suite.Require().NoError(err)
+
+	// transactionWith returns a transaction with arguments of the given byte sizes
+	transactionWith := func(sizes ...int) *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture(unittest.WithReferenceBlock(final.ID()))
+		for _, size := range sizes {
+			tx.Arguments = append(tx.Arguments, make([]byte, size))
+		}
+		return &tx
+	}
+
+	forwarded := 0
+	for _, test := range []struct {
+		name  string
+		sizes []int
+		err   error // nil if the transaction is forwarded
+	}{
+		{name: "argument count at the limit", sizes: []int{1, 1, 1}},
+		{name: "argument count over the limit", sizes: []int{1, 1, 1, 1}, err: access.InvalidArgumentCountError{Actual: 4, Maximum: 3}},
+		{name: "argument size at the limit", sizes: []int{10}},
+		{name: "argument size over the limit", sizes: []int{5, 11}, err: access.InvalidArgumentByteSizeError{Index: 1, Actual: 11, Maximum: 10}},
+		{name: "total argument size at the limit", sizes: []int{10, 10, 5}},
+		{name: "total argument size over the limit", sizes: []int{10, 10, 6}, err: access.InvalidArgumentsByteSizeError{Actual: 26, Maximum: 25}},
+	} {
+		suite.Run(test.name, func() {
+			err := backend.SendTransaction(context.Background(), transactionWith(test.sizes...))
+			if test.err == nil {
+				suite.Require().NoError(err)
+				forwarded++
+			} else {
+				suite.Require().Error(err)
+				suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+				suite.Assert().Contains(err.Error(), test.err.Error())
+			}
+			suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", forwarded)
+		})
+	}
+}
+
+// TestSendTransaction_StrictArguments tests that transactions with arguments, which are not valid
+// JSON-CDC encodings, are only rejected in strict mode.
+func (suite *Suite) TestSendTransaction_StrictArguments() {
+	strict := suite.argumentLimitsBackend(ArgumentLimits{Strict: true})
+	lenient := suite.argumentLimitsBackend(ArgumentLimits{})
+	final, err := suite.snapshot.Head()
+	suite.Require().NoError(err)
+
+	valid := jsoncdc.MustEncode(cadence.NewInt(1))
+	tx := unittest.TransactionBodyFixture(unittest.WithReferenceBlock(final.ID()))
+	tx.Arguments = [][]byte{valid, valid}
+
+	suite.Run("valid arguments", func() {
+		suite.Require().NoError(strict.SendTransaction(context.Background(), &tx))
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 1)
+	})
+
+	tx.Arguments = [][]byte{valid, []byte("{")}
+
+	suite.Run("invalid argument in strict mode", func() {
+		err := strict.SendTransaction(context.Background(), &tx)
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		suite.Assert().Contains(err.Error(), "transaction argument 1 is not a valid JSON-CDC encoding")
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 1)
+	})
+
+	suite.Run("invalid argument in lenient mode", func() {
+		suite.Require().NoError(lenient.SendTransaction(context.Background(), &tx))
+		suite.colClient.AssertNumberOfCalls(suite.T(), "SendTransaction", 2)
+	})
+}
+
+// argumentLimitsBackend returns a backend with the given argument limits, which accepts transactions
+// referencing the finalized block and forwards them to the collection node.
+func (suite *Suite) argumentLimitsBackend(limits ArgumentLimits) *Backend {
+	final := unittest.BlockHeaderFixture()
+	suite.state.On("Final").Return(suite.snapshot)
+	suite.state.On("AtBlockID", final.ID()).Return(suite.snapshot)
+	suite.snapshot.On("Head").Return(&final, nil)
+	suite.colClient.On("SendTransaction", mock.Anything, mock.Anything).Return(&accessproto.SendTransactionResponse{}, nil)
+	suite.transactions.On("Store", mock.Anything).Return(nil)
+
+	return New(
+		suite.state,
+		suite.colClient,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		flow.DefaultTransactionExpiryBuffer,
+		limits,
+		nil,
+		nil,
+		suite.log,
+	)
+}
+
+// TestTransactionValidator_UnknownReferenceBlock tests that the validator rejects transactions
+// with unknown reference blocks with an InvalidReferenceBlockError.
+func (suite *Suite) TestTransactionValidator_UnknownReferenceBlock() {
+	tx := unittest.TransactionBodyFixture()
+	suite.state.On("AtBlockID", tx.ReferenceBlockID).Return(unknownBlockSnapshot())
+
+	validator := configureTransactionValidator(suite.state, suite.chainID, flow.DefaultTransactionExpiryBuffer, ArgumentLimits{})
+	err := validator.Validate(&tx)
+
+	var refErr access.InvalidReferenceBlockError
+	suite.Require().True(errors.As(err, &refErr))
+	suite.Assert().Equal(tx.ReferenceBlockID, refErr.ReferenceBlockID)
+	suite.Assert().True(errors.Is(err, access.ErrUnknownReferenceBlock))
+}
+
+// unknownBlockSnapshot returns a snapshot of the protocol state at a block, which is not known.
+func unknownBlockSnapshot() *protocol.Snapshot {
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Head").Return(nil, storage.ErrNotFound)
+	return snapshot
+}
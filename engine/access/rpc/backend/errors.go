@@ -1,8 +1,12 @@
 package backend
 
 import (
+	"errors"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -15,3 +19,26 @@ type InsufficientExecutionReceipts struct {
 func (e InsufficientExecutionReceipts) Error() string {
 	return fmt.Sprintf("insufficient execution receipts found (%d) for block ID: %s", e.receiptCount, e.blockID.String())
 }
+
+// InsufficientHistoryError indicates that data was requested for a height below the earliest height
+// for which the access node serves historical data.
+type InsufficientHistoryError struct {
+	Height         uint64
+	EarliestHeight uint64
+}
+
+func (e InsufficientHistoryError) Error() string {
+	return fmt.Sprintf("insufficient history for height %d: earliest available height is %d", e.Height, e.EarliestHeight)
+}
+
+// GRPCStatus returns the gRPC status of the error, which is OutOfRange, so that the error is
+// returned to gRPC clients with the corresponding code.
+func (e InsufficientHistoryError) GRPCStatus() *status.Status {
+	return status.New(codes.OutOfRange, e.Error())
+}
+
+// IsInsufficientHistoryError returns whether the given error is an InsufficientHistoryError.
+func IsInsufficientHistoryError(err error) bool {
+	var historyErr InsufficientHistoryError
+	return errors.As(err, &historyErr)
+}
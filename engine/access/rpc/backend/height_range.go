@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// heightRange guards height based requests against the window of block heights for which the
+// access node serves historical data. The node never has data below the root block of its protocol
+// state, while operators may raise the earliest height further to bound the history served.
+type heightRange struct {
+	state          protocol.State
+	earliestHeight uint64
+}
+
+func newHeightRange(state protocol.State, earliestHeight uint64) *heightRange {
+	return &heightRange{
+		state:          state,
+		earliestHeight: earliestHeight,
+	}
+}
+
+// earliest returns the earliest height for which data is served, which is the configured
+// earliest height or the root block height, whichever is higher.
+func (r *heightRange) earliest() (uint64, error) {
+	root, err := r.state.Params().Root()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get root block: %v", err)
+	}
+	if root.Height > r.earliestHeight {
+		return root.Height, nil
+	}
+	return r.earliestHeight, nil
+}
+
+// check returns an InsufficientHistoryError if the given height is below the earliest height
+// for which data is served.
+func (r *heightRange) check(height uint64) error {
+	earliest, err := r.earliest()
+	if err != nil {
+		return err
+	}
+	if height < earliest {
+		return InsufficientHistoryError{Height: height, EarliestHeight: earliest}
+	}
+	return nil
+}
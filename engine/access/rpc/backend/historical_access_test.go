@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -52,6 +53,12 @@ func (suite *Suite) TestHistoricalTransactionResult() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
@@ -73,6 +80,63 @@ func (suite *Suite) TestHistoricalTransactionResult() {
 	suite.assertAllExpectations()
 }
 
+// TestHistoricalTransactionResultErrorTruncated tests that error messages of transaction results
+// from historical access nodes are truncated
+func (suite *Suite) TestHistoricalTransactionResultErrorTruncated() {
+
+	ctx := context.Background()
+	collection := unittest.CollectionFixture(1)
+	transactionBody := collection.Transactions[0]
+
+	txID := transactionBody.ID()
+	suite.transactions.
+		On("ByID", txID).
+		Return(nil, status.Errorf(codes.NotFound, "not found on main node"))
+
+	errorMessage := "error\n" + strings.Repeat("x", 1000)
+	suite.historicalAccessClient.
+		On("GetTransactionResult", ctx, &accessproto.GetTransactionRequest{Id: txID[:]}).
+		Return(&accessproto.TransactionResultResponse{
+			Status:       entities.TransactionStatus(flow.TransactionStatusSealed),
+			ErrorMessage: errorMessage,
+		}, nil).
+		Once()
+
+	backend := New(
+		suite.state,
+		nil,
+		[]accessproto.AccessAPIClient{suite.historicalAccessClient},
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		100,
+		0,
+		ArgumentLimits{},
+		nil,
+		nil,
+		suite.log,
+	)
+
+	result, err := backend.GetTransactionResult(ctx, txID)
+	suite.checkResponse(result, err)
+
+	suite.Assert().LessOrEqual(len(result.ErrorMessage), 100)
+	suite.Assert().Equal(uint(len(errorMessage)), result.ErrorMessageLength)
+
+	suite.assertAllExpectations()
+}
+
 // TestHistoricalTransaction tests to see if the historical transaction can be retrieved
 func (suite *Suite) TestHistoricalTransaction() {
 
@@ -109,6 +173,12 @@ func (suite *Suite) TestHistoricalTransaction() {
 		nil,
 		false,
 		DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		ArgumentLimits{},
 		nil,
 		nil,
 		suite.log,
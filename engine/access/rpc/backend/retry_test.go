@@ -31,7 +31,7 @@ func (suite *Suite) TestTransactionRetry() {
 	headBlock.Header.Height = block.Header.Height - 1 // head is behind the current block
 	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
 
-	suite.snapshot.On("Head").Return(headBlock.Header, nil)
+	suite.state.On("Boundaries").Return(headBlock.Header, headBlock.Header, nil)
 	snapshotAtBlock := new(protocol.Snapshot)
 	snapshotAtBlock.On("Head").Return(block.Header, nil)
 	suite.state.On("AtBlockID", block.ID()).Return(snapshotAtBlock, nil)
@@ -44,7 +44,7 @@ func (suite *Suite) TestTransactionRetry() {
 	// Setup Handler + Retry
 	backend := New(suite.state, suite.colClient, nil, suite.blocks, suite.headers,
 		suite.collections, suite.transactions, suite.receipts, suite.results, suite.chainID, metrics.NewNoopCollector(), nil,
-		false, DefaultMaxHeightRange, nil, nil, suite.log)
+		false, DefaultMaxHeightRange, 0, 0, 0, 0, 0, ArgumentLimits{}, nil, nil, suite.log)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
@@ -108,7 +108,7 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 	// Setup Handler + Retry
 	backend := New(suite.state, suite.colClient, nil, suite.blocks, suite.headers,
 		suite.collections, suite.transactions, suite.receipts, suite.results, suite.chainID, metrics.NewNoopCollector(), connFactory,
-		false, DefaultMaxHeightRange, nil, nil, suite.log)
+		false, DefaultMaxHeightRange, 0, 0, 0, 0, 0, ArgumentLimits{}, nil, nil, suite.log)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"encoding/binary"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// DefaultScriptCacheTTL is the default time for which a script execution result is cached.
+const DefaultScriptCacheTTL = 10 * time.Second
+
+// scriptCacheKey identifies a script execution by the block it is executed at, and the
+// SHA3-256 hash of the script and its arguments.
+type scriptCacheKey struct {
+	blockID flow.Identifier
+	hash    flow.Identifier
+}
+
+// scriptCacheEntry is a cached script execution result.
+type scriptCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// scriptCache is an LRU cache of script execution results, of which entries expire after a
+// fixed time. As the entries are keyed by block, scripts executed at the latest sealed block
+// stop hitting the cache once another block is sealed.
+// scriptCache is safe for concurrent use.
+type scriptCache struct {
+	cache   *lru.Cache
+	ttl     time.Duration
+	metrics module.TransactionMetrics
+}
+
+// newScriptCache creates a script cache for up to the given number of entries. Returns nil,
+// which disables caching, if the size or the TTL is zero.
+func newScriptCache(size uint, ttl time.Duration, metrics module.TransactionMetrics) *scriptCache {
+	if size == 0 || ttl <= 0 {
+		return nil
+	}
+	// the error only reports a non-positive size
+	cache, _ := lru.New(int(size))
+	return &scriptCache{
+		cache:   cache,
+		ttl:     ttl,
+		metrics: metrics,
+	}
+}
+
+// get returns the cached result of the script execution, if it exists and has not expired.
+func (c *scriptCache) get(key scriptCacheKey) ([]byte, bool) {
+	value, ok := c.cache.Get(key)
+	if ok {
+		entry := value.(*scriptCacheEntry)
+		if time.Now().Before(entry.expires) {
+			c.metrics.ScriptExecutionCacheHit()
+			return entry.value, true
+		}
+		c.cache.Remove(key)
+	}
+	c.metrics.ScriptExecutionCacheMiss()
+	return nil, false
+}
+
+// put caches the result of a successful script execution.
+func (c *scriptCache) put(key scriptCacheKey, value []byte) {
+	c.cache.Add(key, &scriptCacheEntry{
+		value:   value,
+		expires: time.Now().Add(c.ttl),
+	})
+}
+
+// newScriptCacheKey returns the cache key of the script execution at the given block.
+// The script and each argument are prefixed with their length, so that different splits
+// of the same bytes into script and arguments don't collide.
+func newScriptCacheKey(blockID flow.Identifier, script []byte, arguments [][]byte) scriptCacheKey {
+	hasher := hash.NewSHA3_256()
+	var length [8]byte
+	write := func(data []byte) {
+		binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+		_, _ = hasher.Write(length[:])
+		_, _ = hasher.Write(data)
+	}
+	write(script)
+	for _, argument := range arguments {
+		write(argument)
+	}
+	return scriptCacheKey{
+		blockID: blockID,
+		hash:    flow.HashToID(hasher.SumHash()),
+	}
+}
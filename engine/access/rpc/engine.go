@@ -14,6 +14,8 @@ import (
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/onflow/flow-go/access"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
@@ -42,6 +44,12 @@ type Config struct {
 	ExecutionClientTimeout    time.Duration                    // execution API GRPC client timeout
 	CollectionClientTimeout   time.Duration                    // collection API GRPC client timeout
 	MaxHeightRange            uint                             // max size of height range requests
+	EarliestHeight            uint64                           // earliest height for which historical data is served, at least the root block height
+	ScriptCacheSize           uint                             // max number of cached script execution results, 0 disables the cache
+	ScriptCacheTTL            time.Duration                    // time for which a script execution result is cached
+	MaxErrorMessageSize       uint                             // max size in bytes of transaction error messages in responses
+	TransactionExpiryBuffer   uint                             // min number of blocks until expiry for transactions to be accepted, 0 means the default
+	TransactionArgumentLimits backend.ArgumentLimits           // limits on the arguments of transactions to be accepted
 	PreferredExecutionNodeIDs []string                         // preferred list of upstream execution node IDs
 	FixedExecutionNodeIDs     []string                         // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
 }
@@ -55,6 +63,7 @@ type Engine struct {
 	backend             *backend.Backend // the gRPC service implementation
 	unsecureGrpcServer  *grpc.Server     // the unsecure gRPC server
 	secureGrpcServer    *grpc.Server     // the secure gRPC server
+	healthServer        *health.Server   // the gRPC health service, nil if not enabled
 	httpServer          *http.Server
 	restServer          *http.Server
 	config              Config
@@ -151,6 +160,12 @@ func New(log zerolog.Logger,
 		connectionFactory,
 		retryEnabled,
 		config.MaxHeightRange,
+		config.EarliestHeight,
+		config.ScriptCacheSize,
+		config.ScriptCacheTTL,
+		config.MaxErrorMessageSize,
+		config.TransactionExpiryBuffer,
+		config.TransactionArgumentLimits,
 		config.PreferredExecutionNodeIDs,
 		config.FixedExecutionNodeIDs,
 		log,
@@ -206,12 +221,23 @@ func (e *Engine) Ready() <-chan struct{} {
 	if e.config.RESTListenAddr != "" {
 		e.unit.Launch(e.serveREST)
 	}
-	return e.unit.Ready()
+	ready := e.unit.Ready()
+	if e.healthServer != nil {
+		e.unit.Launch(func() {
+			<-ready
+			e.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+		})
+	}
+	return ready
 }
 
 // Done returns a done channel that is closed once the engine has fully stopped.
 // It sends a signal to stop the gRPC server, then closes the channel.
 func (e *Engine) Done() <-chan struct{} {
+	if e.healthServer != nil {
+		// sets all services to NOT_SERVING and ignores any later status updates
+		e.healthServer.Shutdown()
+	}
 	return e.unit.Done(
 		e.unsecureGrpcServer.GracefulStop,
 		e.secureGrpcServer.GracefulStop,
@@ -241,6 +267,12 @@ func (e *Engine) SubmitLocal(event interface{}) {
 	})
 }
 
+// setServingStatus sets the health status of the overall server and the Access API.
+func (e *Engine) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	e.healthServer.SetServingStatus("", status)
+	e.healthServer.SetServingStatus(accessproto.AccessAPI_ServiceDesc.ServiceName, status)
+}
+
 func (e *Engine) UnsecureGRPCAddress() net.Addr {
 	return e.unsecureGrpcAddress
 }
@@ -332,7 +364,7 @@ func (e *Engine) serveREST() {
 
 	e.log.Info().Str("rest_api_address", e.config.RESTListenAddr).Msg("starting REST server on address")
 
-	restAPIHandler := rest.NewHandlers(e.backend, e.log)
+	restAPIHandler := rest.NewHandlers(e.backend, e.log, e.config.MaxErrorMessageSize)
 	e.restServer = rest.NewServer(restAPIHandler, e.config.RESTListenAddr, e.log)
 
 	l, err := net.Listen("tcp", e.config.RESTListenAddr)
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	observerproto "github.com/onflow/flow-go/engine/access/observer/protobuf"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	eventsproto "github.com/onflow/flow-go/engine/access/rpc/protobuf"
+)
+
+// RPCEngineBuilder registers optional services on the gRPC servers of the RPC engine.
+// All services are registered on both the secure and the unsecure gRPC server.
+type RPCEngineBuilder struct {
+	*Engine
+}
+
+// NewRPCEngineBuilder creates a builder for the given RPC engine. The builder must
+// be used before the engine is started, as services can't be registered on running servers.
+func NewRPCEngineBuilder(engine *Engine) *RPCEngineBuilder {
+	return &RPCEngineBuilder{
+		Engine: engine,
+	}
+}
+
+// WithReflection registers the standard gRPC reflection service, which allows tooling
+// such as grpcurl to list and describe the served APIs.
+func (builder *RPCEngineBuilder) WithReflection() *RPCEngineBuilder {
+	reflection.Register(builder.unsecureGrpcServer)
+	reflection.Register(builder.secureGrpcServer)
+	return builder
+}
+
+// WithHealthService registers the standard gRPC health service. The reported status is
+// NOT_SERVING until the engine is ready, and flips back to NOT_SERVING once the engine
+// begins to shut down.
+func (builder *RPCEngineBuilder) WithHealthService() *RPCEngineBuilder {
+	builder.healthServer = health.NewServer()
+	builder.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(builder.unsecureGrpcServer, builder.healthServer)
+	healthpb.RegisterHealthServer(builder.secureGrpcServer, builder.healthServer)
+	return builder
+}
+
+// WithConsensusObserver registers the given ConsensusObserverAPI implementation, which
+// streams the receipts and approvals observed by the node.
+func (builder *RPCEngineBuilder) WithConsensusObserver(server observerproto.ConsensusObserverAPIServer) *RPCEngineBuilder {
+	observerproto.RegisterConsensusObserverAPIServer(builder.unsecureGrpcServer, server)
+	observerproto.RegisterConsensusObserverAPIServer(builder.secureGrpcServer, server)
+	return builder
+}
+
+// WithEventsSubscription registers the EventsAPI, which streams the events of sealed blocks
+// retrieved from the execution nodes to subscribers.
+func (builder *RPCEngineBuilder) WithEventsSubscription(config backend.EventsStreamConfig) *RPCEngineBuilder {
+	handler := newEventsHandler(backend.NewEventsStreamer(builder.backend, config), builder.unit.Quit())
+	eventsproto.RegisterEventsAPIServer(builder.unsecureGrpcServer, handler)
+	eventsproto.RegisterEventsAPIServer(builder.secureGrpcServer, handler)
+	return builder
+}
+
+// Build returns the configured RPC engine.
+func (builder *RPCEngineBuilder) Build() *Engine {
+	return builder.Engine
+}
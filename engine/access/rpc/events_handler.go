@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	eventsproto "github.com/onflow/flow-go/engine/access/rpc/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// eventsHandler implements the EventsAPI on top of the events streamer of the backend.
+type eventsHandler struct {
+	eventsproto.UnimplementedEventsAPIServer
+
+	streamer *backend.EventsStreamer
+	quit     <-chan struct{}
+}
+
+func newEventsHandler(streamer *backend.EventsStreamer, quit <-chan struct{}) *eventsHandler {
+	return &eventsHandler{
+		streamer: streamer,
+		quit:     quit,
+	}
+}
+
+// SubscribeEvents streams the events of the requested types for all sealed blocks starting at the
+// requested height, until the caller cancels the stream or the engine shuts down.
+func (h *eventsHandler) SubscribeEvents(req *eventsproto.SubscribeEventsRequest, stream eventsproto.EventsAPI_SubscribeEventsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// the gRPC servers only stop gracefully once all streams have ended
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-h.quit:
+			cancel()
+		}
+	}()
+
+	err := h.streamer.Subscribe(ctx, req.GetStartHeight(), req.GetEventTypes(), func(batch flow.BlockEvents) error {
+		return stream.Send(blockEventsToMessage(batch))
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-h.quit:
+		return status.Error(codes.Unavailable, "node is shutting down")
+	default:
+		return nil
+	}
+}
+
+// blockEventsToMessage converts the events of a block into a batch message, which is marked as
+// heartbeat if the block has no events.
+func blockEventsToMessage(batch flow.BlockEvents) *eventsproto.EventsBatch {
+	events := make([]*eventsproto.Event, len(batch.Events))
+	for i, event := range batch.Events {
+		events[i] = &eventsproto.Event{
+			Type:             string(event.Type),
+			TransactionId:    event.TransactionID[:],
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Payload:          event.Payload,
+		}
+	}
+
+	return &eventsproto.EventsBatch{
+		BlockId:        batch.BlockID[:],
+		BlockHeight:    batch.BlockHeight,
+		BlockTimestamp: timestamppb.New(batch.BlockTimestamp),
+		Events:         events,
+		Heartbeat:      len(events) == 0,
+	}
+}
@@ -0,0 +1,235 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
+	eventsproto "github.com/onflow/flow-go/engine/access/rpc/protobuf"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// eventsBackend mocks the protocol state, storage and execution nodes for a chain of sealed
+// blocks, where block i has one account created event, and one account updated event if i is even.
+func eventsBackend(blocks int) (*backend.Backend, map[uint64]*flow.Header) {
+	headers := make(map[uint64]*flow.Header)
+	heights := make(map[flow.Identifier]uint64)
+	executors := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+
+	headerStorage := new(storagemock.Headers)
+	receipts := new(storagemock.ExecutionReceipts)
+	for height := uint64(1); height <= uint64(blocks); height++ {
+		block := unittest.BlockFixture()
+		block.Header.Height = height
+		headers[height] = block.Header
+		heights[block.ID()] = height
+		headerStorage.On("ByHeight", height).Return(block.Header, nil)
+
+		result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+		receipts.On("ByBlockID", block.ID()).Return(flow.ExecutionReceiptList{
+			unittest.ExecutionReceiptFixture(unittest.WithResult(result), unittest.WithExecutorID(executors[0].NodeID)),
+			unittest.ExecutionReceiptFixture(unittest.WithResult(result), unittest.WithExecutorID(executors[1].NodeID)),
+		}, nil)
+	}
+
+	root := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(0))
+	params := new(protocol.Params)
+	params.On("Root").Return(&root, nil)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Head").Return(headers[uint64(blocks)], nil)
+	snapshot.On("Identities", mock.Anything).Return(executors, nil)
+	state := new(protocol.State)
+	state.On("Params").Return(params)
+	state.On("Sealed").Return(snapshot)
+	state.On("Final").Return(snapshot)
+
+	execClient := new(accessmock.ExecutionAPIClient)
+	execClient.On("GetEventsForBlockIDs", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *execproto.GetEventsForBlockIDsRequest, _ ...grpc.CallOption) *execproto.GetEventsForBlockIDsResponse {
+			results := make([]*execproto.GetEventsForBlockIDsResponse_Result, len(req.GetBlockIds()))
+			for i, blockID := range convert.MessagesToIdentifiers(req.GetBlockIds()) {
+				height := heights[blockID]
+				var events []flow.Event
+				switch flow.EventType(req.GetType()) {
+				case flow.EventAccountCreated:
+					events = append(events, unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0))
+				case flow.EventAccountUpdated:
+					if height%2 == 0 {
+						events = append(events, unittest.EventFixture(flow.EventAccountUpdated, 0, 1, unittest.IdentifierFixture(), 0))
+					}
+				}
+				results[i] = &execproto.GetEventsForBlockIDsResponse_Result{
+					BlockId:     blockID[:],
+					BlockHeight: height,
+					Events:      convert.EventsToMessages(events),
+				}
+			}
+			return &execproto.GetEventsForBlockIDsResponse{Results: results}
+		},
+		func(context.Context, *execproto.GetEventsForBlockIDsRequest, ...grpc.CallOption) error { return nil },
+	)
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionAPIClient", mock.Anything).Return(execClient, &mockCloser{}, nil)
+
+	b := backend.New(
+		state,
+		nil, nil, nil,
+		headerStorage,
+		nil, nil,
+		receipts,
+		nil,
+		flow.Testnet,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		backend.DefaultMaxHeightRange,
+		0,
+		0,
+		0,
+		0,
+		0,
+		backend.ArgumentLimits{},
+		nil,
+		nil,
+		unittest.Logger(),
+	)
+
+	return b, headers
+}
+
+type mockCloser struct{}
+
+func (mc *mockCloser) Close() error { return nil }
+
+// eventsClient serves the given events handler over an in-memory connection, and returns a client of it.
+func eventsClient(t *testing.T, handler eventsproto.EventsAPIServer) eventsproto.EventsAPIClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	eventsproto.RegisterEventsAPIServer(server, handler)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return eventsproto.NewEventsAPIClient(conn)
+}
+
+// TestSubscribeEvents_ConcurrentSubscribers tests that concurrent subscribers with different
+// event type filters each receive one batch per sealed block with the events matching their filter,
+// and heartbeats for the blocks without matching events.
+func TestSubscribeEvents_ConcurrentSubscribers(t *testing.T) {
+	b, headers := eventsBackend(6)
+	config := backend.EventsStreamConfig{BufferSize: 10, PollInterval: 10 * time.Millisecond}
+	client := eventsClient(t, newEventsHandler(backend.NewEventsStreamer(b, config), make(chan struct{})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// subscribe receives the batches of all sealed blocks, starting at the given height
+	subscribe := func(startHeight uint64, eventType flow.EventType) []*eventsproto.EventsBatch {
+		stream, err := client.SubscribeEvents(ctx, &eventsproto.SubscribeEventsRequest{
+			StartHeight: startHeight,
+			EventTypes:  []string{string(eventType)},
+		})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		var batches []*eventsproto.EventsBatch
+		for height := startHeight; height <= uint64(len(headers)); height++ {
+			batch, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return batches
+			}
+			batches = append(batches, batch)
+		}
+		return batches
+	}
+
+	var wg sync.WaitGroup
+	var created, updated []*eventsproto.EventsBatch
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		created = subscribe(1, flow.EventAccountCreated)
+	}()
+	go func() {
+		defer wg.Done()
+		updated = subscribe(3, flow.EventAccountUpdated)
+	}()
+	wg.Wait()
+
+	require.Len(t, created, 6)
+	for i, batch := range created {
+		header := headers[uint64(i+1)]
+		assert.Equal(t, header.ID(), flow.HashToID(batch.GetBlockId()))
+		assert.Equal(t, header.Height, batch.GetBlockHeight())
+		assert.Equal(t, header.Timestamp.UnixNano(), batch.GetBlockTimestamp().AsTime().UnixNano())
+		assert.False(t, batch.GetHeartbeat())
+		require.Len(t, batch.GetEvents(), 1)
+		assert.Equal(t, string(flow.EventAccountCreated), batch.GetEvents()[0].GetType())
+	}
+
+	require.Len(t, updated, 4)
+	for i, batch := range updated {
+		height := uint64(i + 3)
+		assert.Equal(t, height, batch.GetBlockHeight())
+		if height%2 == 1 {
+			assert.True(t, batch.GetHeartbeat())
+			assert.Empty(t, batch.GetEvents())
+			continue
+		}
+		assert.False(t, batch.GetHeartbeat())
+		require.Len(t, batch.GetEvents(), 1)
+		assert.Equal(t, string(flow.EventAccountUpdated), batch.GetEvents()[0].GetType())
+		assert.Equal(t, uint32(1), batch.GetEvents()[0].GetEventIndex())
+	}
+}
+
+// TestSubscribeEvents_Shutdown tests that streams are ended once the engine shuts down.
+func TestSubscribeEvents_Shutdown(t *testing.T) {
+	b, _ := eventsBackend(2)
+	quit := make(chan struct{})
+	config := backend.EventsStreamConfig{BufferSize: 10, PollInterval: 10 * time.Millisecond}
+	client := eventsClient(t, newEventsHandler(backend.NewEventsStreamer(b, config), quit))
+
+	stream, err := client.SubscribeEvents(context.Background(), &eventsproto.SubscribeEventsRequest{
+		StartHeight: 1,
+		EventTypes:  []string{string(flow.EventAccountCreated)},
+	})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = stream.Recv()
+		require.NoError(t, err)
+	}
+
+	close(quit)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
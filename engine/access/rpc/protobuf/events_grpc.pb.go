@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package events
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EventsAPIClient is the client API for EventsAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EventsAPIClient interface {
+	// SubscribeEvents streams the events of the given types for all sealed blocks, starting at
+	// the given height. One batch is sent per block, including blocks without matching events.
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (EventsAPI_SubscribeEventsClient, error)
+}
+
+type eventsAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventsAPIClient(cc grpc.ClientConnInterface) EventsAPIClient {
+	return &eventsAPIClient{cc}
+}
+
+func (c *eventsAPIClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (EventsAPI_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EventsAPI_ServiceDesc.Streams[0], "/events.EventsAPI/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventsAPISubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventsAPI_SubscribeEventsClient interface {
+	Recv() (*EventsBatch, error)
+	grpc.ClientStream
+}
+
+type eventsAPISubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventsAPISubscribeEventsClient) Recv() (*EventsBatch, error) {
+	m := new(EventsBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventsAPIServer is the server API for EventsAPI service.
+// All implementations must embed UnimplementedEventsAPIServer
+// for forward compatibility
+type EventsAPIServer interface {
+	// SubscribeEvents streams the events of the given types for all sealed blocks, starting at
+	// the given height. One batch is sent per block, including blocks without matching events.
+	SubscribeEvents(*SubscribeEventsRequest, EventsAPI_SubscribeEventsServer) error
+	mustEmbedUnimplementedEventsAPIServer()
+}
+
+// UnimplementedEventsAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedEventsAPIServer struct {
+}
+
+func (UnimplementedEventsAPIServer) SubscribeEvents(*SubscribeEventsRequest, EventsAPI_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedEventsAPIServer) mustEmbedUnimplementedEventsAPIServer() {}
+
+// UnsafeEventsAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventsAPIServer will
+// result in compilation errors.
+type UnsafeEventsAPIServer interface {
+	mustEmbedUnimplementedEventsAPIServer()
+}
+
+func RegisterEventsAPIServer(s grpc.ServiceRegistrar, srv EventsAPIServer) {
+	s.RegisterService(&EventsAPI_ServiceDesc, srv)
+}
+
+func _EventsAPI_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventsAPIServer).SubscribeEvents(m, &eventsAPISubscribeEventsServer{stream})
+}
+
+type EventsAPI_SubscribeEventsServer interface {
+	Send(*EventsBatch) error
+	grpc.ServerStream
+}
+
+type eventsAPISubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventsAPISubscribeEventsServer) Send(m *EventsBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// EventsAPI_ServiceDesc is the grpc.ServiceDesc for EventsAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventsAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "events.EventsAPI",
+	HandlerType: (*EventsAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _EventsAPI_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protobuf/events.proto",
+}
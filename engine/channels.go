@@ -36,6 +36,20 @@ func RolesByChannel(channel network.Channel) (flow.RoleList, bool) {
 	return roles, ok
 }
 
+// AllowAccessObservingApprovals adds the access role to the roles of the approvals channels, so
+// that access nodes can observe result approvals. As the roles of a channel determine the topology
+// and the subscriptions accepted from peers, it needs to be enabled on all nodes of the network.
+// It must be called before the network is started.
+func AllowAccessObservingApprovals() {
+	if channelRoleMap[PushApprovals].Contains(flow.RoleAccess) {
+		return
+	}
+	roles := append(flow.RoleList{}, channelRoleMap[PushApprovals]...)
+	roles = append(roles, flow.RoleAccess)
+	channelRoleMap[PushApprovals] = roles
+	channelRoleMap[ReceiveApprovals] = roles
+}
+
 // Exists returns true if the channel exists.
 func Exists(channel network.Channel) bool {
 	if _, ok := RolesByChannel(channel); ok {
@@ -238,20 +252,14 @@ func IsClusterChannel(channel network.Channel) bool {
 // TopicFromChannel returns the unique LibP2P topic form the channel.
 // The channel is made up of name string suffixed with root block id.
 // The root block id is used to prevent cross talks between nodes on different sporks.
+// This includes cluster specific channels: although their names are unique for each epoch,
+// the same cluster (and hence the same channel name) may be set up on another network.
 func TopicFromChannel(channel network.Channel, rootBlockID flow.Identifier) network.Topic {
-	// skip root block suffix, if this is a cluster specific channel. A cluster specific channel is inherently
-	// unique for each epoch
-	if IsClusterChannel(channel) {
-		return network.Topic(channel)
-	}
 	return network.Topic(fmt.Sprintf("%s/%s", string(channel), rootBlockID.String()))
 }
 
+// ChannelFromTopic returns the channel of the topic, i.e. the topic name without its root block id suffix.
 func ChannelFromTopic(topic network.Topic) (network.Channel, bool) {
-	if IsClusterChannel(network.Channel(topic)) {
-		return network.Channel(topic), true
-	}
-
 	if index := strings.LastIndex(topic.String(), "/"); index != -1 {
 		return network.Channel(topic[:index]), true
 	}
@@ -259,6 +267,21 @@ func ChannelFromTopic(topic network.Topic) (network.Channel, bool) {
 	return "", false
 }
 
+// SporkIDFromTopic returns the root block id of the spork, which the topic is specific to.
+func SporkIDFromTopic(topic network.Topic) (flow.Identifier, bool) {
+	index := strings.LastIndex(topic.String(), "/")
+	if index == -1 {
+		return flow.ZeroID, false
+	}
+
+	sporkID, err := flow.HexStringToIdentifier(topic.String()[index+1:])
+	if err != nil {
+		return flow.ZeroID, false
+	}
+
+	return sporkID, true
+}
+
 // ChannelConsensusCluster returns a dynamic cluster consensus channel based on
 // the chain ID of the cluster in question.
 func ChannelConsensusCluster(clusterID flow.ChainID) network.Channel {
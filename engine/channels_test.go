@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/utils/unittest"
 )
 
 // TestGetRolesByChannel_NonClusterChannel evaluates correctness of RolesByChannel function against
@@ -129,3 +131,45 @@ func TestUniqueChannels_ClusterChannels(t *testing.T) {
 	require.Contains(t, uniques, consensusCluster) // cluster channel
 	require.Contains(t, uniques, PushTransactions) // non-cluster channel
 }
+
+// TestTopicFromChannel verifies that the topics of the same channel differ across sporks, for cluster and
+// non-cluster channels alike, and that the channel and spork id can be recovered from the topic.
+func TestTopicFromChannel(t *testing.T) {
+	sporkA, sporkB := unittest.IdentifierFixture(), unittest.IdentifierFixture()
+
+	for _, channel := range []network.Channel{PushBlocks, ChannelSyncCluster(flow.Emulator)} {
+		topicA := TopicFromChannel(channel, sporkA)
+		topicB := TopicFromChannel(channel, sporkB)
+		assert.NotEqual(t, topicA, topicB, "topics of channel %s should differ across sporks", channel)
+
+		recovered, ok := ChannelFromTopic(topicA)
+		require.True(t, ok)
+		assert.Equal(t, channel, recovered)
+
+		sporkID, ok := SporkIDFromTopic(topicA)
+		require.True(t, ok)
+		assert.Equal(t, sporkA, sporkID)
+	}
+
+	_, ok := SporkIDFromTopic(network.Topic(PushBlocks))
+	assert.False(t, ok)
+}
+
+// TestAllowAccessObservingApprovals evaluates that access nodes are only allowed on the approvals
+// channels once observing approvals is enabled.
+func TestAllowAccessObservingApprovals(t *testing.T) {
+	defer initializeChannelRoleMap()
+
+	roles, ok := RolesByChannel(PushApprovals)
+	require.True(t, ok)
+	assert.NotContains(t, roles, flow.RoleAccess)
+	assert.NotContains(t, ChannelsByRole(flow.RoleAccess), PushApprovals)
+
+	AllowAccessObservingApprovals()
+	AllowAccessObservingApprovals()
+
+	roles, ok = RolesByChannel(ReceiveApprovals)
+	require.True(t, ok)
+	assert.ElementsMatch(t, flow.RoleList{flow.RoleConsensus, flow.RoleVerification, flow.RoleAccess}, roles)
+	assert.Contains(t, ChannelsByRole(flow.RoleAccess), PushApprovals)
+}
@@ -31,6 +31,7 @@ type Core struct {
 	collectionMetrics module.CollectionMetrics
 	headers           storage.Headers
 	state             clusterkv.MutableState
+	chainID           flow.ChainID                     // chain ID of the cluster, proposals for other chains are rejected
 	pending           module.PendingClusterBlockBuffer // pending block cache
 	sync              module.BlockRequester
 	hotstuff          module.HotStuff
@@ -47,6 +48,11 @@ func NewCore(
 	pending module.PendingClusterBlockBuffer,
 ) (*Core, error) {
 
+	chainID, err := state.Params().ChainID()
+	if err != nil {
+		return nil, fmt.Errorf("could not get chain ID: %w", err)
+	}
+
 	c := &Core{
 		log:               log.With().Str("cluster_compliance", "core").Logger(),
 		metrics:           collector,
@@ -54,6 +60,7 @@ func NewCore(
 		collectionMetrics: collectionMetrics,
 		headers:           headers,
 		state:             state,
+		chainID:           chainID,
 		pending:           pending,
 		sync:              nil, // use `WithSync`
 		hotstuff:          nil, // use `WithConsensus`
@@ -82,6 +89,11 @@ func (c *Core) OnBlockProposal(originID flow.Identifier, proposal *messages.Clus
 		Logger()
 	log.Info().Msg("block proposal received")
 
+	// reject proposals for other chains right away, they can't ever be connected to our chain
+	if header.ChainID != c.chainID {
+		return engine.NewWrongChainErrorf(c.chainID, header.ChainID, "cluster block proposal %x", header.ID())
+	}
+
 	c.prunePendingCache()
 
 	// first, we reject all blocks that we don't need to process:
@@ -246,12 +258,23 @@ func (c *Core) processBlockProposal(proposal *messages.ClusterBlockProposal) err
 		Logger()
 	log.Info().Msg("processing block proposal")
 
+	// retrieve the parent and check that the proposal correctly extends it
+	parent, err := c.headers.ByBlockID(header.ParentID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve proposal parent: %w", err)
+	}
+	err = header.VerifyParentLink(parent)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("invalid parent link (block: %x, height: %d): %w",
+			header.ID(), header.Height, err)
+	}
+
 	// see if the block is a valid extension of the protocol state
 	block := &cluster.Block{
 		Header:  proposal.Header,
 		Payload: proposal.Payload,
 	}
-	err := c.state.Extend(block)
+	err = c.state.Extend(block)
 	// if the block proposes an invalid extension of the protocol state, then the block is invalid
 	if state.IsInvalidExtensionError(err) {
 		return engine.NewInvalidInputErrorf("invalid extension of protocol state (block: %x, height: %d): %w",
@@ -265,12 +288,6 @@ func (c *Core) processBlockProposal(proposal *messages.ClusterBlockProposal) err
 		return fmt.Errorf("could not extend protocol state (block: %x, height: %d): %w", header.ID(), header.Height, err)
 	}
 
-	// retrieve the parent
-	parent, err := c.headers.ByBlockID(header.ParentID)
-	if err != nil {
-		return fmt.Errorf("could not retrieve proposal parent: %w", err)
-	}
-
 	// submit the model to hotstuff for processing
 	log.Info().Msg("forwarding block proposal to hotstuff")
 	c.hotstuff.SubmitProposal(header, parent.View)
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/cluster"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
@@ -19,6 +20,7 @@ import (
 	module "github.com/onflow/flow-go/module/mock"
 	clusterint "github.com/onflow/flow-go/state/cluster"
 	clusterstate "github.com/onflow/flow-go/state/cluster/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storerr "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -97,6 +99,9 @@ func (cs *ComplianceCoreSuite) SetupTest() {
 		},
 	)
 	cs.state.On("Extend", mock.Anything).Return(nil)
+	clusterParams := &protocol.Params{}
+	clusterParams.On("ChainID").Return(cs.head.Header.ChainID, nil)
+	cs.state.On("Params").Return(clusterParams)
 
 	// set up protocol snapshot mock
 	cs.snapshot = &clusterstate.Snapshot{}
@@ -212,6 +217,26 @@ func (cs *ComplianceCoreSuite) TestOnBlockProposalValidAncestor() {
 	cs.hotstuff.AssertExpectations(cs.T())
 }
 
+func (cs *ComplianceCoreSuite) TestOnBlockProposalWrongChain() {
+
+	// create a proposal that directly descends from the latest finalized header, but for another cluster
+	originID := unittest.IdentifierFixture()
+	block := unittest.ClusterBlockWithParent(cs.head)
+	block.Header.ChainID = "other-cluster"
+	proposal := &messages.ClusterBlockProposal{
+		Header:  block.Header,
+		Payload: block.Payload,
+	}
+
+	// it should be rejected as a proposal for another chain
+	err := cs.core.OnBlockProposal(originID, proposal)
+	require.True(cs.T(), engine.IsWrongChainError(err), "proposal for another chain should be rejected")
+
+	// we should neither try to extend the state nor cache the proposal
+	cs.state.AssertNotCalled(cs.T(), "Extend", mock.Anything)
+	cs.pending.AssertNotCalled(cs.T(), "Add", mock.Anything, mock.Anything)
+}
+
 func (cs *ComplianceCoreSuite) TestOnBlockProposalInvalidExtension() {
 
 	// create a proposal that has two ancestors in the cache
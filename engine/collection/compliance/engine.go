@@ -270,6 +270,10 @@ func (e *Engine) processAvailableMessages() error {
 		msg, ok := e.pendingBlocks.Get()
 		if ok {
 			err := e.core.OnBlockProposal(msg.OriginID, msg.Payload.(*messages.ClusterBlockProposal))
+			if engine.IsWrongChainError(err) {
+				e.log.Warn().Err(err).Hex("origin_id", msg.OriginID[:]).Msg("dropping block proposal for another chain")
+				continue
+			}
 			if err != nil {
 				return fmt.Errorf("could not handle block proposal: %w", err)
 			}
@@ -73,11 +73,7 @@ func (cs *ComplianceSuite) SetupTest() {
 	cs.protoState = &protocol.MutableState{}
 	cs.protoState.On("Final").Return(protoSnapshot)
 
-	cs.clusterID = "cluster-id"
-	clusterParams := &protocol.Params{}
-	clusterParams.On("ChainID").Return(cs.clusterID, nil)
-
-	cs.state.On("Params").Return(clusterParams)
+	cs.clusterID = cs.head.Header.ChainID
 
 	// set up local module mock
 	cs.me = &module.Local{}
@@ -11,18 +11,70 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/blocktime"
 	"github.com/onflow/flow-go/module/mempool/epochs"
 	"github.com/onflow/flow-go/module/util"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/cluster"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/events"
+	"github.com/onflow/flow-go/storage"
 )
 
 // DefaultStartupTimeout is the default time we wait when starting epoch
 // components before giving up.
 const DefaultStartupTimeout = 30 * time.Second
 
+// DefaultEpochTransitionDeadline is the default deadline for handling an epoch
+// transition, after which the overrun is logged. It includes the time we wait
+// for the new epoch components to start up.
+const DefaultEpochTransitionDeadline = 2 * DefaultStartupTimeout
+
+// Opt is a functional option to configure the epoch manager.
+type Opt func(*Engine)
+
+// WithEpochTransitionDeadline sets the deadline for handling an epoch transition.
+func WithEpochTransitionDeadline(deadline time.Duration) Opt {
+	return func(e *Engine) {
+		e.transitionDeadline = deadline
+	}
+}
+
+// WithStartupTimeout sets how long we wait for epoch components to start up
+// or shut down.
+func WithStartupTimeout(timeout time.Duration) Opt {
+	return func(e *Engine) {
+		e.startupTimeout = timeout
+	}
+}
+
+// WithEpochTransitionErrorHandler sets the handler of errors failing an epoch
+// transition. By default, failing an epoch transition is a fatal error. This
+// is meant for tests, which observe the failure instead.
+func WithEpochTransitionErrorHandler(handler func(error)) Opt {
+	return func(e *Engine) {
+		e.onTransitionError = handler
+	}
+}
+
+// WithFollowUnstakedEpochs configures the epoch manager to follow the cluster
+// state of epochs in which this node is not staked. For these epochs, it follows
+// the cluster to which the observed node is assigned. Following is disabled, if
+// the observed node is flow.ZeroID.
+func WithFollowUnstakedEpochs(observed flow.Identifier) Opt {
+	return func(e *Engine) {
+		e.followedMember = observed
+	}
+}
+
+// WithBlockTimeEstimator sets the estimator used to approximate when epoch
+// components are stopped.
+func WithBlockTimeEstimator(estimator *blocktime.Estimator) Opt {
+	return func(e *Engine) {
+		e.blockTime = estimator
+	}
+}
+
 // ErrUnstakedForEpoch is returned when we attempt to create epoch components
 // for an epoch in which we are not staked. This is the case for epochs during
 // which this node is joining or leaving the network.
@@ -46,6 +98,25 @@ func (ec *EpochComponents) Done() <-chan struct{} {
 	return util.AllDone(ec.prop, ec.sync, ec.hotstuff)
 }
 
+// FollowerComponents represents the dependencies for following the cluster
+// state of an epoch in which this node is not staked.
+type FollowerComponents struct {
+	state    cluster.State
+	prop     network.Engine
+	sync     network.Engine
+	follower module.HotStuffFollower
+}
+
+// Ready starts all follower components.
+func (fc *FollowerComponents) Ready() <-chan struct{} {
+	return util.AllReady(fc.prop, fc.sync, fc.follower)
+}
+
+// Done stops all follower components.
+func (fc *FollowerComponents) Done() <-chan struct{} {
+	return util.AllDone(fc.prop, fc.sync, fc.follower)
+}
+
 // Engine is the epoch manager, which coordinates the lifecycle of other modules
 // and processes that are epoch-dependent. The manager is responsible for
 // spinning up engines when a new epoch is about to start and spinning down
@@ -60,10 +131,17 @@ type Engine struct {
 	pools        *epochs.TransactionPools  // epoch-scoped transaction pools
 	factory      EpochComponentsFactory    // consolidates creating epoch for an epoch
 	voter        module.ClusterRootQCVoter // manages process of voting for next epoch's QC
-	heightEvents events.Heights            // allows subscribing to particular heights
-
-	epochs         map[uint64]*EpochComponents // epoch-scoped components per epoch
-	startupTimeout time.Duration               // how long we wait for epoch components to start up
+	votes        storage.ClusterQCVotes    // persists whether we have voted for an epoch's QC
+	heightEvents events.PersistentHeights  // allows subscribing to particular heights, also across restarts
+	blockTime    *blocktime.Estimator      // approximates the duration of a number of blocks
+
+	epochs             map[uint64]*EpochComponents    // epoch-scoped components per epoch
+	followers          map[uint64]*FollowerComponents // follower components per epoch in which we are not staked
+	stopFactories      map[uint64]struct{}            // epochs for which the stop callback factory is registered
+	followedMember     flow.Identifier                // node whose cluster we follow in epochs in which we are not staked
+	startupTimeout     time.Duration                  // how long we wait for epoch components to start up
+	transitionDeadline time.Duration                  // deadline for handling an epoch transition
+	onTransitionError  func(error)                    // handles errors failing an epoch transition, if set
 }
 
 func New(
@@ -72,23 +150,41 @@ func New(
 	state protocol.State,
 	pools *epochs.TransactionPools,
 	voter module.ClusterRootQCVoter,
+	votes storage.ClusterQCVotes,
 	factory EpochComponentsFactory,
-	heightEvents events.Heights,
+	heightEvents events.PersistentHeights,
+	opts ...Opt,
 ) (*Engine, error) {
 
 	e := &Engine{
-		unit:           engine.NewUnit(),
-		log:            log.With().Str("engine", "epochmgr").Logger(),
-		me:             me,
-		state:          state,
-		pools:          pools,
-		voter:          voter,
-		factory:        factory,
-		heightEvents:   heightEvents,
-		epochs:         make(map[uint64]*EpochComponents),
-		startupTimeout: DefaultStartupTimeout,
+		unit:               engine.NewUnit(),
+		log:                log.With().Str("engine", "epochmgr").Logger(),
+		me:                 me,
+		state:              state,
+		pools:              pools,
+		voter:              voter,
+		votes:              votes,
+		factory:            factory,
+		heightEvents:       heightEvents,
+		blockTime:          blocktime.NewEstimator(),
+		epochs:             make(map[uint64]*EpochComponents),
+		followers:          make(map[uint64]*FollowerComponents),
+		stopFactories:      make(map[uint64]struct{}),
+		startupTimeout:     DefaultStartupTimeout,
+		transitionDeadline: DefaultEpochTransitionDeadline,
 	}
 
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	// handling an epoch transition keeps running past its deadline, but the overrun is observable
+	e.unit.OnDeadlineExceeded(func(_ context.Context, deadline time.Duration) {
+		e.log.Warn().
+			Dur("deadline", deadline).
+			Msg("epoch transition exceeded deadline")
+	})
+
 	// set up epoch-scoped epoch managed by this engine for the current epoch
 	epoch := e.state.Final().Epochs().Current()
 	counter, err := epoch.Counter()
@@ -96,9 +192,26 @@ func New(
 		return nil, fmt.Errorf("could not get epoch counter: %w", err)
 	}
 
+	// reattach the callback stopping the previous epoch, in case we restarted
+	// between the epoch transition and the height at which the epoch is stopped
+	if counter > 0 {
+		err = e.registerStopEpochFactory(counter - 1)
+		if err != nil {
+			return nil, fmt.Errorf("could not register callback to stop previous epoch: %w", err)
+		}
+	}
+
 	components, err := e.createEpochComponents(epoch)
 	// don't set up consensus components if we aren't staked in current epoch
 	if errors.Is(err, ErrUnstakedForEpoch) {
+		if e.followedMember == flow.ZeroID {
+			return e, nil
+		}
+		follower, err := e.createFollowerComponents(epoch)
+		if err != nil {
+			return nil, fmt.Errorf("could not create follower components for current epoch: %w", err)
+		}
+		e.followers[counter] = follower
 		return e, nil
 	}
 	if err != nil {
@@ -117,10 +230,13 @@ func (e *Engine) Ready() <-chan struct{} {
 	return e.unit.Ready(func() {
 		// Start up components for all epochs. This is typically a single epoch
 		// but can be multiple near epoch boundaries
-		epochs := make([]module.ReadyDoneAware, 0, len(e.epochs))
+		epochs := make([]module.ReadyDoneAware, 0, len(e.epochs)+len(e.followers))
 		for _, epoch := range e.epochs {
 			epochs = append(epochs, epoch)
 		}
+		for _, follower := range e.followers {
+			epochs = append(epochs, follower)
+		}
 		<-util.AllReady(epochs...)
 	}, func() {
 		// check the current phase on startup, in case we are in setup phase
@@ -141,10 +257,13 @@ func (e *Engine) Done() <-chan struct{} {
 	return e.unit.Done(func() {
 		// Stop components for all epochs. This is typically a single epoch
 		// but can be multiple near epoch boundaries
-		epochs := make([]module.ReadyDoneAware, 0, len(e.epochs))
+		epochs := make([]module.ReadyDoneAware, 0, len(e.epochs)+len(e.followers))
 		for _, epoch := range e.epochs {
 			epochs = append(epochs, epoch)
 		}
+		for _, follower := range e.followers {
+			epochs = append(epochs, follower)
+		}
 		<-util.AllDone(epochs...)
 	})
 }
@@ -169,15 +288,38 @@ func (e *Engine) createEpochComponents(epoch protocol.Epoch) (*EpochComponents,
 	return components, err
 }
 
+// createFollowerComponents instantiates and returns the components for
+// following the given epoch, in which this node is not staked, using the
+// configured factory.
+func (e *Engine) createFollowerComponents(epoch protocol.Epoch) (*FollowerComponents, error) {
+
+	state, prop, sync, follower, err := e.factory.CreateFollower(epoch, e.followedMember)
+	if err != nil {
+		return nil, fmt.Errorf("could not setup follower requirements for epoch (%d): %w", epoch, err)
+	}
+
+	components := &FollowerComponents{
+		state:    state,
+		prop:     prop,
+		sync:     sync,
+		follower: follower,
+	}
+	return components, nil
+}
+
 // EpochTransition handles the epoch transition protocol event.
 func (e *Engine) EpochTransition(_ uint64, first *flow.Header) {
-	e.unit.Launch(func() {
+	e.unit.LaunchWithDeadline(func(_ context.Context) {
 		err := e.onEpochTransition(first)
 		if err != nil {
+			if e.onTransitionError != nil {
+				e.onTransitionError(err)
+				return
+			}
 			// failing to complete epoch transition is a fatal error
 			e.log.Fatal().Err(err).Msg("failed to complete epoch transition")
 		}
-	})
+	}, e.transitionDeadline)
 }
 
 // EpochSetupPhaseStarted handles the epoch setup phase started protocol event.
@@ -208,7 +350,8 @@ func (e *Engine) onEpochTransition(first *flow.Header) error {
 
 	// exit early and log if the epoch already exists
 	_, exists := e.epochs[counter]
-	if exists {
+	_, following := e.followers[counter]
+	if exists || following {
 		log.Warn().Msg("epoch transition: components for new epoch already setup")
 		return nil
 	}
@@ -219,6 +362,13 @@ func (e *Engine) onEpochTransition(first *flow.Header) error {
 	components, err := e.createEpochComponents(epoch)
 	// if we are not staked in this epoch, skip starting up cluster consensus
 	if errors.Is(err, ErrUnstakedForEpoch) {
+		if e.followedMember != flow.ZeroID {
+			err = e.startFollowerComponents(epoch, counter)
+			if err != nil {
+				return fmt.Errorf("could not start follower components: %w", err)
+			}
+			log.Info().Msg("epoch transition: unstaked in new epoch, follower components started successfully")
+		}
 		e.prepareToStopEpochComponents(counter-1, lastEpochMaxHeight)
 		return nil
 	}
@@ -264,39 +414,110 @@ func (e *Engine) prepareToStopEpochComponents(epochCounter, epochMaxHeight uint6
 		Str("step", "epoch_transition").
 		Logger()
 
-	log.Debug().Msgf("preparing to stop epoch components at height %d", stopAtHeight)
-
-	e.heightEvents.OnHeight(stopAtHeight, func() {
-		e.unit.Launch(func() {
-			e.unit.Lock()
-			defer e.unit.Unlock()
+	// the first block of the new epoch, at the height following the epoch
+	// max height, has just been finalized
+	eta := e.blockTime.EstimateDuration(stopAtHeight - (epochMaxHeight + 1))
+	log.Info().
+		Dur("stop_eta", eta).
+		Msgf("preparing to stop epoch components at height %d", stopAtHeight)
 
-			log.Info().Msg("stopping components for previous epoch...")
+	err := e.registerStopEpochFactory(epochCounter)
+	if err != nil {
+		log.Error().Err(err).Msg("could not register callback to stop epoch components")
+		return
+	}
+	err = e.heightEvents.OnHeight(stopAtHeight, stopEpochCallbackID(epochCounter))
+	if err != nil {
+		log.Error().Err(err).Msg("could not register callback to stop epoch components")
+	}
+}
 
-			err := e.stopEpochComponents(epochCounter)
-			if err != nil {
-				e.log.Error().Err(err).Msgf("failed to stop components for epoch %d", epochCounter)
-				return
-			}
+// stopEpochCallbackID returns the ID of the height callback stopping the epoch
+// with the given counter.
+func stopEpochCallbackID(epochCounter uint64) string {
+	return fmt.Sprintf("stop-epoch-%d", epochCounter)
+}
 
-			log.Info().Msg("previous epoch components stopped successfully")
-		})
+// registerStopEpochFactory registers the factory of the height callback, which
+// stops the components of the epoch with the given counter. The factory is
+// registered at most once per epoch, so that it is only registered again after
+// a restart.
+func (e *Engine) registerStopEpochFactory(epochCounter uint64) error {
+	if _, registered := e.stopFactories[epochCounter]; registered {
+		return nil
+	}
+	err := e.heightEvents.RegisterFactory(stopEpochCallbackID(epochCounter), func() func() {
+		return func() {
+			e.unit.Launch(func() {
+				e.unit.Lock()
+				defer e.unit.Unlock()
+
+				log := e.log.With().
+					Uint64("epoch_counter", epochCounter).
+					Str("step", "epoch_transition").
+					Logger()
+				log.Info().Msg("stopping components for previous epoch...")
+
+				err := e.stopEpochComponents(epochCounter)
+				if err != nil {
+					log.Error().Err(err).Msgf("failed to stop components for epoch %d", epochCounter)
+					return
+				}
+
+				log.Info().Msg("previous epoch components stopped successfully")
+			})
+		}
 	})
+	if err != nil {
+		return err
+	}
+	e.stopFactories[epochCounter] = struct{}{}
+	return nil
+}
+
+// HasVoted returns whether we have submitted our vote for the root cluster QC
+// of the epoch with the given counter.
+func (e *Engine) HasVoted(counter uint64) (bool, error) {
+	return e.votes.HasVoted(counter)
 }
 
 // onEpochSetupPhaseStarted is called either when we transition into the epoch
 // setup phase, or when the node is restarted during the epoch setup phase. It
 // kicks off setup tasks for the phase, in particular submitting a vote for the
-// next epoch's root cluster QC.
+// next epoch's root cluster QC, unless we have already voted before a restart.
 func (e *Engine) onEpochSetupPhaseStarted() {
 
 	epoch := e.state.Final().Epochs().Next()
+	counter, err := epoch.Counter()
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not get next epoch counter")
+		return
+	}
+
+	log := e.log.With().Uint64("next_epoch_counter", counter).Logger()
+
+	voted, err := e.votes.HasVoted(counter)
+	if err != nil {
+		// voting again is safe, as the voter checks the vote status with the contract
+		log.Error().Err(err).Msg("could not check QC vote status for next epoch")
+	} else if voted {
+		log.Info().Msg("already submitted QC vote for next epoch - skipping vote")
+		return
+	}
 
 	ctx, cancel := context.WithCancel(e.unit.Ctx())
 	defer cancel()
-	err := e.voter.Vote(ctx, epoch)
+	err = e.voter.Vote(ctx, epoch)
+	if errors.Is(err, module.ErrClusterRootQCAlreadyVoted) {
+		log.Info().Msg("QC vote for next epoch was already submitted")
+	} else if err != nil {
+		log.Error().Err(err).Msg("failed to submit QC vote for next epoch")
+		return
+	}
+
+	err = e.votes.SetVoted(counter)
 	if err != nil {
-		e.log.Error().Err(err).Msg("failed to submit QC vote for next epoch")
+		log.Error().Err(err).Msg("could not persist QC vote status for next epoch")
 	}
 }
 
@@ -315,15 +536,42 @@ func (e *Engine) startEpochComponents(counter uint64, components *EpochComponent
 	}
 }
 
+// startFollowerComponents creates and starts the follower components for the
+// given epoch, and adds them to the engine's internal mapping of followers.
+//
+// CAUTION: the caller MUST acquire the engine lock.
+func (e *Engine) startFollowerComponents(epoch protocol.Epoch, counter uint64) error {
+
+	follower, err := e.createFollowerComponents(epoch)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-follower.Ready():
+		e.followers[counter] = follower
+		return nil
+	case <-time.After(e.startupTimeout):
+		return fmt.Errorf("could not start epoch %d follower components after %s", counter, e.startupTimeout)
+	}
+}
+
 // stopEpochComponents stops the components for the given epoch and removes them
-// from the engine's internal mapping.
+// from the engine's internal mapping. If we are only following the epoch, the
+// follower components are stopped instead.
 //
 // CAUTION: the caller MUST acquire the engine lock.
 func (e *Engine) stopEpochComponents(counter uint64) error {
 
 	components, exists := e.epochs[counter]
 	if !exists {
-		return fmt.Errorf("can not stop non-existent epoch %d", counter)
+		if _, following := e.followers[counter]; !following {
+			// after a restart the components of the previous epoch are not
+			// started, only its transaction pool has to be cleared
+			e.pools.ForEpoch(counter).Clear()
+			return nil
+		}
+		return e.stopFollowerComponents(counter)
 	}
 
 	select {
@@ -335,3 +583,23 @@ func (e *Engine) stopEpochComponents(counter uint64) error {
 		return fmt.Errorf("could not stop epoch %d components after %s", counter, e.startupTimeout)
 	}
 }
+
+// stopFollowerComponents stops the follower components for the given epoch and
+// removes them from the engine's internal mapping of followers.
+//
+// CAUTION: the caller MUST acquire the engine lock.
+func (e *Engine) stopFollowerComponents(counter uint64) error {
+
+	follower, exists := e.followers[counter]
+	if !exists {
+		return fmt.Errorf("can not stop non-existent epoch %d", counter)
+	}
+
+	select {
+	case <-follower.Done():
+		delete(e.followers, counter)
+		return nil
+	case <-time.After(e.startupTimeout):
+		return fmt.Errorf("could not stop epoch %d follower components after %s", counter, e.startupTimeout)
+	}
+}
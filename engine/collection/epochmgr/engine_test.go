@@ -1,11 +1,14 @@
 package epochmgr
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,8 +27,11 @@ import (
 	realcluster "github.com/onflow/flow-go/state/cluster"
 	cluster "github.com/onflow/flow-go/state/cluster/mock"
 	realprotocol "github.com/onflow/flow-go/state/protocol"
-	events "github.com/onflow/flow-go/state/protocol/events/mock"
+	"github.com/onflow/flow-go/state/protocol/events/gadgets"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/mocks"
 )
@@ -68,7 +74,13 @@ type Suite struct {
 	client  *module.QCContractClient
 	voter   *module.ClusterRootQCVoter
 	factory *epochmgr.EpochComponentsFactory
-	heights *events.Heights
+	heights *gadgets.PersistentHeights
+	final   uint64 // finalized height observed by the height events
+
+	// vote status persisted in the database
+	db    *badger.DB
+	dbDir string
+	votes storage.ClusterQCVotes
 
 	epochQuery *mocks.EpochQuery
 	counter    uint64                     // reflects the counter of the current epoch
@@ -92,7 +104,12 @@ func (suite *Suite) SetupTest() {
 	suite.client = new(module.QCContractClient)
 	suite.voter = new(module.ClusterRootQCVoter)
 	suite.factory = new(epochmgr.EpochComponentsFactory)
-	suite.heights = new(events.Heights)
+	suite.db, suite.dbDir = unittest.TempBadgerDB(suite.T())
+	suite.final = 0
+	var err error
+	suite.heights, err = gadgets.NewPersistentHeights(suite.log, suite.db, suite.final)
+	suite.Require().NoError(err)
+	suite.votes = bstorage.NewClusterQCVotes(suite.db)
 
 	// mock out Create so that it instantiates the appropriate mocks
 	suite.factory.On("Create", mock.Anything).
@@ -121,11 +138,15 @@ func (suite *Suite) SetupTest() {
 
 	suite.pools = epochs.NewTransactionPools(func() mempool.Transactions { return stdmap.NewTransactions(1000) })
 
-	var err error
-	suite.engine, err = New(suite.log, suite.me, suite.state, suite.pools, suite.voter, suite.factory, suite.heights)
+	suite.engine, err = New(suite.log, suite.me, suite.state, suite.pools, suite.voter, suite.votes, suite.factory, suite.heights)
 	suite.Require().Nil(err)
 }
 
+func (suite *Suite) TearDownTest() {
+	suite.Require().NoError(suite.db.Close())
+	suite.Require().NoError(os.RemoveAll(suite.dbDir))
+}
+
 func TestEpochManager(t *testing.T) {
 	suite.Run(t, new(Suite))
 }
@@ -180,11 +201,98 @@ func (suite *Suite) MockAsUnstakedNode() {
 		On("Create", mock.Anything).
 		Return(nil, nil, nil, nil, ErrUnstakedForEpoch)
 
+	// the engine replaces the one created on setup, which registered its callback factories
+	var err error
+	suite.heights, err = gadgets.NewPersistentHeights(suite.log, suite.db, suite.final)
+	suite.Require().NoError(err)
+	suite.engine, err = New(suite.log, suite.me, suite.state, suite.pools, suite.voter, suite.votes, suite.factory, suite.heights)
+	suite.Require().Nil(err)
+}
+
+// MockAsFollowingUnstakedNode mocks the factory to return a sentinel indicating
+// we are not a staked node in the epoch, and to create follower components
+// instead, with which the engine is configured to follow unstaked epochs.
+func (suite *Suite) MockAsFollowingUnstakedNode() {
+
+	observed := unittest.IdentifierFixture()
+	suite.factory = new(epochmgr.EpochComponentsFactory)
+	suite.factory.
+		On("Create", mock.Anything).
+		Return(nil, nil, nil, nil, ErrUnstakedForEpoch)
+	suite.factory.On("CreateFollower", mock.Anything, observed).
+		Run(func(args mock.Arguments) {
+			epoch, ok := args.Get(0).(realprotocol.Epoch)
+			suite.Require().Truef(ok, "invalid type %T", args.Get(0))
+			counter, err := epoch.Counter()
+			suite.Require().Nil(err)
+			suite.components[counter] = newMockComponents()
+		}).
+		Return(
+			func(epoch realprotocol.Epoch, _ flow.Identifier) realcluster.State {
+				return suite.ComponentsForEpoch(epoch).state
+			},
+			func(epoch realprotocol.Epoch, _ flow.Identifier) network.Engine {
+				return suite.ComponentsForEpoch(epoch).prop
+			},
+			func(epoch realprotocol.Epoch, _ flow.Identifier) network.Engine {
+				return suite.ComponentsForEpoch(epoch).sync
+			},
+			func(epoch realprotocol.Epoch, _ flow.Identifier) realmodule.HotStuffFollower {
+				return suite.ComponentsForEpoch(epoch).hotstuff
+			},
+			func(epoch realprotocol.Epoch, _ flow.Identifier) error { return nil },
+		)
+
+	// the engine replaces the one created on setup, which registered its callback factories
+	var err error
+	suite.heights, err = gadgets.NewPersistentHeights(suite.log, suite.db, suite.final)
+	suite.Require().NoError(err)
+	suite.engine, err = New(suite.log, suite.me, suite.state, suite.pools, suite.voter, suite.votes, suite.factory, suite.heights, WithFollowUnstakedEpochs(observed))
+	suite.Require().Nil(err)
+}
+
+// RestartEngine stops the engine and creates a new engine reading the vote status
+// and height callbacks from the same database, as if the node was restarted.
+func (suite *Suite) RestartEngine() {
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+
 	var err error
-	suite.engine, err = New(suite.log, suite.me, suite.state, suite.pools, suite.voter, suite.factory, suite.heights)
+	suite.heights, err = gadgets.NewPersistentHeights(suite.log, suite.db, suite.final)
+	suite.Require().NoError(err)
+	suite.votes = bstorage.NewClusterQCVotes(suite.db)
+	suite.engine, err = New(suite.log, suite.me, suite.state, suite.pools, suite.voter, suite.votes, suite.factory, suite.heights)
 	suite.Require().Nil(err)
 }
 
+// FinalizeHeight notifies the height events of a finalized block at the given height.
+func (suite *Suite) FinalizeHeight(height uint64) {
+	suite.final = height
+	header := unittest.BlockHeaderFixture()
+	header.Height = height
+	suite.heights.BlockFinalized(&header)
+}
+
+// HeightCallbacks returns the height callbacks stored in the database.
+func (suite *Suite) HeightCallbacks() map[uint64][]string {
+	callbacks := make(map[uint64][]string)
+	err := suite.db.View(operation.RetrieveHeightCallbacks(callbacks))
+	suite.Require().NoError(err)
+	return callbacks
+}
+
+// AwaitHeightCallback waits until the callback with the given ID is registered
+// for the given height.
+func (suite *Suite) AwaitHeightCallback(height uint64, callbackID string) {
+	suite.Assert().Eventually(func() bool {
+		for _, id := range suite.HeightCallbacks()[height] {
+			if id == callbackID {
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond, "should register callback for expiry of previous epoch")
+}
+
 // if we start up during the setup phase, we should kick off the root QC voter
 func (suite *Suite) TestRestartInSetupPhase() {
 
@@ -206,6 +314,75 @@ func (suite *Suite) TestRestartInSetupPhase() {
 	suite.voter.AssertExpectations(suite.T())
 }
 
+// if we restart during the setup phase after having voted, we should not vote again
+func (suite *Suite) TestRestartInSetupPhaseAfterVoting() {
+
+	suite.snap.On("Phase").Return(flow.EpochPhaseSetup, nil)
+	suite.voter.On("Vote", mock.Anything, suite.epochQuery.Next()).Return(nil)
+	nextCounter := suite.counter + 1
+
+	// the first lifetime of the engine votes and records the vote
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	suite.Assert().Eventually(func() bool {
+		voted, err := suite.engine.HasVoted(nextCounter)
+		suite.Require().NoError(err)
+		return voted
+	}, time.Second, time.Millisecond)
+
+	// the second lifetime of the engine finds the recorded vote and does not vote
+	suite.RestartEngine()
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	suite.engine.EpochSetupPhaseStarted(0, nil)
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+
+	suite.voter.AssertNumberOfCalls(suite.T(), "Vote", 1)
+	voted, err := suite.engine.HasVoted(nextCounter)
+	suite.Require().NoError(err)
+	suite.Assert().True(voted)
+}
+
+// if the vote is rejected because we have already voted, we should record the
+// vote as successful and not vote again after a restart
+func (suite *Suite) TestRecordVoteRejectedAsAlreadyVoted() {
+
+	suite.snap.On("Phase").Return(flow.EpochPhaseSetup, nil)
+	suite.voter.On("Vote", mock.Anything, suite.epochQuery.Next()).Return(realmodule.ErrClusterRootQCAlreadyVoted)
+	nextCounter := suite.counter + 1
+
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	suite.Assert().Eventually(func() bool {
+		voted, err := suite.engine.HasVoted(nextCounter)
+		suite.Require().NoError(err)
+		return voted
+	}, time.Second, time.Millisecond)
+
+	suite.RestartEngine()
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+
+	suite.voter.AssertNumberOfCalls(suite.T(), "Vote", 1)
+}
+
+// if voting fails, we should not record the vote and vote again after a restart
+func (suite *Suite) TestRestartInSetupPhaseAfterFailedVote() {
+
+	suite.snap.On("Phase").Return(flow.EpochPhaseSetup, nil)
+	suite.voter.On("Vote", mock.Anything, suite.epochQuery.Next()).Return(fmt.Errorf("voting failed"))
+	nextCounter := suite.counter + 1
+
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+	voted, err := suite.engine.HasVoted(nextCounter)
+	suite.Require().NoError(err)
+	suite.Assert().False(voted)
+
+	suite.RestartEngine()
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+
+	suite.voter.AssertNumberOfCalls(suite.T(), "Vote", 2)
+}
+
 // When a collection node joins the network at an epoch boundary, they must
 // start running during the EpochSetup phase in the epoch before they become
 // a staked member so they submit their cluster QC vote.
@@ -237,6 +414,29 @@ func (suite *Suite) TestStartAsUnstakedNode() {
 	assert.Empty(suite.T(), suite.engine.epochs, "should have 0 epoch components")
 }
 
+// When a collection node is not staked in the current epoch and is configured
+// to follow unstaked epochs, it should fall back to the follower components,
+// which are stopped with the engine.
+func (suite *Suite) TestStartAsFollowingUnstakedNode() {
+	suite.MockAsFollowingUnstakedNode()
+	suite.snap.On("Phase").Return(flow.EpochPhaseStaking, nil)
+
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+
+	// should have follower components only
+	suite.Assert().Empty(suite.engine.epochs, "should have 0 epoch components")
+	suite.Assert().Len(suite.engine.followers, 1)
+	_, exists := suite.engine.followers[suite.counter]
+	suite.Assert().True(exists, "should have current epoch follower components")
+
+	// the follower components should have been started
+	suite.AssertEpochStarted(suite.counter)
+
+	// stopping the engine should stop the follower components
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+	suite.AssertEpochStopped(suite.counter)
+}
+
 // should kick off root QC voter on setup phase start event
 func (suite *Suite) TestRespondToPhaseChange() {
 
@@ -256,52 +456,38 @@ func (suite *Suite) TestRespondToPhaseChange() {
 	suite.voter.AssertExpectations(suite.T())
 }
 
-func (suite *Suite) TestRespondToEpochTransition() {
+// if we restart after the epoch transition, but before the previous epoch
+// expires, the persisted callback should stop the previous epoch exactly once
+func (suite *Suite) TestRestartBeforePreviousEpochExpired() {
 
 	first := unittest.BlockHeaderFixture()
+	stopAtHeight := first.Height + flow.DefaultTransactionExpiry
 
-	// should set up callback for height at which previous epoch expires
-	var expiryCallback func()
-	suite.heights.On("OnHeight", first.Height+flow.DefaultTransactionExpiry, mock.Anything).
-		Run(func(args mock.Arguments) {
-			expiryCallback = args.Get(1).(func())
-		}).
-		Once()
-
-	// mock the epoch transition
+	suite.snap.On("Phase").Return(flow.EpochPhaseStaking, nil)
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
 	suite.TransitionEpoch()
-	// notify the engine of the epoch transition
 	suite.engine.EpochTransition(suite.counter, &first)
+	suite.AwaitHeightCallback(stopAtHeight, stopEpochCallbackID(suite.counter-1))
+
+	// the second lifetime of the engine only runs the current epoch, and stops
+	// the previous one once its expiry height is finalized
+	previous := suite.counter - 1
+	tx := unittest.TransactionBodyFixture()
+	suite.pools.ForEpoch(previous).Add(&tx)
+	suite.RestartEngine()
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	suite.Assert().Len(suite.engine.epochs, 1)
 
+	suite.FinalizeHeight(stopAtHeight)
 	suite.Assert().Eventually(func() bool {
-		return expiryCallback != nil
-	}, time.Second, time.Millisecond)
-
-	// the engine should have two epochs under management, the just ended epoch
-	// and the newly started epoch
-	suite.Assert().Len(suite.engine.epochs, 2)
-	_, exists := suite.engine.epochs[suite.counter-1]
-	suite.Assert().True(exists, "should have previous epoch components")
-	_, exists = suite.engine.epochs[suite.counter]
-	suite.Assert().True(exists, "should have current epoch components")
-
-	// the newly started (current) epoch should have been started
-	suite.AssertEpochStarted(suite.counter)
-
-	// when we invoke the callback registered to handle the previous epoch's
-	// expiry, the previous epoch components should be cleaned up
-	expiryCallback()
-
-	suite.Assert().Eventually(func() bool {
-		return len(suite.engine.epochs) == 1
-	}, time.Second, time.Millisecond)
+		return suite.pools.ForEpoch(previous).Size() == 0
+	}, time.Second, time.Millisecond, "should clear transaction pool of previous epoch")
+	suite.Assert().Empty(suite.HeightCallbacks(), "should remove invoked height callback")
 
-	// after the previous epoch expires, we should only have current epoch
-	_, exists = suite.engine.epochs[suite.counter]
-	suite.Assert().True(exists, "should have current epoch components")
-	_, exists = suite.engine.epochs[suite.counter-1]
-	suite.Assert().False(exists, "should not have previous epoch components")
-
-	// the expired epoch should have been stopped
-	suite.AssertEpochStopped(suite.counter - 1)
+	// the third lifetime of the engine does not stop the previous epoch again
+	suite.pools.ForEpoch(previous).Add(&tx)
+	suite.RestartEngine()
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
+	unittest.AssertClosesBefore(suite.T(), suite.engine.Done(), time.Second)
+	suite.Assert().Equal(uint(1), suite.pools.ForEpoch(previous).Size())
 }
@@ -3,6 +3,7 @@ package factories
 import (
 	"github.com/dgraph-io/badger/v2"
 
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	builder "github.com/onflow/flow-go/module/builder/collection"
 	finalizer "github.com/onflow/flow-go/module/finalizer/collection"
@@ -65,3 +66,36 @@ func (f *BuilderFactory) Create(
 
 	return build, final, nil
 }
+
+// CreateFollowerFinalizer creates the finalizer of a cluster, which this node
+// follows without being a member. Its finalized collections are not pushed to
+// the consensus committee, as this node is not a guarantor for them.
+func (f *BuilderFactory) CreateFollowerFinalizer(pool mempool.Transactions) *finalizer.Finalizer {
+	return finalizer.NewFinalizer(
+		f.db,
+		pool,
+		&discardEngine{},
+		f.metrics,
+	)
+}
+
+// discardEngine is the provider of the finalizer of a followed cluster, to
+// which the finalizer would push the guarantees of finalized collections. As
+// followers are not guarantors of these collections, it discards them.
+type discardEngine struct {
+	module.NoopReadDoneAware
+}
+
+var _ network.Engine = (*discardEngine)(nil)
+
+func (e *discardEngine) SubmitLocal(interface{}) {}
+
+func (e *discardEngine) Submit(network.Channel, flow.Identifier, interface{}) {}
+
+func (e *discardEngine) ProcessLocal(interface{}) error {
+	return nil
+}
+
+func (e *discardEngine) Process(network.Channel, flow.Identifier, interface{}) error {
+	return nil
+}
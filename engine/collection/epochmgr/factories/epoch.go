@@ -3,7 +3,9 @@ package factories
 import (
 	"fmt"
 
+	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/engine/collection/epochmgr"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool/epochs"
 	chainsync "github.com/onflow/flow-go/module/synchronization"
@@ -154,3 +156,96 @@ func (factory *EpochComponentsFactory) Create(
 
 	return
 }
+
+// CreateFollower creates the components for following the cluster of an epoch,
+// in which this node is not staked. The followed cluster is the one to which
+// the observed node is assigned. Its blocks are processed by a compliance
+// engine and finalized by a HotStuff follower, so that the synchronization
+// engine serves the finalized cluster state to peers.
+func (factory *EpochComponentsFactory) CreateFollower(
+	epoch protocol.Epoch,
+	observed flow.Identifier,
+) (
+	state cluster.State,
+	proposal network.Engine,
+	sync network.Engine,
+	follower module.HotStuffFollower,
+	err error,
+) {
+
+	counter, err := epoch.Counter()
+	if err != nil {
+		err = fmt.Errorf("could not get epoch counter: %w", err)
+		return
+	}
+
+	// determine the cluster of the observed node for the epoch
+	clusters, err := epoch.Clustering()
+	if err != nil {
+		err = fmt.Errorf("could not get clusters for epoch: %w", err)
+		return
+	}
+	_, clusterIndex, ok := clusters.ByNodeID(observed)
+	if !ok {
+		err = fmt.Errorf("observed node is not assigned to a cluster (node_id=%x, epoch=%d)", observed, counter)
+		return
+	}
+	cluster, err := epoch.Cluster(clusterIndex)
+	if err != nil {
+		err = fmt.Errorf("could not get cluster info: %w", err)
+		return
+	}
+
+	// create the cluster state
+	stateRoot, err := badger.NewStateRoot(cluster.RootBlock())
+	if err != nil {
+		err = fmt.Errorf("could not create valid state root: %w", err)
+		return
+	}
+	mutableState, headers, payloads, blocks, err := factory.state.Create(stateRoot)
+	if err != nil {
+		err = fmt.Errorf("could not create cluster state: %w", err)
+		return
+	}
+	state = mutableState
+
+	finalizer := factory.builder.CreateFollowerFinalizer(factory.pools.ForEpoch(counter))
+
+	proposalEng, err := factory.proposal.Create(mutableState, headers, payloads)
+	if err != nil {
+		err = fmt.Errorf("could not create proposal engine: %w", err)
+		return
+	}
+
+	var syncCore *chainsync.Core
+	syncCore, sync, err = factory.sync.Create(cluster.Members(), state, blocks, proposalEng)
+	if err != nil {
+		err = fmt.Errorf("could not create sync engine: %w", err)
+		return
+	}
+
+	followerLoop, err := factory.hotstuff.CreateFollower(epoch, cluster, state, headers, payloads, finalizer)
+	if err != nil {
+		err = fmt.Errorf("could not create hotstuff follower: %w", err)
+		return
+	}
+	follower = followerLoop
+
+	// attach dependencies to the proposal engine
+	proposal = proposalEng.
+		WithConsensus(&followerConsensus{FollowerLoop: followerLoop}).
+		WithSync(syncCore)
+
+	return
+}
+
+// followerConsensus is the consensus algorithm of the compliance engine of a
+// followed cluster. It forwards proposals to the HotStuff follower and drops
+// votes, as this node is not a member of the cluster.
+type followerConsensus struct {
+	*hotstuff.FollowerLoop
+}
+
+var _ module.HotStuff = (*followerConsensus)(nil)
+
+func (f *followerConsensus) SubmitVote(flow.Identifier, flow.Identifier, uint64, []byte) {}
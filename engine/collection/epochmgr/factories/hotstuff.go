@@ -82,6 +82,10 @@ func (f *HotStuffFactory) Create(
 	if err != nil {
 		return nil, fmt.Errorf("could not create cluster committee: %w", err)
 	}
+	committee, err = committees.NewIdentityCache(committee, committees.DefaultIdentityCacheSize) // cache for the participants of recently verified blocks
+	if err != nil {
+		return nil, fmt.Errorf("could not create cluster committee identity cache: %w", err)
+	}
 	committee = committees.NewMetricsWrapper(committee, metrics) // wrapper for measuring time spent determining consensus committee relations
 
 	// create a signing provider
@@ -114,3 +118,51 @@ func (f *HotStuffFactory) Create(
 	)
 	return participant, err
 }
+
+// CreateFollower creates a HotStuff follower for a cluster, which this node
+// follows without being a member. It verifies the blocks of the cluster and
+// finalizes them with the given finalizer.
+func (f *HotStuffFactory) CreateFollower(
+	epoch protocol.Epoch,
+	cluster protocol.Cluster,
+	clusterState cluster.State,
+	headers storage.Headers,
+	payloads storage.ClusterPayloads,
+	updater module.Finalizer,
+) (*hotstuff.FollowerLoop, error) {
+
+	notifier := pubsub.NewDistributor()
+	notifier.AddConsumer(notifications.NewLogConsumer(f.log))
+
+	var committee hotstuff.Committee
+	var err error
+	committee, err = committees.NewClusterCommittee(f.protoState, payloads, cluster, epoch, f.me.NodeID())
+	if err != nil {
+		return nil, fmt.Errorf("could not create cluster committee: %w", err)
+	}
+	committee, err = committees.NewIdentityCache(committee, committees.DefaultIdentityCacheSize) // cache for the participants of recently verified blocks
+	if err != nil {
+		return nil, fmt.Errorf("could not create cluster committee identity cache: %w", err)
+	}
+
+	verifier := verification.NewSingleVerifier(committee, f.aggregator)
+
+	finalized, pending, err := recovery.FindLatest(clusterState, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	follower, err := consensus.NewFollower(
+		f.log,
+		committee,
+		headers,
+		updater,
+		verifier,
+		notifier,
+		cluster.RootBlock().Header,
+		cluster.RootQC(),
+		finalized,
+		pending,
+	)
+	return follower, err
+}
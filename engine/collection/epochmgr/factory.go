@@ -1,6 +1,7 @@
 package epochmgr
 
 import (
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/cluster"
@@ -24,4 +25,18 @@ type EpochComponentsFactory interface {
 		hotstuff module.HotStuff,
 		err error,
 	)
+
+	// CreateFollower sets up and instantiates the dependencies for following
+	// the cluster state of an epoch in which this node is not staked. The
+	// followed cluster is the one the observed node is assigned to. The
+	// compliance engine processes the blocks of the cluster, which a HotStuff
+	// follower finalizes, and the synchronization engine serves the cluster
+	// state to peers, without participating in cluster consensus.
+	CreateFollower(epoch protocol.Epoch, observed flow.Identifier) (
+		state cluster.State,
+		proposal network.Engine,
+		sync network.Engine,
+		follower module.HotStuffFollower,
+		err error,
+	)
 }
@@ -0,0 +1,97 @@
+package harness
+
+import (
+	"sync"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+)
+
+// Component is a programmable epoch component, which can stand in for the
+// proposal engine, the synchronization engine and HotStuff. The test controls
+// whether the component becomes ready when it is started, and observes whether
+// it was started and stopped. Component ignores all submitted events.
+type Component struct {
+	mu        sync.Mutex
+	ready     chan struct{}
+	done      chan struct{}
+	readyOnce sync.Once
+	doneOnce  sync.Once
+	blocked   bool // if set, the component only becomes ready once unblocked
+	started   bool
+	stopped   bool
+}
+
+var _ network.Engine = (*Component)(nil)
+var _ module.HotStuff = (*Component)(nil)
+
+// NewComponent returns a new component. If blockStartup is set, the component
+// does not become ready when started, until Unblock is called.
+func NewComponent(blockStartup bool) *Component {
+	return &Component{
+		ready:   make(chan struct{}),
+		done:    make(chan struct{}),
+		blocked: blockStartup,
+	}
+}
+
+// Ready starts the component.
+func (c *Component) Ready() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.started = true
+	if !c.blocked {
+		c.readyOnce.Do(func() { close(c.ready) })
+	}
+	return c.ready
+}
+
+// Done stops the component.
+func (c *Component) Done() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	c.doneOnce.Do(func() { close(c.done) })
+	return c.done
+}
+
+// Unblock lets the component become ready, if it was started or once it is started.
+func (c *Component) Unblock() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocked = false
+	if c.started {
+		c.readyOnce.Do(func() { close(c.ready) })
+	}
+}
+
+// Started returns whether the component was started.
+func (c *Component) Started() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.started
+}
+
+// Stopped returns whether the component was stopped.
+func (c *Component) Stopped() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopped
+}
+
+func (c *Component) SubmitLocal(interface{}) {}
+
+func (c *Component) Submit(network.Channel, flow.Identifier, interface{}) {}
+
+func (c *Component) ProcessLocal(interface{}) error {
+	return nil
+}
+
+func (c *Component) Process(network.Channel, flow.Identifier, interface{}) error {
+	return nil
+}
+
+func (c *Component) SubmitProposal(*flow.Header, uint64) {}
+
+func (c *Component) SubmitVote(flow.Identifier, flow.Identifier, uint64, []byte) {}
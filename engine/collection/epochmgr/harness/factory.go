@@ -0,0 +1,165 @@
+package harness
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/engine/collection/epochmgr"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/cluster"
+	clustermock "github.com/onflow/flow-go/state/cluster/mock"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// Behavior programs how the factory creates the components of an epoch.
+type Behavior struct {
+	Unstaked     bool  // the node is not staked in the epoch, only follower components can be created
+	Err          error // creating the components fails with this error
+	BlockStartup bool  // the components don't become ready, until they are unblocked
+}
+
+// EpochComponents are the components created by the factory for an epoch. For
+// follower components, the HotStuff component is the HotStuff follower.
+type EpochComponents struct {
+	State    *clustermock.State
+	Proposal *Component
+	Sync     *Component
+	HotStuff *Component
+	Follower bool
+}
+
+// Components returns the components, which are set.
+func (c *EpochComponents) Components() []*Component {
+	return []*Component{c.Proposal, c.Sync, c.HotStuff}
+}
+
+// Started returns whether all components were started.
+func (c *EpochComponents) Started() bool {
+	for _, component := range c.Components() {
+		if !component.Started() {
+			return false
+		}
+	}
+	return true
+}
+
+// Stopped returns whether all components were stopped.
+func (c *EpochComponents) Stopped() bool {
+	for _, component := range c.Components() {
+		if !component.Stopped() {
+			return false
+		}
+	}
+	return true
+}
+
+// Unblock lets all components become ready.
+func (c *EpochComponents) Unblock() {
+	for _, component := range c.Components() {
+		component.Unblock()
+	}
+}
+
+// Factory is an epoch components factory creating programmable components,
+// according to the behavior set for each epoch. By default, the node is staked
+// in every epoch and the components become ready as soon as they are started.
+// Factory is safe for concurrent use.
+type Factory struct {
+	mu         sync.Mutex
+	behaviors  map[uint64]Behavior
+	components map[uint64]*EpochComponents
+}
+
+var _ epochmgr.EpochComponentsFactory = (*Factory)(nil)
+
+// NewFactory returns a new factory.
+func NewFactory() *Factory {
+	return &Factory{
+		behaviors:  make(map[uint64]Behavior),
+		components: make(map[uint64]*EpochComponents),
+	}
+}
+
+// SetBehavior sets how the components of the epoch with the given counter are
+// created.
+func (f *Factory) SetBehavior(counter uint64, behavior Behavior) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.behaviors[counter] = behavior
+}
+
+// Components returns the components last created for the epoch with the given
+// counter, and false if no components were created for the epoch.
+func (f *Factory) Components(counter uint64) (*EpochComponents, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	components, ok := f.components[counter]
+	return components, ok
+}
+
+func (f *Factory) Create(epoch protocol.Epoch) (
+	cluster.State,
+	network.Engine,
+	network.Engine,
+	module.HotStuff,
+	error,
+) {
+	counter, behavior, err := f.behavior(epoch)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if behavior.Unstaked {
+		return nil, nil, nil, nil, epochmgr.ErrUnstakedForEpoch
+	}
+
+	components := &EpochComponents{
+		State:    new(clustermock.State),
+		Proposal: NewComponent(behavior.BlockStartup),
+		Sync:     NewComponent(behavior.BlockStartup),
+		HotStuff: NewComponent(behavior.BlockStartup),
+	}
+	f.mu.Lock()
+	f.components[counter] = components
+	f.mu.Unlock()
+	return components.State, components.Proposal, components.Sync, components.HotStuff, nil
+}
+
+func (f *Factory) CreateFollower(epoch protocol.Epoch, _ flow.Identifier) (
+	cluster.State,
+	network.Engine,
+	network.Engine,
+	module.HotStuffFollower,
+	error,
+) {
+	counter, behavior, err := f.behavior(epoch)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	components := &EpochComponents{
+		State:    new(clustermock.State),
+		Proposal: NewComponent(behavior.BlockStartup),
+		Sync:     NewComponent(behavior.BlockStartup),
+		HotStuff: NewComponent(behavior.BlockStartup),
+		Follower: true,
+	}
+	f.mu.Lock()
+	f.components[counter] = components
+	f.mu.Unlock()
+	return components.State, components.Proposal, components.Sync, components.HotStuff, nil
+}
+
+// behavior returns the counter of the epoch and the behavior set for it, or
+// the error the behavior programs the creation to fail with.
+func (f *Factory) behavior(epoch protocol.Epoch) (uint64, Behavior, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return 0, Behavior{}, fmt.Errorf("could not get epoch counter: %w", err)
+	}
+	f.mu.Lock()
+	behavior := f.behaviors[counter]
+	f.mu.Unlock()
+	return counter, behavior, behavior.Err
+}
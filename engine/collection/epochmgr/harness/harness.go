@@ -0,0 +1,208 @@
+// Package harness provides a test harness for the epoch manager, which runs
+// the engine with programmable epoch components over mocked protocol state,
+// and drives it through epoch transitions and setup phases.
+package harness
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/collection/epochmgr"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/module/mempool/epochs"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	module "github.com/onflow/flow-go/module/mock"
+	realprotocol "github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events/gadgets"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+// Timeout is how long the harness waits for the engine to reach the state
+// resulting from an event.
+const Timeout = time.Second
+
+// Harness runs the epoch manager with the programmable components created by
+// its factory. The protocol state starts in the staking phase of epoch 0, with
+// epoch 1 set up as the next epoch.
+type Harness struct {
+	t       *testing.T
+	Engine  *epochmgr.Engine
+	Factory *Factory
+	State   *protocol.State
+	Snap    *protocol.Snapshot
+	Epochs  *mocks.EpochQuery
+	Voter   *module.ClusterRootQCVoter
+	Pools   *epochs.TransactionPools
+	Heights *gadgets.PersistentHeights
+
+	db          *badger.DB
+	counter     uint64      // reflects the counter of the current epoch
+	votes       chan uint64 // counters of the epochs voted for
+	transitions chan error  // errors failing epoch transitions
+}
+
+// NewHarness creates a new harness. The factory can be programmed before the
+// engine is started.
+func NewHarness(t *testing.T) *Harness {
+	h := &Harness{
+		t:           t,
+		Factory:     NewFactory(),
+		State:       new(protocol.State),
+		Snap:        new(protocol.Snapshot),
+		Voter:       new(module.ClusterRootQCVoter),
+		votes:       make(chan uint64, 16),
+		transitions: make(chan error, 16),
+	}
+
+	h.Epochs = mocks.NewEpochQuery(t, h.counter)
+	h.State.On("Final").Return(h.Snap)
+	h.Snap.On("Epochs").Return(h.Epochs)
+	h.Snap.On("Phase").Return(flow.EpochPhaseStaking, nil)
+	h.addEpoch(h.counter)
+	h.addEpoch(h.counter + 1)
+
+	h.Voter.On("Vote", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			epoch := args.Get(1).(realprotocol.Epoch)
+			counter, err := epoch.Counter()
+			require.NoError(t, err)
+			h.votes <- counter
+		}).
+		Return(nil)
+
+	h.Pools = epochs.NewTransactionPools(func() mempool.Transactions { return stdmap.NewTransactions(1000) })
+
+	return h
+}
+
+// Start creates the engine with the given options and starts it. Errors
+// failing epoch transitions are reported by FireEpochTransition, instead of
+// being fatal.
+func (h *Harness) Start(opts ...epochmgr.Opt) {
+	log := zerolog.New(ioutil.Discard)
+	db, dir := unittest.TempBadgerDB(h.t)
+	h.t.Cleanup(func() {
+		require.NoError(h.t, db.Close())
+		require.NoError(h.t, os.RemoveAll(dir))
+	})
+	h.db = db
+
+	var err error
+	h.Heights, err = gadgets.NewPersistentHeights(log, db, 0)
+	require.NoError(h.t, err)
+
+	opts = append(opts, epochmgr.WithEpochTransitionErrorHandler(func(err error) {
+		h.transitions <- err
+	}))
+	h.Engine, err = epochmgr.New(
+		log,
+		new(module.Local),
+		h.State,
+		h.Pools,
+		h.Voter,
+		bstorage.NewClusterQCVotes(db),
+		h.Factory,
+		h.Heights,
+		opts...,
+	)
+	require.NoError(h.t, err)
+
+	unittest.AssertClosesBefore(h.t, h.Engine.Ready(), Timeout)
+	h.t.Cleanup(func() {
+		unittest.AssertClosesBefore(h.t, h.Engine.Done(), Timeout)
+	})
+}
+
+// Counter returns the counter of the current epoch.
+func (h *Harness) Counter() uint64 {
+	return h.counter
+}
+
+// FireEpochTransition transitions the protocol state to the next epoch, of
+// which the given header is the first block, and sets up the epoch after it.
+// It notifies the engine of the transition, and waits until the engine has
+// scheduled stopping the previous epoch, or returns the error failing the
+// transition.
+func (h *Harness) FireEpochTransition(first *flow.Header) error {
+	h.counter++
+	h.Epochs.Transition()
+	h.addEpoch(h.counter + 1)
+
+	h.Engine.EpochTransition(h.counter, first)
+
+	stopAtHeight := first.Height + flow.DefaultTransactionExpiry
+	callbackID := fmt.Sprintf("stop-epoch-%d", h.counter-1)
+	deadline := time.After(Timeout)
+	for {
+		select {
+		case err := <-h.transitions:
+			return err
+		case <-deadline:
+			return fmt.Errorf("epoch %d transition did not complete within %s", h.counter, Timeout)
+		case <-time.After(time.Millisecond):
+			for _, id := range h.HeightCallbacks()[stopAtHeight] {
+				if id == callbackID {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// FireSetupPhase notifies the engine of the start of the setup phase, and waits
+// until the engine has voted for the root QC of the next epoch.
+func (h *Harness) FireSetupPhase() {
+	h.Engine.EpochSetupPhaseStarted(h.counter, nil)
+
+	select {
+	case counter := <-h.votes:
+		require.Equal(h.t, h.counter+1, counter, "should vote for next epoch")
+	case <-time.After(Timeout):
+		require.Fail(h.t, "should vote for next epoch", "epoch %d", h.counter+1)
+	}
+}
+
+// FinalizeHeight notifies the height events of a finalized block at the
+// given height.
+func (h *Harness) FinalizeHeight(height uint64) {
+	header := unittest.BlockHeaderFixture()
+	header.Height = height
+	h.Heights.BlockFinalized(&header)
+}
+
+// AwaitStopped waits until the components of the epoch with the given counter
+// are stopped.
+func (h *Harness) AwaitStopped(counter uint64) {
+	require.Eventually(h.t, func() bool {
+		components, ok := h.Factory.Components(counter)
+		return ok && components.Stopped()
+	}, Timeout, time.Millisecond, "should stop components of epoch %d", counter)
+}
+
+// HeightCallbacks returns the height callbacks stored in the database.
+func (h *Harness) HeightCallbacks() map[uint64][]string {
+	callbacks := make(map[uint64][]string)
+	err := h.db.View(operation.RetrieveHeightCallbacks(callbacks))
+	require.NoError(h.t, err)
+	return callbacks
+}
+
+// addEpoch adds an epoch with the given counter to the protocol state.
+func (h *Harness) addEpoch(counter uint64) {
+	epoch := new(protocol.Epoch)
+	epoch.On("Counter").Return(counter, nil)
+	h.Epochs.Add(epoch)
+}
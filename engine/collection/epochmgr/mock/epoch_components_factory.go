@@ -3,6 +3,7 @@
 package mock
 
 import (
+	flow "github.com/onflow/flow-go/model/flow"
 	cluster "github.com/onflow/flow-go/state/cluster"
 
 	mock "github.com/stretchr/testify/mock"
@@ -68,3 +69,53 @@ func (_m *EpochComponentsFactory) Create(epoch protocol.Epoch) (cluster.State, n
 
 	return r0, r1, r2, r3, r4
 }
+
+// CreateFollower provides a mock function with given fields: epoch, observed
+func (_m *EpochComponentsFactory) CreateFollower(epoch protocol.Epoch, observed flow.Identifier) (cluster.State, network.Engine, network.Engine, module.HotStuffFollower, error) {
+	ret := _m.Called(epoch, observed)
+
+	var r0 cluster.State
+	if rf, ok := ret.Get(0).(func(protocol.Epoch, flow.Identifier) cluster.State); ok {
+		r0 = rf(epoch, observed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cluster.State)
+		}
+	}
+
+	var r1 network.Engine
+	if rf, ok := ret.Get(1).(func(protocol.Epoch, flow.Identifier) network.Engine); ok {
+		r1 = rf(epoch, observed)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(network.Engine)
+		}
+	}
+
+	var r2 network.Engine
+	if rf, ok := ret.Get(2).(func(protocol.Epoch, flow.Identifier) network.Engine); ok {
+		r2 = rf(epoch, observed)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(network.Engine)
+		}
+	}
+
+	var r3 module.HotStuffFollower
+	if rf, ok := ret.Get(3).(func(protocol.Epoch, flow.Identifier) module.HotStuffFollower); ok {
+		r3 = rf(epoch, observed)
+	} else {
+		if ret.Get(3) != nil {
+			r3 = ret.Get(3).(module.HotStuffFollower)
+		}
+	}
+
+	var r4 error
+	if rf, ok := ret.Get(4).(func(protocol.Epoch, flow.Identifier) error); ok {
+		r4 = rf(epoch, observed)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
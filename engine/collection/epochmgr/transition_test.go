@@ -0,0 +1,121 @@
+package epochmgr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/collection/epochmgr"
+	"github.com/onflow/flow-go/engine/collection/epochmgr/harness"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEpochTransition tests starting the components of the new epoch on an
+// epoch transition, and stopping the components of the previous epoch once
+// the transactions referencing it have expired.
+func TestEpochTransition(t *testing.T) {
+	cases := []struct {
+		name     string
+		behavior harness.Behavior
+		opts     []epochmgr.Opt
+		follower bool // whether follower components are started for the new epoch
+		fails    bool // whether the epoch transition fails
+	}{
+		{
+			name: "staked in new epoch",
+		},
+		{
+			name:     "unstaked in new epoch",
+			behavior: harness.Behavior{Unstaked: true},
+		},
+		{
+			name:     "unstaked in new epoch, following unstaked epochs",
+			behavior: harness.Behavior{Unstaked: true},
+			opts:     []epochmgr.Opt{epochmgr.WithFollowUnstakedEpochs(unittest.IdentifierFixture())},
+			follower: true,
+		},
+		{
+			name:     "new epoch components time out on startup",
+			behavior: harness.Behavior{BlockStartup: true},
+			opts:     []epochmgr.Opt{epochmgr.WithStartupTimeout(50 * time.Millisecond)},
+			fails:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := harness.NewHarness(t)
+			h.Factory.SetBehavior(1, c.behavior)
+			h.Start(c.opts...)
+
+			previous, ok := h.Factory.Components(0)
+			require.True(t, ok, "should create components of current epoch")
+			assert.True(t, previous.Started(), "should start components of current epoch")
+
+			first := unittest.BlockHeaderFixture()
+			stopAtHeight := first.Height + flow.DefaultTransactionExpiry
+			err := h.FireEpochTransition(&first)
+			if c.fails {
+				require.Error(t, err)
+				// the previous epoch keeps running, as the transition was not completed
+				assert.Empty(t, h.HeightCallbacks(), "should not schedule stopping previous epoch")
+				assert.False(t, previous.Stopped(), "should not stop components of previous epoch")
+				return
+			}
+			require.NoError(t, err)
+
+			current, ok := h.Factory.Components(1)
+			if c.behavior.Unstaked && !c.follower {
+				assert.False(t, ok, "should not create components of unstaked epoch")
+			} else {
+				require.True(t, ok, "should create components of new epoch")
+				assert.Equal(t, c.follower, current.Follower)
+				assert.True(t, current.Started(), "should start components of new epoch")
+			}
+
+			// the previous epoch is stopped once its transactions have expired
+			assert.False(t, previous.Stopped(), "should not stop components of previous epoch before expiry")
+			h.FinalizeHeight(stopAtHeight)
+			h.AwaitStopped(0)
+			if ok {
+				assert.False(t, current.Stopped(), "should not stop components of new epoch")
+			}
+			assert.Empty(t, h.HeightCallbacks(), "should remove invoked height callback")
+		})
+	}
+}
+
+// TestSetupPhase tests voting for the root QC of the next epoch on the start of
+// the setup phase, also in epochs in which the node is not staked.
+func TestSetupPhase(t *testing.T) {
+	cases := []struct {
+		name     string
+		behavior harness.Behavior
+	}{
+		{
+			name: "staked in current epoch",
+		},
+		{
+			name:     "unstaked in current epoch",
+			behavior: harness.Behavior{Unstaked: true},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := harness.NewHarness(t)
+			h.Factory.SetBehavior(0, c.behavior)
+			h.Start()
+
+			h.FireSetupPhase()
+			assert.Eventually(t, func() bool {
+				voted, err := h.Engine.HasVoted(1)
+				require.NoError(t, err)
+				return voted
+			}, time.Second, time.Millisecond, "should record vote for next epoch")
+		})
+	}
+}
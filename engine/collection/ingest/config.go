@@ -22,6 +22,8 @@ type Config struct {
 	MaxTransactionByteSize uint64
 	// maximum collection byte size, it acts as hard limit max for the tx size.
 	MaxCollectionByteSize uint64
+	// the maximum number of arguments a transaction may pass to its script
+	MaxArgumentCount uint
 }
 
 func DefaultConfig() Config {
@@ -32,6 +34,7 @@ func DefaultConfig() Config {
 		MaxCollectionByteSize:  flow.DefaultMaxCollectionByteSize,
 		CheckScriptsParse:      true,
 		MaxAddressIndex:        flow.DefaultMaxAddressIndex,
+		MaxArgumentCount:       flow.DefaultMaxTransactionArgumentCount,
 		PropagationRedundancy:  2,
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/logging"
 )
 
@@ -62,6 +63,7 @@ func New(
 			CheckScriptsParse:      config.CheckScriptsParse,
 			MaxTransactionByteSize: config.MaxTransactionByteSize,
 			MaxCollectionByteSize:  config.MaxCollectionByteSize,
+			MaxArgumentCount:       config.MaxArgumentCount,
 		},
 	)
 
@@ -168,6 +170,10 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 	refSnapshot := e.state.AtBlockID(tx.ReferenceBlockID)
 	// fail fast if this is an unknown reference
 	_, err := refSnapshot.Head()
+	if errors.Is(err, storage.ErrNotFound) {
+		e.colMetrics.TransactionRejected(metrics.TransactionRejectedReferenceBlock)
+		return engine.NewInvalidInputErrorf("invalid transaction: %w", access.InvalidReferenceBlockError{ReferenceBlockID: tx.ReferenceBlockID})
+	}
 	if err != nil {
 		return fmt.Errorf("could not get reference block: %w", err)
 	}
@@ -197,6 +203,7 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 	// check if the transaction is valid
 	err = e.transactionValidator.Validate(tx)
 	if err != nil {
+		e.colMetrics.TransactionRejected(rejectionReason(err))
 		return engine.NewInvalidInputErrorf("invalid transaction: %w", err)
 	}
 
@@ -249,3 +256,32 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 
 	return nil
 }
+
+// rejectionReason returns the metrics label for the validation error of a rejected transaction.
+func rejectionReason(err error) string {
+	switch {
+	case errors.As(err, &access.InvalidTxByteSizeError{}):
+		return metrics.TransactionRejectedByteSize
+	case errors.As(err, &access.InvalidGasLimitError{}):
+		return metrics.TransactionRejectedGasLimit
+	case errors.As(err, &access.InvalidScriptError{}):
+		return metrics.TransactionRejectedScript
+	case errors.As(err, &access.InvalidArgumentCountError{}),
+		errors.As(err, &access.InvalidArgumentByteSizeError{}),
+		errors.As(err, &access.InvalidArgumentsByteSizeError{}),
+		errors.As(err, &access.InvalidArgumentEncodingError{}):
+		return metrics.TransactionRejectedArguments
+	case errors.As(err, &access.IncompleteTransactionError{}):
+		return metrics.TransactionRejectedMissingFields
+	case errors.As(err, &access.ExpiredTransactionError{}):
+		return metrics.TransactionRejectedExpired
+	case errors.As(err, &access.InvalidReferenceBlockError{}):
+		return metrics.TransactionRejectedReferenceBlock
+	case errors.As(err, &access.InvalidAddressError{}):
+		return metrics.TransactionRejectedAddress
+	case errors.As(err, &access.InvalidSignatureError{}), errors.As(err, &access.DuplicatedSignatureError{}):
+		return metrics.TransactionRejectedSignature
+	default:
+		return metrics.TransactionRejectedOther
+	}
+}
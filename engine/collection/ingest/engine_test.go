@@ -147,13 +147,34 @@ func (suite *Suite) TestInvalidTransaction() {
 		suite.Assert().True(errors.As(err, &access.InvalidGasLimitError{}))
 	})
 
+	suite.Run("byte size exceeds the maximum allowed", func() {
+		tx := unittest.TransactionBodyFixture()
+		tx.Payer = unittest.RandomAddressFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx.Arguments = [][]byte{make([]byte, suite.conf.MaxTransactionByteSize)}
+
+		err := suite.engine.ProcessLocal(&tx)
+		suite.Assert().Error(err)
+		suite.Assert().True(errors.As(err, &access.InvalidTxByteSizeError{}))
+	})
+
+	suite.Run("argument count exceeds the maximum allowed", func() {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx.Arguments = make([][]byte, suite.conf.MaxArgumentCount+1)
+
+		err := suite.engine.ProcessLocal(&tx)
+		suite.Assert().Error(err)
+		suite.Assert().True(errors.As(err, &access.InvalidArgumentCountError{}))
+	})
+
 	suite.Run("invalid reference block ID", func() {
 		tx := unittest.TransactionBodyFixture()
 		tx.ReferenceBlockID = unittest.IdentifierFixture()
 
 		err := suite.engine.ProcessLocal(&tx)
 		suite.Assert().Error(err)
-		suite.Assert().True(errors.As(err, &access.ErrUnknownReferenceBlock))
+		suite.Assert().True(errors.Is(err, access.ErrUnknownReferenceBlock))
 	})
 
 	suite.Run("un-parseable script", func() {
@@ -278,6 +299,88 @@ func (suite *Suite) TestInvalidTransaction() {
 	})
 }
 
+// should count every rejected transaction by the reason it was rejected for
+func (suite *Suite) TestInvalidTransaction_RejectionMetrics() {
+
+	colMetrics := new(module.CollectionMetrics)
+	net := new(mocknetwork.Network)
+	net.On("Register", mock.Anything, mock.Anything).Return(suite.conduit, nil).Once()
+	eng, err := New(zerolog.New(ioutil.Discard), net, suite.state, metrics.NewNoopCollector(), colMetrics, suite.me, flow.Testnet.Chain(), suite.pools, suite.conf)
+	suite.Require().NoError(err)
+
+	cases := map[string]func(tx *flow.TransactionBody){
+		metrics.TransactionRejectedByteSize: func(tx *flow.TransactionBody) {
+			tx.Payer = unittest.RandomAddressFixture()
+			tx.Arguments = [][]byte{make([]byte, suite.conf.MaxTransactionByteSize)}
+		},
+		metrics.TransactionRejectedGasLimit: func(tx *flow.TransactionBody) {
+			tx.Payer = unittest.RandomAddressFixture()
+			tx.GasLimit = suite.conf.MaxGasLimit + 1
+		},
+		metrics.TransactionRejectedScript: func(tx *flow.TransactionBody) {
+			tx.Script = []byte("definitely a real transaction")
+		},
+		metrics.TransactionRejectedArguments: func(tx *flow.TransactionBody) {
+			tx.Arguments = make([][]byte, suite.conf.MaxArgumentCount+1)
+		},
+		metrics.TransactionRejectedMissingFields: func(tx *flow.TransactionBody) {
+			tx.Script = nil
+		},
+		metrics.TransactionRejectedReferenceBlock: func(tx *flow.TransactionBody) {
+			tx.ReferenceBlockID = unittest.IdentifierFixture()
+		},
+		metrics.TransactionRejectedAddress: func(tx *flow.TransactionBody) {
+			tx.Payer = unittest.InvalidAddressFixture()
+		},
+		metrics.TransactionRejectedSignature: func(tx *flow.TransactionBody) {
+			tx.EnvelopeSignatures[0] = unittest.InvalidFormatSignature()
+		},
+	}
+
+	for reason, alter := range cases {
+		suite.Run(reason, func() {
+			tx := unittest.TransactionBodyFixture()
+			tx.ReferenceBlockID = suite.root.ID()
+			alter(&tx)
+
+			colMetrics.On("TransactionRejected", reason).Once()
+			err := eng.ProcessLocal(&tx)
+			suite.Assert().True(engine.IsInvalidInputError(err))
+			colMetrics.AssertExpectations(suite.T())
+		})
+	}
+
+	// should not be added to the mempool
+	counter, err := suite.epochQuery.Current().Counter()
+	suite.Require().NoError(err)
+	suite.Assert().Zero(suite.pools.ForEpoch(counter).Size())
+	suite.conduit.AssertNumberOfCalls(suite.T(), "Multicast", 0)
+}
+
+// should add valid transactions to the mempool as they were received
+func (suite *Suite) TestValidTransaction_Unaltered() {
+
+	local, _, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+	suite.Require().True(ok)
+
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	tx.Arguments = make([][]byte, suite.conf.MaxArgumentCount)
+	tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+	expected := tx
+
+	suite.conduit.On("Multicast", &tx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := suite.engine.ProcessLocal(&tx)
+	suite.Require().NoError(err)
+
+	counter, err := suite.epochQuery.Current().Counter()
+	suite.Require().NoError(err)
+	stored, ok := suite.pools.ForEpoch(counter).ByID(tx.ID())
+	suite.Require().True(ok)
+	suite.Assert().Equal(&expected, stored)
+}
+
 // should store transactions for local cluster and propagate to other cluster members
 func (suite *Suite) TestRoutingLocalCluster() {
 
@@ -560,3 +663,37 @@ func (suite *Suite) TestRouting_ClusterAssignmentAdded() {
 	err = suite.engine.ProcessLocal(&tx)
 	suite.Assert().NoError(err)
 }
+
+// should accept identical resubmissions and replacements of pending transactions, since the
+// sequence numbers of their proposal keys are only checked during execution
+func (suite *Suite) TestRoutingLocalCluster_SameProposalKey() {
+
+	local, _, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+	suite.Require().True(ok)
+	suite.conduit.On("Multicast", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+
+	// a replacement of the transaction, using the same proposal key and sequence number
+	replacement := unittest.TransactionBodyFixture()
+	replacement.ReferenceBlockID = suite.root.ID()
+	replacement = unittest.AlterTransactionForCluster(replacement, suite.clusters, local, func(transaction *flow.TransactionBody) {
+		transaction.ProposalKey = tx.ProposalKey
+	})
+	suite.Require().NotEqual(tx.ID(), replacement.ID())
+
+	err := suite.engine.ProcessLocal(&tx)
+	suite.Assert().NoError(err)
+	// identical resubmission
+	err = suite.engine.ProcessLocal(&tx)
+	suite.Assert().NoError(err)
+	err = suite.engine.ProcessLocal(&replacement)
+	suite.Assert().NoError(err)
+
+	counter, err := suite.epochQuery.Current().Counter()
+	suite.Require().NoError(err)
+	suite.Assert().True(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	suite.Assert().True(suite.pools.ForEpoch(counter).Has(replacement.ID()))
+}
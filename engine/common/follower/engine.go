@@ -28,15 +28,33 @@ type Engine struct {
 	me             module.Local
 	engMetrics     module.EngineMetrics
 	mempoolMetrics module.MempoolMetrics
+	cacheMetrics   module.CacheMetrics
 	cleaner        storage.Cleaner
 	headers        storage.Headers
 	payloads       storage.Payloads
 	state          protocol.MutableState
+	chainID        flow.ChainID // chain ID of the local chain, proposals for other chains are rejected
 	pending        module.PendingBlockBuffer
 	follower       module.HotStuffFollower
 	con            network.Conduit
 	sync           module.BlockRequester
 	tracer         module.Tracer
+	seenCapacity   uint        // number of blocks remembered by each generation of the seen blocks filter
+	seenFPRate     float64     // false positive rate of each generation of the seen blocks filter
+	seen           *seenBlocks // filter of processed and cached blocks, consulted before locking
+}
+
+// Option is a functional option for the follower engine.
+type Option func(*Engine)
+
+// WithSeenBlocksFilter configures the filter of seen blocks, which lets the engine skip proposals
+// for blocks it processed or cached already without waiting for the lock. Each of its two generations
+// remembers the given number of blocks, and reports false positives at the given rate.
+func WithSeenBlocksFilter(capacity uint, falsePositiveRate float64) Option {
+	return func(e *Engine) {
+		e.seenCapacity = capacity
+		e.seenFPRate = falsePositiveRate
+	}
 }
 
 func New(
@@ -45,6 +63,7 @@ func New(
 	me module.Local,
 	engMetrics module.EngineMetrics,
 	mempoolMetrics module.MempoolMetrics,
+	cacheMetrics module.CacheMetrics,
 	cleaner storage.Cleaner,
 	headers storage.Headers,
 	payloads storage.Payloads,
@@ -53,22 +72,41 @@ func New(
 	follower module.HotStuffFollower,
 	sync module.BlockRequester,
 	tracer module.Tracer,
+	opts ...Option,
 ) (*Engine, error) {
 
+	chainID, err := state.Params().ChainID()
+	if err != nil {
+		return nil, fmt.Errorf("could not get chain ID: %w", err)
+	}
+
 	e := &Engine{
 		unit:           engine.NewUnit(),
 		log:            log.With().Str("engine", "follower").Logger(),
 		me:             me,
 		engMetrics:     engMetrics,
 		mempoolMetrics: mempoolMetrics,
+		cacheMetrics:   cacheMetrics,
 		cleaner:        cleaner,
 		headers:        headers,
 		payloads:       payloads,
 		state:          state,
+		chainID:        chainID,
 		pending:        pending,
 		follower:       follower,
 		sync:           sync,
 		tracer:         tracer,
+		seenCapacity:   DefaultSeenBlocksCapacity,
+		seenFPRate:     DefaultSeenBlocksFalsePositiveRate,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	e.seen, err = newSeenBlocks(e.seenCapacity, e.seenFPRate)
+	if err != nil {
+		return nil, fmt.Errorf("could not create seen blocks filter: %w", err)
 	}
 
 	con, err := net.Register(engine.ReceiveBlocks, e)
@@ -139,12 +177,18 @@ func (e *Engine) process(originID flow.Identifier, input interface{}) error {
 	case *events.SyncedBlock:
 		e.engMetrics.MessageReceived(metrics.EngineFollower, metrics.MessageSyncedBlock)
 		defer e.engMetrics.MessageHandled(metrics.EngineFollower, metrics.MessageSyncedBlock)
+		if originID == e.me.NodeID() && e.seenBefore(v.Block.Header.ID()) {
+			return nil
+		}
 		e.unit.Lock()
 		defer e.unit.Unlock()
 		return e.onSyncedBlock(originID, v)
 	case *messages.BlockProposal:
 		e.engMetrics.MessageReceived(metrics.EngineFollower, metrics.MessageBlockProposal)
 		defer e.engMetrics.MessageHandled(metrics.EngineFollower, metrics.MessageBlockProposal)
+		if e.seenBefore(v.Header.ID()) {
+			return nil
+		}
 		e.unit.Lock()
 		defer e.unit.Unlock()
 		return e.onBlockProposal(originID, v)
@@ -153,6 +197,27 @@ func (e *Engine) process(originID flow.Identifier, input interface{}) error {
 	}
 }
 
+// seenBefore returns true if the given block was processed or cached already, so that its proposal
+// can be skipped without taking the lock. As the filter of seen blocks reports false positives,
+// its hits are only trusted if confirmed by the pending blocks cache or the storage; all other
+// proposals take the regular path, which checks again while holding the lock.
+func (e *Engine) seenBefore(blockID flow.Identifier) bool {
+	if !e.seen.Has(blockID) {
+		e.cacheMetrics.CacheMiss(metrics.ResourceFollowerSeenBlock)
+		return false
+	}
+	_, cached := e.pending.ByID(blockID)
+	if !cached {
+		_, err := e.headers.ByBlockID(blockID)
+		if err != nil {
+			e.cacheMetrics.CacheNotFound(metrics.ResourceFollowerSeenBlock)
+			return false
+		}
+	}
+	e.cacheMetrics.CacheHit(metrics.ResourceFollowerSeenBlock)
+	return true
+}
+
 func (e *Engine) onSyncedBlock(originID flow.Identifier, synced *events.SyncedBlock) error {
 
 	// a block that is synced has to come locally, from the synchronization engine
@@ -191,6 +256,11 @@ func (e *Engine) onBlockProposal(originID flow.Identifier, proposal *messages.Bl
 
 	log.Info().Msg("block proposal received")
 
+	// reject proposals for other chains right away, they can't ever be connected to our chain
+	if header.ChainID != e.chainID {
+		return engine.NewWrongChainErrorf(e.chainID, header.ChainID, "block proposal %x", header.ID())
+	}
+
 	e.prunePendingCache()
 
 	// first, we reject all blocks that we don't need to process:
@@ -201,6 +271,7 @@ func (e *Engine) onBlockProposal(originID flow.Identifier, proposal *messages.Bl
 	// ignore proposals that are already cached
 	_, cached := e.pending.ByID(header.ID())
 	if cached {
+		e.seen.Add(header.ID())
 		log.Debug().Msg("skipping already cached proposal")
 		return nil
 	}
@@ -208,6 +279,7 @@ func (e *Engine) onBlockProposal(originID flow.Identifier, proposal *messages.Bl
 	// ignore proposals that were already processed
 	_, err := e.headers.ByBlockID(header.ID())
 	if err == nil {
+		e.seen.Add(header.ID())
 		log.Debug().Msg("skipping already processed proposal")
 		return nil
 	}
@@ -229,6 +301,7 @@ func (e *Engine) onBlockProposal(originID flow.Identifier, proposal *messages.Bl
 
 		// add the block to the cache
 		_ = e.pending.Add(originID, proposal)
+		e.seen.Add(header.ID())
 
 		// go to the first missing ancestor
 		ancestorID := ancestor.Header.ParentID
@@ -259,6 +332,7 @@ func (e *Engine) onBlockProposal(originID flow.Identifier, proposal *messages.Bl
 	if errors.Is(err, storage.ErrNotFound) {
 
 		_ = e.pending.Add(originID, proposal)
+		e.seen.Add(header.ID())
 
 		log.Debug().Msg("requesting missing parent for proposal")
 
@@ -321,8 +395,25 @@ func (e *Engine) processBlockProposal(ctx context.Context, proposal *messages.Bl
 	// it only checks the block header, since checking block body is expensive.
 	// The full block check is done by the consensus participants.
 	err := e.state.Extend(ctx, block)
+	// if the block is at or below the finalized height, or on a fork conflicting with the
+	// finalized blocks, it can never be finalized; we drop it along with its pending children
+	if errors.Is(err, protocol.ErrOutdatedBlock) {
+		log.Debug().Err(err).Msg("dropping outdated block proposal")
+		e.pending.DropForParent(header.ID())
+		return nil
+	}
+	if errors.Is(err, protocol.ErrOrphanedBlock) {
+		log.Info().Err(err).Msg("dropping block proposal on abandoned fork")
+		e.pending.DropForParent(header.ID())
+		return nil
+	}
+
 	// if the error is a known invalid extension of the protocol state, then
-	// the input is invalid
+	// the input is invalid and is reported along with the violated rule
+	var invalidErr protocol.InvalidExtensionError
+	if errors.As(err, &invalidErr) {
+		return engine.NewInvalidInputErrorf("invalid extension of protocol state (%s): %w", invalidErr.Reason, err)
+	}
 	if state.IsInvalidExtensionError(err) {
 		return engine.NewInvalidInputErrorf("invalid extension of protocol state: %w", err)
 	}
@@ -336,6 +427,7 @@ func (e *Engine) processBlockProposal(ctx context.Context, proposal *messages.Bl
 	if err != nil {
 		return fmt.Errorf("could not extend protocol state: %w", err)
 	}
+	e.seen.Add(header.ID())
 
 	// retrieve the parent
 	parent, err := e.headers.ByBlockID(header.ParentID)
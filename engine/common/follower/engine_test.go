@@ -16,6 +16,8 @@ import (
 	module "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network/mocknetwork"
+	st "github.com/onflow/flow-go/state"
+	protint "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -59,6 +61,9 @@ func (suite *Suite) SetupTest() {
 	suite.headers.On("Store", mock.Anything).Return(nil)
 	suite.payloads.On("Store", mock.Anything, mock.Anything).Return(nil)
 	suite.state.On("Final").Return(suite.snapshot)
+	params := new(protocol.Params)
+	params.On("ChainID").Return(flow.Emulator, nil)
+	suite.state.On("Params").Return(params)
 	suite.cache.On("PruneByHeight", mock.Anything).Return()
 	suite.cache.On("Size", mock.Anything).Return(uint(0))
 
@@ -68,6 +73,7 @@ func (suite *Suite) SetupTest() {
 		suite.me,
 		metrics,
 		metrics,
+		metrics,
 		suite.cleaner,
 		suite.headers,
 		suite.payloads,
@@ -116,6 +122,22 @@ func (suite *Suite) TestHandlePendingBlock() {
 	suite.con.AssertExpectations(suite.T())
 }
 
+func (suite *Suite) TestHandleProposalWrongChain() {
+
+	originID := unittest.IdentifierFixture()
+	block := unittest.BlockFixture()
+	block.Header.ChainID = flow.Testnet
+
+	// the proposal is rejected before it is looked up or cached
+	proposal := unittest.ProposalFromBlock(&block)
+	err := suite.engine.Process(engine.ReceiveBlocks, originID, proposal)
+	require.True(suite.T(), engine.IsWrongChainError(err), "proposal for another chain should be rejected")
+
+	suite.cache.AssertNotCalled(suite.T(), "ByID", mock.Anything)
+	suite.cache.AssertNotCalled(suite.T(), "Add", mock.Anything, mock.Anything)
+	suite.follower.AssertNotCalled(suite.T(), "SubmitProposal", mock.Anything)
+}
+
 func (suite *Suite) TestHandleProposal() {
 
 	originID := unittest.IdentifierFixture()
@@ -150,6 +172,116 @@ func (suite *Suite) TestHandleProposal() {
 	suite.follower.AssertExpectations(suite.T())
 }
 
+func (suite *Suite) TestHandleProposalRejected() {
+
+	rejections := map[string]error{
+		"outdated": st.NewOutdatedExtensionErrorf("%w", protint.ErrOutdatedBlock),
+		"orphaned": st.NewOutdatedExtensionErrorf("%w", protint.ErrOrphanedBlock),
+		"invalid":  protint.NewInvalidExtensionErrorf(protint.InvalidTimestamp, "invalid timestamp"),
+	}
+	for name, rejection := range rejections {
+		suite.Run(name, func() {
+			originID := unittest.IdentifierFixture()
+			parent := unittest.BlockFixture()
+			block := unittest.BlockFixture()
+
+			parent.Header.Height = 10
+			block.Header.Height = 11
+			block.Header.ParentID = parent.ID()
+
+			suite.cache.On("ByID", block.ID()).Return(nil, false).Once()
+			suite.cache.On("ByID", block.Header.ParentID).Return(nil, false).Once()
+			suite.headers.On("ByBlockID", block.ID()).Return(nil, realstorage.ErrNotFound).Once()
+			suite.headers.On("ByBlockID", block.Header.ParentID).Return(parent.Header, nil).Once()
+			suite.snapshot.On("Head").Return(parent.Header, nil).Once()
+			suite.state.On("Extend", mock.Anything, &block).Return(rejection).Once()
+			suite.cache.On("DropForParent", block.ID()).Once()
+
+			proposal := unittest.ProposalFromBlock(&block)
+			err := suite.engine.Process(engine.ReceiveBlocks, originID, proposal)
+
+			// invalid blocks are reported, while outdated and orphaned ones are dropped along with their children
+			if name == "invalid" {
+				require.True(suite.T(), engine.IsInvalidInputError(err), err)
+				suite.cache.AssertNotCalled(suite.T(), "DropForParent", block.ID())
+			} else {
+				require.NoError(suite.T(), err)
+				suite.cache.AssertCalled(suite.T(), "DropForParent", block.ID())
+			}
+			suite.follower.AssertNotCalled(suite.T(), "SubmitProposal", block.Header, mock.Anything)
+		})
+	}
+}
+
+func (suite *Suite) TestHandleDuplicateProposal() {
+
+	originID := unittest.IdentifierFixture()
+	parent := unittest.BlockFixture()
+	block := unittest.BlockFixture()
+
+	parent.Header.Height = 10
+	block.Header.Height = 11
+	block.Header.ParentID = parent.ID()
+
+	// the first proposal is processed
+	suite.cache.On("ByID", block.ID()).Return(nil, false).Twice()
+	suite.cache.On("ByID", block.Header.ParentID).Return(nil, false).Once()
+	suite.headers.On("ByBlockID", block.ID()).Return(nil, realstorage.ErrNotFound).Once()
+	suite.snapshot.On("Head").Return(parent.Header, nil).Once()
+	suite.state.On("Extend", mock.Anything, &block).Return(nil).Once()
+	suite.headers.On("ByBlockID", block.Header.ParentID).Return(parent.Header, nil).Twice()
+	suite.cache.On("ByParentID", block.ID()).Return(nil, false)
+	suite.follower.On("SubmitProposal", block.Header, parent.Header.View).Once()
+
+	// the duplicate is found by the filter of seen blocks, and confirmed by the storage
+	suite.headers.On("ByBlockID", block.ID()).Return(block.Header, nil).Once()
+
+	proposal := unittest.ProposalFromBlock(&block)
+	err := suite.engine.Process(engine.ReceiveBlocks, originID, proposal)
+	require.NoError(suite.T(), err)
+	err = suite.engine.Process(engine.ReceiveBlocks, originID, proposal)
+	require.NoError(suite.T(), err)
+
+	// the duplicate is skipped before the pending cache is pruned
+	suite.snapshot.AssertNumberOfCalls(suite.T(), "Head", 1)
+	suite.state.AssertNumberOfCalls(suite.T(), "Extend", 1)
+	suite.follower.AssertExpectations(suite.T())
+	suite.headers.AssertNumberOfCalls(suite.T(), "ByBlockID", 4)
+}
+
+func (suite *Suite) TestHandleDuplicateProposalFalsePositive() {
+
+	originID := unittest.IdentifierFixture()
+	head := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(10))
+	block := unittest.BlockFixture()
+	block.Header.Height = 12
+
+	// the proposal is cached, as its parent is missing
+	suite.cache.On("ByID", block.ID()).Return(nil, false).Once()
+	suite.headers.On("ByBlockID", block.ID()).Return(nil, realstorage.ErrNotFound).Once()
+	suite.snapshot.On("Head").Return(&head, nil)
+	suite.cache.On("ByID", block.Header.ParentID).Return(nil, false)
+	suite.headers.On("ByBlockID", block.Header.ParentID).Return(nil, realstorage.ErrNotFound)
+	suite.cache.On("Add", mock.Anything, mock.Anything).Return(true).Once()
+	suite.sync.On("RequestBlock", block.Header.ParentID).Return()
+
+	proposal := unittest.ProposalFromBlock(&block)
+	err := suite.engine.Process(engine.ReceiveBlocks, originID, proposal)
+	require.NoError(suite.T(), err)
+
+	// once the proposal was pruned from the cache, the filter still reports it as seen, but as
+	// neither the cache nor the storage confirm it, it is processed again
+	suite.cache.On("ByID", block.ID()).Return(nil, false).Twice()
+	suite.headers.On("ByBlockID", block.ID()).Return(nil, realstorage.ErrNotFound).Twice()
+	suite.cache.On("Add", mock.Anything, mock.Anything).Return(true).Once()
+
+	err = suite.engine.Process(engine.ReceiveBlocks, originID, proposal)
+	require.NoError(suite.T(), err)
+
+	suite.cache.AssertNumberOfCalls(suite.T(), "Add", 2)
+	suite.sync.AssertNumberOfCalls(suite.T(), "RequestBlock", 2)
+}
+
 func (suite *Suite) TestHandleProposalWithPendingChildren() {
 
 	originID := unittest.IdentifierFixture()
@@ -0,0 +1,149 @@
+package follower
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// DefaultSeenBlocksCapacity is the default number of blocks remembered by each generation of
+	// the filter of seen blocks.
+	DefaultSeenBlocksCapacity = 100_000
+
+	// DefaultSeenBlocksFalsePositiveRate is the default rate of false positives of each generation
+	// of the filter of seen blocks.
+	DefaultSeenBlocksFalsePositiveRate = 0.01
+)
+
+// seenBlocks is a filter of the IDs of the blocks seen by the follower engine, which can be
+// queried without locking. It consists of two generations of bloom filters: blocks are added to
+// the current generation, which replaces the previous one once it holds its capacity of blocks.
+// Hence, a block is remembered for at least capacity and at most twice capacity additions.
+//
+// Like any bloom filter, it may report blocks, which were never added, at about the false positive
+// rate, while it never misses remembered blocks.
+type seenBlocks struct {
+	capacity    uint64
+	numBits     uint64
+	numHashes   uint64
+	rotateLock  sync.Mutex
+	generations atomic.Value // *seenBlocksGenerations
+}
+
+// seenBlocksGenerations holds the current and the previous generation of the filter.
+type seenBlocksGenerations struct {
+	current  *bloomFilter
+	previous *bloomFilter
+}
+
+// bloomFilter is a bloom filter with bits that are set atomically.
+type bloomFilter struct {
+	words []uint64
+	count uint64 // number of additions, accessed atomically
+}
+
+// newSeenBlocks creates a filter remembering at least the given number of blocks, where each
+// generation reports false positives at the given rate once it holds its capacity of blocks.
+func newSeenBlocks(capacity uint, falsePositiveRate float64) (*seenBlocks, error) {
+	if capacity == 0 {
+		return nil, fmt.Errorf("capacity of seen blocks must be positive")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, fmt.Errorf("false positive rate of seen blocks must be in (0, 1), got %v", falsePositiveRate)
+	}
+
+	// optimal number of bits and hash functions for the capacity and false positive rate
+	numBits := math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	numHashes := math.Max(1, math.Round(numBits/float64(capacity)*math.Ln2))
+
+	s := &seenBlocks{
+		capacity:  uint64(capacity),
+		numBits:   uint64(numBits),
+		numHashes: uint64(numHashes),
+	}
+	s.generations.Store(&seenBlocksGenerations{
+		current:  s.newBloomFilter(),
+		previous: s.newBloomFilter(),
+	})
+	return s, nil
+}
+
+func (s *seenBlocks) newBloomFilter() *bloomFilter {
+	return &bloomFilter{
+		words: make([]uint64, (s.numBits+63)/64),
+	}
+}
+
+// Add remembers the given block. Once the current generation holds its capacity of blocks,
+// it replaces the previous generation, which forgets the blocks only remembered by it.
+func (s *seenBlocks) Add(blockID flow.Identifier) {
+	generations := s.generations.Load().(*seenBlocksGenerations)
+	current := generations.current
+
+	h1, h2 := hashes(blockID)
+	for i := uint64(0); i < s.numHashes; i++ {
+		current.set((h1 + i*h2) % s.numBits)
+	}
+
+	// only the addition reaching the capacity rotates
+	if atomic.AddUint64(&current.count, 1) != s.capacity {
+		return
+	}
+
+	s.rotateLock.Lock()
+	defer s.rotateLock.Unlock()
+	if s.generations.Load().(*seenBlocksGenerations) != generations {
+		return
+	}
+	s.generations.Store(&seenBlocksGenerations{
+		current:  s.newBloomFilter(),
+		previous: current,
+	})
+}
+
+// Has returns true if the given block is remembered, or in case of a false positive.
+func (s *seenBlocks) Has(blockID flow.Identifier) bool {
+	generations := s.generations.Load().(*seenBlocksGenerations)
+	h1, h2 := hashes(blockID)
+	return s.has(generations.current, h1, h2) || s.has(generations.previous, h1, h2)
+}
+
+func (s *seenBlocks) has(filter *bloomFilter, h1 uint64, h2 uint64) bool {
+	for i := uint64(0); i < s.numHashes; i++ {
+		if !filter.isSet((h1 + i*h2) % s.numBits) {
+			return false
+		}
+	}
+	return true
+}
+
+// set sets the given bit of the filter.
+func (f *bloomFilter) set(bit uint64) {
+	word := &f.words[bit/64]
+	mask := uint64(1) << (bit % 64)
+	for {
+		old := atomic.LoadUint64(word)
+		if old&mask != 0 || atomic.CompareAndSwapUint64(word, old, old|mask) {
+			return
+		}
+	}
+}
+
+// isSet returns true if the given bit of the filter is set.
+func (f *bloomFilter) isSet(bit uint64) bool {
+	return atomic.LoadUint64(&f.words[bit/64])&(uint64(1)<<(bit%64)) != 0
+}
+
+// hashes derives the two hashes for double hashing from the given block ID. As block IDs are
+// hashes themselves, their bytes are uniformly distributed already. The second hash is odd, so
+// that it is never zero.
+func hashes(blockID flow.Identifier) (uint64, uint64) {
+	h1 := binary.LittleEndian.Uint64(blockID[0:8])
+	h2 := binary.LittleEndian.Uint64(blockID[8:16]) | 1
+	return h1, h2
+}
@@ -0,0 +1,142 @@
+package follower
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/buffer"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSeenBlocks_Rotation tests that blocks are remembered while they are in the current or the
+// previous generation, and expire once their generation was rotated out.
+func TestSeenBlocks_Rotation(t *testing.T) {
+	const capacity = 100
+	seen, err := newSeenBlocks(capacity, 0.001)
+	require.NoError(t, err)
+
+	// once the first generation is full, it becomes the previous generation
+	first := unittest.IdentifierListFixture(capacity)
+	for _, blockID := range first {
+		seen.Add(blockID)
+	}
+	for _, blockID := range first {
+		assert.True(t, seen.Has(blockID))
+	}
+
+	// the first blocks are remembered until the second generation is full
+	second := unittest.IdentifierListFixture(capacity)
+	for _, blockID := range second[:capacity-1] {
+		seen.Add(blockID)
+	}
+	for _, blockID := range append(first, second[:capacity-1]...) {
+		assert.True(t, seen.Has(blockID))
+	}
+
+	// the first blocks expire with the second rotation, up to false positives
+	seen.Add(second[capacity-1])
+	remembered := 0
+	for _, blockID := range first {
+		if seen.Has(blockID) {
+			remembered++
+		}
+	}
+	assert.LessOrEqual(t, remembered, 5)
+	for _, blockID := range second {
+		assert.True(t, seen.Has(blockID))
+	}
+}
+
+// TestSeenBlocks_FalsePositiveRate tests that a full generation reports blocks, which were never
+// added, at about the configured false positive rate.
+func TestSeenBlocks_FalsePositiveRate(t *testing.T) {
+	const capacity = 10_000
+	const falsePositiveRate = 0.01
+	seen, err := newSeenBlocks(capacity, falsePositiveRate)
+	require.NoError(t, err)
+
+	// stay just below the capacity, so that the generation isn't rotated
+	for _, blockID := range unittest.IdentifierListFixture(capacity - 1) {
+		seen.Add(blockID)
+	}
+
+	const queries = 10_000
+	falsePositives := 0
+	for _, blockID := range unittest.IdentifierListFixture(queries) {
+		if seen.Has(blockID) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/queries, 2*falsePositiveRate)
+}
+
+// TestSeenBlocks_InvalidConfig tests that filters with zero capacity or a false positive rate
+// outside of (0, 1) are rejected.
+func TestSeenBlocks_InvalidConfig(t *testing.T) {
+	_, err := newSeenBlocks(0, DefaultSeenBlocksFalsePositiveRate)
+	assert.Error(t, err)
+	_, err = newSeenBlocks(DefaultSeenBlocksCapacity, 0)
+	assert.Error(t, err)
+	_, err = newSeenBlocks(DefaultSeenBlocksCapacity, 1)
+	assert.Error(t, err)
+}
+
+// BenchmarkSeenBlocks_Has benchmarks the lock-free check of the filter of seen blocks, which
+// proposals for blocks that were not seen yet pass before taking the engine lock.
+func BenchmarkSeenBlocks_Has(b *testing.B) {
+	seen, err := newSeenBlocks(DefaultSeenBlocksCapacity, DefaultSeenBlocksFalsePositiveRate)
+	require.NoError(b, err)
+	for _, blockID := range unittest.IdentifierListFixture(DefaultSeenBlocksCapacity / 2) {
+		seen.Add(blockID)
+	}
+	blockIDs := unittest.IdentifierListFixture(1024)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_ = seen.Has(blockIDs[i%len(blockIDs)])
+			i++
+		}
+	})
+}
+
+// BenchmarkSeenBlocks_Add benchmarks adding blocks to the filter of seen blocks, including rotations.
+func BenchmarkSeenBlocks_Add(b *testing.B) {
+	seen, err := newSeenBlocks(DefaultSeenBlocksCapacity, DefaultSeenBlocksFalsePositiveRate)
+	require.NoError(b, err)
+	blockIDs := unittest.IdentifierListFixture(1024)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			seen.Add(blockIDs[i%len(blockIDs)])
+			i++
+		}
+	})
+}
+
+// BenchmarkPendingBlocks_ByID benchmarks the lookup in the pending blocks cache, which proposals
+// took under the engine lock before the filter of seen blocks was checked.
+func BenchmarkPendingBlocks_ByID(b *testing.B) {
+	pending := buffer.NewPendingBlocks()
+	for i := 0; i < DefaultSeenBlocksCapacity/100; i++ {
+		block := unittest.BlockFixture()
+		pending.Add(flow.ZeroID, &messages.BlockProposal{Header: block.Header, Payload: block.Payload})
+	}
+	blockIDs := unittest.IdentifierListFixture(1024)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = pending.ByID(blockIDs[i%len(blockIDs)])
+			i++
+		}
+	})
+}
@@ -196,7 +196,24 @@ func (e *Engine) Process(channel network.Channel, originID flow.Identifier, mess
 // selection of a collection cluster. Use `filter.Any` if no additional selection
 // is required. Checks integrity of response to make sure that we got entity that we were requesting.
 func (e *Engine) EntityByID(entityID flow.Identifier, selector flow.IdentityFilter) {
-	e.addEntityRequest(entityID, selector, true)
+	e.addEntityRequest(entityID, selector, true, nil)
+}
+
+// EntityByIDWithRetryPolicy works like `EntityByID`, but the entity is re-requested
+// according to the given retry policy rather than the global retry configuration
+// of the engine. The maximum retry interval of the engine still applies.
+func (e *Engine) EntityByIDWithRetryPolicy(entityID flow.Identifier, selector flow.IdentityFilter, policy module.RetryPolicy) {
+	e.addEntityRequest(entityID, selector, true, &policy)
+}
+
+// CancelEntityByID removes the pending item for the given entity or query key.
+// If a request for it was already dispatched, the entity is ignored when the
+// response arrives. Cancelling an unknown entity has no effect.
+func (e *Engine) CancelEntityByID(entityID flow.Identifier) {
+	e.unit.Lock()
+	defer e.unit.Unlock()
+
+	delete(e.items, entityID)
 }
 
 // Query will request data through the request engine backing the interface.
@@ -205,10 +222,10 @@ func (e *Engine) EntityByID(entityID flow.Identifier, selector flow.IdentityFilt
 // over which providers to request data from. Doesn't perform integrity check
 // can be used to get entities without knowing their ID.
 func (e *Engine) Query(key flow.Identifier, selector flow.IdentityFilter) {
-	e.addEntityRequest(key, selector, false)
+	e.addEntityRequest(key, selector, false, nil)
 }
 
-func (e *Engine) addEntityRequest(entityID flow.Identifier, selector flow.IdentityFilter, checkIntegrity bool) {
+func (e *Engine) addEntityRequest(entityID flow.Identifier, selector flow.IdentityFilter, checkIntegrity bool, policy *module.RetryPolicy) {
 	e.unit.Lock()
 	defer e.unit.Unlock()
 
@@ -219,13 +236,18 @@ func (e *Engine) addEntityRequest(entityID flow.Identifier, selector flow.Identi
 	}
 
 	// otherwise, add a new item to the list
+	retryAfter := e.cfg.RetryInitial
+	if policy != nil {
+		retryAfter = policy.InitialDelay
+	}
 	item := &Item{
 		EntityID:       entityID,
 		NumAttempts:    0,
 		LastRequested:  time.Time{},
-		RetryAfter:     e.cfg.RetryInitial,
+		RetryAfter:     retryAfter,
 		ExtraSelector:  selector,
 		checkIntegrity: checkIntegrity,
+		retryPolicy:    policy,
 	}
 	e.items[entityID] = item
 }
@@ -296,7 +318,7 @@ func (e *Engine) dispatchRequest() (bool, error) {
 		}
 
 		// if the item reached maximum amount of retries, drop
-		if item.NumAttempts >= e.cfg.RetryAttempts {
+		if item.NumAttempts >= item.maxAttempts(e.cfg.RetryAttempts) {
 			delete(e.items, entityID)
 			continue
 		}
@@ -335,15 +357,7 @@ func (e *Engine) dispatchRequest() (bool, error) {
 		entityIDs = append(entityIDs, entityID)
 		item.NumAttempts++
 		item.LastRequested = now
-		item.RetryAfter = e.cfg.RetryFunction(item.RetryAfter)
-
-		// make sure the interval is within parameters
-		if item.RetryAfter < e.cfg.RetryInitial {
-			item.RetryAfter = e.cfg.RetryInitial
-		}
-		if item.RetryAfter > e.cfg.RetryMaximum {
-			item.RetryAfter = e.cfg.RetryMaximum
-		}
+		item.RetryAfter = item.nextRetryAfter(e.cfg)
 
 		// if we reached the maximum size for a batch, bail
 		if uint(len(entityIDs)) >= e.cfg.BatchThreshold {
@@ -2,6 +2,7 @@ package requester
 
 import (
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vmihailenco/msgpack"
 
+	flowmodule "github.com/onflow/flow-go/module"
 	module "github.com/onflow/flow-go/module/mock"
 
 	"github.com/onflow/flow-go/engine"
@@ -46,6 +48,242 @@ func TestEntityByID(t *testing.T) {
 	}
 }
 
+func TestEntityByIDWithRetryPolicy(t *testing.T) {
+
+	request := Engine{
+		unit:  engine.NewUnit(),
+		cfg:   Config{RetryInitial: time.Minute},
+		items: make(map[flow.Identifier]*Item),
+	}
+
+	policy := flowmodule.RetryPolicy{
+		InitialDelay: time.Second,
+		Multiplier:   3,
+		MaxAttempts:  5,
+	}
+
+	entityID := unittest.IdentifierFixture()
+	request.EntityByIDWithRetryPolicy(entityID, filter.Any, policy)
+
+	item, contains := request.items[entityID]
+	require.True(t, contains)
+	assert.Equal(t, policy.InitialDelay, item.RetryAfter)
+	assert.Equal(t, policy, *item.retryPolicy)
+	assert.True(t, item.checkIntegrity)
+}
+
+// TestCancelEntityBeforeDispatch verifies that a cancelled item is never
+// included in a request.
+func TestCancelEntityBeforeDispatch(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(16)
+
+	final := &protocol.Snapshot{}
+	final.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+
+	state := &protocol.State{}
+	state.On("Final").Return(final)
+
+	cfg := Config{
+		BatchInterval:  time.Hour,
+		BatchThreshold: 999,
+		RetryInitial:   time.Hour,
+		RetryFunction:  RetryConstant(),
+		RetryAttempts:  3,
+		RetryMaximum:   time.Hour,
+	}
+
+	request := Engine{
+		unit:     engine.NewUnit(),
+		metrics:  metrics.NewNoopCollector(),
+		cfg:      cfg,
+		state:    state,
+		con:      &mocknetwork.Conduit{},
+		items:    make(map[flow.Identifier]*Item),
+		requests: make(map[uint64]*messages.EntityRequest),
+		selector: filter.Any,
+	}
+
+	entityID := unittest.IdentifierFixture()
+	request.EntityByID(entityID, filter.Any)
+	request.CancelEntityByID(entityID)
+	assert.NotContains(t, request.items, entityID)
+
+	// cancelling an unknown entity should be a no-op
+	request.CancelEntityByID(unittest.IdentifierFixture())
+
+	// no request should go out, so the conduit mock would panic on Unicast
+	dispatched, err := request.dispatchRequest()
+	require.NoError(t, err)
+	require.False(t, dispatched)
+}
+
+// TestCancelEntityAfterDispatch verifies that the response for an entity that
+// was cancelled after its request was dispatched is not handled.
+func TestCancelEntityAfterDispatch(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(16)
+	targetID := identities[0].NodeID
+
+	final := &protocol.Snapshot{}
+	final.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+
+	state := &protocol.State{}
+	state.On("Final").Return(final)
+
+	cfg := Config{
+		BatchInterval:  time.Hour,
+		BatchThreshold: 999,
+		RetryInitial:   time.Hour,
+		RetryFunction:  RetryConstant(),
+		RetryAttempts:  3,
+		RetryMaximum:   time.Hour,
+	}
+
+	wanted := unittest.CollectionFixture(1)
+	cancelled := unittest.CollectionFixture(2)
+
+	var req *messages.EntityRequest
+	con := &mocknetwork.Conduit{}
+	con.On("Unicast", mock.Anything, targetID).Run(
+		func(args mock.Arguments) {
+			req = args.Get(0).(*messages.EntityRequest)
+		},
+	).Return(nil).Once()
+
+	var handled []flow.Identifier
+	var mu sync.Mutex
+	request := Engine{
+		unit:     engine.NewUnit(),
+		metrics:  metrics.NewNoopCollector(),
+		cfg:      cfg,
+		state:    state,
+		con:      con,
+		items:    make(map[flow.Identifier]*Item),
+		requests: make(map[uint64]*messages.EntityRequest),
+		selector: filter.HasNodeID(targetID),
+		create:   func() flow.Entity { return &flow.Collection{} },
+		handle: func(_ flow.Identifier, entity flow.Entity) {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, entity.ID())
+		},
+	}
+
+	request.EntityByID(wanted.ID(), filter.Any)
+	request.EntityByID(cancelled.ID(), filter.Any)
+
+	dispatched, err := request.dispatchRequest()
+	require.NoError(t, err)
+	require.True(t, dispatched)
+	require.ElementsMatch(t, req.EntityIDs, []flow.Identifier{wanted.ID(), cancelled.ID()})
+
+	request.CancelEntityByID(cancelled.ID())
+
+	bwanted, _ := msgpack.Marshal(wanted)
+	bcancelled, _ := msgpack.Marshal(cancelled)
+	res := &messages.EntityResponse{
+		Nonce:     req.Nonce,
+		EntityIDs: []flow.Identifier{wanted.ID(), cancelled.ID()},
+		Blobs:     [][]byte{bwanted, bcancelled},
+	}
+	err = request.onEntityResponse(targetID, res)
+	require.NoError(t, err)
+
+	// only the entity that is still wanted should be handled
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(handled) == 1
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []flow.Identifier{wanted.ID()}, handled)
+	mu.Unlock()
+	assert.Empty(t, request.items)
+	con.AssertExpectations(t)
+}
+
+// TestRetryPolicyExhaustion verifies that an item with a bounded retry policy is
+// requested with growing intervals and dropped once its attempts are exhausted,
+// independently of the global retry configuration.
+func TestRetryPolicyExhaustion(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(16)
+
+	final := &protocol.Snapshot{}
+	final.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+
+	state := &protocol.State{}
+	state.On("Final").Return(final)
+
+	cfg := Config{
+		BatchInterval:  time.Hour,
+		BatchThreshold: 999,
+		RetryInitial:   time.Hour,
+		RetryFunction:  RetryConstant(),
+		RetryAttempts:  100,
+		RetryMaximum:   10 * time.Hour,
+	}
+
+	con := &mocknetwork.Conduit{}
+	con.On("Unicast", mock.Anything, mock.Anything).Return(nil)
+
+	request := Engine{
+		unit:     engine.NewUnit(),
+		metrics:  metrics.NewNoopCollector(),
+		cfg:      cfg,
+		state:    state,
+		con:      con,
+		items:    make(map[flow.Identifier]*Item),
+		requests: make(map[uint64]*messages.EntityRequest),
+		selector: filter.Any,
+	}
+
+	policy := flowmodule.RetryPolicy{
+		InitialDelay: time.Second,
+		Multiplier:   2,
+		MaxAttempts:  3,
+	}
+	entityID := unittest.IdentifierFixture()
+	request.EntityByIDWithRetryPolicy(entityID, filter.Any, policy)
+	item := request.items[entityID]
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for attempt := 0; attempt < int(policy.MaxAttempts); attempt++ {
+		// pretend the retry interval has elapsed
+		item.LastRequested = time.Time{}
+		dispatched, err := request.dispatchRequest()
+		require.NoError(t, err)
+		require.True(t, dispatched)
+		assert.Equal(t, uint(attempt+1), item.NumAttempts)
+		assert.Equal(t, expected[attempt], item.RetryAfter)
+	}
+
+	// the policy is exhausted, so the item should be dropped on the next dispatch
+	item.LastRequested = time.Time{}
+	dispatched, err := request.dispatchRequest()
+	require.NoError(t, err)
+	require.False(t, dispatched)
+	assert.NotContains(t, request.items, entityID)
+	con.AssertNumberOfCalls(t, "Unicast", int(policy.MaxAttempts))
+}
+
 func TestDispatchRequestVarious(t *testing.T) {
 
 	identities := unittest.IdentityListFixture(16)
@@ -482,3 +720,31 @@ func TestOriginValidation(t *testing.T) {
 	// handler are called async, but this should be extremely quick
 	require.Eventually(t, func() bool { return called }, 100*time.Millisecond, 10*time.Millisecond)
 }
+
+// TestRetryPolicyJitter verifies that the retry intervals of an item with a jittered
+// retry policy stay within the jitter bounds of the exponential backoff.
+func TestRetryPolicyJitter(t *testing.T) {
+	cfg := Config{
+		RetryInitial:  time.Hour,
+		RetryFunction: RetryConstant(),
+		RetryMaximum:  10 * time.Second,
+	}
+	policy := flowmodule.RetryPolicy{
+		InitialDelay:  time.Second,
+		Multiplier:    2,
+		JitterPercent: 20,
+	}
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+	for attempt, interval := range expected {
+		item := &Item{
+			NumAttempts: uint(attempt + 1),
+			retryPolicy: &policy,
+		}
+		for i := 0; i < 100; i++ {
+			next := item.nextRetryAfter(cfg)
+			assert.GreaterOrEqual(t, int64(next), int64(interval*8/10))
+			assert.LessOrEqual(t, int64(next), int64(interval*12/10))
+		}
+	}
+}
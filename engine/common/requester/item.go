@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/utils/retry"
 )
 
 type Item struct {
@@ -13,4 +15,49 @@ type Item struct {
 	RetryAfter     time.Duration       // interval until request should be retried
 	ExtraSelector  flow.IdentityFilter // additional filters for providers of this entity
 	checkIntegrity bool                // check response integrity using `EntityID`
+	retryPolicy    *module.RetryPolicy // custom retry policy for this entity; nil means global config
+}
+
+// maxAttempts returns the maximum number of request attempts for the item, given
+// the default of the engine configuration.
+func (i *Item) maxAttempts(defaultAttempts uint) uint {
+	if i.retryPolicy == nil || i.retryPolicy.MaxAttempts == 0 {
+		return defaultAttempts
+	}
+	return i.retryPolicy.MaxAttempts
+}
+
+// nextRetryAfter returns the retry interval for the next attempt of the item,
+// bounded by the minimum and maximum intervals of the engine configuration.
+func (i *Item) nextRetryAfter(cfg Config) time.Duration {
+	minimum := cfg.RetryInitial
+	var next time.Duration
+	if i.retryPolicy == nil {
+		next = cfg.RetryFunction(i.RetryAfter)
+	} else {
+		// the item starts out with the interval of the first retry, so after
+		// the n-th attempt, it waits for the interval of the (n+1)-th retry
+		minimum = i.retryPolicy.InitialDelay
+		next, _ = itemRetryPolicy(*i.retryPolicy, cfg).Next(i.NumAttempts+1, 0)
+		if i.retryPolicy.JitterPercent > 0 {
+			// keep jittered intervals spread around the maximum interval, so that
+			// items requested at the same time are not retried in lockstep
+			return next
+		}
+	}
+
+	// make sure the interval is within parameters
+	if next < minimum {
+		next = minimum
+	}
+	if next > cfg.RetryMaximum {
+		next = cfg.RetryMaximum
+	}
+	return next
+}
+
+// itemRetryPolicy returns the backoff policy for an item with a custom retry policy.
+func itemRetryPolicy(policy module.RetryPolicy, cfg Config) retry.Policy {
+	backoff := retry.Exponential(policy.InitialDelay, policy.Multiplier, cfg.RetryMaximum)
+	return retry.WithJitter(policy.JitterPercent, backoff)
 }
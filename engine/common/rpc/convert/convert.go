@@ -1,7 +1,7 @@
 package convert
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -368,28 +368,25 @@ func MessagesToIdentifiers(l [][]byte) []flow.Identifier {
 	return results
 }
 
-// SnapshotToBytes converts a `protocol.Snapshot` to bytes, encoded as JSON
+// SnapshotToBytes converts a `protocol.Snapshot` to bytes, using the versioned
+// binary snapshot encoding
 func SnapshotToBytes(snapshot protocol.Snapshot) ([]byte, error) {
-	serializable, err := inmem.FromSnapshot(snapshot)
+	var buf bytes.Buffer
+	err := inmem.EncodeSnapshot(&buf, snapshot)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := json.Marshal(serializable.Encodable())
-	if err != nil {
-		return nil, err
-	}
-
-	return data, nil
+	return buf.Bytes(), nil
 }
 
-// BytesToInmemSnapshot converts an array of bytes to `inmem.Snapshot`
-func BytesToInmemSnapshot(bytes []byte) (*inmem.Snapshot, error) {
-	var encodable inmem.EncodableSnapshot
-	err := json.Unmarshal(bytes, &encodable)
+// BytesToInmemSnapshot converts an array of bytes to `inmem.Snapshot`. Both the
+// binary snapshot encoding and the legacy JSON encoding are accepted.
+func BytesToInmemSnapshot(data []byte) (*inmem.Snapshot, error) {
+	snapshot, err := inmem.DecodeSnapshot(bytes.NewReader(data))
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal decoded snapshot: %w", err)
+		return nil, fmt.Errorf("could not decode snapshot: %w", err)
 	}
 
-	return inmem.SnapshotFromEncodable(encodable), nil
+	return snapshot, nil
 }
@@ -1,14 +1,18 @@
 package approvals
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/module/trace"
 )
 
 // ApprovalCollector is responsible for distributing work to chunk collectorTree,
@@ -16,13 +20,16 @@ import (
 // creating and submitting seal candidates once signatures for every chunk are aggregated.
 type ApprovalCollector struct {
 	log                  zerolog.Logger
-	incorporatedBlock    *flow.Header                    // block that incorporates execution result
-	executedBlock        *flow.Header                    // block that was executed
-	incorporatedResult   *flow.IncorporatedResult        // incorporated result that is being sealed
-	chunkCollectors      []*ChunkApprovalCollector       // slice of chunk collectorTree that is created on construction and doesn't change
-	aggregatedSignatures *AggregatedSignatures           // aggregated signature for each chunk
-	seals                mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
-	numberOfChunks       uint64                          // number of chunks for execution result, remains constant
+	incorporatedBlock    *flow.Header                       // block that incorporates execution result
+	executedBlock        *flow.Header                       // block that was executed
+	incorporatedResult   *flow.IncorporatedResult           // incorporated result that is being sealed
+	chunkCollectors      []*ChunkApprovalCollector          // slice of chunk collectorTree that is created on construction and doesn't change
+	aggregatedSignatures *AggregatedSignatures              // aggregated signature for each chunk
+	seals                mempool.IncorporatedResultSeals    // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
+	numberOfChunks       uint64                             // number of chunks for execution result, remains constant
+	approvers            map[flow.Identifier]*flow.Identity // authorized verifiers, used to compute the approving stake of the seal
+	tracer               module.Tracer                      // used to trace the construction of seal candidates
+	requireSPoCKs        bool                               // whether every contributing approval must provide a SPoCK proof for the seal
 }
 
 func NewApprovalCollector(
@@ -31,13 +38,16 @@ func NewApprovalCollector(
 	incorporatedBlock *flow.Header,
 	executedBlock *flow.Header,
 	assignment *chunks.Assignment,
+	approvers map[flow.Identifier]*flow.Identity,
 	seals mempool.IncorporatedResultSeals,
-	requiredApprovalsForSealConstruction uint,
+	sufficiency ApprovalSufficiency,
+	requireSPoCKs bool,
+	tracer module.Tracer,
 ) (*ApprovalCollector, error) {
 	chunkCollectors := make([]*ChunkApprovalCollector, 0, result.Result.Chunks.Len())
 	for _, chunk := range result.Result.Chunks {
 		chunkAssignment := assignment.Verifiers(chunk).Lookup()
-		collector := NewChunkApprovalCollector(chunkAssignment, requiredApprovalsForSealConstruction)
+		collector := NewChunkApprovalCollector(chunkAssignment, approvers, sufficiency)
 		chunkCollectors = append(chunkCollectors, collector)
 	}
 
@@ -59,11 +69,14 @@ func NewApprovalCollector(
 		chunkCollectors:      chunkCollectors,
 		aggregatedSignatures: aggSigs,
 		seals:                seals,
+		approvers:            approvers,
+		tracer:               tracer,
+		requireSPoCKs:        requireSPoCKs,
 	}
 
 	// The following code implements a TEMPORARY SHORTCUT: In case no approvals are required
 	// to seal an incorporated result, we seal right away when creating the ApprovalCollector.
-	if requiredApprovalsForSealConstruction == 0 {
+	if collector.sufficientWithoutApprovals() {
 		// The high-level logic is: as soon as we have collected enough approvals, we aggregate
 		// them and store them in collector.aggregatedSignatures. If we don't require any signatures,
 		// this condition is satisfied right away. Hence, we add aggregated signature for each chunk.
@@ -82,6 +95,16 @@ func NewApprovalCollector(
 	return &collector, nil
 }
 
+// sufficientWithoutApprovals returns true if every chunk can be sealed without any approvals.
+func (c *ApprovalCollector) sufficientWithoutApprovals() bool {
+	for _, chunkCollector := range c.chunkCollectors {
+		if !chunkCollector.IsSufficient() {
+			return false
+		}
+	}
+	return true
+}
+
 // IncorporatedBlockID returns the ID of block which incorporates execution result
 func (c *ApprovalCollector) IncorporatedBlockID() flow.Identifier {
 	return c.incorporatedResult.IncorporatedBlockID
@@ -97,22 +120,47 @@ func (c *ApprovalCollector) IncorporatedResult() *flow.IncorporatedResult {
 	return c.incorporatedResult
 }
 
+// SealResult constructs a seal candidate for the incorporated result from the aggregated approvals,
+// and adds it to the seals mempool.
 func (c *ApprovalCollector) SealResult() error {
+	_, err := c.sealResult(false)
+	return err
+}
+
+// EmergencySealResult constructs a seal candidate for the incorporated result in emergency mode, i.e.
+// regardless of whether the approvals are sufficient, and adds it to the seals mempool. The seal is
+// marked as emergency sealed. Returns true if the seal was added, and false if a seal for the
+// incorporated result already existed in the mempool.
+func (c *ApprovalCollector) EmergencySealResult() (bool, error) {
+	return c.sealResult(true)
+}
+
+func (c *ApprovalCollector) sealResult(emergency bool) (bool, error) {
 	// get final state of execution result
 	finalState, err := c.incorporatedResult.Result.FinalStateCommitment()
 	if err != nil {
 		// message correctness should have been checked before: failure here is an internal implementation bug
-		return fmt.Errorf("failed to get final state commitment from Execution Result: %w", err)
+		return false, fmt.Errorf("failed to get final state commitment from Execution Result: %w", err)
 	}
 
-	// TODO: Check SPoCK proofs
+	// TODO: verify the SPoCK proofs of the approvals against the SPoCK proofs of the executors
+	//       for the same chunks, using crypto.VerifySPoCKPair
+
+	// SPoCK proofs are only included in seals if they are required, as they change the seal ID, so
+	// seals keep the IDs of seals constructed by nodes not collecting SPoCK proofs otherwise
+	aggregatedSigs := c.aggregatedSignatures.Collect()
+	if !c.requireSPoCKs {
+		for i := range aggregatedSigs {
+			aggregatedSigs[i].Spocks = nil
+		}
+	}
 
 	// generate & store seal
 	seal := &flow.Seal{
 		BlockID:                c.incorporatedResult.Result.BlockID,
 		ResultID:               c.incorporatedResult.Result.ID(),
 		FinalState:             finalState,
-		AggregatedApprovalSigs: c.aggregatedSignatures.Collect(),
+		AggregatedApprovalSigs: aggregatedSigs,
 	}
 
 	// Adding a seal that already exists in the mempool is a NoOp. But to reduce log
@@ -121,25 +169,56 @@ func (c *ApprovalCollector) SealResult() error {
 		IncorporatedResult: c.incorporatedResult,
 		Seal:               seal,
 		Header:             c.executedBlock,
+		ApprovingStake:     c.approvingStake(seal.AggregatedApprovalSigs),
+		EmergencySealed:    emergency,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to store IncorporatedResultSeal in mempool: %w", err)
+		return false, fmt.Errorf("failed to store IncorporatedResultSeal in mempool: %w", err)
 	}
 	if added {
+		span, _, isSampled := c.tracer.StartBlockSpan(context.Background(), seal.BlockID, trace.CONSealingSealCandidate)
+		if isSampled {
+			span.LogFields(
+				log.String("result_id", seal.ResultID.String()),
+				log.String("incorporated_block_id", c.IncorporatedBlockID().String()),
+			)
+		}
+		span.Finish()
+
 		c.log.Info().
 			Str("executed_block_id", seal.BlockID.String()).
 			Uint64("executed_block_height", c.executedBlock.Height).
 			Str("result_id", seal.ResultID.String()).
 			Str("incorporating_block", c.IncorporatedBlockID().String()).
+			Bool("emergency_sealed", emergency).
 			Msg("added candidate seal to IncorporatedResultSeals mempool")
 	}
-	return nil
+	return added, nil
+}
+
+// approvingStake returns the total stake of the distinct verifiers that signed any of the given
+// aggregated signatures.
+func (c *ApprovalCollector) approvingStake(sigs []flow.AggregatedSignature) uint64 {
+	signers := make(map[flow.Identifier]struct{})
+	stake := uint64(0)
+	for _, sig := range sigs {
+		for _, signerID := range sig.SignerIDs {
+			if _, ok := signers[signerID]; ok {
+				continue
+			}
+			signers[signerID] = struct{}{}
+			if identity, ok := c.approvers[signerID]; ok {
+				stake += identity.Stake
+			}
+		}
+	}
+	return stake
 }
 
 // ProcessApproval performs processing of result approvals and bookkeeping of aggregated signatures
 // for every chunk. Triggers sealing of execution result when processed last result approval needed for sealing.
 // Returns:
-// - engine.InvalidInputError - result approval is invalid
+// - engine.InvalidInputError - result approval is invalid, or has no SPoCK proof while SPoCK proofs are required
 // - exception in case of any other error, usually this is not expected
 // - nil on success
 func (c *ApprovalCollector) ProcessApproval(approval *flow.ResultApproval) error {
@@ -152,6 +231,11 @@ func (c *ApprovalCollector) ProcessApproval(approval *flow.ResultApproval) error
 	if chunkIndex >= uint64(len(c.chunkCollectors)) {
 		return engine.NewInvalidInputErrorf("approval collector chunk index out of range: %v", chunkIndex)
 	}
+	// if SPoCK proofs are required, approvals without SPoCK proof must not contribute to the aggregated
+	// signature of the chunk, so that no seal is constructed with a missing SPoCK proof
+	if c.requireSPoCKs && len(approval.Body.Spock) == 0 {
+		return engine.NewInvalidInputErrorf("approval for chunk %d has no SPoCK proof", chunkIndex)
+	}
 	// there is no need to process approval if we have already enough info for sealing
 	if c.aggregatedSignatures.HasSignature(chunkIndex) {
 		return nil
@@ -10,6 +10,7 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	mempool "github.com/onflow/flow-go/module/mempool/mock"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -34,7 +35,7 @@ func (s *ApprovalCollectorTestSuite) SetupTest() {
 	s.sealsPL = &mempool.IncorporatedResultSeals{}
 
 	var err error
-	s.collector, err = NewApprovalCollector(unittest.Logger(), s.IncorporatedResult, &s.IncorporatedBlock, &s.Block, s.ChunksAssignment, s.sealsPL, uint(len(s.AuthorizedVerifiers)))
+	s.collector, err = NewApprovalCollector(unittest.Logger(), s.IncorporatedResult, &s.IncorporatedBlock, &s.Block, s.ChunksAssignment, s.AuthorizedVerifiers, s.sealsPL, CountThreshold{N: uint(len(s.AuthorizedVerifiers))}, false, trace.NewNoopTracer())
 	require.NoError(s.T(), err)
 }
 
@@ -50,6 +51,11 @@ func (s *ApprovalCollectorTestSuite) TestProcessApproval_ValidApproval() {
 // met for each chunk.
 func (s *ApprovalCollectorTestSuite) TestProcessApproval_SealResult() {
 	expectedSignatures := make([]flow.AggregatedSignature, s.IncorporatedResult.Result.Chunks.Len())
+	// every authorized verifier approves every chunk, hence each of them contributes its stake once
+	expectedStake := uint64(0)
+	for _, identity := range s.AuthorizedVerifiers {
+		expectedStake += identity.Stake
+	}
 	s.sealsPL.On("Add", mock.Anything).Run(
 		func(args mock.Arguments) {
 			seal := args.Get(0).(*flow.IncorporatedResultSeal)
@@ -57,6 +63,11 @@ func (s *ApprovalCollectorTestSuite) TestProcessApproval_SealResult() {
 			require.Equal(s.T(), s.IncorporatedResult.Result.ID(), seal.Seal.ResultID)
 			require.Equal(s.T(), s.IncorporatedResult.Result.BlockID, seal.Seal.BlockID)
 			require.Equal(s.T(), seal.Seal.BlockID, seal.Header.ID())
+			require.Equal(s.T(), expectedStake, seal.ApprovingStake)
+			// SPoCK proofs are not included in seals, unless they are required
+			for _, sig := range seal.Seal.AggregatedApprovalSigs {
+				require.Empty(s.T(), sig.Spocks)
+			}
 		},
 	).Return(true, nil).Once()
 
@@ -65,14 +76,52 @@ func (s *ApprovalCollectorTestSuite) TestProcessApproval_SealResult() {
 		sigCollector := NewSignatureCollector()
 		for verID := range s.AuthorizedVerifiers {
 			approval := unittest.ResultApprovalFixture(unittest.WithChunk(chunk.Index), unittest.WithApproverID(verID))
+			approval.Body.Spock = unittest.SignatureFixture()
+			err = s.collector.ProcessApproval(approval)
+			require.NoError(s.T(), err)
+			sigCollector.Add(approval.Body.ApproverID, approval.Body.AttestationSignature, approval.Body.Spock)
+		}
+		expectedSignatures[i] = sigCollector.ToAggregatedSignature()
+	}
+
+	s.sealsPL.AssertExpectations(s.T())
+}
+
+// TestProcessApproval_RequiredSPoCKs tests that, if SPoCK proofs are required, approvals without SPoCK proof
+// are rejected and don't contribute to a seal, while the SPoCK proofs of the contributing approvals are
+// collected into the seal.
+func (s *ApprovalCollectorTestSuite) TestProcessApproval_RequiredSPoCKs() {
+	var err error
+	s.collector, err = NewApprovalCollector(unittest.Logger(), s.IncorporatedResult, &s.IncorporatedBlock, &s.Block, s.ChunksAssignment, s.AuthorizedVerifiers, s.sealsPL, CountThreshold{N: uint(len(s.AuthorizedVerifiers))}, true, trace.NewNoopTracer())
+	require.NoError(s.T(), err)
+
+	expectedSignatures := make([]flow.AggregatedSignature, s.IncorporatedResult.Result.Chunks.Len())
+	var sealed *flow.IncorporatedResultSeal
+	s.sealsPL.On("Add", mock.Anything).Run(
+		func(args mock.Arguments) {
+			sealed = args.Get(0).(*flow.IncorporatedResultSeal)
+		},
+	).Return(true, nil).Once()
+
+	for i, chunk := range s.Chunks {
+		sigCollector := NewSignatureCollector()
+		for verID := range s.AuthorizedVerifiers {
+			// an approval without SPoCK proof is rejected
+			approval := unittest.ResultApprovalFixture(unittest.WithChunk(chunk.Index), unittest.WithApproverID(verID))
+			err = s.collector.ProcessApproval(approval)
+			require.True(s.T(), engine.IsInvalidInputError(err))
+
+			approval.Body.Spock = unittest.SignatureFixture()
 			err = s.collector.ProcessApproval(approval)
 			require.NoError(s.T(), err)
-			sigCollector.Add(approval.Body.ApproverID, approval.Body.AttestationSignature)
+			sigCollector.Add(approval.Body.ApproverID, approval.Body.AttestationSignature, approval.Body.Spock)
 		}
 		expectedSignatures[i] = sigCollector.ToAggregatedSignature()
+		require.Len(s.T(), expectedSignatures[i].Spocks, len(s.AuthorizedVerifiers))
 	}
 
 	s.sealsPL.AssertExpectations(s.T())
+	require.Equal(s.T(), expectedSignatures, sealed.Seal.AggregatedApprovalSigs)
 }
 
 // TestProcessApproval_InvalidChunk tests that approval with invalid chunk index will be rejected without
@@ -0,0 +1,68 @@
+package approvals
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ApprovalSufficiency decides whether the approvals collected for a chunk are sufficient
+// for constructing a candidate seal.
+type ApprovalSufficiency interface {
+	// IsSufficient returns true if the approvals by the given signers are sufficient for sealing
+	// a chunk, which is assigned to the given verifiers. Signers which are not among the
+	// assigned verifiers are ignored.
+	IsSufficient(assigned flow.IdentityList, signers flow.IdentifierList) bool
+}
+
+// CountThreshold requires approvals from at least N distinct assigned verifiers.
+// With N equal to 0, chunks are sufficiently approved without any approvals.
+type CountThreshold struct {
+	N uint
+}
+
+var _ ApprovalSufficiency = CountThreshold{}
+
+func (c CountThreshold) IsSufficient(assigned flow.IdentityList, signers flow.IdentifierList) bool {
+	if c.N == 0 {
+		return true
+	}
+	assignment := assigned.Lookup()
+	count := uint(0)
+	for _, signerID := range signers {
+		if _, ok := assignment[signerID]; ok {
+			count++
+		}
+	}
+	return count >= c.N
+}
+
+// StakeFraction requires approvals from assigned verifiers, which together hold at least the
+// given fraction of the total stake of all assigned verifiers. Assigned verifiers with zero stake
+// are excluded from the total stake. If no assigned verifier has positive stake, the chunk can
+// only be sealed with a fraction of 0.
+type StakeFraction struct {
+	Fraction float64
+}
+
+var _ ApprovalSufficiency = StakeFraction{}
+
+func (s StakeFraction) IsSufficient(assigned flow.IdentityList, signers flow.IdentifierList) bool {
+	if s.Fraction <= 0 {
+		return true
+	}
+	signed := signers.Lookup()
+	totalStake := uint64(0)
+	approvingStake := uint64(0)
+	for _, identity := range assigned {
+		if identity.Stake == 0 {
+			continue
+		}
+		totalStake += identity.Stake
+		if _, ok := signed[identity.NodeID]; ok {
+			approvingStake += identity.Stake
+		}
+	}
+	if totalStake == 0 {
+		return false
+	}
+	return float64(approvingStake) >= s.Fraction*float64(totalStake)
+}
@@ -0,0 +1,100 @@
+package approvals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// unequalStakeVerifiers returns four verifiers with stakes 1000, 800, 200 and 100.
+func unequalStakeVerifiers() flow.IdentityList {
+	return flow.IdentityList{
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification), unittest.WithStake(1000)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification), unittest.WithStake(800)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification), unittest.WithStake(200)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification), unittest.WithStake(100)),
+	}
+}
+
+// TestCountThreshold tests that approvals by at least N distinct assigned verifiers are sufficient,
+// regardless of their stake.
+func TestCountThreshold(t *testing.T) {
+	verifiers := unequalStakeVerifiers()
+	threshold := CountThreshold{N: 3}
+
+	assert.False(t, threshold.IsSufficient(verifiers, verifiers[:2].NodeIDs()))
+	assert.True(t, threshold.IsSufficient(verifiers, verifiers[1:].NodeIDs()))
+	// signers which were not assigned are not counted
+	notAssigned := unittest.IdentifierFixture()
+	assert.False(t, threshold.IsSufficient(verifiers, append(verifiers[:2].NodeIDs(), notAssigned)))
+
+	assert.True(t, CountThreshold{N: 0}.IsSufficient(verifiers, nil))
+}
+
+// TestStakeFraction tests that approvals are sufficient once the approving verifiers hold the required
+// fraction of the stake of all assigned verifiers.
+func TestStakeFraction(t *testing.T) {
+	verifiers := unequalStakeVerifiers()
+	fraction := StakeFraction{Fraction: 2.0 / 3.0}
+
+	// the two large verifiers hold 1800 out of 2100 stake
+	assert.True(t, fraction.IsSufficient(verifiers, verifiers[:2].NodeIDs()))
+	// the three small verifiers hold only 1100 out of 2100 stake
+	assert.False(t, fraction.IsSufficient(verifiers, verifiers[1:].NodeIDs()))
+	// signers which were not assigned don't contribute stake
+	assert.False(t, fraction.IsSufficient(verifiers, flow.IdentifierList{verifiers[1].NodeID, unittest.IdentifierFixture()}))
+
+	assert.True(t, StakeFraction{Fraction: 0}.IsSufficient(verifiers, nil))
+}
+
+// TestStakeFraction_ZeroStake tests that assigned verifiers with zero stake are excluded from the
+// total stake.
+func TestStakeFraction_ZeroStake(t *testing.T) {
+	verifiers := unequalStakeVerifiers()
+	fraction := StakeFraction{Fraction: 2.0 / 3.0}
+
+	// with two additional zero-stake verifiers assigned, 1800 out of 2100 stake is still sufficient
+	zeroStake := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleVerification), unittest.WithStake(0))
+	assigned := append(verifiers.Copy(), zeroStake...)
+	assert.True(t, fraction.IsSufficient(assigned, verifiers[:2].NodeIDs()))
+	assert.False(t, fraction.IsSufficient(assigned, zeroStake.NodeIDs()))
+
+	// without any positive stake, approvals are never sufficient
+	assert.False(t, fraction.IsSufficient(zeroStake, zeroStake.NodeIDs()))
+}
+
+// TestChunkApprovalCollector_StakeFraction tests that the chunk collector delegates to the sufficiency
+// strategy, and provides the aggregated signature once two large verifiers approved the chunk,
+// but not after three small verifiers approved it.
+func TestChunkApprovalCollector_StakeFraction(t *testing.T) {
+	verifiers := unequalStakeVerifiers()
+	approvers := verifiers.Lookup()
+	assignment := flow.IdentifierList(verifiers.NodeIDs()).Lookup()
+	chunkIndex := uint64(0)
+
+	t.Run("two large approvers", func(t *testing.T) {
+		collector := NewChunkApprovalCollector(assignment, approvers, StakeFraction{Fraction: 2.0 / 3.0})
+		require.False(t, collector.IsSufficient())
+
+		_, collected := collector.ProcessApproval(unittest.ResultApprovalFixture(unittest.WithChunk(chunkIndex), unittest.WithApproverID(verifiers[0].NodeID)))
+		require.False(t, collected)
+		aggregatedSig, collected := collector.ProcessApproval(unittest.ResultApprovalFixture(unittest.WithChunk(chunkIndex), unittest.WithApproverID(verifiers[1].NodeID)))
+		require.True(t, collected)
+		assert.ElementsMatch(t, verifiers[:2].NodeIDs(), aggregatedSig.SignerIDs)
+		assert.True(t, collector.IsSufficient())
+	})
+
+	t.Run("three small approvers", func(t *testing.T) {
+		collector := NewChunkApprovalCollector(assignment, approvers, StakeFraction{Fraction: 2.0 / 3.0})
+		for _, verifier := range verifiers[1:] {
+			_, collected := collector.ProcessApproval(unittest.ResultApprovalFixture(unittest.WithChunk(chunkIndex), unittest.WithApproverID(verifier.NodeID)))
+			require.False(t, collected)
+		}
+		assert.False(t, collector.IsSufficient())
+		assert.Equal(t, flow.IdentifierList{verifiers[0].NodeID}, collector.GetMissingSigners())
+	})
+}
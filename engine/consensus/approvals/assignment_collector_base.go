@@ -7,6 +7,8 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -20,21 +22,70 @@ import (
 type AssignmentCollectorBase struct {
 	log zerolog.Logger
 
-	workerPool                           *workerpool.WorkerPool
-	assigner                             module.ChunkAssigner            // component for computing chunk assignments
-	state                                protocol.State                  // protocol state
-	headers                              storage.Headers                 // used to query headers from storage
-	verifier                             module.Verifier                 // used to validate result approvals
-	seals                                mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
-	approvalConduit                      network.Conduit                 // used to request missing approvals from verification nodes
-	requestTracker                       *RequestTracker                 // used to keep track of number of approval requests, and blackout periods, by chunk
-	requiredApprovalsForSealConstruction uint                            // number of approvals that are required for each chunk to be sealed
+	workerPool          *workerpool.WorkerPool
+	assigner            module.ChunkAssigner            // component for computing chunk assignments
+	state               protocol.State                  // protocol state
+	headers             storage.Headers                 // used to query headers from storage
+	verifier            module.Verifier                 // used to validate result approvals
+	seals               mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
+	approvalConduit     network.Conduit                 // used to request missing approvals from verification nodes
+	requestTracker      *RequestTracker                 // used to keep track of number of approval requests, and blackout periods, by chunk
+	approvalSufficiency ApprovalSufficiency             // decides if the approvals for a chunk are sufficient for it to be sealed
+	tracer              module.Tracer                   // used to trace the construction of seal candidates
+	metrics             module.ConsensusMetrics         // used to report seal candidates constructed in emergency mode
+
+	emergencySealingThreshold uint64 // min height difference between the latest finalized block and the block incorporating a result, for emergency sealing the result
+	requireSPoCKs             bool   // whether seal candidates are only constructed, if every contributing approval provides a SPoCK proof
 
 	result        *flow.ExecutionResult // execution result
 	resultID      flow.Identifier       // ID of execution result
 	executedBlock *flow.Header          // header of the executed block
 }
 
+// AssignmentCollectorOption configures optional behaviour of an assignment collector.
+type AssignmentCollectorOption func(*AssignmentCollectorBase)
+
+// WithTracer sets the tracer, which traces the construction of seal candidates as part of the
+// trace of the executed block. By default, nothing is traced.
+func WithTracer(tracer module.Tracer) AssignmentCollectorOption {
+	return func(cb *AssignmentCollectorBase) {
+		cb.tracer = tracer
+	}
+}
+
+// WithApprovalSufficiency sets the strategy, which decides if the approvals for a chunk are sufficient
+// for constructing a seal. By default, approvals are counted against `requiredApprovalsForSealConstruction`.
+func WithApprovalSufficiency(sufficiency ApprovalSufficiency) AssignmentCollectorOption {
+	return func(cb *AssignmentCollectorBase) {
+		cb.approvalSufficiency = sufficiency
+	}
+}
+
+// WithConsensusMetrics sets the metrics, to which seal candidates constructed in emergency mode are
+// reported. By default, nothing is reported.
+func WithConsensusMetrics(metrics module.ConsensusMetrics) AssignmentCollectorOption {
+	return func(cb *AssignmentCollectorBase) {
+		cb.metrics = metrics
+	}
+}
+
+// WithEmergencySealingThreshold sets the min number of blocks between the block incorporating a result and
+// the latest finalized block, for the result to be emergency sealed. By default, DefaultEmergencySealingThreshold
+// is used.
+func WithEmergencySealingThreshold(threshold uint64) AssignmentCollectorOption {
+	return func(cb *AssignmentCollectorBase) {
+		cb.emergencySealingThreshold = threshold
+	}
+}
+
+// WithRequiredSPoCKs sets whether seal candidates are only constructed, if every approval contributing
+// to the seal provides a SPoCK proof. By default, SPoCK proofs are collected but not required.
+func WithRequiredSPoCKs(required bool) AssignmentCollectorOption {
+	return func(cb *AssignmentCollectorBase) {
+		cb.requireSPoCKs = required
+	}
+}
+
 func NewAssignmentCollectorBase(logger zerolog.Logger,
 	workerPool *workerpool.WorkerPool,
 	result *flow.ExecutionResult,
@@ -46,27 +97,37 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
 	requiredApprovalsForSealConstruction uint,
+	opts ...AssignmentCollectorOption,
 ) (AssignmentCollectorBase, error) {
 	executedBlock, err := headers.ByBlockID(result.BlockID)
 	if err != nil {
 		return AssignmentCollectorBase{}, err
 	}
 
-	return AssignmentCollectorBase{
-		log:                                  logger,
-		workerPool:                           workerPool,
-		assigner:                             assigner,
-		state:                                state,
-		headers:                              headers,
-		verifier:                             sigVerifier,
-		seals:                                seals,
-		approvalConduit:                      approvalConduit,
-		requestTracker:                       requestTracker,
-		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
-		result:                               result,
-		resultID:                             result.ID(),
-		executedBlock:                        executedBlock,
-	}, nil
+	base := AssignmentCollectorBase{
+		log:                 logger,
+		workerPool:          workerPool,
+		assigner:            assigner,
+		state:               state,
+		headers:             headers,
+		verifier:            sigVerifier,
+		seals:               seals,
+		approvalConduit:     approvalConduit,
+		requestTracker:      requestTracker,
+		approvalSufficiency: CountThreshold{N: requiredApprovalsForSealConstruction},
+		tracer:              trace.NewNoopTracer(),
+		metrics:             metrics.NewNoopCollector(),
+		result:              result,
+		resultID:            result.ID(),
+		executedBlock:       executedBlock,
+
+		emergencySealingThreshold: DefaultEmergencySealingThreshold,
+	}
+	for _, apply := range opts {
+		apply(&base)
+	}
+
+	return base, nil
 }
 
 func (cb *AssignmentCollectorBase) BlockID() flow.Identifier      { return cb.result.BlockID }
@@ -78,6 +78,12 @@ func (ac *CachingAssignmentCollector) ProcessApproval(approval *flow.ResultAppro
 		return engine.NewInvalidInputErrorf("result approval for invalid block, expected (%x) vs (%x)",
 			ac.BlockID(), approval.Body.BlockID)
 	}
+	// the result is known, so approvals for chunks it doesn't have are rejected right away,
+	// instead of being cached until the collector starts verifying approvals
+	chunkIndex := approval.Body.ChunkIndex
+	if chunkIndex >= uint64(ac.result.Chunks.Len()) {
+		return engine.NewInvalidInputErrorf("chunk index out of range: %v", chunkIndex)
+	}
 
 	// if we have this approval cached already, no need to process it again
 	approvalCacheID := approval.Body.PartialID()
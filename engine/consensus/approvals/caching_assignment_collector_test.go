@@ -57,12 +57,21 @@ func (s *CachingAssignmentCollectorTestSuite) TestProcessApproval() {
 	require.Error(s.T(), err)
 	require.True(s.T(), engine.IsInvalidInputError(err))
 
+	// approvals for chunks, which the result doesn't have, are rejected
+	approval = unittest.ResultApprovalFixture(
+		unittest.WithBlockID(s.executedBlock.ID()),
+		unittest.WithExecutionResultID(s.result.ID()),
+		unittest.WithChunk(uint64(s.result.Chunks.Len())))
+	err = s.collector.ProcessApproval(approval)
+	require.Error(s.T(), err)
+	require.True(s.T(), engine.IsInvalidInputError(err))
+
 	var expected []*flow.ResultApproval
 	for i := 0; i < 5; i++ {
 		approval := unittest.ResultApprovalFixture(
 			unittest.WithBlockID(s.executedBlock.ID()),
 			unittest.WithExecutionResultID(s.result.ID()),
-			unittest.WithChunk(uint64(i)))
+			unittest.WithChunk(uint64(i%s.result.Chunks.Len())))
 		err := s.collector.ProcessApproval(approval)
 		require.NoError(s.T(), err)
 		expected = append(expected, approval)
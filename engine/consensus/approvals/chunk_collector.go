@@ -9,29 +9,50 @@ import (
 // ChunkApprovalCollector implements logic for checking chunks against assignments as
 // well as accumulating signatures of already checked approvals.
 type ChunkApprovalCollector struct {
-	assignment                           map[flow.Identifier]struct{} // set of verifiers that were assigned to current chunk
-	chunkApprovals                       SignatureCollector           // accumulator of signatures for current collector
-	lock                                 sync.Mutex                   // lock to protect `chunkApprovals`
-	requiredApprovalsForSealConstruction uint                         // number of approvals that are required for each chunk to be sealed
+	assignment     map[flow.Identifier]struct{} // set of verifiers that were assigned to current chunk
+	assigned       flow.IdentityList            // identities of the assigned verifiers, which are authorized approvers
+	chunkApprovals SignatureCollector           // accumulator of signatures for current collector
+	lock           sync.Mutex                   // lock to protect `chunkApprovals`
+	sufficiency    ApprovalSufficiency          // decides if the approvals are sufficient for the chunk to be sealed
 }
 
-func NewChunkApprovalCollector(assignment map[flow.Identifier]struct{}, requiredApprovalsForSealConstruction uint) *ChunkApprovalCollector {
+// NewChunkApprovalCollector creates a collector for the approvals of a chunk with the given assignment.
+// The identities of the assigned verifiers are taken from the given authorized approvers.
+func NewChunkApprovalCollector(
+	assignment map[flow.Identifier]struct{},
+	approvers map[flow.Identifier]*flow.Identity,
+	sufficiency ApprovalSufficiency,
+) *ChunkApprovalCollector {
+	assigned := make(flow.IdentityList, 0, len(assignment))
+	for verifierID := range assignment {
+		if identity, ok := approvers[verifierID]; ok {
+			assigned = append(assigned, identity)
+		}
+	}
 	return &ChunkApprovalCollector{
-		assignment:                           assignment,
-		chunkApprovals:                       NewSignatureCollector(),
-		lock:                                 sync.Mutex{},
-		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
+		assignment:     assignment,
+		assigned:       assigned,
+		chunkApprovals: NewSignatureCollector(),
+		lock:           sync.Mutex{},
+		sufficiency:    sufficiency,
 	}
 }
 
+// IsSufficient returns true if the approvals processed so far are sufficient for the chunk to be sealed.
+func (c *ChunkApprovalCollector) IsSufficient() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sufficiency.IsSufficient(c.assigned, c.chunkApprovals.signerIDs)
+}
+
 // ProcessApproval performs processing and bookkeeping of single approval
 func (c *ChunkApprovalCollector) ProcessApproval(approval *flow.ResultApproval) (flow.AggregatedSignature, bool) {
 	approverID := approval.Body.ApproverID
 	if _, ok := c.assignment[approverID]; ok {
 		c.lock.Lock()
 		defer c.lock.Unlock()
-		c.chunkApprovals.Add(approverID, approval.Body.AttestationSignature)
-		if c.chunkApprovals.NumberSignatures() >= c.requiredApprovalsForSealConstruction {
+		c.chunkApprovals.Add(approverID, approval.Body.AttestationSignature, approval.Body.Spock)
+		if c.sufficiency.IsSufficient(c.assigned, c.chunkApprovals.signerIDs) {
 			return c.chunkApprovals.ToAggregatedSignature(), true
 		}
 	}
@@ -33,7 +33,7 @@ func (s *ChunkApprovalCollectorTestSuite) SetupTest() {
 	for _, verifier := range s.ChunksAssignment.Verifiers(s.chunk) {
 		s.chunkAssignment[verifier] = struct{}{}
 	}
-	s.collector = NewChunkApprovalCollector(s.chunkAssignment, uint(len(s.chunkAssignment)))
+	s.collector = NewChunkApprovalCollector(s.chunkAssignment, s.AuthorizedVerifiers, CountThreshold{N: uint(len(s.chunkAssignment))})
 }
 
 // TestProcessApproval_ValidApproval tests processing a valid approval. Expected to process it without error
@@ -64,7 +64,7 @@ func (s *ChunkApprovalCollectorTestSuite) TestGetAggregatedSignature_MultipleApp
 	for verID := range s.AuthorizedVerifiers {
 		approval := unittest.ResultApprovalFixture(unittest.WithChunk(s.chunk.Index), unittest.WithApproverID(verID))
 		aggregatedSig, collected = s.collector.ProcessApproval(approval)
-		sigCollector.Add(approval.Body.ApproverID, approval.Body.AttestationSignature)
+		sigCollector.Add(approval.Body.ApproverID, approval.Body.AttestationSignature, approval.Body.Spock)
 	}
 
 	require.True(s.T(), collected)
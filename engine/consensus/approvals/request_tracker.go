@@ -15,6 +15,10 @@ import (
 RequestTrackerItem
 ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~*/
 
+// MaxBlackoutPeriod is the upper bound, in seconds, of the blackout period after a request,
+// which grows exponentially with the number of requests made.
+const MaxBlackoutPeriod = 10 * 60
+
 // RequestTrackerItem is an object that keeps track of how many times a request
 // has been made, as well as the time until a new request can be made.
 // It is not concurrency-safe.
@@ -38,9 +42,13 @@ func NewRequestTrackerItem(blackoutPeriodMin, blackoutPeriodMax int) RequestTrac
 }
 
 // Update creates a _new_ RequestTrackerItem with incremented request number and updated NextTimeout.
+// The blackout period doubles with every request, i.e. after the n-th request, it is contained
+// between min*2^(n-1) and max*2^(n-1), but never exceeds MaxBlackoutPeriod. Hence, chunks which
+// stay short of approvals for a long time don't cause a steady stream of requests.
 func (i RequestTrackerItem) Update() RequestTrackerItem {
 	i.Requests++
-	i.NextTimeout = randBlackout(i.blackoutPeriodMin, i.blackoutPeriodMax)
+	min, max := backoff(i.blackoutPeriodMin, i.Requests), backoff(i.blackoutPeriodMax, i.Requests)
+	i.NextTimeout = randBlackout(min, max)
 	return i
 }
 
@@ -48,6 +56,18 @@ func (i RequestTrackerItem) IsBlackout() bool {
 	return time.Now().Before(i.NextTimeout)
 }
 
+// backoff returns the blackout period after the given number of requests, for the given initial
+// blackout period.
+func backoff(blackoutPeriod int, requests uint) int {
+	for n := uint(1); n < requests && blackoutPeriod < MaxBlackoutPeriod; n++ {
+		blackoutPeriod *= 2
+	}
+	if blackoutPeriod > MaxBlackoutPeriod {
+		return MaxBlackoutPeriod
+	}
+	return blackoutPeriod
+}
+
 func randBlackout(min int, max int) time.Time {
 	blackoutSeconds := rand.Intn(max-min+1) + min
 	blackout := time.Now().Add(time.Duration(blackoutSeconds) * time.Second)
@@ -59,6 +59,28 @@ func (s *RequestTrackerTestSuite) TestTryUpdate_CreateAndUpdate() {
 	}
 }
 
+// TestTryUpdate_ExponentialBackoff tests that the blackout period doubles with every request,
+// until it reaches the maximum blackout period.
+func (s *RequestTrackerTestSuite) TestTryUpdate_ExponentialBackoff() {
+	item := NewRequestTrackerItem(1, 3)
+	for requests := uint(1); requests <= 12; requests++ {
+		before := time.Now()
+		item = item.Update()
+		require.Equal(s.T(), requests, item.Requests)
+
+		min, max := time.Duration(1<<(requests-1))*time.Second, time.Duration(3<<(requests-1))*time.Second
+		if min > MaxBlackoutPeriod*time.Second {
+			min = MaxBlackoutPeriod * time.Second
+		}
+		if max > MaxBlackoutPeriod*time.Second {
+			max = MaxBlackoutPeriod * time.Second
+		}
+		blackout := item.NextTimeout.Sub(before)
+		require.GreaterOrEqual(s.T(), blackout, min, "blackout too short after %d requests", requests)
+		require.LessOrEqual(s.T(), blackout, max+time.Second, "blackout too long after %d requests", requests)
+	}
+}
+
 // TestTryUpdate_ConcurrentTracking tests that TryUpdate behaves correctly under concurrent updates
 func (s *RequestTrackerTestSuite) TestTryUpdate_ConcurrentTracking() {
 	s.tracker.blackoutPeriodMax = 0
@@ -14,6 +14,8 @@ type SignatureCollector struct {
 	verifierSignatures []crypto.Signature
 	// List of signer identifiers
 	signerIDs []flow.Identifier
+	// List of SPoCK proofs
+	spocks []crypto.Signature
 
 	// set of all signerIDs for de-duplicating signatures; the mapped value
 	// is the storage index in the verifierSignatures and signerIDs
@@ -25,6 +27,7 @@ func NewSignatureCollector() SignatureCollector {
 	return SignatureCollector{
 		verifierSignatures: nil,
 		signerIDs:          nil,
+		spocks:             nil,
 		signerIDSet:        make(map[flow.Identifier]int),
 	}
 }
@@ -38,9 +41,13 @@ func (c *SignatureCollector) ToAggregatedSignature() flow.AggregatedSignature {
 	signers := make([]flow.Identifier, len(c.signerIDs))
 	copy(signers, c.signerIDs)
 
+	spocks := make([]crypto.Signature, len(c.spocks))
+	copy(spocks, c.spocks)
+
 	return flow.AggregatedSignature{
 		VerifierSignatures: signatures,
 		SignerIDs:          signers,
+		Spocks:             spocks,
 	}
 }
 
@@ -59,14 +66,16 @@ func (c *SignatureCollector) HasSigned(signerID flow.Identifier) bool {
 	return found
 }
 
-// Add appends a signature. Only the _first_ signature is retained for each signerID.
-func (c *SignatureCollector) Add(signerID flow.Identifier, signature crypto.Signature) {
+// Add appends a signature together with the SPoCK proof of the signer. Only the _first_ signature
+// is retained for each signerID.
+func (c *SignatureCollector) Add(signerID flow.Identifier, signature crypto.Signature, spock crypto.Signature) {
 	if _, found := c.signerIDSet[signerID]; found {
 		return
 	}
 	c.signerIDSet[signerID] = len(c.signerIDs)
 	c.signerIDs = append(c.signerIDs, signerID)
 	c.verifierSignatures = append(c.verifierSignatures, signature)
+	c.spocks = append(c.spocks, spock)
 }
 
 // NumberSignatures returns the number of stored (distinct) signatures
@@ -14,6 +14,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module/mempool"
+	sigmessages "github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
@@ -73,9 +74,9 @@ func (ac *VerifyingAssignmentCollector) collectorByBlockID(incorporatedBlockID f
 // sealing kicks in. This will be removed when implementation of Sealing & Verification is finished.
 func (ac *VerifyingAssignmentCollector) emergencySealable(collector *ApprovalCollector, finalizedBlockHeight uint64) bool {
 	// Criterion for emergency sealing:
-	// there must be at least DefaultEmergencySealingThreshold number of blocks between
+	// there must be at least emergencySealingThreshold number of blocks between
 	// the block that _incorporates_ result and the latest finalized block
-	return collector.IncorporatedBlock().Height+DefaultEmergencySealingThreshold <= finalizedBlockHeight
+	return collector.IncorporatedBlock().Height+ac.emergencySealingThreshold <= finalizedBlockHeight
 }
 
 // CheckEmergencySealing checks the managed assignments whether their result can be emergency
@@ -84,12 +85,24 @@ func (ac *VerifyingAssignmentCollector) CheckEmergencySealing(observer consensus
 	for _, collector := range ac.allCollectors() {
 		sealable := ac.emergencySealable(collector, finalizedBlockHeight)
 		observer.QualifiesForEmergencySealing(collector.IncorporatedResult(), sealable)
-		if sealable {
-			err := collector.SealResult()
-			if err != nil {
-				return fmt.Errorf("could not create emergency seal for result %x incorporated at %x: %w",
-					ac.ResultID(), collector.IncorporatedBlockID(), err)
-			}
+		if !sealable {
+			continue
+		}
+		added, err := collector.EmergencySealResult()
+		if err != nil {
+			return fmt.Errorf("could not create emergency seal for result %x incorporated at %x: %w",
+				ac.ResultID(), collector.IncorporatedBlockID(), err)
+		}
+		if added {
+			ac.log.Warn().
+				Str("executed_block_id", ac.BlockID().String()).
+				Uint64("executed_block_height", ac.Block().Height).
+				Str("result_id", ac.ResultID().String()).
+				Str("incorporated_block_id", collector.IncorporatedBlockID().String()).
+				Uint64("incorporated_block_height", collector.IncorporatedBlock().Height).
+				Uint64("finalized_block_height", finalizedBlockHeight).
+				Msg("EMERGENCY SEALING: constructed seal candidate without sufficient approvals")
+			ac.metrics.EmergencySealed(ac.Block().Height)
 		}
 	}
 
@@ -143,7 +156,7 @@ func (ac *VerifyingAssignmentCollector) ProcessIncorporatedResult(incorporatedRe
 		return fmt.Errorf("failed to retrieve header of incorporatedResult %s: %w",
 			incorporatedResult.Result.BlockID, err)
 	}
-	collector, err := NewApprovalCollector(ac.log, incorporatedResult, incorporatedBlock, executedBlock, assignment, ac.seals, ac.requiredApprovalsForSealConstruction)
+	collector, err := NewApprovalCollector(ac.log, incorporatedResult, incorporatedBlock, executedBlock, assignment, ac.authorizedApprovers, ac.seals, ac.approvalSufficiency, ac.requireSPoCKs, ac.tracer)
 	if err != nil {
 		return fmt.Errorf("instantiation of ApprovalCollector failed: %w", err)
 	}
@@ -191,8 +204,8 @@ func (ac *VerifyingAssignmentCollector) allCollectors() []*ApprovalCollector {
 }
 
 func (ac *VerifyingAssignmentCollector) verifyAttestationSignature(approval *flow.ResultApprovalBody, nodeIdentity *flow.Identity) error {
-	id := approval.Attestation.ID()
-	valid, err := ac.verifier.Verify(id[:], approval.AttestationSignature, nodeIdentity.StakingPubKey)
+	msg := sigmessages.AttestationMessage(&approval.Attestation)
+	valid, err := ac.verifier.Verify(msg, approval.AttestationSignature, nodeIdentity.StakingPubKey)
 	if err != nil {
 		return fmt.Errorf("failed to verify attestation signature: %w", err)
 	}
@@ -205,8 +218,8 @@ func (ac *VerifyingAssignmentCollector) verifyAttestationSignature(approval *flo
 }
 
 func (ac *VerifyingAssignmentCollector) verifySignature(approval *flow.ResultApproval, nodeIdentity *flow.Identity) error {
-	id := approval.Body.ID()
-	valid, err := ac.verifier.Verify(id[:], approval.VerifierSignature, nodeIdentity.StakingPubKey)
+	msg := sigmessages.ResultApprovalMessage(&approval.Body)
+	valid, err := ac.verifier.Verify(msg, approval.VerifierSignature, nodeIdentity.StakingPubKey)
 	if err != nil {
 		return fmt.Errorf("failed to verify approval signature: %w", err)
 	}
@@ -221,6 +234,7 @@ func (ac *VerifyingAssignmentCollector) verifySignature(approval *flow.ResultApp
 // validateApproval performs result level checks of flow.ResultApproval
 // checks:
 // - verification node identity
+// - SPoCK proof presence, if SPoCK proofs are required
 // - attestation signature
 // - signature of verification node
 // - chunk index sanity check
@@ -251,6 +265,12 @@ func (ac *VerifyingAssignmentCollector) validateApproval(approval *flow.ResultAp
 		return engine.NewInvalidInputErrorf("approval not from authorized verifier")
 	}
 
+	// approvals without SPoCK proof are rejected before they are cached, as they
+	// must not contribute to seals if SPoCK proofs are required
+	if ac.requireSPoCKs && len(approval.Body.Spock) == 0 {
+		return engine.NewInvalidInputErrorf("approval has no SPoCK proof")
+	}
+
 	err := ac.verifyAttestationSignature(&approval.Body, identity)
 	if err != nil {
 		return fmt.Errorf("validating attestation signature failed: %w", err)
@@ -376,12 +396,7 @@ func (ac *VerifyingAssignmentCollector) RequestMissingApprovals(observation cons
 //   * have _positive_ weight and
 //   * are not ejected
 func authorizedVerifiersAtBlock(state protocol.State, blockID flow.Identifier) (map[flow.Identifier]*flow.Identity, error) {
-	authorizedVerifierList, err := state.AtBlockID(blockID).Identities(
-		filter.And(
-			filter.HasRole(flow.RoleVerification),
-			filter.HasStake(true),
-			filter.Not(filter.Ejected),
-		))
+	authorizedVerifierList, err := state.AtBlockID(blockID).Identities(filter.IsAuthorizedApprovalSource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve Identities for block %v: %w", blockID, err)
 	}
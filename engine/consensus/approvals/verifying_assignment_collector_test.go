@@ -49,9 +49,10 @@ func newVerifyingAssignmentCollector(logger zerolog.Logger,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
 	requiredApprovalsForSealConstruction uint,
+	opts ...AssignmentCollectorOption,
 ) (*VerifyingAssignmentCollector, error) {
 	b, err := NewAssignmentCollectorBase(logger, workerPool, result, state, headers, assigner, seals, sigVerifier,
-		approvalConduit, requestTracker, requiredApprovalsForSealConstruction)
+		approvalConduit, requestTracker, requiredApprovalsForSealConstruction, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +163,28 @@ func (s *AssignmentCollectorTestSuite) TestProcessApproval_InvalidSignature() {
 	require.True(s.T(), engine.IsInvalidInputError(err))
 }
 
+// TestProcessApproval_MissingSPoCK tests that, if SPoCK proofs are required, an approval without SPoCK
+// proof is rejected before its signatures are verified.
+func (s *AssignmentCollectorTestSuite) TestProcessApproval_MissingSPoCK() {
+	var err error
+	s.collector, err = newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
+		s.Assigner, s.SealsPL, s.SigVerifier, s.Conduit, s.RequestTracker, uint(len(s.AuthorizedVerifiers)), WithRequiredSPoCKs(true))
+	require.NoError(s.T(), err)
+
+	err = s.collector.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	approval := unittest.ResultApprovalFixture(unittest.WithChunk(s.Chunks[0].Index),
+		unittest.WithApproverID(s.VerID),
+		unittest.WithBlockID(s.Block.ID()),
+		unittest.WithExecutionResultID(s.IncorporatedResult.Result.ID()))
+
+	err = s.collector.ProcessApproval(approval)
+	require.Error(s.T(), err)
+	require.True(s.T(), engine.IsInvalidInputError(err))
+	s.SigVerifier.AssertNotCalled(s.T(), "Verify", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestProcessApproval_InvalidBlockID tests a scenario processing approval with invalid block ID
 func (s *AssignmentCollectorTestSuite) TestProcessApproval_InvalidBlockID() {
 
@@ -380,6 +403,58 @@ func (s *AssignmentCollectorTestSuite) TestRequestMissingApprovals() {
 	}
 }
 
+// TestRequestMissingApprovals_NonApprovingVerifiers checks that approvals are requested only from
+// the assigned verifiers, which haven't approved the chunk yet.
+func (s *AssignmentCollectorTestSuite) TestRequestMissingApprovals_NonApprovingVerifiers() {
+	// build new assignment with 3 verifiers per chunk
+	assignment := chunks.NewAssignment()
+	for _, chunk := range s.Chunks {
+		verifiers := s.ChunksAssignment.Verifiers(chunk)
+		assignment.Add(chunk, verifiers[:3])
+	}
+	s.ChunksAssignment = assignment
+	// requests can be made right away
+	s.RequestTracker.blackoutPeriodMin = 0
+	s.RequestTracker.blackoutPeriodMax = 0
+
+	err := s.collector.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	// the first assigned verifier approves every chunk
+	s.SigVerifier.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	expectedTargets := make(map[uint64]flow.IdentifierList)
+	for _, chunk := range s.Chunks {
+		verifiers := assignment.Verifiers(chunk)
+		approval := unittest.ResultApprovalFixture(unittest.WithChunk(chunk.Index),
+			unittest.WithApproverID(verifiers[0]),
+			unittest.WithBlockID(s.Block.ID()),
+			unittest.WithExecutionResultID(s.IncorporatedResult.Result.ID()))
+		err = s.collector.ProcessApproval(approval)
+		require.NoError(s.T(), err)
+		expectedTargets[chunk.Index] = verifiers[1:]
+	}
+
+	targets := make(map[uint64]flow.IdentifierList)
+	s.Conduit.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			req, ok := args[0].(*messages.ApprovalRequest)
+			s.Require().True(ok)
+			s.Require().Equal(s.IncorporatedResult.Result.ID(), req.ResultID)
+			for _, arg := range args[1:] {
+				targets[req.ChunkIndex] = append(targets[req.ChunkIndex], arg.(flow.Identifier))
+			}
+		})
+
+	requestCount, err := s.collector.RequestMissingApprovals(&tracker.NoopSealingTracker{}, s.IncorporatedBlock.Height)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), s.Chunks.Len(), int(requestCount))
+	require.Len(s.T(), targets, len(expectedTargets))
+	for chunkIndex, expected := range expectedTargets {
+		require.ElementsMatch(s.T(), expected, targets[chunkIndex], "unexpected targets for chunk %d", chunkIndex)
+	}
+}
+
 // TestCheckEmergencySealing tests that currently tracked incorporated results can be emergency sealed
 // when height difference reached the emergency sealing threshold.
 func (s *AssignmentCollectorTestSuite) TestCheckEmergencySealing() {
@@ -404,3 +479,81 @@ func (s *AssignmentCollectorTestSuite) TestCheckEmergencySealing() {
 
 	s.SealsPL.AssertExpectations(s.T())
 }
+
+// TestCheckEmergencySealing_Threshold tests that an incorporated result is emergency sealed once exactly the
+// configured threshold of blocks was finalized on top of the incorporating block, and not one block before.
+// The seal candidate is marked as emergency sealed, and reported to the metrics once.
+func (s *AssignmentCollectorTestSuite) TestCheckEmergencySealing_Threshold() {
+	threshold := uint64(10)
+	conMetrics := &module.ConsensusMetrics{}
+	collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
+		s.Assigner, s.SealsPL, s.SigVerifier, s.Conduit, s.RequestTracker, uint(len(s.AuthorizedVerifiers)),
+		WithEmergencySealingThreshold(threshold), WithConsensusMetrics(conMetrics))
+	require.NoError(s.T(), err)
+
+	err = collector.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	// one block before the threshold, no seal is created and nothing is reported
+	err = collector.CheckEmergencySealing(&tracker.NoopSealingTracker{}, s.IncorporatedBlock.Height+threshold-1)
+	require.NoError(s.T(), err)
+	s.SealsPL.AssertNotCalled(s.T(), "Add", mock.Anything)
+	conMetrics.AssertNotCalled(s.T(), "EmergencySealed", mock.Anything)
+
+	// exactly at the threshold, the result is emergency sealed
+	s.SealsPL.On("Add", mock.Anything).Run(
+		func(args mock.Arguments) {
+			seal := args.Get(0).(*flow.IncorporatedResultSeal)
+			require.Equal(s.T(), s.Block.ID(), seal.Seal.BlockID)
+			require.Equal(s.T(), s.IncorporatedResult.Result.ID(), seal.Seal.ResultID)
+			require.True(s.T(), seal.EmergencySealed)
+		},
+	).Return(true, nil).Once()
+	conMetrics.On("EmergencySealed", s.Block.Height).Return().Once()
+
+	err = collector.CheckEmergencySealing(&tracker.NoopSealingTracker{}, s.IncorporatedBlock.Height+threshold)
+	require.NoError(s.T(), err)
+
+	// the seal already exists in the mempool, so it is not reported again
+	s.SealsPL.On("Add", mock.Anything).Return(false, nil).Once()
+	err = collector.CheckEmergencySealing(&tracker.NoopSealingTracker{}, s.IncorporatedBlock.Height+threshold+1)
+	require.NoError(s.T(), err)
+
+	s.SealsPL.AssertExpectations(s.T())
+	conMetrics.AssertExpectations(s.T())
+}
+
+// TestProcessApproval_NoEmergencySeal tests that seals constructed from sufficient approvals are not marked as
+// emergency sealed, and not reported as emergency seals.
+func (s *AssignmentCollectorTestSuite) TestProcessApproval_NoEmergencySeal() {
+	conMetrics := &module.ConsensusMetrics{}
+	collector, err := newVerifyingAssignmentCollector(unittest.Logger(), s.WorkerPool, s.IncorporatedResult.Result, s.State, s.Headers,
+		s.Assigner, s.SealsPL, s.SigVerifier, s.Conduit, s.RequestTracker, uint(len(s.AuthorizedVerifiers)),
+		WithConsensusMetrics(conMetrics))
+	require.NoError(s.T(), err)
+
+	err = collector.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	s.SealsPL.On("Add", mock.Anything).Run(
+		func(args mock.Arguments) {
+			seal := args.Get(0).(*flow.IncorporatedResultSeal)
+			require.False(s.T(), seal.EmergencySealed)
+		},
+	).Return(true, nil).Once()
+	s.SigVerifier.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	for _, chunk := range s.Chunks {
+		for verID := range s.AuthorizedVerifiers {
+			approval := unittest.ResultApprovalFixture(unittest.WithChunk(chunk.Index),
+				unittest.WithApproverID(verID),
+				unittest.WithBlockID(s.Block.ID()),
+				unittest.WithExecutionResultID(s.IncorporatedResult.Result.ID()))
+			err = collector.ProcessApproval(approval)
+			require.NoError(s.T(), err)
+		}
+	}
+
+	s.SealsPL.AssertExpectations(s.T())
+	conMetrics.AssertNotCalled(s.T(), "EmergencySealed", mock.Anything)
+}
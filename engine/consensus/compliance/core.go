@@ -38,6 +38,7 @@ type Core struct {
 	headers           storage.Headers
 	payloads          storage.Payloads
 	state             protocol.MutableState
+	chainID           flow.ChainID              // chain ID of the local chain, proposals for other chains are rejected
 	pending           module.PendingBlockBuffer // pending block cache
 	sync              module.BlockRequester
 	hotstuff          module.HotStuff
@@ -58,6 +59,11 @@ func NewCore(
 	sync module.BlockRequester,
 ) (*Core, error) {
 
+	chainID, err := state.Params().ChainID()
+	if err != nil {
+		return nil, fmt.Errorf("could not get chain ID: %w", err)
+	}
+
 	e := &Core{
 		log:               log.With().Str("compliance", "core").Logger(),
 		metrics:           collector,
@@ -68,6 +74,7 @@ func NewCore(
 		headers:           headers,
 		payloads:          payloads,
 		state:             state,
+		chainID:           chainID,
 		pending:           pending,
 		sync:              sync,
 		hotstuff:          nil, // use `WithConsensus`
@@ -111,6 +118,11 @@ func (c *Core) OnBlockProposal(originID flow.Identifier, proposal *messages.Bloc
 		Logger()
 	log.Info().Msg("block proposal received")
 
+	// reject proposals for other chains right away, they can't ever be connected to our chain
+	if header.ChainID != c.chainID {
+		return engine.NewWrongChainErrorf(c.chainID, header.ChainID, "block proposal %x", header.ID())
+	}
+
 	c.prunePendingCache()
 
 	// first, we reject all blocks that we don't need to process:
@@ -222,16 +234,30 @@ func (c *Core) processBlockAndDescendants(proposal *messages.BlockProposal) erro
 
 	// process block itself
 	err := c.processBlockProposal(proposal)
-	// child is outdated by the time we started processing it
-	// => node was probably behind and is catching up. Log as warning
+	// block is at or below the finalized height by the time we started processing it
+	// => it was finalized concurrently on another fork; drop it along with its descendants
+	if errors.Is(err, protocol.ErrOutdatedBlock) {
+		c.log.Debug().Err(err).Msg("dropped processing of outdated block")
+		c.pending.DropForParent(blockID)
+		return nil
+	}
+	// block is on a fork conflicting with the finalized blocks
+	// => node was probably behind and is catching up. Log as info
 	if engine.IsOutdatedInputError(err) {
 		c.log.Info().Msg("dropped processing of abandoned fork; this might be an indicator that the node is slightly behind")
+		c.pending.DropForParent(blockID)
 		return nil
 	}
-	// the block is invalid; log as error as we desire honest participation
+	// the block is invalid, and so are its descendants; log as error as we desire honest participation
 	// ToDo: potential slashing
 	if engine.IsInvalidInputError(err) {
-		c.log.Warn().Err(err).Msg("received invalid block from other node (potential slashing evidence?)")
+		warn := c.log.Warn().Err(err)
+		var invalidErr protocol.InvalidExtensionError
+		if errors.As(err, &invalidErr) {
+			warn = warn.Str("reason", string(invalidErr.Reason))
+		}
+		warn.Msg("received invalid block from other node (potential slashing evidence?)")
+		c.pending.DropForParent(blockID)
 		return nil
 	}
 	if err != nil {
@@ -290,12 +316,23 @@ func (c *Core) processBlockProposal(proposal *messages.BlockProposal) error {
 		Logger()
 	log.Info().Msg("processing block proposal")
 
+	// retrieve the parent and check that the proposal correctly extends it
+	parent, err := c.headers.ByBlockID(header.ParentID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve proposal parent: %w", err)
+	}
+	err = header.VerifyParentLink(parent)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("invalid parent link (block: %x, height: %d): %w",
+			header.ID(), header.Height, err)
+	}
+
 	// see if the block is a valid extension of the protocol state
 	block := &flow.Block{
 		Header:  proposal.Header,
 		Payload: proposal.Payload,
 	}
-	err := c.state.Extend(ctx, block)
+	err = c.state.Extend(ctx, block)
 	// if the block proposes an invalid extension of the protocol state, then the block is invalid
 	if state.IsInvalidExtensionError(err) {
 		return engine.NewInvalidInputErrorf("invalid extension of protocol state (block: %x, height: %d): %w",
@@ -309,12 +346,6 @@ func (c *Core) processBlockProposal(proposal *messages.BlockProposal) error {
 		return fmt.Errorf("could not extend protocol state (block: %x, height: %d): %w", header.ID(), header.Height, err)
 	}
 
-	// retrieve the parent
-	parent, err := c.headers.ByBlockID(header.ParentID)
-	if err != nil {
-		return fmt.Errorf("could not retrieve proposal parent: %w", err)
-	}
-
 	// submit the model to hotstuff for processing
 	log.Info().Msg("forwarding block proposal to hotstuff")
 	c.hotstuff.SubmitProposal(header, parent.View)
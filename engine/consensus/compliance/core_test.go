@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
 	realModule "github.com/onflow/flow-go/module"
@@ -20,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/module/trace"
 	netint "github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/mocknetwork"
+	st "github.com/onflow/flow-go/state"
 	protint "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storerr "github.com/onflow/flow-go/storage"
@@ -155,6 +157,9 @@ func (cs *ComplianceCoreSuite) SetupTest() {
 		},
 	)
 	cs.state.On("Extend", mock.Anything, mock.Anything).Return(nil)
+	params := &protocol.Params{}
+	params.On("ChainID").Return(cs.head.ChainID, nil)
+	cs.state.On("Params").Return(params)
 
 	// set up protocol snapshot mock
 	cs.snapshot = &protocol.Snapshot{}
@@ -294,6 +299,23 @@ func (cs *ComplianceCoreSuite) TestOnBlockProposalValidAncestor() {
 	cs.hotstuff.AssertExpectations(cs.T())
 }
 
+func (cs *ComplianceCoreSuite) TestOnBlockProposalWrongChain() {
+
+	// create a proposal that directly descends from the latest finalized header, but for another chain
+	originID := cs.participants[1].NodeID
+	block := unittest.BlockWithParentFixture(cs.head)
+	block.Header.ChainID = "other-chain"
+	proposal := unittest.ProposalFromBlock(block)
+
+	// it should be rejected as a proposal for another chain
+	err := cs.core.OnBlockProposal(originID, proposal)
+	require.True(cs.T(), engine.IsWrongChainError(err), "proposal for another chain should be rejected")
+
+	// we should neither try to extend the state nor cache the proposal
+	cs.state.AssertNotCalled(cs.T(), "Extend", mock.Anything, mock.Anything)
+	cs.pending.AssertNotCalled(cs.T(), "Add", mock.Anything, mock.Anything)
+}
+
 func (cs *ComplianceCoreSuite) TestOnBlockProposalInvalidExtension() {
 
 	// create a proposal that has two ancestors in the cache
@@ -327,6 +349,28 @@ func (cs *ComplianceCoreSuite) TestOnBlockProposalInvalidExtension() {
 	cs.hotstuff.AssertExpectations(cs.T())
 }
 
+func (cs *ComplianceCoreSuite) TestOnBlockProposalHeightGap() {
+
+	// create a proposal that references the latest finalized header as parent, but skips a height
+	originID := cs.participants[1].NodeID
+	block := unittest.BlockWithParentFixture(cs.head)
+	block.Header.Height = cs.head.Height + 2
+	proposal := unittest.ProposalFromBlock(block)
+
+	// the proposal should be rejected with the specific violation
+	err := cs.core.processBlockProposal(proposal)
+	require.True(cs.T(), engine.IsInvalidInputError(err), "proposal with height gap should be invalid")
+	require.ErrorIs(cs.T(), err, flow.ErrHeightGap)
+
+	// it should be dropped without error, as it is invalid
+	err = cs.core.OnBlockProposal(originID, proposal)
+	require.NoError(cs.T(), err, "proposal with height gap should be dropped")
+
+	// we should neither extend the state nor submit the proposal to hotstuff
+	cs.state.AssertNotCalled(cs.T(), "Extend", mock.Anything, mock.Anything)
+	cs.hotstuff.AssertNotCalled(cs.T(), "SubmitProposal", mock.Anything, mock.Anything)
+}
+
 func (cs *ComplianceCoreSuite) TestProcessBlockAndDescendants() {
 
 	// create three children blocks
@@ -366,6 +410,42 @@ func (cs *ComplianceCoreSuite) TestProcessBlockAndDescendants() {
 	cs.pending.AssertCalled(cs.T(), "DropForParent", parent.Header.ID())
 }
 
+// TestProcessBlockAndDescendants_Rejected tests that blocks rejected by the protocol state as
+// outdated, orphaned or invalid are dropped without error, along with their pending children.
+func (cs *ComplianceCoreSuite) TestProcessBlockAndDescendants_Rejected() {
+	rejections := map[string]error{
+		"outdated": st.NewOutdatedExtensionErrorf("%w", protint.ErrOutdatedBlock),
+		"orphaned": st.NewOutdatedExtensionErrorf("%w", protint.ErrOrphanedBlock),
+		"invalid":  protint.NewInvalidExtensionErrorf(protint.InvalidGuarantees, "duplicate guarantee"),
+	}
+	for name, rejection := range rejections {
+		cs.Run(name, func() {
+			parent := unittest.BlockWithParentFixture(cs.head)
+			child := unittest.BlockWithParentFixture(parent.Header)
+			cs.childrenDB[parent.ID()] = []*flow.PendingBlock{unittest.PendingFromBlock(child)}
+
+			*cs.state = protocol.MutableState{}
+			cs.state.On("Extend", mock.Anything, parent).Return(rejection).Once()
+
+			err := cs.core.processBlockAndDescendants(unittest.ProposalFromBlock(parent))
+			require.NoError(cs.T(), err, "rejected block should be dropped")
+
+			// the child is dropped without being processed
+			cs.state.AssertNotCalled(cs.T(), "Extend", mock.Anything, child)
+			cs.pending.AssertCalled(cs.T(), "DropForParent", parent.ID())
+			cs.hotstuff.AssertNotCalled(cs.T(), "SubmitProposal", mock.Anything, mock.Anything)
+		})
+	}
+
+	// unexpected errors are escalated
+	parent := unittest.BlockWithParentFixture(cs.head)
+	*cs.state = protocol.MutableState{}
+	cs.state.On("Extend", mock.Anything, parent).Return(errors.New("dummy error")).Once()
+	err := cs.core.processBlockAndDescendants(unittest.ProposalFromBlock(parent))
+	require.Error(cs.T(), err)
+	cs.pending.AssertNotCalled(cs.T(), "DropForParent", parent.ID())
+}
+
 func (cs *ComplianceCoreSuite) TestOnSubmitVote() {
 
 	// create a vote
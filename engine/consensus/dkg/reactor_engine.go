@@ -35,6 +35,11 @@ type dkgInfo struct {
 // ReactorEngine is an engine that reacts to chain events to start new DKG runs,
 // and manage subsequent phase transitions. Any unexpected error triggers a
 // panic as it would undermine the security of the protocol.
+//
+// A DKG run interrupted by a restart of the node is resumed from the latest
+// checkpoint stored by its controller. The run is aborted when the engine
+// shuts down, which stores a final checkpoint, and the checkpoint is removed
+// once the DKG has ended.
 type ReactorEngine struct {
 	events.Noop
 	unit              *engine.Unit
@@ -42,6 +47,7 @@ type ReactorEngine struct {
 	me                module.Local
 	State             protocol.State
 	dkgState          storage.DKGState
+	checkpoints       storage.DKGCheckpoints
 	controller        module.DKGController
 	controllerFactory module.DKGControllerFactory
 	viewEvents        events.Views
@@ -54,6 +60,7 @@ func NewReactorEngine(
 	me module.Local,
 	state protocol.State,
 	dkgState storage.DKGState,
+	checkpoints storage.DKGCheckpoints,
 	controllerFactory module.DKGControllerFactory,
 	viewEvents events.Views,
 ) *ReactorEngine {
@@ -68,6 +75,7 @@ func NewReactorEngine(
 		me:                me,
 		State:             state,
 		dkgState:          dkgState,
+		checkpoints:       checkpoints,
 		controllerFactory: controllerFactory,
 		viewEvents:        viewEvents,
 		pollStep:          DefaultPollStep,
@@ -80,8 +88,9 @@ func NewReactorEngine(
 func (e *ReactorEngine) Ready() <-chan struct{} {
 	return e.unit.Ready(func() {
 		// If we are starting up in the EpochSetup phase, try to start the DKG.
-		// If the DKG for this epoch has been started previously, we will exit
-		// and fail this epoch's DKG.
+		// If the DKG for this epoch has been started previously, we resume it
+		// from its checkpoint. Without a checkpoint, we will exit and fail this
+		// epoch's DKG.
 		snap := e.State.Final()
 
 		phase, err := snap.Phase()
@@ -113,9 +122,16 @@ func (e *ReactorEngine) Ready() <-chan struct{} {
 }
 
 // Done implements the module ReadyDoneAware interface. It returns a channel
-// that will close when the engine has successfully stopped.
+// that will close when the engine has successfully stopped. A running DKG is
+// aborted, so that it is resumed from its checkpoint after a restart.
 func (e *ReactorEngine) Done() <-chan struct{} {
-	return e.unit.Done()
+	return e.unit.Done(func() {
+		e.unit.Lock()
+		defer e.unit.Unlock()
+		if e.controller != nil {
+			e.controller.Abort()
+		}
+	})
 }
 
 // EpochSetupPhaseStarted handles the EpochSetupPhaseStarted protocol event by
@@ -132,7 +148,9 @@ func (e *ReactorEngine) EpochCommittedPhaseStarted(currentEpochCounter uint64, f
 
 // startDKGForEpoch starts the DKG instance for the given epoch, only if we have
 // never started the DKG during setup phase for the given epoch. This allows consensus nodes which
-// boot from a state snapshot within the EpochSetup phase to run the DKG.
+// boot from a state snapshot within the EpochSetup phase to run the DKG. If we have started the
+// DKG before, it is resumed from its checkpoint, which allows consensus nodes restarting during
+// the DKG to complete it.
 //
 // It starts a new controller for the epoch and registers the triggers to regularly
 // query the DKG smart-contract and transition between phases at the specified views.
@@ -148,22 +166,33 @@ func (e *ReactorEngine) startDKGForEpoch(currentEpochCounter uint64, first *flow
 		Hex("first_block_id", firstID[:]).        // id of first block in EpochSetup phase
 		Logger()
 
-	// if we have started the dkg for this epoch already, exit
+	dkgInstanceID := dkgmodule.CanonicalInstanceID(first.ChainID, nextEpochCounter)
+
+	// if we have started the dkg for this epoch already, resume it from its
+	// checkpoint, or exit if there is none
 	started, err := e.dkgState.GetDKGStarted(nextEpochCounter)
 	if err != nil {
 		// unexpected storage-level error
 		log.Fatal().Err(err).Msg("could not check whether DKG is started")
 	}
+	var checkpoint []byte
 	if started {
-		log.Warn().Msg("DKG started before, skipping starting the DKG for this epoch")
-		return
-	}
-
-	// flag that we are starting the dkg for this epoch
-	err = e.dkgState.SetDKGStarted(nextEpochCounter)
-	if err != nil {
-		// unexpected storage-level error
-		log.Fatal().Err(err).Msg("could not set dkg started")
+		checkpoint, err = e.checkpoints.RetrieveDKGCheckpoint(dkgInstanceID)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn().Msg("DKG started before without checkpoint, skipping starting the DKG for this epoch")
+			return
+		}
+		if err != nil {
+			// unexpected storage-level error
+			log.Fatal().Err(err).Msg("could not retrieve DKG checkpoint")
+		}
+	} else {
+		// flag that we are starting the dkg for this epoch
+		err = e.dkgState.SetDKGStarted(nextEpochCounter)
+		if err != nil {
+			// unexpected storage-level error
+			log.Fatal().Err(err).Msg("could not set dkg started")
+		}
 	}
 
 	curDKGInfo, err := e.getDKGInfo(firstID)
@@ -181,16 +210,26 @@ func (e *ReactorEngine) startDKGForEpoch(currentEpochCounter uint64, first *flow
 		Interface("members", committee.NodeIDs()).
 		Msg("epoch info")
 
-	controller, err := e.controllerFactory.Create(
-		dkgmodule.CanonicalInstanceID(first.ChainID, nextEpochCounter),
-		committee,
-		curDKGInfo.seed,
-	)
-	if err != nil {
-		// no expected errors in controller factory
-		log.Fatal().Err(err).Msg("could not create DKG controller")
+	var controller module.DKGController
+	if started {
+		controller, err = e.controllerFactory.CreateFromCheckpoint(dkgInstanceID, checkpoint)
+		if err != nil {
+			// the checkpoint is unusable, so we fail this epoch's DKG as if
+			// there was no checkpoint
+			log.Warn().Err(err).Msg("could not resume DKG from checkpoint, skipping starting the DKG for this epoch")
+			return
+		}
+		log.Info().Msg("resuming DKG from checkpoint")
+	} else {
+		controller, err = e.controllerFactory.Create(dkgInstanceID, committee, curDKGInfo.seed)
+		if err != nil {
+			// no expected errors in controller factory
+			log.Fatal().Err(err).Msg("could not create DKG controller")
+		}
 	}
+	e.unit.Lock()
 	e.controller = controller
+	e.unit.Unlock()
 
 	e.unit.Launch(func() {
 		log.Info().Msg("DKG Run")
@@ -213,20 +252,44 @@ func (e *ReactorEngine) startDKGForEpoch(currentEpochCounter uint64, first *flow
 	// specifications and implementations of the DKGBroker and DKGController
 	// interfaces).
 
+	//
+	// When resuming from a checkpoint, the transitions for views finalized
+	// while the node was down are triggered by the next finalized block. The
+	// resumed controller may have ended these phases before the checkpoint
+	// was taken, in which case the transitions are skipped.
+
+	endPhase1, endPhase2 := controller.EndPhase1, controller.EndPhase2
+	if started {
+		endPhase1, endPhase2 = skipEndedPhase(endPhase1), skipEndedPhase(endPhase2)
+	}
+
 	for view := curDKGInfo.phase1FinalView; view > first.View; view -= e.pollStep {
 		e.registerPoll(view)
 	}
-	e.registerPhaseTransition(curDKGInfo.phase1FinalView, dkgmodule.Phase1, e.controller.EndPhase1)
+	e.registerPhaseTransition(curDKGInfo.phase1FinalView, dkgmodule.Phase1, endPhase1)
 
 	for view := curDKGInfo.phase2FinalView; view > curDKGInfo.phase1FinalView; view -= e.pollStep {
 		e.registerPoll(view)
 	}
-	e.registerPhaseTransition(curDKGInfo.phase2FinalView, dkgmodule.Phase2, e.controller.EndPhase2)
+	e.registerPhaseTransition(curDKGInfo.phase2FinalView, dkgmodule.Phase2, endPhase2)
 
 	for view := curDKGInfo.phase3FinalView; view > curDKGInfo.phase2FinalView; view -= e.pollStep {
 		e.registerPoll(view)
 	}
-	e.registerPhaseTransition(curDKGInfo.phase3FinalView, dkgmodule.Phase3, e.end(nextEpochCounter))
+	e.registerPhaseTransition(curDKGInfo.phase3FinalView, dkgmodule.Phase3, e.end(nextEpochCounter, dkgInstanceID))
+}
+
+// skipEndedPhase wraps the transition ending a phase of a DKG resumed from a
+// checkpoint, which does nothing if the phase was ended before the checkpoint
+// was taken.
+func skipEndedPhase(phaseTransition func() error) func() error {
+	return func() error {
+		err := phaseTransition()
+		if dkgmodule.IsInvalidStateTransitionError(err) {
+			return nil
+		}
+		return err
+	}
 }
 
 // handleEpochCommittedPhaseStarted is invoked upon the transition to the EpochCommitted
@@ -253,6 +316,14 @@ func (e *ReactorEngine) handleEpochCommittedPhaseStarted(currentEpochCounter uin
 		Uint64("next_epoch", nextEpochCounter).   // the epoch the just-finished DKG was preparing for
 		Logger()
 
+	// the DKG has ended globally, so its checkpoint is of no use anymore, even
+	// if the DKG has not ended locally
+	err := e.checkpoints.RemoveDKGCheckpoint(dkgmodule.CanonicalInstanceID(firstBlock.ChainID, nextEpochCounter))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not remove DKG checkpoint")
+		return
+	}
+
 	// Check whether we have already set the end state for this DKG.
 	// This can happen if the DKG failed locally, if we failed to generate
 	// a local private beacon key, or if we crashed while performing this
@@ -392,9 +463,9 @@ func (e *ReactorEngine) registerPhaseTransition(view uint64, fromState dkgmodule
 }
 
 // end returns a callback that is used to end the DKG protocol, save the
-// resulting private key to storage, and publish the other results to the DKG
-// smart-contract.
-func (e *ReactorEngine) end(nextEpochCounter uint64) func() error {
+// resulting private key to storage, remove the checkpoint of the DKG, and
+// publish the other results to the DKG smart-contract.
+func (e *ReactorEngine) end(nextEpochCounter uint64, dkgInstanceID string) func() error {
 	return func() error {
 
 		err := e.controller.End()
@@ -417,6 +488,11 @@ func (e *ReactorEngine) end(nextEpochCounter uint64) func() error {
 			}
 		}
 
+		err = e.checkpoints.RemoveDKGCheckpoint(dkgInstanceID)
+		if err != nil {
+			return fmt.Errorf("could not remove DKG checkpoint: %w", err)
+		}
+
 		err = e.controller.SubmitResult()
 		if err != nil {
 			return fmt.Errorf("couldn't publish DKG results: %w", err)
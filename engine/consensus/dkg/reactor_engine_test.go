@@ -58,9 +58,10 @@ type ReactorEngineSuite_SetupPhase struct {
 	state        *protocol.State
 	viewEvents   *gadgets.Views
 
-	dkgState   *storage.DKGState
-	controller *module.DKGController
-	factory    *module.DKGControllerFactory
+	dkgState    *storage.DKGState
+	checkpoints *storage.DKGCheckpoints
+	controller  *module.DKGController
+	factory     *module.DKGControllerFactory
 
 	engine *dkg.ReactorEngine
 }
@@ -149,6 +150,10 @@ func (suite *ReactorEngineSuite_SetupPhase) SetupTest() {
 		Return(nil).
 		Once()
 
+	// ensure that the checkpoint of the dkg is removed once it has ended
+	suite.checkpoints = new(storage.DKGCheckpoints)
+	suite.checkpoints.On("RemoveDKGCheckpoint", dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter())).Return(nil).Once()
+
 	// we will ensure that the controller state transitions get called appropriately
 	suite.controller = new(module.DKGController)
 	suite.controller.On("Run").Return(nil).Once()
@@ -175,6 +180,7 @@ func (suite *ReactorEngineSuite_SetupPhase) SetupTest() {
 		suite.local,
 		suite.state,
 		suite.dkgState,
+		suite.checkpoints,
 		suite.factory,
 		suite.viewEvents,
 	)
@@ -197,6 +203,7 @@ func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_PhaseTransition() {
 	time.Sleep(50 * time.Millisecond)
 	suite.controller.AssertExpectations(suite.T())
 	suite.dkgState.AssertExpectations(suite.T())
+	suite.checkpoints.AssertExpectations(suite.T())
 	// happy path - no warn logs expected
 	suite.Assert().Equal(0, suite.warnsLogged)
 }
@@ -224,21 +231,22 @@ func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase() {
 	time.Sleep(50 * time.Millisecond)
 	suite.controller.AssertExpectations(suite.T())
 	suite.dkgState.AssertExpectations(suite.T())
+	suite.checkpoints.AssertExpectations(suite.T())
 	// happy path - no warn logs expected
 	suite.Assert().Equal(0, suite.warnsLogged)
 }
 
 // TestRunDKG_StartupInSetupPhase_DKGAlreadyStarted tests that the DKG is NOT
 // started, when the engine starts up during the EpochSetup phase, and the DKG
-// for this epoch HAS been started previously. This will be the case for
-// consensus nodes which restart during the DKG.
+// for this epoch HAS been started previously without storing a checkpoint.
 //
 func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase_DKGAlreadyStarted() {
 
 	// we are in the EpochSetup phase
 	suite.snapshot.On("Phase").Return(flow.EpochPhaseSetup, nil).Once()
-	// the dkg for this epoch has been started
+	// the dkg for this epoch has been started, without storing a checkpoint
 	suite.dkgState.On("GetDKGStarted", suite.NextEpochCounter()).Return(true, nil).Once()
+	suite.checkpoints.On("RetrieveDKGCheckpoint", mock.Anything).Return(nil, storerr.ErrNotFound).Once()
 
 	// start up the engine
 	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
@@ -254,6 +262,76 @@ func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase_DKGAl
 	suite.Assert().Equal(1, suite.warnsLogged)
 }
 
+// TestRunDKG_StartupInSetupPhase_ResumeFromCheckpoint tests that the DKG is
+// resumed from its checkpoint, when the engine starts up during the EpochSetup
+// phase, and the DKG for this epoch HAS been started previously. This will be
+// the case for consensus nodes which restart during the DKG.
+//
+// The node restarts at view 170, after phase 1 was ended before the restart.
+// The transition ending phase 1 is triggered again, and must be skipped.
+//
+func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase_ResumeFromCheckpoint() {
+
+	dkgInstanceID := dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter())
+	checkpoint := unittest.RandomBytes(100)
+	restartBlock := suite.blocksByView[170]
+
+	// we are in the EpochSetup phase, after the node restarted at view 170
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Phase").Return(flow.EpochPhaseSetup, nil).Once()
+	snapshot.On("Epochs").Return(suite.epochQuery)
+	snapshot.On("Head").Return(restartBlock, nil)
+	state := new(protocol.State)
+	state.On("AtBlockID", restartBlock.ID()).Return(snapshot)
+	state.On("Final").Return(snapshot)
+
+	// the dkg for this epoch has been started, and checkpointed in phase 2
+	suite.dkgState.On("GetDKGStarted", suite.NextEpochCounter()).Return(true, nil).Once()
+	suite.checkpoints.On("RetrieveDKGCheckpoint", dkgInstanceID).Return(checkpoint, nil).Once()
+
+	controller := new(module.DKGController)
+	controller.On("Run").Return(nil).Once()
+	controller.On("EndPhase1").Return(dkgmodule.NewInvalidStateTransitionError(dkgmodule.Phase2, dkgmodule.Phase2)).Once()
+	controller.On("EndPhase2").Return(nil).Once()
+	controller.On("End").Return(nil).Once()
+	controller.On("Poll", mock.Anything).Return(nil).Times(10)
+	controller.On("GetArtifacts").Return(suite.expectedPrivateKey, nil, nil).Once()
+	controller.On("SubmitResult").Return(nil).Once()
+	controller.On("Abort").Once()
+
+	factory := new(module.DKGControllerFactory)
+	factory.On("CreateFromCheckpoint", dkgInstanceID, checkpoint).Return(controller, nil).Once()
+
+	engine := dkg.NewReactorEngine(
+		suite.logger,
+		suite.local,
+		state,
+		suite.dkgState,
+		suite.checkpoints,
+		factory,
+		suite.viewEvents,
+	)
+
+	// start up the engine
+	unittest.AssertClosesBefore(suite.T(), engine.Ready(), time.Second)
+
+	for view := uint64(180); view <= 250; view += dkg.DefaultPollStep {
+		suite.viewEvents.BlockFinalized(suite.blocksByView[view])
+	}
+
+	// the resumed dkg is completed, its key stored and its checkpoint removed
+	time.Sleep(50 * time.Millisecond)
+	factory.AssertExpectations(suite.T())
+	suite.dkgState.AssertNotCalled(suite.T(), "SetDKGStarted", suite.NextEpochCounter())
+	suite.dkgState.AssertCalled(suite.T(), "InsertMyBeaconPrivateKey", suite.NextEpochCounter(), suite.expectedPrivateKey)
+	suite.checkpoints.AssertExpectations(suite.T())
+	suite.Assert().Equal(0, suite.warnsLogged)
+
+	// the dkg is aborted when the engine shuts down
+	unittest.AssertClosesBefore(suite.T(), engine.Done(), time.Second)
+	controller.AssertExpectations(suite.T())
+}
+
 // ReactorEngineSuite_CommittedPhase tests the Reactor engine's operation
 // during the transition to the EpochCommitted phase, after the DKG has
 // completed locally, and we are comparing our local results to the
@@ -354,6 +432,9 @@ func (suite *ReactorEngineSuite_CommittedPhase) SetupTest() {
 	suite.warnsLogged = 0
 	logger := hookedLogger(&suite.warnsLogged)
 
+	checkpoints := new(storage.DKGCheckpoints)
+	checkpoints.On("RemoveDKGCheckpoint", dkgmodule.CanonicalInstanceID(firstBlock.ChainID, suite.NextEpochCounter())).Return(nil)
+
 	factory := new(module.DKGControllerFactory)
 	viewEvents := gadgets.NewViews()
 
@@ -362,6 +443,7 @@ func (suite *ReactorEngineSuite_CommittedPhase) SetupTest() {
 		suite.me,
 		suite.state,
 		suite.dkgState,
+		checkpoints,
 		factory,
 		viewEvents,
 	)
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 
+	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
@@ -22,18 +24,21 @@ import (
 // Core represents core logic of the ingestion engine. It contains logic
 // for handling single collection which are channeled from engine in concurrent way.
 type Core struct {
-	log     zerolog.Logger        // used to log relevant actions with context
-	tracer  module.Tracer         // used for tracing
-	mempool module.MempoolMetrics // used to track mempool metrics
-	state   protocol.State        // used to access the protocol state
-	headers storage.Headers       // used to retrieve headers
-	pool    mempool.Guarantees    // used to keep pending guarantees in pool
+	log     zerolog.Logger          // used to log relevant actions with context
+	tracer  module.Tracer           // used for tracing
+	mempool module.MempoolMetrics   // used to track mempool metrics
+	metrics module.ConsensusMetrics // used to track rejected guarantees
+	state   protocol.State          // used to access the protocol state
+	headers storage.Headers         // used to retrieve headers
+	pool    mempool.Guarantees      // used to keep pending guarantees in pool
+	pending *pendingGuarantees      // used to cache guarantees with unknown reference blocks for retry
 }
 
 func NewCore(
 	log zerolog.Logger,
 	tracer module.Tracer,
 	mempool module.MempoolMetrics,
+	metrics module.ConsensusMetrics,
 	state protocol.State,
 	headers storage.Headers,
 	pool mempool.Guarantees,
@@ -42,18 +47,23 @@ func NewCore(
 		log:     log.With().Str("ingestion", "core").Logger(),
 		tracer:  tracer,
 		mempool: mempool,
+		metrics: metrics,
 		state:   state,
 		headers: headers,
 		pool:    pool,
+		pending: newPendingGuarantees(DefaultPendingGuaranteesCapacity, DefaultPendingGuaranteesTTL),
 	}
 }
 
 // OnGuarantee is used to process collection guarantees received
 // from nodes that are not consensus nodes (notably collection nodes).
+// Guarantees with an unknown reference block are cached for retry.
 // Returns expected errors:
 //  * engine.InvalidInputError if the collection violates protocol rules
 //  * engine.UnverifiableInputError if the reference block of the collection is unknown
+//    and the guarantee can't be cached for retry
 //  * engine.OutdatedInputError if the collection is already expired
+// The errors wrap the reason for rejecting the guarantee, as defined in errors.go.
 // All other errors are unexpected and potential symptoms of internal state corruption.
 func (e *Core) OnGuarantee(originID flow.Identifier, guarantee *flow.CollectionGuarantee) error {
 
@@ -79,18 +89,27 @@ func (e *Core) OnGuarantee(originID flow.Identifier, guarantee *flow.CollectionG
 		return nil
 	}
 
-	// check collection guarantee's validity
-	err := e.validateOrigin(originID, guarantee) // retrieve and validate the sender of the collection guarantee
-	if err != nil {
-		return fmt.Errorf("origin validation error: %w", err)
+	// resolve the reference block of the collection guarantee; it might not have reached us yet,
+	// so guarantees with an unknown reference block are cached for retry
+	ref, err := e.headers.ByBlockID(guarantee.ReferenceBlockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		if !e.pending.Add(originID, guarantee, time.Now()) {
+			e.metrics.RejectedGuarantee(rejectionReason(ErrUnknownReferenceBlock))
+			return engine.NewUnverifiableInputError("collection guarantee refers to an unknown block (id=%x) and too many guarantees are pending: %w", guarantee.ReferenceBlockID, ErrUnknownReferenceBlock)
+		}
+		log.Debug().Hex("reference_block_id", guarantee.ReferenceBlockID[:]).Msg("caching collection guarantee with unknown reference block")
+		e.mempool.MempoolEntries(metrics.ResourcePendingGuarantee, e.pending.Size())
+		return nil
 	}
-	err = e.validateExpiry(guarantee) // ensure that collection has not expired
 	if err != nil {
-		return fmt.Errorf("expiry validation error: %w", err)
+		return fmt.Errorf("could not retrieve reference block (id=%x): %w", guarantee.ReferenceBlockID, err)
 	}
-	err = e.validateGuarantors(guarantee) // ensure the guarantors are allowed to produce this collection
+
+	// check collection guarantee's validity
+	err = e.validateGuarantee(originID, guarantee, ref)
 	if err != nil {
-		return fmt.Errorf("guarantor validation error: %w", err)
+		e.metrics.RejectedGuarantee(rejectionReason(err))
+		return err
 	}
 
 	// at this point, we can add the guarantee to the memory pool
@@ -105,22 +124,73 @@ func (e *Core) OnGuarantee(originID flow.Identifier, guarantee *flow.CollectionG
 	return nil
 }
 
-// validateExpiry validates that the collection has not expired w.r.t. the local
-// latest finalized block.
+// RetryPendingGuarantees processes the cached guarantees, whose reference blocks are known by now,
+// and drops the ones which were cached for longer than their TTL. Invalid guarantees are logged
+// and dropped.
+// No errors are expected during normal operation.
+func (e *Core) RetryPendingGuarantees() error {
+	dropped := e.pending.Expire(time.Now())
+	for i := uint(0); i < dropped; i++ {
+		e.metrics.RejectedGuarantee(rejectionReason(ErrUnknownReferenceBlock))
+	}
+
+	for _, refID := range e.pending.References() {
+		_, err := e.headers.ByBlockID(refID)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve reference block (id=%x): %w", refID, err)
+		}
+
+		for _, pending := range e.pending.RemoveByReference(refID) {
+			err := e.OnGuarantee(pending.originID, pending.guarantee)
+			if engine.IsInvalidInputError(err) || engine.IsOutdatedInputError(err) || engine.IsUnverifiableInputError(err) {
+				e.log.Warn().Hex("origin_id", pending.originID[:]).Err(err).Msg("dropping pending collection guarantee")
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not process pending collection guarantee: %w", err)
+			}
+		}
+	}
+
+	e.mempool.MempoolEntries(metrics.ResourcePendingGuarantee, e.pending.Size())
+	return nil
+}
+
+// validateGuarantee validates the collection guarantee w.r.t. its reference block.
 // Expected errors during normal operation:
-//  * engine.UnverifiableInputError if the reference block of the collection is unknown
+//  * engine.InvalidInputError if the collection violates protocol rules
 //  * engine.OutdatedInputError if the collection is already expired
 // All other errors are unexpected and potential symptoms of internal state corruption.
-func (e *Core) validateExpiry(guarantee *flow.CollectionGuarantee) error {
-	// get the last finalized header and the reference block header
+func (e *Core) validateGuarantee(originID flow.Identifier, guarantee *flow.CollectionGuarantee, ref *flow.Header) error {
+	err := e.validateExpiry(ref) // ensure that collection has not expired
+	if err != nil {
+		return fmt.Errorf("expiry validation error: %w", err)
+	}
+	err = e.validateOrigin(originID, guarantee) // retrieve and validate the sender of the collection guarantee
+	if err != nil {
+		return fmt.Errorf("origin validation error: %w", err)
+	}
+	err = e.validateGuarantors(guarantee) // ensure the guarantors are allowed to produce this collection
+	if err != nil {
+		return fmt.Errorf("guarantor validation error: %w", err)
+	}
+	return nil
+}
+
+// validateExpiry validates that the collection with the given reference block has not
+// expired w.r.t. the local latest finalized block.
+// Expected errors during normal operation:
+//  * engine.OutdatedInputError if the collection is already expired
+// All other errors are unexpected and potential symptoms of internal state corruption.
+func (e *Core) validateExpiry(ref *flow.Header) error {
+	// get the last finalized header
 	final, err := e.state.Final().Head()
 	if err != nil {
 		return fmt.Errorf("could not get finalized header: %w", err)
 	}
-	ref, err := e.headers.ByBlockID(guarantee.ReferenceBlockID)
-	if errors.Is(err, storage.ErrNotFound) {
-		return engine.NewUnverifiableInputError("collection guarantee refers to an unknown block (id=%x): %w", guarantee.ReferenceBlockID, err)
-	}
 
 	// if head has advanced beyond the block referenced by the collection guarantee by more than 'expiry' number of blocks,
 	// then reject the collection
@@ -128,51 +198,61 @@ func (e *Core) validateExpiry(guarantee *flow.CollectionGuarantee) error {
 		return nil // the reference block is newer than the latest finalized one
 	}
 	if final.Height-ref.Height > flow.DefaultTransactionExpiry {
-		return engine.NewOutdatedInputErrorf("collection guarantee expired ref_height=%d final_height=%d", ref.Height, final.Height)
+		return engine.NewOutdatedInputErrorf("collection guarantee expired ref_height=%d final_height=%d: %w", ref.Height, final.Height, ErrExpiredGuarantee)
 	}
 
 	return nil
 }
 
 // validateGuarantors validates that the guarantors of a collection are valid,
-// in that they are all from the same cluster and that cluster is allowed to
-// produce the given collection w.r.t. the guarantee's reference block.
+// in that they are all from the same cluster, that cluster is allowed to
+// produce the given collection w.r.t. the guarantee's reference block, and
+// they hold more than 2/3 of the cluster's stake.
 // Expected errors during normal operation:
-//  * engine.InvalidInputError if the origin violates any requirements
-//  * engine.UnverifiableInputError if the reference block of the collection is unknown
+//  * engine.InvalidInputError if the guarantors violate any requirements
 // All other errors are unexpected and potential symptoms of internal state corruption.
-// TODO: Eventually we should check the signatures, ensure a quorum of the
-//	     cluster, and ensure HotStuff finalization rules. Likely a cluster-specific
-//	     version of the follower will be a good fit for this. For now, collection
-//	     nodes independently decide when a collection is finalized and we only check
-//	     that the guarantors are all from the same cluster. This implementation is NOT BFT.
+// TODO: Eventually we should check the signatures and ensure HotStuff finalization
+//	     rules. Likely a cluster-specific version of the follower will be a good fit
+//	     for this. For now, collection nodes independently decide when a collection
+//	     is finalized and we only check that the guarantors are from the same cluster
+//	     and hold sufficient stake. This implementation is NOT BFT.
 func (e *Core) validateGuarantors(guarantee *flow.CollectionGuarantee) error {
 	guarantors := guarantee.SignerIDs
 	if len(guarantors) == 0 {
-		return engine.NewInvalidInputError("invalid collection guarantee with no guarantors")
+		return engine.NewInvalidInputErrorf("invalid collection guarantee: %w", ErrNoGuarantors)
 	}
 
-	// get the clusters to assign the guarantee and check if the guarantor is part of it
+	// get the clusters of the reference block's epoch to assign the guarantee and check if the guarantor is part of it
 	snapshot := e.state.AtBlockID(guarantee.ReferenceBlockID)
 	clusters, err := snapshot.Epochs().Current().Clustering()
-	if errors.Is(err, storage.ErrNotFound) {
-		return engine.NewUnverifiableInputError("could not get clusters for unknown reference block (id=%x): %w", guarantee.ReferenceBlockID, err)
-	}
 	if err != nil {
 		return fmt.Errorf("internal error retrieving collector clusters: %w", err)
 	}
 	cluster, _, ok := clusters.ByNodeID(guarantors[0])
 	if !ok {
-		return engine.NewInvalidInputErrorf("guarantor (id=%s) does not exist in any cluster", guarantors[0])
+		return engine.NewInvalidInputErrorf("guarantor (id=%s) does not exist in any cluster: %w", guarantors[0], ErrGuarantorNotInCluster)
 	}
 
-	// ensure the guarantors are from the same cluster
+	// ensure the guarantors are from the same cluster, counting the stake of each guarantor once
 	clusterLookup := cluster.Lookup()
+	counted := make(map[flow.Identifier]struct{}, len(guarantors))
+	guarantorStake := uint64(0)
 	for _, guarantorID := range guarantors {
-		_, exists := clusterLookup[guarantorID]
+		member, exists := clusterLookup[guarantorID]
 		if !exists {
-			return engine.NewInvalidInputError("inconsistent guarantors from different clusters")
+			return engine.NewInvalidInputErrorf("inconsistent guarantors from different clusters (id=%s): %w", guarantorID, ErrGuarantorNotInCluster)
 		}
+		if _, duplicate := counted[guarantorID]; duplicate {
+			continue
+		}
+		counted[guarantorID] = struct{}{}
+		guarantorStake += member.Stake
+	}
+
+	// ensure the guarantors hold sufficient stake to finalize the collection in the cluster
+	threshold := hotstuff.ComputeStakeThresholdForBuildingQC(cluster.TotalStake())
+	if guarantorStake < threshold {
+		return engine.NewInvalidInputErrorf("guarantors hold insufficient stake (stake=%d, threshold=%d): %w", guarantorStake, threshold, ErrInsufficientGuarantorStake)
 	}
 
 	return nil
@@ -184,7 +264,6 @@ func (e *Core) validateGuarantors(guarantee *flow.CollectionGuarantee) error {
 // a staked, non-ejected collector node.
 // Expected errors during normal operation:
 //  * engine.InvalidInputError if the origin violates any requirements
-//  * engine.UnverifiableInputError if the reference block of the collection is unknown
 // All other errors are unexpected and potential symptoms of internal state corruption.
 //
 // TODO: ultimately, the origin broadcasting a collection is irrelevant, as long as the
@@ -194,14 +273,10 @@ func (e *Core) validateOrigin(originID flow.Identifier, guarantee *flow.Collecti
 	refState := e.state.AtBlockID(guarantee.ReferenceBlockID)
 	valid, err := protocol.IsNodeStakedWithRoleAt(refState, originID, flow.RoleCollection)
 	if err != nil {
-		// collection with an unknown reference block is unverifiable
-		if errors.Is(err, storage.ErrNotFound) {
-			return engine.NewUnverifiableInputError("could not get origin (id=%x) for unknown reference block (id=%x): %w", originID, guarantee.ReferenceBlockID, err)
-		}
 		return fmt.Errorf("unexpected error checking collection origin %x at reference block %x: %w", originID, guarantee.ReferenceBlockID, err)
 	}
 	if !valid {
-		return engine.NewInvalidInputErrorf("invalid collection origin (id=%x)", originID)
+		return engine.NewInvalidInputErrorf("invalid collection origin (id=%x): %w", originID, ErrInvalidOrigin)
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ package ingestion
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -12,9 +13,11 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	mockmempool "github.com/onflow/flow-go/module/mempool/mock"
 	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/state/protocol"
 	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
 	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -35,6 +38,7 @@ type IngestionCoreSuite struct {
 
 	finalIdentities flow.IdentityList // identities at finalized state
 	refIdentities   flow.IdentityList // identities at reference block state
+	clusters        flow.ClusterList  // clusters of the reference block's epoch
 
 	final *mockprotocol.Snapshot // finalized state snapshot
 	ref   *mockprotocol.Snapshot // state snapshot w.r.t. reference block
@@ -64,7 +68,7 @@ func (suite *IngestionCoreSuite) SetupTest() {
 	suite.execID = exec.NodeID
 	suite.verifID = verif.NodeID
 
-	clusters := flow.ClusterList{flow.IdentityList{coll}}
+	suite.clusters = flow.ClusterList{flow.IdentityList{coll}}
 
 	identities := flow.IdentityList{access, con, coll, exec, verif}
 	suite.finalIdentities = identities.Copy()
@@ -106,7 +110,12 @@ func (suite *IngestionCoreSuite) SetupTest() {
 	)
 	ref.On("Epochs").Return(suite.query)
 	suite.query.On("Current").Return(suite.epoch)
-	suite.epoch.On("Clustering").Return(clusters, nil)
+	suite.epoch.On("Clustering").Return(
+		func() flow.ClusterList {
+			return suite.clusters
+		},
+		nil,
+	)
 
 	state.On("AtBlockID", mock.Anything).Return(ref)
 	ref.On("Identity", mock.Anything).Return(
@@ -129,7 +138,7 @@ func (suite *IngestionCoreSuite) SetupTest() {
 	// only used for metrics, nobody cares
 	pool.On("Size").Return(uint(0))
 
-	ingest := NewCore(unittest.Logger(), tracer, metrics, state, headers, pool)
+	ingest := NewCore(unittest.Logger(), tracer, metrics, metrics, state, headers, pool)
 
 	suite.head = &head
 	suite.final = final
@@ -205,6 +214,7 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeNoGuarantors() {
 	err := suite.core.OnGuarantee(suite.collID, guarantee)
 	suite.Assert().Error(err, "should error with missing guarantor")
 	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().ErrorIs(err, ErrNoGuarantors)
 
 	// check that the guarantee has _not_ been added to the mempool
 	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
@@ -227,6 +237,7 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeInvalidRole() {
 		err := suite.core.OnGuarantee(suite.collID, guarantee)
 		suite.Assert().Error(err, "should error with missing guarantor")
 		suite.Assert().True(engine.IsInvalidInputError(err))
+		suite.Assert().ErrorIs(err, ErrGuarantorNotInCluster)
 
 		// check that the guarantee has _not_ been added to the mempool
 		suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
@@ -253,6 +264,7 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeExpired() {
 	err := suite.core.OnGuarantee(suite.collID, guarantee)
 	suite.Assert().Error(err, "should error with expired collection")
 	suite.Assert().True(engine.IsOutdatedInputError(err))
+	suite.Assert().ErrorIs(err, ErrExpiredGuarantee)
 
 }
 
@@ -272,6 +284,7 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeInvalidGuarantor() {
 	err := suite.core.OnGuarantee(suite.collID, guarantee)
 	suite.Assert().Error(err, "should error with invalid guarantor")
 	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().ErrorIs(err, ErrGuarantorNotInCluster)
 
 	// check that the guarantee has _not_ been added to the mempool
 	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
@@ -316,9 +329,185 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeUnknownOrigin() {
 	err := suite.core.OnGuarantee(unittest.IdentifierFixture(), guarantee)
 	suite.Assert().Error(err)
 	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().ErrorIs(err, ErrInvalidOrigin)
+
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+
+}
+
+// TestOnGuaranteeWrongCluster verifies that collections with guarantors from
+// different clusters of the reference block's epoch are rejected.
+func (suite *IngestionCoreSuite) TestOnGuaranteeWrongCluster() {
+
+	// split the collectors into two clusters
+	collectors := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleCollection))
+	suite.clusters = unittest.ClusterList(2, collectors)
+	suite.refIdentities = append(suite.refIdentities, collectors...)
+
+	guarantee := suite.validGuarantee()
+	guarantee.SignerIDs = append(suite.clusters[0].NodeIDs(), suite.clusters[1][0].NodeID)
+	conMetrics := suite.expectRejection("guarantor_not_in_cluster")
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+
+	err := suite.core.OnGuarantee(collectors[0].NodeID, guarantee)
+	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().ErrorIs(err, ErrGuarantorNotInCluster)
+
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+	conMetrics.AssertExpectations(suite.T())
+}
+
+// TestOnGuaranteeWrongEpoch verifies that collections are rejected, if their guarantors
+// are not part of any cluster of the reference block's epoch.
+func (suite *IngestionCoreSuite) TestOnGuaranteeWrongEpoch() {
+
+	// the epoch of the reference block is served by other collectors
+	suite.clusters = unittest.ClusterList(1, unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleCollection)))
+
+	guarantee := suite.validGuarantee()
+	conMetrics := suite.expectRejection("guarantor_not_in_cluster")
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+
+	err := suite.core.OnGuarantee(suite.collID, guarantee)
+	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().ErrorIs(err, ErrGuarantorNotInCluster)
+
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+	conMetrics.AssertExpectations(suite.T())
+}
+
+// TestOnGuaranteeInsufficientStake verifies that collections are rejected, if their
+// guarantors hold at most 2/3 of the stake of their cluster. Guarantors listed
+// repeatedly count once.
+func (suite *IngestionCoreSuite) TestOnGuaranteeInsufficientStake() {
+
+	collectors := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleCollection))
+	suite.clusters = unittest.ClusterList(1, collectors)
+	suite.refIdentities = append(suite.refIdentities, collectors...)
+
+	signers := suite.clusters[0].NodeIDs()
+	guarantee := suite.validGuarantee()
+	guarantee.SignerIDs = []flow.Identifier{signers[0], signers[1], signers[1]}
+	conMetrics := suite.expectRejection("insufficient_stake")
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+
+	err := suite.core.OnGuarantee(collectors[0].NodeID, guarantee)
+	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().ErrorIs(err, ErrInsufficientGuarantorStake)
+
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+	conMetrics.AssertExpectations(suite.T())
+}
+
+// TestOnGuaranteeSufficientStake verifies that collections are accepted, if their
+// guarantors hold more than 2/3 of the stake of their cluster.
+func (suite *IngestionCoreSuite) TestOnGuaranteeSufficientStake() {
+
+	collectors := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleCollection))
+	suite.clusters = unittest.ClusterList(1, collectors)
+	suite.refIdentities = append(suite.refIdentities, collectors...)
 
+	guarantee := suite.validGuarantee()
+	guarantee.SignerIDs = suite.clusters[0].NodeIDs()[:3]
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+	suite.pool.On("Add", guarantee).Return(true).Once()
+
+	err := suite.core.OnGuarantee(collectors[0].NodeID, guarantee)
+	suite.Assert().NoError(err)
+
+	suite.pool.AssertExpectations(suite.T())
+}
+
+// TestOnGuaranteeUnknownReference verifies that collections with an unknown reference
+// block are cached, and processed once the reference block is known.
+func (suite *IngestionCoreSuite) TestOnGuaranteeUnknownReference() {
+
+	ref := unittest.BlockHeaderWithParentFixture(suite.head)
+	suite.headers.On("ByBlockID", ref.ID()).Return(nil, storage.ErrNotFound).Twice()
+
+	guarantee := suite.validGuarantee()
+	guarantee.ReferenceBlockID = ref.ID()
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+	suite.pool.On("Add", guarantee).Return(true).Once()
+
+	// the guarantee is cached rather than rejected
+	err := suite.core.OnGuarantee(suite.collID, guarantee)
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(uint(1), suite.core.pending.Size())
 	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
 
+	// the guarantee is kept while the reference block is unknown
+	err = suite.core.RetryPendingGuarantees()
+	suite.Require().NoError(err)
+	suite.Assert().Equal(uint(1), suite.core.pending.Size())
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+
+	// once the reference block is known, the guarantee is processed
+	suite.headers.On("ByBlockID", ref.ID()).Return(&ref, nil)
+	err = suite.core.RetryPendingGuarantees()
+	suite.Require().NoError(err)
+	suite.Assert().Zero(suite.core.pending.Size())
+	suite.pool.AssertExpectations(suite.T())
+}
+
+// TestOnGuaranteeUnknownReferenceFull verifies that collections with an unknown reference
+// block are rejected, if too many guarantees are cached already.
+func (suite *IngestionCoreSuite) TestOnGuaranteeUnknownReferenceFull() {
+	suite.core.pending = newPendingGuarantees(0, DefaultPendingGuaranteesTTL)
+
+	guarantee := suite.validGuarantee()
+	guarantee.ReferenceBlockID = unittest.IdentifierFixture()
+	suite.headers.On("ByBlockID", guarantee.ReferenceBlockID).Return(nil, storage.ErrNotFound)
+	conMetrics := suite.expectRejection("unknown_reference_block")
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+
+	err := suite.core.OnGuarantee(suite.collID, guarantee)
+	suite.Assert().True(engine.IsUnverifiableInputError(err))
+	suite.Assert().ErrorIs(err, ErrUnknownReferenceBlock)
+
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+	conMetrics.AssertExpectations(suite.T())
+}
+
+// TestRetryPendingGuaranteesExpired verifies that cached collections are dropped, once
+// they were cached for longer than the TTL.
+func (suite *IngestionCoreSuite) TestRetryPendingGuaranteesExpired() {
+	suite.core.pending = newPendingGuarantees(DefaultPendingGuaranteesCapacity, time.Nanosecond)
+
+	guarantee := suite.validGuarantee()
+	guarantee.ReferenceBlockID = unittest.IdentifierFixture()
+	suite.headers.On("ByBlockID", guarantee.ReferenceBlockID).Return(nil, storage.ErrNotFound).Once()
+
+	suite.pool.On("Has", guarantee.ID()).Return(false)
+
+	err := suite.core.OnGuarantee(suite.collID, guarantee)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint(1), suite.core.pending.Size())
+
+	conMetrics := suite.expectRejection("unknown_reference_block")
+	time.Sleep(time.Millisecond)
+	err = suite.core.RetryPendingGuarantees()
+	suite.Require().NoError(err)
+	suite.Assert().Zero(suite.core.pending.Size())
+
+	suite.headers.AssertNumberOfCalls(suite.T(), "ByBlockID", 1)
+	suite.pool.AssertNotCalled(suite.T(), "Add", guarantee)
+	conMetrics.AssertExpectations(suite.T())
+}
+
+// expectRejection replaces the metrics of the core by a mock, which expects a single
+// rejected guarantee with the given reason.
+func (suite *IngestionCoreSuite) expectRejection(reason string) *mockmodule.ConsensusMetrics {
+	conMetrics := &mockmodule.ConsensusMetrics{}
+	conMetrics.On("RejectedGuarantee", reason).Once()
+	suite.core.metrics = conMetrics
+	return conMetrics
 }
 
 // validGuarantee returns a valid collection guarantee based on the suite state.
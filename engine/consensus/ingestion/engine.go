@@ -5,6 +5,7 @@ package ingestion
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -24,6 +25,9 @@ const defaultGuaranteeQueueCapacity = 1000
 // defaultIngestionEngineWorkers number of goroutines engine will use for processing events
 const defaultIngestionEngineWorkers = 3
 
+// defaultPendingGuaranteesRetryInterval is the interval at which guarantees with unknown reference blocks are retried
+const defaultPendingGuaranteesRetryInterval = time.Second
+
 // Engine represents the ingestion engine, used to funnel collections from a
 // cluster of collection nodes to the set of consensus nodes. It represents the
 // link between collection nodes and consensus nodes and has a counterpart with
@@ -97,6 +101,11 @@ func New(
 		})
 	}
 
+	componentManagerBuilder.AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+		ready()
+		e.retryLoop(ctx)
+	})
+
 	e.ComponentManager = componentManagerBuilder.Build()
 
 	// register the engine with the network layer and store the conduit
@@ -196,3 +205,20 @@ func (e *Engine) loop(ctx context.Context) error {
 		}
 	}
 }
+
+// retryLoop periodically retries the collection guarantees with unknown reference blocks.
+func (e *Engine) retryLoop(ctx irrecoverable.SignalerContext) {
+	ticker := time.NewTicker(defaultPendingGuaranteesRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := e.core.RetryPendingGuarantees()
+			if err != nil {
+				ctx.Throw(fmt.Errorf("internal error retrying pending guarantees: %w", err))
+			}
+		}
+	}
+}
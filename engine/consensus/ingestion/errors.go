@@ -0,0 +1,36 @@
+package ingestion
+
+import (
+	"errors"
+)
+
+// Reasons for rejecting collection guarantees. The engine errors returned by the core wrap
+// them, so that the reason can be determined with errors.Is.
+var (
+	ErrUnknownReferenceBlock      = errors.New("unknown reference block")
+	ErrExpiredGuarantee           = errors.New("expired guarantee")
+	ErrInvalidOrigin              = errors.New("invalid origin")
+	ErrNoGuarantors               = errors.New("no guarantors")
+	ErrGuarantorNotInCluster      = errors.New("guarantor not in responsible cluster")
+	ErrInsufficientGuarantorStake = errors.New("insufficient guarantor stake")
+)
+
+// rejectionReason returns the metrics label of the reason for rejecting a guarantee with the given error.
+func rejectionReason(err error) string {
+	switch {
+	case errors.Is(err, ErrUnknownReferenceBlock):
+		return "unknown_reference_block"
+	case errors.Is(err, ErrExpiredGuarantee):
+		return "expired"
+	case errors.Is(err, ErrInvalidOrigin):
+		return "invalid_origin"
+	case errors.Is(err, ErrNoGuarantors):
+		return "no_guarantors"
+	case errors.Is(err, ErrGuarantorNotInCluster):
+		return "guarantor_not_in_cluster"
+	case errors.Is(err, ErrInsufficientGuarantorStake):
+		return "insufficient_stake"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,133 @@
+package ingestion
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// DefaultPendingGuaranteesCapacity is the default maximum number of guarantees with unknown
+	// reference blocks, which are cached for retry.
+	DefaultPendingGuaranteesCapacity = 1000
+
+	// DefaultPendingGuaranteesTTL is the default duration, for which guarantees with unknown
+	// reference blocks are cached for retry.
+	DefaultPendingGuaranteesTTL = 30 * time.Second
+)
+
+// pendingGuarantee is a guarantee with an unknown reference block, cached for retry.
+type pendingGuarantee struct {
+	originID  flow.Identifier
+	guarantee *flow.CollectionGuarantee
+	expiry    time.Time
+}
+
+// pendingGuarantees caches guarantees with unknown reference blocks for a short time. Collection
+// nodes may reference blocks, which did not reach this node yet, so the guarantees are retried
+// once their reference blocks are known, instead of being dropped.
+type pendingGuarantees struct {
+	sync.Mutex
+	capacity    uint
+	ttl         time.Duration
+	byID        map[flow.Identifier]*pendingGuarantee
+	byReference map[flow.Identifier]map[flow.Identifier]struct{} // reference block ID -> guarantee IDs
+}
+
+func newPendingGuarantees(capacity uint, ttl time.Duration) *pendingGuarantees {
+	return &pendingGuarantees{
+		capacity:    capacity,
+		ttl:         ttl,
+		byID:        make(map[flow.Identifier]*pendingGuarantee),
+		byReference: make(map[flow.Identifier]map[flow.Identifier]struct{}),
+	}
+}
+
+// Add caches the guarantee received at the given time. It returns false if the cache is full.
+// Guarantees which are cached already keep their origin and expiry.
+func (p *pendingGuarantees) Add(originID flow.Identifier, guarantee *flow.CollectionGuarantee, now time.Time) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	guaranteeID := guarantee.ID()
+	if _, ok := p.byID[guaranteeID]; ok {
+		return true
+	}
+	if uint(len(p.byID)) >= p.capacity {
+		return false
+	}
+
+	p.byID[guaranteeID] = &pendingGuarantee{
+		originID:  originID,
+		guarantee: guarantee,
+		expiry:    now.Add(p.ttl),
+	}
+	guarantees, ok := p.byReference[guarantee.ReferenceBlockID]
+	if !ok {
+		guarantees = make(map[flow.Identifier]struct{})
+		p.byReference[guarantee.ReferenceBlockID] = guarantees
+	}
+	guarantees[guaranteeID] = struct{}{}
+	return true
+}
+
+// Expire drops the guarantees, which were cached for longer than the TTL at the given time,
+// and returns their number.
+func (p *pendingGuarantees) Expire(now time.Time) uint {
+	p.Lock()
+	defer p.Unlock()
+
+	dropped := uint(0)
+	for guaranteeID, pending := range p.byID {
+		if now.Before(pending.expiry) {
+			continue
+		}
+		p.remove(guaranteeID, pending.guarantee.ReferenceBlockID)
+		dropped++
+	}
+	return dropped
+}
+
+// References returns the IDs of the reference blocks of the cached guarantees.
+func (p *pendingGuarantees) References() []flow.Identifier {
+	p.Lock()
+	defer p.Unlock()
+
+	refIDs := make([]flow.Identifier, 0, len(p.byReference))
+	for refID := range p.byReference {
+		refIDs = append(refIDs, refID)
+	}
+	return refIDs
+}
+
+// RemoveByReference removes and returns the cached guarantees referencing the given block.
+func (p *pendingGuarantees) RemoveByReference(refID flow.Identifier) []*pendingGuarantee {
+	p.Lock()
+	defer p.Unlock()
+
+	guarantees := p.byReference[refID]
+	removed := make([]*pendingGuarantee, 0, len(guarantees))
+	for guaranteeID := range guarantees {
+		removed = append(removed, p.byID[guaranteeID])
+		p.remove(guaranteeID, refID)
+	}
+	return removed
+}
+
+// Size returns the number of cached guarantees.
+func (p *pendingGuarantees) Size() uint {
+	p.Lock()
+	defer p.Unlock()
+	return uint(len(p.byID))
+}
+
+// remove removes the given guarantee. It must be called while holding the lock.
+func (p *pendingGuarantees) remove(guaranteeID flow.Identifier, refID flow.Identifier) {
+	delete(p.byID, guaranteeID)
+	guarantees := p.byReference[refID]
+	delete(guarantees, guaranteeID)
+	if len(guarantees) == 0 {
+		delete(p.byReference, refID)
+	}
+}
@@ -0,0 +1,63 @@
+package ingestion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestPendingGuarantees_Capacity tests that the cache rejects guarantees once it is full,
+// while guarantees which are cached already are accepted again.
+func TestPendingGuarantees_Capacity(t *testing.T) {
+	pending := newPendingGuarantees(2, DefaultPendingGuaranteesTTL)
+	now := time.Now()
+
+	guarantees := unittest.CollectionGuaranteesFixture(3)
+	require.True(t, pending.Add(unittest.IdentifierFixture(), guarantees[0], now))
+	require.True(t, pending.Add(unittest.IdentifierFixture(), guarantees[1], now))
+	assert.False(t, pending.Add(unittest.IdentifierFixture(), guarantees[2], now))
+	assert.True(t, pending.Add(unittest.IdentifierFixture(), guarantees[0], now))
+	assert.Equal(t, uint(2), pending.Size())
+}
+
+// TestPendingGuarantees_Expire tests that guarantees are dropped once they were cached for
+// longer than the TTL.
+func TestPendingGuarantees_Expire(t *testing.T) {
+	pending := newPendingGuarantees(DefaultPendingGuaranteesCapacity, time.Minute)
+	now := time.Now()
+
+	guarantees := unittest.CollectionGuaranteesFixture(2)
+	require.True(t, pending.Add(unittest.IdentifierFixture(), guarantees[0], now))
+	require.True(t, pending.Add(unittest.IdentifierFixture(), guarantees[1], now.Add(time.Second)))
+
+	assert.Zero(t, pending.Expire(now.Add(time.Minute-time.Nanosecond)))
+	assert.Equal(t, uint(1), pending.Expire(now.Add(time.Minute)))
+	assert.Equal(t, []flow.Identifier{guarantees[1].ReferenceBlockID}, pending.References())
+}
+
+// TestPendingGuarantees_RemoveByReference tests that guarantees are removed by their reference block.
+func TestPendingGuarantees_RemoveByReference(t *testing.T) {
+	pending := newPendingGuarantees(DefaultPendingGuaranteesCapacity, DefaultPendingGuaranteesTTL)
+	now := time.Now()
+
+	refID := unittest.IdentifierFixture()
+	referencing := unittest.CollectionGuaranteesFixture(2, unittest.WithCollRef(refID))
+	other := unittest.CollectionGuaranteeFixture()
+	for _, guarantee := range append(referencing, other) {
+		require.True(t, pending.Add(unittest.IdentifierFixture(), guarantee, now))
+	}
+	assert.ElementsMatch(t, []flow.Identifier{refID, other.ReferenceBlockID}, pending.References())
+
+	var removed []*flow.CollectionGuarantee
+	for _, entry := range pending.RemoveByReference(refID) {
+		removed = append(removed, entry.guarantee)
+	}
+	assert.ElementsMatch(t, referencing, removed)
+	assert.Equal(t, []flow.Identifier{other.ReferenceBlockID}, pending.References())
+	assert.Empty(t, pending.RemoveByReference(refID))
+}
@@ -17,4 +17,8 @@ type MatchingCore interface {
 	// * exception in case of unexpected error
 	// * nil - successfully processed finalized block
 	OnBlockFinalization() error
+	// Healthy returns false if the core repeatedly failed to read from storage, for
+	// reasons other than missing data. The engine crashes the node, once the core
+	// is unhealthy after processing a finalized block.
+	Healthy() bool
 }
@@ -0,0 +1,117 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkMatchingThroughput measures the sealing throughput of the matching core together with
+// the approval collectors of the sealing core, for each workload profile. The inputs are generated
+// from the fixed seed of the profile, so results of separate runs can be compared:
+//
+//   go test -run=^$ -bench=BenchmarkMatchingThroughput -count=10 ./engine/consensus/matching
+//
+// Besides time and allocations per iteration, each profile reports the number of seals per second
+// spent processing receipts and approvals, and the p50/p99 latencies of processing single receipts
+// and approvals in nanoseconds.
+func BenchmarkMatchingThroughput(b *testing.B) {
+	profiles := []workloadProfile{
+		steadyStateProfile,
+		catchUpProfile,
+		approvalFloodProfile,
+	}
+	for _, profile := range profiles {
+		profile := profile
+		b.Run(profile.name, func(b *testing.B) {
+			benchmarkWorkload(b, profile)
+		})
+	}
+}
+
+func benchmarkWorkload(b *testing.B, profile workloadProfile) {
+	w := generateWorkload(profile)
+
+	var (
+		busy              time.Duration
+		seals             int
+		receiptLatencies  []time.Duration
+		approvalLatencies []time.Duration
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		h := newHarness(w)
+		b.StartTimer()
+
+		elapsed, err := h.run()
+		require.NoError(b, err)
+
+		busy += elapsed
+		seals += h.sealCount
+		receiptLatencies = append(receiptLatencies, h.receiptLatencies...)
+		approvalLatencies = append(approvalLatencies, h.approvalLatencies...)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(seals)/busy.Seconds(), "seals/s")
+	b.ReportMetric(float64(percentile(receiptLatencies, 0.5).Nanoseconds()), "receipt-p50-ns")
+	b.ReportMetric(float64(percentile(receiptLatencies, 0.99).Nanoseconds()), "receipt-p99-ns")
+	b.ReportMetric(float64(percentile(approvalLatencies, 0.5).Nanoseconds()), "approval-p50-ns")
+	b.ReportMetric(float64(percentile(approvalLatencies, 0.99).Nanoseconds()), "approval-p99-ns")
+}
+
+// TestMatchingWorkload_SealsAllBlocks checks that the harness seals every block of each workload
+// profile, so the throughput reported by the benchmark covers the full workload.
+func TestMatchingWorkload_SealsAllBlocks(t *testing.T) {
+	profiles := []workloadProfile{
+		steadyStateProfile,
+		catchUpProfile,
+		approvalFloodProfile,
+	}
+	for _, profile := range profiles {
+		profile := profile
+		t.Run(profile.name, func(t *testing.T) {
+			// a shorter simulated duration is sufficient to cover the outage
+			profile.duration = profile.outageBlocks + 20
+			w := generateWorkload(profile)
+
+			h := newHarness(w)
+			_, err := h.run()
+			require.NoError(t, err)
+			require.Equal(t, len(w.headers), h.sealCount)
+			require.Len(t, h.receiptLatencies, countReceipts(w))
+		})
+	}
+}
+
+// TestMatchingWorkload_Deterministic checks that workloads are generated deterministically from
+// the seed of the profile.
+func TestMatchingWorkload_Deterministic(t *testing.T) {
+	profile := catchUpProfile
+	profile.duration = profile.outageBlocks + 5
+
+	first := generateWorkload(profile)
+	second := generateWorkload(profile)
+	require.Equal(t, len(first.events), len(second.events))
+	for i := range first.events {
+		require.Equal(t, first.events[i], second.events[i])
+	}
+
+	profile.seed++
+	other := generateWorkload(profile)
+	require.NotEqual(t, first.headers[0].ID(), other.headers[0].ID())
+}
+
+// countReceipts returns the number of receipts delivered by the workload, including duplicates.
+func countReceipts(w *workload) int {
+	count := 0
+	for _, event := range w.events {
+		if event.receipt != nil {
+			count++
+		}
+	}
+	return count
+}
@@ -6,18 +6,22 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/engine"
+	sealing "github.com/onflow/flow-go/engine/consensus"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/logging"
@@ -25,20 +29,48 @@ import (
 
 // Config is a structure of values that configure behavior of matching engine
 type Config struct {
-	SealingThreshold    uint // threshold between sealed and finalized blocks
-	MaxResultsToRequest uint // maximum number of receipts to request
+	SealingThreshold        uint          // threshold between sealed and finalized blocks
+	MaxResultsToRequest     uint          // maximum number of receipts to request
+	StorageFailureThreshold uint          // number of consecutive unexpected storage errors, beyond which the core is unhealthy
+	RequestRetryInitial     time.Duration // interval after the first request for the receipts of a block, before it is requested again after a restart
+	RequestRetryMaximum     time.Duration // maximum interval between requests for the receipts of a block after a restart
 }
 
 func DefaultConfig() Config {
 	return Config{
-		SealingThreshold:    10,
-		MaxResultsToRequest: 20,
+		SealingThreshold:        10,
+		MaxResultsToRequest:     20,
+		StorageFailureThreshold: 3,
+		RequestRetryInitial:     10 * time.Second,
+		RequestRetryMaximum:     2 * time.Minute,
 	}
 }
 
+// receiptRequest is the bookkeeping of the requests for the receipts of a block.
+type receiptRequest struct {
+	*flow.ReceiptRequest
+	resumed bool // restored from storage after a restart, and not requested since
+}
+
+// tunables are the parameters of the core, which can be updated at runtime. A snapshot is
+// never modified once it is published; updates replace the snapshot as a whole, so that
+// the parameters read from one snapshot are always consistent with each other.
+type tunables struct {
+	sealingThreshold    uint // threshold between sealed and finalized blocks, beyond which missing receipts are requested
+	maxResultsToRequest uint // maximum number of receipts to request
+}
+
 // Core represents the matching business logic, used to process receipts received from
 // p2p network. Performs processing of pending receipts, storing of receipts and re-requesting
 // missing execution receipts.
+//
+// All fields are immutable after construction, except for:
+//   * the tunables, which are updated at runtime through the setters and replaced atomically
+//   * the storage failure counter, which is atomic
+//   * the requested blocks, which are only accessed when warming up and processing
+//     finalization, which is never done concurrently
+//   * the receipt consumers, which are only added before the core is used
+// The components referenced by the core (mempools, trace sampler) are safe for concurrent use.
 type Core struct {
 	log              zerolog.Logger                  // used to log relevant actions with context
 	tracer           module.Tracer                   // used to trace execution
@@ -47,12 +79,24 @@ type Core struct {
 	state            protocol.State                  // used to access the  protocol state
 	headersDB        storage.Headers                 // used to check sealed headers
 	receiptsDB       storage.ExecutionReceipts       // to persist received execution receipts
+	requestsDB       storage.ReceiptRequests         // to persist the bookkeeping of receipt requests across restarts
 	receipts         mempool.ExecutionTree           // holds execution receipts; indexes them by height; can search all receipts derived from a given parent result
 	pendingReceipts  mempool.PendingReceipts         // buffer for receipts where an ancestor result is missing, so they can't be connected to the sealed results
 	seals            mempool.IncorporatedResultSeals // holds candidate seals for incorporated results that have acquired sufficient approvals; candidate seals are constructed  without consideration of the sealability of parent results
 	receiptValidator module.ReceiptValidator         // used to validate receipts
 	receiptRequester module.Requester                // used to request missing execution receipts by block ID
-	config           Config                          // config for matching core
+	misbehavior      module.MisbehaviorReporter      // used to report invalid receipts
+	traceSampler     *sealing.TraceSampler           // used to sample receipts for detailed validation traces
+	failureThreshold uint64                          // number of consecutive unexpected storage errors, beyond which the core is unhealthy
+	retryInitial     time.Duration                   // interval after the first request for the receipts of a block
+	retryMaximum     time.Duration                   // maximum interval between requests for the receipts of a block
+	receiptConsumers []module.ReceiptConsumer        // notified of admitted receipts; only added before the core is used
+
+	// runtime-mutable state
+	tunables        atomic.Value                        // current snapshot of the tunables, of type *tunables
+	tunablesLock    sync.Mutex                          // serializes updates of the tunables
+	storageFailures *atomic.Uint64                      // number of consecutive unexpected storage errors
+	requestedBlocks map[flow.Identifier]*receiptRequest // blocks whose receipts were requested; only accessed when warming up and processing finalization
 }
 
 func NewCore(
@@ -63,14 +107,17 @@ func NewCore(
 	state protocol.State,
 	headersDB storage.Headers,
 	receiptsDB storage.ExecutionReceipts,
+	requestsDB storage.ReceiptRequests,
 	receipts mempool.ExecutionTree,
 	pendingReceipts mempool.PendingReceipts,
 	seals mempool.IncorporatedResultSeals,
 	receiptValidator module.ReceiptValidator,
 	receiptRequester module.Requester,
+	misbehavior module.MisbehaviorReporter,
+	traceSampler *sealing.TraceSampler,
 	config Config,
 ) *Core {
-	return &Core{
+	core := &Core{
 		log:              log.With().Str("engine", "matching.Core").Logger(),
 		tracer:           tracer,
 		metrics:          metrics,
@@ -78,13 +125,131 @@ func NewCore(
 		state:            state,
 		headersDB:        headersDB,
 		receiptsDB:       receiptsDB,
+		requestsDB:       requestsDB,
 		receipts:         receipts,
 		pendingReceipts:  pendingReceipts,
 		seals:            seals,
 		receiptValidator: receiptValidator,
 		receiptRequester: receiptRequester,
-		config:           config,
+		misbehavior:      misbehavior,
+		traceSampler:     traceSampler,
+		failureThreshold: uint64(config.StorageFailureThreshold),
+		retryInitial:     config.RequestRetryInitial,
+		retryMaximum:     config.RequestRetryMaximum,
+		storageFailures:  atomic.NewUint64(0),
+		requestedBlocks:  make(map[flow.Identifier]*receiptRequest),
 	}
+	core.tunables.Store(&tunables{
+		sealingThreshold:    config.SealingThreshold,
+		maxResultsToRequest: config.MaxResultsToRequest,
+	})
+	return core
+}
+
+// AddReceiptConsumer adds a consumer, which is notified of the receipts admitted to the
+// receipts mempool. Consumers must be added before the core is used, including WarmUp.
+func (c *Core) AddReceiptConsumer(consumer module.ReceiptConsumer) {
+	c.receiptConsumers = append(c.receiptConsumers, consumer)
+}
+
+// currentTunables returns the current snapshot of the tunables, which must not be modified.
+func (c *Core) currentTunables() *tunables {
+	return c.tunables.Load().(*tunables)
+}
+
+// updateTunables replaces the snapshot of the tunables by a modified copy.
+func (c *Core) updateTunables(update func(*tunables)) {
+	c.tunablesLock.Lock()
+	defer c.tunablesLock.Unlock()
+	updated := *c.currentTunables()
+	update(&updated)
+	c.tunables.Store(&updated)
+}
+
+// SealingThreshold returns the number of unsealed finalized blocks, beyond which
+// missing receipts are requested.
+func (c *Core) SealingThreshold() uint {
+	return c.currentTunables().sealingThreshold
+}
+
+// SetSealingThreshold sets the number of unsealed finalized blocks, beyond which
+// missing receipts are requested. It takes effect with the next finalized block.
+func (c *Core) SetSealingThreshold(threshold uint) {
+	c.updateTunables(func(t *tunables) {
+		t.sealingThreshold = threshold
+	})
+}
+
+// MaxResultsToRequest returns the maximum number of blocks, whose receipts are
+// requested at once.
+func (c *Core) MaxResultsToRequest() uint {
+	return c.currentTunables().maxResultsToRequest
+}
+
+// SetMaxResultsToRequest sets the maximum number of blocks, whose receipts are
+// requested at once. It takes effect with the next finalized block.
+func (c *Core) SetMaxResultsToRequest(max uint) {
+	c.updateTunables(func(t *tunables) {
+		t.maxResultsToRequest = max
+	})
+}
+
+// Healthy returns false once the number of consecutive unexpected storage errors reached the
+// configured threshold. Missing data is expected and doesn't count as a storage error.
+// A successful storage read makes the core healthy again. With a threshold of 0, the
+// core is always healthy.
+func (c *Core) Healthy() bool {
+	return c.failureThreshold == 0 || c.storageFailures.Load() < c.failureThreshold
+}
+
+// onStorageSuccess resets the number of consecutive unexpected storage errors.
+func (c *Core) onStorageSuccess() {
+	c.storageFailures.Store(0)
+}
+
+// onStorageFailure escalates an unexpected storage error, other than missing data, of the
+// given operation on the given key.
+func (c *Core) onStorageFailure(operation string, key interface{}, err error) {
+	failures := c.storageFailures.Inc()
+	c.metrics.MatchingStorageFailure(operation)
+	c.log.Error().Err(err).
+		Str("operation", operation).
+		Str("key", fmt.Sprint(key)).
+		Uint64("consecutive_failures", failures).
+		Bool("healthy", c.Healthy()).
+		Msg("unexpected storage error")
+}
+
+// RegisterConfigs registers the parameters of the core, which can be updated at runtime.
+func (c *Core) RegisterConfigs(manager *updatable_configs.Manager) error {
+	err := manager.RegisterUintConfig("matching.request-receipt-threshold",
+		c.SealingThreshold,
+		func(threshold uint) error {
+			c.SetSealingThreshold(threshold)
+			return nil
+		},
+		nil)
+	if err != nil {
+		return fmt.Errorf("could not register receipt request threshold: %w", err)
+	}
+
+	err = manager.RegisterUintConfig("matching.max-unsealed-results",
+		c.MaxResultsToRequest,
+		func(max uint) error {
+			c.SetMaxResultsToRequest(max)
+			return nil
+		},
+		func(max uint) error {
+			// zero would silently stop requesting missing receipts
+			if max == 0 {
+				return fmt.Errorf("maximum number of results to request must be positive")
+			}
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("could not register maximum number of results to request: %w", err)
+	}
+	return nil
 }
 
 // ProcessReceipt processes a new execution receipt.
@@ -161,14 +326,28 @@ func (c *Core) processReceipt(receipt *flow.ExecutionReceipt) (bool, error) {
 		Hex("block_id", receipt.ExecutionResult.BlockID[:]).
 		Hex("executor_id", receipt.ExecutorID[:]).
 		Logger()
+
+	// for sampled receipts, we trace every check along with its outcome; the result
+	// ID is remembered so the sealing steps for the result are traced as well
+	var validationTrace *sealing.ValidationTrace
+	if c.traceSampler.Sample(receipt.ExecutionResult.ID()) {
+		validationTrace = sealing.NewValidationTrace(log, receipt.ID())
+	}
+	outcome := "internal_error"
+	defer func() { validationTrace.Emit(outcome) }()
+
 	initialState, finalState, err := getStartAndEndStates(receipt)
 	if err != nil {
 		if errors.Is(err, flow.ErrNoChunks) {
 			log.Error().Err(err).Msg("discarding malformed receipt")
+			c.misbehavior.Report(receipt.ExecutorID, flow.MisbehaviorMalformedReceipt, receipt)
+			validationTrace.Step("start_and_end_states", "no_chunks")
+			outcome = "discarded_malformed"
 			return false, nil
 		}
 		return false, fmt.Errorf("internal problem retrieving start- and end-state commitment from receipt: %w", err)
 	}
+	validationTrace.Step("start_and_end_states", "ok")
 	log = log.With().
 		Hex("initial_state", initialState[:]).
 		Hex("final_state", finalState[:]).Logger()
@@ -176,10 +355,23 @@ func (c *Core) processReceipt(receipt *flow.ExecutionReceipt) (bool, error) {
 	// if the receipt is for an unknown block, skip it. It will be re-requested
 	// later by `requestPending` function.
 	executedBlock, err := c.headersDB.ByBlockID(receipt.ExecutionResult.BlockID)
-	if err != nil {
+	if errors.Is(err, storage.ErrNotFound) {
+		c.onStorageSuccess()
 		log.Debug().Msg("discarding receipt for unknown block")
+		validationTrace.Step("executed_block_known", "unknown_block")
+		outcome = "discarded_unknown_block"
+		return false, nil
+	}
+	if err != nil {
+		// the receipt is only skipped on transient storage errors, it will be re-requested
+		// later by `requestPending` function as well.
+		c.onStorageFailure("headers_by_block_id", receipt.ExecutionResult.BlockID, err)
+		validationTrace.Step("executed_block_known", "storage_failure")
+		outcome = "discarded_storage_failure"
 		return false, nil
 	}
+	c.onStorageSuccess()
+	validationTrace.Step("executed_block_known", "ok")
 
 	log = log.With().
 		Uint64("block_view", executedBlock.View).
@@ -189,14 +381,17 @@ func (c *Core) processReceipt(receipt *flow.ExecutionReceipt) (bool, error) {
 
 	// if Execution Receipt is for block whose height is lower or equal to already sealed height
 	//  => drop Receipt
-	sealed, err := c.state.Sealed().Head()
+	sealedHeight, err := c.state.SealedHeight()
 	if err != nil {
-		return false, fmt.Errorf("could not find sealed block: %w", err)
+		return false, fmt.Errorf("could not find sealed height: %w", err)
 	}
-	if executedBlock.Height <= sealed.Height {
+	if executedBlock.Height <= sealedHeight {
 		log.Debug().Msg("discarding receipt for already sealed and finalized block height")
+		validationTrace.Step("executed_block_unsealed", "already_sealed")
+		outcome = "discarded_sealed"
 		return false, nil
 	}
+	validationTrace.Step("executed_block_unsealed", "ok")
 
 	childSpan := c.tracer.StartSpanFromParent(receiptSpan, trace.CONMatchProcessReceiptVal)
 	err = c.receiptValidator.Validate(receipt)
@@ -218,27 +413,178 @@ func (c *Core) processReceipt(receipt *flow.ExecutionReceipt) (bool, error) {
 		// if yes, then process it.
 		c.pendingReceipts.Add(receipt)
 		log.Info().Msg("receipt is cached because its previous result is missing")
+		validationTrace.Step("receipt_validation", "unverifiable")
+		outcome = "cached_pending"
 		return false, nil
 	}
 
 	if err != nil {
 		if engine.IsInvalidInputError(err) {
 			log.Err(err).Msg("invalid execution receipt")
+			c.misbehavior.Report(receipt.ExecutorID, flow.MisbehaviorInvalidReceipt, receipt)
+			validationTrace.Step("receipt_validation", "invalid")
+			outcome = "discarded_invalid"
 			return false, nil
 		}
 		return false, fmt.Errorf("failed to validate execution receipt: %w", err)
 	}
+	validationTrace.Step("receipt_validation", "ok")
 
-	_, err = c.storeReceipt(receipt, executedBlock)
+	storeSpan := c.tracer.StartSpanFromParent(receiptSpan, trace.CONMatchProcessReceiptStore)
+	added, err := c.storeReceipt(receipt, executedBlock)
+	if isSampled {
+		storeSpan.SetTag("added", added)
+	}
+	storeSpan.Finish()
 	if err != nil {
 		return false, fmt.Errorf("failed to store receipt: %w", err)
 	}
+	if added {
+		validationTrace.Step("store_receipt", "added")
+	} else {
+		validationTrace.Step("store_receipt", "duplicate")
+	}
 
 	log.Info().Msg("execution result processed and stored")
+	outcome = "stored"
 
 	return true, nil
 }
 
+// WarmUp repopulates the receipts mempool with the persisted receipts for the unsealed finalized
+// blocks, from the lowest height upwards, for at most MaxResultsToRequest heights. This way, a
+// restarted node doesn't need to re-request the receipts it has already received from execution
+// nodes, before it can resume sealing. Receipts are only persisted once they are validated, hence
+// they are added to the mempool without validating them again.
+// It also restores the bookkeeping of the receipt requests for unsealed blocks, so that the
+// receipts of blocks requested shortly before the restart aren't requested again at once.
+// It returns the number of receipts added to the mempool.
+func (c *Core) WarmUp() (uint, error) {
+	final, sealed, err := c.state.Boundaries()
+	if err != nil {
+		return 0, fmt.Errorf("could not get finalized and sealed heights: %w", err)
+	}
+	err = c.restoreReceiptRequests(sealed.Height)
+	if err != nil {
+		return 0, fmt.Errorf("could not restore receipt requests: %w", err)
+	}
+	maxHeights := c.currentTunables().maxResultsToRequest
+
+	added := uint(0)
+	for height := sealed.Height + 1; height <= final.Height && height-sealed.Height <= uint64(maxHeights); height++ {
+		header, err := c.headersDB.ByHeight(height)
+		if err != nil {
+			return added, fmt.Errorf("could not get header (height=%d): %w", height, err)
+		}
+		blockID := header.ID()
+
+		receipts, err := c.receiptsDB.ByBlockID(blockID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return added, fmt.Errorf("could not get receipts for block %x: %w", blockID, err)
+		}
+		for _, receipt := range receipts {
+			ok, err := c.receipts.AddReceipt(receipt, header)
+			if err != nil {
+				return added, fmt.Errorf("adding receipt (%x) to mempool failed: %w", receipt.ID(), err)
+			}
+			if ok {
+				added++
+				c.notifyReceiptAdmitted(receipt, header)
+			}
+		}
+	}
+	c.mempool.MempoolEntries(metrics.ResourceReceipt, c.receipts.Size())
+
+	c.log.Info().
+		Uint64("sealed_height", sealed.Height).
+		Uint64("finalized_height", final.Height).
+		Uint("receipts", added).
+		Msg("warmed up receipts mempool from storage")
+	return added, nil
+}
+
+// restoreReceiptRequests loads the persisted receipt requests for the blocks above the given
+// sealed height. The requests for blocks, which were sealed while the node was down, are removed.
+func (c *Core) restoreReceiptRequests(sealedHeight uint64) error {
+	requests, err := c.requestsDB.All()
+	if err != nil {
+		return fmt.Errorf("could not load receipt requests: %w", err)
+	}
+
+	removed := 0
+	for _, request := range requests {
+		if request.Height <= sealedHeight {
+			err = c.requestsDB.Remove(request.BlockID)
+			if err != nil {
+				return fmt.Errorf("could not remove receipt request of sealed block %x: %w", request.BlockID, err)
+			}
+			removed++
+			continue
+		}
+		c.requestedBlocks[request.BlockID] = &receiptRequest{
+			ReceiptRequest: request,
+			resumed:        true,
+		}
+	}
+
+	c.log.Info().
+		Uint64("sealed_height", sealedHeight).
+		Int("restored", len(c.requestedBlocks)).
+		Int("removed", removed).
+		Msg("restored receipt requests from storage")
+	return nil
+}
+
+// retryInterval returns the interval after the last request for the receipts of a block, before
+// they are requested again, which doubles with every attempt up to the maximum.
+func (c *Core) retryInterval(attempts uint64) time.Duration {
+	interval := c.retryInitial
+	for i := uint64(1); i < attempts && interval > 0 && interval < c.retryMaximum; i++ {
+		interval *= 2
+	}
+	if interval > c.retryMaximum {
+		return c.retryMaximum
+	}
+	return interval
+}
+
+// trackReceiptRequest updates the bookkeeping of the requests for the receipts of the given block
+// and decides whether to request them now. Blocks requested since the start of the node are
+// requested with every finalized block, as the requester de-duplicates requests in flight. The
+// bookkeeping is only updated and persisted once the retry interval has passed, which bounds the
+// number of writes. The receipts of blocks restored after a restart are only requested once their
+// retry interval has passed, as the requests sent before the restart might still be answered.
+func (c *Core) trackReceiptRequest(blockID flow.Identifier, height uint64, now time.Time) bool {
+	request, ok := c.requestedBlocks[blockID]
+	if !ok {
+		request = &receiptRequest{
+			ReceiptRequest: &flow.ReceiptRequest{
+				BlockID: blockID,
+				Height:  height,
+			},
+		}
+		c.requestedBlocks[blockID] = request
+	}
+
+	due := request.Attempts == 0 || !now.Before(request.LastRequested.Add(c.retryInterval(request.Attempts)))
+	if request.resumed && !due {
+		return false
+	}
+	request.resumed = false
+	if !due {
+		return true
+	}
+
+	request.Attempts++
+	request.LastRequested = now
+	err := c.requestsDB.Store(request.ReceiptRequest)
+	if err != nil {
+		// the bookkeeping is only needed after a restart, don't hold up the request
+		c.onStorageFailure("receipt_requests_store", blockID, err)
+	}
+	return true
+}
+
 // storeReceipt adds the receipt to the receipts mempool as well as to the persistent storage layer.
 // Return values:
 //  * bool to indicate whether the receipt is stored.
@@ -254,6 +600,7 @@ func (c *Core) storeReceipt(receipt *flow.ExecutionReceipt, head *flow.Header) (
 	// TODO: we'd better wrap the `receipts` with the metrics method to avoid the metrics
 	// getting out of sync
 	c.mempool.MempoolEntries(metrics.ResourceReceipt, c.receipts.Size())
+	c.notifyReceiptAdmitted(receipt, head)
 
 	// persist receipt in database. Even if the receipt is already in persistent storage,
 	// we still need to process it, as it is not in the mempool. This can happen if the
@@ -270,22 +617,18 @@ func (c *Core) storeReceipt(receipt *flow.ExecutionReceipt, head *flow.Header) (
 // it returns the number of pending receipts requests being created, and
 // the first finalized height at which there is no receipt for the block
 func (c *Core) requestPendingReceipts() (int, uint64, error) {
-	finalSnapshot := c.state.Final()
-	final, err := finalSnapshot.Head() // last finalized block
-	if err != nil {
-		return 0, 0, fmt.Errorf("could not get finalized height: %w", err)
-	}
-	_, seal, err := finalSnapshot.SealedResult() // last finalized seal
-	if err != nil {
-		return 0, 0, fmt.Errorf("could not retrieve latest finalized seal: %w", err)
-	}
-	sealed, err := c.headersDB.ByBlockID(seal.BlockID) // last sealed block
+	// last finalized and last sealed block, read atomically so that the range of
+	// unsealed finalized blocks is consistent
+	final, sealed, err := c.state.Boundaries()
 	if err != nil {
-		return 0, 0, fmt.Errorf("could not get sealed height: %w", err)
+		return 0, 0, fmt.Errorf("could not get finalized and sealed heights: %w", err)
 	}
 
+	// both parameters are read from the same snapshot, in case they are updated concurrently
+	params := c.currentTunables()
+
 	// only request if number of unsealed finalized blocks exceeds the threshold
-	if uint(final.Height-sealed.Height) < c.config.SealingThreshold {
+	if uint(final.Height-sealed.Height) < params.sealingThreshold {
 		return 0, 0, nil
 	}
 
@@ -294,7 +637,9 @@ func (c *Core) requestPendingReceipts() (int, uint64, error) {
 	// right order. The right order gives the priority to the execution result
 	// of lower height blocks to be requested first, since a gap in the sealing
 	// heights would stop the sealing.
-	missingBlocksOrderedByHeight := make([]flow.Identifier, 0, c.config.MaxResultsToRequest)
+	maxResultsToRequest := params.maxResultsToRequest
+	missingBlocksOrderedByHeight := make([]flow.Identifier, 0, maxResultsToRequest)
+	missingHeights := make([]uint64, 0, maxResultsToRequest)
 
 	var firstMissingHeight uint64 = math.MaxUint64
 	// traverse each unsealed and finalized block with height from low to high,
@@ -303,21 +648,29 @@ func (c *Core) requestPendingReceipts() (int, uint64, error) {
 HEIGHT_LOOP:
 	for height := sealed.Height + 1; height <= final.Height; height++ {
 		// add at most <maxUnsealedResults> number of results
-		if len(missingBlocksOrderedByHeight) >= int(c.config.MaxResultsToRequest) {
+		if uint(len(missingBlocksOrderedByHeight)) >= maxResultsToRequest {
 			break
 		}
 
-		// get the block header at this height (should not error as heights are finalized)
+		// get the block header at this height (should not be missing as heights are finalized)
 		header, err := c.headersDB.ByHeight(height)
-		if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
 			return 0, 0, fmt.Errorf("could not get header (height=%d): %w", height, err)
 		}
+		if err != nil {
+			// skip requesting receipts on transient storage errors, they are requested with
+			// the next finalized block
+			c.onStorageFailure("headers_by_height", height, err)
+			return 0, 0, nil
+		}
 		blockID := header.ID()
 
 		receipts, err := c.receiptsDB.ByBlockID(blockID)
 		if err != nil && !errors.Is(err, storage.ErrNotFound) {
-			return 0, 0, fmt.Errorf("could not get receipts by block ID: %v, %w", blockID, err)
+			c.onStorageFailure("receipts_by_block_id", blockID, err)
+			return 0, 0, nil
 		}
+		c.onStorageSuccess()
 
 		// We require at least 2 consistent receipts from different ENs to seal a block. If don't need to fetching receipts.
 		// CAUTION: This is a temporary shortcut incompatible with the mature BFT protocol!
@@ -331,14 +684,24 @@ HEIGHT_LOOP:
 		}
 
 		missingBlocksOrderedByHeight = append(missingBlocksOrderedByHeight, blockID)
+		missingHeights = append(missingHeights, height)
 		if height < firstMissingHeight {
 			firstMissingHeight = height
 		}
 	}
 
 	// request missing execution results, if sealed height is low enough
-	for _, blockID := range missingBlocksOrderedByHeight {
+	now := time.Now()
+	for i, blockID := range missingBlocksOrderedByHeight {
+		if !c.trackReceiptRequest(blockID, missingHeights[i], now) {
+			continue
+		}
+		span, _, isSampled := c.tracer.StartBlockSpan(context.Background(), blockID, trace.CONMatchRequestReceipts)
+		if isSampled {
+			span.LogFields(log.Uint64("height", missingHeights[i]))
+		}
 		c.receiptRequester.Query(blockID, filter.Any)
+		span.Finish()
 	}
 
 	return len(missingBlocksOrderedByHeight), firstMissingHeight, nil
@@ -353,20 +716,14 @@ func (c *Core) OnBlockFinalization() error {
 		return fmt.Errorf("could not request pending block results: %w", err)
 	}
 
-	// Prune Execution Tree
-	lastSealed, err := c.state.Sealed().Head()
+	// clear the mempools of the data for sealed blocks
+	lastSealed, err := c.state.AtSealed().Head()
 	if err != nil {
 		return fmt.Errorf("could not retrieve last sealed block : %w", err)
 	}
-	err = c.receipts.PruneUpToHeight(lastSealed.Height)
-	if err != nil {
-		return fmt.Errorf("failed to prune execution tree up to latest sealed and finalized block %v, height: %v: %w",
-			lastSealed.ID(), lastSealed.Height, err)
-	}
-
-	err = c.pendingReceipts.PruneUpToHeight(lastSealed.Height)
+	err = c.clearPools(lastSealed.Height)
 	if err != nil {
-		return fmt.Errorf("failed to prune pending receipts mempool up to latest sealed and finalized block %v, height: %v: %w",
+		return fmt.Errorf("could not clear mempools up to latest sealed block %v, height: %v: %w",
 			lastSealed.ID(), lastSealed.Height, err)
 	}
 
@@ -381,6 +738,63 @@ func (c *Core) OnBlockFinalization() error {
 	return nil
 }
 
+// clearPools removes the data for blocks at or below the given sealed height from the
+// mempools in one pass, once the blocks are sealed:
+//  * the execution tree retains the results for the sealed height, as the receipts for
+//    unsealed blocks are searched starting from the latest sealed result
+//  * pending receipts for sealed blocks can never be connected to the execution tree anymore
+//  * outstanding receipt requests for sealed blocks are cancelled, and their bookkeeping
+//    is removed from storage
+//  * the receipt consumers drop their data for the sealed blocks
+// All mempools are pruned by the block heights recorded when the entries were added, so
+// clearing the pools does not read from storage. Candidate seals are pruned by the sealing
+// core, and approvals by the assignment collectors of the sealing core.
+func (c *Core) clearPools(sealedHeight uint64) error {
+	err := c.receipts.PruneUpToHeight(sealedHeight)
+	if err != nil {
+		return fmt.Errorf("failed to prune execution tree: %w", err)
+	}
+	c.mempool.MempoolEntries(metrics.ResourceReceipt, c.receipts.Size())
+
+	err = c.pendingReceipts.PruneUpToHeight(sealedHeight + 1)
+	if err != nil {
+		return fmt.Errorf("failed to prune pending receipts mempool: %w", err)
+	}
+
+	c.cancelSealedRequests(sealedHeight)
+	for _, consumer := range c.receiptConsumers {
+		consumer.PruneUpToHeight(sealedHeight)
+	}
+	return nil
+}
+
+// notifyReceiptAdmitted notifies the receipt consumers of the receipt admitted to the
+// receipts mempool.
+func (c *Core) notifyReceiptAdmitted(receipt *flow.ExecutionReceipt, executedBlock *flow.Header) {
+	for _, consumer := range c.receiptConsumers {
+		consumer.OnReceiptAdmitted(receipt, executedBlock)
+	}
+}
+
+// cancelSealedRequests cancels the receipt requests for all blocks at or below
+// the given sealed height, as we don't need their receipts anymore, and removes
+// their persisted bookkeeping.
+func (c *Core) cancelSealedRequests(sealedHeight uint64) {
+	for blockID, request := range c.requestedBlocks {
+		if request.Height > sealedHeight {
+			continue
+		}
+		c.receiptRequester.CancelEntityByID(blockID)
+		delete(c.requestedBlocks, blockID)
+
+		// requests which fail to be removed are removed when they are restored after a restart
+		err := c.requestsDB.Remove(blockID)
+		if err != nil {
+			c.onStorageFailure("receipt_requests_remove", blockID, err)
+		}
+	}
+}
+
 // getStartAndEndStates returns the pair: (start state commitment; final state commitment)
 // Error returns:
 //  * ErrNoChunks: if there are no chunks, i.e. the ExecutionResult is malformed
@@ -1,18 +1,35 @@
 package matching
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine"
+	sealing "github.com/onflow/flow-go/engine/consensus"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/consensus"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/module/updatable_configs"
+	realproto "github.com/onflow/flow-go/state/protocol"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -24,7 +41,9 @@ type MatchingSuite struct {
 	unittest.BaseChainSuite
 	// misc SERVICE COMPONENTS which are injected into Sealing Core
 	requester        *mockmodule.Requester
+	requestsDB       *mockstorage.ReceiptRequests
 	receiptValidator *mockmodule.ReceiptValidator
+	misbehavior      *mockmodule.MisbehaviorReporter
 
 	// MATCHING CORE
 	core *Core
@@ -40,10 +59,15 @@ func (ms *MatchingSuite) SetupTest() {
 	// ~~~~~~~~~~~~~~~~~~~~~~~ SETUP MATCHING CORE ~~~~~~~~~~~~~~~~~~~~~~~ //
 	ms.requester = new(mockmodule.Requester)
 	ms.receiptValidator = &mockmodule.ReceiptValidator{}
+	ms.misbehavior = &mockmodule.MisbehaviorReporter{}
+	ms.requestsDB = &mockstorage.ReceiptRequests{}
+	ms.requestsDB.On("Store", mock.Anything).Return(nil).Maybe()
+	ms.requestsDB.On("Remove", mock.Anything).Return(nil).Maybe()
 
 	config := Config{
-		SealingThreshold:    10,
-		MaxResultsToRequest: 200,
+		SealingThreshold:        10,
+		MaxResultsToRequest:     200,
+		StorageFailureThreshold: 3,
 	}
 
 	ms.core = NewCore(
@@ -54,11 +78,14 @@ func (ms *MatchingSuite) SetupTest() {
 		ms.State,
 		ms.HeadersDB,
 		ms.ReceiptsDB,
+		ms.requestsDB,
 		ms.ReceiptsPL,
 		ms.PendingReceipts,
 		ms.SealsPL,
 		ms.receiptValidator,
 		ms.requester,
+		ms.misbehavior,
+		sealing.NewTraceSampler(0),
 		config,
 	)
 }
@@ -145,6 +172,11 @@ func (ms *MatchingSuite) TestOnReceiptValid() {
 	ms.ReceiptsPL.On("AddReceipt", receipt, ms.UnfinalizedBlock.Header).Return(true, nil).Once()
 	ms.ReceiptsDB.On("Store", receipt).Return(nil).Once()
 
+	// the receipt consumers should be notified of the admitted receipt
+	consumer := new(mockmodule.ReceiptConsumer)
+	consumer.On("OnReceiptAdmitted", receipt, ms.UnfinalizedBlock.Header).Once()
+	ms.core.AddReceiptConsumer(consumer)
+
 	// onReceipt should run to completion without throwing an error
 	_, err := ms.core.processReceipt(receipt)
 	ms.Require().NoError(err, "should add receipt and result to mempools if valid")
@@ -152,6 +184,192 @@ func (ms *MatchingSuite) TestOnReceiptValid() {
 	ms.receiptValidator.AssertExpectations(ms.T())
 	ms.ReceiptsPL.AssertExpectations(ms.T())
 	ms.ReceiptsDB.AssertExpectations(ms.T())
+	consumer.AssertExpectations(ms.T())
+}
+
+// TestReceiptToSealTrace tests that the spans for processing a receipt and for constructing
+// the seal of its result are part of the same trace, which is rooted at the executed block.
+func (ms *MatchingSuite) TestReceiptToSealTrace() {
+	tracer := trace.NewRecordingTracer()
+	ms.core.tracer = tracer
+
+	blockID := ms.UnfinalizedBlock.ID()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&ms.UnfinalizedBlock))
+	result.Chunks = unittest.ChunkListFixture(2, blockID)
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(ms.ExeID), unittest.WithResult(result))
+
+	ms.receiptValidator.On("Validate", receipt).Return(nil).Once()
+	ms.ReceiptsPL.On("AddReceipt", receipt, ms.UnfinalizedBlock.Header).Return(true, nil).Once()
+	ms.ReceiptsDB.On("Store", receipt).Return(nil).Once()
+
+	_, err := ms.core.processReceipt(receipt)
+	ms.Require().NoError(err)
+
+	// seal the result with approvals of a single verifier, which is assigned to all chunks
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	assignment := chunks.NewAssignment()
+	for _, chunk := range result.Chunks {
+		assignment.Add(chunk, flow.IdentifierList{verifier.NodeID})
+	}
+	incorporatedBlock := unittest.BlockHeaderWithParentFixture(ms.UnfinalizedBlock.Header)
+	collector, err := approvals.NewApprovalCollector(
+		unittest.Logger(),
+		flow.NewIncorporatedResult(incorporatedBlock.ID(), &receipt.ExecutionResult),
+		&incorporatedBlock,
+		ms.UnfinalizedBlock.Header,
+		assignment,
+		map[flow.Identifier]*flow.Identity{verifier.NodeID: verifier},
+		stdmap.NewIncorporatedResultSeals(10),
+		approvals.CountThreshold{N: 1},
+		false,
+		tracer,
+	)
+	ms.Require().NoError(err)
+	for _, chunk := range result.Chunks {
+		approval := unittest.ResultApprovalFixture(
+			unittest.WithExecutionResultID(result.ID()),
+			unittest.WithBlockID(blockID),
+			unittest.WithChunk(chunk.Index),
+			unittest.WithApproverID(verifier.NodeID),
+		)
+		ms.Require().NoError(collector.ProcessApproval(approval))
+	}
+
+	root, ok := tracer.EntityRoot(blockID)
+	ms.Require().True(ok, "trace of executed block should have a root span")
+
+	receiptSpans := tracer.SpansByName(trace.CONMatchProcessReceipt)
+	ms.Require().Len(receiptSpans, 1)
+	receiptSpan := receiptSpans[0]
+	ms.Assert().Equal(root.SpanID(), receiptSpan.ParentID())
+	ms.Assert().True(receiptSpan.Finished())
+	resultID, _ := receiptSpan.Tag("result_id")
+	ms.Assert().Equal(result.ID().String(), resultID)
+	executor, _ := receiptSpan.Tag("executor")
+	ms.Assert().Equal(ms.ExeID.String(), executor)
+
+	for _, name := range []trace.SpanName{trace.CONMatchProcessReceiptVal, trace.CONMatchProcessReceiptStore} {
+		spans := tracer.SpansByName(name)
+		ms.Require().Len(spans, 1, name)
+		ms.Assert().Equal(receiptSpan.SpanID(), spans[0].ParentID(), name)
+		ms.Assert().True(spans[0].Finished(), name)
+	}
+
+	sealSpans := tracer.SpansByName(trace.CONSealingSealCandidate)
+	ms.Require().Len(sealSpans, 1)
+	ms.Assert().Equal(root.SpanID(), sealSpans[0].ParentID())
+	ms.Assert().Equal(blockID, sealSpans[0].EntityID())
+	sealedResultID, _ := sealSpans[0].Tag("result_id")
+	ms.Assert().Equal(result.ID().String(), sealedResultID)
+	incorporatedBlockID, _ := sealSpans[0].Tag("incorporated_block_id")
+	ms.Assert().Equal(incorporatedBlock.ID().String(), incorporatedBlockID)
+}
+
+// TestReceiptToSealWithRequiredSPoCKs tests that, if SPoCK proofs are required, the seal of a processed
+// receipt is only constructed from approvals providing a SPoCK proof, and carries their SPoCK proofs.
+func (ms *MatchingSuite) TestReceiptToSealWithRequiredSPoCKs() {
+	blockID := ms.UnfinalizedBlock.ID()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&ms.UnfinalizedBlock))
+	result.Chunks = unittest.ChunkListFixture(2, blockID)
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(ms.ExeID), unittest.WithResult(result))
+
+	ms.receiptValidator.On("Validate", receipt).Return(nil).Once()
+	ms.ReceiptsPL.On("AddReceipt", receipt, ms.UnfinalizedBlock.Header).Return(true, nil).Once()
+	ms.ReceiptsDB.On("Store", receipt).Return(nil).Once()
+
+	_, err := ms.core.processReceipt(receipt)
+	ms.Require().NoError(err)
+
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	assignment := chunks.NewAssignment()
+	for _, chunk := range result.Chunks {
+		assignment.Add(chunk, flow.IdentifierList{verifier.NodeID})
+	}
+	incorporatedBlock := unittest.BlockHeaderWithParentFixture(ms.UnfinalizedBlock.Header)
+	seals := stdmap.NewIncorporatedResultSeals(10)
+	collector, err := approvals.NewApprovalCollector(
+		unittest.Logger(),
+		flow.NewIncorporatedResult(incorporatedBlock.ID(), &receipt.ExecutionResult),
+		&incorporatedBlock,
+		ms.UnfinalizedBlock.Header,
+		assignment,
+		map[flow.Identifier]*flow.Identity{verifier.NodeID: verifier},
+		seals,
+		approvals.CountThreshold{N: 1},
+		true,
+		trace.NewNoopTracer(),
+	)
+	ms.Require().NoError(err)
+
+	spocks := make([]crypto.Signature, 0, len(result.Chunks))
+	for _, chunk := range result.Chunks {
+		approval := unittest.ResultApprovalFixture(
+			unittest.WithExecutionResultID(result.ID()),
+			unittest.WithBlockID(blockID),
+			unittest.WithChunk(chunk.Index),
+			unittest.WithApproverID(verifier.NodeID),
+		)
+		err = collector.ProcessApproval(approval)
+		ms.Require().True(engine.IsInvalidInputError(err), "approval without SPoCK proof should be rejected")
+		ms.Require().Equal(uint(0), seals.Size())
+
+		approval.Body.Spock = unittest.SignatureFixture()
+		ms.Require().NoError(collector.ProcessApproval(approval))
+		spocks = append(spocks, approval.Body.Spock)
+	}
+
+	sealed := seals.All()
+	ms.Require().Len(sealed, 1)
+	ms.Require().Len(sealed[0].Seal.AggregatedApprovalSigs, len(result.Chunks))
+	for i, sig := range sealed[0].Seal.AggregatedApprovalSigs {
+		ms.Assert().Equal(flow.IdentifierList{verifier.NodeID}, sig.SignerIDs)
+		ms.Assert().Equal([]crypto.Signature{spocks[i]}, sig.Spocks)
+	}
+}
+
+// TestReceiptTraceSampling tests that sampled receipts are logged with a detailed
+// validation trace, that no traces are emitted with sampling turned off, and that
+// the sampling rate can be adjusted at runtime.
+func (ms *MatchingSuite) TestReceiptTraceSampling() {
+	var logs bytes.Buffer
+	ms.core.log = zerolog.New(&logs)
+	ms.core.traceSampler = sealing.NewTraceSampler(1)
+
+	process := func() *flow.ExecutionReceipt {
+		receipt := unittest.ExecutionReceiptFixture(
+			unittest.WithExecutorID(ms.ExeID),
+			unittest.WithResult(unittest.ExecutionResultFixture(unittest.WithBlock(&ms.UnfinalizedBlock))),
+		)
+		ms.receiptValidator.On("Validate", receipt).Return(nil).Once()
+		ms.ReceiptsPL.On("AddReceipt", receipt, ms.UnfinalizedBlock.Header).Return(true, nil).Once()
+		ms.ReceiptsDB.On("Store", receipt).Return(nil).Once()
+
+		_, err := ms.core.processReceipt(receipt)
+		ms.Require().NoError(err)
+		return receipt
+	}
+
+	// with sampling 1-in-1, every receipt is traced
+	for i := 0; i < 3; i++ {
+		receipt := process()
+		ms.Require().Contains(logs.String(), "sampled validation trace")
+		ms.Require().Contains(logs.String(), `"trace_outcome":"stored"`)
+		ms.Require().Contains(logs.String(), `"check":"receipt_validation","outcome":"ok"`)
+		ms.Require().Contains(logs.String(), fmt.Sprintf(`"traced_entity_id":"%x"`, receipt.ID()))
+		ms.Require().True(ms.core.traceSampler.Traced(receipt.ExecutionResult.ID()))
+		logs.Reset()
+	}
+
+	// with sampling turned off at runtime, no trace records are emitted
+	ms.core.traceSampler.SetSamplingRate(0)
+	receipt := process()
+	ms.Require().NotContains(logs.String(), "sampled validation trace")
+	ms.Require().False(ms.core.traceSampler.Traced(receipt.ExecutionResult.ID()))
+
+	// turning sampling back on takes effect for the next receipt
+	ms.core.traceSampler.SetSamplingRate(1)
+	process()
+	ms.Require().Contains(logs.String(), "sampled validation trace")
 }
 
 // TestOnReceiptInvalid tests that we reject receipts that don't pass the ReceiptValidator
@@ -165,9 +383,12 @@ func (ms *MatchingSuite) TestOnReceiptInvalid() {
 	)
 
 	// check that _expected_ failure case of invalid receipt is handled without error
+	// and the executor is reported for misbehavior
 	ms.receiptValidator.On("Validate", receipt).Return(engine.NewInvalidInputError("")).Once()
+	ms.misbehavior.On("Report", originID, flow.MisbehaviorInvalidReceipt, receipt).Once()
 	_, err := ms.core.processReceipt(receipt)
 	ms.Require().NoError(err, "invalid receipt should be dropped but not error")
+	ms.misbehavior.AssertExpectations(ms.T())
 
 	// check that _unexpected_ failure case causes the error to be escalated
 	ms.receiptValidator.On("Validate", receipt).Return(fmt.Errorf("")).Once()
@@ -235,6 +456,311 @@ func (ms *MatchingSuite) TestRequestPendingReceipts() {
 	ms.requester.AssertExpectations(ms.T()) // asserts that requester.Query(<blockID>, filter.Any) was called
 }
 
+// TestRequestPendingReceipts_ConsistentBoundaries verifies that requestPendingReceipts reads the latest
+// finalized and sealed blocks atomically. We use a protocol state, which advances finalization and sealing
+// by `step` blocks on every read:
+//   * Reading the finalized and the sealed head through separate calls observes two different points of
+//     the state history, and yields a sealed block above the finalized one.
+//   * Boundaries() returns a consistent pair, for which exactly the unsealed finalized blocks are requested.
+func (ms *MatchingSuite) TestRequestPendingReceipts_ConsistentBoundaries() {
+	// create blocks
+	n := 30
+	orderedBlocks := make([]flow.Block, 0, n)
+	parentBlock := ms.UnfinalizedBlock
+	for i := 0; i < n; i++ {
+		block := unittest.BlockWithParentFixture(parentBlock.Header)
+		ms.Extend(block)
+		orderedBlocks = append(orderedBlocks, *block)
+		parentBlock = *block
+	}
+
+	const step = 6
+	finalized, sealed := 5, 0 // indices of the latest finalized and sealed block in orderedBlocks
+	advance := func(mock.Arguments) {
+		finalized += step
+		sealed += step
+	}
+	snapshotOf := func(block flow.Block) *mockprotocol.Snapshot {
+		snapshot := &mockprotocol.Snapshot{}
+		snapshot.On("Head").Return(block.Header, nil)
+		return snapshot
+	}
+	state := &mockprotocol.State{}
+	state.On("Final").Return(func() realproto.Snapshot { return snapshotOf(orderedBlocks[finalized]) }).Run(advance)
+	state.On("Sealed").Return(func() realproto.Snapshot { return snapshotOf(orderedBlocks[sealed]) }).Run(advance)
+	state.On("Boundaries").Return(
+		func() *flow.Header { return orderedBlocks[finalized].Header },
+		func() *flow.Header { return orderedBlocks[sealed].Header },
+		nil,
+	).Run(advance)
+	ms.core.state = state
+	ms.core.SetSealingThreshold(0)
+
+	// separate reads of the finalized and sealed head are inconsistent
+	final, err := state.Final().Head()
+	ms.Require().NoError(err)
+	lastSealed, err := state.Sealed().Head()
+	ms.Require().NoError(err)
+	ms.Require().Greater(lastSealed.Height, final.Height, "separate reads should observe the state advancing")
+
+	// the unsealed finalized blocks of a consistent pair are requested: the state has advanced
+	// twice above, and advances once more when Core reads the boundaries
+	for i := 3*step + 1; i <= 3*step+5; i++ {
+		ms.requester.On("Query", orderedBlocks[i].ID(), mock.Anything).Return().Once()
+	}
+	ms.ReceiptsDB.On("ByBlockID", mock.Anything).Return(nil, nil)
+
+	requested, _, err := ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Require().Equal(5, requested)
+	ms.requester.AssertExpectations(ms.T())
+	ms.requester.AssertNumberOfCalls(ms.T(), "Query", 5)
+}
+
+// TestRequestPendingReceipts_UpdatedConfig verifies that updating the receipt request threshold and the
+// maximum number of results to request through the config manager takes effect with the next request,
+// and that invalid values are rejected.
+func (ms *MatchingSuite) TestRequestPendingReceipts_UpdatedConfig() {
+	// create blocks
+	n := 20
+	orderedBlocks := make([]flow.Block, 0, n)
+	parentBlock := ms.UnfinalizedBlock
+	for i := 0; i < n; i++ {
+		block := unittest.BlockWithParentFixture(parentBlock.Header)
+		ms.Extend(block)
+		orderedBlocks = append(orderedBlocks, *block)
+		parentBlock = *block
+	}
+	ms.LatestSealedBlock = orderedBlocks[0]
+	ms.LatestFinalizedBlock = &orderedBlocks[n-1]
+	ms.requester.On("Query", mock.Anything, mock.Anything).Return()
+	ms.ReceiptsDB.On("ByBlockID", mock.Anything).Return(nil, nil)
+
+	store := &mockstorage.ConfigOverrides{}
+	store.On("All").Return(map[string]string{}, nil)
+	store.On("Store", mock.Anything, mock.Anything).Return(nil)
+	manager, err := updatable_configs.NewManager(unittest.Logger(), store)
+	ms.Require().NoError(err)
+	ms.Require().NoError(ms.core.RegisterConfigs(manager))
+
+	// no receipts are requested below the threshold
+	_, err = manager.Set("matching.request-receipt-threshold", float64(n))
+	ms.Require().NoError(err)
+	requested, _, err := ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Assert().Equal(0, requested)
+
+	// receipts are requested for at most the configured number of results
+	_, err = manager.Set("matching.request-receipt-threshold", float64(0))
+	ms.Require().NoError(err)
+	_, err = manager.Set("matching.max-unsealed-results", float64(5))
+	ms.Require().NoError(err)
+	requested, _, err = ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Assert().Equal(5, requested)
+	ms.requester.AssertNumberOfCalls(ms.T(), "Query", 5)
+
+	// requesting the receipts of no results at all is rejected
+	_, err = manager.Set("matching.max-unsealed-results", float64(0))
+	ms.Require().True(updatable_configs.IsValidationError(err))
+	ms.Assert().Equal(uint(5), ms.core.MaxResultsToRequest())
+}
+
+// TestConcurrentConfigUpdates verifies that the runtime-tunable parameters of the core can be
+// updated while receipts and finalized blocks are processed, without data races. It is only
+// meaningful with the race detector enabled.
+func (ms *MatchingSuite) TestConcurrentConfigUpdates() {
+	n := 20
+	orderedBlocks := make([]flow.Block, 0, n)
+	parentBlock := ms.UnfinalizedBlock
+	for i := 0; i < n; i++ {
+		block := unittest.BlockWithParentFixture(parentBlock.Header)
+		ms.Extend(block)
+		orderedBlocks = append(orderedBlocks, *block)
+		parentBlock = *block
+	}
+	ms.LatestSealedBlock = orderedBlocks[0]
+	ms.LatestFinalizedBlock = &orderedBlocks[n-1]
+
+	ms.requester.On("Query", mock.Anything, mock.Anything).Return()
+	ms.requester.On("CancelEntityByID", mock.Anything).Return()
+	ms.receiptValidator.On("Validate", mock.Anything).Return(nil)
+	ms.ReceiptsPL.On("AddReceipt", mock.Anything, mock.Anything).Return(true, nil)
+	ms.ReceiptsPL.On("PruneUpToHeight", mock.Anything).Return(nil)
+	ms.PendingReceipts.On("PruneUpToHeight", mock.Anything).Return(nil)
+	ms.ReceiptsDB.On("Store", mock.Anything).Return(nil)
+	ms.ReceiptsDB.On("ByBlockID", mock.Anything).Return(nil, nil)
+
+	store := &mockstorage.ConfigOverrides{}
+	store.On("All").Return(map[string]string{}, nil)
+	store.On("Store", mock.Anything, mock.Anything).Return(nil)
+	manager, err := updatable_configs.NewManager(unittest.Logger(), store)
+	ms.Require().NoError(err)
+	ms.Require().NoError(ms.core.RegisterConfigs(manager))
+
+	receipts := make([]*flow.ExecutionReceipt, 100)
+	for i := range receipts {
+		receipts[i] = unittest.ExecutionReceiptFixture(
+			unittest.WithExecutorID(ms.ExeID),
+			unittest.WithResult(unittest.ExecutionResultFixture(unittest.WithBlock(&ms.UnfinalizedBlock))),
+		)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// toggle the settings until the receipts and finalized blocks are processed
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := uint(0); ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			_, err := manager.Set("matching.request-receipt-threshold", float64(i%uint(n)))
+			ms.Assert().NoError(err)
+			ms.core.SetMaxResultsToRequest(1 + i%10)
+			ms.core.traceSampler.SetSamplingRate(uint64(i % 3))
+			_ = ms.core.SealingThreshold()
+			_ = manager.Fields()
+		}
+	}()
+
+	var processing sync.WaitGroup
+	processing.Add(2)
+	go func() {
+		defer processing.Done()
+		for _, receipt := range receipts {
+			_, err := ms.core.processReceipt(receipt)
+			ms.Assert().NoError(err)
+		}
+	}()
+	// finalized blocks are processed sequentially, like by the engine
+	go func() {
+		defer processing.Done()
+		for i := 0; i < 100; i++ {
+			ms.Assert().NoError(ms.core.OnBlockFinalization())
+		}
+	}()
+
+	processing.Wait()
+	close(done)
+	wg.Wait()
+}
+
+// TestCancelRequestsForSealedBlocks verifies that, once blocks are sealed, the
+// receipt requests for them are cancelled, while requests for unsealed blocks
+// are kept alive.
+func (ms *MatchingSuite) TestCancelRequestsForSealedBlocks() {
+	// create blocks
+	n := 10
+	orderedBlocks := make([]flow.Block, 0, n)
+	parentBlock := ms.UnfinalizedBlock
+	for i := 0; i < n; i++ {
+		block := unittest.BlockWithParentFixture(parentBlock.Header)
+		ms.Extend(block)
+		orderedBlocks = append(orderedBlocks, *block)
+		parentBlock = *block
+	}
+
+	// progress latest sealed and latest finalized:
+	ms.LatestSealedBlock = orderedBlocks[0]
+	ms.LatestFinalizedBlock = &orderedBlocks[n-1]
+	ms.core.SetSealingThreshold(0)
+
+	ms.requester.On("Query", mock.Anything, mock.Anything).Return()
+	ms.ReceiptsDB.On("ByBlockID", mock.Anything).Return(nil, nil)
+
+	requested, _, err := ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Require().Equal(n-1, requested)
+
+	// seal up to the middle of the requested range; requests for blocks at or
+	// below the sealed height must be cancelled exactly once
+	sealedIdx := n / 2
+	for i := 1; i <= sealedIdx; i++ {
+		ms.requester.On("CancelEntityByID", orderedBlocks[i].ID()).Return().Once()
+	}
+	ms.core.cancelSealedRequests(orderedBlocks[sealedIdx].Header.Height)
+	ms.requester.AssertExpectations(ms.T())
+	ms.requester.AssertNumberOfCalls(ms.T(), "CancelEntityByID", sealedIdx)
+
+	// cancelling again for the same height should not produce any more calls
+	ms.core.cancelSealedRequests(orderedBlocks[sealedIdx].Header.Height)
+	ms.requester.AssertNumberOfCalls(ms.T(), "CancelEntityByID", sealedIdx)
+	ms.Require().Len(ms.core.requestedBlocks, n-1-sealedIdx)
+}
+
+// TestClearPoolsAfterSeal verifies that, once blocks are sealed, their receipts, pending
+// receipts and receipt requests are removed from the mempools in one pass, while the data
+// for unsealed blocks is retained. The execution tree keeps the results for the sealed
+// block, as unsealed results are searched starting from them.
+func (ms *MatchingSuite) TestClearPoolsAfterSeal() {
+	receipts := consensus.NewExecutionTree()
+	pendingReceipts := stdmap.NewPendingReceipts(ms.HeadersDB, 100)
+	ms.core.receipts = receipts
+	ms.core.pendingReceipts = pendingReceipts
+
+	// create blocks with one receipt in the execution tree, one pending receipt
+	// and one outstanding receipt request each
+	n := 6
+	orderedBlocks := make([]*flow.Block, 0, n)
+	pending := make([]*flow.ExecutionReceipt, 0, n)
+	parentBlock := ms.UnfinalizedBlock
+	for i := 0; i < n; i++ {
+		block := unittest.BlockWithParentFixture(parentBlock.Header)
+		ms.Extend(block)
+		orderedBlocks = append(orderedBlocks, block)
+		parentBlock = *block
+
+		_, err := receipts.AddReceipt(unittest.ReceiptForBlockFixture(block), block.Header)
+		ms.Require().NoError(err)
+		receipt := unittest.ReceiptForBlockFixture(block)
+		receipt.ExecutionResult.PreviousResultID = unittest.IdentifierFixture()
+		ms.Require().True(pendingReceipts.Add(receipt))
+		pending = append(pending, receipt)
+		ms.core.requestedBlocks[block.ID()] = &receiptRequest{ReceiptRequest: &flow.ReceiptRequest{BlockID: block.ID(), Height: block.Header.Height}}
+	}
+
+	// seal and finalize blocks, without exceeding the threshold for requesting receipts
+	sealedIdx := 2
+	ms.LatestSealedBlock = *orderedBlocks[sealedIdx]
+	ms.LatestFinalizedBlock = orderedBlocks[n-1]
+	for i := 0; i <= sealedIdx; i++ {
+		ms.requester.On("CancelEntityByID", orderedBlocks[i].ID()).Return().Once()
+	}
+	consumer := new(mockmodule.ReceiptConsumer)
+	consumer.On("PruneUpToHeight", orderedBlocks[sealedIdx].Header.Height).Once()
+	ms.core.AddReceiptConsumer(consumer)
+
+	err := ms.core.OnBlockFinalization()
+	ms.Require().NoError(err)
+	consumer.AssertExpectations(ms.T())
+
+	// receipts for blocks below the sealed height are removed
+	ms.requester.AssertExpectations(ms.T())
+	ms.Assert().Equal(orderedBlocks[sealedIdx].Header.Height, receipts.LowestHeight())
+	ms.Assert().Equal(uint(n-sealedIdx), receipts.Size())
+
+	// pending receipts for sealed blocks are removed, the others survive
+	for i, receipt := range pending {
+		found := pendingReceipts.ByPreviousResultID(receipt.ExecutionResult.PreviousResultID)
+		if i <= sealedIdx {
+			ms.Assert().Empty(found)
+		} else {
+			ms.Assert().Len(found, 1)
+		}
+	}
+
+	// requests for unsealed blocks are retained
+	ms.Require().Len(ms.core.requestedBlocks, n-1-sealedIdx)
+	for _, block := range orderedBlocks[sealedIdx+1:] {
+		ms.Assert().Contains(ms.core.requestedBlocks, block.ID())
+	}
+}
+
 // TestRequestSecondPendingReceipt verifies that a second receipt is re-requested
 // Situation A:
 //  * we have _once_ receipt for an unsealed finalized block in storage
@@ -246,7 +772,7 @@ func (ms *MatchingSuite) TestRequestPendingReceipts() {
 // TODO: this test is temporarily requires as long as sealing.Core requires _two_ receipts from different ENs to seal
 func (ms *MatchingSuite) TestRequestSecondPendingReceipt() {
 
-	ms.core.config.SealingThreshold = 0 // request receipts for all unsealed finalized blocks
+	ms.core.SetSealingThreshold(0) // request receipts for all unsealed finalized blocks
 
 	result := unittest.ExecutionResultFixture(unittest.WithBlock(ms.LatestFinalizedBlock))
 
@@ -271,3 +797,240 @@ func (ms *MatchingSuite) TestRequestSecondPendingReceipt() {
 	ms.Require().NoError(err, "should request results for pending blocks")
 	ms.requester.AssertExpectations(ms.T()) // asserts that requester.Query(<blockID>, filter.Any) was called
 }
+
+// storageFailureMetrics replaces the metrics of the core with a mock, which expects the given number
+// of unexpected storage errors of the given operation.
+func (ms *MatchingSuite) storageFailureMetrics(operation string, failures int) *mockmodule.ConsensusMetrics {
+	consensusMetrics := &mockmodule.ConsensusMetrics{}
+	consensusMetrics.On("OnReceiptProcessingDuration", mock.Anything).Maybe()
+	if failures > 0 {
+		consensusMetrics.On("MatchingStorageFailure", operation).Times(failures)
+	}
+	ms.core.metrics = consensusMetrics
+	return consensusMetrics
+}
+
+// TestOnReceipt_StorageFailure verifies that a receipt is discarded without an error, if its executed
+// block can't be read due to an unexpected storage error, and that the failure is escalated.
+func (ms *MatchingSuite) TestOnReceipt_StorageFailure() {
+	consensusMetrics := ms.storageFailureMetrics("headers_by_block_id", 1)
+	headersDB := &mockstorage.Headers{}
+	headersDB.On("ByBlockID", mock.Anything).Return(nil, errors.New("transient badger error"))
+	ms.core.headersDB = headersDB
+
+	receipt := unittest.ExecutionReceiptFixture(
+		unittest.WithResult(unittest.ExecutionResultFixture(unittest.WithBlock(&ms.UnfinalizedBlock))),
+	)
+	added, err := ms.core.processReceipt(receipt)
+	ms.Require().NoError(err)
+	ms.Require().False(added)
+	ms.Require().True(ms.core.Healthy(), "a single failure should not cross the threshold")
+
+	consensusMetrics.AssertExpectations(ms.T())
+	ms.ReceiptsPL.AssertNumberOfCalls(ms.T(), "AddReceipt", 0)
+	ms.ReceiptsDB.AssertNumberOfCalls(ms.T(), "Store", 0)
+}
+
+// TestOnReceipt_MissingBlockIsBenign verifies that receipts for unknown blocks are not escalated as
+// storage failures, no matter how many of them are received.
+func (ms *MatchingSuite) TestOnReceipt_MissingBlockIsBenign() {
+	consensusMetrics := ms.storageFailureMetrics("headers_by_block_id", 0)
+
+	for i := 0; i < 10; i++ {
+		_, err := ms.core.processReceipt(unittest.ExecutionReceiptFixture())
+		ms.Require().NoError(err)
+	}
+	ms.Require().True(ms.core.Healthy())
+	consensusMetrics.AssertNotCalled(ms.T(), "MatchingStorageFailure", mock.Anything)
+}
+
+// TestRequestPendingReceipts_StorageFailure verifies that unexpected errors reading the receipts of
+// unsealed finalized blocks abort requesting receipts for that round, without returning an error.
+func (ms *MatchingSuite) TestRequestPendingReceipts_StorageFailure() {
+	ms.core.SetSealingThreshold(0) // request receipts for all unsealed finalized blocks
+	consensusMetrics := ms.storageFailureMetrics("receipts_by_block_id", 1)
+	ms.ReceiptsDB.On("ByBlockID", mock.Anything).Return(nil, errors.New("transient badger error"))
+
+	requested, _, err := ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Require().Equal(0, requested)
+	ms.requester.AssertNotCalled(ms.T(), "Query", mock.Anything, mock.Anything)
+	consensusMetrics.AssertExpectations(ms.T())
+}
+
+// TestStorageFailureThreshold verifies that the core becomes unhealthy once the number of consecutive
+// unexpected storage errors reaches the threshold, and healthy again after a successful read.
+func (ms *MatchingSuite) TestStorageFailureThreshold() {
+	ms.core.SetSealingThreshold(0) // request receipts for all unsealed finalized blocks
+	consensusMetrics := ms.storageFailureMetrics("headers_by_height", 3)
+
+	headersDB := &mockstorage.Headers{}
+	headersDB.On("ByHeight", mock.Anything).Return(nil, errors.New("transient badger error")).Times(3)
+	headersDB.On("ByHeight", ms.LatestFinalizedBlock.Header.Height).Return(ms.LatestFinalizedBlock.Header, nil)
+	ms.core.headersDB = headersDB
+
+	for i := 0; i < 2; i++ {
+		_, _, err := ms.core.requestPendingReceipts()
+		ms.Require().NoError(err)
+		ms.Require().True(ms.core.Healthy(), "core should be healthy below the threshold")
+	}
+	_, _, err := ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Require().False(ms.core.Healthy(), "core should be unhealthy after reaching the threshold")
+	consensusMetrics.AssertExpectations(ms.T())
+
+	// a successful read resets the consecutive failures
+	ms.ReceiptsDB.On("ByBlockID", ms.LatestFinalizedBlock.ID()).Return(nil, storage.ErrNotFound)
+	ms.requester.On("Query", ms.LatestFinalizedBlock.ID(), mock.Anything).Return().Once()
+	_, _, err = ms.core.requestPendingReceipts()
+	ms.Require().NoError(err)
+	ms.Require().True(ms.core.Healthy())
+	ms.requester.AssertExpectations(ms.T())
+}
+
+// TestWarmUp verifies that a fresh core repopulates the receipts mempool with the persisted receipts
+// for the lowest unsealed finalized heights, bounded by the maximum number of results to request.
+func (ms *MatchingSuite) TestWarmUp() {
+	unittest.RunWithBadgerDB(ms.T(), func(db *badger.DB) {
+		collector := metrics.NewNoopCollector()
+		receiptsDB := bstorage.NewExecutionReceipts(collector, db, bstorage.NewExecutionResults(collector, db), bstorage.DefaultCacheSize)
+
+		// a finalized chain with 5 unsealed blocks, each with 2 persisted receipts
+		sealed := unittest.BlockHeaderFixture()
+		headersDB := &mockstorage.Headers{}
+		headersDB.On("ByHeight", sealed.Height).Return(&sealed, nil).Maybe()
+		blocks := []*flow.Block{}
+		parent := &sealed
+		for i := 0; i < 5; i++ {
+			block := unittest.BlockWithParentFixture(parent)
+			headersDB.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+			blocks = append(blocks, block)
+			parent = block.Header
+		}
+		receipts := make(map[flow.Identifier]flow.ExecutionReceiptList)
+		for _, block := range blocks {
+			for i := 0; i < 2; i++ {
+				receipt := unittest.ReceiptForBlockFixture(block)
+				ms.Require().NoError(receiptsDB.Store(receipt))
+				receipts[block.ID()] = append(receipts[block.ID()], receipt)
+			}
+		}
+
+		state := &mockprotocol.State{}
+		state.On("Boundaries").Return(blocks[len(blocks)-1].Header, &sealed, nil)
+
+		receiptsPL := consensus.NewExecutionTree()
+		core := NewCore(unittest.Logger(), trace.NewNoopTracer(), collector, collector, state, headersDB, receiptsDB,
+			bstorage.NewReceiptRequests(db), receiptsPL, ms.PendingReceipts, ms.SealsPL, ms.receiptValidator, ms.requester, ms.misbehavior,
+			sealing.NewTraceSampler(0), Config{SealingThreshold: 10, MaxResultsToRequest: 3})
+
+		added, err := core.WarmUp()
+		ms.Require().NoError(err)
+		ms.Require().Equal(uint(6), added)
+		ms.Require().Equal(uint(6), receiptsPL.Size())
+
+		// the receipts of the 3 lowest unsealed blocks are known, the others are not
+		for i, block := range blocks {
+			for _, receipt := range receipts[block.ID()] {
+				isNew, err := receiptsPL.AddReceipt(receipt, block.Header)
+				ms.Require().NoError(err)
+				ms.Require().Equal(i >= 3, isNew, "unexpected mempool state for receipt of block %d", i)
+			}
+		}
+	})
+}
+
+// TestRestoreReceiptRequests verifies that a restarted core, constructed over the same storage,
+// doesn't immediately request the receipts of blocks requested shortly before the restart, while
+// the receipts of blocks whose retry interval has passed are requested. The bookkeeping of sealed
+// blocks is removed from storage, both on restart and once the blocks are sealed.
+func (ms *MatchingSuite) TestRestoreReceiptRequests() {
+	unittest.RunWithBadgerDB(ms.T(), func(db *badger.DB) {
+		requestsDB := bstorage.NewReceiptRequests(db)
+		config := Config{
+			SealingThreshold:    0,
+			MaxResultsToRequest: 10,
+			RequestRetryInitial: time.Minute,
+			RequestRetryMaximum: 10 * time.Minute,
+		}
+
+		// a finalized chain with 4 unsealed blocks without receipts
+		sealed := unittest.BlockHeaderFixture()
+		headersDB := &mockstorage.Headers{}
+		blocks := []*flow.Block{}
+		parent := &sealed
+		for i := 0; i < 4; i++ {
+			block := unittest.BlockWithParentFixture(parent)
+			headersDB.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+			blocks = append(blocks, block)
+			parent = block.Header
+		}
+		ms.ReceiptsDB.On("ByBlockID", mock.Anything).Return(nil, nil)
+		state := &mockprotocol.State{}
+		state.On("Boundaries").Return(blocks[len(blocks)-1].Header, &sealed, nil)
+		newCore := func(requester *mockmodule.Requester) *Core {
+			return NewCore(unittest.Logger(), trace.NewNoopTracer(), metrics.NewNoopCollector(), metrics.NewNoopCollector(),
+				state, headersDB, ms.ReceiptsDB, requestsDB, consensus.NewExecutionTree(), ms.PendingReceipts, ms.SealsPL,
+				ms.receiptValidator, requester, ms.misbehavior, sealing.NewTraceSampler(0), config)
+		}
+
+		// the receipts of all blocks are requested before the restart
+		requester := &mockmodule.Requester{}
+		requester.On("Query", mock.Anything, mock.Anything).Return()
+		_, _, err := newCore(requester).requestPendingReceipts()
+		ms.Require().NoError(err)
+		requester.AssertNumberOfCalls(ms.T(), "Query", len(blocks))
+
+		// the last block was requested a while ago, and a request for the sealed block is left over
+		overdue := &flow.ReceiptRequest{
+			BlockID:       blocks[3].ID(),
+			Height:        blocks[3].Header.Height,
+			LastRequested: time.Now().Add(-time.Hour),
+			Attempts:      3,
+		}
+		ms.Require().NoError(requestsDB.Store(overdue))
+		ms.Require().NoError(requestsDB.Store(&flow.ReceiptRequest{BlockID: sealed.ID(), Height: sealed.Height, Attempts: 1}))
+
+		// after the restart, only the overdue block is requested
+		requester = &mockmodule.Requester{}
+		requester.On("Query", blocks[3].ID(), mock.Anything).Return().Once()
+		core := newCore(requester)
+		_, err = core.WarmUp()
+		ms.Require().NoError(err)
+		ms.Require().Len(core.requestedBlocks, len(blocks))
+		_, _, err = core.requestPendingReceipts()
+		ms.Require().NoError(err)
+		requester.AssertExpectations(ms.T())
+		requester.AssertNumberOfCalls(ms.T(), "Query", 1)
+
+		requests, err := requestsDB.All()
+		ms.Require().NoError(err)
+		ms.Require().Len(requests, len(blocks))
+		for _, request := range requests {
+			if request.BlockID == overdue.BlockID {
+				ms.Assert().Equal(uint64(4), request.Attempts)
+			} else {
+				ms.Assert().Equal(uint64(1), request.Attempts)
+			}
+		}
+
+		// once blocks are sealed, their bookkeeping is removed
+		requester.On("CancelEntityByID", blocks[0].ID()).Return().Once()
+		requester.On("CancelEntityByID", blocks[1].ID()).Return().Once()
+		core.cancelSealedRequests(blocks[1].Header.Height)
+		requester.AssertExpectations(ms.T())
+		requests, err = requestsDB.All()
+		ms.Require().NoError(err)
+		ms.Require().Len(requests, 2)
+	})
+}
+
+// TestRetryInterval verifies that the interval between requests for the receipts of a block
+// doubles with every attempt, up to the maximum.
+func (ms *MatchingSuite) TestRetryInterval() {
+	ms.core.retryInitial = time.Second
+	ms.core.retryMaximum = 10 * time.Second
+	for attempts, expected := range []time.Duration{time.Second, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		ms.Assert().Equal(expected, ms.core.retryInterval(uint64(attempts)), "unexpected interval after %d attempts", attempts)
+	}
+}
@@ -1,7 +1,9 @@
 package matching
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -10,10 +12,14 @@ import (
 	sealing "github.com/onflow/flow-go/engine/consensus"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
+	"github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 // defaultReceiptQueueCapacity maximum capacity of receipts queue
@@ -22,8 +28,73 @@ const defaultReceiptQueueCapacity = 10000
 // defaultIncorporatedBlockQueueCapacity maximum capacity of block incorporated events queue
 const defaultIncorporatedBlockQueueCapacity = 10
 
+// defaultViolationTrackerCapacity is the maximum number of origins whose violations are tracked
+const defaultViolationTrackerCapacity = 100
+
+// DefaultViolationThreshold is the default number of violations by an origin, beyond which the
+// origin is reported as misbehaving.
+const DefaultViolationThreshold = 10
+
+// DefaultReceiptProcessingDeadline is the default deadline for processing a single receipt,
+// after which the overrun is logged and reported to the engine metrics.
+const DefaultReceiptProcessingDeadline = 5 * time.Second
+
+// Opt is a functional option to configure the matching engine.
+type Opt func(*Engine)
+
+// WithReceiptProcessingDeadline sets the deadline for processing a single receipt.
+func WithReceiptProcessingDeadline(deadline time.Duration) Opt {
+	return func(e *Engine) {
+		e.receiptDeadline = deadline
+	}
+}
+
+// WithDeduplicator sets the deduplicator, which drops receipts received from the network that were
+// received recently already, before they are queued for processing.
+func WithDeduplicator(dedup *engine.Deduplicator) Opt {
+	return func(e *Engine) {
+		e.dedup = dedup
+	}
+}
+
+// WithViolationThreshold sets the number of violations by an origin, beyond which the origin is
+// reported as misbehaving. With a threshold of 0, origins are never reported.
+func WithViolationThreshold(threshold uint) Opt {
+	return func(e *Engine) {
+		e.violationThreshold = threshold
+	}
+}
+
+// WithMisbehaviorReporter sets the reporter, which is notified of origins exceeding the
+// violation threshold.
+func WithMisbehaviorReporter(reporter module.MisbehaviorReporter) Opt {
+	return func(e *Engine) {
+		e.misbehavior = reporter
+	}
+}
+
+// WithoutReceiptSignatureVerification disables the verification of the executor signatures of
+// receipts received from the network. It must only be used in tests with unsigned receipts.
+func WithoutReceiptSignatureVerification() Opt {
+	return func(e *Engine) {
+		e.verifySignatures = false
+	}
+}
+
+// WithBackpressureWaterMarks sets the fractions of the pending receipts mempool capacity, at which
+// the engine starts rejecting receipts from the network with an engine.BackpressureError (high), and
+// below which it admits them again (low).
+func WithBackpressureWaterMarks(low float64, high float64) Opt {
+	return func(e *Engine) {
+		e.lowWaterMark = low
+		e.highWaterMark = high
+	}
+}
+
 // Engine is a wrapper struct for `Core` which implements consensus algorithm.
 // Engine is responsible for handling incoming messages, queueing for processing, broadcasting proposals.
+// All fields are immutable after construction; the parameters of the matching logic, which can be
+// updated at runtime, are held by the core.
 type Engine struct {
 	unit                       *engine.Unit
 	log                        zerolog.Logger
@@ -33,11 +104,23 @@ type Engine struct {
 	receipts                   storage.ExecutionReceipts
 	index                      storage.Index
 	metrics                    module.EngineMetrics
+	consensusMetrics           module.ConsensusMetrics
 	inboundEventsNotifier      engine.Notifier
 	finalizationEventsNotifier engine.Notifier
 	blockIncorporatedNotifier  engine.Notifier
 	pendingReceipts            *fifoqueue.FifoQueue
 	pendingIncorporatedBlocks  *fifoqueue.FifoQueue
+	receiptDeadline            time.Duration        // deadline for processing a single receipt
+	dedup                      *engine.Deduplicator // drops receipts which were received recently
+	violations                 *violationTracker    // counts rejected receipts by origin
+	violationThreshold         uint                 // number of violations, beyond which an origin is reported
+	misbehavior                module.MisbehaviorReporter
+	receiptPool                mempool.PendingReceipts   // mempool of the core, which ejects receipts once it is full
+	saturation                 *engine.SaturationTracker // tracks saturation of the pending receipts mempool
+	lowWaterMark               float64                   // fraction of the mempool capacity, below which receipts are admitted again
+	highWaterMark              float64                   // fraction of the mempool capacity, at which receipts are rejected
+	receiptVerifier            module.Verifier           // verifies the executor signatures of receipts
+	verifySignatures           bool                      // whether executor signatures of receipts are verified
 }
 
 func NewEngine(
@@ -45,16 +128,20 @@ func NewEngine(
 	net network.Network,
 	me module.Local,
 	engineMetrics module.EngineMetrics,
-	mempool module.MempoolMetrics,
+	consensusMetrics module.ConsensusMetrics,
+	mempoolMetrics module.MempoolMetrics,
 	state protocol.State,
 	receipts storage.ExecutionReceipts,
 	index storage.Index,
-	core sealing.MatchingCore) (*Engine, error) {
+	core sealing.MatchingCore,
+	receiptPool mempool.PendingReceipts,
+	receiptVerifier module.Verifier,
+	opts ...Opt) (*Engine, error) {
 
 	// FIFO queue for execution receipts
 	receiptsQueue, err := fifoqueue.NewFifoQueue(
 		fifoqueue.WithCapacity(defaultReceiptQueueCapacity),
-		fifoqueue.WithLengthObserver(func(len int) { mempool.MempoolEntries(metrics.ResourceBlockProposalQueue, uint(len)) }),
+		fifoqueue.WithLengthObserver(func(len int) { mempoolMetrics.MempoolEntries(metrics.ResourceBlockProposalQueue, uint(len)) }),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create queue for inbound receipts: %w", err)
@@ -66,22 +153,54 @@ func NewEngine(
 		return nil, fmt.Errorf("failed to create queue for incorporated block events: %w", err)
 	}
 
+	dedup, err := engine.NewDeduplicator(engine.WithDeduplicatorCapacity(defaultReceiptQueueCapacity))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create receipt deduplicator: %w", err)
+	}
+
 	e := &Engine{
 		log:                        log.With().Str("engine", "matching.Engine").Logger(),
 		unit:                       engine.NewUnit(),
 		me:                         me,
 		core:                       core,
+		receiptPool:                receiptPool,
 		state:                      state,
 		receipts:                   receipts,
 		index:                      index,
 		metrics:                    engineMetrics,
+		consensusMetrics:           consensusMetrics,
 		inboundEventsNotifier:      engine.NewNotifier(),
 		finalizationEventsNotifier: engine.NewNotifier(),
 		blockIncorporatedNotifier:  engine.NewNotifier(),
 		pendingReceipts:            receiptsQueue,
 		pendingIncorporatedBlocks:  pendingIncorporatedBlocks,
+		receiptDeadline:            DefaultReceiptProcessingDeadline,
+		dedup:                      dedup,
+		violationThreshold:         DefaultViolationThreshold,
+		misbehavior:                misbehavior.NewNoopReporter(),
+		lowWaterMark:               engine.DefaultLowWaterMark,
+		highWaterMark:              engine.DefaultHighWaterMark,
+		receiptVerifier:            receiptVerifier,
+		verifySignatures:           true,
 	}
 
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.violations = newViolationTracker(defaultViolationTrackerCapacity, e.violationThreshold)
+	e.saturation, err = engine.NewSaturationTracker(
+		engine.WithWaterMarks(e.lowWaterMark, e.highWaterMark),
+		engine.WithSaturationObserver(func(duration time.Duration) {
+			mempoolMetrics.MempoolSaturated(metrics.ResourcePendingReceipt, duration)
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saturation tracker for pending receipts: %w", err)
+	}
+
+	// processing a receipt keeps running past its deadline, but the overrun is observable
+	e.unit.OnDeadlineExceeded(e.onReceiptDeadlineExceeded)
+
 	// register engine with the receipt provider
 	_, err = net.Register(engine.ReceiveReceipts, e)
 	if err != nil {
@@ -107,6 +226,12 @@ func (e *Engine) Done() <-chan struct{} {
 	return e.unit.Done()
 }
 
+// ViolationReport returns the number of receipts rejected per violation type, for the origins
+// with the most violations.
+func (e *Engine) ViolationReport() ViolationReport {
+	return e.violations.Report()
+}
+
 // SubmitLocal submits an event originating on the local node.
 func (e *Engine) SubmitLocal(event interface{}) {
 	err := e.ProcessLocal(event)
@@ -132,13 +257,49 @@ func (e *Engine) ProcessLocal(event interface{}) error {
 
 // Process processes the given event from the node with the given origin ID in
 // a blocking manner. It returns the potential processing error when done.
+// Receipts must be sent by their executor, which must be a staked execution node; other
+// receipts are dropped, and recorded as violation of the origin. While the mempool of pending
+// receipts is saturated, receipts are rejected with an engine.BackpressureError, instead of
+// admitting receipts which the mempool would immediately eject. Receipts which were received
+// recently already are dropped. The copies of a receipt delivered repeatedly are identical, and it
+// is sufficient to process the first one. The origin is checked before deduplication, so that copies
+// of a receipt sent by other nodes cannot suppress the receipt sent by its executor. Receipts are
+// deduplicated by their checksum, which covers the executor signature, so that a copy with a forged
+// signature cannot suppress the correctly signed receipt either. Receipts with an invalid executor
+// signature are rejected with an engine.InvalidInputError, before they are queued for processing.
 func (e *Engine) Process(channel network.Channel, originID flow.Identifier, event interface{}) error {
+	if receipt, ok := event.(*flow.ExecutionReceipt); ok {
+		if e.saturation.Check(e.receiptPool.Size(), e.receiptPool.Limit()) {
+			return engine.NewBackpressureErrorf("pending receipts mempool is saturated, rejecting receipt %x from %x", receipt.ID(), originID)
+		}
+		executor, err := e.checkOrigin(originID, receipt)
+		if err != nil {
+			return fmt.Errorf("could not check origin of receipt: %w", err)
+		}
+		if executor == nil {
+			return nil
+		}
+		if e.dedup.Seen(receipt.Checksum()) {
+			e.metrics.MessageDeduplicated(metrics.EngineSealing, metrics.MessageExecutionReceipt)
+			return nil
+		}
+		if e.verifySignatures {
+			err = e.verifySignature(executor, receipt)
+			if err != nil {
+				return fmt.Errorf("could not verify signature of receipt %x: %w", receipt.ID(), err)
+			}
+		}
+	}
+
 	err := e.process(originID, event)
 	if err != nil {
 		if engine.IsIncompatibleInputTypeError(err) {
 			e.log.Warn().Msgf("%v delivered unsupported message %T through %v", originID, event, channel)
 			return nil
 		}
+		if engine.IsBackpressureError(err) {
+			return err
+		}
 		return fmt.Errorf("unexpected error while processing engine message: %w", err)
 	}
 	return nil
@@ -152,11 +313,81 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 			engine.IncompatibleInputTypeError)
 	}
 	e.metrics.MessageReceived(metrics.EngineSealing, metrics.MessageExecutionReceipt)
-	e.pendingReceipts.Push(receipt)
+	e.pendingReceipts.Push(&engine.Message{OriginID: originID, Payload: receipt})
 	e.inboundEventsNotifier.Notify()
 	return nil
 }
 
+// checkOrigin checks that the receipt was sent by its executor, which must be a staked
+// execution node, and that it is structurally valid. Otherwise, the violation is recorded
+// and nil is returned. Otherwise, the identity of the executor is returned. Any error indicates
+// an unexpected problem reading the protocol state.
+func (e *Engine) checkOrigin(originID flow.Identifier, receipt *flow.ExecutionReceipt) (*flow.Identity, error) {
+	identity, err := e.state.Final().Identity(originID)
+	if protocol.IsIdentityNotFound(err) {
+		e.onViolation(originID, "unknown", ViolationUnstaked, receipt)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get identity of origin %x: %w", originID, err)
+	}
+
+	role := identity.Role.String()
+	switch {
+	case identity.Stake == 0 || identity.Ejected:
+		e.onViolation(originID, role, ViolationUnstaked, receipt)
+		return nil, nil
+	case identity.Role != flow.RoleExecution:
+		e.onViolation(originID, role, ViolationInvalidRole, receipt)
+		return nil, nil
+	case receipt.ExecutorID != originID:
+		e.onViolation(originID, role, ViolationOriginMismatch, receipt)
+		return nil, nil
+	case len(receipt.ExecutionResult.Chunks) == 0:
+		e.onViolation(originID, role, ViolationMalformed, receipt)
+		return nil, nil
+	}
+	return identity, nil
+}
+
+// verifySignature verifies the signature of the receipt against the staking key of its executor.
+// Returns an engine.InvalidInputError if the signature is invalid, which is recorded as violation
+// of the executor.
+func (e *Engine) verifySignature(executor *flow.Identity, receipt *flow.ExecutionReceipt) error {
+	msg := messages.ExecutionReceiptMessage(receipt.Meta())
+	valid, err := e.receiptVerifier.Verify(msg, receipt.ExecutorSignature, executor.StakingPubKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if !valid {
+		e.onViolation(executor.NodeID, executor.Role.String(), ViolationInvalidSignature, receipt)
+		return engine.NewInvalidInputErrorf("invalid signature by executor %x", executor.NodeID)
+	}
+	return nil
+}
+
+// onViolation records the violation of the origin with the given role. Once the number of
+// violations of the origin reaches the threshold, the origin is reported as misbehaving.
+func (e *Engine) onViolation(originID flow.Identifier, role string, violation Violation, receipt *flow.ExecutionReceipt) {
+	e.consensusMetrics.InvalidSubmission(role, string(violation))
+	total, reachedThreshold := e.violations.Record(originID, violation)
+
+	log := e.log.With().
+		Hex("origin_id", originID[:]).
+		Str("origin_role", role).
+		Str("violation", string(violation)).
+		Hex("receipt_id", logging.Entity(receipt)).
+		Uint64("violations", total).
+		Logger()
+
+	if !reachedThreshold {
+		log.Warn().Msg("dropping invalid receipt")
+		return
+	}
+	log.Error().Msg("origin exceeded threshold of invalid receipts")
+	e.misbehavior.Report(originID, flow.MisbehaviorRepeatedInvalidSubmissions, receipt)
+}
+
 // HandleReceipt ingests receipts from the Requester module.
 func (e *Engine) HandleReceipt(originID flow.Identifier, receipt flow.Entity) {
 	e.log.Debug().Msg("received receipt from requester engine")
@@ -197,7 +428,8 @@ func (e *Engine) processIncorporatedBlock(finalizedBlockID flow.Identifier) erro
 		if err != nil {
 			return fmt.Errorf("could not retrieve receipt incorporated in block %v: %w", finalizedBlockID, err)
 		}
-		e.pendingReceipts.Push(receipt)
+		// receipts incorporated in a block are processed on behalf of this node
+		e.pendingReceipts.Push(&engine.Message{OriginID: e.me.NodeID(), Payload: receipt})
 	}
 	e.inboundEventsNotifier.Notify()
 	return nil
@@ -215,6 +447,10 @@ func (e *Engine) finalizationProcessingLoop() {
 			if err != nil {
 				e.log.Fatal().Err(err).Msg("could not process last finalized event")
 			}
+			// a persistent storage failure would otherwise only be logged, while sealing stalls
+			if !e.core.Healthy() {
+				e.log.Fatal().Msg("matching core repeatedly failed to read from storage")
+			}
 		}
 	}
 }
@@ -297,7 +533,7 @@ func (e *Engine) processAvailableEvents() error {
 
 		msg, ok = e.pendingReceipts.Pop()
 		if ok {
-			err := e.core.ProcessReceipt(msg.(*flow.ExecutionReceipt))
+			err := e.processReceipt(msg.(*engine.Message))
 			if err != nil {
 				return fmt.Errorf("could not handle execution receipt: %w", err)
 			}
@@ -309,3 +545,22 @@ func (e *Engine) processAvailableEvents() error {
 		return nil
 	}
 }
+
+// processReceipt forwards the receipt to the matching core for processing, with a context
+// carrying the origin ID of the receipt and the configured per-receipt deadline.
+func (e *Engine) processReceipt(msg *engine.Message) error {
+	ctx := engine.WithOriginID(e.unit.Ctx(), msg.OriginID)
+	return e.unit.DoWithDeadline(ctx, e.receiptDeadline, func(ctx context.Context) error {
+		return e.core.ProcessReceipt(msg.Payload.(*flow.ExecutionReceipt))
+	})
+}
+
+// onReceiptDeadlineExceeded is invoked when processing a receipt is still running past its deadline.
+func (e *Engine) onReceiptDeadlineExceeded(ctx context.Context, deadline time.Duration) {
+	originID, _ := engine.OriginIDFromContext(ctx)
+	e.log.Warn().
+		Hex("origin_id", originID[:]).
+		Dur("deadline", deadline).
+		Msg("receipt processing exceeded deadline")
+	e.metrics.MessageDeadlineExceeded(metrics.EngineSealing, metrics.MessageExecutionReceipt)
+}
@@ -0,0 +1,106 @@
+// +build relic
+
+package matching
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/module/signature/messages"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// verifyingEngine returns an engine, which verifies the executor signatures of receipts.
+func (s *MatchingEngineSuite) verifyingEngine() *Engine {
+	me := &mockmodule.Local{}
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+	net := &mocknetwork.Network{}
+	net.On("Register", mock.Anything, mock.Anything).Return(&mocknetwork.Conduit{}, nil).Once()
+
+	noop := metrics.NewNoopCollector()
+	e, err := NewEngine(unittest.Logger(), net, me, noop, noop, noop, s.state, s.receipts, s.index, s.core,
+		signature.NewAggregationVerifier(messages.ExecutionReceiptTag))
+	s.Require().NoError(err)
+	<-e.Ready()
+	return e
+}
+
+// signedReceipt returns a receipt of the executor, signed with the given staking key.
+func (s *MatchingEngineSuite) signedReceipt(executor *flow.Identity, stakingKey crypto.PrivateKey) *flow.ExecutionReceipt {
+	block := unittest.BlockFixture()
+	return unittest.ReceiptForBlockFixture(&block,
+		unittest.WithExecutorID(executor.NodeID),
+		unittest.WithSignedReceipt(stakingKey, crypto.NewBLSKMAC(messages.ExecutionReceiptTag)),
+	)
+}
+
+// TestSignedReceipt tests that a receipt signed by its executor is processed.
+func (s *MatchingEngineSuite) TestSignedReceipt() {
+	e := s.verifyingEngine()
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.identities[executor.NodeID] = executor
+
+	receipt := s.signedReceipt(executor, unittest.StakingPrivKeyByIdentifier(executor.NodeID))
+	s.core.On("ProcessReceipt", receipt).Return(nil).Once()
+	s.Require().NoError(e.Process(engine.ReceiveReceipts, executor.NodeID, receipt))
+
+	// matching engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+	s.core.AssertExpectations(s.T())
+	s.Assert().Empty(e.ViolationReport())
+}
+
+// TestTamperedReceiptSignature tests that a receipt with a tampered signature is rejected before
+// it is queued, and that it does not suppress the correctly signed receipt.
+func (s *MatchingEngineSuite) TestTamperedReceiptSignature() {
+	e := s.verifyingEngine()
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.identities[executor.NodeID] = executor
+
+	receipt := s.signedReceipt(executor, unittest.StakingPrivKeyByIdentifier(executor.NodeID))
+	tampered := *receipt
+	tampered.ExecutorSignature = make(crypto.Signature, len(receipt.ExecutorSignature))
+	copy(tampered.ExecutorSignature, receipt.ExecutorSignature)
+	tampered.ExecutorSignature[len(tampered.ExecutorSignature)-1] ^= 0x01
+
+	err := e.Process(engine.ReceiveReceipts, executor.NodeID, &tampered)
+	s.Require().Error(err)
+	s.Require().True(engine.IsInvalidInputError(err))
+	s.Assert().Equal(0, e.pendingReceipts.Len())
+
+	// the correctly signed copy of the receipt is still processed
+	s.core.On("ProcessReceipt", receipt).Return(nil).Once()
+	s.Require().NoError(e.Process(engine.ReceiveReceipts, executor.NodeID, receipt))
+
+	time.Sleep(1 * time.Second)
+	s.core.AssertExpectations(s.T())
+	s.core.AssertNumberOfCalls(s.T(), "ProcessReceipt", 1)
+	s.Assert().Equal(ViolationReport{executor.NodeID: {ViolationInvalidSignature: 1}}, e.ViolationReport())
+}
+
+// TestReceiptSignedByOtherNode tests that a receipt signed with the staking key of another node
+// than its executor is rejected.
+func (s *MatchingEngineSuite) TestReceiptSignedByOtherNode() {
+	e := s.verifyingEngine()
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	other := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.identities[executor.NodeID] = executor
+	s.identities[other.NodeID] = other
+
+	receipt := s.signedReceipt(executor, unittest.StakingPrivKeyByIdentifier(other.NodeID))
+	err := e.Process(engine.ReceiveReceipts, executor.NodeID, receipt)
+	require.True(s.T(), engine.IsInvalidInputError(err))
+
+	time.Sleep(200 * time.Millisecond)
+	s.core.AssertNotCalled(s.T(), "ProcessReceipt", mock.Anything)
+	s.Assert().Equal(ViolationReport{executor.NodeID: {ViolationInvalidSignature: 1}}, e.ViolationReport())
+}
@@ -1,10 +1,14 @@
 package matching
 
 import (
+	"bytes"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -12,9 +16,11 @@ import (
 	"github.com/onflow/flow-go/engine"
 	mockconsensus "github.com/onflow/flow-go/engine/consensus/mock"
 	"github.com/onflow/flow-go/model/flow"
+	mockmempool "github.com/onflow/flow-go/module/mempool/mock"
 	"github.com/onflow/flow-go/module/metrics"
 	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/state/protocol"
 	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
 	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -27,10 +33,11 @@ func TestMatchingEngineContext(t *testing.T) {
 type MatchingEngineSuite struct {
 	suite.Suite
 
-	index    *mockstorage.Index
-	receipts *mockstorage.ExecutionReceipts
-	core     *mockconsensus.MatchingCore
-	state    *mockprotocol.State
+	index      *mockstorage.Index
+	receipts   *mockstorage.ExecutionReceipts
+	core       *mockconsensus.MatchingCore
+	state      *mockprotocol.State
+	identities map[flow.Identifier]*flow.Identity // identities at the finalized state
 
 	// Matching Engine
 	engine *Engine
@@ -44,6 +51,8 @@ func (s *MatchingEngineSuite) SetupTest() {
 	s.index = &mockstorage.Index{}
 	s.receipts = &mockstorage.ExecutionReceipts{}
 	s.state = &mockprotocol.State{}
+	s.identities = make(map[flow.Identifier]*flow.Identity)
+	s.state.On("Final").Return(identitySnapshot(s.identities))
 
 	ourNodeID := unittest.IdentifierFixture()
 	me.On("NodeID").Return(ourNodeID)
@@ -52,7 +61,8 @@ func (s *MatchingEngineSuite) SetupTest() {
 	net.On("Register", mock.Anything, mock.Anything).Return(con, nil).Once()
 
 	var err error
-	s.engine, err = NewEngine(unittest.Logger(), net, me, metrics, metrics, s.state, s.receipts, s.index, s.core)
+	s.engine, err = NewEngine(unittest.Logger(), net, me, metrics, metrics, metrics, s.state, s.receipts, s.index, s.core, emptyReceiptPool(), nil,
+		WithoutReceiptSignatureVerification())
 	require.NoError(s.T(), err)
 
 	<-s.engine.Ready()
@@ -66,6 +76,7 @@ func (s *MatchingEngineSuite) TestOnFinalizedBlock() {
 	finalizedBlockID := finalizedBlock.ID()
 	s.state.On("Final").Return(unittest.StateSnapshotForKnownBlock(&finalizedBlock, nil))
 	s.core.On("OnBlockFinalization").Return(nil).Once()
+	s.core.On("Healthy").Return(true).Once()
 	s.engine.OnFinalizedBlock(finalizedBlockID)
 
 	// matching engine has at least 100ms ticks for processing events
@@ -103,7 +114,9 @@ func (s *MatchingEngineSuite) TestOnBlockIncorporated() {
 // TestMultipleProcessingItems tests that the engine queues multiple receipts
 // and eventually feeds them into matching.Core for processing
 func (s *MatchingEngineSuite) TestMultipleProcessingItems() {
-	originID := unittest.IdentifierFixture()
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.identities[executor.NodeID] = executor
+	originID := executor.NodeID
 	block := unittest.BlockFixture()
 
 	receipts := make([]*flow.ExecutionReceipt, 20)
@@ -134,6 +147,77 @@ func (s *MatchingEngineSuite) TestMultipleProcessingItems() {
 	s.core.AssertExpectations(s.T())
 }
 
+// TestDuplicateReceiptDropped tests that a receipt, which is delivered repeatedly, is fed into
+// matching.Core only once, while receipts incorporated in blocks are always processed.
+func (s *MatchingEngineSuite) TestDuplicateReceiptDropped() {
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.identities[executor.NodeID] = executor
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(executor.NodeID))
+	s.core.On("ProcessReceipt", receipt).Return(nil).Once()
+
+	for i := 0; i < 3; i++ {
+		err := s.engine.Process(engine.ReceiveReceipts, executor.NodeID, receipt)
+		s.Require().NoError(err)
+	}
+
+	// matching engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+	s.core.AssertNumberOfCalls(s.T(), "ProcessReceipt", 1)
+
+	// the receipt is processed again, when it is incorporated in a block
+	incorporatedBlockID := unittest.IdentifierFixture()
+	s.index.On("ByBlockID", incorporatedBlockID).Return(&flow.Index{ReceiptIDs: []flow.Identifier{receipt.ID()}}, nil)
+	s.receipts.On("ByID", receipt.ID()).Return(receipt, nil).Once()
+	s.core.On("ProcessReceipt", receipt).Return(nil).Once()
+	s.engine.OnBlockIncorporated(incorporatedBlockID)
+
+	time.Sleep(1 * time.Second)
+	s.core.AssertExpectations(s.T())
+	s.core.AssertNumberOfCalls(s.T(), "ProcessReceipt", 2)
+}
+
+// TestInvalidOrigin tests that receipts, which are not sent by their executor as a staked execution
+// node, or are malformed, are dropped and recorded as violation of their origin. In particular,
+// copies of a receipt sent by other nodes must not suppress the receipt sent by its executor.
+func (s *MatchingEngineSuite) TestInvalidOrigin() {
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	unstaked := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithStake(0))
+	for _, identity := range []*flow.Identity{executor, verifier, unstaked} {
+		s.identities[identity.NodeID] = identity
+	}
+	unknownID := unittest.IdentifierFixture()
+
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(executor.NodeID))
+	malformed := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(executor.NodeID))
+	malformed.ExecutionResult.Chunks = nil
+
+	s.Require().NoError(s.engine.Process(engine.ReceiveReceipts, verifier.NodeID, receipt))
+	s.Require().NoError(s.engine.Process(engine.ReceiveReceipts, unstaked.NodeID, receipt))
+	s.Require().NoError(s.engine.Process(engine.ReceiveReceipts, unknownID, receipt))
+	s.Require().NoError(s.engine.Process(engine.ReceiveReceipts, executor.NodeID, malformed))
+	otherExecutor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	s.identities[otherExecutor.NodeID] = otherExecutor
+	s.Require().NoError(s.engine.Process(engine.ReceiveReceipts, otherExecutor.NodeID, receipt))
+
+	// the receipt sent by its executor is still processed
+	s.core.On("ProcessReceipt", receipt).Return(nil).Once()
+	s.Require().NoError(s.engine.Process(engine.ReceiveReceipts, executor.NodeID, receipt))
+
+	// matching engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+	s.core.AssertExpectations(s.T())
+	s.core.AssertNumberOfCalls(s.T(), "ProcessReceipt", 1)
+
+	s.Assert().Equal(ViolationReport{
+		verifier.NodeID:      {ViolationInvalidRole: 1},
+		unstaked.NodeID:      {ViolationUnstaked: 1},
+		unknownID:            {ViolationUnstaked: 1},
+		executor.NodeID:      {ViolationMalformed: 1},
+		otherExecutor.NodeID: {ViolationOriginMismatch: 1},
+	}, s.engine.ViolationReport())
+}
+
 // TestProcessUnsupportedMessageType tests that Process and ProcessLocal correctly handle a case where invalid message type
 // was submitted from network layer.
 func (s *MatchingEngineSuite) TestProcessUnsupportedMessageType() {
@@ -146,3 +230,195 @@ func (s *MatchingEngineSuite) TestProcessUnsupportedMessageType() {
 	require.Error(s.T(), err)
 	require.True(s.T(), engine.IsIncompatibleInputTypeError(err))
 }
+
+// TestReceiptProcessingDeadline tests that receipts whose processing exceeds the deadline
+// are logged and reported to the engine metrics, while fast receipts are not reported.
+func TestReceiptProcessingDeadline(t *testing.T) {
+	core := &mockconsensus.MatchingCore{}
+	me := &mockmodule.Local{}
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+	net := &mocknetwork.Network{}
+	net.On("Register", mock.Anything, mock.Anything).Return(&mocknetwork.Conduit{}, nil).Once()
+	engineMetrics := &mockmodule.EngineMetrics{}
+	engineMetrics.On("MessageReceived", metrics.EngineSealing, metrics.MessageExecutionReceipt)
+
+	var logs bytes.Buffer
+	var logsMu sync.Mutex
+	log := zerolog.New(writerFunc(func(p []byte) (int, error) {
+		logsMu.Lock()
+		defer logsMu.Unlock()
+		return logs.Write(p)
+	}))
+
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	state := &mockprotocol.State{}
+	state.On("Final").Return(identitySnapshot(map[flow.Identifier]*flow.Identity{executor.NodeID: executor}))
+
+	noop := metrics.NewNoopCollector()
+	e, err := NewEngine(log, net, me, engineMetrics, noop, noop, state, &mockstorage.ExecutionReceipts{}, &mockstorage.Index{}, core, emptyReceiptPool(), nil,
+		WithoutReceiptSignatureVerification(),
+		WithReceiptProcessingDeadline(50*time.Millisecond))
+	require.NoError(t, err)
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "ready did not close")
+
+	originID := executor.NodeID
+	fast := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(originID))
+	slow := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(originID))
+	processed := make(chan struct{}, 2)
+	core.On("ProcessReceipt", fast).Return(nil).Run(func(mock.Arguments) {
+		processed <- struct{}{}
+	}).Once()
+	core.On("ProcessReceipt", slow).Return(nil).Run(func(mock.Arguments) {
+		time.Sleep(200 * time.Millisecond)
+		processed <- struct{}{}
+	}).Once()
+
+	overrun := make(chan struct{})
+	engineMetrics.On("MessageDeadlineExceeded", metrics.EngineSealing, metrics.MessageExecutionReceipt).Run(func(mock.Arguments) {
+		close(overrun)
+	}).Once()
+
+	// the fast receipt is processed within the deadline
+	require.NoError(t, e.Process(engine.ReceiveReceipts, originID, fast))
+	unittest.RequireReturnsBefore(t, func() { <-processed }, time.Second, "fast receipt was not processed")
+	time.Sleep(100 * time.Millisecond)
+	engineMetrics.AssertNotCalled(t, "MessageDeadlineExceeded", mock.Anything, mock.Anything)
+
+	// the slow receipt keeps being processed past the deadline, but the overrun is reported
+	require.NoError(t, e.Process(engine.ReceiveReceipts, originID, slow))
+	unittest.RequireCloseBefore(t, overrun, time.Second, "deadline overrun was not reported")
+	unittest.RequireReturnsBefore(t, func() { <-processed }, time.Second, "slow receipt was not processed")
+
+	logsMu.Lock()
+	output := logs.String()
+	logsMu.Unlock()
+	assert.Contains(t, output, "receipt processing exceeded deadline")
+	assert.Contains(t, output, originID.String())
+	assert.Equal(t, 1, strings.Count(output, "exceeded deadline"))
+
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "done did not close")
+	core.AssertExpectations(t)
+	engineMetrics.AssertExpectations(t)
+}
+
+// TestViolationThreshold tests that an origin, which repeatedly sends invalid receipts, is reported
+// as misbehaving once its violations reach the threshold.
+func TestViolationThreshold(t *testing.T) {
+	me := &mockmodule.Local{}
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+	net := &mocknetwork.Network{}
+	net.On("Register", mock.Anything, mock.Anything).Return(&mocknetwork.Conduit{}, nil).Once()
+
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	state := &mockprotocol.State{}
+	state.On("Final").Return(identitySnapshot(map[flow.Identifier]*flow.Identity{verifier.NodeID: verifier}))
+
+	consensusMetrics := &mockmodule.ConsensusMetrics{}
+	consensusMetrics.On("InvalidSubmission", flow.RoleVerification.String(), string(ViolationInvalidRole)).Times(5)
+	reporter := &mockmodule.MisbehaviorReporter{}
+	reporter.On("Report", verifier.NodeID, flow.MisbehaviorRepeatedInvalidSubmissions, mock.Anything).Once()
+
+	noop := metrics.NewNoopCollector()
+	core := &mockconsensus.MatchingCore{}
+	e, err := NewEngine(unittest.Logger(), net, me, noop, consensusMetrics, noop, state, &mockstorage.ExecutionReceipts{}, &mockstorage.Index{}, core, emptyReceiptPool(), nil,
+		WithoutReceiptSignatureVerification(),
+		WithViolationThreshold(3),
+		WithMisbehaviorReporter(reporter))
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(verifier.NodeID))
+		require.NoError(t, e.Process(engine.ReceiveReceipts, verifier.NodeID, receipt))
+	}
+
+	reporter.AssertExpectations(t)
+	consensusMetrics.AssertExpectations(t)
+	core.AssertNotCalled(t, "ProcessReceipt", mock.Anything)
+	assert.Equal(t, ViolationReport{verifier.NodeID: {ViolationInvalidRole: 5}}, e.ViolationReport())
+}
+
+// TestBackpressure tests that receipts from the network are rejected with a back-pressure error
+// once the pending receipts mempool reaches the high-water mark, and admitted again only after the
+// mempool dropped below the low-water mark.
+func TestBackpressure(t *testing.T) {
+	me := &mockmodule.Local{}
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+	net := &mocknetwork.Network{}
+	net.On("Register", mock.Anything, mock.Anything).Return(&mocknetwork.Conduit{}, nil).Once()
+
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	state := &mockprotocol.State{}
+	state.On("Final").Return(identitySnapshot(map[flow.Identifier]*flow.Identity{executor.NodeID: executor}))
+
+	var size uint
+	pool := &mockmempool.PendingReceipts{}
+	pool.On("Size").Return(func() uint { return size })
+	pool.On("Limit").Return(uint(1000))
+
+	noop := metrics.NewNoopCollector()
+	e, err := NewEngine(unittest.Logger(), net, me, noop, noop, noop, state, &mockstorage.ExecutionReceipts{}, &mockstorage.Index{}, &mockconsensus.MatchingCore{}, pool, nil,
+		WithoutReceiptSignatureVerification(),
+		WithBackpressureWaterMarks(0.5, 0.8))
+	require.NoError(t, err)
+
+	process := func() error {
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(executor.NodeID))
+		return e.Process(engine.ReceiveReceipts, executor.NodeID, receipt)
+	}
+
+	// below the high-water mark, receipts are admitted
+	size = 799
+	require.NoError(t, process())
+
+	// at the high-water mark, receipts are rejected
+	size = 800
+	require.True(t, engine.IsBackpressureError(process()))
+
+	// between the water marks, receipts are still rejected
+	size = 600
+	require.True(t, engine.IsBackpressureError(process()))
+
+	// below the low-water mark, receipts are admitted again
+	size = 499
+	require.NoError(t, process())
+
+	// receipts from the requester engine bypass back-pressure
+	size = 1000
+	require.True(t, engine.IsBackpressureError(process()))
+	queued := e.pendingReceipts.Len()
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(executor.NodeID))
+	e.HandleReceipt(executor.NodeID, receipt)
+	assert.Equal(t, queued+1, e.pendingReceipts.Len())
+}
+
+// emptyReceiptPool returns an empty pending receipts mempool, which is far from saturation.
+func emptyReceiptPool() *mockmempool.PendingReceipts {
+	pool := &mockmempool.PendingReceipts{}
+	pool.On("Size").Return(uint(0))
+	pool.On("Limit").Return(uint(1000))
+	return pool
+}
+
+// identitySnapshot returns a snapshot, which contains the given identities.
+func identitySnapshot(identities map[flow.Identifier]*flow.Identity) *mockprotocol.Snapshot {
+	snapshot := &mockprotocol.Snapshot{}
+	snapshot.On("Identity", mock.Anything).Return(
+		func(nodeID flow.Identifier) *flow.Identity {
+			return identities[nodeID]
+		},
+		func(nodeID flow.Identifier) error {
+			if _, ok := identities[nodeID]; !ok {
+				return protocol.IdentityNotFoundError{NodeID: nodeID}
+			}
+			return nil
+		},
+	)
+	return snapshot
+}
+
+// writerFunc adapts a function to the io.Writer interface.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
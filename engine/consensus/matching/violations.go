@@ -0,0 +1,106 @@
+package matching
+
+import (
+	"sync"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Violation is the reason why the matching engine rejected a message from another node.
+type Violation string
+
+const (
+	// ViolationInvalidRole is recorded for receipts sent by a node which is not an execution node.
+	ViolationInvalidRole Violation = "invalid_role"
+	// ViolationUnstaked is recorded for receipts sent by a node which is unknown, has no stake
+	// or is ejected.
+	ViolationUnstaked Violation = "unstaked"
+	// ViolationOriginMismatch is recorded for receipts sent by a node other than their executor.
+	ViolationOriginMismatch Violation = "origin_mismatch"
+	// ViolationMalformed is recorded for receipts which are structurally malformed.
+	ViolationMalformed Violation = "structural_failure"
+	// ViolationInvalidSignature is recorded for receipts without a valid signature of their executor.
+	ViolationInvalidSignature Violation = "invalid_signature"
+)
+
+// ViolationReport is the number of violations per violation type, by origin.
+type ViolationReport map[flow.Identifier]map[Violation]uint64
+
+// originViolations is the violation record of a single origin.
+type originViolations struct {
+	counts map[Violation]uint64
+	total  uint64
+}
+
+// violationTracker counts the violations of the origins with the most violations. Its memory
+// is bounded by its capacity: once the capacity is reached, recording a violation of a new
+// origin evicts the record of the origin with the fewest violations. The tracker is safe for
+// concurrent use.
+type violationTracker struct {
+	mu        sync.Mutex
+	capacity  uint
+	threshold uint64 // number of violations from an origin, beyond which the origin is reported
+	origins   map[flow.Identifier]*originViolations
+}
+
+func newViolationTracker(capacity uint, threshold uint) *violationTracker {
+	return &violationTracker{
+		capacity:  capacity,
+		threshold: uint64(threshold),
+		origins:   make(map[flow.Identifier]*originViolations),
+	}
+}
+
+// Record records a violation of the given origin. It returns the total number of violations
+// recorded for the origin, and whether the number of violations reached the threshold with this
+// violation. Hence, the threshold is reached once per origin, unless the origin was evicted
+// from the tracker meanwhile. With a threshold of 0, the threshold is never reached.
+func (t *violationTracker) Record(originID flow.Identifier, violation Violation) (uint64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.origins[originID]
+	if !ok {
+		if uint(len(t.origins)) >= t.capacity {
+			t.evict()
+		}
+		record = &originViolations{counts: make(map[Violation]uint64)}
+		t.origins[originID] = record
+	}
+	record.counts[violation]++
+	record.total++
+
+	return record.total, t.threshold > 0 && record.total == t.threshold
+}
+
+// evict removes the record of the origin with the fewest violations.
+// Must be called with the lock held.
+func (t *violationTracker) evict() {
+	var (
+		evictID flow.Identifier
+		fewest  *originViolations
+	)
+	for originID, record := range t.origins {
+		if fewest == nil || record.total < fewest.total {
+			evictID = originID
+			fewest = record
+		}
+	}
+	delete(t.origins, evictID)
+}
+
+// Report returns a copy of the recorded violations.
+func (t *violationTracker) Report() ViolationReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := make(ViolationReport, len(t.origins))
+	for originID, record := range t.origins {
+		counts := make(map[Violation]uint64, len(record.counts))
+		for violation, count := range record.counts {
+			counts[violation] = count
+		}
+		report[originID] = counts
+	}
+	return report
+}
@@ -0,0 +1,53 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestViolationTracker_Threshold tests that the threshold is reached exactly once per origin.
+func TestViolationTracker_Threshold(t *testing.T) {
+	tracker := newViolationTracker(10, 3)
+	originID := unittest.IdentifierFixture()
+
+	var reached int
+	for i := 0; i < 10; i++ {
+		total, reachedThreshold := tracker.Record(originID, ViolationInvalidRole)
+		assert.Equal(t, uint64(i+1), total)
+		if reachedThreshold {
+			reached++
+			assert.Equal(t, uint64(3), total)
+		}
+	}
+	assert.Equal(t, 1, reached)
+
+	// with a threshold of 0, the threshold is never reached
+	tracker = newViolationTracker(10, 0)
+	for i := 0; i < 10; i++ {
+		_, reachedThreshold := tracker.Record(originID, ViolationInvalidRole)
+		assert.False(t, reachedThreshold)
+	}
+}
+
+// TestViolationTracker_Eviction tests that the tracker is bounded by its capacity, and keeps the
+// origins with the most violations.
+func TestViolationTracker_Eviction(t *testing.T) {
+	tracker := newViolationTracker(2, 0)
+	frequent := unittest.IdentifierFixture()
+	rare := unittest.IdentifierFixture()
+	tracker.Record(frequent, ViolationUnstaked)
+	tracker.Record(frequent, ViolationOriginMismatch)
+	tracker.Record(rare, ViolationUnstaked)
+
+	// a new origin evicts the origin with the fewest violations
+	newcomer := unittest.IdentifierFixture()
+	tracker.Record(newcomer, ViolationMalformed)
+
+	assert.Equal(t, ViolationReport{
+		frequent: {ViolationUnstaked: 1, ViolationOriginMismatch: 1},
+		newcomer: {ViolationMalformed: 1},
+	}, tracker.Report())
+}
@@ -0,0 +1,617 @@
+package matching
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	sealing "github.com/onflow/flow-go/engine/consensus"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool/consensus"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// workloadProfile describes the traffic seen by the matching core of a consensus node.
+type workloadProfile struct {
+	name               string
+	seed               int64
+	blocksPerSecond    uint    // rate at which blocks are finalized
+	duration           uint    // simulated duration in seconds
+	resultsPerBlock    uint    // distinct results per block, all but the first one are never approved
+	receiptsPerResult  uint    // number of execution nodes committing to each result
+	chunksPerResult    uint    // chunks of each result
+	approversPerChunk  uint    // verifiers assigned to each chunk
+	requiredApprovals  uint    // approvals required for sealing a chunk
+	duplicateRate      float64 // probability that a receipt or approval is delivered twice
+	outageBlocks       uint    // blocks at the start, whose receipts and approvals are only delivered afterwards in reverse order
+	approvalsPerVerify uint    // number of approvals each assigned verifier sends for its chunk
+}
+
+// steadyStateProfile models a healthy network, where receipts and approvals arrive in order
+// shortly after the block was finalized.
+var steadyStateProfile = workloadProfile{
+	name:               "steady_state",
+	seed:               1,
+	blocksPerSecond:    1,
+	duration:           300,
+	resultsPerBlock:    1,
+	receiptsPerResult:  2,
+	chunksPerResult:    4,
+	approversPerChunk:  3,
+	requiredApprovals:  2,
+	duplicateRate:      0.05,
+	approvalsPerVerify: 1,
+}
+
+// catchUpProfile models the recovery from an outage of the execution and verification nodes.
+// The receipts and approvals of the blocks finalized during the outage arrive in reverse order
+// after the outage, so receipts accumulate in the pending receipts mempool.
+var catchUpProfile = workloadProfile{
+	name:               "catch_up_after_outage",
+	seed:               2,
+	blocksPerSecond:    1,
+	duration:           300,
+	resultsPerBlock:    2,
+	receiptsPerResult:  2,
+	chunksPerResult:    4,
+	approversPerChunk:  3,
+	requiredApprovals:  2,
+	duplicateRate:      0.05,
+	outageBlocks:       200,
+	approvalsPerVerify: 1,
+}
+
+// approvalFloodProfile models verifiers flooding the node with redundant approvals.
+var approvalFloodProfile = workloadProfile{
+	name:               "approval_flood",
+	seed:               3,
+	blocksPerSecond:    1,
+	duration:           100,
+	resultsPerBlock:    1,
+	receiptsPerResult:  2,
+	chunksPerResult:    8,
+	approversPerChunk:  20,
+	requiredApprovals:  14,
+	duplicateRate:      0.5,
+	approvalsPerVerify: 3,
+}
+
+// workloadEvent is a single input of the harness: a receipt, an approval or the finalization
+// of a block.
+type workloadEvent struct {
+	receipt   *flow.ExecutionReceipt
+	approval  *flow.ResultApproval
+	finalized *flow.Header
+}
+
+// workload is the deterministic sequence of inputs generated from a profile.
+type workload struct {
+	profile      workloadProfile
+	root         *flow.Header
+	rootResult   *flow.ExecutionResult
+	headers      []*flow.Header                               // finalized blocks in order of height, excluding the root
+	incorporated map[flow.Identifier]*flow.IncorporatedResult // incorporated result by ID of the approved result
+	assignments  map[flow.Identifier]*chunks.Assignment       // assignment by ID of the approved result
+	approvers    map[flow.Identifier]*flow.Identity
+	events       []workloadEvent
+	receipts     int
+	approvals    int
+}
+
+// generateWorkload generates the inputs of the given profile from its seed.
+func generateWorkload(profile workloadProfile) *workload {
+	rng := rand.New(rand.NewSource(profile.seed))
+	randomID := func() flow.Identifier {
+		var id flow.Identifier
+		_, _ = rng.Read(id[:])
+		return id
+	}
+	randomSignature := func() []byte {
+		signature := make([]byte, 48)
+		_, _ = rng.Read(signature)
+		return signature
+	}
+	randomState := func() flow.StateCommitment {
+		var state flow.StateCommitment
+		_, _ = rng.Read(state[:])
+		return state
+	}
+
+	w := &workload{
+		profile:      profile,
+		incorporated: make(map[flow.Identifier]*flow.IncorporatedResult),
+		assignments:  make(map[flow.Identifier]*chunks.Assignment),
+		approvers:    make(map[flow.Identifier]*flow.Identity),
+	}
+
+	verifiers := make(flow.IdentityList, 0, 2*profile.approversPerChunk)
+	for i := uint(0); i < 2*profile.approversPerChunk; i++ {
+		verifier := &flow.Identity{NodeID: randomID(), Role: flow.RoleVerification, Stake: 1000}
+		verifiers = append(verifiers, verifier)
+		w.approvers[verifier.NodeID] = verifier
+	}
+	executors := make(flow.IdentifierList, 0, profile.receiptsPerResult)
+	for i := uint(0); i < profile.receiptsPerResult; i++ {
+		executors = append(executors, randomID())
+	}
+
+	start := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	w.root = &flow.Header{
+		ChainID:     flow.Emulator,
+		Height:      0,
+		View:        0,
+		PayloadHash: randomID(),
+		Timestamp:   start,
+	}
+	w.rootResult = &flow.ExecutionResult{BlockID: w.root.ID(), Chunks: flow.ChunkList{
+		{ChunkBody: flow.ChunkBody{BlockID: w.root.ID(), StartState: randomState()}, EndState: randomState()},
+	}}
+
+	// deliver adds the event, and delivers it a second time with the duplicate rate
+	deliver := func(events []workloadEvent, event workloadEvent) []workloadEvent {
+		events = append(events, event)
+		if rng.Float64() < profile.duplicateRate {
+			events = append(events, event)
+		}
+		return events
+	}
+
+	blocks := profile.blocksPerSecond * profile.duration
+	parent := w.root
+	parentResult := w.rootResult
+	delayed := make([][]workloadEvent, 0, profile.outageBlocks)
+	var pendingApprovals []workloadEvent
+	for height := uint64(1); height <= uint64(blocks); height++ {
+		header := &flow.Header{
+			ChainID:     flow.Emulator,
+			ParentID:    parent.ID(),
+			Height:      height,
+			View:        height,
+			PayloadHash: randomID(),
+			Timestamp:   start.Add(time.Duration(height) * time.Second / time.Duration(profile.blocksPerSecond)),
+		}
+		blockID := header.ID()
+		w.headers = append(w.headers, header)
+
+		// the block is finalized, before its receipts are received
+		events := []workloadEvent{{finalized: header}}
+
+		// approvals of the previous block are received together with the receipts of this block
+		events = append(events, pendingApprovals...)
+		pendingApprovals = nil
+
+		var approved *flow.ExecutionResult
+		for r := uint(0); r < profile.resultsPerBlock; r++ {
+			result := &flow.ExecutionResult{
+				PreviousResultID: parentResult.ID(),
+				BlockID:          blockID,
+				ExecutionDataID:  randomID(),
+			}
+			startState := parentResult.Chunks[len(parentResult.Chunks)-1].EndState
+			for c := uint(0); c < profile.chunksPerResult; c++ {
+				chunk := &flow.Chunk{
+					ChunkBody: flow.ChunkBody{
+						CollectionIndex: c,
+						StartState:      startState,
+						EventCollection: randomID(),
+						BlockID:         blockID,
+					},
+					Index:    uint64(c),
+					EndState: randomState(),
+				}
+				startState = chunk.EndState
+				result.Chunks = append(result.Chunks, chunk)
+			}
+			for _, executorID := range executors {
+				events = deliver(events, workloadEvent{receipt: &flow.ExecutionReceipt{
+					ExecutorID:        executorID,
+					ExecutionResult:   *result,
+					ExecutorSignature: randomSignature(),
+				}})
+				w.receipts++
+			}
+			if r == 0 {
+				approved = result
+			}
+		}
+
+		// only the first result is approved by the verifiers. It is incorporated in the block itself,
+		// as matching doesn't depend on the incorporating block.
+		resultID := approved.ID()
+		w.incorporated[resultID] = flow.NewIncorporatedResult(blockID, approved)
+		assignment := chunks.NewAssignment()
+		for _, chunk := range approved.Chunks {
+			rng.Shuffle(len(verifiers), func(i, j int) { verifiers[i], verifiers[j] = verifiers[j], verifiers[i] })
+			assigned := verifiers[:profile.approversPerChunk].NodeIDs()
+			assignment.Add(chunk, assigned)
+			for _, verifierID := range assigned {
+				for a := uint(0); a < profile.approvalsPerVerify; a++ {
+					pendingApprovals = deliver(pendingApprovals, workloadEvent{approval: &flow.ResultApproval{
+						Body: flow.ResultApprovalBody{
+							Attestation: flow.Attestation{
+								BlockID:           blockID,
+								ExecutionResultID: resultID,
+								ChunkIndex:        chunk.Index,
+							},
+							ApproverID:           verifierID,
+							AttestationSignature: randomSignature(),
+							Spock:                randomSignature(),
+						},
+					}})
+					w.approvals++
+				}
+			}
+		}
+		w.assignments[resultID] = assignment
+
+		// during the outage, only the finalization of blocks is observed
+		if height <= uint64(profile.outageBlocks) {
+			w.events = append(w.events, events[0])
+			delayed = append(delayed, events[1:])
+			if height == uint64(profile.outageBlocks) {
+				// after the outage, the delayed receipts are received from the highest to the lowest
+				// block, followed by the approvals
+				for i := len(delayed) - 1; i >= 0; i-- {
+					for _, event := range delayed[i] {
+						if event.receipt != nil {
+							w.events = append(w.events, event)
+						}
+					}
+				}
+				for _, events := range delayed {
+					for _, event := range events {
+						if event.approval != nil {
+							w.events = append(w.events, event)
+						}
+					}
+				}
+			}
+		} else {
+			w.events = append(w.events, events...)
+		}
+
+		parent = header
+		parentResult = approved
+	}
+	w.events = append(w.events, pendingApprovals...)
+
+	return w
+}
+
+// harness drives the matching core with real mempools and in-memory storage, and collects the
+// approvals for the results it stored the same way the sealing core does.
+type harness struct {
+	workload   *workload
+	state      *workloadState
+	headers    *workloadHeaders
+	receipts   *workloadReceipts
+	validator  *workloadValidator
+	seals      *stdmap.IncorporatedResultSeals
+	core       *Core
+	collectors map[flow.Identifier]*approvals.ApprovalCollector
+	sealed     map[uint64]flow.Identifier // IDs of the approved results by height
+
+	receiptLatencies  []time.Duration
+	approvalLatencies []time.Duration
+	sealCount         int
+}
+
+// newHarness creates the matching core for the workload, with the root block sealed.
+func newHarness(w *workload) *harness {
+	h := &harness{
+		workload:   w,
+		state:      &workloadState{finalized: w.root, sealed: w.root},
+		headers:    newWorkloadHeaders(),
+		receipts:   newWorkloadReceipts(),
+		validator:  newWorkloadValidator(w.rootResult.ID()),
+		seals:      stdmap.NewIncorporatedResultSeals(100000),
+		collectors: make(map[flow.Identifier]*approvals.ApprovalCollector),
+		sealed:     make(map[uint64]flow.Identifier),
+
+		receiptLatencies:  make([]time.Duration, 0, w.receipts*2),
+		approvalLatencies: make([]time.Duration, 0, w.approvals*2),
+	}
+	h.headers.add(w.root)
+	for _, header := range w.headers {
+		h.headers.add(header)
+	}
+	for resultID, incorporated := range w.incorporated {
+		header, _ := h.headers.ByBlockID(incorporated.Result.BlockID)
+		h.sealed[header.Height] = resultID
+	}
+
+	collector := metrics.NewNoopCollector()
+	h.core = NewCore(
+		zerolog.Nop(),
+		trace.NewNoopTracer(),
+		collector,
+		collector,
+		h.state,
+		h.headers,
+		h.receipts,
+		&workloadReceiptRequests{},
+		consensus.NewExecutionTree(),
+		stdmap.NewPendingReceipts(h.headers, 100000),
+		h.seals,
+		h.validator,
+		&workloadRequester{},
+		misbehavior.NewNoopReporter(),
+		sealing.NewTraceSampler(0),
+		DefaultConfig(),
+	)
+	return h
+}
+
+// run processes all events of the workload, and returns the total time spent processing
+// receipts and approvals.
+func (h *harness) run() (time.Duration, error) {
+	var busy time.Duration
+	for _, event := range h.workload.events {
+		switch {
+		case event.receipt != nil:
+			start := time.Now()
+			err := h.core.ProcessReceipt(event.receipt)
+			elapsed := time.Since(start)
+			if err != nil {
+				return 0, fmt.Errorf("could not process receipt: %w", err)
+			}
+			h.receiptLatencies = append(h.receiptLatencies, elapsed)
+			busy += elapsed
+
+		case event.approval != nil:
+			collector, err := h.incorporate(event.approval.Body.ExecutionResultID)
+			if err != nil {
+				return 0, err
+			}
+			if collector == nil {
+				// the sealing core caches approvals for unknown results; this is not measured
+				continue
+			}
+			start := time.Now()
+			err = collector.ProcessApproval(event.approval)
+			elapsed := time.Since(start)
+			if err != nil {
+				return 0, fmt.Errorf("could not process approval: %w", err)
+			}
+			h.approvalLatencies = append(h.approvalLatencies, elapsed)
+			busy += elapsed
+
+		case event.finalized != nil:
+			h.state.finalized = event.finalized
+			err := h.sealFinalized()
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	// the remaining candidate seals are included in the blocks following the workload
+	err := h.sealFinalized()
+	if err != nil {
+		return 0, err
+	}
+	return busy, nil
+}
+
+// incorporate returns the approval collector for the approved result with the given ID. The
+// collector is created once the result was stored by the matching core, and nil is returned
+// before, as well as for results which are not approved or already sealed.
+func (h *harness) incorporate(resultID flow.Identifier) (*approvals.ApprovalCollector, error) {
+	collector, exists := h.collectors[resultID]
+	if exists {
+		return collector, nil
+	}
+	incorporated, approved := h.workload.incorporated[resultID]
+	if !approved || !h.validator.isKnown(resultID) {
+		return nil, nil
+	}
+	executed, err := h.headers.ByBlockID(incorporated.Result.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get executed block: %w", err)
+	}
+	if executed.Height <= h.state.sealed.Height {
+		return nil, nil
+	}
+	collector, err = approvals.NewApprovalCollector(
+		zerolog.Nop(),
+		incorporated,
+		executed,
+		executed,
+		h.workload.assignments[resultID],
+		h.workload.approvers,
+		h.seals,
+		approvals.CountThreshold{N: h.workload.profile.requiredApprovals},
+		false,
+		trace.NewNoopTracer(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create approval collector: %w", err)
+	}
+	h.collectors[resultID] = collector
+	return collector, nil
+}
+
+// sealFinalized includes the candidate seals for the lowest unsealed heights in the chain, and
+// notifies the matching core of the finalization.
+func (h *harness) sealFinalized() error {
+	for {
+		next := h.state.sealed.Height + 1
+		resultID, ok := h.sealed[next]
+		if !ok {
+			break
+		}
+		incorporated := h.workload.incorporated[resultID]
+		if _, ok := h.seals.ByID(incorporated.ID()); !ok {
+			break
+		}
+		h.seals.Rem(incorporated.ID())
+		delete(h.collectors, resultID)
+		header, err := h.headers.ByHeight(next)
+		if err != nil {
+			return fmt.Errorf("could not get sealed block: %w", err)
+		}
+		h.state.sealed = header
+		h.sealCount++
+	}
+	err := h.core.OnBlockFinalization()
+	if err != nil {
+		return fmt.Errorf("could not process finalization: %w", err)
+	}
+	return nil
+}
+
+// percentile returns the given percentile of the latencies, which are sorted in place.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	index := int(p * float64(len(latencies)-1))
+	return latencies[index]
+}
+
+// workloadState is the protocol state of the harness, in which blocks are finalized and sealed
+// in order of height.
+type workloadState struct {
+	protocol.State
+	finalized *flow.Header
+	sealed    *flow.Header
+}
+
+func (s *workloadState) SealedHeight() (uint64, error) {
+	return s.sealed.Height, nil
+}
+
+func (s *workloadState) Boundaries() (*flow.Header, *flow.Header, error) {
+	return s.finalized, s.sealed, nil
+}
+
+func (s *workloadState) AtSealed() protocol.Snapshot {
+	return &workloadSnapshot{head: s.sealed}
+}
+
+type workloadSnapshot struct {
+	protocol.Snapshot
+	head *flow.Header
+}
+
+func (s *workloadSnapshot) Head() (*flow.Header, error) {
+	return s.head, nil
+}
+
+// workloadHeaders is an in-memory storage of the headers of the workload.
+type workloadHeaders struct {
+	storage.Headers
+	byID     map[flow.Identifier]*flow.Header
+	byHeight map[uint64]*flow.Header
+}
+
+func newWorkloadHeaders() *workloadHeaders {
+	return &workloadHeaders{
+		byID:     make(map[flow.Identifier]*flow.Header),
+		byHeight: make(map[uint64]*flow.Header),
+	}
+}
+
+func (h *workloadHeaders) add(header *flow.Header) {
+	h.byID[header.ID()] = header
+	h.byHeight[header.Height] = header
+}
+
+func (h *workloadHeaders) ByBlockID(blockID flow.Identifier) (*flow.Header, error) {
+	header, ok := h.byID[blockID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return header, nil
+}
+
+func (h *workloadHeaders) ByHeight(height uint64) (*flow.Header, error) {
+	header, ok := h.byHeight[height]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return header, nil
+}
+
+// workloadReceipts is an in-memory storage of execution receipts.
+type workloadReceipts struct {
+	storage.ExecutionReceipts
+	byID      map[flow.Identifier]*flow.ExecutionReceipt
+	byBlockID map[flow.Identifier]flow.ExecutionReceiptList
+}
+
+func newWorkloadReceipts() *workloadReceipts {
+	return &workloadReceipts{
+		byID:      make(map[flow.Identifier]*flow.ExecutionReceipt),
+		byBlockID: make(map[flow.Identifier]flow.ExecutionReceiptList),
+	}
+}
+
+func (r *workloadReceipts) Store(receipt *flow.ExecutionReceipt) error {
+	receiptID := receipt.ID()
+	if _, ok := r.byID[receiptID]; ok {
+		return storage.ErrAlreadyExists
+	}
+	r.byID[receiptID] = receipt
+	blockID := receipt.ExecutionResult.BlockID
+	r.byBlockID[blockID] = append(r.byBlockID[blockID], receipt)
+	return nil
+}
+
+func (r *workloadReceipts) ByBlockID(blockID flow.Identifier) (flow.ExecutionReceiptList, error) {
+	return r.byBlockID[blockID], nil
+}
+
+// workloadValidator accepts receipts, whose previous result is known, like the receipt validator
+// for receipts with valid signatures.
+type workloadValidator struct {
+	module.ReceiptValidator
+	known map[flow.Identifier]struct{}
+}
+
+func newWorkloadValidator(rootResultID flow.Identifier) *workloadValidator {
+	return &workloadValidator{known: map[flow.Identifier]struct{}{rootResultID: {}}}
+}
+
+func (v *workloadValidator) Validate(receipt *flow.ExecutionReceipt) error {
+	if _, ok := v.known[receipt.ExecutionResult.PreviousResultID]; !ok {
+		return engine.NewUnverifiableInputError("previous result %x is unknown", receipt.ExecutionResult.PreviousResultID)
+	}
+	v.known[receipt.ExecutionResult.ID()] = struct{}{}
+	return nil
+}
+
+func (v *workloadValidator) isKnown(resultID flow.Identifier) bool {
+	_, ok := v.known[resultID]
+	return ok
+}
+
+// workloadRequester drops all requests for missing receipts, as the workload delivers them anyway.
+type workloadRequester struct {
+	module.Requester
+}
+
+func (r *workloadRequester) Query(flow.Identifier, flow.IdentityFilter) {}
+
+func (r *workloadRequester) CancelEntityByID(flow.Identifier) {}
+
+// workloadReceiptRequests drops the bookkeeping of receipt requests, as the workload never restarts.
+type workloadReceiptRequests struct{}
+
+func (r *workloadReceiptRequests) Store(*flow.ReceiptRequest) error { return nil }
+
+func (r *workloadReceiptRequests) All() ([]*flow.ReceiptRequest, error) { return nil, nil }
+
+func (r *workloadReceiptRequests) Remove(flow.Identifier) error { return nil }
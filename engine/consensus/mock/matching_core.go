@@ -12,6 +12,20 @@ type MatchingCore struct {
 	mock.Mock
 }
 
+// Healthy provides a mock function with given fields:
+func (_m *MatchingCore) Healthy() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // OnBlockFinalization provides a mock function with given fields:
 func (_m *MatchingCore) OnBlockFinalization() error {
 	ret := _m.Called()
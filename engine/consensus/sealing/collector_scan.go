@@ -0,0 +1,65 @@
+package sealing
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+)
+
+// collectorScan bounds the number of assignment collectors which are checked per
+// finalized block. With a large backlog of unsealed results (e.g. after an execution
+// outage), checking all collectors on every finalized block can take several seconds.
+// Instead, each scan checks at most `budget` collectors and the next scan resumes at
+// the height where the previous one stopped.
+// collectorScan is concurrency safe.
+type collectorScan struct {
+	lock       sync.Mutex
+	budget     uint   // max number of collectors per scan, 0 means no limit
+	nextHeight uint64 // executed block height at which the next scan starts
+}
+
+func newCollectorScan(budget uint) *collectorScan {
+	return &collectorScan{
+		budget: budget,
+	}
+}
+
+// next selects the collectors for the next scan from the given collectors, which are
+// required to be ordered by executed block height ascending (as returned by
+// AssignmentCollectorTree.GetCollectorsByInterval). The selection starts at the lowest
+// collector with height of at least the height where the previous scan stopped, and
+// wraps around to the lowest height once the highest collector has been selected.
+// If the collectors at the stop height are only partially selected, the next scan
+// re-checks all collectors at this height.
+func (s *collectorScan) next(collectors []approvals.AssignmentCollector) []approvals.AssignmentCollector {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.budget == 0 || uint(len(collectors)) <= s.budget {
+		s.nextHeight = 0
+		return collectors
+	}
+
+	start := sort.Search(len(collectors), func(i int) bool {
+		return collectors[i].Block().Height >= s.nextHeight
+	})
+	if start == len(collectors) {
+		start = 0
+	}
+
+	selected := make([]approvals.AssignmentCollector, 0, s.budget)
+	for i := 0; uint(i) < s.budget; i++ {
+		selected = append(selected, collectors[(start+i)%len(collectors)])
+	}
+
+	startHeight := collectors[start].Block().Height
+	s.nextHeight = collectors[(start+int(s.budget))%len(collectors)].Block().Height
+	if s.nextHeight == startHeight {
+		// a single height holds more collectors than the budget, move on to avoid
+		// checking the same collectors over and over again
+		s.nextHeight++
+	}
+
+	return selected
+}
@@ -0,0 +1,90 @@
+package sealing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	mockapprovals "github.com/onflow/flow-go/engine/consensus/approvals/mock"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// collectorsFixture creates mocked collectors for the given executed block heights.
+func collectorsFixture(heights ...uint64) []approvals.AssignmentCollector {
+	collectors := make([]approvals.AssignmentCollector, 0, len(heights))
+	for _, height := range heights {
+		collector := &mockapprovals.AssignmentCollector{}
+		collector.On("Block").Return(&flow.Header{Height: height})
+		collectors = append(collectors, collector)
+	}
+	return collectors
+}
+
+func heightsOf(collectors []approvals.AssignmentCollector) []uint64 {
+	heights := make([]uint64, 0, len(collectors))
+	for _, collector := range collectors {
+		heights = append(heights, collector.Block().Height)
+	}
+	return heights
+}
+
+func heightRange(from, to uint64) []uint64 {
+	heights := make([]uint64, 0, to-from)
+	for height := from; height < to; height++ {
+		heights = append(heights, height)
+	}
+	return heights
+}
+
+// TestCollectorScan_Budget tests that with a backlog of 1000 results, a scan selects only
+// the budgeted number of collectors, starting with the lowest heights, and that the next
+// scan resumes where the previous one stopped.
+func TestCollectorScan_Budget(t *testing.T) {
+	collectors := collectorsFixture(heightRange(1, 1001)...)
+	scan := newCollectorScan(DefaultMaxResultsPerCheck)
+
+	selected := scan.next(collectors)
+	require.Equal(t, heightRange(1, 501), heightsOf(selected))
+
+	selected = scan.next(collectors)
+	require.Equal(t, heightRange(501, 1001), heightsOf(selected))
+
+	// the lowest 300 results have been sealed in the meantime, the scan
+	// continues with the lowest remaining heights
+	selected = scan.next(collectors[300:])
+	require.Equal(t, heightRange(301, 801), heightsOf(selected))
+
+	// the scan wraps around to the lowest height once the highest collector is selected
+	selected = scan.next(collectors[300:])
+	require.Equal(t, append(heightRange(801, 1001), heightRange(301, 601)...), heightsOf(selected))
+}
+
+// TestCollectorScan_NoBacklog tests that all collectors are selected if their number
+// doesn't exceed the budget, or if the budget is zero.
+func TestCollectorScan_NoBacklog(t *testing.T) {
+	collectors := collectorsFixture(heightRange(1, 11)...)
+
+	scan := newCollectorScan(10)
+	require.Equal(t, collectors, scan.next(collectors))
+	require.Equal(t, collectors, scan.next(collectors))
+
+	scan = newCollectorScan(0)
+	require.Equal(t, collectors, scan.next(collectors))
+	require.Equal(t, collectors, scan.next(collectors))
+}
+
+// TestCollectorScan_Forks tests scanning collectors of multiple forks, i.e. with several
+// collectors at the same height.
+func TestCollectorScan_Forks(t *testing.T) {
+	collectors := collectorsFixture(1, 2, 2, 2, 3, 3, 4)
+	scan := newCollectorScan(3)
+
+	// the collectors at height 2 are only partially selected, hence re-checked by the next scan
+	require.Equal(t, []uint64{1, 2, 2}, heightsOf(scan.next(collectors)))
+	require.Equal(t, []uint64{2, 2, 2}, heightsOf(scan.next(collectors)))
+
+	// all collectors at height 2 have been checked, the scan moves on to height 3 and wraps around
+	require.Equal(t, []uint64{3, 3, 4}, heightsOf(scan.next(collectors)))
+	require.Equal(t, []uint64{1, 2, 2}, heightsOf(scan.next(collectors)))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/onflow/flow-go/state/fork"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 // DefaultRequiredApprovalsForSealConstruction is the default number of approvals required to construct a candidate seal
@@ -37,18 +38,28 @@ const DefaultRequiredApprovalsForSealConstruction = 1
 // to make fire fighting easier while seal & verification is under development.
 const DefaultEmergencySealingActive = false
 
+// DefaultMaxResultsPerCheck is the default number of execution results that are checked for emergency
+// sealing and missing approvals when processing a finalized block.
+const DefaultMaxResultsPerCheck = 500
+
 // Config is a structure of values that configure behavior of sealing engine
 type Config struct {
-	EmergencySealingActive               bool   // flag which indicates if emergency sealing is active or not. NOTE: this is temporary while sealing & verification is under development
-	RequiredApprovalsForSealConstruction uint   // min number of approvals required for constructing a candidate seal
-	ApprovalRequestsThreshold            uint64 // threshold for re-requesting approvals: min height difference between the latest finalized block and the block incorporating a result
+	EmergencySealingActive               bool                          // flag which indicates if emergency sealing is active or not. NOTE: this is temporary while sealing & verification is under development
+	EmergencySealingThreshold            uint64                        // threshold for emergency sealing: min height difference between the latest finalized block and the block incorporating a result
+	RequiredApprovalsForSealConstruction uint                          // min number of approvals required for constructing a candidate seal
+	ApprovalSufficiency                  approvals.ApprovalSufficiency // strategy deciding if a chunk has sufficient approvals for constructing a candidate seal; nil counts approvals against RequiredApprovalsForSealConstruction
+	ApprovalRequestsThreshold            uint64                        // threshold for re-requesting approvals: min height difference between the latest finalized block and the block incorporating a result
+	MaxResultsPerCheck                   uint                          // max number of results checked for emergency sealing and missing approvals per finalized block, 0 means no limit
+	RequireSPoCKs                        bool                          // whether seal candidates are only constructed, if every contributing approval provides a SPoCK proof
 }
 
 func DefaultConfig() Config {
 	return Config{
 		EmergencySealingActive:               DefaultEmergencySealingActive,
+		EmergencySealingThreshold:            approvals.DefaultEmergencySealingThreshold,
 		RequiredApprovalsForSealConstruction: DefaultRequiredApprovalsForSealConstruction,
 		ApprovalRequestsThreshold:            10,
+		MaxResultsPerCheck:                   DefaultMaxResultsPerCheck,
 	}
 }
 
@@ -58,6 +69,9 @@ func DefaultConfig() Config {
 // 	- processing multiple incorporated results
 // 	- pre-validating approvals (if they are outdated or non-verifiable)
 // 	- pruning already processed collectorTree
+// All fields are immutable after construction, including the config, which has no runtime-tunable
+// parameters. The mutable state is held by components which are safe for concurrent use: the
+// monotonous counters, the collector tree, the caches and the collector scans.
 type Core struct {
 	unit                       *engine.Unit
 	workerPool                 *workerpool.WorkerPool             // worker pool used by collectors
@@ -74,6 +88,9 @@ type Core struct {
 	metrics                    module.ConsensusMetrics            // used to track consensus metrics
 	sealingTracker             consensus.SealingTracker           // logic-aware component for tracking sealing progress.
 	tracer                     module.Tracer                      // used to trace execution
+	traceSampler               *consensus.TraceSampler            // used to sample approvals and results for detailed validation traces
+	emergencySealingScan       *collectorScan                     // bounds and resumes the collectors checked for emergency sealing
+	approvalRequestsScan       *collectorScan                     // bounds and resumes the collectors checked for missing approvals
 	config                     Config
 }
 
@@ -91,8 +108,13 @@ func NewCore(
 	verifier module.Verifier,
 	sealsMempool mempool.IncorporatedResultSeals,
 	approvalConduit network.Conduit,
+	traceSampler *consensus.TraceSampler,
 	config Config,
 ) (*Core, error) {
+	if config.EmergencySealingActive && config.EmergencySealingThreshold == 0 {
+		return nil, fmt.Errorf("emergency sealing threshold must be positive if emergency sealing is active")
+	}
+
 	lastSealed, err := state.Sealed().Head()
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve last sealed block: %w", err)
@@ -112,14 +134,27 @@ func NewCore(
 		state:                      state,
 		seals:                      sealsDB,
 		sealsMempool:               sealsMempool,
+		traceSampler:               traceSampler,
 		config:                     config,
 		requestTracker:             approvals.NewRequestTracker(headers, 10, 30),
+		emergencySealingScan:       newCollectorScan(config.MaxResultsPerCheck),
+		approvalRequestsScan:       newCollectorScan(config.MaxResultsPerCheck),
+	}
+
+	collectorOpts := []approvals.AssignmentCollectorOption{
+		approvals.WithTracer(tracer),
+		approvals.WithConsensusMetrics(conMetrics),
+		approvals.WithEmergencySealingThreshold(config.EmergencySealingThreshold),
+		approvals.WithRequiredSPoCKs(config.RequireSPoCKs),
+	}
+	if config.ApprovalSufficiency != nil {
+		collectorOpts = append(collectorOpts, approvals.WithApprovalSufficiency(config.ApprovalSufficiency))
 	}
 
 	factoryMethod := func(result *flow.ExecutionResult) (approvals.AssignmentCollector, error) {
 		base, err := approvals.NewAssignmentCollectorBase(core.log, core.workerPool, result, core.state, core.headers,
 			assigner, sealsMempool, verifier,
-			approvalConduit, core.requestTracker, config.RequiredApprovalsForSealConstruction)
+			approvalConduit, core.requestTracker, config.RequiredApprovalsForSealConstruction, collectorOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("could not create base collector: %w", err)
 		}
@@ -304,7 +339,18 @@ func (c *Core) ProcessIncorporatedResult(result *flow.IncorporatedResult) error
 	span, _, _ := c.tracer.StartBlockSpan(context.Background(), result.Result.BlockID, trace.CONSealingProcessIncorporatedResult)
 	defer span.Finish()
 
+	// results of sampled receipts are traced through sealing as well
+	var validationTrace *consensus.ValidationTrace
+	if c.traceSampler.Traced(result.Result.ID()) {
+		validationTrace = consensus.NewValidationTrace(c.log.With().
+			Hex("result_id", logging.Entity(result.Result)).
+			Hex("incorporated_block_id", result.IncorporatedBlockID[:]).
+			Logger(), result.ID())
+	}
+
 	err := c.processIncorporatedResult(result)
+	validationTrace.Step("process_incorporated_result", traceOutcome(err))
+	validationTrace.Emit(traceOutcome(err))
 	// We expect only engine.OutdatedInputError. If we encounter UnverifiableInputError or InvalidInputError, we
 	// have a serious problem, because these results are coming from the node's local HotStuff, which is trusted.
 	if engine.IsOutdatedInputError(err) {
@@ -340,6 +386,23 @@ func (c *Core) checkBlockOutdated(blockID flow.Identifier) error {
 	return nil
 }
 
+// traceOutcome classifies the error returned by processing an approval or
+// incorporated result for the validation trace.
+func traceOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case engine.IsOutdatedInputError(err):
+		return "outdated"
+	case engine.IsUnverifiableInputError(err):
+		return "unverifiable"
+	case engine.IsInvalidInputError(err):
+		return "invalid"
+	default:
+		return "internal_error"
+	}
+}
+
 // ProcessApproval processes approval in blocking way. Concurrency safe.
 // Returns:
 // * exception in case of unexpected error
@@ -357,9 +420,22 @@ func (c *Core) ProcessApproval(approval *flow.ResultApproval) error {
 	}
 	defer span.Finish()
 
+	// approvals are traced if sampled themselves, or if they approve the result of a sampled receipt
+	var validationTrace *consensus.ValidationTrace
+	approvalID := approval.ID()
+	if c.traceSampler.Sample(approvalID) || c.traceSampler.Traced(approval.Body.ExecutionResultID) {
+		validationTrace = consensus.NewValidationTrace(c.log.With().
+			Hex("result_id", approval.Body.ExecutionResultID[:]).
+			Hex("approver_id", approval.Body.ApproverID[:]).
+			Uint64("chunk_index", approval.Body.ChunkIndex).
+			Logger(), approvalID)
+	}
+
 	startTime := time.Now()
 	err := c.processApproval(approval)
 	c.metrics.OnApprovalProcessingDuration(time.Since(startTime))
+	validationTrace.Step("process_approval", traceOutcome(err))
+	validationTrace.Emit(traceOutcome(err))
 
 	if err != nil {
 		if engine.IsOutdatedInputError(err) {
@@ -379,6 +455,7 @@ func (c *Core) ProcessApproval(approval *flow.ResultApproval) error {
 		}
 		if engine.IsInvalidInputError(err) {
 			lg.Error().Msg("received invalid approval")
+			c.metrics.InvalidApproval()
 			return nil
 		}
 		lg.Error().Msg("unexpected error processing result approval")
@@ -426,10 +503,10 @@ func (c *Core) checkEmergencySealing(observer consensus.SealingObservation, last
 		return nil
 	}
 
-	emergencySealingHeight := lastSealedHeight + approvals.DefaultEmergencySealingThreshold
+	emergencySealingHeight := lastSealedHeight + c.config.EmergencySealingThreshold
 
 	// we are interested in all collectors that match condition:
-	// lastSealedBlock + sealing.DefaultEmergencySealingThreshold < lastFinalizedHeight
+	// lastSealedBlock + EmergencySealingThreshold < lastFinalizedHeight
 	// in other words we should check for emergency sealing only if threshold was reached
 	if emergencySealingHeight >= lastFinalizedHeight {
 		return nil
@@ -439,7 +516,8 @@ func (c *Core) checkEmergencySealing(observer consensus.SealingObservation, last
 	// if block is emergency sealable depends on it's incorporated block height
 	// collectors tree stores collector by executed block height
 	// we need to select multiple levels to find eligible collectors for emergency sealing
-	for _, collector := range c.collectorTree.GetCollectorsByInterval(lastSealedHeight, lastSealedHeight+delta) {
+	collectors := c.collectorTree.GetCollectorsByInterval(lastSealedHeight, lastSealedHeight+delta)
+	for _, collector := range c.emergencySealingScan.next(collectors) {
 		err := collector.CheckEmergencySealing(observer, lastFinalizedHeight)
 		if err != nil {
 			return err
@@ -448,6 +526,10 @@ func (c *Core) checkEmergencySealing(observer consensus.SealingObservation, last
 	return nil
 }
 
+// processPendingApprovals processes the approvals, which were cached before their result was known,
+// with the new collector of the result. All cached approvals of the result are removed from the cache,
+// approvals which turn out to be invalid against the result (e.g. with a chunk index out of range)
+// are dropped.
 func (c *Core) processPendingApprovals(collector approvals.AssignmentCollectorState) error {
 	resultID := collector.ResultID()
 	// filter cached approvals for concrete execution result
@@ -455,10 +537,12 @@ func (c *Core) processPendingApprovals(collector approvals.AssignmentCollectorSt
 		err := collector.ProcessApproval(approval)
 		if err != nil {
 			if engine.IsInvalidInputError(err) {
-				c.log.Debug().
+				c.log.Warn().
 					Hex("result_id", resultID[:]).
+					Hex("approver_id", approval.Body.ApproverID[:]).
+					Uint64("chunk_index", approval.Body.ChunkIndex).
 					Err(err).
-					Msgf("invalid approval with id %s", approval.ID())
+					Msgf("dropping invalid cached approval with id %s", approval.ID())
 			} else {
 				return fmt.Errorf("could not process assignment: %w", err)
 			}
@@ -593,7 +677,7 @@ func (c *Core) requestPendingApprovals(observation consensus.SealingObservation,
 
 	pendingApprovalRequests := uint(0)
 	collectors := c.collectorTree.GetCollectorsByInterval(lastSealedHeight, maxHeightForRequesting)
-	for _, collector := range collectors {
+	for _, collector := range c.approvalRequestsScan.next(collectors) {
 		// Note:
 		// * The `AssignmentCollectorTree` works with the height of the _executed_ block. However,
 		//   the `maxHeightForRequesting` should use the height of the block _incorporating the result_
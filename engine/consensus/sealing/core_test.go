@@ -1,15 +1,18 @@
 package sealing
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/consensus"
 	"github.com/onflow/flow-go/engine/consensus/approvals"
 	"github.com/onflow/flow-go/engine/consensus/approvals/tracker"
 	"github.com/onflow/flow-go/model/chunks"
@@ -58,12 +61,13 @@ func (s *ApprovalProcessingCoreTestSuite) SetupTest() {
 
 	options := Config{
 		EmergencySealingActive:               false,
+		EmergencySealingThreshold:            approvals.DefaultEmergencySealingThreshold,
 		RequiredApprovalsForSealConstruction: uint(len(s.AuthorizedVerifiers)),
 		ApprovalRequestsThreshold:            2,
 	}
 
 	var err error
-	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.Assigner, s.SigVerifier, s.SealsPL, s.Conduit, options)
+	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.Assigner, s.SigVerifier, s.SealsPL, s.Conduit, consensus.NewTraceSampler(0), options)
 	require.NoError(s.T(), err)
 }
 
@@ -235,6 +239,35 @@ func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ApprovalsBefor
 	s.SealsPL.AssertCalled(s.T(), "Add", mock.Anything)
 }
 
+// TestApprovalTraceSampling tests that approvals for the result of a sampled receipt are
+// traced, even if the approvals themselves are not sampled.
+func (s *ApprovalProcessingCoreTestSuite) TestApprovalTraceSampling() {
+	var logs bytes.Buffer
+	s.core.log = zerolog.New(&logs)
+	// a sampling rate this high effectively only traces entities which were sampled before
+	s.core.traceSampler = consensus.NewTraceSampler(1 << 32)
+	s.SigVerifier.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	approval := unittest.ResultApprovalFixture(unittest.WithChunk(0),
+		unittest.WithApproverID(s.VerID),
+		unittest.WithBlockID(s.Block.ID()),
+		unittest.WithExecutionResultID(s.IncorporatedResult.Result.ID()))
+	err := s.core.ProcessApproval(approval)
+	require.NoError(s.T(), err)
+	require.NotContains(s.T(), logs.String(), "sampled validation trace")
+
+	// sample a message referencing the result, as the matching core does for receipts
+	s.core.traceSampler.SetSamplingRate(1)
+	s.core.traceSampler.Sample(s.IncorporatedResult.Result.ID())
+	s.core.traceSampler.SetSamplingRate(1 << 32)
+
+	err = s.core.ProcessApproval(approval)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), logs.String(), "sampled validation trace")
+	require.Contains(s.T(), logs.String(), fmt.Sprintf(`"traced_entity_id":"%x"`, approval.ID()))
+	require.Contains(s.T(), logs.String(), `"check":"process_approval","outcome":"ok"`)
+}
+
 // TestProcessIncorporated_ApprovalsAfterResult tests a scenario when first we have discovered execution result
 //// and after that we started receiving approvals. In this scenario we should be able to create a seal right
 //// after processing last needed approval to meet `RequiredApprovalsForSealConstruction` threshold.
@@ -260,6 +293,39 @@ func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ApprovalsAfter
 	s.SealsPL.AssertCalled(s.T(), "Add", mock.Anything)
 }
 
+// TestProcessIncorporated_ExecutionDataID tests that the execution data ID of a sealed result
+// is carried into the seal unchanged, and that the seal commits to it through the result ID.
+func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ExecutionDataID() {
+	s.SigVerifier.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	executionDataID := unittest.IdentifierFixture()
+	s.IncorporatedResult.Result.ExecutionDataID = executionDataID
+	result := s.IncorporatedResult.Result
+
+	var sealed *flow.IncorporatedResultSeal
+	s.SealsPL.On("Add", mock.Anything).Run(func(args mock.Arguments) {
+		sealed = args.Get(0).(*flow.IncorporatedResultSeal)
+	}).Return(true, nil).Once()
+
+	err := s.core.processIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	for _, chunk := range s.Chunks {
+		for verID := range s.AuthorizedVerifiers {
+			approval := unittest.ResultApprovalFixture(unittest.WithChunk(chunk.Index),
+				unittest.WithApproverID(verID),
+				unittest.WithBlockID(s.Block.ID()),
+				unittest.WithExecutionResultID(result.ID()))
+			err := s.core.processApproval(approval)
+			require.NoError(s.T(), err)
+		}
+	}
+
+	require.NotNil(s.T(), sealed)
+	require.Equal(s.T(), executionDataID, sealed.IncorporatedResult.Result.ExecutionDataID)
+	require.Equal(s.T(), result.ID(), sealed.Seal.ResultID)
+}
+
 // TestProcessIncorporated_ProcessingInvalidApproval tests that processing invalid approval when result is discovered
 // is correctly handled in case of sentinel error
 func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ProcessingInvalidApproval() {
@@ -282,6 +348,75 @@ func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ProcessingInva
 	require.NoError(s.T(), err)
 }
 
+// TestProcessApproval_ChunkIndexOutOfRange tests that an approval for a chunk, which the referenced result
+// doesn't have, is rejected right away once the result is known, and dropped from the cache when the result
+// is discovered after the approval.
+func (s *ApprovalProcessingCoreTestSuite) TestProcessApproval_ChunkIndexOutOfRange() {
+	s.SigVerifier.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	resultID := s.IncorporatedResult.Result.ID()
+	outOfRange := uint64(s.IncorporatedResult.Result.Chunks.Len())
+
+	s.Run("approval before result", func() {
+		approval := unittest.ResultApprovalFixture(unittest.WithChunk(outOfRange),
+			unittest.WithApproverID(s.VerID),
+			unittest.WithBlockID(s.Block.ID()),
+			unittest.WithExecutionResultID(resultID))
+
+		// the result is not known yet, so the approval is cached for later
+		err := s.core.processApproval(approval)
+		require.NoError(s.T(), err)
+		require.NotNil(s.T(), s.core.approvalsCache.Peek(approval.Body.PartialID()))
+
+		// once the result arrives, the approval is validated against it and purged
+		err = s.core.processIncorporatedResult(s.IncorporatedResult)
+		require.NoError(s.T(), err)
+		require.Nil(s.T(), s.core.approvalsCache.Peek(approval.Body.PartialID()))
+		require.Empty(s.T(), s.core.approvalsCache.TakeByResultID(resultID))
+	})
+
+	s.Run("approval after result", func() {
+		approval := unittest.ResultApprovalFixture(unittest.WithChunk(outOfRange),
+			unittest.WithApproverID(s.VerID),
+			unittest.WithBlockID(s.Block.ID()),
+			unittest.WithExecutionResultID(resultID))
+
+		err := s.core.processApproval(approval)
+		require.Error(s.T(), err)
+		require.True(s.T(), engine.IsInvalidInputError(err))
+		require.Nil(s.T(), s.core.approvalsCache.Peek(approval.Body.PartialID()))
+	})
+}
+
+// invalidApprovalsCounter counts the approvals rejected as invalid
+type invalidApprovalsCounter struct {
+	*metrics.NoopCollector
+	invalid int
+}
+
+func (c *invalidApprovalsCounter) InvalidApproval() {
+	c.invalid++
+}
+
+// TestProcessApproval_CountsInvalidApprovals tests that approvals rejected as invalid, e.g. because of an
+// invalid approver signature, are dropped without error and counted.
+func (s *ApprovalProcessingCoreTestSuite) TestProcessApproval_CountsInvalidApprovals() {
+	counter := &invalidApprovalsCounter{NoopCollector: metrics.NewNoopCollector()}
+	s.core.metrics = counter
+
+	err := s.core.processIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	s.SigVerifier.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+	approval := unittest.ResultApprovalFixture(unittest.WithChunk(s.Chunks[0].Index),
+		unittest.WithApproverID(s.VerID),
+		unittest.WithBlockID(s.Block.ID()),
+		unittest.WithExecutionResultID(s.IncorporatedResult.Result.ID()))
+
+	err = s.core.ProcessApproval(approval)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, counter.invalid)
+}
+
 // TestProcessIncorporated_ApprovalVerificationException tests that processing invalid approval when result is discovered
 // is correctly handled in case of exception
 func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ApprovalVerificationException() {
@@ -707,7 +842,7 @@ func (s *ApprovalProcessingCoreTestSuite) TestRepopulateAssignmentCollectorTree(
 	s.State.On("Final").Return(finalSnapShot)
 
 	core, err := NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(),
-		s.Headers, s.State, s.sealsDB, assigner, s.SigVerifier, s.SealsPL, s.Conduit, s.core.config)
+		s.Headers, s.State, s.sealsDB, assigner, s.SigVerifier, s.SealsPL, s.Conduit, s.core.traceSampler, s.core.config)
 	require.NoError(s.T(), err)
 
 	err = core.RepopulateAssignmentCollectorTree(payloads)
@@ -17,6 +17,7 @@ import (
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 type Event struct {
@@ -68,7 +69,9 @@ type Engine struct {
 	finalizationEventsNotifier engine.Notifier
 	blockIncorporatedNotifier  engine.Notifier
 	messageHandler             *engine.MessageHandler
+	misbehavior                module.MisbehaviorReporter
 	rootHeader                 *flow.Header
+	dedup                      *engine.Deduplicator // drops approvals which were received recently
 }
 
 // NewEngine constructs new `Engine` which runs on it's own unit.
@@ -89,6 +92,8 @@ func NewEngine(log zerolog.Logger,
 	assigner module.ChunkAssigner,
 	verifier module.Verifier,
 	sealsMempool mempool.IncorporatedResultSeals,
+	misbehavior module.MisbehaviorReporter,
+	traceSampler *consensus.TraceSampler,
 	options Config,
 ) (*Engine, error) {
 	rootHeader, err := state.Params().Root()
@@ -108,6 +113,7 @@ func NewEngine(log zerolog.Logger,
 		headers:       headers,
 		results:       results,
 		index:         index,
+		misbehavior:   misbehavior,
 		rootHeader:    rootHeader,
 	}
 
@@ -133,7 +139,7 @@ func NewEngine(log zerolog.Logger,
 		return nil, fmt.Errorf("could not register for requesting approvals: %w", err)
 	}
 
-	core, err := NewCore(log, e.workerPool, tracer, conMetrics, sealingTracker, unit, headers, state, sealsDB, assigner, verifier, sealsMempool, approvalConduit, options)
+	core, err := NewCore(log, e.workerPool, tracer, conMetrics, sealingTracker, unit, headers, state, sealsDB, assigner, verifier, sealsMempool, approvalConduit, traceSampler, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init sealing engine: %w", err)
 	}
@@ -194,6 +200,11 @@ func (e *Engine) setupMessageHandler(requiredApprovalsForSealConstruction uint)
 		FifoQueue: pendingRequestedApprovalsQueue,
 	}
 
+	e.dedup, err = engine.NewDeduplicator(engine.WithDeduplicatorCapacity(defaultApprovalQueueCapacity))
+	if err != nil {
+		return fmt.Errorf("failed to create approval deduplicator: %w", err)
+	}
+
 	e.inboundEventsNotifier = engine.NewNotifier()
 	// define message queueing behaviour
 	e.messageHandler = engine.NewMessageHandler(
@@ -213,6 +224,15 @@ func (e *Engine) setupMessageHandler(requiredApprovalsForSealConstruction uint)
 					return nil, false
 				}
 
+				// Only approvals delivered by their approver are remembered, as approvals relayed
+				// by other nodes are dropped later on. Otherwise, a node could suppress an approval
+				// by relaying it before the approver delivers it.
+				approval := msg.Payload.(*flow.ResultApproval)
+				if msg.OriginID == approval.Body.ApproverID && e.dedup.Seen(approval.ID()) {
+					e.engineMetrics.MessageDeduplicated(metrics.EngineSealing, metrics.MessageResultApproval)
+					return nil, false
+				}
+
 				return msg, true
 			},
 			Store: e.pendingApprovals,
@@ -239,6 +259,35 @@ func (e *Engine) setupMessageHandler(requiredApprovalsForSealConstruction uint)
 			},
 			Store: e.pendingRequestedApprovals,
 		},
+		engine.Pattern{
+			Match: func(msg *engine.Message) bool {
+				_, ok := msg.Payload.(*messages.ResultApprovalBatch)
+				if ok {
+					e.engineMetrics.MessageReceived(metrics.EngineSealing, metrics.MessageResultApprovalBatch)
+				}
+				return ok
+			},
+			Map: func(msg *engine.Message) (*engine.Message, bool) {
+				if requiredApprovalsForSealConstruction < 1 {
+					// if we don't require approvals to construct a seal, don't even process approvals.
+					return nil, false
+				}
+
+				batch := msg.Payload.(*messages.ResultApprovalBatch)
+				if len(batch.Approvals) > messages.MaxApprovalBatchSize || batch.ByteSize() > messages.MaxApprovalBatchByteSize {
+					e.log.Warn().
+						Hex("origin_id", msg.OriginID[:]).
+						Hex("result_id", batch.ResultID[:]).
+						Int("approvals", len(batch.Approvals)).
+						Uint("byte_size", batch.ByteSize()).
+						Msg("dropping oversized approval batch")
+					e.misbehavior.Report(msg.OriginID, flow.MisbehaviorMalformedApprovalBatch)
+					return nil, false
+				}
+				return msg, true
+			},
+			Store: e.pendingApprovals,
+		},
 	)
 
 	return nil
@@ -285,11 +334,21 @@ func (e *Engine) processAvailableMessages() error {
 			msg, ok = e.pendingApprovals.Get()
 		}
 		if ok {
-			e.log.Debug().Msg("got new result approval")
+			switch payload := msg.Payload.(type) {
+			case *flow.ResultApproval:
+				e.log.Debug().Msg("got new result approval")
 
-			err := e.onApproval(msg.OriginID, msg.Payload.(*flow.ResultApproval))
-			if err != nil {
-				return fmt.Errorf("could not process result approval: %w", err)
+				err := e.onApproval(msg.OriginID, payload)
+				if err != nil {
+					return fmt.Errorf("could not process result approval: %w", err)
+				}
+			case *messages.ResultApprovalBatch:
+				e.log.Debug().Msg("got new result approval batch")
+
+				err := e.onApprovalBatch(msg.OriginID, payload)
+				if err != nil {
+					return fmt.Errorf("could not process result approval batch: %w", err)
+				}
 			}
 			continue
 		}
@@ -375,6 +434,31 @@ func (e *Engine) onApproval(originID flow.Identifier, approval *flow.ResultAppro
 	return nil
 }
 
+// onApprovalBatch processes each approval of the batch individually, attributing it to the origin of
+// the batch. Approvals for another result than the one of the batch, or by another approver than
+// the origin, are reported and skipped, while the remaining approvals of the batch are processed.
+func (e *Engine) onApprovalBatch(originID flow.Identifier, batch *messages.ResultApprovalBatch) error {
+	for i := range batch.Approvals {
+		approval := &batch.Approvals[i]
+		if approval.Body.ExecutionResultID != batch.ResultID || approval.Body.ApproverID != originID {
+			e.log.Warn().
+				Hex("origin_id", originID[:]).
+				Hex("result_id", batch.ResultID[:]).
+				Hex("approval_id", logging.Entity(approval)).
+				Msg("skipping approval which does not match its batch")
+			e.misbehavior.Report(originID, flow.MisbehaviorMalformedApprovalBatch, approval)
+			continue
+		}
+
+		err := e.onApproval(originID, approval)
+		if err != nil {
+			return err
+		}
+	}
+	e.engineMetrics.MessageHandled(metrics.EngineSealing, metrics.MessageResultApprovalBatch)
+	return nil
+}
+
 // SubmitLocal submits an event originating on the local node.
 func (e *Engine) SubmitLocal(event interface{}) {
 	err := e.ProcessLocal(event)
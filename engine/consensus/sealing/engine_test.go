@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -28,11 +29,12 @@ func TestSealingEngineContext(t *testing.T) {
 type SealingEngineSuite struct {
 	suite.Suite
 
-	core    *mockconsensus.SealingCore
-	state   *mockprotocol.State
-	index   *mockstorage.Index
-	results *mockstorage.ExecutionResults
-	myID    flow.Identifier
+	core        *mockconsensus.SealingCore
+	state       *mockprotocol.State
+	index       *mockstorage.Index
+	results     *mockstorage.ExecutionResults
+	misbehavior *mockmodule.MisbehaviorReporter
+	myID        flow.Identifier
 
 	// Sealing Engine
 	engine *Engine
@@ -44,6 +46,7 @@ func (s *SealingEngineSuite) SetupTest() {
 	s.state = &mockprotocol.State{}
 	s.index = &mockstorage.Index{}
 	s.results = &mockstorage.ExecutionResults{}
+	s.misbehavior = &mockmodule.MisbehaviorReporter{}
 	s.myID = unittest.IdentifierFixture()
 	me := &mockmodule.Local{}
 	// set up local module mock
@@ -67,6 +70,7 @@ func (s *SealingEngineSuite) SetupTest() {
 		index:         s.index,
 		results:       s.results,
 		state:         s.state,
+		misbehavior:   s.misbehavior,
 	}
 
 	// setup inbound queues for trusted inputs and message handler for untrusted inputs
@@ -201,6 +205,111 @@ func (s *SealingEngineSuite) TestApprovalInvalidOrigin() {
 	s.core.AssertNumberOfCalls(s.T(), "ProcessApproval", 0)
 }
 
+// TestApprovalDeduplication tests that an approval, which is delivered by its approver repeatedly,
+// is fed into sealing.Core only once, and that copies relayed by other nodes don't suppress it.
+func (s *SealingEngineSuite) TestApprovalDeduplication() {
+	approverID := unittest.IdentifierFixture()
+	approval := unittest.ResultApprovalFixture(unittest.WithApproverID(approverID))
+	s.core.On("ProcessApproval", approval).Return(nil).Once()
+
+	// a copy relayed by another node is dropped by the origin check, but not remembered
+	err := s.engine.Process(engine.ReceiveApprovals, unittest.IdentifierFixture(), approval)
+	s.Require().NoError(err)
+	for i := 0; i < 3; i++ {
+		err := s.engine.Process(engine.ReceiveApprovals, approverID, approval)
+		s.Require().NoError(err)
+	}
+
+	// sealing engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+
+	s.core.AssertExpectations(s.T())
+	s.core.AssertNumberOfCalls(s.T(), "ProcessApproval", 1)
+}
+
+// approvalBatchFixture returns a batch of n approvals by the given approver for a random result.
+func approvalBatchFixture(n int, approverID flow.Identifier) *messages.ResultApprovalBatch {
+	batch := &messages.ResultApprovalBatch{
+		ResultID: unittest.IdentifierFixture(),
+	}
+	for i := 0; i < n; i++ {
+		approval := unittest.ResultApprovalFixture(
+			unittest.WithExecutionResultID(batch.ResultID),
+			unittest.WithApproverID(approverID),
+			unittest.WithChunk(uint64(i)),
+		)
+		batch.Approvals = append(batch.Approvals, *approval)
+	}
+	return batch
+}
+
+// TestApprovalBatch tests that each approval of a batch, which is received as a single message,
+// is fed into sealing.Core individually.
+func (s *SealingEngineSuite) TestApprovalBatch() {
+	approverID := unittest.IdentifierFixture()
+	batch := approvalBatchFixture(5, approverID)
+	for i := range batch.Approvals {
+		s.core.On("ProcessApproval", &batch.Approvals[i]).Return(nil).Once()
+	}
+
+	err := s.engine.Process(engine.ReceiveApprovals, approverID, batch)
+	s.Require().NoError(err, "should process approval batch")
+
+	// sealing engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+
+	s.core.AssertExpectations(s.T())
+	s.core.AssertNumberOfCalls(s.T(), "ProcessApproval", 5)
+	s.misbehavior.AssertNotCalled(s.T(), "Report", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestApprovalBatch_InvalidApproval tests that approvals of a batch, which are for another result
+// than the one of the batch or by another approver than the origin, are reported, while the valid
+// approvals of the batch are fed into sealing.Core.
+func (s *SealingEngineSuite) TestApprovalBatch_InvalidApproval() {
+	approverID := unittest.IdentifierFixture()
+	batch := approvalBatchFixture(5, approverID)
+	batch.Approvals[1].Body.ExecutionResultID = unittest.IdentifierFixture()
+	batch.Approvals[3].Body.ApproverID = unittest.IdentifierFixture()
+	for _, i := range []int{0, 2, 4} {
+		s.core.On("ProcessApproval", &batch.Approvals[i]).Return(nil).Once()
+	}
+	for _, i := range []int{1, 3} {
+		s.misbehavior.On("Report", approverID, flow.MisbehaviorMalformedApprovalBatch, &batch.Approvals[i]).Return().Once()
+	}
+
+	err := s.engine.Process(engine.ReceiveApprovals, approverID, batch)
+	s.Require().NoError(err, "should process approval batch")
+
+	// sealing engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+
+	s.core.AssertExpectations(s.T())
+	s.core.AssertNumberOfCalls(s.T(), "ProcessApproval", 3)
+	s.misbehavior.AssertExpectations(s.T())
+}
+
+// TestApprovalBatch_Oversized tests that batches exceeding the maximum number of approvals or the
+// maximum byte size are rejected as a whole and reported.
+func (s *SealingEngineSuite) TestApprovalBatch_Oversized() {
+	approverID := unittest.IdentifierFixture()
+	tooMany := approvalBatchFixture(messages.MaxApprovalBatchSize+1, approverID)
+	tooLarge := approvalBatchFixture(2, approverID)
+	tooLarge.Approvals[0].Body.Spock = make([]byte, messages.MaxApprovalBatchByteSize)
+	s.misbehavior.On("Report", approverID, flow.MisbehaviorMalformedApprovalBatch).Return().Twice()
+
+	for _, batch := range []*messages.ResultApprovalBatch{tooMany, tooLarge} {
+		err := s.engine.Process(engine.ReceiveApprovals, approverID, batch)
+		s.Require().NoError(err, "oversized approval batch should be dropped but not error")
+	}
+
+	// sealing engine has at least 100ms ticks for processing events
+	time.Sleep(1 * time.Second)
+
+	s.core.AssertNumberOfCalls(s.T(), "ProcessApproval", 0)
+	s.misbehavior.AssertExpectations(s.T())
+}
+
 // TestProcessUnsupportedMessageType tests that Process and ProcessLocal correctly handle a case where invalid message type
 // was submitted from network layer.
 func (s *SealingEngineSuite) TestProcessUnsupportedMessageType() {
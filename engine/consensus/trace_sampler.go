@@ -0,0 +1,139 @@
+package consensus
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultTracedEntitiesCapacity is the default number of entity IDs for which
+// the follow-up processing steps are traced after the entity has been sampled.
+const DefaultTracedEntitiesCapacity = 1000
+
+// TraceSampler decides which receipts and approvals get a detailed trace of their
+// validation journey logged. With a sampling rate of N, every N-th message is
+// sampled; a rate of zero disables sampling. Once an entity is sampled, its ID is
+// remembered, so that subsequent processing steps related to the same entity
+// (e.g. the sealing of a sampled result) are traced as well.
+// The sampling rate can be adjusted at runtime. TraceSampler is concurrency safe.
+type TraceSampler struct {
+	rate    *atomic.Uint64 // sample one out of `rate` messages; zero disables sampling
+	counter *atomic.Uint64 // number of messages seen by the sampler
+	traced  *lru.Cache     // IDs of entities whose follow-up processing steps are traced
+}
+
+// NewTraceSampler creates a new sampler, which samples one out of `rate` messages.
+func NewTraceSampler(rate uint64) *TraceSampler {
+	traced, err := lru.New(DefaultTracedEntitiesCapacity)
+	if err != nil {
+		// only happens for non-positive capacity
+		panic(err)
+	}
+	return &TraceSampler{
+		rate:    atomic.NewUint64(rate),
+		counter: atomic.NewUint64(0),
+		traced:  traced,
+	}
+}
+
+// SetSamplingRate sets the sampling rate to one out of `rate` messages. Zero disables sampling.
+func (s *TraceSampler) SetSamplingRate(rate uint64) {
+	s.rate.Store(rate)
+}
+
+// SamplingRate returns the current sampling rate.
+func (s *TraceSampler) SamplingRate() uint64 {
+	return s.rate.Load()
+}
+
+// Sample counts the message and returns true if it is sampled. All the given
+// entity IDs are remembered for a sampled message, so that Traced returns true
+// for them afterwards.
+func (s *TraceSampler) Sample(entityIDs ...flow.Identifier) bool {
+	count := s.counter.Inc()
+	rate := s.rate.Load()
+	if rate == 0 || count%rate != 0 {
+		return false
+	}
+	for _, entityID := range entityIDs {
+		s.traced.Add(entityID, struct{}{})
+	}
+	return true
+}
+
+// Traced returns true if the entity with the given ID was part of a sampled message.
+func (s *TraceSampler) Traced(entityID flow.Identifier) bool {
+	if s.rate.Load() == 0 {
+		return false
+	}
+	return s.traced.Contains(entityID)
+}
+
+// ValidationTrace records the checks performed while processing a single sampled
+// message, together with their outcome and timing. It is _not concurrency safe_,
+// as it is intended to be used by the goroutine processing the message.
+// A nil ValidationTrace is valid and discards all steps, so that the
+// non-sampled path doesn't need to branch on sampling.
+type ValidationTrace struct {
+	log   zerolog.Logger
+	start time.Time
+	last  time.Time
+	steps []traceStep
+}
+
+type traceStep struct {
+	check    string
+	outcome  string
+	duration time.Duration
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (t traceStep) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("check", t.check).Str("outcome", t.outcome).Dur("duration", t.duration)
+}
+
+type traceSteps []traceStep
+
+// MarshalZerologArray implements zerolog.LogArrayMarshaler.
+func (ts traceSteps) MarshalZerologArray(a *zerolog.Array) {
+	for _, step := range ts {
+		a.Object(step)
+	}
+}
+
+// NewValidationTrace starts a trace for the processing of a sampled entity. The
+// given logger should already contain the fields identifying the entity.
+func NewValidationTrace(log zerolog.Logger, entityID flow.Identifier) *ValidationTrace {
+	now := time.Now()
+	return &ValidationTrace{
+		log:   log.With().Hex("traced_entity_id", entityID[:]).Logger(),
+		start: now,
+		last:  now,
+	}
+}
+
+// Step records the outcome of a check, timed since the previous step.
+func (t *ValidationTrace) Step(check string, outcome string) {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.steps = append(t.steps, traceStep{check: check, outcome: outcome, duration: now.Sub(t.last)})
+	t.last = now
+}
+
+// Emit logs the trace with all recorded steps, using the given final outcome.
+func (t *ValidationTrace) Emit(outcome string) {
+	if t == nil {
+		return
+	}
+	t.log.Info().
+		Str("trace_outcome", outcome).
+		Array("trace_steps", traceSteps(t.steps)).
+		Dur("trace_duration", time.Since(t.start)).
+		Msg("sampled validation trace")
+}
@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestTraceSampler_SamplingRate tests that one out of N messages is sampled and
+// that sampled entity IDs are remembered.
+func TestTraceSampler_SamplingRate(t *testing.T) {
+	sampler := NewTraceSampler(3)
+
+	ids := unittest.IdentifierListFixture(6)
+	sampled := make([]bool, 0, len(ids))
+	for _, id := range ids {
+		sampled = append(sampled, sampler.Sample(id))
+	}
+	assert.Equal(t, []bool{false, false, true, false, false, true}, sampled)
+	assert.True(t, sampler.Traced(ids[2]))
+	assert.False(t, sampler.Traced(ids[3]))
+
+	// disabling sampling takes effect immediately
+	sampler.SetSamplingRate(0)
+	assert.Equal(t, uint64(0), sampler.SamplingRate())
+	assert.False(t, sampler.Sample(unittest.IdentifierFixture()))
+	assert.False(t, sampler.Traced(ids[2]))
+}
+
+// TestValidationTrace tests that a trace emits all recorded steps and that a nil
+// trace is a no-op.
+func TestValidationTrace(t *testing.T) {
+	var logs bytes.Buffer
+	entityID := unittest.IdentifierFixture()
+
+	trace := NewValidationTrace(zerolog.New(&logs), entityID)
+	trace.Step("first_check", "ok")
+	trace.Step("second_check", "failed")
+	trace.Emit("discarded")
+
+	out := logs.String()
+	require.Contains(t, out, "sampled validation trace")
+	assert.Contains(t, out, `"trace_outcome":"discarded"`)
+	assert.Contains(t, out, `"check":"first_check","outcome":"ok"`)
+	assert.Contains(t, out, `"check":"second_check","outcome":"failed"`)
+	assert.Contains(t, out, entityID.String())
+
+	var nilTrace *ValidationTrace
+	nilTrace.Step("check", "ok")
+	nilTrace.Emit("ok")
+}
@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// originIDKey is the context key of the origin ID of the message being processed.
+type originIDKey struct{}
+
+// WithOriginID returns a copy of the parent context carrying the ID of the node
+// from which the processed message originates, e.g. for logging and tracing.
+func WithOriginID(parent context.Context, originID flow.Identifier) context.Context {
+	return context.WithValue(parent, originIDKey{}, originID)
+}
+
+// OriginIDFromContext returns the origin ID carried by the context. The second return
+// value indicates whether the context carries an origin ID.
+func OriginIDFromContext(ctx context.Context) (flow.Identifier, bool) {
+	originID, ok := ctx.Value(originIDKey{}).(flow.Identifier)
+	return originID, ok
+}
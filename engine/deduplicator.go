@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultDeduplicatorCapacity is the default number of entities remembered by a deduplicator.
+const DefaultDeduplicatorCapacity = 10000
+
+// DefaultDeduplicatorTTL is the default duration after which a deduplicator forgets an entity.
+const DefaultDeduplicatorTTL = time.Minute
+
+// DeduplicatorOption configures optional behaviour of a deduplicator.
+type DeduplicatorOption func(*Deduplicator) error
+
+// WithDeduplicatorCapacity sets the number of recently seen entities the deduplicator remembers
+// at least. By default, this is DefaultDeduplicatorCapacity.
+func WithDeduplicatorCapacity(capacity uint) DeduplicatorOption {
+	return func(d *Deduplicator) error {
+		if capacity == 0 {
+			return fmt.Errorf("capacity for deduplicator must be positive")
+		}
+		d.capacity = capacity
+		return nil
+	}
+}
+
+// WithDeduplicatorTTL sets the duration after which an entity seen by the deduplicator is
+// forgotten, unless it is seen again in the meantime. By default, this is DefaultDeduplicatorTTL.
+func WithDeduplicatorTTL(ttl time.Duration) DeduplicatorOption {
+	return func(d *Deduplicator) error {
+		if ttl <= 0 {
+			return fmt.Errorf("ttl for deduplicator must be positive")
+		}
+		d.ttl = ttl
+		return nil
+	}
+}
+
+// WithBloomFilter makes the deduplicator remember entities in bloom filters with the given false
+// positive rate, instead of maps. This bounds the memory of the deduplicator to a few bytes per
+// entity, at the cost of reporting an unseen entity as seen with the given probability.
+func WithBloomFilter(falsePositiveRate float64) DeduplicatorOption {
+	return func(d *Deduplicator) error {
+		if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+			return fmt.Errorf("false positive rate for deduplicator must be in (0, 1), got %f", falsePositiveRate)
+		}
+		d.falsePositiveRate = falsePositiveRate
+		return nil
+	}
+}
+
+// Deduplicator remembers the IDs of recently seen entities, so that engines can drop duplicates
+// of the same message received from many peers, before doing any expensive work for them.
+//
+// Entities are remembered in two generations: new entities are added to the current generation,
+// which replaces the previous generation once it is full or older than the TTL. Hence, an entity
+// is remembered for at least the capacity of a generation or the TTL, whichever is reached first,
+// and for at most twice that. Entities which are seen again are renewed in the current generation.
+// Deduplicator is safe for concurrent use.
+type Deduplicator struct {
+	mu                sync.Mutex
+	capacity          uint
+	ttl               time.Duration
+	falsePositiveRate float64 // zero, if entities are remembered in maps
+	now               func() time.Time
+
+	current  entitySet
+	previous entitySet
+	rotated  time.Time // time at which the current generation was started
+}
+
+// NewDeduplicator creates a deduplicator, which remembers recently seen entities in maps, unless
+// configured otherwise by the given options.
+func NewDeduplicator(opts ...DeduplicatorOption) (*Deduplicator, error) {
+	d := &Deduplicator{
+		capacity: DefaultDeduplicatorCapacity,
+		ttl:      DefaultDeduplicatorTTL,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		err := opt(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.current = d.newSet()
+	d.previous = d.newSet()
+	d.rotated = d.now()
+	return d, nil
+}
+
+// Seen returns whether the entity with the given ID was seen recently, and remembers it as seen.
+func (d *Deduplicator) Seen(entityID flow.Identifier) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	age := d.now().Sub(d.rotated)
+	if age >= d.ttl {
+		d.rotate()
+	}
+	if age >= 2*d.ttl {
+		// the previous generation is expired as well
+		d.rotate()
+	}
+
+	if d.current.contains(entityID) {
+		return true
+	}
+	seen := d.previous.contains(entityID)
+
+	if d.current.len() >= d.capacity {
+		d.rotate()
+	}
+	d.current.add(entityID)
+	return seen
+}
+
+// rotate replaces the previous generation with the current one, and starts a new generation.
+// Must be called while holding the lock.
+func (d *Deduplicator) rotate() {
+	d.previous = d.current
+	d.current = d.newSet()
+	d.rotated = d.now()
+}
+
+func (d *Deduplicator) newSet() entitySet {
+	if d.falsePositiveRate > 0 {
+		return newBloomSet(d.capacity, d.falsePositiveRate)
+	}
+	return make(mapSet, d.capacity)
+}
+
+// entitySet is a generation of entities remembered by the deduplicator.
+type entitySet interface {
+	add(entityID flow.Identifier)
+	contains(entityID flow.Identifier) bool
+	len() uint
+}
+
+// mapSet is an exact set of entities.
+type mapSet map[flow.Identifier]struct{}
+
+func (s mapSet) add(entityID flow.Identifier) {
+	s[entityID] = struct{}{}
+}
+
+func (s mapSet) contains(entityID flow.Identifier) bool {
+	_, ok := s[entityID]
+	return ok
+}
+
+func (s mapSet) len() uint {
+	return uint(len(s))
+}
+
+// bloomSet is a bloom filter of entities, sized for a given capacity and false positive rate.
+// As entity IDs are hashes, the bit positions are derived from their bytes by double hashing.
+type bloomSet struct {
+	bits   []uint64
+	m      uint64 // number of bits
+	k      uint64 // number of bit positions per entity
+	placed uint
+}
+
+func newBloomSet(capacity uint, falsePositiveRate float64) *bloomSet {
+	n := float64(capacity)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+	return &bloomSet{
+		bits: make([]uint64, (uint64(m)+63)/64),
+		m:    uint64(m),
+		k:    uint64(k),
+	}
+}
+
+func (s *bloomSet) positions(entityID flow.Identifier, f func(bit uint64) bool) bool {
+	h1 := binary.BigEndian.Uint64(entityID[0:8])
+	h2 := binary.BigEndian.Uint64(entityID[8:16]) | 1
+	for i := uint64(0); i < s.k; i++ {
+		if !f((h1 + i*h2) % s.m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *bloomSet) add(entityID flow.Identifier) {
+	s.positions(entityID, func(bit uint64) bool {
+		s.bits[bit/64] |= 1 << (bit % 64)
+		return true
+	})
+	s.placed++
+}
+
+func (s *bloomSet) contains(entityID flow.Identifier) bool {
+	return s.positions(entityID, func(bit uint64) bool {
+		return s.bits[bit/64]&(1<<(bit%64)) != 0
+	})
+}
+
+func (s *bloomSet) len() uint {
+	return s.placed
+}
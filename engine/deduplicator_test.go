@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestDeduplicator_Seen tests that an entity is reported as seen after it was seen once.
+func TestDeduplicator_Seen(t *testing.T) {
+	for name, opts := range map[string][]DeduplicatorOption{
+		"map":   nil,
+		"bloom": {WithBloomFilter(0.01)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dedup, err := NewDeduplicator(opts...)
+			require.NoError(t, err)
+
+			ids := unittest.IdentifierListFixture(100)
+			for _, id := range ids {
+				assert.False(t, dedup.Seen(id))
+			}
+			for _, id := range ids {
+				assert.True(t, dedup.Seen(id))
+			}
+		})
+	}
+}
+
+// TestDeduplicator_ForgetsAfterTTL tests that an entity is remembered for at least the TTL, and
+// is forgotten once two generations expired.
+func TestDeduplicator_ForgetsAfterTTL(t *testing.T) {
+	now := time.Now()
+	dedup, err := NewDeduplicator(WithDeduplicatorTTL(time.Minute))
+	require.NoError(t, err)
+	dedup.now = func() time.Time { return now }
+	dedup.rotated = now
+
+	ids := unittest.IdentifierListFixture(3)
+	assert.False(t, dedup.Seen(ids[0]))
+
+	// after one TTL, the entity is in the previous generation and still remembered
+	now = now.Add(90 * time.Second)
+	assert.False(t, dedup.Seen(ids[1]))
+	assert.True(t, dedup.Seen(ids[0]))
+
+	// an entity which is seen again is renewed, while the other entity is eventually forgotten
+	now = now.Add(90 * time.Second)
+	assert.True(t, dedup.Seen(ids[0]))
+	now = now.Add(90 * time.Second)
+	assert.False(t, dedup.Seen(ids[1]))
+	assert.True(t, dedup.Seen(ids[0]))
+
+	// after two idle TTLs, all entities are forgotten
+	now = now.Add(3 * time.Minute)
+	assert.False(t, dedup.Seen(ids[0]))
+	assert.False(t, dedup.Seen(ids[2]))
+}
+
+// TestDeduplicator_ForgetsAfterCapacity tests that the deduplicator remembers at least as many
+// entities as its capacity, and that older entities are forgotten, once the capacity is exceeded twice.
+func TestDeduplicator_ForgetsAfterCapacity(t *testing.T) {
+	dedup, err := NewDeduplicator(WithDeduplicatorCapacity(10))
+	require.NoError(t, err)
+
+	ids := unittest.IdentifierListFixture(21)
+	for _, id := range ids[:20] {
+		require.False(t, dedup.Seen(id))
+	}
+	// the first generation is full, hence the first entities are still remembered
+	assert.True(t, dedup.Seen(ids[15]))
+	assert.True(t, dedup.previous.contains(ids[0]))
+
+	// starting a third generation forgets the first one
+	assert.False(t, dedup.Seen(ids[20]))
+	assert.True(t, dedup.Seen(ids[15]))
+	assert.False(t, dedup.Seen(ids[0]))
+}
+
+// TestDeduplicator_BloomFalsePositiveRate tests that the bloom filter of a generation reports
+// unseen entities as seen with at most about the configured false positive rate, once it is full.
+func TestDeduplicator_BloomFalsePositiveRate(t *testing.T) {
+	const capacity = 10000
+	const falsePositiveRate = 0.01
+
+	set := newBloomSet(capacity, falsePositiveRate)
+	for _, id := range unittest.IdentifierListFixture(capacity) {
+		set.add(id)
+	}
+
+	falsePositives := 0
+	for _, id := range unittest.IdentifierListFixture(capacity) {
+		if set.contains(id) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/capacity, 2*falsePositiveRate)
+}
+
+// TestDeduplicator_InvalidOptions tests that invalid options are rejected.
+func TestDeduplicator_InvalidOptions(t *testing.T) {
+	_, err := NewDeduplicator(WithDeduplicatorCapacity(0))
+	assert.Error(t, err)
+	_, err = NewDeduplicator(WithDeduplicatorTTL(0))
+	assert.Error(t, err)
+	_, err = NewDeduplicator(WithBloomFilter(0))
+	assert.Error(t, err)
+	_, err = NewDeduplicator(WithBloomFilter(1))
+	assert.Error(t, err)
+}
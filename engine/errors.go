@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
 )
 
 var (
@@ -122,6 +124,64 @@ func IsDuplicatedEntryError(err error) bool {
 	return errors.As(err, &errDuplicatedEntryError)
 }
 
+// WrongChainError is for inputs that belong to a chain other than the local one, e.g. because
+// they were gossiped by a node which is accidentally connected to the wrong network.
+// Entities which only reference blocks by ID can't be attributed to a chain unless the block is
+// known, and known blocks are on the local chain, as the protocol state only accepts blocks of
+// the local chain. Hence, this error is raised for entities embedding a chain ID, such as proposals.
+type WrongChainError struct {
+	Expected flow.ChainID
+	Actual   flow.ChainID
+	err      error
+}
+
+func NewWrongChainErrorf(expected flow.ChainID, actual flow.ChainID, msg string, args ...interface{}) error {
+	return WrongChainError{
+		Expected: expected,
+		Actual:   actual,
+		err:      fmt.Errorf(msg, args...),
+	}
+}
+
+func (e WrongChainError) Unwrap() error {
+	return e.err
+}
+
+func (e WrongChainError) Error() string {
+	return fmt.Sprintf("wrong chain (expected %s, got %s): %s", e.Expected, e.Actual, e.err.Error())
+}
+
+func IsWrongChainError(err error) bool {
+	var errWrongChainError WrongChainError
+	return errors.As(err, &errWrongChainError)
+}
+
+// BackpressureError is for inputs which are rejected because the pool they would be admitted to
+// is saturated. The input might be valid, and could be accepted again once the pool recovered.
+// The networking layer may use it to deprioritize the channel of the input.
+type BackpressureError struct {
+	err error
+}
+
+func NewBackpressureErrorf(msg string, args ...interface{}) error {
+	return BackpressureError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e BackpressureError) Unwrap() error {
+	return e.err
+}
+
+func (e BackpressureError) Error() string {
+	return e.err.Error()
+}
+
+func IsBackpressureError(err error) bool {
+	var errBackpressureError BackpressureError
+	return errors.As(err, &errBackpressureError)
+}
+
 // LogError logs the engine processing error
 func LogError(log zerolog.Logger, err error) {
 	LogErrorWithMsg(log, "could not process message", err)
@@ -143,6 +203,13 @@ func LogErrorWithMsg(log zerolog.Logger, msg string, err error) {
 		return
 	}
 
+	// Inputs for other chains are gossiped by nodes connected to the wrong network,
+	// which is a misconfiguration of the sender rather than a problem of this node.
+	if IsWrongChainError(err) {
+		log.Warn().Str("error_type", "wrong_chain").Err(err).Msg(msg)
+		return
+	}
+
 	// Outdated input errors, on the other hand, can happen regularly, even
 	// before opening the network up, as some messages might just be late
 	// due to network delays or other infrastructure issues. They should
@@ -152,6 +219,13 @@ func LogErrorWithMsg(log zerolog.Logger, msg string, err error) {
 		return
 	}
 
+	// Back-pressure is applied by healthy engines under high load, and the input may be
+	// delivered again later.
+	if IsBackpressureError(err) {
+		log.Warn().Str("error_type", "backpressure").Err(err).Msg(msg)
+		return
+	}
+
 	// Unverifiable input errors may be due to out-of-date node state, or could
 	// indicate a malicious/unexpected message from another node. Since we don't
 	// know, log as warning.
@@ -13,6 +13,7 @@ import (
 	"github.com/uber/jaeger-client-go"
 
 	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/state/audit"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/blueprints"
@@ -54,6 +55,30 @@ type blockComputer struct {
 	log            zerolog.Logger
 	systemChunkCtx fvm.Context
 	committer      ViewCommitter
+	auditor        *audit.Auditor
+	// transactions with a larger execution effort are logged with their effort breakdown,
+	// zero disables execution effort profiling
+	effortLogThreshold uint64
+}
+
+// BlockComputerOption is a functional option to configure the block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithRegisterAuditor enables recording the accesses of executed transactions to the
+// registers audited by the given auditor.
+func WithRegisterAuditor(auditor *audit.Auditor) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.auditor = auditor
+	}
+}
+
+// WithExecutionEffortLogThreshold enables execution effort profiling, and logs the effort
+// breakdown of transactions whose execution effort exceeds the given threshold.
+// A threshold of zero disables the profiling.
+func WithExecutionEffortLogThreshold(threshold uint64) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.effortLogThreshold = threshold
+	}
 }
 
 func SystemChunkContext(vmCtx fvm.Context, logger zerolog.Logger) fvm.Context {
@@ -64,6 +89,7 @@ func SystemChunkContext(vmCtx fvm.Context, logger zerolog.Logger) fvm.Context {
 		fvm.WithServiceEventCollectionEnabled(),
 		fvm.WithTransactionProcessors(fvm.NewTransactionInvoker(logger)),
 		fvm.WithMaxStateInteractionSize(SystemChunkLedgerIntractionLimit),
+		fvm.WithMaxAggregateInteractionSize(SystemChunkLedgerIntractionLimit),
 		fvm.WithEventCollectionSizeLimit(SystemChunkEventCollectionMaxSize),
 	)
 }
@@ -76,8 +102,9 @@ func NewBlockComputer(
 	tracer module.Tracer,
 	logger zerolog.Logger,
 	committer ViewCommitter,
+	opts ...BlockComputerOption,
 ) (BlockComputer, error) {
-	return &blockComputer{
+	e := &blockComputer{
 		vm:             vm,
 		vmCtx:          vmCtx,
 		metrics:        metrics,
@@ -85,7 +112,15 @@ func NewBlockComputer(
 		log:            logger,
 		systemChunkCtx: SystemChunkContext(vmCtx, logger),
 		committer:      committer,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.effortLogThreshold > 0 {
+		e.vmCtx = fvm.NewContextFromParent(e.vmCtx, fvm.WithExecutionEffortProfiling(true))
+		e.systemChunkCtx = SystemChunkContext(e.vmCtx, logger)
+	}
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -366,9 +401,18 @@ func (e *blockComputer) executeTransaction(
 		return fmt.Errorf("failed to execute transaction: %w", err)
 	}
 
+	// the audit mode is opt-in, skip it entirely unless any accounts are audited
+	if e.auditor != nil && e.auditor.Enabled() {
+		err = e.auditor.AuditTransaction(ctx.BlockHeader, txID, txView.(*delta.View))
+		if err != nil {
+			return fmt.Errorf("failed to audit transaction: %w", err)
+		}
+	}
+
 	txResult := flow.TransactionResult{
-		TransactionID:   tx.ID,
-		ComputationUsed: tx.ComputationUsed,
+		TransactionID:        tx.ID,
+		ComputationUsed:      tx.ComputationUsed,
+		ComputationBreakdown: tx.ComputationBreakdown,
 	}
 
 	if tx.Err != nil {
@@ -415,6 +459,19 @@ func (e *blockComputer) executeTransaction(
 		Int64("timeSpentInMS", time.Since(startedAt).Milliseconds()).
 		Msg("transaction executed")
 
+	if e.effortLogThreshold > 0 && tx.ExecutionEffort > e.effortLogThreshold {
+		breakdown := zerolog.Dict()
+		for kind, amount := range tx.ComputationBreakdown {
+			breakdown.Uint64(kind, amount)
+		}
+		e.log.Warn().
+			Str("txHash", tx.ID.String()).
+			Uint64("execution_effort", tx.ExecutionEffort).
+			Uint64("threshold", e.effortLogThreshold).
+			Dict("computation_breakdown", breakdown).
+			Msg("transaction exceeded execution effort threshold")
+	}
+
 	e.metrics.ExecutionTransactionExecuted(time.Since(startedAt), tx.ComputationUsed, len(tx.Events), tx.Err != nil)
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/stdlib"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -22,6 +23,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	computermock "github.com/onflow/flow-go/engine/execution/computation/computer/mock"
+	"github.com/onflow/flow-go/engine/execution/state/audit"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
@@ -35,6 +37,7 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	modulemock "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -665,6 +668,54 @@ func Test_ExecutingSystemCollection(t *testing.T) {
 	committer.AssertExpectations(t)
 }
 
+func Test_RegisterAudit(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		watched := flow.HexToAddress("01")
+		config := audit.DefaultConfig()
+		config.Owners = []flow.Address{watched}
+		auditor := audit.NewAuditor(zerolog.Nop(), bstorage.NewRegisterAuditRecords(db), config)
+
+		// every transaction writes a watched and an unwatched register
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				tx := args[1].(*fvm.TransactionProcedure)
+				view := args[2].(state.View)
+				require.NoError(t, view.Set(string(watched.Bytes()), "", "key", tx.ID[:]))
+				require.NoError(t, view.Set(string(flow.HexToAddress("02").Bytes()), "", "key", tx.ID[:]))
+			}).
+			Times(2 + 1) // 2 txs in collection + system chunk
+
+		exe, err := computer.NewBlockComputer(vm, fvm.NewContext(zerolog.Nop()), metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer.NewNoopViewCommitter(), computer.WithRegisterAuditor(auditor))
+		require.NoError(t, err)
+
+		block := generateBlock(1, 2, &RandomAddressGenerator{})
+		view := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+
+		records, err := auditor.AuditRecords(watched, block.Height(), block.Height())
+		require.NoError(t, err)
+		require.Len(t, records, len(result.TransactionResults))
+
+		txIDs := make(map[flow.Identifier]struct{})
+		for _, txResult := range result.TransactionResults {
+			txIDs[txResult.TransactionID] = struct{}{}
+		}
+		for _, record := range records {
+			assert.Contains(t, txIDs, record.TransactionID)
+			assert.Equal(t, block.ID(), record.BlockID)
+			assert.Equal(t, flow.RegisterWrite, record.Access)
+		}
+
+		records, err = auditor.AuditRecords(flow.HexToAddress("02"), block.Height(), block.Height())
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
+
 func generateBlock(collectionCount, transactionCount int, addressGenerator flow.AddressGenerator) *entity.ExecutableBlock {
 	return generateBlockWithVisitor(collectionCount, transactionCount, addressGenerator, nil)
 }
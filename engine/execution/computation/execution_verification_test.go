@@ -658,7 +658,7 @@ func executeBlockAndVerifyWithParameters(t *testing.T,
 
 	prevResultId := unittest.IdentifierFixture()
 
-	_, chdps, er, err := execution.GenerateExecutionResultAndChunkDataPacks(prevResultId, initialCommit, computationResult)
+	_, chdps, er, err := execution.GenerateExecutionResultAndChunkDataPacks(prevResultId, initialCommit, computationResult, flow.ChunkBodyV0, flow.ExecutionResultV0)
 	require.NoError(t, err)
 
 	verifier := chunks.NewChunkVerifier(vm, fvmContext, logger)
@@ -70,6 +70,7 @@ func New(
 	committer computer.ViewCommitter,
 	scriptLogThreshold time.Duration,
 	uploaders []uploader.Uploader,
+	opts ...computer.BlockComputerOption,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
@@ -80,6 +81,7 @@ func New(
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
 		committer,
+		opts...,
 	)
 
 	if err != nil {
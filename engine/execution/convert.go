@@ -2,15 +2,23 @@ package execution
 
 import (
 	"fmt"
+	"math"
 
+	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/model/convert"
 	"github.com/onflow/flow-go/model/flow"
 )
 
+// GenerateExecutionResultAndChunkDataPacks creates the execution result and the chunk data packs of
+// the computation result of a block. The chunks are created in the given version of chunk bodies,
+// and the result in the given version of execution results, which commit to the execution data
+// ID of the block only from ExecutionResultV1 on.
 func GenerateExecutionResultAndChunkDataPacks(
 	prevResultId flow.Identifier,
 	startState flow.StateCommitment,
-	result *ComputationResult) (
+	result *ComputationResult,
+	chunkVersion flow.ChunkBodyVersion,
+	resultVersion flow.ExecutionResultVersion) (
 	endState flow.StateCommitment,
 	chdps []*flow.ChunkDataPack,
 	executionResult *flow.ExecutionResult,
@@ -40,12 +48,18 @@ func GenerateExecutionResultAndChunkDataPacks(
 			completeCollection := result.ExecutableBlock.CompleteCollections[collectionGuarantee.ID()]
 			collection := completeCollection.Collection()
 			chunk = GenerateChunk(i, startState, endState, blockID, result.EventsHashes[i], uint64(len(completeCollection.Transactions)))
+			if chunkVersion >= flow.ChunkBodyV1 {
+				chunk.TotalByteSize, err = TotalByteSize(completeCollection.Transactions)
+				if err != nil {
+					return flow.DummyStateCommitment, nil, nil, fmt.Errorf("could not generate chunk %d: %w", i, err)
+				}
+			}
 			chdps[i] = GenerateChunkDataPack(chunk.ID(), startState, &collection, result.Proofs[i])
 		} else {
 			// system chunk
 			// note that system chunk does not have a collection.
 			// also, number of transactions is one for system chunk.
-			chunk = GenerateChunk(i, startState, endState, blockID, result.EventsHashes[i], 1)
+			chunk = flow.NewSystemChunk(uint64(i), startState, endState, blockID, result.EventsHashes[i], 1)
 			// system chunk has a nil collection.
 			chdps[i] = GenerateChunkDataPack(chunk.ID(), startState, nil, result.Proofs[i])
 		}
@@ -55,7 +69,12 @@ func GenerateExecutionResultAndChunkDataPacks(
 		startState = endState
 	}
 
-	executionResult, err = GenerateExecutionResultForBlock(prevResultId, block, chunks, result.ServiceEvents)
+	executionDataID := flow.ZeroID
+	if resultVersion >= flow.ExecutionResultV1 {
+		executionDataID = GenerateExecutionDataID(result)
+	}
+
+	executionResult, err = GenerateExecutionResultForBlock(prevResultId, block, chunks, result.ServiceEvents, executionDataID)
 	if err != nil {
 		return flow.DummyStateCommitment, nil, nil, fmt.Errorf("could not generate execution result: %w", err)
 	}
@@ -70,6 +89,7 @@ func GenerateExecutionResultForBlock(
 	block *flow.Block,
 	chunks []*flow.Chunk,
 	serviceEvents []flow.Event,
+	executionDataID flow.Identifier,
 ) (*flow.ExecutionResult, error) {
 
 	// convert Cadence service event representation to flow-go representation
@@ -87,11 +107,47 @@ func GenerateExecutionResultForBlock(
 		BlockID:          block.ID(),
 		Chunks:           chunks,
 		ServiceEvents:    convertedServiceEvents,
+		ExecutionDataID:  executionDataID,
 	}
 
 	return er, nil
 }
 
+// executionData is the canonical encoding of the data produced by executing a
+// block, which the execution data ID of its execution result commits to.
+type executionData struct {
+	BlockID            flow.Identifier
+	CollectionIDs      []flow.Identifier
+	TransactionResults []flow.TransactionResult
+	Events             []flow.Event
+	TrieUpdates        []*ledger.TrieUpdate
+}
+
+// GenerateExecutionDataID computes the execution data ID of a block from its computation
+// result. It commits to the collections in the order of the block's guarantees, all
+// transaction results and events in execution order and the trie updates of all chunks.
+func GenerateExecutionDataID(result *ComputationResult) flow.Identifier {
+	block := result.ExecutableBlock.Block
+
+	collectionIDs := make([]flow.Identifier, 0, len(block.Payload.Guarantees))
+	for _, guarantee := range block.Payload.Guarantees {
+		collectionIDs = append(collectionIDs, guarantee.CollectionID)
+	}
+
+	var events []flow.Event
+	for _, chunkEvents := range result.Events {
+		events = append(events, chunkEvents...)
+	}
+
+	return flow.MakeID(executionData{
+		BlockID:            block.ID(),
+		CollectionIDs:      collectionIDs,
+		TransactionResults: result.TransactionResults,
+		Events:             events,
+		TrieUpdates:        result.TrieUpdates,
+	})
+}
+
 // GenerateChunk creates a chunk from the provided computation data.
 func GenerateChunk(colIndex int,
 	startState, endState flow.StateCommitment,
@@ -111,6 +167,19 @@ func GenerateChunk(colIndex int,
 	}
 }
 
+// TotalByteSize returns the total byte size of the given transactions, as committed to by chunk
+// bodies of version ChunkBodyV1. It errors if the size exceeds the range of the chunk body field.
+func TotalByteSize(transactions []*flow.TransactionBody) (uint32, error) {
+	var size uint64
+	for _, tx := range transactions {
+		size += uint64(tx.ByteSize())
+	}
+	if size > math.MaxUint32 {
+		return 0, fmt.Errorf("total byte size of %d transactions exceeds %d bytes", len(transactions), uint32(math.MaxUint32))
+	}
+	return uint32(size), nil
+}
+
 func GenerateChunkDataPack(
 	chunkID flow.Identifier,
 	startState flow.StateCommitment,
@@ -23,7 +23,7 @@ func Test_BuildChunkDataPack(t *testing.T) {
 			{flow.ZeroID},
 		})
 
-		_, _, result, err := execution.GenerateExecutionResultAndChunkDataPacks(unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), cr)
+		_, _, result, err := execution.GenerateExecutionResultAndChunkDataPacks(unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), cr, flow.ChunkBodyV0, flow.ExecutionResultV0)
 		assert.NoError(t, err)
 
 		require.Len(t, result.Chunks, 4) // +1 for system chunk
@@ -34,5 +34,91 @@ func Test_BuildChunkDataPack(t *testing.T) {
 
 		// system chunk is special case, but currently also 1 tx
 		assert.Equal(t, uint64(1), result.Chunks[3].NumberOfTransactions)
+
+		// the total byte size is only set for chunk bodies of version 1
+		for _, chunk := range result.Chunks {
+			assert.Equal(t, uint32(0), chunk.TotalByteSize)
+		}
+	})
+
+	t.Run("total byte size is included", func(t *testing.T) {
+		cr := executionUnittest.ComputationResultFixture([][]flow.Identifier{
+			{flow.ZeroID},
+			{flow.ZeroID},
+		})
+
+		_, chdps, result, err := execution.GenerateExecutionResultAndChunkDataPacks(unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), cr, flow.ChunkBodyV1, flow.ExecutionResultV0)
+		require.NoError(t, err)
+		require.Len(t, result.Chunks, 3) // +1 for system chunk
+
+		for i, guarantee := range cr.ExecutableBlock.Block.Payload.Guarantees {
+			collection := cr.ExecutableBlock.CompleteCollections[guarantee.ID()]
+			var size uint
+			for _, tx := range collection.Transactions {
+				size += tx.ByteSize()
+			}
+			require.NotZero(t, size)
+
+			chunk := result.Chunks[i]
+			assert.Equal(t, uint32(size), chunk.TotalByteSize)
+			assert.Equal(t, flow.ChunkBodyV1, chunk.Version())
+			// the chunk data pack refers to the chunk including its size
+			assert.Equal(t, chunk.ID(), chdps[i].ChunkID)
+		}
+
+		// the system chunk has no collection
+		assert.Equal(t, uint32(0), result.Chunks[2].TotalByteSize)
+	})
+}
+
+func Test_ExecutionDataID(t *testing.T) {
+	cr := executionUnittest.ComputationResultFixture([][]flow.Identifier{
+		{flow.ZeroID},
+		{flow.ZeroID},
+	})
+	cr.Events = []flow.EventsList{
+		{unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0)},
+		{},
+		{unittest.EventFixture(flow.EventAccountUpdated, 0, 0, unittest.IdentifierFixture(), 0)},
+	}
+	cr.TransactionResults = []flow.TransactionResult{
+		{TransactionID: unittest.IdentifierFixture()},
+		{TransactionID: unittest.IdentifierFixture(), ErrorMessage: "failed"},
+		{TransactionID: unittest.IdentifierFixture()},
+	}
+
+	t.Run("stable for the same computation result", func(t *testing.T) {
+		executionDataID := execution.GenerateExecutionDataID(cr)
+		assert.NotEqual(t, flow.ZeroID, executionDataID)
+		assert.Equal(t, executionDataID, execution.GenerateExecutionDataID(cr))
+
+		_, _, result, err := execution.GenerateExecutionResultAndChunkDataPacks(unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), cr, flow.ChunkBodyV0, flow.ExecutionResultV1)
+		require.NoError(t, err)
+		assert.Equal(t, executionDataID, result.ExecutionDataID)
+		assert.Equal(t, flow.ExecutionResultV1, result.Version())
+	})
+
+	t.Run("not included before version 1", func(t *testing.T) {
+		_, _, result, err := execution.GenerateExecutionResultAndChunkDataPacks(unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), cr, flow.ChunkBodyV0, flow.ExecutionResultV0)
+		require.NoError(t, err)
+		assert.Equal(t, flow.ZeroID, result.ExecutionDataID)
+		assert.Equal(t, flow.ExecutionResultV0, result.Version())
+	})
+
+	t.Run("commits to the execution data", func(t *testing.T) {
+		executionDataID := execution.GenerateExecutionDataID(cr)
+
+		events := cr.Events
+		cr.Events = []flow.EventsList{events[0], {}, {}}
+		assert.NotEqual(t, executionDataID, execution.GenerateExecutionDataID(cr))
+		cr.Events = events
+
+		results := cr.TransactionResults
+		cr.TransactionResults = append([]flow.TransactionResult{}, results...)
+		cr.TransactionResults[1].ErrorMessage = ""
+		assert.NotEqual(t, executionDataID, execution.GenerateExecutionDataID(cr))
+		cr.TransactionResults = results
+
+		assert.Equal(t, executionDataID, execution.GenerateExecutionDataID(cr))
 	})
 }
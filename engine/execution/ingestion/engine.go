@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool/entity"
 	"github.com/onflow/flow-go/module/mempool/queue"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
@@ -35,6 +36,13 @@ import (
 	"github.com/onflow/flow-go/utils/logging"
 )
 
+// DefaultReloadScanLimit is the default maximum number of unexecuted finalized blocks, which are scanned
+// for the last executed block when reloading the unexecuted blocks on startup.
+const DefaultReloadScanLimit = 100_000
+
+// DefaultMaxConcurrentBlockExecutions is the default maximum number of blocks, which are executed concurrently.
+const DefaultMaxConcurrentBlockExecutions = 4
+
 // An Engine receives and saves incoming blocks.
 type Engine struct {
 	psEvents.Noop // satisfy protocol events consumer interface
@@ -65,6 +73,12 @@ type Engine struct {
 	syncFast           bool                // sync fast allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up
 	checkStakedAtBlock func(blockID flow.Identifier) (bool, error)
 	pauseExecution     bool
+	chunkVersion       flow.ChunkBodyVersion       // version of the chunk bodies of the produced execution results
+	resultVersion      flow.ExecutionResultVersion // version of the produced execution results
+	myReceipts         storage.MyExecutionReceipts
+	reloadScanLimit    uint64        // maximum number of unexecuted finalized blocks scanned for the last executed block on startup
+	rebroadcast        bool          // re-broadcast the stored receipts of executed, but unsealed blocks, which are received again
+	executionSlots     chan struct{} // bounds the number of blocks executed concurrently
 }
 
 func New(
@@ -90,7 +104,17 @@ func New(
 	syncFast bool,
 	checkStakedAtBlock func(blockID flow.Identifier) (bool, error),
 	pauseExecution bool,
+	chunkVersion flow.ChunkBodyVersion,
+	resultVersion flow.ExecutionResultVersion,
+	myReceipts storage.MyExecutionReceipts,
+	reloadScanLimit uint64,
+	rebroadcast bool,
+	maxConcurrentExecutions uint,
 ) (*Engine, error) {
+	if maxConcurrentExecutions == 0 {
+		return nil, fmt.Errorf("maximum number of concurrent block executions must be positive")
+	}
+
 	log := logger.With().Str("engine", "ingestion").Logger()
 
 	mempool := newMempool()
@@ -121,6 +145,12 @@ func New(
 		syncFast:           syncFast,
 		checkStakedAtBlock: checkStakedAtBlock,
 		pauseExecution:     pauseExecution,
+		chunkVersion:       chunkVersion,
+		resultVersion:      resultVersion,
+		myReceipts:         myReceipts,
+		reloadScanLimit:    reloadScanLimit,
+		rebroadcast:        rebroadcast,
+		executionSlots:     make(chan struct{}, maxConcurrentExecutions),
 	}
 
 	// move to state syncing engine
@@ -190,11 +220,14 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 	return nil
 }
 
-func (e *Engine) finalizedUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.Identifier, error) {
+// finalizedUnexecutedBlocks returns the finalized blocks, which have not been executed yet, as well as
+// the executed finalized blocks, which have not been sealed yet. Both scans together are bounded by the
+// reload scan limit.
+func (e *Engine) finalizedUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.Identifier, []flow.Identifier, error) {
 	// get finalized height
 	final, err := finalized.Head()
 	if err != nil {
-		return nil, fmt.Errorf("could not get finalized block: %w", err)
+		return nil, nil, fmt.Errorf("could not get finalized block: %w", err)
 	}
 
 	// find the first unexecuted and finalized block
@@ -209,25 +242,63 @@ func (e *Engine) finalizedUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.
 
 	rootBlock, err := e.state.Params().Root()
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve root block: %w", err)
+		return nil, nil, fmt.Errorf("failed to retrieve root block: %w", err)
+	}
+
+	// the scan is bounded by the reload scan limit, the root block is executed during bootstrapping
+	// and terminates it otherwise
+	lowest := rootBlock.Height
+	if final.Height-rootBlock.Height > e.reloadScanLimit {
+		lowest = final.Height - e.reloadScanLimit
 	}
 
 	for ; lastExecuted > rootBlock.Height; lastExecuted-- {
+		if lastExecuted < lowest {
+			// the unexecuted blocks below the lowest scanned height are not reloaded
+			e.log.Warn().
+				Uint64("finalized_height", final.Height).
+				Uint64("lowest_scanned_height", lowest).
+				Msgf("reached the reload scan limit of %d blocks before finding an executed finalized block", e.reloadScanLimit)
+			break
+		}
+
 		header, err := e.state.AtHeight(lastExecuted).Head()
 		if err != nil {
-			return nil, fmt.Errorf("could not get header at height: %v, %w", lastExecuted, err)
+			return nil, nil, fmt.Errorf("could not get header at height: %v, %w", lastExecuted, err)
 		}
 
-		executed, err := state.IsBlockExecuted(e.unit.Ctx(), e.execState, header.ID())
+		isExecuted, err := state.IsBlockExecuted(e.unit.Ctx(), e.execState, header.ID())
 		if err != nil {
-			return nil, fmt.Errorf("could not check whether block is executed: %w", err)
+			return nil, nil, fmt.Errorf("could not check whether block is executed: %w", err)
 		}
 
-		if executed {
+		if isExecuted {
 			break
 		}
 	}
 
+	// the finalized blocks from the last executed one down to the last sealed one have been executed,
+	// as blocks are executed in the order of their heights, but the consensus nodes might still lack
+	// their receipts
+	executed := make([]flow.Identifier, 0)
+	if lastExecuted >= lowest && lastExecuted > rootBlock.Height {
+		sealed, err := e.state.Sealed().Head()
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get sealed block: %w", err)
+		}
+		if sealed.Height+1 > lowest {
+			lowest = sealed.Height + 1
+		}
+
+		for height := lastExecuted; height >= lowest; height-- {
+			header, err := e.state.AtHeight(height).Head()
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not get header at height: %v, %w", height, err)
+			}
+			executed = append(executed, header.ID())
+		}
+	}
+
 	firstUnexecuted := lastExecuted + 1
 
 	e.log.Info().Msgf("last finalized and executed height: %v", lastExecuted)
@@ -239,53 +310,61 @@ func (e *Engine) finalizedUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.
 	for height := firstUnexecuted; height <= final.Height; height++ {
 		header, err := e.state.AtHeight(height).Head()
 		if err != nil {
-			return nil, fmt.Errorf("could not get header at height: %v, %w", height, err)
+			return nil, nil, fmt.Errorf("could not get header at height: %v, %w", height, err)
 		}
 
 		unexecuted = append(unexecuted, header.ID())
 	}
 
-	return unexecuted, nil
+	return unexecuted, executed, nil
 }
 
-func (e *Engine) pendingUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.Identifier, error) {
+// pendingUnexecutedBlocks returns the pending blocks, which have not been executed yet, as well as the
+// pending blocks which have been executed already.
+func (e *Engine) pendingUnexecutedBlocks(finalized protocol.Snapshot) ([]flow.Identifier, []flow.Identifier, error) {
 	pendings, err := finalized.ValidDescendants()
 	if err != nil {
-		return nil, fmt.Errorf("could not get pending blocks: %w", err)
+		return nil, nil, fmt.Errorf("could not get pending blocks: %w", err)
 	}
 
 	unexecuted := make([]flow.Identifier, 0)
+	executed := make([]flow.Identifier, 0)
 
 	for _, pending := range pendings {
-		executed, err := state.IsBlockExecuted(e.unit.Ctx(), e.execState, pending)
+		isExecuted, err := state.IsBlockExecuted(e.unit.Ctx(), e.execState, pending)
 		if err != nil {
-			return nil, fmt.Errorf("could not check block executed or not: %w", err)
+			return nil, nil, fmt.Errorf("could not check block executed or not: %w", err)
 		}
 
-		if !executed {
-			unexecuted = append(unexecuted, pending)
+		if isExecuted {
+			executed = append(executed, pending)
+			continue
 		}
+
+		unexecuted = append(unexecuted, pending)
 	}
 
-	return unexecuted, nil
+	return unexecuted, executed, nil
 }
 
-func (e *Engine) unexecutedBlocks() (finalized []flow.Identifier, pending []flow.Identifier, err error) {
+// unexecutedBlocks returns the finalized and pending blocks, which have not been executed yet, and the
+// blocks which are skipped, because they have been executed already, but have not been sealed yet.
+func (e *Engine) unexecutedBlocks() (finalized []flow.Identifier, pending []flow.Identifier, executed []flow.Identifier, err error) {
 	// pin the snapshot so that finalizedUnexecutedBlocks and pendingUnexecutedBlocks are based
 	// on the same snapshot.
 	snapshot := e.state.Final()
 
-	finalized, err = e.finalizedUnexecutedBlocks(snapshot)
+	finalized, executedFinalized, err := e.finalizedUnexecutedBlocks(snapshot)
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not read finalized unexecuted blocks")
+		return nil, nil, nil, fmt.Errorf("could not read finalized unexecuted blocks: %w", err)
 	}
 
-	pending, err = e.pendingUnexecutedBlocks(snapshot)
+	pending, executedPending, err := e.pendingUnexecutedBlocks(snapshot)
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not read pending unexecuted blocks")
+		return nil, nil, nil, fmt.Errorf("could not read pending unexecuted blocks: %w", err)
 	}
 
-	return finalized, pending, nil
+	return finalized, pending, append(executedFinalized, executedPending...), nil
 }
 
 // on nodes startup, we need to load all the unexecuted blocks to the execution queues.
@@ -298,7 +377,8 @@ func (e *Engine) reloadUnexecutedBlocks() error {
 	// to the queues yet.
 	// So one solution here is to lock the execution queues during reloading, so that if BlockProcessable
 	// is called before reloading is finished, it will be blocked, which will avoid that edge case.
-	return e.mempool.Run(func(
+	var executed []flow.Identifier
+	err := e.mempool.Run(func(
 		blockByCollection *stdmap.BlockByCollectionBackdata,
 		executionQueues *stdmap.QueuesBackdata) error {
 
@@ -346,7 +426,8 @@ func (e *Engine) reloadUnexecutedBlocks() error {
 			}
 		}
 
-		finalized, pending, err := e.unexecutedBlocks()
+		var finalized, pending []flow.Identifier
+		finalized, pending, executed, err = e.unexecutedBlocks()
 		if err != nil {
 			return fmt.Errorf("could not reload unexecuted blocks: %w", err)
 		}
@@ -357,6 +438,7 @@ func (e *Engine) reloadUnexecutedBlocks() error {
 			Int("total", len(unexecuted)).
 			Int("finalized", len(finalized)).
 			Int("pending", len(pending)).
+			Int("skipped_executed", len(executed)).
 			Uint64("last_executed", lastExecutedHeight).
 			Hex("last_executed_id", lastExecutedID[:]).
 			Logger()
@@ -376,6 +458,25 @@ func (e *Engine) reloadUnexecutedBlocks() error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// the node might have crashed after executing the skipped blocks, but before broadcasting their receipts
+	if e.rebroadcast {
+		e.unit.Launch(func() {
+			for _, blockID := range executed {
+				header, err := e.state.AtBlockID(blockID).Head()
+				if err != nil {
+					e.log.Error().Err(err).Hex("block_id", blockID[:]).Msg("could not get executed block for re-broadcasting its receipt")
+					continue
+				}
+				e.rebroadcastReceipt(e.unit.Ctx(), header)
+			}
+		})
+	}
+
+	return nil
 }
 
 func (e *Engine) reloadBlock(
@@ -442,6 +543,9 @@ func (e *Engine) handleBlock(ctx context.Context, block *flow.Block) error {
 
 	if executed {
 		log.Debug().Msg("block has been executed already")
+		if e.rebroadcast {
+			e.rebroadcastReceipt(ctx, block.Header)
+		}
 		return nil
 	}
 
@@ -461,6 +565,52 @@ func (e *Engine) handleBlock(ctx context.Context, block *flow.Block) error {
 	return nil
 }
 
+// rebroadcastReceipt re-broadcasts the stored receipt of the given executed block, unless the block is
+// sealed already, as the consensus nodes might lack the receipt if the node crashed before broadcasting it.
+func (e *Engine) rebroadcastReceipt(ctx context.Context, header *flow.Header) {
+	blockID := header.ID()
+	log := e.log.With().
+		Hex("block_id", blockID[:]).
+		Uint64("block_height", header.Height).
+		Logger()
+
+	lastSealed, err := e.state.Sealed().Head()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get sealed block before re-broadcasting receipt")
+		return
+	}
+	if header.Height <= lastSealed.Height {
+		return
+	}
+
+	stakedAtBlock, err := e.checkStakedAtBlock(blockID)
+	if err != nil {
+		log.Error().Err(err).Msg("could not check staking status before re-broadcasting receipt")
+		return
+	}
+	if !stakedAtBlock {
+		return
+	}
+
+	receipt, err := e.myReceipts.MyReceipt(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		log.Warn().Msg("block has been executed, but its receipt has not been stored")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("could not get receipt for re-broadcasting")
+		return
+	}
+
+	err = e.providerEngine.BroadcastExecutionReceipt(ctx, receipt)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to re-broadcast the receipt")
+		return
+	}
+
+	log.Info().Hex("receipt_id", logging.Entity(receipt)).Msg("re-broadcasted receipt of executed block")
+}
+
 func (e *Engine) enqueueBlockAndCheckExecutable(
 	blockByCollection *stdmap.BlockByCollectionBackdata,
 	executionQueues *stdmap.QueuesBackdata,
@@ -771,7 +921,19 @@ func (e *Engine) executeBlockIfComplete(eb *entity.ExecutableBlock) bool {
 		// no external synchronisation is used because this method must be run in a thread-safe context
 		eb.Executing = true
 
+		// blocks on different forks, whose parents have been executed, are independent of each other and
+		// executed concurrently. The children of a block only become complete once its results have been
+		// persisted, so the blocks of a fork are executed and persisted in order.
 		e.unit.Launch(func() {
+			select {
+			case e.executionSlots <- struct{}{}:
+			case <-e.unit.Quit():
+				return
+			}
+			defer func() {
+				<-e.executionSlots
+			}()
+
 			e.executeBlock(e.unit.Ctx(), eb)
 		})
 		return true
@@ -1122,7 +1284,7 @@ func (e *Engine) saveExecutionResults(
 			block.Header.ParentID, err)
 	}
 
-	endState, chdps, executionResult, err := execution.GenerateExecutionResultAndChunkDataPacks(previousErID, startState, result)
+	endState, chdps, executionResult, err := execution.GenerateExecutionResultAndChunkDataPacks(previousErID, startState, result, e.chunkVersion, e.resultVersion)
 	if err != nil {
 		return nil, fmt.Errorf("cannot build chunk data pack: %w", err)
 	}
@@ -1215,8 +1377,7 @@ func (e *Engine) generateExecutionReceipt(
 	}
 
 	// generates a signature over the execution result
-	id := receipt.ID()
-	sig, err := e.me.Sign(id[:], e.receiptHasher)
+	sig, err := e.me.Sign(messages.ExecutionReceiptMessage(receipt.Meta()), e.receiptHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not sign execution result: %w", err)
 	}
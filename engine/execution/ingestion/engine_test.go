@@ -32,6 +32,7 @@ import (
 	stateProtocol "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storageerr "github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
 	storage "github.com/onflow/flow-go/storage/mocks"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/mocks"
@@ -66,6 +67,7 @@ type testingContext struct {
 	identity            *flow.Identity
 	broadcastedReceipts map[flow.Identifier]*flow.ExecutionReceipt
 	collectionRequester *module.MockRequester
+	onComputeBlock      func(block *entity.ExecutableBlock) // called when the computation of a block starts, if set
 }
 
 func runWithEngine(t *testing.T, f func(testingContext)) {
@@ -171,6 +173,12 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		false,
 		checkStakedAtBlock,
 		false,
+		flow.ChunkBodyV0,
+		flow.ExecutionResultV0,
+		nil,
+		DefaultReloadScanLimit,
+		false,
+		DefaultMaxConcurrentBlockExecutions,
 	)
 	require.NoError(t, err)
 
@@ -219,6 +227,9 @@ func (ctx *testingContext) assertSuccessfulBlockComputation(
 
 	ctx.computationManager.
 		On("ComputeBlock", mock.Anything, &eb, mock.Anything).Run(func(args mock.Arguments) {
+		if ctx.onComputeBlock != nil {
+			ctx.onComputeBlock(args[1].(*entity.ExecutableBlock))
+		}
 	}).
 		Return(computationResult, nil).Once()
 
@@ -880,6 +891,169 @@ func TestExecuteBlockInOrder(t *testing.T) {
 	})
 }
 
+// TestExecuteForksConcurrently tests that the blocks of different forks, whose parents have been executed,
+// are executed concurrently up to the maximum number of concurrent block executions.
+func TestExecuteForksConcurrently(t *testing.T) {
+	runWithEngine(t, func(ctx testingContext) {
+		// create blocks with the following relations
+		// A <- B
+		// A <- C
+		// A <- D
+		blockSealed := unittest.BlockHeaderFixture()
+
+		blocks := make(map[string]*entity.ExecutableBlock)
+		blocks["A"] = unittest.ExecutableBlockFixtureWithParent(nil, &blockSealed)
+		blocks["A"].StartState = unittest.StateCommitmentPointerFixture()
+		blocks["B"] = unittest.ExecutableBlockFixtureWithParent(nil, blocks["A"].Block.Header)
+		blocks["C"] = unittest.ExecutableBlockFixtureWithParent(nil, blocks["A"].Block.Header)
+		blocks["D"] = unittest.ExecutableBlockFixtureWithParent(nil, blocks["A"].Block.Header)
+		blocks["B"].StartState = blocks["A"].StartState
+		blocks["C"].StartState = blocks["A"].StartState
+		blocks["D"].StartState = blocks["A"].StartState
+
+		logBlocks(blocks)
+
+		// block A has been executed already
+		commits := make(map[flow.Identifier]flow.StateCommitment)
+		commits[blocks["A"].ID()] = *blocks["A"].StartState
+		ctx.mockStateCommitsWithMap(commits)
+
+		ctx.state.On("Sealed").Return(ctx.snapshot)
+		ctx.snapshot.On("Head").Return(&blockSealed, nil)
+
+		// the fork tips are held in computation until released
+		var running, maxRunning int32
+		var lock sync.Mutex
+		release := make(chan struct{})
+		ctx.onComputeBlock = func(*entity.ExecutableBlock) {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			<-release
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}
+		runningBlocks := func() int32 {
+			lock.Lock()
+			defer lock.Unlock()
+			return running
+		}
+		ctx.engine.executionSlots = make(chan struct{}, 2)
+
+		wg := sync.WaitGroup{}
+		onPersisted := func(blockID flow.Identifier, commit flow.StateCommitment) {
+			wg.Done()
+		}
+		resultA := unittest.IdentifierFixture()
+		for _, name := range []string{"B", "C", "D"} {
+			ctx.assertSuccessfulBlockComputation(commits, onPersisted, blocks[name], resultA, false, *blocks[name].StartState, nil)
+		}
+
+		wg.Add(3)
+		for _, name := range []string{"B", "C", "D"} {
+			err := ctx.engine.handleBlock(context.Background(), blocks[name].Block)
+			require.NoError(t, err)
+		}
+
+		// two of the fork tips are executed concurrently, while the third one waits for a worker
+		require.Eventually(t, func() bool { return runningBlocks() == 2 }, time.Second, time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, int32(2), runningBlocks())
+
+		close(release)
+		unittest.AssertReturnsBefore(t, wg.Wait, 10*time.Second)
+
+		_, more := <-ctx.engine.Done() //wait for all the blocks to be processed
+		require.False(t, more)
+
+		lock.Lock()
+		defer lock.Unlock()
+		require.Equal(t, int32(2), maxRunning)
+	})
+}
+
+// TestExecuteChainSequentially tests that the blocks of the same chain are executed in order, each one only
+// after the results of its parent have been persisted, even if more blocks could be executed concurrently.
+func TestExecuteChainSequentially(t *testing.T) {
+	runWithEngine(t, func(ctx testingContext) {
+		// A <- B <- C
+		blockSealed := unittest.BlockHeaderFixture()
+
+		blocks := make(map[string]*entity.ExecutableBlock)
+		blocks["A"] = unittest.ExecutableBlockFixtureWithParent(nil, &blockSealed)
+		blocks["A"].StartState = unittest.StateCommitmentPointerFixture()
+		blocks["B"] = unittest.ExecutableBlockFixtureWithParent(nil, blocks["A"].Block.Header)
+		blocks["C"] = unittest.ExecutableBlockFixtureWithParent(nil, blocks["B"].Block.Header)
+		blocks["B"].StartState = blocks["A"].StartState
+		blocks["C"].StartState = blocks["B"].StartState
+
+		logBlocks(blocks)
+
+		commits := make(map[flow.Identifier]flow.StateCommitment)
+		commits[blocks["A"].Block.Header.ParentID] = *blocks["A"].StartState
+		ctx.mockStateCommitsWithMap(commits)
+
+		ctx.state.On("Sealed").Return(ctx.snapshot)
+		ctx.snapshot.On("Head").Return(&blockSealed, nil)
+
+		var lock sync.Mutex
+		var running, maxRunning int
+		executed := make(map[flow.Identifier]bool)
+		var order []flow.Identifier
+		ctx.onComputeBlock = func(block *entity.ExecutableBlock) {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			order = append(order, block.ID())
+			// the parent's results have been persisted before the block is executed
+			assert.True(t, block.Block.Header.ParentID == blockSealed.ID() || executed[block.Block.Header.ParentID])
+			lock.Unlock()
+
+			// give blocks, which are not supposed to be executed yet, the chance to start
+			time.Sleep(20 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}
+
+		wg := sync.WaitGroup{}
+		onPersisted := func(blockID flow.Identifier, commit flow.StateCommitment) {
+			lock.Lock()
+			executed[blockID] = true
+			lock.Unlock()
+			wg.Done()
+		}
+		ctx.assertSuccessfulBlockComputation(commits, onPersisted, blocks["A"], unittest.IdentifierFixture(), false, *blocks["A"].StartState, nil)
+		ctx.assertSuccessfulBlockComputation(commits, onPersisted, blocks["B"], unittest.IdentifierFixture(), false, *blocks["B"].StartState, nil)
+		ctx.assertSuccessfulBlockComputation(commits, onPersisted, blocks["C"], unittest.IdentifierFixture(), false, *blocks["C"].StartState, nil)
+
+		wg.Add(3)
+		for _, name := range []string{"A", "B", "C"} {
+			err := ctx.engine.handleBlock(context.Background(), blocks[name].Block)
+			require.NoError(t, err)
+		}
+
+		unittest.AssertReturnsBefore(t, wg.Wait, 10*time.Second)
+
+		_, more := <-ctx.engine.Done() //wait for all the blocks to be processed
+		require.False(t, more)
+
+		lock.Lock()
+		defer lock.Unlock()
+		require.Equal(t, 1, maxRunning)
+		unittest.IDsEqual(t, []flow.Identifier{blocks["A"].ID(), blocks["B"].ID(), blocks["C"].ID()}, order)
+	})
+}
+
 func TestExecutionGenerationResultsAreChained(t *testing.T) {
 
 	execState := new(state.ExecutionState)
@@ -1001,6 +1175,51 @@ func Test_SPOCKGeneration(t *testing.T) {
 	})
 }
 
+// TestRebroadcastReceiptOfExecutedBlock tests that receiving an executed block again re-broadcasts its
+// stored receipt instead of executing it again, unless the block has been sealed already.
+func TestRebroadcastReceiptOfExecutedBlock(t *testing.T) {
+	runWithEngine(t, func(ctx testingContext) {
+		// sealed <- A <- B
+		blockSealed := unittest.BlockHeaderFixture()
+		blockA := unittest.BlockWithParentFixture(&blockSealed)
+		blockB := unittest.BlockWithParentFixture(blockA.Header)
+
+		ctx.mockStateCommitsWithMap(map[flow.Identifier]flow.StateCommitment{
+			blockSealed.ID(): unittest.StateCommitmentFixture(),
+			blockA.ID():      unittest.StateCommitmentFixture(),
+			blockB.ID():      unittest.StateCommitmentFixture(),
+		})
+		ctx.state.On("Sealed").Return(ctx.snapshot)
+		ctx.snapshot.On("Head").Return(&blockSealed, nil)
+		ctx.mockStakedAtBlockID(blockA.ID(), true)
+
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithResult(unittest.ExecutionResultFixture(unittest.WithBlock(blockA))))
+		myReceipts := new(storagemock.MyExecutionReceipts)
+		myReceipts.On("MyReceipt", blockA.ID()).Return(receipt, nil).Once()
+		ctx.providerEngine.On("BroadcastExecutionReceipt", mock.Anything, receipt).Return(nil).Once()
+		ctx.engine.myReceipts = myReceipts
+		ctx.engine.rebroadcast = true
+
+		err := ctx.engine.handleBlock(context.Background(), blockA)
+		require.NoError(t, err)
+
+		// without the receipt stored, there is nothing to re-broadcast
+		ctx.mockStakedAtBlockID(blockB.ID(), true)
+		myReceipts.On("MyReceipt", blockB.ID()).Return(nil, storageerr.ErrNotFound).Once()
+		err = ctx.engine.handleBlock(context.Background(), blockB)
+		require.NoError(t, err)
+
+		// receipts of sealed blocks are not re-broadcasted
+		sealed := unittest.BlockWithParentFixture(&blockSealed)
+		sealed.Header = &blockSealed
+		err = ctx.engine.handleBlock(context.Background(), sealed)
+		require.NoError(t, err)
+
+		myReceipts.AssertExpectations(t)
+		ctx.providerEngine.AssertNumberOfCalls(t, "BroadcastExecutionReceipt", 1)
+	})
+}
+
 func TestUnstakedNodeDoesNotBroadcastReceipts(t *testing.T) {
 	unittest.SkipUnless(t, unittest.TEST_FLAKY, "flaky test")
 
@@ -1179,6 +1398,12 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		false,
 		checkStakedAtBlock,
 		false,
+		flow.ChunkBodyV0,
+		flow.ExecutionResultV0,
+		nil,
+		DefaultReloadScanLimit,
+		false,
+		DefaultMaxConcurrentBlockExecutions,
 	)
 
 	require.NoError(t, err)
@@ -1206,7 +1431,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es := mocks.NewExecutionState(seal)
 		engine := newIngestionEngine(t, ps, es)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1231,7 +1456,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es := mocks.NewExecutionState(seal)
 		engine := newIngestionEngine(t, ps, es)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1259,7 +1484,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es.ExecuteBlock(t, blockA)
 		es.ExecuteBlock(t, blockB)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1290,7 +1515,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es.ExecuteBlock(t, blockB)
 		es.ExecuteBlock(t, blockC)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1321,7 +1546,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es.ExecuteBlock(t, blockB)
 		es.ExecuteBlock(t, blockC)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1352,7 +1577,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es.ExecuteBlock(t, blockC)
 		es.ExecuteBlock(t, blockD)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1410,7 +1635,7 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 		es.ExecuteBlock(t, blockG)
 		es.ExecuteBlock(t, blockJ)
 
-		finalized, pending, err := engine.unexecutedBlocks()
+		finalized, pending, _, err := engine.unexecutedBlocks()
 		require.NoError(t, err)
 
 		unittest.IDsEqual(t, []flow.Identifier{}, finalized)
@@ -1423,4 +1648,87 @@ func TestLoadingUnexecutedBlocks(t *testing.T) {
 			blockH.ID()},
 			pending)
 	})
+
+	t.Run("half of the finalized blocks executed", func(t *testing.T) {
+		ps := mocks.NewProtocolState()
+
+		// Genesis <- A <- B <- C <- D (finalized) <- E
+		chain, result, seal := unittest.ChainFixture(5)
+		genesis, blockA, blockB, blockC, blockD, blockE :=
+			chain[0], chain[1], chain[2], chain[3], chain[4], chain[5]
+
+		logChain(chain)
+
+		require.NoError(t, ps.Bootstrap(genesis, result, seal))
+		require.NoError(t, ps.Extend(blockA))
+		require.NoError(t, ps.Extend(blockB))
+		require.NoError(t, ps.Extend(blockC))
+		require.NoError(t, ps.Extend(blockD))
+		require.NoError(t, ps.Extend(blockE))
+		require.NoError(t, ps.Finalize(blockD.ID()))
+
+		es := mocks.NewExecutionState(seal)
+		engine := newIngestionEngine(t, ps, es)
+
+		es.ExecuteBlock(t, blockA)
+		es.ExecuteBlock(t, blockB)
+
+		finalized, pending, executed, err := engine.unexecutedBlocks()
+		require.NoError(t, err)
+
+		// the executed, but unsealed blocks are skipped and reported for re-broadcasting their receipts
+		unittest.IDsEqual(t, []flow.Identifier{blockC.ID(), blockD.ID()}, finalized)
+		unittest.IDsEqual(t, []flow.Identifier{blockE.ID()}, pending)
+		unittest.IDsEqual(t, []flow.Identifier{blockB.ID(), blockA.ID()}, executed)
+
+		// the receipts of sealed blocks are not re-broadcasted
+		require.NoError(t, ps.Seal(blockA.ID()))
+
+		finalized, pending, executed, err = engine.unexecutedBlocks()
+		require.NoError(t, err)
+
+		unittest.IDsEqual(t, []flow.Identifier{blockC.ID(), blockD.ID()}, finalized)
+		unittest.IDsEqual(t, []flow.Identifier{blockE.ID()}, pending)
+		unittest.IDsEqual(t, []flow.Identifier{blockB.ID()}, executed)
+	})
+
+	t.Run("scan limit", func(t *testing.T) {
+		ps := mocks.NewProtocolState()
+
+		chain, result, seal := unittest.ChainFixture(4)
+		genesis, blockA, blockB, blockC, blockD :=
+			chain[0], chain[1], chain[2], chain[3], chain[4]
+
+		logChain(chain)
+
+		require.NoError(t, ps.Bootstrap(genesis, result, seal))
+		require.NoError(t, ps.Extend(blockA))
+		require.NoError(t, ps.Extend(blockB))
+		require.NoError(t, ps.Extend(blockC))
+		require.NoError(t, ps.Extend(blockD))
+		require.NoError(t, ps.Finalize(blockD.ID()))
+
+		es := mocks.NewExecutionState(seal)
+		engine := newIngestionEngine(t, ps, es)
+		engine.reloadScanLimit = 2
+
+		es.ExecuteBlock(t, blockA)
+
+		// three finalized blocks are unexecuted, the scan stops at the limit and reloads the scanned blocks
+		finalized, pending, executed, err := engine.unexecutedBlocks()
+		require.NoError(t, err)
+
+		unittest.IDsEqual(t, []flow.Identifier{blockB.ID(), blockC.ID(), blockD.ID()}, finalized)
+		unittest.IDsEqual(t, []flow.Identifier{}, pending)
+		unittest.IDsEqual(t, []flow.Identifier{}, executed)
+
+		es.ExecuteBlock(t, blockB)
+
+		finalized, pending, executed, err = engine.unexecutedBlocks()
+		require.NoError(t, err)
+
+		unittest.IDsEqual(t, []flow.Identifier{blockC.ID(), blockD.ID()}, finalized)
+		unittest.IDsEqual(t, []flow.Identifier{}, pending)
+		unittest.IDsEqual(t, []flow.Identifier{blockB.ID()}, executed)
+	})
 }
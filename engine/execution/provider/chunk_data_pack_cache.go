@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultChunkDataPackCacheBudget is the default total encoded byte size of the chunk data packs
+// cached by the provider engine (256MB).
+const DefaultChunkDataPackCacheBudget = 256 << 20
+
+// cachedChunkDataPack is a chunk data pack cached along with the height of the block of its chunk,
+// and its encoded byte size.
+type cachedChunkDataPack struct {
+	chunkID       flow.Identifier
+	chunkDataPack *flow.ChunkDataPack
+	height        uint64
+	size          uint64
+}
+
+// chunkDataPackCache caches the chunk data packs served to verification nodes, as most requests
+// for a chunk data pack arrive shortly after its block was executed. Once the total encoded size
+// of the cached chunk data packs exceeds the byte budget, the least recently requested ones are
+// evicted. Concurrent requests for a chunk data pack, which is not cached, retrieve it only once.
+type chunkDataPackCache struct {
+	sync.Mutex
+	budget   uint64
+	size     uint64
+	order    *list.List // least recently requested chunk data pack at the back
+	entries  map[flow.Identifier]*list.Element
+	inflight singleflight.Group
+	metrics  module.CacheMetrics
+	retrieve func(chunkID flow.Identifier) (*cachedChunkDataPack, error)
+}
+
+// newChunkDataPackCache creates a cache of chunk data packs up to the given total byte size, which
+// retrieves chunk data packs that are not cached with the given function. A zero budget disables
+// caching, while concurrent requests are still coalesced.
+func newChunkDataPackCache(
+	budget uint64,
+	collector module.CacheMetrics,
+	retrieve func(chunkID flow.Identifier) (*cachedChunkDataPack, error),
+) *chunkDataPackCache {
+	return &chunkDataPackCache{
+		budget:   budget,
+		order:    list.New(),
+		entries:  make(map[flow.Identifier]*list.Element),
+		metrics:  collector,
+		retrieve: retrieve,
+	}
+}
+
+// ByChunkID returns the chunk data pack of the given chunk, retrieving it if it is not cached.
+func (c *chunkDataPackCache) ByChunkID(chunkID flow.Identifier) (*flow.ChunkDataPack, error) {
+	c.Lock()
+	element, ok := c.entries[chunkID]
+	if ok {
+		c.order.MoveToFront(element)
+	}
+	c.Unlock()
+	if ok {
+		c.metrics.CacheHit(metrics.ResourceProvidedChunkDataPack)
+		return element.Value.(*cachedChunkDataPack).chunkDataPack, nil
+	}
+
+	value, err, _ := c.inflight.Do(chunkID.String(), func() (interface{}, error) {
+		entry, err := c.retrieve(chunkID)
+		if err != nil {
+			return nil, err
+		}
+		c.add(chunkID, entry)
+		return entry, nil
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		c.metrics.CacheNotFound(metrics.ResourceProvidedChunkDataPack)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.metrics.CacheMiss(metrics.ResourceProvidedChunkDataPack)
+	return value.(*cachedChunkDataPack).chunkDataPack, nil
+}
+
+// add caches the given chunk data pack, evicting the least recently requested chunk data packs
+// until the cache fits into its budget. Chunk data packs exceeding the budget on their own are not cached.
+func (c *chunkDataPackCache) add(chunkID flow.Identifier, entry *cachedChunkDataPack) {
+	c.Lock()
+	defer c.Unlock()
+
+	if entry.size > c.budget {
+		return
+	}
+	if _, ok := c.entries[chunkID]; ok {
+		return
+	}
+
+	entry.chunkID = chunkID
+	c.entries[chunkID] = c.order.PushFront(entry)
+	c.size += entry.size
+	for c.size > c.budget {
+		c.remove(c.order.Back())
+	}
+	c.metrics.CacheEntries(metrics.ResourceProvidedChunkDataPack, uint(len(c.entries)))
+}
+
+// PruneUpToHeight removes the chunk data packs of all blocks up to and including the given height,
+// as verification nodes no longer request the chunk data packs of sealed blocks.
+func (c *chunkDataPackCache) PruneUpToHeight(height uint64) {
+	c.Lock()
+	defer c.Unlock()
+
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*cachedChunkDataPack).height <= height {
+			c.remove(element)
+		}
+		element = next
+	}
+	c.metrics.CacheEntries(metrics.ResourceProvidedChunkDataPack, uint(len(c.entries)))
+}
+
+// Size returns the total encoded byte size of the cached chunk data packs.
+func (c *chunkDataPackCache) Size() uint64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.size
+}
+
+// remove removes the given element from the cache. It must be called while holding the lock.
+func (c *chunkDataPackCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*cachedChunkDataPack)
+	delete(c.entries, entry.chunkID)
+	c.size -= entry.size
+}
+
+// newCachedChunkDataPack wraps the given chunk data pack of a chunk of the block at the given height for caching.
+func newCachedChunkDataPack(chunkDataPack *flow.ChunkDataPack, height uint64) (*cachedChunkDataPack, error) {
+	encoded, err := cbor.EncMode.Marshal(chunkDataPack)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedChunkDataPack{
+		chunkDataPack: chunkDataPack,
+		height:        height,
+		size:          uint64(len(encoded)),
+	}, nil
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// chunkDataPackStore mocks the retrieval of chunk data packs of chunks of the blocks at the given heights.
+type chunkDataPackStore struct {
+	sync.Mutex
+	packs     map[flow.Identifier]*cachedChunkDataPack
+	retrieved map[flow.Identifier]int
+}
+
+func newChunkDataPackStore(t *testing.T, heights ...uint64) (*chunkDataPackStore, []flow.Identifier) {
+	store := &chunkDataPackStore{
+		packs:     make(map[flow.Identifier]*cachedChunkDataPack),
+		retrieved: make(map[flow.Identifier]int),
+	}
+	chunkIDs := make([]flow.Identifier, 0, len(heights))
+	for _, height := range heights {
+		chunkID := unittest.IdentifierFixture()
+		entry, err := newCachedChunkDataPack(unittest.ChunkDataPackFixture(chunkID), height)
+		require.NoError(t, err)
+		store.packs[chunkID] = entry
+		chunkIDs = append(chunkIDs, chunkID)
+	}
+	return store, chunkIDs
+}
+
+func (s *chunkDataPackStore) retrieve(chunkID flow.Identifier) (*cachedChunkDataPack, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.retrieved[chunkID]++
+	entry, ok := s.packs[chunkID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	// return a copy, as the cache takes ownership of the entry
+	copied := *entry
+	return &copied, nil
+}
+
+func (s *chunkDataPackStore) retrievals(chunkID flow.Identifier) int {
+	s.Lock()
+	defer s.Unlock()
+	return s.retrieved[chunkID]
+}
+
+// TestChunkDataPackCache_ByteBudget tests that the least recently requested chunk data packs are
+// evicted once the total size of the cached chunk data packs exceeds the byte budget.
+func TestChunkDataPackCache_ByteBudget(t *testing.T) {
+	store, chunkIDs := newChunkDataPackStore(t, 1, 1, 1)
+	budget := store.packs[chunkIDs[0]].size + store.packs[chunkIDs[1]].size
+	cache := newChunkDataPackCache(budget, metrics.NewNoopCollector(), store.retrieve)
+
+	for _, chunkID := range chunkIDs[:2] {
+		_, err := cache.ByChunkID(chunkID)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, budget, cache.Size())
+
+	// requesting the first chunk data pack again makes the second one the least recently requested
+	_, err := cache.ByChunkID(chunkIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.retrievals(chunkIDs[0]))
+
+	// the third chunk data pack only fits by evicting the second one
+	_, err = cache.ByChunkID(chunkIDs[2])
+	require.NoError(t, err)
+	assert.LessOrEqual(t, cache.Size(), budget)
+
+	_, err = cache.ByChunkID(chunkIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.retrievals(chunkIDs[0]))
+	_, err = cache.ByChunkID(chunkIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.retrievals(chunkIDs[1]))
+}
+
+// TestChunkDataPackCache_ExceedingBudget tests that chunk data packs larger than the byte budget
+// are served, but not cached.
+func TestChunkDataPackCache_ExceedingBudget(t *testing.T) {
+	store, chunkIDs := newChunkDataPackStore(t, 1)
+	cache := newChunkDataPackCache(store.packs[chunkIDs[0]].size-1, metrics.NewNoopCollector(), store.retrieve)
+
+	for i := 1; i <= 2; i++ {
+		chunkDataPack, err := cache.ByChunkID(chunkIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, chunkIDs[0], chunkDataPack.ChunkID)
+		assert.Equal(t, i, store.retrievals(chunkIDs[0]))
+	}
+	assert.Zero(t, cache.Size())
+}
+
+// TestChunkDataPackCache_ConcurrentRequests tests that concurrent requests for a chunk data pack,
+// which is not cached, retrieve it only once.
+func TestChunkDataPackCache_ConcurrentRequests(t *testing.T) {
+	store, chunkIDs := newChunkDataPackStore(t, 1)
+	release := make(chan struct{})
+	retrieving := atomic.NewInt32(0)
+	cache := newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), func(chunkID flow.Identifier) (*cachedChunkDataPack, error) {
+		retrieving.Inc()
+		<-release
+		return store.retrieve(chunkID)
+	})
+
+	const requests = 10
+	var wg sync.WaitGroup
+	served := make([]*flow.ChunkDataPack, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			chunkDataPack, err := cache.ByChunkID(chunkIDs[0])
+			assert.NoError(t, err)
+			served[i] = chunkDataPack
+		}(i)
+	}
+
+	require.Eventually(t, func() bool { return retrieving.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	unittest.RequireReturnsBefore(t, wg.Wait, time.Second, "concurrent requests were not served")
+
+	assert.Equal(t, 1, store.retrievals(chunkIDs[0]))
+	for _, chunkDataPack := range served {
+		assert.Same(t, served[0], chunkDataPack)
+	}
+}
+
+// TestChunkDataPackCache_ServedBytes tests that cached chunk data packs encode to the same bytes
+// as freshly retrieved ones.
+func TestChunkDataPackCache_ServedBytes(t *testing.T) {
+	store, chunkIDs := newChunkDataPackStore(t, 1)
+	cache := newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), store.retrieve)
+
+	fresh, err := cbor.EncMode.Marshal(store.packs[chunkIDs[0]].chunkDataPack)
+	require.NoError(t, err)
+
+	// the first request is a cache miss, the second one a hit
+	for i := 0; i < 2; i++ {
+		chunkDataPack, err := cache.ByChunkID(chunkIDs[0])
+		require.NoError(t, err)
+		served, err := cbor.EncMode.Marshal(chunkDataPack)
+		require.NoError(t, err)
+		assert.Equal(t, fresh, served)
+	}
+	assert.Equal(t, 1, store.retrievals(chunkIDs[0]))
+	assert.Equal(t, uint64(len(fresh)), cache.Size())
+}
+
+// TestChunkDataPackCache_NotFound tests that missing chunk data packs are not cached.
+func TestChunkDataPackCache_NotFound(t *testing.T) {
+	store, _ := newChunkDataPackStore(t)
+	cache := newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), store.retrieve)
+
+	chunkID := unittest.IdentifierFixture()
+	for i := 1; i <= 2; i++ {
+		_, err := cache.ByChunkID(chunkID)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+		assert.Equal(t, i, store.retrievals(chunkID))
+	}
+}
+
+// TestChunkDataPackCache_PruneUpToHeight tests that pruning removes the chunk data packs of
+// the blocks up to the pruned height.
+func TestChunkDataPackCache_PruneUpToHeight(t *testing.T) {
+	store, chunkIDs := newChunkDataPackStore(t, 10, 11, 12)
+	cache := newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), store.retrieve)
+	for _, chunkID := range chunkIDs {
+		_, err := cache.ByChunkID(chunkID)
+		require.NoError(t, err)
+	}
+
+	cache.PruneUpToHeight(11)
+	assert.Equal(t, store.packs[chunkIDs[2]].size, cache.Size())
+
+	for i, chunkID := range chunkIDs {
+		_, err := cache.ByChunkID(chunkID)
+		require.NoError(t, err)
+		expected := 2
+		if i == 2 {
+			expected = 1
+		}
+		assert.Equal(t, expected, store.retrievals(chunkID))
+	}
+}
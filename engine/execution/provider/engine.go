@@ -18,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/logging"
 )
@@ -30,6 +31,7 @@ type ProviderEngine interface {
 // An Engine provides means of accessing data about execution state and broadcasts execution receipts to nodes in the network.
 // Also generates and saves execution receipts
 type Engine struct {
+	events.Noop         // satisfy protocol events consumer interface
 	unit                *engine.Unit
 	log                 zerolog.Logger
 	tracer              module.Tracer
@@ -42,6 +44,7 @@ type Engine struct {
 	checkStakedAtBlock  func(blockID flow.Identifier) (bool, error)
 	chdpQueryTimeout    time.Duration
 	chdpDeliveryTimeout time.Duration
+	chunks              *chunkDataPackCache
 }
 
 func New(
@@ -55,6 +58,8 @@ func New(
 	checkStakedAtBlock func(blockID flow.Identifier) (bool, error),
 	chdpQueryTimeout uint,
 	chdpDeliveryTimeout uint,
+	cacheMetrics module.CacheMetrics,
+	chdpCacheBudget uint64,
 ) (*Engine, error) {
 
 	log := logger.With().Str("engine", "receipts").Logger()
@@ -71,6 +76,7 @@ func New(
 		chdpQueryTimeout:    time.Duration(chdpQueryTimeout) * time.Second,
 		chdpDeliveryTimeout: time.Duration(chdpDeliveryTimeout) * time.Second,
 	}
+	eng.chunks = newChunkDataPackCache(chdpCacheBudget, cacheMetrics, eng.retrieveChunkDataPack)
 
 	var err error
 
@@ -166,7 +172,7 @@ func (e *Engine) onChunkDataRequest(
 	// increases collector metric
 	e.metrics.ChunkDataPackRequested()
 
-	chunkDataPack, err := e.execState.ChunkDataPackByChunkID(ctx, chunkID)
+	chunkDataPack, err := e.chunks.ByChunkID(chunkID)
 	// we might be behind when we don't have the requested chunk.
 	// if this happen, log it and return nil
 	if errors.Is(err, storage.ErrNotFound) {
@@ -236,6 +242,37 @@ func (e *Engine) onChunkDataRequest(
 	})
 }
 
+// retrieveChunkDataPack retrieves the chunk data pack of the given chunk from the execution state
+// for caching, along with the height of the block of the chunk.
+func (e *Engine) retrieveChunkDataPack(chunkID flow.Identifier) (*cachedChunkDataPack, error) {
+	chunkDataPack, err := e.execState.ChunkDataPackByChunkID(context.Background(), chunkID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chunk data pack: %w", err)
+	}
+
+	blockID, err := e.execState.GetBlockIDByChunkID(chunkID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve block ID of chunk: %w", err)
+	}
+	header, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve block of chunk (%x): %w", blockID, err)
+	}
+
+	return newCachedChunkDataPack(chunkDataPack, header.Height)
+}
+
+// BlockFinalized removes the chunk data packs of sealed blocks from the cache, as verification
+// nodes no longer request them.
+func (e *Engine) BlockFinalized(*flow.Header) {
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not get last sealed block to prune chunk data pack cache")
+		return
+	}
+	e.chunks.PruneUpToHeight(sealed.Height)
+}
+
 func (e *Engine) ensureStaked(chunkID flow.Identifier, originID flow.Identifier) (*flow.Identity, error) {
 
 	blockID, err := e.execState.GetBlockIDByChunkID(chunkID)
@@ -37,6 +37,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			metrics:            metrics.NewNoopCollector(),
 			chunksConduit:      &chunkConduit,
 			checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
+		e.chunks = newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), e.retrieveChunkDataPack)
 
 		originID := unittest.IdentifierFixture()
 		chunkID := unittest.IdentifierFixture()
@@ -44,6 +45,8 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		chunkDataPack := unittest.ChunkDataPackFixture(chunkID)
 
 		ps.On("AtBlockID", blockID).Return(ss)
+		header := unittest.BlockHeaderFixture()
+		ss.On("Head").Return(&header, nil)
 		ss.On("Identity", originID).Return(unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution)), nil)
 		execState.On("ChunkDataPackByChunkID", mock.Anything, mock.Anything).Return(chunkDataPack, nil)
 		execState.On("GetBlockIDByChunkID", chunkID).Return(blockID, nil)
@@ -80,6 +83,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			metrics:            metrics.NewNoopCollector(),
 			chunksConduit:      &chunkConduit,
 			checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
+		e.chunks = newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), e.retrieveChunkDataPack)
 
 		originID := unittest.IdentifierFixture()
 		chunkID := unittest.IdentifierFixture()
@@ -87,6 +91,8 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		chunkDataPack := unittest.ChunkDataPackFixture(chunkID)
 
 		ps.On("AtBlockID", blockID).Return(ss)
+		header := unittest.BlockHeaderFixture()
+		ss.On("Head").Return(&header, nil)
 		ss.On("Identity", originID).Return(unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithStake(0)), nil)
 		execState.On("ChunkDataPackByChunkID", mock.Anything, mock.Anything).Return(chunkDataPack, nil)
 		execState.On("GetBlockIDByChunkID", chunkID).Return(blockID, nil)
@@ -123,6 +129,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			metrics:            metrics.NewNoopCollector(),
 			chunksConduit:      &chunkConduit,
 			checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
+		e.chunks = newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), e.retrieveChunkDataPack)
 
 		originID := unittest.IdentifierFixture()
 		chunkID := unittest.IdentifierFixture()
@@ -130,6 +137,8 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		chunkDataPack := unittest.ChunkDataPackFixture(chunkID)
 
 		ps.On("AtBlockID", blockID).Return(ss)
+		header := unittest.BlockHeaderFixture()
+		ss.On("Head").Return(&header, nil)
 		ss.On("Identity", originID).Return(nil, protocol.IdentityNotFoundError{})
 		execState.On("ChunkDataPackByChunkID", mock.Anything, mock.Anything).Return(chunkDataPack, nil)
 		execState.On("GetBlockIDByChunkID", chunkID).Return(blockID, nil)
@@ -168,6 +177,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			chunksConduit:      &chunkConduit,
 			metrics:            metrics.NewNoopCollector(),
 			checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
+		e.chunks = newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), e.retrieveChunkDataPack)
 
 		originIdentity := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
 
@@ -205,6 +215,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			execState:          execState,
 			metrics:            metrics.NewNoopCollector(),
 			checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
+		e.chunks = newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), e.retrieveChunkDataPack)
 
 		originIdentity := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
 
@@ -213,6 +224,8 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		blockID := unittest.IdentifierFixture()
 
 		ps.On("AtBlockID", blockID).Return(ss)
+		header := unittest.BlockHeaderFixture()
+		ss.On("Head").Return(&header, nil)
 		ss.On("Identity", originIdentity.NodeID).Return(originIdentity, nil)
 		chunkConduit.On("Unicast", mock.Anything, originIdentity.NodeID).
 			Run(func(args mock.Arguments) {
@@ -261,6 +274,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			metrics:            metrics.NewNoopCollector(),
 			checkStakedAtBlock: checkStakedAtBlock,
 		}
+		e.chunks = newChunkDataPackCache(DefaultChunkDataPackCacheBudget, metrics.NewNoopCollector(), e.retrieveChunkDataPack)
 
 		originIdentity := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
 
@@ -270,6 +284,8 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 
 		execState.On("GetBlockIDByChunkID", chunkID).Return(blockID, nil)
 		ps.On("AtBlockID", blockID).Return(ss)
+		header := unittest.BlockHeaderFixture()
+		ss.On("Head").Return(&header, nil)
 
 		ss.On("Identity", originIdentity.NodeID).Return(originIdentity, nil).Once()
 		chunkConduit.On("Unicast", mock.Anything, originIdentity.NodeID).
@@ -282,7 +298,8 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			}).
 			Return(nil).Once()
 
-		execState.On("ChunkDataPackByChunkID", mock.Anything, chunkID).Return(chunkDataPack, nil).Twice()
+		// the second request is served from the cache
+		execState.On("ChunkDataPackByChunkID", mock.Anything, chunkID).Return(chunkDataPack, nil).Once()
 
 		req := &messages.ChunkDataRequest{
 			ChunkID: chunkID,
@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Config defines the configurable options of the register audit mode.
+type Config struct {
+	// Owners are the addresses of the accounts whose registers are audited. The audit
+	// mode is disabled if no owners are given.
+	Owners []flow.Address
+	// Retention is the number of heights for which audit records are kept in storage.
+	// Records are kept forever if the retention is zero.
+	Retention uint64
+	// PruneInterval is the interval at which records beyond the retention are removed from storage.
+	PruneInterval time.Duration
+}
+
+// DefaultConfig returns the default configuration of the register audit mode, which is disabled.
+func DefaultConfig() Config {
+	return Config{
+		Owners:        nil,
+		Retention:     100_000,
+		PruneInterval: time.Hour,
+	}
+}
+
+// Auditor records an audit trail of the transactions which read or wrote registers owned by
+// a configured set of watched accounts. For every access to a watched register, an audit record
+// attributing the access to the transaction and block is appended to storage, where it is kept
+// for the retention period.
+type Auditor struct {
+	unit    *engine.Unit
+	log     zerolog.Logger
+	records storage.RegisterAuditRecords
+	config  Config
+	enabled bool
+	watched map[string]struct{} // register owners of the watched accounts
+
+	mu      sync.Mutex
+	highest uint64 // highest height at which an access was audited
+}
+
+// NewAuditor creates a new auditor for the registers of the configured owners, which appends
+// audit records to the given storage.
+func NewAuditor(log zerolog.Logger, records storage.RegisterAuditRecords, config Config) *Auditor {
+	watched := make(map[string]struct{}, len(config.Owners))
+	for _, owner := range config.Owners {
+		watched[string(owner.Bytes())] = struct{}{}
+	}
+
+	return &Auditor{
+		unit:    engine.NewUnit(),
+		log:     log.With().Str("module", "register_auditor").Logger(),
+		records: records,
+		config:  config,
+		enabled: len(watched) > 0,
+		watched: watched,
+	}
+}
+
+// Enabled returns whether any accounts are audited.
+func (a *Auditor) Enabled() bool {
+	return a.enabled
+}
+
+// Ready returns a ready channel that is closed once the auditor has started pruning
+// records beyond the retention period.
+func (a *Auditor) Ready() <-chan struct{} {
+	if a.enabled && a.config.Retention > 0 {
+		a.unit.LaunchPeriodically(a.prune, a.config.PruneInterval, 0)
+	}
+	return a.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the auditor has stopped.
+func (a *Auditor) Done() <-chan struct{} {
+	return a.unit.Done()
+}
+
+// AuditTransaction appends an audit record for every watched register touched by the given
+// transaction within the given block. The view must contain the register touches of the
+// transaction only, and must not be merged into its parent view yet. Registers written by the
+// transaction are recorded with the hash of the written value, all other touched registers are
+// recorded as reads with the hash of the value read from the parent view.
+func (a *Auditor) AuditTransaction(header *flow.Header, txID flow.Identifier, view *delta.View) error {
+	if !a.enabled {
+		return nil
+	}
+
+	var ids []flow.RegisterID
+	view.ForEachTouch(func(id flow.RegisterID) {
+		if _, ok := a.watched[id.Owner]; ok {
+			ids = append(ids, id)
+		}
+	})
+	if len(ids) == 0 {
+		return nil
+	}
+	// the touched registers are unordered, sort them for a deterministic audit trail
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	blockID := header.ID()
+	records := make([]*flow.RegisterAuditRecord, 0, len(ids))
+	for _, id := range ids {
+		access := flow.RegisterWrite
+		value, written := view.Delta().Get(id.Owner, id.Controller, id.Key)
+		if !written {
+			access = flow.RegisterRead
+			var err error
+			value, err = view.Peek(id.Owner, id.Controller, id.Key)
+			if err != nil {
+				return fmt.Errorf("could not read audited register %s: %w", id, err)
+			}
+		}
+
+		records = append(records, &flow.RegisterAuditRecord{
+			TransactionID: txID,
+			BlockID:       blockID,
+			Height:        header.Height,
+			RegisterID:    id,
+			Access:        access,
+			ValueHash:     flow.HashToID(hash.NewSHA3_256().ComputeHash(value)),
+		})
+	}
+
+	err := a.records.Store(records)
+	if err != nil {
+		return fmt.Errorf("could not store register audit records: %w", err)
+	}
+
+	a.mu.Lock()
+	if header.Height > a.highest {
+		a.highest = header.Height
+	}
+	a.mu.Unlock()
+
+	return nil
+}
+
+// AuditRecords returns the audit records of the registers owned by the given account, which were
+// accessed in blocks within the given height range (inclusive), ordered by height.
+func (a *Auditor) AuditRecords(owner flow.Address, fromHeight, toHeight uint64) ([]*flow.RegisterAuditRecord, error) {
+	return a.records.ByOwner(owner, fromHeight, toHeight)
+}
+
+// prune removes the records of accesses beyond the retention period below the highest audited height.
+func (a *Auditor) prune() {
+	a.mu.Lock()
+	highest := a.highest
+	a.mu.Unlock()
+
+	if highest < a.config.Retention {
+		return
+	}
+
+	removed, err := a.records.PruneBelow(highest - a.config.Retention)
+	if err != nil {
+		a.log.Error().Err(err).Msg("could not prune register audit records")
+		return
+	}
+	if removed > 0 {
+		a.log.Info().Uint("removed", removed).Msg("pruned register audit records beyond retention")
+	}
+}
@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+var (
+	watched   = flow.HexToAddress("01")
+	unwatched = flow.HexToAddress("02")
+)
+
+func owner(address flow.Address) string {
+	return string(address.Bytes())
+}
+
+func valueHash(value flow.RegisterValue) flow.Identifier {
+	return flow.HashToID(hash.NewSHA3_256().ComputeHash(value))
+}
+
+// executeTransaction simulates the execution of a transaction on a child view of the given
+// block view, which reads and writes the given registers.
+func executeTransaction(t *testing.T, blockView *delta.View, reads []flow.RegisterID, writes map[flow.RegisterID]flow.RegisterValue) *delta.View {
+	txView := blockView.NewChild().(*delta.View)
+	for _, id := range reads {
+		_, err := txView.Get(id.Owner, id.Controller, id.Key)
+		require.NoError(t, err)
+	}
+	for id, value := range writes {
+		require.NoError(t, txView.Set(id.Owner, id.Controller, id.Key, value))
+	}
+	return txView
+}
+
+func records(t *testing.T, auditor *Auditor, address flow.Address) []*flow.RegisterAuditRecord {
+	result, err := auditor.AuditRecords(address, 0, math.MaxUint64)
+	require.NoError(t, err)
+	return result
+}
+
+// TestAuditor_Attribution tests that accesses to watched registers are recorded and attributed
+// to the transaction and block, while accesses to unwatched registers are not recorded.
+func TestAuditor_Attribution(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		config := DefaultConfig()
+		config.Owners = []flow.Address{watched}
+		auditor := NewAuditor(unittest.Logger(), bstorage.NewRegisterAuditRecords(db), config)
+		require.True(t, auditor.Enabled())
+
+		read := flow.NewRegisterID(owner(watched), "", "balance")
+		written := flow.NewRegisterID(owner(watched), owner(watched), "contract")
+		other := flow.NewRegisterID(owner(unwatched), "", "balance")
+		stored := flow.RegisterValue("stored")
+
+		blockView := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return stored, nil
+		})
+		header := unittest.BlockHeaderFixture()
+		txID := unittest.IdentifierFixture()
+		txView := executeTransaction(t, blockView,
+			[]flow.RegisterID{read, other},
+			map[flow.RegisterID]flow.RegisterValue{
+				written: flow.RegisterValue("written"),
+				other:   flow.RegisterValue("other"),
+			},
+		)
+		require.NoError(t, auditor.AuditTransaction(&header, txID, txView))
+
+		result := records(t, auditor, watched)
+		require.Len(t, result, 2)
+		byRegister := make(map[flow.RegisterID]*flow.RegisterAuditRecord)
+		for _, record := range result {
+			assert.Equal(t, txID, record.TransactionID)
+			assert.Equal(t, header.ID(), record.BlockID)
+			assert.Equal(t, header.Height, record.Height)
+			assert.Equal(t, watched, record.Owner())
+			byRegister[record.RegisterID] = record
+		}
+		require.Contains(t, byRegister, read)
+		assert.Equal(t, flow.RegisterRead, byRegister[read].Access)
+		assert.Equal(t, valueHash(stored), byRegister[read].ValueHash)
+		require.Contains(t, byRegister, written)
+		assert.Equal(t, flow.RegisterWrite, byRegister[written].Access)
+		assert.Equal(t, valueHash(flow.RegisterValue("written")), byRegister[written].ValueHash)
+
+		// the unwatched account was touched, but isn't recorded
+		assert.Empty(t, records(t, auditor, unwatched))
+	})
+}
+
+// TestAuditor_HeightRange tests that records are queried by the height of the accessing block.
+func TestAuditor_HeightRange(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		config := DefaultConfig()
+		config.Owners = []flow.Address{watched}
+		auditor := NewAuditor(unittest.Logger(), bstorage.NewRegisterAuditRecords(db), config)
+
+		id := flow.NewRegisterID(owner(watched), "", "balance")
+		blockView := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+		for height := uint64(10); height < 20; height++ {
+			header := unittest.BlockHeaderWithParentFixture(&flow.Header{Height: height - 1})
+			txView := executeTransaction(t, blockView, []flow.RegisterID{id}, nil)
+			require.NoError(t, auditor.AuditTransaction(&header, unittest.IdentifierFixture(), txView))
+		}
+
+		result, err := auditor.AuditRecords(watched, 12, 14)
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		for i, record := range result {
+			assert.Equal(t, uint64(12+i), record.Height)
+		}
+
+		_, err = auditor.AuditRecords(watched, 14, 12)
+		assert.Error(t, err)
+	})
+}
+
+// TestAuditor_Retention tests that pruning removes the records beyond the retention.
+func TestAuditor_Retention(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		config := DefaultConfig()
+		config.Owners = []flow.Address{watched}
+		config.Retention = 5
+		auditor := NewAuditor(unittest.Logger(), bstorage.NewRegisterAuditRecords(db), config)
+
+		id := flow.NewRegisterID(owner(watched), "", "balance")
+		blockView := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+		for height := uint64(1); height <= 10; height++ {
+			header := unittest.BlockHeaderWithParentFixture(&flow.Header{Height: height - 1})
+			txView := executeTransaction(t, blockView, []flow.RegisterID{id}, nil)
+			require.NoError(t, auditor.AuditTransaction(&header, unittest.IdentifierFixture(), txView))
+		}
+		require.Len(t, records(t, auditor, watched), 10)
+
+		auditor.prune()
+		result := records(t, auditor, watched)
+		require.Len(t, result, 6)
+		assert.Equal(t, uint64(5), result[0].Height)
+	})
+}
+
+// TestAuditor_Disabled tests that no records are added if no accounts are audited.
+func TestAuditor_Disabled(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		auditor := NewAuditor(unittest.Logger(), bstorage.NewRegisterAuditRecords(db), DefaultConfig())
+		require.False(t, auditor.Enabled())
+
+		id := flow.NewRegisterID(owner(watched), "", "balance")
+		blockView := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+		header := unittest.BlockHeaderFixture()
+		txView := executeTransaction(t, blockView, []flow.RegisterID{id}, map[flow.RegisterID]flow.RegisterValue{
+			id: flow.RegisterValue("written"),
+		})
+		require.NoError(t, auditor.AuditTransaction(&header, unittest.IdentifierFixture(), txView))
+
+		assert.Empty(t, records(t, auditor, watched))
+	})
+}
@@ -237,6 +237,14 @@ func (r *Snapshot) RegisterTouches() map[string]flow.RegisterID {
 	return ret
 }
 
+// ForEachTouch calls the given function for each register touched by this view (either read or
+// written to), including all merged child views, without copying the set of touched registers.
+func (v *View) ForEachTouch(f func(id flow.RegisterID)) {
+	for _, id := range v.regTouchSet {
+		f(id)
+	}
+}
+
 // ReadsCount returns the total number of reads performed on this view including all child views
 func (v *View) ReadsCount() uint64 {
 	return v.readsCount
@@ -186,6 +186,38 @@ func (_m *ExecutionState) GetRegisters(_a0 context.Context, _a1 flow.StateCommit
 	return r0, r1
 }
 
+// GetRegistersByOwner provides a mock function with given fields: ctx, commit, owner, pageToken, limit
+func (_m *ExecutionState) GetRegistersByOwner(ctx context.Context, commit flow.StateCommitment, owner flow.Address, pageToken []byte, limit int) ([]flow.RegisterEntry, []byte, error) {
+	ret := _m.Called(ctx, commit, owner, pageToken, limit)
+
+	var r0 []flow.RegisterEntry
+	if rf, ok := ret.Get(0).(func(context.Context, flow.StateCommitment, flow.Address, []byte, int) []flow.RegisterEntry); ok {
+		r0 = rf(ctx, commit, owner, pageToken, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.RegisterEntry)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(context.Context, flow.StateCommitment, flow.Address, []byte, int) []byte); ok {
+		r1 = rf(ctx, commit, owner, pageToken, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, flow.StateCommitment, flow.Address, []byte, int) error); ok {
+		r2 = rf(ctx, commit, owner, pageToken, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewView provides a mock function with given fields: _a0
 func (_m *ExecutionState) NewView(_a0 flow.StateCommitment) *delta.View {
 	ret := _m.Called(_a0)
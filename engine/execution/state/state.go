@@ -37,6 +37,19 @@ type ReadOnlyExecutionState interface {
 		[]flow.RegisterID,
 	) (flow.StorageProof, error)
 
+	// GetRegistersByOwner returns a page of up to `limit` registers owned by the given address at the
+	// given state commitment, and the token of the next page (nil if there are no more registers).
+	// The page size is capped at MaxRegistersPageSize, and an empty page token starts with the first page.
+	// As the work of a page is bounded, a page can hold fewer registers than the limit, or none at all,
+	// while further pages follow.
+	GetRegistersByOwner(
+		ctx context.Context,
+		commit flow.StateCommitment,
+		owner flow.Address,
+		pageToken []byte,
+		limit int,
+	) ([]flow.RegisterEntry, []byte, error)
+
 	// StateCommitmentByBlockID returns the final state commitment for the provided block ID.
 	StateCommitmentByBlockID(context.Context, flow.Identifier) (flow.StateCommitment, error)
 
@@ -75,6 +88,9 @@ const (
 	KeyPartKey        = uint16(2)
 )
 
+// MaxRegistersPageSize is the maximum number of registers returned by a single GetRegistersByOwner call.
+const MaxRegistersPageSize = 1000
+
 type state struct {
 	tracer             module.Tracer
 	ls                 ledger.Ledger
@@ -280,6 +296,63 @@ func (s *state) GetRegisters(
 	return registerValues, nil
 }
 
+func (s *state) GetRegistersByOwner(
+	ctx context.Context,
+	commit flow.StateCommitment,
+	owner flow.Address,
+	pageToken []byte,
+	limit int,
+) ([]flow.RegisterEntry, []byte, error) {
+	span, _ := s.tracer.StartSpanFromContext(ctx, trace.EXEGetRegistersByOwner)
+	defer span.Finish()
+
+	scanner, ok := s.ls.(ledger.Scanner)
+	if !ok {
+		return nil, nil, fmt.Errorf("ledger does not support scanning registers")
+	}
+
+	if limit < 1 || limit > MaxRegistersPageSize {
+		limit = MaxRegistersPageSize
+	}
+	prefix := []ledger.KeyPart{ledger.NewKeyPart(KeyPartOwner, owner.Bytes())}
+	query, err := ledger.NewScanQuery(ledger.State(commit), prefix, pageToken, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create ledger scan query: %w", err)
+	}
+
+	result, err := scanner.Scan(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot scan ledger: %w", err)
+	}
+
+	entries := make([]flow.RegisterEntry, 0, len(result.Keys))
+	for i, key := range result.Keys {
+		registerID, err := keyToRegisterID(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, flow.RegisterEntry{Key: registerID, Value: flow.RegisterValue(result.Values[i])})
+	}
+
+	return entries, result.NextPageToken, nil
+}
+
+// keyToRegisterID converts a ledger key into a register ID.
+func keyToRegisterID(key ledger.Key) (flow.RegisterID, error) {
+	if len(key.KeyParts) != 3 ||
+		key.KeyParts[0].Type != KeyPartOwner ||
+		key.KeyParts[1].Type != KeyPartController ||
+		key.KeyParts[2].Type != KeyPartKey {
+		return flow.RegisterID{}, fmt.Errorf("key not in expected format %s", key.String())
+	}
+
+	return flow.NewRegisterID(
+		string(key.KeyParts[0].Value),
+		string(key.KeyParts[1].Value),
+		string(key.KeyParts[2].Value),
+	), nil
+}
+
 func (s *state) GetProof(
 	ctx context.Context,
 	commit flow.StateCommitment,
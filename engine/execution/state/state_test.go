@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
@@ -212,4 +213,64 @@ func TestExecutionStateWithTrieStorage(t *testing.T) {
 		require.Equal(t, sc2, sc2Same)
 	}))
 
+	t.Run("get registers by owner page by page", prepareTest(func(t *testing.T, es state.ExecutionState, l *ledger.Ledger) {
+		// TODO: use real block ID
+		sc1, err := es.StateCommitmentByBlockID(context.Background(), flow.Identifier{})
+		assert.NoError(t, err)
+
+		owner := flow.HexToAddress("01")
+		other := flow.HexToAddress("02")
+
+		view1 := es.NewView(sc1)
+		expected := make(map[string]flow.RegisterValue)
+		for i := 0; i < 10; i++ {
+			key := fmt.Sprintf("key%d", i)
+			value := flow.RegisterValue(fmt.Sprintf("value%d", i))
+			err = view1.Set(string(owner.Bytes()), "", key, value)
+			require.NoError(t, err)
+			err = view1.Set(string(other.Bytes()), "", key, value)
+			require.NoError(t, err)
+			expected[key] = value
+		}
+		sc2, _, err := state.CommitDelta(l, view1.Delta(), sc1)
+		require.NoError(t, err)
+
+		var entries []flow.RegisterEntry
+		var pageToken []byte
+		for page := 0; ; page++ {
+			require.Less(t, page, 10, "too many pages")
+			var registers []flow.RegisterEntry
+			registers, pageToken, err = es.GetRegistersByOwner(context.Background(), sc2, owner, pageToken, 3)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(registers), 3)
+			entries = append(entries, registers...)
+			if pageToken == nil {
+				break
+			}
+		}
+
+		require.Len(t, entries, len(expected))
+		for _, entry := range entries {
+			assert.Equal(t, string(owner.Bytes()), entry.Key.Owner)
+			assert.Equal(t, expected[entry.Key.Key], entry.Value)
+			delete(expected, entry.Key.Key)
+		}
+		assert.Empty(t, expected)
+
+		// the order of the registers is stable
+		all, pageToken, err := es.GetRegistersByOwner(context.Background(), sc2, owner, nil, 0)
+		require.NoError(t, err)
+		assert.Nil(t, pageToken)
+		assert.Equal(t, entries, all)
+
+		// no registers at the previous state
+		empty, pageToken, err := es.GetRegistersByOwner(context.Background(), sc1, owner, nil, 3)
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+		assert.Nil(t, pageToken)
+
+		// invalid page token
+		_, _, err = es.GetRegistersByOwner(context.Background(), sc2, owner, []byte("invalid"), 3)
+		assert.Error(t, err)
+	}))
 }
@@ -6,12 +6,13 @@ import (
 	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow-go/fvm/crypto"
 	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/module/signature/messages"
 )
 
 // NewExecutionReceiptHasher generates and returns a hasher for signing
 // and verification of execution receipts
 func NewExecutionReceiptHasher() hash.Hasher {
-	h := crypto.NewBLSKMAC(encoding.ExecutionReceiptTag)
+	h := crypto.NewBLSKMAC(messages.ExecutionReceiptTag)
 	return h
 }
 
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultHighWaterMark is the default fraction of its capacity, at which a pool is saturated.
+const DefaultHighWaterMark = 0.9
+
+// DefaultLowWaterMark is the default fraction of its capacity, below which a saturated pool
+// is no longer saturated.
+const DefaultLowWaterMark = 0.7
+
+// SaturationOption configures optional behaviour of a saturation tracker.
+type SaturationOption func(*SaturationTracker) error
+
+// WithWaterMarks sets the fractions of the capacity, at which the pool becomes saturated (high)
+// and below which it stops being saturated (low). By default, these are DefaultHighWaterMark
+// and DefaultLowWaterMark.
+func WithWaterMarks(low float64, high float64) SaturationOption {
+	return func(t *SaturationTracker) error {
+		if high <= 0 || high > 1 {
+			return fmt.Errorf("high-water mark must be in (0, 1], got %f", high)
+		}
+		if low < 0 || low >= high {
+			return fmt.Errorf("low-water mark must be in [0, %f), got %f", high, low)
+		}
+		t.lowWater = low
+		t.highWater = high
+		return nil
+	}
+}
+
+// WithSaturationObserver sets a callback, which is invoked with the time spent saturated. While
+// the pool is saturated, the callback is invoked on every check with the time since the last
+// check, and a final time when the saturation clears.
+func WithSaturationObserver(observer func(time.Duration)) SaturationOption {
+	return func(t *SaturationTracker) error {
+		t.observer = observer
+		return nil
+	}
+}
+
+// SaturationTracker detects whether a pool is saturated, so that engines can apply back-pressure
+// instead of admitting entries which the pool would immediately eject. To avoid flapping, the
+// tracker implements hysteresis: the pool becomes saturated once its size reaches the high-water
+// mark, and stays saturated until its size drops below the low-water mark.
+// The tracker is safe for concurrent use.
+type SaturationTracker struct {
+	mu        sync.Mutex
+	lowWater  float64
+	highWater float64
+	observer  func(time.Duration)
+	saturated bool
+	lastCheck time.Time // time of the last check while saturated
+}
+
+// NewSaturationTracker creates a new saturation tracker.
+func NewSaturationTracker(opts ...SaturationOption) (*SaturationTracker, error) {
+	t := &SaturationTracker{
+		lowWater:  DefaultLowWaterMark,
+		highWater: DefaultHighWaterMark,
+		observer:  func(time.Duration) {},
+	}
+	for _, opt := range opts {
+		err := opt(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid saturation tracker option: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// Check updates the saturation state with the current size and capacity of the pool, and returns
+// whether the pool is saturated. A pool without capacity is never saturated.
+func (t *SaturationTracker) Check(size uint, capacity uint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if capacity == 0 {
+		return false
+	}
+	fill := float64(size) / float64(capacity)
+	now := time.Now()
+
+	if !t.saturated {
+		if fill >= t.highWater {
+			t.saturated = true
+			t.lastCheck = now
+		}
+		return t.saturated
+	}
+
+	t.observer(now.Sub(t.lastCheck))
+	t.lastCheck = now
+	if fill < t.lowWater {
+		t.saturated = false
+	}
+	return t.saturated
+}
+
+// Saturated returns whether the pool was saturated at the last check.
+func (t *SaturationTracker) Saturated() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.saturated
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSaturationTracker_Hysteresis tests that the tracker becomes saturated at the high-water
+// mark, and does not flap while the fill oscillates between the water marks.
+func TestSaturationTracker_Hysteresis(t *testing.T) {
+	tracker, err := NewSaturationTracker()
+	require.NoError(t, err)
+
+	assert.False(t, tracker.Check(89, 100))
+	assert.True(t, tracker.Check(90, 100))
+
+	// oscillating around the high-water mark keeps the tracker saturated
+	for i := 0; i < 10; i++ {
+		assert.True(t, tracker.Check(89, 100))
+		assert.True(t, tracker.Check(91, 100))
+	}
+
+	// at the low-water mark, the tracker is still saturated
+	assert.True(t, tracker.Check(70, 100))
+
+	// below the low-water mark, the saturation clears
+	assert.False(t, tracker.Check(69, 100))
+	assert.False(t, tracker.Saturated())
+
+	// oscillating below the high-water mark keeps the tracker unsaturated
+	for i := 0; i < 10; i++ {
+		assert.False(t, tracker.Check(89, 100))
+		assert.False(t, tracker.Check(71, 100))
+	}
+}
+
+// TestSaturationTracker_Observer tests that the observer is invoked with the time spent saturated.
+func TestSaturationTracker_Observer(t *testing.T) {
+	var total time.Duration
+	calls := 0
+	tracker, err := NewSaturationTracker(
+		WithWaterMarks(0.5, 0.8),
+		WithSaturationObserver(func(duration time.Duration) {
+			total += duration
+			calls++
+		}),
+	)
+	require.NoError(t, err)
+
+	// no saturation, no observation
+	assert.False(t, tracker.Check(7, 10))
+	assert.Equal(t, 0, calls)
+
+	started := time.Now()
+	assert.True(t, tracker.Check(8, 10))
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, tracker.Check(6, 10))
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, tracker.Check(4, 10))
+	elapsed := time.Since(started)
+
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, int64(total), int64(20*time.Millisecond))
+	assert.LessOrEqual(t, int64(total), int64(elapsed))
+
+	// a pool without capacity is never saturated
+	assert.False(t, tracker.Check(0, 0))
+}
+
+// TestSaturationTracker_InvalidWaterMarks tests that invalid water marks are rejected.
+func TestSaturationTracker_InvalidWaterMarks(t *testing.T) {
+	for _, marks := range [][2]float64{{0.5, 0}, {0.5, 1.1}, {0.9, 0.9}, {-0.1, 0.9}} {
+		_, err := NewSaturationTracker(WithWaterMarks(marks[0], marks[1]))
+		assert.Error(t, err, "low: %f, high: %f", marks[0], marks[1])
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/common/provider"
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
+	conengine "github.com/onflow/flow-go/engine/consensus"
 	"github.com/onflow/flow-go/engine/consensus/approvals/tracker"
 	consensusingest "github.com/onflow/flow-go/engine/consensus/ingestion"
 	"github.com/onflow/flow-go/engine/consensus/matching"
@@ -47,7 +48,6 @@ import (
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	completeLedger "github.com/onflow/flow-go/ledger/complete"
 	"github.com/onflow/flow-go/ledger/complete/wal"
-	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
@@ -61,8 +61,10 @@ import (
 	"github.com/onflow/flow-go/module/mempool/epochs"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
 	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/module/signature/messages"
 	chainsync "github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/module/validation"
@@ -319,7 +321,10 @@ func CollectionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, rootSn
 	rootQCVoter := new(mockmodule.ClusterRootQCVoter)
 	rootQCVoter.On("Vote", mock.Anything, mock.Anything).Return(nil)
 
-	heights := gadgets.NewHeights()
+	final, err := node.State.Final().Head()
+	require.NoError(t, err)
+	heights, err := gadgets.NewPersistentHeights(node.Log, node.PublicDB, final.Height)
+	require.NoError(t, err)
 	node.ProtocolEvents.AddConsumer(heights)
 
 	epochManager, err := epochmgr.New(
@@ -328,6 +333,7 @@ func CollectionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, rootSn
 		node.State,
 		pools,
 		rootQCVoter,
+		storage.NewClusterQCVotes(node.PublicDB),
 		factory,
 		heights,
 	)
@@ -371,6 +377,7 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 
 	resultsDB := storage.NewExecutionResults(node.Metrics, node.PublicDB)
 	receiptsDB := storage.NewExecutionReceipts(node.Metrics, node.PublicDB, resultsDB, storage.DefaultCacheSize)
+	requestsDB := storage.NewReceiptRequests(node.PublicDB)
 
 	guarantees, err := stdmap.NewGuarantees(1000)
 	require.NoError(t, err)
@@ -380,7 +387,7 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	seals := stdmap.NewIncorporatedResultSeals(1000)
 	pendingReceipts := stdmap.NewPendingReceipts(node.Headers, 1000)
 
-	ingestionCore := consensusingest.NewCore(node.Log, node.Tracer, node.Metrics, node.State,
+	ingestionCore := consensusingest.NewCore(node.Log, node.Tracer, node.Metrics, node.Metrics, node.State,
 		node.Headers, guarantees)
 	// receive collections
 	ingestionEngine, err := consensusingest.New(node.Log, node.Metrics, node.Net, node.Me, ingestionCore)
@@ -394,12 +401,14 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	require.Nil(t, err)
 
 	receiptValidator := validation.NewReceiptValidator(node.State, node.Headers, node.Index, resultsDB, node.Seals,
-		signature.NewAggregationVerifier(encoding.ExecutionReceiptTag))
+		signature.NewAggregationVerifier(messages.ExecutionReceiptTag))
 
-	approvalVerifier := signature.NewAggregationVerifier(encoding.ResultApprovalTag)
+	approvalVerifier := signature.NewAggregationVerifier(messages.ResultApprovalTag)
 
 	sealingConfig := sealing.DefaultConfig()
 
+	traceSampler := conengine.NewTraceSampler(0)
+
 	sealingEngine, err := sealing.NewEngine(
 		node.Log,
 		node.Tracer,
@@ -418,6 +427,8 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		assigner,
 		approvalVerifier,
 		seals,
+		misbehavior.NewNoopReporter(),
+		traceSampler,
 		sealingConfig)
 	require.NoError(t, err)
 
@@ -431,11 +442,14 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		node.State,
 		node.Headers,
 		receiptsDB,
+		requestsDB,
 		receipts,
 		pendingReceipts,
 		seals,
 		receiptValidator,
 		receiptRequester,
+		misbehavior.NewNoopReporter(),
+		traceSampler,
 		matchingConfig)
 
 	matchingEngine, err := matching.NewEngine(
@@ -444,10 +458,13 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		node.Me,
 		node.Metrics,
 		node.Metrics,
+		node.Metrics,
 		node.State,
 		receiptsDB,
 		node.Index,
 		matchingCore,
+		pendingReceipts,
+		signature.NewAggregationVerifier(messages.ExecutionReceiptTag),
 	)
 	require.NoError(t, err)
 
@@ -550,6 +567,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	metrics := metrics.NewNoopCollector()
 	pusherEngine, err := executionprovider.New(
 		node.Log, node.Tracer, node.Net, node.State, node.Me, execState, metrics, checkStakedAtBlock, 10, 10,
+		metrics, executionprovider.DefaultChunkDataPackCacheBudget,
 	)
 	require.NoError(t, err)
 
@@ -617,6 +635,12 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		false,
 		checkStakedAtBlock,
 		false,
+		flow.ChunkBodyV0,
+		flow.ExecutionResultV0,
+		myReceipts,
+		ingestion.DefaultReloadScanLimit,
+		false,
+		ingestion.DefaultMaxConcurrentBlockExecutions,
 	)
 	require.NoError(t, err)
 	requestEngine.WithHandle(ingestionEngine.OnCollection)
@@ -628,7 +652,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	// initialize cleaner for DB
 	cleaner := storage.NewCleaner(node.Log, node.PublicDB, node.Metrics, flow.DefaultValueLogGCFrequency)
 
-	followerEng, err := follower.New(node.Log, node.Net, node.Me, node.Metrics, node.Metrics, cleaner,
+	followerEng, err := follower.New(node.Log, node.Net, node.Me, node.Metrics, node.Metrics, node.Metrics, cleaner,
 		node.Headers, node.Payloads, followerState, pendingBlocks, followerCore, syncCore, node.Tracer)
 	require.NoError(t, err)
 
@@ -803,15 +827,11 @@ func VerificationNode(t testing.TB,
 	}
 
 	if node.ChunkStatuses == nil {
-		node.ChunkStatuses = stdmap.NewChunkStatuses(chunksLimit)
-		err = mempoolCollector.Register(metrics.ResourceChunkStatus, node.ChunkStatuses.Size)
-		require.Nil(t, err)
+		node.ChunkStatuses = stdmap.NewChunkStatuses(chunksLimit, stdmap.WithMetrics(mempoolCollector, metrics.ResourceChunkStatus))
 	}
 
 	if node.ChunkRequests == nil {
-		node.ChunkRequests = stdmap.NewChunkRequests(chunksLimit)
-		err = mempoolCollector.Register(metrics.ResourceChunkRequest, node.ChunkRequests.Size)
-		require.NoError(t, err)
+		node.ChunkRequests = stdmap.NewChunkRequests(chunksLimit, stdmap.WithMetrics(mempoolCollector, metrics.ResourceChunkRequest))
 	}
 
 	if node.Results == nil {
@@ -879,7 +899,9 @@ func VerificationNode(t testing.TB,
 				vereq.DefaultBackoffMultiplier,
 				vereq.DefaultBackoffMaxInterval,
 				vereq.DefaultBackoffMinInterval),
-			vereq.DefaultRequestTargets)
+			vereq.DefaultRequestTargets,
+			vereq.DefaultDispatchLimit,
+			vereq.DefaultMaxRequestDuration)
 
 		require.NoError(t, err)
 	}
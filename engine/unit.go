@@ -8,12 +8,17 @@ import (
 	"time"
 )
 
+// DeadlineExceededFunc is invoked with the context of a function executed with a deadline,
+// if the function is still running once its deadline has passed.
+type DeadlineExceededFunc func(ctx context.Context, deadline time.Duration)
+
 // Unit handles synchronization management, startup, and shutdown for engines.
 type Unit struct {
-	wg         sync.WaitGroup     // tracks in-progress functions
-	ctx        context.Context    // context that is cancelled when the unit is Done
-	cancel     context.CancelFunc // cancels the context
-	sync.Mutex                    // can be used to synchronize the engine
+	wg               sync.WaitGroup       // tracks in-progress functions
+	ctx              context.Context      // context that is cancelled when the unit is Done
+	cancel           context.CancelFunc   // cancels the context
+	deadlineExceeded DeadlineExceededFunc // invoked when functions run past their deadline
+	sync.Mutex                            // can be used to synchronize the engine
 }
 
 // NewUnit returns a new unit.
@@ -56,6 +61,58 @@ func (u *Unit) Launch(f func()) {
 	}()
 }
 
+// OnDeadlineExceeded registers the function which is invoked when a function executed with
+// LaunchWithDeadline or DoWithDeadline is still running once its deadline has passed. It must
+// be registered before any function is executed with a deadline.
+func (u *Unit) OnDeadlineExceeded(f DeadlineExceededFunc) {
+	u.deadlineExceeded = f
+}
+
+// LaunchWithDeadline asynchronously executes the input function unless the unit has shut
+// down. The function is passed a context which is cancelled once the deadline has passed
+// or the unit shuts down. A function still running past its deadline is not interrupted,
+// but reported to the function registered with OnDeadlineExceeded. If f is executed, the
+// unit will not shut down until after f returns.
+func (u *Unit) LaunchWithDeadline(f func(ctx context.Context), d time.Duration) {
+	u.Launch(func() {
+		_ = u.runWithDeadline(u.ctx, d, func(ctx context.Context) error {
+			f(ctx)
+			return nil
+		})
+	})
+}
+
+// DoWithDeadline synchronously executes the input function unless the unit has shut down.
+// The function is passed a context derived from the given parent, which is cancelled once
+// the deadline has passed. A function still running past its deadline is not interrupted,
+// but reported to the function registered with OnDeadlineExceeded. It returns the result
+// of f. If f is executed, the unit will not shut down until after f returns.
+func (u *Unit) DoWithDeadline(parent context.Context, d time.Duration, f func(ctx context.Context) error) error {
+	return u.Do(func() error {
+		return u.runWithDeadline(parent, d, f)
+	})
+}
+
+// runWithDeadline executes f with a context which expires after the given deadline, and
+// reports f to the registered function if it is still running once the deadline has passed.
+func (u *Unit) runWithDeadline(parent context.Context, d time.Duration, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(parent, d)
+	defer cancel()
+
+	if u.deadlineExceeded != nil {
+		timer := time.AfterFunc(d, func() {
+			// a function interrupted by the shutdown of its parent hasn't overrun
+			if parent.Err() != nil {
+				return
+			}
+			u.deadlineExceeded(ctx, d)
+		})
+		defer timer.Stop()
+	}
+
+	return f(ctx)
+}
+
 // LaunchAfter asynchronously executes the input function after a certain delay
 // unless the unit has shut down.
 func (u *Unit) LaunchAfter(delay time.Duration, f func()) {
@@ -1,10 +1,14 @@
 package engine_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -59,3 +63,95 @@ func TestLaunchPeriod_Delay(t *testing.T) {
 	// ensure we can stop the unit quickly (we should not need to wait for initial delay)
 	unittest.RequireCloseBefore(t, u.Done(), time.Second, "done did not close")
 }
+
+// TestLaunchWithDeadline tests that functions running past their deadline are reported, but
+// keep running, while functions completing within their deadline are not reported.
+func TestLaunchWithDeadline(t *testing.T) {
+	u := engine.NewUnit()
+	unittest.RequireCloseBefore(t, u.Ready(), time.Second, "ready did not close")
+
+	exceeded := make(chan time.Duration, 2)
+	u.OnDeadlineExceeded(func(ctx context.Context, deadline time.Duration) {
+		exceeded <- deadline
+	})
+
+	t.Run("slow function", func(t *testing.T) {
+		finished := make(chan struct{})
+		u.LaunchWithDeadline(func(ctx context.Context) {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+			<-ctx.Done()
+			// the function keeps running past its deadline
+			time.Sleep(10 * time.Millisecond)
+			close(finished)
+		}, 50*time.Millisecond)
+
+		select {
+		case deadline := <-exceeded:
+			assert.Equal(t, 50*time.Millisecond, deadline)
+		case <-time.After(time.Second):
+			t.Fatal("deadline overrun was not reported")
+		}
+		unittest.RequireCloseBefore(t, finished, time.Second, "function did not finish")
+	})
+
+	t.Run("fast function", func(t *testing.T) {
+		finished := make(chan struct{})
+		u.LaunchWithDeadline(func(ctx context.Context) {
+			close(finished)
+		}, 50*time.Millisecond)
+
+		unittest.RequireCloseBefore(t, finished, time.Second, "function did not finish")
+		select {
+		case <-exceeded:
+			t.Fatal("fast function must not be reported")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	unittest.RequireCloseBefore(t, u.Done(), time.Second, "done did not close")
+}
+
+// TestDoWithDeadline tests that the function is executed synchronously with a context
+// derived from the given parent, and that overruns are reported.
+func TestDoWithDeadline(t *testing.T) {
+	u := engine.NewUnit()
+	unittest.RequireCloseBefore(t, u.Ready(), time.Second, "ready did not close")
+
+	originID := unittest.IdentifierFixture()
+	parent := engine.WithOriginID(u.Ctx(), originID)
+
+	exceeded := atomic.NewUint32(0)
+	u.OnDeadlineExceeded(func(ctx context.Context, _ time.Duration) {
+		// the overrun is attributed to the origin of the message
+		actual, ok := engine.OriginIDFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, originID, actual)
+		exceeded.Inc()
+	})
+
+	sentinel := errors.New("sentinel")
+	err := u.DoWithDeadline(parent, time.Second, func(ctx context.Context) error {
+		actual, ok := engine.OriginIDFromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, originID, actual)
+		return sentinel
+	})
+	assert.ErrorIs(t, err, sentinel)
+	assert.Equal(t, uint32(0), exceeded.Load())
+
+	err = u.DoWithDeadline(parent, 10*time.Millisecond, func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), exceeded.Load())
+
+	unittest.RequireCloseBefore(t, u.Done(), time.Second, "done did not close")
+
+	// functions aren't executed once the unit has shut down
+	err = u.DoWithDeadline(parent, time.Second, func(ctx context.Context) error {
+		return sentinel
+	})
+	assert.NoError(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/chunks"
@@ -33,6 +34,8 @@ type Engine struct {
 	chunksQueue           storage.ChunksQueue       // to store chunks to be verified.
 	newChunkListener      module.NewJobListener     // to notify chunk queue consumer about a new chunk.
 	blockConsumerNotifier module.ProcessingNotifier // to report a block has been processed.
+	assignments           storage.ChunkAssignments  // to persist chunk assignments for auditing, optional.
+	prunedHeight          *atomic.Uint64            // height below which persisted chunk assignments were pruned.
 }
 
 func New(
@@ -55,6 +58,7 @@ func New(
 		assigner:         assigner,
 		chunksQueue:      chunksQueue,
 		newChunkListener: newChunkListener,
+		prunedHeight:     atomic.NewUint64(0),
 	}
 }
 
@@ -62,6 +66,12 @@ func (e *Engine) WithBlockConsumerNotifier(notifier module.ProcessingNotifier) {
 	e.blockConsumerNotifier = notifier
 }
 
+// WithChunkAssignments makes the engine persist the chunk assignments of all results in the
+// given storage, and prune the assignments of results incorporated below the sealed height.
+func (e *Engine) WithChunkAssignments(assignments storage.ChunkAssignments) {
+	e.assignments = assignments
+}
+
 func (e *Engine) Ready() <-chan struct{} {
 	return e.unit.Ready()
 }
@@ -76,6 +86,7 @@ func (e *Engine) Done() <-chan struct{} {
 func (e *Engine) resultChunkAssignment(ctx context.Context,
 	result *flow.ExecutionResult,
 	incorporatingBlock flow.Identifier,
+	incorporatingHeight uint64,
 ) (flow.ChunkList, error) {
 	resultID := result.ID()
 	log := log.With().
@@ -96,7 +107,7 @@ func (e *Engine) resultChunkAssignment(ctx context.Context,
 	}
 
 	// chunk assignment
-	chunkList, err := e.chunkAssignments(ctx, result, incorporatingBlock)
+	chunkList, err := e.chunkAssignments(ctx, result, incorporatingBlock, incorporatingHeight)
 	if err != nil {
 		return nil, fmt.Errorf("could not determine chunk assignment: %w", err)
 	}
@@ -194,7 +205,7 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 		resultLog.Debug().Msg("determining chunk assignment for incorporated result")
 
 		// compute chunk assignment
-		chunkList, err := e.resultChunkAssignmentWithTracing(ctx, result, blockID)
+		chunkList, err := e.resultChunkAssignmentWithTracing(ctx, result, blockID, block.Header.Height)
 		if err != nil {
 			resultLog.Fatal().Err(err).Msg("could not determine assigned chunks for result")
 		}
@@ -216,6 +227,8 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 		}
 	}
 
+	e.pruneChunkAssignments()
+
 	e.metrics.OnFinalizedBlockArrivedAtAssigner(block.Header.Height)
 	lg.Info().
 		Uint64("total_assigned_chunks", assignedChunksCount).
@@ -224,7 +237,7 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 }
 
 // chunkAssignments returns the list of chunks in the chunk list assigned to this verification node.
-func (e *Engine) chunkAssignments(ctx context.Context, result *flow.ExecutionResult, incorporatingBlock flow.Identifier) (flow.ChunkList, error) {
+func (e *Engine) chunkAssignments(ctx context.Context, result *flow.ExecutionResult, incorporatingBlock flow.Identifier, incorporatingHeight uint64) (flow.ChunkList, error) {
 	var span opentracing.Span
 	span, _ = e.tracer.StartSpanFromContext(ctx, trace.VERMatchMyChunkAssignments)
 	defer span.Finish()
@@ -234,6 +247,14 @@ func (e *Engine) chunkAssignments(ctx context.Context, result *flow.ExecutionRes
 		return nil, err
 	}
 
+	if e.assignments != nil {
+		record := chunks.NewAssignmentRecord(result.ID(), incorporatingBlock, incorporatingHeight, result.Chunks, assignment)
+		err = e.assignments.Store(record)
+		if err != nil {
+			return nil, fmt.Errorf("could not persist chunk assignment: %w", err)
+		}
+	}
+
 	mine, err := assignedChunks(e.me.NodeID(), assignment, result.Chunks)
 	if err != nil {
 		return nil, fmt.Errorf("could not determine my assignments: %w", err)
@@ -242,6 +263,35 @@ func (e *Engine) chunkAssignments(ctx context.Context, result *flow.ExecutionRes
 	return mine, nil
 }
 
+// pruneChunkAssignments removes the persisted chunk assignments of results incorporated below
+// the sealed height. Failing to prune is not critical, as pruning is retried with the next block.
+func (e *Engine) pruneChunkAssignments() {
+	if e.assignments == nil {
+		return
+	}
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not get sealed height for pruning chunk assignments")
+		return
+	}
+	// blocks are processed concurrently, only one of them prunes up to a new sealed height
+	pruned := e.prunedHeight.Load()
+	if sealed.Height <= pruned || !e.prunedHeight.CAS(pruned, sealed.Height) {
+		return
+	}
+
+	removed, err := e.assignments.PruneBelow(sealed.Height)
+	if err != nil {
+		e.log.Error().Err(err).Uint64("sealed_height", sealed.Height).Msg("could not prune chunk assignments")
+		return
+	}
+	e.log.Debug().
+		Uint64("sealed_height", sealed.Height).
+		Uint("removed_assignments", removed).
+		Msg("pruned chunk assignments below sealed height")
+}
+
 // stakedAsVerification checks whether this instance of verification node has staked at specified block ID.
 // It returns true and nil if verification node is staked at referenced block ID, and returns false and nil otherwise.
 // It returns false and error if it could not extract the stake of node as a verification node at the specified block.
@@ -275,11 +325,12 @@ func (e *Engine) resultChunkAssignmentWithTracing(
 	ctx context.Context,
 	result *flow.ExecutionResult,
 	incorporatingBlock flow.Identifier,
+	incorporatingHeight uint64,
 ) (flow.ChunkList, error) {
 	var err error
 	var chunkList flow.ChunkList
 	e.tracer.WithSpanFromContext(ctx, trace.VERAssignerHandleExecutionReceipt, func() {
-		chunkList, err = e.resultChunkAssignment(ctx, result, incorporatingBlock)
+		chunkList, err = e.resultChunkAssignment(ctx, result, incorporatingBlock, incorporatingHeight)
 	})
 	return chunkList, err
 }
@@ -146,6 +146,9 @@ func TestAssignerEngine(t *testing.T) {
 	t.Run("chunk queue unhappy path duplicate", func(t *testing.T) {
 		chunkQueueUnhappyPathDuplicate(t)
 	})
+	t.Run("new block persists assignment", func(t *testing.T) {
+		newBlockPersistsAssignment(t)
+	})
 }
 
 // newBlockHappyPath evaluates that passing a new finalized block to assigner engine that contains
@@ -416,3 +419,67 @@ func mockChunksQueueForAssignment(t *testing.T,
 
 	return wg
 }
+
+// newBlockPersistsAssignment evaluates that the assigner engine persists the assignment of all chunks
+// of a result, including the chunks assigned to other verifiers, and prunes the persisted assignments
+// below the sealed height once it advances.
+func newBlockPersistsAssignment(t *testing.T) {
+	s := SetupTest()
+	e := NewAssignerEngine(s)
+	assignments := &storage.ChunkAssignments{}
+	e.WithChunkAssignments(assignments)
+
+	// creates a container block, with a single receipt, that contains 2 chunks, of which only
+	// the first one is assigned to this verification node.
+	other := unittest.IdentifierFixture()
+	containerBlock, assignment := createContainerBlock(
+		vertestutils.WithChunks(
+			vertestutils.WithAssignee(s.myID()),
+			vertestutils.WithAssignee(other)))
+	result := containerBlock.Payload.Results[0]
+	s.mockStateAtBlockID(result.BlockID)
+	chunksNum := s.mockChunkAssigner(flow.NewIncorporatedResult(containerBlock.ID(), result), assignment)
+	require.Equal(t, chunksNum, 1)
+
+	assignments.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+		record := args.Get(0).(*chunks.AssignmentRecord)
+		require.Equal(t, result.ID(), record.ResultID)
+		require.Equal(t, containerBlock.ID(), record.IncorporatedBlockID)
+		require.Equal(t, containerBlock.Header.Height, record.IncorporatedHeight)
+		require.Equal(t, []flow.IdentifierList{{s.myID()}, {other}}, record.Verifiers)
+	}).Return(nil).Once()
+
+	// assignments are pruned below the sealed height
+	sealed := unittest.BlockHeaderFixture()
+	s.state.On("Sealed").Return(s.snapshot)
+	s.snapshot.On("Head").Return(&sealed, nil)
+	assignments.On("PruneBelow", sealed.Height).Return(uint(1), nil).Once()
+
+	chunksQueueWG := mockChunksQueueForAssignment(t, s.verIdentity.NodeID, s.chunksQueue, result.ID(), assignment, true, nil)
+	s.newChunkListener.On("Check").Return().Times(chunksNum)
+	s.notifier.On("Notify", containerBlock.ID()).Return().Once()
+	s.metrics.On("OnAssignedChunkProcessedAtAssigner").Return().Once()
+	s.metrics.On("OnFinalizedBlockArrivedAtAssigner", containerBlock.Header.Height).Return().Once()
+	s.metrics.On("OnExecutionResultReceivedAtAssignerEngine").Return().Once()
+	e.ProcessFinalizedBlock(containerBlock)
+
+	unittest.RequireReturnsBefore(t, chunksQueueWG.Wait, 10*time.Millisecond, "could not receive chunk locators")
+
+	// a block at the same sealed height doesn't prune again
+	containerBlock, assignment = createContainerBlock()
+	result = containerBlock.Payload.Results[0]
+	s.mockStateAtBlockID(result.BlockID)
+	s.mockChunkAssigner(flow.NewIncorporatedResult(containerBlock.ID(), result), assignment)
+	assignments.On("Store", mock.Anything).Return(nil).Once()
+	s.notifier.On("Notify", containerBlock.ID()).Return().Once()
+	s.metrics.On("OnFinalizedBlockArrivedAtAssigner", containerBlock.Header.Height).Return().Once()
+	s.metrics.On("OnExecutionResultReceivedAtAssignerEngine").Return().Once()
+	e.ProcessFinalizedBlock(containerBlock)
+
+	mock.AssertExpectationsForObjects(t,
+		s.metrics,
+		s.assigner,
+		s.notifier,
+		assignments)
+	assignments.AssertNumberOfCalls(t, "PruneBelow", 1)
+}
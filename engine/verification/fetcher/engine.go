@@ -200,7 +200,7 @@ func (e *Engine) processAssignedChunk(chunk *flow.Chunk, result *flow.ExecutionR
 		return false, blockHeight, nil
 	}
 
-	err = e.requestChunkDataPack(chunk.Index, chunkID, result.ID(), chunk.BlockID)
+	err = e.requestChunkDataPack(chunk, chunkID, result.ID())
 	if err != nil {
 		return false, blockHeight, fmt.Errorf("could not request chunk data pack: %w", err)
 	}
@@ -407,7 +407,7 @@ func (e Engine) validateCollectionID(
 		return e.validateSystemChunkCollection(chunkDataPack)
 	}
 
-	return e.validateNonSystemChunkCollection(chunkDataPack, chunk)
+	return e.validateNonSystemChunkCollection(chunkDataPack, result, chunk)
 }
 
 // validateSystemChunkCollection returns nil if the system chunk data pack has a nil collection.
@@ -424,7 +424,11 @@ func (e Engine) validateSystemChunkCollection(chunkDataPack *flow.ChunkDataPack)
 // validateNonSystemChunkCollection returns nil if the collection is matching the non-system chunk data pack.
 // A collection is valid against a non-system chunk if it has a matching ID with the
 // collection ID of corresponding guarantee of the chunk in the referenced block payload.
-func (e Engine) validateNonSystemChunkCollection(chunkDataPack *flow.ChunkDataPack, chunk *flow.Chunk) error {
+func (e Engine) validateNonSystemChunkCollection(
+	chunkDataPack *flow.ChunkDataPack,
+	result *flow.ExecutionResult,
+	chunk *flow.Chunk) error {
+
 	collID := chunkDataPack.Collection.ID()
 
 	block, err := e.blocks.ByID(chunk.BlockID)
@@ -432,9 +436,14 @@ func (e Engine) validateNonSystemChunkCollection(chunkDataPack *flow.ChunkDataPa
 		return fmt.Errorf("could not get block: %w", err)
 	}
 
-	if block.Payload.Guarantees[chunk.Index].CollectionID != collID {
+	collIndex, ok := result.Chunks.CollectionIndexSafe(chunk.Index)
+	if !ok || collIndex >= uint(len(block.Payload.Guarantees)) {
+		return engine.NewInvalidInputErrorf("chunk %d does not reference a collection of block %v", chunk.Index, chunk.BlockID)
+	}
+
+	if block.Payload.Guarantees[collIndex].CollectionID != collID {
 		return engine.NewInvalidInputErrorf("mismatch collection id with guarantee, expected: %v, got: %v",
-			block.Payload.Guarantees[chunk.Index].CollectionID,
+			block.Payload.Guarantees[collIndex].CollectionID,
 			collID)
 	}
 
@@ -489,6 +498,38 @@ func (e *Engine) NotifyChunkDataPackSealed(chunkIndex uint64, resultID flow.Iden
 		Msg("discards fetching chunk of an already sealed block and notified consumer")
 }
 
+// NotifyChunkDataPackRequestFailed is called by the ChunkDataPackRequester to notify the ChunkDataPackHandler that the requester
+// gave up requesting the specified chunk, as none of the execution nodes returned its chunk data pack in time.
+//
+// When the requester calls this callback method, it will never return a chunk data pack for this specified chunk to the handler (i.e.,
+// through HandleChunkDataPack).
+func (e *Engine) NotifyChunkDataPackRequestFailed(chunkIndex uint64, resultID flow.Identifier, err error) {
+	lg := e.log.With().
+		Uint64("chunk_index", chunkIndex).
+		Hex("result_id", logging.ID(resultID)).
+		Logger()
+
+	// we need to report that the job has been finished eventually
+	status, exists := e.pendingChunks.Get(chunkIndex, resultID)
+	if !exists {
+		lg.Debug().
+			Msg("could not fetch pending status for failed chunk from mempool, dropping chunk data")
+		return
+	}
+
+	chunkLocatorID := status.ChunkLocatorID()
+	lg = lg.With().
+		Uint64("block_height", status.BlockHeight).
+		Hex("result_id", logging.ID(status.ExecutionResult.ID())).Logger()
+	removed := e.pendingChunks.Rem(chunkIndex, resultID)
+
+	e.chunkConsumerNotifier.Notify(chunkLocatorID)
+	lg.Error().
+		Err(err).
+		Bool("removed", removed).
+		Msg("discards fetching chunk as its chunk data pack could not be requested and notified consumer")
+}
+
 // pushToVerifierWithTracing encapsulates the logic of pushing a verifiable chunk to verifier engine with tracing enabled.
 func (e *Engine) pushToVerifierWithTracing(
 	ctx context.Context,
@@ -564,7 +605,8 @@ func (e *Engine) makeVerifiableChunkData(chunk *flow.Chunk,
 }
 
 // requestChunkDataPack creates and dispatches a chunk data pack request to the requester engine.
-func (e *Engine) requestChunkDataPack(chunkIndex uint64, chunkID flow.Identifier, resultID flow.Identifier, blockID flow.Identifier) error {
+func (e *Engine) requestChunkDataPack(chunk *flow.Chunk, chunkID flow.Identifier, resultID flow.Identifier) error {
+	blockID := chunk.BlockID
 	agrees, disagrees, err := e.getAgreeAndDisagreeExecutors(blockID, resultID)
 	if err != nil {
 		return fmt.Errorf("could not segregate the agree and disagree executors for result: %x of block: %x", resultID, blockID)
@@ -583,7 +625,7 @@ func (e *Engine) requestChunkDataPack(chunkIndex uint64, chunkID flow.Identifier
 	request := &verification.ChunkDataPackRequest{
 		Locator: chunks.Locator{
 			ResultID: resultID,
-			Index:    chunkIndex,
+			Index:    chunk.Index,
 		},
 		ChunkDataPackRequestInfo: verification.ChunkDataPackRequestInfo{
 			ChunkID:   chunkID,
@@ -591,6 +633,7 @@ func (e *Engine) requestChunkDataPack(chunkIndex uint64, chunkID flow.Identifier
 			Agrees:    agrees,
 			Disagrees: disagrees,
 			Targets:   allExecutors,
+			ByteSize:  chunk.TotalByteSize,
 		},
 	}
 
@@ -686,5 +729,5 @@ func TransactionOffsetForChunk(chunks flow.ChunkList, chunkIndex uint64) (uint32
 // In the current version, a chunk is a system chunk if it is the last chunk of the
 // execution result.
 func IsSystemChunk(chunkIndex uint64, result *flow.ExecutionResult) bool {
-	return chunkIndex == uint64(len(result.Chunks)-1)
+	return result.Chunks.IsSystemChunk(chunkIndex)
 }
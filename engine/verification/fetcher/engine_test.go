@@ -273,6 +273,64 @@ func TestProcessAssignChunkSealedAfterRequest(t *testing.T) {
 	s.verifier.AssertNotCalled(t, "ProcessLocal")
 }
 
+// TestProcessAssignChunkRequestFailed evaluates behavior of fetcher engine respect to receiving an assigned chunk
+// that its chunk data pack could not be requested from any execution node in time.
+// The requester notifies the fetcher back that it gave up requesting the chunk data pack.
+// The fetcher engine then should remove chunk request status from memory, and notify the
+// chunk consumer that it is done processing this chunk.
+func TestProcessAssignChunkRequestFailed(t *testing.T) {
+	s := setupTest()
+	e := newFetcherEngine(s)
+
+	// creates a result with 2 chunks, which one of those chunks is assigned to this fetcher engine
+	// also, the result has been created by two execution nodes, while the rest two have a conflicting result with it.
+	// also the chunk belongs to an unsealed block.
+	block, result, statuses, locators, collMap := completeChunkStatusListFixture(t, 2, 1)
+	_, _, agrees, disagrees := mockReceiptsBlockID(t, block.ID(), s.receipts, result, 2, 2)
+	mockBlockSealingStatus(s.state, s.headers, block, false)
+	s.metrics.On("OnAssignedChunkReceivedAtFetcher").Return().Times(len(locators))
+
+	// mocks resources on fetcher engine side.
+	mockResultsByIDs(s.results, []*flow.ExecutionResult{result})
+	mockPendingChunksAdd(t, s.pendingChunks, statuses, true)
+	mockPendingChunksRem(t, s.pendingChunks, statuses, true)
+	mockPendingChunksGet(s.pendingChunks, statuses)
+	mockStateAtBlockIDForIdentities(s.state, block.ID(), agrees.Union(disagrees))
+
+	// generates and mocks requesting chunk data pack fixture
+	requests := chunkRequestsFixture(result.ID(), statuses, agrees, disagrees)
+	responses, _ := verifiableChunksFixture(t, statuses, block, result, collMap)
+
+	// fetcher engine should request chunk data for received (assigned) chunk locators
+	// as the response it receives a notification that the requester gave up requesting the chunk data pack.
+	s.metrics.On("OnChunkDataPackRequestSentByFetcher").Return().Times(len(requests))
+	requesterWg := mockRequester(t, s.requester, requests, responses, func(originID flow.Identifier,
+		response *verification.ChunkDataPackResponse) {
+		e.NotifyChunkDataPackRequestFailed(response.Index, response.ResultID,
+			fetcher.NewChunkDataPackRequestTimeoutError(response.Cdp.ChunkID, 10, time.Minute))
+	})
+
+	// fetcher engine should notify
+	mockChunkConsumerNotifier(t, s.chunkConsumerNotifier, flow.GetIDs(locators.ToList()))
+
+	// passes chunk data requests in parallel.
+	processWG := &sync.WaitGroup{}
+	processWG.Add(len(locators))
+	for _, locator := range locators {
+		go func(l *chunks.Locator) {
+			e.ProcessAssignedChunk(l)
+			processWG.Done()
+		}(locator)
+	}
+
+	unittest.RequireReturnsBefore(t, requesterWg.Wait, time.Second, "could not handle failed chunks notification on time")
+	unittest.RequireReturnsBefore(t, processWG.Wait, 1*time.Second, "could not process chunks on time")
+
+	mock.AssertExpectationsForObjects(t, s.requester, s.pendingChunks, s.chunkConsumerNotifier, s.metrics)
+	// no verifiable chunk should be passed to verifier engine
+	s.verifier.AssertNotCalled(t, "ProcessLocal")
+}
+
 // TestChunkResponse_InvalidChunkDataPack evaluates unhappy path of receiving an invalid chunk data response.
 // A chunk data response is invalid if its integrity is violated. We consider collection id, chunk id, and start state,
 // as the necessary conditions for chunk data integrity.
@@ -3,6 +3,7 @@ package fetcher
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -51,3 +52,31 @@ func (c ChunkDataPackValidationError) Error() string {
 func IsChunkDataPackValidationError(err error) bool {
 	return errors.As(err, &ChunkDataPackValidationError{})
 }
+
+// ChunkDataPackRequestTimeoutError is returned when the chunk data pack of a chunk could not be fetched from any of the
+// execution nodes within the maximum duration of requesting it.
+type ChunkDataPackRequestTimeoutError struct {
+	chunkID  flow.Identifier
+	attempts uint64
+	elapsed  time.Duration
+}
+
+func NewChunkDataPackRequestTimeoutError(chunkID flow.Identifier, attempts uint64, elapsed time.Duration) error {
+	return ChunkDataPackRequestTimeoutError{
+		chunkID:  chunkID,
+		attempts: attempts,
+		elapsed:  elapsed,
+	}
+}
+
+func (c ChunkDataPackRequestTimeoutError) Error() string {
+	return fmt.Sprintf(
+		"chunk data pack request timed out, chunkID: %x, attempts: %d, elapsed: %s",
+		c.chunkID,
+		c.attempts,
+		c.elapsed)
+}
+
+func IsChunkDataPackRequestTimeoutError(err error) bool {
+	return errors.As(err, &ChunkDataPackRequestTimeoutError{})
+}
@@ -19,6 +19,11 @@ func (_m *ChunkDataPackHandler) HandleChunkDataPack(originID flow.Identifier, re
 	_m.Called(originID, response)
 }
 
+// NotifyChunkDataPackRequestFailed provides a mock function with given fields: chunkIndex, resultID, err
+func (_m *ChunkDataPackHandler) NotifyChunkDataPackRequestFailed(chunkIndex uint64, resultID flow.Identifier, err error) {
+	_m.Called(chunkIndex, resultID, err)
+}
+
 // NotifyChunkDataPackSealed provides a mock function with given fields: chunkIndex, resultID
 func (_m *ChunkDataPackHandler) NotifyChunkDataPackSealed(chunkIndex uint64, resultID flow.Identifier) {
 	_m.Called(chunkIndex, resultID)
@@ -28,4 +28,11 @@ type ChunkDataPackHandler interface {
 	// When the requester calls this callback method, it will never return a chunk data pack for this specified chunk to the handler (i.e.,
 	// through HandleChunkDataPack).
 	NotifyChunkDataPackSealed(chunkIndex uint64, resultID flow.Identifier)
+
+	// NotifyChunkDataPackRequestFailed is called by the ChunkDataPackRequester to notify the ChunkDataPackHandler that the requester
+	// gave up requesting the specified chunk, as none of the execution nodes returned its chunk data pack in time.
+	//
+	// When the requester calls this callback method, it will never return a chunk data pack for this specified chunk to the handler (i.e.,
+	// through HandleChunkDataPack).
+	NotifyChunkDataPackRequestFailed(chunkIndex uint64, resultID flow.Identifier, err error)
 }
@@ -3,6 +3,7 @@ package requester
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -36,6 +37,14 @@ const (
 
 	// DefaultRequestTargets is the  maximum number of execution nodes a chunk data pack request is dispatched to.
 	DefaultRequestTargets = 2
+
+	// DefaultDispatchLimit is the maximum number of chunk data pack requests dispatched in a single round, zero
+	// dispatches all qualified requests.
+	DefaultDispatchLimit = 0
+
+	// DefaultMaxRequestDuration is the maximum time interval a chunk data pack is requested, before the requester gives up
+	// requesting it, zero keeps requesting the chunk data pack until its block is sealed.
+	DefaultMaxRequestDuration = time.Duration(0)
 )
 
 // Engine implements a ChunkDataPackRequester that is responsible of receiving chunk data pack requests,
@@ -58,6 +67,8 @@ type Engine struct {
 	// internal logic
 	retryInterval    time.Duration                          // determines time in milliseconds for retrying chunk data requests.
 	requestTargets   uint64                                 // maximum number of execution nodes being asked for a chunk data pack.
+	dispatchLimit    uint                                   // maximum number of requests dispatched in a single round, zero for no limit.
+	maxDuration      time.Duration                          // maximum duration a chunk data pack is requested, zero for no limit.
 	pendingRequests  mempool.ChunkRequests                  // used to track requested chunks.
 	reqQualifierFunc RequestQualifierFunc                   // used to decide whether to dispatch a request at a certain cycle.
 	reqUpdaterFunc   mempool.ChunkRequestHistoryUpdaterFunc // used to atomically update chunk request info on mempool.
@@ -72,7 +83,9 @@ func New(log zerolog.Logger,
 	retryInterval time.Duration,
 	reqQualifierFunc RequestQualifierFunc,
 	reqUpdaterFunc mempool.ChunkRequestHistoryUpdaterFunc,
-	requestTargets uint64) (*Engine, error) {
+	requestTargets uint64,
+	dispatchLimit uint,
+	maxDuration time.Duration) (*Engine, error) {
 
 	e := &Engine{
 		log:              log.With().Str("engine", "requester").Logger(),
@@ -82,6 +95,8 @@ func New(log zerolog.Logger,
 		metrics:          metrics,
 		retryInterval:    retryInterval,
 		requestTargets:   requestTargets,
+		dispatchLimit:    dispatchLimit,
+		maxDuration:      maxDuration,
 		pendingRequests:  pendingRequests,
 		reqUpdaterFunc:   reqUpdaterFunc,
 		reqQualifierFunc: reqQualifierFunc,
@@ -214,6 +229,7 @@ func (e *Engine) handleChunkDataPack(originID flow.Identifier, chunkDataPack *fl
 
 // Request receives a chunk data pack request and adds it into the pending requests mempool.
 func (e *Engine) Request(request *verification.ChunkDataPackRequest) {
+	request.RequestedAt = time.Now()
 	added := e.pendingRequests.Add(request)
 
 	e.metrics.OnChunkDataPackRequestReceivedByRequester()
@@ -229,8 +245,15 @@ func (e *Engine) Request(request *verification.ChunkDataPackRequest) {
 
 // onTimer should run periodically, it goes through all pending requests, and requests their chunk data pack.
 // It also retries the chunk data request if the data hasn't been received for a while.
+// If there are more pending requests than the dispatch limit, the requests of the smallest chunks are dispatched
+// first, so that the verification of small chunks is not delayed by large ones.
 func (e *Engine) onTimer() {
 	pendingReqs := e.pendingRequests.All()
+	saturated := e.dispatchLimit > 0 && uint(len(pendingReqs)) > e.dispatchLimit
+	if saturated {
+		sortBySize(pendingReqs)
+	}
+	dispatched := uint(0)
 
 	// keeps maximum attempts made on chunk data packs of the next unsealed height for telemetry
 	maxAttempts := uint64(0)
@@ -247,7 +270,14 @@ func (e *Engine) onTimer() {
 	}
 
 	for _, request := range pendingReqs {
+		if saturated && dispatched >= e.dispatchLimit && request.Height > lastSealed.Height {
+			// the requests of sealed blocks are still dropped after reaching the limit
+			continue
+		}
 		attempts := e.handleChunkDataPackRequestWithTracing(request, lastSealed.Height)
+		if attempts > 0 {
+			dispatched++
+		}
 		if attempts > maxAttempts && request.Height == lastSealed.Height+uint64(1) {
 			maxAttempts = attempts
 		}
@@ -256,6 +286,21 @@ func (e *Engine) onTimer() {
 	e.metrics.SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester(maxAttempts)
 }
 
+// sortBySize sorts the requests in ascending order of the byte size of their chunks, and by height for chunks of the
+// same size. Chunks of unknown size, i.e. of legacy chunk bodies, are sorted after all chunks of known size.
+func sortBySize(requests verification.ChunkDataPackRequestInfoList) {
+	sort.SliceStable(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.ByteSize != b.ByteSize {
+			if a.ByteSize == 0 || b.ByteSize == 0 {
+				return b.ByteSize == 0
+			}
+			return a.ByteSize < b.ByteSize
+		}
+		return a.Height < b.Height
+	})
+}
+
 // handleChunkDataPackRequestWithTracing encapsulates the logic of dispatching chunk data request in network with tracing enabled.
 func (e *Engine) handleChunkDataPackRequestWithTracing(request *verification.ChunkDataPackRequestInfo, lastSealedHeight uint64) uint64 {
 	// TODO (Ramtin) - enable tracing later
@@ -291,13 +336,19 @@ func (e *Engine) handleChunkDataPackRequest(ctx context.Context, request *verifi
 		return 0
 	}
 
-	qualified := e.canDispatchRequest(request.ChunkID)
+	prevAttempts, qualified := e.canDispatchRequest(request.ChunkID)
 	if !qualified {
 		lg.Debug().Msg("chunk data pack request is not qualified for dispatching at this round")
 		return 0
 	}
 
-	err := e.requestChunkDataPackWithTracing(ctx, request)
+	// if none of the execution nodes returned the chunk data pack in time, we give up
+	if e.maxDuration > 0 && !request.RequestedAt.IsZero() && time.Since(request.RequestedAt) > e.maxDuration {
+		e.onRequestTimedOut(request, prevAttempts)
+		return 0
+	}
+
+	err := e.requestChunkDataPackWithTracing(ctx, request, prevAttempts)
 	if err != nil {
 		lg.Error().Err(err).Msg("could not request chunk data pack")
 		return 0
@@ -315,23 +366,24 @@ func (e *Engine) handleChunkDataPackRequest(ctx context.Context, request *verifi
 }
 
 // requestChunkDataPack dispatches request for the chunk data pack to the execution nodes.
-func (e *Engine) requestChunkDataPackWithTracing(ctx context.Context, request *verification.ChunkDataPackRequestInfo) error {
+func (e *Engine) requestChunkDataPackWithTracing(ctx context.Context, request *verification.ChunkDataPackRequestInfo, attempt uint64) error {
 	var err error
 	e.tracer.WithSpanFromContext(ctx, trace.VERRequesterDispatchChunkDataRequest, func() {
-		err = e.requestChunkDataPack(request)
+		err = e.requestChunkDataPack(request, attempt)
 	})
 	return err
 }
 
-// requestChunkDataPack dispatches request for the chunk data pack to the execution nodes.
-func (e *Engine) requestChunkDataPack(request *verification.ChunkDataPackRequestInfo) error {
+// requestChunkDataPack dispatches request for the chunk data pack to the execution nodes. The targets are rotated on each attempt,
+// so that the request is retried against alternate execution nodes when the previous ones are unresponsive.
+func (e *Engine) requestChunkDataPack(request *verification.ChunkDataPackRequestInfo, attempt uint64) error {
 	req := &messages.ChunkDataRequest{
 		ChunkID: request.ChunkID,
 		Nonce:   rand.Uint64(), // prevent the request from being deduplicated by the receiver
 	}
 
 	// publishes the chunk data request to the network
-	targetIDs := request.SampleTargets(int(e.requestTargets))
+	targetIDs := request.RotateTargets(int(e.requestTargets), attempt)
 	err := e.con.Publish(req, targetIDs...)
 	if err != nil {
 		return fmt.Errorf("could not publish chunk data pack request for chunk (id=%s): %w", request.ChunkID, err)
@@ -340,14 +392,42 @@ func (e *Engine) requestChunkDataPack(request *verification.ChunkDataPackRequest
 	return nil
 }
 
-// canDispatchRequest returns whether chunk data request for this chunk ID can be dispatched.
-func (e *Engine) canDispatchRequest(chunkID flow.Identifier) bool {
+// canDispatchRequest returns whether chunk data request for this chunk ID can be dispatched, along with the number of times it
+// has been dispatched so far.
+func (e *Engine) canDispatchRequest(chunkID flow.Identifier) (uint64, bool) {
 	attempts, lastAttempt, retryAfter, exists := e.pendingRequests.RequestHistory(chunkID)
 	if !exists {
-		return false
+		return 0, false
+	}
+
+	return attempts, e.reqQualifierFunc(attempts, lastAttempt, retryAfter)
+}
+
+// onRequestTimedOut encapsulates the logic of giving up a chunk data pack request after the maximum request duration. It drops
+// the request, and notifies the handler of all locators of its chunk.
+func (e *Engine) onRequestTimedOut(request *verification.ChunkDataPackRequestInfo, attempts uint64) {
+	lg := e.log.With().
+		Hex("chunk_id", logging.ID(request.ChunkID)).
+		Uint64("block_height", request.Height).
+		Uint64("attempts_made", attempts).
+		Logger()
+
+	locators, removed := e.pendingRequests.PopAll(request.ChunkID)
+	if !removed {
+		lg.Debug().Msg("chunk request status not found in mempool to be removed, drops timed out chunk request")
+		return
 	}
+	e.metrics.OnChunkDataPackRequestTimedOutAtRequester()
 
-	return e.reqQualifierFunc(attempts, lastAttempt, retryAfter)
+	err := fetcher.NewChunkDataPackRequestTimeoutError(request.ChunkID, attempts, time.Since(request.RequestedAt))
+	for _, locator := range locators {
+		e.handler.NotifyChunkDataPackRequestFailed(locator.Index, locator.ResultID, err)
+		lg.Warn().
+			Err(err).
+			Hex("result_id", logging.ID(locator.ResultID)).
+			Uint64("chunk_index", locator.Index).
+			Msg("gives up requesting chunk data pack")
+	}
 }
 
 // onRequestDispatched encapsulates the logic of updating the chunk data request post a successful dispatch.
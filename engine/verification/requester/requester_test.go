@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
 	mockfetcher "github.com/onflow/flow-go/engine/verification/fetcher/mock"
 	"github.com/onflow/flow-go/engine/verification/requester"
 	vertestutils "github.com/onflow/flow-go/engine/verification/utils/unittest"
@@ -20,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	flowmempool "github.com/onflow/flow-go/module/mempool"
 	mempool "github.com/onflow/flow-go/module/mempool/mock"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network/mocknetwork"
@@ -43,6 +45,8 @@ type RequesterEngineTestSuite struct {
 
 	// parameters
 	requestTargets uint64
+	dispatchLimit  uint
+	maxDuration    time.Duration // maximum duration a chunk data pack is requested, zero for no limit.
 	retryInterval  time.Duration // determines time in milliseconds for retrying chunk data requests.
 }
 
@@ -66,6 +70,17 @@ func setupTest() *RequesterEngineTestSuite {
 
 // newRequesterEngine returns a requester engine for testing.
 func newRequesterEngine(t *testing.T, s *RequesterEngineTestSuite) *requester.Engine {
+	// exponential backoff with multiplier of 2, minimum interval of a second, and
+	// maximum interval of an hour.
+	return newRequesterEngineWithMempool(t, s, s.pendingRequests, flowmempool.ExponentialUpdater(2, time.Hour, time.Second))
+}
+
+// newRequesterEngineWithMempool returns a requester engine for testing on the given pending requests mempool and request history updater.
+func newRequesterEngineWithMempool(t *testing.T,
+	s *RequesterEngineTestSuite,
+	pendingRequests flowmempool.ChunkRequests,
+	reqUpdaterFunc flowmempool.ChunkRequestHistoryUpdaterFunc) *requester.Engine {
+
 	net := &mocknetwork.Network{}
 	// mocking the network registration of the engine
 	net.On("Register", engine.RequestChunks, testifymock.Anything).
@@ -77,14 +92,14 @@ func newRequesterEngine(t *testing.T, s *RequesterEngineTestSuite) *requester.En
 		net,
 		s.tracer,
 		s.metrics,
-		s.pendingRequests,
+		pendingRequests,
 		s.retryInterval,
 		// requests are only qualified if their retryAfter is elapsed.
 		requester.RetryAfterQualifier,
-		// exponential backoff with multiplier of 2, minimum interval of a second, and
-		// maximum interval of an hour.
-		flowmempool.ExponentialUpdater(2, time.Hour, time.Second),
-		s.requestTargets)
+		reqUpdaterFunc,
+		s.requestTargets,
+		s.dispatchLimit,
+		s.maxDuration)
 	require.NoError(t, err)
 	testifymock.AssertExpectationsForObjects(t, net)
 
@@ -532,6 +547,181 @@ func TestDispatchingRequests_Hybrid(t *testing.T) {
 	testifymock.AssertExpectationsForObjects(t, s.pendingRequests, s.metrics)
 }
 
+// TestDispatchingRequests_Saturated evaluates that once there are more pending requests than the dispatch limit, only
+// the requests of the smallest chunks are dispatched, and requests of chunks of unknown size are deferred.
+func TestDispatchingRequests_Saturated(t *testing.T) {
+	s := setupTest()
+	s.dispatchLimit = 3
+	e := newRequesterEngine(t, s)
+
+	agrees := unittest.IdentifierListFixture(2)
+	disagrees := unittest.IdentifierListFixture(3)
+	vertestutils.MockLastSealedHeight(s.state, 5)
+	requests := unittest.ChunkDataPackRequestListFixture(10,
+		unittest.WithHeightGreaterThan(5),
+		unittest.WithAgrees(agrees),
+		unittest.WithDisagrees(disagrees))
+	// the first request is of unknown size, the sizes of the others decrease, so the last three are the smallest.
+	for i, request := range requests {
+		if i > 0 {
+			request.ByteSize = uint32(1000 - 10*i)
+		}
+	}
+	smallest := requests[len(requests)-3:]
+	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
+
+	attempts := 5
+	// the qualification of requests beyond the limit is not even checked.
+	requestHistoryWG, updateHistoryWG := mockPendingRequestInfoAndUpdate(t,
+		s.pendingRequests,
+		smallest,
+		verification.ChunkDataPackRequestList{},
+		verification.ChunkDataPackRequestList{},
+		attempts)
+
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine on time")
+
+	conduitWG := mockConduitForChunkDataPackRequest(t, s.con, smallest, attempts, func(*messages.ChunkDataRequest) {})
+	s.metrics.On("OnChunkDataPackRequestDispatchedInNetworkByRequester").Return().Times(len(smallest) * attempts)
+	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", testifymock.Anything).Return()
+
+	unittest.RequireReturnsBefore(t, requestHistoryWG.Wait, time.Duration(2*attempts)*s.retryInterval,
+		"could not check chunk requests qualification on time")
+	unittest.RequireReturnsBefore(t, updateHistoryWG.Wait, time.Duration(2*attempts)*s.retryInterval,
+		"could not update chunk request history on time")
+	unittest.RequireReturnsBefore(t, conduitWG.Wait, time.Duration(2*attempts)*s.retryInterval,
+		"could not request and handle chunks on time")
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	testifymock.AssertExpectationsForObjects(t, s.pendingRequests, s.metrics)
+}
+
+// TestRequestChunkDataPack_UnresponsiveTarget evaluates that once an execution node does not respond to a chunk data pack request,
+// the request is retried against the next candidate execution node, and the chunk data pack of that node is passed to the handler.
+func TestRequestChunkDataPack_UnresponsiveTarget(t *testing.T) {
+	s := setupTest()
+	s.requestTargets = 1
+	pendingRequests := stdmap.NewChunkRequests(10)
+	// makes requests instantly qualified for a retry on the next round.
+	e := newRequesterEngineWithMempool(t, s, pendingRequests, flowmempool.IncrementalAttemptUpdater())
+
+	unresponsive := unittest.IdentifierFixture()
+	responsive := unittest.IdentifierFixture()
+	request := unittest.ChunkDataPackRequestFixture(
+		unittest.WithHeightGreaterThan(5),
+		unittest.WithAgrees(flow.IdentifierList{unresponsive, responsive}))
+	response := unittest.ChunkDataResponseMsgFixture(request.ChunkID)
+	vertestutils.MockLastSealedHeight(s.state, 5)
+
+	s.metrics.On("OnChunkDataPackRequestReceivedByRequester").Return().Once()
+	s.metrics.On("OnChunkDataPackRequestDispatchedInNetworkByRequester").Return().Twice()
+	s.metrics.On("OnChunkDataPackResponseReceivedFromNetworkByRequester").Return().Once()
+	s.metrics.On("OnChunkDataPackSentToFetcher").Return().Once()
+	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", testifymock.Anything).Return()
+	handlerWG := mockChunkDataPackHandler(t, s.handler, verification.ChunkDataPackRequestList{request})
+
+	// the unresponsive execution node never returns the chunk data pack, i.e., the request times out.
+	published := make(map[flow.Identifier]int)
+	mutex := &sync.Mutex{}
+	s.con.On("Publish", testifymock.Anything, testifymock.Anything).
+		Run(func(args testifymock.Arguments) {
+			mutex.Lock()
+			target, ok := args[1].(flow.Identifier)
+			require.True(t, ok)
+			published[target]++
+			mutex.Unlock()
+
+			if target == responsive {
+				err := e.Process(engine.RequestChunks, target, response)
+				require.NoError(t, err)
+			}
+		}).Return(nil)
+
+	e.Request(request)
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine on time")
+	unittest.RequireReturnsBefore(t, handlerWG.Wait, time.Duration(5)*s.retryInterval, "could not handle chunk data response on time")
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	// the chunk data pack is requested once from each execution node, and is no longer pending.
+	mutex.Lock()
+	require.Equal(t, map[flow.Identifier]int{unresponsive: 1, responsive: 1}, published)
+	mutex.Unlock()
+	require.Zero(t, pendingRequests.Size())
+	s.handler.AssertNumberOfCalls(t, "HandleChunkDataPack", 1)
+	s.handler.AssertCalled(t, "HandleChunkDataPack", responsive, testifymock.Anything)
+	testifymock.AssertExpectationsForObjects(t, s.metrics)
+}
+
+// TestRequestChunkDataPack_Timeout evaluates that when none of the execution nodes responds to a chunk data pack request, the
+// request is rotated among the agreeing and the non-responding staked execution nodes, and given up after the maximum request
+// duration, notifying the handler with a timeout error.
+func TestRequestChunkDataPack_Timeout(t *testing.T) {
+	s := setupTest()
+	s.requestTargets = 1
+	s.maxDuration = 5 * s.retryInterval
+	pendingRequests := stdmap.NewChunkRequests(10)
+	e := newRequesterEngineWithMempool(t, s, pendingRequests, flowmempool.IncrementalAttemptUpdater())
+
+	// besides the agreeing and disagreeing execution nodes, there is a staked execution node without a receipt.
+	agree := unittest.IdentifierFixture()
+	disagree := unittest.IdentifierFixture()
+	nonResponder := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	request := unittest.ChunkDataPackRequestFixture(
+		unittest.WithHeightGreaterThan(5),
+		unittest.WithAgrees(flow.IdentifierList{agree}),
+		unittest.WithDisagrees(flow.IdentifierList{disagree}))
+	request.Targets = append(request.Targets, nonResponder)
+	vertestutils.MockLastSealedHeight(s.state, 5)
+
+	s.metrics.On("OnChunkDataPackRequestReceivedByRequester").Return().Once()
+	s.metrics.On("OnChunkDataPackRequestDispatchedInNetworkByRequester").Return()
+	s.metrics.On("OnChunkDataPackRequestTimedOutAtRequester").Return().Once()
+	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", testifymock.Anything).Return()
+
+	failedWG := &sync.WaitGroup{}
+	failedWG.Add(1)
+	s.handler.On("NotifyChunkDataPackRequestFailed", request.Index, request.ResultID, testifymock.Anything).
+		Run(func(args testifymock.Arguments) {
+			err, ok := args[2].(error)
+			require.True(t, ok)
+			require.True(t, fetcher.IsChunkDataPackRequestTimeoutError(err))
+			failedWG.Done()
+		}).Return().Once()
+
+	// none of the execution nodes responds.
+	var targets flow.IdentifierList
+	mutex := &sync.Mutex{}
+	s.con.On("Publish", testifymock.Anything, testifymock.Anything).
+		Run(func(args testifymock.Arguments) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			target, ok := args[1].(flow.Identifier)
+			require.True(t, ok)
+			targets = append(targets, target)
+		}).Return(nil)
+
+	e.Request(request)
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine on time")
+	unittest.RequireReturnsBefore(t, failedWG.Wait, time.Duration(10)*s.retryInterval, "could not give up chunk data pack request on time")
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	// the request alternates between the agreeing and the non-responding execution node, and is never
+	// dispatched to the disagreeing one.
+	mutex.Lock()
+	require.GreaterOrEqual(t, len(targets), 2)
+	for i, target := range targets {
+		if i%2 == 0 {
+			require.Equal(t, agree, target)
+		} else {
+			require.Equal(t, nonResponder.NodeID, target)
+		}
+	}
+	mutex.Unlock()
+	require.Zero(t, pendingRequests.Size())
+	s.handler.AssertNotCalled(t, "HandleChunkDataPack", testifymock.Anything, testifymock.Anything)
+	testifymock.AssertExpectationsForObjects(t, s.metrics, s.handler)
+}
+
 // toChunkIDs is a test helper that extracts chunk ids from chunk data pack requests.
 func toChunkIDs(t *testing.T, requests verification.ChunkDataPackRequestList) flow.IdentifierList {
 	var chunkIDs flow.IdentifierList
@@ -264,7 +264,10 @@ func WithChunks(setAssignees ...func(flow.Identifier, uint64, *chunks.Assignment
 	return func(result *flow.ExecutionResult, assignment *chunks.Assignment) {
 		for i, setAssignee := range setAssignees {
 			chunk := setAssignee(result.BlockID, uint64(i), assignment)
-			result.Chunks.Insert(chunk)
+			err := result.Chunks.Insert(chunk)
+			if err != nil {
+				panic(err)
+			}
 		}
 	}
 }
@@ -0,0 +1,113 @@
+package verifier
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// SuggestedApprovalBatchWindow is the suggested duration, for which the approvals for chunks of
+// the same execution result are collected before they are dispatched together, once batching
+// is enabled. Batching is disabled by default.
+const SuggestedApprovalBatchWindow = 100 * time.Millisecond
+
+// pendingBatch is the batch of approvals for a single execution result, which is waiting for
+// its window to close.
+type pendingBatch struct {
+	batch *messages.ResultApprovalBatch
+	timer *time.Timer
+}
+
+// approvalBatcher collects the approvals for chunks of the same execution result, which are
+// generated within a window starting with the first of them, and dispatches them together.
+// A batch is dispatched early once it reaches the size limits of a ResultApprovalBatch.
+type approvalBatcher struct {
+	sync.Mutex
+	window   time.Duration
+	dispatch func(*messages.ResultApprovalBatch) // called without holding the lock
+	pending  map[flow.Identifier]*pendingBatch   // pending batches, keyed by result ID
+}
+
+// newApprovalBatcher creates a new batcher, which collects approvals for the given window, and
+// dispatches them with the given function.
+func newApprovalBatcher(window time.Duration, dispatch func(*messages.ResultApprovalBatch)) *approvalBatcher {
+	return &approvalBatcher{
+		window:   window,
+		dispatch: dispatch,
+		pending:  make(map[flow.Identifier]*pendingBatch),
+	}
+}
+
+// add adds the approval to the pending batch of its result.
+func (b *approvalBatcher) add(approval *flow.ResultApproval) {
+	resultID := approval.Body.ExecutionResultID
+	var full []*messages.ResultApprovalBatch
+
+	b.Lock()
+	pending, ok := b.pending[resultID]
+	if ok && !fits(pending.batch, approval) {
+		// the approval exceeds the limits of the pending batch, which is dispatched right away
+		full = append(full, b.remove(resultID))
+		ok = false
+	}
+	if !ok {
+		pending = &pendingBatch{
+			batch: &messages.ResultApprovalBatch{ResultID: resultID},
+		}
+		pending.timer = time.AfterFunc(b.window, func() { b.flush(resultID, pending) })
+		b.pending[resultID] = pending
+	}
+	pending.batch.Approvals = append(pending.batch.Approvals, *approval)
+	if len(pending.batch.Approvals) >= messages.MaxApprovalBatchSize {
+		full = append(full, b.remove(resultID))
+	}
+	b.Unlock()
+
+	for _, batch := range full {
+		b.dispatch(batch)
+	}
+}
+
+// remove stops the timer of the pending batch of the result, and removes it.
+// It must be called while holding the lock.
+func (b *approvalBatcher) remove(resultID flow.Identifier) *messages.ResultApprovalBatch {
+	pending := b.pending[resultID]
+	pending.timer.Stop()
+	delete(b.pending, resultID)
+	return pending.batch
+}
+
+// flush dispatches the given pending batch of the result, unless it was dispatched already.
+func (b *approvalBatcher) flush(resultID flow.Identifier, pending *pendingBatch) {
+	b.Lock()
+	if b.pending[resultID] != pending {
+		b.Unlock()
+		return
+	}
+	delete(b.pending, resultID)
+	b.Unlock()
+
+	b.dispatch(pending.batch)
+}
+
+// flushAll dispatches all pending batches right away.
+func (b *approvalBatcher) flushAll() {
+	b.Lock()
+	batches := make([]*messages.ResultApprovalBatch, 0, len(b.pending))
+	for resultID := range b.pending {
+		batches = append(batches, b.remove(resultID))
+	}
+	b.Unlock()
+
+	for _, batch := range batches {
+		b.dispatch(batch)
+	}
+}
+
+// fits returns true if the approval can be added to the batch without exceeding its limits.
+func fits(batch *messages.ResultApprovalBatch, approval *flow.ResultApproval) bool {
+	return len(batch.Approvals) < messages.MaxApprovalBatchSize &&
+		batch.ByteSize()+messages.ResultApprovalByteSize(approval) <= messages.MaxApprovalBatchByteSize
+}
@@ -0,0 +1,123 @@
+package verifier
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// batchRecorder records the batches dispatched by a batcher.
+type batchRecorder struct {
+	sync.Mutex
+	batches []*messages.ResultApprovalBatch
+}
+
+func (r *batchRecorder) dispatch(batch *messages.ResultApprovalBatch) {
+	r.Lock()
+	defer r.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *batchRecorder) dispatched() []*messages.ResultApprovalBatch {
+	r.Lock()
+	defer r.Unlock()
+	return append([]*messages.ResultApprovalBatch(nil), r.batches...)
+}
+
+func approvalsFixture(n int, resultID flow.Identifier) []*flow.ResultApproval {
+	approvals := make([]*flow.ResultApproval, 0, n)
+	for i := 0; i < n; i++ {
+		approvals = append(approvals, unittest.ResultApprovalFixture(
+			unittest.WithExecutionResultID(resultID),
+			unittest.WithChunk(uint64(i)),
+		))
+	}
+	return approvals
+}
+
+// TestApprovalBatcher_Window checks that the approvals of the same result, which are added within
+// the window, are dispatched as a single batch once the window closes, separately from the
+// approvals of other results.
+func TestApprovalBatcher_Window(t *testing.T) {
+	recorder := &batchRecorder{}
+	batcher := newApprovalBatcher(100*time.Millisecond, recorder.dispatch)
+
+	resultA, resultB := unittest.IdentifierFixture(), unittest.IdentifierFixture()
+	approvalsA := approvalsFixture(3, resultA)
+	approvalsB := approvalsFixture(1, resultB)
+	for _, approval := range append(approvalsA, approvalsB...) {
+		batcher.add(approval)
+	}
+	assert.Empty(t, recorder.dispatched(), "approvals should not be dispatched before the window closes")
+
+	require.Eventually(t, func() bool {
+		return len(recorder.dispatched()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	batches := map[flow.Identifier]*messages.ResultApprovalBatch{}
+	for _, batch := range recorder.dispatched() {
+		batches[batch.ResultID] = batch
+	}
+	require.Len(t, batches[resultA].Approvals, 3)
+	for i, approval := range approvalsA {
+		assert.Equal(t, *approval, batches[resultA].Approvals[i])
+	}
+	require.Len(t, batches[resultB].Approvals, 1)
+	assert.Equal(t, *approvalsB[0], batches[resultB].Approvals[0])
+
+	// no batch is dispatched twice
+	time.Sleep(200 * time.Millisecond)
+	assert.Len(t, recorder.dispatched(), 2)
+}
+
+// TestApprovalBatcher_Limits checks that batches are dispatched right away once they reach the
+// maximum number of approvals, or once the next approval would exceed the maximum byte size.
+func TestApprovalBatcher_Limits(t *testing.T) {
+	t.Run("count", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		batcher := newApprovalBatcher(time.Hour, recorder.dispatch)
+
+		resultID := unittest.IdentifierFixture()
+		for _, approval := range approvalsFixture(messages.MaxApprovalBatchSize+1, resultID) {
+			batcher.add(approval)
+		}
+		batches := recorder.dispatched()
+		require.Len(t, batches, 1)
+		assert.Len(t, batches[0].Approvals, messages.MaxApprovalBatchSize)
+
+		// the remaining approval is dispatched on flushing
+		batcher.flushAll()
+		batches = recorder.dispatched()
+		require.Len(t, batches, 2)
+		assert.Len(t, batches[1].Approvals, 1)
+	})
+
+	t.Run("byte size", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		batcher := newApprovalBatcher(time.Hour, recorder.dispatch)
+
+		resultID := unittest.IdentifierFixture()
+		approvals := approvalsFixture(2, resultID)
+		approvals[1].Body.Spock = make([]byte, messages.MaxApprovalBatchByteSize/2)
+
+		batcher.add(approvals[0])
+		batcher.add(approvals[1])
+		assert.Empty(t, recorder.dispatched())
+
+		large := approvalsFixture(1, resultID)[0]
+		large.Body.Spock = make([]byte, messages.MaxApprovalBatchByteSize/2)
+		batcher.add(large)
+
+		batches := recorder.dispatched()
+		require.Len(t, batches, 1)
+		assert.Len(t, batches[0].Approvals, 2)
+		assert.LessOrEqual(t, batches[0].ByteSize(), uint(messages.MaxApprovalBatchByteSize))
+	})
+}
@@ -3,9 +3,11 @@ package verifier
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/crypto/hash"
@@ -18,6 +20,7 @@ import (
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module"
+	sigmessages "github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
@@ -30,18 +33,44 @@ import (
 // constructing a partial trie, executing transactions and check the final state commitment and
 // other chunk meta data (e.g. tx count)
 type Engine struct {
-	unit        *engine.Unit               // used to control startup/shutdown
-	log         zerolog.Logger             // used to log relevant actions
-	metrics     module.VerificationMetrics // used to capture the performance metrics
-	tracer      module.Tracer              // used for tracing
-	pushConduit network.Conduit            // used to push result approvals
-	pullConduit network.Conduit            // used to respond to requests for result approvals
-	me          module.Local               // used to access local node information
-	state       protocol.State             // used to access the protocol state
-	rah         hash.Hasher                // used as hasher to sign the result approvals
-	chVerif     module.ChunkVerifier       // used to verify chunks
-	spockHasher hash.Hasher                // used for generating spocks
-	approvals   storage.ResultApprovals    // used to store result approvals
+	unit        *engine.Unit                // used to control startup/shutdown
+	log         zerolog.Logger              // used to log relevant actions
+	metrics     module.VerificationMetrics  // used to capture the performance metrics
+	tracer      module.Tracer               // used for tracing
+	pushConduit network.Conduit             // used to push result approvals
+	pullConduit network.Conduit             // used to respond to requests for result approvals
+	me          module.Local                // used to access local node information
+	state       protocol.State              // used to access the protocol state
+	rah         hash.Hasher                 // used as hasher to sign the result approvals
+	chVerif     module.ChunkVerifier        // used to verify chunks
+	spockHasher hash.Hasher                 // used for generating spocks
+	approvals   storage.ResultApprovals     // used to store result approvals
+	batchWindow time.Duration               // window for batching approvals of the same result, zero disables batching
+	batcher     *approvalBatcher            // used to batch approvals of the same result, nil if batching is disabled
+	records     storage.VerificationRecords // used to keep records of verified chunks, optional
+	pruned      *atomic.Uint64              // height below which verification records have been pruned
+}
+
+// Option is a functional option for the verifier engine.
+type Option func(*Engine)
+
+// WithApprovalBatchWindow enables batching of approvals: the approvals for chunks of the same
+// result, which are generated within the given window, are sent to the consensus nodes as a
+// single ResultApprovalBatch. A zero window disables batching, which is the default. Consensus
+// nodes running a codec without ResultApprovalBatch drop the batches, so batching may only be
+// enabled once all consensus nodes understand them.
+func WithApprovalBatchWindow(window time.Duration) Option {
+	return func(e *Engine) {
+		e.batchWindow = window
+	}
+}
+
+// WithVerificationRecords makes the engine persist a record of each verified chunk alongside its
+// result approval in the given storage, and prune the records of blocks below the sealed height.
+func WithVerificationRecords(records storage.VerificationRecords) Option {
+	return func(e *Engine) {
+		e.records = records
+	}
 }
 
 // New creates and returns a new instance of a verifier engine.
@@ -54,6 +83,7 @@ func New(
 	me module.Local,
 	chVerif module.ChunkVerifier,
 	approvals storage.ResultApprovals,
+	opts ...Option,
 ) (*Engine, error) {
 
 	e := &Engine{
@@ -67,6 +97,18 @@ func New(
 		rah:         utils.NewResultApprovalHasher(),
 		spockHasher: crypto.NewBLSKMAC(encoding.SPOCKTag),
 		approvals:   approvals,
+		pruned:      atomic.NewUint64(0),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.batchWindow > 0 {
+		e.batcher = newApprovalBatcher(e.batchWindow, func(batch *messages.ResultApprovalBatch) {
+			err := e.publish(batch)
+			if err != nil {
+				e.log.Error().Err(err).Hex("result_id", logging.ID(batch.ResultID)).Msg("could not submit result approval batch")
+			}
+		})
 	}
 
 	var err error
@@ -89,8 +131,13 @@ func (e *Engine) Ready() <-chan struct{} {
 }
 
 // Done returns a channel that is closed when the verifier engine is done.
+// Pending approval batches are sent right away.
 func (e *Engine) Done() <-chan struct{} {
-	return e.unit.Done()
+	return e.unit.Done(func() {
+		if e.batcher != nil {
+			e.batcher.flushAll()
+		}
+	})
 }
 
 // SubmitLocal submits an event originating on the local node.
@@ -189,11 +236,13 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 
 	var spockSecret []byte
 	var chFault chmodels.ChunkFault
+	start := time.Now()
 	if vc.IsSystemChunk {
 		spockSecret, chFault, err = e.chVerif.SystemChunkVerify(vc)
 	} else {
 		spockSecret, chFault, err = e.chVerif.Verify(vc)
 	}
+	duration := time.Since(start)
 	span.Finish()
 	// Any err means that something went wrong when verify the chunk
 	// the outcome of the verification is captured inside the chFault and not the err
@@ -243,6 +292,72 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 		return fmt.Errorf("could not index approval: %w", err)
 	}
 
+	// the verification record is written asynchronously, so that it does not delay the approval
+	if e.records != nil {
+		record := verification.NewVerificationRecord(vc, approval, duration)
+		e.unit.Launch(func() {
+			e.storeVerificationRecord(record)
+		})
+	}
+
+	// approvals of the same result are sent together once the batch window closes
+	if e.batcher != nil {
+		e.batcher.add(approval)
+		log.Info().Msg("result approval added to batch")
+		return nil
+	}
+
+	err = e.publish(&messages.ResultApprovalBatch{
+		ResultID:  approval.Body.ExecutionResultID,
+		Approvals: []flow.ResultApproval{*approval},
+	})
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("result approval submitted")
+
+	return nil
+}
+
+// storeVerificationRecord persists the given verification record, and prunes the records of blocks below
+// the sealed height. Failing to store the record is not critical, as it is only kept for investigation.
+func (e *Engine) storeVerificationRecord(record *verification.VerificationRecord) {
+	lg := e.log.With().
+		Hex("result_id", logging.ID(record.ResultID)).
+		Uint64("chunk_index", record.ChunkIndex).
+		Logger()
+
+	err := e.records.Store(record)
+	if err != nil {
+		lg.Error().Err(err).Msg("could not store verification record")
+		return
+	}
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		lg.Error().Err(err).Msg("could not get sealed height for pruning verification records")
+		return
+	}
+	// records are stored concurrently, only one of them prunes up to a new sealed height
+	pruned := e.pruned.Load()
+	if sealed.Height <= pruned || !e.pruned.CAS(pruned, sealed.Height) {
+		return
+	}
+
+	removed, err := e.records.PruneBelow(sealed.Height)
+	if err != nil {
+		lg.Error().Err(err).Uint64("sealed_height", sealed.Height).Msg("could not prune verification records")
+		return
+	}
+	lg.Debug().
+		Uint64("sealed_height", sealed.Height).
+		Uint("removed_records", removed).
+		Msg("pruned verification records below sealed height")
+}
+
+// publish broadcasts the approvals of the batch to the consensus nodes. A batch with a single
+// approval is sent as a plain approval.
+func (e *Engine) publish(batch *messages.ResultApprovalBatch) error {
 	// Extracting consensus node ids
 	// TODO state extraction should be done based on block references
 	consensusNodes, err := e.state.Final().
@@ -252,15 +367,21 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 		return fmt.Errorf("could not load consensus node IDs: %w", err)
 	}
 
-	// broadcast result approval to the consensus nodes
-	err = e.pushConduit.Publish(approval, consensusNodes.NodeIDs()...)
+	var event interface{} = batch
+	if len(batch.Approvals) == 1 {
+		event = &batch.Approvals[0]
+	}
+
+	// broadcast result approvals to the consensus nodes
+	err = e.pushConduit.Publish(event, consensusNodes.NodeIDs()...)
 	if err != nil {
 		// TODO this error needs more advance handling after MVP
 		return fmt.Errorf("could not submit result approval: %w", err)
 	}
-	log.Info().Msg("result approval submitted")
 	// increases number of sent result approvals for sake of metrics
-	e.metrics.OnResultApprovalDispatchedInNetworkByVerifier()
+	for range batch.Approvals {
+		e.metrics.OnResultApprovalDispatchedInNetworkByVerifier()
+	}
 
 	return nil
 }
@@ -280,8 +401,7 @@ func (e *Engine) GenerateResultApproval(chunkIndex uint64,
 	}
 
 	// generates a signature over the attestation part of approval
-	atstID := atst.ID()
-	atstSign, err := e.me.Sign(atstID[:], e.rah)
+	atstSign, err := e.me.Sign(sigmessages.AttestationMessage(&atst), e.rah)
 	if err != nil {
 		return nil, fmt.Errorf("could not sign attestation: %w", err)
 	}
@@ -301,8 +421,7 @@ func (e *Engine) GenerateResultApproval(chunkIndex uint64,
 	}
 
 	// generates a signature over result approval body
-	bodyID := body.ID()
-	bodySign, err := e.me.Sign(bodyID[:], e.rah)
+	bodySign, err := e.me.Sign(sigmessages.ResultApprovalMessage(&body), e.rah)
 	if err != nil {
 		return nil, fmt.Errorf("could not sign result approval body: %w", err)
 	}
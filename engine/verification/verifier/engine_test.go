@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
@@ -167,6 +168,80 @@ func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 
 }
 
+// TestVerifyHappyPath_VerificationRecord tests that the verifier engine persists a record of the verified
+// chunk matching the emitted result approval, and prunes the records of blocks below the sealed height.
+func (suite *VerifierEngineTestSuite) TestVerifyHappyPath_VerificationRecord() {
+	records := &mockstorage.VerificationRecords{}
+	eng, err := verifier.New(
+		zerolog.Logger{},
+		suite.metrics,
+		suite.tracer,
+		suite.net,
+		suite.state,
+		suite.me,
+		ChunkVerifierMock{},
+		suite.approvals,
+		verifier.WithVerificationRecords(records))
+	require.NoError(suite.T(), err)
+
+	consensusNodes := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleConsensus))
+	vChunk := unittest.VerifiableChunkDataFixture(uint64(0))
+	suite.me.MockNodeID(unittest.IdentifierFixture())
+	suite.ss.On("Identities", testifymock.Anything).Return(consensusNodes, nil)
+	suite.metrics.On("OnVerifiableChunkReceivedAtVerifierEngine").Return()
+	suite.metrics.On("OnResultApprovalDispatchedInNetworkByVerifier").Return()
+
+	sealed := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(vChunk.Header.Height))
+	sealedSnapshot := &protocol.Snapshot{}
+	sealedSnapshot.On("Head").Return(&sealed, nil)
+	suite.state.On("Sealed").Return(sealedSnapshot)
+
+	var approval *flow.ResultApproval
+	suite.pushCon.
+		On("Publish", testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			approval = args[0].(*flow.ResultApproval)
+		}).
+		Once()
+
+	var record *verification.VerificationRecord
+	records.On("Store", testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			record = args[0].(*verification.VerificationRecord)
+		}).
+		Once()
+	pruned := make(chan struct{})
+	records.On("PruneBelow", sealed.Height).
+		Return(uint(1), nil).
+		Run(func(testifymock.Arguments) {
+			close(pruned)
+		}).
+		Once()
+
+	err = eng.ProcessLocal(vChunk)
+	suite.Require().NoError(err)
+	unittest.RequireCloseBefore(suite.T(), pruned, time.Second, "verification records were not pruned")
+
+	suite.Require().NotNil(approval)
+	suite.Require().NotNil(record)
+	suite.Assert().Equal(vChunk.Chunk.ID(), record.ChunkID)
+	suite.Assert().Equal(approval.Body.ExecutionResultID, record.ResultID)
+	suite.Assert().Equal(vChunk.Result.ID(), record.ResultID)
+	suite.Assert().Equal(approval.Body.ChunkIndex, record.ChunkIndex)
+	suite.Assert().Equal(approval.Body.BlockID, record.BlockID)
+	suite.Assert().Equal(vChunk.Header.Height, record.Height)
+	suite.Assert().Equal(approval.ID(), record.ApprovalID)
+	suite.Assert().Equal(vChunk.Chunk.StartState, record.StartState)
+	suite.Assert().Equal(vChunk.EndState, record.EndState)
+	suite.Assert().Equal(uint64(len(vChunk.ChunkDataPack.Collection.Transactions)), record.TransactionCount)
+	suite.Assert().Equal(approval.Body.Spock, record.Spock)
+
+	records.AssertExpectations(suite.T())
+	suite.pushCon.AssertExpectations(suite.T())
+}
+
 func (suite *VerifierEngineTestSuite) TestVerifyUnhappyPaths() {
 	eng := suite.TestNewEngine()
 	myID := unittest.IdentifierFixture()
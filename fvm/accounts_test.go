@@ -14,6 +14,7 @@ import (
 
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -726,6 +727,71 @@ func TestAddAccountKey(t *testing.T) {
 		)
 	}
 
+	for _, test := range multipleKeysTests {
+		t.Run(fmt.Sprintf("Duplicate keys %s", test.apiVersion),
+			newVMTest().withContextOptions(append(options, fvm.WithAccountKeyChecks(true))...).
+				run(func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, view state.View, programs *programs.Programs) {
+					address := createAccount(t, vm, chain, ctx, view, programs)
+
+					privateKey, err := unittest.AccountKeyDefaultFixture()
+					require.NoError(t, err)
+
+					_, publicKeyArg := newAccountKey(t, privateKey, test.apiVersion)
+
+					txBody := flow.NewTransactionBody().
+						SetScript([]byte(test.source)).
+						AddArgument(publicKeyArg).
+						AddArgument(publicKeyArg).
+						AddAuthorizer(address)
+
+					tx := fvm.Transaction(txBody, 0)
+
+					err = vm.Run(ctx, tx, view, programs)
+					require.NoError(t, err)
+
+					require.Error(t, tx.Err)
+					assert.Contains(t, tx.Err.Error(), errors.ErrCodeDuplicateAccountKeyError.String())
+
+					after, err := vm.GetAccount(ctx, address, view, programs)
+					require.NoError(t, err)
+
+					assert.Empty(t, after.Keys)
+				}),
+		)
+	}
+
+	t.Run("Key limit",
+		newVMTest().withContextOptions(append(options, fvm.WithMaxAccountKeyCount(1))...).
+			run(func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, view state.View, programs *programs.Programs) {
+				address := createAccount(t, vm, chain, ctx, view, programs)
+
+				addAccountKey(t, vm, ctx, view, programs, address, accountKeyAPIVersionV2)
+
+				privateKey, err := unittest.AccountKeyDefaultFixture()
+				require.NoError(t, err)
+
+				_, publicKeyArg := newAccountKey(t, privateKey, accountKeyAPIVersionV2)
+
+				txBody := flow.NewTransactionBody().
+					SetScript([]byte(addAccountKeyTransactionV2)).
+					AddArgument(publicKeyArg).
+					AddAuthorizer(address)
+
+				tx := fvm.Transaction(txBody, 0)
+
+				err = vm.Run(ctx, tx, view, programs)
+				require.NoError(t, err)
+
+				require.Error(t, tx.Err)
+				assert.Contains(t, tx.Err.Error(), errors.ErrCodeAccountKeyLimitExceededError.String())
+
+				after, err := vm.GetAccount(ctx, address, view, programs)
+				require.NoError(t, err)
+
+				assert.Len(t, after.Keys, 1)
+			}),
+	)
+
 	t.Run("Invalid hash algorithms", func(t *testing.T) {
 
 		for _, hashAlgo := range []string{"SHA2_384", "SHA3_384"} {
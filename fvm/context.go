@@ -20,7 +20,10 @@ type Context struct {
 	MaxStateKeySize               uint64
 	MaxStateValueSize             uint64
 	MaxStateInteractionSize       uint64
+	MaxAggregateInteractionSize   uint64
 	EventCollectionByteSizeLimit  uint64
+	MaxAccountKeyCount            uint64
+	AccountKeyChecksEnabled       bool
 	MaxNumOfTxRetries             uint8
 	BlockHeader                   *flow.Header
 	ServiceAccountEnabled         bool
@@ -32,6 +35,7 @@ type Context struct {
 	ServiceEventCollectionEnabled bool
 	AccountFreezeAvailable        bool
 	ExtensiveTracing              bool
+	ExecutionEffortProfiling      bool
 	SignatureVerifier             crypto.SignatureVerifier
 	TransactionProcessors         []TransactionProcessor
 	ScriptProcessors              []ScriptProcessor
@@ -56,11 +60,12 @@ func newContext(ctx Context, opts ...Option) Context {
 	return ctx
 }
 
-const AccountKeyWeightThreshold = 1000
+const AccountKeyWeightThreshold = handler.AccountKeyWeightThreshold
 
 const (
 	DefaultGasLimit                     = 100_000 // 100K
 	DefaultEventCollectionByteSizeLimit = 256_000 // 256KB
+	DefaultMaxAccountKeyCount           = 1_000   // including revoked keys
 	DefaultMaxNumOfTxRetries            = 3
 )
 
@@ -74,7 +79,10 @@ func defaultContext(logger zerolog.Logger) Context {
 		MaxStateKeySize:               state.DefaultMaxKeySize,
 		MaxStateValueSize:             state.DefaultMaxValueSize,
 		MaxStateInteractionSize:       state.DefaultMaxInteractionSize,
+		MaxAggregateInteractionSize:   state.DefaultMaxInteractionSize,
 		EventCollectionByteSizeLimit:  DefaultEventCollectionByteSizeLimit,
+		MaxAccountKeyCount:            0,
+		AccountKeyChecksEnabled:       false,
 		MaxNumOfTxRetries:             DefaultMaxNumOfTxRetries,
 		BlockHeader:                   nil,
 		ServiceAccountEnabled:         true,
@@ -84,6 +92,7 @@ func defaultContext(logger zerolog.Logger) Context {
 		ServiceEventCollectionEnabled: false,
 		AccountFreezeAvailable:        false,
 		ExtensiveTracing:              false,
+		ExecutionEffortProfiling:      false,
 		SignatureVerifier:             crypto.NewDefaultSignatureVerifier(),
 		TransactionProcessors: []TransactionProcessor{
 			NewTransactionAccountFrozenChecker(),
@@ -143,6 +152,41 @@ func WithMaxStateInteractionSize(limit uint64) Option {
 	}
 }
 
+// WithMaxAggregateInteractionSize sets the byte size limit for the total interaction with ledger
+// of a procedure, summed over all states including merged children.
+func WithMaxAggregateInteractionSize(limit uint64) Option {
+	return func(ctx Context) Context {
+		ctx.MaxAggregateInteractionSize = limit
+		return ctx
+	}
+}
+
+// WithMaxAccountKeyCount sets the maximum number of keys of an account, including revoked keys,
+// beyond which no keys can be added. A zero limit disables it.
+//
+// The limit changes the results of transactions, so it must be identical for all execution and
+// verification nodes. Nodes therefore don't configure it individually, it is set for the chain
+// with the other FVM options of the node builder, and only changes with a spork.
+func WithMaxAccountKeyCount(limit uint64) Option {
+	return func(ctx Context) Context {
+		ctx.MaxAccountKeyCount = limit
+		return ctx
+	}
+}
+
+// WithAccountKeyChecks enables or disables rejecting account keys with a weight out of range,
+// and duplicates of any key of an account, which is not revoked, when adding keys.
+//
+// The checks change the results of transactions, so they must be identical for all execution and
+// verification nodes. Nodes therefore don't configure them individually, they are set for the
+// chain with the other FVM options of the node builder, and only change with a spork.
+func WithAccountKeyChecks(enabled bool) Option {
+	return func(ctx Context) Context {
+		ctx.AccountKeyChecksEnabled = enabled
+		return ctx
+	}
+}
+
 // WithEventCollectionSizeLimit sets the event collection byte size limit for a virtual machine context.
 func WithEventCollectionSizeLimit(limit uint64) Option {
 	return func(ctx Context) Context {
@@ -188,6 +232,15 @@ func WithExtensiveTracing() Option {
 	}
 }
 
+// WithExecutionEffortProfiling enables or disables accumulating the execution effort of
+// transactions into categories, which are reported in their ComputationBreakdown.
+func WithExecutionEffortProfiling(enabled bool) Option {
+	return func(ctx Context) Context {
+		ctx.ExecutionEffortProfiling = enabled
+		return ctx
+	}
+}
+
 // WithBlocks sets the block storage provider for a virtual machine context.
 //
 // The VM uses the block storage provider to provide historical block information to
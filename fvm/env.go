@@ -9,5 +9,8 @@ import (
 type Environment interface {
 	Context() *Context
 	VM() *VirtualMachine
+	// CountAccountKeys returns the number of keys of an account, including the revoked ones,
+	// which is limited by Context().MaxAccountKeyCount.
+	CountAccountKeys(address runtime.Address) (uint64, error)
 	runtime.Interface
 }
@@ -117,3 +117,74 @@ func (e FrozenAccountError) Error() string {
 func (e FrozenAccountError) Code() ErrorCode {
 	return ErrCodeFrozenAccountError
 }
+
+// DuplicateAccountKeyError is returned when a key is added to an account, which already has the same
+// public key with the same signature and hashing algorithms, and it is not revoked
+type DuplicateAccountKeyError struct {
+	address  flow.Address
+	keyIndex int
+}
+
+// NewDuplicateAccountKeyError constructs a new DuplicateAccountKeyError
+func NewDuplicateAccountKeyError(address flow.Address, keyIndex int) error {
+	return &DuplicateAccountKeyError{address: address, keyIndex: keyIndex}
+}
+
+// KeyIndex returns the index of the existing key
+func (e DuplicateAccountKeyError) KeyIndex() int {
+	return e.keyIndex
+}
+
+func (e DuplicateAccountKeyError) Error() string {
+	return fmt.Sprintf(
+		"%s account %s already has the same key at key index %d",
+		e.Code().String(),
+		e.address,
+		e.keyIndex,
+	)
+}
+
+// Code returns the error code for this error type
+func (e DuplicateAccountKeyError) Code() ErrorCode {
+	return ErrCodeDuplicateAccountKeyError
+}
+
+// IsDuplicateAccountKeyError returns true if error has this type
+func IsDuplicateAccountKeyError(err error) bool {
+	var t *DuplicateAccountKeyError
+	return errors.As(err, &t)
+}
+
+// AccountKeyLimitExceededError is returned when a key is added to an account, which already has
+// the maximum number of keys, including the revoked ones
+type AccountKeyLimitExceededError struct {
+	address flow.Address
+	count   uint64
+	limit   uint64
+}
+
+// NewAccountKeyLimitExceededError constructs a new AccountKeyLimitExceededError
+func NewAccountKeyLimitExceededError(address flow.Address, count uint64, limit uint64) error {
+	return &AccountKeyLimitExceededError{address: address, count: count, limit: limit}
+}
+
+func (e AccountKeyLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"%s account %s has %d keys, which is the limit of keys per account (%d)",
+		e.Code().String(),
+		e.address,
+		e.count,
+		e.limit,
+	)
+}
+
+// Code returns the error code for this error type
+func (e AccountKeyLimitExceededError) Code() ErrorCode {
+	return ErrCodeAccountKeyLimitExceededError
+}
+
+// IsAccountKeyLimitExceededError returns true if error has this type
+func IsAccountKeyLimitExceededError(err error) bool {
+	var t *AccountKeyLimitExceededError
+	return errors.As(err, &t)
+}
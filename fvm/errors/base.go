@@ -42,7 +42,7 @@ func (e InvalidAddressError) Unwrap() error {
 // InvalidArgumentError indicates that a transaction includes invalid arguments.
 // this error is the result of failure in any of the following conditions:
 // - number of arguments doesn't match the template
-// TODO add more cases like argument size
+// - an argument is not a valid JSON-CDC encoding
 type InvalidArgumentError struct {
 	err error
 }
@@ -53,7 +53,7 @@ func NewInvalidArgumentErrorf(msg string, args ...interface{}) *InvalidArgumentE
 }
 
 func (e InvalidArgumentError) Error() string {
-	return fmt.Sprintf("%s transaction arguments are invalid: (%s)", e.Code().String(), e.err.Error())
+	return fmt.Sprintf("%s transaction arguments are invalid: %s", e.Code().String(), e.err.Error())
 }
 
 // Code returns the error code for this error type
@@ -24,6 +24,10 @@ const (
 	FailureCodeMetaTransactionFailure FailureCode = 2100
 )
 
+// Error codes are part of the public API, and are reported to clients as part of the transaction
+// results. Codes must never be renumbered or reused: a retired code stays reserved, and new errors
+// get new codes within the range of their category. All codes are registered in DefaultRegistry,
+// and their numeric values are pinned by TestErrorCodeValues.
 const (
 	// tx validation errors 1000 - 1049
 	// ErrCodeTxValidationError         ErrorCode = 1000 - reserved
@@ -67,6 +71,8 @@ const (
 	ErrCodeAccountPublicKeyNotFoundError ErrorCode = 1202
 	ErrCodeAccountAlreadyExistsError     ErrorCode = 1203
 	ErrCodeFrozenAccountError            ErrorCode = 1204
+	ErrCodeDuplicateAccountKeyError      ErrorCode = 1205
+	ErrCodeAccountKeyLimitExceededError  ErrorCode = 1206
 
 	// contract errors 1250 - 1300
 	// ErrCodeContractError          ErrorCode = 1250 - reserved
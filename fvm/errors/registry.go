@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// ErrorCategory is the stage of processing a transaction at which an error occurred.
+type ErrorCategory string
+
+const (
+	// CategoryUnknown is the category of codes which are not registered.
+	CategoryUnknown ErrorCategory = "unknown"
+	// CategoryValidation covers errors of transactions which failed verification before being
+	// executed, such as invalid signatures or sequence numbers.
+	CategoryValidation ErrorCategory = "validation"
+	// CategoryExecution covers errors of transactions which failed while being executed.
+	CategoryExecution ErrorCategory = "execution"
+)
+
+// ErrorCodeInfo describes a registered error code.
+type ErrorCodeInfo struct {
+	Code     ErrorCode
+	Name     string
+	Category ErrorCategory
+	// Retryable indicates that resubmitting the transaction, after refreshing its reference
+	// block or proposal sequence number, may succeed.
+	Retryable bool
+}
+
+// Registry maps error codes to their names, categories and retryability hints.
+type Registry struct {
+	infos map[ErrorCode]ErrorCodeInfo
+}
+
+// NewRegistry creates a registry of the given error codes.
+func NewRegistry(infos ...ErrorCodeInfo) *Registry {
+	r := &Registry{infos: make(map[ErrorCode]ErrorCodeInfo, len(infos))}
+	for _, info := range infos {
+		r.infos[info.Code] = info
+	}
+	return r
+}
+
+// DefaultRegistry is the registry of all error codes that can be returned for transactions and scripts.
+var DefaultRegistry = NewRegistry(
+	validationCode(ErrCodeInvalidTxByteSizeError, "InvalidTxByteSizeError", false),
+	validationCode(ErrCodeInvalidReferenceBlockError, "InvalidReferenceBlockError", true),
+	validationCode(ErrCodeExpiredTransactionError, "ExpiredTransactionError", true),
+	validationCode(ErrCodeInvalidScriptError, "InvalidScriptError", false),
+	validationCode(ErrCodeInvalidGasLimitError, "InvalidGasLimitError", false),
+	validationCode(ErrCodeInvalidProposalSignatureError, "InvalidProposalSignatureError", false),
+	validationCode(ErrCodeInvalidProposalSeqNumberError, "InvalidProposalSeqNumberError", true),
+	validationCode(ErrCodeInvalidPayloadSignatureError, "InvalidPayloadSignatureError", false),
+	validationCode(ErrCodeInvalidEnvelopeSignatureError, "InvalidEnvelopeSignatureError", false),
+
+	executionCode(ErrCodeFVMInternalError, "FVMInternalError"),
+	executionCode(ErrCodeValueError, "ValueError"),
+	executionCode(ErrCodeInvalidArgumentError, "InvalidArgumentError"),
+	executionCode(ErrCodeInvalidAddressError, "InvalidAddressError"),
+	executionCode(ErrCodeInvalidLocationError, "InvalidLocationError"),
+	executionCode(ErrCodeAccountAuthorizationError, "AccountAuthorizationError"),
+	executionCode(ErrCodeOperationAuthorizationError, "OperationAuthorizationError"),
+	executionCode(ErrCodeOperationNotSupportedError, "OperationNotSupportedError"),
+
+	executionCode(ErrCodeCadenceRunTimeError, "CadenceRunTimeError"),
+	executionCode(ErrCodeEncodingUnsupportedValue, "EncodingUnsupportedValue"),
+	executionCode(ErrCodeStorageCapacityExceeded, "StorageCapacityExceeded"),
+	executionCode(ErrCodeGasLimitExceededError, "GasLimitExceededError"),
+	executionCode(ErrCodeEventLimitExceededError, "EventLimitExceededError"),
+	executionCode(ErrCodeLedgerIntractionLimitExceededError, "LedgerIntractionLimitExceededError"),
+	executionCode(ErrCodeStateKeySizeLimitError, "StateKeySizeLimitError"),
+	executionCode(ErrCodeStateValueSizeLimitError, "StateValueSizeLimitError"),
+	executionCode(ErrCodeTransactionFeeDeductionFailedError, "TransactionFeeDeductionFailedError"),
+
+	executionCode(ErrCodeAccountNotFoundError, "AccountNotFoundError"),
+	executionCode(ErrCodeAccountPublicKeyNotFoundError, "AccountPublicKeyNotFoundError"),
+	executionCode(ErrCodeAccountAlreadyExistsError, "AccountAlreadyExistsError"),
+	executionCode(ErrCodeFrozenAccountError, "FrozenAccountError"),
+	executionCode(ErrCodeDuplicateAccountKeyError, "DuplicateAccountKeyError"),
+	executionCode(ErrCodeAccountKeyLimitExceededError, "AccountKeyLimitExceededError"),
+
+	executionCode(ErrCodeContractNotFoundError, "ContractNotFoundError"),
+	executionCode(ErrCodeContractNamesNotFoundError, "ContractNamesNotFoundError"),
+)
+
+func validationCode(code ErrorCode, name string, retryable bool) ErrorCodeInfo {
+	return ErrorCodeInfo{Code: code, Name: name, Category: CategoryValidation, Retryable: retryable}
+}
+
+func executionCode(code ErrorCode, name string) ErrorCodeInfo {
+	return ErrorCodeInfo{Code: code, Name: name, Category: CategoryExecution}
+}
+
+// Info returns the description of the code, and false if the code is not registered.
+func (r *Registry) Info(code ErrorCode) (ErrorCodeInfo, bool) {
+	info, ok := r.infos[code]
+	return info, ok
+}
+
+// Name returns the name of the code, or "Unknown" if the code is not registered.
+func (r *Registry) Name(code ErrorCode) string {
+	info, ok := r.infos[code]
+	if !ok {
+		return "Unknown"
+	}
+	return info.Name
+}
+
+// IsRetryable returns true if resubmitting a transaction which failed with the code may succeed.
+// Codes which are not registered are not retryable.
+func (r *Registry) IsRetryable(code ErrorCode) bool {
+	return r.infos[code].Retryable
+}
+
+// Category returns the category of the code, or CategoryUnknown if the code is not registered.
+func (r *Registry) Category(code ErrorCode) ErrorCategory {
+	info, ok := r.infos[code]
+	if !ok {
+		return CategoryUnknown
+	}
+	return info.Category
+}
+
+// Codes returns all registered codes in ascending order.
+func (r *Registry) Codes() []ErrorCode {
+	codes := make([]ErrorCode, 0, len(r.infos))
+	for code := range r.infos {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
+
+// errorCodePattern matches the code prefixed to error messages by ErrorCode.String.
+var errorCodePattern = regexp.MustCompile(`\[Error Code: (\d+)\]`)
+
+// ParseErrorCode extracts the error code from the message of a transaction error, and returns false
+// if the message doesn't contain an error code.
+func ParseErrorCode(message string) (ErrorCode, bool) {
+	match := errorCodePattern.FindStringSubmatch(message)
+	if match == nil {
+		return 0, false
+	}
+	code, err := strconv.ParseUint(match[1], 10, 16)
+	if err != nil {
+		return 0, false
+	}
+	return ErrorCode(code), true
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TestErrorCodeValues pins the numeric value of every registered error code. Clients depend on
+// these values, hence changing any of them is a breaking change of the API.
+func TestErrorCodeValues(t *testing.T) {
+	expected := map[string]ErrorCode{
+		"InvalidTxByteSizeError":        1001,
+		"InvalidReferenceBlockError":    1002,
+		"ExpiredTransactionError":       1003,
+		"InvalidScriptError":            1004,
+		"InvalidGasLimitError":          1005,
+		"InvalidProposalSignatureError": 1006,
+		"InvalidProposalSeqNumberError": 1007,
+		"InvalidPayloadSignatureError":  1008,
+		"InvalidEnvelopeSignatureError": 1009,
+
+		"FVMInternalError":            1050,
+		"ValueError":                  1051,
+		"InvalidArgumentError":        1052,
+		"InvalidAddressError":         1053,
+		"InvalidLocationError":        1054,
+		"AccountAuthorizationError":   1055,
+		"OperationAuthorizationError": 1056,
+		"OperationNotSupportedError":  1057,
+
+		"CadenceRunTimeError":                1101,
+		"EncodingUnsupportedValue":           1102,
+		"StorageCapacityExceeded":            1103,
+		"GasLimitExceededError":              1104,
+		"EventLimitExceededError":            1105,
+		"LedgerIntractionLimitExceededError": 1106,
+		"StateKeySizeLimitError":             1107,
+		"StateValueSizeLimitError":           1108,
+		"TransactionFeeDeductionFailedError": 1109,
+
+		"AccountNotFoundError":          1201,
+		"AccountPublicKeyNotFoundError": 1202,
+		"AccountAlreadyExistsError":     1203,
+		"FrozenAccountError":            1204,
+		"DuplicateAccountKeyError":      1205,
+		"AccountKeyLimitExceededError":  1206,
+
+		"ContractNotFoundError":      1251,
+		"ContractNamesNotFoundError": 1252,
+	}
+
+	codes := DefaultRegistry.Codes()
+	require.Len(t, codes, len(expected), "every registered code must be pinned")
+	for _, code := range codes {
+		name := DefaultRegistry.Name(code)
+		expectedCode, ok := expected[name]
+		require.True(t, ok, "code %d (%s) is not pinned", code, name)
+		assert.Equal(t, expectedCode, code, "code of %s changed", name)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	seqNumberErr := NewInvalidProposalSeqNumberError(flow.HexToAddress("01"), 0, 2, 1)
+	assert.Equal(t, "InvalidProposalSeqNumberError", DefaultRegistry.Name(seqNumberErr.Code()))
+	assert.Equal(t, CategoryValidation, DefaultRegistry.Category(seqNumberErr.Code()))
+	assert.True(t, DefaultRegistry.IsRetryable(seqNumberErr.Code()))
+
+	payloadSigErr := NewInvalidPayloadSignatureError(flow.HexToAddress("01"), 0, fmt.Errorf("invalid signature"))
+	assert.Equal(t, "InvalidPayloadSignatureError", DefaultRegistry.Name(payloadSigErr.Code()))
+	assert.Equal(t, CategoryValidation, DefaultRegistry.Category(payloadSigErr.Code()))
+	assert.False(t, DefaultRegistry.IsRetryable(payloadSigErr.Code()))
+
+	assert.Equal(t, CategoryExecution, DefaultRegistry.Category(ErrCodeCadenceRunTimeError))
+
+	unknown := ErrorCode(9999)
+	assert.Equal(t, "Unknown", DefaultRegistry.Name(unknown))
+	assert.Equal(t, CategoryUnknown, DefaultRegistry.Category(unknown))
+	assert.False(t, DefaultRegistry.IsRetryable(unknown))
+}
+
+func TestParseErrorCode(t *testing.T) {
+	err := NewInvalidProposalSeqNumberError(flow.HexToAddress("01"), 0, 2, 1)
+	code, ok := ParseErrorCode(err.Error())
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeInvalidProposalSeqNumberError, code)
+
+	_, ok = ParseErrorCode("some error without a code")
+	assert.False(t, ok)
+	_, ok = ParseErrorCode("[Error Code: 99999999] overflowing code")
+	assert.False(t, ok)
+}
@@ -49,6 +49,10 @@ func (vm *VirtualMachine) Run(ctx Context, proc Procedure, v state.View, program
 		state.WithMaxValueSizeAllowed(ctx.MaxStateValueSize),
 		state.WithMaxInteractionSizeAllowed(ctx.MaxStateInteractionSize))
 	sth := state.NewStateHolder(st)
+	sth.SetMaxInteractionAllowed(ctx.MaxAggregateInteractionSize)
+	if ctx.ExecutionEffortProfiling {
+		sth.EnableEffortProfiling()
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -81,6 +85,7 @@ func (vm *VirtualMachine) GetAccount(ctx Context, address flow.Address, v state.
 		state.WithMaxInteractionSizeAllowed(ctx.MaxStateInteractionSize))
 
 	sth := state.NewStateHolder(st)
+	sth.SetMaxInteractionAllowed(ctx.MaxAggregateInteractionSize)
 	account, err := getAccount(vm, ctx, sth, programs, address)
 	if err != nil {
 		if errors.IsALedgerFailure(err) {
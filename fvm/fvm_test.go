@@ -753,6 +753,97 @@ func TestBlockContext_ExecuteTransaction_GasLimit(t *testing.T) {
 	}
 }
 
+func executionEffortScript(iterations int) string {
+	return fmt.Sprintf(`
+        transaction {
+            execute {
+                var i = 0
+                while i < %d {
+                    i = i + 1
+                }
+            }
+        }
+    `, iterations)
+}
+
+func TestBlockContext_ExecuteTransaction_ExecutionEffort(t *testing.T) {
+
+	t.Parallel()
+
+	rt := fvm.NewInterpreterRuntime()
+
+	chain := flow.Mainnet.Chain()
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	ctx := fvm.NewContext(
+		zerolog.Nop(),
+		fvm.WithChain(chain),
+	)
+
+	execute := func(t *testing.T, ctx fvm.Context, iterations int) *fvm.TransactionProcedure {
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(executionEffortScript(iterations))).
+			SetGasLimit(10_000)
+
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+
+		tx := fvm.Transaction(txBody, 0)
+
+		err = vm.Run(ctx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, tx.Err)
+		return tx
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		tx := execute(t, ctx, 10)
+
+		assert.Nil(t, tx.ComputationBreakdown)
+		assert.Zero(t, tx.ExecutionEffort)
+	})
+
+	t.Run("categories sum up to the execution effort", func(t *testing.T) {
+		ctx := fvm.NewContextFromParent(ctx, fvm.WithExecutionEffortProfiling(true))
+
+		for _, iterations := range []int{10, 100} {
+			tx := execute(t, ctx, iterations)
+
+			breakdown := tx.ComputationBreakdown
+			require.NotNil(t, breakdown)
+
+			// every loop iteration is metered by the runtime
+			assert.GreaterOrEqual(t, breakdown[string(state.ComputationKindFunctionInvocation)], uint64(iterations))
+			assert.Equal(t, tx.ComputationUsed, breakdown[string(state.ComputationKindFunctionInvocation)])
+			// the signature of the service account is verified and its keys are read from the ledger
+			assert.Equal(t, uint64(1), breakdown[string(state.ComputationKindCryptoOperation)])
+			assert.NotZero(t, breakdown[string(state.ComputationKindLedgerInteraction)])
+
+			var total uint64
+			for _, amount := range breakdown {
+				total += amount
+			}
+			assert.Equal(t, tx.ExecutionEffort, total)
+		}
+	})
+
+	t.Run("effort grows with the loop count", func(t *testing.T) {
+		ctx := fvm.NewContextFromParent(ctx, fvm.WithExecutionEffortProfiling(true))
+
+		short := execute(t, ctx, 10)
+		long := execute(t, ctx, 100)
+
+		kind := string(state.ComputationKindFunctionInvocation)
+		assert.GreaterOrEqual(t, long.ComputationBreakdown[kind]-short.ComputationBreakdown[kind], uint64(90))
+		assert.Equal(t,
+			short.ComputationBreakdown[string(state.ComputationKindLedgerInteraction)],
+			long.ComputationBreakdown[string(state.ComputationKindLedgerInteraction)])
+	})
+}
+
 func TestBlockContext_ExecuteTransaction_StorageLimit(t *testing.T) {
 
 	t.Parallel()
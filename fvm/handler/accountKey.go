@@ -15,10 +15,29 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 )
 
+// AccountKeyWeightThreshold is the total weight of keys required to sign for an account,
+// which is also the maximum weight of a single key.
+const AccountKeyWeightThreshold = 1000
+
 // AccountKeyHandler handles all interaction
 // with account keys such as get/set/revoke
 type AccountKeyHandler struct {
-	accounts state.Accounts
+	accounts    state.Accounts
+	maxKeyCount uint64
+	checkKeys   bool
+}
+
+// ValidateAccountKeyWeight returns a ValueError if the given key weight is negative
+// or exceeds the weight threshold.
+func ValidateAccountKeyWeight(weight int) error {
+	if weight < 0 || weight > AccountKeyWeightThreshold {
+		return errors.NewValueErrorf(
+			fmt.Sprint(weight),
+			"key weight must be between 0 and %d",
+			AccountKeyWeightThreshold,
+		)
+	}
+	return nil
 }
 
 // NewAccountPublicKey construct an account public key given a runtime public key.
@@ -58,16 +77,81 @@ func NewAccountPublicKey(publicKey *runtime.PublicKey,
 	}, nil
 }
 
-func NewAccountKeyHandler(accounts state.Accounts) *AccountKeyHandler {
+// NewAccountKeyHandler creates a handler of the keys of the given accounts, which rejects adding keys
+// to accounts having maxKeyCount keys already, including the revoked ones. A zero maxKeyCount
+// disables the limit. If checkKeys is set, keys with a weight out of range and duplicates of any
+// key of an account, which is not revoked, are rejected as well.
+func NewAccountKeyHandler(accounts state.Accounts, maxKeyCount uint64, checkKeys bool) *AccountKeyHandler {
 	return &AccountKeyHandler{
-		accounts: accounts,
+		accounts:    accounts,
+		maxKeyCount: maxKeyCount,
+		checkKeys:   checkKeys,
+	}
+}
+
+// CountAccountKeys returns the number of keys of an existing account, including the revoked ones.
+func (h *AccountKeyHandler) CountAccountKeys(address runtime.Address) (uint64, error) {
+	accountAddress := flow.Address(address)
+
+	ok, err := h.accounts.Exists(accountAddress)
+	if err != nil {
+		return 0, fmt.Errorf("counting account keys failed: %w", err)
+	}
+	if !ok {
+		issue := errors.NewAccountNotFoundError(accountAddress)
+		return 0, fmt.Errorf("counting account keys failed: %w", issue)
+	}
+
+	count, err := h.accounts.GetPublicKeyCount(accountAddress)
+	if err != nil {
+		return 0, fmt.Errorf("counting account keys failed: %w", err)
+	}
+
+	return count, nil
+}
+
+// checkNewAccountKey checks that the given key can be added to the account with the given number of
+// keys. All keys of the account are compared to detect duplicates, so the registers read grow with the
+// number of keys, which is bounded by the key limit. It returns
+// * AccountKeyLimitExceededError - if the limit is enabled and the account has the maximum number of keys already
+// * ValueError - if the checks are enabled and the weight of the key is out of range
+// * DuplicateAccountKeyError - if the checks are enabled and a key of the account, which is not revoked,
+//   is the same key
+func (h *AccountKeyHandler) checkNewAccountKey(address flow.Address, count uint64, publicKey flow.AccountPublicKey) error {
+	if h.maxKeyCount > 0 && count >= h.maxKeyCount {
+		return errors.NewAccountKeyLimitExceededError(address, count, h.maxKeyCount)
+	}
+
+	if !h.checkKeys {
+		return nil
+	}
+
+	err := ValidateAccountKeyWeight(publicKey.Weight)
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < count; i++ {
+		existing, err := h.accounts.GetPublicKey(address, i)
+		if err != nil {
+			return err
+		}
+		if existing.Revoked || existing.SignAlgo != publicKey.SignAlgo || existing.HashAlgo != publicKey.HashAlgo {
+			continue
+		}
+		if existing.PublicKey.Equals(publicKey.PublicKey) {
+			return errors.NewDuplicateAccountKeyError(address, existing.Index)
+		}
 	}
+
+	return nil
 }
 
 // AddAccountKey adds a public key to an existing account.
 //
-// This function returns an error if the specified account does not exist or
-// if the key insertion fails.
+// This function returns an error if the specified account does not exist, if the account
+// has the maximum number of keys already, if the key is rejected by the enabled key checks,
+// or if the key insertion fails.
 func (h *AccountKeyHandler) AddAccountKey(address runtime.Address,
 	publicKey *runtime.PublicKey,
 	hashAlgo runtime.HashAlgorithm,
@@ -97,6 +181,11 @@ func (h *AccountKeyHandler) AddAccountKey(address runtime.Address,
 		return nil, fmt.Errorf("adding account key failed: %w", err)
 	}
 
+	err = h.checkNewAccountKey(accountAddress, keyIndex, *accountPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("adding account key failed: %w", err)
+	}
+
 	err = h.accounts.AppendPublicKey(accountAddress, *accountPublicKey)
 	if err != nil {
 		return nil, fmt.Errorf("adding account key failed: %w", err)
@@ -248,6 +337,8 @@ func (h *AccountKeyHandler) GetAccountKey(address runtime.Address, keyIndex int)
 // This function returns following error
 // * NewAccountNotFoundError - if the specified account does not exist
 // * ValueError - if the provided encodedPublicKey is not valid public key
+// * AccountKeyLimitExceededError - if the account has the maximum number of keys already
+// * DuplicateAccountKeyError - if the key checks are enabled and the key is a duplicate of a recent key
 func (e *AccountKeyHandler) AddEncodedAccountKey(address runtime.Address, encodedPublicKey []byte) (err error) {
 	accountAddress := flow.Address(address)
 
@@ -269,6 +360,16 @@ func (e *AccountKeyHandler) AddEncodedAccountKey(address runtime.Address, encode
 		return fmt.Errorf("adding encoded account key failed: %w", err)
 	}
 
+	count, err := e.accounts.GetPublicKeyCount(accountAddress)
+	if err != nil {
+		return fmt.Errorf("adding encoded account key failed: %w", err)
+	}
+
+	err = e.checkNewAccountKey(accountAddress, count, publicKey)
+	if err != nil {
+		return fmt.Errorf("adding encoded account key failed: %w", err)
+	}
+
 	err = e.accounts.AppendPublicKey(accountAddress, publicKey)
 	if err != nil {
 		return fmt.Errorf("adding encoded account key failed: %w", err)
@@ -13,10 +13,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/fvm/utils"
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
 )
 
 func TestAddEncodedAccountKey_error_handling_produces_valid_utf8(t *testing.T) {
@@ -67,6 +70,154 @@ func TestAddEncodedAccountKey_error_handling_produces_valid_utf8(t *testing.T) {
 	require.Equal(t, errorString, unmarshalledString)
 }
 
+// newAccountKeyHandler creates a handler of the keys of a single new account without keys.
+func newAccountKeyHandler(t *testing.T, maxKeyCount uint64, checkKeys bool) (*AccountKeyHandler, runtime.Address) {
+	accounts := state.NewAccounts(state.NewStateHolder(state.NewState(utils.NewSimpleView())))
+	address := flow.HexToAddress("01")
+	err := accounts.Create(nil, address)
+	require.NoError(t, err)
+	return NewAccountKeyHandler(accounts, maxKeyCount, checkKeys), runtime.Address(address)
+}
+
+// newRuntimePublicKey returns a new random ECDSA P-256 public key.
+func newRuntimePublicKey(t *testing.T) *runtime.PublicKey {
+	privateKey, err := unittest.AccountKeyDefaultFixture()
+	require.NoError(t, err)
+	return &runtime.PublicKey{
+		PublicKey: privateKey.PrivateKey.PublicKey().Encode(),
+		SignAlgo:  runtime.SignatureAlgorithmECDSA_P256,
+	}
+}
+
+func TestAddAccountKey_duplicate(t *testing.T) {
+	akh, address := newAccountKeyHandler(t, 0, true)
+	publicKey := newRuntimePublicKey(t)
+
+	_, err := akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 1000)
+	require.NoError(t, err)
+
+	// the same key with the same algorithms is rejected
+	_, err = akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 500)
+	require.True(t, errors.IsDuplicateAccountKeyError(err), "unexpected error: %v", err)
+
+	var duplicateErr *errors.DuplicateAccountKeyError
+	require.True(t, errors2.As(err, &duplicateErr))
+	assert.Equal(t, 0, duplicateErr.KeyIndex())
+
+	// the same key with a different hashing algorithm is a different key
+	_, err = akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA2_256, 1000)
+	require.NoError(t, err)
+
+	// the encoded key API rejects duplicates as well
+	encodedPublicKey, err := flow.EncodeRuntimeAccountPublicKey(flow.AccountPublicKey{
+		PublicKey: mustDecodePublicKey(t, publicKey),
+		SignAlgo:  crypto.ECDSAP256,
+		HashAlgo:  hash.SHA3_256,
+		Weight:    1000,
+	})
+	require.NoError(t, err)
+	err = akh.AddEncodedAccountKey(address, encodedPublicKey)
+	require.True(t, errors.IsDuplicateAccountKeyError(err), "unexpected error: %v", err)
+
+	// a revoked key can be added again
+	_, err = akh.RevokeAccountKey(address, 0)
+	require.NoError(t, err)
+	key, err := akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, 2, key.KeyIndex)
+
+	count, err := akh.CountAccountKeys(address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+}
+
+func TestAddAccountKey_keyLimit(t *testing.T) {
+	const limit = 3
+	akh, address := newAccountKeyHandler(t, limit, false)
+
+	for i := 0; i < limit; i++ {
+		_, err := akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, 1000)
+		require.NoError(t, err)
+	}
+
+	_, err := akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, 1000)
+	require.True(t, errors.IsAccountKeyLimitExceededError(err), "unexpected error: %v", err)
+
+	// revoked keys count towards the limit
+	_, err = akh.RevokeAccountKey(address, 0)
+	require.NoError(t, err)
+	_, err = akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, 1000)
+	require.True(t, errors.IsAccountKeyLimitExceededError(err), "unexpected error: %v", err)
+
+	count, err := akh.CountAccountKeys(address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(limit), count)
+}
+
+func TestAddAccountKey_weight(t *testing.T) {
+	akh, address := newAccountKeyHandler(t, 0, true)
+
+	for _, weight := range []int{0, 1, AccountKeyWeightThreshold} {
+		_, err := akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, weight)
+		require.NoError(t, err, "weight %d", weight)
+	}
+
+	for _, weight := range []int{-1, AccountKeyWeightThreshold + 1} {
+		_, err := akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, weight)
+		var valueErr *errors.ValueError
+		require.True(t, errors2.As(err, &valueErr), "weight %d: unexpected error: %v", weight, err)
+	}
+
+	count, err := akh.CountAccountKeys(address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+}
+
+// TestAddAccountKey_checksDisabled tests that duplicates and weights out of range are accepted
+// without the key checks, which is the behaviour of nodes not enabling them.
+func TestAddAccountKey_checksDisabled(t *testing.T) {
+	akh, address := newAccountKeyHandler(t, 0, false)
+	publicKey := newRuntimePublicKey(t)
+
+	_, err := akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 1000)
+	require.NoError(t, err)
+	_, err = akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 1000)
+	require.NoError(t, err)
+	_, err = akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, AccountKeyWeightThreshold+1)
+	require.NoError(t, err)
+
+	count, err := akh.CountAccountKeys(address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+}
+
+// TestAddAccountKey_duplicateOfFirstKey tests that duplicates are detected among all keys of an account,
+// i.e. that the first key of an account with many keys can't be added again.
+func TestAddAccountKey_duplicateOfFirstKey(t *testing.T) {
+	akh, address := newAccountKeyHandler(t, 0, true)
+	publicKey := newRuntimePublicKey(t)
+
+	_, err := akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 1000)
+	require.NoError(t, err)
+	for i := 0; i < 150; i++ {
+		_, err := akh.AddAccountKey(address, newRuntimePublicKey(t), runtime.HashAlgorithmSHA3_256, 1000)
+		require.NoError(t, err)
+	}
+
+	_, err = akh.AddAccountKey(address, publicKey, runtime.HashAlgorithmSHA3_256, 1000)
+	require.True(t, errors.IsDuplicateAccountKeyError(err), "unexpected error: %v", err)
+
+	var duplicateErr *errors.DuplicateAccountKeyError
+	require.True(t, errors2.As(err, &duplicateErr))
+	assert.Equal(t, 0, duplicateErr.KeyIndex())
+}
+
+func mustDecodePublicKey(t *testing.T, publicKey *runtime.PublicKey) crypto.PublicKey {
+	decoded, err := crypto.DecodePublicKey(crypto.ECDSAP256, publicKey.PublicKey)
+	require.NoError(t, err)
+	return decoded
+}
+
 type FakePublicKey struct {
 	data []byte
 }
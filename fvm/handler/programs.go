@@ -77,7 +77,7 @@ func (h *ProgramsHandler) mergeState(state *state.State) error {
 		h.masterState.SetActiveState(h.viewsStack[len(h.viewsStack)-1].state)
 	}
 
-	return h.masterState.State().MergeState(state, h.masterState.EnforceInteractionLimits())
+	return h.masterState.CommitChild(h.masterState.State(), state)
 }
 
 func (h *ProgramsHandler) Get(location common.Location) (*interpreter.Program, bool) {
@@ -132,13 +132,13 @@ func (h *ProgramsHandler) Cleanup() error {
 
 	for i := stackLen - 1; i > 0; i-- {
 		entry := h.viewsStack[i]
-		err := h.viewsStack[i-1].state.MergeState(entry.state, h.masterState.EnforceInteractionLimits())
+		err := h.masterState.CommitChild(h.viewsStack[i-1].state, entry.state)
 		if err != nil {
 			return fmt.Errorf("cannot merge state while cleanup: %w", err)
 		}
 	}
 
-	err := h.initialState.MergeState(h.viewsStack[0].state, h.masterState.EnforceInteractionLimits())
+	err := h.masterState.CommitChild(h.initialState, h.viewsStack[0].state)
 	if err != nil {
 		return err
 	}
@@ -102,6 +102,27 @@ func (_m *Environment) Context() *fvm.Context {
 	return r0
 }
 
+// CountAccountKeys provides a mock function with given fields: address
+func (_m *Environment) CountAccountKeys(address common.Address) (uint64, error) {
+	ret := _m.Called(address)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Address) uint64); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateAccount provides a mock function with given fields: payer
 func (_m *Environment) CreateAccount(payer common.Address) (common.Address, error) {
 	ret := _m.Called(payer)
@@ -66,7 +66,7 @@ func NewScriptEnvironment(
 	accounts := state.NewAccounts(sth)
 	uuidGenerator := state.NewUUIDGenerator(sth)
 	programsHandler := handler.NewProgramsHandler(programs, sth)
-	accountKeys := handler.NewAccountKeyHandler(accounts)
+	accountKeys := handler.NewAccountKeyHandler(accounts, ctx.MaxAccountKeyCount, ctx.AccountKeyChecksEnabled)
 	metrics := handler.NewMetricsHandler(ctx.Metrics)
 	computationHandler := handler.NewComputationMeteringHandler(ctx.GasLimit)
 
@@ -593,6 +593,18 @@ func (e *ScriptEnv) GetAccountKey(address runtime.Address, index int) (*runtime.
 	return nil, errors.NewOperationNotSupportedError("GetAccountKey")
 }
 
+func (e *ScriptEnv) CountAccountKeys(address runtime.Address) (uint64, error) {
+	if e.accountKeys != nil {
+		count, err := e.accountKeys.CountAccountKeys(address)
+		if err != nil {
+			return 0, fmt.Errorf("counting account keys failed: %w", err)
+		}
+		return count, nil
+	}
+
+	return 0, errors.NewOperationNotSupportedError("CountAccountKeys")
+}
+
 func (e *ScriptEnv) RevokeAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
 	return nil, errors.NewOperationNotSupportedError("RevokeAccountKey")
 }
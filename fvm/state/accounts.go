@@ -418,9 +418,9 @@ func (a *StatefulAccounts) GetValue(address flow.Address, key string) (flow.Regi
 
 func (a *StatefulAccounts) getValue(address flow.Address, isController bool, key string) (flow.RegisterValue, error) {
 	if isController {
-		return a.stateHolder.State().Get(string(address.Bytes()), string(address.Bytes()), key, a.stateHolder.EnforceInteractionLimits())
+		return a.stateHolder.Get(string(address.Bytes()), string(address.Bytes()), key)
 	}
-	return a.stateHolder.State().Get(string(address.Bytes()), "", key, a.stateHolder.EnforceInteractionLimits())
+	return a.stateHolder.Get(string(address.Bytes()), "", key)
 }
 
 // SetValue sets a value in address' storage
@@ -435,9 +435,9 @@ func (a *StatefulAccounts) setValue(address flow.Address, isController bool, key
 	}
 
 	if isController {
-		return a.stateHolder.State().Set(string(address.Bytes()), string(address.Bytes()), key, value, a.stateHolder.EnforceInteractionLimits())
+		return a.stateHolder.Set(string(address.Bytes()), string(address.Bytes()), key, value)
 	}
-	return a.stateHolder.State().Set(string(address.Bytes()), "", key, value, a.stateHolder.EnforceInteractionLimits())
+	return a.stateHolder.Set(string(address.Bytes()), "", key, value)
 }
 
 func (a *StatefulAccounts) updateRegisterSizeChange(address flow.Address, isController bool, key string, value flow.RegisterValue) error {
@@ -501,10 +501,10 @@ func RegisterSize(address flow.Address, isController bool, key string, value flo
 // TODO handle errors
 func (a *StatefulAccounts) touch(address flow.Address, isController bool, key string) {
 	if isController {
-		_, _ = a.stateHolder.State().Get(string(address.Bytes()), string(address.Bytes()), key, a.stateHolder.EnforceInteractionLimits())
+		_, _ = a.stateHolder.Get(string(address.Bytes()), string(address.Bytes()), key)
 		return
 	}
-	_, _ = a.stateHolder.State().Get(string(address.Bytes()), "", key, a.stateHolder.EnforceInteractionLimits())
+	_, _ = a.stateHolder.Get(string(address.Bytes()), "", key)
 }
 
 func (a *StatefulAccounts) TouchContract(contractName string, address flow.Address) {
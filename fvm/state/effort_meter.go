@@ -0,0 +1,50 @@
+package state
+
+// ComputationKind is a category of the effort spent on executing a procedure.
+type ComputationKind string
+
+const (
+	// ComputationKindFunctionInvocation is the computation reported by the Cadence runtime
+	// (statements, loop iterations and function invocations).
+	ComputationKindFunctionInvocation ComputationKind = "function_invocation"
+	// ComputationKindLedgerInteraction is a single register read or write.
+	ComputationKindLedgerInteraction ComputationKind = "ledger_interaction"
+	// ComputationKindCryptoOperation is a single hashing or signature verification.
+	ComputationKindCryptoOperation ComputationKind = "crypto_operation"
+)
+
+// EffortMeter accumulates the effort spent on executing a procedure into named categories.
+// It is only used for profiling and is not used to enforce any limits.
+type EffortMeter struct {
+	breakdown map[ComputationKind]uint64
+}
+
+// NewEffortMeter constructs a new, empty effort meter.
+func NewEffortMeter() *EffortMeter {
+	return &EffortMeter{
+		breakdown: make(map[ComputationKind]uint64),
+	}
+}
+
+// Meter adds the given amount of effort to the category.
+func (m *EffortMeter) Meter(kind ComputationKind, amount uint64) {
+	m.breakdown[kind] += amount
+}
+
+// Breakdown returns a copy of the effort accumulated so far, keyed by category.
+func (m *EffortMeter) Breakdown() map[string]uint64 {
+	breakdown := make(map[string]uint64, len(m.breakdown))
+	for kind, amount := range m.breakdown {
+		breakdown[string(kind)] = amount
+	}
+	return breakdown
+}
+
+// Total returns the sum of the effort of all categories.
+func (m *EffortMeter) Total() uint64 {
+	var total uint64
+	for _, amount := range m.breakdown {
+		total += amount
+	}
+	return total
+}
@@ -1,23 +1,40 @@
 package state
 
+import (
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/model/flow"
+)
+
 // StateHolder provides active states
 // and facilitates common state management operations
 // in order to make services such as accounts not worry about
 // the state it is recommended that such services wraps
 // a state manager instead of a state itself.
+//
+// The holder keeps track of the aggregate ledger interaction of all the
+// states it holds, so that the interaction limit is enforced for the sum of
+// a parent and its children, rather than for each state individually.
 type StateHolder struct {
 	enforceInteractionLimits bool
 	payerIsServiceAccount    bool
 	startState               *State
 	activeState              *State
+	maxInteractionAllowed    uint64            // hard cap on the aggregate interaction
+	interactionUsed          uint64            // aggregate interaction of all held states
+	accountedInteraction     map[*State]uint64 // interaction of each state which is already part of interactionUsed
+	effortMeter              *EffortMeter      // nil unless execution effort profiling is enabled
 }
 
-// NewStateHolder constructs a new state manager
+// NewStateHolder constructs a new state manager. The cap on the aggregate
+// ledger interaction defaults to the interaction limit of the start state.
 func NewStateHolder(startState *State) *StateHolder {
 	return &StateHolder{
 		enforceInteractionLimits: true,
 		startState:               startState,
 		activeState:              startState,
+		maxInteractionAllowed:    startState.maxInteractionAllowed,
+		interactionUsed:          startState.InteractionUsed(),
+		accountedInteraction:     map[*State]uint64{startState: startState.InteractionUsed()},
 	}
 }
 
@@ -31,6 +48,11 @@ func (s *StateHolder) SetActiveState(st *State) {
 	s.activeState = st
 }
 
+// SetMaxInteractionAllowed sets the cap on the aggregate ledger interaction
+func (s *StateHolder) SetMaxInteractionAllowed(limit uint64) {
+	s.maxInteractionAllowed = limit
+}
+
 // SetActiveState sets active state
 func (s *StateHolder) SetPayerIsServiceAccount() {
 	s.payerIsServiceAccount = true
@@ -63,3 +85,96 @@ func (s *StateHolder) EnforceInteractionLimits() bool {
 	}
 	return s.enforceInteractionLimits
 }
+
+// EnableEffortProfiling starts accumulating the execution effort of all held states into
+// categories, which can be retrieved with EffortBreakdown.
+func (s *StateHolder) EnableEffortProfiling() {
+	if s.effortMeter == nil {
+		s.effortMeter = NewEffortMeter()
+	}
+}
+
+// MeterEffort adds the given amount of effort to the category. It is a no-op unless
+// effort profiling is enabled.
+func (s *StateHolder) MeterEffort(kind ComputationKind, amount uint64) {
+	if s.effortMeter != nil {
+		s.effortMeter.Meter(kind, amount)
+	}
+}
+
+// EffortBreakdown returns the execution effort accumulated so far, keyed by category,
+// or nil if effort profiling is not enabled.
+func (s *StateHolder) EffortBreakdown() map[string]uint64 {
+	if s.effortMeter == nil {
+		return nil
+	}
+	return s.effortMeter.Breakdown()
+}
+
+// InteractionUsed returns the aggregate ledger interaction (total ledger byte read + total ledger
+// byte written) of all states held by this holder, including merged children.
+func (s *StateHolder) InteractionUsed() uint64 {
+	return s.interactionUsed
+}
+
+// Get returns a register value from the active state and accounts for the interaction.
+func (s *StateHolder) Get(owner, controller, key string) (flow.RegisterValue, error) {
+	value, err := s.activeState.Get(owner, controller, key, s.EnforceInteractionLimits())
+	s.accountInteraction(s.activeState)
+	s.MeterEffort(ComputationKindLedgerInteraction, 1)
+	if err != nil {
+		return nil, err
+	}
+	return value, s.checkMaxInteraction()
+}
+
+// Set updates a register value on the active state and accounts for the interaction.
+func (s *StateHolder) Set(owner, controller, key string, value flow.RegisterValue) error {
+	err := s.activeState.Set(owner, controller, key, value, s.EnforceInteractionLimits())
+	s.accountInteraction(s.activeState)
+	s.MeterEffort(ComputationKindLedgerInteraction, 1)
+	if err != nil {
+		return err
+	}
+	return s.checkMaxInteraction()
+}
+
+// CommitChild merges the child into the parent state and accounts for the
+// interaction of the child which didn't happen through this holder (i.e. for
+// states which were operated on directly). Returns the ledger interaction limit
+// error if the aggregate interaction exceeds the cap after the merge.
+func (s *StateHolder) CommitChild(parent, child *State) error {
+	err := parent.MergeState(child, s.EnforceInteractionLimits())
+	s.accountInteraction(child)
+	s.accountedInteraction[parent] += s.accountedInteraction[child]
+	delete(s.accountedInteraction, child)
+	if err != nil {
+		return err
+	}
+	return s.checkMaxInteraction()
+}
+
+// DiscardChildren sets the parent as the active state and drops the interaction of all
+// other states held, which are discarded without being committed, e.g. when a transaction
+// is retried. The aggregate interaction is reset to the interaction of the parent.
+func (s *StateHolder) DiscardChildren(parent *State) {
+	s.activeState = parent
+	s.accountInteraction(parent)
+	s.interactionUsed = s.accountedInteraction[parent]
+	s.accountedInteraction = map[*State]uint64{parent: s.interactionUsed}
+}
+
+// accountInteraction adds the interaction of the given state which isn't accounted for yet.
+// The interaction of a state can decrease, if a register is overwritten with a smaller value.
+func (s *StateHolder) accountInteraction(st *State) {
+	used := st.InteractionUsed()
+	s.interactionUsed = s.interactionUsed + used - s.accountedInteraction[st]
+	s.accountedInteraction[st] = used
+}
+
+func (s *StateHolder) checkMaxInteraction() error {
+	if s.EnforceInteractionLimits() && s.interactionUsed > s.maxInteractionAllowed {
+		return errors.NewLedgerIntractionLimitExceededError(s.interactionUsed, s.maxInteractionAllowed)
+	}
+	return nil
+}
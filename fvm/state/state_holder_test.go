@@ -0,0 +1,169 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/fvm/utils"
+)
+
+func TestStateHolder_InteractionUsed(t *testing.T) {
+	view := utils.NewSimpleView()
+	st := state.NewState(view)
+	sth := state.NewStateHolder(st)
+
+	// read on the start state - interaction 3
+	_, err := sth.Get("1", "2", "3")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), sth.InteractionUsed())
+
+	// update on a child - interaction 7
+	child := sth.NewChild()
+	err = sth.Set("2", "3", "4", []byte{'A'})
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), sth.InteractionUsed())
+
+	// interaction performed directly on a nested child is accounted for on commit - interaction 10
+	grandChild := child.NewChild()
+	_, err = grandChild.Get("3", "4", "5", true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), sth.InteractionUsed())
+
+	err = sth.CommitChild(child, grandChild)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), sth.InteractionUsed())
+
+	// committing the child to the start state doesn't count its interaction twice
+	err = sth.CommitChild(st, child)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), sth.InteractionUsed())
+	require.Equal(t, st.InteractionUsed(), sth.InteractionUsed())
+}
+
+func TestStateHolder_DiscardChildren(t *testing.T) {
+	view := utils.NewSimpleView()
+	st := state.NewState(view, state.WithMaxInteractionSizeAllowed(10))
+	sth := state.NewStateHolder(st)
+
+	// read on the start state - interaction 3
+	_, err := sth.Get("1", "2", "3")
+	require.NoError(t, err)
+
+	// interaction of discarded children doesn't count towards the aggregate
+	for i := 0; i < 3; i++ {
+		sth.NewChild()
+		_, err = sth.Get("12", "34", "56")
+		require.NoError(t, err)
+		require.Equal(t, uint64(9), sth.InteractionUsed())
+
+		sth.DiscardChildren(st)
+		require.Equal(t, st, sth.State())
+		require.Equal(t, uint64(3), sth.InteractionUsed())
+	}
+}
+
+func TestStateHolder_MaxInteraction(t *testing.T) {
+	var interactionLimitErr *errors.LedgerIntractionLimitExceededError
+
+	t.Run("operation exceeding the aggregate interaction fails", func(t *testing.T) {
+		view := utils.NewSimpleView()
+		sth := state.NewStateHolder(state.NewState(view, state.WithMaxInteractionSizeAllowed(10)))
+
+		// read - interaction 6
+		_, err := sth.Get("12", "34", "56")
+		require.NoError(t, err)
+
+		// read on a child - 6 for the child itself, but 12 in aggregate
+		sth.NewChild()
+		_, err = sth.Get("23", "45", "67")
+		require.Error(t, err)
+		require.True(t, errors.As(err, &interactionLimitErr))
+	})
+
+	t.Run("merge of nested children exceeding the aggregate interaction fails", func(t *testing.T) {
+		view := utils.NewSimpleView()
+		st := state.NewState(view, state.WithMaxInteractionSizeAllowed(10))
+		sth := state.NewStateHolder(st)
+
+		// read - interaction 6
+		_, err := sth.Get("12", "34", "56")
+		require.NoError(t, err)
+
+		// each of the nested children stays within the limit on its own
+		child := sth.NewChild()
+		grandChild := child.NewChild()
+		_, err = grandChild.Get("23", "45", "67", true)
+		require.NoError(t, err)
+
+		// merging the nested children causes the aggregate to exceed the limit
+		err = sth.CommitChild(child, grandChild)
+		require.Error(t, err)
+		require.True(t, errors.As(err, &interactionLimitErr))
+		require.Equal(t, uint64(12), sth.InteractionUsed())
+	})
+
+	t.Run("configured cap replaces the limit of the start state", func(t *testing.T) {
+		view := utils.NewSimpleView()
+		sth := state.NewStateHolder(state.NewState(view, state.WithMaxInteractionSizeAllowed(10)))
+		sth.SetMaxInteractionAllowed(5)
+
+		// read - interaction 6
+		_, err := sth.Get("12", "34", "56")
+		require.Error(t, err)
+		require.True(t, errors.As(err, &interactionLimitErr))
+	})
+
+	t.Run("service account bypasses the limit", func(t *testing.T) {
+		view := utils.NewSimpleView()
+		st := state.NewState(view, state.WithMaxInteractionSizeAllowed(10))
+		sth := state.NewStateHolder(st)
+		sth.SetPayerIsServiceAccount()
+
+		_, err := sth.Get("12", "34", "56")
+		require.NoError(t, err)
+
+		child := sth.NewChild()
+		_, err = sth.Get("23", "45", "67")
+		require.NoError(t, err)
+
+		grandChild := child.NewChild()
+		_, err = grandChild.Get("34", "56", "78", true)
+		require.NoError(t, err)
+
+		err = sth.CommitChild(child, grandChild)
+		require.NoError(t, err)
+		err = sth.CommitChild(st, child)
+		require.NoError(t, err)
+		require.Equal(t, uint64(18), sth.InteractionUsed())
+	})
+}
+
+func TestStateHolder_EffortProfiling(t *testing.T) {
+	view := utils.NewSimpleView()
+	sth := state.NewStateHolder(state.NewState(view))
+
+	// effort is not metered unless profiling is enabled
+	_, err := sth.Get("1", "2", "3")
+	require.NoError(t, err)
+	sth.MeterEffort(state.ComputationKindCryptoOperation, 1)
+	require.Nil(t, sth.EffortBreakdown())
+
+	sth.EnableEffortProfiling()
+
+	_, err = sth.Get("1", "2", "3")
+	require.NoError(t, err)
+	sth.NewChild()
+	err = sth.Set("2", "3", "4", []byte{'A'})
+	require.NoError(t, err)
+	sth.MeterEffort(state.ComputationKindFunctionInvocation, 5)
+	sth.MeterEffort(state.ComputationKindCryptoOperation, 2)
+
+	require.Equal(t, map[string]uint64{
+		"ledger_interaction":  2,
+		"function_invocation": 5,
+		"crypto_operation":    2,
+	}, sth.EffortBreakdown())
+}
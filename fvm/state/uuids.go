@@ -21,7 +21,7 @@ func NewUUIDGenerator(stateHolder *StateHolder) *UUIDGenerator {
 
 // GetUUID reads uint64 byte value for uuid from the state
 func (u *UUIDGenerator) GetUUID() (uint64, error) {
-	stateBytes, err := u.stateHolder.State().Get("", "", keyUUID, u.stateHolder.EnforceInteractionLimits())
+	stateBytes, err := u.stateHolder.Get("", "", keyUUID)
 	if err != nil {
 		return 0, fmt.Errorf("cannot get uuid byte from state: %w", err)
 	}
@@ -34,7 +34,7 @@ func (u *UUIDGenerator) GetUUID() (uint64, error) {
 func (u *UUIDGenerator) SetUUID(uuid uint64) error {
 	bytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(bytes, uuid)
-	err := u.stateHolder.State().Set("", "", keyUUID, bytes, u.stateHolder.EnforceInteractionLimits())
+	err := u.stateHolder.Set("", "", keyUUID, bytes)
 	if err != nil {
 		return fmt.Errorf("cannot set uuid byte to state: %w", err)
 	}
@@ -33,6 +33,12 @@ type TransactionProcedure struct {
 	Events          []flow.Event
 	ServiceEvents   []flow.Event
 	ComputationUsed uint64
+	InteractionUsed uint64
+	// ComputationBreakdown is the execution effort of the transaction keyed by category, see
+	// state.ComputationKind. It is only populated if execution effort profiling is enabled.
+	ComputationBreakdown map[string]uint64
+	// ExecutionEffort is the sum of the ComputationBreakdown.
+	ExecutionEffort uint64
 	Err             errors.Error
 	Retried         int
 	TraceSpan       opentracing.Span
@@ -61,6 +67,10 @@ func (proc *TransactionProcedure) Run(vm *VirtualMachine, ctx Context, st *state
 		st.SetPayerIsServiceAccount()
 	}
 
+	if ctx.ExecutionEffortProfiling {
+		defer proc.recordExecutionEffort(st)
+	}
+
 	for _, p := range ctx.TransactionProcessors {
 		err := p.Process(vm, &ctx, proc, st, programs)
 		txErr, failure := errors.SplitErrorTypes(err)
@@ -79,3 +89,12 @@ func (proc *TransactionProcedure) Run(vm *VirtualMachine, ctx Context, st *state
 
 	return nil
 }
+
+// recordExecutionEffort records the execution effort accumulated by the state holder.
+func (proc *TransactionProcedure) recordExecutionEffort(sth *state.StateHolder) {
+	proc.ComputationBreakdown = sth.EffortBreakdown()
+	proc.ExecutionEffort = 0
+	for _, amount := range proc.ComputationBreakdown {
+		proc.ExecutionEffort += amount
+	}
+}
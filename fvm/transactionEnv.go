@@ -74,7 +74,7 @@ func NewTransactionEnvironment(
 		ctx.ServiceEventCollectionEnabled,
 		ctx.EventCollectionByteSizeLimit,
 	)
-	accountKeys := handler.NewAccountKeyHandler(accounts)
+	accountKeys := handler.NewAccountKeyHandler(accounts, ctx.MaxAccountKeyCount, ctx.AccountKeyChecksEnabled)
 	metrics := handler.NewMetricsHandler(ctx.Metrics)
 	computationHandler := handler.NewComputationMeteringHandler(computationLimit(ctx, tx))
 
@@ -540,6 +540,7 @@ func (e *TransactionEnv) GetComputationLimit() uint64 {
 }
 
 func (e *TransactionEnv) SetComputationUsed(used uint64) error {
+	e.sth.MeterEffort(state.ComputationKindFunctionInvocation, used)
 	return e.computationHandler.AddUsed(used)
 }
 
@@ -589,6 +590,8 @@ func (e *TransactionEnv) Hash(data []byte, tag string, hashAlgorithm runtime.Has
 		defer sp.Finish()
 	}
 
+	e.sth.MeterEffort(state.ComputationKindCryptoOperation, 1)
+
 	hashAlgo := crypto.RuntimeToCryptoHashingAlgorithm(hashAlgorithm)
 	return crypto.HashWithTag(hashAlgo, tag, data)
 }
@@ -606,6 +609,8 @@ func (e *TransactionEnv) VerifySignature(
 		defer sp.Finish()
 	}
 
+	e.sth.MeterEffort(state.ComputationKindCryptoOperation, 1)
+
 	valid, err := crypto.VerifySignatureFromRuntime(
 		e.ctx.SignatureVerifier,
 		signature,
@@ -811,6 +816,15 @@ func (e *TransactionEnv) GetAccountKey(address runtime.Address, keyIndex int) (*
 	return accKey, err
 }
 
+// CountAccountKeys returns the number of keys of an existing account, including the revoked ones.
+func (e *TransactionEnv) CountAccountKeys(address runtime.Address) (uint64, error) {
+	count, err := e.accountKeys.CountAccountKeys(address)
+	if err != nil {
+		return 0, fmt.Errorf("counting account keys failed: %w", err)
+	}
+	return count, nil
+}
+
 // RevokeAccountKey revokes a public key by index from an existing account,
 // and returns the revoked key.
 //
@@ -83,17 +83,18 @@ func (i *TransactionInvoker) Process(
 			proc.Events = make([]flow.Event, 0)
 			proc.ServiceEvents = make([]flow.Event, 0)
 		}
-		if mergeError := parentState.MergeState(childState, sth.EnforceInteractionLimits()); mergeError != nil {
+		if mergeError := sth.CommitChild(parentState, childState); mergeError != nil {
 			processErr = fmt.Errorf("transaction invocation failed: %w", mergeError)
 		}
 		sth.SetActiveState(parentState)
+		proc.InteractionUsed = sth.InteractionUsed()
 		sth.EnableLimitEnforcement()
 	}()
 
 	for numberOfRetries = 0; numberOfRetries < int(ctx.MaxNumOfTxRetries); numberOfRetries++ {
 		if retry {
 			// rest state
-			sth.SetActiveState(parentState)
+			sth.DiscardChildren(parentState)
 			childState = sth.NewChild()
 			// force cleanup if retries
 			programs.ForceCleanup()
@@ -102,7 +103,7 @@ func (i *TransactionInvoker) Process(
 				Str("txHash", txIDStr).
 				Uint64("blockHeight", blockHeight).
 				Int("retries_count", numberOfRetries).
-				Uint64("ledger_interaction_used", sth.State().InteractionUsed()).
+				Uint64("ledger_interaction_used", sth.InteractionUsed()).
 				Msg("retrying transaction execution")
 
 			// reset error part of proc
@@ -186,7 +187,7 @@ func (i *TransactionInvoker) Process(
 		i.logger.Info().
 			Str("txHash", txIDStr).
 			Uint64("blockHeight", blockHeight).
-			Uint64("ledgerInteractionUsed", sth.State().InteractionUsed()).
+			Uint64("ledgerInteractionUsed", sth.InteractionUsed()).
 			Msg("transaction executed with error")
 
 		// reset env
@@ -208,7 +209,7 @@ func (i *TransactionInvoker) Process(
 			i.logger.Info().
 				Str("txHash", txIDStr).
 				Uint64("blockHeight", blockHeight).
-				Uint64("ledgerInteractionUsed", sth.State().InteractionUsed()).
+				Uint64("ledgerInteractionUsed", sth.InteractionUsed()).
 				Msg("transaction fee deduction executed with error")
 
 			return feesError
@@ -218,7 +219,7 @@ func (i *TransactionInvoker) Process(
 		i.logger.Info().
 			Str("txHash", txIDStr).
 			Uint64("blockHeight", blockHeight).
-			Uint64("ledgerInteractionUsed", sth.State().InteractionUsed()).
+			Uint64("ledgerInteractionUsed", sth.InteractionUsed()).
 			Int("retried", proc.Retried).
 			Msg("transaction executed successfully")
 	}
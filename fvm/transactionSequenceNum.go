@@ -39,7 +39,7 @@ func (c *TransactionSequenceNumberChecker) checkAndIncrementSequenceNumber(
 	parentState := sth.State()
 	childState := sth.NewChild()
 	defer func() {
-		if mergeError := parentState.MergeState(childState, sth.EnforceInteractionLimits()); mergeError != nil {
+		if mergeError := sth.CommitChild(parentState, childState); mergeError != nil {
 			panic(mergeError)
 		}
 		sth.SetActiveState(parentState)
@@ -71,6 +71,8 @@ func (v *TransactionSignatureVerifier) verifyTransactionSignatures(
 		return fmt.Errorf("transaction verification failed: %w", err)
 	}
 
+	sth.MeterEffort(state.ComputationKindCryptoOperation, uint64(len(tx.PayloadSignatures)+len(tx.EnvelopeSignatures)))
+
 	payloadWeights, proposalKeyVerifiedInPayload, err = v.aggregateAccountSignatures(
 		accounts,
 		tx.PayloadSignatures,
@@ -452,11 +452,13 @@ func (s *DKGSuite) initEngines(node *node, ids flow.IdentityList) {
 		core.Me,
 		core.State,
 		dkgState,
+		dkgState,
 		dkg.NewControllerFactory(
 			controllerFactoryLogger,
 			core.Me,
 			[]module.DKGContractClient{node.dkgContractClient},
 			brokerTunnel,
+			dkgState,
 			config,
 		),
 		viewsObserver,
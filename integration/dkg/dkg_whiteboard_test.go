@@ -149,11 +149,13 @@ func createNode(
 		core.Me,
 		core.State,
 		dkgState,
+		dkgState,
 		dkg.NewControllerFactory(
 			controllerFactoryLogger,
 			core.Me,
 			[]module.DKGContractClient{NewWhiteboardClient(id.NodeID, whiteboard)},
 			brokerTunnel,
+			dkgState,
 			config,
 		),
 		viewsObserver,
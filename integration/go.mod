@@ -15,6 +15,7 @@ require (
 	github.com/ethereum/go-ethereum v1.10.1 // indirect
 	github.com/go-openapi/strfmt v0.20.1 // indirect
 	github.com/go-test/deep v1.0.7 // indirect
+	github.com/hashicorp/go-multierror v1.1.1
 	github.com/jedib0t/go-pretty v4.3.0+incompatible
 	github.com/logrusorgru/aurora v2.0.3+incompatible // indirect
 	github.com/onflow/cadence v0.20.2
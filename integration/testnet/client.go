@@ -2,11 +2,14 @@ package testnet
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/onflow/flow-go-sdk/templates"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/cadence"
 	sdk "github.com/onflow/flow-go-sdk"
@@ -247,6 +250,28 @@ func (c *Client) GetLatestBlockID(ctx context.Context) (flow.Identifier, error)
 	return id, nil
 }
 
+// GetLatestSealedHeader returns the header of the latest sealed block, which is the lowest block of the
+// sealing segment of the latest protocol state snapshot. Unlike the block headers of the Access API, it
+// includes the view of the block.
+func (c *Client) GetLatestSealedHeader(ctx context.Context) (*flow.Header, error) {
+	snapshot, err := c.GetLatestProtocolSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	segment, err := snapshot.SealingSegment()
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealing segment: %w", err)
+	}
+
+	return segment.Lowest().Header, nil
+}
+
+// GetTransactionResult returns the current result of the transaction with the given ID.
+func (c *Client) GetTransactionResult(ctx context.Context, id sdk.Identifier) (*sdk.TransactionResult, error) {
+	return c.client.GetTransactionResult(ctx, id)
+}
+
 func (c *Client) UserAddress(txResp *sdk.TransactionResult) (sdk.Address, bool) {
 	var (
 		address sdk.Address
@@ -297,6 +322,72 @@ func (c *Client) GetAccount(accountAddress sdk.Address) (*sdk.Account, error) {
 	return account, nil
 }
 
+// HeightNotIndexedError is returned when the access node has no state for the requested height,
+// either because the height is below its earliest indexed height, or because no block at the
+// height has been finalized yet.
+type HeightNotIndexedError struct {
+	Height uint64
+	Err    error
+}
+
+func (e HeightNotIndexedError) Error() string {
+	return fmt.Sprintf("height %d is not indexed by the access node: %v", e.Height, e.Err)
+}
+
+func (e HeightNotIndexedError) Unwrap() error {
+	return e.Err
+}
+
+// IsHeightNotIndexedError returns whether the given error is a HeightNotIndexedError.
+func IsHeightNotIndexedError(err error) bool {
+	var notIndexedErr HeightNotIndexedError
+	return errors.As(err, &notIndexedErr)
+}
+
+// GetAccountAtHeight returns the account with the given address as of the finalized block at the
+// given height, including its keys and deployed contracts. If the access node has no state for
+// the height, a HeightNotIndexedError is returned.
+func (c *Client) GetAccountAtHeight(ctx context.Context, address sdk.Address, height uint64) (*sdk.Account, error) {
+	account, err := c.client.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.OutOfRange:
+			return nil, HeightNotIndexedError{Height: height, Err: err}
+		}
+		return nil, fmt.Errorf("could not get account at height %d: %w", height, err)
+	}
+
+	return account, nil
+}
+
+// WaitForAccountKeyCount polls the account with the given address as of the latest block, until it
+// has the given number of keys, including revoked keys. It returns the account once it has that
+// many keys, or an error if it didn't within the timeout.
+func (c *Client) WaitForAccountKeyCount(ctx context.Context, address sdk.Address, count int, timeout time.Duration) (*sdk.Account, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	keys := 0
+	for {
+		account, err := c.client.GetAccountAtLatestBlock(ctx, address)
+		if err == nil {
+			if len(account.Keys) == count {
+				return account, nil
+			}
+			keys = len(account.Keys)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("account %s did not have %d keys within %s (last seen: %d keys): %w", address, count, timeout, keys, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 func (c *Client) CreateAccount(
 	ctx context.Context,
 	accountKey *sdk.AccountKey,
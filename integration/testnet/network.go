@@ -114,6 +114,7 @@ type FlowNetwork struct {
 	seal                       *flow.Seal
 	BootstrapDir               string
 	BootstrapSnapshot          *inmem.Snapshot
+	partition                  *partition // current partition of the network, if any
 }
 
 // Identities returns a list of identities, one for each node in the network.
@@ -186,6 +187,13 @@ func (net *FlowNetwork) RemoveContainers() {
 
 	err := net.suite.Remove()
 	assert.NoError(net.t, err)
+
+	// remove the partition network, which is not managed by the suite
+	if net.partition != nil {
+		err = net.cli.NetworkRemove(context.Background(), net.partition.networkID)
+		assert.NoError(net.t, err)
+		net.partition = nil
+	}
 }
 
 // DropDBs resets the protocol state database for all containers in the network
@@ -510,7 +518,7 @@ func PrepareFlowNetwork(t *testing.T, networkConf NetworkConfig) *FlowNetwork {
 		seal:                       seal,
 		result:                     result,
 		BootstrapDir:               bootstrapDir,
-		BootstrapSnapshot: 			bootstrapSnapshot,
+		BootstrapSnapshot:          bootstrapSnapshot,
 	}
 
 	// check that at-least 2 full access nodes must be configure in your test suite
@@ -0,0 +1,181 @@
+package testnet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// partition is a network partition, for which the containers of one group were moved from the
+// network to a dedicated partition network.
+type partition struct {
+	networkID  string
+	containers []*Container
+}
+
+// PartitionNodes partitions the network into two groups of nodes, which can't communicate with
+// each other, while the nodes of each group remain connected among themselves.
+// The nodes of group A remain connected to the network, and hence to all other nodes which are
+// in neither group. The nodes of group B are moved to a dedicated partition network, where they
+// remain reachable by their container names.
+// Only one partition can exist at a time, it is removed with Heal.
+func (net *FlowNetwork) PartitionNodes(ctx context.Context, groupA, groupB []flow.Identifier) error {
+	if net.partition != nil {
+		return fmt.Errorf("network is already partitioned")
+	}
+	if len(groupA) == 0 || len(groupB) == 0 {
+		return fmt.Errorf("partition groups must not be empty")
+	}
+	inGroupA := make(map[flow.Identifier]struct{}, len(groupA))
+	for _, nodeID := range groupA {
+		inGroupA[nodeID] = struct{}{}
+	}
+	containers := make([]*Container, 0, len(groupB))
+	for _, nodeID := range groupB {
+		if _, ok := inGroupA[nodeID]; ok {
+			return fmt.Errorf("node %x is in both partition groups", nodeID)
+		}
+		containers = append(containers, net.ContainerByID(nodeID))
+	}
+
+	res, err := net.cli.NetworkCreate(ctx, net.config.Name+"-partition", types.NetworkCreate{
+		CheckDuplicate: true,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create partition network: %w", err)
+	}
+	net.partition = &partition{
+		networkID:  res.ID,
+		containers: containers,
+	}
+
+	for _, c := range containers {
+		err = c.moveNetwork(ctx, net.network.ID(), res.ID)
+		if err != nil {
+			return fmt.Errorf("could not partition container (%s): %w", c.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Heal removes the partition of the network, which was created with PartitionNodes, by moving
+// the partitioned nodes back to the network.
+func (net *FlowNetwork) Heal(ctx context.Context) error {
+	if net.partition == nil {
+		return fmt.Errorf("network is not partitioned")
+	}
+
+	for _, c := range net.partition.containers {
+		err := c.moveNetwork(ctx, net.partition.networkID, net.network.ID())
+		if err != nil {
+			return fmt.Errorf("could not heal container (%s): %w", c.Name(), err)
+		}
+	}
+
+	err := net.cli.NetworkRemove(ctx, net.partition.networkID)
+	if err != nil {
+		return fmt.Errorf("could not remove partition network: %w", err)
+	}
+	net.partition = nil
+
+	return nil
+}
+
+// AwaitPartitionEffective waits until none of the containers of group A shares a network with
+// any of the containers of group B. From then on, packets between the groups are not routed
+// anymore, so that cross-group libp2p and gRPC connections can't carry any traffic and are torn
+// down by their keep-alive timeouts. Returns an error if the context expires before.
+func (net *FlowNetwork) AwaitPartitionEffective(ctx context.Context, groupA, groupB []flow.Identifier) error {
+	retryAfter := checkContainerPeriod
+	for {
+		shared, err := net.sharedNetworks(ctx, groupA, groupB)
+		if err != nil {
+			return err
+		}
+		if len(shared) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("partition not effective, groups still share networks %v", shared)
+		case <-time.After(retryAfter):
+			retryAfter *= 2
+			continue
+		}
+	}
+}
+
+// sharedNetworks returns the networks, which containers of both groups are connected to.
+func (net *FlowNetwork) sharedNetworks(ctx context.Context, groupA, groupB []flow.Identifier) ([]string, error) {
+	networksOf := func(group []flow.Identifier) (map[string]struct{}, error) {
+		networks := make(map[string]struct{})
+		for _, nodeID := range group {
+			c := net.ContainerByID(nodeID)
+			res, err := net.cli.ContainerInspect(ctx, c.ID)
+			if err != nil {
+				return nil, fmt.Errorf("could not inspect container (%s): %w", c.Name(), err)
+			}
+			for name := range res.NetworkSettings.Networks {
+				networks[name] = struct{}{}
+			}
+		}
+		return networks, nil
+	}
+
+	networksA, err := networksOf(groupA)
+	if err != nil {
+		return nil, err
+	}
+	networksB, err := networksOf(groupB)
+	if err != nil {
+		return nil, err
+	}
+	var shared []string
+	for name := range networksB {
+		if _, ok := networksA[name]; ok {
+			shared = append(shared, name)
+		}
+	}
+	return shared, nil
+}
+
+// moveNetwork connects this container to the target network, where it is reachable by its
+// name, and then disconnects it from the source network. Connecting first makes sure that the
+// container is never detached from all networks.
+func (c *Container) moveNetwork(ctx context.Context, sourceID, targetID string) error {
+	ctx, cancel := context.WithTimeout(ctx, checkContainerTimeout)
+	defer cancel()
+
+	err := c.net.cli.NetworkConnect(ctx, targetID, c.ID, &network.EndpointSettings{
+		Aliases: []string{c.Name()},
+	})
+	if err != nil {
+		return fmt.Errorf("could not connect to network: %w", err)
+	}
+	err = c.net.cli.NetworkDisconnect(ctx, sourceID, c.ID, false)
+	if err != nil {
+		return fmt.Errorf("could not disconnect from network: %w", err)
+	}
+
+	err = c.waitForCondition(ctx, func(state *types.ContainerJSON) bool {
+		if len(state.NetworkSettings.Networks) != 1 {
+			return false
+		}
+		for _, settings := range state.NetworkSettings.Networks {
+			return settings.NetworkID == targetID
+		}
+		return false
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for container to move networks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/integration/testnet"
+)
+
+// TestAccountKeysAtHeight adds a key to the service account, and checks that the account state
+// at the heights before and after the transaction reflects the number of keys at that height.
+func TestAccountKeysAtHeight(t *testing.T) {
+	flowNetwork := testnet.PrepareFlowNetwork(t, buildMVPNetConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	flowNetwork.Start(ctx)
+	defer flowNetwork.Remove()
+
+	chain := flowNetwork.Root().Header.ChainID.Chain()
+	client, err := testnet.NewClient(fmt.Sprintf(":%s", flowNetwork.AccessPorts[testnet.AccessNodeAPIPort]), chain)
+	require.NoError(t, err)
+
+	// wait for the network to seal the first block after the root block
+	sealed, err := WaitForSealedHeight(ctx, client, flowNetwork.Root().Header.Height+1, sealingTimeout)
+	require.NoError(t, err)
+
+	serviceAddress := sdk.Address(chain.ServiceAddress())
+	before, err := client.GetAccountAtHeight(ctx, serviceAddress, sealed.Height)
+	require.NoError(t, err)
+
+	accountKey := sdk.NewAccountKey().
+		FromPrivateKey(RandomPrivateKey()).
+		SetHashAlgo(sdkcrypto.SHA3_256).
+		SetWeight(sdk.AccountKeyWeightThreshold)
+
+	addKeyTx := templates.AddAccountKey(serviceAddress, accountKey).
+		SetReferenceBlockID(sdk.Identifier(sealed.ID())).
+		SetProposalKey(serviceAddress, 0, client.GetSeqNumber()).
+		SetPayer(serviceAddress).
+		SetGasLimit(9999)
+	sendAndWaitSealed(t, ctx, client, addKeyTx)
+
+	_, err = client.WaitForAccountKeyCount(ctx, serviceAddress, len(before.Keys)+1, sealingTimeout)
+	require.NoError(t, err)
+
+	latest, err := client.GetLatestSealedHeader(ctx)
+	require.NoError(t, err)
+	after, err := client.GetAccountAtHeight(ctx, serviceAddress, latest.Height)
+	require.NoError(t, err)
+
+	// the key count at the height before the transaction is unchanged
+	previous, err := client.GetAccountAtHeight(ctx, serviceAddress, sealed.Height)
+	require.NoError(t, err)
+	require.Len(t, previous.Keys, len(before.Keys))
+	require.Len(t, after.Keys, len(before.Keys)+1)
+
+	added := after.Keys[len(after.Keys)-1]
+	require.Equal(t, len(before.Keys), added.Index)
+	require.Equal(t, sdk.AccountKeyWeightThreshold, added.Weight)
+	require.False(t, added.Revoked)
+	require.Equal(t, uint64(0), added.SequenceNumber)
+
+	// heights beyond the latest finalized block are not indexed by the access node
+	_, err = client.GetAccountAtHeight(ctx, serviceAddress, latest.Height+1_000_000)
+	require.Error(t, err)
+	require.True(t, testnet.IsHeightNotIndexedError(err), err)
+}
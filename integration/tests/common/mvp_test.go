@@ -25,6 +25,9 @@ import (
 // timeout for individual actions
 const defaultTimeout = time.Second * 10
 
+// timeout for transactions to be sealed
+const sealingTimeout = time.Minute * 2
+
 func TestMVP_Network(t *testing.T) {
 	flowNetwork := testnet.PrepareFlowNetwork(t, buildMVPNetConfig())
 
@@ -136,8 +139,10 @@ func runMVPTest(t *testing.T, ctx context.Context, net *testnet.FlowNetwork) {
 	serviceAccountClient, err := testnet.NewClient(fmt.Sprintf(":%s", net.AccessPorts[testnet.AccessNodeAPIPort]), chain)
 	require.NoError(t, err)
 
-	latestBlockID, err := serviceAccountClient.GetLatestBlockID(ctx)
+	// wait for the network to seal the first block after the root block
+	sealed, err := WaitForSealedHeight(ctx, serviceAccountClient, net.Root().Header.Height+1, sealingTimeout)
 	require.NoError(t, err)
+	latestBlockID := sealed.ID()
 
 	// create new account to deploy Counter to
 	accountPrivateKey := RandomPrivateKey()
@@ -164,15 +169,8 @@ func runMVPTest(t *testing.T, ctx context.Context, net *testnet.FlowNetwork) {
 		SetPayer(serviceAddress).
 		SetGasLimit(9999)
 
-	childCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
-	err = serviceAccountClient.SignAndSendTransaction(ctx, createAccountTx)
-	require.NoError(t, err)
-
-	cancel()
-
 	// wait for account to be created
-	accountCreationTxRes, err := serviceAccountClient.WaitForSealed(context.Background(), createAccountTx.ID())
-	require.NoError(t, err)
+	accountCreationTxRes := sendAndWaitSealed(t, ctx, serviceAccountClient, createAccountTx)
 	t.Log(accountCreationTxRes)
 
 	var newAccountAddress sdk.Address
@@ -222,14 +220,7 @@ func runMVPTest(t *testing.T, ctx context.Context, net *testnet.FlowNetwork) {
 
 	t.Log(">> funding new account...")
 
-	childCtx, cancel = context.WithTimeout(ctx, defaultTimeout)
-	err = serviceAccountClient.SignAndSendTransaction(ctx, fundAccountTx)
-	require.NoError(t, err)
-
-	cancel()
-
-	fundCreationTxRes, err := serviceAccountClient.WaitForSealed(context.Background(), fundAccountTx.ID())
-	require.NoError(t, err)
+	fundCreationTxRes := sendAndWaitSealed(t, ctx, serviceAccountClient, fundAccountTx)
 	t.Log(fundCreationTxRes)
 
 	accountClient, err := testnet.NewClientWithKey(
@@ -241,7 +232,7 @@ func runMVPTest(t *testing.T, ctx context.Context, net *testnet.FlowNetwork) {
 	require.NoError(t, err)
 
 	// contract is deployed, but no instance is created yet
-	childCtx, cancel = context.WithTimeout(ctx, defaultTimeout)
+	childCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	counter, err := readCounter(childCtx, accountClient, newAccountAddress)
 	cancel()
 	require.NoError(t, err)
@@ -258,26 +249,26 @@ func runMVPTest(t *testing.T, ctx context.Context, net *testnet.FlowNetwork) {
 
 	t.Log(">> creating counter...")
 
+	resp := sendAndWaitSealed(t, ctx, accountClient, createCounterTx)
+	t.Log(resp)
+
+	// the counter is created and incremented as of the latest sealed block, which includes the sealed transaction
 	childCtx, cancel = context.WithTimeout(ctx, defaultTimeout)
-	err = accountClient.SignAndSendTransaction(ctx, createCounterTx)
+	counter, err = readCounter(childCtx, serviceAccountClient, newAccountAddress)
 	cancel()
-
 	require.NoError(t, err)
+	require.Equal(t, 2, counter)
+}
 
-	resp, err := accountClient.WaitForSealed(context.Background(), createCounterTx.ID())
+// sendAndWaitSealed signs and submits the transaction, and waits until it is sealed without an error.
+func sendAndWaitSealed(t *testing.T, ctx context.Context, client *testnet.Client, tx *sdk.Transaction) *sdk.TransactionResult {
+	tx, err := client.SignTransaction(tx)
 	require.NoError(t, err)
 
-	require.NoError(t, resp.Error)
-	t.Log(resp)
-
-	t.Log(">> awaiting counter incrementing...")
-
-	// counter is created and incremented eventually
-	require.Eventually(t, func() bool {
-		childCtx, cancel = context.WithTimeout(ctx, defaultTimeout)
-		counter, err = readCounter(ctx, serviceAccountClient, newAccountAddress)
-		cancel()
+	ctx, cancel := context.WithTimeout(ctx, sealingTimeout)
+	defer cancel()
 
-		return err == nil && counter == 2
-	}, 30*time.Second, time.Second)
+	results, err := SubmitAndWaitAll(ctx, client, []*sdk.Transaction{tx}, 1)
+	require.NoError(t, err)
+	return results[0]
 }
@@ -0,0 +1,212 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	sdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// sealedPollInterval is the interval at which the wait helpers poll the latest sealed block.
+var sealedPollInterval = 500 * time.Millisecond
+
+// SealingClient is the subset of the Access API used by the helpers waiting for blocks and
+// transactions to be sealed. It is implemented by testnet.Client.
+type SealingClient interface {
+	// GetLatestSealedHeader returns the header of the latest sealed block.
+	GetLatestSealedHeader(ctx context.Context) (*flow.Header, error)
+	// SendTransaction submits a signed transaction.
+	SendTransaction(ctx context.Context, tx *sdk.Transaction) error
+	// GetTransactionResult returns the current result of the transaction with the given ID.
+	GetTransactionResult(ctx context.Context, txID sdk.Identifier) (*sdk.TransactionResult, error)
+}
+
+// TransactionError attributes an error to the transaction which caused it.
+type TransactionError struct {
+	TxID sdk.Identifier
+	Err  error
+}
+
+func (e TransactionError) Error() string {
+	return fmt.Sprintf("transaction %s: %v", e.TxID, e.Err)
+}
+
+func (e TransactionError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForSealedHeight blocks until the latest sealed block is at or above the given height and returns
+// its header. It returns an error if this does not happen within the timeout or before the context is done.
+func WaitForSealedHeight(ctx context.Context, client SealingClient, height uint64, timeout time.Duration) (*flow.Header, error) {
+	sealed, err := waitForSealed(ctx, client, timeout, func(header *flow.Header) bool {
+		return header.Height >= height
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not wait for sealed height %d (latest sealed: %s): %w", height, describeHeader(sealed), err)
+	}
+	return sealed, nil
+}
+
+// WaitForSealedView blocks until the latest sealed block is at or above the given view and returns
+// its header. It returns an error if this does not happen within the timeout or before the context is done.
+func WaitForSealedView(ctx context.Context, client SealingClient, view uint64, timeout time.Duration) (*flow.Header, error) {
+	sealed, err := waitForSealed(ctx, client, timeout, func(header *flow.Header) bool {
+		return header.View >= view
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not wait for sealed view %d (latest sealed: %s): %w", view, describeHeader(sealed), err)
+	}
+	return sealed, nil
+}
+
+// waitForSealed polls the latest sealed block until it satisfies the given condition. It returns the
+// latest sealed header which was observed, also if the condition was not satisfied in time.
+func waitForSealed(ctx context.Context, client SealingClient, timeout time.Duration, condition func(*flow.Header) bool) (*flow.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var sealed *flow.Header
+	err := pollSealed(ctx, client, func(header *flow.Header) bool {
+		sealed = header
+		return condition(header)
+	})
+	return sealed, err
+}
+
+// SubmitAndWaitAll submits the given signed transactions, with at most the given number of requests in
+// flight at any time, and waits until each transaction is sealed. It returns the results in the order of
+// the given transactions. Transactions which could not be submitted, failed or were not sealed before the
+// context is done have a nil result, and their errors are aggregated, each attributed to its transaction
+// by a TransactionError. Sealed transactions whose execution failed have both their result and an error.
+func SubmitAndWaitAll(ctx context.Context, client SealingClient, txs []*sdk.Transaction, concurrency int) ([]*sdk.TransactionResult, error) {
+	results := make([]*sdk.TransactionResult, len(txs))
+	errs := make([]error, len(txs))
+
+	// submit all transactions, remembering the ones which were accepted
+	forEach(len(txs), concurrency, func(i int) {
+		err := client.SendTransaction(ctx, txs[i])
+		if err != nil {
+			errs[i] = fmt.Errorf("could not submit: %w", err)
+		}
+	})
+
+	pending := make(map[int]struct{}, len(txs))
+	for i := range txs {
+		if errs[i] == nil {
+			pending[i] = struct{}{}
+		}
+	}
+
+	// the results are only queried when a new block is sealed, so that all transactions share
+	// the single poll of the latest sealed block
+	var mu sync.Mutex
+	err := pollSealed(ctx, client, func(*flow.Header) bool {
+		indices := make([]int, 0, len(pending))
+		for i := range pending {
+			indices = append(indices, i)
+		}
+		forEach(len(indices), concurrency, func(j int) {
+			i := indices[j]
+			result, err := client.GetTransactionResult(ctx, txs[i].ID())
+			if err != nil {
+				// the result is queried again once the next block is sealed
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch result.Status {
+			case sdk.TransactionStatusSealed:
+				results[i] = result
+				if result.Error != nil {
+					errs[i] = fmt.Errorf("execution failed: %w", result.Error)
+				}
+			case sdk.TransactionStatusExpired:
+				errs[i] = fmt.Errorf("transaction expired")
+			default:
+				return
+			}
+			delete(pending, i)
+		})
+		return len(pending) == 0
+	})
+	if err != nil {
+		for i := range pending {
+			errs[i] = fmt.Errorf("not sealed: %w", err)
+		}
+	}
+
+	var merr *multierror.Error
+	for i, err := range errs {
+		if err != nil {
+			merr = multierror.Append(merr, TransactionError{TxID: txs[i].ID(), Err: err})
+		}
+	}
+	return results, merr.ErrorOrNil()
+}
+
+// pollSealed polls the latest sealed block and calls onSealed whenever a new block was sealed, until
+// onSealed returns true or the context is done. Errors querying the latest sealed block are retried
+// at the next poll.
+func pollSealed(ctx context.Context, client SealingClient, onSealed func(*flow.Header) bool) error {
+	ticker := time.NewTicker(sealedPollInterval)
+	defer ticker.Stop()
+
+	var lastID flow.Identifier
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		header, err := client.GetLatestSealedHeader(ctx)
+		if err == nil && header.ID() != lastID {
+			lastID = header.ID()
+			if onSealed(header) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// forEach calls f for each index in [0, n), with at most the given number of calls running concurrently.
+func forEach(n int, concurrency int, f func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func describeHeader(header *flow.Header) string {
+	if header == nil {
+		return "none"
+	}
+	return fmt.Sprintf("height %d, view %d", header.Height, header.View)
+}
@@ -0,0 +1,210 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	sdk "github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fakeSealingClient is a lightweight fake of the Access API. If sealing is enabled, every poll of the latest
+// sealed block seals a new block, which seals all transactions submitted so far.
+type fakeSealingClient struct {
+	mu       sync.Mutex
+	sealing  bool
+	sealed   *flow.Header
+	failures map[sdk.Identifier]error // execution errors of transactions
+	sent     map[sdk.Identifier]bool  // whether the transaction was sealed
+	inFlight int
+	// maxInFlight is the maximum number of concurrent submissions
+	maxInFlight int
+	// polls is the number of polls of the latest sealed block
+	polls int
+}
+
+func newFakeSealingClient(sealing bool) *fakeSealingClient {
+	header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(0))
+	return &fakeSealingClient{
+		sealing:  sealing,
+		sealed:   &header,
+		failures: make(map[sdk.Identifier]error),
+		sent:     make(map[sdk.Identifier]bool),
+	}
+}
+
+func (c *fakeSealingClient) GetLatestSealedHeader(_ context.Context) (*flow.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.polls++
+	if c.sealing {
+		header := unittest.BlockHeaderWithParentFixture(c.sealed)
+		c.sealed = &header
+		for txID := range c.sent {
+			c.sent[txID] = true
+		}
+	}
+	return c.sealed, nil
+}
+
+func (c *fakeSealingClient) SendTransaction(_ context.Context, tx *sdk.Transaction) error {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	c.sent[tx.ID()] = false
+	return nil
+}
+
+func (c *fakeSealingClient) GetTransactionResult(_ context.Context, txID sdk.Identifier) (*sdk.TransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sealed, ok := c.sent[txID]
+	if !ok {
+		return &sdk.TransactionResult{Status: sdk.TransactionStatusUnknown}, nil
+	}
+	if !sealed {
+		return &sdk.TransactionResult{Status: sdk.TransactionStatusPending}, nil
+	}
+	return &sdk.TransactionResult{Status: sdk.TransactionStatusSealed, Error: c.failures[txID]}, nil
+}
+
+func transactionsFixture(n int) []*sdk.Transaction {
+	txs := make([]*sdk.Transaction, n)
+	for i := range txs {
+		txs[i] = sdk.NewTransaction().SetScript([]byte(fmt.Sprintf("transaction { execute { log(%d) } }", i)))
+	}
+	return txs
+}
+
+// fastPolling reduces the poll interval of the wait helpers for the duration of a test.
+func fastPolling(t *testing.T) {
+	interval := sealedPollInterval
+	sealedPollInterval = time.Millisecond
+	t.Cleanup(func() {
+		sealedPollInterval = interval
+	})
+}
+
+func TestWaitForSealed(t *testing.T) {
+	fastPolling(t)
+
+	t.Run("sealed height", func(t *testing.T) {
+		client := newFakeSealingClient(true)
+		header, err := WaitForSealedHeight(context.Background(), client, 5, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), header.Height)
+	})
+
+	t.Run("sealed view", func(t *testing.T) {
+		client := newFakeSealingClient(true)
+		view := client.sealed.View + 5
+		header, err := WaitForSealedView(context.Background(), client, view, time.Second)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, header.View, view)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client := newFakeSealingClient(false)
+		start := time.Now()
+		_, err := WaitForSealedHeight(context.Background(), client, 5, 50*time.Millisecond)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		client := newFakeSealingClient(false)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := WaitForSealedView(ctx, client, client.sealed.View, time.Minute)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestSubmitAndWaitAll(t *testing.T) {
+	fastPolling(t)
+
+	t.Run("success", func(t *testing.T) {
+		client := newFakeSealingClient(true)
+		txs := transactionsFixture(10)
+
+		results, err := SubmitAndWaitAll(context.Background(), client, txs, 3)
+		require.NoError(t, err)
+		require.Len(t, results, len(txs))
+		for _, result := range results {
+			require.NotNil(t, result)
+			assert.Equal(t, sdk.TransactionStatusSealed, result.Status)
+		}
+		assert.LessOrEqual(t, client.maxInFlight, 3)
+		// all transactions share the poll of the latest sealed block, which seals them at once
+		assert.Equal(t, 1, client.polls)
+	})
+
+	t.Run("failing transaction", func(t *testing.T) {
+		client := newFakeSealingClient(true)
+		txs := transactionsFixture(5)
+		failure := errors.New("execution failed")
+		client.failures[txs[2].ID()] = failure
+
+		results, err := SubmitAndWaitAll(context.Background(), client, txs, 2)
+		require.Error(t, err)
+		for _, result := range results {
+			require.NotNil(t, result)
+		}
+		assert.Equal(t, failure, results[2].Error)
+
+		// the error is attributed to the failing transaction only
+		var merr *multierror.Error
+		require.True(t, errors.As(err, &merr))
+		require.Len(t, merr.Errors, 1)
+		var txErr TransactionError
+		require.True(t, errors.As(merr.Errors[0], &txErr))
+		assert.Equal(t, txs[2].ID(), txErr.TxID)
+		assert.True(t, errors.Is(err, failure))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client := newFakeSealingClient(false)
+		txs := transactionsFixture(3)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		results, err := SubmitAndWaitAll(ctx, client, txs, 3)
+		require.Error(t, err)
+		for _, result := range results {
+			assert.Nil(t, result)
+		}
+
+		var merr *multierror.Error
+		require.True(t, errors.As(err, &merr))
+		require.Len(t, merr.Errors, len(txs))
+		for i, err := range merr.Errors {
+			var txErr TransactionError
+			require.True(t, errors.As(err, &txErr))
+			assert.Equal(t, txs[i].ID(), txErr.TxID)
+			assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		}
+	})
+}
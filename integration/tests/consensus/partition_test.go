@@ -0,0 +1,198 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/engine/ghost/client"
+	"github.com/onflow/flow-go/integration/testnet"
+	"github.com/onflow/flow-go/integration/tests/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestConsensusPartition(t *testing.T) {
+	suite.Run(t, new(PartitionSuite))
+}
+
+// PartitionSuite tests that a consensus node catches up with the others, after it was
+// partitioned away from the network for a while.
+type PartitionSuite struct {
+	suite.Suite
+	cancel    context.CancelFunc
+	net       *testnet.FlowNetwork
+	conIDs    []flow.Identifier
+	ghostID   flow.Identifier
+	proposals chan *messages.BlockProposal
+}
+
+func (ps *PartitionSuite) Ghost() *client.GhostClient {
+	ghost := ps.net.ContainerByID(ps.ghostID)
+	client, err := common.GetGhostClient(ghost)
+	require.NoError(ps.T(), err, "could not get ghost client")
+	return client
+}
+
+func (ps *PartitionSuite) SetupTest() {
+
+	var nodeConfigs []testnet.NodeConfig
+
+	// need one execution node to observe block proposals (used ghost)
+	ps.ghostID = unittest.IdentifierFixture()
+	exeConfig := testnet.NewNodeConfig(flow.RoleExecution, testnet.WithLogLevel(zerolog.FatalLevel), testnet.WithID(ps.ghostID), testnet.AsGhost())
+	nodeConfigs = append(nodeConfigs, exeConfig)
+
+	// need one dummy verification node (unused ghost)
+	verConfig := testnet.NewNodeConfig(flow.RoleVerification, testnet.WithLogLevel(zerolog.FatalLevel), testnet.AsGhost())
+	nodeConfigs = append(nodeConfigs, verConfig)
+
+	// need four real consensus nodes, so that three of them can make progress without the fourth
+	ps.conIDs = nil
+	for n := 0; n < 4; n++ {
+		conID := unittest.IdentifierFixture()
+		nodeConfig := testnet.NewNodeConfig(flow.RoleConsensus, testnet.WithLogLevel(zerolog.WarnLevel), testnet.WithID(conID))
+		nodeConfigs = append(nodeConfigs, nodeConfig)
+		ps.conIDs = append(ps.conIDs, conID)
+	}
+
+	// need one dummy collection node (unused ghost)
+	collConfig := testnet.NewNodeConfig(flow.RoleCollection, testnet.WithLogLevel(zerolog.FatalLevel), testnet.AsGhost())
+	nodeConfigs = append(nodeConfigs, collConfig)
+
+	nodeConfigs = append(nodeConfigs,
+		testnet.NewNodeConfig(flow.RoleAccess, testnet.WithLogLevel(zerolog.FatalLevel)),
+	)
+
+	netConfig := testnet.NewNetworkConfig("consensus_partition", nodeConfigs)
+	ps.net = testnet.PrepareFlowNetwork(ps.T(), netConfig)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ps.cancel = cancel
+	ps.net.Start(ctx)
+
+	// subscribe to the ghost and forward all block proposals
+	var reader *client.FlowMessageStreamReader
+	for attempts := 0; ; attempts++ {
+		var err error
+		reader, err = ps.Ghost().Subscribe(context.Background())
+		if err == nil {
+			break
+		}
+		if attempts >= 10 {
+			require.NoError(ps.T(), err, "could not subscribe to ghost (%d attempts)", attempts)
+		}
+	}
+	ps.proposals = make(chan *messages.BlockProposal, 1000)
+	go func() {
+		for {
+			_, msg, err := reader.Next()
+			if err != nil {
+				return
+			}
+			proposal, ok := msg.(*messages.BlockProposal)
+			if !ok {
+				continue
+			}
+			select {
+			case ps.proposals <- proposal:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (ps *PartitionSuite) TearDownTest() {
+	ps.net.Remove()
+	ps.cancel()
+}
+
+// TestPartitionAndHeal partitions one consensus node away from the network for 30 seconds, and
+// checks that it catches up with the finalized height of the other nodes once the partition is
+// healed.
+func (ps *PartitionSuite) TestPartitionAndHeal() {
+
+	isolated := ps.conIDs[0]
+	var others []flow.Identifier
+	for _, identity := range ps.net.Identities() {
+		if identity.NodeID != isolated {
+			others = append(others, identity.NodeID)
+		}
+	}
+
+	// wait for the network to make progress
+	ps.awaitProposal(30*time.Second, func(*messages.BlockProposal) bool { return true })
+
+	// partition the node away from the network
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err := ps.net.PartitionNodes(ctx, others, []flow.Identifier{isolated})
+	require.NoError(ps.T(), err, "could not partition network")
+	err = ps.net.AwaitPartitionEffective(ctx, others, []flow.Identifier{isolated})
+	require.NoError(ps.T(), err, "partition did not become effective")
+
+	// the other nodes make progress without the isolated node
+	var height uint64
+	partitionEnd := time.After(30 * time.Second)
+PartitionLoop:
+	for {
+		select {
+		case proposal := <-ps.proposals:
+			require.NotEqual(ps.T(), isolated, proposal.Header.ProposerID, "isolated node should not reach the ghost")
+			if proposal.Header.Height > height {
+				height = proposal.Header.Height
+			}
+		case <-partitionEnd:
+			break PartitionLoop
+		}
+	}
+	ps.T().Logf("network reached height %d while partitioned", height)
+
+	// heal the partition
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err = ps.net.Heal(ctx)
+	require.NoError(ps.T(), err, "could not heal network")
+
+	// the isolated node can only propose blocks above the partition height, once it caught up
+	ps.awaitProposal(2*time.Minute, func(proposal *messages.BlockProposal) bool {
+		return proposal.Header.ProposerID == isolated && proposal.Header.Height > height
+	})
+
+	// let the network make a few more blocks of progress, so that the isolated node also
+	// finalizes the blocks up to the partition height
+	ps.awaitProposal(time.Minute, func(proposal *messages.BlockProposal) bool {
+		return proposal.Header.Height > height+10
+	})
+
+	// the finalized height of the isolated node reaches the height of the others
+	ps.net.StopContainers()
+	container := ps.net.ContainerByID(isolated)
+	state, err := container.OpenState()
+	require.NoError(ps.T(), err)
+	final, err := state.Final().Head()
+	require.NoError(ps.T(), err)
+	require.GreaterOrEqual(ps.T(), final.Height, height, "isolated node should have caught up with the finalized height of the network")
+}
+
+// awaitProposal waits until a block proposal satisfying the condition is observed by the ghost,
+// and fails the test after the timeout.
+func (ps *PartitionSuite) awaitProposal(timeout time.Duration, condition func(*messages.BlockProposal) bool) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case proposal := <-ps.proposals:
+			if condition(proposal) {
+				return
+			}
+		case <-deadline:
+			ps.T().Fatalf("no matching block proposal within %s", timeout)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/integration/tests/common"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
+	sigmessages "github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -229,8 +230,7 @@ SearchLoop:
 	}
 
 	// generates a signature over the execution result
-	id := receipt.ID()
-	sig, err := ss.exeSK.Sign(id[:], exeUtils.NewExecutionReceiptHasher())
+	sig, err := ss.exeSK.Sign(sigmessages.ExecutionReceiptMessage(receipt.Meta()), exeUtils.NewExecutionReceiptHasher())
 	require.NoError(ss.T(), err)
 
 	receipt.ExecutorSignature = sig
@@ -258,8 +258,7 @@ ReceiptLoop:
 	}
 
 	// generates a signature over the attestation part of approval
-	atstID := atst.ID()
-	atstSign, err := ss.verSK.Sign(atstID[:], verUtils.NewResultApprovalHasher())
+	atstSign, err := ss.verSK.Sign(sigmessages.AttestationMessage(&atst), verUtils.NewResultApprovalHasher())
 	require.NoError(ss.T(), err)
 
 	// result approval body
@@ -271,8 +270,7 @@ ReceiptLoop:
 	}
 
 	// generates a signature over result approval body
-	bodyID := body.ID()
-	bodySign, err := ss.verSK.Sign(bodyID[:], verUtils.NewResultApprovalHasher())
+	bodySign, err := ss.verSK.Sign(sigmessages.ResultApprovalMessage(&body), verUtils.NewResultApprovalHasher())
 	require.NoError(ss.T(), err)
 
 	approval := flow.ResultApproval{
@@ -1,6 +1,7 @@
 package complete
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"time"
@@ -214,6 +215,52 @@ func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
 	return proofToGo, err
 }
 
+// MaxScannedRegisters is the maximum number of registers visited by a single scan, matching or
+// not. As the registers matching a scan query are spread over the whole trie, it bounds the work
+// of a page, which then contains fewer registers than requested.
+const MaxScannedRegisters = 100_000
+
+// Scan returns a page of the registers at the state of the query, of which the keys start with
+// the key parts of the query. The registers are returned in ascending order of their paths, the
+// page token is the path of the last register visited for the previous page.
+func (l *Ledger) Scan(ctx context.Context, query *ledger.ScanQuery) (*ledger.ScanResult, error) {
+	start := time.Now()
+
+	var after *ledger.Path
+	if token := query.PageToken(); len(token) > 0 {
+		path, err := ledger.ToPath(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %w", err)
+		}
+		after = &path
+	}
+
+	match := func(payload *ledger.Payload) bool {
+		return query.Matches(payload.Key)
+	}
+	_, payloads, next, err := l.forest.Scan(ctx, ledger.RootHash(query.State()), after, query.Limit(), MaxScannedRegisters, match)
+	if err != nil {
+		return nil, fmt.Errorf("could not scan state %s: %w", query.State(), err)
+	}
+
+	result := &ledger.ScanResult{
+		Keys:   make([]ledger.Key, 0, len(payloads)),
+		Values: make([]ledger.Value, 0, len(payloads)),
+	}
+	for _, payload := range payloads {
+		result.Keys = append(result.Keys, payload.Key)
+		result.Values = append(result.Values, payload.Value)
+	}
+	if next != nil {
+		result.NextPageToken = append([]byte(nil), next[:]...)
+	}
+
+	l.metrics.ReadValuesNumber(uint64(len(payloads)))
+	l.metrics.ReadDuration(time.Since(start))
+
+	return result, nil
+}
+
 // MemSize return the amount of memory used by ledger
 // TODO implement an approximate MemSize method
 func (l *Ledger) MemSize() (int64, error) {
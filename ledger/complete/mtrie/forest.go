@@ -1,6 +1,7 @@
 package mtrie
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -124,6 +125,41 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]*ledger.Payload, error) {
 	return orderedPayloads, nil
 }
 
+// Scan iterates the registers of the trie with the given root hash in ascending order of their
+// paths, starting after the given path, or at the first register if `after` is nil. It returns
+// the paths and payloads of up to `limit` registers, of which the payload satisfies `match`,
+// visiting at most `maxVisited` registers, and the path to continue the scan after, if the scan
+// stopped before visiting all registers.
+// The forest is only accessed to look up the trie, the scan itself operates on the immutable trie.
+func (f *Forest) Scan(
+	ctx context.Context,
+	rootHash ledger.RootHash,
+	after *ledger.Path,
+	limit int,
+	maxVisited int,
+	match func(*ledger.Payload) bool,
+) ([]ledger.Path, []*ledger.Payload, *ledger.Path, error) {
+	trie, err := f.GetTrie(rootHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	paths, payloads, next, err := trie.Scan(ctx, after, limit, maxVisited, match)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	copiedPayloads := make([]*ledger.Payload, 0, len(payloads))
+	totalPayloadSize := 0
+	for _, payload := range payloads {
+		copiedPayloads = append(copiedPayloads, payload.DeepCopy())
+		totalPayloadSize += payload.Size()
+	}
+	f.metrics.ReadValuesSize(uint64(totalPayloadSize))
+
+	return paths, copiedPayloads, next, nil
+}
+
 // Update updates the Values for the registers and returns rootHash and error (if any).
 // In case there are multiple updates to the same register, Update will persist the latest
 // written value.
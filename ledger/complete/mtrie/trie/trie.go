@@ -1,6 +1,8 @@
 package trie
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -172,6 +174,105 @@ func read(payloads []*ledger.Payload, paths []ledger.Path, head *node.Node) {
 	}
 }
 
+// scanCancellationInterval is the number of registers visited by a scan between checks for the
+// cancellation of its context.
+const scanCancellationInterval = 1024
+
+// Scan iterates the registers of the trie in ascending order of their paths, starting after the
+// given path, or at the first register if `after` is nil. The scan descends directly to the
+// registers following `after`, without visiting the registers preceding it.
+// It returns the paths and payloads of up to `limit` registers, of which the payload satisfies
+// `match`. Registers with empty values are skipped. At most `maxVisited` registers are visited,
+// matching or not, which bounds the work of a scan, as the matching registers can be spread
+// over the whole trie. If the scan stopped before visiting all registers, the path to continue
+// the scan after is returned, otherwise nil. The scan also stops with the context error, once
+// the context is done.
+// As MTries are immutable, the scan requires no locking and doesn't block concurrent updates.
+func (mt *MTrie) Scan(
+	ctx context.Context,
+	after *ledger.Path,
+	limit int,
+	maxVisited int,
+	match func(*ledger.Payload) bool,
+) ([]ledger.Path, []*ledger.Payload, *ledger.Path, error) {
+	s := &scanner{
+		ctx:        ctx,
+		after:      after,
+		limit:      limit,
+		maxVisited: maxVisited,
+		match:      match,
+	}
+	if s.scan(mt.root, after != nil) {
+		return s.paths, s.payloads, nil, nil
+	}
+	if s.err != nil {
+		return nil, nil, nil, s.err
+	}
+	return s.paths, s.payloads, s.last, nil
+}
+
+// scanner holds the state of a scan of the registers of a trie.
+type scanner struct {
+	ctx        context.Context
+	after      *ledger.Path
+	limit      int
+	maxVisited int
+	match      func(*ledger.Payload) bool
+	paths      []ledger.Path
+	payloads   []*ledger.Payload
+	visited    int
+	last       *ledger.Path // path of the last register which was visited and not left for the next scan
+	err        error
+}
+
+// scan traverses the subtree with `head` as root node in ascending order of paths. If `bounded`
+// is set, the subtree contains the path `after`, so that registers up to this path are skipped.
+// Returns false once the scan stopped before visiting all registers.
+func (s *scanner) scan(head *node.Node, bounded bool) bool {
+	if head == nil {
+		return true
+	}
+
+	// reached a leaf node
+	if head.IsLeaf() {
+		path := *head.Path()
+		if bounded && bytes.Compare(path[:], s.after[:]) <= 0 {
+			return true
+		}
+		if s.visited == s.maxVisited {
+			return false
+		}
+		if s.visited%scanCancellationInterval == 0 {
+			s.err = s.ctx.Err()
+			if s.err != nil {
+				return false
+			}
+		}
+		s.visited++
+
+		payload := head.Payload()
+		if len(payload.Value) > 0 && s.match(payload) {
+			if len(s.paths) == s.limit {
+				return false
+			}
+			s.paths = append(s.paths, path)
+			s.payloads = append(s.payloads, payload)
+		}
+		s.last = &path
+		return true
+	}
+
+	depth := ledger.NodeMaxHeight - head.Height() // distance to the tree root
+	if bounded && bitutils.Bit(s.after[:], depth) == 1 {
+		// all registers of the left subtree precede `after`
+		return s.scan(head.RightChild(), true)
+	}
+	if !s.scan(head.LeftChild(), bounded) {
+		return false
+	}
+	return s.scan(head.RightChild(), false)
+}
+
 // NewTrieWithUpdatedRegisters constructs a new trie containing all registers from the parent trie.
 // The key-value pairs specify the registers whose values are supposed to hold updated values
 // compared to the parent trie. Constructing the new trie is done in a COPY-ON-WRITE manner:
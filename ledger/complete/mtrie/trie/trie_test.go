@@ -2,6 +2,7 @@ package trie_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"math"
@@ -582,6 +583,100 @@ func Test_Pruning(t *testing.T) {
 	})
 }
 
+// Test_Scan tests that scanning a trie page by page returns all registers satisfying the filter
+// exactly once, in ascending order of their paths.
+func Test_Scan(t *testing.T) {
+	rng := &LinearCongruentialGenerator{seed: 0}
+	paths, payloads := deduplicateWrites(sampleRandomRegisterWrites(rng, 500))
+	updatedTrie, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+
+	// only registers with even values match
+	match := func(payload *ledger.Payload) bool {
+		return payload.Value[len(payload.Value)-1]%2 == 0
+	}
+	var expected []ledger.Path
+	for i, path := range paths {
+		if match(&payloads[i]) {
+			expected = append(expected, path)
+		}
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return bytes.Compare(expected[i][:], expected[j][:]) < 0
+	})
+
+	ctx := context.Background()
+	maxVisited := len(paths)
+
+	t.Run("all registers in one page", func(t *testing.T) {
+		scanned, scannedPayloads, next, err := updatedTrie.Scan(ctx, nil, len(expected), maxVisited, match)
+		require.NoError(t, err)
+		require.Nil(t, next)
+		require.Equal(t, expected, scanned)
+		require.Len(t, scannedPayloads, len(expected))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var scanned []ledger.Path
+		var after *ledger.Path
+		for {
+			page, pagePayloads, next, err := updatedTrie.Scan(ctx, after, 7, maxVisited, match)
+			require.NoError(t, err)
+			require.Len(t, pagePayloads, len(page))
+			require.LessOrEqual(t, len(page), 7)
+			scanned = append(scanned, page...)
+			if next == nil {
+				break
+			}
+			require.Len(t, page, 7)
+			after = next
+		}
+		require.Equal(t, expected, scanned)
+	})
+
+	t.Run("bounded number of visited registers", func(t *testing.T) {
+		var scanned []ledger.Path
+		var after *ledger.Path
+		pages := 0
+		for {
+			page, _, next, err := updatedTrie.Scan(ctx, after, len(expected), 50, match)
+			require.NoError(t, err)
+			scanned = append(scanned, page...)
+			pages++
+			if next == nil {
+				break
+			}
+			// pages stop after 50 visited registers, of which only the matching ones are returned
+			require.LessOrEqual(t, len(page), 50)
+			after = next
+		}
+		require.Equal(t, expected, scanned)
+		require.Equal(t, (len(paths)+49)/50, pages)
+	})
+
+	t.Run("after the last register", func(t *testing.T) {
+		last := expected[len(expected)-1]
+		scanned, _, next, err := updatedTrie.Scan(ctx, &last, 10, maxVisited, match)
+		require.NoError(t, err)
+		require.Nil(t, next)
+		require.Empty(t, scanned)
+	})
+
+	t.Run("empty trie", func(t *testing.T) {
+		scanned, _, next, err := trie.NewEmptyMTrie().Scan(ctx, nil, 10, maxVisited, match)
+		require.NoError(t, err)
+		require.Nil(t, next)
+		require.Empty(t, scanned)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, _, err := updatedTrie.Scan(cancelled, nil, 10, maxVisited, match)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
 func hashToString(hash ledger.RootHash) string {
 	return hex.EncodeToString(hash[:])
 }
@@ -1,42 +1,60 @@
 package wal
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/lifecycle"
 	"github.com/onflow/flow-go/module/observable"
 )
 
+// ErrNoSegmentsToCheckpoint is returned when triggering a checkpoint, while all WAL segments, which are
+// not written to anymore, are checkpointed already.
+var ErrNoSegmentsToCheckpoint = errors.New("no WAL segments to checkpoint")
+
 type Compactor struct {
 	checkpointer *Checkpointer
 	logger       zerolog.Logger
+	metrics      module.WALMetrics
 	stopc        chan struct{}
+	trigger      chan struct{}
 	lm           *lifecycle.LifecycleManager
 	sync.Mutex
 	observers          map[observable.Observer]struct{}
 	interval           time.Duration
 	checkpointDistance uint
 	checkpointsToKeep  uint
+
+	checkpointLock sync.Mutex // protects the numbers of the triggered and the running checkpoint
+	triggered      int        // number of the manually triggered checkpoint, -1 if none is scheduled
+	running        int        // number of the checkpoint being created, -1 if none is running
 }
 
-func NewCompactor(checkpointer *Checkpointer, interval time.Duration, checkpointDistance uint, checkpointsToKeep uint, logger zerolog.Logger) *Compactor {
+func NewCompactor(checkpointer *Checkpointer, interval time.Duration, checkpointDistance uint, checkpointsToKeep uint, metrics module.WALMetrics, logger zerolog.Logger) *Compactor {
 	if checkpointDistance < 1 {
 		checkpointDistance = 1
 	}
 	return &Compactor{
 		checkpointer:       checkpointer,
 		logger:             logger,
+		metrics:            metrics,
 		stopc:              make(chan struct{}),
+		trigger:            make(chan struct{}, 1),
 		observers:          make(map[observable.Observer]struct{}),
 		lm:                 lifecycle.NewLifecycleManager(),
 		interval:           interval,
 		checkpointDistance: checkpointDistance,
 		checkpointsToKeep:  checkpointsToKeep,
+		triggered:          -1,
+		running:            -1,
 	}
 }
 
@@ -77,11 +95,47 @@ func (c *Compactor) start() {
 		select {
 		case <-c.stopc:
 			return
+		case <-c.trigger:
 		case <-time.After(c.interval):
 		}
 	}
 }
 
+// TriggerCheckpoint schedules a checkpoint of all WAL segments up to the last one, which is not being
+// written to anymore, regardless of the checkpoint distance, and returns the number of the checkpoint.
+// Triggering a checkpoint while one is scheduled or being created returns the number of that checkpoint
+// instead of scheduling another one.
+func (c *Compactor) TriggerCheckpoint() (int, error) {
+	c.checkpointLock.Lock()
+	defer c.checkpointLock.Unlock()
+
+	if c.running >= 0 {
+		return c.running, nil
+	}
+	if c.triggered >= 0 {
+		return c.triggered, nil
+	}
+
+	from, to, err := c.checkpointer.NotCheckpointedSegments()
+	if err != nil {
+		return -1, fmt.Errorf("cannot get not checkpointed segments: %w", err)
+	}
+	// presumably last segment is being written to
+	if from < 0 || to-1 < from {
+		return -1, ErrNoSegmentsToCheckpoint
+	}
+
+	c.triggered = to - 1
+	c.logger.Info().Msgf("triggered a checkpoint from segment %d to segment %d", from, c.triggered)
+
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+
+	return c.triggered, nil
+}
+
 func (c *Compactor) Run() error {
 	c.Lock()
 	defer c.Unlock()
@@ -113,19 +167,53 @@ func (c *Compactor) createCheckpoints() (int, error) {
 
 	// we only return a positive value if the latest checkpoint index has changed
 	newLatestCheckpoint := -1
-	// more then one segment means we can checkpoint safely up to `to`-1
-	// presumably last segment is being written to
-	if to-from > int(c.checkpointDistance) {
-		checkpointNumber := to - 1
-		c.logger.Info().Msgf("creating a checkpoint from segment %d to segment %d\n", from, checkpointNumber)
-		err = c.checkpointer.Checkpoint(checkpointNumber, func() (io.WriteCloser, error) {
-			return c.checkpointer.CheckpointWriter(checkpointNumber)
-		})
-		if err != nil {
-			return -1, fmt.Errorf("error creating checkpoint (%d): %w", checkpointNumber, err)
-		}
-		newLatestCheckpoint = checkpointNumber
+
+	c.checkpointLock.Lock()
+	checkpointNumber := -1
+	if c.triggered >= from {
+		// a triggered checkpoint is created regardless of the checkpoint distance
+		checkpointNumber = c.triggered
+	} else if to-from > int(c.checkpointDistance) {
+		// more then one segment means we can checkpoint safely up to `to`-1
+		// presumably last segment is being written to
+		checkpointNumber = to - 1
+	}
+	c.triggered = -1
+	c.running = checkpointNumber
+	c.checkpointLock.Unlock()
+
+	if checkpointNumber < 0 {
+		return newLatestCheckpoint, nil
 	}
+	defer func() {
+		c.checkpointLock.Lock()
+		c.running = -1
+		c.checkpointLock.Unlock()
+	}()
+
+	c.logger.Info().Msgf("creating a checkpoint from segment %d to segment %d\n", from, checkpointNumber)
+	startedAt := time.Now()
+	err = c.checkpointer.Checkpoint(checkpointNumber, func() (io.WriteCloser, error) {
+		return c.checkpointer.CheckpointWriter(checkpointNumber)
+	})
+	if err != nil {
+		return -1, fmt.Errorf("error creating checkpoint (%d): %w", checkpointNumber, err)
+	}
+	duration := time.Since(startedAt)
+
+	info, err := os.Stat(path.Join(c.checkpointer.dir, NumberToFilename(checkpointNumber)))
+	if err != nil {
+		return -1, fmt.Errorf("cannot get size of checkpoint (%d): %w", checkpointNumber, err)
+	}
+	c.metrics.ExecutionCheckpointCreated(duration, uint(checkpointNumber-from+1), uint64(info.Size()))
+
+	c.logger.Info().
+		Int("checkpoint", checkpointNumber).
+		Dur("duration", duration).
+		Int64("file_size", info.Size()).
+		Msg("checkpoint created")
+
+	newLatestCheckpoint = checkpointNumber
 	return newLatestCheckpoint, nil
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/ledger"
@@ -18,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -73,7 +75,7 @@ func Test_Compactor(t *testing.T) {
 			checkpointer, err := wal.NewCheckpointer()
 			require.NoError(t, err)
 
-			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 1, zerolog.Nop()) //keep only latest checkpoint
+			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 1, metrics.NewNoopCollector(), zerolog.Nop()) //keep only latest checkpoint
 			co := CompactorObserver{fromBound: 9, done: make(chan struct{})}
 			compactor.Subscribe(&co)
 
@@ -249,7 +251,7 @@ func Test_Compactor_checkpointInterval(t *testing.T) {
 			checkpointer, err := wal.NewCheckpointer()
 			require.NoError(t, err)
 
-			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 2, zerolog.Nop())
+			compactor := NewCompactor(checkpointer, 100*time.Millisecond, checkpointDistance, 2, metrics.NewNoopCollector(), zerolog.Nop())
 
 			// Generate the tree and create WAL
 			for i := 0; i < size; i++ {
@@ -331,6 +333,125 @@ func Test_Compactor_checkpointInterval(t *testing.T) {
 	})
 }
 
+// Test_Compactor_triggerCheckpoint tests that a triggered checkpoint is created regardless of the
+// checkpoint distance, and that triggering a checkpoint again before it was created returns its number.
+func Test_Compactor_triggerCheckpoint(t *testing.T) {
+	numInsPerStep := 2
+	pathByteSize := 32
+	minPayloadByteSize := 2 << 15
+	maxPayloadByteSize := 2 << 16
+	size := 5
+	checkpointDistance := uint(100) // no checkpoints are created because of the distance
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewForest(size*10, &metrics.NoopCollector{}, func(tree *trie.MTrie) error { return nil })
+		require.NoError(t, err)
+
+		var rootHash = f.GetEmptyRootHash()
+
+		wal, err := NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, size*10, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+
+		collector := new(mockmodule.WALMetrics)
+		compactor := NewCompactor(checkpointer, time.Hour, checkpointDistance, 2, collector, zerolog.Nop())
+
+		// recordUpdates records the given number of updates, each of which spans multiple WAL segments
+		recordUpdates := func(n int) {
+			for i := 0; i < n; i++ {
+				update := &ledger.TrieUpdate{
+					RootHash: rootHash,
+					Paths:    utils.RandomPaths(numInsPerStep),
+					Payloads: utils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize),
+				}
+
+				err := wal.RecordUpdate(update)
+				require.NoError(t, err)
+
+				rootHash, err = f.Update(update)
+				require.NoError(t, err)
+			}
+		}
+
+		t.Run("triggered checkpoint is created regardless of the distance", func(t *testing.T) {
+			recordUpdates(size)
+
+			from, to, err := checkpointer.NotCheckpointedSegments()
+			require.NoError(t, err)
+
+			checkpoint, err := compactor.createCheckpoints()
+			require.NoError(t, err)
+			require.Equal(t, -1, checkpoint)
+
+			triggered, err := compactor.TriggerCheckpoint()
+			require.NoError(t, err)
+			require.Equal(t, to-1, triggered)
+
+			// triggering again before the checkpoint was created returns the same checkpoint
+			again, err := compactor.TriggerCheckpoint()
+			require.NoError(t, err)
+			require.Equal(t, triggered, again)
+
+			collector.On("ExecutionCheckpointCreated", mock.Anything, uint(triggered-from+1), mock.Anything).Once()
+
+			checkpoint, err = compactor.createCheckpoints()
+			require.NoError(t, err)
+			require.Equal(t, triggered, checkpoint)
+			require.FileExists(t, path.Join(dir, NumberToFilename(triggered)))
+			collector.AssertExpectations(t)
+
+			// all segments, which are not written to anymore, are checkpointed
+			_, err = compactor.TriggerCheckpoint()
+			require.ErrorIs(t, err, ErrNoSegmentsToCheckpoint)
+		})
+
+		t.Run("triggering while a checkpoint is created returns its number", func(t *testing.T) {
+			compactor.checkpointLock.Lock()
+			compactor.running = 42
+			compactor.checkpointLock.Unlock()
+
+			checkpoint, err := compactor.TriggerCheckpoint()
+			require.NoError(t, err)
+			require.Equal(t, 42, checkpoint)
+
+			compactor.checkpointLock.Lock()
+			compactor.running = -1
+			compactor.checkpointLock.Unlock()
+		})
+
+		t.Run("triggered checkpoint does not wait for the interval", func(t *testing.T) {
+			recordUpdates(2)
+
+			_, to, err := checkpointer.NotCheckpointedSegments()
+			require.NoError(t, err)
+
+			collector.On("ExecutionCheckpointCreated", mock.Anything, mock.Anything, mock.Anything).Once()
+			co := CompactorObserver{fromBound: to - 1, done: make(chan struct{})}
+			compactor.Subscribe(&co)
+			<-compactor.Ready()
+
+			triggered, err := compactor.TriggerCheckpoint()
+			require.NoError(t, err)
+			require.Equal(t, to-1, triggered)
+
+			select {
+			case <-co.done:
+				// continue
+			case <-time.After(60 * time.Second):
+				assert.FailNow(t, "timed out")
+			}
+			require.FileExists(t, path.Join(dir, NumberToFilename(triggered)))
+			collector.AssertExpectations(t)
+
+			<-compactor.Done()
+			<-wal.Done()
+		})
+	})
+}
+
 func loadIntoForest(forest *mtrie.Forest, forestSequencing *flattener.FlattenedForest) error {
 	tries, err := flattener.RebuildTries(forestSequencing)
 	if err != nil {
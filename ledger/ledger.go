@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -33,6 +34,75 @@ type Ledger interface {
 	Prove(query *Query) (proof Proof, err error)
 }
 
+// Scanner is implemented by ledgers which can iterate the registers stored at a state, e.g. for
+// debugging purposes. Scans are not part of the Ledger interface, as not every ledger holds the full
+// state (e.g. a partial ledger).
+type Scanner interface {
+	// Scan returns a page of the registers at the state of the scan query, of which the keys start
+	// with the key parts of the query. The registers are returned in a stable order and the result
+	// contains a token to continue the scan with the next page, if the scan is not complete. As the
+	// work of a scan is bounded, a page can hold fewer registers than the limit of the query, even
+	// if it is followed by others. The scan stops with an error once the context is done.
+	Scan(ctx context.Context, query *ScanQuery) (*ScanResult, error)
+}
+
+// ScanQuery holds all data needed for a ledger scan
+type ScanQuery struct {
+	state     State
+	prefix    []KeyPart
+	pageToken []byte
+	limit     int
+}
+
+// NewScanQuery constructs a new ledger scan query for up to `limit` registers, of which the keys
+// start with the given key parts. An empty page token starts the scan with the first page.
+func NewScanQuery(sc State, prefix []KeyPart, pageToken []byte, limit int) (*ScanQuery, error) {
+	if limit < 1 {
+		return nil, fmt.Errorf("scan limit must be positive, but is %d", limit)
+	}
+	return &ScanQuery{state: sc, prefix: prefix, pageToken: pageToken, limit: limit}, nil
+}
+
+// State returns the state part of the scan query
+func (q *ScanQuery) State() State {
+	return q.state
+}
+
+// Prefix returns the leading key parts that the keys of the scanned registers match
+func (q *ScanQuery) Prefix() []KeyPart {
+	return q.prefix
+}
+
+// PageToken returns the token of the page to continue the scan at, or nil for the first page
+func (q *ScanQuery) PageToken() []byte {
+	return q.pageToken
+}
+
+// Limit returns the maximum number of registers to scan
+func (q *ScanQuery) Limit() int {
+	return q.limit
+}
+
+// Matches returns true if the given key starts with the key parts of the scan query
+func (q *ScanQuery) Matches(key Key) bool {
+	if len(key.KeyParts) < len(q.prefix) {
+		return false
+	}
+	for i := range q.prefix {
+		if !key.KeyParts[i].Equals(&q.prefix[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ScanResult holds a page of registers returned by a ledger scan
+type ScanResult struct {
+	Keys          []Key
+	Values        []Value
+	NextPageToken []byte // token to continue the scan with the next page, nil if there are no more registers
+}
+
 // Query holds all data needed for a ledger read or ledger proof
 type Query struct {
 	state State
@@ -0,0 +1,47 @@
+package chunks
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AssignmentRecord is the persisted chunk assignment of an execution result, which was
+// incorporated in the given block. It allows to reconstruct which verifiers were assigned
+// to each chunk after the in-memory assignment has been discarded.
+type AssignmentRecord struct {
+	ResultID            flow.Identifier
+	IncorporatedBlockID flow.Identifier
+	IncorporatedHeight  uint64
+	// Verifiers holds the verifiers assigned to each chunk in the order of the chunks
+	// of the result, which is their chunk index. Verifiers are sorted by node ID.
+	Verifiers []flow.IdentifierList
+}
+
+// NewAssignmentRecord creates the record of the assignment of the given chunks of an
+// execution result, which was incorporated in the block with the given ID and height.
+func NewAssignmentRecord(resultID flow.Identifier, incorporatedBlockID flow.Identifier, incorporatedHeight uint64, chunks flow.ChunkList, assignment *Assignment) *AssignmentRecord {
+	verifiers := make([]flow.IdentifierList, len(chunks))
+	for i, chunk := range chunks {
+		assigned := assignment.Verifiers(chunk)
+		sort.Sort(assigned)
+		verifiers[i] = assigned
+	}
+	return &AssignmentRecord{
+		ResultID:            resultID,
+		IncorporatedBlockID: incorporatedBlockID,
+		IncorporatedHeight:  incorporatedHeight,
+		Verifiers:           verifiers,
+	}
+}
+
+// ByNodeID returns the indices of all chunks assigned to the given verifier.
+func (r *AssignmentRecord) ByNodeID(verifierID flow.Identifier) []uint64 {
+	var indices []uint64
+	for index, verifiers := range r.Verifiers {
+		if verifiers.Contains(verifierID) {
+			indices = append(indices, uint64(index))
+		}
+	}
+	return indices
+}
@@ -50,3 +50,18 @@ func (c *Codec) NewEncoder(w io.Writer) encoding.Encoder {
 func (c *Codec) NewDecoder(r io.Reader) encoding.Decoder {
 	return json.NewDecoder(r)
 }
+
+var _ encoding.Codec = (*PrettyCodec)(nil)
+
+// PrettyCodec is a JSON codec, which indents the encoded values for readability.
+type PrettyCodec struct{}
+
+func (c *PrettyCodec) NewEncoder(w io.Writer) encoding.Encoder {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc
+}
+
+func (c *PrettyCodec) NewDecoder(r io.Reader) encoding.Decoder {
+	return json.NewDecoder(r)
+}
@@ -1,6 +1,11 @@
 package flow
 
 import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
 	"github.com/onflow/flow-go/crypto"
 )
 
@@ -12,6 +17,61 @@ type AggregatedSignature struct {
 	VerifierSignatures []crypto.Signature
 	// List of signer identifiers
 	SignerIDs IdentifierList
+	// List of the SPoCK proofs of the signers' approvals, in the order of the signer identifiers.
+	// It is empty, unless SPoCK proofs are required for sealing.
+	//
+	// The SPoCK proofs are appended to the hash pre-image only if they are set, so that the IDs of
+	// seals without SPoCK proofs are identical to the encoding without the field. Requiring SPoCK
+	// proofs must only be enabled for all consensus nodes at once (e.g. with a spork).
+	Spocks []crypto.Signature
+}
+
+// aggregatedSignatureEncoding is the hash pre-image of aggregated signatures. The SPoCK proofs
+// are appended to the original list of fields, so that the encoding of an aggregated signature
+// without SPoCK proofs is the encoding of the original model.
+type aggregatedSignatureEncoding struct {
+	VerifierSignatures []crypto.Signature
+	SignerIDs          IdentifierList
+	Spocks             [][]crypto.Signature `rlp:"tail"` // empty without SPoCK proofs
+}
+
+// EncodeRLP implements the RLP encoder interface, encoding the SPoCK proofs only if there are any.
+func (a AggregatedSignature) EncodeRLP(w io.Writer) error {
+	enc := aggregatedSignatureEncoding{
+		VerifierSignatures: a.VerifierSignatures,
+		SignerIDs:          a.SignerIDs,
+	}
+	if len(a.Spocks) > 0 {
+		enc.Spocks = [][]crypto.Signature{a.Spocks}
+	}
+	return rlp.Encode(w, enc)
+}
+
+// DecodeRLP implements the RLP decoder interface. It accepts aggregated signatures with and without
+// SPoCK proofs, and rejects non-canonical encodings, i.e. an explicitly encoded empty list of proofs.
+func (a *AggregatedSignature) DecodeRLP(s *rlp.Stream) error {
+	var enc aggregatedSignatureEncoding
+	err := s.Decode(&enc)
+	if err != nil {
+		return err
+	}
+	var spocks []crypto.Signature
+	switch len(enc.Spocks) {
+	case 0:
+	case 1:
+		spocks = enc.Spocks[0]
+		if len(spocks) == 0 {
+			return fmt.Errorf("non-canonical aggregated signature encoding: explicit empty list of SPoCK proofs")
+		}
+	default:
+		return fmt.Errorf("unknown aggregated signature encoding with %d additional fields", len(enc.Spocks))
+	}
+	*a = AggregatedSignature{
+		VerifierSignatures: enc.VerifierSignatures,
+		SignerIDs:          enc.SignerIDs,
+		Spocks:             spocks,
+	}
+	return nil
 }
 
 // CardinalitySignerSet returns the number of _distinct_ signer IDs in the AggregatedSignature.
@@ -1,6 +1,27 @@
 package flow
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// MaxChunks is the maximum number of chunks in a chunk list. Each chunk executes one
+// collection of the block, plus the system chunk, so the limit is far beyond the number
+// of collections a block can hold. It bounds the resources spent on a malicious result.
+const MaxChunks = 10_000
+
+// ErrTooManyChunks is returned when a chunk list would exceed MaxChunks.
+var ErrTooManyChunks = errors.New("chunk list exceeds the maximum number of chunks")
+
 type ChunkBody struct {
+	// CollectionIndex is the index of the collection in the block payload executed by this chunk.
+	// The system chunk, which is the last chunk of an execution result, has no collection. Its
+	// CollectionIndex equals the number of guarantees in the block payload. Use
+	// ChunkList.CollectionIndexSafe to avoid handling this index as a collection.
 	CollectionIndex uint
 
 	// execution info
@@ -11,6 +32,96 @@ type ChunkBody struct {
 	// Computation consumption info
 	TotalComputationUsed uint64 // total amount of computation used by running all txs in this chunk
 	NumberOfTransactions uint64 // number of transactions inside the collection
+
+	// TotalByteSize is the total byte size of the transactions of the collection executed by
+	// this chunk, which lets verification nodes estimate the effort of verifying the chunk
+	// before fetching its chunk data pack. It is zero for chunks of version ChunkBodyV0, and
+	// for chunks without transactions in a collection, such as the system chunk.
+	//
+	// The size is appended to the hash pre-image only if it is set, so that the IDs of chunks
+	// of version ChunkBodyV0 are identical to the encoding without the field. As all execution
+	// nodes must produce identical results, the version is selected by configuration, and must
+	// only be changed for all execution nodes at once (e.g. with a spork).
+	TotalByteSize uint32
+}
+
+// ChunkBodyVersion is the version of the hash pre-image of chunk bodies.
+type ChunkBodyVersion uint
+
+const (
+	// ChunkBodyV0 is the original version of chunk bodies, which don't commit to their size.
+	ChunkBodyV0 ChunkBodyVersion = iota
+	// ChunkBodyV1 is the version of chunk bodies, which commit to the total byte size of the
+	// transactions of their collection.
+	ChunkBodyV1
+)
+
+// Version returns the version of the chunk body, which is determined by the fields it commits to.
+func (ch ChunkBody) Version() ChunkBodyVersion {
+	if ch.TotalByteSize > 0 {
+		return ChunkBodyV1
+	}
+	return ChunkBodyV0
+}
+
+// chunkBodyEncoding is the hash pre-image of chunk bodies. The fields added with a later
+// version are appended to the list of fields of version ChunkBodyV0, so that the encoding of
+// a chunk body of version ChunkBodyV0 is the encoding of the original model.
+type chunkBodyEncoding struct {
+	CollectionIndex      uint
+	StartState           StateCommitment
+	EventCollection      Identifier
+	BlockID              Identifier
+	TotalComputationUsed uint64
+	NumberOfTransactions uint64
+	TotalByteSize        []uint32 `rlp:"tail"` // empty for ChunkBodyV0, the total byte size for ChunkBodyV1
+}
+
+// EncodeRLP implements the RLP encoder interface, encoding the chunk body in the layout of its version.
+func (ch ChunkBody) EncodeRLP(w io.Writer) error {
+	enc := chunkBodyEncoding{
+		CollectionIndex:      ch.CollectionIndex,
+		StartState:           ch.StartState,
+		EventCollection:      ch.EventCollection,
+		BlockID:              ch.BlockID,
+		TotalComputationUsed: ch.TotalComputationUsed,
+		NumberOfTransactions: ch.NumberOfTransactions,
+	}
+	if ch.Version() == ChunkBodyV1 {
+		enc.TotalByteSize = []uint32{ch.TotalByteSize}
+	}
+	return rlp.Encode(w, enc)
+}
+
+// DecodeRLP implements the RLP decoder interface. It accepts the layouts of all versions, and
+// rejects non-canonical encodings, i.e. an explicitly encoded total byte size of zero.
+func (ch *ChunkBody) DecodeRLP(s *rlp.Stream) error {
+	var enc chunkBodyEncoding
+	err := s.Decode(&enc)
+	if err != nil {
+		return err
+	}
+	var totalByteSize uint32
+	switch len(enc.TotalByteSize) {
+	case 0:
+	case 1:
+		totalByteSize = enc.TotalByteSize[0]
+		if totalByteSize == 0 {
+			return fmt.Errorf("non-canonical chunk body encoding: explicit total byte size of zero")
+		}
+	default:
+		return fmt.Errorf("unknown chunk body encoding with %d additional fields", len(enc.TotalByteSize))
+	}
+	*ch = ChunkBody{
+		CollectionIndex:      enc.CollectionIndex,
+		StartState:           enc.StartState,
+		EventCollection:      enc.EventCollection,
+		BlockID:              enc.BlockID,
+		TotalComputationUsed: enc.TotalComputationUsed,
+		NumberOfTransactions: enc.NumberOfTransactions,
+		TotalByteSize:        totalByteSize,
+	}
+	return nil
 }
 
 type Chunk struct {
@@ -21,6 +132,63 @@ type Chunk struct {
 	EndState StateCommitment
 }
 
+// NewSystemChunk creates the system chunk of an execution result. Index is the index of
+// the chunk within the result, which equals the number of collections in the block.
+func NewSystemChunk(
+	index uint64,
+	startState StateCommitment,
+	endState StateCommitment,
+	blockID Identifier,
+	eventCollection Identifier,
+	numberOfTransactions uint64,
+) *Chunk {
+	return &Chunk{
+		ChunkBody: ChunkBody{
+			CollectionIndex:      uint(index),
+			StartState:           startState,
+			EventCollection:      eventCollection,
+			BlockID:              blockID,
+			TotalComputationUsed: 0,
+			NumberOfTransactions: numberOfTransactions,
+		},
+		Index:    index,
+		EndState: endState,
+	}
+}
+
+// chunkEncoding is the hash pre-image of chunks. It is required as the chunk embeds the chunk
+// body, whose RLP encoder would otherwise be promoted to the chunk.
+type chunkEncoding struct {
+	ChunkBody ChunkBody
+	Index     uint64
+	EndState  StateCommitment
+}
+
+// EncodeRLP implements the RLP encoder interface, encoding the chunk body followed by the
+// fields of the chunk.
+func (ch Chunk) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, chunkEncoding{
+		ChunkBody: ch.ChunkBody,
+		Index:     ch.Index,
+		EndState:  ch.EndState,
+	})
+}
+
+// DecodeRLP implements the RLP decoder interface.
+func (ch *Chunk) DecodeRLP(s *rlp.Stream) error {
+	var enc chunkEncoding
+	err := s.Decode(&enc)
+	if err != nil {
+		return err
+	}
+	*ch = Chunk{
+		ChunkBody: enc.ChunkBody,
+		Index:     enc.Index,
+		EndState:  enc.EndState,
+	}
+	return nil
+}
+
 // ID returns a unique id for this entity
 func (ch *Chunk) ID() Identifier {
 	return MakeID(ch.ChunkBody)
@@ -62,8 +230,81 @@ func (cl ChunkList) Fingerprint() Identifier {
 	return MerkleRoot(GetIDs(cl)...)
 }
 
-func (cl *ChunkList) Insert(ch *Chunk) {
+// Insert appends the given chunk to the list. It returns ErrTooManyChunks if the list
+// already holds MaxChunks chunks.
+func (cl *ChunkList) Insert(ch *Chunk) error {
+	if len(*cl) >= MaxChunks {
+		return ErrTooManyChunks
+	}
 	*cl = append(*cl, ch)
+	return nil
+}
+
+// EncodeCanonical returns the canonical encoding of the chunk list, which is the RLP encoding
+// of the chunks in order. It is the encoding the IDs of execution results commit to, so it
+// must never change for existing chunk lists. The system chunk is not marked in the encoding,
+// as it is always the last chunk of the list.
+func (cl ChunkList) EncodeCanonical() ([]byte, error) {
+	// convert to the underlying slice type, so the encoder doesn't recurse into EncodeRLP
+	data, err := rlp.EncodeToBytes([]*Chunk(cl))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode chunk list: %w", err)
+	}
+	return data, nil
+}
+
+// EncodeRLP implements the RLP encoder interface, so that entities embedding a chunk list,
+// such as execution results, are always hashed using its canonical encoding.
+func (cl ChunkList) EncodeRLP(w io.Writer) error {
+	data, err := cl.EncodeCanonical()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// DecodeChunkList decodes a chunk list from its canonical encoding. It returns an error if the
+// data is malformed or contains more than MaxChunks chunks. The chunks are decoded one by one,
+// so that no more than MaxChunks chunks are ever allocated. As for any chunk list, the system
+// chunk of the decoded list is its last chunk, see IsSystemChunk.
+func DecodeChunkList(data []byte) (*ChunkList, error) {
+	stream := rlp.NewStream(bytes.NewReader(data), uint64(len(data)))
+	_, err := stream.List()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode chunk list: %w", err)
+	}
+
+	var chunks ChunkList
+	for {
+		_, _, err = stream.Kind()
+		if errors.Is(err, rlp.EOL) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not decode chunk list: %w", err)
+		}
+		if len(chunks) >= MaxChunks {
+			return nil, ErrTooManyChunks
+		}
+		var chunk Chunk
+		err = stream.Decode(&chunk)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode chunk %d: %w", len(chunks), err)
+		}
+		chunks = append(chunks, &chunk)
+	}
+
+	err = stream.ListEnd()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode chunk list: %w", err)
+	}
+	_, _, err = stream.Kind()
+	if !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not decode chunk list: %w", rlp.ErrMoreThanOneValue)
+	}
+
+	return &chunks, nil
 }
 
 func (cl ChunkList) Items() []*Chunk {
@@ -84,6 +325,23 @@ func (cl ChunkList) Indices() []uint64 {
 	return indices
 }
 
+// IsSystemChunk returns true if the chunk with the given index is the system chunk of the list.
+// The system chunk is not marked explicitly, it is derived from its position: it is always the
+// last chunk of an execution result.
+func (cl ChunkList) IsSystemChunk(i uint64) bool {
+	return len(cl) > 0 && i == uint64(len(cl)-1)
+}
+
+// CollectionIndexSafe returns the index of the collection within the block payload, which is
+// executed by the chunk with the given index. It returns false for the system chunk, which has
+// no collection, and for indices out of the range of the list.
+func (cl ChunkList) CollectionIndexSafe(i uint64) (uint, bool) {
+	if i >= uint64(len(cl)) || cl.IsSystemChunk(i) {
+		return 0, false
+	}
+	return cl[i].CollectionIndex, true
+}
+
 // ByChecksum returns an entity from the list by entity fingerprint
 func (cl ChunkList) ByChecksum(cs Identifier) (*Chunk, bool) {
 	for _, ch := range cl {
@@ -1,10 +1,14 @@
 package flow_test
 
 import (
+	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/encoding/rlp"
+	"github.com/onflow/flow-go/model/fingerprint"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -104,3 +108,228 @@ func TestChunkList_Indices(t *testing.T) {
 		require.Contains(t, indices, uint64(0), uint64(2), uint64(4))
 	})
 }
+
+// TestChunk_SystemChunkOnly evaluates that a result without guarantees consists of the system
+// chunk only, whose collection index equals the number of guarantees.
+func TestChunk_SystemChunkOnly(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	systemChunk := flow.NewSystemChunk(0, unittest.StateCommitmentFixture(), unittest.StateCommitmentFixture(),
+		blockID, unittest.IdentifierFixture(), 1)
+	result := unittest.ExecutionResultFixture(func(result *flow.ExecutionResult) {
+		result.BlockID = blockID
+		result.Chunks = flow.ChunkList{systemChunk}
+	})
+
+	chunk, ok := result.Chunks.ByIndex(0)
+	require.True(t, ok)
+	require.Equal(t, uint(0), chunk.CollectionIndex)
+	require.Equal(t, uint64(1), chunk.NumberOfTransactions)
+	require.True(t, result.Chunks.IsSystemChunk(0))
+	_, ok = result.Chunks.CollectionIndexSafe(0)
+	require.False(t, ok)
+}
+
+// TestChunkList_CollectionIndexSafe evaluates that the collection index is only returned for
+// chunks executing a collection, i.e. neither for the system chunk nor out of range.
+func TestChunkList_CollectionIndexSafe(t *testing.T) {
+	chunks := unittest.ChunkListFixture(3, unittest.IdentifierFixture())
+
+	for i := uint64(0); i < 2; i++ {
+		require.False(t, chunks.IsSystemChunk(i))
+		index, ok := chunks.CollectionIndexSafe(i)
+		require.True(t, ok)
+		require.Equal(t, chunks[i].CollectionIndex, index)
+	}
+
+	require.True(t, chunks.IsSystemChunk(2))
+	_, ok := chunks.CollectionIndexSafe(2)
+	require.False(t, ok)
+
+	require.False(t, chunks.IsSystemChunk(3))
+	_, ok = chunks.CollectionIndexSafe(3)
+	require.False(t, ok)
+
+	require.False(t, flow.ChunkList{}.IsSystemChunk(0))
+}
+
+// canonicalChunkListFixture returns a chunk list with fixed field values, so its encoding is pinned.
+func canonicalChunkListFixture() flow.ChunkList {
+	var blockID flow.Identifier
+	for i := range blockID {
+		blockID[i] = byte(i)
+	}
+	chunks := make(flow.ChunkList, 0, 2)
+	for i := 0; i < 2; i++ {
+		var state flow.StateCommitment
+		var events flow.Identifier
+		for j := range state {
+			state[j] = byte(i + 1)
+			events[j] = byte(0xf0 + i)
+		}
+		chunks = append(chunks, &flow.Chunk{
+			ChunkBody: flow.ChunkBody{
+				CollectionIndex:      uint(i),
+				StartState:           state,
+				EventCollection:      events,
+				BlockID:              blockID,
+				TotalComputationUsed: uint64(100 * i),
+				NumberOfTransactions: uint64(i + 1),
+			},
+			Index:    uint64(i),
+			EndState: state,
+		})
+	}
+	return chunks
+}
+
+// TestChunkList_EncodeCanonical pins the canonical encoding of a chunk list, which execution
+// result IDs commit to, and verifies that it round trips.
+func TestChunkList_EncodeCanonical(t *testing.T) {
+	chunks := canonicalChunkListFixture()
+
+	encoded, err := chunks.EncodeCanonical()
+	require.NoError(t, err)
+	require.Equal(t, canonicalChunkListVector, hex.EncodeToString(encoded))
+
+	// the encoding of execution results embeds the canonical encoding
+	result := flow.ExecutionResult{Chunks: chunks}
+	require.Contains(t, hex.EncodeToString(fingerprint.Fingerprint(result)), canonicalChunkListVector)
+
+	decoded, err := flow.DecodeChunkList(encoded)
+	require.NoError(t, err)
+	require.Len(t, *decoded, len(chunks))
+	for i, chunk := range *decoded {
+		require.Equal(t, chunks[i], chunk)
+	}
+	require.Equal(t, flow.MakeID(result), flow.MakeID(flow.ExecutionResult{Chunks: *decoded}))
+
+	t.Run("empty list", func(t *testing.T) {
+		encoded, err := flow.ChunkList{}.EncodeCanonical()
+		require.NoError(t, err)
+		decoded, err := flow.DecodeChunkList(encoded)
+		require.NoError(t, err)
+		require.Empty(t, *decoded)
+	})
+
+	t.Run("truncated input", func(t *testing.T) {
+		for _, n := range []int{0, 1, len(encoded) / 2, len(encoded) - 1} {
+			_, err := flow.DecodeChunkList(encoded[:n])
+			require.Error(t, err, "decoding %d of %d bytes should fail", n, len(encoded))
+		}
+	})
+}
+
+// canonicalChunkListVector is the canonical encoding of canonicalChunkListFixture. It must only
+// be updated deliberately, as changing it changes the ID of every execution result.
+const canonicalChunkListVector = "" +
+	"f90118f88af86680a00101010101010101010101010101010101010101010101" +
+	"010101010101010101a0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0" +
+	"f0f0f0f0f0f0f0f0f0f0a0000102030405060708090a0b0c0d0e0f1011121314" +
+	"15161718191a1b1c1d1e1f800180a00101010101010101010101010101010101" +
+	"010101010101010101010101010101f88af86601a00202020202020202020202" +
+	"020202020202020202020202020202020202020202a0f1f1f1f1f1f1f1f1f1f1" +
+	"f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1f1a0000102030405060708" +
+	"090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f640201a00202020202" +
+	"020202020202020202020202020202020202020202020202020202"
+
+// TestChunkList_MaxChunks tests that chunk lists are bounded by MaxChunks.
+func TestChunkList_MaxChunks(t *testing.T) {
+	chunks := make(flow.ChunkList, 0, flow.MaxChunks)
+	for i := 0; i < flow.MaxChunks; i++ {
+		require.NoError(t, chunks.Insert(&flow.Chunk{Index: uint64(i)}))
+	}
+	err := chunks.Insert(&flow.Chunk{Index: flow.MaxChunks})
+	require.True(t, errors.Is(err, flow.ErrTooManyChunks))
+	require.Len(t, chunks, flow.MaxChunks)
+
+	// oversized lists can be encoded, so their IDs can be computed, but are not decoded
+	encoded, err := append(chunks, &flow.Chunk{}).EncodeCanonical()
+	require.NoError(t, err)
+	_, err = flow.DecodeChunkList(encoded)
+	require.True(t, errors.Is(err, flow.ErrTooManyChunks))
+
+	encoded, err = chunks.EncodeCanonical()
+	require.NoError(t, err)
+	decoded, err := flow.DecodeChunkList(encoded)
+	require.NoError(t, err)
+	require.Len(t, *decoded, flow.MaxChunks)
+}
+
+// TestChunkBody_TotalByteSizeEncoding pins the encoding of chunk bodies of version ChunkBodyV1,
+// which append the total byte size to the fields of version ChunkBodyV0, and verifies that
+// chunk bodies of both versions round trip.
+func TestChunkBody_TotalByteSizeEncoding(t *testing.T) {
+	// V1ChunkBody replicates the layout of chunk bodies of version ChunkBodyV1
+	type V1ChunkBody struct {
+		CollectionIndex      uint
+		StartState           flow.StateCommitment
+		EventCollection      flow.Identifier
+		BlockID              flow.Identifier
+		TotalComputationUsed uint64
+		NumberOfTransactions uint64
+		TotalByteSize        uint32
+	}
+
+	chunks := canonicalChunkListFixture()
+	chunks[0].TotalByteSize = 0x1234
+	require.Equal(t, flow.ChunkBodyV1, chunks[0].Version())
+	require.Equal(t, flow.ChunkBodyV0, chunks[1].Version())
+
+	body := chunks[0].ChunkBody
+	encoded := rlp.NewMarshaler().MustMarshal(body)
+	require.Equal(t, v1ChunkBodyVector, hex.EncodeToString(encoded))
+	require.Equal(t, rlp.NewMarshaler().MustMarshal(V1ChunkBody{
+		CollectionIndex:      body.CollectionIndex,
+		StartState:           body.StartState,
+		EventCollection:      body.EventCollection,
+		BlockID:              body.BlockID,
+		TotalComputationUsed: body.TotalComputationUsed,
+		NumberOfTransactions: body.NumberOfTransactions,
+		TotalByteSize:        body.TotalByteSize,
+	}), encoded)
+
+	// the size changes the ID of the chunk and the result
+	withoutSize := *chunks[0]
+	withoutSize.TotalByteSize = 0
+	require.NotEqual(t, withoutSize.ID(), chunks[0].ID())
+	require.NotEqual(t, withoutSize.Checksum(), chunks[0].Checksum())
+
+	// chunk lists with chunks of both versions round trip
+	encodedList, err := chunks.EncodeCanonical()
+	require.NoError(t, err)
+	decoded, err := flow.DecodeChunkList(encodedList)
+	require.NoError(t, err)
+	require.Len(t, *decoded, len(chunks))
+	for i, chunk := range *decoded {
+		require.Equal(t, chunks[i], chunk)
+	}
+
+	t.Run("explicit zero size", func(t *testing.T) {
+		encoded := rlp.NewMarshaler().MustMarshal(V1ChunkBody{CollectionIndex: 1})
+		var decoded flow.ChunkBody
+		require.Error(t, rlp.NewMarshaler().Unmarshal(encoded, &decoded))
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		encoded := rlp.NewMarshaler().MustMarshal(struct {
+			CollectionIndex      uint
+			StartState           flow.StateCommitment
+			EventCollection      flow.Identifier
+			BlockID              flow.Identifier
+			TotalComputationUsed uint64
+			NumberOfTransactions uint64
+			TotalByteSize        uint32
+			Unknown              uint32
+		}{TotalByteSize: 1, Unknown: 1})
+		var decoded flow.ChunkBody
+		require.Error(t, rlp.NewMarshaler().Unmarshal(encoded, &decoded))
+	})
+}
+
+// v1ChunkBodyVector is the encoding of the first chunk body of canonicalChunkListFixture, with
+// a total byte size of 0x1234.
+const v1ChunkBodyVector = "" +
+	"f86980a001010101010101010101010101010101010101010101010101010101" +
+	"01010101a0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0" +
+	"f0f0f0f0f0a0000102030405060708090a0b0c0d0e0f10111213141516171819" +
+	"1a1b1c1d1e1f8001821234"
@@ -38,6 +38,9 @@ const DefaultMaxCollectionSize = 100
 // DefaultMaxAddressIndex is the default for the maximum address index allowed to be acceptable by collection and acccess nodes.
 const DefaultMaxAddressIndex = 20_000_000
 
+// DefaultMaxTransactionArgumentCount is the default maximum number of arguments allowed for a transaction.
+const DefaultMaxTransactionArgumentCount = 100
+
 // DefaultValueLogGCFrequency is the default frequency in blocks that we call the
 // badger value log GC. Equivalent to 10 mins for a 1 second block time
 const DefaultValueLogGCFrequency = 10 * 60
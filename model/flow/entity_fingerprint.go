@@ -0,0 +1,223 @@
+package flow
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/fingerprint"
+)
+
+// EncodingVersion is the version of the canonical encodings of the entities, which determine
+// their IDs. Nodes running software with different encoding versions compute different IDs
+// for the same entities, and cannot participate in the same network. Any change of a canonical
+// encoding requires a new version, which must be introduced with a spork.
+const EncodingVersion uint = 1
+
+// goldenFingerprints are the canonical encodings and IDs of the registered entity fixtures,
+// as generated by TestEntityFingerprints for the current EncodingVersion.
+//
+//go:embed testdata/fingerprints.json
+var goldenFingerprints []byte
+
+// FingerprintGolden is the pinned canonical encoding and ID of an entity fixture.
+type FingerprintGolden struct {
+	Name        string
+	Fingerprint string // hex encoding of the canonical encoding
+	ID          Identifier
+}
+
+// FingerprintGoldens is the set of pinned entity fixtures for an encoding version.
+type FingerprintGoldens struct {
+	EncodingVersion uint
+	Entities        []FingerprintGolden
+}
+
+// entityFingerprints are the fixed fixtures of the entities, whose canonical encodings are pinned.
+var entityFingerprints = make(map[string]interface{})
+
+// RegisterEntityFingerprint registers a fixed fixture of an entity, whose canonical encoding and
+// ID are pinned by the golden fingerprints. The fixture must be fully deterministic, and must not
+// be changed once its golden fingerprint exists. Panics if the name is registered already.
+func RegisterEntityFingerprint(name string, entity interface{}) {
+	_, exists := entityFingerprints[name]
+	if exists {
+		panic(fmt.Sprintf("entity fingerprint %q registered twice", name))
+	}
+	entityFingerprints[name] = entity
+}
+
+// EntityFingerprints returns the canonical encodings and IDs of the registered entity fixtures,
+// ordered by name. The ID is the ID of the fixture if it is an entity, and its hash otherwise.
+func EntityFingerprints() FingerprintGoldens {
+	names := make([]string, 0, len(entityFingerprints))
+	for name := range entityFingerprints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	goldens := FingerprintGoldens{
+		EncodingVersion: EncodingVersion,
+		Entities:        make([]FingerprintGolden, 0, len(names)),
+	}
+	for _, name := range names {
+		entity := entityFingerprints[name]
+		var id Identifier
+		if e, ok := entity.(Entity); ok {
+			id = e.ID()
+		} else {
+			id = MakeID(entity)
+		}
+		goldens.Entities = append(goldens.Entities, FingerprintGolden{
+			Name:        name,
+			Fingerprint: hex.EncodeToString(fingerprint.Fingerprint(entity)),
+			ID:          id,
+		})
+	}
+	return goldens
+}
+
+// VerifyFingerprintCompatibility checks that the canonical encodings and IDs of the registered
+// entity fixtures match the golden fingerprints compiled into the software. A mismatch means
+// that the software computes IDs differently from the software the goldens were generated with,
+// and would fork from the network.
+func VerifyFingerprintCompatibility() error {
+	var goldens FingerprintGoldens
+	err := json.Unmarshal(goldenFingerprints, &goldens)
+	if err != nil {
+		return fmt.Errorf("could not decode golden fingerprints: %w", err)
+	}
+	if goldens.EncodingVersion != EncodingVersion {
+		return fmt.Errorf("golden fingerprints are for encoding version %d, but the encoding version is %d", goldens.EncodingVersion, EncodingVersion)
+	}
+
+	pinned := make(map[string]FingerprintGolden, len(goldens.Entities))
+	for _, golden := range goldens.Entities {
+		pinned[golden.Name] = golden
+	}
+	for _, actual := range EntityFingerprints().Entities {
+		golden, ok := pinned[actual.Name]
+		if !ok {
+			return fmt.Errorf("no golden fingerprint for entity %q", actual.Name)
+		}
+		delete(pinned, actual.Name)
+		if actual.Fingerprint != golden.Fingerprint {
+			return fmt.Errorf("canonical encoding of entity %q changed: expected %s, got %s", actual.Name, golden.Fingerprint, actual.Fingerprint)
+		}
+		if actual.ID != golden.ID {
+			return fmt.Errorf("ID of entity %q changed: expected %x, got %x", actual.Name, golden.ID, actual.ID)
+		}
+	}
+	for name := range pinned {
+		return fmt.Errorf("golden fingerprint for unregistered entity %q", name)
+	}
+
+	return nil
+}
+
+// fingerprintFixtureID returns a fixed identifier, whose bytes are all the given value.
+func fingerprintFixtureID(value byte) Identifier {
+	var id Identifier
+	for i := range id {
+		id[i] = value
+	}
+	return id
+}
+
+func fingerprintFixtureHeader() *Header {
+	return &Header{
+		ChainID:            Mainnet,
+		ParentID:           fingerprintFixtureID(0x01),
+		Height:             42,
+		PayloadHash:        fingerprintFixtureID(0x02),
+		Timestamp:          time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		View:               43,
+		ParentVoterIDs:     []Identifier{fingerprintFixtureID(0x03), fingerprintFixtureID(0x04)},
+		ParentVoterSigData: []byte{0x05, 0x06, 0x07},
+		ProposerID:         fingerprintFixtureID(0x08),
+		ProposerSigData:    []byte{0x09, 0x0a},
+	}
+}
+
+func fingerprintFixtureChunkBody(totalByteSize uint32) ChunkBody {
+	return ChunkBody{
+		CollectionIndex:      1,
+		StartState:           StateCommitment(fingerprintFixtureID(0x11)),
+		EventCollection:      fingerprintFixtureID(0x12),
+		BlockID:              fingerprintFixtureID(0x13),
+		TotalComputationUsed: 1000,
+		NumberOfTransactions: 3,
+		TotalByteSize:        totalByteSize,
+	}
+}
+
+func fingerprintFixtureResult(executionDataID Identifier) *ExecutionResult {
+	return &ExecutionResult{
+		PreviousResultID: fingerprintFixtureID(0x21),
+		BlockID:          fingerprintFixtureID(0x13),
+		Chunks: ChunkList{
+			{
+				ChunkBody: fingerprintFixtureChunkBody(0),
+				Index:     0,
+				EndState:  StateCommitment(fingerprintFixtureID(0x22)),
+			},
+		},
+		ExecutionDataID: executionDataID,
+	}
+}
+
+func fingerprintFixtureSeal() *Seal {
+	return &Seal{
+		BlockID:    fingerprintFixtureID(0x13),
+		ResultID:   fingerprintFixtureID(0x31),
+		FinalState: StateCommitment(fingerprintFixtureID(0x22)),
+		AggregatedApprovalSigs: []AggregatedSignature{
+			{
+				VerifierSignatures: []crypto.Signature{{0x32, 0x33}},
+				SignerIDs:          IdentifierList{fingerprintFixtureID(0x34)},
+			},
+		},
+	}
+}
+
+func fingerprintFixtureTransaction() *TransactionBody {
+	address := Address{0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48}
+	return &TransactionBody{
+		ReferenceBlockID: fingerprintFixtureID(0x01),
+		Script:           []byte("transaction { execute { log(42) } }"),
+		Arguments:        [][]byte{[]byte(`{"type":"Int","value":"42"}`)},
+		GasLimit:         9999,
+		ProposalKey: ProposalKey{
+			Address:        address,
+			KeyIndex:       1,
+			SequenceNumber: 2,
+		},
+		Payer:       address,
+		Authorizers: []Address{address},
+		EnvelopeSignatures: []TransactionSignature{
+			{
+				Address:     address,
+				SignerIndex: 0,
+				KeyIndex:    1,
+				Signature:   []byte{0x49, 0x4a},
+			},
+		},
+	}
+}
+
+func init() {
+	RegisterEntityFingerprint("Header", fingerprintFixtureHeader())
+	RegisterEntityFingerprint("ChunkBody", fingerprintFixtureChunkBody(0))
+	RegisterEntityFingerprint("ChunkBodyWithByteSize", fingerprintFixtureChunkBody(256))
+	RegisterEntityFingerprint("ExecutionResult", fingerprintFixtureResult(ZeroID))
+	RegisterEntityFingerprint("ExecutionResultWithExecutionDataID", fingerprintFixtureResult(fingerprintFixtureID(0x23)))
+	RegisterEntityFingerprint("Seal", fingerprintFixtureSeal())
+	RegisterEntityFingerprint("TransactionBody", fingerprintFixtureTransaction())
+	RegisterEntityFingerprint("Collection", &Collection{
+		Transactions: []*TransactionBody{fingerprintFixtureTransaction()},
+	})
+}
@@ -3,6 +3,8 @@ package flow
 import (
 	"encoding/json"
 	"errors"
+
+	"github.com/onflow/flow-go/model/fingerprint"
 )
 
 var ErrNoChunks = errors.New("execution result has no chunks")
@@ -14,6 +16,32 @@ type ExecutionResult struct {
 	BlockID          Identifier // commit of the current block
 	Chunks           ChunkList
 	ServiceEvents    ServiceEventList
+	// ExecutionDataID commits to the execution data of the block (collections, transaction
+	// results, events and trie updates). It is the ZeroID for results of version
+	// ExecutionResultV0. As all execution nodes must produce identical results, the version is
+	// selected by configuration, and must only be changed for all execution nodes at once.
+	ExecutionDataID Identifier
+}
+
+// ExecutionResultVersion is the version of the hash pre-image of execution results.
+type ExecutionResultVersion uint
+
+const (
+	// ExecutionResultV0 is the original version of execution results, which don't commit to
+	// the execution data of their block.
+	ExecutionResultV0 ExecutionResultVersion = iota
+	// ExecutionResultV1 is the version of execution results, which commit to the execution
+	// data ID of their block.
+	ExecutionResultV1
+)
+
+// Version returns the version of the execution result, which is determined by the fields it
+// commits to.
+func (er ExecutionResult) Version() ExecutionResultVersion {
+	if er.ExecutionDataID != ZeroID {
+		return ExecutionResultV1
+	}
+	return ExecutionResultV0
 }
 
 // ID returns the hash of the execution result body
@@ -21,6 +49,40 @@ func (er ExecutionResult) ID() Identifier {
 	return MakeID(er)
 }
 
+// Fingerprint returns the canonical encoding of the execution result. Results without an
+// execution data ID are encoded as before the field was introduced, so that the IDs of
+// existing results remain unchanged. Both encodings have a different number of fields,
+// so a result with an execution data ID can never have the ID of a result without one.
+// Chunks are encoded with the canonical encoding of the chunk list in both cases.
+func (er ExecutionResult) Fingerprint() []byte {
+	if er.Version() == ExecutionResultV0 {
+		return fingerprint.Fingerprint(struct {
+			PreviousResultID Identifier
+			BlockID          Identifier
+			Chunks           ChunkList
+			ServiceEvents    ServiceEventList
+		}{
+			PreviousResultID: er.PreviousResultID,
+			BlockID:          er.BlockID,
+			Chunks:           er.Chunks,
+			ServiceEvents:    er.ServiceEvents,
+		})
+	}
+	return fingerprint.Fingerprint(struct {
+		PreviousResultID Identifier
+		BlockID          Identifier
+		Chunks           ChunkList
+		ServiceEvents    ServiceEventList
+		ExecutionDataID  Identifier
+	}{
+		PreviousResultID: er.PreviousResultID,
+		BlockID:          er.BlockID,
+		Chunks:           er.Chunks,
+		ServiceEvents:    er.ServiceEvents,
+		ExecutionDataID:  er.ExecutionDataID,
+	})
+}
+
 // Checksum ...
 func (er ExecutionResult) Checksum() Identifier {
 	return MakeID(er)
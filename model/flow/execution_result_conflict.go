@@ -0,0 +1,47 @@
+package flow
+
+import (
+	"bytes"
+)
+
+// ExecutionResultConflict is the evidence of an execution fork: two different execution
+// results for the same block, which build on the same previous result, together with the
+// execution nodes which committed to each of the results.
+type ExecutionResultConflict struct {
+	BlockID          Identifier
+	PreviousResultID Identifier
+	ResultIDs        [2]Identifier     // the conflicting results, in ascending order
+	ExecutorIDs      [2]IdentifierList // the executors of each result, in the order of the results
+}
+
+// NewExecutionResultConflict creates the conflict between the two given results for the
+// given block and previous result, without any executors.
+func NewExecutionResultConflict(blockID Identifier, previousResultID Identifier, resultID1 Identifier, resultID2 Identifier) *ExecutionResultConflict {
+	if bytes.Compare(resultID1[:], resultID2[:]) > 0 {
+		resultID1, resultID2 = resultID2, resultID1
+	}
+	return &ExecutionResultConflict{
+		BlockID:          blockID,
+		PreviousResultID: previousResultID,
+		ResultIDs:        [2]Identifier{resultID1, resultID2},
+	}
+}
+
+// AddExecutor adds the executor to the executors of the given result, which must be one of
+// the conflicting results. It returns false if the executor was already added, or if
+// the result is not part of the conflict.
+func (c *ExecutionResultConflict) AddExecutor(resultID Identifier, executorID Identifier) bool {
+	for i, conflictingID := range c.ResultIDs {
+		if conflictingID != resultID {
+			continue
+		}
+		for _, existingID := range c.ExecutorIDs[i] {
+			if existingID == executorID {
+				return false
+			}
+		}
+		c.ExecutorIDs[i] = append(c.ExecutorIDs[i], executorID)
+		return true
+	}
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v4"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -41,3 +43,54 @@ func TestExecutionResultGroupBy(t *testing.T) {
 	unknown := groups.GetGroup(unittest.IdentifierFixture())
 	assert.Equal(t, 0, unknown.Size())
 }
+
+// legacyExecutionResult is the execution result before the execution data ID was introduced.
+type legacyExecutionResult struct {
+	PreviousResultID flow.Identifier
+	BlockID          flow.Identifier
+	Chunks           flow.ChunkList
+	ServiceEvents    flow.ServiceEventList
+}
+
+// TestExecutionResultExecutionDataID tests that the ID of an execution result commits to its
+// execution data ID, while results without one keep their ID and encoding from before the
+// field was introduced.
+func TestExecutionResultExecutionDataID(t *testing.T) {
+	result := unittest.ExecutionResultFixture(unittest.WIthServiceEvents(2))
+	require.Equal(t, flow.ZeroID, result.ExecutionDataID)
+	legacy := legacyExecutionResult{
+		PreviousResultID: result.PreviousResultID,
+		BlockID:          result.BlockID,
+		Chunks:           result.Chunks,
+		ServiceEvents:    result.ServiceEvents,
+	}
+
+	t.Run("legacy results keep their ID", func(t *testing.T) {
+		assert.Equal(t, flow.ExecutionResultV0, result.Version())
+		assert.Equal(t, flow.MakeID(legacy), result.ID())
+	})
+
+	t.Run("ID commits to the execution data ID", func(t *testing.T) {
+		withData := *result
+		withData.ExecutionDataID = unittest.IdentifierFixture()
+		assert.Equal(t, flow.ExecutionResultV1, withData.Version())
+		assert.NotEqual(t, result.ID(), withData.ID())
+
+		other := withData
+		other.ExecutionDataID = unittest.IdentifierFixture()
+		assert.NotEqual(t, withData.ID(), other.ID())
+		assert.Equal(t, withData.ID(), withData.Checksum())
+	})
+
+	t.Run("legacy encoding decodes", func(t *testing.T) {
+		legacy := legacy
+		legacy.ServiceEvents = nil
+		data, err := msgpack.Marshal(legacy)
+		require.NoError(t, err)
+
+		var decoded flow.ExecutionResult
+		require.NoError(t, msgpack.Unmarshal(data, &decoded))
+		assert.Equal(t, flow.ZeroID, decoded.ExecutionDataID)
+		assert.Equal(t, flow.MakeID(legacy), decoded.ID())
+	})
+}
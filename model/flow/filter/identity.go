@@ -100,7 +100,9 @@ func HasRole(roles ...flow.Role) flow.IdentityFilter {
 }
 
 // IsValidCurrentEpochParticipant is an identity filter for members of the
-// current epoch in good standing.
+// current epoch in good standing: they have positive stake and were not ejected.
+// Identities with zero stake, such as observer-style access nodes, are members of
+// the identity table, but do not participate in the protocol.
 var IsValidCurrentEpochParticipant = And(
 	HasStake(true),
 	Not(Ejected),
@@ -113,6 +115,22 @@ var IsVotingConsensusCommitteeMember = And(
 	IsValidCurrentEpochParticipant,
 )
 
+// IsAuthorizedReceiptSource is an identity filter for all nodes authorized to
+// produce execution receipts: execution nodes with positive stake, which were
+// not ejected.
+var IsAuthorizedReceiptSource = And(
+	HasRole(flow.RoleExecution),
+	IsValidCurrentEpochParticipant,
+)
+
+// IsAuthorizedApprovalSource is an identity filter for all nodes authorized to
+// produce result approvals: verification nodes with positive stake, which were
+// not ejected.
+var IsAuthorizedApprovalSource = And(
+	HasRole(flow.RoleVerification),
+	IsValidCurrentEpochParticipant,
+)
+
 // IsValidDKGParticipant is an identity filter for all DKG participants. It is
 // equivalent to the filter for consensus committee members, as these are
 // the same group for now.
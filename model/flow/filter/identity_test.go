@@ -0,0 +1,75 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// identityTable contains, for each role, a staked identity in good standing, an ejected
+// identity and an identity with zero stake.
+type identityTable struct {
+	staked   map[flow.Role]*flow.Identity
+	ejected  map[flow.Role]*flow.Identity
+	unstaked map[flow.Role]*flow.Identity
+}
+
+func newIdentityTable() identityTable {
+	table := identityTable{
+		staked:   make(map[flow.Role]*flow.Identity),
+		ejected:  make(map[flow.Role]*flow.Identity),
+		unstaked: make(map[flow.Role]*flow.Identity),
+	}
+	for _, role := range flow.Roles() {
+		table.staked[role] = unittest.IdentityFixture(unittest.WithRole(role), unittest.WithStake(100))
+		ejected := unittest.IdentityFixture(unittest.WithRole(role), unittest.WithStake(100))
+		ejected.Ejected = true
+		table.ejected[role] = ejected
+		table.unstaked[role] = unittest.IdentityFixture(unittest.WithRole(role), unittest.WithStake(0))
+	}
+	return table
+}
+
+// assertPredicate checks the predicate against all identities of the table. The predicate
+// must accept exactly the staked identities with the accepted roles, and no identities with
+// zero stake or ejected identities.
+func assertPredicate(t *testing.T, predicate flow.IdentityFilter, staked []flow.Role) {
+	table := newIdentityTable()
+	contains := func(roles []flow.Role, role flow.Role) bool {
+		for _, r := range roles {
+			if r == role {
+				return true
+			}
+		}
+		return false
+	}
+	for _, role := range flow.Roles() {
+		assert.Equal(t, contains(staked, role), predicate(table.staked[role]), "staked %s", role)
+		assert.False(t, predicate(table.unstaked[role]), "zero stake %s", role)
+		assert.False(t, predicate(table.ejected[role]), "ejected %s", role)
+	}
+}
+
+func TestIsValidCurrentEpochParticipant(t *testing.T) {
+	assertPredicate(t, filter.IsValidCurrentEpochParticipant, flow.Roles())
+}
+
+func TestIsVotingConsensusCommitteeMember(t *testing.T) {
+	assertPredicate(t, filter.IsVotingConsensusCommitteeMember, []flow.Role{flow.RoleConsensus})
+}
+
+func TestIsValidDKGParticipant(t *testing.T) {
+	assertPredicate(t, filter.IsValidDKGParticipant, []flow.Role{flow.RoleConsensus})
+}
+
+func TestIsAuthorizedReceiptSource(t *testing.T) {
+	assertPredicate(t, filter.IsAuthorizedReceiptSource, []flow.Role{flow.RoleExecution})
+}
+
+func TestIsAuthorizedApprovalSource(t *testing.T) {
+	assertPredicate(t, filter.IsAuthorizedApprovalSource, []flow.Role{flow.RoleVerification})
+}
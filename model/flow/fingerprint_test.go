@@ -0,0 +1,67 @@
+package flow_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// updateFingerprints regenerates the golden fingerprints. It must only be used deliberately
+// together with a new flow.EncodingVersion, as changing a canonical encoding changes the IDs
+// of the entities, which forks the network.
+var updateFingerprints = flag.Bool("update-fingerprints", false, "regenerate the golden entity fingerprints")
+
+var goldenFingerprintsFile = filepath.Join("testdata", "fingerprints.json")
+
+// TestEntityFingerprints tests that the canonical encodings and IDs of the registered entity
+// fixtures match the golden fingerprints.
+func TestEntityFingerprints(t *testing.T) {
+	actual := flow.EntityFingerprints()
+
+	if *updateFingerprints {
+		data, err := json.MarshalIndent(actual, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(goldenFingerprintsFile, append(data, '\n'), 0644))
+	}
+
+	data, err := os.ReadFile(goldenFingerprintsFile)
+	require.NoError(t, err)
+	var goldens flow.FingerprintGoldens
+	require.NoError(t, json.Unmarshal(data, &goldens))
+	require.Equal(t, flow.EncodingVersion, goldens.EncodingVersion, "golden fingerprints are for a different encoding version")
+
+	pinned := make(map[string]flow.FingerprintGolden)
+	for _, golden := range goldens.Entities {
+		pinned[golden.Name] = golden
+	}
+	for _, entity := range actual.Entities {
+		t.Run(entity.Name, func(t *testing.T) {
+			golden, ok := pinned[entity.Name]
+			require.True(t, ok, "no golden fingerprint, regenerate the goldens with -update-fingerprints")
+			assert.Equal(t, golden.Fingerprint, entity.Fingerprint, "canonical encoding changed")
+			assert.Equal(t, golden.ID, entity.ID, "ID changed")
+		})
+		delete(pinned, entity.Name)
+	}
+	assert.Empty(t, pinned, "golden fingerprints for unregistered entities")
+}
+
+// TestVerifyFingerprintCompatibility tests that the golden fingerprints compiled into the
+// software match the registered entity fixtures.
+func TestVerifyFingerprintCompatibility(t *testing.T) {
+	require.NoError(t, flow.VerifyFingerprintCompatibility())
+}
+
+// TestRegisterEntityFingerprint_Duplicate tests that an entity name can only be registered once.
+func TestRegisterEntityFingerprint_Duplicate(t *testing.T) {
+	assert.Panics(t, func() {
+		flow.RegisterEntityFingerprint("Header", &flow.Header{})
+	})
+}
@@ -3,6 +3,8 @@ package flow
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -137,6 +139,39 @@ func (h Header) Checksum() Identifier {
 	return MakeID(h)
 }
 
+var (
+	// ErrParentMismatch is returned if the header doesn't reference the given parent.
+	ErrParentMismatch = errors.New("header does not reference parent")
+	// ErrChainMismatch is returned if the header is on another chain than its parent.
+	ErrChainMismatch = errors.New("header chain ID differs from parent")
+	// ErrHeightGap is returned if the height of the header isn't the height of its parent + 1.
+	ErrHeightGap = errors.New("header height does not follow parent height")
+	// ErrViewRegression is returned if the view of the header isn't greater than the view of its parent.
+	ErrViewRegression = errors.New("header view is not greater than parent view")
+)
+
+// VerifyParentLink checks that the header correctly extends the given parent header, i.e. that
+// it references the parent by its ID, that it is on the same chain, that its height is the
+// height of the parent + 1 and that its view is strictly greater than the view of the parent.
+// The returned error wraps one of ErrParentMismatch, ErrChainMismatch, ErrHeightGap and
+// ErrViewRegression, so that callers can decide how to treat each violation.
+func (h Header) VerifyParentLink(parent *Header) error {
+	parentID := parent.ID()
+	if h.ParentID != parentID {
+		return fmt.Errorf("parent ID %x instead of %x: %w", h.ParentID, parentID, ErrParentMismatch)
+	}
+	if h.ChainID != parent.ChainID {
+		return fmt.Errorf("chain ID %s instead of %s: %w", h.ChainID, parent.ChainID, ErrChainMismatch)
+	}
+	if h.Height != parent.Height+1 {
+		return fmt.Errorf("height %d with parent height %d: %w", h.Height, parent.Height, ErrHeightGap)
+	}
+	if h.View <= parent.View {
+		return fmt.Errorf("view %d with parent view %d: %w", h.View, parent.View, ErrViewRegression)
+	}
+	return nil
+}
+
 // MarshalJSON makes sure the timestamp is encoded in UTC.
 func (h Header) MarshalJSON() ([]byte, error) {
 
@@ -97,3 +97,40 @@ func TestNonUTCTimestampSameHashAsUTC(t *testing.T) {
 	checkedID := header.ID()
 	assert.Equal(t, headerID, checkedID)
 }
+
+func TestHeaderVerifyParentLink(t *testing.T) {
+	parent := unittest.BlockHeaderFixture()
+
+	t.Run("valid link", func(t *testing.T) {
+		header := unittest.BlockHeaderWithParentFixture(&parent)
+		require.NoError(t, header.VerifyParentLink(&parent))
+	})
+
+	t.Run("parent mismatch", func(t *testing.T) {
+		header := unittest.BlockHeaderWithParentFixture(&parent)
+		header.ParentID = unittest.IdentifierFixture()
+		require.ErrorIs(t, header.VerifyParentLink(&parent), flow.ErrParentMismatch)
+	})
+
+	t.Run("chain mismatch", func(t *testing.T) {
+		header := unittest.BlockHeaderWithParentFixture(&parent)
+		header.ChainID = flow.Testnet
+		require.ErrorIs(t, header.VerifyParentLink(&parent), flow.ErrChainMismatch)
+	})
+
+	t.Run("height gap", func(t *testing.T) {
+		header := unittest.BlockHeaderWithParentFixture(&parent)
+		header.Height = parent.Height + 2
+		require.ErrorIs(t, header.VerifyParentLink(&parent), flow.ErrHeightGap)
+		header.Height = parent.Height
+		require.ErrorIs(t, header.VerifyParentLink(&parent), flow.ErrHeightGap)
+	})
+
+	t.Run("view regression", func(t *testing.T) {
+		header := unittest.BlockHeaderWithParentFixture(&parent)
+		header.View = parent.View
+		require.ErrorIs(t, header.VerifyParentLink(&parent), flow.ErrViewRegression)
+		header.View = parent.View - 1
+		require.ErrorIs(t, header.VerifyParentLink(&parent), flow.ErrViewRegression)
+	})
+}
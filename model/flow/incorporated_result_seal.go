@@ -15,6 +15,15 @@ type IncorporatedResultSeal struct {
 	// the header of the executed block
 	// useful for indexing the seal by height in the mempool in order for fast pruning
 	Header *Header
+
+	// ApprovingStake is the total stake of the distinct verifiers whose approvals are aggregated
+	// in the seal, computed when the seal is constructed. The value is advisory: the block builder
+	// uses it to prefer among competing seals, it is not part of the seal included in blocks.
+	ApprovingStake uint64
+
+	// EmergencySealed marks seals, which were constructed in emergency mode without sufficient
+	// approvals. Like ApprovingStake, it is advisory and not part of the seal included in blocks.
+	EmergencySealed bool
 }
 
 // ID implements flow.Entity.ID for IncorporatedResultSeal to make it capable of
@@ -0,0 +1,63 @@
+package flow
+
+import (
+	"time"
+)
+
+// MisbehaviorReason describes the protocol violation of a misbehavior report.
+type MisbehaviorReason string
+
+const (
+	// MisbehaviorInvalidReceipt is reported for execution receipts which fail validation.
+	MisbehaviorInvalidReceipt MisbehaviorReason = "invalid_receipt"
+	// MisbehaviorMalformedReceipt is reported for execution receipts which are structurally malformed.
+	MisbehaviorMalformedReceipt MisbehaviorReason = "malformed_receipt"
+	// MisbehaviorChannelViolation is reported for messages sent on a channel the receiving
+	// node isn't subscribed to.
+	MisbehaviorChannelViolation MisbehaviorReason = "channel_violation"
+	// MisbehaviorMalformedApprovalBatch is reported for result approval batches which exceed
+	// the size limits, or which contain approvals for another result or by another approver.
+	MisbehaviorMalformedApprovalBatch MisbehaviorReason = "malformed_approval_batch"
+	// MisbehaviorRepeatedInvalidSubmissions is reported for nodes which repeatedly sent messages
+	// rejected by the receiving engine, e.g. from a node with the wrong role.
+	MisbehaviorRepeatedInvalidSubmissions MisbehaviorReason = "repeated_invalid_submissions"
+)
+
+// MisbehaviorReport is a report of a protocol violation by a node, together with the
+// IDs of the entities evidencing the violation.
+type MisbehaviorReport struct {
+	OriginID    Identifier        // node which violated the protocol
+	Reason      MisbehaviorReason // the violated protocol rule
+	EvidenceIDs []Identifier      // IDs of the entities evidencing the violation
+	Timestamp   time.Time         // time at which the violation was reported
+}
+
+// Key returns the deduplication key of the report, which is identical for all
+// reports of the same origin and reason with the same evidence.
+func (r *MisbehaviorReport) Key() Identifier {
+	return MakeID(struct {
+		OriginID    Identifier
+		Reason      string
+		EvidenceIDs []Identifier
+	}{
+		OriginID:    r.OriginID,
+		Reason:      string(r.Reason),
+		EvidenceIDs: r.EvidenceIDs,
+	})
+}
+
+// ID returns the unique ID of the report.
+func (r *MisbehaviorReport) ID() Identifier {
+	return MakeID(struct {
+		Key       Identifier
+		Timestamp uint64
+	}{
+		Key:       r.Key(),
+		Timestamp: uint64(r.Timestamp.UnixNano()),
+	})
+}
+
+// Checksum returns the checksum of the report.
+func (r *MisbehaviorReport) Checksum() Identifier {
+	return r.ID()
+}
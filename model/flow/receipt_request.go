@@ -0,0 +1,15 @@
+package flow
+
+import (
+	"time"
+)
+
+// ReceiptRequest is the bookkeeping of a consensus node's requests for the execution receipts
+// of a finalized block. It is persisted, so that a restarted node backs off from the blocks it
+// requested recently, instead of requesting the receipts of all unsealed blocks at once.
+type ReceiptRequest struct {
+	BlockID       Identifier
+	Height        uint64    // height of the block, used for pruning once the block is sealed
+	LastRequested time.Time // time of the last request
+	Attempts      uint64    // number of requests so far
+}
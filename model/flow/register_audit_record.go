@@ -0,0 +1,36 @@
+package flow
+
+// RegisterAccess describes how a transaction accessed a register.
+type RegisterAccess string
+
+const (
+	// RegisterRead is recorded for registers which were only read by a transaction.
+	RegisterRead RegisterAccess = "read"
+	// RegisterWrite is recorded for registers which were written by a transaction.
+	RegisterWrite RegisterAccess = "write"
+)
+
+// RegisterAuditRecord records the access of a transaction to a register owned by an audited account.
+type RegisterAuditRecord struct {
+	TransactionID Identifier     // transaction which accessed the register
+	BlockID       Identifier     // block containing the transaction
+	Height        uint64         // height of the block containing the transaction
+	RegisterID    RegisterID     // the accessed register
+	Access        RegisterAccess // whether the register was read or written
+	ValueHash     Identifier     // hash of the value which was read or written
+}
+
+// Owner returns the address of the account owning the accessed register.
+func (r *RegisterAuditRecord) Owner() Address {
+	return BytesToAddress([]byte(r.RegisterID.Owner))
+}
+
+// ID returns the unique ID of the record.
+func (r *RegisterAuditRecord) ID() Identifier {
+	return MakeID(r)
+}
+
+// Checksum returns the checksum of the record.
+func (r *RegisterAuditRecord) Checksum() Identifier {
+	return MakeID(r)
+}
@@ -4,7 +4,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding/rlp"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -24,3 +28,47 @@ func Test_SealID(t *testing.T) {
 	assert.NotEqual(t, id, seal.ID())
 	assert.NotEqual(t, cs, seal.Checksum())
 }
+
+// Test_AggregatedSignatureSpocksEncoding checks that the SPoCK proofs are only encoded if they are
+// set, so that seals without SPoCK proofs keep the IDs of the encoding without the field, and that
+// aggregated signatures with and without SPoCK proofs round trip.
+func Test_AggregatedSignatureSpocksEncoding(t *testing.T) {
+	// legacyAggregatedSignature replicates the layout of aggregated signatures without SPoCK proofs
+	type legacyAggregatedSignature struct {
+		VerifierSignatures []crypto.Signature
+		SignerIDs          flow.IdentifierList
+	}
+
+	sig := unittest.Seal.AggregatedSignatureFixture()
+	encoded := rlp.NewMarshaler().MustMarshal(sig)
+	require.Equal(t, rlp.NewMarshaler().MustMarshal(legacyAggregatedSignature{
+		VerifierSignatures: sig.VerifierSignatures,
+		SignerIDs:          sig.SignerIDs,
+	}), encoded)
+
+	var decoded flow.AggregatedSignature
+	require.NoError(t, rlp.NewMarshaler().Unmarshal(encoded, &decoded))
+	require.Equal(t, sig, decoded)
+
+	// the SPoCK proofs change the ID of the seal
+	seal := unittest.Seal.Fixture()
+	id := seal.ID()
+	seal.AggregatedApprovalSigs[0].Spocks = unittest.SignaturesFixture(len(seal.AggregatedApprovalSigs[0].SignerIDs))
+	require.NotEqual(t, id, seal.ID())
+
+	withSpocks := seal.AggregatedApprovalSigs[0]
+	encoded = rlp.NewMarshaler().MustMarshal(withSpocks)
+	decoded = flow.AggregatedSignature{}
+	require.NoError(t, rlp.NewMarshaler().Unmarshal(encoded, &decoded))
+	require.Equal(t, withSpocks, decoded)
+
+	t.Run("explicit empty list of proofs", func(t *testing.T) {
+		encoded := rlp.NewMarshaler().MustMarshal(struct {
+			VerifierSignatures []crypto.Signature
+			SignerIDs          flow.IdentifierList
+			Spocks             []crypto.Signature
+		}{})
+		var decoded flow.AggregatedSignature
+		require.Error(t, rlp.NewMarshaler().Unmarshal(encoded, &decoded))
+	})
+}
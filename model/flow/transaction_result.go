@@ -13,6 +13,10 @@ type TransactionResult struct {
 	ErrorMessage string
 	// Computation used
 	ComputationUsed uint64
+	// ComputationBreakdown is the execution effort of the transaction keyed by category.
+	// It is only populated if execution effort profiling is enabled, which is a local setting
+	// of the execution node, hence it is not part of the canonical encoding.
+	ComputationBreakdown map[string]uint64 `rlp:"-"`
 }
 
 // String returns the string representation of this error.
@@ -0,0 +1,165 @@
+package messages
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DKGMessageEncodingVersion is the version of the canonical DKG message encoding.
+// It is the first byte of every canonically encoded message, which distinguishes
+// canonical encodings from the legacy JSON encoding (starting with '{').
+const DKGMessageEncodingVersion byte = 0x01
+
+// ErrInvalidDKGMessageEncoding is returned when decoding a malformed canonical DKG message.
+var ErrInvalidDKGMessageEncoding = errors.New("invalid canonical DKG message encoding")
+
+// MarshalCanonical returns the canonical encoding of the DKG message. Integers are
+// big-endian and byte fields are prefixed with their length as a 4-byte unsigned
+// integer. The fields are encoded in the following order:
+//
+//   version        1 byte, always DKGMessageEncodingVersion
+//   orig           8 bytes
+//   len(data)      4 bytes
+//   data           len(data) bytes
+//   len(instance)  4 bytes
+//   instance       len(instance) bytes, the UTF-8 encoded DKG instance ID
+//
+// The canonical encoding is the pre-image of the signature of broadcast messages.
+// Test vectors are provided in testdata/dkg_message_vectors.json.
+func (m DKGMessage) MarshalCanonical() ([]byte, error) {
+	if uint64(len(m.Data)) > math.MaxUint32 || uint64(len(m.DKGInstanceID)) > math.MaxUint32 {
+		return nil, fmt.Errorf("DKG message fields exceed maximum encodable length")
+	}
+
+	buf := make([]byte, 0, 1+8+4+len(m.Data)+4+len(m.DKGInstanceID))
+	buf = append(buf, DKGMessageEncodingVersion)
+	buf = appendUint64(buf, m.Orig)
+	buf = appendBytes(buf, m.Data)
+	buf = appendBytes(buf, []byte(m.DKGInstanceID))
+	return buf, nil
+}
+
+// UnmarshalCanonical decodes the canonical encoding of a DKG message, as produced
+// by MarshalCanonical. Encodings with an unknown version, truncated fields or
+// trailing bytes are rejected with ErrInvalidDKGMessageEncoding.
+func (m *DKGMessage) UnmarshalCanonical(encoded []byte) error {
+	rest, err := m.decodeCanonical(encoded)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%d trailing bytes: %w", len(rest), ErrInvalidDKGMessageEncoding)
+	}
+	return nil
+}
+
+// decodeCanonical decodes a canonical DKG message from the start of the given
+// bytes and returns the remaining bytes.
+func (m *DKGMessage) decodeCanonical(encoded []byte) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("empty input: %w", ErrInvalidDKGMessageEncoding)
+	}
+	if encoded[0] != DKGMessageEncodingVersion {
+		return nil, fmt.Errorf("unknown version %d: %w", encoded[0], ErrInvalidDKGMessageEncoding)
+	}
+
+	orig, rest, err := readUint64(encoded[1:])
+	if err != nil {
+		return nil, fmt.Errorf("could not decode orig: %w", err)
+	}
+	data, rest, err := readBytes(rest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode data: %w", err)
+	}
+	instanceID, rest, err := readBytes(rest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode DKG instance ID: %w", err)
+	}
+
+	*m = DKGMessage{
+		Orig:          orig,
+		Data:          data,
+		DKGInstanceID: string(instanceID),
+	}
+	return rest, nil
+}
+
+// MarshalCanonical returns the canonical encoding of the broadcast message, which
+// is the canonical encoding of the DKG message followed by the length-prefixed
+// signature:
+//
+//   message        canonical encoding of the DKG message
+//   len(signature) 4 bytes
+//   signature      len(signature) bytes
+func (m BroadcastDKGMessage) MarshalCanonical() ([]byte, error) {
+	encoded, err := m.DKGMessage.MarshalCanonical()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(m.Signature)) > math.MaxUint32 {
+		return nil, fmt.Errorf("signature exceeds maximum encodable length")
+	}
+	return appendBytes(encoded, m.Signature), nil
+}
+
+// UnmarshalCanonical decodes the canonical encoding of a broadcast message, as
+// produced by MarshalCanonical.
+func (m *BroadcastDKGMessage) UnmarshalCanonical(encoded []byte) error {
+	var msg DKGMessage
+	rest, err := msg.decodeCanonical(encoded)
+	if err != nil {
+		return err
+	}
+	signature, rest, err := readBytes(rest)
+	if err != nil {
+		return fmt.Errorf("could not decode signature: %w", err)
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%d trailing bytes: %w", len(rest), ErrInvalidDKGMessageEncoding)
+	}
+
+	*m = BroadcastDKGMessage{
+		DKGMessage: msg,
+		Signature:  signature,
+	}
+	return nil
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendBytes(buf []byte, data []byte) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(len(data)))
+	buf = append(buf, b[:]...)
+	return append(buf, data...)
+}
+
+func readUint64(encoded []byte) (uint64, []byte, error) {
+	if len(encoded) < 8 {
+		return 0, nil, fmt.Errorf("truncated integer: %w", ErrInvalidDKGMessageEncoding)
+	}
+	return binary.BigEndian.Uint64(encoded[:8]), encoded[8:], nil
+}
+
+func readBytes(encoded []byte) ([]byte, []byte, error) {
+	if len(encoded) < 4 {
+		return nil, nil, fmt.Errorf("truncated length prefix: %w", ErrInvalidDKGMessageEncoding)
+	}
+	length := binary.BigEndian.Uint32(encoded[:4])
+	encoded = encoded[4:]
+	if uint64(len(encoded)) < uint64(length) {
+		return nil, nil, fmt.Errorf("truncated field of length %d: %w", length, ErrInvalidDKGMessageEncoding)
+	}
+	if length == 0 {
+		return nil, encoded, nil
+	}
+	data := make([]byte, length)
+	copy(data, encoded[:length])
+	return data, encoded[length:], nil
+}
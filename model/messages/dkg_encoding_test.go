@@ -0,0 +1,155 @@
+package messages_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// dkgMessageVectors is the format of the DKG message test vector file, which is
+// intended to be consumed by alternative implementations of the encoding as well.
+type dkgMessageVectors struct {
+	Messages []struct {
+		Name          string `json:"name"`
+		Orig          uint64 `json:"orig"`
+		Data          string `json:"data"`
+		DKGInstanceID string `json:"dkg_instance_id"`
+		Encoded       string `json:"encoded"`
+	} `json:"messages"`
+	BroadcastMessages []struct {
+		Name          string `json:"name"`
+		Orig          uint64 `json:"orig"`
+		Data          string `json:"data"`
+		DKGInstanceID string `json:"dkg_instance_id"`
+		Signature     string `json:"signature"`
+		Encoded       string `json:"encoded"`
+	} `json:"broadcast_messages"`
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// TestDKGMessage_CanonicalVectors checks the canonical encoding against the golden test vectors.
+func TestDKGMessage_CanonicalVectors(t *testing.T) {
+	raw, err := os.ReadFile("testdata/dkg_message_vectors.json")
+	require.NoError(t, err)
+	var vectors dkgMessageVectors
+	require.NoError(t, json.Unmarshal(raw, &vectors))
+	require.NotEmpty(t, vectors.Messages)
+	require.NotEmpty(t, vectors.BroadcastMessages)
+
+	for _, vector := range vectors.Messages {
+		t.Run(vector.Name, func(t *testing.T) {
+			msg := messages.DKGMessage{
+				Orig:          vector.Orig,
+				Data:          decodeHex(t, vector.Data),
+				DKGInstanceID: vector.DKGInstanceID,
+			}
+
+			encoded, err := msg.MarshalCanonical()
+			require.NoError(t, err)
+			assert.Equal(t, vector.Encoded, hex.EncodeToString(encoded))
+
+			var decoded messages.DKGMessage
+			require.NoError(t, decoded.UnmarshalCanonical(decodeHex(t, vector.Encoded)))
+			assert.Equal(t, msg, decoded)
+		})
+	}
+
+	for _, vector := range vectors.BroadcastMessages {
+		t.Run(vector.Name, func(t *testing.T) {
+			msg := messages.BroadcastDKGMessage{
+				DKGMessage: messages.DKGMessage{
+					Orig:          vector.Orig,
+					Data:          decodeHex(t, vector.Data),
+					DKGInstanceID: vector.DKGInstanceID,
+				},
+				Signature: decodeHex(t, vector.Signature),
+			}
+
+			encoded, err := msg.MarshalCanonical()
+			require.NoError(t, err)
+			assert.Equal(t, vector.Encoded, hex.EncodeToString(encoded))
+
+			var decoded messages.BroadcastDKGMessage
+			require.NoError(t, decoded.UnmarshalCanonical(decodeHex(t, vector.Encoded)))
+			assert.Equal(t, msg, decoded)
+		})
+	}
+}
+
+// TestDKGMessage_CanonicalRoundTrip checks that random messages survive an encoding round trip.
+func TestDKGMessage_CanonicalRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	for i := 0; i < 100; i++ {
+		data := make([]byte, 1+rng.Intn(1024))
+		_, _ = rng.Read(data)
+		signature := make([]byte, 48)
+		_, _ = rng.Read(signature)
+
+		msg := messages.BroadcastDKGMessage{
+			DKGMessage: messages.NewDKGMessage(rng.Intn(1000), data, "dkg-instance"),
+			Signature:  signature,
+		}
+
+		encoded, err := msg.DKGMessage.MarshalCanonical()
+		require.NoError(t, err)
+		var decodedMsg messages.DKGMessage
+		require.NoError(t, decodedMsg.UnmarshalCanonical(encoded))
+		require.Equal(t, msg.DKGMessage, decodedMsg)
+
+		encoded, err = msg.MarshalCanonical()
+		require.NoError(t, err)
+		var decodedBcast messages.BroadcastDKGMessage
+		require.NoError(t, decodedBcast.UnmarshalCanonical(encoded))
+		require.Equal(t, msg, decodedBcast)
+	}
+}
+
+// TestDKGMessage_CanonicalMalformed checks that malformed encodings are rejected.
+func TestDKGMessage_CanonicalMalformed(t *testing.T) {
+	msg := messages.NewDKGMessage(1, []byte("hello world"), "flow-testnet-42")
+	encoded, err := msg.MarshalCanonical()
+	require.NoError(t, err)
+
+	unknownVersion := append([]byte{}, encoded...)
+	unknownVersion[0] = 0x02
+
+	cases := map[string][]byte{
+		"empty":           {},
+		"legacy json":     []byte(`{"Orig":1,"Data":"aGVsbG8gd29ybGQ=","DKGInstanceID":"flow-testnet-42"}`),
+		"unknown version": unknownVersion,
+		"truncated orig":  encoded[:5],
+		"truncated data":  encoded[:15],
+		"truncated id":    encoded[:len(encoded)-1],
+		"trailing bytes":  append(append([]byte{}, encoded...), 0x00),
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			var decoded messages.DKGMessage
+			err := decoded.UnmarshalCanonical(input)
+			assert.True(t, errors.Is(err, messages.ErrInvalidDKGMessageEncoding), err)
+		})
+	}
+
+	// a DKG message without signature is not a valid broadcast message
+	var decoded messages.BroadcastDKGMessage
+	err = decoded.UnmarshalCanonical(encoded)
+	assert.True(t, errors.Is(err, messages.ErrInvalidDKGMessageEncoding), err)
+}
@@ -15,3 +15,38 @@ type ApprovalResponse struct {
 	Nonce    uint64
 	Approval flow.ResultApproval
 }
+
+const (
+	// MaxApprovalBatchSize is the maximum number of approvals in a ResultApprovalBatch.
+	MaxApprovalBatchSize = 16
+
+	// MaxApprovalBatchByteSize is the maximum byte size of a ResultApprovalBatch, as
+	// returned by ByteSize.
+	MaxApprovalBatchByteSize = 8 * 1024
+)
+
+// ResultApprovalBatch carries multiple approvals for chunks of the same execution
+// result. Verification nodes send it instead of separate approvals, when several
+// chunk verifications for one result complete within a short window.
+type ResultApprovalBatch struct {
+	ResultID  flow.Identifier
+	Approvals []flow.ResultApproval
+}
+
+// ByteSize returns the size of the batch, as the total size of its identifiers,
+// chunk indices and signatures, without any encoding overhead.
+func (b *ResultApprovalBatch) ByteSize() uint {
+	size := uint(len(b.ResultID))
+	for i := range b.Approvals {
+		size += ResultApprovalByteSize(&b.Approvals[i])
+	}
+	return size
+}
+
+// ResultApprovalByteSize returns the size of the approval within a ResultApprovalBatch.
+func ResultApprovalByteSize(approval *flow.ResultApproval) uint {
+	body := approval.Body
+	return uint(len(body.BlockID)+len(body.ExecutionResultID)+len(body.ApproverID)) +
+		8 + // chunk index
+		uint(len(body.AttestationSignature)+len(body.Spock)+len(approval.VerifierSignature))
+}
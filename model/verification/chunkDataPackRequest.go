@@ -1,6 +1,8 @@
 package verification
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -19,24 +21,40 @@ type ChunkDataPackRequestInfo struct {
 	Agrees    flow.IdentifierList // execution node ids that generated the result of chunk.
 	Disagrees flow.IdentifierList // execution node ids that generated a conflicting result with result of chunk.
 	Targets   flow.IdentityList   // list of all execution nodes identity at the block height of this chunk (including non-responders).
+	ByteSize  uint32              // total byte size of the transactions of the chunk, zero if unknown, used to prioritize small chunks.
+	// time the chunk data pack was first requested, zero if unknown, used to give up requesting it after a maximum duration.
+	RequestedAt time.Time
 }
 
-// SampleTargets returns identifier of execution nodes that can be asked for the chunk data pack, based on
-// the agreeing and disagreeing execution nodes of the chunk data pack request.
-func (c ChunkDataPackRequestInfo) SampleTargets(count int) flow.IdentifierList {
-	// if there are enough receipts produced the same result (agrees), we sample from them.
-	if len(c.Agrees) >= count {
-		return c.Targets.Filter(filter.HasNodeID(c.Agrees...)).Sample(uint(count)).NodeIDs()
-	}
+// Candidates returns identifier of all execution nodes that can be asked for the chunk data pack. The agreeing execution nodes
+// come first, followed by the execution nodes at the block height of the chunk that have not produced a conflicting result.
+func (c ChunkDataPackRequestInfo) Candidates() flow.IdentifierList {
+	others := c.Targets.Filter(filter.Not(filter.HasNodeID(c.Agrees.Union(c.Disagrees)...))).NodeIDs()
 
-	// since there is at least one agree, then usually, we just need `count - 1` extra nodes as backup.
-	// We pick these extra nodes randomly from the rest nodes who we haven't received its receipt.
-	// In the case where all other execution nodes has produced different results, then we will only
-	// fetch from the one produced the same result (the only agree)
-	need := uint(count - len(c.Agrees))
+	candidates := make(flow.IdentifierList, 0, len(c.Agrees)+len(others))
+	candidates = append(candidates, c.Agrees...)
+	return append(candidates, others...)
+}
 
-	nonResponders := c.Targets.Filter(filter.Not(filter.HasNodeID(c.Disagrees...))).Sample(need).NodeIDs()
-	return append(c.Agrees, nonResponders...)
+// RotateTargets returns identifier of execution nodes that are asked for the chunk data pack on the given attempt, i.e., the number
+// of times the chunk data pack has been requested so far. Each attempt moves on to the next `count` candidates, so that the request
+// is retried against alternate execution nodes when the previous ones do not respond. After running out of candidates, it starts
+// over with the first ones.
+func (c ChunkDataPackRequestInfo) RotateTargets(count int, attempt uint64) flow.IdentifierList {
+	candidates := c.Candidates()
+	if len(candidates) == 0 {
+		return flow.IdentifierList{}
+	}
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+
+	start := int(attempt * uint64(count) % uint64(len(candidates)))
+	targets := make(flow.IdentifierList, 0, count)
+	for i := 0; i < count; i++ {
+		targets = append(targets, candidates[(start+i)%len(candidates)])
+	}
+	return targets
 }
 
 type ChunkDataPackRequestInfoList []*ChunkDataPackRequestInfo
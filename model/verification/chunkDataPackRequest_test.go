@@ -52,3 +52,37 @@ func TestChunkDataPackRequestList_UniqueRequestInfo(t *testing.T) {
 	otherChunkIDReqInfo := reqInfoMap[otherChunkID]
 	require.Equal(t, *otherChunkIDReqInfo, otherReq.ChunkDataPackRequestInfo)
 }
+
+// TestChunkDataPackRequestInfo_RotateTargets tests that the targets of a chunk data pack request are rotated among the agreeing
+// execution nodes first, followed by the execution nodes without a conflicting result, on each attempt.
+func TestChunkDataPackRequestInfo_RotateTargets(t *testing.T) {
+	agrees := unittest.IdentifierListFixture(2)
+	disagrees := unittest.IdentifierListFixture(1)
+	nonResponders := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleExecution))
+	request := unittest.ChunkDataPackRequestFixture(unittest.WithAgrees(agrees), unittest.WithDisagrees(disagrees))
+	request.Targets = append(request.Targets, nonResponders...)
+
+	candidates := request.Candidates()
+	require.Equal(t, flow.IdentifierList{agrees[0], agrees[1], nonResponders[0].NodeID}, candidates)
+
+	t.Run("single target", func(t *testing.T) {
+		for attempt := uint64(0); attempt < 6; attempt++ {
+			require.Equal(t, flow.IdentifierList{candidates[attempt%3]}, request.RotateTargets(1, attempt))
+		}
+	})
+
+	t.Run("multiple targets", func(t *testing.T) {
+		require.Equal(t, flow.IdentifierList{agrees[0], agrees[1]}, request.RotateTargets(2, 0))
+		require.Equal(t, flow.IdentifierList{nonResponders[0].NodeID, agrees[0]}, request.RotateTargets(2, 1))
+		require.Equal(t, flow.IdentifierList{agrees[1], nonResponders[0].NodeID}, request.RotateTargets(2, 2))
+	})
+
+	t.Run("more targets than candidates", func(t *testing.T) {
+		require.Equal(t, candidates, request.RotateTargets(5, 0))
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		empty := verification.ChunkDataPackRequestInfo{}
+		require.Empty(t, empty.RotateTargets(2, 1))
+	})
+}
@@ -0,0 +1,51 @@
+package verification
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// VerificationRecord is the local record of the verification of a chunk, which the verifier engine keeps
+// alongside the result approval it emitted for the chunk, so that disputes on the approval can be investigated.
+type VerificationRecord struct {
+	ChunkID          flow.Identifier      // the verified chunk
+	ResultID         flow.Identifier      // execution result of the verified chunk
+	ChunkIndex       uint64               // index of the chunk within its execution result
+	BlockID          flow.Identifier      // block of the execution result
+	Height           uint64               // height of the block of the execution result, used for pruning
+	ApprovalID       flow.Identifier      // result approval emitted for the chunk
+	StartState       flow.StateCommitment // state commitment the chunk was executed on
+	EndState         flow.StateCommitment // state commitment checked at the end of the chunk
+	TransactionCount uint64               // number of transactions executed during verification
+	Duration         time.Duration        // duration of executing and checking the chunk
+	Spock            crypto.Signature     // SPoCK of the result approval
+}
+
+// NewVerificationRecord creates the record of verifying the given chunk, which took the given duration and
+// resulted in the given result approval.
+func NewVerificationRecord(vc *VerifiableChunkData, approval *flow.ResultApproval, duration time.Duration) *VerificationRecord {
+	// the system chunk only executes the system transaction
+	transactionCount := uint64(1)
+	if !vc.IsSystemChunk {
+		transactionCount = 0
+		if vc.ChunkDataPack.Collection != nil {
+			transactionCount = uint64(len(vc.ChunkDataPack.Collection.Transactions))
+		}
+	}
+
+	return &VerificationRecord{
+		ChunkID:          vc.Chunk.ID(),
+		ResultID:         approval.Body.ExecutionResultID,
+		ChunkIndex:       approval.Body.ChunkIndex,
+		BlockID:          approval.Body.BlockID,
+		Height:           vc.Header.Height,
+		ApprovalID:       approval.ID(),
+		StartState:       vc.Chunk.StartState,
+		EndState:         vc.EndState,
+		TransactionCount: transactionCount,
+		Duration:         duration,
+		Spock:            approval.Body.Spock,
+	}
+}
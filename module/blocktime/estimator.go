@@ -0,0 +1,129 @@
+package blocktime
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+// DefaultBlockTime is the block time assumed before the time between two
+// finalized blocks has been observed.
+const DefaultBlockTime = time.Second
+
+// DefaultWindow is the default number of recent blocks, which dominate the
+// exponential moving average of the block time.
+const DefaultWindow = 100
+
+// Opt is a functional option to configure the estimator.
+type Opt func(*Estimator)
+
+// WithAlpha sets the weight of the most recent block time in the exponential
+// moving average. It must be in (0, 1].
+func WithAlpha(alpha float64) Opt {
+	return func(e *Estimator) {
+		e.alpha = alpha
+	}
+}
+
+// WithWindow sets the weight of the most recent block time in the exponential
+// moving average, such that it roughly averages over the given number of
+// recent blocks.
+func WithWindow(window uint) Opt {
+	return func(e *Estimator) {
+		e.alpha = 2 / (float64(window) + 1)
+	}
+}
+
+// WithInitialBlockTime sets the block time which is assumed before the time
+// between two finalized blocks has been observed.
+func WithInitialBlockTime(blockTime time.Duration) Opt {
+	return func(e *Estimator) {
+		e.initial = blockTime
+	}
+}
+
+// Estimator is a protocol events consumer, which tracks the exponential moving
+// average of the time between finalized blocks, based on their timestamps. It
+// converts a number of blocks into an approximate wall-clock duration.
+type Estimator struct {
+	events.Noop
+	mu      sync.RWMutex
+	alpha   float64       // weight of the most recent block time
+	initial time.Duration // block time assumed before any block time was observed
+	last    *flow.Header  // latest finalized block
+	average float64       // average block time in nanoseconds
+	samples uint64        // number of observed block times
+}
+
+// NewEstimator creates a new block time estimator, which averages over the
+// block times of roughly the last DefaultWindow blocks by default.
+func NewEstimator(opts ...Opt) *Estimator {
+	e := &Estimator{
+		alpha:   2 / (float64(DefaultWindow) + 1),
+		initial: DefaultBlockTime,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// BlockFinalized handles block finalized protocol events, updating the average
+// block time with the time since the previously finalized block.
+func (e *Estimator) BlockFinalized(block *flow.Header) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	last := e.last
+	if last != nil && block.Height <= last.Height {
+		return
+	}
+	e.last = block
+	if last == nil {
+		return
+	}
+
+	// timestamps are not guaranteed to increase, as they are proposed by
+	// the leaders; such samples are discarded
+	elapsed := block.Timestamp.Sub(last.Timestamp)
+	if elapsed < 0 {
+		return
+	}
+	// the block time is distributed over all blocks since the last observed block
+	blockTime := float64(elapsed) / float64(block.Height-last.Height)
+
+	if e.samples == 0 {
+		e.average = blockTime
+	} else {
+		e.average = e.alpha*blockTime + (1-e.alpha)*e.average
+	}
+	e.samples++
+}
+
+// CurrentBlockTime returns the average time between finalized blocks, or the
+// initial block time if no block time was observed yet.
+func (e *Estimator) CurrentBlockTime() time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.samples == 0 {
+		return e.initial
+	}
+	return time.Duration(e.average)
+}
+
+// EstimateDuration returns the approximate duration until the given number of
+// blocks are finalized, based on the current block time.
+func (e *Estimator) EstimateDuration(heights uint64) time.Duration {
+	blockTime := e.CurrentBlockTime()
+	if blockTime <= 0 {
+		return 0
+	}
+	if heights > uint64(math.MaxInt64/blockTime) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(heights) * blockTime
+}
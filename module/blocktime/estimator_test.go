@@ -0,0 +1,106 @@
+package blocktime
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// finalize feeds the estimator with the given number of blocks, which follow
+// the given block at the given cadence, and returns the last block.
+func finalize(e *Estimator, last *flow.Header, blocks int, cadence time.Duration) *flow.Header {
+	for i := 0; i < blocks; i++ {
+		block := &flow.Header{
+			Height:    last.Height + 1,
+			Timestamp: last.Timestamp.Add(cadence),
+		}
+		e.BlockFinalized(block)
+		last = block
+	}
+	return last
+}
+
+// TestEstimator_ColdStart tests that the initial block time is used until the
+// time between two blocks was observed.
+func TestEstimator_ColdStart(t *testing.T) {
+	e := NewEstimator()
+	assert.Equal(t, DefaultBlockTime, e.CurrentBlockTime())
+	assert.Equal(t, 600*time.Second, e.EstimateDuration(600))
+	assert.Equal(t, time.Duration(0), e.EstimateDuration(0))
+
+	e = NewEstimator(WithInitialBlockTime(2 * time.Second))
+	assert.Equal(t, 20*time.Second, e.EstimateDuration(10))
+
+	// a single block doesn't provide a block time
+	e.BlockFinalized(&flow.Header{Height: 10, Timestamp: time.Now()})
+	assert.Equal(t, 2*time.Second, e.CurrentBlockTime())
+}
+
+// TestEstimator_Converges tests that the average converges to the block time
+// after the cadence of blocks changes.
+func TestEstimator_Converges(t *testing.T) {
+	e := NewEstimator(WithWindow(10))
+	last := &flow.Header{Height: 100, Timestamp: time.Unix(1600000000, 0)}
+	e.BlockFinalized(last)
+
+	// the first observed block time is taken as is
+	last = finalize(e, last, 1, 500*time.Millisecond)
+	assert.Equal(t, 500*time.Millisecond, e.CurrentBlockTime())
+
+	last = finalize(e, last, 100, 500*time.Millisecond)
+	assert.Equal(t, 500*time.Millisecond, e.CurrentBlockTime())
+
+	// after slowing down, the average moves towards, but stays below the new block time
+	last = finalize(e, last, 5, 2*time.Second)
+	assert.Greater(t, int64(e.CurrentBlockTime()), int64(time.Second))
+	assert.Less(t, int64(e.CurrentBlockTime()), int64(2*time.Second))
+
+	_ = finalize(e, last, 100, 2*time.Second)
+	assert.InDelta(t, float64(2*time.Second), float64(e.CurrentBlockTime()), float64(time.Millisecond))
+	assert.InDelta(t, float64(200*time.Second), float64(e.EstimateDuration(100)), float64(100*time.Millisecond))
+}
+
+// TestEstimator_Alpha tests the exponential moving average with an explicit weight.
+func TestEstimator_Alpha(t *testing.T) {
+	e := NewEstimator(WithAlpha(0.5))
+	last := &flow.Header{Height: 1, Timestamp: time.Unix(1600000000, 0)}
+	e.BlockFinalized(last)
+	last = finalize(e, last, 1, time.Second)
+	last = finalize(e, last, 1, 3*time.Second)
+	assert.Equal(t, 2*time.Second, e.CurrentBlockTime())
+	_ = finalize(e, last, 1, 4*time.Second)
+	assert.Equal(t, 3*time.Second, e.CurrentBlockTime())
+}
+
+// TestEstimator_IrregularBlocks tests that skipped heights distribute the time over the
+// skipped blocks, and that stale blocks and decreasing timestamps are ignored.
+func TestEstimator_IrregularBlocks(t *testing.T) {
+	e := NewEstimator()
+	start := time.Unix(1600000000, 0)
+	e.BlockFinalized(&flow.Header{Height: 10, Timestamp: start})
+
+	// 10 blocks in 5 seconds
+	e.BlockFinalized(&flow.Header{Height: 20, Timestamp: start.Add(5 * time.Second)})
+	require.Equal(t, 500*time.Millisecond, e.CurrentBlockTime())
+
+	// blocks at or below the latest finalized height are ignored
+	e.BlockFinalized(&flow.Header{Height: 20, Timestamp: start.Add(time.Hour)})
+	e.BlockFinalized(&flow.Header{Height: 15, Timestamp: start.Add(time.Hour)})
+	require.Equal(t, 500*time.Millisecond, e.CurrentBlockTime())
+
+	// a decreasing timestamp is not used as a sample
+	e.BlockFinalized(&flow.Header{Height: 21, Timestamp: start})
+	require.Equal(t, 500*time.Millisecond, e.CurrentBlockTime())
+}
+
+// TestEstimator_EstimateDurationOverflow tests that estimates for very large numbers of
+// blocks saturate instead of overflowing.
+func TestEstimator_EstimateDurationOverflow(t *testing.T) {
+	e := NewEstimator()
+	assert.Equal(t, time.Duration(math.MaxInt64), e.EstimateDuration(math.MaxUint64))
+}
@@ -203,7 +203,12 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 		var transactions []*flow.TransactionBody
 		var totalByteSize uint64
 		var totalGas uint64
-		for _, tx := range b.transactions.All() {
+		ranked := b.transactions.Ranked()
+		for {
+			tx, ok := ranked.Next()
+			if !ok {
+				break
+			}
 
 			// if we have reached maximum number of transactions, stop
 			if uint(len(transactions)) >= b.config.MaxCollectionSize {
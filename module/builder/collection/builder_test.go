@@ -277,6 +277,47 @@ func (suite *BuilderSuite) TestBuildOn_WithForks() {
 	assert.False(t, collectionContains(builtCollection, tx1.ID()))
 }
 
+// a transaction included in a pending collection must not be included again on
+// the same fork, but may be included on a different fork
+func (suite *BuilderSuite) TestBuildOn_PendingAncestorCollections() {
+	txA := suite.pool.All()[0]
+
+	// fork 1: genesis <- block1 (A) <- block2
+	block1 := unittest.ClusterBlockWithParent(suite.genesis)
+	block1.SetPayload(suite.Payload(txA))
+	suite.InsertBlock(block1)
+	block2 := unittest.ClusterBlockWithParent(&block1)
+	block2.SetPayload(suite.Payload())
+	suite.InsertBlock(block2)
+
+	// fork 2: genesis <- block3
+	block3 := unittest.ClusterBlockWithParent(suite.genesis)
+	block3.SetPayload(suite.Payload())
+	suite.InsertBlock(block3)
+
+	build := func(parentID flow.Identifier) flow.Collection {
+		header, err := suite.builder.BuildOn(parentID, noopSetter)
+		suite.Require().Nil(err)
+		var built model.Block
+		err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+		suite.Require().Nil(err)
+		return built.Payload.Collection
+	}
+
+	// a proposal on fork 1 should skip A
+	collection := build(block2.ID())
+	suite.Assert().Len(collection.Transactions, 2)
+	suite.Assert().False(collectionContains(collection, txA.ID()))
+
+	// a proposal on fork 2 may include A
+	collection = build(block3.ID())
+	suite.Assert().Len(collection.Transactions, 3)
+	suite.Assert().True(collectionContains(collection, txA.ID()))
+
+	// A should remain in the mempool, as it is not finalized on any fork
+	suite.Assert().True(suite.pool.Has(txA.ID()))
+}
+
 func (suite *BuilderSuite) TestBuildOn_ConflictingFinalizedBlock() {
 	t := suite.T()
 
@@ -561,6 +602,44 @@ func (suite *BuilderSuite) TestBuildOn_ExpiredTransaction() {
 	suite.Assert().False(suite.pool.Has(tx1.ID()))
 }
 
+func (suite *BuilderSuite) TestBuildOn_TransactionPriority() {
+
+	// buildWith builds a collection of at most 3 transactions from a pool with the given priority,
+	// holding transactions with the given gas limits in arrival order
+	buildWith := func(priority stdmap.TransactionPriorityFunc, gasLimits ...uint64) ([]*flow.TransactionBody, flow.Collection) {
+		suite.pool = stdmap.NewTransactionsWithPriority(1000, priority)
+		suite.builder = builder.NewBuilder(suite.db, trace.NewNoopTracer(), suite.headers, suite.headers, suite.payloads, suite.pool, builder.WithMaxCollectionSize(3))
+
+		txs := make([]*flow.TransactionBody, 0, len(gasLimits))
+		for i, gasLimit := range gasLimits {
+			tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+				tx.ReferenceBlockID = suite.ProtoStateRoot().ID()
+				tx.ProposalKey.SequenceNumber = uint64(i)
+				tx.GasLimit = gasLimit
+			})
+			suite.pool.Add(&tx)
+			txs = append(txs, &tx)
+		}
+
+		header, err := suite.builder.BuildOn(suite.genesis.ID(), noopSetter)
+		suite.Require().Nil(err)
+		var built model.Block
+		err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+		suite.Require().Nil(err)
+		return txs, built.Payload.Collection
+	}
+
+	suite.Run("arrival", func() {
+		txs, collection := buildWith(stdmap.PriorityArrival, 10, 50, 30, 40, 20)
+		suite.Assert().Equal(flow.Collection{Transactions: txs[:3]}, collection)
+	})
+
+	suite.Run("gas limit", func() {
+		txs, collection := buildWith(stdmap.PriorityGasLimit, 10, 50, 30, 40, 20)
+		suite.Assert().Equal(flow.Collection{Transactions: []*flow.TransactionBody{txs[1], txs[3], txs[2]}}, collection)
+	})
+}
+
 func (suite *BuilderSuite) TestBuildOn_EmptyMempool() {
 
 	// start with an empty mempool
@@ -3,18 +3,22 @@
 package consensus
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter/id"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/module/validation"
 	"github.com/onflow/flow-go/state/fork"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
@@ -26,6 +30,7 @@ import (
 // hash, it also memorizes which entities were included into the payload.
 type Builder struct {
 	metrics    module.MempoolMetrics
+	conMetrics module.ConsensusMetrics
 	tracer     module.Tracer
 	db         *badger.DB
 	state      protocol.MutableState
@@ -38,12 +43,14 @@ type Builder struct {
 	guarPool   mempool.Guarantees
 	sealPool   mempool.IncorporatedResultSeals
 	recPool    mempool.ExecutionTree
+	sealChain  *validation.SealChainValidator
 	cfg        Config
 }
 
 // NewBuilder creates a new block builder.
 func NewBuilder(
 	metrics module.MempoolMetrics,
+	conMetrics module.ConsensusMetrics,
 	db *badger.DB,
 	state protocol.MutableState,
 	headers storage.Headers,
@@ -70,6 +77,7 @@ func NewBuilder(
 		maxSealCount:      100,
 		maxGuaranteeCount: 100,
 		maxReceiptCount:   200,
+		maxPayloadSize:    DefaultMaxPayloadSize,
 		expiry:            flow.DefaultTransactionExpiry,
 	}
 
@@ -80,6 +88,7 @@ func NewBuilder(
 
 	b := &Builder{
 		metrics:    metrics,
+		conMetrics: conMetrics,
 		db:         db,
 		tracer:     tracer,
 		state:      state,
@@ -92,6 +101,7 @@ func NewBuilder(
 		guarPool:   guarPool,
 		sealPool:   sealPool,
 		recPool:    recPool,
+		sealChain:  validation.NewSealChainValidator(),
 		cfg:        cfg,
 	}
 
@@ -113,24 +123,29 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 
 	startTime := time.Now()
 
+	// the sections of the payload share its size budget in the order of their priority: seals
+	// drive sealing progress and are small, guarantees are included before they expire, and
+	// receipts that don't fit can still be included in a later block
+	budget := newPayloadBudget(b.cfg.maxPayloadSize)
+
+	// get the seals to insert in the payload
+	insertableSeals, err := b.getInsertableSeals(parentID, budget)
+	if err != nil {
+		return nil, fmt.Errorf("could not insert seals: %w", err)
+	}
+
 	// get the collection guarantees to insert in the payload
-	insertableGuarantees, err := b.getInsertableGuarantees(parentID)
+	insertableGuarantees, err := b.getInsertableGuarantees(parentID, budget)
 	if err != nil {
 		return nil, fmt.Errorf("could not insert guarantees: %w", err)
 	}
 
 	// get the receipts to insert in the payload
-	insertableReceipts, err := b.getInsertableReceipts(parentID)
+	insertableReceipts, err := b.getInsertableReceipts(parentID, budget)
 	if err != nil {
 		return nil, fmt.Errorf("could not insert receipts: %w", err)
 	}
 
-	// get the seals to insert in the payload
-	insertableSeals, err := b.getInsertableSeals(parentID)
-	if err != nil {
-		return nil, fmt.Errorf("could not insert seals: %w", err)
-	}
-
 	// assemble the block proposal
 	proposal, err := b.createProposal(parentID,
 		insertableGuarantees,
@@ -149,6 +164,19 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 		return nil, fmt.Errorf("could not extend state with built proposal: %w", err)
 	}
 
+	// the inclusion of a seal concludes the trace of the sealed block
+	proposalID := proposal.ID()
+	for _, seal := range proposal.Payload.Seals {
+		sealSpan, _, isSampled := b.tracer.StartBlockSpan(context.Background(), seal.BlockID, trace.CONBuilderIncludeSeal)
+		if isSampled {
+			sealSpan.LogFields(
+				log.String("result_id", seal.ResultID.String()),
+				log.String("including_block_id", proposalID.String()),
+			)
+		}
+		sealSpan.Finish()
+	}
+
 	return proposal.Header, nil
 }
 
@@ -252,7 +280,7 @@ func (b *Builder) repopulateExecutionTree() error {
 // 3) If the referenced block has an expired height, skip.
 //
 // 4) Otherwise, this guarantee can be included in the payload.
-func (b *Builder) getInsertableGuarantees(parentID flow.Identifier) ([]*flow.CollectionGuarantee, error) {
+func (b *Builder) getInsertableGuarantees(parentID flow.Identifier, budget *payloadBudget) ([]*flow.CollectionGuarantee, error) {
 
 	// we look back only as far as the expiry limit for the current height we
 	// are building for; any guarantee with a reference block before that can
@@ -278,8 +306,8 @@ func (b *Builder) getInsertableGuarantees(parentID flow.Identifier) ([]*flow.Col
 		limit = rootHeight
 	}
 
-	// blockLookup keeps track of the blocks from limit to parent
-	blockLookup := make(map[flow.Identifier]struct{})
+	// blockLookup keeps track of the heights of the blocks from limit to parent
+	blockLookup := make(map[flow.Identifier]uint64)
 
 	// receiptLookup keeps track of the receipts contained in blocks between
 	// limit and parent
@@ -289,7 +317,7 @@ func (b *Builder) getInsertableGuarantees(parentID flow.Identifier) ([]*flow.Col
 	// and keep track of blocks and collections visited on the way
 	forkScanner := func(header *flow.Header) error {
 		ancestorID := header.ID()
-		blockLookup[ancestorID] = struct{}{}
+		blockLookup[ancestorID] = header.Height
 
 		index, err := b.index.ByBlockID(ancestorID)
 		if err != nil {
@@ -310,13 +338,6 @@ func (b *Builder) getInsertableGuarantees(parentID flow.Identifier) ([]*flow.Col
 	// go through mempool and collect valid collections
 	var guarantees []*flow.CollectionGuarantee
 	for _, guarantee := range b.guarPool.All() {
-		// add at most <maxGuaranteeCount> number of collection guarantees in a new block proposal
-		// in order to prevent the block payload from being too big or computationally heavy for the
-		// execution nodes
-		if uint(len(guarantees)) >= b.cfg.maxGuaranteeCount {
-			break
-		}
-
 		collID := guarantee.ID()
 
 		// skip collections that are already included in a block on the fork
@@ -334,7 +355,27 @@ func (b *Builder) getInsertableGuarantees(parentID flow.Identifier) ([]*flow.Col
 		guarantees = append(guarantees, guarantee)
 	}
 
-	return guarantees, nil
+	// add at most <maxGuaranteeCount> number of collection guarantees in a new block proposal
+	// in order to prevent the block payload from being too big or computationally heavy for the
+	// execution nodes; the guarantees with the oldest reference blocks expire first, so they are
+	// included first
+	sort.Slice(guarantees, func(i, j int) bool {
+		heightI, heightJ := blockLookup[guarantees[i].ReferenceBlockID], blockLookup[guarantees[j].ReferenceBlockID]
+		if heightI != heightJ {
+			return heightI < heightJ
+		}
+		idI, idJ := guarantees[i].ID(), guarantees[j].ID()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+	included, err := budget.selectPrefix(len(guarantees), b.cfg.maxGuaranteeCount, func(i int) (uint64, error) {
+		return encodedSize(guarantees[i])
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not limit guarantees: %w", err)
+	}
+	b.conMetrics.BuilderPayloadSection(sectionGuarantees, uint(included), uint(len(guarantees)-included))
+
+	return guarantees[:included], nil
 }
 
 // getInsertableSeals returns the list of Seals from the mempool that should be
@@ -351,8 +392,8 @@ func (b *Builder) getInsertableGuarantees(parentID flow.Identifier) ([]*flow.Col
 //  (2) The result must be for an _unsealed_ block.
 //  (3) The result's parent must have been previously sealed (either by a seal in an ancestor
 //      block or by a seal included earlier in the block that we are constructing).
-// To limit block size, we cap the number of seals to maxSealCount.
-func (b *Builder) getInsertableSeals(parentID flow.Identifier) ([]*flow.Seal, error) {
+// To limit block size, we cap the number of seals to maxSealCount, and to the payload size budget.
+func (b *Builder) getInsertableSeals(parentID flow.Identifier, budget *payloadBudget) ([]*flow.Seal, error) {
 	// get the latest seal in the fork, which we are extending and
 	// the corresponding block, whose result is sealed
 	// Note: the last seal might not be included in a finalized block yet
@@ -382,6 +423,7 @@ func (b *Builder) getInsertableSeals(parentID flow.Identifier) ([]*flow.Seal, er
 	//  * A result can only be incorporated in a child of the block that it computes.
 	//    Therefore, we only have to inspect the results incorporated in unsealed blocks.
 	sealsSuperset := make(map[uint64][]*flow.IncorporatedResultSeal) // map: executedBlock.Height -> candidate Seals
+	sealedResults := make(map[flow.Identifier]*flow.ExecutionResult) // map: result ID -> result of candidate Seals
 	sealCollector := func(header *flow.Header) error {
 		blockID := header.ID()
 		if blockID == parentID {
@@ -435,6 +477,7 @@ func (b *Builder) getInsertableSeals(parentID flow.Identifier) ([]*flow.Seal, er
 			// The following is a subtle but important protocol edge case: There can be multiple
 			// candidate seals for the same block. We have to include all to guarantee sealing liveness!
 			sealsSuperset[executedBlock.Height] = append(sealsSuperset[executedBlock.Height], irSeal)
+			sealedResults[irSeal.Seal.ResultID] = irSeal.IncorporatedResult.Result
 		}
 
 		return nil
@@ -450,13 +493,9 @@ func (b *Builder) getInsertableSeals(parentID flow.Identifier) ([]*flow.Seal, er
 	// have a seal for the child block (at latestSealedBlock.Height +1), which connects to the
 	// sealed result. If we find such a seal, we can now consider the child block sealed.
 	// We continue until we stop finding a seal for the child.
+	sealedTip := lastSeal
 	seals := make([]*flow.Seal, 0, len(sealsSuperset))
 	for {
-		// cap the number of seals
-		if uint(len(seals)) >= b.cfg.maxSealCount {
-			break
-		}
-
 		// enforce condition (3):
 		candidateSeal, ok := connectingSeal(sealsSuperset[latestSealedHeight+1], lastSeal)
 		if !ok {
@@ -466,18 +505,57 @@ func (b *Builder) getInsertableSeals(parentID flow.Identifier) ([]*flow.Seal, er
 		lastSeal = candidateSeal
 		latestSealedHeight += 1
 	}
-	return seals, nil
+
+	// The selected seals form a chain by construction. We still apply the checks
+	// of the payload validator, which rejects the block unless all seals are valid,
+	// and only include the valid prefix.
+	resultLookup := func(resultID flow.Identifier) (*flow.ExecutionResult, error) {
+		result, ok := sealedResults[resultID]
+		if !ok {
+			return nil, storage.ErrNotFound
+		}
+		return result, nil
+	}
+	seals, err = b.sealChain.Validate(seals, sealedTip, resultLookup)
+	if err != nil && !validation.IsSealChainError(err) {
+		return nil, fmt.Errorf("could not validate chain of seals: %w", err)
+	}
+
+	// cap the number and size of seals; any prefix of the chain of seals is a chain of seals
+	included, err := budget.selectPrefix(len(seals), b.cfg.maxSealCount, func(i int) (uint64, error) {
+		return encodedSize(seals[i])
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not limit seals: %w", err)
+	}
+	b.conMetrics.BuilderPayloadSection(sectionSeals, uint(included), uint(len(seals)-included))
+
+	return seals[:included], nil
 }
 
 // connectingSeal looks through `sealsForNextBlock`. It checks whether the
 // sealed result directly descends from the lastSealed result.
+// If multiple seals connect to the lastSealed result, the one with the most approving
+// stake is preferred, while ties are resolved in favour of the first candidate.
+// The approving stake only breaks ties among connecting seals, it never admits a seal
+// that does not connect to the lastSealed result.
+// Note: competing connecting seals for the same height are seals for different results
+// of the same block, i.e. evidence of an execution fork. Usually, the seals mempool
+// stops providing seals once it detects such a fork (see consensus.ExecForkSuppressor).
 func connectingSeal(sealsForNextBlock []*flow.IncorporatedResultSeal, lastSealed *flow.Seal) (*flow.Seal, bool) {
+	var selected *flow.IncorporatedResultSeal
 	for _, candidateSeal := range sealsForNextBlock {
-		if candidateSeal.IncorporatedResult.Result.PreviousResultID == lastSealed.ResultID {
-			return candidateSeal.Seal, true
+		if candidateSeal.IncorporatedResult.Result.PreviousResultID != lastSealed.ResultID {
+			continue
+		}
+		if selected == nil || candidateSeal.ApprovingStake > selected.ApprovingStake {
+			selected = candidateSeal
 		}
 	}
-	return nil, false
+	if selected == nil {
+		return nil, false
+	}
+	return selected.Seal, true
 }
 
 type InsertableReceipts struct {
@@ -499,7 +577,7 @@ type InsertableReceipts struct {
 // 3) Otherwise, this receipt can be included in the payload.
 //
 // Receipts have to be ordered by block height.
-func (b *Builder) getInsertableReceipts(parentID flow.Identifier) (*InsertableReceipts, error) {
+func (b *Builder) getInsertableReceipts(parentID flow.Identifier, budget *payloadBudget) (*InsertableReceipts, error) {
 
 	// Get the latest sealed block on this fork, ie the highest block for which
 	// there is a seal in this fork. This block is not necessarily finalized.
@@ -561,40 +639,56 @@ func (b *Builder) getInsertableReceipts(parentID flow.Identifier) (*InsertableRe
 		return nil, fmt.Errorf("failed to retrieve reachable receipts from memool: %w", err)
 	}
 
-	insertables := toInsertables(receipts, includedResults, b.cfg.maxReceiptCount)
+	insertables, err := toInsertables(receipts, includedResults, b.cfg.maxReceiptCount, budget)
+	if err != nil {
+		return nil, fmt.Errorf("could not limit receipts: %w", err)
+	}
+	b.conMetrics.BuilderPayloadSection(sectionReceipts, uint(len(insertables.receipts)), uint(len(receipts)-len(insertables.receipts)))
+
 	return insertables, nil
 }
 
 // toInsertables separates the provided receipts into ExecutionReceiptMeta and
 // ExecutionResult. Results that are in includedResults are skipped.
-// We also limit the number of receipts to maxReceiptCount.
-func toInsertables(receipts []*flow.ExecutionReceipt, includedResults map[flow.Identifier]struct{}, maxReceiptCount uint) *InsertableReceipts {
+// We also limit the number of receipts to maxReceiptCount, and their size, including
+// the size of the results they add to the payload, to the payload size budget.
+func toInsertables(receipts []*flow.ExecutionReceipt, includedResults map[flow.Identifier]struct{}, maxReceiptCount uint, budget *payloadBudget) (*InsertableReceipts, error) {
 	results := make([]*flow.ExecutionResult, 0)
+	filteredReceipts := make([]*flow.ExecutionReceiptMeta, 0)
 
-	count := uint(len(receipts))
-	// don't collect more than maxReceiptCount receipts
-	if count > maxReceiptCount {
-		count = maxReceiptCount
-	}
-
-	filteredReceipts := make([]*flow.ExecutionReceiptMeta, 0, count)
+	// receipts are ordered by height, so we stop at the first receipt exceeding a limit
+	for _, receipt := range receipts {
+		// don't collect more than maxReceiptCount receipts
+		if uint(len(filteredReceipts)) >= maxReceiptCount {
+			break
+		}
 
-	for i := uint(0); i < count; i++ {
-		receipt := receipts[i]
 		meta := receipt.Meta()
 		resultID := meta.ResultID
-		if _, inserted := includedResults[resultID]; !inserted {
+		_, inserted := includedResults[resultID]
+		items := []interface{}{meta}
+		if !inserted {
+			items = append(items, &receipt.ExecutionResult)
+		}
+		size, err := encodedSize(items...)
+		if err != nil {
+			return nil, err
+		}
+		if !budget.take(size) {
+			break
+		}
+
+		if !inserted {
 			results = append(results, &receipt.ExecutionResult)
 			includedResults[resultID] = struct{}{}
 		}
-
 		filteredReceipts = append(filteredReceipts, meta)
 	}
 
 	return &InsertableReceipts{
 		receipts: filteredReceipts,
 		results:  results,
-	}
+	}, nil
 }
 
 // createProposal assembles a block with the provided header and payload
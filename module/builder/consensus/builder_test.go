@@ -16,6 +16,7 @@ import (
 	mempoolImpl "github.com/onflow/flow-go/module/mempool/consensus"
 	mempool "github.com/onflow/flow-go/module/mempool/mock"
 	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
 	realproto "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
@@ -408,6 +409,7 @@ func (bs *BuilderSuite) SetupTest() {
 
 	// initialize the builder
 	bs.build, err = NewBuilder(
+		noopMetrics,
 		noopMetrics,
 		bs.db,
 		bs.state,
@@ -508,6 +510,29 @@ func (bs *BuilderSuite) TestPayloadGuaranteeReferenceExpired() {
 	bs.Assert().ElementsMatch(valid, bs.assembled.Guarantees, "should have valid from mempool in payload")
 }
 
+// TestPayloadGuaranteeLimit_OldestFirst verifies that the builder includes the guarantees with
+// the oldest reference blocks first, when there are more than maxGuaranteeCount valid guarantees.
+func (bs *BuilderSuite) TestPayloadGuaranteeLimit_OldestFirst() {
+
+	// create two guarantees referencing each finalized block, from the oldest to the newest
+	var guarantees []*flow.CollectionGuarantee
+	for _, blockID := range bs.finalizedBlockIDs {
+		guarantees = append(guarantees, unittest.CollectionGuaranteesFixture(2, unittest.WithCollRef(blockID))...)
+	}
+
+	// add the guarantees to the pool in random order
+	bs.pendingGuarantees = make([]*flow.CollectionGuarantee, len(guarantees))
+	for i, j := range rand.Perm(len(guarantees)) {
+		bs.pendingGuarantees[i] = guarantees[j]
+	}
+
+	limit := uint(4)
+	bs.build.cfg.maxGuaranteeCount = limit
+	_, err := bs.build.BuildOn(bs.parentID, bs.setter)
+	bs.Require().NoError(err)
+	bs.Assert().ElementsMatch(guarantees[:limit], bs.assembled.Guarantees, "should have included the oldest guarantees")
+}
+
 // TestPayloadSeals_AllValid checks that builder seals as many blocks as possible (happy path):
 //    [first] <- [F0] <- [F1] <- [F2] <- [F3] <- [final] <- [A0] <- [A1] <- [A2] <- [A3] <- [parent]
 // Where block
@@ -547,6 +572,58 @@ func (bs *BuilderSuite) TestPayloadSeals_Limit() {
 	bs.Assert().Equal(bs.chain[:limit], bs.assembled.Seals, "should have excluded seals above maxSealCount")
 }
 
+// TestPayloadSize_SealsFirst verifies that seals take priority over guarantees, when the payload
+// size budget doesn't fit all of them.
+func (bs *BuilderSuite) TestPayloadSize_SealsFirst() {
+	bs.pendingSeals = bs.irsMap
+	bs.pendingGuarantees = unittest.CollectionGuaranteesFixture(4, unittest.WithCollRef(bs.finalID))
+
+	// the budget fits the first two seals only
+	size, err := encodedSize(bs.chain[0], bs.chain[1])
+	bs.Require().NoError(err)
+	bs.build.cfg.maxPayloadSize = size
+
+	_, err = bs.build.BuildOn(bs.parentID, bs.setter)
+	bs.Require().NoError(err)
+	bs.Assert().Equal(bs.chain[:2], bs.assembled.Seals, "should have excluded seals exceeding the payload size")
+	bs.Assert().Empty(bs.assembled.Guarantees, "should have excluded guarantees exceeding the payload size")
+}
+
+// TestPayloadSize_Budget verifies that the builder fills the payload exactly up to the item that
+// doesn't fit into the payload size budget anymore, and reports the included and skipped items
+// of each section.
+func (bs *BuilderSuite) TestPayloadSize_Budget() {
+	bs.pendingSeals = bs.irsMap
+
+	// one guarantee referencing each finalized block, from the oldest to the newest
+	var guarantees []*flow.CollectionGuarantee
+	for _, blockID := range bs.finalizedBlockIDs {
+		guarantees = append(guarantees, unittest.CollectionGuaranteeFixture(unittest.WithCollRef(blockID)))
+	}
+	bs.pendingGuarantees = guarantees
+
+	// the budget fits all seals and the first two guarantees, but misses one byte for the third
+	sealsSize, err := encodedSize(seals(bs.chain)...)
+	bs.Require().NoError(err)
+	guaranteesSize, err := encodedSize(guarantees[0], guarantees[1])
+	bs.Require().NoError(err)
+	nextSize, err := encodedSize(guarantees[2])
+	bs.Require().NoError(err)
+	bs.build.cfg.maxPayloadSize = sealsSize + guaranteesSize + nextSize - 1
+
+	conMetrics := &mockmodule.ConsensusMetrics{}
+	conMetrics.On("BuilderPayloadSection", sectionSeals, uint(len(bs.chain)), uint(0)).Once()
+	conMetrics.On("BuilderPayloadSection", sectionGuarantees, uint(2), uint(len(guarantees)-2)).Once()
+	conMetrics.On("BuilderPayloadSection", sectionReceipts, uint(0), uint(0)).Once()
+	bs.build.conMetrics = conMetrics
+
+	_, err = bs.build.BuildOn(bs.parentID, bs.setter)
+	bs.Require().NoError(err)
+	bs.Assert().ElementsMatch(bs.chain, bs.assembled.Seals, "should have included all seals")
+	bs.Assert().Equal(guarantees[:2], bs.assembled.Guarantees, "should have excluded guarantees exceeding the payload size")
+	conMetrics.AssertExpectations(bs.T())
+}
+
 // TestPayloadSeals_OnlyFork checks that the builder only includes seals corresponding
 // to blocks on the current fork (and _not_ seals for sealable blocks on other forks)
 func (bs *BuilderSuite) TestPayloadSeals_OnlyFork() {
@@ -803,6 +880,92 @@ func (bs *BuilderSuite) TestValidatePayloadSeals_ExecutionForks() {
 	})
 }
 
+// TestPayloadSeals_ApprovingStake verifies that the builder prefers the seal with the most approving stake,
+// if there are multiple seals connecting to the last sealed result for the same height.
+// We test with the following main chain
+//   [F] <- [A{ER[F]}] <- [B{ER[A]_1, ER[A]_2}] <- [C{ER[B]_1, ER[B]_2}] <- [D{Seal[F]}]
+// where ER[A]_1 and ER[A]_2 both descend from the sealed result ER[F], i.e. the two execution
+// forks branch off after the sealed result.
+func (bs *BuilderSuite) TestPayloadSeals_ApprovingStake() {
+	bs.build.cfg.expiry = 4 // reduce expiry so collection dedup algorithm doesn't walk past  [lastSeal]
+
+	blockF := bs.blocks[bs.finalID]
+	blocks := []*flow.Block{blockF}
+	blocks = append(blocks, unittest.ChainFixtureFrom(4, blockF.Header)...)              // elements  [F, A, B, C, D]
+	receiptChain1 := unittest.ReceiptChainFor(blocks, unittest.ExecutionResultFixture()) // elements  [Result[F], Result[A]_1, Result[B]_1, ...]
+	receiptChain2 := unittest.ReceiptChainFor(blocks, &receiptChain1[0].ExecutionResult) // elements  [Result[F], Result[A]_2, Result[B]_2, ...]
+
+	blocks[1].SetPayload(flow.Payload{ // set payload for block A
+		Results:  []*flow.ExecutionResult{&receiptChain1[0].ExecutionResult},
+		Receipts: []*flow.ExecutionReceiptMeta{receiptChain1[0].Meta()},
+	})
+	for i := 2; i <= 3; i++ { // set payload for blocks B, C
+		blocks[i].SetPayload(flow.Payload{
+			Results:  []*flow.ExecutionResult{&receiptChain1[i-1].ExecutionResult, &receiptChain2[i-1].ExecutionResult},
+			Receipts: []*flow.ExecutionReceiptMeta{receiptChain1[i-1].Meta(), receiptChain2[i-1].Meta()},
+		})
+	}
+	sealedResult := receiptChain1[0].ExecutionResult
+	sealF := unittest.Seal.Fixture(unittest.Seal.WithResult(&sealedResult))
+	blocks[4].SetPayload(flow.Payload{ // set payload for block D
+		Seals: []*flow.Seal{sealF},
+	})
+	for i := 0; i <= 4; i++ {
+		unittest.ReconnectBlocksAndReceipts(blocks, receiptChain1)
+		unittest.ReconnectBlocksAndReceipts(blocks, receiptChain2)
+	}
+	require.Equal(bs.T(), sealedResult.ID(), receiptChain2[1].ExecutionResult.PreviousResultID)
+
+	for _, b := range blocks {
+		bs.storeBlock(b)
+	}
+	bs.sealDB = &storage.Seals{}
+	bs.build.seals = bs.sealDB
+	bs.sealDB.On("ByBlockID", mock.Anything).Return(sealF, nil)
+	bs.resultByID[sealedResult.ID()] = &sealedResult
+
+	bs.T().Run("seal with most approving stake is preferred", func(t *testing.T) {
+		bs.pendingSeals = make(map[flow.Identifier]*flow.IncorporatedResultSeal)
+		sealResultA_1 := storeSealForIncorporatedResult(&receiptChain1[1].ExecutionResult, blocks[2].ID(), bs.pendingSeals)
+		sealResultA_2 := storeSealForIncorporatedResult(&receiptChain2[1].ExecutionResult, blocks[2].ID(), bs.pendingSeals)
+		sealResultA_1.ApprovingStake = 1000
+		sealResultA_2.ApprovingStake = 2000
+
+		_, err := bs.build.BuildOn(blocks[4].ID(), bs.setter)
+		require.NoError(t, err)
+		require.Equal(t, []*flow.Seal{sealResultA_2.Seal}, bs.assembled.Seals)
+	})
+
+	bs.T().Run("equal approving stake resolves to first candidate", func(t *testing.T) {
+		bs.pendingSeals = make(map[flow.Identifier]*flow.IncorporatedResultSeal)
+		sealResultA_1 := storeSealForIncorporatedResult(&receiptChain1[1].ExecutionResult, blocks[2].ID(), bs.pendingSeals)
+		sealResultA_2 := storeSealForIncorporatedResult(&receiptChain2[1].ExecutionResult, blocks[2].ID(), bs.pendingSeals)
+		sealResultA_1.ApprovingStake = 1000
+		sealResultA_2.ApprovingStake = 1000
+
+		_, err := bs.build.BuildOn(blocks[4].ID(), bs.setter)
+		require.NoError(t, err)
+		require.Equal(t, []*flow.Seal{sealResultA_1.Seal}, bs.assembled.Seals)
+	})
+
+	bs.T().Run("approving stake never breaks continuity", func(t *testing.T) {
+		// Result[B]_1 has the most approving stake, but does not descend from the preferred Result[A]_2
+		bs.pendingSeals = make(map[flow.Identifier]*flow.IncorporatedResultSeal)
+		sealResultA_1 := storeSealForIncorporatedResult(&receiptChain1[1].ExecutionResult, blocks[2].ID(), bs.pendingSeals)
+		sealResultA_2 := storeSealForIncorporatedResult(&receiptChain2[1].ExecutionResult, blocks[2].ID(), bs.pendingSeals)
+		sealResultB_1 := storeSealForIncorporatedResult(&receiptChain1[2].ExecutionResult, blocks[3].ID(), bs.pendingSeals)
+		sealResultB_2 := storeSealForIncorporatedResult(&receiptChain2[2].ExecutionResult, blocks[3].ID(), bs.pendingSeals)
+		sealResultA_1.ApprovingStake = 1000
+		sealResultA_2.ApprovingStake = 2000
+		sealResultB_1.ApprovingStake = 5000
+		sealResultB_2.ApprovingStake = 1000
+
+		_, err := bs.build.BuildOn(blocks[4].ID(), bs.setter)
+		require.NoError(t, err)
+		require.Equal(t, []*flow.Seal{sealResultA_2.Seal, sealResultB_2.Seal}, bs.assembled.Seals)
+	})
+}
+
 // TestPayloadReceipts_TraverseExecutionTreeFromLastSealedResult tests the receipt selection:
 // Expectation: Builder should trigger ExecutionTree to search Execution Tree from
 //              last sealed result on respective fork.
@@ -1002,6 +1165,29 @@ func (bs *BuilderSuite) TestPayloadReceipts_BlockLimit() {
 	bs.Assert().ElementsMatch(expectedResults[:limit], bs.assembled.Results, "should have excluded results above maxReceiptCount")
 }
 
+// TestPayloadReceipts_SizeLimit tests that the builder does not include more receipts, including
+// their results, than fit into the payload size budget.
+func (bs *BuilderSuite) TestPayloadReceipts_SizeLimit() {
+
+	// populate the mempool with 5 valid receipts
+	var receipts []*flow.ExecutionReceipt
+	for i := 0; i < 5; i++ {
+		blockOnFork := bs.blocks[bs.irsList[i].Seal.BlockID]
+		receipts = append(receipts, unittest.ReceiptForBlockFixture(blockOnFork))
+	}
+	bs.pendingReceipts = receipts
+
+	// the budget fits the first two receipts and their results
+	size, err := encodedSize(receipts[0].Meta(), &receipts[0].ExecutionResult, receipts[1].Meta(), &receipts[1].ExecutionResult)
+	bs.Require().NoError(err)
+	bs.build.cfg.maxPayloadSize = size
+
+	_, err = bs.build.BuildOn(bs.parentID, bs.setter)
+	bs.Require().NoError(err)
+	bs.Assert().ElementsMatch([]*flow.ExecutionReceiptMeta{receipts[0].Meta(), receipts[1].Meta()}, bs.assembled.Receipts, "should have excluded receipts exceeding the payload size")
+	bs.Assert().ElementsMatch([]*flow.ExecutionResult{&receipts[0].ExecutionResult, &receipts[1].ExecutionResult}, bs.assembled.Results, "should have excluded results exceeding the payload size")
+}
+
 // TestPayloadReceipts_AsProvidedByReceiptForest tests the receipt selection.
 // Expectation: Builder should embed the Receipts as provided by the ExecutionTree
 func (bs *BuilderSuite) TestPayloadReceipts_AsProvidedByReceiptForest() {
@@ -1388,6 +1574,7 @@ func (bs *BuilderSuite) TestIntegration_RepopulateExecutionTreeAtStartup() {
 	// create builder which has to repopulate execution tree
 	var err error
 	bs.build, err = NewBuilder(
+		noopMetrics,
 		noopMetrics,
 		bs.db,
 		bs.state,
@@ -1427,3 +1614,12 @@ func (bs *BuilderSuite) TestIntegration_RepopulateExecutionTreeAtStartup() {
 	bs.Assert().ElementsMatch(expectedReceipts, bs.assembled.Receipts, "payload should contain receipts from valid execution forks")
 	bs.Assert().ElementsMatch(expectedResults, bs.assembled.Results, "payload should contain results from valid execution forks")
 }
+
+// seals converts the given seals for encoding them with encodedSize.
+func seals(chain []*flow.Seal) []interface{} {
+	items := make([]interface{}, 0, len(chain))
+	for _, seal := range chain {
+		items = append(items, seal)
+	}
+	return items
+}
@@ -6,13 +6,20 @@ import (
 	"github.com/onflow/flow-go/state/protocol"
 )
 
+// DefaultMaxPayloadSize is the default max total encoded byte size of a block payload (4MB). It
+// leaves room for the header and the message envelope below the 5MB limit of pubsub messages,
+// with which block proposals are broadcast.
+const DefaultMaxPayloadSize = 4 << 20
+
 type Config struct {
 	blockTimer protocol.BlockTimer
 	// the max number of seals to be included in a block proposal
 	maxSealCount      uint
 	maxGuaranteeCount uint
 	maxReceiptCount   uint
-	expiry            uint
+	// the max total encoded byte size of the seals, guarantees, receipts and results of a block payload
+	maxPayloadSize uint64
+	expiry         uint
 }
 
 func WithBlockTimer(timer protocol.BlockTimer) func(*Config) {
@@ -38,3 +45,11 @@ func WithMaxReceiptCount(maxReceiptCount uint) func(*Config) {
 		cfg.maxReceiptCount = maxReceiptCount
 	}
 }
+
+// WithMaxPayloadSize limits the total encoded byte size of the items included in a block
+// payload. Seals take priority over guarantees, which take priority over receipts.
+func WithMaxPayloadSize(maxPayloadSize uint64) func(*Config) {
+	return func(cfg *Config) {
+		cfg.maxPayloadSize = maxPayloadSize
+	}
+}
@@ -0,0 +1,66 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+)
+
+// Payload sections, as labelled in the builder metrics.
+const (
+	sectionSeals      = "seals"
+	sectionGuarantees = "guarantees"
+	sectionReceipts   = "receipts"
+)
+
+// payloadBudget tracks the remaining byte size of the block payload under construction. The size
+// of a payload is estimated as the total size of the canonical encodings of its items, which
+// neglects the few bytes framing the sections.
+type payloadBudget struct {
+	remaining uint64
+}
+
+func newPayloadBudget(maxPayloadSize uint64) *payloadBudget {
+	return &payloadBudget{remaining: maxPayloadSize}
+}
+
+// take deducts the given size from the budget and returns true, if it fits into the remaining budget.
+func (p *payloadBudget) take(size uint64) bool {
+	if size > p.remaining {
+		return false
+	}
+	p.remaining -= size
+	return true
+}
+
+// selectPrefix returns the number of the given candidates to include in the payload: the longest
+// prefix of at most maxCount candidates, whose sizes fit into the budget. As candidates are ordered
+// by priority, selection stops at the first candidate exceeding a limit.
+func (p *payloadBudget) selectPrefix(candidates int, maxCount uint, size func(i int) (uint64, error)) (int, error) {
+	for i := 0; i < candidates; i++ {
+		if uint(i) >= maxCount {
+			return i, nil
+		}
+		s, err := size(i)
+		if err != nil {
+			return 0, fmt.Errorf("could not determine size of payload item: %w", err)
+		}
+		if !p.take(s) {
+			return i, nil
+		}
+	}
+	return candidates, nil
+}
+
+// encodedSize returns the total size of the canonical encodings of the given items.
+func encodedSize(items ...interface{}) (uint64, error) {
+	var size uint64
+	for _, item := range items {
+		encoded, err := cbor.EncMode.Marshal(item)
+		if err != nil {
+			return 0, fmt.Errorf("could not encode %T: %w", item, err)
+		}
+		size += uint64(len(encoded))
+	}
+	return size, nil
+}
@@ -285,6 +285,31 @@ func (a *PublicAssignmentTestSuite) ChunkAssignmentScenario(chunkNum, verNum, al
 	}
 }
 
+// TestSystemChunkOnlyAssignment evaluates that the system chunk of a result for a block
+// without guarantees is assigned to alpha verifiers like any other chunk.
+func (a *PublicAssignmentTestSuite) TestSystemChunkOnlyAssignment() {
+	alpha := 3
+	head, snapshot, state := a.SetupTest(alpha)
+
+	systemChunk := flow.NewSystemChunk(0, unittest.StateCommitmentFixture(), unittest.StateCommitmentFixture(),
+		head.ID(), unittest.IdentifierFixture(), 1)
+	result := &flow.ExecutionResult{
+		BlockID: head.ID(),
+		Chunks:  flow.ChunkList{systemChunk},
+	}
+	seed := a.HashResult(result, a.T())
+	snapshot.On("Seed", mock.Anything, mock.Anything, mock.Anything).Return(seed, nil)
+
+	nodes := unittest.IdentityListFixture(5)
+	snapshot.On("Identities", mock.Anything).Return(nodes, nil).Once()
+	assigner, err := NewChunkAssigner(uint(alpha), state)
+	require.NoError(a.T(), err)
+
+	assignment, err := assigner.Assign(result, head.ID())
+	require.NoError(a.T(), err)
+	require.Equal(a.T(), alpha, assignment.Verifiers(systemChunk).Len())
+}
+
 func (a *PublicAssignmentTestSuite) TestCacheAssignment() {
 	head, snapshot, state := a.SetupTest(3)
 
@@ -345,7 +370,7 @@ func (a *PublicAssignmentTestSuite) CreateChunks(num int, t *testing.T) flow.Chu
 				BlockID:    blockID,
 			},
 		}
-		list.Insert(c)
+		require.NoError(a.T(), list.Insert(c))
 	}
 	require.Equal(a.T(), num, list.Len())
 	return list
@@ -59,6 +59,10 @@ type DKGController interface {
 	// Shutdown stops the controller regardless of the current state.
 	Shutdown()
 
+	// Abort stops the controller regardless of the current state, after
+	// storing a checkpoint from which the run is resumed after a restart.
+	Abort()
+
 	// Poll instructs the controller to actively fetch broadcast messages (ex.
 	// read from DKG smart contract). The method does not return until all
 	// received messages are processed.
@@ -82,4 +86,8 @@ type DKGControllerFactory interface {
 
 	// Create instantiates a new DKGController.
 	Create(dkgInstanceID string, participants flow.IdentityList, seed []byte) (DKGController, error)
+
+	// CreateFromCheckpoint instantiates a DKGController which resumes a DKG
+	// run from a checkpoint stored by a DKGController before a restart.
+	CreateFromCheckpoint(dkgInstanceID string, data []byte) (DKGController, error)
 }
@@ -56,6 +56,7 @@ type Broker struct {
 	privateMsgCh              chan messages.DKGMessage   // channel to forward incoming private messages to consumers
 	broadcastMsgCh            chan messages.DKGMessage   // channel to forward incoming broadcast messages to consumers
 	messageOffset             uint                       // offset for next broadcast messages to fetch
+	offsetLock                sync.Mutex                 // protects access to messageOffset, which is only updated by polls
 	shutdownCh                chan struct{}              // channel to stop the broker from listening
 
 	broadcasts    uint       // broadcasts counts the number of successful broadcasts
@@ -264,10 +265,29 @@ func (b *Broker) Poll(referenceBlock flow.Identifier) error {
 
 	// update message offset to use for future polls, this avoids forwarding the
 	// same message more than once
+	b.offsetLock.Lock()
 	b.messageOffset += uint(len(msgs))
+	b.offsetLock.Unlock()
 	return nil
 }
 
+// MessageOffset returns the offset of the next broadcast messages to fetch. It
+// does not wait for an ongoing poll, so the messages of that poll which were
+// already forwarded are not counted yet.
+func (b *Broker) MessageOffset() uint {
+	b.offsetLock.Lock()
+	defer b.offsetLock.Unlock()
+	return b.messageOffset
+}
+
+// setMessageOffset sets the offset of the next broadcast messages to fetch. It
+// is used to resume a DKG run from a checkpoint, before the first poll.
+func (b *Broker) setMessageOffset(offset uint) {
+	b.offsetLock.Lock()
+	defer b.offsetLock.Unlock()
+	b.messageOffset = offset
+}
+
 // SubmitResult publishes the result of the DKG protocol to the smart contract.
 func (b *Broker) SubmitResult(pubKey crypto.PublicKey, groupKeys []crypto.PublicKey) error {
 	expRetry, err := retry.NewExponential(retryDuration)
@@ -396,8 +416,11 @@ func (b *Broker) prepareBroadcastMessage(data []byte) (messages.BroadcastDKGMess
 		data,
 		b.dkgInstanceID,
 	)
-	sigData := fingerprint.Fingerprint(dkgMessage)
-	signature, err := b.me.Sign(sigData[:], NewDKGMessageHasher())
+	sigData, err := dkgMessage.MarshalCanonical()
+	if err != nil {
+		return messages.BroadcastDKGMessage{}, fmt.Errorf("could not encode DKG message: %w", err)
+	}
+	signature, err := b.me.Sign(sigData, NewDKGMessageHasher())
 	if err != nil {
 		return messages.BroadcastDKGMessage{}, err
 	}
@@ -410,6 +433,9 @@ func (b *Broker) prepareBroadcastMessage(data []byte) (messages.BroadcastDKGMess
 
 // verifyBroadcastMessage checks the DKG instance and Origin of a broadcast
 // message, as well as the signature against the staking key of the sender.
+// The signature is computed over the canonical encoding of the DKG message. For
+// messages broadcast by nodes running the previous release, the signature over
+// the legacy fingerprint of the DKG message is accepted as well.
 // Returns:
 // * true, nil if the message contents are valid and have a valid signature
 // * false, nil if the message contents are valid but have an invalid signature
@@ -422,10 +448,24 @@ func (b *Broker) verifyBroadcastMessage(bcastMsg messages.BroadcastDKGMessage) (
 		return false, err
 	}
 	origin := b.committee[bcastMsg.Orig]
-	signData := fingerprint.Fingerprint(bcastMsg.DKGMessage)
+	signData, err := bcastMsg.DKGMessage.MarshalCanonical()
+	if err != nil {
+		return false, fmt.Errorf("could not encode DKG message: %w", err)
+	}
+	valid, err := origin.StakingPubKey.Verify(
+		bcastMsg.Signature,
+		signData,
+		NewDKGMessageHasher(),
+	)
+	if err != nil || valid {
+		return valid, err
+	}
+
+	// TODO: remove the legacy signature scheme after the next spork
+	legacySignData := fingerprint.Fingerprint(bcastMsg.DKGMessage)
 	return origin.StakingPubKey.Verify(
 		bcastMsg.Signature,
-		signData[:],
+		legacySignData[:],
 		NewDKGMessageHasher(),
 	)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/fingerprint"
 	"github.com/onflow/flow-go/model/flow"
 	msg "github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
@@ -271,6 +272,67 @@ func TestBroadcastMessage(t *testing.T) {
 	contractClient2.AssertExpectations(t)
 }
 
+// TestBroadcastMessage_Signature checks that broadcast messages are signed over the
+// canonical encoding of the DKG message, and that signatures over the legacy
+// fingerprint are still accepted.
+func TestBroadcastMessage_Signature(t *testing.T) {
+	committee, locals := initCommittee(2)
+
+	sender := NewBroker(
+		zerolog.Logger{},
+		dkgInstanceID,
+		committee,
+		locals[orig],
+		orig,
+		[]module.DKGContractClient{&mock.DKGContractClient{}},
+		NewBrokerTunnel(),
+	)
+	recipient := NewBroker(
+		zerolog.Logger{},
+		dkgInstanceID,
+		committee,
+		locals[dest],
+		dest,
+		[]module.DKGContractClient{&mock.DKGContractClient{}},
+		NewBrokerTunnel(),
+	)
+
+	t.Run("canonical", func(t *testing.T) {
+		bcastMsg, err := sender.prepareBroadcastMessage(msgb)
+		require.NoError(t, err)
+
+		encoded, err := bcastMsg.DKGMessage.MarshalCanonical()
+		require.NoError(t, err)
+		valid, err := committee[orig].StakingPubKey.Verify(bcastMsg.Signature, encoded, NewDKGMessageHasher())
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		valid, err = recipient.verifyBroadcastMessage(bcastMsg)
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		// the signature doesn't verify for modified messages
+		bcastMsg.Data = []byte("tampered")
+		valid, err = recipient.verifyBroadcastMessage(bcastMsg)
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		dkgMessage := msg.NewDKGMessage(orig, msgb, dkgInstanceID)
+		legacySigData := fingerprint.Fingerprint(dkgMessage)
+		signature, err := locals[orig].Sign(legacySigData[:], NewDKGMessageHasher())
+		require.NoError(t, err)
+
+		valid, err := recipient.verifyBroadcastMessage(msg.BroadcastDKGMessage{
+			DKGMessage: dkgMessage,
+			Signature:  signature,
+		})
+		require.NoError(t, err)
+		require.True(t, valid)
+	})
+}
+
 // TestPoll checks that the broker correctly calls the smart contract to fetch
 // broadcast messages, and forwards the messages to the broadcast channel.
 func TestPoll(t *testing.T) {
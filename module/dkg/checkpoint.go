@@ -0,0 +1,99 @@
+package dkg
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// eventType is the type of an operation applied to the DKG participant.
+type eventType uint8
+
+const (
+	eventStart eventType = iota
+	eventPrivateMsg
+	eventBroadcastMsg
+	eventTimeout
+)
+
+// event is an operation applied to the DKG participant by the Controller.
+type event struct {
+	Type eventType
+	Orig int    // index of the message origin, for messages
+	Data []byte // message payload, for messages
+}
+
+// checkpoint captures the state of a Controller, from which a DKG run that was
+// interrupted by a restart is resumed.
+//
+// The private state of the crypto DKG participant cannot be exported, but the
+// participant is deterministic for a given seed. So instead, the checkpoint
+// records all operations successfully applied to the participant, in order;
+// replaying them on a new participant restores its state. The offset of the
+// broker determines the first broadcast message to fetch when resuming.
+//
+// CAUTION: the checkpoint contains the seed of the participant's secret
+// polynomial, and must only be stored in the secrets database.
+type checkpoint struct {
+	Participants  flow.IdentityList
+	Seed          []byte
+	Phase         State // latest phase entered by the participant
+	MessageOffset uint
+	Events        []event
+}
+
+// replay applies the events of the checkpoint to the given DKG participant,
+// which must be newly created.
+func (cp *checkpoint) replay(dkg crypto.DKGState) error {
+	for i, ev := range cp.Events {
+		var err error
+		switch ev.Type {
+		case eventStart:
+			err = dkg.Start(cp.Seed)
+		case eventPrivateMsg:
+			err = dkg.HandlePrivateMsg(ev.Orig, ev.Data)
+		case eventBroadcastMsg:
+			err = dkg.HandleBroadcastMsg(ev.Orig, ev.Data)
+		case eventTimeout:
+			err = dkg.NextTimeout()
+		default:
+			err = fmt.Errorf("unknown event type %d", ev.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("could not replay event %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// broadcastKey returns the key identifying a handled broadcast message, used
+// to skip messages that are fetched again after resuming from a checkpoint.
+func broadcastKey(orig int, data []byte) string {
+	return fmt.Sprintf("%d:%x", orig, sha256.Sum256(data))
+}
+
+// replayProcessor wraps the processor of a DKG participant resumed from a
+// checkpoint. While the checkpoint is replayed, the messages the participant
+// sends are dropped, as they were sent before the checkpoint was taken.
+type replayProcessor struct {
+	crypto.DKGProcessor
+	replaying bool // only set before the Controller runs the participant
+}
+
+// PrivateSend implements the crypto.DKGProcessor interface.
+func (p *replayProcessor) PrivateSend(dest int, data []byte) {
+	if p.replaying {
+		return
+	}
+	p.DKGProcessor.PrivateSend(dest, data)
+}
+
+// Broadcast implements the crypto.DKGProcessor interface.
+func (p *replayProcessor) Broadcast(data []byte) {
+	if p.replaying {
+		return
+	}
+	p.DKGProcessor.Broadcast(data)
+}
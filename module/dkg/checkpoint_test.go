@@ -0,0 +1,119 @@
+package dkg
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	msg "github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// recordingDKG is a crypto.DKGState which records the operations applied to it.
+type recordingDKG struct {
+	crypto.DKGState
+	sync.Mutex
+	ops []string
+}
+
+func (d *recordingDKG) record(op string) error {
+	d.Lock()
+	defer d.Unlock()
+	d.ops = append(d.ops, op)
+	return nil
+}
+
+func (d *recordingDKG) recorded() []string {
+	d.Lock()
+	defer d.Unlock()
+	return append([]string(nil), d.ops...)
+}
+
+func (d *recordingDKG) Size() int { return 2 }
+
+func (d *recordingDKG) Start(seed []byte) error { return d.record(fmt.Sprintf("start %x", seed)) }
+
+func (d *recordingDKG) HandlePrivateMsg(orig int, data []byte) error {
+	return d.record(fmt.Sprintf("private %d %x", orig, data))
+}
+
+func (d *recordingDKG) HandleBroadcastMsg(orig int, data []byte) error {
+	return d.record(fmt.Sprintf("broadcast %d %x", orig, data))
+}
+
+func (d *recordingDKG) NextTimeout() error { return d.record("timeout") }
+
+func (d *recordingDKG) End() (crypto.PrivateKey, crypto.PublicKey, []crypto.PublicKey, error) {
+	return nil, nil, nil, d.record("end")
+}
+
+// TestControllerCheckpoint tests that the checkpoint of an aborted controller
+// restores the state of its DKG participant, and that the resumed controller
+// continues the run in the checkpointed phase.
+func TestControllerCheckpoint(t *testing.T) {
+	privateChannels := []chan msg.DKGMessage{make(chan msg.DKGMessage, 10), make(chan msg.DKGMessage, 10)}
+	broadcastChannels := []chan msg.DKGMessage{make(chan msg.DKGMessage, 10), make(chan msg.DKGMessage, 10)}
+	broker := &broker{
+		privateChannels:   privateChannels,
+		broadcastChannels: broadcastChannels,
+		logger:            zerolog.New(os.Stderr),
+	}
+	store := newCheckpoints()
+	seed := unittest.SeedFixture(20)
+	private := msg.NewDKGMessage(1, unittest.RandomBytes(10), "dkg_test")
+	broadcast := msg.NewDKGMessage(1, unittest.RandomBytes(10), "dkg_test")
+
+	// run the controller into phase 2, handling a message in phase 1
+	dkg := &recordingDKG{}
+	controller := NewController(zerolog.New(os.Stderr), "dkg_test", nil, dkg, seed, broker, store, ControllerConfig{})
+	runErrCh := make(chan error)
+	go func() {
+		runErrCh <- controller.Run()
+	}()
+	require.Eventually(t, func() bool { return controller.GetState() == Phase1 }, time.Second, time.Millisecond)
+	privateChannels[0] <- private
+	broadcastChannels[0] <- broadcast
+	require.Eventually(t, func() bool { return len(dkg.recorded()) == 3 }, time.Second, time.Millisecond)
+	require.NoError(t, controller.EndPhase1())
+	require.Eventually(t, func() bool { return len(dkg.recorded()) == 4 }, time.Second, time.Millisecond)
+
+	// the checkpoint taken on aborting restores the same participant state
+	controller.Abort()
+	require.NoError(t, <-runErrCh)
+	controller.Abort()
+	cp := store.checkpoint(t, "dkg_test")
+	assert.Equal(t, Phase2, cp.Phase)
+	resumedDKG := &recordingDKG{}
+	require.NoError(t, cp.replay(resumedDKG))
+	assert.Equal(t, dkg.recorded(), resumedDKG.recorded())
+
+	// the resumed controller skips the messages it handled before, and continues in phase 2
+	resumed := NewController(zerolog.New(os.Stderr), "dkg_test", nil, resumedDKG, cp.Seed, broker, store, ControllerConfig{})
+	resumed.resume(cp)
+	require.Equal(t, Phase2, resumed.GetState())
+	go func() {
+		runErrCh <- resumed.Run()
+	}()
+	next := msg.NewDKGMessage(1, unittest.RandomBytes(10), "dkg_test")
+	broadcastChannels[0] <- broadcast
+	broadcastChannels[0] <- next
+	require.Eventually(t, func() bool { return len(resumedDKG.recorded()) == 5 }, time.Second, time.Millisecond)
+	require.NoError(t, resumed.EndPhase2())
+	require.Eventually(t, func() bool { return len(resumedDKG.recorded()) == 6 }, time.Second, time.Millisecond)
+	require.NoError(t, resumed.End())
+	require.NoError(t, <-runErrCh)
+
+	expected := append(dkg.recorded(), fmt.Sprintf("broadcast 1 %x", next.Data), "timeout", "end")
+	assert.Equal(t, expected, resumedDKG.recorded())
+
+	// the phase 3 checkpoint of the resumed controller includes all operations before the end
+	require.Eventually(t, func() bool { return store.checkpoint(t, "dkg_test").Phase == Phase3 }, time.Second, time.Millisecond)
+	assert.Len(t, store.checkpoint(t, "dkg_test").Events, 6)
+}
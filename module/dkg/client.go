@@ -2,9 +2,11 @@ package dkg
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/onflow/cadence"
@@ -81,14 +83,14 @@ func (c *Client) Broadcast(msg model.BroadcastDKGMessage) error {
 		SetPayer(account.Address).
 		AddAuthorizer(account.Address)
 
-	// json encode the DKG message
-	jsonMessage, err := json.Marshal(msg)
+	// canonically encode the DKG message
+	encodedMessage, err := msg.MarshalCanonical()
 	if err != nil {
-		return fmt.Errorf("could not marshal DKG messages struct: %v", err)
+		return fmt.Errorf("could not encode DKG message: %w", err)
 	}
 
-	// add dkg message json encoded string to tx args
-	cdcMessage, err := cadence.NewString(string(jsonMessage))
+	// add hex encoded dkg message to tx args
+	cdcMessage, err := cadence.NewString(hex.EncodeToString(encodedMessage))
 	if err != nil {
 		return fmt.Errorf("could not convert DKG message to cadence: %w", err)
 	}
@@ -139,15 +141,14 @@ func (c *Client) ReadBroadcast(fromIndex uint, referenceBlock flow.Identifier) (
 	for _, val := range values {
 
 		content := val.(cadence.Struct).Fields[1]
-		jsonString, err := strconv.Unquote(content.String())
+		contentString, err := strconv.Unquote(content.String())
 		if err != nil {
-			return nil, fmt.Errorf("could not unquote json string: %w", err)
+			return nil, fmt.Errorf("could not unquote message string: %w", err)
 		}
 
-		var flowMsg model.BroadcastDKGMessage
-		err = json.Unmarshal([]byte(jsonString), &flowMsg)
+		flowMsg, err := decodeBroadcastMessage(contentString)
 		if err != nil {
-			return nil, fmt.Errorf("could not unmarshal dkg message: %w", err)
+			return nil, fmt.Errorf("could not decode dkg message: %w", err)
 		}
 		messages = append(messages, flowMsg)
 	}
@@ -240,6 +241,33 @@ func (c *Client) SubmitResult(groupPublicKey crypto.PublicKey, publicKeys []cryp
 	return nil
 }
 
+// decodeBroadcastMessage decodes a broadcast message read from the DKG smart contract.
+// Messages are stored as the hex encoding of their canonical encoding. Messages
+// broadcast by nodes running the previous release are stored as JSON, which is
+// detected by the leading '{' (not a valid hex character).
+// TODO: remove the legacy JSON encoding after the next spork
+func decodeBroadcastMessage(content string) (model.BroadcastDKGMessage, error) {
+	var msg model.BroadcastDKGMessage
+
+	if strings.HasPrefix(content, "{") {
+		err := json.Unmarshal([]byte(content), &msg)
+		if err != nil {
+			return model.BroadcastDKGMessage{}, fmt.Errorf("could not unmarshal legacy dkg message: %w", err)
+		}
+		return msg, nil
+	}
+
+	encoded, err := hex.DecodeString(content)
+	if err != nil {
+		return model.BroadcastDKGMessage{}, fmt.Errorf("could not decode hex: %w", err)
+	}
+	err = msg.UnmarshalCanonical(encoded)
+	if err != nil {
+		return model.BroadcastDKGMessage{}, err
+	}
+	return msg, nil
+}
+
 // trim0x trims the `0x` if it exists from a hexadecimal string
 // This method is required as the DKG contract expects key lengths of 192 bytes
 // the `PublicKey.String()` method returns the hexadecimal string representation of the
@@ -1,6 +1,9 @@
 package dkg
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"sort"
 	"testing"
 
@@ -23,6 +26,7 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
 	emulatormod "github.com/onflow/flow-go/module/emulator"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -339,3 +343,35 @@ func (s *ClientSuite) executeScript(script []byte, arguments [][]byte) cadence.V
 
 	return result.Value
 }
+
+// TestDecodeBroadcastMessage checks that broadcast messages stored in the DKG smart
+// contract are decoded from both the canonical and the legacy JSON encoding.
+func TestDecodeBroadcastMessage(t *testing.T) {
+	expected := unittest.DKGBroadcastMessageFixture()
+
+	t.Run("canonical", func(t *testing.T) {
+		encoded, err := expected.MarshalCanonical()
+		require.NoError(t, err)
+
+		decoded, err := decodeBroadcastMessage(hex.EncodeToString(encoded))
+		require.NoError(t, err)
+		assert.Equal(t, *expected, decoded)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		encoded, err := json.Marshal(expected)
+		require.NoError(t, err)
+
+		decoded, err := decodeBroadcastMessage(string(encoded))
+		require.NoError(t, err)
+		assert.Equal(t, *expected, decoded)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := decodeBroadcastMessage("not hex")
+		assert.Error(t, err)
+
+		_, err = decodeBroadcastMessage("0200")
+		assert.True(t, errors.Is(err, messages.ErrInvalidDKGMessageEncoding))
+	})
+}
@@ -1,6 +1,7 @@
 package dkg
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/rand"
@@ -12,6 +13,7 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
 )
 
 const (
@@ -68,6 +70,12 @@ type ControllerConfig struct {
 // Controller implements the DKGController interface. It controls the execution
 // of a Joint Feldman DKG instance. A new Controller must be instantiated for
 // every epoch.
+//
+// After each phase transition, the Controller stores a checkpoint of its state,
+// from which the run is resumed if the node restarts (see
+// ControllerFactory.CreateFromCheckpoint). Checkpoints are taken by the
+// background worker between handling messages, so that all messages counted by
+// the offset of the broker are reflected in the checkpoint.
 type Controller struct {
 	// The embedded state Manager is used to manage the controller's underlying
 	// state.
@@ -75,23 +83,47 @@ type Controller struct {
 
 	log zerolog.Logger
 
+	dkgInstanceID string
+	participants  flow.IdentityList
+
 	// DKGState is the object that actually executes the protocol steps.
 	dkg crypto.DKGState
 
-	// dkgLock protects access to dkg
+	// dkgLock protects access to dkg, and to the fields recording the operations
+	// applied to it
 	dkgLock sync.Mutex
 
+	// events are the operations successfully applied to dkg, and entered is the
+	// latest phase entered by dkg
+	events  []event
+	entered State
+
+	// replayed are the broadcast messages handled before resuming from a
+	// checkpoint, which are skipped if they are fetched again
+	replayed map[string]struct{}
+
+	// aborted indicates that the worker stores a final checkpoint on shutdown
+	aborted bool
+
 	// seed is required by DKGState
 	seed []byte
 
 	// broker enables the controller to communicate with other nodes
 	broker module.DKGBroker
 
+	// checkpoints stores the checkpoints of the controller
+	checkpoints storage.DKGCheckpoints
+
 	// Channels used internally to trigger state transitions
-	h1Ch       chan struct{}
-	h2Ch       chan struct{}
-	endCh      chan struct{}
-	shutdownCh chan struct{}
+	h1Ch         chan struct{}
+	h2Ch         chan struct{}
+	endCh        chan struct{}
+	shutdownCh   chan struct{}
+	checkpointCh chan struct{} // requests the worker to store a checkpoint
+	workerDone   chan struct{} // closed when the worker has stopped
+
+	shutdownOnce sync.Once
+	workerOnce   sync.Once
 
 	// private fields that hold the DKG artifacts when the protocol runs to
 	// completion
@@ -110,9 +142,11 @@ type Controller struct {
 func NewController(
 	log zerolog.Logger,
 	dkgInstanceID string,
+	participants flow.IdentityList,
 	dkg crypto.DKGState,
 	seed []byte,
 	broker module.DKGBroker,
+	checkpoints storage.DKGCheckpoints,
 	config ControllerConfig,
 ) *Controller {
 
@@ -122,17 +156,36 @@ func NewController(
 		Logger()
 
 	return &Controller{
-		log:        logger,
-		dkg:        dkg,
-		seed:       seed,
-		broker:     broker,
-		h1Ch:       make(chan struct{}),
-		h2Ch:       make(chan struct{}),
-		endCh:      make(chan struct{}),
-		shutdownCh: make(chan struct{}),
-		once:       new(sync.Once),
-		config:     config,
+		log:           logger,
+		dkgInstanceID: dkgInstanceID,
+		participants:  participants,
+		dkg:           dkg,
+		seed:          seed,
+		broker:        broker,
+		checkpoints:   checkpoints,
+		h1Ch:          make(chan struct{}),
+		h2Ch:          make(chan struct{}),
+		endCh:         make(chan struct{}),
+		shutdownCh:    make(chan struct{}),
+		checkpointCh:  make(chan struct{}, 1),
+		workerDone:    make(chan struct{}),
+		replayed:      make(map[string]struct{}),
+		once:          new(sync.Once),
+		config:        config,
+	}
+}
+
+// resume restores the state of the controller from the given checkpoint, which
+// has been replayed on the controller's DKG participant.
+func (c *Controller) resume(cp *checkpoint) {
+	c.events = cp.Events
+	c.entered = cp.Phase
+	for _, ev := range cp.Events {
+		if ev.Type == eventBroadcastMsg {
+			c.replayed[broadcastKey(ev.Orig, ev.Data)] = struct{}{}
+		}
 	}
+	c.SetState(cp.Phase)
 }
 
 /*******************************************************************************
@@ -144,15 +197,20 @@ Implement DKGController
 // the protocol phases.
 func (c *Controller) Run() error {
 
-	// Start DKG and transition to phase 1
-	err := c.start()
-	if err != nil {
-		return err
+	// Start DKG and transition to phase 1, unless the controller was resumed
+	// from a checkpoint
+	if c.GetState() == Init {
+		err := c.start()
+		if err != nil {
+			return err
+		}
 	}
 
 	// Start a background routine to listen for incoming private and broadcast
 	// messages from other nodes
-	go c.doBackgroundWork()
+	c.workerOnce.Do(func() {
+		go c.doBackgroundWork()
+	})
 
 	// Execute DKG State Machine
 	for {
@@ -241,9 +299,37 @@ func (c *Controller) End() error {
 
 // Shutdown stops the controller regardless of the current state.
 func (c *Controller) Shutdown() {
-	c.broker.Shutdown()
-	c.SetState(Shutdown)
-	close(c.shutdownCh)
+	c.shutdownOnce.Do(func() {
+		c.broker.Shutdown()
+		c.SetState(Shutdown)
+		close(c.shutdownCh)
+	})
+}
+
+// Abort stops the controller regardless of the current state, like Shutdown,
+// but first stores a final checkpoint, from which the run can be resumed after
+// a restart. Once the DKG has ended, there is nothing to resume, and Abort is
+// a no-op.
+func (c *Controller) Abort() {
+	state := c.GetState()
+	if state == End || state == Shutdown {
+		return
+	}
+
+	c.dkgLock.Lock()
+	c.aborted = true
+	c.dkgLock.Unlock()
+
+	c.Shutdown()
+
+	// if the worker is running, it stores the final checkpoint once it has
+	// handled the messages it received, otherwise it is prevented from starting
+	c.workerOnce.Do(func() {
+		close(c.workerDone)
+	})
+	<-c.workerDone
+
+	c.log.Info().Str("state", state.String()).Msg("DKG run aborted")
 }
 
 // Poll instructs the broker to read new broadcast messages, which will be
@@ -280,6 +366,8 @@ WORKERS
 *******************************************************************************/
 
 func (c *Controller) doBackgroundWork() {
+	defer close(c.workerDone)
+
 	privateMsgCh := c.broker.GetPrivateMsgCh()
 	broadcastMsgCh := c.broker.GetBroadcastMsgCh()
 	for {
@@ -287,6 +375,9 @@ func (c *Controller) doBackgroundWork() {
 		case msg := <-privateMsgCh:
 			c.dkgLock.Lock()
 			err := c.dkg.HandlePrivateMsg(int(msg.Orig), msg.Data)
+			if err == nil {
+				c.events = append(c.events, event{Type: eventPrivateMsg, Orig: int(msg.Orig), Data: msg.Data})
+			}
 			c.dkgLock.Unlock()
 			if err != nil {
 				c.log.Err(err).Msg("error processing DKG private message")
@@ -294,6 +385,17 @@ func (c *Controller) doBackgroundWork() {
 
 		case msg := <-broadcastMsgCh:
 
+			// skip broadcast messages fetched again after resuming from a
+			// checkpoint, which were already handled before the checkpoint
+			key := broadcastKey(int(msg.Orig), msg.Data)
+			c.dkgLock.Lock()
+			_, handled := c.replayed[key]
+			delete(c.replayed, key)
+			c.dkgLock.Unlock()
+			if handled {
+				continue
+			}
+
 			// before processing a broadcast message during phase 1, sleep for a
 			// random delay to avoid synchronizing this expensive operation across
 			// all consensus nodes
@@ -319,17 +421,71 @@ func (c *Controller) doBackgroundWork() {
 
 			c.dkgLock.Lock()
 			err := c.dkg.HandleBroadcastMsg(int(msg.Orig), msg.Data)
+			if err == nil {
+				c.events = append(c.events, event{Type: eventBroadcastMsg, Orig: int(msg.Orig), Data: msg.Data})
+			}
 			c.dkgLock.Unlock()
 			if err != nil {
 				c.log.Err(err).Msg("error processing DKG broadcast message")
 			}
 
+		case <-c.checkpointCh:
+			c.checkpoint()
+
 		case <-c.shutdownCh:
+			c.dkgLock.Lock()
+			aborted := c.aborted
+			c.dkgLock.Unlock()
+			if aborted {
+				c.checkpoint()
+			}
 			return
 		}
 	}
 }
 
+// requestCheckpoint requests the worker to store a checkpoint, once it has
+// handled the message it is currently handling.
+func (c *Controller) requestCheckpoint() {
+	select {
+	case c.checkpointCh <- struct{}{}:
+	default:
+		// a checkpoint is pending already, which will include the latest state
+	}
+}
+
+// checkpoint stores a checkpoint of the current state. It must only be called
+// by the worker, while it is not handling a message. Failing to store a
+// checkpoint does not affect the current run, only its resumption after a
+// restart, so errors are logged.
+func (c *Controller) checkpoint() {
+	cp := checkpoint{
+		Participants:  c.participants,
+		Seed:          c.seed,
+		MessageOffset: c.broker.MessageOffset(),
+	}
+	c.dkgLock.Lock()
+	cp.Phase = c.entered
+	cp.Events = c.events[:len(c.events):len(c.events)]
+	c.dkgLock.Unlock()
+
+	data, err := json.Marshal(cp)
+	if err != nil {
+		c.log.Err(err).Msg("could not encode DKG checkpoint")
+		return
+	}
+	err = c.checkpoints.StoreDKGCheckpoint(c.dkgInstanceID, data)
+	if err != nil {
+		c.log.Err(err).Msg("could not store DKG checkpoint")
+		return
+	}
+	c.log.Debug().
+		Str("phase", cp.Phase.String()).
+		Int("events", len(cp.Events)).
+		Uint("message_offset", cp.MessageOffset).
+		Msg("DKG checkpoint stored")
+}
+
 func (c *Controller) start() error {
 	state := c.GetState()
 	if state != Init {
@@ -344,6 +500,10 @@ func (c *Controller) start() error {
 
 	c.dkgLock.Lock()
 	err := c.dkg.Start(c.seed)
+	if err == nil {
+		c.events = append(c.events, event{Type: eventStart})
+		c.entered = Phase1
+	}
 	c.dkgLock.Unlock()
 	if err != nil {
 		return fmt.Errorf("Error starting DKG: %w", err)
@@ -351,6 +511,7 @@ func (c *Controller) start() error {
 
 	c.log.Debug().Msg("DKG engine started")
 	c.SetState(Phase1)
+	c.requestCheckpoint()
 	return nil
 }
 
@@ -377,11 +538,9 @@ func (c *Controller) phase2() error {
 		return fmt.Errorf("Cannot execute phase2 routine in state %s", state)
 	}
 
-	c.dkgLock.Lock()
-	err := c.dkg.NextTimeout()
-	c.dkgLock.Unlock()
+	err := c.nextTimeout(state)
 	if err != nil {
-		return fmt.Errorf("Error calling NextTimeout: %w", err)
+		return err
 	}
 
 	c.log.Debug().Msg("Waiting for end of phase 2")
@@ -401,11 +560,9 @@ func (c *Controller) phase3() error {
 		return fmt.Errorf("Cannot execute phase3 routine in state %s", state)
 	}
 
-	c.dkgLock.Lock()
-	err := c.dkg.NextTimeout()
-	c.dkgLock.Unlock()
+	err := c.nextTimeout(state)
 	if err != nil {
-		return fmt.Errorf("Error calling NextTimeout: %w", err)
+		return err
 	}
 
 	c.log.Debug().Msg("Waiting for end of phase 3")
@@ -419,6 +576,28 @@ func (c *Controller) phase3() error {
 	}
 }
 
+// nextTimeout sets the next timeout of the DKG participant on entering the
+// given phase, unless it was entered before resuming from a checkpoint.
+func (c *Controller) nextTimeout(phase State) error {
+	c.dkgLock.Lock()
+	if c.entered >= phase {
+		c.dkgLock.Unlock()
+		return nil
+	}
+	err := c.dkg.NextTimeout()
+	if err == nil {
+		c.events = append(c.events, event{Type: eventTimeout})
+		c.entered = phase
+	}
+	c.dkgLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("Error calling NextTimeout: %w", err)
+	}
+
+	c.requestCheckpoint()
+	return nil
+}
+
 // preStartDelay returns a duration to delay prior to starting the DKG process.
 // This prevents synchronization of the DKG starting (an expensive operation)
 // across the network, which can impact finalization.
@@ -1,6 +1,7 @@
 package dkg
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/rs/zerolog"
@@ -9,18 +10,20 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/storage"
 )
 
 // ControllerFactory is a factory object that creates new Controllers for new
 // epochs. Each Controller produced by a factory shares the same underlying
 // Local object to sign broadcast messages, the same tunnel tying it to the
 // MessagingEngine, and the same client to communicate with the DKG
-// smart-contract.
+// smart-contract. The Controllers store their checkpoints to the same storage.
 type ControllerFactory struct {
 	log                zerolog.Logger
 	me                 module.Local
 	dkgContractClients []module.DKGContractClient
 	tunnel             *BrokerTunnel
+	checkpoints        storage.DKGCheckpoints
 	config             ControllerConfig
 }
 
@@ -31,6 +34,7 @@ func NewControllerFactory(
 	me module.Local,
 	dkgContractClients []module.DKGContractClient,
 	tunnel *BrokerTunnel,
+	checkpoints storage.DKGCheckpoints,
 	config ControllerConfig) *ControllerFactory {
 
 	return &ControllerFactory{
@@ -38,6 +42,7 @@ func NewControllerFactory(
 		me:                 me,
 		dkgContractClients: dkgContractClients,
 		tunnel:             tunnel,
+		checkpoints:        checkpoints,
 		config:             config,
 	}
 }
@@ -49,15 +54,9 @@ func (f *ControllerFactory) Create(
 	participants flow.IdentityList,
 	seed []byte) (module.DKGController, error) {
 
-	myIndex := -1
-	for i, id := range participants.NodeIDs() {
-		if id == f.me.NodeID() {
-			myIndex = i
-			break
-		}
-	}
-	if myIndex < 0 {
-		return nil, fmt.Errorf("node does not belong to dkg committee")
+	myIndex, err := f.myIndex(participants)
+	if err != nil {
+		return nil, err
 	}
 
 	broker := NewBroker(
@@ -80,11 +79,102 @@ func (f *ControllerFactory) Create(
 	controller := NewController(
 		f.log,
 		dkgInstanceID,
+		participants,
 		dkg,
 		seed,
 		broker,
+		f.checkpoints,
 		f.config,
 	)
 
 	return controller, nil
 }
+
+// CreateFromCheckpoint creates an epoch-specific Controller which resumes the
+// DKG run from the given checkpoint, stored by a Controller for the same DKG
+// instance before the node restarted. The returned Controller is in the latest
+// phase entered before the checkpoint was taken.
+func (f *ControllerFactory) CreateFromCheckpoint(dkgInstanceID string, data []byte) (module.DKGController, error) {
+
+	var cp checkpoint
+	err := json.Unmarshal(data, &cp)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode checkpoint: %w", err)
+	}
+
+	myIndex, err := f.myIndex(cp.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	broker := NewBroker(
+		f.log,
+		dkgInstanceID,
+		cp.Participants,
+		f.me,
+		myIndex,
+		f.dkgContractClients,
+		f.tunnel,
+	)
+	broker.setMessageOffset(cp.MessageOffset)
+
+	controller, err := resumeController(f.log, dkgInstanceID, &cp, myIndex, broker, f.checkpoints, f.config)
+	if err != nil {
+		broker.Shutdown()
+		return nil, fmt.Errorf("could not resume controller from checkpoint: %w", err)
+	}
+
+	return controller, nil
+}
+
+// myIndex returns the index of this node in the given DKG committee.
+func (f *ControllerFactory) myIndex(participants flow.IdentityList) (int, error) {
+	for i, id := range participants.NodeIDs() {
+		if id == f.me.NodeID() {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("node does not belong to dkg committee")
+}
+
+// resumeController creates a Controller for the participant with the given
+// index, and restores its state by replaying the given checkpoint.
+func resumeController(
+	log zerolog.Logger,
+	dkgInstanceID string,
+	cp *checkpoint,
+	myIndex int,
+	broker module.DKGBroker,
+	checkpoints storage.DKGCheckpoints,
+	config ControllerConfig,
+) (*Controller, error) {
+
+	// the messages sent while replaying the checkpoint were sent before
+	processor := &replayProcessor{DKGProcessor: broker, replaying: true}
+
+	n := len(cp.Participants)
+	threshold := signature.RandomBeaconThreshold(n)
+	dkg, err := crypto.NewJointFeldman(n, threshold, myIndex, processor)
+	if err != nil {
+		return nil, err
+	}
+	err = cp.replay(dkg)
+	if err != nil {
+		return nil, err
+	}
+	processor.replaying = false
+
+	controller := NewController(
+		log,
+		dkgInstanceID,
+		cp.Participants,
+		dkg,
+		cp.Seed,
+		broker,
+		checkpoints,
+		config,
+	)
+	controller.resume(cp)
+
+	return controller, nil
+}
@@ -1,8 +1,10 @@
 package dkg
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	msg "github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -22,6 +25,8 @@ import (
 type node struct {
 	id             int
 	controller     *Controller
+	broker         *broker
+	checkpoints    *checkpoints
 	phase1Duration time.Duration
 	phase2Duration time.Duration
 	phase3Duration time.Duration
@@ -146,12 +151,62 @@ func (b *broker) GetBroadcastMsgCh() <-chan msg.DKGMessage {
 // Poll implements the DKGBroker interface.
 func (b *broker) Poll(referenceBlock flow.Identifier) error { return nil }
 
+// MessageOffset implements the DKGBroker interface.
+func (b *broker) MessageOffset() uint { return 0 }
+
 // SubmitResult implements the DKGBroker interface.
 func (b *broker) SubmitResult(crypto.PublicKey, []crypto.PublicKey) error { return nil }
 
 // Shutdown implements the DKGBroker interface.
 func (b *broker) Shutdown() {}
 
+// checkpoints is an in-memory implementation of storage.DKGCheckpoints.
+type checkpoints struct {
+	sync.Mutex
+	data map[string][]byte
+}
+
+func newCheckpoints() *checkpoints {
+	return &checkpoints{data: make(map[string][]byte)}
+}
+
+// StoreDKGCheckpoint implements the storage.DKGCheckpoints interface.
+func (c *checkpoints) StoreDKGCheckpoint(dkgInstanceID string, data []byte) error {
+	c.Lock()
+	defer c.Unlock()
+	c.data[dkgInstanceID] = data
+	return nil
+}
+
+// RetrieveDKGCheckpoint implements the storage.DKGCheckpoints interface.
+func (c *checkpoints) RetrieveDKGCheckpoint(dkgInstanceID string) ([]byte, error) {
+	c.Lock()
+	defer c.Unlock()
+	data, ok := c.data[dkgInstanceID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return data, nil
+}
+
+// RemoveDKGCheckpoint implements the storage.DKGCheckpoints interface.
+func (c *checkpoints) RemoveDKGCheckpoint(dkgInstanceID string) error {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.data, dkgInstanceID)
+	return nil
+}
+
+// checkpoint returns the decoded checkpoint of the given DKG instance.
+func (c *checkpoints) checkpoint(t *testing.T, dkgInstanceID string) *checkpoint {
+	data, err := c.RetrieveDKGCheckpoint(dkgInstanceID)
+	require.NoError(t, err)
+	var cp checkpoint
+	err = json.Unmarshal(data, &cp)
+	require.NoError(t, err)
+	return &cp
+}
+
 type testCase struct {
 	totalNodes     int
 	phase1Duration time.Duration
@@ -200,6 +255,53 @@ func TestDKGThreshold(t *testing.T) {
 	}
 }
 
+// TestDKGResumeFromCheckpoint tests that a node, which is restarted in the
+// middle of phase 2, resumes the DKG from its checkpoint and completes it with
+// the same results as the other nodes.
+func TestDKGResumeFromCheckpoint(t *testing.T) {
+	totalNodes := 5
+	phase1Duration, phase2Duration, phase3Duration := time.Second, 200*time.Millisecond, 200*time.Millisecond
+	nodes := initNodes(t, totalNodes, phase1Duration, phase2Duration, phase3Duration)
+
+	for _, n := range nodes[1:] {
+		go func(node *node) {
+			err := node.run()
+			require.NoError(t, err)
+		}(n)
+	}
+
+	// run the first node until the middle of phase 2, and abort it
+	restarted := nodes[0]
+	runErrCh := make(chan error)
+	go func() {
+		runErrCh <- restarted.controller.Run()
+	}()
+	time.Sleep(phase1Duration)
+	require.NoError(t, restarted.controller.EndPhase1())
+	time.Sleep(phase2Duration / 2)
+	restarted.controller.Abort()
+	require.NoError(t, <-runErrCh)
+
+	// rebuild the node from its checkpoint, and complete the DKG
+	cp := restarted.checkpoints.checkpoint(t, "dkg_test")
+	require.Equal(t, Phase2, cp.Phase)
+	controller, err := resumeController(zerolog.New(os.Stderr), "dkg_test", cp, restarted.id, restarted.broker, restarted.checkpoints, ControllerConfig{})
+	require.NoError(t, err)
+	require.Equal(t, Phase2, controller.GetState())
+	restarted.controller = controller
+	go func() {
+		runErrCh <- controller.Run()
+	}()
+	time.Sleep(phase2Duration / 2)
+	require.NoError(t, controller.EndPhase2())
+	time.Sleep(phase3Duration)
+	require.NoError(t, controller.End())
+	require.NoError(t, <-runErrCh)
+
+	wait(t, nodes, 5*phase1Duration)
+	checkArtifacts(t, nodes, totalNodes)
+}
+
 func testDKG(t *testing.T, totalNodes int, goodNodes int, phase1Duration, phase2Duration, phase3Duration time.Duration) {
 	nodes := initNodes(t, totalNodes, phase1Duration, phase2Duration, phase3Duration)
 	gnodes := nodes[:goodNodes]
@@ -243,6 +345,7 @@ func initNodes(t *testing.T, n int, phase1Duration, phase2Duration, phase3Durati
 		}
 
 		seed := unittest.SeedFixture(20)
+		checkpoints := newCheckpoints()
 
 		dkg, err := crypto.NewJointFeldman(n, signature.RandomBeaconThreshold(n), i, broker)
 		require.NoError(t, err)
@@ -257,14 +360,18 @@ func initNodes(t *testing.T, n int, phase1Duration, phase2Duration, phase3Durati
 		controller := NewController(
 			logger,
 			"dkg_test",
+			nil,
 			dkg,
 			seed,
 			broker,
+			checkpoints,
 			config,
 		)
 		require.NoError(t, err)
 
 		node := newNode(i, controller, phase1Duration, phase2Duration, phase3Duration)
+		node.broker = broker
+		node.checkpoints = checkpoints
 		nodes = append(nodes, node)
 	}
 
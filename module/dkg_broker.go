@@ -1,4 +1,4 @@
-// +build relic
+// +build relic sandbox
 
 package module
 
@@ -31,6 +31,10 @@ type DKGBroker interface {
 	// all received messages are processed by the consumer.
 	Poll(referenceBlock flow.Identifier) error
 
+	// MessageOffset returns the offset of the next broadcast messages to fetch,
+	// ie. the number of broadcast messages fetched by polls so far.
+	MessageOffset() uint
+
 	// SubmitResult instructs the broker to publish the results of the DKG run
 	// (ex. publish to DKG smart contract).
 	SubmitResult(crypto.PublicKey, []crypto.PublicKey) error
@@ -2,11 +2,16 @@ package module
 
 import (
 	"context"
+	"errors"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
+// ErrClusterRootQCAlreadyVoted is returned when the cluster QC aggregator smart
+// contract rejects a vote, because this node has already voted in the current epoch.
+var ErrClusterRootQCAlreadyVoted = errors.New("already voted for the root cluster qc")
+
 // ClusterRootQCVoter is responsible for submitting a vote to the cluster QC
 // contract to coordinate generation of a valid root quorum certificate for the
 // next epoch.
@@ -29,7 +34,9 @@ type QCContractClient interface {
 	// SubmitVote submits the given vote to the cluster QC aggregator smart
 	// contract. This function returns only once the transaction has been
 	// processed by the network. An error is returned if the transaction has
-	// failed and should be re-submitted.
+	// failed and should be re-submitted, unless the contract rejected the vote
+	// because we have already voted, in which case ErrClusterRootQCAlreadyVoted
+	// is returned.
 	SubmitVote(ctx context.Context, vote *model.Vote) error
 
 	// Voted returns true if we have successfully submitted a vote to the
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -28,6 +29,10 @@ const (
 	// TransactionStatusRetryTimeout is the time after which the status of a
 	// transaction is checked again
 	TransactionStatusRetryTimeout = 1 * time.Second
+
+	// alreadyVotedErrorMessage is the message of the failed precondition of the
+	// cluster QC aggregator smart contract which rejects a second vote of a node.
+	alreadyVotedErrorMessage = "Vote must not have been cast already"
 )
 
 // QCContractClient is a client to the Quorum Certificate contract. Allows the client to
@@ -66,7 +71,9 @@ func NewQCContractClient(
 // SubmitVote submits the given vote to the cluster QC aggregator smart
 // contract. This function returns only once the transaction has been
 // processed by the network. An error is returned if the transaction has
-// failed and should be re-submitted.
+// failed and should be re-submitted, unless the contract rejected the vote
+// because we have already voted, in which case module.ErrClusterRootQCAlreadyVoted
+// is returned.
 func (c *QCContractClient) SubmitVote(ctx context.Context, vote *model.Vote) error {
 
 	// time method was invoked
@@ -133,6 +140,9 @@ func (c *QCContractClient) SubmitVote(ctx context.Context, vote *model.Vote) err
 
 	err = c.WaitForSealed(ctx, txID, started)
 	if err != nil {
+		if strings.Contains(err.Error(), alreadyVotedErrorMessage) {
+			return fmt.Errorf("vote was rejected: %w", module.ErrClusterRootQCAlreadyVoted)
+		}
 		return fmt.Errorf("failed to wait for transaction seal: %w", err)
 	}
 
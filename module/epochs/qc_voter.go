@@ -2,14 +2,11 @@ package epochs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
-	"github.com/onflow/flow-go/module/retrymiddleware"
-
-	"github.com/sethvargo/go-retry"
-
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/consensus/hotstuff"
@@ -18,6 +15,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	clusterstate "github.com/onflow/flow-go/state/cluster"
 	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/utils/retry"
 )
 
 const (
@@ -25,6 +23,9 @@ const (
 	// requests - increases exponentially for subsequent retries
 	retryDuration = time.Second
 
+	// retryMultiplier is the factor by which the duration to wait between retries grows
+	retryMultiplier = 2
+
 	// update qc contract client after 2 consecutive failures
 	retryMaxConsecutiveFailures = 2
 
@@ -109,35 +110,33 @@ func (voter *RootQCVoter) Vote(ctx context.Context, epoch protocol.Epoch) error
 
 	// this backoff configuration will never terminate on its own, but the
 	// request logic will exit when we exit the EpochSetup phase
-	expRetry, err := retry.NewExponential(retryDuration)
-	if err != nil {
-		log.Fatal().Err(err).Msg("create retry mechanism")
-	}
-	maxedRetry := retry.WithCappedDuration(retryDurationMax, expRetry)
-	withJitter := retry.WithJitterPercent(retryJitterPercent, maxedRetry)
+	policy := retry.WithJitter(retryJitterPercent, retry.Exponential(retryDuration, retryMultiplier, retryDurationMax))
 
 	clientIndex, qcContractClient := voter.getInitialContractClient()
-	onMaxConsecutiveRetries := func(totalAttempts int) {
-		voter.updateContractClient(clientIndex)
-		log.Warn().Msgf("retrying on attempt (%d) with fallback access node at index (%d)", totalAttempts, clientIndex)
+	onFailure := func(attempt uint, _ error, _ time.Duration) {
+		// fall back to the next contract client after consecutive failures
+		if attempt%retryMaxConsecutiveFailures != 0 {
+			return
+		}
+		clientIndex, qcContractClient = voter.updateContractClient(clientIndex)
+		log.Warn().Msgf("retrying on attempt (%d) with fallback access node at index (%d)", attempt+1, clientIndex)
 	}
-	afterConsecutiveRetries := retrymiddleware.AfterConsecutiveFailures(retryMaxConsecutiveFailures, withJitter, onMaxConsecutiveRetries)
 
-	err = retry.Do(ctx, afterConsecutiveRetries, func(ctx context.Context) error {
+	err = retry.Do(ctx, policy, func(ctx context.Context) error {
 		// check that we're still in the setup phase, if we're not we can't
 		// submit a vote anyway and must exit this process
 		phase, err := voter.state.Final().Phase()
 		if err != nil {
 			log.Error().Err(err).Msg("could not get current phase")
 		} else if phase != flow.EpochPhaseSetup {
-			return fmt.Errorf("could not submit vote - no longer in setup phase")
+			return retry.Permanent(fmt.Errorf("could not submit vote - no longer in setup phase"))
 		}
 
 		// check whether we've already voted, if we have we can exit early
 		voted, err := qcContractClient.Voted(ctx)
 		if err != nil {
 			log.Error().Err(err).Msg("could not check vote status")
-			return retry.RetryableError(err)
+			return err
 		} else if voted {
 			log.Info().Msg("already voted - exiting QC vote process...")
 			// update our last successful client index for future calls
@@ -149,9 +148,15 @@ func (voter *RootQCVoter) Vote(ctx context.Context, epoch protocol.Epoch) error
 		// either succeeded or we are able to retry
 		log.Info().Msg("submitting vote...")
 		err = qcContractClient.SubmitVote(ctx, vote)
+		if errors.Is(err, module.ErrClusterRootQCAlreadyVoted) {
+			// a previous submission has succeeded, which is as good as succeeding now
+			log.Info().Msg("vote rejected as already voted - exiting QC vote process...")
+			voter.updateLastSuccessfulClient(clientIndex)
+			return nil
+		}
 		if err != nil {
 			log.Error().Err(err).Msg("could not submit vote - retrying...")
-			return retry.RetryableError(err)
+			return err
 		}
 
 		log.Info().Msg("successfully submitted vote - exiting QC vote process...")
@@ -159,7 +164,7 @@ func (voter *RootQCVoter) Vote(ctx context.Context, epoch protocol.Epoch) error
 		// update our last successful client index for future calls
 		voter.updateLastSuccessfulClient(clientIndex)
 		return nil
-	})
+	}, retry.WithObserver(onFailure))
 
 	return err
 }
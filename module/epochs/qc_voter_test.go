@@ -123,3 +123,31 @@ func (suite *Suite) TestVoting() {
 	err := suite.voter.Vote(context.Background(), suite.epoch)
 	suite.Assert().Nil(err)
 }
+
+// should fall back to the next contract client after consecutive failures
+func (suite *Suite) TestFallbackContractClient() {
+	failing := new(module.QCContractClient)
+	failing.On("Voted", mock.Anything).Return(false, fmt.Errorf("unavailable"))
+
+	voter := epochs.NewRootQCVoter(zerolog.New(ioutil.Discard), suite.local, suite.signer, suite.state, []flowmodule.QCContractClient{failing, suite.client})
+	err := voter.Vote(context.Background(), suite.epoch)
+	suite.Require().NoError(err)
+
+	failing.AssertNumberOfCalls(suite.T(), "Voted", 2)
+	failing.AssertNotCalled(suite.T(), "SubmitVote", mock.Anything, mock.Anything)
+	suite.client.AssertCalled(suite.T(), "SubmitVote", mock.Anything, mock.Anything)
+}
+
+// should succeed and exit if the contract rejects the vote because we've already voted
+func (suite *Suite) TestVoteRejectedAsAlreadyVoted() {
+	rejecting := new(module.QCContractClient)
+	rejecting.On("Voted", mock.Anything).Return(false, nil)
+	rejecting.On("SubmitVote", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("vote was rejected: %w", flowmodule.ErrClusterRootQCAlreadyVoted)).
+		Once()
+
+	voter := epochs.NewRootQCVoter(zerolog.New(ioutil.Discard), suite.local, suite.signer, suite.state, []flowmodule.QCContractClient{rejecting})
+	err := voter.Vote(context.Background(), suite.epoch)
+	suite.Require().NoError(err)
+	rejecting.AssertExpectations(suite.T())
+}
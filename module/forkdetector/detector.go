@@ -0,0 +1,170 @@
+package forkdetector
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+)
+
+// resultGroup identifies the results, which have to agree unless execution forked: the
+// results for the same block, building on the same previous result.
+type resultGroup struct {
+	blockID          flow.Identifier
+	previousResultID flow.Identifier
+}
+
+// groupResults are the observed results of a group, with the executors of each result.
+type groupResults struct {
+	height    uint64                                  // height of the executed block, for pruning
+	executors map[flow.Identifier]flow.IdentifierList // executors by result ID
+}
+
+// Detector detects execution forks from the receipts admitted by the matching engine. It
+// groups the results by block and previous result; any two different results within a group
+// are a conflict. Conflicts are persisted together with the executors of both results, and
+// each conflict is only alerted once, even if it is observed repeatedly or after a restart.
+//
+// Detector is safe for concurrent use.
+type Detector struct {
+	log       zerolog.Logger
+	metrics   module.ConsensusMetrics
+	conflicts storage.ExecutionResultConflicts
+
+	mu      sync.Mutex
+	results map[resultGroup]*groupResults
+}
+
+var _ module.ReceiptConsumer = (*Detector)(nil)
+
+// NewDetector creates a new execution fork detector, which persists the detected conflicts
+// to the given storage.
+func NewDetector(log zerolog.Logger, metrics module.ConsensusMetrics, conflicts storage.ExecutionResultConflicts) *Detector {
+	return &Detector{
+		log:       log.With().Str("module", "fork_detector").Logger(),
+		metrics:   metrics,
+		conflicts: conflicts,
+		results:   make(map[resultGroup]*groupResults),
+	}
+}
+
+// OnReceiptAdmitted records the result of the receipt, and records a conflict with every
+// different result for the same block and previous result.
+func (d *Detector) OnReceiptAdmitted(receipt *flow.ExecutionReceipt, executedBlock *flow.Header) {
+	result := &receipt.ExecutionResult
+	resultID := result.ID()
+	group := resultGroup{
+		blockID:          result.BlockID,
+		previousResultID: result.PreviousResultID,
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	results, ok := d.results[group]
+	if !ok {
+		results = &groupResults{
+			height:    executedBlock.Height,
+			executors: make(map[flow.Identifier]flow.IdentifierList),
+		}
+		d.results[group] = results
+	}
+	for _, executorID := range results.executors[resultID] {
+		if executorID == receipt.ExecutorID {
+			// repeated observation of the same receipt
+			return
+		}
+	}
+	results.executors[resultID] = append(results.executors[resultID], receipt.ExecutorID)
+
+	for conflictingID, conflictingExecutors := range results.executors {
+		if conflictingID == resultID {
+			continue
+		}
+		conflict := flow.NewExecutionResultConflict(group.blockID, group.previousResultID, resultID, conflictingID)
+		for _, executorID := range results.executors[resultID] {
+			conflict.AddExecutor(resultID, executorID)
+		}
+		for _, executorID := range conflictingExecutors {
+			conflict.AddExecutor(conflictingID, executorID)
+		}
+
+		err := d.record(conflict)
+		if err != nil {
+			d.log.Error().Err(err).
+				Hex("block_id", group.blockID[:]).
+				Hex("result_id", resultID[:]).
+				Hex("conflicting_result_id", conflictingID[:]).
+				Msg("could not record execution result conflict")
+		}
+	}
+}
+
+// record persists the conflict. If the conflict is already stored, the executors are
+// merged with the stored executors instead, and the conflict is not alerted again.
+func (d *Detector) record(conflict *flow.ExecutionResultConflict) error {
+	err := d.conflicts.Store(conflict)
+	if err == nil {
+		d.metrics.ExecutionForkDetected()
+		d.log.Error().
+			Hex("block_id", conflict.BlockID[:]).
+			Hex("previous_result_id", conflict.PreviousResultID[:]).
+			Hex("result_id_1", conflict.ResultIDs[0][:]).
+			Hex("result_id_2", conflict.ResultIDs[1][:]).
+			Str("executors_1", fmt.Sprint(conflict.ExecutorIDs[0])).
+			Str("executors_2", fmt.Sprint(conflict.ExecutorIDs[1])).
+			Msg("execution fork detected: conflicting execution results for the same block and previous result")
+		return nil
+	}
+	if !errors.Is(err, storage.ErrAlreadyExists) {
+		return fmt.Errorf("could not store conflict: %w", err)
+	}
+
+	stored, err := d.conflicts.ByResultIDs(conflict.BlockID, conflict.ResultIDs[0], conflict.ResultIDs[1])
+	if err != nil {
+		return fmt.Errorf("could not retrieve stored conflict: %w", err)
+	}
+	updated := false
+	for i, resultID := range conflict.ResultIDs {
+		for _, executorID := range conflict.ExecutorIDs[i] {
+			if stored.AddExecutor(resultID, executorID) {
+				updated = true
+			}
+		}
+	}
+	if !updated {
+		return nil
+	}
+	err = d.conflicts.Update(stored)
+	if err != nil {
+		return fmt.Errorf("could not update stored conflict: %w", err)
+	}
+	return nil
+}
+
+// PruneUpToHeight drops the observed results for blocks up to the given sealed height.
+// Persisted conflicts are kept.
+func (d *Detector) PruneUpToHeight(height uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for group, results := range d.results {
+		if results.height <= height {
+			delete(d.results, group)
+		}
+	}
+}
+
+// Conflicts returns the detected conflicts between execution results for the given block.
+func (d *Detector) Conflicts(blockID flow.Identifier) ([]*flow.ExecutionResultConflict, error) {
+	conflicts, err := d.conflicts.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get conflicts: %w", err)
+	}
+	return conflicts, nil
+}
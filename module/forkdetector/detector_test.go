@@ -0,0 +1,133 @@
+package forkdetector
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// resultFixture returns a result for the given block, building on the given previous result.
+func resultFixture(block *flow.Header, previousResultID flow.Identifier) *flow.ExecutionResult {
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(block.ID()))
+	result.PreviousResultID = previousResultID
+	return result
+}
+
+// receiptsWithResult returns receipts of the given executors for the given result.
+func receiptsWithResult(result *flow.ExecutionResult, executorIDs ...flow.Identifier) []*flow.ExecutionReceipt {
+	receipts := make([]*flow.ExecutionReceipt, 0, len(executorIDs))
+	for _, executorID := range executorIDs {
+		receipts = append(receipts, unittest.ExecutionReceiptFixture(
+			unittest.WithExecutorID(executorID),
+			unittest.WithResult(result),
+		))
+	}
+	return receipts
+}
+
+// TestDetectConflict tests that two disagreeing receipts, followed by a third receipt agreeing
+// with one of them, are recorded as exactly one conflict with the executors of both results.
+func TestDetectConflict(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := new(mockmodule.ConsensusMetrics)
+		metrics.On("ExecutionForkDetected").Once()
+		detector := NewDetector(unittest.Logger(), metrics, bstorage.NewExecutionResultConflicts(db))
+
+		block := unittest.BlockHeaderFixture()
+		previousResultID := unittest.IdentifierFixture()
+		result1 := resultFixture(&block, previousResultID)
+		result2 := resultFixture(&block, previousResultID)
+		executor1, executor2, executor3 := unittest.IdentifierFixture(), unittest.IdentifierFixture(), unittest.IdentifierFixture()
+
+		detector.OnReceiptAdmitted(receiptsWithResult(result1, executor1)[0], &block)
+		detector.OnReceiptAdmitted(receiptsWithResult(result2, executor2)[0], &block)
+		detector.OnReceiptAdmitted(receiptsWithResult(result1, executor3)[0], &block)
+		metrics.AssertExpectations(t)
+
+		conflicts, err := detector.Conflicts(block.ID())
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		conflict := conflicts[0]
+		assert.Equal(t, block.ID(), conflict.BlockID)
+		assert.Equal(t, previousResultID, conflict.PreviousResultID)
+		assert.ElementsMatch(t, []flow.Identifier{result1.ID(), result2.ID()}, conflict.ResultIDs[:])
+		for i, resultID := range conflict.ResultIDs {
+			if resultID == result1.ID() {
+				assert.ElementsMatch(t, flow.IdentifierList{executor1, executor3}, conflict.ExecutorIDs[i])
+			} else {
+				assert.ElementsMatch(t, flow.IdentifierList{executor2}, conflict.ExecutorIDs[i])
+			}
+		}
+	})
+}
+
+// TestRepeatedObservations tests that conflicts are only alerted once, when the same receipts
+// are observed repeatedly, also after a restart.
+func TestRepeatedObservations(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		conflicts := bstorage.NewExecutionResultConflicts(db)
+		metrics := new(mockmodule.ConsensusMetrics)
+		metrics.On("ExecutionForkDetected").Once()
+
+		block := unittest.BlockHeaderFixture()
+		previousResultID := unittest.IdentifierFixture()
+		result1 := resultFixture(&block, previousResultID)
+		result2 := resultFixture(&block, previousResultID)
+		receipts := append(
+			receiptsWithResult(result1, unittest.IdentifierFixture()),
+			receiptsWithResult(result2, unittest.IdentifierFixture())...,
+		)
+
+		// the same detector observes the receipts twice, a restarted detector observes them again
+		detector := NewDetector(unittest.Logger(), metrics, conflicts)
+		for i := 0; i < 2; i++ {
+			for _, receipt := range receipts {
+				detector.OnReceiptAdmitted(receipt, &block)
+			}
+		}
+		detector = NewDetector(unittest.Logger(), metrics, conflicts)
+		for _, receipt := range receipts {
+			detector.OnReceiptAdmitted(receipt, &block)
+		}
+		metrics.AssertExpectations(t)
+
+		stored, err := detector.Conflicts(block.ID())
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Len(t, stored[0].ExecutorIDs[0], 1)
+		assert.Len(t, stored[0].ExecutorIDs[1], 1)
+	})
+}
+
+// TestNoConflict tests that results for the same block are not in conflict, if they build on
+// different previous results, and that no conflict is detected for pruned blocks.
+func TestNoConflict(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := new(mockmodule.ConsensusMetrics)
+		detector := NewDetector(unittest.Logger(), metrics, bstorage.NewExecutionResultConflicts(db))
+
+		// the results build on different forks, which are detected for their own blocks
+		block := unittest.BlockHeaderFixture()
+		result1 := resultFixture(&block, unittest.IdentifierFixture())
+		result2 := resultFixture(&block, unittest.IdentifierFixture())
+		detector.OnReceiptAdmitted(receiptsWithResult(result1, unittest.IdentifierFixture())[0], &block)
+		detector.OnReceiptAdmitted(receiptsWithResult(result2, unittest.IdentifierFixture())[0], &block)
+
+		// the results observed before the block was sealed are dropped
+		result3 := resultFixture(&block, result1.PreviousResultID)
+		detector.PruneUpToHeight(block.Height)
+		detector.OnReceiptAdmitted(receiptsWithResult(result3, unittest.IdentifierFixture())[0], &block)
+
+		conflicts, err := detector.Conflicts(block.ID())
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		metrics.AssertNotCalled(t, "ExecutionForkDetected")
+	})
+}
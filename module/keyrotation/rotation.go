@@ -0,0 +1,178 @@
+// Package keyrotation implements the rotation of an account key: the new key is
+// added to the account and the old key is revoked in a single transaction,
+// which is signed with the old key.
+package keyrotation
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/rs/zerolog"
+
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/epochs"
+)
+
+// TransactionSubmissionTimeout is the time after which we return an error.
+const TransactionSubmissionTimeout = 5 * time.Minute
+
+// rotateKeyTemplate adds the new key and revokes the old key within one transaction,
+// so the account is never left without a key, nor with both keys valid.
+const rotateKeyTemplate = `
+transaction(publicKey: String, revokeKeyIndex: Int) {
+	prepare(signer: AuthAccount) {
+		signer.addPublicKey(publicKey.decodeHex())
+		signer.removePublicKey(revokeKeyIndex)
+	}
+}
+`
+
+var (
+	// ErrNewKeyMissing is returned if the new key is not a valid key of the account
+	// after the rotation.
+	ErrNewKeyMissing = errors.New("new key is missing from the account")
+	// ErrOldKeyNotRevoked is returned if the old key is still valid after the rotation.
+	ErrOldKeyNotRevoked = errors.New("old key was not revoked")
+)
+
+// BuildKeyRotationTx builds the transaction, which adds the new key to the account and
+// revokes the old key. The old key is the proposal key of the transaction, hence its
+// sequence number must be up to date; the account pays for the transaction. The caller
+// has to set the reference block and sign the envelope with the old key.
+func BuildKeyRotationTx(account sdk.Address, oldKey, newKey *sdk.AccountKey) (*sdk.Transaction, error) {
+	if oldKey == nil || newKey == nil {
+		return nil, fmt.Errorf("old and new key must be given")
+	}
+	if oldKey.Revoked {
+		return nil, fmt.Errorf("old key %d is revoked already", oldKey.Index)
+	}
+	if newKey.PublicKey == nil {
+		return nil, fmt.Errorf("new key has no public key")
+	}
+	if newKey.PublicKey.Equals(oldKey.PublicKey) {
+		return nil, fmt.Errorf("new key must differ from old key")
+	}
+	// the old key is revoked, so the new key alone must be able to authorize the account
+	if newKey.Weight < sdk.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf("weight of new key (%d) is below the weight threshold (%d)", newKey.Weight, sdk.AccountKeyWeightThreshold)
+	}
+
+	tx := sdk.NewTransaction().
+		SetScript([]byte(rotateKeyTemplate)).
+		SetGasLimit(9999).
+		SetProposalKey(account, oldKey.Index, oldKey.SequenceNumber).
+		SetPayer(account).
+		AddAuthorizer(account)
+
+	publicKey, err := cadence.NewString(hex.EncodeToString(newKey.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("could not convert new key: %w", err)
+	}
+	err = tx.AddArgument(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not add new key to transaction: %w", err)
+	}
+	err = tx.AddArgument(cadence.NewInt(oldKey.Index))
+	if err != nil {
+		return nil, fmt.Errorf("could not add old key index to transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// RotateKey rotates the old key of the account to the new key: it submits the key rotation
+// transaction signed with the old key, waits for the transaction to be sealed, and checks
+// that the new key was added and the old key revoked. It returns the account after the
+// rotation. Returns ErrNewKeyMissing or ErrOldKeyNotRevoked if the transaction was sealed,
+// but the rotation did not take effect.
+func RotateKey(
+	ctx context.Context,
+	log zerolog.Logger,
+	client module.SDKClientWrapper,
+	account sdk.Address,
+	oldKey *sdk.AccountKey,
+	signer sdkcrypto.Signer,
+	newKey *sdk.AccountKey,
+) (*sdk.Account, error) {
+
+	started := time.Now()
+
+	// add a timeout to the context
+	ctx, cancel := context.WithTimeout(ctx, TransactionSubmissionTimeout)
+	defer cancel()
+
+	tx, err := BuildKeyRotationTx(account, oldKey, newKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not build key rotation transaction: %w", err)
+	}
+
+	// get latest finalized block to execute transaction
+	latestBlock, err := client.GetLatestBlock(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest block from node: %w", err)
+	}
+	tx.SetReferenceBlockID(latestBlock.ID)
+
+	err = tx.SignEnvelope(account, oldKey.Index, signer)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign transaction: %w", err)
+	}
+
+	base := epochs.NewBaseClient(log, client, account.Hex(), uint(oldKey.Index), signer, "")
+	txID, err := base.SendTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit transaction: %w", err)
+	}
+	err = base.WaitForSealed(ctx, txID, started)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction seal: %w", err)
+	}
+
+	updated, err := client.GetAccount(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("could not get account after key rotation: %w", err)
+	}
+	err = CheckRotated(updated, oldKey, newKey)
+	if err != nil {
+		return nil, fmt.Errorf("key rotation transaction %x did not take effect: %w", txID, err)
+	}
+
+	log.Info().
+		Str("account", account.Hex()).
+		Int("old_key_index", oldKey.Index).
+		Hex("tx_id", txID[:]).
+		Msg("rotated account key")
+
+	return updated, nil
+}
+
+// CheckRotated checks that the new key is a valid key of the account, and that the old key
+// is revoked. Returns ErrNewKeyMissing or ErrOldKeyNotRevoked otherwise.
+func CheckRotated(account *sdk.Account, oldKey, newKey *sdk.AccountKey) error {
+	added := false
+	for _, key := range account.Keys {
+		if !key.Revoked && key.PublicKey.Equals(newKey.PublicKey) {
+			added = true
+			break
+		}
+	}
+	if !added {
+		return ErrNewKeyMissing
+	}
+
+	if oldKey.Index >= len(account.Keys) {
+		return fmt.Errorf("%w: account has no key with index %d", ErrOldKeyNotRevoked, oldKey.Index)
+	}
+	if !account.Keys[oldKey.Index].Revoked {
+		return fmt.Errorf("%w: key %d is still valid", ErrOldKeyNotRevoked, oldKey.Index)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package keyrotation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// accountKeyFixture returns an account key with full weight and its signer.
+func accountKeyFixture(t *testing.T, index int) (*sdk.AccountKey, sdkcrypto.Signer) {
+	privateKey, err := sdkcrypto.GeneratePrivateKey(sdkcrypto.ECDSA_P256, unittest.SeedFixture(sdkcrypto.MinSeedLength))
+	require.NoError(t, err)
+	key := sdk.NewAccountKey().
+		FromPrivateKey(privateKey).
+		SetHashAlgo(sdkcrypto.SHA3_256).
+		SetWeight(sdk.AccountKeyWeightThreshold)
+	key.Index = index
+	return key, sdkcrypto.NewInMemorySigner(privateKey, key.HashAlgo)
+}
+
+// clientFixture returns a client, on which the key rotation transaction is sealed, and which
+// returns the accounts before and after the rotation.
+func clientFixture(before, after *sdk.Account) *mockmodule.SDKClientWrapper {
+	client := &mockmodule.SDKClientWrapper{}
+	client.On("GetLatestBlock", mock.Anything, false).Return(&sdk.Block{
+		BlockHeader: sdk.BlockHeader{ID: sdk.Identifier(unittest.IdentifierFixture())},
+	}, nil)
+	client.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	client.On("GetTransactionResult", mock.Anything, mock.Anything).Return(&sdk.TransactionResult{
+		Status: sdk.TransactionStatusSealed,
+	}, nil)
+	client.On("GetAccount", mock.Anything, before.Address).Return(after, nil).Once()
+	return client
+}
+
+func TestBuildKeyRotationTx(t *testing.T) {
+	address := unittest.RandomSDKAddressFixture()
+	oldKey, _ := accountKeyFixture(t, 0)
+	newKey, _ := accountKeyFixture(t, 1)
+
+	tx, err := BuildKeyRotationTx(address, oldKey, newKey)
+	require.NoError(t, err)
+	assert.Equal(t, address, tx.Payer)
+	assert.Equal(t, []sdk.Address{address}, tx.Authorizers)
+	assert.Equal(t, sdk.ProposalKey{Address: address, KeyIndex: 0, SequenceNumber: oldKey.SequenceNumber}, tx.ProposalKey)
+	assert.Len(t, tx.Arguments, 2)
+
+	t.Run("revoked old key", func(t *testing.T) {
+		revoked := *oldKey
+		revoked.Revoked = true
+		_, err := BuildKeyRotationTx(address, &revoked, newKey)
+		assert.Error(t, err)
+	})
+	t.Run("same key", func(t *testing.T) {
+		_, err := BuildKeyRotationTx(address, oldKey, oldKey)
+		assert.Error(t, err)
+	})
+	t.Run("insufficient weight", func(t *testing.T) {
+		light := *newKey
+		light.Weight = sdk.AccountKeyWeightThreshold - 1
+		_, err := BuildKeyRotationTx(address, oldKey, &light)
+		assert.Error(t, err)
+	})
+}
+
+func TestRotateKey(t *testing.T) {
+	address := unittest.RandomSDKAddressFixture()
+	oldKey, signer := accountKeyFixture(t, 0)
+	newKey, _ := accountKeyFixture(t, 1)
+
+	before := &sdk.Account{Address: address, Keys: []*sdk.AccountKey{oldKey}}
+
+	t.Run("rotated", func(t *testing.T) {
+		revoked := *oldKey
+		revoked.Revoked = true
+		after := &sdk.Account{Address: address, Keys: []*sdk.AccountKey{&revoked, newKey}}
+		client := clientFixture(before, after)
+
+		account, err := RotateKey(context.Background(), unittest.Logger(), client, address, oldKey, signer, newKey)
+		require.NoError(t, err)
+		assert.Equal(t, after, account)
+
+		// the submitted transaction is signed by the old key
+		tx := client.Calls[1].Arguments.Get(1).(sdk.Transaction)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, oldKey.Index, tx.EnvelopeSignatures[0].KeyIndex)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("old key not revoked", func(t *testing.T) {
+		after := &sdk.Account{Address: address, Keys: []*sdk.AccountKey{oldKey, newKey}}
+		client := clientFixture(before, after)
+
+		_, err := RotateKey(context.Background(), unittest.Logger(), client, address, oldKey, signer, newKey)
+		assert.True(t, errors.Is(err, ErrOldKeyNotRevoked))
+	})
+
+	t.Run("new key missing", func(t *testing.T) {
+		revoked := *oldKey
+		revoked.Revoked = true
+		after := &sdk.Account{Address: address, Keys: []*sdk.AccountKey{&revoked}}
+		client := clientFixture(before, after)
+
+		_, err := RotateKey(context.Background(), unittest.Logger(), client, address, oldKey, signer, newKey)
+		assert.True(t, errors.Is(err, ErrNewKeyMissing))
+	})
+
+	t.Run("transaction failed", func(t *testing.T) {
+		client := &mockmodule.SDKClientWrapper{}
+		client.On("GetLatestBlock", mock.Anything, false).Return(&sdk.Block{}, nil)
+		client.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+		client.On("GetTransactionResult", mock.Anything, mock.Anything).Return(&sdk.TransactionResult{
+			Status: sdk.TransactionStatusSealed,
+			Error:  errors.New("cannot remove key"),
+		}, nil)
+
+		_, err := RotateKey(context.Background(), unittest.Logger(), client, address, oldKey, signer, newKey)
+		assert.Error(t, err)
+		client.AssertNotCalled(t, "GetAccount", mock.Anything, mock.Anything)
+	})
+}
@@ -6,3 +6,21 @@ import "github.com/onflow/flow-go/model/flow"
 // one of its elements. The callbacks are executed from within the thread
 // that serves the mempool. Implementations should be non-blocking.
 type OnEjection func(flow.Entity)
+
+// Inspectable is a memory pool, whose content can be inspected for debugging.
+type Inspectable interface {
+	// Snapshot returns up to limit entities of the pool, which satisfy the filter. If limit
+	// is zero or negative, all entities satisfying the filter are returned; if filter is nil,
+	// any entity satisfies it. The returned entities must not be modified.
+	Snapshot(limit int, filter func(entity flow.Entity) bool) []flow.Entity
+
+	// Size returns the number of entities in the pool.
+	Size() uint
+
+	// Capacity returns the maximum number of entities in the pool, beyond which entities
+	// are ejected.
+	Capacity() uint
+
+	// EjectionCount returns the number of entities ejected from the pool since its creation.
+	EjectionCount() uint64
+}
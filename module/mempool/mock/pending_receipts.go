@@ -43,6 +43,20 @@ func (_m *PendingReceipts) ByPreviousResultID(previousReusltID flow.Identifier)
 	return r0
 }
 
+// Limit provides a mock function with given fields:
+func (_m *PendingReceipts) Limit() uint {
+	ret := _m.Called()
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
 // PruneUpToHeight provides a mock function with given fields: height
 func (_m *PendingReceipts) PruneUpToHeight(height uint64) error {
 	ret := _m.Called(height)
@@ -70,3 +84,17 @@ func (_m *PendingReceipts) Rem(receiptID flow.Identifier) bool {
 
 	return r0
 }
+
+// Size provides a mock function with given fields:
+func (_m *PendingReceipts) Size() uint {
+	ret := _m.Called()
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
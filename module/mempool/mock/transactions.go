@@ -4,6 +4,7 @@ package mempool
 
 import (
 	flow "github.com/onflow/flow-go/model/flow"
+	mempool "github.com/onflow/flow-go/module/mempool"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -101,6 +102,22 @@ func (_m *Transactions) Hash() flow.Identifier {
 	return r0
 }
 
+// Ranked provides a mock function with given fields:
+func (_m *Transactions) Ranked() mempool.TransactionIterator {
+	ret := _m.Called()
+
+	var r0 mempool.TransactionIterator
+	if rf, ok := ret.Get(0).(func() mempool.TransactionIterator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mempool.TransactionIterator)
+		}
+	}
+
+	return r0
+}
+
 // Rem provides a mock function with given fields: txID
 func (_m *Transactions) Rem(txID flow.Identifier) bool {
 	ret := _m.Called(txID)
@@ -18,6 +18,13 @@ type PendingReceipts interface {
 	// matches the given result id
 	ByPreviousResultID(previousReusltID flow.Identifier) []*flow.ExecutionReceipt
 
+	// Size returns the number of pending receipts.
+	Size() uint
+
+	// Limit returns the maximum number of pending receipts, beyond which
+	// receipts are ejected.
+	Limit() uint
+
 	// PruneUpToHeight remove all receipts for blocks whose height is strictly
 	// smaller that height. Note: receipts for blocks at height are retained.
 	// After pruning, receipts below for blocks below the given height are dropped.
@@ -7,13 +7,16 @@ import (
 	"sync"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
 	_ "github.com/onflow/flow-go/utils/binstat"
 )
 
 // Backdata implements a generic memory pool backed by a Go map.
+// It notifies its secondary indices of every entity it adds or removes.
 type Backdata struct {
 	entities map[flow.Identifier]flow.Entity
+	indices  []Index
 }
 
 func NewBackdata() Backdata {
@@ -36,6 +39,9 @@ func (b *Backdata) Add(entityID flow.Identifier, entity flow.Entity) bool {
 		return false
 	}
 	b.entities[entityID] = entity
+	for _, index := range b.indices {
+		index.OnAdd(entityID, entity)
+	}
 	return true
 }
 
@@ -46,6 +52,9 @@ func (b *Backdata) Rem(entityID flow.Identifier) (flow.Entity, bool) {
 		return nil, false
 	}
 	delete(b.entities, entityID)
+	for _, index := range b.indices {
+		index.OnRemove(entityID, entity)
+	}
 	return entity, true
 }
 
@@ -60,7 +69,15 @@ func (b *Backdata) Adjust(entityID flow.Identifier, f func(flow.Entity) flow.Ent
 	newentityID := newentity.ID()
 
 	delete(b.entities, entityID)
+	replaced, exists := b.entities[newentityID]
 	b.entities[newentityID] = newentity
+	for _, index := range b.indices {
+		index.OnRemove(entityID, entity)
+		if exists {
+			index.OnRemove(newentityID, replaced)
+		}
+		index.OnAdd(newentityID, newentity)
+	}
 	return newentity, true
 }
 
@@ -90,6 +107,9 @@ func (b *Backdata) All() []flow.Entity {
 // Clear removes all entities from the pool.
 func (b *Backdata) Clear() {
 	b.entities = make(map[flow.Identifier]flow.Entity)
+	for _, index := range b.indices {
+		index.OnClear()
+	}
 }
 
 // Hash will use a merkle root hash to hash all items.
@@ -105,6 +125,9 @@ type Backend struct {
 	batchEject         BatchEjectFunc
 	eject              EjectFunc
 	ejectionCallbacks  []mempool.OnEjection
+	metrics            module.MempoolMetrics
+	resource           string
+	ejections          uint64 // number of entities ejected since creation
 }
 
 // NewBackend creates a new memory pool backend.
@@ -116,6 +139,7 @@ func NewBackend(options ...OptionFunc) *Backend {
 		batchEject:         EjectTrueRandomFast,
 		eject:              nil,
 		ejectionCallbacks:  nil,
+		metrics:            nil,
 	}
 	for _, option := range options {
 		option(&b)
@@ -151,6 +175,7 @@ func (b *Backend) Add(entity flow.Entity) bool {
 	defer b.Unlock()
 	added := b.Backdata.Add(entityID, entity)
 	b.reduce()
+	b.reportSize()
 	return added
 }
 
@@ -164,6 +189,7 @@ func (b *Backend) Rem(entityID flow.Identifier) bool {
 	//defer binstat.Leave(bs2)
 	defer b.Unlock()
 	_, removed := b.Backdata.Rem(entityID)
+	b.reportSize()
 	return removed
 }
 
@@ -205,6 +231,7 @@ func (b *Backend) Run(f func(backdata map[flow.Identifier]flow.Entity) error) er
 	defer b.Unlock()
 	err := f(b.Backdata.entities)
 	b.reduce()
+	b.reportSize()
 	return err
 }
 
@@ -221,11 +248,74 @@ func (b *Backend) Size() uint {
 	return size
 }
 
+// Capacity returns the maximum number of items allowed in the backend. It is the same as Limit,
+// and implements the mempool.Inspectable interface.
+func (b *Backend) Capacity() uint {
+	return b.Limit()
+}
+
+// EjectionCount returns the number of items ejected from the backend since its creation.
+func (b *Backend) EjectionCount() uint64 {
+	b.RLock()
+	defer b.RUnlock()
+	return b.ejections
+}
+
+// Snapshot returns up to limit entities of the pool, which satisfy the filter. If limit is
+// zero or negative, all entities satisfying the filter are returned; if filter is nil, any
+// entity satisfies it. The read lock is only held while collecting the entities, hence callers
+// can inspect or serialize the returned entities without blocking the pool. As entities are
+// returned without copying them, they must not be modified.
+func (b *Backend) Snapshot(limit int, filter func(entity flow.Entity) bool) []flow.Entity {
+	b.RLock()
+	defer b.RUnlock()
+
+	var entities []flow.Entity
+	for _, entity := range b.Backdata.entities {
+		if limit > 0 && len(entities) >= limit {
+			break
+		}
+		if filter != nil && !filter(entity) {
+			continue
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
 // Limit returns the maximum number of items allowed in the backend.
 func (b *Backend) Limit() uint {
+	b.RLock()
+	defer b.RUnlock()
 	return b.guaranteedCapacity
 }
 
+// SetLimit adjusts the maximum number of items allowed in the backend. If the backend holds more
+// items than the new limit, items are ejected according to the ejection policy of the backend.
+func (b *Backend) SetLimit(limit uint) {
+	b.Lock()
+	defer b.Unlock()
+	b.guaranteedCapacity = limit
+	b.reduce()
+	b.reportSize()
+}
+
+// Lookup returns the entities indexed under the given key by the given index, which must be one of
+// the indices of the backend.
+func (b *Backend) Lookup(index *IdentifierIndex, key flow.Identifier) []flow.Entity {
+	b.RLock()
+	defer b.RUnlock()
+	entityIDs := index.Lookup(key)
+	entities := make([]flow.Entity, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		entity, ok := b.Backdata.entities[entityID]
+		if ok {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
 // All returns all entities from the pool.
 func (b *Backend) All() []flow.Entity {
 	//bs1 := binstat.EnterTime(binstat.BinStdmap + ".r_lock.(Backend)All")
@@ -248,6 +338,7 @@ func (b *Backend) Clear() {
 	//defer binstat.Leave(bs2)
 	defer b.Unlock()
 	b.Backdata.Clear()
+	b.reportSize()
 }
 
 // Hash will use a merkle root hash to hash all items.
@@ -281,17 +372,45 @@ func (b *Backend) reduce() {
 	//bs := binstat.EnterTime(binstat.BinStdmap + ".??lock.(Backend)reduce")
 	//defer binstat.Leave(bs)
 
-	// we keep reducing the cache size until we are at limit again
-	// this was a loop, but the loop is now in EjectTrueRandomFast()
-	// the ejections are batched, so this call to eject() may not actually
+	if len(b.entities) <= int(b.guaranteedCapacity) {
+		return
+	}
+	size := len(b.entities)
+
+	// the batch ejection was a loop, but the loop is now in EjectTrueRandomFast()
+	// the ejections are batched, so this call to batchEject() may not actually
 	// do anything until the batch threshold is reached (currently 128)
-	if len(b.entities) > int(b.guaranteedCapacity) {
-		// get the key from the eject function
-		// we don't do anything if there is an error
-		if b.batchEject != nil {
-			_ = b.batchEject(b)
-		} else {
-			_, _, _ = b.eject(b)
+	if b.batchEject != nil {
+		_ = b.batchEject(b)
+	} else {
+		// we keep reducing the cache size until we are at limit again
+		for len(b.entities) > int(b.guaranteedCapacity) {
+			entityID, entity, found := b.eject(b)
+			if !found {
+				break
+			}
+			_, removed := b.Backdata.Rem(entityID)
+			if !removed {
+				// the ejector picked an entity which is not in the backend, we stop
+				// here rather than to loop forever
+				break
+			}
+			for _, callback := range b.ejectionCallbacks {
+				callback(entity)
+			}
 		}
 	}
+
+	ejected := size - len(b.entities)
+	b.ejections += uint64(ejected)
+	if ejected > 0 && b.metrics != nil {
+		b.metrics.MempoolEjections(b.resource, uint(ejected))
+	}
+}
+
+// reportSize reports the size of the backend to the mempool metrics, if configured.
+func (b *Backend) reportSize() {
+	if b.metrics != nil {
+		b.metrics.MempoolEntries(b.resource, uint(len(b.entities)))
+	}
 }
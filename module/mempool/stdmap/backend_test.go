@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -235,3 +236,123 @@ func addRandomEntities(t *testing.T, backend *Backend, num int) {
 	}
 	unittest.RequireReturnsBefore(t, wg.Wait, 1*time.Second, "failed to add elements in time")
 }
+
+// TestBackend_SetLimit verifies that lowering the limit of the Backend ejects the entities beyond the
+// new limit, and that raising it allows the Backend to grow again.
+func TestBackend_SetLimit(t *testing.T) {
+	ejected := uint64(0)
+	pool := NewBackend(WithLimit(1000), WithEject(EjectTrueRandom))
+	pool.RegisterEjectionCallbacks(func(flow.Entity) {
+		atomic.AddUint64(&ejected, 1)
+	})
+	addRandomEntities(t, pool, 100)
+	require.Equal(t, uint(100), pool.Size())
+
+	pool.SetLimit(60)
+	assert.Equal(t, uint(60), pool.Limit())
+	assert.Equal(t, uint(60), pool.Size())
+	assert.Equal(t, uint64(40), atomic.LoadUint64(&ejected))
+
+	pool.SetLimit(80)
+	addRandomEntities(t, pool, 20)
+	assert.Equal(t, uint(80), pool.Size())
+	assert.Equal(t, uint64(40), atomic.LoadUint64(&ejected))
+}
+
+// TestBackend_EjectFunc verifies that the Backend removes the entities picked by a custom ejector
+// until it is within its limit again.
+func TestBackend_EjectFunc(t *testing.T) {
+	const limit = 10
+	ejector := NewLRUEjector()
+	pool := NewBackend(WithLimit(limit), WithEject(ejector.Eject))
+
+	items := make([]fake, 0, limit+5)
+	for i := 0; i < limit+5; i++ {
+		item := fake(fmt.Sprintf("item%d", i))
+		items = append(items, item)
+		ejector.Track(item.ID())
+		pool.Add(item)
+	}
+
+	require.Equal(t, uint(limit), pool.Size())
+	// the oldest entities are ejected first
+	for i, item := range items {
+		assert.Equal(t, i >= 5, pool.Has(item.ID()))
+	}
+}
+
+// TestBackend_Metrics verifies that the Backend reports its size and the number of ejected entities.
+func TestBackend_Metrics(t *testing.T) {
+	const resource = "test"
+	collector := &mockmodule.MempoolMetrics{}
+	pool := NewBackend(WithLimit(2), WithEject(EjectTrueRandom), WithMetrics(collector, resource))
+
+	collector.On("MempoolEntries", resource, uint(1)).Once()
+	collector.On("MempoolEntries", resource, uint(2)).Once()
+	pool.Add(fake("A"))
+	pool.Add(fake("B"))
+
+	// adding a third entity ejects one of the entities
+	collector.On("MempoolEjections", resource, uint(1)).Once()
+	collector.On("MempoolEntries", resource, uint(2)).Once()
+	pool.Add(fake("C"))
+
+	collector.On("MempoolEntries", resource, uint(0)).Once()
+	pool.Clear()
+
+	collector.AssertExpectations(t)
+}
+
+// TestBackend_Snapshot verifies that a snapshot of the Backend contains the entities satisfying the
+// filter, up to the given limit.
+func TestBackend_Snapshot(t *testing.T) {
+	pool := NewBackend()
+	for i := 0; i < 10; i++ {
+		pool.Add(fake(fmt.Sprintf("item%d", i)))
+	}
+	// entities with an even index
+	even := func(entity flow.Entity) bool {
+		item := entity.(fake)
+		return (item[len(item)-1]-'0')%2 == 0
+	}
+
+	t.Run("without limit and filter", func(t *testing.T) {
+		assert.ElementsMatch(t, pool.All(), pool.Snapshot(0, nil))
+	})
+
+	t.Run("with filter", func(t *testing.T) {
+		snapshot := pool.Snapshot(0, even)
+		assert.ElementsMatch(t, []flow.Entity{fake("item0"), fake("item2"), fake("item4"), fake("item6"), fake("item8")}, snapshot)
+	})
+
+	t.Run("with limit", func(t *testing.T) {
+		assert.Len(t, pool.Snapshot(3, nil), 3)
+		assert.Len(t, pool.Snapshot(20, nil), 10)
+	})
+
+	t.Run("with limit and filter", func(t *testing.T) {
+		snapshot := pool.Snapshot(2, even)
+		require.Len(t, snapshot, 2)
+		for _, entity := range snapshot {
+			assert.True(t, even(entity))
+		}
+	})
+
+	t.Run("without matches", func(t *testing.T) {
+		assert.Empty(t, pool.Snapshot(0, func(flow.Entity) bool { return false }))
+	})
+}
+
+// TestBackend_EjectionCount verifies that the Backend counts the ejected entities.
+func TestBackend_EjectionCount(t *testing.T) {
+	pool := NewBackend(WithLimit(5), WithEject(EjectTrueRandom))
+	assert.Equal(t, uint(5), pool.Capacity())
+
+	addRandomEntities(t, pool, 8)
+	assert.Equal(t, uint(5), pool.Size())
+	assert.Equal(t, uint64(3), pool.EjectionCount())
+
+	// removing entities is not an ejection
+	pool.Clear()
+	assert.Equal(t, uint64(3), pool.EjectionCount())
+}
@@ -19,9 +19,11 @@ type ChunkRequests struct {
 	*Backend
 }
 
-func NewChunkRequests(limit uint) *ChunkRequests {
+// NewChunkRequests creates a new memory pool for chunk requests. The options are applied to its
+// backend, e.g. WithMetrics to report its size to the mempool metrics.
+func NewChunkRequests(limit uint, opts ...OptionFunc) *ChunkRequests {
 	return &ChunkRequests{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend: NewBackend(append([]OptionFunc{WithLimit(limit)}, opts...)...),
 	}
 }
 
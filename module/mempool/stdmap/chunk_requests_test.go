@@ -14,6 +14,8 @@ import (
 	"github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -257,3 +259,19 @@ func TestAddingDuplicateChunkIDs(t *testing.T) {
 	require.False(t, ok)
 	require.Nil(t, locators)
 }
+
+// TestChunkRequests_Metrics evaluates that chunk requests report their size to the mempool metrics
+// as the configured resource.
+func TestChunkRequests_Metrics(t *testing.T) {
+	collector := &mockmodule.MempoolMetrics{}
+	requests := stdmap.NewChunkRequests(10, stdmap.WithMetrics(collector, metrics.ResourceChunkRequest))
+
+	request := unittest.ChunkDataPackRequestFixture()
+	collector.On("MempoolEntries", metrics.ResourceChunkRequest, uint(1)).Once()
+	require.True(t, requests.Add(request))
+
+	collector.On("MempoolEntries", metrics.ResourceChunkRequest, uint(0)).Once()
+	require.True(t, requests.Rem(request.ChunkID))
+
+	collector.AssertExpectations(t)
+}
@@ -13,9 +13,11 @@ type ChunkStatuses struct {
 	*Backend
 }
 
-func NewChunkStatuses(limit uint) *ChunkStatuses {
+// NewChunkStatuses creates a new memory pool for chunk statuses. The options are applied to its
+// backend, e.g. WithMetrics to report its size to the mempool metrics.
+func NewChunkStatuses(limit uint, opts ...OptionFunc) *ChunkStatuses {
 	return &ChunkStatuses{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend: NewBackend(append([]OptionFunc{WithLimit(limit)}, opts...)...),
 	}
 }
 
@@ -31,6 +31,8 @@ const overCapacityThreshold = 128
 //    concurrency (specifically, it locks the mempool during ejection).
 //  * The implementation should be non-blocking (though, it is allowed to
 //    take a bit of time; the mempool will just be locked during this time).
+// An EjectFunc only picks a single element to eject, which the Backend then removes and
+// notifies the callbacks of; it is called repeatedly until the mempool is within its capacity.
 type BatchEjectFunc func(b *Backend) bool
 type EjectFunc func(b *Backend) (flow.Identifier, flow.Entity, bool)
 
@@ -84,7 +86,7 @@ func EjectTrueRandomFast(b *Backend) bool {
 	i := 0                       // index into the entities map
 	for entityID, entity := range b.entities {
 		if i == next2Remove {
-			delete(b.entities, entityID) // remove entity
+			b.Backdata.Rem(entityID) // remove entity
 			for _, callback := range b.ejectionCallbacks {
 				callback(entity) // notify callback
 			}
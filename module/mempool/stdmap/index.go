@@ -0,0 +1,85 @@
+package stdmap
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Index is a secondary index over the entities of a backend. The backend keeps its indices up to date
+// by notifying them of every entity it adds or removes, including the entities it ejects. All
+// notifications are delivered while the backend is locked, hence implementations do not need to be
+// concurrency safe, but must be read while holding the backend's lock (e.g. through Backend.Lookup).
+//
+// Entities written to or deleted from the raw map exposed by Backend.Run bypass the indices.
+type Index interface {
+	// OnAdd is called after the entity with the given ID was added to the backend.
+	OnAdd(entityID flow.Identifier, entity flow.Entity)
+
+	// OnRemove is called after the entity with the given ID was removed from the backend.
+	OnRemove(entityID flow.Identifier, entity flow.Entity)
+
+	// OnClear is called after all entities were removed from the backend.
+	OnClear()
+}
+
+// IndexKeyFunc returns the key under which an entity is indexed.
+type IndexKeyFunc func(flow.Entity) flow.Identifier
+
+// IdentifierIndex indexes the entities of a backend by an identifier derived from each entity, such
+// as the ID of the execution result an approval or a receipt refers to. Multiple entities can be
+// indexed under the same key.
+type IdentifierIndex struct {
+	key     IndexKeyFunc
+	entries map[flow.Identifier]map[flow.Identifier]struct{}
+}
+
+var _ Index = (*IdentifierIndex)(nil)
+
+// NewIdentifierIndex creates a new index, which indexes each entity by the key returned by the given function.
+func NewIdentifierIndex(key IndexKeyFunc) *IdentifierIndex {
+	return &IdentifierIndex{
+		key:     key,
+		entries: make(map[flow.Identifier]map[flow.Identifier]struct{}),
+	}
+}
+
+func (i *IdentifierIndex) OnAdd(entityID flow.Identifier, entity flow.Entity) {
+	key := i.key(entity)
+	entityIDs, ok := i.entries[key]
+	if !ok {
+		entityIDs = make(map[flow.Identifier]struct{})
+		i.entries[key] = entityIDs
+	}
+	entityIDs[entityID] = struct{}{}
+}
+
+func (i *IdentifierIndex) OnRemove(entityID flow.Identifier, entity flow.Entity) {
+	key := i.key(entity)
+	entityIDs, ok := i.entries[key]
+	if !ok {
+		return
+	}
+	delete(entityIDs, entityID)
+	if len(entityIDs) == 0 {
+		delete(i.entries, key)
+	}
+}
+
+func (i *IdentifierIndex) OnClear() {
+	i.entries = make(map[flow.Identifier]map[flow.Identifier]struct{})
+}
+
+// Lookup returns the IDs of the entities indexed under the given key.
+// It is not concurrency safe, and must be called while holding the lock of the indexed backend.
+func (i *IdentifierIndex) Lookup(key flow.Identifier) []flow.Identifier {
+	entityIDs := i.entries[key]
+	result := make([]flow.Identifier, 0, len(entityIDs))
+	for entityID := range entityIDs {
+		result = append(result, entityID)
+	}
+	return result
+}
+
+// Size returns the number of distinct keys in the index.
+func (i *IdentifierIndex) Size() uint {
+	return uint(len(i.entries))
+}
@@ -0,0 +1,176 @@
+package stdmap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// byPrefix indexes fake entities by their first byte.
+func byPrefix(entity flow.Entity) flow.Identifier {
+	return flow.HashToID(entity.(fake)[:1])
+}
+
+// byLength indexes fake entities by their length.
+func byLength(entity flow.Entity) flow.Identifier {
+	return flow.HashToID([]byte{byte(len(entity.(fake)))})
+}
+
+// requireIndexed requires the index to reference exactly the entities of the backend.
+func requireIndexed(t *testing.T, pool *Backend, index *IdentifierIndex, key IndexKeyFunc) {
+	pool.RLock()
+	defer pool.RUnlock()
+
+	indexed := 0
+	for _, entityIDs := range index.entries {
+		require.NotEmpty(t, entityIDs, "index must not retain empty keys")
+		indexed += len(entityIDs)
+	}
+	require.Equal(t, len(pool.entities), indexed)
+	for entityID, entity := range pool.entities {
+		require.Contains(t, index.entries[key(entity)], entityID)
+	}
+}
+
+func TestIdentifierIndex(t *testing.T) {
+	prefixes := NewIdentifierIndex(byPrefix)
+	lengths := NewIdentifierIndex(byLength)
+	pool := NewBackend(WithIndex(prefixes, lengths))
+
+	ab, ac, b := fake("AB"), fake("AC"), fake("B")
+	pool.Add(ab)
+	pool.Add(ac)
+	pool.Add(b)
+
+	t.Run("lookup", func(t *testing.T) {
+		assert.ElementsMatch(t, []flow.Entity{ab, ac}, pool.Lookup(prefixes, byPrefix(ab)))
+		assert.ElementsMatch(t, []flow.Entity{b}, pool.Lookup(prefixes, byPrefix(b)))
+		assert.ElementsMatch(t, []flow.Entity{ab, ac}, pool.Lookup(lengths, byLength(ab)))
+		assert.Empty(t, pool.Lookup(prefixes, byPrefix(fake("C"))))
+		requireIndexed(t, pool, prefixes, byPrefix)
+		requireIndexed(t, pool, lengths, byLength)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		require.True(t, pool.Rem(b.ID()))
+		assert.Empty(t, pool.Lookup(prefixes, byPrefix(b)))
+		assert.Equal(t, uint(1), prefixes.Size())
+		requireIndexed(t, pool, prefixes, byPrefix)
+		requireIndexed(t, pool, lengths, byLength)
+	})
+
+	t.Run("adjust", func(t *testing.T) {
+		replacement := fake("CCC")
+		_, ok := pool.Adjust(ac.ID(), func(flow.Entity) flow.Entity { return replacement })
+		require.True(t, ok)
+		assert.ElementsMatch(t, []flow.Entity{ab}, pool.Lookup(prefixes, byPrefix(ab)))
+		assert.ElementsMatch(t, []flow.Entity{replacement}, pool.Lookup(lengths, byLength(replacement)))
+		requireIndexed(t, pool, prefixes, byPrefix)
+		requireIndexed(t, pool, lengths, byLength)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		pool.Clear()
+		assert.Empty(t, pool.Lookup(prefixes, byPrefix(ab)))
+		assert.Zero(t, prefixes.Size())
+		assert.Zero(t, lengths.Size())
+	})
+}
+
+// TestIdentifierIndex_Ejection verifies that ejected entities are removed from the indices, both
+// for batch ejections and for custom ejectors.
+func TestIdentifierIndex_Ejection(t *testing.T) {
+	const limit = 10
+
+	t.Run("batch ejection", func(t *testing.T) {
+		prefixes := NewIdentifierIndex(byPrefix)
+		pool := NewBackend(WithLimit(limit), WithIndex(prefixes))
+		addRandomEntities(t, pool, limit+overCapacityThreshold+1)
+		require.Less(t, pool.Size(), uint(limit+overCapacityThreshold+1))
+		requireIndexed(t, pool, prefixes, byPrefix)
+	})
+
+	t.Run("custom ejector", func(t *testing.T) {
+		prefixes := NewIdentifierIndex(byPrefix)
+		pool := NewBackend(WithLimit(limit), WithEject(EjectTrueRandom), WithIndex(prefixes))
+		addRandomEntities(t, pool, 2*limit)
+		require.Equal(t, uint(limit), pool.Size())
+		requireIndexed(t, pool, prefixes, byPrefix)
+	})
+}
+
+// TestIdentifierIndex_Concurrency concurrently adds, removes, adjusts and looks up entities of a
+// backend with two indices registered, while the backend ejects entities beyond its limit, and
+// verifies that the indices are consistent with the backend afterwards. It is meant to be run with
+// the race detector.
+func TestIdentifierIndex_Concurrency(t *testing.T) {
+	const (
+		limit   = 100
+		workers = 10
+		items   = 100
+	)
+	prefixes := NewIdentifierIndex(byPrefix)
+	lengths := NewIdentifierIndex(byLength)
+	pool := NewBackend(WithLimit(limit), WithEject(EjectTrueRandom), WithIndex(prefixes, lengths))
+
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < items; i++ {
+				item := fake(fmt.Sprintf("%d-item%d", w, i))
+				pool.Add(item)
+				_ = pool.Lookup(prefixes, byPrefix(item))
+				_ = pool.Lookup(lengths, byLength(item))
+				switch i % 3 {
+				case 0:
+					pool.Rem(item.ID())
+				case 1:
+					pool.Adjust(item.ID(), func(flow.Entity) flow.Entity {
+						return fake(fmt.Sprintf("%d-adjusted%d", w, i))
+					})
+				}
+			}
+		}(w)
+	}
+	unittest.RequireReturnsBefore(t, wg.Wait, 5*time.Second, "test could not finish on time")
+
+	require.LessOrEqual(t, pool.Size(), uint(limit))
+	requireIndexed(t, pool, prefixes, byPrefix)
+	requireIndexed(t, pool, lengths, byLength)
+}
+
+// BenchmarkBackend_AddRem compares the throughput of adding and removing entities of a backend
+// without indices with that of a backend with two indices registered.
+func BenchmarkBackend_AddRem(b *testing.B) {
+	items := make([]fake, 1000)
+	for i := range items {
+		id := unittest.IdentifierFixture()
+		items[i] = fake(id[:])
+	}
+
+	run := func(b *testing.B, pool *Backend) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			item := items[i%len(items)]
+			pool.Add(item)
+			pool.Rem(item.ID())
+		}
+	}
+
+	b.Run("without indices", func(b *testing.B) {
+		run(b, NewBackend())
+	})
+	b.Run("with two indices", func(b *testing.B) {
+		run(b, NewBackend(WithIndex(NewIdentifierIndex(byPrefix), NewIdentifierIndex(byLength))))
+	})
+}
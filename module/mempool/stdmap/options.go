@@ -2,6 +2,10 @@
 
 package stdmap
 
+import (
+	"github.com/onflow/flow-go/module"
+)
+
 // OptionFunc is a function that can be provided to the backend on creation in
 // order to set a certain custom option.
 type OptionFunc func(*Backend)
@@ -24,3 +28,20 @@ func WithEject(eject EjectFunc) OptionFunc {
 		be.batchEject = nil
 	}
 }
+
+// WithIndex can be provided to the backend on creation in order to register secondary
+// indices, which the backend keeps up to date on every addition and removal of an entity.
+func WithIndex(indices ...Index) OptionFunc {
+	return func(be *Backend) {
+		be.indices = append(be.indices, indices...)
+	}
+}
+
+// WithMetrics can be provided to the backend on creation in order to report its size and
+// the number of ejected entities as the given resource to the mempool metrics.
+func WithMetrics(collector module.MempoolMetrics, resource string) OptionFunc {
+	return func(be *Backend) {
+		be.metrics = collector
+		be.resource = resource
+	}
+}
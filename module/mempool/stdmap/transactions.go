@@ -3,21 +3,60 @@
 package stdmap
 
 import (
+	"container/heap"
 	"fmt"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
 )
 
+// RankedTransaction is a transaction of a pool, along with its arrival number. Arrival numbers
+// increase with every transaction added to the pool, hence they order transactions by arrival time.
+type RankedTransaction struct {
+	Tx      *flow.TransactionBody
+	Arrival uint64
+}
+
+// TransactionPriorityFunc ranks the transactions of a pool. It returns true if the transaction a
+// should be included in a collection before the transaction b.
+type TransactionPriorityFunc func(a, b RankedTransaction) bool
+
+// PriorityArrival ranks transactions first in, first out.
+func PriorityArrival(a, b RankedTransaction) bool {
+	return a.Arrival < b.Arrival
+}
+
+// PriorityGasLimit ranks transactions by descending gas limit, and transactions with the same gas
+// limit first in, first out.
+func PriorityGasLimit(a, b RankedTransaction) bool {
+	if a.Tx.GasLimit != b.Tx.GasLimit {
+		return a.Tx.GasLimit > b.Tx.GasLimit
+	}
+	return PriorityArrival(a, b)
+}
+
 // Transactions implements the transactions memory pool of the consensus nodes,
 // used to store transactions and to generate block payloads.
 type Transactions struct {
 	*Backend
+	arrivals *arrivalIndex
+	priority TransactionPriorityFunc
 }
 
-// NewTransactions creates a new memory pool for transctions.
+// NewTransactions creates a new memory pool for transctions, which ranks
+// transactions by arrival time.
 func NewTransactions(limit uint) *Transactions {
+	return NewTransactionsWithPriority(limit, PriorityArrival)
+}
+
+// NewTransactionsWithPriority creates a new memory pool for transactions, which
+// ranks transactions by the given priority function.
+func NewTransactionsWithPriority(limit uint, priority TransactionPriorityFunc) *Transactions {
+	arrivals := newArrivalIndex()
 	t := &Transactions{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend:  NewBackend(WithLimit(limit), WithIndex(arrivals)),
+		arrivals: arrivals,
+		priority: priority,
 	}
 
 	return t
@@ -50,3 +89,78 @@ func (t *Transactions) All() []*flow.TransactionBody {
 	}
 	return txs
 }
+
+// Ranked returns an iterator over the transactions of the mempool, highest ranked first. Only
+// the snapshot of the mempool is taken while holding its lock, the transactions are ranked by
+// the iterator, as they are iterated.
+func (t *Transactions) Ranked() mempool.TransactionIterator {
+	t.RLock()
+	ranked := make([]RankedTransaction, 0, len(t.Backdata.entities))
+	for txID, entity := range t.Backdata.entities {
+		ranked = append(ranked, RankedTransaction{Tx: entity.(*flow.TransactionBody), Arrival: t.arrivals.arrival(txID)})
+	}
+	t.RUnlock()
+
+	it := &rankedIterator{ranked: ranked, priority: t.priority}
+	heap.Init(it)
+	return it
+}
+
+// rankedIterator iterates over a snapshot of the transactions of a pool in the order of their
+// priority. It pops the transactions from a heap, so that iterating over the k highest ranked
+// of n transactions takes O(n + k*log(n)).
+type rankedIterator struct {
+	ranked   []RankedTransaction
+	priority TransactionPriorityFunc
+}
+
+var _ mempool.TransactionIterator = (*rankedIterator)(nil)
+
+func (r *rankedIterator) Next() (*flow.TransactionBody, bool) {
+	if len(r.ranked) == 0 {
+		return nil, false
+	}
+	return heap.Pop(r).(RankedTransaction).Tx, true
+}
+
+func (r *rankedIterator) Len() int           { return len(r.ranked) }
+func (r *rankedIterator) Less(i, j int) bool { return r.priority(r.ranked[i], r.ranked[j]) }
+func (r *rankedIterator) Swap(i, j int)      { r.ranked[i], r.ranked[j] = r.ranked[j], r.ranked[i] }
+func (r *rankedIterator) Push(x interface{}) { r.ranked = append(r.ranked, x.(RankedTransaction)) }
+func (r *rankedIterator) Pop() interface{} {
+	last := r.ranked[len(r.ranked)-1]
+	r.ranked = r.ranked[:len(r.ranked)-1]
+	return last
+}
+
+// arrivalIndex numbers the transactions of a pool in the order they were added.
+type arrivalIndex struct {
+	next     uint64
+	arrivals map[flow.Identifier]uint64
+}
+
+var _ Index = (*arrivalIndex)(nil)
+
+func newArrivalIndex() *arrivalIndex {
+	return &arrivalIndex{
+		arrivals: make(map[flow.Identifier]uint64),
+	}
+}
+
+func (a *arrivalIndex) OnAdd(txID flow.Identifier, _ flow.Entity) {
+	a.arrivals[txID] = a.next
+	a.next++
+}
+
+func (a *arrivalIndex) OnRemove(txID flow.Identifier, _ flow.Entity) {
+	delete(a.arrivals, txID)
+}
+
+func (a *arrivalIndex) OnClear() {
+	a.arrivals = make(map[flow.Identifier]uint64)
+}
+
+// arrival returns the arrival number of the transaction with the given ID.
+func (a *arrivalIndex) arrival(txID flow.Identifier) uint64 {
+	return a.arrivals[txID]
+}
@@ -3,10 +3,14 @@
 package stdmap_test
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -58,3 +62,118 @@ func TestTransactionPool(t *testing.T) {
 		assert.Equal(t, uint(0), pool.Size())
 	})
 }
+
+func TestTransactionPool_Ranked(t *testing.T) {
+	// fixtures returns n transactions with the given gas limits, in arrival order
+	fixtures := func(gasLimits ...uint64) []*flow.TransactionBody {
+		txs := make([]*flow.TransactionBody, 0, len(gasLimits))
+		for _, gasLimit := range gasLimits {
+			tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+				tx.GasLimit = gasLimit
+			})
+			txs = append(txs, &tx)
+		}
+		return txs
+	}
+
+	// next returns up to n transactions from the iterator, or all if n is zero
+	next := func(it mempool.TransactionIterator, n int) []*flow.TransactionBody {
+		var txs []*flow.TransactionBody
+		for n == 0 || len(txs) < n {
+			tx, ok := it.Next()
+			if !ok {
+				break
+			}
+			txs = append(txs, tx)
+		}
+		return txs
+	}
+
+	t.Run("should rank by arrival", func(t *testing.T) {
+		pool := stdmap.NewTransactions(1000)
+		txs := fixtures(10, 30, 20, 30)
+		for _, tx := range txs {
+			pool.Add(tx)
+		}
+
+		assert.Equal(t, txs, next(pool.Ranked(), 0))
+		assert.Equal(t, txs[:2], next(pool.Ranked(), 2))
+		// ranked transactions remain in the pool
+		assert.EqualValues(t, 4, pool.Size())
+	})
+
+	t.Run("should rank by gas limit", func(t *testing.T) {
+		pool := stdmap.NewTransactionsWithPriority(1000, stdmap.PriorityGasLimit)
+		txs := fixtures(10, 30, 20, 30)
+		for _, tx := range txs {
+			pool.Add(tx)
+		}
+
+		// transactions with the same gas limit are ranked by arrival
+		expected := []*flow.TransactionBody{txs[1], txs[3], txs[2], txs[0]}
+		assert.Equal(t, expected, next(pool.Ranked(), 0))
+		assert.Equal(t, expected[:3], next(pool.Ranked(), 3))
+	})
+
+	t.Run("should rank re-added transactions as new arrivals", func(t *testing.T) {
+		pool := stdmap.NewTransactions(1000)
+		txs := fixtures(10, 20, 30)
+		for _, tx := range txs {
+			pool.Add(tx)
+		}
+		pool.Rem(txs[0].ID())
+		pool.Add(txs[0])
+
+		assert.Equal(t, []*flow.TransactionBody{txs[1], txs[2], txs[0]}, next(pool.Ranked(), 0))
+	})
+
+	t.Run("should allow modifying the pool while iterating", func(t *testing.T) {
+		pool := stdmap.NewTransactions(1000)
+		txs := fixtures(10, 20, 30)
+		for _, tx := range txs {
+			pool.Add(tx)
+		}
+
+		it := pool.Ranked()
+		var ranked []*flow.TransactionBody
+		for tx, ok := it.Next(); ok; tx, ok = it.Next() {
+			pool.Rem(tx.ID())
+			pool.Add(fixtures(40)[0])
+			ranked = append(ranked, tx)
+		}
+		// the iterator ranks the pool as it was when ranking started
+		assert.Equal(t, txs, ranked)
+		assert.EqualValues(t, 3, pool.Size())
+	})
+
+	t.Run("should stay consistent under concurrent access and ejection", func(t *testing.T) {
+		const limit = 10
+		pool := stdmap.NewTransactions(limit)
+		txs := fixtures(make([]uint64, 1000)...)
+
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := w; i < len(txs); i += 4 {
+					pool.Add(txs[i])
+					if i%3 == 0 {
+						pool.Rem(txs[i].ID())
+					}
+					_ = next(pool.Ranked(), 5)
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		// the ranking should cover exactly the transactions remaining in the pool
+		ranked := next(pool.Ranked(), 0)
+		require.Len(t, ranked, int(pool.Size()))
+		assert.ElementsMatch(t, pool.All(), ranked)
+		assert.Greater(t, pool.EjectionCount(), uint64(0))
+
+		pool.Clear()
+		assert.Empty(t, next(pool.Ranked(), 0))
+	})
+}
@@ -32,6 +32,13 @@ type Transactions interface {
 	// as a slice.
 	All() []*flow.TransactionBody
 
+	// Ranked returns an iterator over the transactions of the memory pool,
+	// ordered by the priority of the pool, highest ranked first. The iterator
+	// ranks a snapshot of the memory pool taken when called, so that the memory
+	// pool can be modified while iterating. Transactions are ranked as they are
+	// iterated, hence stopping early avoids ranking the rest of the pool.
+	Ranked() TransactionIterator
+
 	// Clear removes all transactions from the mempool.
 	Clear()
 
@@ -39,3 +46,11 @@ type Transactions interface {
 	// entire memory pool.
 	Hash() flow.Identifier
 }
+
+// TransactionIterator iterates over transactions.
+type TransactionIterator interface {
+
+	// Next returns the next transaction. It returns false once all
+	// transactions were returned.
+	Next() (*flow.TransactionBody, bool)
+}
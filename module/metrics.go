@@ -14,15 +14,20 @@ type ResolverMetrics interface {
 	// DNSLookupDuration tracks the time spent to resolve a DNS address.
 	DNSLookupDuration(duration time.Duration)
 
-	// OnDNSCacheMiss tracks the total number of dns requests resolved through looking up the network.
-	OnDNSCacheMiss()
+	// OnDNSCacheMiss tracks the total number of dns requests of the given lookup type (ip or txt) resolved
+	// through looking up the network.
+	OnDNSCacheMiss(lookupType string)
 
-	// DNSCacheResolution tracks the total number of dns requests resolved through the cache without
-	// looking up the network.
-	OnDNSCacheHit()
+	// OnDNSCacheHit tracks the total number of dns requests of the given lookup type (ip or txt) resolved
+	// through the cache without looking up the network.
+	OnDNSCacheHit(lookupType string)
 
-	// OnDNSCacheInvalidated is called whenever dns cache is invalidated for an entry
-	OnDNSCacheInvalidated()
+	// OnDNSCacheInvalidated is called whenever dns cache is invalidated for an entry of the given lookup type (ip or txt).
+	OnDNSCacheInvalidated(lookupType string)
+
+	// OnDNSLookupError tracks the total number of dns requests of the given lookup type (ip or txt) that
+	// failed on the underlying resolver.
+	OnDNSLookupError(lookupType string)
 }
 
 type NetworkMetrics interface {
@@ -37,6 +42,12 @@ type NetworkMetrics interface {
 	// NetworkDuplicateMessagesDropped counts number of messages dropped due to duplicate detection
 	NetworkDuplicateMessagesDropped(topic string, messageType string)
 
+	// UnicastFallbackActivated counts the number of times the fallback of unicast messages to pubsub was activated for a target
+	UnicastFallbackActivated(topic string)
+
+	// UnicastStreamFailure counts the failures of unicast stream creations and writes by failure class
+	UnicastStreamFailure(reason string)
+
 	// Message receive queue metrics
 	// MessageAdded increments the metric tracking the number of messages in the queue with the given priority
 	MessageAdded(priority int)
@@ -67,6 +78,8 @@ type EngineMetrics interface {
 	MessageSent(engine string, message string)
 	MessageReceived(engine string, message string)
 	MessageHandled(engine string, messages string)
+	MessageDeadlineExceeded(engine string, message string)
+	MessageDeduplicated(engine string, message string)
 }
 
 type ComplianceMetrics interface {
@@ -83,6 +96,7 @@ type ComplianceMetrics interface {
 	CurrentDKGPhase2FinalView(view uint64)
 	CurrentDKGPhase3FinalView(view uint64)
 	EpochEmergencyFallbackTriggered()
+	RejectedEpochPhaseTransition(from flow.EpochPhase, to flow.EpochPhase)
 }
 
 type CleanerMetrics interface {
@@ -102,6 +116,11 @@ type CacheMetrics interface {
 
 type MempoolMetrics interface {
 	MempoolEntries(resource string, entries uint)
+	// MempoolEjections counts the number of entries ejected from the mempool of the given resource
+	MempoolEjections(resource string, ejections uint)
+	// MempoolSaturated records the time the mempool of the given resource spent saturated, during
+	// which new entries were rejected
+	MempoolSaturated(resource string, duration time.Duration)
 	Register(resource string, entriesFunc EntriesFunc) error
 }
 
@@ -155,6 +174,10 @@ type CollectionMetrics interface {
 	// a tx->col span for the transaction.
 	TransactionIngested(txID flow.Identifier)
 
+	// TransactionRejected is called when an inbound transaction is rejected by
+	// the ingest engine, because it failed validation for the given reason.
+	TransactionRejected(reason string)
+
 	// ClusterBlockProposed is called when a new collection is proposed by us or
 	// any other node in the cluster.
 	ClusterBlockProposed(block *cluster.Block)
@@ -179,6 +202,10 @@ type ConsensusMetrics interface {
 	// EmergencySeal increments the number of seals that were created in emergency mode
 	EmergencySeal()
 
+	// EmergencySealed records the height of the executed block, for which a seal candidate was constructed
+	// in emergency mode, i.e. without sufficient approvals
+	EmergencySealed(height uint64)
+
 	// OnReceiptProcessingDuration records the number of seconds spent processing a receipt
 	OnReceiptProcessingDuration(duration time.Duration)
 
@@ -187,6 +214,34 @@ type ConsensusMetrics interface {
 
 	// CheckSealingDuration records absolute time for the full sealing check by the consensus match engine
 	CheckSealingDuration(duration time.Duration)
+
+	// BeaconKeyAvailable records whether this node has a random beacon key which is safe for signing in the given epoch
+	BeaconKeyAvailable(epoch uint64, available bool)
+
+	// MatchingStorageFailure increments the number of unexpected storage errors of the given
+	// operation in the matching engine, other than missing data
+	MatchingStorageFailure(operation string)
+
+	// ExecutionForkDetected increments the number of detected pairs of conflicting execution
+	// results for the same block and previous result
+	ExecutionForkDetected()
+
+	// InvalidSubmission increments the number of messages rejected by the matching engine, by
+	// the role of the origin and the reason for the rejection
+	InvalidSubmission(originRole string, reason string)
+
+	// InvalidApproval increments the number of approvals rejected as invalid by the sealing engine,
+	// e.g. because of an invalid approver signature or a missing SPoCK proof
+	InvalidApproval()
+
+	// BuilderPayloadSection records the number of items of the given payload section, which the
+	// block builder included in a payload, and the number of candidates it skipped because of
+	// the payload limits
+	BuilderPayloadSection(section string, included uint, skipped uint)
+
+	// RejectedGuarantee increments the number of collection guarantees rejected by the ingestion
+	// engine, by the reason for the rejection
+	RejectedGuarantee(reason string)
 }
 
 type VerificationMetrics interface {
@@ -235,6 +290,10 @@ type VerificationMetrics interface {
 	// requester engine receives from execution nodes (through network).
 	OnChunkDataPackResponseReceivedFromNetworkByRequester()
 
+	// OnChunkDataPackRequestTimedOutAtRequester increments a counter that keeps track of number of chunk data pack requests that the
+	// requester engine gives up on, as no execution node returned the chunk data pack within the maximum request duration.
+	OnChunkDataPackRequestTimedOutAtRequester()
+
 	// SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester is invoked when a cycle of requesting chunk data packs is done by requester engine.
 	// It updates the maximum number of attempts made by requester engine for requesting the chunk data packs of the next unsealed height.
 	// The maximum is taken over the history of all chunk data packs requested during that cycle that belong to the next unsealed height.
@@ -312,6 +371,10 @@ type LedgerMetrics interface {
 type WALMetrics interface {
 	// DiskSize records the amount of disk space used by the storage (in bytes)
 	DiskSize(uint64)
+
+	// ExecutionCheckpointCreated records the duration of creating a checkpoint, the number of WAL
+	// segments it covers and the size of the checkpoint file (in bytes)
+	ExecutionCheckpointCreated(duration time.Duration, segments uint, fileSize uint64)
 }
 
 type ExecutionDataServiceMetrics interface {
@@ -410,6 +473,22 @@ type TransactionMetrics interface {
 
 	// TransactionSubmissionFailed should be called whenever we try to submit a transaction and it fails
 	TransactionSubmissionFailed()
+
+	// ScriptExecutionCacheHit tracks the number of script executions served from the script result cache
+	ScriptExecutionCacheHit()
+
+	// ScriptExecutionCacheMiss tracks the number of script executions not found in the script result cache
+	ScriptExecutionCacheMiss()
+}
+
+// MisbehaviorMetrics tracks the misbehavior reports processed by the misbehavior reporting pipeline.
+type MisbehaviorMetrics interface {
+	// MisbehaviorReported tracks the number of accepted misbehavior reports with the given reason
+	MisbehaviorReported(reason string)
+
+	// MisbehaviorReportDropped tracks the number of misbehavior reports with the given reason which were
+	// dropped, e.g. because they were duplicates or the origin exceeded its rate limit
+	MisbehaviorReportDropped(reason string, cause string)
 }
 
 type PingMetrics interface {
@@ -12,6 +12,7 @@ import (
 type CollectionCollector struct {
 	tracer               module.Tracer
 	transactionsIngested prometheus.Counter       // tracks the number of ingested transactions
+	transactionsRejected *prometheus.CounterVec   // tracks the number of rejected transactions, by reason
 	finalizedHeight      *prometheus.GaugeVec     // tracks the finalized height
 	proposals            *prometheus.HistogramVec // tracks the number/size of PROPOSED collections
 	guarantees           *prometheus.HistogramVec // counts the number/size of FINALIZED collections
@@ -28,6 +29,12 @@ func NewCollectionCollector(tracer module.Tracer) *CollectionCollector {
 			Help:      "count of transactions ingested by this node",
 		}),
 
+		transactionsRejected: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceCollection,
+			Name:      "rejected_transactions_total",
+			Help:      "count of inbound transactions rejected by this node, by reason",
+		}, []string{LabelReason}),
+
 		finalizedHeight: promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespaceCollection,
 			Subsystem: subsystemProposal,
@@ -61,6 +68,11 @@ func (cc *CollectionCollector) TransactionIngested(txID flow.Identifier) {
 	cc.transactionsIngested.Inc()
 }
 
+// TransactionRejected increments the count of transactions rejected for the given reason.
+func (cc *CollectionCollector) TransactionRejected(reason string) {
+	cc.transactionsRejected.With(prometheus.Labels{LabelReason: reason}).Inc()
+}
+
 // ClusterBlockProposed tracks the size and number of proposals, as well as
 // starting the collection->guarantee span.
 func (cc *CollectionCollector) ClusterBlockProposed(block *cluster.Block) {
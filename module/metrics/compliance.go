@@ -27,6 +27,7 @@ type ComplianceCollector struct {
 	currentDKGPhase2FinalView       prometheus.Gauge
 	currentDKGPhase3FinalView       prometheus.Gauge
 	epochEmergencyFallbackTriggered prometheus.Gauge
+	rejectedEpochPhaseTransitions   *prometheus.CounterVec
 }
 
 func NewComplianceCollector() *ComplianceCollector {
@@ -153,6 +154,13 @@ func NewComplianceCollector() *ComplianceCollector {
 			Subsystem: subsystemCompliance,
 			Help:      "indicates whether epoch emergency fallback is triggered; if >0, the fallback is triggered",
 		}),
+
+		rejectedEpochPhaseTransitions: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "rejected_epoch_phase_transitions_total",
+			Namespace: namespaceConsensus,
+			Subsystem: subsystemCompliance,
+			Help:      "the number of service events rejected because they would cause an illegal epoch phase transition",
+		}, []string{LabelFromPhase, LabelToPhase}),
 	}
 
 	return cc
@@ -223,3 +231,7 @@ func (cc *ComplianceCollector) CurrentDKGPhase3FinalView(view uint64) {
 func (cc *ComplianceCollector) EpochEmergencyFallbackTriggered() {
 	cc.epochEmergencyFallbackTriggered.Set(float64(1))
 }
+
+func (cc *ComplianceCollector) RejectedEpochPhaseTransition(from flow.EpochPhase, to flow.EpochPhase) {
+	cc.rejectedEpochPhaseTransitions.WithLabelValues(from.String(), to.String()).Inc()
+}
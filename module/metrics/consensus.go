@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,6 +25,32 @@ type ConsensusCollector struct {
 
 	// The number of emergency seals
 	emergencySealedBlocks prometheus.Counter
+
+	// The number and the latest height of seal candidates constructed in emergency mode
+	emergencySealCandidates      prometheus.Counter
+	emergencySealCandidateHeight prometheus.Gauge
+
+	// Whether a safe random beacon key is available, by epoch
+	beaconKeyAvailable *prometheus.GaugeVec
+
+	// The number of unexpected storage errors in the matching engine, by operation
+	matchingStorageFailures *prometheus.CounterVec
+
+	// The number of detected pairs of conflicting execution results
+	executionForks prometheus.Counter
+
+	// The number of messages rejected by the matching engine, by origin role and reason
+	invalidSubmissions *prometheus.CounterVec
+
+	// The number of approvals rejected as invalid by the sealing engine
+	invalidApprovals prometheus.Counter
+
+	// The number of candidates included in and skipped for block payloads by the builder, by section
+	builderIncludedItems *prometheus.CounterVec
+	builderSkippedItems  *prometheus.CounterVec
+
+	// The number of collection guarantees rejected by the ingestion engine, by reason
+	rejectedGuarantees *prometheus.CounterVec
 }
 
 // NewConsensusCollector created a new consensus collector
@@ -52,11 +79,81 @@ func NewConsensusCollector(tracer module.Tracer, registerer prometheus.Registere
 		Subsystem: subsystemCompliance,
 		Help:      "the number of blocks sealed in emergency mode",
 	})
+	emergencySealCandidates := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "emergency_seal_candidates_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemSealing,
+		Help:      "the number of seal candidates constructed in emergency mode, without sufficient approvals",
+	})
+	emergencySealCandidateHeight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "emergency_seal_candidate_height",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemSealing,
+		Help:      "the height of the latest block, for which a seal candidate was constructed in emergency mode",
+	})
+	beaconKeyAvailable := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "beacon_key_available",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemHotstuff,
+		Help:      "whether the node has a random beacon key which is safe for signing in the epoch (1) or falls back to staking-only signatures (0)",
+	}, []string{LabelEpoch})
+	matchingStorageFailures := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "storage_failures_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemMatchEngine,
+		Help:      "the number of unexpected storage errors, other than missing data, in consensus matching engine",
+	}, []string{LabelOperation})
+	executionForks := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "execution_forks_detected_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemMatchEngine,
+		Help:      "the number of detected pairs of conflicting execution results for the same block and previous result",
+	})
+	invalidSubmissions := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "invalid_submissions_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemMatchEngine,
+		Help:      "the number of messages rejected by the consensus matching engine, by origin role and reason",
+	}, []string{LabelNodeRole, LabelReason})
+	rejectedGuarantees := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "rejected_guarantees_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemIngestion,
+		Help:      "the number of collection guarantees rejected by the consensus ingestion engine, by reason",
+	}, []string{LabelReason})
+	invalidApprovals := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "invalid_approvals_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemSealing,
+		Help:      "the number of approvals rejected as invalid by the sealing engine",
+	})
+	builderIncludedItems := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "payload_items_included_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemBuilder,
+		Help:      "the number of items included in block payloads by the builder, by payload section",
+	}, []string{LabelSection})
+	builderSkippedItems := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "payload_items_skipped_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemBuilder,
+		Help:      "the number of candidates skipped by the builder because of the payload limits, by payload section",
+	}, []string{LabelSection})
 	registerer.MustRegister(
 		onReceiptDuration,
 		onApprovalDuration,
 		checkSealingDuration,
 		emergencySealedBlocks,
+		emergencySealCandidates,
+		emergencySealCandidateHeight,
+		beaconKeyAvailable,
+		matchingStorageFailures,
+		executionForks,
+		invalidSubmissions,
+		invalidApprovals,
+		builderIncludedItems,
+		builderSkippedItems,
+		rejectedGuarantees,
 	)
 	cc := &ConsensusCollector{
 		tracer:                tracer,
@@ -64,6 +161,17 @@ func NewConsensusCollector(tracer module.Tracer, registerer prometheus.Registere
 		onApprovalDuration:    onApprovalDuration,
 		checkSealingDuration:  checkSealingDuration,
 		emergencySealedBlocks: emergencySealedBlocks,
+		beaconKeyAvailable:    beaconKeyAvailable,
+
+		emergencySealCandidates:      emergencySealCandidates,
+		emergencySealCandidateHeight: emergencySealCandidateHeight,
+		matchingStorageFailures:      matchingStorageFailures,
+		executionForks:               executionForks,
+		invalidSubmissions:           invalidSubmissions,
+		invalidApprovals:             invalidApprovals,
+		builderIncludedItems:         builderIncludedItems,
+		builderSkippedItems:          builderSkippedItems,
+		rejectedGuarantees:           rejectedGuarantees,
 	}
 	return cc
 }
@@ -89,6 +197,13 @@ func (cc *ConsensusCollector) EmergencySeal() {
 	cc.emergencySealedBlocks.Inc()
 }
 
+// EmergencySealed increments the counter of seal candidates constructed in emergency mode, and records the
+// height of the executed block.
+func (cc *ConsensusCollector) EmergencySealed(height uint64) {
+	cc.emergencySealCandidates.Inc()
+	cc.emergencySealCandidateHeight.Set(float64(height))
+}
+
 // OnReceiptProcessingDuration increases the number of seconds spent processing receipts
 func (cc *ConsensusCollector) OnReceiptProcessingDuration(duration time.Duration) {
 	cc.onReceiptDuration.Add(duration.Seconds())
@@ -103,3 +218,43 @@ func (cc *ConsensusCollector) OnApprovalProcessingDuration(duration time.Duratio
 func (cc *ConsensusCollector) CheckSealingDuration(duration time.Duration) {
 	cc.checkSealingDuration.Add(duration.Seconds())
 }
+
+// BeaconKeyAvailable records whether a safe random beacon key is available for the given epoch
+func (cc *ConsensusCollector) BeaconKeyAvailable(epoch uint64, available bool) {
+	value := 0.0
+	if available {
+		value = 1.0
+	}
+	cc.beaconKeyAvailable.WithLabelValues(strconv.FormatUint(epoch, 10)).Set(value)
+}
+
+// MatchingStorageFailure increments the number of unexpected storage errors of the given operation
+func (cc *ConsensusCollector) MatchingStorageFailure(operation string) {
+	cc.matchingStorageFailures.WithLabelValues(operation).Inc()
+}
+
+// ExecutionForkDetected increments the number of detected pairs of conflicting execution results
+func (cc *ConsensusCollector) ExecutionForkDetected() {
+	cc.executionForks.Inc()
+}
+
+// InvalidSubmission increments the number of messages rejected by the matching engine
+func (cc *ConsensusCollector) InvalidSubmission(originRole string, reason string) {
+	cc.invalidSubmissions.WithLabelValues(originRole, reason).Inc()
+}
+
+// InvalidApproval increments the number of approvals rejected as invalid
+func (cc *ConsensusCollector) InvalidApproval() {
+	cc.invalidApprovals.Inc()
+}
+
+// BuilderPayloadSection records the number of included and skipped items of the given payload section
+func (cc *ConsensusCollector) BuilderPayloadSection(section string, included uint, skipped uint) {
+	cc.builderIncludedItems.WithLabelValues(section).Add(float64(included))
+	cc.builderSkippedItems.WithLabelValues(section).Add(float64(skipped))
+}
+
+// RejectedGuarantee increments the number of collection guarantees rejected by the ingestion engine
+func (cc *ConsensusCollector) RejectedGuarantee(reason string) {
+	cc.rejectedGuarantees.WithLabelValues(reason).Inc()
+}
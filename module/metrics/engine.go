@@ -9,6 +9,8 @@ type EngineCollector struct {
 	sent     *prometheus.CounterVec
 	received *prometheus.CounterVec
 	handled  *prometheus.CounterVec
+	overrun  *prometheus.CounterVec
+	dedup    *prometheus.CounterVec
 }
 
 func NewEngineCollector() *EngineCollector {
@@ -35,6 +37,20 @@ func NewEngineCollector() *EngineCollector {
 			Subsystem: subsystemEngine,
 			Help:      "the number of messages handled by engines",
 		}, []string{EngineLabel, LabelMessage}),
+
+		overrun: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "messages_deadline_exceeded_total",
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemEngine,
+			Help:      "the number of messages whose handling by engines exceeded the processing deadline",
+		}, []string{EngineLabel, LabelMessage}),
+
+		dedup: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "messages_deduplicated_total",
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemEngine,
+			Help:      "the number of messages dropped by engines as duplicates of recently received messages",
+		}, []string{EngineLabel, LabelMessage}),
 	}
 
 	return ec
@@ -51,3 +67,11 @@ func (ec *EngineCollector) MessageReceived(engine string, message string) {
 func (ec *EngineCollector) MessageHandled(engine string, message string) {
 	ec.handled.With(prometheus.Labels{EngineLabel: engine, LabelMessage: message}).Inc()
 }
+
+func (ec *EngineCollector) MessageDeadlineExceeded(engine string, message string) {
+	ec.overrun.With(prometheus.Labels{EngineLabel: engine, LabelMessage: message}).Inc()
+}
+
+func (ec *EngineCollector) MessageDeduplicated(engine string, message string) {
+	ec.dedup.With(prometheus.Labels{EngineLabel: engine, LabelMessage: message}).Inc()
+}
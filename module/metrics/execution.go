@@ -58,6 +58,9 @@ type ExecutionCollector struct {
 	totalChunkDataPackRequests       prometheus.Counter
 	stateSyncActive                  prometheus.Gauge
 	executionStateDiskUsage          prometheus.Gauge
+	checkpointDuration               prometheus.Histogram
+	checkpointSegments               prometheus.Gauge
+	checkpointSize                   prometheus.Gauge
 	blockDataUploadsInProgress       prometheus.Gauge
 	blockDataUploadsDuration         prometheus.Histogram
 }
@@ -490,6 +493,28 @@ func NewExecutionCollector(tracer module.Tracer, registerer prometheus.Registere
 			Name:      "execution_state_disk_usage",
 			Help:      "the disk usage of execution state",
 		}),
+
+		checkpointDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemMTrie,
+			Name:      "checkpoint_duration_seconds",
+			Help:      "the duration of creating a checkpoint of the execution state",
+			Buckets:   []float64{10, 30, 60, 120, 300, 600, 1200, 1800},
+		}),
+
+		checkpointSegments: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemMTrie,
+			Name:      "checkpoint_segments",
+			Help:      "the number of WAL segments covered by the latest checkpoint",
+		}),
+
+		checkpointSize: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemMTrie,
+			Name:      "checkpoint_size_bytes",
+			Help:      "the size of the latest checkpoint file in bytes",
+		}),
 	}
 
 	return ec
@@ -693,3 +718,11 @@ func (ec *ExecutionCollector) RuntimeSetNumberOfAccounts(count uint64) {
 func (ec *ExecutionCollector) DiskSize(bytes uint64) {
 	ec.executionStateDiskUsage.Set(float64(bytes))
 }
+
+// ExecutionCheckpointCreated reports the duration, the number of covered WAL segments and the file size
+// of a newly created checkpoint
+func (ec *ExecutionCollector) ExecutionCheckpointCreated(duration time.Duration, segments uint, fileSize uint64) {
+	ec.checkpointDuration.Observe(duration.Seconds())
+	ec.checkpointSegments.Set(float64(segments))
+	ec.checkpointSize.Set(float64(fileSize))
+}
@@ -13,6 +13,37 @@ const (
 	LabelNodeInfo    = "nodeinfo"
 	LabelNodeVersion = "nodeversion"
 	LabelPriority    = "priority"
+	LabelEpoch       = "epoch"
+	LabelLookupType  = "lookup_type"
+	LabelReason      = "reason"
+	LabelCause       = "cause"
+	LabelFromPhase   = "from_phase"
+	LabelToPhase     = "to_phase"
+	LabelOperation   = "operation"
+	LabelSection     = "section"
+)
+
+const (
+	DNSLookupTypeIP  = "ip"
+	DNSLookupTypeTXT = "txt"
+)
+
+const (
+	MisbehaviorDropCauseDuplicate   = "duplicate"
+	MisbehaviorDropCauseRateLimited = "rate_limited"
+)
+
+const (
+	TransactionRejectedByteSize       = "byte_size"
+	TransactionRejectedGasLimit       = "gas_limit"
+	TransactionRejectedScript         = "script"
+	TransactionRejectedArguments      = "arguments"
+	TransactionRejectedMissingFields  = "missing_fields"
+	TransactionRejectedExpired        = "expired"
+	TransactionRejectedReferenceBlock = "reference_block"
+	TransactionRejectedAddress        = "address"
+	TransactionRejectedSignature      = "signature"
+	TransactionRejectedOther          = "other"
 )
 
 const (
@@ -46,6 +77,7 @@ const (
 	ResourceIdentity                 = "identity"
 	ResourceGuarantee                = "guarantee"
 	ResourceResult                   = "result"
+	ResourceResultByBlock            = "result_by_block"
 	ResourceResultApprovals          = "result_approvals"
 	ResourceReceipt                  = "receipt"
 	ResourceMyReceipt                = "my_receipt"
@@ -84,9 +116,12 @@ const (
 	ResourceApprovalResponseQueue     = "sealing_approval_response_queue"   // consensus node, sealing engine
 	ResourceBlockProposalQueue        = "compliance_proposal_queue"         // consensus node, compliance engine
 	ResourceBlockVoteQueue            = "compliance_vote_queue"             // consensus node, compliance engine
+	ResourceFollowerSeenBlock         = "follower_seen_block"               // follower engine
 	ResourceCollectionGuaranteesQueue = "ingestion_col_guarantee_queue"     // consensus node, ingestion engine
+	ResourcePendingGuarantee          = "ingestion_pending_guarantee"       // consensus node, ingestion engine
 	ResourceChunkDataPack             = "chunk_data_pack"                   // execution node
 	ResourceEvents                    = "events"                            // execution node
+	ResourceProvidedChunkDataPack     = "provided_chunk_data_pack"          // execution node, provider engine
 	ResourceServiceEvents             = "service_events"                    // execution node
 	ResourceTransactionResults        = "transaction_results"               // execution node
 )
@@ -97,6 +132,7 @@ const (
 	MessageBlockVote            = "vote"
 	MessageExecutionReceipt     = "receipt"
 	MessageResultApproval       = "approval"
+	MessageResultApprovalBatch  = "approval_batch"
 	MessageSyncRequest          = "ping"
 	MessageSyncResponse         = "pong"
 	MessageRangeRequest         = "range"
@@ -14,6 +14,8 @@ import (
 type MempoolCollector struct {
 	unit         *engine.Unit
 	entries      *prometheus.GaugeVec
+	ejections    *prometheus.CounterVec
+	saturated    *prometheus.CounterVec
 	interval     time.Duration
 	delay        time.Duration
 	entriesFuncs map[string]module.EntriesFunc // keeps map of registered EntriesFunc of mempools
@@ -33,6 +35,20 @@ func NewMempoolCollector(interval time.Duration) *MempoolCollector {
 			Subsystem: subsystemMempool,
 			Help:      "the number of entries in the mempool",
 		}, []string{LabelResource}),
+
+		ejections: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "ejections_total",
+			Namespace: namespaceStorage,
+			Subsystem: subsystemMempool,
+			Help:      "the number of entries ejected from the mempool",
+		}, []string{LabelResource}),
+
+		saturated: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "saturated_seconds_total",
+			Namespace: namespaceStorage,
+			Subsystem: subsystemMempool,
+			Help:      "the time the mempool spent saturated, rejecting new entries",
+		}, []string{LabelResource}),
 	}
 
 	return mc
@@ -42,6 +58,14 @@ func (mc *MempoolCollector) MempoolEntries(resource string, entries uint) {
 	mc.entries.With(prometheus.Labels{LabelResource: resource}).Set(float64(entries))
 }
 
+func (mc *MempoolCollector) MempoolEjections(resource string, ejections uint) {
+	mc.ejections.With(prometheus.Labels{LabelResource: resource}).Add(float64(ejections))
+}
+
+func (mc *MempoolCollector) MempoolSaturated(resource string, duration time.Duration) {
+	mc.saturated.With(prometheus.Labels{LabelResource: resource}).Add(duration.Seconds())
+}
+
 // Register registers entriesFunc for a resource
 func (mc *MempoolCollector) Register(resource string, entriesFunc module.EntriesFunc) error {
 	mc.unit.Lock()
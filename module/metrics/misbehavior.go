@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type MisbehaviorCollector struct {
+	reported *prometheus.CounterVec
+	dropped  *prometheus.CounterVec
+}
+
+func NewMisbehaviorCollector() *MisbehaviorCollector {
+	mc := &MisbehaviorCollector{
+		reported: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "reports_total",
+			Namespace: namespaceMisbehavior,
+			Help:      "the number of accepted misbehavior reports",
+		}, []string{LabelReason}),
+		dropped: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "reports_dropped_total",
+			Namespace: namespaceMisbehavior,
+			Help:      "the number of dropped misbehavior reports",
+		}, []string{LabelReason, LabelCause}),
+	}
+
+	return mc
+}
+
+func (mc *MisbehaviorCollector) MisbehaviorReported(reason string) {
+	mc.reported.WithLabelValues(reason).Inc()
+}
+
+func (mc *MisbehaviorCollector) MisbehaviorReportDropped(reason string, cause string) {
+	mc.dropped.WithLabelValues(reason, cause).Inc()
+}
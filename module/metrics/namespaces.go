@@ -11,6 +11,7 @@ const (
 	namespaceExecution    = "execution"
 	namespaceLoader       = "loader"
 	namespaceStateSync    = "state_synchronization"
+	namespaceMisbehavior  = "misbehavior"
 )
 
 // Network subsystems represent the various layers of networking.
@@ -32,6 +33,7 @@ const (
 const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
+	subsystemScriptExecution       = "script_execution"
 )
 
 // Collection subsystem
@@ -44,6 +46,8 @@ const (
 	subsystemCompliance  = "compliance"
 	subsystemHotstuff    = "hotstuff"
 	subsystemMatchEngine = "match"
+	subsystemSealing     = "sealing"
+	subsystemBuilder     = "builder"
 )
 
 // Execution Subsystems
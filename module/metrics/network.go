@@ -19,15 +19,18 @@ type NetworkCollector struct {
 	outboundMessageSize             *prometheus.HistogramVec
 	inboundMessageSize              *prometheus.HistogramVec
 	duplicateMessagesDropped        *prometheus.CounterVec
+	unicastFallbackActivations      *prometheus.CounterVec
+	unicastStreamFailures           *prometheus.CounterVec
 	queueSize                       *prometheus.GaugeVec
 	queueDuration                   *prometheus.HistogramVec
 	inboundProcessTime              *prometheus.CounterVec
 	outboundConnectionCount         prometheus.Gauge
 	inboundConnectionCount          prometheus.Gauge
 	dnsLookupDuration               prometheus.Histogram
-	dnsCacheMissCount               prometheus.Counter
-	dnsCacheHitCount                prometheus.Counter
-	dnsCacheInvalidationCount       prometheus.Counter
+	dnsCacheMissCount               *prometheus.CounterVec
+	dnsCacheHitCount                *prometheus.CounterVec
+	dnsCacheInvalidationCount       *prometheus.CounterVec
+	dnsLookupErrorCount             *prometheus.CounterVec
 	unstakedOutboundConnectionCount prometheus.Gauge
 	unstakedInboundConnectionCount  prometheus.Gauge
 }
@@ -59,6 +62,20 @@ func NewNetworkCollector() *NetworkCollector {
 			Help:      "number of duplicate messages dropped",
 		}, []string{LabelChannel, LabelMessage}),
 
+		unicastFallbackActivations: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "unicast_fallback_activations_total",
+			Help:      "number of times the fallback of unicast messages to pubsub was activated for a target",
+		}, []string{LabelChannel}),
+
+		unicastStreamFailures: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "unicast_stream_failures_total",
+			Help:      "number of failures of unicast stream creations and writes by failure class",
+		}, []string{LabelReason}),
+
 		dnsLookupDuration: promauto.NewHistogram(prometheus.HistogramOpts{
 			Namespace: namespaceNetwork,
 			Subsystem: subsystemGossip,
@@ -67,26 +84,33 @@ func NewNetworkCollector() *NetworkCollector {
 			Help:      "the time spent on resolving a dns lookup (including cache hits)",
 		}),
 
-		dnsCacheMissCount: promauto.NewCounter(prometheus.CounterOpts{
+		dnsCacheMissCount: promauto.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespaceNetwork,
 			Subsystem: subsystemGossip,
 			Name:      "dns_cache_miss_total",
 			Help:      "the number of dns lookups that miss the cache and made through network",
-		}),
+		}, []string{LabelLookupType}),
 
-		dnsCacheInvalidationCount: promauto.NewCounter(prometheus.CounterOpts{
+		dnsCacheInvalidationCount: promauto.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespaceNetwork,
 			Subsystem: subsystemGossip,
 			Name:      "dns_cache_invalidation_total",
 			Help:      "the number of times dns cache is invalidated for an entry",
-		}),
+		}, []string{LabelLookupType}),
 
-		dnsCacheHitCount: promauto.NewCounter(prometheus.CounterOpts{
+		dnsCacheHitCount: promauto.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespaceNetwork,
 			Subsystem: subsystemGossip,
 			Name:      "dns_cache_hit_total",
 			Help:      "the number of dns cache hits",
-		}),
+		}, []string{LabelLookupType}),
+
+		dnsLookupErrorCount: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "dns_lookup_error_total",
+			Help:      "the number of dns lookups that failed on the underlying resolver",
+		}, []string{LabelLookupType}),
 
 		queueSize: promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespaceNetwork,
@@ -159,6 +183,17 @@ func (nc *NetworkCollector) NetworkDuplicateMessagesDropped(topic, messageType s
 	nc.duplicateMessagesDropped.WithLabelValues(topic, messageType).Add(1)
 }
 
+// UnicastFallbackActivated tracks the number of times the fallback of unicast messages to pubsub was activated for
+// a target on the given topic
+func (nc *NetworkCollector) UnicastFallbackActivated(topic string) {
+	nc.unicastFallbackActivations.WithLabelValues(topic).Inc()
+}
+
+// UnicastStreamFailure tracks the number of failures of unicast stream creations and writes with the given failure class
+func (nc *NetworkCollector) UnicastStreamFailure(reason string) {
+	nc.unicastStreamFailures.WithLabelValues(reason).Inc()
+}
+
 func (nc *NetworkCollector) MessageAdded(priority int) {
 	nc.queueSize.WithLabelValues(strconv.Itoa(priority)).Inc()
 }
@@ -192,19 +227,24 @@ func (nc *NetworkCollector) DNSLookupDuration(duration time.Duration) {
 }
 
 // OnDNSCacheMiss tracks the total number of dns requests resolved through looking up the network.
-func (nc *NetworkCollector) OnDNSCacheMiss() {
-	nc.dnsCacheMissCount.Inc()
+func (nc *NetworkCollector) OnDNSCacheMiss(lookupType string) {
+	nc.dnsCacheMissCount.WithLabelValues(lookupType).Inc()
 }
 
 // OnDNSCacheInvalidated is called whenever dns cache is invalidated for an entry
-func (nc *NetworkCollector) OnDNSCacheInvalidated() {
-	nc.dnsCacheInvalidationCount.Inc()
+func (nc *NetworkCollector) OnDNSCacheInvalidated(lookupType string) {
+	nc.dnsCacheInvalidationCount.WithLabelValues(lookupType).Inc()
 }
 
 // OnDNSCacheHit tracks the total number of dns requests resolved through the cache without
 // looking up the network.
-func (nc *NetworkCollector) OnDNSCacheHit() {
-	nc.dnsCacheHitCount.Inc()
+func (nc *NetworkCollector) OnDNSCacheHit(lookupType string) {
+	nc.dnsCacheHitCount.WithLabelValues(lookupType).Inc()
+}
+
+// OnDNSLookupError tracks the total number of dns requests that failed on the underlying resolver.
+func (nc *NetworkCollector) OnDNSLookupError(lookupType string) {
+	nc.dnsLookupErrorCount.WithLabelValues(lookupType).Inc()
 }
 
 func (nc *NetworkCollector) UnstakedOutboundConnections(connectionCount uint) {
@@ -18,6 +18,8 @@ func NewNoopCollector() *NoopCollector {
 func (nc *NoopCollector) NetworkMessageSent(sizeBytes int, topic string, messageType string)     {}
 func (nc *NoopCollector) NetworkMessageReceived(sizeBytes int, topic string, messageType string) {}
 func (nc *NoopCollector) NetworkDuplicateMessagesDropped(topic string, messageType string)       {}
+func (nc *NoopCollector) UnicastFallbackActivated(topic string)                                  {}
+func (nc *NoopCollector) UnicastStreamFailure(reason string)                                     {}
 func (nc *NoopCollector) MessageAdded(priority int)                                              {}
 func (nc *NoopCollector) MessageRemoved(priority int)                                            {}
 func (nc *NoopCollector) QueueDuration(duration time.Duration, priority int)                     {}
@@ -25,12 +27,17 @@ func (nc *NoopCollector) InboundProcessDuration(topic string, duration time.Dura
 func (nc *NoopCollector) MessageSent(engine string, message string)                              {}
 func (nc *NoopCollector) MessageReceived(engine string, message string)                          {}
 func (nc *NoopCollector) MessageHandled(engine string, message string)                           {}
+func (nc *NoopCollector) MessageDeadlineExceeded(engine string, message string)                  {}
+func (nc *NoopCollector) MessageDeduplicated(engine string, message string)                      {}
 func (nc *NoopCollector) OutboundConnections(_ uint)                                             {}
 func (nc *NoopCollector) InboundConnections(_ uint)                                              {}
 func (nc *NoopCollector) DNSLookupDuration(duration time.Duration)                               {}
-func (nc *NoopCollector) OnDNSCacheMiss()                                                        {}
-func (nc *NoopCollector) OnDNSCacheInvalidated()                                                 {}
-func (nc *NoopCollector) OnDNSCacheHit()                                                         {}
+func (nc *NoopCollector) OnDNSCacheMiss(lookupType string)                                       {}
+func (nc *NoopCollector) OnDNSCacheInvalidated(lookupType string)                                {}
+func (nc *NoopCollector) OnDNSCacheHit(lookupType string)                                        {}
+func (nc *NoopCollector) OnDNSLookupError(lookupType string)                                     {}
+func (nc *NoopCollector) MisbehaviorReported(reason string)                                      {}
+func (nc *NoopCollector) MisbehaviorReportDropped(reason string, cause string)                   {}
 func (nc *NoopCollector) UnstakedOutboundConnections(_ uint)                                     {}
 func (nc *NoopCollector) UnstakedInboundConnections(_ uint)                                      {}
 func (nc *NoopCollector) RanGC(duration time.Duration)                                           {}
@@ -58,10 +65,13 @@ func (nc *NoopCollector) CurrentDKGPhase1FinalView(view uint64)
 func (nc *NoopCollector) CurrentDKGPhase2FinalView(view uint64)                                  {}
 func (nc *NoopCollector) CurrentDKGPhase3FinalView(view uint64)                                  {}
 func (nc *NoopCollector) EpochEmergencyFallbackTriggered()                                       {}
+func (nc *NoopCollector) RejectedEpochPhaseTransition(from flow.EpochPhase, to flow.EpochPhase)  {}
 func (nc *NoopCollector) CacheEntries(resource string, entries uint)                             {}
 func (nc *NoopCollector) CacheHit(resource string)                                               {}
 func (nc *NoopCollector) CacheNotFound(resource string)                                          {}
 func (nc *NoopCollector) CacheMiss(resource string)                                              {}
+func (nc *NoopCollector) MempoolEjections(resource string, ejections uint)                       {}
+func (nc *NoopCollector) MempoolSaturated(resource string, duration time.Duration)               {}
 func (nc *NoopCollector) MempoolEntries(resource string, entries uint)                           {}
 func (nc *NoopCollector) Register(resource string, entriesFunc module.EntriesFunc) error         { return nil }
 func (nc *NoopCollector) HotStuffBusyDuration(duration time.Duration, event string)              {}
@@ -77,6 +87,7 @@ func (nc *NoopCollector) SignerProcessingDuration(duration time.Duration)
 func (nc *NoopCollector) ValidatorProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) PayloadProductionDuration(duration time.Duration)                       {}
 func (nc *NoopCollector) TransactionIngested(txID flow.Identifier)                               {}
+func (nc *NoopCollector) TransactionRejected(reason string)                                      {}
 func (nc *NoopCollector) ClusterBlockProposed(*cluster.Block)                                    {}
 func (nc *NoopCollector) ClusterBlockFinalized(*cluster.Block)                                   {}
 func (nc *NoopCollector) StartCollectionToFinalized(collectionID flow.Identifier)                {}
@@ -84,9 +95,17 @@ func (nc *NoopCollector) FinishCollectionToFinalized(collectionID flow.Identifie
 func (nc *NoopCollector) StartBlockToSeal(blockID flow.Identifier)                               {}
 func (nc *NoopCollector) FinishBlockToSeal(blockID flow.Identifier)                              {}
 func (nc *NoopCollector) EmergencySeal()                                                         {}
+func (nc *NoopCollector) EmergencySealed(height uint64)                                          {}
 func (nc *NoopCollector) OnReceiptProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) OnApprovalProcessingDuration(duration time.Duration)                    {}
 func (nc *NoopCollector) CheckSealingDuration(duration time.Duration)                            {}
+func (nc *NoopCollector) BeaconKeyAvailable(epoch uint64, available bool)                        {}
+func (nc *NoopCollector) MatchingStorageFailure(operation string)                                {}
+func (nc *NoopCollector) ExecutionForkDetected()                                                 {}
+func (nc *NoopCollector) InvalidSubmission(originRole string, reason string)                     {}
+func (nc *NoopCollector) InvalidApproval()                                                       {}
+func (nc *NoopCollector) BuilderPayloadSection(section string, included uint, skipped uint)      {}
+func (nc *NoopCollector) RejectedGuarantee(reason string)                                        {}
 func (nc *NoopCollector) OnExecutionResultReceivedAtAssignerEngine()                             {}
 func (nc *NoopCollector) OnVerifiableChunkReceivedAtVerifierEngine()                             {}
 func (nc *NoopCollector) OnResultApprovalDispatchedInNetworkByVerifier()                         {}
@@ -99,6 +118,7 @@ func (nc *NoopCollector) OnAssignedChunkReceivedAtFetcher()
 func (nc *NoopCollector) OnChunkDataPackRequestDispatchedInNetworkByRequester()                 {}
 func (nc *NoopCollector) OnChunkDataPackRequestSentByFetcher()                                  {}
 func (nc *NoopCollector) OnChunkDataPackRequestReceivedByRequester()                            {}
+func (nc *NoopCollector) OnChunkDataPackRequestTimedOutAtRequester()                            {}
 func (nc *NoopCollector) OnChunkDataPackArrivedAtFetcher()                                      {}
 func (nc *NoopCollector) OnChunkDataPackSentToFetcher()                                         {}
 func (nc *NoopCollector) OnVerifiableChunkSentToVerifier()                                      {}
@@ -142,9 +162,12 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)              {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                               {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                                          {}
+func (nc *NoopCollector) ScriptExecutionCacheHit()                                              {}
+func (nc *NoopCollector) ScriptExecutionCacheMiss()                                             {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                               {}
 func (nc *NoopCollector) ExecutionSync(syncing bool)                                            {}
 func (nc *NoopCollector) DiskSize(uint64)                                                       {}
+func (nc *NoopCollector) ExecutionCheckpointCreated(time.Duration, uint, uint64)                {}
 func (nc *NoopCollector) ExecutionBlockDataUploadStarted()                                      {}
 func (nc *NoopCollector) ExecutionBlockDataUploadFinished(dur time.Duration)                    {}
 func (nc *NoopCollector) ExecutionDataAddStarted()                                              {}
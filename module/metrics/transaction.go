@@ -21,6 +21,7 @@ type TransactionCollector struct {
 	timeToExecuted             prometheus.Summary
 	timeToFinalizedExecuted    prometheus.Summary
 	transactionSubmission      *prometheus.CounterVec
+	scriptExecutionCache       *prometheus.CounterVec
 }
 
 func NewTransactionCollector(transactionTimings mempool.TransactionTimings, log zerolog.Logger,
@@ -81,6 +82,12 @@ func NewTransactionCollector(transactionTimings mempool.TransactionTimings, log
 			Subsystem: subsystemTransactionSubmission,
 			Help:      "counter for the success/failure of transaction submissions",
 		}, []string{"result"}),
+		scriptExecutionCache: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "cache",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptExecution,
+			Help:      "counter for the hits/misses of the script execution result cache",
+		}, []string{"result"}),
 	}
 
 	return tc
@@ -197,6 +204,14 @@ func (tc *TransactionCollector) TransactionSubmissionFailed() {
 	tc.transactionSubmission.WithLabelValues("failed").Inc()
 }
 
+func (tc *TransactionCollector) ScriptExecutionCacheHit() {
+	tc.scriptExecutionCache.WithLabelValues("hit").Inc()
+}
+
+func (tc *TransactionCollector) ScriptExecutionCacheMiss() {
+	tc.scriptExecutionCache.WithLabelValues("miss").Inc()
+}
+
 func (tc *TransactionCollector) TransactionExpired(txID flow.Identifier) {
 	_, exist := tc.transactionTimings.ByID(txID)
 
@@ -21,3 +21,11 @@ func (ec *EngineCollector) MessageReceived(engine string, message string) {
 func (ec *EngineCollector) MessageHandled(engine string, message string) {
 	ec.metrics.MessageHandled("unstaked_"+engine, message)
 }
+
+func (ec *EngineCollector) MessageDeadlineExceeded(engine string, message string) {
+	ec.metrics.MessageDeadlineExceeded("unstaked_"+engine, message)
+}
+
+func (ec *EngineCollector) MessageDeduplicated(engine string, message string) {
+	ec.metrics.MessageDeduplicated("unstaked_"+engine, message)
+}
@@ -33,6 +33,8 @@ type VerificationCollector struct {
 	sentChunkDataRequestMessagesTotalRequester prometheus.Counter
 	// total number of chunk data response messages received by requester from network.
 	receivedChunkDataResponseMessageTotalRequester prometheus.Counter
+	// total number of chunk data pack requests given up by requester engine after the maximum request duration.
+	timedOutChunkDataPackRequestsTotalRequester prometheus.Counter
 	// total number of chunk data pack sent by requester to fetcher engine.
 	sentChunkDataPackByRequesterTotal prometheus.Counter
 	// maximum number of attempts made for requesting a chunk data pack belonging to the next unsealed height.
@@ -119,6 +121,13 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		Help:      "total number of chunk data response messages received from network by requester engine",
 	})
 
+	timedOutChunkDataPackRequestsTotalRequester := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "chunk_data_pack_request_timed_out_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemRequesterEngine,
+		Help:      "total number of chunk data pack requests given up by requester engine after the maximum request duration",
+	})
+
 	sentChunkDataPackByRequesterTotal := prometheus.NewCounter(prometheus.CounterOpts{
 		Name:      "chunk_data_pack_sent_total",
 		Namespace: namespaceVerification,
@@ -195,6 +204,7 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		receivedChunkDataPackRequestsTotalRequester,
 		sentChunkDataRequestMessagesTotalRequester,
 		receivedChunkDataResponseMessagesTotalRequester,
+		timedOutChunkDataPackRequestsTotalRequester,
 		sentChunkDataPackByRequesterTotal,
 		maxChunkDataPackRequestAttemptForNextUnsealedHeight,
 
@@ -229,6 +239,7 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		receivedChunkDataPackRequestsTotalRequester:         receivedChunkDataPackRequestsTotalRequester,
 		sentChunkDataRequestMessagesTotalRequester:          sentChunkDataRequestMessagesTotalRequester,
 		receivedChunkDataResponseMessageTotalRequester:      receivedChunkDataResponseMessagesTotalRequester,
+		timedOutChunkDataPackRequestsTotalRequester:         timedOutChunkDataPackRequestsTotalRequester,
 		sentChunkDataPackByRequesterTotal:                   sentChunkDataPackByRequesterTotal,
 		maxChunkDataPackRequestAttemptForNextUnsealedHeight: maxChunkDataPackRequestAttemptForNextUnsealedHeight,
 	}
@@ -304,6 +315,12 @@ func (vc *VerificationCollector) OnChunkDataPackResponseReceivedFromNetworkByReq
 	vc.receivedChunkDataResponseMessageTotalRequester.Inc()
 }
 
+// OnChunkDataPackRequestTimedOutAtRequester increments a counter that keeps track of number of chunk data pack requests that the
+// requester engine gives up on, as no execution node returned the chunk data pack within the maximum request duration.
+func (vc *VerificationCollector) OnChunkDataPackRequestTimedOutAtRequester() {
+	vc.timedOutChunkDataPackRequestsTotalRequester.Inc()
+}
+
 // OnChunkDataPackSentToFetcher increases a counter that keeps track of number of chunk data packs sent to the fetcher engine from
 // requester engine.
 func (vc *VerificationCollector) OnChunkDataPackSentToFetcher() {
@@ -0,0 +1,15 @@
+package module
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MisbehaviorReporter collects reports of protocol violations by other nodes.
+type MisbehaviorReporter interface {
+
+	// Report reports a protocol violation with the given reason by the node with the
+	// given origin ID. The evidence entities are referenced by ID in the report.
+	// Reporting never fails; reports might be dropped, e.g. if they are duplicates
+	// of recent reports.
+	Report(originID flow.Identifier, reason flow.MisbehaviorReason, evidence ...flow.Entity)
+}
@@ -0,0 +1,17 @@
+package misbehavior
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// NoopReporter is a misbehavior reporter which drops all reports.
+type NoopReporter struct{}
+
+var _ module.MisbehaviorReporter = (*NoopReporter)(nil)
+
+func NewNoopReporter() *NoopReporter {
+	return &NoopReporter{}
+}
+
+func (n *NoopReporter) Report(flow.Identifier, flow.MisbehaviorReason, ...flow.Entity) {}
@@ -0,0 +1,204 @@
+package misbehavior
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Config defines the configurable options of the misbehavior reporting pipeline.
+type Config struct {
+	// DedupWindow is the duration for which identical reports (same origin, reason and evidence)
+	// are dropped after a report was accepted.
+	DedupWindow time.Duration
+	// RateLimit is the sustained number of reports per second which are accepted per origin.
+	RateLimit rate.Limit
+	// RateBurst is the maximum number of reports which are accepted at once per origin.
+	RateBurst int
+	// Retention is the duration for which accepted reports are kept in storage.
+	Retention time.Duration
+	// PruneInterval is the interval at which reports beyond the retention are removed from storage.
+	PruneInterval time.Duration
+}
+
+// DefaultConfig returns the default configuration of the misbehavior reporting pipeline.
+func DefaultConfig() Config {
+	return Config{
+		DedupWindow:   10 * time.Minute,
+		RateLimit:     rate.Every(10 * time.Second),
+		RateBurst:     10,
+		Retention:     7 * 24 * time.Hour,
+		PruneInterval: time.Hour,
+	}
+}
+
+// originState holds the rate limiter of an origin.
+type originState struct {
+	limiter    *rate.Limiter
+	lastReport time.Time
+}
+
+// Pipeline is the shared misbehavior reporting pipeline. Producers report protocol violations
+// of other nodes, the pipeline deduplicates identical reports within the dedup window, limits
+// the rate of accepted reports per origin to bound the storage, and persists the accepted
+// reports for the retention period, so they can be queried by operators.
+type Pipeline struct {
+	unit    *engine.Unit
+	log     zerolog.Logger
+	metrics module.MisbehaviorMetrics
+	reports storage.MisbehaviorReports
+	config  Config
+	now     func() time.Time
+
+	mu      sync.Mutex
+	recent  map[flow.Identifier]time.Time // dedup key -> time of the last accepted report
+	origins map[flow.Identifier]*originState
+}
+
+var _ module.MisbehaviorReporter = (*Pipeline)(nil)
+
+// NewPipeline creates a new misbehavior reporting pipeline persisting reports to the given storage.
+func NewPipeline(log zerolog.Logger, metrics module.MisbehaviorMetrics, reports storage.MisbehaviorReports, config Config) *Pipeline {
+	return &Pipeline{
+		unit:    engine.NewUnit(),
+		log:     log.With().Str("module", "misbehavior_pipeline").Logger(),
+		metrics: metrics,
+		reports: reports,
+		config:  config,
+		now:     time.Now,
+		recent:  make(map[flow.Identifier]time.Time),
+		origins: make(map[flow.Identifier]*originState),
+	}
+}
+
+// Ready returns a ready channel that is closed once the pipeline has started
+// pruning reports beyond the retention period.
+func (p *Pipeline) Ready() <-chan struct{} {
+	p.unit.LaunchPeriodically(p.prune, p.config.PruneInterval, 0)
+	return p.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the pipeline has stopped.
+func (p *Pipeline) Done() <-chan struct{} {
+	return p.unit.Done()
+}
+
+// Report reports a protocol violation with the given reason by the node with the given
+// origin ID. Duplicates of reports accepted within the dedup window, and reports of origins
+// which exceeded their rate limit, are dropped. Accepted reports are persisted.
+func (p *Pipeline) Report(originID flow.Identifier, reason flow.MisbehaviorReason, evidence ...flow.Entity) {
+	evidenceIDs := make([]flow.Identifier, 0, len(evidence))
+	for _, entity := range evidence {
+		evidenceIDs = append(evidenceIDs, entity.ID())
+	}
+	report := &flow.MisbehaviorReport{
+		OriginID:    originID,
+		Reason:      reason,
+		EvidenceIDs: evidenceIDs,
+		Timestamp:   p.now().UTC(),
+	}
+
+	log := p.log.With().
+		Hex("origin_id", originID[:]).
+		Str("reason", string(reason)).
+		Logger()
+
+	accepted, cause := p.accept(report)
+	if !accepted {
+		p.metrics.MisbehaviorReportDropped(string(reason), cause)
+		log.Debug().Str("cause", cause).Msg("dropping misbehavior report")
+		return
+	}
+
+	err := p.reports.Store(report)
+	if err != nil {
+		log.Error().Err(err).Msg("could not persist misbehavior report")
+		return
+	}
+	p.metrics.MisbehaviorReported(string(reason))
+
+	log.Warn().
+		Str("evidence_ids", fmt.Sprintf("%v", evidenceIDs)).
+		Msg("misbehavior reported")
+}
+
+// accept checks whether the given report is neither a duplicate of a recently accepted
+// report, nor exceeds the rate limit of its origin. If the report is accepted, it is
+// remembered for deduplication. Otherwise, the cause for dropping it is returned.
+func (p *Pipeline) accept(report *flow.MisbehaviorReport) (bool, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := report.Timestamp
+	key := report.Key()
+	last, ok := p.recent[key]
+	if ok && now.Sub(last) < p.config.DedupWindow {
+		return false, metrics.MisbehaviorDropCauseDuplicate
+	}
+
+	origin, ok := p.origins[report.OriginID]
+	if !ok {
+		origin = &originState{
+			limiter: rate.NewLimiter(p.config.RateLimit, p.config.RateBurst),
+		}
+		p.origins[report.OriginID] = origin
+	}
+	if !origin.limiter.AllowN(now, 1) {
+		return false, metrics.MisbehaviorDropCauseRateLimited
+	}
+
+	origin.lastReport = now
+	p.recent[key] = now
+	return true, ""
+}
+
+// Query returns the persisted reports for the given origin which were reported at or after the
+// given time. If the origin is flow.ZeroID, the reports of all origins are returned.
+func (p *Pipeline) Query(originID flow.Identifier, since time.Time) ([]*flow.MisbehaviorReport, error) {
+	if originID == flow.ZeroID {
+		return p.reports.All(since)
+	}
+	return p.reports.ByOriginID(originID, since)
+}
+
+// prune removes the reports beyond the retention period from storage, and forgets about the
+// reports and origins which are no longer relevant for deduplication and rate limiting.
+func (p *Pipeline) prune() {
+	now := p.now()
+
+	removed, err := p.reports.PruneBefore(now.Add(-p.config.Retention))
+	if err != nil {
+		p.log.Error().Err(err).Msg("could not prune misbehavior reports")
+	} else if removed > 0 {
+		p.log.Info().Uint("removed", removed).Msg("pruned misbehavior reports beyond retention")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, last := range p.recent {
+		if now.Sub(last) >= p.config.DedupWindow {
+			delete(p.recent, key)
+		}
+	}
+	// an origin whose last accepted report is older than the time to refill its
+	// burst has a full limiter again, which is identical to a new limiter
+	if p.config.RateLimit <= 0 {
+		return
+	}
+	refill := time.Duration(float64(p.config.RateBurst) / float64(p.config.RateLimit) * float64(time.Second))
+	for originID, origin := range p.origins {
+		if now.Sub(origin.lastReport) >= refill {
+			delete(p.origins, originID)
+		}
+	}
+}
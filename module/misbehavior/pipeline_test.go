@@ -0,0 +1,187 @@
+package misbehavior
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// clock is a manually advanced clock for testing time-dependent behaviour of the pipeline.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestPipeline(db *badger.DB, config Config) (*Pipeline, *clock) {
+	c := &clock{now: time.Unix(1_600_000_000, 0)}
+	p := NewPipeline(unittest.Logger(), metrics.NewNoopCollector(), bstorage.NewMisbehaviorReports(db), config)
+	p.now = c.Now
+	return p, c
+}
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.RateLimit = rate.Inf
+	return config
+}
+
+func query(t *testing.T, p *Pipeline, originID flow.Identifier, since time.Time) []*flow.MisbehaviorReport {
+	reports, err := p.Query(originID, since)
+	require.NoError(t, err)
+	return reports
+}
+
+// TestPipeline_Dedup tests that identical reports are dropped within the dedup window,
+// while reports with a different reason or evidence are accepted.
+func TestPipeline_Dedup(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		p, c := newTestPipeline(db, testConfig())
+		originID := unittest.IdentifierFixture()
+		receipt := unittest.ExecutionReceiptFixture()
+
+		p.Report(originID, flow.MisbehaviorInvalidReceipt, receipt)
+		c.Advance(time.Minute)
+		p.Report(originID, flow.MisbehaviorInvalidReceipt, receipt)
+		require.Len(t, query(t, p, originID, time.Time{}), 1)
+
+		// different reason and different evidence are not duplicates
+		p.Report(originID, flow.MisbehaviorMalformedReceipt, receipt)
+		p.Report(originID, flow.MisbehaviorInvalidReceipt, unittest.ExecutionReceiptFixture())
+		require.Len(t, query(t, p, originID, time.Time{}), 3)
+
+		// after the dedup window, the report is accepted again
+		c.Advance(p.config.DedupWindow)
+		p.Report(originID, flow.MisbehaviorInvalidReceipt, receipt)
+		require.Len(t, query(t, p, originID, time.Time{}), 4)
+	})
+}
+
+// TestPipeline_RateLimit tests that reports are rate limited per origin.
+func TestPipeline_RateLimit(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		config := DefaultConfig()
+		config.RateLimit = rate.Every(time.Second)
+		config.RateBurst = 3
+		p, c := newTestPipeline(db, config)
+		originID := unittest.IdentifierFixture()
+		otherID := unittest.IdentifierFixture()
+
+		for i := 0; i < 10; i++ {
+			p.Report(originID, flow.MisbehaviorChannelViolation, unittest.ExecutionReceiptFixture())
+		}
+		require.Len(t, query(t, p, originID, time.Time{}), 3)
+
+		// other origins have their own limit
+		p.Report(otherID, flow.MisbehaviorChannelViolation, unittest.ExecutionReceiptFixture())
+		require.Len(t, query(t, p, otherID, time.Time{}), 1)
+
+		// the limit refills over time
+		c.Advance(2 * time.Second)
+		for i := 0; i < 10; i++ {
+			p.Report(originID, flow.MisbehaviorChannelViolation, unittest.ExecutionReceiptFixture())
+		}
+		require.Len(t, query(t, p, originID, time.Time{}), 5)
+	})
+}
+
+// TestPipeline_Persistence tests that reports are still queryable after a restart.
+func TestPipeline_Persistence(t *testing.T) {
+	dir := unittest.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	originID := unittest.IdentifierFixture()
+	receipt := unittest.ExecutionReceiptFixture()
+
+	db := unittest.BadgerDB(t, dir)
+	p, _ := newTestPipeline(db, testConfig())
+	p.Report(originID, flow.MisbehaviorInvalidReceipt, receipt)
+	expected := query(t, p, originID, time.Time{})
+	require.Len(t, expected, 1)
+	require.NoError(t, db.Close())
+
+	db = unittest.BadgerDB(t, dir)
+	defer db.Close()
+	p, _ = newTestPipeline(db, testConfig())
+	reports := query(t, p, originID, time.Time{})
+	require.Len(t, reports, 1)
+	assert.Equal(t, expected[0].ID(), reports[0].ID())
+	assert.Equal(t, originID, reports[0].OriginID)
+	assert.Equal(t, flow.MisbehaviorInvalidReceipt, reports[0].Reason)
+	assert.Equal(t, []flow.Identifier{receipt.ID()}, reports[0].EvidenceIDs)
+	assert.True(t, expected[0].Timestamp.Equal(reports[0].Timestamp))
+}
+
+// TestPipeline_Retention tests that pruning removes the reports beyond the retention period.
+func TestPipeline_Retention(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		config := testConfig()
+		config.Retention = time.Hour
+		p, c := newTestPipeline(db, config)
+		originID := unittest.IdentifierFixture()
+
+		p.Report(originID, flow.MisbehaviorInvalidReceipt, unittest.ExecutionReceiptFixture())
+		c.Advance(30 * time.Minute)
+		p.Report(originID, flow.MisbehaviorInvalidReceipt, unittest.ExecutionReceiptFixture())
+		c.Advance(45 * time.Minute)
+
+		p.prune()
+		reports := query(t, p, originID, time.Time{})
+		require.Len(t, reports, 1)
+		assert.True(t, c.Now().Add(-45*time.Minute).Equal(reports[0].Timestamp))
+
+		c.Advance(time.Hour)
+		p.prune()
+		require.Empty(t, query(t, p, originID, time.Time{}))
+	})
+}
+
+// TestPipeline_Query tests filtering reports by origin and time.
+func TestPipeline_Query(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		p, c := newTestPipeline(db, testConfig())
+		origin1 := unittest.IdentifierFixture()
+		origin2 := unittest.IdentifierFixture()
+
+		start := c.Now()
+		p.Report(origin1, flow.MisbehaviorInvalidReceipt, unittest.ExecutionReceiptFixture())
+		p.Report(origin2, flow.MisbehaviorInvalidReceipt, unittest.ExecutionReceiptFixture())
+		c.Advance(time.Minute)
+		since := c.Now()
+		p.Report(origin1, flow.MisbehaviorChannelViolation)
+		p.Report(origin2, flow.MisbehaviorChannelViolation)
+		p.Report(origin2, flow.MisbehaviorMalformedReceipt, unittest.ExecutionReceiptFixture())
+
+		assert.Len(t, query(t, p, origin1, start), 2)
+		assert.Len(t, query(t, p, origin2, start), 3)
+		assert.Len(t, query(t, p, flow.ZeroID, start), 5)
+
+		reports := query(t, p, origin1, since)
+		require.Len(t, reports, 1)
+		assert.Equal(t, flow.MisbehaviorChannelViolation, reports[0].Reason)
+		assert.Len(t, query(t, p, origin2, since), 2)
+		assert.Len(t, query(t, p, flow.ZeroID, since), 3)
+
+		for _, report := range query(t, p, flow.ZeroID, since) {
+			assert.False(t, report.Timestamp.Before(since))
+		}
+		assert.Empty(t, query(t, p, unittest.IdentifierFixture(), start))
+		assert.Empty(t, query(t, p, flow.ZeroID, c.Now().Add(time.Second)))
+	})
+}
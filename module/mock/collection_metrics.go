@@ -28,3 +28,8 @@ func (_m *CollectionMetrics) ClusterBlockProposed(block *cluster.Block) {
 func (_m *CollectionMetrics) TransactionIngested(txID flow.Identifier) {
 	_m.Called(txID)
 }
+
+// TransactionRejected provides a mock function with given fields: reason
+func (_m *CollectionMetrics) TransactionRejected(reason string) {
+	_m.Called(reason)
+}
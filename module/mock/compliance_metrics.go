@@ -74,6 +74,11 @@ func (_m *ComplianceMetrics) FinalizedHeight(height uint64) {
 	_m.Called(height)
 }
 
+// RejectedEpochPhaseTransition provides a mock function with given fields: from, to
+func (_m *ComplianceMetrics) RejectedEpochPhaseTransition(from flow.EpochPhase, to flow.EpochPhase) {
+	_m.Called(from, to)
+}
+
 // SealedHeight provides a mock function with given fields: height
 func (_m *ComplianceMetrics) SealedHeight(height uint64) {
 	_m.Called(height)
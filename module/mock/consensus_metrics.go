@@ -14,6 +14,16 @@ type ConsensusMetrics struct {
 	mock.Mock
 }
 
+// BeaconKeyAvailable provides a mock function with given fields: epoch, available
+func (_m *ConsensusMetrics) BeaconKeyAvailable(epoch uint64, available bool) {
+	_m.Called(epoch, available)
+}
+
+// BuilderPayloadSection provides a mock function with given fields: section, included, skipped
+func (_m *ConsensusMetrics) BuilderPayloadSection(section string, included uint, skipped uint) {
+	_m.Called(section, included, skipped)
+}
+
 // CheckSealingDuration provides a mock function with given fields: duration
 func (_m *ConsensusMetrics) CheckSealingDuration(duration time.Duration) {
 	_m.Called(duration)
@@ -24,6 +34,16 @@ func (_m *ConsensusMetrics) EmergencySeal() {
 	_m.Called()
 }
 
+// EmergencySealed provides a mock function with given fields: height
+func (_m *ConsensusMetrics) EmergencySealed(height uint64) {
+	_m.Called(height)
+}
+
+// ExecutionForkDetected provides a mock function with given fields:
+func (_m *ConsensusMetrics) ExecutionForkDetected() {
+	_m.Called()
+}
+
 // FinishBlockToSeal provides a mock function with given fields: blockID
 func (_m *ConsensusMetrics) FinishBlockToSeal(blockID flow.Identifier) {
 	_m.Called(blockID)
@@ -34,6 +54,21 @@ func (_m *ConsensusMetrics) FinishCollectionToFinalized(collectionID flow.Identi
 	_m.Called(collectionID)
 }
 
+// InvalidApproval provides a mock function with given fields:
+func (_m *ConsensusMetrics) InvalidApproval() {
+	_m.Called()
+}
+
+// InvalidSubmission provides a mock function with given fields: originRole, reason
+func (_m *ConsensusMetrics) InvalidSubmission(originRole string, reason string) {
+	_m.Called(originRole, reason)
+}
+
+// MatchingStorageFailure provides a mock function with given fields: operation
+func (_m *ConsensusMetrics) MatchingStorageFailure(operation string) {
+	_m.Called(operation)
+}
+
 // OnApprovalProcessingDuration provides a mock function with given fields: duration
 func (_m *ConsensusMetrics) OnApprovalProcessingDuration(duration time.Duration) {
 	_m.Called(duration)
@@ -44,6 +79,11 @@ func (_m *ConsensusMetrics) OnReceiptProcessingDuration(duration time.Duration)
 	_m.Called(duration)
 }
 
+// RejectedGuarantee provides a mock function with given fields: reason
+func (_m *ConsensusMetrics) RejectedGuarantee(reason string) {
+	_m.Called(reason)
+}
+
 // StartBlockToSeal provides a mock function with given fields: blockID
 func (_m *ConsensusMetrics) StartBlockToSeal(blockID flow.Identifier) {
 	_m.Called(blockID)
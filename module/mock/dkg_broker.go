@@ -61,6 +61,20 @@ func (_m *DKGBroker) GetIndex() int {
 	return r0
 }
 
+// MessageOffset provides a mock function with given fields:
+func (_m *DKGBroker) MessageOffset() uint {
+	ret := _m.Called()
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
 // GetPrivateMsgCh provides a mock function with given fields:
 func (_m *DKGBroker) GetPrivateMsgCh() <-chan messages.DKGMessage {
 	ret := _m.Called()
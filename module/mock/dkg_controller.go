@@ -14,6 +14,11 @@ type DKGController struct {
 	mock.Mock
 }
 
+// Abort provides a mock function with given fields:
+func (_m *DKGController) Abort() {
+	_m.Called()
+}
+
 // End provides a mock function with given fields:
 func (_m *DKGController) End() error {
 	ret := _m.Called()
@@ -36,3 +36,26 @@ func (_m *DKGControllerFactory) Create(dkgInstanceID string, participants flow.I
 
 	return r0, r1
 }
+
+// CreateFromCheckpoint provides a mock function with given fields: dkgInstanceID, data
+func (_m *DKGControllerFactory) CreateFromCheckpoint(dkgInstanceID string, data []byte) (module.DKGController, error) {
+	ret := _m.Called(dkgInstanceID, data)
+
+	var r0 module.DKGController
+	if rf, ok := ret.Get(0).(func(string, []byte) module.DKGController); ok {
+		r0 = rf(dkgInstanceID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(module.DKGController)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(dkgInstanceID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -9,6 +9,16 @@ type EngineMetrics struct {
 	mock.Mock
 }
 
+// MessageDeadlineExceeded provides a mock function with given fields: engine, message
+func (_m *EngineMetrics) MessageDeadlineExceeded(engine string, message string) {
+	_m.Called(engine, message)
+}
+
+// MessageDeduplicated provides a mock function with given fields: engine, message
+func (_m *EngineMetrics) MessageDeduplicated(engine string, message string) {
+	_m.Called(engine, message)
+}
+
 // MessageHandled provides a mock function with given fields: engine, messages
 func (_m *EngineMetrics) MessageHandled(engine string, messages string) {
 	_m.Called(engine, messages)
@@ -39,6 +39,11 @@ func (_m *ExecutionMetrics) ExecutionBlockExecuted(dur time.Duration, compUsed u
 	_m.Called(dur, compUsed, txCounts, colCounts)
 }
 
+// ExecutionCheckpointCreated provides a mock function with given fields: duration, segments, fileSize
+func (_m *ExecutionMetrics) ExecutionCheckpointCreated(duration time.Duration, segments uint, fileSize uint64) {
+	_m.Called(duration, segments, fileSize)
+}
+
 // ExecutionCollectionExecuted provides a mock function with given fields: dur, compUsed, txCounts
 func (_m *ExecutionMetrics) ExecutionCollectionExecuted(dur time.Duration, compUsed uint64, txCounts int) {
 	_m.Called(dur, compUsed, txCounts)
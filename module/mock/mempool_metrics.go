@@ -5,6 +5,8 @@ package mock
 import (
 	module "github.com/onflow/flow-go/module"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MempoolMetrics is an autogenerated mock type for the MempoolMetrics type
@@ -12,11 +14,21 @@ type MempoolMetrics struct {
 	mock.Mock
 }
 
+// MempoolEjections provides a mock function with given fields: resource, ejections
+func (_m *MempoolMetrics) MempoolEjections(resource string, ejections uint) {
+	_m.Called(resource, ejections)
+}
+
 // MempoolEntries provides a mock function with given fields: resource, entries
 func (_m *MempoolMetrics) MempoolEntries(resource string, entries uint) {
 	_m.Called(resource, entries)
 }
 
+// MempoolSaturated provides a mock function with given fields: resource, duration
+func (_m *MempoolMetrics) MempoolSaturated(resource string, duration time.Duration) {
+	_m.Called(resource, duration)
+}
+
 // Register provides a mock function with given fields: resource, entriesFunc
 func (_m *MempoolMetrics) Register(resource string, entriesFunc module.EntriesFunc) error {
 	ret := _m.Called(resource, entriesFunc)
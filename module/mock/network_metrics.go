@@ -53,19 +53,24 @@ func (_m *NetworkMetrics) NetworkMessageSent(sizeBytes int, topic string, messag
 	_m.Called(sizeBytes, topic, messageType)
 }
 
-// OnDNSCacheHit provides a mock function with given fields:
-func (_m *NetworkMetrics) OnDNSCacheHit() {
-	_m.Called()
+// OnDNSCacheHit provides a mock function with given fields: lookupType
+func (_m *NetworkMetrics) OnDNSCacheHit(lookupType string) {
+	_m.Called(lookupType)
 }
 
-// OnDNSCacheInvalidated provides a mock function with given fields:
-func (_m *NetworkMetrics) OnDNSCacheInvalidated() {
-	_m.Called()
+// OnDNSCacheInvalidated provides a mock function with given fields: lookupType
+func (_m *NetworkMetrics) OnDNSCacheInvalidated(lookupType string) {
+	_m.Called(lookupType)
 }
 
-// OnDNSCacheMiss provides a mock function with given fields:
-func (_m *NetworkMetrics) OnDNSCacheMiss() {
-	_m.Called()
+// OnDNSCacheMiss provides a mock function with given fields: lookupType
+func (_m *NetworkMetrics) OnDNSCacheMiss(lookupType string) {
+	_m.Called(lookupType)
+}
+
+// OnDNSLookupError provides a mock function with given fields: lookupType
+func (_m *NetworkMetrics) OnDNSLookupError(lookupType string) {
+	_m.Called(lookupType)
 }
 
 // OutboundConnections provides a mock function with given fields: connectionCount
@@ -87,3 +92,13 @@ func (_m *NetworkMetrics) UnstakedInboundConnections(connectionCount uint) {
 func (_m *NetworkMetrics) UnstakedOutboundConnections(connectionCount uint) {
 	_m.Called(connectionCount)
 }
+
+// UnicastFallbackActivated provides a mock function with given fields: topic
+func (_m *NetworkMetrics) UnicastFallbackActivated(topic string) {
+	_m.Called(topic)
+}
+
+// UnicastStreamFailure provides a mock function with given fields: reason
+func (_m *NetworkMetrics) UnicastStreamFailure(reason string) {
+	_m.Called(reason)
+}
@@ -5,6 +5,8 @@ package mock
 import (
 	flow "github.com/onflow/flow-go/model/flow"
 	mock "github.com/stretchr/testify/mock"
+
+	module "github.com/onflow/flow-go/module"
 )
 
 // Requester is an autogenerated mock type for the Requester type
@@ -12,11 +14,21 @@ type Requester struct {
 	mock.Mock
 }
 
+// CancelEntityByID provides a mock function with given fields: entityID
+func (_m *Requester) CancelEntityByID(entityID flow.Identifier) {
+	_m.Called(entityID)
+}
+
 // EntityByID provides a mock function with given fields: entityID, selector
 func (_m *Requester) EntityByID(entityID flow.Identifier, selector flow.IdentityFilter) {
 	_m.Called(entityID, selector)
 }
 
+// EntityByIDWithRetryPolicy provides a mock function with given fields: entityID, selector, policy
+func (_m *Requester) EntityByIDWithRetryPolicy(entityID flow.Identifier, selector flow.IdentityFilter, policy module.RetryPolicy) {
+	_m.Called(entityID, selector, policy)
+}
+
 // Force provides a mock function with given fields:
 func (_m *Requester) Force() {
 	_m.Called()
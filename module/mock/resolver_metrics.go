@@ -18,17 +18,22 @@ func (_m *ResolverMetrics) DNSLookupDuration(duration time.Duration) {
 	_m.Called(duration)
 }
 
-// OnDNSCacheHit provides a mock function with given fields:
-func (_m *ResolverMetrics) OnDNSCacheHit() {
-	_m.Called()
+// OnDNSCacheHit provides a mock function with given fields: lookupType
+func (_m *ResolverMetrics) OnDNSCacheHit(lookupType string) {
+	_m.Called(lookupType)
 }
 
-// OnDNSCacheInvalidated provides a mock function with given fields:
-func (_m *ResolverMetrics) OnDNSCacheInvalidated() {
-	_m.Called()
+// OnDNSCacheInvalidated provides a mock function with given fields: lookupType
+func (_m *ResolverMetrics) OnDNSCacheInvalidated(lookupType string) {
+	_m.Called(lookupType)
 }
 
-// OnDNSCacheMiss provides a mock function with given fields:
-func (_m *ResolverMetrics) OnDNSCacheMiss() {
-	_m.Called()
+// OnDNSCacheMiss provides a mock function with given fields: lookupType
+func (_m *ResolverMetrics) OnDNSCacheMiss(lookupType string) {
+	_m.Called(lookupType)
+}
+
+// OnDNSLookupError provides a mock function with given fields: lookupType
+func (_m *ResolverMetrics) OnDNSLookupError(lookupType string) {
+	_m.Called(lookupType)
 }
@@ -14,6 +14,16 @@ type TransactionMetrics struct {
 	mock.Mock
 }
 
+// ScriptExecutionCacheHit provides a mock function with given fields:
+func (_m *TransactionMetrics) ScriptExecutionCacheHit() {
+	_m.Called()
+}
+
+// ScriptExecutionCacheMiss provides a mock function with given fields:
+func (_m *TransactionMetrics) ScriptExecutionCacheMiss() {
+	_m.Called()
+}
+
 // TransactionExecuted provides a mock function with given fields: txID, when
 func (_m *TransactionMetrics) TransactionExecuted(txID flow.Identifier, when time.Time) {
 	_m.Called(txID, when)
@@ -49,6 +49,11 @@ func (_m *VerificationMetrics) OnChunkDataPackRequestSentByFetcher() {
 	_m.Called()
 }
 
+// OnChunkDataPackRequestTimedOutAtRequester provides a mock function with given fields:
+func (_m *VerificationMetrics) OnChunkDataPackRequestTimedOutAtRequester() {
+	_m.Called()
+}
+
 // OnChunkDataPackResponseReceivedFromNetworkByRequester provides a mock function with given fields:
 func (_m *VerificationMetrics) OnChunkDataPackResponseReceivedFromNetworkByRequester() {
 	_m.Called()
@@ -9,6 +9,7 @@ import (
 	crypto "github.com/onflow/flow-go/crypto"
 	hash "github.com/onflow/flow-go/crypto/hash"
 	flow "github.com/onflow/flow-go/model/flow"
+	module "github.com/onflow/flow-go/module"
 	reflect "reflect"
 )
 
@@ -130,6 +131,18 @@ func (m *MockRequester) EXPECT() *MockRequesterMockRecorder {
 	return m.recorder
 }
 
+// CancelEntityByID mocks base method
+func (m *MockRequester) CancelEntityByID(arg0 flow.Identifier) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelEntityByID", arg0)
+}
+
+// CancelEntityByID indicates an expected call of CancelEntityByID
+func (mr *MockRequesterMockRecorder) CancelEntityByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEntityByID", reflect.TypeOf((*MockRequester)(nil).CancelEntityByID), arg0)
+}
+
 // EntityByID mocks base method
 func (m *MockRequester) EntityByID(arg0 flow.Identifier, arg1 flow.IdentityFilter) {
 	m.ctrl.T.Helper()
@@ -142,6 +155,18 @@ func (mr *MockRequesterMockRecorder) EntityByID(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EntityByID", reflect.TypeOf((*MockRequester)(nil).EntityByID), arg0, arg1)
 }
 
+// EntityByIDWithRetryPolicy mocks base method
+func (m *MockRequester) EntityByIDWithRetryPolicy(arg0 flow.Identifier, arg1 flow.IdentityFilter, arg2 module.RetryPolicy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EntityByIDWithRetryPolicy", arg0, arg1, arg2)
+}
+
+// EntityByIDWithRetryPolicy indicates an expected call of EntityByIDWithRetryPolicy
+func (mr *MockRequesterMockRecorder) EntityByIDWithRetryPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EntityByIDWithRetryPolicy", reflect.TypeOf((*MockRequester)(nil).EntityByIDWithRetryPolicy), arg0, arg1, arg2)
+}
+
 // Force mocks base method
 func (m *MockRequester) Force() {
 	m.ctrl.T.Helper()
@@ -0,0 +1,18 @@
+package module
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ReceiptConsumer consumes the execution receipts, which the matching engine validated and
+// admitted to its receipts mempool.
+type ReceiptConsumer interface {
+
+	// OnReceiptAdmitted is called when the receipt for the given executed block was admitted.
+	// Receipts might be admitted again, after a restart.
+	OnReceiptAdmitted(receipt *flow.ExecutionReceipt, executedBlock *flow.Header)
+
+	// PruneUpToHeight is called when the blocks up to the given height are sealed, so that
+	// the receipts for these blocks are no longer admitted.
+	PruneUpToHeight(height uint64)
+}
@@ -1,9 +1,21 @@
 package module
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
+// RetryPolicy defines how often and how many times a single entity is
+// re-requested by the requester engine. It overrides the global retry
+// parameters of the engine for the entity it is attached to.
+type RetryPolicy struct {
+	InitialDelay  time.Duration // interval after which we retry the request for the first time
+	Multiplier    float64       // factor by which the retry interval grows after each attempt
+	MaxAttempts   uint          // maximum amount of request attempts; use zero for the engine default
+	JitterPercent uint          // percentage by which each retry interval is randomly varied; use zero for none
+}
+
 type Requester interface {
 	// EntityByID will request an entity through the request engine backing
 	// the interface. The additional selector will be applied to the subset
@@ -14,6 +26,16 @@ type Requester interface {
 	// entites by their IDs.
 	EntityByID(entityID flow.Identifier, selector flow.IdentityFilter)
 
+	// EntityByIDWithRetryPolicy works like EntityByID, but retries the request
+	// for the entity according to the given policy instead of the global
+	// retry configuration of the request engine.
+	EntityByIDWithRetryPolicy(entityID flow.Identifier, selector flow.IdentityFilter, policy RetryPolicy)
+
+	// CancelEntityByID removes a pending request for the entity with the given ID.
+	// If the request was already dispatched, a later response for the entity is
+	// ignored. It also cancels data requested through `Query` with the same key.
+	CancelEntityByID(entityID flow.Identifier)
+
 	// Query will request data through the request engine backing the interface.
 	// The additional selector will be applied to the subset
 	// of valid providers for the data and allows finer-grained control
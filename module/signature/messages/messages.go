@@ -0,0 +1,47 @@
+// Package messages defines the canonical messages, which are signed for the
+// protocol artifacts produced by execution and verification nodes, together
+// with their domain separation tags.
+//
+// Producers and verifiers of a signature must construct the signed message with
+// the same function of this package. Changing the bytes returned for any artifact
+// is a protocol change, which invalidates all existing signatures.
+//
+// Collection guarantees have no message in this package, as no single node signs
+// them: the signature of a guarantee is the aggregated signature of the collector
+// votes certifying the cluster block, which contains the collection. These votes are
+// signed and verified by the cluster consensus (see module/finalizer/collection).
+package messages
+
+import (
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var (
+	// ExecutionReceiptTag is the domain tag of executor signatures over execution receipts.
+	ExecutionReceiptTag = encoding.ExecutionReceiptTag
+	// ResultApprovalTag is the domain tag of verifier signatures over attestations and
+	// result approvals.
+	ResultApprovalTag = encoding.ResultApprovalTag
+)
+
+// ExecutionReceiptMessage returns the message signed by the executor of the receipt,
+// which is the ID of the receipt. It excludes the executor signature.
+func ExecutionReceiptMessage(receipt *flow.ExecutionReceiptMeta) []byte {
+	id := receipt.ID()
+	return id[:]
+}
+
+// AttestationMessage returns the message signed by a verifier approving a chunk,
+// which is the ID of the attestation. Seals carry these signatures for each chunk.
+func AttestationMessage(attestation *flow.Attestation) []byte {
+	id := attestation.ID()
+	return id[:]
+}
+
+// ResultApprovalMessage returns the message signed by the verifier over the body of
+// a result approval, which is the ID of the body. It includes the attestation signature.
+func ResultApprovalMessage(body *flow.ResultApprovalBody) []byte {
+	id := body.ID()
+	return id[:]
+}
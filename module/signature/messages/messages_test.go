@@ -0,0 +1,68 @@
+package messages
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// The golden vectors below pin the exact bytes signed for each artifact. A failing
+// test means that signatures produced before the change are no longer valid, so
+// vectors must only be updated as part of a deliberate protocol change.
+
+// identifier returns a fixed identifier filled with the given byte.
+func identifier(b byte) flow.Identifier {
+	var id flow.Identifier
+	for i := range id {
+		id[i] = b
+	}
+	return id
+}
+
+func goldenAttestation() flow.Attestation {
+	return flow.Attestation{
+		BlockID:           identifier(0x01),
+		ExecutionResultID: identifier(0x02),
+		ChunkIndex:        3,
+	}
+}
+
+// TestTags pins the domain tags of all signed artifacts.
+func TestTags(t *testing.T) {
+	assert.Equal(t, "FLOW-V0.0_Execution-Receipt", ExecutionReceiptTag)
+	assert.Equal(t, "FLOW-V0.0_Result-Approval", ResultApprovalTag)
+}
+
+func TestExecutionReceiptMessage(t *testing.T) {
+	receipt := &flow.ExecutionReceiptMeta{
+		ExecutorID:        identifier(0x01),
+		ResultID:          identifier(0x02),
+		Spocks:            []crypto.Signature{{0x03, 0x04}, {0x05}},
+		ExecutorSignature: crypto.Signature{0x06},
+	}
+	golden := "f91243ef6dad20d85cd8ae950a098246ff36fef3d758ebf51651699cc757bcb6"
+	assert.Equal(t, golden, hex.EncodeToString(ExecutionReceiptMessage(receipt)))
+
+	// the executor signature is not part of the message
+	receipt.ExecutorSignature = crypto.Signature{0x07}
+	assert.Equal(t, golden, hex.EncodeToString(ExecutionReceiptMessage(receipt)))
+}
+
+func TestAttestationMessage(t *testing.T) {
+	attestation := goldenAttestation()
+	assert.Equal(t, "e7b48257f9a8a83a0beb2418b9028b81a1062c7df95d940a9c22fab3eb22413a", hex.EncodeToString(AttestationMessage(&attestation)))
+}
+
+func TestResultApprovalMessage(t *testing.T) {
+	body := &flow.ResultApprovalBody{
+		Attestation:          goldenAttestation(),
+		ApproverID:           identifier(0x04),
+		AttestationSignature: crypto.Signature{0x05},
+		Spock:                crypto.Signature{0x06},
+	}
+	assert.Equal(t, "1308c6f068b0811f12323333332ccb2bd1f5e6217cba3aaab6a2b860d618248d", hex.EncodeToString(ResultApprovalMessage(body)))
+}
@@ -3,6 +3,8 @@ package signature
 import (
 	"fmt"
 
+	"github.com/rs/zerolog"
+
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/storage"
@@ -11,15 +13,23 @@ import (
 // EpochAwareSignerStore implements the SignerStore interface. It is epoch
 // aware, and provides the appropriate threshold signers on a per-view basis,
 // using the database to retrieve the relevant beacon keys.
+// For epochs without a beacon key which is safe for signing (e.g. because the
+// DKG failed), the store falls back to a signer producing invalid beacon
+// signature shares. Entering such an epoch is reported once per epoch, through
+// a warning log and the beacon key availability metric.
 type EpochAwareSignerStore struct {
+	log         zerolog.Logger
+	metrics     module.ConsensusMetrics           // used to report beacon key availability by epoch
 	epochLookup module.EpochLookup                // used to fetch epoch counter by view
 	keys        storage.SafeBeaconKeys            // used to fetch beacon private key by epoch
 	signers     map[uint64]module.ThresholdSigner // cache of signers by epoch
 }
 
 // NewEpochAwareSignerStore instantiates a new EpochAwareSignerStore
-func NewEpochAwareSignerStore(epochLookup module.EpochLookup, keys storage.SafeBeaconKeys) *EpochAwareSignerStore {
+func NewEpochAwareSignerStore(log zerolog.Logger, metrics module.ConsensusMetrics, epochLookup module.EpochLookup, keys storage.SafeBeaconKeys) *EpochAwareSignerStore {
 	return &EpochAwareSignerStore{
+		log:         log.With().Str("component", "signer_store").Logger(),
+		metrics:     metrics,
 		epochLookup: epochLookup,
 		keys:        keys,
 		signers:     make(map[uint64]module.ThresholdSigner),
@@ -45,10 +55,17 @@ func (s *EpochAwareSignerStore) GetThresholdSigner(view uint64) (module.Threshol
 		return nil, fmt.Errorf("could not retrieve beacon private key for epoch counter: %v, at view: %v, err: %w", epoch, view, err)
 	}
 
+	s.metrics.BeaconKeyAvailable(epoch, safe)
 	if !safe {
 		// we do not have a consistent beacon key which is safe for signing
-		// CAUTION: for now, we produce explicitly invalid signatures
+		// CAUTION: we produce explicitly invalid signature shares, which keep the
+		// combined signature structurally valid. Peers accept our votes based on the
+		// staking signature only, and exclude our share from the threshold signature.
 		// TODO: in Crypto V2, we will fallback to using staking signatures
+		s.log.Warn().
+			Uint64("epoch", epoch).
+			Uint64("view", view).
+			Msg("no safe random beacon key for epoch, falling back to staking-only signatures")
 		signer = NewThresholdProvider(encoding.RandomBeaconTag, nil)
 	} else {
 		// we have a beacon key which has been explicitly marked safe for use
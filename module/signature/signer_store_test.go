@@ -1,14 +1,18 @@
 package signature_test
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	modmocks "github.com/onflow/flow-go/module/mock"
@@ -36,14 +40,22 @@ func TestGetThresholdSigner(t *testing.T) {
 			err = dkgState.SetDKGEndState(epochCounter, flow.DKGEndStateDKGFailure)
 			require.NoError(t, err)
 
-			signerStore := signature.NewEpochAwareSignerStore(epochLookup, dkgKeys)
+			var logs bytes.Buffer
+			conMetrics := new(modmocks.ConsensusMetrics)
+			conMetrics.On("BeaconKeyAvailable", epochCounter, false).Once()
+			signerStore := signature.NewEpochAwareSignerStore(zerolog.New(&logs), conMetrics, epochLookup, dkgKeys)
 
-			signer, err := signerStore.GetThresholdSigner(uint64(1))
-			require.NoError(t, err)
+			// entering the epoch without a key is reported only once
+			for view := uint64(1); view <= 3; view++ {
+				signer, err := signerStore.GetThresholdSigner(view)
+				require.NoError(t, err)
 
-			signed, err := signer.Sign([]byte{})
-			require.NoError(t, err)
-			assert.Equal(t, signed, crypto.BLSInvalidSignature())
+				signed, err := signer.Sign([]byte{})
+				require.NoError(t, err)
+				assert.Equal(t, signed, crypto.BLSInvalidSignature())
+			}
+			conMetrics.AssertExpectations(t)
+			assert.Equal(t, 1, strings.Count(logs.String(), "falling back to staking-only signatures"))
 		})
 	})
 
@@ -60,7 +72,9 @@ func TestGetThresholdSigner(t *testing.T) {
 			err = dkgState.SetDKGEndState(epochCounter, flow.DKGEndStateInconsistentKey)
 			require.NoError(t, err)
 
-			signerStore := signature.NewEpochAwareSignerStore(epochLookup, dkgKeys)
+			conMetrics := new(modmocks.ConsensusMetrics)
+			conMetrics.On("BeaconKeyAvailable", epochCounter, false).Once()
+			signerStore := signature.NewEpochAwareSignerStore(zerolog.Nop(), conMetrics, epochLookup, dkgKeys)
 
 			signer, err := signerStore.GetThresholdSigner(uint64(1))
 			require.NoError(t, err)
@@ -70,4 +84,38 @@ func TestGetThresholdSigner(t *testing.T) {
 			assert.Equal(t, signed, crypto.BLSInvalidSignature())
 		})
 	})
+
+	t.Run("with safe key", func(t *testing.T) {
+		unittest.RunWithTypedBadgerDB(t, storage.InitSecret, func(db *badger.DB) {
+			metrics := metrics.NewNoopCollector()
+			dkgState, err := storage.NewDKGState(metrics, db)
+			require.NoError(t, err)
+			dkgKeys := storage.NewSafeBeaconPrivateKeys(dkgState)
+
+			// store a key, mark the DKG as successful
+			beaconKey := unittest.RandomBeaconPriv().PrivateKey
+			err = dkgState.InsertMyBeaconPrivateKey(epochCounter, beaconKey)
+			require.NoError(t, err)
+			err = dkgState.SetDKGEndState(epochCounter, flow.DKGEndStateSuccess)
+			require.NoError(t, err)
+
+			var logs bytes.Buffer
+			conMetrics := new(modmocks.ConsensusMetrics)
+			conMetrics.On("BeaconKeyAvailable", epochCounter, true).Once()
+			signerStore := signature.NewEpochAwareSignerStore(zerolog.New(&logs), conMetrics, epochLookup, dkgKeys)
+
+			signer, err := signerStore.GetThresholdSigner(uint64(1))
+			require.NoError(t, err)
+
+			msg := []byte("message")
+			signed, err := signer.Sign(msg)
+			require.NoError(t, err)
+			valid, err := beaconKey.PublicKey().Verify(signed, msg, crypto.NewBLSKMAC(encoding.RandomBeaconTag))
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			conMetrics.AssertExpectations(t)
+			assert.Empty(t, logs.String())
+		})
+	})
 }
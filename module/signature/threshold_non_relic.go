@@ -1,4 +1,4 @@
-// +build !relic
+// +build !relic,!sandbox
 
 package signature
 
@@ -25,7 +25,8 @@ const (
 	//
 
 	// Builder
-	CONBuilderBuildOn SpanName = "con.builder.buildOn"
+	CONBuilderBuildOn     SpanName = "con.builder.buildOn"
+	CONBuilderIncludeSeal SpanName = "con.builder.includeSeal"
 
 	// Finalizer
 	CONFinalizerFinalizeBlock SpanName = "con.finalizer.finalizeBlock"
@@ -41,8 +42,10 @@ const (
 	CONCompOnBlockVote          SpanName = "con.compliance.onBlockVote"
 
 	// Matching
-	CONMatchProcessReceipt    SpanName = "con.matching.processReceipt"
-	CONMatchProcessReceiptVal SpanName = "con.matching.processReceipt.validation"
+	CONMatchProcessReceipt      SpanName = "con.matching.processReceipt"
+	CONMatchProcessReceiptVal   SpanName = "con.matching.processReceipt.validation"
+	CONMatchProcessReceiptStore SpanName = "con.matching.processReceipt.store"
+	CONMatchRequestReceipts     SpanName = "con.matching.requestReceipts"
 
 	// Sealing
 	CONSealingProcessFinalizedBlock           SpanName = "con.sealing.processFinalizedBlock"
@@ -51,6 +54,7 @@ const (
 	CONSealingRequestingPendingApproval       SpanName = "con.sealing.processFinalizedBlock.requestPendingApprovals"
 	CONSealingProcessIncorporatedResult       SpanName = "con.sealing.processIncorporatedResult"
 	CONSealingProcessApproval                 SpanName = "con.sealing.processApproval"
+	CONSealingSealCandidate                   SpanName = "con.sealing.sealCandidate"
 
 	//Follower Engine
 	FollowerOnBlockProposal        SpanName = "follower.onBlockProposal"
@@ -77,6 +81,13 @@ const (
 	COLClusterStateMutatorExtendCheckTransactionsDupes SpanName = "col.state.mutator.extend.transactions.dupes"
 	COLClusterStateMutatorExtendDBInsert               SpanName = "col.state.mutator.extend.dbInsert"
 
+	// Access Node
+	//
+
+	// Ingestion
+	ACCIngestionHandleExecutionReceipt SpanName = "acc.ingestion.handleExecutionReceipt"
+	ACCIngestionProcessFinalizedBlock  SpanName = "acc.ingestion.processFinalizedBlock"
+
 	// Execution Node
 	//
 
@@ -99,6 +110,7 @@ const (
 	EXECommitDelta                        SpanName = "exe.state.commitDelta"
 	EXEGetRegisters                       SpanName = "exe.state.getRegisters"
 	EXEGetRegistersWithProofs             SpanName = "exe.state.getRegistersWithProofs"
+	EXEGetRegistersByOwner                SpanName = "exe.state.getRegistersByOwner"
 	EXEGetExecutionResultID               SpanName = "exe.state.getExecutionResultID"
 	EXEUpdateHighestExecutedBlockIfHigher SpanName = "exe.state.updateHighestExecutedBlockIfHigher"
 	EXEHashEvents                         SpanName = "exe.state.hashEvents"
@@ -0,0 +1,272 @@
+package trace
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RecordingTracer is the implementation of the Tracer interface which records all spans
+// in memory. Like the OpenTracer, it creates one root span per entity, and spans started
+// for an entity are children of its root span. This is mostly useful for testing.
+type RecordingTracer struct {
+	mu    sync.Mutex
+	roots map[flow.Identifier]*RecordingSpan
+	spans []*RecordingSpan
+}
+
+// NewRecordingTracer creates a new recording tracer.
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{
+		roots: make(map[flow.Identifier]*RecordingSpan),
+	}
+}
+
+func (t *RecordingTracer) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+func (t *RecordingTracer) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// Spans returns all recorded spans, including the entity root spans, in the order they
+// were started.
+func (t *RecordingTracer) Spans() []*RecordingSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans := make([]*RecordingSpan, len(t.spans))
+	copy(spans, t.spans)
+	return spans
+}
+
+// SpansByName returns all recorded spans with the given operation name.
+func (t *RecordingTracer) SpansByName(operationName SpanName) []*RecordingSpan {
+	var spans []*RecordingSpan
+	for _, span := range t.Spans() {
+		if span.OperationName() == operationName {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// EntityRoot returns the root span of the given entity, if any span was started for it.
+func (t *RecordingTracer) EntityRoot(entityID flow.Identifier) (*RecordingSpan, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	root, ok := t.roots[entityID]
+	return root, ok
+}
+
+func (t *RecordingTracer) newSpan(operationName SpanName, parent *RecordingSpan) *RecordingSpan {
+	span := &RecordingSpan{
+		tracer:        t,
+		spanID:        rand.Uint64(),
+		operationName: operationName,
+		start:         time.Now(),
+		tags:          make(map[string]interface{}),
+	}
+	if parent != nil {
+		span.parentID = parent.spanID
+		span.entityID = parent.entityID
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return span
+}
+
+func (t *RecordingTracer) entitySpan(
+	ctx context.Context,
+	entityID flow.Identifier,
+	entityType string,
+	spanName SpanName,
+) (opentracing.Span, context.Context, bool) {
+	t.mu.Lock()
+	root, ok := t.roots[entityID]
+	if !ok {
+		// the root span is finished right away, like the entity root spans of the OpenTracer
+		now := time.Now()
+		root = &RecordingSpan{
+			tracer:        t,
+			spanID:        rand.Uint64(),
+			entityID:      entityID,
+			operationName: SpanName(entityType),
+			start:         now,
+			end:           now,
+			tags:          make(map[string]interface{}),
+		}
+		t.roots[entityID] = root
+		t.spans = append(t.spans, root)
+	}
+	t.mu.Unlock()
+
+	ctx = opentracing.ContextWithSpan(ctx, root)
+	return t.newSpan(spanName, root), ctx, true
+}
+
+func (t *RecordingTracer) StartBlockSpan(
+	ctx context.Context,
+	blockID flow.Identifier,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+	return t.entitySpan(ctx, blockID, EntityTypeBlock, spanName)
+}
+
+func (t *RecordingTracer) StartCollectionSpan(
+	ctx context.Context,
+	collectionID flow.Identifier,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+	return t.entitySpan(ctx, collectionID, EntityTypeCollection, spanName)
+}
+
+func (t *RecordingTracer) StartTransactionSpan(
+	ctx context.Context,
+	transactionID flow.Identifier,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+	return t.entitySpan(ctx, transactionID, EntityTypeTransaction, spanName)
+}
+
+func (t *RecordingTracer) StartSpanFromContext(
+	ctx context.Context,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) (opentracing.Span, context.Context) {
+	parent, _ := opentracing.SpanFromContext(ctx).(*RecordingSpan)
+	span := t.newSpan(operationName, parent)
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+func (t *RecordingTracer) StartSpanFromParent(
+	span opentracing.Span,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	parent, _ := span.(*RecordingSpan)
+	return t.newSpan(operationName, parent)
+}
+
+func (t *RecordingTracer) RecordSpanFromParent(
+	span opentracing.Span,
+	operationName SpanName,
+	duration time.Duration,
+	logs []opentracing.LogRecord,
+	opts ...opentracing.StartSpanOption,
+) {
+	parent, _ := span.(*RecordingSpan)
+	sp := t.newSpan(operationName, parent)
+	sp.start = time.Now().Add(-duration)
+	sp.Finish()
+}
+
+// WithSpanFromContext encapsulates executing a function within an span, i.e., it starts a span with the specified SpanName from the context,
+// executes the function f, and finishes the span once the function returns.
+func (t *RecordingTracer) WithSpanFromContext(ctx context.Context,
+	operationName SpanName,
+	f func(),
+	opts ...opentracing.StartSpanOption) {
+	span, _ := t.StartSpanFromContext(ctx, operationName, opts...)
+	defer span.Finish()
+
+	f()
+}
+
+// RecordingSpan is a span recorded by the RecordingTracer.
+type RecordingSpan struct {
+	tracer        *RecordingTracer
+	spanID        uint64
+	parentID      uint64
+	entityID      flow.Identifier
+	operationName SpanName
+	start         time.Time
+	end           time.Time
+	tags          map[string]interface{}
+}
+
+// SpanID returns the unique ID of the span.
+func (s *RecordingSpan) SpanID() uint64 {
+	return s.spanID
+}
+
+// ParentID returns the ID of the parent span, or zero for root spans.
+func (s *RecordingSpan) ParentID() uint64 {
+	return s.parentID
+}
+
+// EntityID returns the ID of the entity, whose root span is an ancestor of the span.
+func (s *RecordingSpan) EntityID() flow.Identifier {
+	return s.entityID
+}
+
+// OperationName returns the name of the span.
+func (s *RecordingSpan) OperationName() SpanName {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	return s.operationName
+}
+
+// Finished returns whether the span was finished.
+func (s *RecordingSpan) Finished() bool {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	return !s.end.IsZero()
+}
+
+// Tag returns the value of the given tag or logged field of the span.
+func (s *RecordingSpan) Tag(key string) (interface{}, bool) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	value, ok := s.tags[key]
+	return value, ok
+}
+
+func (s *RecordingSpan) Finish() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.end = time.Now()
+}
+func (s *RecordingSpan) FinishWithOptions(opts opentracing.FinishOptions) {
+	s.Finish()
+}
+func (s *RecordingSpan) Context() opentracing.SpanContext {
+	return &NoopSpanContext{}
+}
+func (s *RecordingSpan) SetOperationName(operationName string) opentracing.Span {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.operationName = SpanName(operationName)
+	return s
+}
+func (s *RecordingSpan) SetTag(key string, value interface{}) opentracing.Span {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tags[key] = value
+	return s
+}
+func (s *RecordingSpan) LogFields(fields ...log.Field) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, f := range fields {
+		s.tags[f.Key()] = f.Value()
+	}
+}
+func (s *RecordingSpan) LogKV(alternatingKeyValues ...interface{})                   {}
+func (s *RecordingSpan) SetBaggageItem(restrictedKey, value string) opentracing.Span { return s }
+func (s *RecordingSpan) BaggageItem(restrictedKey string) string                     { return "" }
+func (s *RecordingSpan) Tracer() opentracing.Tracer                                  { return nil }
+func (s *RecordingSpan) LogEvent(event string)                                       {}
+func (s *RecordingSpan) LogEventWithPayload(event string, payload interface{})       {}
+func (s *RecordingSpan) Log(data opentracing.LogData)                                {}
@@ -14,6 +14,7 @@ type TraceSpan opentracing.Span
 
 var _ Tracer = &trace.OpenTracer{}
 var _ Tracer = &trace.NoopTracer{}
+var _ Tracer = &trace.RecordingTracer{}
 
 // Tracer interface for tracers in flow. Uses open tracing span definitions
 type Tracer interface {
@@ -0,0 +1,131 @@
+package updatable_configs
+
+import (
+	"math"
+	"time"
+)
+
+// GetUintConfigFunc returns the current value of a uint field.
+type GetUintConfigFunc func() uint
+
+// SetUintConfigFunc sets the value of a uint field.
+type SetUintConfigFunc func(uint) error
+
+// ValidateUintConfigFunc returns an error if the given value is invalid for a uint field.
+// A nil validator accepts all values.
+type ValidateUintConfigFunc func(uint) error
+
+// RegisterUintConfig registers a uint field. Its JSON representation is a non-negative integer.
+func (m *Manager) RegisterUintConfig(name string, get GetUintConfigFunc, set SetUintConfigFunc, validate ValidateUintConfigFunc) error {
+	return m.register(&field{
+		name: name,
+		typ:  "uint",
+		get: func() interface{} {
+			return get()
+		},
+		set: func(value interface{}) error {
+			return set(value.(uint))
+		},
+		validate: func(value interface{}) error {
+			if validate == nil {
+				return nil
+			}
+			return validate(value.(uint))
+		},
+		parse: func(raw interface{}) (interface{}, error) {
+			number, ok := raw.(float64)
+			if !ok || number < 0 || number > math.MaxInt64 || math.Trunc(number) != number {
+				return nil, NewValidationErrorf("invalid value %v for %s: expected a non-negative integer", raw, name)
+			}
+			return uint(number), nil
+		},
+		format: func(value interface{}) interface{} {
+			return value
+		},
+	})
+}
+
+// GetBoolConfigFunc returns the current value of a bool field.
+type GetBoolConfigFunc func() bool
+
+// SetBoolConfigFunc sets the value of a bool field.
+type SetBoolConfigFunc func(bool) error
+
+// ValidateBoolConfigFunc returns an error if the given value is invalid for a bool field.
+// A nil validator accepts all values.
+type ValidateBoolConfigFunc func(bool) error
+
+// RegisterBoolConfig registers a bool field. Its JSON representation is a boolean.
+func (m *Manager) RegisterBoolConfig(name string, get GetBoolConfigFunc, set SetBoolConfigFunc, validate ValidateBoolConfigFunc) error {
+	return m.register(&field{
+		name: name,
+		typ:  "bool",
+		get: func() interface{} {
+			return get()
+		},
+		set: func(value interface{}) error {
+			return set(value.(bool))
+		},
+		validate: func(value interface{}) error {
+			if validate == nil {
+				return nil
+			}
+			return validate(value.(bool))
+		},
+		parse: func(raw interface{}) (interface{}, error) {
+			value, ok := raw.(bool)
+			if !ok {
+				return nil, NewValidationErrorf("invalid value %v for %s: expected a boolean", raw, name)
+			}
+			return value, nil
+		},
+		format: func(value interface{}) interface{} {
+			return value
+		},
+	})
+}
+
+// GetDurationConfigFunc returns the current value of a duration field.
+type GetDurationConfigFunc func() time.Duration
+
+// SetDurationConfigFunc sets the value of a duration field.
+type SetDurationConfigFunc func(time.Duration) error
+
+// ValidateDurationConfigFunc returns an error if the given value is invalid for a duration field.
+// A nil validator accepts all values.
+type ValidateDurationConfigFunc func(time.Duration) error
+
+// RegisterDurationConfig registers a duration field. Its JSON representation is a string as
+// accepted by time.ParseDuration, e.g. "1m30s".
+func (m *Manager) RegisterDurationConfig(name string, get GetDurationConfigFunc, set SetDurationConfigFunc, validate ValidateDurationConfigFunc) error {
+	return m.register(&field{
+		name: name,
+		typ:  "duration",
+		get: func() interface{} {
+			return get()
+		},
+		set: func(value interface{}) error {
+			return set(value.(time.Duration))
+		},
+		validate: func(value interface{}) error {
+			if validate == nil {
+				return nil
+			}
+			return validate(value.(time.Duration))
+		},
+		parse: func(raw interface{}) (interface{}, error) {
+			str, ok := raw.(string)
+			if !ok {
+				return nil, NewValidationErrorf("invalid value %v for %s: expected a duration string", raw, name)
+			}
+			value, err := time.ParseDuration(str)
+			if err != nil {
+				return nil, NewValidationErrorf("invalid value %v for %s: %w", raw, name, err)
+			}
+			return value, nil
+		},
+		format: func(value interface{}) interface{} {
+			return value.(time.Duration).String()
+		},
+	})
+}
@@ -0,0 +1,265 @@
+package updatable_configs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// ErrAlreadyRegistered is returned when a field is registered with a name which is already in use.
+var ErrAlreadyRegistered = errors.New("config field already registered")
+
+// ErrUnknownField is returned when accessing a field which was not registered.
+var ErrUnknownField = errors.New("unknown config field")
+
+// ValidationError is returned when a value is rejected for a field, either because it is
+// of the wrong type or because the validator of the field rejected it.
+type ValidationError struct {
+	err error
+}
+
+func NewValidationErrorf(msg string, args ...interface{}) error {
+	return ValidationError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.err
+}
+
+// IsValidationError returns whether the given error is a ValidationError.
+func IsValidationError(err error) bool {
+	var validationErr ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// FieldInfo describes the current state of a registered field.
+type FieldInfo struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Value      interface{} `json:"value"`
+	Default    interface{} `json:"default"`
+	Overridden bool        `json:"overridden"`
+}
+
+// field is a registered tunable. Values are handled in their typed representation, except for
+// parse and format, which convert from and to the JSON representation used by the admin commands
+// and by the persisted overrides.
+type field struct {
+	name         string
+	typ          string
+	get          func() interface{}
+	set          func(interface{}) error
+	validate     func(interface{}) error
+	parse        func(interface{}) (interface{}, error)
+	format       func(interface{}) interface{}
+	defaultValue interface{}
+	overridden   bool
+}
+
+// Manager manages the configuration parameters which can be updated at runtime, without
+// restarting the node. Components register each of their tunables as a named, typed field along
+// with functions to get, set and validate its value. The manager exposes the fields to operators
+// (e.g. through admin commands), validates new values before applying them, and persists the
+// overrides, so that they are re-applied when the fields are registered again after a restart.
+//
+// Setters are called while the manager is locked, hence they must not call into the manager.
+type Manager struct {
+	mu        sync.Mutex
+	log       zerolog.Logger
+	store     storage.ConfigOverrides
+	fields    map[string]*field
+	overrides map[string]string // persisted overrides, keyed by field name
+}
+
+// NewManager creates a new manager, which persists overrides to the given storage, and loads
+// the overrides persisted before the last restart. They are applied as soon as the respective
+// fields are registered.
+func NewManager(log zerolog.Logger, store storage.ConfigOverrides) (*Manager, error) {
+	overrides, err := store.All()
+	if err != nil {
+		return nil, fmt.Errorf("could not load config overrides: %w", err)
+	}
+	return &Manager{
+		log:       log.With().Str("component", "config_manager").Logger(),
+		store:     store,
+		fields:    make(map[string]*field),
+		overrides: overrides,
+	}, nil
+}
+
+// register adds the field and applies its persisted override, if any. An override which can not
+// be applied, e.g. because the validation rules changed since it was persisted, is discarded.
+func (m *Manager) register(f *field) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.fields[f.name]; ok {
+		return fmt.Errorf("could not register %s: %w", f.name, ErrAlreadyRegistered)
+	}
+	f.defaultValue = f.get()
+	m.fields[f.name] = f
+
+	encoded, ok := m.overrides[f.name]
+	if !ok {
+		return nil
+	}
+	log := m.log.With().Str("field", f.name).Str("override", encoded).Logger()
+
+	err := m.apply(f, encoded)
+	if err != nil {
+		log.Warn().Err(err).Msg("discarding persisted config override")
+		delete(m.overrides, f.name)
+		err = m.store.Remove(f.name)
+		if err != nil {
+			return fmt.Errorf("could not remove override of %s: %w", f.name, err)
+		}
+		return nil
+	}
+	f.overridden = true
+	log.Info().Msg("applied persisted config override")
+	return nil
+}
+
+// apply decodes the persisted override and sets it as the value of the field.
+func (m *Manager) apply(f *field, encoded string) error {
+	var raw interface{}
+	err := json.Unmarshal([]byte(encoded), &raw)
+	if err != nil {
+		return fmt.Errorf("could not decode override: %w", err)
+	}
+	value, err := f.parse(raw)
+	if err != nil {
+		return err
+	}
+	err = f.validate(value)
+	if err != nil {
+		return err
+	}
+	return f.set(value)
+}
+
+// Fields returns all registered fields, ordered by name.
+func (m *Manager) Fields() []FieldInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := make([]FieldInfo, 0, len(m.fields))
+	for _, f := range m.fields {
+		infos = append(infos, f.info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// Get returns the field with the given name.
+// Expected errors during normal operations:
+//   * ErrUnknownField if no field with the given name is registered
+func (m *Manager) Get(name string) (FieldInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.fields[name]
+	if !ok {
+		return FieldInfo{}, fmt.Errorf("could not get %s: %w", name, ErrUnknownField)
+	}
+	return f.info(), nil
+}
+
+// Set validates the given value and sets it as the value of the field with the given name, and
+// persists it as override of the field. The value is given in its JSON representation, i.e.
+// numbers as float64 and durations as strings such as "1m30s".
+// Expected errors during normal operations:
+//   * ErrUnknownField if no field with the given name is registered
+//   * ValidationError if the value is rejected for the field
+func (m *Manager) Set(name string, raw interface{}) (FieldInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.fields[name]
+	if !ok {
+		return FieldInfo{}, fmt.Errorf("could not set %s: %w", name, ErrUnknownField)
+	}
+	value, err := f.parse(raw)
+	if err != nil {
+		return FieldInfo{}, err
+	}
+	err = f.validate(value)
+	if err != nil {
+		return FieldInfo{}, NewValidationErrorf("invalid value %v for %s: %w", raw, name, err)
+	}
+	encoded, err := json.Marshal(f.format(value))
+	if err != nil {
+		return FieldInfo{}, fmt.Errorf("could not encode override of %s: %w", name, err)
+	}
+
+	previous := f.get()
+	err = f.set(value)
+	if err != nil {
+		return FieldInfo{}, fmt.Errorf("could not set %s: %w", name, err)
+	}
+	err = m.store.Store(name, string(encoded))
+	if err != nil {
+		// revert to the previous value, so that the value and the persisted override stay consistent
+		revertErr := f.set(previous)
+		if revertErr != nil {
+			m.log.Error().Err(revertErr).Str("field", name).Msg("could not revert config field after failing to persist override")
+		}
+		return FieldInfo{}, fmt.Errorf("could not persist override of %s: %w", name, err)
+	}
+	m.overrides[name] = string(encoded)
+	f.overridden = true
+
+	m.log.Info().Str("field", name).Interface("value", f.format(value)).Msg("config field updated")
+	return f.info(), nil
+}
+
+// Reset restores the value the field with the given name had when it was registered, and removes
+// the persisted override of the field.
+// Expected errors during normal operations:
+//   * ErrUnknownField if no field with the given name is registered
+func (m *Manager) Reset(name string) (FieldInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.fields[name]
+	if !ok {
+		return FieldInfo{}, fmt.Errorf("could not reset %s: %w", name, ErrUnknownField)
+	}
+	err := f.set(f.defaultValue)
+	if err != nil {
+		return FieldInfo{}, fmt.Errorf("could not reset %s: %w", name, err)
+	}
+	err = m.store.Remove(name)
+	if err != nil {
+		return FieldInfo{}, fmt.Errorf("could not remove override of %s: %w", name, err)
+	}
+	delete(m.overrides, name)
+	f.overridden = false
+
+	m.log.Info().Str("field", name).Msg("config field reset to default")
+	return f.info(), nil
+}
+
+func (f *field) info() FieldInfo {
+	return FieldInfo{
+		Name:       f.name,
+		Type:       f.typ,
+		Value:      f.format(f.get()),
+		Default:    f.format(f.defaultValue),
+		Overridden: f.overridden,
+	}
+}
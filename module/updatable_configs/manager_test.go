@@ -0,0 +1,216 @@
+package updatable_configs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// tunables are the values of the fields registered by registerTunables.
+type tunables struct {
+	limit   uint
+	enabled bool
+	ttl     time.Duration
+}
+
+// registerTunables registers a uint, a bool and a duration field, backed by the given tunables.
+// The limit must be positive.
+func registerTunables(t *testing.T, manager *Manager, values *tunables) {
+	err := manager.RegisterUintConfig("test.limit",
+		func() uint { return values.limit },
+		func(limit uint) error {
+			values.limit = limit
+			return nil
+		},
+		func(limit uint) error {
+			if limit == 0 {
+				return fmt.Errorf("limit must be positive")
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	err = manager.RegisterBoolConfig("test.enabled",
+		func() bool { return values.enabled },
+		func(enabled bool) error {
+			values.enabled = enabled
+			return nil
+		},
+		nil)
+	require.NoError(t, err)
+
+	err = manager.RegisterDurationConfig("test.ttl",
+		func() time.Duration { return values.ttl },
+		func(ttl time.Duration) error {
+			values.ttl = ttl
+			return nil
+		},
+		nil)
+	require.NoError(t, err)
+}
+
+func withManager(t *testing.T, f func(*Manager, storage.ConfigOverrides)) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewConfigOverrides(db)
+		manager, err := NewManager(unittest.Logger(), store)
+		require.NoError(t, err)
+		f(manager, store)
+	})
+}
+
+func TestManager_RegisterAndGet(t *testing.T) {
+	withManager(t, func(manager *Manager, _ storage.ConfigOverrides) {
+		values := &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, manager, values)
+
+		fields := manager.Fields()
+		require.Len(t, fields, 3)
+		assert.Equal(t, []string{"test.enabled", "test.limit", "test.ttl"}, []string{fields[0].Name, fields[1].Name, fields[2].Name})
+
+		info, err := manager.Get("test.ttl")
+		require.NoError(t, err)
+		assert.Equal(t, FieldInfo{Name: "test.ttl", Type: "duration", Value: "1m0s", Default: "1m0s"}, info)
+
+		_, err = manager.Get("test.unknown")
+		assert.ErrorIs(t, err, ErrUnknownField)
+
+		// fields can only be registered once
+		err = manager.RegisterUintConfig("test.limit", func() uint { return 0 }, func(uint) error { return nil }, nil)
+		assert.ErrorIs(t, err, ErrAlreadyRegistered)
+	})
+}
+
+func TestManager_Set(t *testing.T) {
+	withManager(t, func(manager *Manager, store storage.ConfigOverrides) {
+		values := &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, manager, values)
+
+		info, err := manager.Set("test.limit", float64(20))
+		require.NoError(t, err)
+		assert.Equal(t, uint(20), values.limit)
+		assert.Equal(t, FieldInfo{Name: "test.limit", Type: "uint", Value: uint(20), Default: uint(10), Overridden: true}, info)
+
+		_, err = manager.Set("test.enabled", false)
+		require.NoError(t, err)
+		assert.False(t, values.enabled)
+
+		_, err = manager.Set("test.ttl", "90s")
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Second, values.ttl)
+
+		overrides, err := store.All()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"test.limit": "20", "test.enabled": "false", "test.ttl": `"1m30s"`}, overrides)
+	})
+}
+
+// TestManager_SetInvalid checks that invalid values are rejected, without changing or persisting the value.
+func TestManager_SetInvalid(t *testing.T) {
+	withManager(t, func(manager *Manager, store storage.ConfigOverrides) {
+		values := &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, manager, values)
+
+		invalid := []struct {
+			name  string
+			value interface{}
+		}{
+			{"test.limit", "20"},
+			{"test.limit", float64(-1)},
+			{"test.limit", float64(1.5)},
+			{"test.limit", float64(0)}, // rejected by the validator
+			{"test.enabled", "true"},
+			{"test.ttl", float64(60)},
+			{"test.ttl", "one minute"},
+		}
+		for _, input := range invalid {
+			_, err := manager.Set(input.name, input.value)
+			assert.True(t, IsValidationError(err), "value %v for %s should be rejected", input.value, input.name)
+		}
+
+		_, err := manager.Set("test.unknown", float64(1))
+		assert.ErrorIs(t, err, ErrUnknownField)
+
+		assert.Equal(t, tunables{limit: 10, enabled: true, ttl: time.Minute}, *values)
+		overrides, err := store.All()
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+	})
+}
+
+// TestManager_Restart checks that overrides are persisted, and applied when the fields are registered
+// again after a restart.
+func TestManager_Restart(t *testing.T) {
+	withManager(t, func(manager *Manager, store storage.ConfigOverrides) {
+		registerTunables(t, manager, &tunables{limit: 10, enabled: true, ttl: time.Minute})
+		_, err := manager.Set("test.limit", float64(20))
+		require.NoError(t, err)
+		_, err = manager.Set("test.ttl", "5m")
+		require.NoError(t, err)
+
+		// after the restart, the components initialize the fields with their defaults again
+		restarted, err := NewManager(unittest.Logger(), store)
+		require.NoError(t, err)
+		values := &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, restarted, values)
+
+		assert.Equal(t, tunables{limit: 20, enabled: true, ttl: 5 * time.Minute}, *values)
+		info, err := restarted.Get("test.limit")
+		require.NoError(t, err)
+		assert.Equal(t, FieldInfo{Name: "test.limit", Type: "uint", Value: uint(20), Default: uint(10), Overridden: true}, info)
+	})
+}
+
+// TestManager_Reset checks that resetting a field restores its default and removes its override.
+func TestManager_Reset(t *testing.T) {
+	withManager(t, func(manager *Manager, store storage.ConfigOverrides) {
+		values := &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, manager, values)
+		_, err := manager.Set("test.limit", float64(20))
+		require.NoError(t, err)
+
+		info, err := manager.Reset("test.limit")
+		require.NoError(t, err)
+		assert.Equal(t, uint(10), values.limit)
+		assert.False(t, info.Overridden)
+
+		overrides, err := store.All()
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+
+		// the default is kept after a restart
+		restarted, err := NewManager(unittest.Logger(), store)
+		require.NoError(t, err)
+		values = &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, restarted, values)
+		assert.Equal(t, uint(10), values.limit)
+
+		_, err = manager.Reset("test.unknown")
+		assert.ErrorIs(t, err, ErrUnknownField)
+	})
+}
+
+// TestManager_InvalidOverride checks that a persisted override, which is no longer valid, is discarded.
+func TestManager_InvalidOverride(t *testing.T) {
+	withManager(t, func(_ *Manager, store storage.ConfigOverrides) {
+		require.NoError(t, store.Store("test.limit", "0"))
+		require.NoError(t, store.Store("test.ttl", `"2m"`))
+
+		manager, err := NewManager(unittest.Logger(), store)
+		require.NoError(t, err)
+		values := &tunables{limit: 10, enabled: true, ttl: time.Minute}
+		registerTunables(t, manager, values)
+
+		assert.Equal(t, tunables{limit: 10, enabled: true, ttl: 2 * time.Minute}, *values)
+		overrides, err := store.All()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"test.ttl": `"2m"`}, overrides)
+	})
+}
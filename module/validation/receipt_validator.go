@@ -6,7 +6,9 @@ import (
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/state/fork"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -37,8 +39,8 @@ func NewReceiptValidator(state protocol.State, headers storage.Headers, index st
 }
 
 func (v *receiptValidator) verifySignature(receipt *flow.ExecutionReceiptMeta, nodeIdentity *flow.Identity) error {
-	id := receipt.ID()
-	valid, err := v.verifier.Verify(id[:], receipt.ExecutorSignature, nodeIdentity.StakingPubKey)
+	msg := messages.ExecutionReceiptMessage(receipt)
+	valid, err := v.verifier.Verify(msg, receipt.ExecutorSignature, nodeIdentity.StakingPubKey)
 	if err != nil {
 		return fmt.Errorf("failed to verify signature: %w", err)
 	}
@@ -51,6 +53,12 @@ func (v *receiptValidator) verifySignature(receipt *flow.ExecutionReceiptMeta, n
 }
 
 func (v *receiptValidator) verifyChunksFormat(result *flow.ExecutionResult) error {
+	// bound the work spent on a result, before inspecting its chunks
+	if result.Chunks.Len() > flow.MaxChunks {
+		return engine.NewInvalidInputErrorf("invalid number of chunks, got %d, maximum is %d",
+			result.Chunks.Len(), flow.MaxChunks)
+	}
+
 	for index, chunk := range result.Chunks.Items() {
 		if uint(index) != chunk.CollectionIndex {
 			return engine.NewInvalidInputErrorf("invalid CollectionIndex, expected %d got %d", index, chunk.CollectionIndex)
@@ -351,9 +359,9 @@ func (v *receiptValidator) validateReceipt(receipt *flow.ExecutionReceiptMeta, b
 			err)
 	}
 
-	err = ensureStakedNodeWithRole(identity, flow.RoleExecution)
+	err = ensureAuthorizedNode(identity, filter.IsAuthorizedReceiptSource)
 	if err != nil {
-		return fmt.Errorf("staked node invalid: %w", err)
+		return fmt.Errorf("executor not authorized: %w", err)
 	}
 
 	err = v.verifySignature(receipt, identity)
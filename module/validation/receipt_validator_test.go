@@ -50,6 +50,33 @@ func (s *ReceiptValidationSuite) TestReceiptValid() {
 	s.verifier.AssertExpectations(s.T())
 }
 
+// TestReceiptValidOnlySystemChunk tests that a receipt for a block without guarantees,
+// whose result consists of the system chunk only, is accepted
+func (s *ReceiptValidationSuite) TestReceiptValidOnlySystemChunk() {
+	valSubgrph := s.ValidSubgraphFixture()
+	block := unittest.BlockWithParentFixture(valSubgrph.ParentBlock.Header)
+	block.SetPayload(flow.EmptyPayload())
+	valSubgrph.Block = block
+	valSubgrph.Result = unittest.ExecutionResultFixture(
+		unittest.WithBlock(block),
+		unittest.WithPreviousResult(*valSubgrph.PreviousResult),
+	)
+	s.Require().Len(valSubgrph.Result.Chunks, 1)
+
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(s.ExeID),
+		unittest.WithResult(valSubgrph.Result))
+	s.AddSubgraphFixtureToMempools(valSubgrph)
+
+	s.verifier.On("Verify",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(true, nil).Once()
+
+	err := s.receiptValidator.Validate(receipt)
+	s.Require().NoError(err, "should successfully validate receipt with only the system chunk")
+	s.verifier.AssertExpectations(s.T())
+}
+
 // TestReceiptNoIdentity tests that we reject receipt with invalid `ExecutionResult.ExecutorID`
 func (s *ReceiptValidationSuite) TestReceiptNoIdentity() {
 	valSubgrph := s.ValidSubgraphFixture()
@@ -160,6 +187,27 @@ func (s *ReceiptValidationSuite) TestReceiptTooManyChunks() {
 	s.Assert().True(engine.IsInvalidInputError(err))
 }
 
+// TestReceiptExceedingMaxChunks tests that we reject receipt with more than the maximum number of
+// chunks, without looking up the payload of the executed block
+func (s *ReceiptValidationSuite) TestReceiptExceedingMaxChunks() {
+	valSubgrph := s.ValidSubgraphFixture()
+	chunks := make(flow.ChunkList, 0, flow.MaxChunks+1)
+	for i := 0; i <= flow.MaxChunks; i++ {
+		chunk := unittest.ChunkFixture(valSubgrph.Result.BlockID, uint(i))
+		chunk.Index = uint64(i)
+		chunks = append(chunks, chunk)
+	}
+	valSubgrph.Result.Chunks = chunks
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(s.ExeID),
+		unittest.WithResult(valSubgrph.Result))
+	s.AddSubgraphFixtureToMempools(valSubgrph)
+
+	err := s.receiptValidator.Validate(receipt)
+	s.Require().Error(err, "should reject with too many chunks")
+	s.Assert().True(engine.IsInvalidInputError(err))
+	s.Assert().Contains(err.Error(), "maximum")
+}
+
 // TestReceiptChunkInvalidBlockID tests that we reject receipt with invalid chunk blockID
 func (s *ReceiptValidationSuite) TestReceiptChunkInvalidBlockID() {
 	valSubgrph := s.ValidSubgraphFixture()
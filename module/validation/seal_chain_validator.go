@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// SealChainValidator checks that seals form a chain on top of the last seal of
+// a fork. It implements the checks shared by the builder, which includes seals
+// in a proposal, and the payload validator, which checks the seals in incoming
+// blocks. The validity of the individual seals (e.g. their approvals) is not
+// checked.
+type SealChainValidator struct{}
+
+// NewSealChainValidator creates a new seal chain validator.
+func NewSealChainValidator() *SealChainValidator {
+	return &SealChainValidator{}
+}
+
+// Validate checks that the candidate seals, in the given order, form a chain on
+// top of the last seal: each seal's result must be known, must be for the block
+// the seal claims, and must build on the result sealed by the preceding seal.
+// It returns the longest prefix of the candidate seals forming a valid chain,
+// and if the candidate seals are not all valid, an error for the first invalid
+// seal:
+//  * SealChainGapError if the seal's result does not build on the previously sealed result
+//  * UnknownSealedResultError if resultLookup returns storage.ErrNotFound for the seal's result
+//  * SealResultMismatchError if the seal's result is for a different block than the seal
+//  * generic error in case of an unexpected error returned by resultLookup
+func (v *SealChainValidator) Validate(
+	candidateSeals []*flow.Seal,
+	lastSeal *flow.Seal,
+	resultLookup func(flow.Identifier) (*flow.ExecutionResult, error),
+) ([]*flow.Seal, error) {
+
+	sealedBlocks := make(map[flow.Identifier]struct{}, len(candidateSeals))
+	latestSeal := lastSeal
+	for i, seal := range candidateSeals {
+		valid := candidateSeals[:i]
+
+		if _, duplicate := sealedBlocks[seal.BlockID]; duplicate {
+			return valid, NewSealChainGapErrorf("duplicate seal %x for block %x", seal.ID(), seal.BlockID)
+		}
+		sealedBlocks[seal.BlockID] = struct{}{}
+
+		result, err := resultLookup(seal.ResultID)
+		if errors.Is(err, storage.ErrNotFound) {
+			return valid, NewUnknownSealedResultErrorf("result %x of seal %x is unknown: %w", seal.ResultID, seal.ID(), err)
+		}
+		if err != nil {
+			return valid, fmt.Errorf("could not look up result %x of seal %x: %w", seal.ResultID, seal.ID(), err)
+		}
+
+		if result.BlockID != seal.BlockID {
+			return valid, NewSealResultMismatchErrorf("seal %x is for block %x, but its result %x is for block %x",
+				seal.ID(), seal.BlockID, seal.ResultID, result.BlockID)
+		}
+
+		if result.PreviousResultID != latestSeal.ResultID {
+			return valid, NewSealChainGapErrorf("result %x sealed for block %x does not connect to previously sealed result %x",
+				seal.ResultID, seal.BlockID, latestSeal.ResultID)
+		}
+
+		latestSeal = seal
+	}
+
+	return candidateSeals, nil
+}
+
+// IsSealChainError returns whether the given error indicates that seals do not
+// form a valid chain, as returned by SealChainValidator.
+func IsSealChainError(err error) bool {
+	return IsSealChainGapError(err) || IsUnknownSealedResultError(err) || IsSealResultMismatchError(err)
+}
+
+// SealChainGapError indicates that a seal does not connect to the previous seal
+// in the chain.
+type SealChainGapError struct {
+	err error
+}
+
+func NewSealChainGapErrorf(msg string, args ...interface{}) error {
+	return SealChainGapError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e SealChainGapError) Unwrap() error {
+	return e.err
+}
+
+func (e SealChainGapError) Error() string {
+	return e.err.Error()
+}
+
+// IsSealChainGapError returns whether the given error is a SealChainGapError error
+func IsSealChainGapError(err error) bool {
+	var sealChainGapError SealChainGapError
+	return errors.As(err, &sealChainGapError)
+}
+
+// UnknownSealedResultError indicates that the result of a seal is unknown.
+type UnknownSealedResultError struct {
+	err error
+}
+
+func NewUnknownSealedResultErrorf(msg string, args ...interface{}) error {
+	return UnknownSealedResultError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e UnknownSealedResultError) Unwrap() error {
+	return e.err
+}
+
+func (e UnknownSealedResultError) Error() string {
+	return e.err.Error()
+}
+
+// IsUnknownSealedResultError returns whether the given error is an UnknownSealedResultError error
+func IsUnknownSealedResultError(err error) bool {
+	var unknownSealedResultError UnknownSealedResultError
+	return errors.As(err, &unknownSealedResultError)
+}
+
+// SealResultMismatchError indicates that the result of a seal is for a
+// different block than the seal.
+type SealResultMismatchError struct {
+	err error
+}
+
+func NewSealResultMismatchErrorf(msg string, args ...interface{}) error {
+	return SealResultMismatchError{
+		err: fmt.Errorf(msg, args...),
+	}
+}
+
+func (e SealResultMismatchError) Unwrap() error {
+	return e.err
+}
+
+func (e SealResultMismatchError) Error() string {
+	return e.err.Error()
+}
+
+// IsSealResultMismatchError returns whether the given error is a SealResultMismatchError error
+func IsSealResultMismatchError(err error) bool {
+	var sealResultMismatchError SealResultMismatchError
+	return errors.As(err, &sealResultMismatchError)
+}
@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// sealChainFixture returns the last seal, and seals for a chain of n results on
+// top of it, together with a lookup for the results.
+func sealChainFixture(n int) (*flow.Seal, []*flow.Seal, map[flow.Identifier]*flow.ExecutionResult) {
+	lastResult := unittest.ExecutionResultFixture()
+	lastSeal := unittest.Seal.Fixture(unittest.Seal.WithResult(lastResult))
+	results := make(map[flow.Identifier]*flow.ExecutionResult)
+	seals := make([]*flow.Seal, 0, n)
+	for i := 0; i < n; i++ {
+		result := unittest.ExecutionResultFixture(unittest.WithPreviousResult(*lastResult))
+		results[result.ID()] = result
+		seals = append(seals, unittest.Seal.Fixture(unittest.Seal.WithResult(result)))
+		lastResult = result
+	}
+	return lastSeal, seals, results
+}
+
+func lookup(results map[flow.Identifier]*flow.ExecutionResult) func(flow.Identifier) (*flow.ExecutionResult, error) {
+	return func(resultID flow.Identifier) (*flow.ExecutionResult, error) {
+		result, ok := results[resultID]
+		if !ok {
+			return nil, storage.ErrNotFound
+		}
+		return result, nil
+	}
+}
+
+// TestSealChain_Valid tests that a chain of seals on top of the last seal is valid.
+func TestSealChain_Valid(t *testing.T) {
+	lastSeal, seals, results := sealChainFixture(3)
+
+	valid, err := NewSealChainValidator().Validate(seals, lastSeal, lookup(results))
+	require.NoError(t, err)
+	assert.Equal(t, seals, valid)
+
+	// no seals are a valid chain
+	valid, err = NewSealChainValidator().Validate(nil, lastSeal, lookup(results))
+	require.NoError(t, err)
+	assert.Empty(t, valid)
+}
+
+// TestSealChain_Gap tests that the chain of seals must not skip a result.
+func TestSealChain_Gap(t *testing.T) {
+	lastSeal, seals, results := sealChainFixture(3)
+
+	// the first seal does not connect to the last seal
+	valid, err := NewSealChainValidator().Validate(seals[1:], lastSeal, lookup(results))
+	assert.True(t, IsSealChainGapError(err))
+	assert.Empty(t, valid)
+
+	// the chain has a hole
+	candidates := []*flow.Seal{seals[0], seals[2]}
+	valid, err = NewSealChainValidator().Validate(candidates, lastSeal, lookup(results))
+	assert.True(t, IsSealChainGapError(err))
+	assert.Equal(t, seals[:1], valid)
+}
+
+// TestSealChain_UnknownResult tests that a seal for an unknown result ends the chain.
+func TestSealChain_UnknownResult(t *testing.T) {
+	lastSeal, seals, results := sealChainFixture(3)
+	delete(results, seals[1].ResultID)
+
+	valid, err := NewSealChainValidator().Validate(seals, lastSeal, lookup(results))
+	assert.True(t, IsUnknownSealedResultError(err))
+	assert.Equal(t, seals[:1], valid)
+
+	// unexpected errors of the lookup are not reported as invalid chain
+	exception := func(flow.Identifier) (*flow.ExecutionResult, error) {
+		return nil, storage.ErrDataMismatch
+	}
+	valid, err = NewSealChainValidator().Validate(seals, lastSeal, exception)
+	require.Error(t, err)
+	assert.False(t, IsSealChainError(err))
+	assert.Empty(t, valid)
+}
+
+// TestSealChain_ResultMismatch tests that a seal must be for the block of its result.
+func TestSealChain_ResultMismatch(t *testing.T) {
+	lastSeal, seals, results := sealChainFixture(3)
+	seals[2].BlockID = unittest.IdentifierFixture()
+
+	valid, err := NewSealChainValidator().Validate(seals, lastSeal, lookup(results))
+	assert.True(t, IsSealResultMismatchError(err))
+	assert.Equal(t, seals[:2], valid)
+}
+
+// TestSealChain_Duplicate tests that a block must only be sealed once in the chain.
+func TestSealChain_Duplicate(t *testing.T) {
+	lastSeal, seals, results := sealChainFixture(2)
+
+	candidates := []*flow.Seal{seals[0], seals[0], seals[1]}
+	valid, err := NewSealChainValidator().Validate(candidates, lastSeal, lookup(results))
+	assert.True(t, IsSealChainGapError(err))
+	assert.Equal(t, seals[:1], valid)
+}
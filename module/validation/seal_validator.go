@@ -6,6 +6,7 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/state/fork"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -32,6 +33,7 @@ type sealValidator struct {
 	requiredApprovalsForSealConstruction uint // number of required approvals per chunk to construct a seal
 	requiredApprovalsForSealVerification uint // number of required approvals per chunk for a seal to be valid
 	metrics                              module.ConsensusMetrics
+	sealChain                            *SealChainValidator
 }
 
 func NewSealValidator(
@@ -62,6 +64,7 @@ func NewSealValidator(
 		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
 		requiredApprovalsForSealVerification: requiredApprovalsForSealVerification,
 		metrics:                              metrics,
+		sealChain:                            NewSealChainValidator(),
 	}, nil
 }
 
@@ -74,7 +77,7 @@ func (s *sealValidator) verifySealSignature(aggregatedSignatures *flow.Aggregate
 		ExecutionResultID: executionResultID,
 		ChunkIndex:        chunk.Index,
 	}
-	atstID := atst.ID()
+	msg := messages.AttestationMessage(&atst)
 
 	for i, signature := range aggregatedSignatures.VerifierSignatures {
 		signerId := aggregatedSignatures.SignerIDs[i]
@@ -84,7 +87,7 @@ func (s *sealValidator) verifySealSignature(aggregatedSignatures *flow.Aggregate
 			return err
 		}
 
-		valid, err := s.verifier.Verify(atstID[:], signature, nodeIdentity.StakingPubKey)
+		valid, err := s.verifier.Verify(msg, signature, nodeIdentity.StakingPubKey)
 		if err != nil {
 			return fmt.Errorf("failed to verify signature: %w", err)
 		}
@@ -196,30 +199,47 @@ func (s *sealValidator) Validate(candidate *flow.Block) (*flow.Seal, error) {
 	// its chunk assignment for verification. Therefore a seal can only be added in the
 	// next block or after. In other words, a receipt and its seal can't be the same block.
 
-	// Iterate through the unsealed blocks, starting at the one with lowest
-	// height and try to create a chain of valid seals.
-	latestSeal := lastSealUpToParent
-	for _, blockID := range unsealedBlockIDs {
-		// if there are no more seals left, we can exit earlier
-		if len(byBlock) == 0 {
-			return latestSeal, nil
-		}
-
-		// the chain of seals should not skip blocks
+	// Order the seals by the unsealed blocks they seal, starting at the one with
+	// the lowest height. The chain of seals must not skip blocks.
+	candidateSeals := make([]*flow.Seal, 0, len(byBlock))
+	var missing *flow.Identifier
+	for i, blockID := range unsealedBlockIDs {
 		seal, found := byBlock[blockID]
 		if !found {
-			return nil, engine.NewInvalidInputErrorf("chain of seals broken (missing seal for block %x)", blockID)
+			missing = &unsealedBlockIDs[i]
+			break
 		}
 		delete(byBlock, blockID)
+		candidateSeals = append(candidateSeals, seal)
+	}
+	if len(byBlock) > 0 {
+		if missing != nil {
+			return nil, engine.NewInvalidInputErrorf("chain of seals broken (missing seal for block %x)", *missing)
+		}
+		// it is illegal to include more seals than there are unsealed blocks in the fork
+		return nil, engine.NewInvalidInputErrorf("more seals then unsealed blocks in fork (left: %d)", len(byBlock))
+	}
 
-		// the sealed result must be previously incorporated in the fork:
-		incorporatedResult, ok := incorporatedResults[seal.ResultID]
+	// the sealed results must be previously incorporated in the fork, and must
+	// form a chain on top of the last sealed result
+	resultLookup := func(resultID flow.Identifier) (*flow.ExecutionResult, error) {
+		incorporatedResult, ok := incorporatedResults[resultID]
 		if !ok {
-			return nil, engine.NewInvalidInputErrorf("seal %x does not correspond to a result on this fork", seal.ID())
+			return nil, fmt.Errorf("result %x is not incorporated in this fork: %w", resultID, storage.ErrNotFound)
+		}
+		return incorporatedResult.Result, nil
+	}
+	_, err = s.sealChain.Validate(candidateSeals, lastSealUpToParent, resultLookup)
+	if err != nil {
+		if !IsSealChainError(err) {
+			return nil, fmt.Errorf("unexpected internal error while validating chain of seals: %w", err)
 		}
+		return nil, engine.NewInvalidInputErrorf("invalid chain of seals: %w", err)
+	}
 
-		// check the integrity of the seal (by itself)
-		err := s.validateSeal(seal, incorporatedResult)
+	// check the integrity of each seal (by itself)
+	for _, seal := range candidateSeals {
+		err := s.validateSeal(seal, incorporatedResults[seal.ResultID])
 		if err != nil {
 			if !engine.IsInvalidInputError(err) {
 				return nil, fmt.Errorf("unexpected internal error while validating seal %x for result %x for block %x: %w",
@@ -227,21 +247,9 @@ func (s *sealValidator) Validate(candidate *flow.Block) (*flow.Seal, error) {
 			}
 			return nil, fmt.Errorf("invalid seal %x for result %x for block %x: %w", seal.ID(), seal.ResultID, seal.BlockID, err)
 		}
-
-		// check that the sealed execution results form a chain
-		if incorporatedResult.Result.PreviousResultID != latestSeal.ResultID {
-			return nil, engine.NewInvalidInputErrorf("sealed execution results for block %x does not connect to previously sealed result", blockID)
-		}
-
-		latestSeal = seal
 	}
 
-	// it is illegal to include more seals than there are unsealed blocks in the fork
-	if len(byBlock) > 0 {
-		return nil, engine.NewInvalidInputErrorf("more seals then unsealed blocks in fork (left: %d)", len(byBlock))
-	}
-
-	return latestSeal, nil
+	return candidateSeals[len(candidateSeals)-1], nil
 }
 
 // validateSeal performs integrity checks of single seal. To be valid, we
@@ -284,6 +292,10 @@ func (s *sealValidator) validateSeal(seal *flow.Seal, incorporatedResult *flow.I
 		if len(chunkSigs.VerifierSignatures) != numberApprovers {
 			return engine.NewInvalidInputErrorf("expecting signatures from %d approvers but got %d", numberApprovers, len(chunkSigs.VerifierSignatures))
 		}
+		// SPoCK proofs are optional, but if present, there must be one for every approver
+		if len(chunkSigs.Spocks) > 0 && len(chunkSigs.Spocks) != numberApprovers {
+			return engine.NewInvalidInputErrorf("expecting SPoCK proofs from %d approvers but got %d", numberApprovers, len(chunkSigs.Spocks))
+		}
 
 		// the chunk must have been approved by at least the minimally
 		// required number of Verification Nodes
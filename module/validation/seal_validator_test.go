@@ -215,6 +215,25 @@ func (s *SealValidationSuite) TestSealInvalidChunkSignaturesCount() {
 	s.Require().True(engine.IsInvalidInputError(err))
 }
 
+// TestSealChunkSpocksCount tests that we accept seals with or without SPoCK proofs, but reject seals
+// whose SPoCK proofs don't match the approvers of a chunk.
+func (s *SealValidationSuite) TestSealChunkSpocksCount() {
+	_, _, newBlock, _, seal := s.generateBasicTestFork()
+
+	// modify seal (seal pointer already included in newBlock's payload)
+	approvers := len(seal.AggregatedApprovalSigs[0].SignerIDs)
+	seal.AggregatedApprovalSigs[0].Spocks = unittest.SignaturesFixture(approvers)
+
+	_, err := s.sealValidator.Validate(newBlock)
+	s.Require().NoError(err)
+
+	seal.AggregatedApprovalSigs[0].Spocks = seal.AggregatedApprovalSigs[0].Spocks[1:]
+
+	_, err = s.sealValidator.Validate(newBlock)
+	s.Require().Error(err)
+	s.Require().True(engine.IsInvalidInputError(err))
+}
+
 // TestSealDuplicatedApproval verifies that the seal validator rejects an invalid
 // seal where approvals are repeated. Otherwise, this would open up an attack vector,
 // where the seal contains insufficient approvals when duplicating.
@@ -71,6 +71,8 @@ func switchenv2v(code uint8) (interface{}, error) {
 		v = &messages.ApprovalRequest{}
 	case CodeApprovalResponse:
 		v = &messages.ApprovalResponse{}
+	case CodeResultApprovalBatch:
+		v = &messages.ResultApprovalBatch{}
 
 	// generic entity exchange engines
 	case CodeEntityRequest:
@@ -154,6 +156,8 @@ func switchenv2what(code uint8) (string, error) {
 		what = "CodeApprovalRequest"
 	case CodeApprovalResponse:
 		what = "CodeApprovalResponse"
+	case CodeResultApprovalBatch:
+		what = "CodeResultApprovalBatch"
 
 	// generic entity exchange engines
 	case CodeEntityRequest:
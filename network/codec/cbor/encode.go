@@ -72,6 +72,8 @@ func switchv2code(v interface{}) (uint8, error) {
 		code = CodeApprovalRequest
 	case *messages.ApprovalResponse:
 		code = CodeApprovalResponse
+	case *messages.ResultApprovalBatch:
+		code = CodeResultApprovalBatch
 
 	// generic entity exchange engines
 	case *messages.EntityRequest:
@@ -156,6 +158,8 @@ func switchv2what(v interface{}) (string, error) {
 		what = "CodeApprovalRequest"
 	case *messages.ApprovalResponse:
 		what = "CodeApprovalResponse"
+	case *messages.ResultApprovalBatch:
+		what = "CodeResultApprovalBatch"
 
 	// generic entity exchange engines
 	case *messages.EntityRequest:
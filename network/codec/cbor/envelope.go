@@ -52,5 +52,8 @@ const (
 	// DKG
 	CodeDKGMessage
 
+	// result approval batches
+	CodeResultApprovalBatch
+
 	CodeMax
 )
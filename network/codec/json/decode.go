@@ -75,6 +75,8 @@ func switchenv2v(env Envelope) (interface{}, error) {
 		v = &messages.ApprovalRequest{}
 	case CodeApprovalResponse:
 		v = &messages.ApprovalResponse{}
+	case CodeResultApprovalBatch:
+		v = &messages.ResultApprovalBatch{}
 
 	// generic entity exchange engines
 	case CodeEntityRequest:
@@ -158,6 +160,8 @@ func switchenv2what(env Envelope) (string, error) {
 		what = "CodeApprovalRequest"
 	case CodeApprovalResponse:
 		what = "CodeApprovalResponse"
+	case CodeResultApprovalBatch:
+		what = "CodeResultApprovalBatch"
 
 	// generic entity exchange engines
 	case CodeEntityRequest:
@@ -54,6 +54,9 @@ const (
 
 	// DKG
 	CodeDKGMessage
+
+	// result approval batches
+	CodeResultApprovalBatch
 )
 
 // Envelope is a wrapper to convey type information with JSON encoding without
@@ -37,6 +37,7 @@ func (c *cache) resolveIPCache(domain string) ([]net.IPAddr, bool, bool) {
 	c.RLock()
 
 	entry, ok := c.ipCache[domain]
+	ttl := c.ttl
 
 	c.RUnlock()
 
@@ -45,7 +46,7 @@ func (c *cache) resolveIPCache(domain string) ([]net.IPAddr, bool, bool) {
 		return nil, !cacheEntryExists, !cacheEntryFresh
 	}
 
-	if time.Duration(runtimeNano()-entry.timestamp) > c.ttl {
+	if time.Duration(runtimeNano()-entry.timestamp) > ttl {
 		// exists but expired
 		return entry.addresses, cacheEntryExists, !cacheEntryFresh
 	}
@@ -61,6 +62,7 @@ func (c *cache) resolveTXTCache(txt string) ([]string, bool, bool) {
 	c.RLock()
 
 	entry, ok := c.txtCache[txt]
+	ttl := c.ttl
 
 	c.RUnlock()
 
@@ -69,7 +71,7 @@ func (c *cache) resolveTXTCache(txt string) ([]string, bool, bool) {
 		return nil, !cacheEntryExists, !cacheEntryFresh
 	}
 
-	if time.Duration(runtimeNano()-entry.timestamp) > c.ttl {
+	if time.Duration(runtimeNano()-entry.timestamp) > ttl {
 		// exists but expired
 		return entry.addresses, cacheEntryExists, !cacheEntryFresh
 	}
@@ -78,6 +80,22 @@ func (c *cache) resolveTXTCache(txt string) ([]string, bool, bool) {
 	return entry.addresses, cacheEntryExists, cacheEntryFresh
 }
 
+// getTTL returns the time-to-live for cache entries.
+func (c *cache) getTTL() time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.ttl
+}
+
+// setTTL sets the time-to-live for cache entries, which applies to the existing entries as well.
+func (c *cache) setTTL(ttl time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.ttl = ttl
+}
+
 // updateIPCache updates the cache entry for the domain.
 func (c *cache) updateIPCache(domain string, addr []net.IPAddr) {
 	c.Lock()
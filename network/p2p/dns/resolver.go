@@ -11,6 +11,7 @@ import (
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
 )
 
 //go:linkname runtimeNano runtime.nanotime
@@ -71,6 +72,17 @@ func NewResolver(collector module.ResolverMetrics, opts ...optFunc) *Resolver {
 	return resolver
 }
 
+// TTL returns the time to live for cache entries.
+func (r *Resolver) TTL() time.Duration {
+	return r.c.getTTL()
+}
+
+// SetTTL sets the time to live for cache entries. The new time to live applies to the entries
+// which are already cached as well, i.e. lowering it causes them to be refreshed sooner.
+func (r *Resolver) SetTTL(ttl time.Duration) {
+	r.c.setTTL(ttl)
+}
+
 // Ready initializes the resolver and returns a channel that is closed when the initialization is done.
 func (r *Resolver) Ready() <-chan struct{} {
 	return r.unit.Ready()
@@ -100,10 +112,12 @@ func (r *Resolver) lookupIPAddr(ctx context.Context, domain string) ([]net.IPAdd
 	addr, exists, fresh := r.c.resolveIPCache(domain)
 
 	if !exists {
-		r.collector.OnDNSCacheMiss()
+		r.collector.OnDNSCacheMiss(metrics.DNSLookupTypeIP)
 		return r.lookupResolverForIPAddr(ctx, domain)
 	}
 
+	r.collector.OnDNSCacheHit(metrics.DNSLookupTypeIP)
+
 	if !fresh && r.shouldResolveIP(domain) {
 		r.unit.Launch(func() {
 			_, err := r.lookupResolverForIPAddr(ctx, domain)
@@ -111,14 +125,13 @@ func (r *Resolver) lookupIPAddr(ctx context.Context, domain string) ([]net.IPAdd
 				// invalidates cached entry when hits error on resolving.
 				invalidated := r.c.invalidateIPCacheEntry(domain)
 				if invalidated {
-					r.collector.OnDNSCacheInvalidated()
+					r.collector.OnDNSCacheInvalidated(metrics.DNSLookupTypeIP)
 				}
 			}
 			r.doneResolvingIP(domain)
 		})
 	}
 
-	r.collector.OnDNSCacheHit()
 	return addr, nil
 }
 
@@ -126,6 +139,7 @@ func (r *Resolver) lookupIPAddr(ctx context.Context, domain string) ([]net.IPAdd
 func (r *Resolver) lookupResolverForIPAddr(ctx context.Context, domain string) ([]net.IPAddr, error) {
 	addr, err := r.res.LookupIPAddr(ctx, domain)
 	if err != nil {
+		r.collector.OnDNSLookupError(metrics.DNSLookupTypeIP)
 		return nil, err
 	}
 
@@ -154,10 +168,12 @@ func (r *Resolver) lookupTXT(ctx context.Context, txt string) ([]string, error)
 	addr, exists, fresh := r.c.resolveTXTCache(txt)
 
 	if !exists {
-		r.collector.OnDNSCacheMiss()
+		r.collector.OnDNSCacheMiss(metrics.DNSLookupTypeTXT)
 		return r.lookupResolverForTXTAddr(ctx, txt)
 	}
 
+	r.collector.OnDNSCacheHit(metrics.DNSLookupTypeTXT)
+
 	if !fresh && r.shouldResolveTXT(txt) {
 		r.unit.Launch(func() {
 			defer r.doneResolvingTXT(txt)
@@ -166,14 +182,13 @@ func (r *Resolver) lookupTXT(ctx context.Context, txt string) ([]string, error)
 				// invalidates cached entry when hits error on resolving.
 				invalidated := r.c.invalidateTXTCacheEntry(txt)
 				if invalidated {
-					r.collector.OnDNSCacheInvalidated()
+					r.collector.OnDNSCacheInvalidated(metrics.DNSLookupTypeTXT)
 				}
 			}
 		})
 
 	}
 
-	r.collector.OnDNSCacheHit()
 	return addr, nil
 }
 
@@ -181,6 +196,7 @@ func (r *Resolver) lookupTXT(ctx context.Context, txt string) ([]string, error)
 func (r *Resolver) lookupResolverForTXTAddr(ctx context.Context, txt string) ([]string, error) {
 	addr, err := r.res.LookupTXT(ctx, txt)
 	if err != nil {
+		r.collector.OnDNSLookupError(metrics.DNSLookupTypeTXT)
 		return nil, err
 	}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/module/metrics"
+	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/network/mocknetwork"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -72,6 +73,26 @@ func TestResolver_CacheExpiry(t *testing.T) {
 	unittest.RequireCloseBefore(t, resolver.Done(), 10*time.Millisecond, "could not stop dns resolver on time")
 }
 
+// TestResolver_SetTTL evaluates that updating the time-to-live of the resolver applies to the entries which are already cached.
+func TestResolver_SetTTL(t *testing.T) {
+	resolver := NewResolver(metrics.NewNoopCollector(), WithBasicResolver(&mocknetwork.BasicResolver{}))
+	require.Equal(t, DefaultTimeToLive, resolver.TTL())
+
+	domain := "example.com"
+	resolver.c.updateIPCache(domain, []net.IPAddr{{IP: net.IPv4(127, 0, 0, 1)}})
+	_, exists, fresh := resolver.c.resolveIPCache(domain)
+	require.True(t, exists)
+	require.True(t, fresh)
+
+	// the cached entry expires under a time-to-live shorter than its age
+	time.Sleep(10 * time.Millisecond)
+	resolver.SetTTL(time.Millisecond)
+	require.Equal(t, time.Millisecond, resolver.TTL())
+	_, exists, fresh = resolver.c.resolveIPCache(domain)
+	require.True(t, exists)
+	require.False(t, fresh)
+}
+
 // TestResolver_Error evaluates that when the underlying resolver returns an error, the resolver itself does not cache the result.
 func TestResolver_Error(t *testing.T) {
 	basicResolver := mocknetwork.BasicResolver{}
@@ -135,6 +156,127 @@ func TestResolver_Expired_Invalidated(t *testing.T) {
 	require.Empty(t, resolver.c.txtCache)
 }
 
+// TestResolver_Metrics evaluates the exact sequence of metrics hooks invoked by the resolver for each lookup type, when a
+// domain is missed, then hit, then hit while expired with a failing refresh that invalidates it, and then missed again.
+func TestResolver_Metrics(t *testing.T) {
+	var ipTestCase *ipLookupTestCase
+	for _, tc := range ipLookupFixture(1) {
+		ipTestCase = tc
+	}
+	var txtTestCase *txtLookupTestCase
+	for _, tc := range txtLookupFixture(1) {
+		txtTestCase = tc
+	}
+
+	testCases := map[string]struct {
+		mockLookup func(basicResolver *mocknetwork.BasicResolver, err error)
+		lookup     func(resolver *Resolver) error
+		cached     func(resolver *Resolver) bool
+	}{
+		metrics.DNSLookupTypeIP: {
+			mockLookup: func(basicResolver *mocknetwork.BasicResolver, err error) {
+				var result []net.IPAddr
+				if err == nil {
+					result = ipTestCase.result
+				}
+				basicResolver.On("LookupIPAddr", mock.Anything, ipTestCase.domain).Return(result, err).Once()
+			},
+			lookup: func(resolver *Resolver) error {
+				_, err := resolver.LookupIPAddr(context.Background(), ipTestCase.domain)
+				return err
+			},
+			cached: func(resolver *Resolver) bool {
+				_, exists, _ := resolver.c.resolveIPCache(ipTestCase.domain)
+				return exists
+			},
+		},
+		metrics.DNSLookupTypeTXT: {
+			mockLookup: func(basicResolver *mocknetwork.BasicResolver, err error) {
+				var result []string
+				if err == nil {
+					result = txtTestCase.result
+				}
+				basicResolver.On("LookupTXT", mock.Anything, txtTestCase.domain).Return(result, err).Once()
+			},
+			lookup: func(resolver *Resolver) error {
+				_, err := resolver.LookupTXT(context.Background(), txtTestCase.domain)
+				return err
+			},
+			cached: func(resolver *Resolver) bool {
+				_, exists, _ := resolver.c.resolveTXTCache(txtTestCase.domain)
+				return exists
+			},
+		},
+	}
+
+	for lookupType, tc := range testCases {
+		t.Run(lookupType, func(t *testing.T) {
+			ttl := 100 * time.Millisecond
+			collector, calls := metricsRecorderFixture()
+			basicResolver := &mocknetwork.BasicResolver{}
+			resolver := NewResolver(collector, WithBasicResolver(basicResolver), WithTTL(ttl))
+			unittest.RequireCloseBefore(t, resolver.Ready(), 10*time.Millisecond, "could not start dns resolver on time")
+
+			// initial lookup, refresh of the expired entry, and lookup after invalidation
+			tc.mockLookup(basicResolver, nil)
+			tc.mockLookup(basicResolver, fmt.Errorf("error"))
+			tc.mockLookup(basicResolver, nil)
+
+			// miss, then hit
+			require.NoError(t, tc.lookup(resolver))
+			require.NoError(t, tc.lookup(resolver))
+
+			// hit on the expired entry, the asynchronous refresh fails and invalidates the entry
+			time.Sleep(2 * ttl)
+			require.NoError(t, tc.lookup(resolver))
+			require.Eventually(t, func() bool {
+				return !tc.cached(resolver)
+			}, time.Second, 10*time.Millisecond, "expired entry was not invalidated")
+
+			// miss again
+			require.NoError(t, tc.lookup(resolver))
+
+			unittest.RequireCloseBefore(t, resolver.Done(), 100*time.Millisecond, "could not stop dns resolver on time")
+			basicResolver.AssertExpectations(t)
+			require.Equal(t, []string{
+				"miss:" + lookupType,
+				"hit:" + lookupType,
+				"hit:" + lookupType,
+				"error:" + lookupType,
+				"invalidated:" + lookupType,
+				"miss:" + lookupType,
+			}, calls())
+		})
+	}
+}
+
+// metricsRecorderFixture returns a mock resolver metrics collector, and a function returning the sequence of cache
+// related hooks invoked on it so far.
+func metricsRecorderFixture() (*mockmodule.ResolverMetrics, func() []string) {
+	mu := sync.Mutex{}
+	var calls []string
+	record := func(hook string) func(mock.Arguments) {
+		return func(args mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, hook+":"+args.String(0))
+		}
+	}
+
+	collector := &mockmodule.ResolverMetrics{}
+	collector.On("DNSLookupDuration", mock.Anything)
+	collector.On("OnDNSCacheMiss", mock.Anything).Run(record("miss"))
+	collector.On("OnDNSCacheHit", mock.Anything).Run(record("hit"))
+	collector.On("OnDNSCacheInvalidated", mock.Anything).Run(record("invalidated"))
+	collector.On("OnDNSLookupError", mock.Anything).Run(record("error"))
+
+	return collector, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, calls...)
+	}
+}
+
 type ipLookupTestCase struct {
 	domain string
 	result []net.IPAddr
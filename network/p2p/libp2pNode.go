@@ -31,9 +31,6 @@ const (
 	// PingTimeout is maximum time to wait for a ping reply from a remote node
 	PingTimeout = time.Second * 4
 
-	// maximum number of attempts to be made to connect to a remote node for 1-1 direct communication
-	maxConnectAttempt = 3
-
 	// timeout for FindPeer queries to the DHT
 	// TODO: is this a sensible value?
 	findPeerQueryTimeout = 10 * time.Second
@@ -171,7 +168,7 @@ func (n *Node) CreateStream(ctx context.Context, peerID peer.ID) (libp2pnet.Stre
 			lg.Debug().Msg("address not found in peer store, but found in dht search")
 		}
 	}
-	stream, dialAddrs, err := n.unicastManager.CreateStream(ctx, peerID, maxConnectAttempt)
+	stream, dialAddrs, err := n.unicastManager.CreateStream(ctx, peerID)
 	if err != nil {
 		return nil, flownet.NewPeerUnreachableError(fmt.Errorf("could not create stream (peer_id: %s, dialing address(s): %v): %w", peerID,
 			dialAddrs, err))
@@ -46,20 +46,18 @@ func DefaultLibP2PNodeFactory(
 	maxPubSubMsgSize int,
 	metrics module.NetworkMetrics,
 	pingInfoProvider PingInfoProvider,
-	dnsResolverTTL time.Duration,
+	resolver *dns.Resolver,
 	role string) (LibP2PFactoryFunc, error) {
 
 	connManager := NewConnManager(log, metrics)
 
 	connGater := NewConnGater(log)
 
-	resolver := dns.NewResolver(metrics, dns.WithTTL(dnsResolverTTL))
-
 	psOpts := DefaultPubsubOptions(maxPubSubMsgSize)
 
 	if role != "ghost" {
 		psOpts = append(psOpts, func(_ context.Context, h host.Host) (pubsub.Option, error) {
-			return pubsub.WithSubscriptionFilter(NewRoleBasedFilter(h.ID(), idProvider)), nil
+			return pubsub.WithSubscriptionFilter(NewRoleBasedFilter(h.ID(), sporkId, idProvider)), nil
 		})
 	}
 
@@ -72,6 +70,7 @@ func DefaultLibP2PNodeFactory(
 			SetPingInfoProvider(pingInfoProvider).
 			SetLogger(log).
 			SetResolver(resolver).
+			SetUnicastOptions(unicast.WithMetrics(metrics)).
 			Build(ctx)
 	}, nil
 }
@@ -86,6 +85,7 @@ type NodeBuilder interface {
 	SetTopicValidation(bool) NodeBuilder
 	SetLogger(zerolog.Logger) NodeBuilder
 	SetResolver(*dns.Resolver) NodeBuilder
+	SetUnicastOptions(...unicast.ManagerOption) NodeBuilder
 	Build(context.Context) (*Node, error)
 }
 
@@ -102,6 +102,7 @@ type DefaultLibP2PNodeBuilder struct {
 	pubSubOpts       []PubsubOption
 	dhtOpts          []dht.Option
 	topicValidation  bool
+	unicastOpts      []unicast.ManagerOption
 }
 
 func NewDefaultLibP2PNodeBuilder(id flow.Identifier, address string, flowKey fcrypto.PrivateKey) NodeBuilder {
@@ -162,6 +163,13 @@ func (builder *DefaultLibP2PNodeBuilder) SetResolver(resolver *dns.Resolver) Nod
 	return builder
 }
 
+// SetUnicastOptions sets the options of the unicast manager, e.g., the dial timeout, write deadline and retries of
+// unicast streams. Options which are not set are defaulted by the unicast manager.
+func (builder *DefaultLibP2PNodeBuilder) SetUnicastOptions(opts ...unicast.ManagerOption) NodeBuilder {
+	builder.unicastOpts = opts
+	return builder
+}
+
 func (builder *DefaultLibP2PNodeBuilder) Build(ctx context.Context) (*Node, error) {
 	node := &Node{
 		id:              builder.id,
@@ -222,7 +230,8 @@ func (builder *DefaultLibP2PNodeBuilder) Build(ctx context.Context) (*Node, erro
 	node.unicastManager = unicast.NewUnicastManager(
 		builder.logger,
 		unicast.NewLibP2PStreamFactory(node.host),
-		builder.sporkId)
+		builder.sporkId,
+		builder.unicastOpts...)
 
 	node.pCache, err = newProtocolPeerCache(node.logger, libp2pHost)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/flow-go/network/validator"
 	psValidator "github.com/onflow/flow-go/network/validator/pubsub"
 	_ "github.com/onflow/flow-go/utils/binstat"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 const (
@@ -79,6 +80,7 @@ type Middleware struct {
 	connectionGating           bool
 	idTranslator               IDTranslator
 	previousProtocolStatePeers []peer.AddrInfo
+	unicastFallback            *unicastFallback
 	*component.ComponentManager
 }
 
@@ -108,6 +110,14 @@ func WithConnectionGating(enabled bool) MiddlewareOption {
 	}
 }
 
+// WithUnicastFallback enables the fallback of unicast messages to pubsub for the channels of the given
+// configuration, once the unicast delivery to a target fails repeatedly.
+func WithUnicastFallback(config UnicastFallbackConfig) MiddlewareOption {
+	return func(mw *Middleware) {
+		mw.unicastFallback = newUnicastFallback(config)
+	}
+}
+
 // NewMiddleware creates a new middleware instance
 // libP2PNodeFactory is the factory used to create a LibP2PNode
 // flowID is this node's Flow ID
@@ -315,7 +325,55 @@ func (m *Middleware) stop() {
 //
 // Dispatch should be used whenever guaranteed delivery to a specific target is required. Otherwise, Publish is
 // a more efficient candidate.
+//
+// If the unicast fallback is enabled for the channel of the message and the unicasts to the target failed
+// repeatedly, the message is additionally published on the channel, and an error is only returned if
+// both the unicast and the publication fail.
 func (m *Middleware) SendDirect(msg *message.Message, targetID flow.Identifier) error {
+	channel := network.Channel(msg.ChannelID)
+	if m.unicastFallback == nil || !m.unicastFallback.enabled(channel) {
+		return m.sendDirect(msg, targetID)
+	}
+
+	err := m.sendDirect(msg, targetID)
+	if err == nil {
+		if m.unicastFallback.onSuccess(targetID) {
+			m.log.Info().
+				Str("channel", channel.String()).
+				Hex("target_id", logging.ID(targetID)).
+				Msg("unicast delivery recovered, deactivated fallback to pubsub")
+		}
+	} else if m.unicastFallback.onFailure(targetID) {
+		m.log.Warn().
+			Err(err).
+			Str("channel", channel.String()).
+			Hex("target_id", logging.ID(targetID)).
+			Msg("unicast delivery failed repeatedly, activated fallback to pubsub")
+		m.metrics.UnicastFallbackActivated(channel.String())
+	}
+
+	if !m.unicastFallback.active(targetID) {
+		return err
+	}
+
+	// the published message is identical to the unicast one, its target IDs restrict the delivery to the
+	// target and its event ID lets the target deduplicate the copies it receives.
+	pubErr := m.Publish(msg, channel)
+	if pubErr != nil {
+		if err != nil {
+			return fmt.Errorf("failed to publish message to %s after failed unicast (%v): %w", targetID, err, pubErr)
+		}
+		m.log.Warn().
+			Err(pubErr).
+			Str("channel", channel.String()).
+			Hex("target_id", logging.ID(targetID)).
+			Msg("failed to publish fallback message")
+	}
+	return nil
+}
+
+// sendDirect sends msg on a 1-1 direct connection to the target ID.
+func (m *Middleware) sendDirect(msg *message.Message, targetID flow.Identifier) error {
 	// translates identifier to peer id
 	peerID, err := m.idTranslator.GetPeerID(targetID)
 	if err != nil {
@@ -356,12 +414,14 @@ func (m *Middleware) SendDirect(msg *message.Message, targetID flow.Identifier)
 
 	err = writer.WriteMsg(msg)
 	if err != nil {
+		m.metrics.UnicastStreamFailure(unicast.WriteFailure(err))
 		return fmt.Errorf("failed to send message to %s: %w", targetID, err)
 	}
 
 	// flush the stream
 	err = bufw.Flush()
 	if err != nil {
+		m.metrics.UnicastStreamFailure(unicast.WriteFailure(err))
 		return fmt.Errorf("failed to flush stream for %s: %w", targetID, err)
 	}
 
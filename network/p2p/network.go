@@ -20,6 +20,7 @@ import (
 	"github.com/onflow/flow-go/module/component"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/misbehavior"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/message"
 	"github.com/onflow/flow-go/network/queue"
@@ -52,9 +53,21 @@ type Network struct {
 	subMngr                     network.SubscriptionManager // used to keep track of subscribed channels
 	registerEngineRequests      chan *registerEngineRequest
 	registerBlobServiceRequests chan *registerBlobServiceRequest
+	misbehavior                 module.MisbehaviorReporter // used to report messages violating the channel subscriptions
 	*component.ComponentManager
 }
 
+// NetworkOption is a functional option to configure the Network.
+type NetworkOption func(*Network)
+
+// WithMisbehaviorReporter sets the reporter of protocol violations detected by the network,
+// e.g. messages which are sent on channels this node isn't subscribed to.
+func WithMisbehaviorReporter(reporter module.MisbehaviorReporter) NetworkOption {
+	return func(n *Network) {
+		n.misbehavior = reporter
+	}
+}
+
 var _ network.Network = (*Network)(nil)
 
 type registerEngineRequest struct {
@@ -95,6 +108,7 @@ func NewNetwork(
 	sm network.SubscriptionManager,
 	metrics module.NetworkMetrics,
 	identityProvider id.IdentityProvider,
+	opts ...NetworkOption,
 ) (*Network, error) {
 
 	rcache, err := newRcvCache(csize)
@@ -119,6 +133,11 @@ func NewNetwork(
 		identityProvider:            identityProvider,
 		registerEngineRequests:      make(chan *registerEngineRequest),
 		registerBlobServiceRequests: make(chan *registerBlobServiceRequest),
+		misbehavior:                 misbehavior.NewNoopReporter(),
+	}
+
+	for _, opt := range opts {
+		opt(o)
 	}
 
 	o.mw.SetOverlay(o)
@@ -526,6 +545,13 @@ func (n *Network) queueSubmitFunc(message interface{}) {
 			Str("channel_id", qm.Target.String()).
 			Str("sender_id", qm.SenderID.String()).
 			Msg("failed to submit message")
+
+		// the sender sent a message on a channel this node isn't subscribed to
+		if entity, ok := qm.Payload.(flow.Entity); ok {
+			n.misbehavior.Report(qm.SenderID, flow.MisbehaviorChannelViolation, entity)
+		} else {
+			n.misbehavior.Report(qm.SenderID, flow.MisbehaviorChannelViolation)
+		}
 		return
 	}
 
@@ -534,7 +560,15 @@ func (n *Network) queueSubmitFunc(message interface{}) {
 	startTimestamp := time.Now()
 
 	err = eng.Process(qm.Target, qm.SenderID, qm.Payload)
-	if err != nil {
+	if channels.IsBackpressureError(err) {
+		// the engine is saturated and rejected the message; this is not a failure of the
+		// sender, hence the sender is neither reported nor the rejection logged as error
+		n.logger.Debug().
+			Err(err).
+			Str("channel_id", qm.Target.String()).
+			Str("sender_id", qm.SenderID.String()).
+			Msg("message rejected by saturated engine")
+	} else if err != nil {
 		n.logger.Error().
 			Err(err).
 			Str("channel_id", qm.Target.String()).
@@ -11,16 +11,20 @@ import (
 )
 
 // RoleBasedFilter implements a subscription filter that filters subscriptions based on a node's role.
+// Subscriptions to topics of other sporks are always filtered, so that nodes connected to the wrong
+// network fail to subscribe, rather than exchanging messages which can't be validated.
 type RoleBasedFilter struct {
 	idProvider id.IdentityProvider
 	myPeerID   peer.ID
 	myRole     *flow.Role
+	sporkID    flow.Identifier
 }
 
-func NewRoleBasedFilter(pid peer.ID, idProvider id.IdentityProvider) *RoleBasedFilter {
+func NewRoleBasedFilter(pid peer.ID, sporkID flow.Identifier, idProvider id.IdentityProvider) *RoleBasedFilter {
 	filter := &RoleBasedFilter{
 		idProvider: idProvider,
 		myPeerID:   pid,
+		sporkID:    sporkID,
 	}
 	filter.myRole = filter.getRole(pid)
 
@@ -36,6 +40,11 @@ func (f *RoleBasedFilter) getRole(pid peer.ID) *flow.Role {
 }
 
 func (f *RoleBasedFilter) allowed(role *flow.Role, topic string) bool {
+	sporkID, ok := engine.SporkIDFromTopic(network.Topic(topic))
+	if !ok || sporkID != f.sporkID {
+		return false
+	}
+
 	channel, ok := engine.ChannelFromTopic(network.Topic(topic))
 	if !ok {
 		return false
@@ -29,8 +29,8 @@ func TestFilterSubscribe(t *testing.T) {
 	identity2, privateKey2 := unittest.IdentityWithNetworkingKeyFixture(unittest.WithRole(flow.RoleAccess))
 	ids := flow.IdentityList{identity1, identity2}
 
-	node1 := createNode(t, identity1.NodeID, privateKey1, sporkId, mockSubscriptionFilterPubsubOption(ids))
-	node2 := createNode(t, identity2.NodeID, privateKey2, sporkId, mockSubscriptionFilterPubsubOption(ids))
+	node1 := createNode(t, identity1.NodeID, privateKey1, sporkId, mockSubscriptionFilterPubsubOption(sporkId, ids))
+	node2 := createNode(t, identity2.NodeID, privateKey2, sporkId, mockSubscriptionFilterPubsubOption(sporkId, ids))
 
 	unstakedKey := unittest.NetworkingPrivKeyFixture()
 	unstakedNode := createNode(t, flow.ZeroID, unstakedKey, sporkId)
@@ -103,7 +103,7 @@ func TestCanSubscribe(t *testing.T) {
 	identity, privateKey := unittest.IdentityWithNetworkingKeyFixture(unittest.WithRole(flow.RoleCollection))
 	sporkId := unittest.IdentifierFixture()
 
-	collectionNode := createNode(t, identity.NodeID, privateKey, sporkId, mockSubscriptionFilterPubsubOption(flow.IdentityList{identity}))
+	collectionNode := createNode(t, identity.NodeID, privateKey, sporkId, mockSubscriptionFilterPubsubOption(sporkId, flow.IdentityList{identity}))
 	defer func() {
 		done, err := collectionNode.Stop()
 		require.NoError(t, err)
@@ -131,11 +131,18 @@ func TestCanSubscribe(t *testing.T) {
 	clusterTopic := engine.TopicFromChannel(engine.ChannelSyncCluster(flow.Emulator), sporkId)
 	_, err = collectionNode.pubSub.Join(clusterTopic.String())
 	require.NoError(t, err)
+
+	// topics of other sporks are rejected, even for channels the role is allowed to subscribe to
+	otherSporkId := unittest.IdentifierFixture()
+	_, err = collectionNode.pubSub.Join(engine.TopicFromChannel(engine.ProvideCollections, otherSporkId).String())
+	require.Error(t, err)
+	_, err = collectionNode.pubSub.Join(engine.TopicFromChannel(engine.ChannelSyncCluster(flow.Emulator), otherSporkId).String())
+	require.Error(t, err)
 }
 
-func mockSubscriptionFilterPubsubOption(ids flow.IdentityList) PubsubOption {
+func mockSubscriptionFilterPubsubOption(sporkId flow.Identifier, ids flow.IdentityList) PubsubOption {
 	idProvider := id.NewFixedIdentityProvider(ids)
 	return func(_ context.Context, h host.Host) (pubsub.Option, error) {
-		return pubsub.WithSubscriptionFilter(NewRoleBasedFilter(h.ID(), idProvider)), nil
+		return pubsub.WithSubscriptionFilter(NewRoleBasedFilter(h.ID(), sporkId, idProvider)), nil
 	}
 }
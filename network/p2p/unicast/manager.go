@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
+	"os"
 	"strings"
 	"time"
 
@@ -17,26 +19,111 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
 )
 
-// MaxConnectAttemptSleepDuration is the maximum number of milliseconds to wait between attempts for a 1-1 direct connection
-const MaxConnectAttemptSleepDuration = 5
+const (
+	// DefaultMaxAttempts is the default maximum number of attempts to create a stream to a remote node on each
+	// unicast protocol.
+	DefaultMaxAttempts = 3
+
+	// DefaultDialTimeout is the default maximum time for a single attempt to connect and create a stream to a
+	// remote node.
+	DefaultDialTimeout = 2 * time.Second
+
+	// DefaultRetryBackoff is the default backoff before the first retry of a stream creation. The backoff doubles
+	// with each retry, and the actual wait is chosen randomly up to the backoff (to ensure that this node and the
+	// target node don't attempt to reconnect at the same time).
+	DefaultRetryBackoff = 5 * time.Millisecond
+
+	// DefaultMaxRetryBackoff is the default upper bound of the backoff between stream creation attempts.
+	DefaultMaxRetryBackoff = 100 * time.Millisecond
+)
+
+// Failure classes of unicast streams, which are reported to the network metrics.
+const (
+	StreamFailureDialTimeout          = "dial_timeout"
+	StreamFailureCanceled             = "canceled"
+	StreamFailureInvalidNodeID        = "invalid_node_id"
+	StreamFailureGaterDisallowed      = "gater_disallowed"
+	StreamFailureConnect              = "connect"
+	StreamFailureProtocolNotSupported = "protocol_not_supported"
+	StreamFailureNewStream            = "new_stream"
+	StreamFailureWriteDeadline        = "write_deadline"
+	StreamFailureWrite                = "write"
+)
+
+// ManagerOption configures optional parameters of the unicast manager.
+type ManagerOption func(*Manager)
+
+// WithMaxAttempts sets the maximum number of attempts to create a stream on each unicast protocol.
+func WithMaxAttempts(maxAttempts int) ManagerOption {
+	return func(m *Manager) {
+		m.maxAttempts = maxAttempts
+	}
+}
+
+// WithDialTimeout sets the maximum time for a single attempt to connect and create a stream.
+func WithDialTimeout(timeout time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.dialTimeout = timeout
+	}
+}
+
+// WithWriteDeadline sets a deadline for writes to created streams, relative to the creation of the stream.
+// Regardless of this option, the writes are bounded by the deadline of the context the stream is created with.
+func WithWriteDeadline(deadline time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.writeDeadline = deadline
+	}
+}
+
+// WithRetryBackoff sets the backoff before the first retry of a stream creation and its upper bound.
+func WithRetryBackoff(backoff time.Duration, maxBackoff time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.retryBackoff = backoff
+		m.maxRetryBackoff = maxBackoff
+	}
+}
+
+// WithMetrics sets the metrics, to which the failures of stream creations are reported.
+func WithMetrics(metrics module.NetworkMetrics) ManagerOption {
+	return func(m *Manager) {
+		m.metrics = metrics
+	}
+}
 
 // Manager manages libp2p stream negotiation and creation, which is utilized for unicast dispatches.
 type Manager struct {
-	logger         zerolog.Logger
-	streamFactory  StreamFactory
-	unicasts       []Protocol
-	defaultHandler libp2pnet.StreamHandler
-	sporkId        flow.Identifier
+	logger          zerolog.Logger
+	streamFactory   StreamFactory
+	unicasts        []Protocol
+	defaultHandler  libp2pnet.StreamHandler
+	sporkId         flow.Identifier
+	metrics         module.NetworkMetrics
+	maxAttempts     int
+	dialTimeout     time.Duration
+	writeDeadline   time.Duration
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
 }
 
-func NewUnicastManager(logger zerolog.Logger, streamFactory StreamFactory, sporkId flow.Identifier) *Manager {
-	return &Manager{
-		logger:        logger,
-		streamFactory: streamFactory,
-		sporkId:       sporkId,
+func NewUnicastManager(logger zerolog.Logger, streamFactory StreamFactory, sporkId flow.Identifier, opts ...ManagerOption) *Manager {
+	m := &Manager{
+		logger:          logger,
+		streamFactory:   streamFactory,
+		sporkId:         sporkId,
+		metrics:         metrics.NewNoopCollector(),
+		maxAttempts:     DefaultMaxAttempts,
+		dialTimeout:     DefaultDialTimeout,
+		retryBackoff:    DefaultRetryBackoff,
+		maxRetryBackoff: DefaultMaxRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // WithDefaultHandler sets the default stream handler for this unicast manager. The default handler is utilized
@@ -76,18 +163,25 @@ func (m *Manager) Register(unicast ProtocolName) error {
 }
 
 // CreateStream tries establishing a libp2p stream to the remote peer id. It tries creating streams in the descending order of preference until
-// it either creates a successful stream or runs out of options. Creating stream on each protocol is tried at most `maxAttempt` one, and then falls
+// it either creates a successful stream or runs out of options. Creating stream on each protocol is tried at most `maxAttempts` times, and then falls
 // back to the less preferred one.
-func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID, maxAttempts int) (libp2pnet.Stream, []multiaddr.Multiaddr, error) {
+// Writes to the created stream fail once the write deadline of the manager, or the deadline of the context, passes.
+func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID) (libp2pnet.Stream, []multiaddr.Multiaddr, error) {
 	var errs error
 
 	for i := len(m.unicasts) - 1; i >= 0; i-- {
-		s, addrs, err := m.createStreamWithProtocol(ctx, m.unicasts[i].ProtocolId(), peerID, maxAttempts)
+		s, addrs, err := m.createStreamWithProtocol(ctx, m.unicasts[i].ProtocolId(), peerID)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
 		}
 
+		err = m.setWriteDeadline(ctx, s)
+		if err != nil {
+			_ = s.Reset()
+			return nil, addrs, fmt.Errorf("could not set write deadline of stream: %w", err)
+		}
+
 		// return first successful stream
 		return s, addrs, nil
 	}
@@ -95,8 +189,24 @@ func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID, maxAttempts
 	return nil, nil, fmt.Errorf("could not create stream on any available unicast protocol: %w", errs)
 }
 
+// setWriteDeadline sets the earlier of the write deadline of the manager and the deadline of the context as the
+// write deadline of the stream. The stream is left without a write deadline, if neither is set.
+func (m *Manager) setWriteDeadline(ctx context.Context, s libp2pnet.Stream) error {
+	deadline, ok := ctx.Deadline()
+	if m.writeDeadline > 0 {
+		writeDeadline := time.Now().Add(m.writeDeadline)
+		if !ok || writeDeadline.Before(deadline) {
+			deadline, ok = writeDeadline, true
+		}
+	}
+	if !ok {
+		return nil
+	}
+	return s.SetWriteDeadline(deadline)
+}
+
 // createStreamWithProtocol creates a stream on specified protocol.
-// It makes at most `maxAttempts` to create a stream with the peer.
+// It makes at most `maxAttempts` to create a stream with the peer, each of which is bounded by the dial timeout.
 // This was put in as a fix for #2416. PubSub and 1-1 communication compete with each other when trying to connect to
 // remote nodes and once in a while NewStream returns an error 'both yamux endpoints are clients'.
 //
@@ -104,16 +214,22 @@ func (m *Manager) CreateStream(ctx context.Context, peerID peer.ID, maxAttempts
 // The multiaddr.Multiaddr return value represents the addresses of `peerID` we dial while trying to create a stream to it.
 func (m *Manager) createStreamWithProtocol(ctx context.Context,
 	protocolID protocol.ID,
-	peerID peer.ID,
-	maxAttempts int) (libp2pnet.Stream, []multiaddr.Multiaddr, error) {
+	peerID peer.ID) (libp2pnet.Stream, []multiaddr.Multiaddr, error) {
 
 	var errs error
-	var s libp2pnet.Stream
-	var retries = 0
 	var dialAddr []multiaddr.Multiaddr // address on which we dial peerID
-	for ; retries < maxAttempts; retries++ {
+	for retries := 0; retries < m.maxAttempts; retries++ {
+		// if this is a retry attempt, wait for some time before retrying
+		if retries > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(m.backoff(retries)):
+			}
+		}
+
 		select {
 		case <-ctx.Done():
+			m.metrics.UnicastStreamFailure(StreamFailureCanceled)
 			return nil, nil, fmt.Errorf("context done before stream could be created (retry attempt: %d, errors: %w)", retries, errs)
 		default:
 		}
@@ -128,48 +244,89 @@ func (m *Manager) createStreamWithProtocol(ctx context.Context,
 		dialAddr = m.streamFactory.DialAddress(peerID)
 		m.streamFactory.ClearBackoff(peerID)
 
-		// if this is a retry attempt, wait for some time before retrying
-		if retries > 0 {
-			// choose a random interval between 0 to 5
-			// (to ensure that this node and the target node don't attempt to reconnect at the same time)
-			r := rand.Intn(MaxConnectAttemptSleepDuration)
-			time.Sleep(time.Duration(r) * time.Millisecond)
+		s, reason, err := m.attemptStream(ctx, protocolID, peerID)
+		if err == nil {
+			return s, dialAddr, nil
 		}
+		m.metrics.UnicastStreamFailure(reason)
 
-		err := m.streamFactory.Connect(ctx, peer.AddrInfo{ID: peerID})
-		if err != nil {
-
+		switch reason {
+		case StreamFailureInvalidNodeID:
 			// if the connection was rejected due to invalid node id, skip the re-attempt
-			if strings.Contains(err.Error(), "failed to negotiate security protocol") {
-				return s, dialAddr, fmt.Errorf("invalid node id: %w", err)
-			}
-
+			return nil, dialAddr, fmt.Errorf("invalid node id: %w", err)
+		case StreamFailureGaterDisallowed:
 			// if the connection was rejected due to allowlisting, skip the re-attempt
-			if errors.Is(err, swarm.ErrGaterDisallowedConnection) {
-				return s, dialAddr, fmt.Errorf("target node is not on the approved list of nodes: %w", err)
-			}
-
-			errs = multierror.Append(errs, err)
-			continue
+			return nil, dialAddr, fmt.Errorf("target node is not on the approved list of nodes: %w", err)
+		case StreamFailureProtocolNotSupported:
+			// if the stream creation failed due to invalid protocol id, skip the re-attempt
+			return nil, dialAddr, fmt.Errorf("remote node is running on a different spork: %w, protocol attempted: %s", err, protocolID)
 		}
+		errs = multierror.Append(errs, err)
+	}
 
-		// creates stream using stream factory
-		s, err = m.streamFactory.NewStream(ctx, peerID, protocolID)
-		if err != nil {
-			// if the stream creation failed due to invalid protocol id, skip the re-attempt
-			if strings.Contains(err.Error(), "protocol not supported") {
-				return nil, dialAddr, fmt.Errorf("remote node is running on a different spork: %w, protocol attempted: %s", err, protocolID)
+	return nil, dialAddr, errs
+}
+
+// attemptStream makes a single attempt to connect to the peer and create a stream on the specified protocol, which is
+// bounded by the dial timeout. In case of failure, the failure class is returned along with the error.
+func (m *Manager) attemptStream(ctx context.Context, protocolID protocol.ID, peerID peer.ID) (libp2pnet.Stream, string, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, m.dialTimeout)
+	defer cancel()
+
+	// timeouts are only attributed to the dial timeout, if the parent context has not expired
+	failure := func(reason string, err error) (libp2pnet.Stream, string, error) {
+		if dialCtx.Err() != nil {
+			if ctx.Err() != nil {
+				return nil, StreamFailureCanceled, err
 			}
-			errs = multierror.Append(errs, err)
-			continue
+			return nil, StreamFailureDialTimeout, err
 		}
+		return nil, reason, err
+	}
 
-		break
+	err := m.streamFactory.Connect(dialCtx, peer.AddrInfo{ID: peerID})
+	if err != nil {
+		if strings.Contains(err.Error(), "failed to negotiate security protocol") {
+			return nil, StreamFailureInvalidNodeID, err
+		}
+		if errors.Is(err, swarm.ErrGaterDisallowedConnection) {
+			return nil, StreamFailureGaterDisallowed, err
+		}
+		return failure(StreamFailureConnect, err)
 	}
 
-	if retries == maxAttempts {
-		return s, dialAddr, errs
+	// creates stream using stream factory
+	s, err := m.streamFactory.NewStream(dialCtx, peerID, protocolID)
+	if err != nil {
+		if strings.Contains(err.Error(), "protocol not supported") {
+			return nil, StreamFailureProtocolNotSupported, err
+		}
+		return failure(StreamFailureNewStream, err)
 	}
 
-	return s, dialAddr, nil
+	return s, "", nil
+}
+
+// backoff returns a random wait before the given retry, which is bounded by the exponentially growing retry backoff.
+func (m *Manager) backoff(retry int) time.Duration {
+	backoff := m.retryBackoff
+	for i := 1; i < retry && backoff < m.maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > m.maxRetryBackoff {
+		backoff = m.maxRetryBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// WriteFailure returns the failure class of an error of a write to a unicast stream.
+func WriteFailure(err error) string {
+	var netErr net.Error
+	if errors.Is(err, os.ErrDeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return StreamFailureWriteDeadline
+	}
+	return StreamFailureWrite
 }
@@ -0,0 +1,188 @@
+package unicast
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestCreateStream_WriteDeadline tests that writes to a created stream, which block on the remote peer, fail once
+// the write deadline of the manager passes.
+func TestCreateStream_WriteDeadline(t *testing.T) {
+	factory := &stubStreamFactory{}
+	manager := newTestManager(factory, WithWriteDeadline(100*time.Millisecond))
+
+	s, _, err := manager.CreateStream(context.Background(), peer.ID("target"))
+	require.NoError(t, err)
+
+	unittest.RequireReturnsBefore(t, func() {
+		_, err = s.Write([]byte("message"))
+	}, time.Second, "write did not fail on write deadline")
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.Equal(t, StreamFailureWriteDeadline, WriteFailure(err))
+}
+
+// TestCreateStream_ContextDeadline tests that writes to a created stream are bounded by the deadline of the context
+// the stream is created with, if it is earlier than the write deadline of the manager.
+func TestCreateStream_ContextDeadline(t *testing.T) {
+	factory := &stubStreamFactory{}
+	manager := newTestManager(factory, WithWriteDeadline(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	s, _, err := manager.CreateStream(ctx, peer.ID("target"))
+	require.NoError(t, err)
+
+	unittest.RequireReturnsBefore(t, func() {
+		_, err = s.Write([]byte("message"))
+	}, time.Second, "write did not fail on context deadline")
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+}
+
+// TestCreateStream_DialTimeout tests that each attempt to create a stream is bounded by the dial timeout, and that the
+// manager gives up after the maximum number of attempts, reporting each failure to the metrics.
+func TestCreateStream_DialTimeout(t *testing.T) {
+	factory := &stubStreamFactory{blockNewStream: true}
+	metrics := &mockmodule.NetworkMetrics{}
+	metrics.On("UnicastStreamFailure", StreamFailureDialTimeout).Return()
+	manager := newTestManager(factory,
+		WithMetrics(metrics),
+		WithDialTimeout(50*time.Millisecond),
+		WithMaxAttempts(4),
+		WithRetryBackoff(time.Millisecond, 5*time.Millisecond))
+
+	var err error
+	unittest.RequireReturnsBefore(t, func() {
+		_, _, err = manager.CreateStream(context.Background(), peer.ID("target"))
+	}, time.Second, "stream creation did not time out")
+	require.Error(t, err)
+
+	assert.Equal(t, int64(4), factory.newStreamCalls.Load())
+	metrics.AssertNumberOfCalls(t, "UnicastStreamFailure", 4)
+}
+
+// TestCreateStream_FailFast tests that the manager does not retry creating a stream, if the remote node does not
+// support the protocol.
+func TestCreateStream_FailFast(t *testing.T) {
+	factory := &stubStreamFactory{newStreamErr: fmt.Errorf("protocol not supported")}
+	metrics := &mockmodule.NetworkMetrics{}
+	metrics.On("UnicastStreamFailure", StreamFailureProtocolNotSupported).Return()
+	manager := newTestManager(factory, WithMetrics(metrics), WithMaxAttempts(4))
+
+	_, _, err := manager.CreateStream(context.Background(), peer.ID("target"))
+	require.Error(t, err)
+
+	assert.Equal(t, int64(1), factory.newStreamCalls.Load())
+	metrics.AssertNumberOfCalls(t, "UnicastStreamFailure", 1)
+}
+
+// TestBackoff tests that the backoff between attempts grows exponentially up to its upper bound.
+func TestBackoff(t *testing.T) {
+	manager := newTestManager(&stubStreamFactory{}, WithRetryBackoff(10*time.Millisecond, 35*time.Millisecond))
+
+	bounds := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 35 * time.Millisecond, 35 * time.Millisecond}
+	for i, bound := range bounds {
+		for j := 0; j < 100; j++ {
+			require.Less(t, int64(manager.backoff(i+1)), int64(bound))
+		}
+	}
+}
+
+func newTestManager(factory StreamFactory, opts ...ManagerOption) *Manager {
+	manager := NewUnicastManager(zerolog.Nop(), factory, unittest.IdentifierFixture(), opts...)
+	manager.WithDefaultHandler(func(libp2pnet.Stream) {})
+	return manager
+}
+
+// stubStreamFactory is a stream factory, which creates streams to a remote peer that never reads from them.
+type stubStreamFactory struct {
+	blockNewStream bool  // if set, stream creations block until the context is done
+	newStreamErr   error // if set, stream creations fail with this error
+	newStreamCalls atomic.Int64
+}
+
+func (f *stubStreamFactory) SetStreamHandler(protocol.ID, libp2pnet.StreamHandler) {}
+
+func (f *stubStreamFactory) DialAddress(peer.ID) []multiaddr.Multiaddr {
+	return nil
+}
+
+func (f *stubStreamFactory) ClearBackoff(peer.ID) {}
+
+func (f *stubStreamFactory) Connect(context.Context, peer.AddrInfo) error {
+	return nil
+}
+
+func (f *stubStreamFactory) NewStream(ctx context.Context, _ peer.ID, _ ...protocol.ID) (libp2pnet.Stream, error) {
+	f.newStreamCalls.Inc()
+	if f.blockNewStream {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if f.newStreamErr != nil {
+		return nil, f.newStreamErr
+	}
+	return newBlockingStream(), nil
+}
+
+// blockingStream is a stream, of which writes block until its write deadline passes or it is closed.
+type blockingStream struct {
+	libp2pnet.Stream
+
+	mu       sync.Mutex
+	deadline time.Time
+	closed   chan struct{}
+	once     sync.Once
+}
+
+func newBlockingStream() *blockingStream {
+	return &blockingStream{closed: make(chan struct{})}
+}
+
+func (s *blockingStream) Write([]byte) (int, error) {
+	s.mu.Lock()
+	deadline := s.deadline
+	s.mu.Unlock()
+
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		expired = time.After(time.Until(deadline))
+	}
+	select {
+	case <-expired:
+		return 0, os.ErrDeadlineExceeded
+	case <-s.closed:
+		return 0, fmt.Errorf("stream closed")
+	}
+}
+
+func (s *blockingStream) SetWriteDeadline(deadline time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadline = deadline
+	return nil
+}
+
+func (s *blockingStream) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *blockingStream) Reset() error {
+	return s.Close()
+}
@@ -0,0 +1,151 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+)
+
+const (
+	// DefaultUnicastFallbackThreshold is the default number of consecutive unicast failures to a target
+	// after which the fallback to pubsub is activated for the target.
+	DefaultUnicastFallbackThreshold = 3
+
+	// DefaultUnicastFallbackWindow is the default duration within which the consecutive unicast failures
+	// to a target must occur to activate the fallback.
+	DefaultUnicastFallbackWindow = time.Minute
+
+	// DefaultUnicastFallbackRecovery is the default number of consecutive successful unicasts to a target
+	// after which the fallback to pubsub is deactivated for the target.
+	DefaultUnicastFallbackRecovery = 3
+)
+
+// UnicastFallbackConfig configures the fallback of unicast messages to pubsub.
+//
+// Once Threshold consecutive unicasts to a target fail within Window, unicast messages to the target
+// on one of the Channels are additionally published on the pubsub topic of the channel, until Recovery
+// consecutive unicasts to the target succeed again. The published message is identical to the unicast
+// one, hence its event ID serves the recipient to deduplicate the copies it receives.
+type UnicastFallbackConfig struct {
+	Channels  network.ChannelList // channels opted in to the fallback
+	Threshold uint                // consecutive failures activating the fallback for a target
+	Window    time.Duration       // duration within which the consecutive failures must occur
+	Recovery  uint                // consecutive successes deactivating the fallback for a target
+}
+
+// DefaultUnicastFallbackConfig returns the default fallback configuration for the given channels.
+func DefaultUnicastFallbackConfig(channels ...network.Channel) UnicastFallbackConfig {
+	return UnicastFallbackConfig{
+		Channels:  channels,
+		Threshold: DefaultUnicastFallbackThreshold,
+		Window:    DefaultUnicastFallbackWindow,
+		Recovery:  DefaultUnicastFallbackRecovery,
+	}
+}
+
+// fallbackState is the unicast delivery state of a single target.
+type fallbackState struct {
+	failures     uint      // consecutive failures
+	firstFailure time.Time // time of the first of the consecutive failures
+	successes    uint      // consecutive successes since the fallback was activated
+	active       bool      // whether the fallback is active
+}
+
+// unicastFallback tracks the unicast delivery state per target and decides whether unicast messages
+// to a target are to be published on pubsub as well.
+type unicastFallback struct {
+	sync.Mutex
+	config UnicastFallbackConfig
+	now    func() time.Time
+	states map[flow.Identifier]*fallbackState
+}
+
+// newUnicastFallback creates a new unicast fallback policy with the given configuration.
+func newUnicastFallback(config UnicastFallbackConfig) *unicastFallback {
+	if config.Threshold == 0 {
+		config.Threshold = DefaultUnicastFallbackThreshold
+	}
+	if config.Recovery == 0 {
+		config.Recovery = DefaultUnicastFallbackRecovery
+	}
+	return &unicastFallback{
+		config: config,
+		now:    time.Now,
+		states: make(map[flow.Identifier]*fallbackState),
+	}
+}
+
+// enabled returns true if the given channel is opted in to the fallback.
+func (u *unicastFallback) enabled(channel network.Channel) bool {
+	return u.config.Channels.Contains(channel)
+}
+
+// active returns true if the fallback is active for the given target.
+func (u *unicastFallback) active(targetID flow.Identifier) bool {
+	u.Lock()
+	defer u.Unlock()
+
+	state, ok := u.states[targetID]
+	return ok && state.active
+}
+
+// onFailure records a failed unicast to the given target. It returns true if the failure activated the
+// fallback for the target.
+func (u *unicastFallback) onFailure(targetID flow.Identifier) bool {
+	u.Lock()
+	defer u.Unlock()
+
+	state, ok := u.states[targetID]
+	if !ok {
+		state = &fallbackState{}
+		u.states[targetID] = state
+	}
+
+	// a failure interrupts the recovery of an active fallback
+	if state.active {
+		state.successes = 0
+		return false
+	}
+
+	// failures only count as consecutive if they occur within the window
+	now := u.now()
+	if state.failures == 0 || (u.config.Window > 0 && now.Sub(state.firstFailure) > u.config.Window) {
+		state.failures = 0
+		state.firstFailure = now
+	}
+	state.failures++
+
+	if state.failures < u.config.Threshold {
+		return false
+	}
+	state.active = true
+	state.successes = 0
+	return true
+}
+
+// onSuccess records a successful unicast to the given target. It returns true if the success deactivated
+// the fallback for the target.
+func (u *unicastFallback) onSuccess(targetID flow.Identifier) bool {
+	u.Lock()
+	defer u.Unlock()
+
+	state, ok := u.states[targetID]
+	if !ok {
+		return false
+	}
+
+	// a success interrupts consecutive failures
+	if !state.active {
+		delete(u.states, targetID)
+		return false
+	}
+
+	state.successes++
+	if state.successes < u.config.Recovery {
+		return false
+	}
+	delete(u.states, targetID)
+	return true
+}
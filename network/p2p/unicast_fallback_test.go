@@ -0,0 +1,107 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fallbackFixture returns a unicast fallback policy for the receipts channel with a threshold of 3 failures
+// within a minute and a recovery of 2 successes, along with a function advancing its clock.
+func fallbackFixture() (*unicastFallback, func(time.Duration)) {
+	fallback := newUnicastFallback(UnicastFallbackConfig{
+		Channels:  network.ChannelList{engine.PushReceipts},
+		Threshold: 3,
+		Window:    time.Minute,
+		Recovery:  2,
+	})
+	now := time.Now()
+	fallback.now = func() time.Time { return now }
+	return fallback, func(d time.Duration) { now = now.Add(d) }
+}
+
+// TestUnicastFallback_Enabled checks that only the configured channels are opted in to the fallback.
+func TestUnicastFallback_Enabled(t *testing.T) {
+	fallback, _ := fallbackFixture()
+	assert.True(t, fallback.enabled(engine.PushReceipts))
+	assert.False(t, fallback.enabled(engine.PushApprovals))
+}
+
+// TestUnicastFallback_Threshold checks that the fallback is activated exactly once the threshold of consecutive
+// failures is reached, and only for the failing target.
+func TestUnicastFallback_Threshold(t *testing.T) {
+	fallback, _ := fallbackFixture()
+	target := unittest.IdentifierFixture()
+	other := unittest.IdentifierFixture()
+
+	assert.False(t, fallback.onFailure(target))
+	assert.False(t, fallback.onFailure(target))
+	assert.False(t, fallback.active(target))
+
+	assert.True(t, fallback.onFailure(target))
+	assert.True(t, fallback.active(target))
+	assert.False(t, fallback.active(other))
+
+	// further failures keep the fallback active without activating it again
+	assert.False(t, fallback.onFailure(target))
+	assert.True(t, fallback.active(target))
+}
+
+// TestUnicastFallback_Interrupted checks that failures only activate the fallback if they are consecutive and
+// occur within the window.
+func TestUnicastFallback_Interrupted(t *testing.T) {
+	t.Run("success interrupts failures", func(t *testing.T) {
+		fallback, _ := fallbackFixture()
+		target := unittest.IdentifierFixture()
+
+		fallback.onFailure(target)
+		fallback.onFailure(target)
+		assert.False(t, fallback.onSuccess(target))
+		assert.False(t, fallback.onFailure(target))
+		assert.False(t, fallback.active(target))
+	})
+
+	t.Run("window expires", func(t *testing.T) {
+		fallback, advance := fallbackFixture()
+		target := unittest.IdentifierFixture()
+
+		fallback.onFailure(target)
+		fallback.onFailure(target)
+		advance(2 * time.Minute)
+		assert.False(t, fallback.onFailure(target))
+		assert.False(t, fallback.active(target))
+
+		// the expired failures are discarded, the latest failure starts a new window
+		assert.False(t, fallback.onFailure(target))
+		assert.True(t, fallback.onFailure(target))
+	})
+}
+
+// TestUnicastFallback_Recovery checks that the fallback is deactivated once the recovery threshold of consecutive
+// successes is reached.
+func TestUnicastFallback_Recovery(t *testing.T) {
+	fallback, _ := fallbackFixture()
+	target := unittest.IdentifierFixture()
+	for i := 0; i < 3; i++ {
+		fallback.onFailure(target)
+	}
+	assert.True(t, fallback.active(target))
+
+	// a failure during the recovery restarts it
+	assert.False(t, fallback.onSuccess(target))
+	fallback.onFailure(target)
+	assert.False(t, fallback.onSuccess(target))
+	assert.True(t, fallback.active(target))
+
+	assert.True(t, fallback.onSuccess(target))
+	assert.False(t, fallback.active(target))
+
+	// after the recovery, the threshold applies again
+	assert.False(t, fallback.onFailure(target))
+	assert.False(t, fallback.active(target))
+}
@@ -78,6 +78,8 @@ func getPriorityByType(message interface{}) Priority {
 		return HighPriority
 	case *flow.ResultApproval:
 		return HighPriority
+	case *messages.ResultApprovalBatch:
+		return HighPriority
 
 	// execution state synchronization
 	case *messages.ExecutionStateSyncRequest:
@@ -0,0 +1,208 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/p2p/keyutils"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// unreachableTranslator translates the flow IDs of unreachable nodes to peer IDs without any known address, so that
+// unicasts to them fail, and defers all other translations to the underlying translator.
+type unreachableTranslator struct {
+	p2p.IDTranslator
+	sync.RWMutex
+	unreachable map[flow.Identifier]peer.ID
+}
+
+func (u *unreachableTranslator) GetPeerID(flowID flow.Identifier) (peer.ID, error) {
+	u.RLock()
+	defer u.RUnlock()
+	if pid, ok := u.unreachable[flowID]; ok {
+		return pid, nil
+	}
+	return u.IDTranslator.GetPeerID(flowID)
+}
+
+func (u *unreachableTranslator) setReachable(t *testing.T, flowID flow.Identifier, reachable bool) {
+	u.Lock()
+	defer u.Unlock()
+	if reachable {
+		delete(u.unreachable, flowID)
+		return
+	}
+	key, err := generateNetworkingKey(unittest.IdentifierFixture())
+	require.NoError(t, err)
+	pid, err := keyutils.PeerIDFromFlowPublicKey(key.PublicKey())
+	require.NoError(t, err)
+	u.unreachable[flowID] = pid
+}
+
+// fallbackMetrics counts the messages published on the test channel and the fallback activations.
+type fallbackMetrics struct {
+	*metrics.NoopCollector
+	published   atomic.Uint32
+	activations atomic.Uint32
+}
+
+func (f *fallbackMetrics) NetworkMessageSent(_ int, topic string, _ string) {
+	if topic == engine.TestNetwork.String() {
+		f.published.Inc()
+	}
+}
+
+func (f *fallbackMetrics) UnicastFallbackActivated(string) {
+	f.activations.Inc()
+}
+
+// TestUnicastFallback evaluates that unicasts on a channel opted in to the fallback are additionally published once
+// the unicasts to the target failed repeatedly, that the target receives exactly one copy of each message, and that
+// the fallback stops once the unicasts to the target succeed again.
+func TestUnicastFallback(t *testing.T) {
+	// three nodes, so that the published messages reach the target through the third node while the sender
+	// fails to reach it directly
+	const count = 3
+	const sender, target = 0, 1
+	logger := zerolog.New(os.Stderr).Level(zerolog.ErrorLevel)
+	log.SetAllLoggers(log.LevelError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ids, libP2PNodes, _ := GenerateIDs(t, logger, count, WithIdentityOpts(unittest.WithAllRoles()))
+
+	config := p2p.DefaultUnicastFallbackConfig(engine.TestNetwork)
+	config.Threshold = 2
+	config.Recovery = 2
+	translator := &unreachableTranslator{
+		IDTranslator: p2p.NewIdentityProviderIDTranslator(NewUpdatableIDProvider(ids)),
+		unreachable:  make(map[flow.Identifier]peer.ID),
+	}
+	collector := &fallbackMetrics{NoopCollector: metrics.NewNoopCollector()}
+
+	mws := make([]network.Middleware, count)
+	for i, id := range ids {
+		node := libP2PNodes[i]
+		factory := func(ctx context.Context) (*p2p.Node, error) {
+			return node, nil
+		}
+
+		var idTranslator p2p.IDTranslator = p2p.NewIdentityProviderIDTranslator(NewUpdatableIDProvider(ids))
+		var mwMetrics module.NetworkMetrics = metrics.NewNoopCollector()
+		opts := []p2p.MiddlewareOption{p2p.WithPeerManager(p2p.PeerManagerFactory(nil))}
+		if i == sender {
+			idTranslator = translator
+			mwMetrics = collector
+			opts = append(opts, p2p.WithUnicastFallback(config))
+		}
+
+		mws[i] = p2p.NewMiddleware(logger,
+			factory,
+			id.NodeID,
+			mwMetrics,
+			sporkID,
+			p2p.DefaultUnicastTimeout,
+			idTranslator,
+			opts...,
+		)
+	}
+	sms := GenerateSubscriptionManagers(t, mws)
+	nets := GenerateNetworks(ctx, t, logger, ids, mws, 100, nil, sms)
+	defer func() {
+		cancel()
+		stopNetworks(t, nets, 3*time.Second)
+	}()
+	engs := GenerateEngines(t, nets)
+
+	// allows the nodes to connect and to form the pubsub mesh
+	time.Sleep(2 * time.Second)
+
+	sequence := 0
+	unicast := func() error {
+		sequence++
+		event := &message.TestMessage{
+			Text: fmt.Sprintf("message %d", sequence),
+		}
+		return engs[sender].con.Unicast(event, ids[target].NodeID)
+	}
+
+	// requireReceivedOnce requires the target to receive exactly one copy of the last message.
+	requireReceivedOnce := func() {
+		expected := fmt.Sprintf("message %d", sequence)
+		select {
+		case event := <-engs[target].event:
+			assert.Equal(t, expected, event.(*message.TestMessage).Text)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "target did not receive message", expected)
+		}
+		<-engs[target].received
+		<-engs[target].channel
+
+		select {
+		case event := <-engs[target].event:
+			require.Fail(t, "target received unexpected message", event.(*message.TestMessage).Text)
+		case <-time.After(time.Second):
+		}
+	}
+	requireNotReceived := func() {
+		select {
+		case event := <-engs[target].event:
+			require.Fail(t, "target received unexpected message", event.(*message.TestMessage).Text)
+		case <-time.After(time.Second):
+		}
+	}
+
+	// the unicasts to the target fail below the threshold, without any fallback
+	translator.setReachable(t, ids[target].NodeID, false)
+	require.Error(t, unicast())
+	requireNotReceived()
+	assert.Zero(t, collector.activations.Load())
+	assert.Zero(t, collector.published.Load())
+
+	// reaching the threshold activates the fallback, and the target receives the published messages
+	require.NoError(t, unicast())
+	requireReceivedOnce()
+	require.NoError(t, unicast())
+	requireReceivedOnce()
+	assert.Equal(t, uint32(1), collector.activations.Load())
+	assert.Equal(t, uint32(2), collector.published.Load())
+
+	// once the unicasts succeed again, the messages are still published until the fallback recovers, and the
+	// target deduplicates the copies it receives
+	translator.setReachable(t, ids[target].NodeID, true)
+	require.NoError(t, unicast())
+	requireReceivedOnce()
+	assert.Equal(t, uint32(3), collector.published.Load())
+
+	// the recovery deactivates the fallback, messages are no longer published
+	require.NoError(t, unicast())
+	requireReceivedOnce()
+	require.NoError(t, unicast())
+	requireReceivedOnce()
+	assert.Equal(t, uint32(3), collector.published.Load())
+
+	// after the recovery, failing unicasts are subject to the threshold again
+	translator.setReachable(t, ids[target].NodeID, false)
+	require.Error(t, unicast())
+	requireNotReceived()
+	assert.Equal(t, uint32(1), collector.activations.Load())
+	assert.Equal(t, uint32(3), collector.published.Load())
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
-	"github.com/onflow/flow-go/storage/badger/procedure"
 	"github.com/onflow/flow-go/storage/badger/transaction"
 )
 
@@ -183,7 +182,7 @@ func (m *FollowerState) headerExtend(candidate *flow.Block) error {
 	header := candidate.Header
 	payload := candidate.Payload
 	if payload.Hash() != header.PayloadHash {
-		return state.NewInvalidExtensionError("payload integrity check failed")
+		return protocol.NewInvalidExtensionErrorf(protocol.InvalidPayloadHash, "payload integrity check failed")
 	}
 
 	// SECOND: Next, we can check whether the block is a valid descendant of the
@@ -191,14 +190,14 @@ func (m *FollowerState) headerExtend(candidate *flow.Block) error {
 
 	parent, err := m.headers.ByBlockID(header.ParentID)
 	if err != nil {
-		return state.NewInvalidExtensionErrorf("could not retrieve parent: %s", err)
+		return protocol.NewInvalidExtensionErrorf(protocol.InvalidParent, "could not retrieve parent: %s", err)
 	}
 	if header.ChainID != parent.ChainID {
-		return state.NewInvalidExtensionErrorf("candidate built for invalid chain (candidate: %s, parent: %s)",
+		return protocol.NewInvalidExtensionErrorf(protocol.InvalidParent, "candidate built for invalid chain (candidate: %s, parent: %s)",
 			header.ChainID, parent.ChainID)
 	}
 	if header.Height != parent.Height+1 {
-		return state.NewInvalidExtensionErrorf("candidate built with invalid height (candidate: %d, parent: %d)",
+		return protocol.NewInvalidExtensionErrorf(protocol.InvalidParent, "candidate built with invalid height (candidate: %d, parent: %d)",
 			header.Height, parent.Height)
 	}
 
@@ -206,7 +205,7 @@ func (m *FollowerState) headerExtend(candidate *flow.Block) error {
 	err = m.blockTimer.Validate(parent.Timestamp, candidate.Header.Timestamp)
 	if err != nil {
 		if protocol.IsInvalidBlockTimestampError(err) {
-			return state.NewInvalidExtensionErrorf("candidate contains invalid timestamp: %w", err)
+			return protocol.NewInvalidExtensionErrorf(protocol.InvalidTimestamp, "candidate contains invalid timestamp: %w", err)
 		}
 		return fmt.Errorf("validating block's time stamp failed with unexpected error: %w", err)
 	}
@@ -227,6 +226,14 @@ func (m *FollowerState) headerExtend(candidate *flow.Block) error {
 		return fmt.Errorf("could not lookup finalized block: %w", err)
 	}
 
+	// blocks at or below the finalized height can't be finalized anymore; the finalized block
+	// itself is never extended again, as it is stored already
+	if header.Height <= finalizedHeight {
+		return state.NewOutdatedExtensionErrorf(
+			"%w: candidate block (height: %d) is not above the finalized height (final: %d)",
+			protocol.ErrOutdatedBlock, header.Height, finalizedHeight)
+	}
+
 	ancestorID := header.ParentID
 	for ancestorID != finalID {
 		ancestor, err := m.headers.ByBlockID(ancestorID)
@@ -242,8 +249,8 @@ func (m *FollowerState) headerExtend(candidate *flow.Block) error {
 			// block G is not a valid block, because it does not include C which has been finalized.
 			// block H and I are a valid, because its their includes C.
 			return state.NewOutdatedExtensionErrorf(
-				"candidate block (height: %d) conflicts with finalized state (ancestor: %d final: %d)",
-				header.Height, ancestor.Height, finalizedHeight)
+				"%w: candidate block (height: %d) conflicts with finalized state (ancestor: %d final: %d)",
+				protocol.ErrOrphanedBlock, header.Height, ancestor.Height, finalizedHeight)
 		}
 		ancestorID = ancestor.ParentID
 	}
@@ -308,7 +315,7 @@ func (m *MutableState) guaranteeExtend(ctx context.Context, candidate *flow.Bloc
 		// if the guarantee was already included before, error
 		_, duplicated := lookup[guarantee.ID()]
 		if duplicated {
-			return state.NewInvalidExtensionErrorf("payload includes duplicate guarantee (%x)", guarantee.ID())
+			return protocol.NewInvalidExtensionErrorf(protocol.InvalidGuarantees, "payload includes duplicate guarantee (%x)", guarantee.ID())
 		}
 
 		// get the reference block to check expiry
@@ -319,7 +326,7 @@ func (m *MutableState) guaranteeExtend(ctx context.Context, candidate *flow.Bloc
 
 		// if the guarantee references a block with expired height, error
 		if ref.Height < limit {
-			return state.NewInvalidExtensionErrorf("payload includes expired guarantee (height: %d, limit: %d)",
+			return protocol.NewInvalidExtensionErrorf(protocol.InvalidGuarantees, "payload includes expired guarantee (height: %d, limit: %d)",
 				ref.Height, limit)
 		}
 	}
@@ -336,7 +343,7 @@ func (m *MutableState) sealExtend(ctx context.Context, candidate *flow.Block) (*
 
 	lastSeal, err := m.sealValidator.Validate(candidate)
 	if err != nil {
-		return nil, state.NewInvalidExtensionErrorf("seal validation error: %w", err)
+		return nil, protocol.NewInvalidExtensionErrorf(protocol.InvalidSeals, "seal validation error: %w", err)
 	}
 
 	return lastSeal, nil
@@ -357,10 +364,10 @@ func (m *MutableState) receiptExtend(ctx context.Context, candidate *flow.Block)
 	if err != nil {
 		// TODO: this might be not an error, potentially it can be solved by requesting more data and processing this receipt again
 		if errors.Is(err, storage.ErrNotFound) {
-			return state.NewInvalidExtensionErrorf("some entities referenced by receipts are missing: %w", err)
+			return protocol.NewInvalidExtensionErrorf(protocol.InvalidReceipts, "some entities referenced by receipts are missing: %w", err)
 		}
 		if engine.IsInvalidInputError(err) {
-			return state.NewInvalidExtensionErrorf("payload includes invalid receipts: %w", err)
+			return protocol.NewInvalidExtensionErrorf(protocol.InvalidReceipts, "payload includes invalid receipts: %w", err)
 		}
 		return fmt.Errorf("unexpected payload validation error %w", err)
 	}
@@ -391,7 +398,7 @@ func (m *FollowerState) lastSealed(candidate *flow.Block) (*flow.Seal, error) {
 		for i, seal := range payload.Seals {
 			header, err := m.headers.ByBlockID(seal.BlockID)
 			if err != nil {
-				return nil, state.NewInvalidExtensionErrorf("could not retrieve the header %v for seal: %w", seal.BlockID, err)
+				return nil, protocol.NewInvalidExtensionErrorf(protocol.InvalidSeals, "could not retrieve the header %v for seal: %w", seal.BlockID, err)
 			}
 
 			if i == 0 || header.Height > highestHeader.Height {
@@ -431,7 +438,8 @@ func (m *FollowerState) insert(ctx context.Context, candidate *flow.Block, last
 	// its final seal to the seal index and initializing its children index.
 
 	err = operation.RetryOnConflictTx(m.db, transaction.Update, func(tx *transaction.Tx) error {
-		// insert the block into the database AND cache
+		// insert the block into the database AND cache, which also adds the
+		// block to the children index of its parent for recovery
 		err := m.blocks.StoreTx(candidate)(tx)
 		if err != nil {
 			return fmt.Errorf("could not store candidate block: %w", err)
@@ -443,12 +451,6 @@ func (m *FollowerState) insert(ctx context.Context, candidate *flow.Block, last
 			return fmt.Errorf("could not index candidate seal: %w", err)
 		}
 
-		// index the child block for recovery
-		err = transaction.WithTx(procedure.IndexNewBlock(blockID, candidate.Header.ParentID))(tx)
-		if err != nil {
-			return fmt.Errorf("could not index new block: %w", err)
-		}
-
 		// apply any optional DB operations from service events
 		for _, apply := range ops {
 			err := apply(tx)
@@ -683,12 +685,15 @@ func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, erro
 	}
 
 	if parentSetup.FinalView < block.View { // first block of a new epoch
-		// sanity check: parent's epoch Preparation should be completed and have EpochSetup and EpochCommit events
-		if parentStatus.NextEpoch.SetupID == flow.ZeroID {
-			return nil, fmt.Errorf("missing setup event for starting next epoch: %w", errIncompleteEpochConfiguration)
+		// sanity check: parent's epoch Preparation should be completed and have EpochSetup and EpochCommit events,
+		// i.e. the parent must be in the committed phase, from which the new epoch starts in the staking phase
+		// Otherwise, the caller triggers EECC, which is reported by its own metric.
+		err = validateStatusTransition(parentStatus, flow.EpochPhaseStaking)
+		if protocol.IsInvalidPhaseTransitionError(err) {
+			return nil, fmt.Errorf("cannot start next epoch (%s): %w", err, errIncompleteEpochConfiguration)
 		}
-		if parentStatus.NextEpoch.CommitID == flow.ZeroID {
-			return nil, fmt.Errorf("missing commit event for starting next epoch: %w", errIncompleteEpochConfiguration)
+		if err != nil {
+			return nil, fmt.Errorf("could not validate epoch transition: %w", err)
 		}
 		status, err := flow.NewEpochStatus(
 			parentStatus.CurrentEpoch.SetupID, parentStatus.CurrentEpoch.CommitID,
@@ -798,8 +803,20 @@ SealLoop:
 			switch ev := event.Event.(type) {
 			case *flow.EpochSetup:
 
+				// the setup event must move the epoch from the staking to the setup phase
+				err := m.validatePhaseTransition(epochStatus, flow.EpochPhaseSetup)
+				if protocol.IsInvalidServiceEventError(err) {
+					// EECC - the setup event is illegal in the current epoch phase, e.g. a
+					// duplicate or late setup event. Flag this in the DB and exit
+					ops = append(ops, transaction.WithTx(operation.SetEpochEmergencyFallbackTriggered(blockID)))
+					break SealLoop
+				}
+				if err != nil {
+					return nil, err
+				}
+
 				// validate the service event
-				err := isValidExtendingEpochSetup(ev, activeSetup, epochStatus)
+				err = isValidExtendingEpochSetup(ev, activeSetup, epochStatus)
 				if protocol.IsInvalidServiceEventError(err) {
 					// EECC - we have observed an invalid service event, which is
 					// an unrecoverable failure. Flag this in the DB and exit
@@ -817,7 +834,19 @@ SealLoop:
 
 				extendingSetup, err := m.epoch.setups.ByID(epochStatus.NextEpoch.SetupID)
 				if err != nil {
-					return nil, state.NewInvalidExtensionErrorf("could not retrieve next epoch setup: %s", err)
+					return nil, protocol.NewInvalidExtensionErrorf(protocol.InvalidServiceEvents, "could not retrieve next epoch setup: %s", err)
+				}
+
+				// the commit event must move the epoch from the setup to the committed phase
+				err = m.validatePhaseTransition(epochStatus, flow.EpochPhaseCommitted)
+				if protocol.IsInvalidServiceEventError(err) {
+					// EECC - the commit event is illegal in the current epoch phase, i.e. a
+					// duplicate commit event. Flag this in the DB and exit
+					ops = append(ops, transaction.WithTx(operation.SetEpochEmergencyFallbackTriggered(blockID)))
+					break SealLoop
+				}
+				if err != nil {
+					return nil, err
 				}
 				// validate the service event
 				err = isValidExtendingEpochCommit(ev, extendingSetup, activeSetup, epochStatus)
@@ -846,6 +875,25 @@ SealLoop:
 	return ops, nil
 }
 
+// validatePhaseTransition checks whether the epoch phase of the given status can move to the next
+// phase by applying a service event. Illegal transitions are counted and reported as invalid
+// service events, which trigger EECC rather than invalidating the block.
+// Returns:
+//  * protocol.InvalidServiceEventError wrapping protocol.InvalidPhaseTransitionError for illegal transitions
+//  * generic error in case of unexpected failure
+func (m *FollowerState) validatePhaseTransition(status *flow.EpochStatus, next flow.EpochPhase) error {
+	err := validateStatusTransition(status, next)
+	var transitionErr protocol.InvalidPhaseTransitionError
+	if errors.As(err, &transitionErr) {
+		m.metrics.RejectedEpochPhaseTransition(transitionErr.From, transitionErr.To)
+		return protocol.NewInvalidServiceEventError("cannot apply service event: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("could not validate epoch phase transition: %w", err)
+	}
+	return nil
+}
+
 // MarkValid marks the block as valid in protocol state, and triggers
 // `BlockProcessable` event to notify that its parent block is processable.
 //
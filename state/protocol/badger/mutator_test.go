@@ -253,6 +253,7 @@ func TestExtendHeightTooLarge(t *testing.T) {
 
 		err = state.Extend(context.Background(), block)
 		require.Error(t, err)
+		requireInvalidExtension(t, err, realprotocol.InvalidParent)
 	})
 }
 
@@ -279,6 +280,8 @@ func TestExtendBlockNotConnected(t *testing.T) {
 
 		err = state.Extend(context.Background(), extend)
 		require.Error(t, err)
+		require.True(t, st.IsOutdatedExtensionError(err), err)
+		require.ErrorIs(t, err, realprotocol.ErrOutdatedBlock)
 
 		// verify seal not indexed
 		var sealID flow.Identifier
@@ -288,6 +291,60 @@ func TestExtendBlockNotConnected(t *testing.T) {
 	})
 }
 
+// TestExtendOrphanedBlock tests that blocks above the finalized height, which are on a fork
+// conflicting with the finalized blocks, are rejected as orphaned.
+func TestExtendOrphanedBlock(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFullProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.MutableState) {
+
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+
+		// root <- block1 <- block2 (finalized)
+		//              ^- fork2 <- fork3
+		block1 := unittest.BlockWithParentFixture(head)
+		block1.SetPayload(flow.EmptyPayload())
+		block2 := unittest.BlockWithParentFixture(block1.Header)
+		block2.SetPayload(flow.EmptyPayload())
+		fork2 := unittest.BlockWithParentFixture(block1.Header)
+		fork2.SetPayload(flow.EmptyPayload())
+		for _, block := range []*flow.Block{block1, block2, fork2} {
+			err = state.Extend(context.Background(), block)
+			require.NoError(t, err)
+		}
+		err = state.Finalize(context.Background(), block1.ID())
+		require.NoError(t, err)
+		err = state.Finalize(context.Background(), block2.ID())
+		require.NoError(t, err)
+
+		fork3 := unittest.BlockWithParentFixture(fork2.Header)
+		fork3.SetPayload(flow.EmptyPayload())
+		err = state.Extend(context.Background(), fork3)
+		require.Error(t, err)
+		require.True(t, st.IsOutdatedExtensionError(err), err)
+		require.ErrorIs(t, err, realprotocol.ErrOrphanedBlock)
+		require.False(t, errors.Is(err, realprotocol.ErrOutdatedBlock))
+	})
+}
+
+// TestExtendInvalidPayloadHash tests that blocks with a payload that doesn't match the
+// payload hash of their header are rejected as invalid.
+func TestExtendInvalidPayloadHash(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFullProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.MutableState) {
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+
+		block := unittest.BlockWithParentFixture(head)
+		block.SetPayload(flow.EmptyPayload())
+		block.Header.PayloadHash = unittest.IdentifierFixture()
+
+		err = state.Extend(context.Background(), block)
+		require.Error(t, err)
+		requireInvalidExtension(t, err, realprotocol.InvalidPayloadHash)
+	})
+}
+
 func TestExtendInvalidChainID(t *testing.T) {
 	rootSnapshot := unittest.RootSnapshotFixture(participants)
 	util.RunWithFullProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.MutableState) {
@@ -301,7 +358,7 @@ func TestExtendInvalidChainID(t *testing.T) {
 
 		err = state.Extend(context.Background(), block)
 		require.Error(t, err)
-		require.True(t, st.IsInvalidExtensionError(err), err)
+		requireInvalidExtension(t, err, realprotocol.InvalidParent)
 	})
 }
 
@@ -1134,6 +1191,68 @@ func TestExtendEpochCommitInvalid(t *testing.T) {
 	})
 }
 
+// replaying a recorded sequence of service events, in which a second EpochSetup event follows the
+// EpochCommit event of the same epoch, should trigger EECC when validating the phase transition,
+// without modifying the epoch state
+//
+// ROOT <- B1 <- B2(S1:setup) <- B3 <- B4(S3:commit) <- B5 <- B6(S5:setup) <- B7
+func TestExtendEpochPhaseTransitionReplay(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFullProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.MutableState) {
+
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+		result, _, err := rootSnapshot.SealedResult()
+		require.NoError(t, err)
+		epoch1Setup := result.ServiceEvents[0].Event.(*flow.EpochSetup)
+
+		// the recorded sequence of service events for the next epoch
+		epoch2Setup := unittest.EpochSetupFixture(
+			unittest.WithParticipants(participants),
+			unittest.SetupWithCounter(epoch1Setup.Counter+1),
+			unittest.WithFinalView(epoch1Setup.FinalView+1000),
+			unittest.WithFirstView(epoch1Setup.FinalView+1),
+		)
+		epoch2Commit := unittest.EpochCommitFixture(
+			unittest.CommitWithCounter(epoch1Setup.Counter+1),
+			unittest.WithDKGFromParticipants(participants),
+		)
+		recorded := []flow.ServiceEvent{
+			epoch2Setup.ServiceEvent(),
+			epoch2Commit.ServiceEvent(),
+			epoch2Setup.ServiceEvent(),
+		}
+
+		block := unittest.BlockWithParentFixture(head)
+		block.SetPayload(flow.EmptyPayload())
+		err = state.Extend(context.Background(), block)
+		require.NoError(t, err)
+
+		for _, event := range recorded {
+			receipt, seal := unittest.ReceiptAndSealForBlock(block)
+			receipt.ExecutionResult.ServiceEvents = []flow.ServiceEvent{event}
+			seal.ResultID = receipt.ExecutionResult.ID()
+			sealingBlock := unittest.SealBlock(t, state, block, receipt, seal)
+
+			// the second setup event would move the epoch from the committed back to the setup
+			// phase, which is an invalid service event rather than an invalid block
+			qcBlock := unittest.BlockWithParentFixture(sealingBlock)
+			err = state.Extend(context.Background(), qcBlock)
+			require.NoError(t, err)
+			block = qcBlock
+		}
+
+		// the illegal setup event triggers EECC and leaves the epoch state unchanged
+		phase, err := state.AtBlockID(block.ID()).Phase()
+		require.NoError(t, err)
+		assert.Equal(t, flow.EpochPhaseCommitted, phase)
+		var triggered bool
+		err = db.View(operation.CheckEpochEmergencyFallbackTriggered(&triggered))
+		require.NoError(t, err)
+		assert.True(t, triggered)
+	})
+}
+
 // if we reach the first block of the next epoch before both setup and commit
 // service events are finalized, the chain should halt
 //
@@ -1597,6 +1716,7 @@ func TestHeaderExtendBlockNotConnected(t *testing.T) {
 		err = state.Extend(context.Background(), block2)
 		require.Error(t, err)
 		require.True(t, st.IsOutdatedExtensionError(err), err)
+		require.ErrorIs(t, err, realprotocol.ErrOutdatedBlock)
 
 		// verify seal not indexed
 		var sealID flow.Identifier
@@ -1725,6 +1845,180 @@ func TestSealed(t *testing.T) {
 	})
 }
 
+// TestBoundaries verifies that Boundaries returns the latest finalized and sealed blocks, consistent
+// with the heads of the finalized and sealed snapshots, as finalization and sealing progress.
+func TestBoundaries(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFollowerProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.FollowerState) {
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+
+		requireBoundaries := func(expectedFinalized, expectedSealed *flow.Header) {
+			finalized, sealed, err := state.Boundaries()
+			require.NoError(t, err)
+			require.Equal(t, expectedFinalized.ID(), finalized.ID())
+			require.Equal(t, expectedSealed.ID(), sealed.ID())
+
+			finalHead, err := state.Final().Head()
+			require.NoError(t, err)
+			require.Equal(t, finalHead.ID(), finalized.ID())
+			sealedHead, err := state.Sealed().Head()
+			require.NoError(t, err)
+			require.Equal(t, sealedHead.ID(), sealed.ID())
+		}
+
+		// the root block is both finalized and sealed
+		requireBoundaries(head, head)
+
+		block1 := unittest.BlockWithParentFixture(head)
+		err = state.Extend(context.Background(), block1)
+		require.NoError(t, err)
+		err = state.Finalize(context.Background(), block1.ID())
+		require.NoError(t, err)
+		requireBoundaries(block1.Header, head)
+
+		// block 2 contains receipt for block 1
+		receipt1, seal1 := unittest.ReceiptAndSealForBlock(block1)
+		block2 := unittest.BlockWithParentFixture(block1.Header)
+		block2.SetPayload(unittest.PayloadFixture(unittest.WithReceipts(receipt1)))
+		err = state.Extend(context.Background(), block2)
+		require.NoError(t, err)
+		err = state.Finalize(context.Background(), block2.ID())
+		require.NoError(t, err)
+		requireBoundaries(block2.Header, head)
+
+		// block 3 contains seal for block 1
+		block3 := unittest.BlockWithParentFixture(block2.Header)
+		block3.SetPayload(flow.Payload{
+			Seals: []*flow.Seal{seal1},
+		})
+		err = state.Extend(context.Background(), block3)
+		require.NoError(t, err)
+
+		// extending does not change the boundaries, only finalizing does
+		requireBoundaries(block2.Header, head)
+		err = state.Finalize(context.Background(), block3.ID())
+		require.NoError(t, err)
+		requireBoundaries(block3.Header, block1.Header)
+	})
+}
+
+// sealingChainFixture extends the state with n blocks on top of the root block, where each block
+// contains the receipt for its parent and the seal for its grandparent. Finalizing the blocks in
+// order thus advances the latest sealed block with every finalized block, starting with the third.
+func sealingChainFixture(t *testing.T, state *protocol.FollowerState, root *flow.Header, n int) []*flow.Block {
+	blocks := make([]*flow.Block, 0, n)
+	seals := make([]*flow.Seal, 0, n)
+	parent := root
+	for i := 0; i < n; i++ {
+		block := unittest.BlockWithParentFixture(parent)
+		var payload flow.Payload
+		if i > 0 {
+			receipt, seal := unittest.ReceiptAndSealForBlock(blocks[i-1])
+			payload.Receipts = []*flow.ExecutionReceiptMeta{receipt.Meta()}
+			payload.Results = []*flow.ExecutionResult{&receipt.ExecutionResult}
+			seals = append(seals, seal)
+		}
+		if i > 1 {
+			payload.Seals = []*flow.Seal{seals[i-2]}
+		}
+		block.SetPayload(payload)
+		err := state.Extend(context.Background(), block)
+		require.NoError(t, err)
+		blocks = append(blocks, block)
+		parent = block.Header
+	}
+	return blocks
+}
+
+// TestAtSealed verifies that AtSealed returns the snapshot at the latest sealed block, and
+// SealedHeight its height, as finalization and sealing progress.
+func TestAtSealed(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFollowerProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.FollowerState) {
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+
+		requireSealed := func(expected *flow.Header) {
+			sealed, err := state.AtSealed().Head()
+			require.NoError(t, err)
+			require.Equal(t, expected.ID(), sealed.ID())
+
+			atBlock, err := state.AtBlockID(expected.ID()).Head()
+			require.NoError(t, err)
+			require.Equal(t, atBlock, sealed)
+
+			height, err := state.SealedHeight()
+			require.NoError(t, err)
+			require.Equal(t, expected.Height, height)
+		}
+
+		// the root block is sealed
+		requireSealed(head)
+
+		blocks := sealingChainFixture(t, state, head, 4)
+		for i, block := range blocks {
+			err = state.Finalize(context.Background(), block.ID())
+			require.NoError(t, err)
+			if i < 2 {
+				requireSealed(head)
+			} else {
+				requireSealed(blocks[i-2].Header)
+			}
+		}
+	})
+}
+
+// TestAtSealed_ConcurrentFinalization verifies that the snapshot returned by AtSealed, while blocks are
+// finalized and sealed concurrently, is never newer than the latest sealed block at the time the
+// method returns, and stays at the same block afterwards.
+func TestAtSealed_ConcurrentFinalization(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFollowerProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.FollowerState) {
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+		blocks := sealingChainFixture(t, state, head, 20)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for _, block := range blocks {
+				err := state.Finalize(context.Background(), block.ID())
+				assert.NoError(t, err)
+			}
+		}()
+
+		finished := false
+		for !finished {
+			select {
+			case <-done:
+				finished = true
+			default:
+			}
+
+			before, err := state.SealedHeight()
+			require.NoError(t, err)
+			snapshot := state.AtSealed()
+			after, err := state.SealedHeight()
+			require.NoError(t, err)
+
+			sealed, err := snapshot.Head()
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, sealed.Height, before)
+			require.LessOrEqual(t, sealed.Height, after)
+
+			// the snapshot doesn't advance with the state
+			again, err := snapshot.Head()
+			require.NoError(t, err)
+			require.Equal(t, sealed.ID(), again.ID())
+		}
+
+		sealed, err := state.AtSealed().Head()
+		require.NoError(t, err)
+		require.Equal(t, blocks[len(blocks)-3].ID(), sealed.ID())
+	})
+}
+
 // Test that when adding a block to database, there are only two cases at any point of time:
 // 1) neither the block header, nor the payload index exist in database
 // 2) both the block header and the payload index can be found in database
@@ -1824,3 +2118,11 @@ func mockMetricsForRootSnapshot(metricsMock *mockmodule.ComplianceMetrics, rootS
 	metricsMock.On("SealedHeight", mock.Anything)
 
 }
+
+// requireInvalidExtension requires the given error to be an invalid extension error for the given reason.
+func requireInvalidExtension(t *testing.T, err error, reason realprotocol.InvalidExtensionReason) {
+	require.True(t, st.IsInvalidExtensionError(err), err)
+	var invalidErr realprotocol.InvalidExtensionError
+	require.True(t, errors.As(err, &invalidErr), err)
+	assert.Equal(t, reason, invalidErr.Reason)
+}
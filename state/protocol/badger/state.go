@@ -95,7 +95,7 @@ func Bootstrap(
 		lowest := segment.Lowest()   // last sealed block
 
 		// bootstrap the sealing segment
-		err = state.bootstrapSealingSegment(segment, highest)(tx)
+		err = state.bootstrapSealingSegment(segment)(tx)
 		if err != nil {
 			return fmt.Errorf("could not bootstrap sealing chain segment blocks: %w", err)
 		}
@@ -156,8 +156,10 @@ func Bootstrap(
 }
 
 // bootstrapSealingSegment inserts all blocks and associated metadata for the
-// protocol state root snapshot to disk.
-func (state *State) bootstrapSealingSegment(segment *flow.SealingSegment, head *flow.Block) func(tx *transaction.Tx) error {
+// protocol state root snapshot to disk. Storing the blocks indexes the
+// parent->child relationships within the segment, and an empty child index
+// for the final block in the segment.
+func (state *State) bootstrapSealingSegment(segment *flow.SealingSegment) func(tx *transaction.Tx) error {
 	return func(tx *transaction.Tx) error {
 
 		for _, result := range segment.ExecutionResults {
@@ -171,7 +173,7 @@ func (state *State) bootstrapSealingSegment(segment *flow.SealingSegment, head *
 			}
 		}
 
-		for i, block := range segment.Blocks {
+		for _, block := range segment.Blocks {
 			blockID := block.ID()
 			height := block.Header.Height
 
@@ -187,20 +189,6 @@ func (state *State) bootstrapSealingSegment(segment *flow.SealingSegment, head *
 			if err != nil {
 				return fmt.Errorf("could not index root block segment (id=%x): %w", blockID, err)
 			}
-
-			// for all but the first block in the segment, index the parent->child relationship
-			if i > 0 {
-				err = transaction.WithTx(operation.InsertBlockChildren(block.Header.ParentID, []flow.Identifier{blockID}))(tx)
-				if err != nil {
-					return fmt.Errorf("could not insert child index for block (id=%x): %w", blockID, err)
-				}
-			}
-		}
-
-		// insert an empty child index for the final block in the segment
-		err := transaction.WithTx(operation.InsertBlockChildren(head.ID(), nil))(tx)
-		if err != nil {
-			return fmt.Errorf("could not insert child index for head block (id=%x): %w", head.ID(), err)
 		}
 
 		return nil
@@ -479,13 +467,38 @@ func (state *State) Params() protocol.Params {
 }
 
 func (state *State) Sealed() protocol.Snapshot {
-	// retrieve the latest sealed height
+	return state.AtSealed()
+}
+
+func (state *State) AtSealed() protocol.Snapshot {
+	// resolve the latest sealed block within a single transaction, so that the
+	// sealed height can't advance before the block at it is looked up
+	var sealedID flow.Identifier
+	err := state.db.View(func(tx *badger.Txn) error {
+		var sealed uint64
+		err := operation.RetrieveSealedHeight(&sealed)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve sealed height: %w", err)
+		}
+		err = operation.LookupBlockHeight(sealed, &sealedID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up sealed block (height=%d): %w", sealed, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return invalid.NewSnapshot(err)
+	}
+	return NewSnapshot(state, sealedID)
+}
+
+func (state *State) SealedHeight() (uint64, error) {
 	var sealed uint64
 	err := state.db.View(operation.RetrieveSealedHeight(&sealed))
 	if err != nil {
-		return invalid.NewSnapshot(fmt.Errorf("could not retrieve sealed height: %w", err))
+		return 0, fmt.Errorf("could not retrieve sealed height: %w", err)
 	}
-	return state.AtHeight(sealed)
+	return sealed, nil
 }
 
 func (state *State) Final() protocol.Snapshot {
@@ -498,6 +511,47 @@ func (state *State) Final() protocol.Snapshot {
 	return state.AtHeight(finalized)
 }
 
+func (state *State) Boundaries() (*flow.Header, *flow.Header, error) {
+	var finalized, sealed flow.Header
+	err := state.db.View(func(tx *badger.Txn) error {
+		// retrieve the latest finalized and sealed heights within the same
+		// transaction, so that they can't advance in between
+		var finalizedHeight, sealedHeight uint64
+		err := operation.RetrieveFinalizedHeight(&finalizedHeight)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve finalized height: %w", err)
+		}
+		err = operation.RetrieveSealedHeight(&sealedHeight)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve sealed height: %w", err)
+		}
+
+		var finalizedID, sealedID flow.Identifier
+		err = operation.LookupBlockHeight(finalizedHeight, &finalizedID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up finalized block (height=%d): %w", finalizedHeight, err)
+		}
+		err = operation.LookupBlockHeight(sealedHeight, &sealedID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up sealed block (height=%d): %w", sealedHeight, err)
+		}
+
+		err = operation.RetrieveHeader(finalizedID, &finalized)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve finalized header: %w", err)
+		}
+		err = operation.RetrieveHeader(sealedID, &sealed)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve sealed header: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &finalized, &sealed, nil
+}
+
 func (state *State) AtHeight(height uint64) protocol.Snapshot {
 	// retrieve the block ID for the finalized height
 	var blockID flow.Identifier
@@ -6,10 +6,35 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/flow/order"
-	"github.com/onflow/flow-go/state"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
+// ValidatePhaseTransition checks whether the epoch phase can move from the current to the next phase.
+// Within an epoch, the phases only ever move forward, from the staking phase to the setup phase to
+// the committed phase, and a new epoch always starts in the staking phase after the committed phase
+// of its predecessor. Any other transition, including staying in the same phase, is illegal.
+// Returns protocol.InvalidPhaseTransitionError for illegal transitions.
+func ValidatePhaseTransition(current, next flow.EpochPhase) error {
+	switch {
+	case current == flow.EpochPhaseStaking && next == flow.EpochPhaseSetup,
+		current == flow.EpochPhaseSetup && next == flow.EpochPhaseCommitted,
+		current == flow.EpochPhaseCommitted && next == flow.EpochPhaseStaking:
+		return nil
+	default:
+		return protocol.InvalidPhaseTransitionError{From: current, To: next}
+	}
+}
+
+// validateStatusTransition checks whether the epoch phase of the given status can move to the next phase.
+// Returns protocol.InvalidPhaseTransitionError for illegal transitions.
+func validateStatusTransition(status *flow.EpochStatus, next flow.EpochPhase) error {
+	current, err := status.Phase()
+	if err != nil {
+		return fmt.Errorf("could not determine epoch phase: %w", err)
+	}
+	return ValidatePhaseTransition(current, next)
+}
+
 // isValidExtendingEpochSetup checks whether an epoch setup service being
 // added to the state is valid. In addition to intrinsic validitym, we also
 // check that it is valid w.r.t. the previous epoch setup event, and the
@@ -160,7 +185,7 @@ func isValidExtendingEpochCommit(extendingCommit *flow.EpochCommit, extendingSet
 
 	err := isValidEpochCommit(extendingCommit, extendingSetup)
 	if err != nil {
-		return state.NewInvalidExtensionErrorf("invalid epoch commit: %s", err)
+		return protocol.NewInvalidExtensionErrorf(protocol.InvalidServiceEvents, "invalid epoch commit: %s", err)
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -101,3 +102,33 @@ func TestBootstrapInvalidEpochCommit(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// TestValidatePhaseTransition checks that only the transitions of the Epoch Preparation Protocol
+// are accepted, and all other pairs of phases are rejected with a typed error.
+func TestValidatePhaseTransition(t *testing.T) {
+	phases := []flow.EpochPhase{
+		flow.EpochPhaseUndefined,
+		flow.EpochPhaseStaking,
+		flow.EpochPhaseSetup,
+		flow.EpochPhaseCommitted,
+	}
+	legal := map[flow.EpochPhase]flow.EpochPhase{
+		flow.EpochPhaseStaking:   flow.EpochPhaseSetup,
+		flow.EpochPhaseSetup:     flow.EpochPhaseCommitted,
+		flow.EpochPhaseCommitted: flow.EpochPhaseStaking,
+	}
+
+	for _, current := range phases {
+		for _, next := range phases {
+			err := ValidatePhaseTransition(current, next)
+			if legalNext, ok := legal[current]; ok && legalNext == next {
+				require.NoError(t, err, "%s -> %s should be accepted", current, next)
+				continue
+			}
+			require.Error(t, err, "%s -> %s should be rejected", current, next)
+			require.True(t, protocol.IsInvalidPhaseTransitionError(err), err)
+			require.Contains(t, err.Error(), current.String())
+			require.Contains(t, err.Error(), next.String())
+		}
+	}
+}
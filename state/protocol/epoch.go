@@ -61,11 +61,17 @@ type Epoch interface {
 	// FinalView returns the largest view number which still belongs to this epoch.
 	FinalView() (uint64, error)
 
-	// RandomSource returns the underlying random source of this epoch.
+	// RandomSource returns the underlying random source of this epoch, specified
+	// in the EpochSetup service event. Seeds for specific use cases must be derived
+	// from it with seed.Derive, using the domain of the use case.
 	RandomSource() ([]byte, error)
 
 	// Seed generates a random seed using the source of randomness for this
 	// epoch, specified in the EpochSetup service event.
+	//
+	// Seeds generated from overlapping indices are not independent across use
+	// cases, new use cases should derive their seeds from RandomSource with
+	// seed.Derive instead.
 	Seed(indices ...uint32) ([]byte, error)
 
 	// InitialIdentities returns the identities for this epoch as they were
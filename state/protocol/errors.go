@@ -21,8 +21,60 @@ var (
 	// not been committed and information is queried that is only accessible
 	// in the EpochCommitted phase.
 	ErrEpochNotCommitted = fmt.Errorf("queried info from EpochCommit event before it was emitted")
+
+	// ErrOutdatedBlock is a sentinel error returned when extending the state with a block at or
+	// below the finalized height, which is not finalized. Such blocks can never be finalized, but
+	// they are not necessarily invalid; they should be dropped without penalizing their sender.
+	ErrOutdatedBlock = errors.New("block is at or below the finalized height")
+
+	// ErrOrphanedBlock is a sentinel error returned when extending the state with a block above
+	// the finalized height, whose fork conflicts with the finalized blocks. Such blocks can never be
+	// finalized; they and their descendants should be dropped.
+	ErrOrphanedBlock = errors.New("block is on a fork conflicting with the finalized blocks")
+)
+
+// InvalidExtensionReason classifies why a block is not a valid extension of the protocol state.
+type InvalidExtensionReason string
+
+const (
+	InvalidPayloadHash   InvalidExtensionReason = "payload_hash"   // payload doesn't match the header's payload hash
+	InvalidParent        InvalidExtensionReason = "parent"         // block doesn't extend its parent
+	InvalidTimestamp     InvalidExtensionReason = "timestamp"      // timestamp is out of the range allowed by the parent
+	InvalidGuarantees    InvalidExtensionReason = "guarantees"     // duplicate or expired collection guarantees
+	InvalidReceipts      InvalidExtensionReason = "receipts"       // invalid execution receipts
+	InvalidSeals         InvalidExtensionReason = "seals"          // invalid block seals
+	InvalidServiceEvents InvalidExtensionReason = "service_events" // invalid service events or epoch phase transitions
 )
 
+// InvalidExtensionError indicates that a block violates the protocol rules for the given reason,
+// and hence is not a valid extension of the protocol state. Its sender should be reported.
+type InvalidExtensionError struct {
+	Reason InvalidExtensionReason
+	err    error
+}
+
+func (e InvalidExtensionError) Unwrap() error {
+	return e.err
+}
+
+func (e InvalidExtensionError) Error() string {
+	return e.err.Error()
+}
+
+func IsInvalidExtensionError(err error) bool {
+	var errInvalidExtension InvalidExtensionError
+	return errors.As(err, &errInvalidExtension)
+}
+
+// NewInvalidExtensionErrorf returns an invalid extension error for the given reason. It is wrapped
+// in the generic state.InvalidExtensionError at construction, so that both can be checked for.
+func NewInvalidExtensionErrorf(reason InvalidExtensionReason, msg string, args ...interface{}) error {
+	return state.NewInvalidExtensionErrorf("%w", InvalidExtensionError{
+		Reason: reason,
+		err:    fmt.Errorf(msg, args...),
+	})
+}
+
 type IdentityNotFoundError struct {
 	NodeID flow.Identifier
 }
@@ -59,6 +111,22 @@ func NewInvalidBlockTimestamp(msg string, args ...interface{}) error {
 	}
 }
 
+// InvalidPhaseTransitionError indicates that applying a change to the protocol state would
+// move the epoch phase from From to To, which is not a legal transition of the epoch phases.
+type InvalidPhaseTransitionError struct {
+	From flow.EpochPhase
+	To   flow.EpochPhase
+}
+
+func (e InvalidPhaseTransitionError) Error() string {
+	return fmt.Sprintf("illegal epoch phase transition from %s to %s", e.From, e.To)
+}
+
+func IsInvalidPhaseTransitionError(err error) bool {
+	var errInvalidPhaseTransition InvalidPhaseTransitionError
+	return errors.As(err, &errInvalidPhaseTransition)
+}
+
 // InvalidServiceEventError indicates an invalid service event was processed.
 type InvalidServiceEventError struct {
 	err error
@@ -81,7 +149,8 @@ func IsInvalidServiceEventError(err error) bool {
 // service events indicate an invalid extension, the service event error is wrapped in
 // the invalid extension error at construction.
 func NewInvalidServiceEventError(msg string, args ...interface{}) error {
-	return state.NewInvalidExtensionErrorf(
+	return NewInvalidExtensionErrorf(
+		InvalidServiceEvents,
 		"cannot extend state with invalid service event: %w",
 		InvalidServiceEventError{
 			err: fmt.Errorf(msg, args...),
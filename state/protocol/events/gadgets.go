@@ -10,6 +10,24 @@ type Heights interface {
 	OnHeight(height uint64, callback func())
 }
 
+// PersistentHeights enables subscribing to specific heights, like Heights, with
+// registrations that survive restarts. As callbacks can't be persisted, they are
+// registered by ID, together with a factory that recreates the callback logic
+// after a restart. A registered callback is invoked once the given height is
+// finalized, or right away if the height was finalized before.
+type PersistentHeights interface {
+
+	// RegisterFactory registers the factory of the callback with the given ID.
+	// The factory must be registered after every restart, so that callbacks
+	// registered before the restart can be invoked. Callbacks of which the
+	// height was finalized while the node was down are invoked on registration.
+	RegisterFactory(callbackID string, factory func() func()) error
+
+	// OnHeight persistently registers the callback with the given ID, of which
+	// the factory must be registered, for the given height.
+	OnHeight(height uint64, callbackID string) error
+}
+
 // OnViewCallback is the type of callback triggered by view events.
 type OnViewCallback func(*flow.Header)
 
@@ -0,0 +1,165 @@
+package gadgets
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol/events"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// PersistentHeights is a protocol events consumer that invokes callbacks when
+// the chain state reaches particular heights, like Heights. The registrations
+// of the callbacks are stored in the database, so that callbacks registered
+// before a restart are still invoked once their height is finalized, or right
+// away when their factory is registered, if the height was finalized while the
+// node was down.
+//
+// A callback is removed from the database once it was invoked. If the node
+// crashes while invoking it, it is invoked again after the restart, hence
+// callbacks must be idempotent.
+type PersistentHeights struct {
+	events.Noop
+	log       zerolog.Logger
+	db        *badger.DB
+	mu        sync.Mutex
+	final     uint64                         // latest finalized height
+	factories map[string]func() func()       // callback factories by callback ID
+	heights   map[uint64]map[string]struct{} // IDs of the registered callbacks by height
+}
+
+var _ events.PersistentHeights = (*PersistentHeights)(nil)
+
+// NewPersistentHeights returns a new PersistentHeights events gadget, loading the
+// callbacks registered before a restart from the database. The finalized height
+// is the height of the latest finalized block at startup.
+func NewPersistentHeights(log zerolog.Logger, db *badger.DB, final uint64) (*PersistentHeights, error) {
+	registered := make(map[uint64][]string)
+	err := db.View(operation.RetrieveHeightCallbacks(registered))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve height callbacks: %w", err)
+	}
+
+	heights := &PersistentHeights{
+		log:       log.With().Str("component", "persistent_heights").Logger(),
+		db:        db,
+		final:     final,
+		factories: make(map[string]func() func()),
+		heights:   make(map[uint64]map[string]struct{}),
+	}
+	for height, callbackIDs := range registered {
+		for _, callbackID := range callbackIDs {
+			heights.add(height, callbackID)
+		}
+	}
+	return heights, nil
+}
+
+// BlockFinalized handles block finalized protocol events, invoking the callbacks
+// of all heights up to the finalized height, of which the factory is registered.
+func (g *PersistentHeights) BlockFinalized(block *flow.Header) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if block.Height > g.final {
+		g.final = block.Height
+	}
+	for height, callbackIDs := range g.heights {
+		if height > g.final {
+			continue
+		}
+		for callbackID := range callbackIDs {
+			g.fire(height, callbackID)
+		}
+	}
+}
+
+// RegisterFactory registers the factory of the callback with the given ID, and
+// invokes the callback for all heights registered before a restart, which are
+// already finalized.
+func (g *PersistentHeights) RegisterFactory(callbackID string, factory func() func()) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.factories[callbackID]; exists {
+		return fmt.Errorf("factory of callback %s is already registered", callbackID)
+	}
+	g.factories[callbackID] = factory
+
+	for height, callbackIDs := range g.heights {
+		if _, ok := callbackIDs[callbackID]; ok && height <= g.final {
+			g.fire(height, callbackID)
+		}
+	}
+	return nil
+}
+
+// OnHeight persistently registers the callback with the given ID for the given
+// height. If the height is already finalized, the callback is invoked right away.
+func (g *PersistentHeights) OnHeight(height uint64, callbackID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.factories[callbackID]; !exists {
+		return fmt.Errorf("factory of callback %s is not registered", callbackID)
+	}
+	if _, exists := g.heights[height][callbackID]; exists {
+		return nil
+	}
+
+	err := g.db.Update(operation.InsertHeightCallback(height, callbackID))
+	if err != nil {
+		return fmt.Errorf("could not store callback %s for height %d: %w", callbackID, height, err)
+	}
+	g.add(height, callbackID)
+
+	if height <= g.final {
+		g.fire(height, callbackID)
+	}
+	return nil
+}
+
+// add adds the callback with the given ID to the callbacks of the given height.
+//
+// CAUTION: the caller MUST acquire the lock, unless the gadget is being constructed.
+func (g *PersistentHeights) add(height uint64, callbackID string) {
+	callbackIDs, ok := g.heights[height]
+	if !ok {
+		callbackIDs = make(map[string]struct{})
+		g.heights[height] = callbackIDs
+	}
+	callbackIDs[callbackID] = struct{}{}
+}
+
+// fire invokes the callback with the given ID for the given height, if its
+// factory is registered, and removes the callback from the database.
+//
+// CAUTION: the caller MUST acquire the lock.
+func (g *PersistentHeights) fire(height uint64, callbackID string) {
+	factory, ok := g.factories[callbackID]
+	if !ok {
+		// the callback is invoked once its factory is registered
+		return
+	}
+
+	factory()()
+
+	delete(g.heights[height], callbackID)
+	if len(g.heights[height]) == 0 {
+		delete(g.heights, height)
+	}
+	err := g.db.Update(operation.RemoveHeightCallback(height, callbackID))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		// the callback is invoked again after a restart
+		g.log.Error().Err(err).
+			Uint64("height", height).
+			Str("callback_id", callbackID).
+			Msg("could not remove invoked height callback")
+	}
+}
@@ -0,0 +1,71 @@
+package gadgets
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPersistentHeights(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		heights, err := NewPersistentHeights(zerolog.Nop(), db, 1)
+		require.NoError(t, err)
+
+		calls := 0
+		factory := func() func() {
+			return func() { calls++ }
+		}
+
+		// callbacks can only be registered once their factory is registered
+		err = heights.OnHeight(2, "callback")
+		assert.Error(t, err)
+
+		require.NoError(t, heights.RegisterFactory("callback", factory))
+		assert.Error(t, heights.RegisterFactory("callback", factory))
+
+		// a callback for an already finalized height is invoked right away
+		require.NoError(t, heights.OnHeight(1, "callback"))
+		assert.Equal(t, 1, calls)
+
+		// registering the same callback twice invokes it once
+		require.NoError(t, heights.OnHeight(3, "callback"))
+		require.NoError(t, heights.OnHeight(3, "callback"))
+		require.NoError(t, heights.OnHeight(5, "callback"))
+
+		block := unittest.BlockHeaderFixture()
+		block.Height = 2
+		heights.BlockFinalized(&block)
+		assert.Equal(t, 1, calls)
+
+		// after a restart at height 4, the callback for height 3 is invoked once
+		// its factory is registered
+		heights, err = NewPersistentHeights(zerolog.Nop(), db, 4)
+		require.NoError(t, err)
+		block.Height = 4
+		heights.BlockFinalized(&block)
+		assert.Equal(t, 1, calls)
+		require.NoError(t, heights.RegisterFactory("callback", factory))
+		assert.Equal(t, 2, calls)
+
+		// after another restart, only the callback for height 5 remains
+		heights, err = NewPersistentHeights(zerolog.Nop(), db, 4)
+		require.NoError(t, err)
+		require.NoError(t, heights.RegisterFactory("callback", factory))
+		assert.Equal(t, 2, calls)
+		for height := uint64(5); height <= 6; height++ {
+			block.Height = height
+			heights.BlockFinalized(&block)
+		}
+		assert.Equal(t, 3, calls)
+
+		// invoked callbacks are removed from the database
+		heights, err = NewPersistentHeights(zerolog.Nop(), db, 6)
+		require.NoError(t, err)
+		assert.Empty(t, heights.heights)
+	})
+}
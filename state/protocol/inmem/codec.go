@@ -0,0 +1,221 @@
+package inmem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+
+	cborcodec "github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// The binary snapshot encoding consists of the magic header, followed by the
+// version byte and the sections of the snapshot for that version. For version 1,
+// the sections are, in order: header, identities, epochs and sealing segment.
+// Each section is CBOR-encoded and prefixed with its length as big-endian uint32.
+//
+// Snapshots encoded before the binary format was introduced are plain JSON of
+// the EncodableSnapshot, without magic header. They are decoded as version 0.
+const (
+	// SnapshotVersionJSON is the version of the legacy JSON snapshot encoding.
+	SnapshotVersionJSON uint8 = 0
+	// SnapshotVersionCBOR is the version of the sectioned CBOR snapshot encoding.
+	SnapshotVersionCBOR uint8 = 1
+	// SnapshotVersion is the version used by EncodeSnapshot.
+	SnapshotVersion = SnapshotVersionCBOR
+)
+
+// snapshotMagic identifies the binary snapshot encoding.
+var snapshotMagic = []byte("FLOWSNAP")
+
+// ErrUnsupportedSnapshotVersion is returned when decoding a snapshot encoded
+// with a version this node does not know, typically by a newer software version.
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot encoding version")
+
+// snapshotHeader is the first section of the binary snapshot encoding,
+// containing all fields not covered by the other sections.
+type snapshotHeader struct {
+	Head              *flow.Header
+	LatestSeal        *flow.Seal
+	LatestResult      *flow.ExecutionResult
+	QuorumCertificate *flow.QuorumCertificate
+	Phase             flow.EpochPhase
+	Params            EncodableParams
+}
+
+// EncodeSnapshot writes the given snapshot to w, using the binary snapshot
+// encoding of the current SnapshotVersion.
+func EncodeSnapshot(w io.Writer, snap protocol.Snapshot) error {
+	serializable, err := FromSnapshot(snap)
+	if err != nil {
+		return fmt.Errorf("could not convert snapshot: %w", err)
+	}
+	enc := serializable.Encodable()
+
+	header := snapshotHeader{
+		Head:              enc.Head,
+		LatestSeal:        enc.LatestSeal,
+		LatestResult:      enc.LatestResult,
+		QuorumCertificate: enc.QuorumCertificate,
+		Phase:             enc.Phase,
+		Params:            enc.Params,
+	}
+	sections := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "header", value: header},
+		{name: "identities", value: enc.Identities},
+		{name: "epochs", value: enc.Epochs},
+		{name: "sealing segment", value: enc.SealingSegment},
+	}
+
+	_, err = w.Write(append(append([]byte{}, snapshotMagic...), SnapshotVersion))
+	if err != nil {
+		return fmt.Errorf("could not write snapshot header: %w", err)
+	}
+	for _, section := range sections {
+		data, err := cborcodec.EncMode.Marshal(section.value)
+		if err != nil {
+			return fmt.Errorf("could not encode %s section: %w", section.name, err)
+		}
+		err = writeSection(w, data)
+		if err != nil {
+			return fmt.Errorf("could not write %s section: %w", section.name, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeSnapshot reads a snapshot from r. It accepts the binary snapshot
+// encoding up to the current SnapshotVersion, as well as the legacy JSON
+// encoding (version 0). Returns ErrUnsupportedSnapshotVersion if the snapshot
+// is encoded with an unknown version.
+func DecodeSnapshot(r io.Reader) (*Snapshot, error) {
+	br := bufio.NewReader(r)
+
+	prefix, err := br.Peek(len(snapshotMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not read snapshot header: %w", err)
+	}
+	if !bytes.Equal(prefix, snapshotMagic) {
+		// without magic header, this must be a legacy JSON snapshot
+		return decodeSnapshotJSON(br)
+	}
+	_, err = br.Discard(len(snapshotMagic))
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot header: %w", err)
+	}
+
+	version, err := br.ReadByte()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not read snapshot version: %w", io.ErrUnexpectedEOF)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read snapshot version: %w", err)
+	}
+
+	switch version {
+	case SnapshotVersionJSON:
+		return decodeSnapshotJSON(br)
+	case SnapshotVersionCBOR:
+		return decodeSnapshotCBOR(br)
+	default:
+		return nil, fmt.Errorf("%w: got version %d, latest supported version is %d", ErrUnsupportedSnapshotVersion, version, SnapshotVersion)
+	}
+}
+
+// decodeSnapshotJSON decodes a snapshot encoded with version 0.
+func decodeSnapshotJSON(r io.Reader) (*Snapshot, error) {
+	var enc EncodableSnapshot
+	err := json.NewDecoder(r).Decode(&enc)
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not decode json snapshot: %w", io.ErrUnexpectedEOF)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not decode json snapshot: %w", err)
+	}
+	return SnapshotFromEncodable(enc), nil
+}
+
+// decodeSnapshotCBOR decodes the sections of a snapshot encoded with version 1.
+func decodeSnapshotCBOR(r io.Reader) (*Snapshot, error) {
+	var (
+		header snapshotHeader
+		enc    EncodableSnapshot
+	)
+	sections := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "header", value: &header},
+		{name: "identities", value: &enc.Identities},
+		{name: "epochs", value: &enc.Epochs},
+		{name: "sealing segment", value: &enc.SealingSegment},
+	}
+
+	for _, section := range sections {
+		data, err := readSection(r)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s section: %w", section.name, err)
+		}
+		err = cbor.Unmarshal(data, section.value)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode %s section: %w", section.name, err)
+		}
+	}
+
+	enc.Head = header.Head
+	enc.LatestSeal = header.LatestSeal
+	enc.LatestResult = header.LatestResult
+	enc.QuorumCertificate = header.QuorumCertificate
+	enc.Phase = header.Phase
+	enc.Params = header.Params
+
+	return SnapshotFromEncodable(enc), nil
+}
+
+// writeSection writes the length-prefixed section data to w.
+func writeSection(w io.Writer, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	_, err := w.Write(length[:])
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// readSection reads the data of a length-prefixed section from r. Returns
+// io.ErrUnexpectedEOF if the input ends before the end of the section.
+func readSection(r io.Reader) ([]byte, error) {
+	var length [4]byte
+	_, err := io.ReadFull(r, length[:])
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// read through a limited reader, rather than allocating the declared
+	// length up front, so a corrupted length cannot exhaust memory
+	size := binary.BigEndian.Uint32(length[:])
+	data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return nil, err
+	}
+	if uint32(len(data)) != size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
@@ -0,0 +1,84 @@
+package inmem_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEncodeDecodeSnapshot tests that a snapshot is unchanged after a cycle of
+// the binary snapshot encoding.
+func TestEncodeDecodeSnapshot(t *testing.T) {
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	snapshot := unittest.RootSnapshotFixture(identities)
+
+	var buf bytes.Buffer
+	err := inmem.EncodeSnapshot(&buf, snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("FLOWSNAP"), buf.Bytes()[:8])
+	assert.Equal(t, inmem.SnapshotVersion, buf.Bytes()[8])
+
+	decoded, err := inmem.DecodeSnapshot(&buf)
+	require.NoError(t, err)
+	assertSnapshotsEqual(t, snapshot, decoded)
+}
+
+// TestDecodeSnapshot_JSON tests that snapshots in the legacy JSON encoding
+// are decoded as version 0.
+func TestDecodeSnapshot_JSON(t *testing.T) {
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	snapshot := unittest.RootSnapshotFixture(identities)
+
+	bz, err := json.Marshal(snapshot.Encodable())
+	require.NoError(t, err)
+
+	t.Run("without header", func(t *testing.T) {
+		decoded, err := inmem.DecodeSnapshot(bytes.NewReader(bz))
+		require.NoError(t, err)
+		assertSnapshotsEqual(t, snapshot, decoded)
+	})
+
+	t.Run("with header", func(t *testing.T) {
+		encoded := append([]byte("FLOWSNAP"), inmem.SnapshotVersionJSON)
+		encoded = append(encoded, bz...)
+		decoded, err := inmem.DecodeSnapshot(bytes.NewReader(encoded))
+		require.NoError(t, err)
+		assertSnapshotsEqual(t, snapshot, decoded)
+	})
+}
+
+// TestDecodeSnapshot_Truncated tests that decoding fails for truncated input.
+func TestDecodeSnapshot_Truncated(t *testing.T) {
+	identities := unittest.IdentityListFixture(10, unittest.WithAllRoles())
+	snapshot := unittest.RootSnapshotFixture(identities)
+
+	var buf bytes.Buffer
+	err := inmem.EncodeSnapshot(&buf, snapshot)
+	require.NoError(t, err)
+	encoded := buf.Bytes()
+
+	// cut off inside the version, the section lengths and the section data
+	for _, length := range []int{0, 8, 10, 100, len(encoded) / 2, len(encoded) - 1} {
+		_, err := inmem.DecodeSnapshot(bytes.NewReader(encoded[:length]))
+		assert.Error(t, err, "decoding should fail for input truncated to %d bytes", length)
+		assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), "unexpected error for input truncated to %d bytes: %v", length, err)
+	}
+}
+
+// TestDecodeSnapshot_UnsupportedVersion tests that decoding a snapshot encoded
+// with an unknown future version is refused.
+func TestDecodeSnapshot_UnsupportedVersion(t *testing.T) {
+	encoded := append([]byte("FLOWSNAP"), inmem.SnapshotVersion+1)
+	encoded = append(encoded, unittest.RandomBytes(100)...)
+
+	_, err := inmem.DecodeSnapshot(bytes.NewReader(encoded))
+	assert.True(t, errors.Is(err, inmem.ErrUnsupportedSnapshotVersion))
+}
@@ -115,6 +115,14 @@ func testEncodeDecode(t *testing.T, snap *inmem.Snapshot) {
 
 	fromEncoded := inmem.SnapshotFromEncodable(encoded)
 	assertSnapshotsEqual(t, snap, fromEncoded)
+
+	var buf bytes.Buffer
+	err = inmem.EncodeSnapshot(&buf, snap)
+	require.NoError(t, err)
+
+	fromBinary, err := inmem.DecodeSnapshot(&buf)
+	require.NoError(t, err)
+	assertSnapshotsEqual(t, snap, fromBinary)
 }
 
 // checks that 2 snapshots are equivalent by converting to a serializable
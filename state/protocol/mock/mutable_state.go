@@ -48,6 +48,54 @@ func (_m *MutableState) AtHeight(height uint64) protocol.Snapshot {
 	return r0
 }
 
+// AtSealed provides a mock function with given fields:
+func (_m *MutableState) AtSealed() protocol.Snapshot {
+	ret := _m.Called()
+
+	var r0 protocol.Snapshot
+	if rf, ok := ret.Get(0).(func() protocol.Snapshot); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(protocol.Snapshot)
+		}
+	}
+
+	return r0
+}
+
+// Boundaries provides a mock function with given fields:
+func (_m *MutableState) Boundaries() (*flow.Header, *flow.Header, error) {
+	ret := _m.Called()
+
+	var r0 *flow.Header
+	if rf, ok := ret.Get(0).(func() *flow.Header); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Header)
+		}
+	}
+
+	var r1 *flow.Header
+	if rf, ok := ret.Get(1).(func() *flow.Header); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*flow.Header)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Extend provides a mock function with given fields: ctx, candidate
 func (_m *MutableState) Extend(ctx context.Context, candidate *flow.Block) error {
 	ret := _m.Called(ctx, candidate)
@@ -137,3 +185,24 @@ func (_m *MutableState) Sealed() protocol.Snapshot {
 
 	return r0
 }
+
+// SealedHeight provides a mock function with given fields:
+func (_m *MutableState) SealedHeight() (uint64, error) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -46,6 +46,54 @@ func (_m *State) AtHeight(height uint64) protocol.Snapshot {
 	return r0
 }
 
+// AtSealed provides a mock function with given fields:
+func (_m *State) AtSealed() protocol.Snapshot {
+	ret := _m.Called()
+
+	var r0 protocol.Snapshot
+	if rf, ok := ret.Get(0).(func() protocol.Snapshot); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(protocol.Snapshot)
+		}
+	}
+
+	return r0
+}
+
+// Boundaries provides a mock function with given fields:
+func (_m *State) Boundaries() (*flow.Header, *flow.Header, error) {
+	ret := _m.Called()
+
+	var r0 *flow.Header
+	if rf, ok := ret.Get(0).(func() *flow.Header); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Header)
+		}
+	}
+
+	var r1 *flow.Header
+	if rf, ok := ret.Get(1).(func() *flow.Header); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*flow.Header)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Final provides a mock function with given fields:
 func (_m *State) Final() protocol.Snapshot {
 	ret := _m.Called()
@@ -93,3 +141,24 @@ func (_m *State) Sealed() protocol.Snapshot {
 
 	return r0
 }
+
+// SealedHeight provides a mock function with given fields:
+func (_m *State) SealedHeight() (uint64, error) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,22 @@
+package seed
+
+// List of domains of the seeds derived from the random source of an epoch.
+//
+// Each use case of the random source derives its seed with its own domain,
+// which is used as domain separation tag, so that no two use cases share
+// a seed, even if they derive it with the same indices.
+
+func tag(domain string) string {
+	return protocolPrefix + domain
+}
+
+// Flow protocol version and prefix
+const protocolPrefix = "FLOW-V0.0_Seed-"
+
+const (
+	// ConsensusLeaderSelection is the domain of the seed for the leader selection of the consensus committee
+	ConsensusLeaderSelection = "Consensus-Leader-Selection"
+	// CollectorClusterLeaderSelection is the domain of the seeds for the leader selection of the collector
+	// clusters, which are derived with the index of the cluster
+	CollectorClusterLeaderSelection = "Collector-Cluster-Leader-Selection"
+)
@@ -27,7 +27,39 @@ func FromParentSignature(indices []uint32, combinedSig crypto.Signature) ([]byte
 // FromRandomSource generates a task-specific seed (task is determined by indices).
 func FromRandomSource(indices []uint32, sor []byte) ([]byte, error) {
 
-	// create the key used for the KMAC by concatenating all indices
+	// create a KMAC instance with our key and 32 bytes output size
+	kmac, err := hash.NewKMAC_128(indicesKey(indices), nil, 32)
+	if err != nil {
+		return nil, fmt.Errorf("could not create kmac: %w", err)
+	}
+
+	return kmac.ComputeHash(sor), nil
+}
+
+// Derive derives the seed for a specific use case from the given source of randomness, such as the
+// random source of an epoch. The domain names the use case and is used as the domain separation tag
+// of the KMAC, so that seeds of different use cases are independent even if they use the same indices.
+// The indices derive independent seeds within the same use case, e.g. one per collector cluster.
+// As the indices are zero-padded to the minimal KMAC key length, no indices are equivalent to index 0.
+func Derive(source []byte, domain string, indices ...uint32) ([]byte, error) {
+	if len(source) == 0 {
+		return nil, fmt.Errorf("source of randomness must not be empty")
+	}
+	if domain == "" {
+		return nil, fmt.Errorf("domain of the seed must not be empty")
+	}
+
+	// create a KMAC instance with our key, the domain tag as customizer and 32 bytes output size
+	kmac, err := hash.NewKMAC_128(indicesKey(indices), []byte(tag(domain)), 32)
+	if err != nil {
+		return nil, fmt.Errorf("could not create kmac: %w", err)
+	}
+
+	return kmac.ComputeHash(source), nil
+}
+
+// indicesKey creates the key used for the KMAC by concatenating all indices.
+func indicesKey(indices []uint32) []byte {
 	keyLen := 4 * len(indices)
 	if keyLen < hash.KmacMinKeyLen {
 		keyLen = hash.KmacMinKeyLen
@@ -36,12 +68,5 @@ func FromRandomSource(indices []uint32, sor []byte) ([]byte, error) {
 	for i, index := range indices {
 		binary.LittleEndian.PutUint32(key[4*i:4*i+4], index)
 	}
-
-	// create a KMAC instance with our key and 32 bytes output size
-	kmac, err := hash.NewKMAC_128(key, nil, 32)
-	if err != nil {
-		return nil, fmt.Errorf("could not create kmac: %w", err)
-	}
-
-	return kmac.ComputeHash(sor), nil
+	return key
 }
@@ -0,0 +1,80 @@
+package seed
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sourceFixture returns a fixed source of randomness, so that the derived seeds can be pinned.
+func sourceFixture() []byte {
+	source := make([]byte, 32)
+	for i := range source {
+		source[i] = byte(i)
+	}
+	return source
+}
+
+// TestDerive_GoldenVectors pins the seeds derived for each domain, as changing them would change
+// the leader selection of all nodes.
+func TestDerive_GoldenVectors(t *testing.T) {
+	vectors := []struct {
+		domain  string
+		indices []uint32
+		seed    string
+	}{
+		{ConsensusLeaderSelection, nil, "2113e96d387a719b793d0db60ac8de9859baea5deadc4f48be91ed4074f85edc"},
+		{CollectorClusterLeaderSelection, []uint32{0}, "e7379416989cfefc0598c440bfa62be909a22e7e649c5890db740b2517b5afeb"},
+		{CollectorClusterLeaderSelection, []uint32{1}, "fe413babc8847fd3cd1a7039b2a1ac4806d61f7deef9c85403aea217b7dfc43b"},
+	}
+
+	for _, vector := range vectors {
+		seed, err := Derive(sourceFixture(), vector.domain, vector.indices...)
+		require.NoError(t, err)
+		assert.Equal(t, vector.seed, hex.EncodeToString(seed), "domain %s, indices %v", vector.domain, vector.indices)
+	}
+}
+
+// TestDerive_Independent checks that different domains and indices derive different seeds
+// from the same source of randomness.
+func TestDerive_Independent(t *testing.T) {
+	source := sourceFixture()
+	seeds := make(map[string]struct{})
+	derive := func(domain string, indices ...uint32) {
+		seed, err := Derive(source, domain, indices...)
+		require.NoError(t, err)
+		require.Len(t, seed, 32)
+		_, duplicate := seeds[string(seed)]
+		assert.False(t, duplicate, "duplicate seed for domain %s, indices %v", domain, indices)
+		seeds[string(seed)] = struct{}{}
+	}
+
+	for _, domain := range []string{ConsensusLeaderSelection, CollectorClusterLeaderSelection} {
+		derive(domain, 0)
+		derive(domain, 1)
+		derive(domain, 0, 1)
+	}
+
+	// seeds derived with a domain differ from the seeds derived without domain separation
+	legacy, err := FromRandomSource(nil, source)
+	require.NoError(t, err)
+	assert.NotContains(t, seeds, string(legacy))
+
+	// deriving is deterministic
+	first, err := Derive(source, ConsensusLeaderSelection)
+	require.NoError(t, err)
+	second, err := Derive(source, ConsensusLeaderSelection)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+// TestDerive_InvalidInput checks that a seed cannot be derived without source or domain.
+func TestDerive_InvalidInput(t *testing.T) {
+	_, err := Derive(nil, ConsensusLeaderSelection)
+	assert.Error(t, err)
+
+	_, err = Derive(sourceFixture(), "")
+	assert.Error(t, err)
+}
@@ -92,6 +92,8 @@ type Snapshot interface {
 	Seed(indices ...uint32) ([]byte, error)
 
 	// Phase returns the epoch phase for the current epoch, as of the Head block.
+	// Along a fork, the phases of an epoch only ever move forward (staking, setup,
+	// committed), as illegal phase transitions are rejected when extending the state.
 	Phase() (flow.EpochPhase, error)
 
 	// Epochs returns a query object enabling querying detailed information about
@@ -25,6 +25,25 @@ type State interface {
 	// over time.
 	Sealed() Snapshot
 
+	// AtSealed returns the snapshot of the persistent protocol state at the
+	// latest sealed block. The sealed block is resolved once, from a single
+	// consistent view of the state, and the returned snapshot stays at that
+	// block, even if further blocks are sealed while the snapshot is in use.
+	AtSealed() Snapshot
+
+	// SealedHeight returns the height of the latest sealed block. Callers that
+	// only need the sealed height should prefer this method over retrieving the
+	// full header of the sealed block.
+	SealedHeight() (uint64, error)
+
+	// Boundaries returns the headers of the latest finalized and the latest sealed
+	// block. Both are read from a single consistent view of the state, hence the
+	// returned pair corresponds to the same point of the protocol state history,
+	// i.e. the sealed block is the block sealed by the finalized fork. Callers that
+	// need both heights should prefer this method over separate calls to Final()
+	// and Sealed(), between which the state can advance.
+	Boundaries() (finalized *flow.Header, sealed *flow.Header, err error)
+
 	// AtHeight returns the snapshot of the persistent protocol state at the
 	// given block number. It is only available for finalized blocks and the
 	// returned snapshot is therefore immutable over time.
@@ -45,6 +64,13 @@ type MutableState interface {
 	// still checking that the given block is a valid extension of the protocol
 	// state. Depending on implementation it might be a lighter version that checks only
 	// block header.
+	// Expected errors during normal operations:
+	//  * state.OutdatedExtensionError wrapping ErrOutdatedBlock if the block is at or below the
+	//    finalized height
+	//  * state.OutdatedExtensionError wrapping ErrOrphanedBlock if the block is on a fork
+	//    conflicting with the finalized blocks
+	//  * state.InvalidExtensionError wrapping an InvalidExtensionError with the reason if the
+	//    block violates the protocol rules
 	Extend(ctx context.Context, candidate *flow.Block) error
 
 	// Finalize finalizes the block with the given hash.
@@ -20,4 +20,6 @@ type All struct {
 	Collections        Collections
 	Events             Events
 	Identities         Identities
+	MisbehaviorReports MisbehaviorReports
+	ConfigOverrides    ConfigOverrides
 }
@@ -0,0 +1,157 @@
+package backfill
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// Indexers returns all indexers which can be backfilled, by name. Events are keyed by block ID
+// and filtered by type when they are read, so they have no separate index to backfill.
+func Indexers() map[string]Indexer {
+	indexers := []Indexer{
+		TransactionsByCollection{},
+		CollectionsByBlock{},
+		HeadersByParent{},
+	}
+	byName := make(map[string]Indexer, len(indexers))
+	for _, indexer := range indexers {
+		byName[indexer.Name()] = indexer
+	}
+	return byName
+}
+
+// IndexerNames returns the names of all indexers which can be backfilled, in lexicographic order.
+func IndexerNames() []string {
+	var names []string
+	for name := range Indexers() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TransactionsByCollection indexes the transactions of the collections guaranteed in a block by
+// collection ID. Collections which were not ingested by the node yet are skipped.
+type TransactionsByCollection struct{}
+
+var _ Indexer = TransactionsByCollection{}
+
+func (TransactionsByCollection) Name() string {
+	return "transactions-by-collection"
+}
+
+func (TransactionsByCollection) IndexBlock(header *flow.Header) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var collIDs []flow.Identifier
+		err := operation.LookupPayloadGuarantees(header.ID(), &collIDs)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up guarantees: %w", err)
+		}
+
+		for _, collID := range collIDs {
+			var collection flow.LightCollection
+			err := operation.RetrieveCollection(collID, &collection)(tx)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not retrieve collection %v: %w", collID, err)
+			}
+
+			for _, txID := range collection.Transactions {
+				err := operation.SkipDuplicates(operation.IndexCollectionByTransaction(txID, collID))(tx)
+				if err != nil {
+					return fmt.Errorf("could not index transaction %v: %w", txID, err)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// CollectionsByBlock indexes the collections guaranteed in a block by block ID.
+type CollectionsByBlock struct{}
+
+var _ Indexer = CollectionsByBlock{}
+
+func (CollectionsByBlock) Name() string {
+	return "collections-by-block"
+}
+
+func (CollectionsByBlock) IndexBlock(header *flow.Header) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		blockID := header.ID()
+		var collIDs []flow.Identifier
+		err := operation.LookupPayloadGuarantees(blockID, &collIDs)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up guarantees: %w", err)
+		}
+
+		for _, collID := range collIDs {
+			err := operation.SkipDuplicates(operation.IndexCollectionBlock(collID, blockID))(tx)
+			if err != nil {
+				return fmt.Errorf("could not index collection %v: %w", collID, err)
+			}
+		}
+		return nil
+	}
+}
+
+// HeadersByParent indexes a block as child of its parent. Unlike indexing a new block, the
+// children already indexed for the block itself are kept.
+type HeadersByParent struct{}
+
+var _ Indexer = HeadersByParent{}
+
+func (HeadersByParent) Name() string {
+	return "headers-by-parent"
+}
+
+func (HeadersByParent) IndexBlock(header *flow.Header) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		blockID := header.ID()
+
+		var childrenIDs []flow.Identifier
+		err := operation.RetrieveBlockChildren(blockID, &childrenIDs)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = operation.InsertBlockChildren(blockID, nil)(tx)
+		}
+		if err != nil {
+			return fmt.Errorf("could not index children of block: %w", err)
+		}
+
+		if header.ParentID == flow.ZeroID {
+			return nil
+		}
+
+		var siblingIDs []flow.Identifier
+		err = operation.RetrieveBlockChildren(header.ParentID, &siblingIDs)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = operation.InsertBlockChildren(header.ParentID, []flow.Identifier{blockID})(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert children of parent: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up children of parent: %w", err)
+		}
+		for _, siblingID := range siblingIDs {
+			if siblingID == blockID {
+				return nil
+			}
+		}
+		err = operation.UpdateBlockChildren(header.ParentID, append(siblingIDs, blockID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not update children of parent: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,179 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// DefaultBatchSize is the default number of blocks indexed within a single database transaction.
+const DefaultBatchSize = 100
+
+// Indexer rebuilds an index for a single finalized block.
+//
+// Indexing must be idempotent: indexing a block which was already indexed, either by a previous
+// backfill or by the live ingestion of the node, must succeed without changing the index. This
+// allows to resume an interrupted backfill, and to run it while the node is ingesting new data.
+type Indexer interface {
+	// Name returns the unique name of the index, which identifies its backfill progress.
+	Name() string
+
+	// IndexBlock indexes the given finalized block.
+	IndexBlock(header *flow.Header) func(*badger.Txn) error
+}
+
+// Runner backfills indexes for the finalized blocks within a height range. Each batch of blocks
+// is indexed within a single database transaction, together with the last indexed height. An
+// interrupted backfill resumes after the last indexed batch, when it is restarted for the same
+// index and height range.
+type Runner struct {
+	log       zerolog.Logger
+	db        *badger.DB
+	batchSize uint64
+}
+
+// NewRunner creates a backfill runner, which indexes up to batchSize blocks per database transaction.
+func NewRunner(log zerolog.Logger, db *badger.DB, batchSize uint) *Runner {
+	if batchSize == 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Runner{
+		log:       log.With().Str("component", "backfill").Logger(),
+		db:        db,
+		batchSize: uint64(batchSize),
+	}
+}
+
+// progressName returns the name under which the backfill progress of the index is persisted for
+// the given height range.
+func progressName(indexer Indexer, from, to uint64) string {
+	return fmt.Sprintf("backfill_%s_%d_%d", indexer.Name(), from, to)
+}
+
+// Progress returns the last height indexed by the backfill of the index for the given height range,
+// and false if the backfill was not started yet.
+func (r *Runner) Progress(indexer Indexer, from, to uint64) (uint64, bool, error) {
+	var processed uint64
+	err := r.db.View(operation.RetrieveProcessedIndex(progressName(indexer, from, to), &processed))
+	if errors.Is(err, storage.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("could not retrieve backfill progress: %w", err)
+	}
+	return processed, true, nil
+}
+
+// Run indexes all finalized blocks from height `from` up to and including height `to`, starting
+// after the last indexed height of a previous run for the same range. It returns the number of
+// blocks indexed by this run. When the context is cancelled, it returns after the current batch
+// with the context error, and the backfill can be resumed later.
+func (r *Runner) Run(ctx context.Context, indexer Indexer, from, to uint64) (uint64, error) {
+	if from > to {
+		return 0, fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+
+	name := progressName(indexer, from, to)
+	log := r.log.With().
+		Str("index", indexer.Name()).
+		Uint64("from_height", from).
+		Uint64("to_height", to).
+		Logger()
+
+	start := from
+	processed, resumed, err := r.Progress(indexer, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if resumed {
+		if processed >= to {
+			log.Info().Msg("backfill already completed")
+			return 0, nil
+		}
+		start = processed + 1
+		log.Info().Uint64("resume_height", start).Msg("resuming backfill")
+	}
+
+	startTime := time.Now()
+	indexed := uint64(0)
+	for height := start; ; height += r.batchSize {
+		if ctx.Err() != nil {
+			return indexed, ctx.Err()
+		}
+
+		end := height + r.batchSize - 1
+		if end > to || end < height {
+			end = to
+		}
+
+		err := operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+			for h := height; h <= end; h++ {
+				err := indexHeight(tx, indexer, h)
+				if err != nil {
+					return fmt.Errorf("could not index block at height %d: %w", h, err)
+				}
+				if h == end {
+					break
+				}
+			}
+			return setProgress(tx, name, end)
+		})
+		if err != nil {
+			return indexed, fmt.Errorf("could not backfill %s: %w", indexer.Name(), err)
+		}
+		indexed += end - height + 1
+
+		log.Info().
+			Uint64("indexed_height", end).
+			Float64("progress", float64(end-from+1)/float64(to-from+1)).
+			Float64("blocks_per_second", float64(indexed)/time.Since(startTime).Seconds()).
+			Msg("backfill batch indexed")
+
+		if end == to {
+			break
+		}
+	}
+
+	log.Info().
+		Uint64("indexed_blocks", indexed).
+		Dur("duration", time.Since(startTime)).
+		Msg("backfill completed")
+
+	return indexed, nil
+}
+
+// indexHeight indexes the finalized block at the given height.
+func indexHeight(tx *badger.Txn, indexer Indexer, height uint64) error {
+	var blockID flow.Identifier
+	err := operation.LookupBlockHeight(height, &blockID)(tx)
+	if err != nil {
+		return fmt.Errorf("could not look up finalized block: %w", err)
+	}
+	var header flow.Header
+	err = operation.RetrieveHeader(blockID, &header)(tx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve header: %w", err)
+	}
+	return indexer.IndexBlock(&header)(tx)
+}
+
+// setProgress persists the last indexed height of the backfill with the given name.
+func setProgress(tx *badger.Txn, name string, height uint64) error {
+	var processed uint64
+	err := operation.RetrieveProcessedIndex(name, &processed)(tx)
+	if errors.Is(err, storage.ErrNotFound) {
+		return operation.InsertProcessedIndex(name, height)(tx)
+	}
+	if err != nil {
+		return fmt.Errorf("could not retrieve backfill progress: %w", err)
+	}
+	return operation.SetProcessedIndex(name, height)(tx)
+}
@@ -0,0 +1,360 @@
+package backfill
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/badger/procedure"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fixtureBlock is a finalized block together with the collections guaranteed in its payload.
+type fixtureBlock struct {
+	header      *flow.Header
+	collections []*flow.LightCollection
+}
+
+// chainFixture creates a chain of finalized blocks with the given number of blocks, each
+// guaranteeing two collections.
+func chainFixture(count int) []fixtureBlock {
+	blocks := make([]fixtureBlock, 0, count)
+	parent := unittest.BlockHeaderFixture()
+	parent.Height = 0
+	parent.ParentID = flow.ZeroID
+	for i := 0; i < count; i++ {
+		header := parent
+		if i > 0 {
+			header = unittest.BlockHeaderWithParentFixture(&parent)
+		}
+		block := fixtureBlock{header: &header}
+		for j := 0; j < 2; j++ {
+			collection := unittest.CollectionFixture(3)
+			light := collection.Light()
+			block.collections = append(block.collections, &light)
+		}
+		blocks = append(blocks, block)
+		parent = header
+	}
+	return blocks
+}
+
+// storeBlock stores a finalized block, as it is stored after bootstrapping from a snapshot
+// or syncing the block while the indexes did not exist yet.
+func storeBlock(block fixtureBlock) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		blockID := block.header.ID()
+		err := operation.InsertHeader(blockID, block.header)(tx)
+		if err != nil {
+			return err
+		}
+		err = operation.IndexBlockHeight(block.header.Height, blockID)(tx)
+		if err != nil {
+			return err
+		}
+		var collIDs []flow.Identifier
+		for _, collection := range block.collections {
+			collIDs = append(collIDs, collection.ID())
+		}
+		return operation.IndexPayloadGuarantees(blockID, collIDs)(tx)
+	}
+}
+
+// storeCollection stores a collection without indexing its transactions.
+func storeCollection(collection *flow.LightCollection) func(*badger.Txn) error {
+	return operation.InsertCollection(collection)
+}
+
+// indexBlockLive indexes a block, as the node does when it ingests the block.
+func indexBlockLive(block fixtureBlock) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		blockID := block.header.ID()
+		err := procedure.IndexNewBlock(blockID, block.header.ParentID)(tx)
+		if err != nil {
+			return err
+		}
+		for _, collection := range block.collections {
+			err = operation.IndexCollectionBlock(collection.ID(), blockID)(tx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// indexCollectionLive indexes the transactions of a collection, as the node does when it
+// ingests the collection.
+func indexCollectionLive(collection *flow.LightCollection) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		for _, txID := range collection.Transactions {
+			err := operation.SkipDuplicates(operation.IndexCollectionByTransaction(txID, collection.ID()))(tx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// populate stores the given blocks with their collections. With live indexing, the blocks and
+// collections are indexed as if they were ingested by the node.
+func populate(t *testing.T, db *badger.DB, blocks []fixtureBlock, live bool) {
+	for _, block := range blocks {
+		require.NoError(t, db.Update(storeBlock(block)))
+		for _, collection := range block.collections {
+			require.NoError(t, db.Update(storeCollection(collection)))
+			if live {
+				require.NoError(t, db.Update(indexCollectionLive(collection)))
+			}
+		}
+		if live {
+			require.NoError(t, db.Update(indexBlockLive(block)))
+		}
+	}
+}
+
+// indexSnapshot reads all indexes rebuilt by the backfill for the given blocks.
+type indexSnapshot struct {
+	collectionByTx    map[flow.Identifier]flow.Identifier
+	blockByCollection map[flow.Identifier]flow.Identifier
+	children          map[flow.Identifier][]flow.Identifier
+}
+
+func readIndexes(t *testing.T, db *badger.DB, blocks []fixtureBlock) indexSnapshot {
+	snapshot := indexSnapshot{
+		collectionByTx:    make(map[flow.Identifier]flow.Identifier),
+		blockByCollection: make(map[flow.Identifier]flow.Identifier),
+		children:          make(map[flow.Identifier][]flow.Identifier),
+	}
+	err := db.View(func(tx *badger.Txn) error {
+		for _, block := range blocks {
+			blockID := block.header.ID()
+			var childrenIDs []flow.Identifier
+			err := operation.RetrieveBlockChildren(blockID, &childrenIDs)(tx)
+			require.NoError(t, err)
+			snapshot.children[blockID] = childrenIDs
+
+			for _, collection := range block.collections {
+				var indexedBlockID flow.Identifier
+				err := operation.LookupCollectionBlock(collection.ID(), &indexedBlockID)(tx)
+				require.NoError(t, err)
+				snapshot.blockByCollection[collection.ID()] = indexedBlockID
+
+				for _, txID := range collection.Transactions {
+					var collID flow.Identifier
+					err := operation.RetrieveCollectionID(txID, &collID)(tx)
+					require.NoError(t, err)
+					snapshot.collectionByTx[txID] = collID
+				}
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return snapshot
+}
+
+// runAll backfills all indexes for the given height range.
+func runAll(t *testing.T, ctx context.Context, runner *Runner, from, to uint64) {
+	for _, name := range IndexerNames() {
+		_, err := runner.Run(ctx, Indexers()[name], from, to)
+		require.NoError(t, err)
+	}
+}
+
+// TestBackfill_MatchesLiveIndexes verifies that backfilling the indexes of blocks stored
+// without indexes results in the same indexes as ingesting the blocks.
+func TestBackfill_MatchesLiveIndexes(t *testing.T) {
+	blocks := chainFixture(25)
+	to := blocks[len(blocks)-1].header.Height
+
+	unittest.RunWithBadgerDB(t, func(liveDB *badger.DB) {
+		populate(t, liveDB, blocks, true)
+		expected := readIndexes(t, liveDB, blocks)
+
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			populate(t, db, blocks, false)
+			runAll(t, context.Background(), NewRunner(unittest.Logger(), db, 7), 0, to)
+			assert.Equal(t, expected, readIndexes(t, db, blocks))
+
+			// running the backfill again for another range does not change the indexes
+			runAll(t, context.Background(), NewRunner(unittest.Logger(), db, 4), 3, to)
+			assert.Equal(t, expected, readIndexes(t, db, blocks))
+		})
+	})
+}
+
+// TestBackfill_SkipsMissingCollections verifies that collections which were not ingested
+// yet are skipped, and indexed by a later backfill once they are stored.
+func TestBackfill_SkipsMissingCollections(t *testing.T) {
+	blocks := chainFixture(5)
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		for _, block := range blocks {
+			require.NoError(t, db.Update(storeBlock(block)))
+		}
+		runner := NewRunner(unittest.Logger(), db, 0)
+		indexer := TransactionsByCollection{}
+
+		indexed, err := runner.Run(context.Background(), indexer, 0, 4)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), indexed)
+
+		missing := blocks[2].collections[0]
+		var collID flow.Identifier
+		err = db.View(operation.RetrieveCollectionID(missing.Transactions[0], &collID))
+		require.Error(t, err)
+
+		require.NoError(t, db.Update(storeCollection(missing)))
+		_, err = runner.Run(context.Background(), indexer, 2, 2)
+		require.NoError(t, err)
+		require.NoError(t, db.View(operation.RetrieveCollectionID(missing.Transactions[0], &collID)))
+		assert.Equal(t, missing.ID(), collID)
+	})
+}
+
+// cancellingIndexer cancels the backfill after indexing the block at the given height.
+type cancellingIndexer struct {
+	Indexer
+	height uint64
+	cancel context.CancelFunc
+}
+
+func (c cancellingIndexer) IndexBlock(header *flow.Header) func(*badger.Txn) error {
+	if header.Height == c.height {
+		c.cancel()
+	}
+	return c.Indexer.IndexBlock(header)
+}
+
+// TestBackfill_ResumeAfterInterrupt verifies that an interrupted backfill persists the last
+// indexed batch, and resumes after it.
+func TestBackfill_ResumeAfterInterrupt(t *testing.T) {
+	blocks := chainFixture(20)
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		populate(t, db, blocks, false)
+		runner := NewRunner(unittest.Logger(), db, 5)
+
+		_, started, err := runner.Progress(CollectionsByBlock{}, 0, 19)
+		require.NoError(t, err)
+		assert.False(t, started)
+
+		// cancel while indexing the second batch, which is still completed
+		ctx, cancel := context.WithCancel(context.Background())
+		interrupted := cancellingIndexer{Indexer: CollectionsByBlock{}, height: 7, cancel: cancel}
+		indexed, err := runner.Run(ctx, interrupted, 0, 19)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, uint64(10), indexed)
+
+		progress, started, err := runner.Progress(CollectionsByBlock{}, 0, 19)
+		require.NoError(t, err)
+		assert.True(t, started)
+		assert.Equal(t, uint64(9), progress)
+
+		// blocks after the persisted progress are not indexed yet
+		var blockID flow.Identifier
+		err = db.View(operation.LookupCollectionBlock(blocks[10].collections[0].ID(), &blockID))
+		require.Error(t, err)
+
+		// resuming indexes the remaining blocks only
+		indexed, err = runner.Run(context.Background(), CollectionsByBlock{}, 0, 19)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), indexed)
+		for _, block := range blocks {
+			for _, collection := range block.collections {
+				require.NoError(t, db.View(operation.LookupCollectionBlock(collection.ID(), &blockID)))
+				assert.Equal(t, block.header.ID(), blockID)
+			}
+		}
+
+		// a completed backfill is not repeated
+		indexed, err = runner.Run(context.Background(), CollectionsByBlock{}, 0, 19)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), indexed)
+
+		// progress is tracked per height range
+		indexed, err = runner.Run(context.Background(), CollectionsByBlock{}, 5, 19)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(15), indexed)
+	})
+}
+
+// TestBackfill_ConcurrentIngestion verifies that backfilling is safe while the node ingests
+// new blocks, and collections of the blocks being backfilled.
+func TestBackfill_ConcurrentIngestion(t *testing.T) {
+	blocks := chainFixture(60)
+	historical, ingested := blocks[:40], blocks[40:]
+
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		// historical blocks are stored without indexes, and half of their collections
+		// are ingested while the backfill is running
+		var pending []*flow.LightCollection
+		for _, block := range historical {
+			require.NoError(t, db.Update(storeBlock(block)))
+			require.NoError(t, db.Update(storeCollection(block.collections[0])))
+			pending = append(pending, block.collections[1])
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for _, collection := range pending {
+				err := operation.RetryOnConflict(db.Update, func(tx *badger.Txn) error {
+					err := storeCollection(collection)(tx)
+					if err != nil {
+						return err
+					}
+					return indexCollectionLive(collection)(tx)
+				})
+				assert.NoError(t, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for _, block := range ingested {
+				err := operation.RetryOnConflict(db.Update, func(tx *badger.Txn) error {
+					err := storeBlock(block)(tx)
+					if err != nil {
+						return err
+					}
+					return indexBlockLive(block)(tx)
+				})
+				assert.NoError(t, err)
+			}
+		}()
+
+		runAll(t, context.Background(), NewRunner(unittest.Logger(), db, 3), 0, historical[len(historical)-1].header.Height)
+		wg.Wait()
+
+		// collections of the ingested blocks were ingested after the blocks
+		for _, block := range ingested {
+			for _, collection := range block.collections {
+				require.NoError(t, db.Update(storeCollection(collection)))
+				require.NoError(t, db.Update(indexCollectionLive(collection)))
+			}
+		}
+
+		snapshot := readIndexes(t, db, blocks)
+		for i, block := range blocks {
+			blockID := block.header.ID()
+			if i+1 < len(blocks) {
+				assert.Equal(t, []flow.Identifier{blocks[i+1].header.ID()}, snapshot.children[blockID])
+			} else {
+				assert.Empty(t, snapshot.children[blockID])
+			}
+			for _, collection := range block.collections {
+				assert.Equal(t, blockID, snapshot.blockByCollection[collection.ID()])
+				for _, txID := range collection.Transactions {
+					assert.Equal(t, collection.ID(), snapshot.collectionByTx[txID])
+				}
+			}
+		}
+	})
+}
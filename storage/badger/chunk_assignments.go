@@ -0,0 +1,58 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ChunkAssignments implements persistent storage for chunk assignment records.
+type ChunkAssignments struct {
+	db *badger.DB
+}
+
+func NewChunkAssignments(db *badger.DB) *ChunkAssignments {
+	return &ChunkAssignments{
+		db: db,
+	}
+}
+
+func (c *ChunkAssignments) Store(record *chunks.AssignmentRecord) error {
+	err := operation.RetryOnConflict(c.db.Update, func(tx *badger.Txn) error {
+		err := operation.SkipDuplicates(operation.InsertChunkAssignment(record))(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert chunk assignment: %w", err)
+		}
+		err = operation.SkipDuplicates(operation.IndexChunkAssignmentByHeight(record))(tx)
+		if err != nil {
+			return fmt.Errorf("could not index chunk assignment by height: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not store chunk assignment of result %x: %w", record.ResultID, err)
+	}
+	return nil
+}
+
+func (c *ChunkAssignments) ByResultID(resultID flow.Identifier) ([]*chunks.AssignmentRecord, error) {
+	var records []*chunks.AssignmentRecord
+	err := c.db.View(operation.LookupChunkAssignmentsByResult(resultID, &records))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up chunk assignments of result %x: %w", resultID, err)
+	}
+	return records, nil
+}
+
+func (c *ChunkAssignments) PruneBelow(height uint64) (uint, error) {
+	var removed uint
+	err := operation.RetryOnConflict(c.db.Update, operation.RemoveChunkAssignmentsBelow(height, &removed))
+	if err != nil {
+		return 0, fmt.Errorf("could not prune chunk assignments: %w", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,102 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// assignmentRecordFixture returns the record of an assignment of a result with three chunks,
+// which was incorporated at the given height.
+func assignmentRecordFixture(resultID flow.Identifier, height uint64) *chunks.AssignmentRecord {
+	return &chunks.AssignmentRecord{
+		ResultID:            resultID,
+		IncorporatedBlockID: unittest.IdentifierFixture(),
+		IncorporatedHeight:  height,
+		Verifiers: []flow.IdentifierList{
+			unittest.IdentifierListFixture(2),
+			unittest.IdentifierListFixture(1),
+			{},
+		},
+	}
+}
+
+func TestChunkAssignmentsStorage(t *testing.T) {
+	withStore := func(t *testing.T, f func(store *bstorage.ChunkAssignments)) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			f(bstorage.NewChunkAssignments(db))
+		})
+	}
+
+	t.Run("get empty", func(t *testing.T) {
+		withStore(t, func(store *bstorage.ChunkAssignments) {
+			records, err := store.ByResultID(unittest.IdentifierFixture())
+			require.NoError(t, err)
+			require.Empty(t, records)
+		})
+	})
+
+	t.Run("store and get", func(t *testing.T) {
+		withStore(t, func(store *bstorage.ChunkAssignments) {
+			resultID := unittest.IdentifierFixture()
+			first := assignmentRecordFixture(resultID, 10)
+			second := assignmentRecordFixture(resultID, 11)
+			other := assignmentRecordFixture(unittest.IdentifierFixture(), 10)
+			for _, record := range []*chunks.AssignmentRecord{first, second, other} {
+				require.NoError(t, store.Store(record))
+			}
+
+			records, err := store.ByResultID(resultID)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []*chunks.AssignmentRecord{first, second}, records)
+		})
+	})
+
+	t.Run("store twice", func(t *testing.T) {
+		withStore(t, func(store *bstorage.ChunkAssignments) {
+			record := assignmentRecordFixture(unittest.IdentifierFixture(), 10)
+			require.NoError(t, store.Store(record))
+			require.NoError(t, store.Store(record))
+
+			records, err := store.ByResultID(record.ResultID)
+			require.NoError(t, err)
+			require.Equal(t, []*chunks.AssignmentRecord{record}, records)
+		})
+	})
+
+	t.Run("prune below height", func(t *testing.T) {
+		withStore(t, func(store *bstorage.ChunkAssignments) {
+			resultID := unittest.IdentifierFixture()
+			pruned := assignmentRecordFixture(resultID, 9)
+			kept := assignmentRecordFixture(resultID, 10)
+			other := assignmentRecordFixture(unittest.IdentifierFixture(), 5)
+			for _, record := range []*chunks.AssignmentRecord{pruned, kept, other} {
+				require.NoError(t, store.Store(record))
+			}
+
+			removed, err := store.PruneBelow(10)
+			require.NoError(t, err)
+			require.Equal(t, uint(2), removed)
+
+			records, err := store.ByResultID(resultID)
+			require.NoError(t, err)
+			require.Equal(t, []*chunks.AssignmentRecord{kept}, records)
+			records, err = store.ByResultID(other.ResultID)
+			require.NoError(t, err)
+			require.Empty(t, records)
+
+			// pruning again removes nothing, and a pruned record can be stored again
+			removed, err = store.PruneBelow(10)
+			require.NoError(t, err)
+			require.Equal(t, uint(0), removed)
+			require.NoError(t, store.Store(pruned))
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ClusterQCVotes stores whether this node has voted for the root cluster QC, per epoch.
+type ClusterQCVotes struct {
+	db *badger.DB
+}
+
+// NewClusterQCVotes returns the ClusterQCVotes implementation backed by Badger DB.
+func NewClusterQCVotes(db *badger.DB) *ClusterQCVotes {
+	return &ClusterQCVotes{
+		db: db,
+	}
+}
+
+// SetVoted records that this node has submitted its vote for the root cluster
+// QC of the given epoch. Recording a vote more than once is a no-op.
+func (cv *ClusterQCVotes) SetVoted(epochCounter uint64) error {
+	err := operation.RetryOnConflict(cv.db.Update, operation.InsertClusterQCVotedForEpoch(epochCounter))
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not record cluster qc vote: %w", err)
+	}
+	return nil
+}
+
+// HasVoted checks whether this node has submitted its vote for the root cluster
+// QC of the given epoch.
+func (cv *ClusterQCVotes) HasVoted(epochCounter uint64) (bool, error) {
+	var voted bool
+	err := cv.db.View(operation.RetrieveClusterQCVotedForEpoch(epochCounter, &voted))
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve cluster qc vote: %w", err)
+	}
+	return voted, nil
+}
@@ -0,0 +1,47 @@
+package badger_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestClusterQCVotes(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewClusterQCVotes(db)
+
+		epochCounter := rand.Uint64()
+
+		t.Run("should default to not voted", func(t *testing.T) {
+			voted, err := store.HasVoted(epochCounter)
+			require.NoError(t, err)
+			assert.False(t, voted)
+		})
+
+		t.Run("should record vote", func(t *testing.T) {
+			require.NoError(t, store.SetVoted(epochCounter))
+			voted, err := store.HasVoted(epochCounter)
+			require.NoError(t, err)
+			assert.True(t, voted)
+		})
+
+		t.Run("should record vote idempotently", func(t *testing.T) {
+			require.NoError(t, store.SetVoted(epochCounter))
+			voted, err := store.HasVoted(epochCounter)
+			require.NoError(t, err)
+			assert.True(t, voted)
+		})
+
+		t.Run("should record votes per epoch", func(t *testing.T) {
+			voted, err := store.HasVoted(epochCounter + 1)
+			require.NoError(t, err)
+			assert.False(t, voted)
+		})
+	})
+}
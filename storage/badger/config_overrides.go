@@ -0,0 +1,51 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ConfigOverrides implements persistent storage for runtime overrides of configuration parameters.
+type ConfigOverrides struct {
+	db *badger.DB
+}
+
+// NewConfigOverrides returns the ConfigOverrides implementation backed by Badger DB.
+func NewConfigOverrides(db *badger.DB) *ConfigOverrides {
+	return &ConfigOverrides{
+		db: db,
+	}
+}
+
+func (c *ConfigOverrides) Store(name string, value string) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.UpsertConfigOverride(name, value))
+	if err != nil {
+		return fmt.Errorf("could not store override of %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *ConfigOverrides) Remove(name string) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.RemoveConfigOverride(name))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not remove override of %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *ConfigOverrides) All() (map[string]string, error) {
+	overrides := make(map[string]string)
+	err := c.db.View(operation.RetrieveConfigOverrides(overrides))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve config overrides: %w", err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,44 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestConfigOverrides(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewConfigOverrides(db)
+
+		t.Run("should default to no overrides", func(t *testing.T) {
+			overrides, err := store.All()
+			require.NoError(t, err)
+			assert.Empty(t, overrides)
+		})
+
+		t.Run("should store and replace overrides", func(t *testing.T) {
+			require.NoError(t, store.Store("a", "1"))
+			require.NoError(t, store.Store("b", "true"))
+			require.NoError(t, store.Store("a", "2"))
+
+			overrides, err := store.All()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"a": "2", "b": "true"}, overrides)
+		})
+
+		t.Run("should remove overrides idempotently", func(t *testing.T) {
+			require.NoError(t, store.Remove("a"))
+			require.NoError(t, store.Remove("a"))
+			require.NoError(t, store.Remove("unknown"))
+
+			overrides, err := store.All()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"b": "true"}, overrides)
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package badger
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dgraph-io/badger/v2"
@@ -10,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/storage/badger/transaction"
 )
@@ -123,6 +125,34 @@ func (ds *DKGState) GetDKGEndState(epochCounter uint64) (flow.DKGEndState, error
 	return endState, err
 }
 
+// StoreDKGCheckpoint stores the checkpoint of the given DKG instance, replacing
+// any previously stored checkpoint.
+func (ds *DKGState) StoreDKGCheckpoint(dkgInstanceID string, data []byte) error {
+	return operation.RetryOnConflict(ds.db.Update, func(tx *badger.Txn) error {
+		err := operation.InsertDKGCheckpoint(dkgInstanceID, data)(tx)
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return operation.UpdateDKGCheckpoint(dkgInstanceID, data)(tx)
+		}
+		return err
+	})
+}
+
+// RetrieveDKGCheckpoint retrieves the latest checkpoint of the given DKG instance.
+func (ds *DKGState) RetrieveDKGCheckpoint(dkgInstanceID string) ([]byte, error) {
+	var data []byte
+	err := ds.db.View(operation.RetrieveDKGCheckpoint(dkgInstanceID, &data))
+	return data, err
+}
+
+// RemoveDKGCheckpoint removes the checkpoint of the given DKG instance, if any.
+func (ds *DKGState) RemoveDKGCheckpoint(dkgInstanceID string) error {
+	err := ds.db.Update(operation.RemoveDKGCheckpoint(dkgInstanceID))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
 // SafeBeaconPrivateKeys is the safe beacon key storage backed by Badger DB.
 type SafeBeaconPrivateKeys struct {
 	state *DKGState
@@ -113,6 +113,57 @@ func TestDKGState_EndState(t *testing.T) {
 	})
 }
 
+func TestDKGState_Checkpoints(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		store, err := bstorage.NewDKGState(metrics, db)
+		require.NoError(t, err)
+
+		dkgInstanceID := "dkg-flow-testnet-42"
+
+		t.Run("should error if retrieving non-existent checkpoint", func(t *testing.T) {
+			_, err := store.RetrieveDKGCheckpoint(dkgInstanceID)
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+		})
+
+		t.Run("should be able to store and read checkpoint", func(t *testing.T) {
+			expected := unittest.RandomBytes(100)
+			err := store.StoreDKGCheckpoint(dkgInstanceID, expected)
+			require.NoError(t, err)
+
+			actual, err := store.RetrieveDKGCheckpoint(dkgInstanceID)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+
+		t.Run("should replace stored checkpoint", func(t *testing.T) {
+			expected := unittest.RandomBytes(200)
+			err := store.StoreDKGCheckpoint(dkgInstanceID, expected)
+			require.NoError(t, err)
+
+			actual, err := store.RetrieveDKGCheckpoint(dkgInstanceID)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+
+			// checkpoints of other instances are independent
+			_, err = store.RetrieveDKGCheckpoint("dkg-flow-testnet-43")
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+		})
+
+		t.Run("should remove stored checkpoint", func(t *testing.T) {
+			err := store.RemoveDKGCheckpoint(dkgInstanceID)
+			require.NoError(t, err)
+
+			_, err = store.RetrieveDKGCheckpoint(dkgInstanceID)
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+			// removing a non-existent checkpoint is a no-op
+			err = store.RemoveDKGCheckpoint(dkgInstanceID)
+			require.NoError(t, err)
+		})
+	})
+}
+
 func TestSafeBeaconPrivateKeys(t *testing.T) {
 	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
 		metrics := metrics.NewNoopCollector()
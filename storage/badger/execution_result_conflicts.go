@@ -0,0 +1,56 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ExecutionResultConflicts implements persistent storage for conflicts between execution results.
+type ExecutionResultConflicts struct {
+	db *badger.DB
+}
+
+func NewExecutionResultConflicts(db *badger.DB) *ExecutionResultConflicts {
+	return &ExecutionResultConflicts{
+		db: db,
+	}
+}
+
+func (c *ExecutionResultConflicts) Store(conflict *flow.ExecutionResultConflict) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.InsertExecutionResultConflict(conflict))
+	if err != nil {
+		return fmt.Errorf("could not insert execution result conflict: %w", err)
+	}
+	return nil
+}
+
+func (c *ExecutionResultConflicts) Update(conflict *flow.ExecutionResultConflict) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.UpdateExecutionResultConflict(conflict))
+	if err != nil {
+		return fmt.Errorf("could not update execution result conflict: %w", err)
+	}
+	return nil
+}
+
+func (c *ExecutionResultConflicts) ByResultIDs(blockID flow.Identifier, resultID1 flow.Identifier, resultID2 flow.Identifier) (*flow.ExecutionResultConflict, error) {
+	key := flow.NewExecutionResultConflict(blockID, flow.ZeroID, resultID1, resultID2)
+	var conflict flow.ExecutionResultConflict
+	err := c.db.View(operation.RetrieveExecutionResultConflict(blockID, key.ResultIDs[0], key.ResultIDs[1], &conflict))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve execution result conflict: %w", err)
+	}
+	return &conflict, nil
+}
+
+func (c *ExecutionResultConflicts) ByBlockID(blockID flow.Identifier) ([]*flow.ExecutionResultConflict, error) {
+	var conflicts []*flow.ExecutionResultConflict
+	err := c.db.View(operation.LookupExecutionResultConflicts(blockID, &conflicts))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up execution result conflicts of block %x: %w", blockID, err)
+	}
+	return conflicts, nil
+}
@@ -93,8 +93,21 @@ func NewHeaders(collector module.CacheMetrics, db *badger.DB) *Headers {
 	return h
 }
 
+// storeTx stores the header and indexes it as child of its parent, within the
+// same transaction, so that ByParentID is consistent with the stored headers.
 func (h *Headers) storeTx(header *flow.Header) func(*transaction.Tx) error {
-	return h.cache.PutTx(header.ID(), header)
+	return func(tx *transaction.Tx) error {
+		blockID := header.ID()
+		err := h.cache.PutTx(blockID, header)(tx)
+		if err != nil {
+			return err
+		}
+		err = transaction.WithTx(procedure.IndexNewBlock(blockID, header.ParentID))(tx)
+		if err != nil {
+			return fmt.Errorf("could not index header by parent: %w", err)
+		}
+		return nil
+	}
 }
 
 func (h *Headers) retrieveTx(blockID flow.Identifier) func(*badger.Txn) (*flow.Header, error) {
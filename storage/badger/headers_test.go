@@ -2,13 +2,16 @@ package badger_test
 
 import (
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/onflow/flow-go/storage/badger/operation"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -50,3 +53,91 @@ func TestHeaderRetrieveWithoutStore(t *testing.T) {
 		require.True(t, errors.Is(err, storage.ErrNotFound))
 	})
 }
+
+func TestHeaderByParentID(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		headers := badgerstorage.NewHeaders(metrics, db)
+
+		parent := unittest.BlockHeaderFixture()
+		err := headers.Store(&parent)
+		require.NoError(t, err)
+
+		// a stored header without children has an empty index
+		children, err := headers.ByParentID(parent.ID())
+		require.NoError(t, err)
+		assert.Empty(t, children)
+
+		// all children of a parent are indexed, in the order they are stored
+		child1 := unittest.BlockHeaderWithParentFixture(&parent)
+		child2 := unittest.BlockHeaderWithParentFixture(&parent)
+		grandchild := unittest.BlockHeaderWithParentFixture(&child1)
+		for _, header := range []*flow.Header{&child1, &child2, &grandchild} {
+			err := headers.Store(header)
+			require.NoError(t, err)
+		}
+		children, err = headers.ByParentID(parent.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []*flow.Header{&child1, &child2}, children)
+
+		children, err = headers.ByParentID(child1.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []*flow.Header{&grandchild}, children)
+
+		// the children of an unknown block are not found
+		_, err = headers.ByParentID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
+
+func TestHeaderByParentIDChildStoredFirst(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		headers := badgerstorage.NewHeaders(metrics, db)
+
+		parent := unittest.BlockHeaderFixture()
+		child := unittest.BlockHeaderWithParentFixture(&parent)
+
+		// storing the parent after its child keeps the child indexed
+		err := headers.Store(&child)
+		require.NoError(t, err)
+		err = headers.Store(&parent)
+		require.NoError(t, err)
+
+		children, err := headers.ByParentID(parent.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []*flow.Header{&child}, children)
+	})
+}
+
+func TestHeaderByParentIDConcurrentInserts(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		headers := badgerstorage.NewHeaders(metrics, db)
+
+		parent := unittest.BlockHeaderFixture()
+		err := headers.Store(&parent)
+		require.NoError(t, err)
+
+		// conflicting updates of the index of the parent are retried
+		children := make([]flow.Header, 20)
+		var wg sync.WaitGroup
+		for i := range children {
+			children[i] = unittest.BlockHeaderWithParentFixture(&parent)
+			wg.Add(1)
+			go func(header *flow.Header) {
+				defer wg.Done()
+				err := headers.Store(header)
+				assert.NoError(t, err)
+			}(&children[i])
+		}
+		wg.Wait()
+
+		indexed, err := headers.ByParentID(parent.ID())
+		require.NoError(t, err)
+		require.Len(t, indexed, len(children))
+		for i := range children {
+			assert.Contains(t, indexed, &children[i])
+		}
+	})
+}
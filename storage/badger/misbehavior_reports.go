@@ -0,0 +1,57 @@
+package badger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// MisbehaviorReports implements persistent storage for misbehavior reports.
+type MisbehaviorReports struct {
+	db *badger.DB
+}
+
+func NewMisbehaviorReports(db *badger.DB) *MisbehaviorReports {
+	return &MisbehaviorReports{
+		db: db,
+	}
+}
+
+func (m *MisbehaviorReports) Store(report *flow.MisbehaviorReport) error {
+	err := operation.RetryOnConflict(m.db.Update, operation.SkipDuplicates(operation.InsertMisbehaviorReport(report)))
+	if err != nil {
+		return fmt.Errorf("could not insert misbehavior report: %w", err)
+	}
+	return nil
+}
+
+func (m *MisbehaviorReports) ByOriginID(originID flow.Identifier, since time.Time) ([]*flow.MisbehaviorReport, error) {
+	var reports []*flow.MisbehaviorReport
+	err := m.db.View(operation.LookupMisbehaviorReportsByOrigin(originID, since, &reports))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up misbehavior reports of origin %x: %w", originID, err)
+	}
+	return reports, nil
+}
+
+func (m *MisbehaviorReports) All(since time.Time) ([]*flow.MisbehaviorReport, error) {
+	var reports []*flow.MisbehaviorReport
+	err := m.db.View(operation.LookupMisbehaviorReports(since, &reports))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up misbehavior reports: %w", err)
+	}
+	return reports, nil
+}
+
+func (m *MisbehaviorReports) PruneBefore(cutoff time.Time) (uint, error) {
+	var removed uint
+	err := operation.RetryOnConflict(m.db.Update, operation.RemoveMisbehaviorReportsBefore(cutoff, &removed))
+	if err != nil {
+		return 0, fmt.Errorf("could not prune misbehavior reports: %w", err)
+	}
+	return removed, nil
+}
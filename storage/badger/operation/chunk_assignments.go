@@ -0,0 +1,88 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// chunkAssignmentByHeightResultOffset is the offset of the result ID within the key of the
+// height index of chunk assignment records.
+const chunkAssignmentByHeightResultOffset = 1 + 8
+
+// InsertChunkAssignment inserts the chunk assignment record, keyed by result ID and incorporating
+// block ID, which allows to efficiently look up the assignments of a result.
+func InsertChunkAssignment(record *chunks.AssignmentRecord) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkAssignment, record.ResultID, record.IncorporatedBlockID), record)
+}
+
+// IndexChunkAssignmentByHeight indexes the chunk assignment record by the height of the
+// incorporating block, which allows to efficiently prune the records below a height.
+func IndexChunkAssignmentByHeight(record *chunks.AssignmentRecord) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkAssignmentByHeight, record.IncorporatedHeight, record.ResultID, record.IncorporatedBlockID), true)
+}
+
+// LookupChunkAssignmentsByResult retrieves the chunk assignment records of the given result for
+// all blocks it was incorporated in, ordered by incorporating block ID.
+func LookupChunkAssignmentsByResult(resultID flow.Identifier, records *[]*chunks.AssignmentRecord) func(*badger.Txn) error {
+	return traverse(makePrefix(codeChunkAssignment, resultID), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var record chunks.AssignmentRecord
+		create := func() interface{} {
+			return &record
+		}
+		handle := func() error {
+			*records = append(*records, &record)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// RemoveChunkAssignmentsBelow removes the chunk assignment records of all results incorporated in
+// blocks below the given height, together with their height index. The number of removed records
+// is written to the given counter.
+func RemoveChunkAssignmentsBelow(height uint64, removed *uint) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		*removed = 0
+		if height == 0 {
+			return nil
+		}
+
+		var keys [][]byte
+		iteration := func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				keys = append(keys, append([]byte{}, key...))
+				// we only need the keys, skip decoding the values
+				return false
+			}
+			return check, nil, nil
+		}
+		start := makePrefix(codeChunkAssignmentByHeight, uint64(0))
+		end := makePrefix(codeChunkAssignmentByHeight, height-1)
+		err := iterate(start, end, iteration)(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			var resultID, blockID flow.Identifier
+			copy(resultID[:], key[chunkAssignmentByHeightResultOffset:])
+			copy(blockID[:], key[chunkAssignmentByHeightResultOffset+flow.IdentifierLen:])
+
+			err := tx.Delete(makePrefix(codeChunkAssignment, resultID, blockID))
+			if err != nil {
+				return err
+			}
+			err = tx.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		*removed = uint(len(keys))
+		return nil
+	}
+}
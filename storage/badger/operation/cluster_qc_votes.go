@@ -0,0 +1,33 @@
+package operation
+
+import (
+	"errors"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// InsertClusterQCVotedForEpoch stores a flag indicating that this node has voted
+// for the root cluster QC of the given epoch.
+func InsertClusterQCVotedForEpoch(epochCounter uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeClusterQCVoted, epochCounter), true)
+}
+
+// RetrieveClusterQCVotedForEpoch retrieves the root cluster QC voted flag for the
+// given epoch. If no flag is set, voted is set to false.
+func RetrieveClusterQCVotedForEpoch(epochCounter uint64, voted *bool) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := retrieve(makePrefix(codeClusterQCVoted, epochCounter), voted)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			// flag not set - therefore not voted
+			*voted = false
+			return nil
+		} else if err != nil {
+			// storage error - set voted to zero value
+			*voted = false
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package operation
+
+import (
+	"errors"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// configOverride is the persisted runtime override of a configuration parameter.
+type configOverride struct {
+	Name  string
+	Value string
+}
+
+// UpsertConfigOverride stores the override of the configuration parameter with the given
+// name, replacing any previously stored override of the parameter.
+func UpsertConfigOverride(name string, value string) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		key := makePrefix(codeConfigOverride, name)
+		err := remove(key)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		return insert(key, configOverride{Name: name, Value: value})(tx)
+	}
+}
+
+// RemoveConfigOverride removes the override of the configuration parameter with the given name.
+// It returns storage.ErrNotFound if the parameter is not overridden.
+func RemoveConfigOverride(name string) func(*badger.Txn) error {
+	return remove(makePrefix(codeConfigOverride, name))
+}
+
+// RetrieveConfigOverrides retrieves the overrides of all configuration parameters, keyed by
+// parameter name.
+func RetrieveConfigOverrides(overrides map[string]string) func(*badger.Txn) error {
+	iteration := func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var override configOverride
+		create := func() interface{} {
+			return &override
+		}
+		handle := func() error {
+			overrides[override.Name] = override.Value
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeConfigOverride), iteration)
+}
@@ -61,3 +61,31 @@ func InsertDKGEndStateForEpoch(epochCounter uint64, endState flow.DKGEndState) f
 func RetrieveDKGEndStateForEpoch(epochCounter uint64, endState *flow.DKGEndState) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeDKGEnded, epochCounter), endState)
 }
+
+// InsertDKGCheckpoint stores the checkpoint of the given DKG instance.
+//
+// CAUTION: This method stores confidential information and should only be
+// used in the context of the secrets database. This is enforced in the above
+// layer (see storage.DKGCheckpoints).
+func InsertDKGCheckpoint(dkgInstanceID string, data []byte) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGCheckpoint, dkgInstanceID), data)
+}
+
+// UpdateDKGCheckpoint replaces the checkpoint of the given DKG instance.
+//
+// CAUTION: This method stores confidential information and should only be
+// used in the context of the secrets database. This is enforced in the above
+// layer (see storage.DKGCheckpoints).
+func UpdateDKGCheckpoint(dkgInstanceID string, data []byte) func(*badger.Txn) error {
+	return update(makePrefix(codeDKGCheckpoint, dkgInstanceID), data)
+}
+
+// RetrieveDKGCheckpoint retrieves the checkpoint of the given DKG instance.
+func RetrieveDKGCheckpoint(dkgInstanceID string, data *[]byte) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDKGCheckpoint, dkgInstanceID), data)
+}
+
+// RemoveDKGCheckpoint removes the checkpoint of the given DKG instance.
+func RemoveDKGCheckpoint(dkgInstanceID string) func(*badger.Txn) error {
+	return remove(makePrefix(codeDKGCheckpoint, dkgInstanceID))
+}
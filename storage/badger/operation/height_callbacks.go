@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+)
+
+// heightCallback is the persisted registration of a callback for a height.
+type heightCallback struct {
+	Height     uint64
+	CallbackID string
+}
+
+// InsertHeightCallback stores the registration of the callback with the given ID for the given height.
+func InsertHeightCallback(height uint64, callbackID string) func(*badger.Txn) error {
+	return insert(makePrefix(codeHeightCallback, height, callbackID), heightCallback{Height: height, CallbackID: callbackID})
+}
+
+// RemoveHeightCallback removes the registration of the callback with the given ID for the given height.
+// It returns storage.ErrNotFound if the callback is not registered for the height.
+func RemoveHeightCallback(height uint64, callbackID string) func(*badger.Txn) error {
+	return remove(makePrefix(codeHeightCallback, height, callbackID))
+}
+
+// RetrieveHeightCallbacks retrieves the IDs of all registered callbacks, keyed by height.
+func RetrieveHeightCallbacks(callbacks map[uint64][]string) func(*badger.Txn) error {
+	iteration := func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var callback heightCallback
+		create := func() interface{} {
+			return &callback
+		}
+		handle := func() error {
+			callbacks[callback.Height] = append(callbacks[callback.Height], callback.CallbackID)
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeHeightCallback), iteration)
+}
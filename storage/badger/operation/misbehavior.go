@@ -0,0 +1,101 @@
+package operation
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// misbehaviorReportTimestampOffset is the offset of the timestamp within the key of a misbehavior report.
+const misbehaviorReportTimestampOffset = 1 + flow.IdentifierLen
+
+func misbehaviorReportTimestamp(timestamp time.Time) uint64 {
+	if timestamp.UnixNano() < 0 {
+		return 0
+	}
+	return uint64(timestamp.UnixNano())
+}
+
+// InsertMisbehaviorReport inserts the misbehavior report. Reports are keyed by origin and
+// timestamp, which allows to efficiently look up the reports of an origin since a given time.
+func InsertMisbehaviorReport(report *flow.MisbehaviorReport) func(*badger.Txn) error {
+	return insert(makePrefix(codeMisbehaviorReport, report.OriginID, misbehaviorReportTimestamp(report.Timestamp), report.ID()), report)
+}
+
+// LookupMisbehaviorReportsByOrigin retrieves the reports for the given origin which were
+// reported at or after the given time, ordered by time.
+func LookupMisbehaviorReportsByOrigin(originID flow.Identifier, since time.Time, reports *[]*flow.MisbehaviorReport) func(*badger.Txn) error {
+	start := makePrefix(codeMisbehaviorReport, originID, misbehaviorReportTimestamp(since))
+	end := makePrefix(codeMisbehaviorReport, originID, uint64(math.MaxUint64))
+	return iterate(start, end, misbehaviorReportIterationFunc(since, reports))
+}
+
+// LookupMisbehaviorReports retrieves the reports of all origins which were reported at or
+// after the given time, ordered by origin and time.
+func LookupMisbehaviorReports(since time.Time, reports *[]*flow.MisbehaviorReport) func(*badger.Txn) error {
+	return traverse(makePrefix(codeMisbehaviorReport), misbehaviorReportIterationFunc(since, reports))
+}
+
+// RemoveMisbehaviorReportsBefore removes all reports which were reported before the given time.
+// The number of removed reports is written to the given counter.
+func RemoveMisbehaviorReportsBefore(cutoff time.Time, removed *uint) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		limit := misbehaviorReportTimestamp(cutoff)
+
+		var keys [][]byte
+		iteration := func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				if misbehaviorReportKeyTimestamp(key) < limit {
+					keys = append(keys, append([]byte{}, key...))
+				}
+				// we only need the keys, skip decoding the reports
+				return false
+			}
+			return check, nil, nil
+		}
+		err := traverse(makePrefix(codeMisbehaviorReport), iteration)(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		*removed = uint(len(keys))
+		return nil
+	}
+}
+
+func misbehaviorReportKeyTimestamp(key []byte) uint64 {
+	if len(key) < misbehaviorReportTimestampOffset+8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(key[misbehaviorReportTimestampOffset : misbehaviorReportTimestampOffset+8])
+}
+
+// misbehaviorReportIterationFunc returns an iteration function which collects all reports
+// reported at or after the given time.
+func misbehaviorReportIterationFunc(since time.Time, reports *[]*flow.MisbehaviorReport) func() (checkFunc, createFunc, handleFunc) {
+	limit := misbehaviorReportTimestamp(since)
+	return func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return misbehaviorReportKeyTimestamp(key) >= limit
+		}
+		var report flow.MisbehaviorReport
+		create := func() interface{} {
+			return &report
+		}
+		handle := func() error {
+			*reports = append(*reports, &report)
+			return nil
+		}
+		return check, create, handle
+	}
+}
@@ -69,12 +69,43 @@ const (
 	codeBeaconPrivateKey = 63 // BeaconPrivateKey, keyed by epoch counter
 	codeDKGStarted       = 64 // flag that the DKG for an epoch has been started
 	codeDKGEnded         = 65 // flag that the DKG for an epoch has ended (stores end state)
+	codeClusterQCVoted   = 66 // flag that this node has voted for the root cluster QC of an epoch
+	codeDKGCheckpoint    = 67 // checkpoint of a running DKG, keyed by DKG instance ID
 
 	// job queue consumers and producers
 	codeJobConsumerProcessed = 70
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes for misbehavior reports
+	codeMisbehaviorReport = 80 // misbehavior report, keyed by origin ID, timestamp and report ID
+
+	// codes for register audit records
+	codeRegisterAuditRecord = 81 // register audit record, keyed by owner, height and record ID
+
+	// codes for chunk assignments
+	codeChunkAssignment         = 82 // chunk assignment record, keyed by result ID and incorporating block ID
+	codeChunkAssignmentByHeight = 83 // index of chunk assignment records by height of the incorporating block
+
+	// codes for execution receipts by executor
+	codeExecutorBlockReceipts = 84 // index mapping block ID and executor ID to multiple receipts
+
+	// codes for node configuration
+	codeConfigOverride = 90 // runtime override of a configuration parameter, keyed by parameter name
+
+	// codes for height events
+	codeHeightCallback = 91 // registered height callback, keyed by height and callback ID
+
+	// codes for execution fork detection
+	codeResultConflict = 92 // conflict between execution results, keyed by block ID and both result IDs
+
+	// codes for verification records
+	codeVerificationRecord         = 93 // verification record of a chunk, keyed by result ID and chunk index
+	codeVerificationRecordByHeight = 94 // index of verification records by height of the block of the result
+
+	// codes for receipt requests
+	codeReceiptRequest = 95 // bookkeeping of the requests for the receipts of a block, keyed by block ID
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -120,6 +151,8 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
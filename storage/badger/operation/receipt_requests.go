@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"errors"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// UpsertReceiptRequest stores the receipt request, keyed by block ID, replacing any previously
+// stored request for the block.
+func UpsertReceiptRequest(request *flow.ReceiptRequest) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		key := makePrefix(codeReceiptRequest, request.BlockID)
+		err := remove(key)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		return insert(key, request)(tx)
+	}
+}
+
+// RemoveReceiptRequest removes the receipt request for the given block.
+// It returns storage.ErrNotFound if no request is stored for the block.
+func RemoveReceiptRequest(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeReceiptRequest, blockID))
+}
+
+// LookupReceiptRequests retrieves all stored receipt requests.
+func LookupReceiptRequests(requests *[]*flow.ReceiptRequest) func(*badger.Txn) error {
+	iteration := func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var request flow.ReceiptRequest
+		create := func() interface{} {
+			return &request
+		}
+		handle := func() error {
+			*requests = append(*requests, &request)
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeReceiptRequest), iteration)
+}
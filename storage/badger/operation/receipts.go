@@ -53,6 +53,23 @@ func LookupExecutionReceipts(blockID flow.Identifier, receiptIDs *[]flow.Identif
 	return traverse(makePrefix(codeAllBlockReceipts, blockID), iterationFunc)
 }
 
+// IndexExecutorExecutionReceipt inserts an execution receipt ID keyed by block ID, executor ID and receipt ID.
+// one executor could produce multiple receipts for the same block, if they commit to different results
+func IndexExecutorExecutionReceipt(blockID, executorID, receiptID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutorBlockReceipts, blockID, executorID, receiptID), receiptID)
+}
+
+// BatchIndexExecutorExecutionReceipt inserts an execution receipt ID keyed by block ID, executor ID and receipt ID into a batch
+func BatchIndexExecutorExecutionReceipt(blockID, executorID, receiptID flow.Identifier) func(batch *badger.WriteBatch) error {
+	return batchInsert(makePrefix(codeExecutorBlockReceipts, blockID, executorID, receiptID), receiptID)
+}
+
+// LookupExecutorExecutionReceipts finds all execution receipts by block ID and executor ID
+func LookupExecutorExecutionReceipts(blockID, executorID flow.Identifier, receiptIDs *[]flow.Identifier) func(*badger.Txn) error {
+	iterationFunc := receiptIterationFunc(receiptIDs)
+	return traverse(makePrefix(codeExecutorBlockReceipts, blockID, executorID), iterationFunc)
+}
+
 // receiptIterationFunc returns an in iteration function which returns all receipt IDs found during traversal
 func receiptIterationFunc(receiptIDs *[]flow.Identifier) func() (checkFunc, createFunc, handleFunc) {
 	check := func(key []byte) bool {
@@ -62,3 +62,25 @@ func TestReceipts_MultiIndex(t *testing.T) {
 		assert.ElementsMatch(t, expected, actual)
 	})
 }
+
+func TestReceipts_ExecutorIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blockID := unittest.IdentifierFixture()
+		executor1 := unittest.IdentifierFixture()
+		executor2 := unittest.IdentifierFixture()
+		expected := []flow.Identifier{unittest.IdentifierFixture(), unittest.IdentifierFixture()}
+
+		for _, id := range expected {
+			err := db.Update(IndexExecutorExecutionReceipt(blockID, executor1, id))
+			require.Nil(t, err)
+		}
+		err := db.Update(IndexExecutorExecutionReceipt(blockID, executor2, unittest.IdentifierFixture()))
+		require.Nil(t, err)
+
+		var actual []flow.Identifier
+		err = db.View(LookupExecutorExecutionReceipts(blockID, executor1, &actual))
+		require.Nil(t, err)
+
+		assert.ElementsMatch(t, expected, actual)
+	})
+}
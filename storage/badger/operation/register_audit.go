@@ -0,0 +1,77 @@
+package operation
+
+import (
+	"encoding/binary"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// registerAuditRecordHeightOffset is the offset of the height within the key of a register audit record.
+const registerAuditRecordHeightOffset = 1 + flow.AddressLength
+
+// InsertRegisterAuditRecord inserts the register audit record. Records are keyed by owner and
+// height, which allows to efficiently look up the records of an owner within a height range.
+func InsertRegisterAuditRecord(record *flow.RegisterAuditRecord) func(*badger.Txn) error {
+	return insert(makePrefix(codeRegisterAuditRecord, record.Owner(), record.Height, record.ID()), record)
+}
+
+// LookupRegisterAuditRecordsByOwner retrieves the records of registers owned by the given
+// account, which were accessed within the given height range (inclusive), ordered by height.
+func LookupRegisterAuditRecordsByOwner(owner flow.Address, fromHeight, toHeight uint64, records *[]*flow.RegisterAuditRecord) func(*badger.Txn) error {
+	start := makePrefix(codeRegisterAuditRecord, owner, fromHeight)
+	end := makePrefix(codeRegisterAuditRecord, owner, toHeight)
+	return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var record flow.RegisterAuditRecord
+		create := func() interface{} {
+			return &record
+		}
+		handle := func() error {
+			*records = append(*records, &record)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// RemoveRegisterAuditRecordsBelow removes the records of all owners which were accessed below
+// the given height. The number of removed records is written to the given counter.
+func RemoveRegisterAuditRecordsBelow(height uint64, removed *uint) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var keys [][]byte
+		iteration := func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				if registerAuditRecordKeyHeight(key) < height {
+					keys = append(keys, append([]byte{}, key...))
+				}
+				// we only need the keys, skip decoding the records
+				return false
+			}
+			return check, nil, nil
+		}
+		err := traverse(makePrefix(codeRegisterAuditRecord), iteration)(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		*removed = uint(len(keys))
+		return nil
+	}
+}
+
+func registerAuditRecordKeyHeight(key []byte) uint64 {
+	if len(key) < registerAuditRecordHeightOffset+8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(key[registerAuditRecordHeightOffset : registerAuditRecordHeightOffset+8])
+}
@@ -0,0 +1,43 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertExecutionResultConflict inserts the conflict between two execution results. Conflicts are
+// keyed by block and both result IDs, which allows to look up all conflicts for a block.
+func InsertExecutionResultConflict(conflict *flow.ExecutionResultConflict) func(*badger.Txn) error {
+	return insert(makePrefix(codeResultConflict, conflict.BlockID, conflict.ResultIDs[0], conflict.ResultIDs[1]), conflict)
+}
+
+// UpdateExecutionResultConflict replaces the stored conflict between the same two execution results.
+func UpdateExecutionResultConflict(conflict *flow.ExecutionResultConflict) func(*badger.Txn) error {
+	return update(makePrefix(codeResultConflict, conflict.BlockID, conflict.ResultIDs[0], conflict.ResultIDs[1]), conflict)
+}
+
+// RetrieveExecutionResultConflict retrieves the conflict between the two given execution results,
+// of which the IDs must be in ascending order.
+func RetrieveExecutionResultConflict(blockID flow.Identifier, resultID1 flow.Identifier, resultID2 flow.Identifier, conflict *flow.ExecutionResultConflict) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeResultConflict, blockID, resultID1, resultID2), conflict)
+}
+
+// LookupExecutionResultConflicts retrieves all conflicts between execution results for the given block.
+func LookupExecutionResultConflicts(blockID flow.Identifier, conflicts *[]*flow.ExecutionResultConflict) func(*badger.Txn) error {
+	iteration := func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var conflict flow.ExecutionResultConflict
+		create := func() interface{} {
+			return &conflict
+		}
+		handle := func() error {
+			*conflicts = append(*conflicts, &conflict)
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeResultConflict, blockID), iteration)
+}
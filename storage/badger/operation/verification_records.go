@@ -0,0 +1,90 @@
+package operation
+
+import (
+	"encoding/binary"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+)
+
+// verificationRecordByHeightResultOffset is the offset of the result ID within the key of the
+// height index of verification records.
+const verificationRecordByHeightResultOffset = 1 + 8
+
+// InsertVerificationRecord inserts the verification record, keyed by result ID and chunk index,
+// which allows to efficiently look up the verified chunks of a result.
+func InsertVerificationRecord(record *verification.VerificationRecord) func(*badger.Txn) error {
+	return insert(makePrefix(codeVerificationRecord, record.ResultID, record.ChunkIndex), record)
+}
+
+// IndexVerificationRecordByHeight indexes the verification record by the height of the block of
+// its result, which allows to efficiently prune the records below a height.
+func IndexVerificationRecordByHeight(record *verification.VerificationRecord) func(*badger.Txn) error {
+	return insert(makePrefix(codeVerificationRecordByHeight, record.Height, record.ResultID, record.ChunkIndex), true)
+}
+
+// LookupVerificationRecordsByResult retrieves the verification records of all verified chunks of
+// the given result, ordered by chunk index.
+func LookupVerificationRecordsByResult(resultID flow.Identifier, records *[]*verification.VerificationRecord) func(*badger.Txn) error {
+	return traverse(makePrefix(codeVerificationRecord, resultID), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var record verification.VerificationRecord
+		create := func() interface{} {
+			return &record
+		}
+		handle := func() error {
+			*records = append(*records, &record)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// RemoveVerificationRecordsBelow removes the verification records of all chunks of blocks below the
+// given height, together with their height index. The number of removed records is written to the
+// given counter.
+func RemoveVerificationRecordsBelow(height uint64, removed *uint) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		*removed = 0
+		if height == 0 {
+			return nil
+		}
+
+		var keys [][]byte
+		iteration := func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				keys = append(keys, append([]byte{}, key...))
+				// we only need the keys, skip decoding the values
+				return false
+			}
+			return check, nil, nil
+		}
+		start := makePrefix(codeVerificationRecordByHeight, uint64(0))
+		end := makePrefix(codeVerificationRecordByHeight, height-1)
+		err := iterate(start, end, iteration)(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			var resultID flow.Identifier
+			copy(resultID[:], key[verificationRecordByHeightResultOffset:])
+			chunkIndex := binary.BigEndian.Uint64(key[verificationRecordByHeightResultOffset+flow.IdentifierLen:])
+
+			err := tx.Delete(makePrefix(codeVerificationRecord, resultID, chunkIndex))
+			if err != nil {
+				return err
+			}
+			err = tx.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		*removed = uint(len(keys))
+		return nil
+	}
+}
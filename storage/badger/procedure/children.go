@@ -16,9 +16,9 @@ import (
 // - for looking up children blocks for a given block. This is useful for forks recovery
 //   where we want to find all the pending children blocks for the lastest finalized block.
 // - when adding parent-child index for a new block, we will add two indexes:
-//   1) since it's a new block, the new block should have no child, so adding an empty
-//      index for the new block. Note: It's impossible there is a block whose parent is the
-//      new block.
+//   1) since it's a new block, the new block usually has no child, so adding an empty
+//      index for the new block. Note: headers can be stored in any order, so if a child of
+//      the new block was indexed before, the existing index is kept.
 //   2) since the parent block has this new block as a child, adding an index for that.
 //      there are two special cases for 2):
 //      - if the parent block is zero, then we don't need to add this index.
@@ -27,10 +27,17 @@ import (
 func IndexNewBlock(blockID flow.Identifier, parentID flow.Identifier) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
 		// Step 1: index the child for the new block.
-		// the new block has no child, so adding an empty child index for it
-		err := operation.InsertBlockChildren(blockID, nil)(tx)
-		if err != nil {
-			return fmt.Errorf("could not insert empty block children: %w", err)
+		// the new block has no child, unless a child was indexed before, so adding
+		// an empty child index for it if there is none yet
+		var existingIDs []flow.Identifier
+		err := operation.RetrieveBlockChildren(blockID, &existingIDs)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = operation.InsertBlockChildren(blockID, nil)(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert empty block children: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("could not look up block children: %w", err)
 		}
 
 		// Step 2: adding the second index for the parent block
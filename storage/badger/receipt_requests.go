@@ -0,0 +1,48 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ReceiptRequests implements persistent storage for the bookkeeping of receipt requests.
+type ReceiptRequests struct {
+	db *badger.DB
+}
+
+func NewReceiptRequests(db *badger.DB) *ReceiptRequests {
+	return &ReceiptRequests{
+		db: db,
+	}
+}
+
+func (r *ReceiptRequests) Store(request *flow.ReceiptRequest) error {
+	err := operation.RetryOnConflict(r.db.Update, operation.UpsertReceiptRequest(request))
+	if err != nil {
+		return fmt.Errorf("could not store receipt request of block %x: %w", request.BlockID, err)
+	}
+	return nil
+}
+
+func (r *ReceiptRequests) All() ([]*flow.ReceiptRequest, error) {
+	var requests []*flow.ReceiptRequest
+	err := r.db.View(operation.LookupReceiptRequests(&requests))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up receipt requests: %w", err)
+	}
+	return requests, nil
+}
+
+func (r *ReceiptRequests) Remove(blockID flow.Identifier) error {
+	err := operation.RetryOnConflict(r.db.Update, operation.RemoveReceiptRequest(blockID))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not remove receipt request of block %x: %w", blockID, err)
+	}
+	return nil
+}
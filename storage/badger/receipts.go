@@ -22,7 +22,7 @@ type ExecutionReceipts struct {
 }
 
 // NewExecutionReceipts Creates ExecutionReceipts instance which is a database of receipts which
-// supports storing and indexing receipts by receipt ID and block ID.
+// supports storing and indexing receipts by receipt ID, block ID and executor ID.
 func NewExecutionReceipts(collector module.CacheMetrics, db *badger.DB, results *ExecutionResults, cacheSize uint) *ExecutionReceipts {
 	store := func(key interface{}, val interface{}) func(*transaction.Tx) error {
 		receipt := val.(*flow.ExecutionReceipt)
@@ -36,6 +36,10 @@ func NewExecutionReceipts(collector module.CacheMetrics, db *badger.DB, results
 		indexReceiptOps := transaction.WithTx(operation.SkipDuplicates(
 			operation.IndexExecutionReceipts(receipt.ExecutionResult.BlockID, receiptID),
 		))
+		// assemble DB operations to index receipt by the block it computes and its executor (no execution)
+		indexExecutorReceiptOps := transaction.WithTx(operation.SkipDuplicates(
+			operation.IndexExecutorExecutionReceipt(receipt.ExecutionResult.BlockID, receipt.ExecutorID, receiptID),
+		))
 
 		return func(tx *transaction.Tx) error {
 			err := storeResultOps(tx) // execute operations to store results
@@ -50,6 +54,10 @@ func NewExecutionReceipts(collector module.CacheMetrics, db *badger.DB, results
 			if err != nil {
 				return fmt.Errorf("could not index receipt by the block it computes: %w", err)
 			}
+			err = indexExecutorReceiptOps(tx)
+			if err != nil {
+				return fmt.Errorf("could not index receipt by the block it computes and its executor: %w", err)
+			}
 			return nil
 		}
 	}
@@ -116,6 +124,26 @@ func (r *ExecutionReceipts) byBlockID(blockID flow.Identifier) func(*badger.Txn)
 	}
 }
 
+func (r *ExecutionReceipts) byBlockIDAndExecutor(blockID, executorID flow.Identifier) func(*badger.Txn) ([]*flow.ExecutionReceipt, error) {
+	return func(tx *badger.Txn) ([]*flow.ExecutionReceipt, error) {
+		var receiptIDs []flow.Identifier
+		err := operation.LookupExecutorExecutionReceipts(blockID, executorID, &receiptIDs)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("could not find receipt index for block and executor: %w", err)
+		}
+
+		var receipts []*flow.ExecutionReceipt
+		for _, id := range receiptIDs {
+			receipt, err := r.byID(id)(tx)
+			if err != nil {
+				return nil, fmt.Errorf("could not find receipt with id %v: %w", id, err)
+			}
+			receipts = append(receipts, receipt)
+		}
+		return receipts, nil
+	}
+}
+
 func (r *ExecutionReceipts) Store(receipt *flow.ExecutionReceipt) error {
 	return operation.RetryOnConflictTx(r.db, transaction.Update, r.storeTx(receipt))
 }
@@ -138,6 +166,11 @@ func (r *ExecutionReceipts) BatchStore(receipt *flow.ExecutionReceipt, batch sto
 		return fmt.Errorf("cannot batch index execution receipt inside execution receipt batch store: %w", err)
 	}
 
+	err = operation.BatchIndexExecutorExecutionReceipt(receipt.ExecutionResult.BlockID, receipt.ExecutorID, receipt.ID())(writeBatch)
+	if err != nil {
+		return fmt.Errorf("cannot batch index execution receipt by executor inside execution receipt batch store: %w", err)
+	}
+
 	return nil
 }
 
@@ -152,3 +185,9 @@ func (r *ExecutionReceipts) ByBlockID(blockID flow.Identifier) (flow.ExecutionRe
 	defer tx.Discard()
 	return r.byBlockID(blockID)(tx)
 }
+
+func (r *ExecutionReceipts) ByBlockIDAndExecutor(blockID, executorID flow.Identifier) (flow.ExecutionReceiptList, error) {
+	tx := r.db.NewTransaction(false)
+	defer tx.Discard()
+	return r.byBlockIDAndExecutor(blockID, executorID)(tx)
+}
@@ -143,4 +143,62 @@ func TestExecutionReceiptsStorage(t *testing.T) {
 			require.ElementsMatch(t, []*flow.ExecutionReceipt{receipt1, receipt2}, receipts)
 		})
 	})
+
+	t.Run("index by block and executor", func(t *testing.T) {
+		withStore(t, func(store *bstorage.ExecutionReceipts) {
+			block1 := unittest.BlockFixture()
+			block2 := unittest.BlockFixture()
+
+			executor1 := unittest.IdentifierFixture()
+			executor2 := unittest.IdentifierFixture()
+
+			// executor1 commits to two different results for block1
+			receipt1 := unittest.ReceiptForBlockExecutorFixture(&block1, executor1)
+			receipt2 := unittest.ReceiptForBlockExecutorFixture(&block1, executor1)
+			receipt3 := unittest.ReceiptForBlockExecutorFixture(&block1, executor2)
+			receipt4 := unittest.ReceiptForBlockExecutorFixture(&block2, executor1)
+			for _, receipt := range []*flow.ExecutionReceipt{receipt1, receipt2, receipt3, receipt4} {
+				err := store.Store(receipt)
+				require.NoError(t, err)
+			}
+
+			receipts, err := store.ByBlockIDAndExecutor(block1.ID(), executor1)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []*flow.ExecutionReceipt{receipt1, receipt2}, receipts)
+
+			receipts, err = store.ByBlockIDAndExecutor(block1.ID(), executor2)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []*flow.ExecutionReceipt{receipt3}, receipts)
+
+			receipts, err = store.ByBlockIDAndExecutor(block2.ID(), executor1)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []*flow.ExecutionReceipt{receipt4}, receipts)
+
+			receipts, err = store.ByBlockIDAndExecutor(block2.ID(), executor2)
+			require.NoError(t, err)
+			require.Empty(t, receipts)
+		})
+	})
+
+	t.Run("batch store indexes by block and executor", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := metrics.NewNoopCollector()
+			results := bstorage.NewExecutionResults(metrics, db)
+			store := bstorage.NewExecutionReceipts(metrics, db, results, bstorage.DefaultCacheSize)
+
+			block := unittest.BlockFixture()
+			executor := unittest.IdentifierFixture()
+			receipt := unittest.ReceiptForBlockExecutorFixture(&block, executor)
+
+			batch := bstorage.NewBatch(db)
+			err := store.BatchStore(receipt, batch)
+			require.NoError(t, err)
+			err = batch.Flush()
+			require.NoError(t, err)
+
+			receipts, err := store.ByBlockIDAndExecutor(block.ID(), executor)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []*flow.ExecutionReceipt{receipt}, receipts)
+		})
+	})
 }
@@ -0,0 +1,58 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// RegisterAuditRecords implements append-only persistent storage for register audit records.
+type RegisterAuditRecords struct {
+	db *badger.DB
+}
+
+func NewRegisterAuditRecords(db *badger.DB) *RegisterAuditRecords {
+	return &RegisterAuditRecords{
+		db: db,
+	}
+}
+
+func (r *RegisterAuditRecords) Store(records []*flow.RegisterAuditRecord) error {
+	err := operation.RetryOnConflict(r.db.Update, func(tx *badger.Txn) error {
+		for _, record := range records {
+			err := operation.SkipDuplicates(operation.InsertRegisterAuditRecord(record))(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert register audit record %x: %w", record.ID(), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not store register audit records: %w", err)
+	}
+	return nil
+}
+
+func (r *RegisterAuditRecords) ByOwner(owner flow.Address, fromHeight, toHeight uint64) ([]*flow.RegisterAuditRecord, error) {
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid height range [%d, %d]", fromHeight, toHeight)
+	}
+	var records []*flow.RegisterAuditRecord
+	err := r.db.View(operation.LookupRegisterAuditRecordsByOwner(owner, fromHeight, toHeight, &records))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up register audit records of owner %s: %w", owner, err)
+	}
+	return records, nil
+}
+
+func (r *RegisterAuditRecords) PruneBelow(height uint64) (uint, error) {
+	var removed uint
+	err := operation.RetryOnConflict(r.db.Update, operation.RemoveRegisterAuditRecordsBelow(height, &removed))
+	if err != nil {
+		return 0, fmt.Errorf("could not prune register audit records: %w", err)
+	}
+	return removed, nil
+}
@@ -16,8 +16,9 @@ import (
 
 // ExecutionResults implements persistent storage for execution results.
 type ExecutionResults struct {
-	db    *badger.DB
-	cache *Cache
+	db         *badger.DB
+	cache      *Cache
+	indexCache *Cache // block ID -> ID of the indexed execution result
 }
 
 func NewExecutionResults(collector module.CacheMetrics, db *badger.DB) *ExecutionResults {
@@ -36,12 +37,24 @@ func NewExecutionResults(collector module.CacheMetrics, db *badger.DB) *Executio
 		}
 	}
 
+	retrieveIndex := func(key interface{}) func(tx *badger.Txn) (interface{}, error) {
+		blockID := key.(flow.Identifier)
+		var resultID flow.Identifier
+		return func(tx *badger.Txn) (interface{}, error) {
+			err := operation.LookupExecutionResult(blockID, &resultID)(tx)
+			return resultID, err
+		}
+	}
+
 	res := &ExecutionResults{
 		db: db,
 		cache: newCache(collector, metrics.ResourceResult,
 			withLimit(flow.DefaultTransactionExpiry+100),
 			withStore(store),
 			withRetrieve(retrieve)),
+		indexCache: newCache(collector, metrics.ResourceResultByBlock,
+			withLimit(flow.DefaultTransactionExpiry+100),
+			withRetrieve(retrieveIndex)),
 	}
 
 	return res
@@ -63,12 +76,11 @@ func (r *ExecutionResults) byID(resultID flow.Identifier) func(*badger.Txn) (*fl
 
 func (r *ExecutionResults) byBlockID(blockID flow.Identifier) func(*badger.Txn) (*flow.ExecutionResult, error) {
 	return func(tx *badger.Txn) (*flow.ExecutionResult, error) {
-		var resultID flow.Identifier
-		err := operation.LookupExecutionResult(blockID, &resultID)(tx)
+		resultID, err := r.indexCache.Get(blockID)(tx)
 		if err != nil {
 			return nil, fmt.Errorf("could not lookup execution result ID: %w", err)
 		}
-		return r.byID(resultID)(tx)
+		return r.byID(resultID.(flow.Identifier))(tx)
 	}
 }
 
@@ -76,6 +88,9 @@ func (r *ExecutionResults) index(blockID, resultID flow.Identifier, force bool)
 	return func(tx *transaction.Tx) error {
 		err := transaction.WithTx(operation.IndexExecutionResult(blockID, resultID))(tx)
 		if err == nil {
+			tx.OnSucceed(func() {
+				r.indexCache.Insert(blockID, resultID)
+			})
 			return nil
 		}
 
@@ -84,7 +99,14 @@ func (r *ExecutionResults) index(blockID, resultID flow.Identifier, force bool)
 		}
 
 		if force {
-			return transaction.WithTx(operation.ReindexExecutionResult(blockID, resultID))(tx)
+			err = transaction.WithTx(operation.ReindexExecutionResult(blockID, resultID))(tx)
+			if err != nil {
+				return err
+			}
+			tx.OnSucceed(func() {
+				r.indexCache.Insert(blockID, resultID)
+			})
+			return nil
 		}
 
 		// when trying to index a result for a block, and there is already a result indexed for this block,
@@ -115,7 +137,14 @@ func (r *ExecutionResults) BatchStore(result *flow.ExecutionResult, batch storag
 
 func (r *ExecutionResults) BatchIndex(blockID flow.Identifier, resultID flow.Identifier, batch storage.BatchStorage) error {
 	writeBatch := batch.GetWriter()
-	return operation.BatchIndexExecutionResult(blockID, resultID)(writeBatch)
+	err := operation.BatchIndexExecutionResult(blockID, resultID)(writeBatch)
+	if err != nil {
+		return err
+	}
+	batch.OnSucceed(func() {
+		r.indexCache.Insert(blockID, resultID)
+	})
+	return nil
 }
 
 func (r *ExecutionResults) ByID(resultID flow.Identifier) (*flow.ExecutionResult, error) {
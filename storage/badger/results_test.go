@@ -107,3 +107,30 @@ func TestResultStoreForceIndexOverridesMapping(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestResultBatchIndexOverridesCachedMapping(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		store := bstorage.NewExecutionResults(metrics, db)
+
+		result1 := unittest.ExecutionResultFixture()
+		result2 := unittest.ExecutionResultFixture()
+		blockID := unittest.IdentifierFixture()
+		require.NoError(t, store.Store(result1))
+		require.NoError(t, store.Store(result2))
+		require.NoError(t, store.Index(blockID, result1.ID()))
+
+		// cache the index of the first result
+		byBlockID, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		require.Equal(t, result1, byBlockID)
+
+		batch := bstorage.NewBatch(db)
+		require.NoError(t, store.BatchIndex(blockID, result2.ID(), batch))
+		require.NoError(t, batch.Flush())
+
+		byBlockID, err = store.ByBlockID(blockID)
+		require.NoError(t, err)
+		require.Equal(t, result2, byBlockID)
+	})
+}
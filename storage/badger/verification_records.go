@@ -0,0 +1,58 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// VerificationRecords implements persistent storage for the records of verified chunks.
+type VerificationRecords struct {
+	db *badger.DB
+}
+
+func NewVerificationRecords(db *badger.DB) *VerificationRecords {
+	return &VerificationRecords{
+		db: db,
+	}
+}
+
+func (v *VerificationRecords) Store(record *verification.VerificationRecord) error {
+	err := operation.RetryOnConflict(v.db.Update, func(tx *badger.Txn) error {
+		err := operation.SkipDuplicates(operation.InsertVerificationRecord(record))(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert verification record: %w", err)
+		}
+		err = operation.SkipDuplicates(operation.IndexVerificationRecordByHeight(record))(tx)
+		if err != nil {
+			return fmt.Errorf("could not index verification record by height: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not store verification record of chunk %d of result %x: %w", record.ChunkIndex, record.ResultID, err)
+	}
+	return nil
+}
+
+func (v *VerificationRecords) ByResultID(resultID flow.Identifier) ([]*verification.VerificationRecord, error) {
+	var records []*verification.VerificationRecord
+	err := v.db.View(operation.LookupVerificationRecordsByResult(resultID, &records))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up verification records of result %x: %w", resultID, err)
+	}
+	return records, nil
+}
+
+func (v *VerificationRecords) PruneBelow(height uint64) (uint, error) {
+	var removed uint
+	err := operation.RetryOnConflict(v.db.Update, operation.RemoveVerificationRecordsBelow(height, &removed))
+	if err != nil {
+		return 0, fmt.Errorf("could not prune verification records: %w", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,107 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// verificationRecordFixture returns the record of verifying the chunk with the given index of a
+// result of a block at the given height.
+func verificationRecordFixture(resultID flow.Identifier, chunkIndex uint64, height uint64) *verification.VerificationRecord {
+	return &verification.VerificationRecord{
+		ChunkID:          unittest.IdentifierFixture(),
+		ResultID:         resultID,
+		ChunkIndex:       chunkIndex,
+		BlockID:          unittest.IdentifierFixture(),
+		Height:           height,
+		ApprovalID:       unittest.IdentifierFixture(),
+		StartState:       unittest.StateCommitmentFixture(),
+		EndState:         unittest.StateCommitmentFixture(),
+		TransactionCount: 3,
+		Duration:         150 * time.Millisecond,
+		Spock:            unittest.SignatureFixture(),
+	}
+}
+
+func TestVerificationRecordsStorage(t *testing.T) {
+	withStore := func(t *testing.T, f func(store *bstorage.VerificationRecords)) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			f(bstorage.NewVerificationRecords(db))
+		})
+	}
+
+	t.Run("get empty", func(t *testing.T) {
+		withStore(t, func(store *bstorage.VerificationRecords) {
+			records, err := store.ByResultID(unittest.IdentifierFixture())
+			require.NoError(t, err)
+			require.Empty(t, records)
+		})
+	})
+
+	t.Run("store and get", func(t *testing.T) {
+		withStore(t, func(store *bstorage.VerificationRecords) {
+			resultID := unittest.IdentifierFixture()
+			second := verificationRecordFixture(resultID, 2, 10)
+			first := verificationRecordFixture(resultID, 0, 10)
+			other := verificationRecordFixture(unittest.IdentifierFixture(), 0, 10)
+			for _, record := range []*verification.VerificationRecord{second, first, other} {
+				require.NoError(t, store.Store(record))
+			}
+
+			// records are ordered by chunk index
+			records, err := store.ByResultID(resultID)
+			require.NoError(t, err)
+			assert.Equal(t, []*verification.VerificationRecord{first, second}, records)
+		})
+	})
+
+	t.Run("store twice", func(t *testing.T) {
+		withStore(t, func(store *bstorage.VerificationRecords) {
+			record := verificationRecordFixture(unittest.IdentifierFixture(), 1, 10)
+			require.NoError(t, store.Store(record))
+			require.NoError(t, store.Store(record))
+
+			records, err := store.ByResultID(record.ResultID)
+			require.NoError(t, err)
+			require.Equal(t, []*verification.VerificationRecord{record}, records)
+		})
+	})
+
+	t.Run("prune below height", func(t *testing.T) {
+		withStore(t, func(store *bstorage.VerificationRecords) {
+			resultID := unittest.IdentifierFixture()
+			pruned := verificationRecordFixture(resultID, 0, 9)
+			kept := verificationRecordFixture(resultID, 1, 10)
+			other := verificationRecordFixture(unittest.IdentifierFixture(), 0, 5)
+			for _, record := range []*verification.VerificationRecord{pruned, kept, other} {
+				require.NoError(t, store.Store(record))
+			}
+
+			removed, err := store.PruneBelow(10)
+			require.NoError(t, err)
+			require.Equal(t, uint(2), removed)
+
+			records, err := store.ByResultID(resultID)
+			require.NoError(t, err)
+			require.Equal(t, []*verification.VerificationRecord{kept}, records)
+			records, err = store.ByResultID(other.ResultID)
+			require.NoError(t, err)
+			require.Empty(t, records)
+
+			// pruning again removes nothing, and a pruned record can be stored again
+			removed, err = store.PruneBelow(10)
+			require.NoError(t, err)
+			require.Equal(t, uint(0), removed)
+			require.NoError(t, store.Store(pruned))
+		})
+	})
+}
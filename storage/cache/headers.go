@@ -0,0 +1,231 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultHeadersCapacity is the default number of headers kept in memory by the header cache.
+const DefaultHeadersCapacity = 1000
+
+// HeadersOption configures optional behaviour of the header cache.
+type HeadersOption func(*Headers)
+
+// WithHeadersCapacity sets the number of recently accessed headers kept in memory. It also
+// bounds the number of finalized heights, which are indexed in memory.
+func WithHeadersCapacity(capacity uint) HeadersOption {
+	return func(h *Headers) {
+		h.capacity = capacity
+	}
+}
+
+// Headers is a storage.Headers decorator, which keeps recently accessed headers in memory, so
+// that components walking the chain backwards, such as the matching engine and the block
+// builder, don't pay a database read for every hop.
+//
+// Headers are kept in an LRU cache by their ID. Additionally, the IDs of finalized blocks are
+// indexed by height within the window [sealed, finalized], which advances with finalization.
+// As finalized blocks are immutable, cached entries never become stale. All reads, which are
+// not served by the cache, fall through to the underlying storage.
+// Headers is safe for concurrent use.
+type Headers struct {
+	storage.Headers
+	state    protocol.State
+	capacity uint
+	byID     *lru.Cache
+
+	mu              sync.RWMutex
+	byHeight        map[uint64]flow.Identifier // finalized block IDs within the window
+	lowestHeight    uint64                     // lowest height of the window
+	finalizedHeight uint64                     // highest height of the window
+}
+
+// NewHeaders creates a header cache on top of the given header storage. The window of indexed
+// heights is initialized from the latest sealed and finalized blocks of the given state. If the
+// cache is used while blocks are finalized, OnFinalizedBlock must be subscribed to finalization
+// events, so that the window advances.
+func NewHeaders(headers storage.Headers, state protocol.State, opts ...HeadersOption) (*Headers, error) {
+	h := &Headers{
+		Headers:  headers,
+		state:    state,
+		capacity: DefaultHeadersCapacity,
+		byHeight: make(map[uint64]flow.Identifier),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.capacity == 0 {
+		return nil, fmt.Errorf("capacity for header cache must be positive")
+	}
+
+	byID, err := lru.New(int(h.capacity))
+	if err != nil {
+		return nil, fmt.Errorf("could not create header cache: %w", err)
+	}
+	h.byID = byID
+
+	err = h.advance()
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize header cache window: %w", err)
+	}
+	return h, nil
+}
+
+// Store stores the header in the underlying storage and caches it.
+func (h *Headers) Store(header *flow.Header) error {
+	err := h.Headers.Store(header)
+	if err != nil {
+		return err
+	}
+	h.byID.Add(header.ID(), header)
+	return nil
+}
+
+// ByBlockID returns the header with the given ID, from the cache if possible.
+func (h *Headers) ByBlockID(blockID flow.Identifier) (*flow.Header, error) {
+	cached, ok := h.byID.Get(blockID)
+	if ok {
+		return cached.(*flow.Header), nil
+	}
+	header, err := h.Headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	h.byID.Add(blockID, header)
+	return header, nil
+}
+
+// ByHeight returns the finalized header at the given height. Finalized heights within the window
+// are served from the cache, all other heights are retrieved from the underlying storage.
+func (h *Headers) ByHeight(height uint64) (*flow.Header, error) {
+	h.mu.RLock()
+	blockID, ok := h.byHeight[height]
+	h.mu.RUnlock()
+	if ok {
+		return h.ByBlockID(blockID)
+	}
+
+	header, err := h.Headers.ByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blockID = header.ID()
+	h.byID.Add(blockID, header)
+
+	h.mu.Lock()
+	if height >= h.lowestHeight && height <= h.finalizedHeight {
+		h.byHeight[height] = blockID
+	}
+	h.mu.Unlock()
+	return header, nil
+}
+
+// AncestorAtHeight returns the ancestor of the given block at the given height. If the height
+// equals the height of the block, the block itself is returned. The walk follows parent links,
+// until it reaches a block, which is known to be finalized. From there, the ancestor is a
+// finalized block as well and is looked up by height.
+func (h *Headers) AncestorAtHeight(descendantID flow.Identifier, height uint64) (*flow.Header, error) {
+	header, err := h.ByBlockID(descendantID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve descendant %x: %w", descendantID, err)
+	}
+	if height > header.Height {
+		return nil, fmt.Errorf("height %d is above height %d of descendant %x", height, header.Height, descendantID)
+	}
+
+	for header.Height > height {
+		if h.isFinalized(header) {
+			ancestor, err := h.ByHeight(height)
+			if err != nil {
+				return nil, fmt.Errorf("could not retrieve finalized ancestor at height %d: %w", height, err)
+			}
+			return ancestor, nil
+		}
+		parentID := header.ParentID
+		header, err = h.ByBlockID(parentID)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve ancestor %x: %w", parentID, err)
+		}
+	}
+	return header, nil
+}
+
+// OnFinalizedBlock advances the window of indexed heights to the latest sealed and finalized
+// blocks, and evicts the heights below the window.
+// It is meant to be subscribed to the finalization distributor.
+func (h *Headers) OnFinalizedBlock(flow.Identifier) {
+	// errors are not fatal here: the cache falls through to storage for heights beyond the
+	// window, and the window is advanced again with the next finalized block
+	_ = h.advance()
+}
+
+// isFinalized returns whether the header is known to be finalized, i.e. whether it is indexed
+// by its height.
+func (h *Headers) isFinalized(header *flow.Header) bool {
+	h.mu.RLock()
+	blockID, ok := h.byHeight[header.Height]
+	h.mu.RUnlock()
+	return ok && blockID == header.ID()
+}
+
+// advance moves the window of indexed heights to the latest sealed and finalized blocks. The
+// blocks, which were finalized since the last advance, are indexed by walking back from the
+// latest finalized block, for at most the capacity of the cache.
+func (h *Headers) advance() error {
+	final, err := h.state.Final().Head()
+	if err != nil {
+		return fmt.Errorf("could not retrieve finalized header: %w", err)
+	}
+	sealed, err := h.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not retrieve sealed header: %w", err)
+	}
+
+	lowest := sealed.Height
+	if final.Height >= uint64(h.capacity) && final.Height-uint64(h.capacity)+1 > lowest {
+		lowest = final.Height - uint64(h.capacity) + 1
+	}
+
+	h.mu.RLock()
+	previous := h.finalizedHeight
+	h.mu.RUnlock()
+	if previous < lowest {
+		previous = lowest
+	}
+
+	// collect the blocks finalized since the last advance, walking back from the latest one
+	finalized := make(map[uint64]flow.Identifier)
+	header := final
+	h.byID.Add(header.ID(), header)
+	for {
+		finalized[header.Height] = header.ID()
+		if header.Height <= previous {
+			break
+		}
+		parentID := header.ParentID
+		header, err = h.ByBlockID(parentID)
+		if err != nil {
+			return fmt.Errorf("could not retrieve finalized ancestor %x: %w", parentID, err)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for height := range h.byHeight {
+		if height < lowest {
+			delete(h.byHeight, height)
+		}
+	}
+	for height, blockID := range finalized {
+		h.byHeight[height] = blockID
+	}
+	h.lowestHeight = lowest
+	h.finalizedHeight = final.Height
+	return nil
+}
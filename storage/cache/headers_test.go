@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestHeaders(t *testing.T) {
+	suite.Run(t, new(HeadersSuite))
+}
+
+// HeadersSuite tests the header cache on top of a mocked header storage, holding a finalized
+// chain of 20 blocks and an unfinalized fork, which branches off at height 10:
+//
+//	[0] <- ... <- [10] <- [11] <- ... <- [19]   (finalized)
+//	                 ^--- [11'] <- ... <- [15']  (fork)
+type HeadersSuite struct {
+	suite.Suite
+
+	byID     map[flow.Identifier]*flow.Header
+	byHeight map[uint64]*flow.Header
+	chain    []*flow.Header
+	fork     []*flow.Header
+	headers  *mockstorage.Headers
+
+	final  *flow.Header
+	sealed *flow.Header
+	state  *protocol.State
+}
+
+func (s *HeadersSuite) SetupTest() {
+	s.byID = make(map[flow.Identifier]*flow.Header)
+	s.byHeight = make(map[uint64]*flow.Header)
+	s.headers = new(mockstorage.Headers)
+	s.headers.On("ByBlockID", mock.Anything).Return(
+		func(blockID flow.Identifier) *flow.Header {
+			return s.byID[blockID]
+		},
+		func(blockID flow.Identifier) error {
+			_, ok := s.byID[blockID]
+			if !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		})
+	s.headers.On("ByHeight", mock.Anything).Return(
+		func(height uint64) *flow.Header {
+			return s.byHeight[height]
+		},
+		func(height uint64) error {
+			_, ok := s.byHeight[height]
+			if !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		})
+
+	genesis := unittest.BlockHeaderFixture()
+	genesis.Height = 0
+	s.chain = []*flow.Header{&genesis}
+	for i := 1; i < 20; i++ {
+		child := unittest.BlockHeaderWithParentFixture(s.chain[i-1])
+		s.chain = append(s.chain, &child)
+	}
+	for _, header := range s.chain {
+		s.byID[header.ID()] = header
+		s.byHeight[header.Height] = header
+	}
+	s.fork = nil
+	parent := s.chain[10]
+	for i := 0; i < 5; i++ {
+		child := unittest.BlockHeaderWithParentFixture(parent)
+		s.byID[child.ID()] = &child
+		s.fork = append(s.fork, &child)
+		parent = &child
+	}
+
+	s.final = s.chain[19]
+	s.sealed = s.chain[15]
+	final := new(protocol.Snapshot)
+	final.On("Head").Return(func() *flow.Header { return s.final }, nil)
+	sealed := new(protocol.Snapshot)
+	sealed.On("Head").Return(func() *flow.Header { return s.sealed }, nil)
+	s.state = new(protocol.State)
+	s.state.On("Final").Return(final)
+	s.state.On("Sealed").Return(sealed)
+}
+
+// TestHitAndMiss tests that the cache returns the same headers as the storage, and only reads
+// headers from the storage, which are not cached yet.
+func (s *HeadersSuite) TestHitAndMiss() {
+	cache, err := NewHeaders(s.headers, s.state)
+	require.NoError(s.T(), err)
+
+	// the window [sealed, finalized] is indexed on initialization
+	s.headers.AssertNumberOfCalls(s.T(), "ByBlockID", 4)
+	for height := s.sealed.Height; height <= s.final.Height; height++ {
+		header, err := cache.ByHeight(height)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), s.chain[height], header)
+	}
+	s.headers.AssertNumberOfCalls(s.T(), "ByBlockID", 4)
+	s.headers.AssertNumberOfCalls(s.T(), "ByHeight", 0)
+
+	// heights below the window fall through to the storage, while their headers are cached
+	header, err := cache.ByHeight(3)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), s.chain[3], header)
+	s.headers.AssertNumberOfCalls(s.T(), "ByHeight", 1)
+	header, err = cache.ByBlockID(s.chain[3].ID())
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), s.chain[3], header)
+	s.headers.AssertNumberOfCalls(s.T(), "ByBlockID", 4)
+
+	// unfinalized blocks are read from the storage once
+	for i := 0; i < 2; i++ {
+		header, err = cache.ByBlockID(s.fork[0].ID())
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), s.fork[0], header)
+	}
+	s.headers.AssertNumberOfCalls(s.T(), "ByBlockID", 5)
+
+	// unknown blocks and heights are reported as not found
+	_, err = cache.ByBlockID(unittest.IdentifierFixture())
+	require.ErrorIs(s.T(), err, storage.ErrNotFound)
+	_, err = cache.ByHeight(s.final.Height + 1)
+	require.ErrorIs(s.T(), err, storage.ErrNotFound)
+}
+
+// TestWindowEviction tests that the window of indexed heights advances with finalization, and
+// that heights below the latest sealed block are evicted.
+func (s *HeadersSuite) TestWindowEviction() {
+	s.final = s.chain[12]
+	s.sealed = s.chain[8]
+	cache, err := NewHeaders(s.headers, s.state)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), cache.byHeight, 5)
+
+	// finalizing and sealing more blocks moves the window
+	s.final = s.chain[19]
+	s.sealed = s.chain[14]
+	cache.OnFinalizedBlock(flow.ZeroID)
+	require.Len(s.T(), cache.byHeight, 6)
+	for height := uint64(0); height < 20; height++ {
+		_, ok := cache.byHeight[height]
+		require.Equal(s.T(), height >= 14, ok, "unexpected index state for height %d", height)
+	}
+
+	// the window is bounded by the capacity, if sealing falls behind
+	cache, err = NewHeaders(s.headers, s.state, WithHeadersCapacity(3))
+	require.NoError(s.T(), err)
+	require.Len(s.T(), cache.byHeight, 3)
+	_, err = cache.ByHeight(14)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), cache.byHeight, 3)
+}
+
+// TestAncestorAtHeight tests that the ancestor walk returns the same ancestors as walking the
+// parents in the storage, for finalized blocks and blocks on the fork.
+func (s *HeadersSuite) TestAncestorAtHeight() {
+	cache, err := NewHeaders(s.headers, s.state, WithHeadersCapacity(8))
+	require.NoError(s.T(), err)
+
+	uncached := func(descendant *flow.Header, height uint64) *flow.Header {
+		header := descendant
+		for header.Height > height {
+			header = s.byID[header.ParentID]
+		}
+		return header
+	}
+
+	descendants := append([]*flow.Header{}, s.chain...)
+	descendants = append(descendants, s.fork...)
+	for _, descendant := range descendants {
+		for height := uint64(0); height <= descendant.Height; height++ {
+			ancestor, err := cache.AncestorAtHeight(descendant.ID(), height)
+			require.NoError(s.T(), err)
+			require.Equal(s.T(), uncached(descendant, height), ancestor, "unexpected ancestor of block at height %d at height %d", descendant.Height, height)
+		}
+	}
+
+	// heights above the descendant and unknown descendants are rejected
+	_, err = cache.AncestorAtHeight(s.fork[0].ID(), s.fork[0].Height+1)
+	require.Error(s.T(), err)
+	_, err = cache.AncestorAtHeight(unittest.IdentifierFixture(), 0)
+	require.ErrorIs(s.T(), err, storage.ErrNotFound)
+}
+
+// TestInvalidCapacity tests that a header cache without capacity is rejected.
+func (s *HeadersSuite) TestInvalidCapacity() {
+	_, err := NewHeaders(s.headers, s.state, WithHeadersCapacity(0))
+	require.Error(s.T(), err)
+}
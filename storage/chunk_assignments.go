@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ChunkAssignments represents persistent storage for the chunk assignments of execution
+// results, which are kept for auditing the assignments of verifiers at past blocks.
+type ChunkAssignments interface {
+
+	// Store inserts the given assignment record. Storing the record of an assignment
+	// which was already stored is a no-op.
+	Store(record *chunks.AssignmentRecord) error
+
+	// ByResultID retrieves the assignment records of the given execution result for
+	// all blocks it was incorporated in, ordered by incorporating block ID.
+	ByResultID(resultID flow.Identifier) ([]*chunks.AssignmentRecord, error)
+
+	// PruneBelow removes the records of all assignments of results incorporated in
+	// blocks below the given height and returns the number of removed records.
+	PruneBelow(height uint64) (uint, error)
+}
@@ -0,0 +1,14 @@
+package storage
+
+// ClusterQCVotes persists whether this node has submitted its vote for the root
+// QC of its cluster, per epoch, so that it does not vote again after a restart.
+type ClusterQCVotes interface {
+
+	// SetVoted records that this node has submitted its vote for the root cluster
+	// QC of the given epoch. Recording a vote more than once is a no-op.
+	SetVoted(epochCounter uint64) error
+
+	// HasVoted checks whether this node has submitted its vote for the root cluster
+	// QC of the given epoch.
+	HasVoted(epochCounter uint64) (bool, error)
+}
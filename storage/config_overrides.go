@@ -0,0 +1,18 @@
+package storage
+
+// ConfigOverrides persists the values of configuration parameters which were overridden at
+// runtime, so that the overrides are kept across restarts. Values are stored in their textual
+// encoding, keyed by the name of the parameter.
+type ConfigOverrides interface {
+
+	// Store stores the override of the parameter with the given name, replacing any previously
+	// stored override of the parameter.
+	Store(name string, value string) error
+
+	// Remove removes the override of the parameter with the given name. Removing a parameter
+	// which is not overridden is a no-op.
+	Remove(name string) error
+
+	// All retrieves the overrides of all parameters, keyed by parameter name.
+	All() (map[string]string, error)
+}
@@ -37,6 +37,26 @@ type DKGState interface {
 	RetrieveMyBeaconPrivateKey(epochCounter uint64) (crypto.PrivateKey, error)
 }
 
+// DKGCheckpoints is the storage interface for checkpoints of running DKGs, from
+// which a DKG interrupted by a restart of the node is resumed.
+//
+// CAUTION: checkpoints contain the private state of a DKG participant, and must
+// only be stored in the secrets database.
+type DKGCheckpoints interface {
+
+	// StoreDKGCheckpoint stores the checkpoint of the given DKG instance,
+	// replacing any previously stored checkpoint.
+	StoreDKGCheckpoint(dkgInstanceID string, data []byte) error
+
+	// RetrieveDKGCheckpoint retrieves the latest checkpoint of the given DKG
+	// instance. Returns storage.ErrNotFound if no checkpoint was stored.
+	RetrieveDKGCheckpoint(dkgInstanceID string) ([]byte, error)
+
+	// RemoveDKGCheckpoint removes the checkpoint of the given DKG instance,
+	// once the DKG has ended. It is a no-op if no checkpoint was stored.
+	RemoveDKGCheckpoint(dkgInstanceID string) error
+}
+
 // SafeBeaconKeys is a safe way to access beacon keys.
 type SafeBeaconKeys interface {
 
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ExecutionResultConflicts represents persistent storage for detected conflicts between
+// execution results.
+type ExecutionResultConflicts interface {
+
+	// Store inserts the conflict. Returns storage.ErrAlreadyExists if a conflict between
+	// the same results is already stored.
+	Store(conflict *flow.ExecutionResultConflict) error
+
+	// Update replaces the stored conflict between the same results.
+	Update(conflict *flow.ExecutionResultConflict) error
+
+	// ByResultIDs retrieves the conflict between the two given results for the given block,
+	// which can be given in any order.
+	ByResultIDs(blockID flow.Identifier, resultID1 flow.Identifier, resultID2 flow.Identifier) (*flow.ExecutionResultConflict, error)
+
+	// ByBlockID retrieves all conflicts between results for the given block.
+	ByBlockID(blockID flow.Identifier) ([]*flow.ExecutionResultConflict, error)
+}
@@ -22,7 +22,9 @@ type Headers interface {
 
 	// Find all children for the given parent block. The returned headers might
 	// be unfinalized; if there is more than one, at least one of them has to
-	// be unfinalized.
+	// be unfinalized. Headers are indexed by their parent when they are stored;
+	// databases created before can be migrated with the headers-by-parent
+	// index backfill. Returns an empty list for a stored block without children.
 	ByParentID(parentID flow.Identifier) ([]*flow.Header, error)
 
 	// Indexes block ID by chunk ID
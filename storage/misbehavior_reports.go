@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MisbehaviorReports represents persistent storage for misbehavior reports.
+type MisbehaviorReports interface {
+
+	// Store inserts the misbehavior report.
+	Store(report *flow.MisbehaviorReport) error
+
+	// ByOriginID retrieves all reports for the given origin which were reported at or
+	// after the given time, ordered by time.
+	ByOriginID(originID flow.Identifier, since time.Time) ([]*flow.MisbehaviorReport, error)
+
+	// All retrieves the reports of all origins which were reported at or after the
+	// given time. Reports are ordered by origin and time.
+	All(since time.Time) ([]*flow.MisbehaviorReport, error)
+
+	// PruneBefore removes all reports which were reported before the given time and
+	// returns the number of removed reports.
+	PruneBefore(cutoff time.Time) (uint, error)
+}
@@ -51,6 +51,29 @@ func (_m *ExecutionReceipts) ByBlockID(blockID flow.Identifier) (flow.ExecutionR
 	return r0, r1
 }
 
+// ByBlockIDAndExecutor provides a mock function with given fields: blockID, executorID
+func (_m *ExecutionReceipts) ByBlockIDAndExecutor(blockID flow.Identifier, executorID flow.Identifier) (flow.ExecutionReceiptList, error) {
+	ret := _m.Called(blockID, executorID)
+
+	var r0 flow.ExecutionReceiptList
+	if rf, ok := ret.Get(0).(func(flow.Identifier, flow.Identifier) flow.ExecutionReceiptList); ok {
+		r0 = rf(blockID, executorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(flow.ExecutionReceiptList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier, flow.Identifier) error); ok {
+		r1 = rf(blockID, executorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ByID provides a mock function with given fields: receiptID
 func (_m *ExecutionReceipts) ByID(receiptID flow.Identifier) (*flow.ExecutionReceipt, error) {
 	ret := _m.Called(receiptID)
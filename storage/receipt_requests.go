@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ReceiptRequests represents persistent storage for the bookkeeping of the requests for the
+// execution receipts of unsealed blocks, keyed by block ID.
+type ReceiptRequests interface {
+
+	// Store inserts the given request, replacing any stored request for the same block.
+	Store(request *flow.ReceiptRequest) error
+
+	// All retrieves all stored requests.
+	All() ([]*flow.ReceiptRequest, error)
+
+	// Remove removes the request for the given block. Removing a request which is not stored
+	// is a no-op.
+	Remove(blockID flow.Identifier) error
+}
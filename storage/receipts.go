@@ -24,6 +24,10 @@ type ExecutionReceipts interface {
 	// ByBlockID retrieves all known execution receipts for the given block
 	// (from any Execution Node).
 	ByBlockID(blockID flow.Identifier) (flow.ExecutionReceiptList, error)
+
+	// ByBlockIDAndExecutor retrieves all known execution receipts for the given
+	// block from the given Execution Node.
+	ByBlockIDAndExecutor(blockID flow.Identifier, executorID flow.Identifier) (flow.ExecutionReceiptList, error)
 }
 
 // MyExecutionReceipts reuses the storage.ExecutionReceipts API, but doesn't expose
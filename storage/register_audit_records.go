@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// RegisterAuditRecords represents append-only persistent storage for the audit records
+// of register accesses by transactions.
+type RegisterAuditRecords interface {
+
+	// Store inserts the given audit records atomically.
+	Store(records []*flow.RegisterAuditRecord) error
+
+	// ByOwner retrieves all records of registers owned by the given account, which were
+	// accessed in blocks within the given height range (inclusive), ordered by height.
+	ByOwner(owner flow.Address, fromHeight, toHeight uint64) ([]*flow.RegisterAuditRecord, error)
+
+	// PruneBelow removes all records of accesses in blocks below the given height and
+	// returns the number of removed records.
+	PruneBelow(height uint64) (uint, error)
+}
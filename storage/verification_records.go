@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+)
+
+// VerificationRecords represents persistent storage for the records of chunks verified by this node,
+// which are kept for investigating disputes on the emitted result approvals.
+type VerificationRecords interface {
+
+	// Store inserts the given verification record. Storing the record of a chunk verification
+	// which was already stored is a no-op.
+	Store(record *verification.VerificationRecord) error
+
+	// ByResultID retrieves the records of all verified chunks of the given execution result,
+	// ordered by chunk index.
+	ByResultID(resultID flow.Identifier) ([]*verification.VerificationRecord, error)
+
+	// PruneBelow removes the records of all verified chunks of blocks below the given height
+	// and returns the number of removed records.
+	PruneBelow(height uint64) (uint, error)
+}
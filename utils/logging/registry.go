@@ -0,0 +1,188 @@
+package logging
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+)
+
+// ErrUnknownComponent is returned when changing the level of a component, which did not register a logger.
+var ErrUnknownComponent = errors.New("unknown logging component")
+
+// Registry manages the log levels of a node at runtime. The node's logger is derived from the
+// root of the registry and follows the global level. Components, such as engines, register their
+// loggers by name, so that their level can be changed independently of the global level, e.g. to
+// debug a single engine without restarting the node and losing the state under investigation.
+//
+// Levels are enforced by zerolog samplers, which are checked before an event is assembled. As
+// zerolog drops events below its global level before sampling, the registry keeps the zerolog
+// global level at the lowest level in use.
+type Registry struct {
+	mu         sync.Mutex
+	global     *atomic.Int32
+	components map[string]*componentSampler
+}
+
+// LevelsInfo describes the global level and the levels of the registered components.
+type LevelsInfo struct {
+	Global     string               `json:"global"`
+	Components []ComponentLevelInfo `json:"components"`
+}
+
+// ComponentLevelInfo describes the level of a registered component.
+type ComponentLevelInfo struct {
+	Name       string `json:"name"`
+	Level      string `json:"level"`
+	Overridden bool   `json:"overridden"`
+}
+
+func NewRegistry(level zerolog.Level) *Registry {
+	r := &Registry{
+		global:     atomic.NewInt32(int32(level)),
+		components: make(map[string]*componentSampler),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apply()
+	return r
+}
+
+// Root returns the given logger following the global level. All loggers derived from it follow
+// the global level, unless they are registered as components.
+func (r *Registry) Root(log zerolog.Logger) zerolog.Logger {
+	return log.Sample(&globalSampler{global: r.global})
+}
+
+// Logger registers the component with the given name, and returns the given logger following
+// the level of the component. Loggers registered with the same name share their level.
+func (r *Registry) Logger(component string, log zerolog.Logger) zerolog.Logger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sampler, ok := r.components[component]
+	if !ok {
+		sampler = &componentSampler{
+			global:     r.global,
+			level:      atomic.NewInt32(0),
+			overridden: atomic.NewBool(false),
+		}
+		r.components[component] = sampler
+	}
+	return log.Sample(sampler)
+}
+
+// SetGlobalLevel sets the level of all loggers, except for components with an overridden level.
+func (r *Registry) SetGlobalLevel(level zerolog.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.global.Store(int32(level))
+	r.apply()
+}
+
+// SetLevel overrides the level of the given component.
+// Expected errors during normal operation:
+//  * ErrUnknownComponent if the component didn't register a logger
+func (r *Registry) SetLevel(component string, level zerolog.Level) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sampler, ok := r.components[component]
+	if !ok {
+		return ErrUnknownComponent
+	}
+	sampler.level.Store(int32(level))
+	sampler.overridden.Store(true)
+	r.apply()
+	return nil
+}
+
+// ResetLevel removes the override of the level of the given component, which follows the global
+// level again.
+// Expected errors during normal operation:
+//  * ErrUnknownComponent if the component didn't register a logger
+func (r *Registry) ResetLevel(component string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sampler, ok := r.components[component]
+	if !ok {
+		return ErrUnknownComponent
+	}
+	sampler.overridden.Store(false)
+	r.apply()
+	return nil
+}
+
+// HasComponent returns true if a component with the given name registered a logger.
+func (r *Registry) HasComponent(component string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.components[component]
+	return ok
+}
+
+// Levels returns the global level and the levels of the registered components, ordered by name.
+func (r *Registry) Levels() LevelsInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	global := zerolog.Level(r.global.Load())
+	info := LevelsInfo{
+		Global:     global.String(),
+		Components: make([]ComponentLevelInfo, 0, len(r.components)),
+	}
+	for name, sampler := range r.components {
+		info.Components = append(info.Components, ComponentLevelInfo{
+			Name:       name,
+			Level:      sampler.current().String(),
+			Overridden: sampler.overridden.Load(),
+		})
+	}
+	sort.Slice(info.Components, func(i, j int) bool {
+		return info.Components[i].Name < info.Components[j].Name
+	})
+	return info
+}
+
+// apply sets the zerolog global level to the lowest level in use. It must be called while
+// holding the lock.
+func (r *Registry) apply() {
+	lowest := zerolog.Level(r.global.Load())
+	for _, sampler := range r.components {
+		if level := sampler.current(); level < lowest {
+			lowest = level
+		}
+	}
+	zerolog.SetGlobalLevel(lowest)
+}
+
+// globalSampler passes the events at or above the global level.
+type globalSampler struct {
+	global *atomic.Int32
+}
+
+func (s *globalSampler) Sample(lvl zerolog.Level) bool {
+	return lvl >= zerolog.Level(s.global.Load())
+}
+
+// componentSampler passes the events at or above the level of a component, which is the global
+// level unless it is overridden.
+type componentSampler struct {
+	global     *atomic.Int32
+	level      *atomic.Int32
+	overridden *atomic.Bool
+}
+
+func (s *componentSampler) Sample(lvl zerolog.Level) bool {
+	return lvl >= s.current()
+}
+
+func (s *componentSampler) current() zerolog.Level {
+	if s.overridden.Load() {
+		return zerolog.Level(s.level.Load())
+	}
+	return zerolog.Level(s.global.Load())
+}
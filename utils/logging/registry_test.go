@@ -0,0 +1,74 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+func TestRegistry_Levels(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	registry := logging.NewRegistry(zerolog.InfoLevel)
+	registry.Logger("matching", zerolog.Nop())
+	registry.Logger("epochmgr", zerolog.Nop())
+	assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+
+	require.NoError(t, registry.SetLevel("matching", zerolog.DebugLevel))
+	registry.SetGlobalLevel(zerolog.WarnLevel)
+	assert.Equal(t, logging.LevelsInfo{
+		Global: "warn",
+		Components: []logging.ComponentLevelInfo{
+			{Name: "epochmgr", Level: "warn", Overridden: false},
+			{Name: "matching", Level: "debug", Overridden: true},
+		},
+	}, registry.Levels())
+	// zerolog must not drop the events of the most verbose component
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+
+	require.NoError(t, registry.ResetLevel("matching"))
+	assert.Equal(t, "warn", registry.Levels().Components[1].Level)
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+}
+
+func TestRegistry_UnknownComponent(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	registry := logging.NewRegistry(zerolog.InfoLevel)
+	assert.False(t, registry.HasComponent("matching"))
+	assert.ErrorIs(t, registry.SetLevel("matching", zerolog.DebugLevel), logging.ErrUnknownComponent)
+	assert.ErrorIs(t, registry.ResetLevel("matching"), logging.ErrUnknownComponent)
+}
+
+// TestRegistry_FilterComponent tests that changing the level of a component takes effect on
+// its existing loggers, including derived ones, while other loggers keep the global level.
+func TestRegistry_FilterComponent(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	registry := logging.NewRegistry(zerolog.InfoLevel)
+	buf := &bytes.Buffer{}
+	root := registry.Root(zerolog.New(buf))
+	component := registry.Logger("matching", root).With().Str("engine", "matching").Logger()
+
+	component.Debug().Msg("dropped")
+	root.Debug().Msg("dropped")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, registry.SetLevel("matching", zerolog.DebugLevel))
+	component.Debug().Msg("component")
+	root.Debug().Msg("dropped")
+	assert.Contains(t, buf.String(), "component")
+	assert.NotContains(t, buf.String(), "dropped")
+
+	buf.Reset()
+	require.NoError(t, registry.ResetLevel("matching"))
+	component.Debug().Msg("dropped")
+	component.Info().Msg("component")
+	assert.Contains(t, buf.String(), "component")
+	assert.NotContains(t, buf.String(), "dropped")
+}
@@ -0,0 +1,98 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy determines the backoff between consecutive attempts of an operation.
+type Policy interface {
+	// Next returns the delay before the given retry, where retry 1 precedes the second attempt
+	// of the operation, given the time elapsed since the first attempt started. It returns false
+	// if no further attempt should be made.
+	Next(retry uint, elapsed time.Duration) (time.Duration, bool)
+}
+
+// PolicyFunc is an adapter to allow the use of ordinary functions as retry policies.
+type PolicyFunc func(retry uint, elapsed time.Duration) (time.Duration, bool)
+
+// Next calls f(retry, elapsed).
+func (f PolicyFunc) Next(retry uint, elapsed time.Duration) (time.Duration, bool) {
+	return f(retry, elapsed)
+}
+
+// Fixed returns a policy which retries indefinitely with the same delay between attempts.
+func Fixed(delay time.Duration) Policy {
+	return PolicyFunc(func(uint, time.Duration) (time.Duration, bool) {
+		return delay, true
+	})
+}
+
+// Exponential returns a policy which retries indefinitely, starting with the initial delay and
+// multiplying the delay by the given factor for each subsequent retry. Factors smaller than one
+// are treated as one. If maximum is positive, the delay never exceeds it.
+func Exponential(initial time.Duration, factor float64, maximum time.Duration) Policy {
+	if factor < 1 {
+		factor = 1
+	}
+	return PolicyFunc(func(retry uint, _ time.Duration) (time.Duration, bool) {
+		if retry == 0 {
+			retry = 1
+		}
+		delay := float64(initial) * math.Pow(factor, float64(retry-1))
+		if maximum > 0 && delay > float64(maximum) {
+			return maximum, true
+		}
+		// guard against overflowing the duration for unbounded policies
+		if delay >= math.MaxInt64 {
+			return time.Duration(math.MaxInt64), true
+		}
+		return time.Duration(delay), true
+	})
+}
+
+// WithJitter wraps the given policy to randomly vary each delay by up to the given percentage
+// in either direction, so that operations started at the same time don't retry in lockstep.
+// Percentages above 100 are treated as 100. Note that the jitter is applied after any maximum
+// of the wrapped policy, so the resulting delay can exceed that maximum by the percentage.
+func WithJitter(percent uint, next Policy) Policy {
+	if percent > 100 {
+		percent = 100
+	}
+	return PolicyFunc(func(retry uint, elapsed time.Duration) (time.Duration, bool) {
+		delay, ok := next.Next(retry, elapsed)
+		if !ok || percent == 0 || delay <= 0 {
+			return delay, ok
+		}
+		spread := int64(delay) * int64(percent) / 100
+		if spread == 0 {
+			return delay, true
+		}
+		return delay + time.Duration(rand.Int63n(2*spread+1)-spread), true
+	})
+}
+
+// WithMaxRetries wraps the given policy to stop after the given number of retries, i.e. the
+// operation is attempted at most max+1 times.
+func WithMaxRetries(max uint, next Policy) Policy {
+	return PolicyFunc(func(retry uint, elapsed time.Duration) (time.Duration, bool) {
+		if retry > max {
+			return 0, false
+		}
+		return next.Next(retry, elapsed)
+	})
+}
+
+// WithMaxDuration wraps the given policy to stop once the next attempt would start later than
+// the given duration after the first attempt started. The delay before the last attempt is
+// not shortened to fit into the duration.
+func WithMaxDuration(max time.Duration, next Policy) Policy {
+	return PolicyFunc(func(retry uint, elapsed time.Duration) (time.Duration, bool) {
+		delay, ok := next.Next(retry, elapsed)
+		if !ok || elapsed+delay > max {
+			return 0, false
+		}
+		return delay, true
+	})
+}
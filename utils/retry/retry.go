@@ -0,0 +1,159 @@
+// Package retry implements retrying of operations with configurable backoff policies,
+// classification of retryable errors and observation of failed attempts.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Classifier determines whether an operation should be retried after it failed with the given error.
+type Classifier func(err error) bool
+
+// Observer is notified about each failed attempt of an operation which is going to be retried,
+// with the number of the failed attempt (starting at 1), its error and the delay before the
+// next attempt. It is invoked synchronously before backing off, so it can also be used to
+// adapt the state used by the next attempt.
+type Observer func(attempt uint, err error, delay time.Duration)
+
+// Clock provides the time and sleeping to the retry loop, so it can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+	// Sleep blocks for the given duration, or until the context is done, in which case
+	// it returns the context error.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type config struct {
+	classifier Classifier
+	observer   Observer
+	clock      Clock
+}
+
+// Option configures the retry loop of Do.
+type Option func(*config)
+
+// WithClassifier sets the classifier determining which errors are retried. By default, all
+// errors are retried except for those marked with Permanent.
+func WithClassifier(classifier Classifier) Option {
+	return func(cfg *config) {
+		cfg.classifier = classifier
+	}
+}
+
+// WithObserver sets an observer which is notified about each failed attempt which is retried,
+// for example to log the failure or to report it to metrics.
+func WithObserver(observer Observer) Option {
+	return func(cfg *config) {
+		cfg.observer = observer
+	}
+}
+
+// WithClock sets the clock used for measuring the elapsed time and for backing off.
+func WithClock(clock Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = clock
+	}
+}
+
+// permanentError marks an error which should not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the given error as permanent, so that the operation returning it is not
+// retried by the default classifier. Do returns the unmarked error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent returns whether the given error, or any error it wraps, was marked as permanent.
+func IsPermanent(err error) bool {
+	var perm permanentError
+	return errors.As(err, &perm)
+}
+
+// DefaultClassifier retries all errors except for those marked with Permanent.
+func DefaultClassifier(err error) bool {
+	return !IsPermanent(err)
+}
+
+// Do calls the given function until it succeeds, backing off between attempts according to the
+// given policy. It stops early if the function returns an error which is not retryable, if the
+// policy does not allow any further attempt, or if the context is done. The context is passed
+// on to the function.
+//
+// Expected errors during normal operations:
+//   - the unmarked error of the last attempt if it was not retryable
+//   - the error of the last attempt, wrapped, if the policy did not allow any further attempt
+//   - the context error if the context is done before or while backing off
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error, opts ...Option) error {
+	cfg := config{
+		classifier: DefaultClassifier,
+		clock:      realClock{},
+	}
+	for _, apply := range opts {
+		apply(&cfg)
+	}
+
+	start := cfg.clock.Now()
+	for attempt := uint(1); ; attempt++ {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if !cfg.classifier(err) {
+			if perm, ok := err.(permanentError); ok {
+				return perm.err
+			}
+			return err
+		}
+
+		delay, ok := policy.Next(attempt, cfg.clock.Now().Sub(start))
+		if !ok {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		if cfg.observer != nil {
+			cfg.observer(attempt, err, delay)
+		}
+		err = cfg.clock.Sleep(ctx, delay)
+		if err != nil {
+			return err
+		}
+	}
+}
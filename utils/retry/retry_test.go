@@ -0,0 +1,221 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock which advances instantly when sleeping and records the delays.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+// failing returns a function failing the given number of times before succeeding, and
+// a pointer to the number of attempts made.
+func failing(failures int) (func(context.Context) error, *int) {
+	attempts := 0
+	return func(context.Context) error {
+		attempts++
+		if attempts <= failures {
+			return fmt.Errorf("failure %d", attempts)
+		}
+		return nil
+	}, &attempts
+}
+
+// TestDo_Timing tests the delays between attempts of the policies using an injected clock.
+func TestDo_Timing(t *testing.T) {
+	t.Run("fixed", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, attempts := failing(3)
+		err := Do(context.Background(), Fixed(time.Second), fn, WithClock(clock))
+		require.NoError(t, err)
+		assert.Equal(t, 4, *attempts)
+		assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, clock.sleeps)
+	})
+
+	t.Run("exponential with maximum", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, attempts := failing(5)
+		err := Do(context.Background(), Exponential(time.Second, 2, 5*time.Second), fn, WithClock(clock))
+		require.NoError(t, err)
+		assert.Equal(t, 6, *attempts)
+		assert.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+		}, clock.sleeps)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, attempts := failing(10)
+		err := Do(context.Background(), WithMaxRetries(2, Fixed(time.Second)), fn, WithClock(clock))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failure 3")
+		assert.Equal(t, 3, *attempts)
+		assert.Len(t, clock.sleeps, 2)
+	})
+
+	t.Run("max duration", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, attempts := failing(10)
+		// attempts start at 0s, 1s, 3s and 7s, the next one would start at 15s
+		err := Do(context.Background(), WithMaxDuration(10*time.Second, Exponential(time.Second, 2, 0)), fn, WithClock(clock))
+		require.Error(t, err)
+		assert.Equal(t, 4, *attempts)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, clock.sleeps)
+	})
+
+	t.Run("unbounded exponential does not overflow", func(t *testing.T) {
+		delay, ok := Exponential(time.Second, 2, 0).Next(1000, 0)
+		require.True(t, ok)
+		assert.Greater(t, int64(delay), int64(0))
+	})
+}
+
+// TestDo_ContextCancellation tests that cancelling the context interrupts the backoff.
+func TestDo_ContextCancellation(t *testing.T) {
+	t.Run("during backoff", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		fn, attempts := failing(10)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+
+		done := make(chan error)
+		go func() {
+			done <- Do(ctx, Fixed(time.Hour), fn)
+		}()
+		select {
+		case err := <-done:
+			assert.True(t, errors.Is(err, context.Canceled))
+			assert.Equal(t, 1, *attempts)
+		case <-time.After(time.Second):
+			t.Fatal("backoff was not interrupted by context cancellation")
+		}
+	})
+
+	t.Run("before first attempt", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fn, attempts := failing(0)
+		err := Do(ctx, Fixed(time.Second), fn, WithClock(&fakeClock{}))
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 0, *attempts)
+	})
+}
+
+// TestDo_Permanent tests that permanent and non-retryable errors short-circuit the retries.
+func TestDo_Permanent(t *testing.T) {
+	sentinel := errors.New("sentinel")
+
+	t.Run("permanent error", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempts := 0
+		err := Do(context.Background(), Fixed(time.Second), func(context.Context) error {
+			attempts++
+			if attempts == 2 {
+				return Permanent(sentinel)
+			}
+			return errors.New("transient")
+		}, WithClock(clock))
+		assert.Equal(t, sentinel, err)
+		assert.Equal(t, 2, attempts)
+		assert.Len(t, clock.sleeps, 1)
+	})
+
+	t.Run("wrapped permanent error", func(t *testing.T) {
+		err := Do(context.Background(), Fixed(time.Second), func(context.Context) error {
+			return fmt.Errorf("context: %w", Permanent(sentinel))
+		}, WithClock(&fakeClock{}))
+		assert.True(t, errors.Is(err, sentinel))
+		assert.True(t, IsPermanent(err))
+		assert.Contains(t, err.Error(), "context")
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempts := 0
+		err := Do(context.Background(), Fixed(time.Second), func(context.Context) error {
+			attempts++
+			return sentinel
+		}, WithClock(clock), WithClassifier(func(err error) bool {
+			return !errors.Is(err, sentinel)
+		}))
+		assert.Equal(t, sentinel, err)
+		assert.Equal(t, 1, attempts)
+		assert.Empty(t, clock.sleeps)
+	})
+
+	assert.Nil(t, Permanent(nil))
+}
+
+// TestDo_Observer tests that the observer is notified about each retried failure.
+func TestDo_Observer(t *testing.T) {
+	var observed []uint
+	var delays []time.Duration
+	fn, _ := failing(3)
+	err := Do(context.Background(), Exponential(time.Second, 2, 0), fn,
+		WithClock(&fakeClock{}),
+		WithObserver(func(attempt uint, err error, delay time.Duration) {
+			assert.Error(t, err)
+			observed = append(observed, attempt)
+			delays = append(delays, delay)
+		}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2, 3}, observed)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, delays)
+}
+
+// TestWithJitter tests that jittered delays stay within bounds and are spread over them.
+func TestWithJitter(t *testing.T) {
+	base := 100 * time.Millisecond
+	policy := WithJitter(25, Fixed(base))
+	min, max := 75*time.Millisecond, 125*time.Millisecond
+
+	below, above := 0, 0
+	distinct := make(map[time.Duration]struct{})
+	for i := 0; i < 1000; i++ {
+		delay, ok := policy.Next(1, 0)
+		require.True(t, ok)
+		require.GreaterOrEqual(t, int64(delay), int64(min))
+		require.LessOrEqual(t, int64(delay), int64(max))
+		if delay < base {
+			below++
+		} else if delay > base {
+			above++
+		}
+		distinct[delay] = struct{}{}
+	}
+	// the delays are spread on both sides of the base delay
+	assert.Greater(t, below, 300)
+	assert.Greater(t, above, 300)
+	assert.Greater(t, len(distinct), 100)
+
+	// no jitter and zero delays are returned unchanged
+	delay, _ := WithJitter(0, Fixed(base)).Next(1, 0)
+	assert.Equal(t, base, delay)
+	delay, _ = WithJitter(25, Fixed(0)).Next(1, 0)
+	assert.Equal(t, time.Duration(0), delay)
+
+	// exhausted policies stay exhausted
+	_, ok := WithJitter(25, WithMaxRetries(0, Fixed(base))).Next(1, 0)
+	assert.False(t, ok)
+}
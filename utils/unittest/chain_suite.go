@@ -164,6 +164,17 @@ func (bc *BaseChainSuite) SetupChain() {
 		},
 		nil,
 	)
+	bc.State.On("AtSealed").Return(
+		func() realproto.Snapshot {
+			return bc.SealedSnapshot
+		},
+	)
+	bc.State.On("SealedHeight").Return(
+		func() uint64 {
+			return bc.LatestSealedBlock.Header.Height
+		},
+		nil,
+	)
 	bc.SealedSnapshot = &protocol.Snapshot{}
 	bc.SealedSnapshot.On("Head").Return(
 		func() *flow.Header {
@@ -172,6 +183,17 @@ func (bc *BaseChainSuite) SetupChain() {
 		nil,
 	)
 
+	// define the latest finalized and sealed blocks, read atomically
+	bc.State.On("Boundaries").Return(
+		func() *flow.Header {
+			return bc.LatestFinalizedBlock.Header
+		},
+		func() *flow.Header {
+			return bc.LatestSealedBlock.Header
+		},
+		nil,
+	)
+
 	findBlockByHeight := func(blocks map[flow.Identifier]*flow.Block, height uint64) (*flow.Block, bool) {
 		for _, block := range blocks {
 			if block.Header.Height == height {
@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module/mempool/entity"
 	"github.com/onflow/flow-go/module/signature"
+	sigmessages "github.com/onflow/flow-go/module/signature/messages"
 	"github.com/onflow/flow-go/state/protocol/inmem"
 	"github.com/onflow/flow-go/utils/dsl"
 )
@@ -618,8 +619,24 @@ func ExecutionReceiptFixture(opts ...func(*flow.ExecutionReceipt)) *flow.Executi
 	return receipt
 }
 
-func ReceiptForBlockFixture(block *flow.Block) *flow.ExecutionReceipt {
-	return ReceiptForBlockExecutorFixture(block, IdentifierFixture())
+func ReceiptForBlockFixture(block *flow.Block, opts ...func(*flow.ExecutionReceipt)) *flow.ExecutionReceipt {
+	receipt := ReceiptForBlockExecutorFixture(block, IdentifierFixture())
+	for _, apply := range opts {
+		apply(receipt)
+	}
+	return receipt
+}
+
+// WithSignedReceipt signs the receipt with the given staking key of its executor, using the
+// given hasher. It must be applied after all other options modifying the receipt.
+func WithSignedReceipt(stakingKey crypto.PrivateKey, hasher hash.Hasher) func(*flow.ExecutionReceipt) {
+	return func(receipt *flow.ExecutionReceipt) {
+		sig, err := stakingKey.Sign(sigmessages.ExecutionReceiptMessage(receipt.Meta()), hasher)
+		if err != nil {
+			panic(err)
+		}
+		receipt.ExecutorSignature = sig
+	}
 }
 
 func ReceiptForBlockExecutorFixture(block *flow.Block, executor flow.Identifier) *flow.ExecutionReceipt {
@@ -689,6 +706,12 @@ func WithExecutionResultBlockID(blockID flow.Identifier) func(*flow.ExecutionRes
 	}
 }
 
+func WithExecutionDataID(executionDataID flow.Identifier) func(result *flow.ExecutionResult) {
+	return func(result *flow.ExecutionResult) {
+		result.ExecutionDataID = executionDataID
+	}
+}
+
 func WIthServiceEvents(n int) func(result *flow.ExecutionResult) {
 	return func(result *flow.ExecutionResult) {
 		result.ServiceEvents = ServiceEventsFixture(n)
@@ -25,6 +25,7 @@ type ProtocolState struct {
 	children  map[flow.Identifier][]flow.Identifier
 	heights   map[uint64]*flow.Block
 	finalized uint64
+	sealed    uint64
 	root      *flow.Block
 	result    *flow.ExecutionResult
 	seal      *flow.Seal
@@ -124,6 +125,20 @@ func (ps *ProtocolState) Final() protocol.Snapshot {
 	return snapshot
 }
 
+func (ps *ProtocolState) Sealed() protocol.Snapshot {
+	ps.Lock()
+	defer ps.Unlock()
+
+	sealed, ok := ps.heights[ps.sealed]
+	if !ok {
+		return nil
+	}
+
+	snapshot := new(protocolmock.Snapshot)
+	snapshot.On("Head").Return(sealed.Header, nil)
+	return snapshot
+}
+
 func pending(ps *ProtocolState, blockID flow.Identifier) []flow.Identifier {
 	var pendingIDs []flow.Identifier
 	pendingIDs, ok := ps.children[blockID]
@@ -154,6 +169,7 @@ func (m *ProtocolState) Bootstrap(root *flow.Block, result *flow.ExecutionResult
 	m.seal = seal
 	m.heights[root.Header.Height] = root
 	m.finalized = root.Header.Height
+	m.sealed = root.Header.Height
 	return nil
 }
 
@@ -212,3 +228,26 @@ func (m *ProtocolState) Finalize(blockID flow.Identifier) error {
 
 	return nil
 }
+
+// Seal marks the given finalized block as the last sealed block.
+func (m *ProtocolState) Seal(blockID flow.Identifier) error {
+	m.Lock()
+	defer m.Unlock()
+
+	block, ok := m.blocks[blockID]
+	if !ok {
+		return fmt.Errorf("could not retrieve sealed header")
+	}
+
+	if block.Header.Height > m.finalized {
+		return fmt.Errorf("could not seal unfinalized blocks")
+	}
+
+	if block.Header.Height < m.sealed {
+		return fmt.Errorf("could not seal old blocks")
+	}
+
+	m.sealed = block.Header.Height
+
+	return nil
+}